@@ -0,0 +1,51 @@
+// cmd/artisan/anonymize.go - Data anonymization command
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/logger"
+)
+
+// runAnonymize scrubs personal data so production dumps can be used locally
+func runAnonymize(anonymizerName string, force bool) {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	if anonymizerName == "list" {
+		fmt.Println("📋 Listing anonymizers...")
+		if err := database.ListAnonymizers(db); err != nil {
+			fmt.Printf("❌ Failed to list anonymizers: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cfg.Env == "production" && !force {
+		fmt.Println("❌ Refusing to anonymize a production database (use -force to override)")
+		os.Exit(1)
+	}
+
+	if !force {
+		fmt.Printf("🚨 WARNING: This will overwrite personal data in database '%s'!\n", cfg.Database.Name)
+		fmt.Print("Type 'ANONYMIZE' to continue: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != "ANONYMIZE" {
+			fmt.Println("❌ Cancelled")
+			os.Exit(1)
+		}
+	}
+
+	fmt.Println("🕶️  Anonymizing data...")
+
+	if err := database.AnonymizeData(db, anonymizerName); err != nil {
+		fmt.Printf("❌ Anonymization failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ Anonymization completed successfully")
+}
@@ -0,0 +1,49 @@
+// cmd/artisan/archive.go - Archiving old rows of hot tables
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/jobs"
+	"go-clean-gin/pkg/logger"
+)
+
+// runArchive archives the rows old enough to archive, of every archived table
+// or only of table, on the main database and each tenant database, as the
+// daily archive:run task does
+func runArchive(table string) {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	c := container.NewContainer(cfg, db)
+
+	total := make(map[string]int64)
+	err := c.Tenants.Each(context.Background(), func(ctx context.Context) error {
+		moved, err := jobs.Archive(ctx, c, table)
+		for name, n := range moved {
+			total[name] += n
+		}
+		return err
+	})
+	if err != nil {
+		fmt.Printf("❌ Failed to archive: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(total) == 0 {
+		fmt.Println("📭 Nothing to archive: set ARCHIVE_RESERVATIONS_AFTER to archive closed reservations")
+		return
+	}
+	names := make([]string, 0, len(total))
+	for name := range total {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("✅ Archived %d row(s) of %s\n", total[name], name)
+	}
+}
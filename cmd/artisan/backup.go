@@ -0,0 +1,213 @@
+// cmd/artisan/backup.go - Database backup and restore commands
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/storage"
+)
+
+// runBackup dumps the database once, or repeatedly when every > 0
+func runBackup(output string, every time.Duration, keep int) {
+	cfg := config.Load()
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fmt.Printf("❌ Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	if keep < 0 {
+		keep = cfg.Backup.Keep
+	}
+
+	store, err := storage.New(&cfg.Storage)
+	if err != nil {
+		fmt.Printf("❌ Failed to initialize storage: %v\n", err)
+		os.Exit(1)
+	}
+
+	if every <= 0 {
+		if _, err := backupOnce(context.Background(), cfg, store, output, keep); err != nil {
+			fmt.Printf("❌ Backup failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if output != "" {
+		fmt.Println("❌ -output cannot be used with -every (scheduled backups go to storage)")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("⏰ Scheduled backups every %s (keeping %d)\n", every, keep)
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		if _, err := backupOnce(ctx, cfg, store, "", keep); err != nil {
+			fmt.Printf("⚠️  Backup failed: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("👋 Stopping scheduled backups")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backupOnce writes a single dump to a local file or to the storage backend
+func backupOnce(ctx context.Context, cfg *config.Config, store storage.Storage, output string, keep int) (string, error) {
+	started := time.Now()
+	fmt.Println("💾 Backing up database...")
+
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return "", fmt.Errorf("failed to create backup file: %w", err)
+		}
+		defer file.Close()
+
+		if err := database.Backup(ctx, &cfg.Database, file); err != nil {
+			os.Remove(output)
+			return "", err
+		}
+
+		fmt.Printf("✅ Backup written: %s (%s)\n", output, time.Since(started).Round(time.Millisecond))
+		return output, nil
+	}
+
+	path := fmt.Sprintf("%s/%s_%s.dump", cfg.Backup.Dir, cfg.Database.Name, time.Now().UTC().Format("2006_01_02_150405"))
+
+	// Stream pg_dump output straight into storage
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(database.Backup(ctx, &cfg.Database, pw))
+	}()
+
+	if err := store.Put(ctx, path, pr); err != nil {
+		pr.CloseWithError(err)
+		return "", err
+	}
+
+	fmt.Printf("✅ Backup stored: %s (%s)\n", path, time.Since(started).Round(time.Millisecond))
+
+	if keep > 0 {
+		if err := pruneBackups(ctx, store, cfg.Backup.Dir, keep); err != nil {
+			fmt.Printf("⚠️  Failed to prune old backups: %v\n", err)
+		}
+	}
+
+	return path, nil
+}
+
+// pruneBackups deletes all but the newest keep backups
+func pruneBackups(ctx context.Context, store storage.Storage, dir string, keep int) error {
+	backups, err := store.List(ctx, dir+"/")
+	if err != nil {
+		return err
+	}
+
+	// Names embed a sortable timestamp, so lexical order is chronological
+	for len(backups) > keep {
+		if err := store.Delete(ctx, backups[0]); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  Removed old backup: %s\n", backups[0])
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// runRestore restores a dump from a local file or the storage backend
+func runRestore(source string, force bool) {
+	cfg := config.Load()
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fmt.Printf("❌ Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	ctx := context.Background()
+
+	store, err := storage.New(&cfg.Storage)
+	if err != nil {
+		fmt.Printf("❌ Failed to initialize storage: %v\n", err)
+		os.Exit(1)
+	}
+
+	if source == "" {
+		fmt.Println("❌ Backup file is required")
+		fmt.Println("Usage: artisan db:restore <file|backup-name> [-force]")
+		if backups, err := store.List(ctx, cfg.Backup.Dir+"/"); err == nil && len(backups) > 0 {
+			fmt.Println("")
+			fmt.Println("📋 Available backups:")
+			for _, backup := range backups {
+				fmt.Printf("  - %s\n", backup)
+			}
+		}
+		os.Exit(1)
+	}
+
+	reader, err := openBackup(ctx, store, cfg.Backup.Dir, source)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	defer reader.Close()
+
+	if !force {
+		fmt.Printf("🚨 WARNING: This will replace all data in database '%s'!\n", cfg.Database.Name)
+		fmt.Print("Type 'RESTORE' to continue: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != "RESTORE" {
+			fmt.Println("❌ Cancelled")
+			os.Exit(1)
+		}
+	}
+
+	started := time.Now()
+	fmt.Printf("♻️  Restoring from %s...\n", source)
+
+	if err := database.Restore(ctx, &cfg.Database, reader); err != nil {
+		fmt.Printf("❌ Restore failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Restore completed (%s)\n", time.Since(started).Round(time.Millisecond))
+}
+
+// openBackup looks for the backup on local disk first, then in storage
+func openBackup(ctx context.Context, store storage.Storage, dir, source string) (io.ReadCloser, error) {
+	if file, err := os.Open(source); err == nil {
+		return file, nil
+	}
+
+	for _, path := range []string{source, dir + "/" + source} {
+		reader, err := store.Get(ctx, path)
+		if err == nil {
+			return reader, nil
+		}
+		if err != storage.ErrNotFound {
+			return nil, fmt.Errorf("failed to open backup: %w", err)
+		}
+	}
+
+	return nil, fmt.Errorf("backup not found: %s", source)
+}
@@ -0,0 +1,49 @@
+// cmd/artisan/bootstrap.go - Shared setup for commands that need the database
+package main
+
+import (
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/money"
+
+	"gorm.io/gorm"
+)
+
+// bootstrap loads configuration, initializes the logger and connects to the database.
+// Quiet mode only logs errors, to stderr, so command output stays machine readable.
+// It exits the process on failure, like the other artisan commands, reporting
+// the error as JSON with -format=json.
+func bootstrap(quiet bool) (*config.Config, *gorm.DB) {
+	cfg := loadConfig()
+
+	level := cfg.Log.Level
+	if quiet {
+		level = "error"
+		logger.Output = "stderr"
+	}
+
+	if err := logger.Init(level, cfg.Log.Format); err != nil {
+		fail("Failed to initialize logger", err)
+	}
+
+	if err := money.Configure(cfg.Currency.Default, cfg.Currency.Supported); err != nil {
+		fail("Invalid currency configuration", err)
+	}
+
+	db, err := database.NewPostgresDB(&cfg.Database)
+	if err != nil {
+		fail("Failed to connect to database", err)
+	}
+
+	return cfg, db
+}
+
+// loadConfig loads the configuration of a command. Commands run system work,
+// such as jobs, scheduled tasks, migrations and seeders, so their database
+// connections bypass row-level security even when the app runs with DB_RLS.
+func loadConfig() *config.Config {
+	cfg := config.Load()
+	cfg.Database.RLS = false
+	return cfg
+}
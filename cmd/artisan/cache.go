@@ -0,0 +1,118 @@
+// cmd/artisan/cache.go - Cache decorator generation for port.go interfaces
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go-clean-gin/internal/generator"
+)
+
+// createCache generates the cache decorator of a single interface, e.g.
+// PostRepository, from the //cache: directives of its methods
+func createCache(interfaceName string) {
+	if interfaceName == "" {
+		fmt.Println("❌ Interface name is required")
+		fmt.Println("Usage: go run ./cmd/artisan make:cache -interface=PostRepository")
+		os.Exit(1)
+	}
+
+	ports, err := readPortFiles()
+	if err != nil {
+		fmt.Printf("❌ Failed to read port.go files: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, port := range ports {
+		names, err := generator.CachedInterfaces(port)
+		if err != nil {
+			fmt.Printf("❌ Failed to parse %s: %v\n", port.Path, err)
+			os.Exit(1)
+		}
+		for _, name := range names {
+			if name != interfaceName {
+				continue
+			}
+			path, err := writeCacheDecorator(port, name)
+			if err != nil {
+				fmt.Printf("❌ Failed to generate cache decorator: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ Cache decorator created: %s\n", path)
+			return
+		}
+	}
+
+	fmt.Printf("❌ Interface %s with //cache:read or //cache:invalidate methods not found in internal/*/port.go\n", interfaceName)
+	os.Exit(1)
+}
+
+// generateCaches regenerates the decorator of every port.go interface with
+// cache directives
+func generateCaches() {
+	ports, err := readPortFiles()
+	if err != nil {
+		fmt.Printf("❌ Failed to read port.go files: %v\n", err)
+		os.Exit(1)
+	}
+
+	count := 0
+	for _, port := range ports {
+		names, err := generator.CachedInterfaces(port)
+		if err != nil {
+			fmt.Printf("❌ Failed to parse %s: %v\n", port.Path, err)
+			os.Exit(1)
+		}
+		for _, name := range names {
+			path, err := writeCacheDecorator(port, name)
+			if err != nil {
+				fmt.Printf("❌ Failed to generate cache decorator for %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ %s → %s\n", name, path)
+			count++
+		}
+	}
+
+	if count == 0 {
+		fmt.Println("📭 No interfaces with //cache:read or //cache:invalidate methods in internal/*/port.go")
+		return
+	}
+	fmt.Printf("🗄️  Generated %d cache decorator(s)\n", count)
+}
+
+// readPortFiles reads internal/*/port.go
+func readPortFiles() ([]generator.File, error) {
+	paths, err := filepath.Glob(filepath.Join("internal", "*", "port.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	ports := make([]generator.File, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, generator.File{Path: path, Content: content})
+	}
+	return ports, nil
+}
+
+// writeCacheDecorator renders the decorator into internal/<pkg>/cached_<name>.go
+func writeCacheDecorator(port generator.File, name string) (string, error) {
+	file, err := generator.CacheDecorator(port, name)
+	if err != nil {
+		return "", err
+	}
+
+	// Never overwrite a hand-written file
+	if existing, err := os.ReadFile(file.Path); err == nil && !bytes.HasPrefix(existing, []byte(generator.CacheHeader)) {
+		return "", fmt.Errorf("%s exists and was not generated by artisan", file.Path)
+	}
+	return file.Path, writeFile(file.Path, file.Content)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-clean-gin/pkg/crypto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+// encryptedEntities returns the entity types with a field tagged
+// serializer:encrypted
+func encryptedEntities(t *testing.T) []string {
+	t.Helper()
+
+	files, err := filepath.Glob("../../internal/entity/*.go")
+	require.NoError(t, err)
+
+	var names []string
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				for _, field := range st.Fields.List {
+					if field.Tag != nil && strings.Contains(field.Tag.Value, "serializer:"+crypto.SerializerName) {
+						names = append(names, spec.Name.Name)
+						break
+					}
+				}
+			}
+			return false
+		})
+	}
+	return names
+}
+
+func TestEncryptedModels_CoverEveryEncryptedEntity(t *testing.T) {
+	var listed []string
+	for _, model := range encryptedModels {
+		listed = append(listed, reflect.TypeOf(model).Elem().Name())
+	}
+	assert.ElementsMatch(t, encryptedEntities(t), listed, "add models with encrypted columns to encryptedModels")
+}
+
+func TestEncryptedModels_CanRotate(t *testing.T) {
+	for _, model := range encryptedModels {
+		s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		require.NoError(t, err)
+
+		assert.Len(t, s.PrimaryFields, 1, "%s: rotation needs a single-column primary key", s.Table)
+		encrypted := false
+		for _, field := range s.Fields {
+			encrypted = encrypted || strings.EqualFold(field.TagSettings["SERIALIZER"], crypto.SerializerName)
+		}
+		assert.True(t, encrypted, "%s has no encrypted column", s.Table)
+	}
+}
@@ -0,0 +1,204 @@
+// cmd/artisan/db.go - Database introspection commands
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// runDBQuery executes a raw SQL statement and prints the result set.
+// Statements run in a read-only transaction unless allowWrite is set.
+func runDBQuery(query, format string, allowWrite bool) {
+	if strings.TrimSpace(query) == "" {
+		fmt.Println("❌ Query is required")
+		fmt.Println("Usage: artisan db:query \"SELECT * FROM tb_users LIMIT 5\" [-format=table|json] [-write]")
+		os.Exit(1)
+	}
+
+	_, db := bootstrap(true)
+	defer logger.Sync()
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		fmt.Printf("❌ Failed to start transaction: %v\n", tx.Error)
+		os.Exit(1)
+	}
+	defer tx.Rollback()
+
+	if !allowWrite {
+		if err := tx.Exec("SET TRANSACTION READ ONLY").Error; err != nil {
+			fmt.Printf("❌ Failed to set read-only transaction: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	columns, rows, err := queryRows(tx, query)
+	if err != nil {
+		fmt.Printf("❌ Query failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if allowWrite {
+		if err := tx.Commit().Error; err != nil {
+			fmt.Printf("❌ Failed to commit: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	printRows(columns, rows, format)
+}
+
+// listTables prints all tables in the public schema
+func listTables(format string) {
+	_, db := bootstrap(true)
+	defer logger.Sync()
+
+	columns, rows, err := queryRows(db, `
+		SELECT t.table_name AS table,
+		       COALESCE(s.n_live_tup, 0) AS estimated_rows,
+		       pg_size_pretty(pg_total_relation_size(quote_ident(t.table_name)::regclass)) AS size
+		FROM information_schema.tables t
+		LEFT JOIN pg_stat_user_tables s ON s.relname = t.table_name
+		WHERE t.table_schema = 'public' AND t.table_type = 'BASE TABLE'
+		ORDER BY t.table_name`)
+	if err != nil {
+		fmt.Printf("❌ Failed to list tables: %v\n", err)
+		os.Exit(1)
+	}
+
+	printRows(columns, rows, format)
+}
+
+// describeTable prints the columns of a table. The tb_ prefix is optional.
+func describeTable(tableName, format string) {
+	if tableName == "" {
+		fmt.Println("❌ Table name is required")
+		fmt.Println("Usage: artisan db:table users [-format=table|json]")
+		os.Exit(1)
+	}
+
+	_, db := bootstrap(true)
+	defer logger.Sync()
+
+	if !db.Migrator().HasTable(tableName) && db.Migrator().HasTable("tb_"+tableName) {
+		tableName = "tb_" + tableName
+	}
+	if !db.Migrator().HasTable(tableName) {
+		fmt.Printf("❌ Table not found: %s\n", tableName)
+		os.Exit(1)
+	}
+
+	columns, rows, err := queryRows(db, `
+		SELECT column_name AS column, data_type AS type, is_nullable AS nullable, column_default AS default
+		FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name = ?
+		ORDER BY ordinal_position`, tableName)
+	if err != nil {
+		fmt.Printf("❌ Failed to describe table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if format != "json" {
+		fmt.Printf("🗂️  Table: %s\n", tableName)
+	}
+	printRows(columns, rows, format)
+}
+
+// queryRows runs a query and returns column names with all row values
+func queryRows(db *gorm.DB, query string, args ...interface{}) ([]string, [][]interface{}, error) {
+	rows, err := db.Raw(query, args...).Rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+
+		for i, value := range values {
+			if b, ok := value.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result = append(result, values)
+	}
+
+	return columns, result, rows.Err()
+}
+
+// printRows renders rows as an aligned table or as JSON
+func printRows(columns []string, rows [][]interface{}, format string) {
+	if format == "json" {
+		records := make([]map[string]interface{}, 0, len(rows))
+		for _, row := range rows {
+			record := make(map[string]interface{}, len(columns))
+			for i, column := range columns {
+				record[column] = row[i]
+			}
+			records = append(records, record)
+		}
+		printJSON(records)
+		return
+	}
+
+	if len(columns) == 0 {
+		fmt.Println("✅ Statement executed")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+
+	separators := make([]string, len(columns))
+	for i, column := range columns {
+		separators[i] = strings.Repeat("-", len(column))
+	}
+	fmt.Fprintln(w, strings.Join(separators, "\t"))
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = formatCell(value)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	w.Flush()
+
+	fmt.Printf("(%d row(s))\n", len(rows))
+}
+
+// formatCell converts a scanned value into a printable string
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case sql.RawBytes:
+		return string(v)
+	default:
+		return strings.ReplaceAll(fmt.Sprint(v), "\n", " ")
+	}
+}
@@ -0,0 +1,91 @@
+// cmd/artisan/dryrun.go - Writing generated files, or with -dry-run showing them
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-clean-gin/internal/generator"
+
+	"golang.org/x/term"
+)
+
+// ANSI colors of diff lines, used when stdout is a terminal
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+	colorReset = "\033[0m"
+)
+
+// keepExisting reports whether the file exists and -skip-existing keeps it,
+// saying so
+func keepExisting(path string) bool {
+	if _, err := os.Stat(path); err != nil || !*skipExisting {
+		return false
+	}
+	fmt.Printf("⏭️  Already exists, skipping: %s\n", path)
+	return true
+}
+
+// writeGeneratedFile creates the file and its directory, refusing to overwrite
+// one without -force
+func writeGeneratedFile(file generator.File) error {
+	if _, err := os.Stat(file.Path); err == nil && !*force {
+		return fmt.Errorf("file already exists: %s (use -force to overwrite or -skip-existing to keep it)", file.Path)
+	}
+	return writeFile(file.Path, file.Content)
+}
+
+// writeFile writes content to path, creating its directory. With -dry-run it
+// prints what would be written instead: the whole of a new file, or a diff
+// of one that exists.
+func writeFile(path string, content []byte) error {
+	if !*dryRun {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		return os.WriteFile(path, content, 0644)
+	}
+
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		fmt.Printf("📄 Would create %s:\n%s\n", path, content)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	diff := generator.Diff(path, existing, content)
+	if diff == "" {
+		fmt.Printf("🟰 Would leave %s unchanged\n", path)
+		return nil
+	}
+	fmt.Printf("✏️  Would change %s:\n%s\n", path, colorDiff(diff))
+	return nil
+}
+
+// colorDiff colors the added, removed and hunk lines of a unified diff when
+// printing to a terminal
+func colorDiff(diff string) string {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return diff
+	}
+
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = colorCyan + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorGreen + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorRed + strings.TrimSuffix(line, "\n") + colorReset + "\n"
+		}
+	}
+	return strings.Join(lines, "")
+}
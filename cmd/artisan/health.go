@@ -0,0 +1,91 @@
+// cmd/artisan/health.go - Healthcheck command for container probes
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/logger"
+)
+
+const defaultHealthTimeout = 3 * time.Second
+
+// runHealth checks the running server's readiness endpoint, or the database
+// directly when checkDB is set, and exits non-zero on failure. It needs no
+// curl/wget in the image, so it can be used as a Docker HEALTHCHECK or K8s exec probe.
+func runHealth(target string, checkDB bool, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+
+	var err error
+	if checkDB {
+		err = checkDatabaseHealth(timeout)
+	} else {
+		if target == "" {
+			cfg := config.Load()
+			target = fmt.Sprintf("http://127.0.0.1:%d/health/ready", cfg.Server.Port)
+		}
+		err = checkHTTPHealth(target, timeout)
+	}
+
+	if err != nil {
+		fmt.Printf("❌ Unhealthy: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ Healthy")
+}
+
+// checkHTTPHealth expects a 2xx response from the given URL
+func checkHTTPHealth(target string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+
+	resp, err := client.Get(target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", target, resp.Status)
+	}
+	return nil
+}
+
+// checkDatabaseHealth connects to the database and pings it
+func checkDatabaseHealth(timeout time.Duration) error {
+	cfg := config.Load()
+	if err := logger.Init("error", cfg.Log.Format); err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		db, err := database.NewPostgresDB(&cfg.Database)
+		if err != nil {
+			result <- err
+			return
+		}
+		if sqlDB, err := db.DB(); err == nil {
+			defer sqlDB.Close()
+		}
+		result <- database.HealthCheck(db)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("database check timed out after %s", timeout)
+	}
+}
@@ -0,0 +1,47 @@
+// cmd/artisan/loadtest.go - Load test data preparation
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/test/loadtest"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// runLoadTestSeed creates the load test user and n products for the k6 profile
+func runLoadTestSeed(n int, force bool) {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	if cfg.Env == "production" && !force {
+		fmt.Println("❌ Refusing to seed load test data into a production database (use -force to override)")
+		os.Exit(1)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(loadtest.UserPassword), bcrypt.DefaultCost)
+	if err != nil {
+		fmt.Printf("❌ Failed to hash password: %v\n", err)
+		os.Exit(1)
+	}
+
+	user, err := loadtest.SeedUser(db, string(hash))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🌱 Seeding %d products...\n", n)
+	start := time.Now()
+
+	if err := loadtest.SeedProducts(db, user.ID, n); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Seeded %d products in %s\n", n, time.Since(start).Round(time.Millisecond))
+	fmt.Printf("   Login: %s / %s\n", loadtest.UserEmail, loadtest.UserPassword)
+}
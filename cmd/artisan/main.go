@@ -7,41 +7,153 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 	"time"
 
-	"go-clean-gin/config"
+	"go-clean-gin/internal/generator"
+	"go-clean-gin/internal/migrations"
+	"go-clean-gin/internal/seeders"
 	"go-clean-gin/pkg/database"
 	"go-clean-gin/pkg/logger"
-
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"go-clean-gin/pkg/version"
 )
 
 var (
-	action = flag.String("action", "", "Action: make:migration, make:seeder, make:model, make:package, migrate, migrate:rollback, migrate:status")
+	action = flag.String("action", "", "Action: make:migration, make:seeder, make:model, make:package, make:request, make:policy, migrate, migrate:rollback, migrate:status, route:list")
 	name   = flag.String("name", "", "Migration/Seeder/Model/Package name")
 	table  = flag.String("table", "", "Table name for migration")
 	create = flag.Bool("create", false, "Create table migration")
+	all    = flag.Bool("all", false, "Also generate the migration, factory, seeder, CRUD package and routes (make:model)")
+	crud   = flag.Bool("crud", false, "Generate create, list, get, update and delete for an existing entity, and serve them (make:package)")
 	fields = flag.String("fields", "", "Fields for migration (name:type,email:string)")
 	deps   = flag.String("deps", "", "Dependencies for seeder (UserSeeder,CategorySeeder)") // เพิ่มบรรทัดนี้
-	count  = flag.Int("count", 1, "Number of migrations to rollback")
+	count  = flag.Int("count", 1, "Number of migrations to rollback, or products to seed (loadtest:seed)")
 	help   = flag.Bool("help", false, "Show help")
+	ver    = flag.Bool("version", false, "Show version information")
+
+	watch   = flag.Bool("watch", false, "Rebuild and restart the server (serve), or regenerate the types (generate:types), on file changes")
+	appPort = flag.Int("app-port", 0, "Internal port for the app process when watching (default: SERVER_PORT+1)")
+
+	email     = flag.String("email", "", "User email (user:create)")
+	password  = flag.String("password", "", "User password (user:create)")
+	role      = flag.String("role", "", "User role: user, admin (user:create)")
+	username  = flag.String("username", "", "Username (user:create)")
+	firstName = flag.String("first-name", "", "User first name (user:create)")
+	lastName  = flag.String("last-name", "", "User last name (user:create)")
+
+	format = flag.String("format", "table", "Output format: table, json (migrate, migrate:status, db:seed, route:list, db:query, db:tables, db:table), or markdown, html, json (schema:docs)")
+	write  = flag.Bool("write", false, "Allow data-modifying statements (db:query)")
+
+	mermaid = flag.Bool("mermaid", false, "Include a Mermaid ER diagram (schema:docs)")
+	verbose = flag.Bool("verbose", false, "Show who ran each migration or seeder, from where and how long it took (migrate:status, db:seed -name=list)")
+
+	output       = flag.String("output", "", "Write the backup to a local file instead of storage (db:backup), the documentation to a file (schema:docs), the types to a file (generate:types, default: web/types/api.ts), or the messages (generate:proto, default: proto/entity/v1/entity.proto)")
+	every        = flag.Duration("every", 0, "Run backups repeatedly at this interval, e.g. 24h (db:backup)")
+	keep         = flag.Int("keep", -1, "Number of backups to keep in storage (db:backup, default: BACKUP_KEEP)")
+	force        = flag.Bool("force", false, "Skip confirmation prompts, or overwrite existing files (make:*, stub:publish)")
+	skipExisting = flag.Bool("skip-existing", false, "Keep existing files and generate only the missing ones (make:*)")
+	dryRun       = flag.Bool("dry-run", false, "Show the files make:* commands would write, with diffs of existing ones, without writing them")
+
+	queues      = flag.String("queue", "", "Comma-separated queues in priority order (queue:work, default: QUEUE_DEFAULT)")
+	concurrency = flag.Int("concurrency", 1, "Number of jobs processed in parallel (queue:work)")
+	jobTimeout  = flag.Duration("timeout", 0, "Maximum duration of a single job, e.g. 5m (queue:work), or of the check (health, default: 3s)")
+	metricsAddr = flag.String("metrics-addr", "", "Expose Prometheus metrics on this address, e.g. :9100 (queue:work, schedule:run)")
+	once        = flag.Bool("once", false, "Run all scheduled tasks once and exit (schedule:run)")
+
+	iface = flag.String("interface", "", "Interface name from a port.go file (make:mock, make:cache, make:retry)")
+
+	modulePath = flag.String("module", "", "Module path generated files import from (make:*, default: from go.mod), or of the project (new, default: its directory name)")
+	from       = flag.String("from", "", "Skeleton to copy, a directory or git URL (new, default: this project)")
+	modules    = flag.String("modules", "", "Comma-separated optional modules to keep (new, default: all)")
+
+	enumValues = flag.String("values", "", "Comma-separated enum values (make:enum), e.g. pending,paid,shipped")
+
+	dtoPackage = flag.String("package", "", "Package of the DTOs (make:request, default: the name without its verb)")
+
+	message = flag.String("message", "", "Text posted with the deploy stage (deploy:notify)")
+
+	upSQL   = flag.String("sql", "", "SQL file the migration runs (make:migration)")
+	downSQL = flag.String("down-sql", "", "SQL file the migration runs to roll back (make:migration, with -sql)")
+	view    = flag.String("view", "", "Materialized view the migration creates (make:migration)")
+
+	healthURL = flag.String("url", "", "Readiness URL to check (health, default: http://127.0.0.1:SERVER_PORT/health/ready)")
+	dbOption  dbFlag
 )
 
+func init() {
+	flag.Var(&dbOption, "db", "Check the database directly instead of the HTTP endpoint (health), or the database of the project (new, only postgres)")
+}
+
+// dbFlag is -db, a switch for health that new also accepts a value for, as
+// in -db=postgres
+type dbFlag string
+
+func (f *dbFlag) String() string     { return string(*f) }
+func (f *dbFlag) Set(v string) error { *f = dbFlag(v); return nil }
+func (f *dbFlag) IsBoolFlag() bool   { return true }
+
+// enabled reports whether -db was given as a switch
+func (f dbFlag) enabled() bool {
+	return f != "" && f != "false"
+}
+
+// driver returns the database named with -db, postgres by default
+func (f dbFlag) driver() string {
+	if f == "" || f == "true" {
+		return "postgres"
+	}
+	return string(f)
+}
+
 func main() {
-	flag.Parse()
+	// Allow "artisan <action> [options]" in addition to "-action=<action>"
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		*action = os.Args[1]
+		flag.CommandLine.Parse(os.Args[2:])
+	} else {
+		flag.Parse()
+	}
+
+	if *ver {
+		fmt.Printf("🎨 Go Clean Gin Artisan %s\n", version.Get())
+		return
+	}
 
 	if *help || *action == "" {
 		showHelp()
 		return
 	}
 
+	// Generators write into the project, wherever in it artisan is run from
+	if strings.HasPrefix(*action, "make:") || strings.HasPrefix(*action, "stub:") || strings.HasPrefix(*action, "generate:") {
+		enterProjectRoot()
+		if *force && *skipExisting {
+			fmt.Println("❌ -force and -skip-existing can't be used together")
+			os.Exit(1)
+		}
+		if *dryRun && !strings.HasPrefix(*action, "stub:") {
+			fmt.Println("🔍 Dry run: showing what would be written, nothing is")
+		}
+	}
+
 	switch *action {
 	case "make:migration":
-		if *name == "" || *table == "" {
+		if *name == "" || (*table == "" && *upSQL == "" && *view == "") {
 			fmt.Println("❌ Migration name is required")
-			fmt.Println("Usage: go run cmd/artisan/main.go -action=make:migration -name=migration_name -table=table_name")
+			fmt.Println("Usage: go run ./cmd/artisan -action=make:migration -name=migration_name -table=table_name")
+			fmt.Println("       go run ./cmd/artisan -action=make:migration -name=migration_name -sql=up.sql [-down-sql=down.sql]")
+			fmt.Println("       go run ./cmd/artisan -action=make:migration -name=migration_name -view=mv_view_name")
+			os.Exit(1)
+		}
+		if *view != "" {
+			createViewMigration(*name, *view)
+			break
+		}
+		if *upSQL != "" {
+			createSQLMigration(*name, *upSQL, *downSQL)
+			break
+		}
+		if *downSQL != "" {
+			fmt.Println("❌ -down-sql needs -sql")
 			os.Exit(1)
 		}
 		createMigration(*name, *table, *create, *fields)
@@ -49,129 +161,244 @@ func main() {
 	case "make:seeder":
 		if *name == "" {
 			fmt.Println("❌ Seeder name is required")
-			fmt.Println("Usage: go run cmd/artisan/main.go -action=make:seeder -name=seeder_name")
+			fmt.Println("Usage: go run ./cmd/artisan -action=make:seeder -name=seeder_name")
 			os.Exit(1)
 		}
 		createSeeder(*name, *table, *deps)
 
 	case "make:model":
-		if *name == "" || *table == "" {
+		if *name == "" {
 			fmt.Println("❌ Model name is required")
-			fmt.Println("Usage: go run cmd/artisan/main.go -action=make:model -name=model_name -table=table_name")
+			fmt.Println("Usage: go run ./cmd/artisan -action=make:model -name=model_name [-table=table_name]")
 			os.Exit(1)
 		}
-		createModel(*name, *table, *fields)
+		if *all {
+			createResource(*name, *table, *fields)
+		} else {
+			createModel(*name, *table, *fields)
+		}
 
 	case "make:package":
 		if *name == "" {
 			fmt.Println("❌ Package name is required")
-			fmt.Println("Usage: go run cmd/artisan/main.go -action=make:package -name=package_name")
+			fmt.Println("Usage: go run ./cmd/artisan -action=make:package -name=package_name [-crud -fields=\"title:string\"]")
+			os.Exit(1)
+		}
+		createPackage(*name, *fields, *crud)
+
+	case "make:enum":
+		if *name == "" || *enumValues == "" {
+			fmt.Println("❌ Enum name and values are required")
+			fmt.Println("Usage: go run ./cmd/artisan make:enum -name=OrderStatus -values=pending,paid,shipped")
+			os.Exit(1)
+		}
+		createEnum(*name, *enumValues)
+
+	case "make:request":
+		if *name == "" {
+			fmt.Println("❌ Request name is required")
+			fmt.Println("Usage: go run ./cmd/artisan make:request -name=CreateOrder -fields=\"customer_id:uuid,note:text:nullable\"")
+			os.Exit(1)
+		}
+		createRequest(*name, *dtoPackage, *fields)
+
+	case "make:policy":
+		if *name == "" {
+			fmt.Println("❌ Policy name is required")
+			fmt.Println("Usage: go run ./cmd/artisan make:policy -name=Post [-fields=\"user:belongsTo=User\"]")
+			os.Exit(1)
+		}
+		createPolicy(*name, *fields)
+
+	case "make:mock":
+		if *iface == "" {
+			*iface = flag.Arg(0)
+		}
+		createMock(*iface)
+
+	case "generate:mocks":
+		generateMocks()
+
+	case "make:cache":
+		if *iface == "" {
+			*iface = flag.Arg(0)
+		}
+		createCache(*iface)
+
+	case "generate:caches":
+		generateCaches()
+
+	case "make:retry":
+		if *iface == "" {
+			*iface = flag.Arg(0)
+		}
+		createRetry(*iface)
+
+	case "generate:retries":
+		generateRetries()
+
+	case "generate:types":
+		runGenerateTypes(positionalArgs(), *output, *watch)
+
+	case "generate:proto":
+		runGenerateProto(positionalArgs(), *output)
+
+	case "stub:publish":
+		publishStubs(*force)
+
+	case "new":
+		dir := argOrName()
+		if dir == "" {
+			fmt.Println("❌ Project directory is required")
+			fmt.Println("Usage: go run ./cmd/artisan new shop -module=github.com/acme/shop")
 			os.Exit(1)
 		}
-		createPackage(*name)
+		newProject(dir, *from, *modulePath, *modules, dbOption.driver())
 
 	case "migrate":
 		runMigrations()
 
+	case "deploy:notify":
+		stage := argOrName()
+		runDeployNotify(stage, *message)
+
 	case "migrate:rollback":
 		rollbackMigrations(*count)
 
 	case "migrate:status":
 		showMigrationStatus()
 
+	case "tenants:migrate":
+		runTenantsMigrate(*name)
+
+	case "crypto:generate-key":
+		runGenerateKey(*name)
+
+	case "crypto:rotate":
+		runRotateKeys()
+
 	case "db:seed":
 		runSeeders(*name)
 
+	case "route:list":
+		listRoutes()
+
+	case "serve":
+		runServe(*watch, *appPort)
+
+	case "user:create":
+		createUser(*email, *password, *role, *username, *firstName, *lastName)
+
+	case "db:query":
+		query := argOrName()
+		runDBQuery(query, *format, *write)
+
+	case "db:tables":
+		listTables(*format)
+
+	case "db:table":
+		table := argOrName()
+		describeTable(table, *format)
+
+	case "db:refresh-views":
+		runRefreshViews(append(positionalArgs(), splitList(*name)...))
+
+	case "archive:run":
+		runArchive(argOrName())
+
+	case "retention:purge":
+		rule := argOrName()
+		runPurge(rule, *dryRun)
+
+	case "schema:docs":
+		runSchemaDocs(*format, *output, *mermaid)
+
+	case "db:backup":
+		runBackup(*output, *every, *keep)
+
+	case "db:restore":
+		source := argOrName()
+		runRestore(source, *force)
+
+	case "db:anonymize":
+		runAnonymize(*name, *force)
+
+	case "queue:work":
+		runQueueWork(*queues, *concurrency, *jobTimeout, *metricsAddr)
+
+	case "schedule:run":
+		runSchedule(*once, *name, *metricsAddr)
+
+	case "health":
+		runHealth(*healthURL, dbOption.enabled(), *jobTimeout)
+
+	case "loadtest:seed":
+		runLoadTestSeed(*count, *force)
+
+	case "products:rebuild-read-model":
+		runRebuildReadModel()
+
+	case "publicid:backfill":
+		runBackfillPublicIDs()
+
 	default:
 		fmt.Printf("❌ Unknown action: %s\n", *action)
 		showHelp()
 		os.Exit(1)
 	}
-}
-
-// createMigration function in main.go
-func createMigration(migrationName, tableName string, isCreate bool, fieldList string) {
-	timestamp := time.Now().Format("2006_01_02_150405")
-	fileName := fmt.Sprintf("%s_%s.go", timestamp, toSnakeCase(migrationName))
 
-	// Create migrations directory if not exists
-	migrationsDir := "internal/migrations"
-	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
-		fmt.Printf("❌ Failed to create migrations directory: %v\n", err)
-		os.Exit(1)
+	if *dryRun && (strings.HasPrefix(*action, "make:") || strings.HasPrefix(*action, "generate:")) {
+		fmt.Println("🔍 Dry run: no files were written")
 	}
+}
 
-	filePath := filepath.Join(migrationsDir, fileName)
-
-	// Check if file already exists
-	if _, err := os.Stat(filePath); err == nil {
-		fmt.Printf("❌ Migration file already exists: %s\n", filePath)
-		os.Exit(1)
+// argOrName returns the first positional argument, falling back to -name.
+// The flag package stops at the first positional argument, so the options
+// that follow it are parsed here; read option values after calling it.
+func argOrName() string {
+	if flag.NArg() > 0 {
+		arg := flag.Arg(0)
+		flag.CommandLine.Parse(flag.Args()[1:])
+		return arg
 	}
+	return *name
+}
 
-	// Use the new parseFields function
-	parsedFields := parseFields(fieldList)
-
-	// Create migration data
-	data := MigrationData{
-		ClassName:   toPascalCase(migrationName),
-		TableName:   tableName,
-		Timestamp:   timestamp,
-		Description: migrationName,
-		Fields:      parsedFields,
-		Version:     fmt.Sprintf("%s_%s", timestamp, migrationName),
+// positionalArgs returns the positional arguments, parsing the options that
+// follow them
+func positionalArgs() []string {
+	var args []string
+	for flag.NArg() > 0 {
+		args = append(args, flag.Arg(0))
+		flag.CommandLine.Parse(flag.Args()[1:])
 	}
+	return args
+}
 
-	// Create file
-	file, err := os.Create(filePath)
+// createMigration generates a migration (and the entity for create-table migrations)
+func createMigration(migrationName, tableName string, isCreate bool, fieldList string) {
+	timestamp := time.Now().Format("2006_01_02_150405")
+	parsedFields := generator.ParseFields(fieldList)
+	data := generator.NewMigrationData(migrationName, tableName, parsedFields, timestamp)
+
+	file, err := generator.Migration(data, isCreate)
 	if err != nil {
-		fmt.Printf("❌ Failed to create migration file: %v\n", err)
+		fmt.Printf("❌ Failed to generate migration file: %v\n", err)
 		os.Exit(1)
 	}
-	defer file.Close()
 
-	// Choose template
-	var tmpl *template.Template
-	if isCreate && tableName != "" {
-		tmpl = template.Must(template.New("create_table").Funcs(templateFuncs).Parse(createTableTemplate))
-	} else if tableName != "" {
-		tmpl = template.Must(template.New("alter_table").Funcs(templateFuncs).Parse(alterTableTemplate))
-	} else {
-		tmpl = template.Must(template.New("migration").Funcs(templateFuncs).Parse(migrationTemplate))
-	}
-
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		fmt.Printf("❌ Failed to generate migration file: %v\n", err)
+	if err := writeGeneratedFile(file); err != nil {
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Migration created: %s\n", filePath)
+	fmt.Printf("✅ Migration created: %s\n", file.Path)
 	fmt.Printf("📝 Class: %s\n", data.ClassName)
 	if tableName != "" {
 		fmt.Printf("🗂️  Table: %s\n", tableName)
 	}
 
-	// Show field summary
-	if len(parsedFields) > 0 {
-		fmt.Printf("📋 Fields:\n")
-		for _, field := range parsedFields {
-			extras := []string{}
-			if field.HasIndex {
-				extras = append(extras, "indexed")
-			}
-			if field.IsForeignKey {
-				extras = append(extras, fmt.Sprintf("FK->%s", field.FKReference))
-			}
-
-			extraStr := ""
-			if len(extras) > 0 {
-				extraStr = fmt.Sprintf(" (%s)", strings.Join(extras, ", "))
-			}
-
-			fmt.Printf("  - %s: %s%s\n", field.Name, field.Type, extraStr)
-		}
-	}
+	printFieldSummary(parsedFields)
 
 	// Auto-create entity if this is a create table migration
 	if isCreate && tableName != "" {
@@ -182,156 +409,226 @@ func createMigration(migrationName, tableName string, isCreate bool, fieldList s
 	}
 }
 
-func autoCreateEntity(tableName string, fields []Field) error {
-	// Generate entity name from table name
-	entityName := getStructName(tableName)
-	fileName := fmt.Sprintf("%s.go", strings.ToLower(entityName))
+// createViewMigration generates a migration creating the materialized view
+// viewName, with the query left to write
+func createViewMigration(migrationName, viewName string) {
+	timestamp := time.Now().Format("2006_01_02_150405")
+	data := generator.NewMigrationData(migrationName, viewName, nil, timestamp)
 
-	// Create entity directory if not exists
-	entityDir := "internal/entity"
-	if err := os.MkdirAll(entityDir, 0755); err != nil {
-		return fmt.Errorf("failed to create entity directory: %w", err)
+	file, err := generator.ViewMigration(data)
+	if err != nil {
+		fmt.Printf("❌ Failed to generate migration file: %v\n", err)
+		os.Exit(1)
 	}
 
-	filePath := filepath.Join(entityDir, fileName)
+	if err := writeGeneratedFile(file); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
 
-	// Check if file already exists - warn but don't fail
-	if _, err := os.Stat(filePath); err == nil {
-		fmt.Printf("⚠️  Entity file already exists, skipping: %s\n", filePath)
-		return nil
+	fmt.Printf("✅ Migration created: %s\n", file.Path)
+	fmt.Printf("📝 Class: %s\n", data.ClassName)
+	fmt.Printf("🪟 Materialized view: %s\n", viewName)
+}
+
+// createSQLMigration generates a migration running the SQL in upPath, and the
+// SQL in downPath to roll back. Both are copied to internal/migrations/sql.
+func createSQLMigration(migrationName, upPath, downPath string) {
+	up, err := os.ReadFile(upPath)
+	if err != nil {
+		fmt.Printf("❌ Failed to read SQL: %v\n", err)
+		os.Exit(1)
+	}
+	var down []byte
+	if downPath != "" {
+		if down, err = os.ReadFile(downPath); err != nil {
+			fmt.Printf("❌ Failed to read down SQL: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Create entity data
-	data := EntityData{
-		EntityName: entityName,
+	timestamp := time.Now().Format("2006_01_02_150405")
+	data := generator.NewMigrationData(migrationName, "", nil, timestamp)
+	files, err := generator.SQLMigration(data, up, down)
+	if err != nil {
+		fmt.Printf("❌ Failed to generate migration file: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, file := range files {
+		if err := writeGeneratedFile(file); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Created: %s\n", file.Path)
+	}
+
+	fmt.Printf("📝 Class: %s\n", data.ClassName)
+	if strings.TrimSpace(string(down)) == "" {
+		fmt.Printf("⚠️  No -down-sql given, rolling back %s will fail\n", data.Version)
+	}
+}
+
+func autoCreateEntity(tableName string, fields []generator.Field) error {
+	file, err := generator.Entity(generator.EntityData{
+		EntityName: generator.GetStructName(tableName),
 		TableName:  tableName,
 		Fields:     fields,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate entity file: %w", err)
 	}
 
-	// Create file
-	file, err := os.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to create entity file: %w", err)
+	// Check if file already exists - warn but don't fail
+	if _, err := os.Stat(file.Path); err == nil && !*force {
+		fmt.Printf("⚠️  Entity file already exists, skipping: %s\n", file.Path)
+		return nil
 	}
-	defer file.Close()
 
-	// Execute template
-	tmpl := template.Must(template.New("entity").Funcs(templateFuncs).Parse(entityTemplate))
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to generate entity file: %w", err)
+	if err := writeGeneratedFile(file); err != nil {
+		return err
 	}
 
-	fmt.Printf("✅ Entity created: %s\n", filePath)
-	fmt.Printf("📝 Entity: %s\n", entityName)
+	fmt.Printf("✅ Entity created: %s\n", file.Path)
+	fmt.Printf("📝 Entity: %s\n", generator.GetStructName(tableName))
 	fmt.Printf("🗂️  Table: %s\n", tableName)
 
-	// Show entity features
-	if len(fields) > 0 {
-		fmt.Printf("📋 Entity Features:\n")
-
-		// Check for associations
-		hasAssociations := false
-		for _, field := range fields {
-			if field.IsForeignKey {
-				hasAssociations = true
-				refEntity := getStructName(field.FKReference)
-				fmt.Printf("  - %s association (belongs to %s)\n", refEntity, refEntity)
-			}
-		}
+	printEntityFeatures(fields)
+	return nil
+}
 
-		// Check for indexes
-		hasIndexes := false
-		for _, field := range fields {
-			if field.HasIndex {
-				hasIndexes = true
-				fmt.Printf("  - Index on %s field\n", field.Name)
-			}
-		}
+func createSeeder(seederName, tableName, depsStr string) {
+	data := generator.NewSeederData(seederName, tableName, depsStr)
 
-		if !hasAssociations && !hasIndexes {
-			fmt.Printf("  - Basic CRUD entity with validation\n")
-		}
+	file, err := generator.Seeder(data)
+	if err != nil {
+		fmt.Printf("❌ Failed to generate seeder file: %v\n", err)
+		os.Exit(1)
+	}
 
-		fmt.Printf("  - Soft deletes enabled\n")
-		fmt.Printf("  - JSON serialization ready\n")
-		fmt.Printf("  - Validation tags included\n")
+	if keepExisting(file.Path) {
+		return
+	}
+	if err := writeGeneratedFile(file); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
 	}
 
-	return nil
+	fmt.Printf("✅ Seeder created: %s\n", file.Path)
+	fmt.Printf("📝 Class: %s\n", data.ClassName)
+	if tableName != "" {
+		fmt.Printf("🗂️  Table: %s\n", tableName)
+	}
+	if len(data.Dependencies) > 0 {
+		fmt.Printf("🔗 Dependencies: %s\n", strings.Join(data.Dependencies, ", "))
+	}
 }
 
-func createSeeder(seederName, tableName, depsStr string) {
-	if !strings.HasSuffix(seederName, "Seeder") {
-		seederName += "Seeder"
+func createEnum(enumName, values string) {
+	data, err := generator.NewEnumData(enumName, values)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
 	}
 
-	fileName := fmt.Sprintf("%s.go", toSnakeCase(seederName))
+	file, err := generator.Enum(data)
+	if err != nil {
+		fmt.Printf("❌ Failed to generate enum file: %v\n", err)
+		os.Exit(1)
+	}
 
-	// Create seeders directory if not exists
-	seedersDir := "internal/seeders"
-	if err := os.MkdirAll(seedersDir, 0755); err != nil {
-		fmt.Printf("❌ Failed to create seeders directory: %v\n", err)
+	if keepExisting(file.Path) {
+		return
+	}
+	if err := writeGeneratedFile(file); err != nil {
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	filePath := filepath.Join(seedersDir, fileName)
+	fmt.Printf("✅ Enum created: %s\n", file.Path)
+	fmt.Printf("📝 Type: entity.%s\n", data.TypeName)
+	fmt.Println("🔢 Values:")
+	for _, v := range data.Values {
+		fmt.Printf("   - %s = %q\n", v.ConstName, v.Value)
+	}
+	fmt.Println("💡 Use it in an entity field:")
+	fmt.Printf("   Status entity.%s `json:\"status\" gorm:\"type:varchar(50);not null\" validate:\"required,enum\"`\n", data.TypeName)
+}
 
-	// Check if file already exists
-	if _, err := os.Stat(filePath); err == nil {
-		fmt.Printf("❌ Seeder file already exists: %s\n", filePath)
+func createRequest(requestName, packageName, fieldList string) {
+	data := generator.NewRequestData(requestName, packageName, generator.ParseFields(fieldList))
+
+	path := filepath.Join("internal", data.PackageName, "dto.go")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	// Parse dependencies
-	var dependencies []string
-	if depsStr != "" {
-		depsList := strings.Split(depsStr, ",")
-		for _, dep := range depsList {
-			dep = strings.TrimSpace(dep)
-			if dep != "" {
-				// Ensure dependency ends with "Seeder"
-				if !strings.HasSuffix(dep, "Seeder") {
-					dep += "Seeder"
-				}
-				dependencies = append(dependencies, dep)
-			}
+	file, err := generator.Request(data, existing)
+	if err != nil {
+		fmt.Printf("❌ Failed to generate %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	if existing != nil {
+		if err := writeFile(file.Path, file.Content); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Request added to: %s\n", file.Path)
+	} else {
+		if err := writeGeneratedFile(file); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("✅ Request created: %s\n", file.Path)
 	}
+	fmt.Printf("📝 Types: %[1]s.%[2]sRequest, %[1]s.%[2]sResponse\n", data.PackageName, data.Name)
+	printFieldSummary(data.Columns())
+	fmt.Println("💡 Bind and validate it in a handler:")
+	fmt.Printf("   var req %sRequest\n", data.Name)
+	fmt.Println("   if err := c.ShouldBindJSON(&req); err != nil { ... }")
+	fmt.Println("   if errs := req.Validate(); errs != nil { ... }")
+}
 
-	data := SeederData{
-		ClassName:    seederName,
-		TableName:    tableName,
-		Dependencies: dependencies,
+func createPolicy(entityName, fieldList string) {
+	entityName = generator.ToPascalCase(generator.ToSnakeCase(strings.TrimSuffix(entityName, "Policy")))
+	data := generator.PackageData{
+		PackageName: strings.ToLower(entityName),
+		EntityName:  entityName,
+		Fields:      generator.ParseFields(fieldList),
 	}
 
-	// Create file
-	file, err := os.Create(filePath)
+	file, err := generator.Policy(data)
 	if err != nil {
-		fmt.Printf("❌ Failed to create seeder file: %v\n", err)
+		fmt.Printf("❌ Failed to generate policy: %v\n", err)
 		os.Exit(1)
 	}
-	defer file.Close()
 
-	// Execute template
-	tmpl := template.Must(template.New("seeder").Parse(seederTemplate))
-	if err := tmpl.Execute(file, data); err != nil {
-		fmt.Printf("❌ Failed to generate seeder file: %v\n", err)
+	if keepExisting(file.Path) {
+		return
+	}
+	if err := writeGeneratedFile(file); err != nil {
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Seeder created: %s\n", filePath)
-	fmt.Printf("📝 Class: %s\n", data.ClassName)
-	if tableName != "" {
-		fmt.Printf("🗂️  Table: %s\n", tableName)
-	}
-	if len(dependencies) > 0 {
-		fmt.Printf("🔗 Dependencies: %s\n", strings.Join(dependencies, ", "))
+	fmt.Printf("✅ Policy created: %s\n", file.Path)
+	if owner := data.Owner(); owner != nil {
+		fmt.Printf("👤 Owner: %s\n", owner.Name)
+	} else {
+		fmt.Println("⚠️  No user:belongsTo=User field, so the policy denies everything until its checks are written")
 	}
+	fmt.Println("💡 Check it in the usecase before acting:")
+	fmt.Printf("   if err := policy.Gate(ctx, u.policy.CanUpdate, userID, %s, errors.ErrForbiddenError); err != nil {\n", strings.ToLower(entityName[:1])+entityName[1:])
+	fmt.Println("       return nil, err")
+	fmt.Println("   }")
 }
 
 func createModel(modelName, table, fieldList string) {
 	// Generate entity struct name
-	entityName := toPascalCase(modelName)
+	entityName := generator.ToPascalCase(generator.ToSnakeCase(modelName))
 
 	// Use TABLE parameter if provided, otherwise auto-generate
 	var tableName string
@@ -339,219 +636,222 @@ func createModel(modelName, table, fieldList string) {
 		tableName = table // Use provided table name
 		fmt.Printf("📋 Using specified table: %s\n", tableName)
 	} else {
-		tableName = strings.ToLower(toSnakeCase(entityName)) + "s" // Auto-generate: posts, users, etc.
+		tableName = generator.Pluralize(generator.ToSnakeCase(entityName)) // Auto-generate: posts, categories, people, etc.
 		fmt.Printf("📋 Auto-generated table: %s\n", tableName)
 	}
 
-	fileName := fmt.Sprintf("%s.go", strings.ToLower(entityName))
-
-	// Create entity directory if not exists
-	entityDir := "internal/entity"
-	if err := os.MkdirAll(entityDir, 0755); err != nil {
-		fmt.Printf("❌ Failed to create entity directory: %v\n", err)
-		os.Exit(1)
-	}
-
-	filePath := filepath.Join(entityDir, fileName)
-
-	// Check if file already exists
-	if _, err := os.Stat(filePath); err == nil {
-		fmt.Printf("❌ Entity file already exists: %s\n", filePath)
-		os.Exit(1)
-	}
-
-	// Use enhanced parseFields function (same as migration)
-	parsedFields := parseFields(fieldList)
+	parsedFields := generator.ParseFields(fieldList)
 
-	// Create entity data
-	data := EntityData{
+	file, err := generator.Entity(generator.EntityData{
 		EntityName: entityName,
-		TableName:  tableName, // Use specified or auto-generated table name
+		TableName:  tableName,
 		Fields:     parsedFields,
-	}
-
-	// Create file
-	file, err := os.Create(filePath)
+	})
 	if err != nil {
-		fmt.Printf("❌ Failed to create entity file: %v\n", err)
+		fmt.Printf("❌ Failed to generate entity file: %v\n", err)
 		os.Exit(1)
 	}
-	defer file.Close()
 
-	// Execute template
-	tmpl := template.Must(template.New("entity").Funcs(templateFuncs).Parse(entityTemplate))
-	if err := tmpl.Execute(file, data); err != nil {
-		fmt.Printf("❌ Failed to generate entity file: %v\n", err)
+	if keepExisting(file.Path) {
+		return
+	}
+	if err := writeGeneratedFile(file); err != nil {
+		fmt.Printf("❌ %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("✅ Entity created: %s\n", filePath)
+	fmt.Printf("✅ Entity created: %s\n", file.Path)
 	fmt.Printf("📝 Entity: %s\n", entityName)
 	fmt.Printf("🗂️  Table: %s\n", tableName)
 
-	// Enhanced field summary (same as migration)
 	if len(parsedFields) > 0 {
-		fmt.Printf("📋 Fields:\n")
-		for _, field := range parsedFields {
-			extras := []string{}
-			if field.HasIndex {
-				extras = append(extras, "indexed")
-			}
-			if field.IsForeignKey {
-				extras = append(extras, fmt.Sprintf("FK->%s", field.FKReference))
-			}
-
-			extraStr := ""
-			if len(extras) > 0 {
-				extraStr = fmt.Sprintf(" (%s)", strings.Join(extras, ", "))
-			}
-
-			fmt.Printf("  - %s: %s%s\n", field.Name, field.Type, extraStr)
-		}
-
-		// Show entity features (same as autoCreateEntity)
-		fmt.Printf("📋 Entity Features:\n")
-
-		// Check for associations
-		hasAssociations := false
-		for _, field := range parsedFields {
-			if field.IsForeignKey {
-				hasAssociations = true
-				refEntity := getStructName(field.FKReference)
-				fmt.Printf("  - %s association (belongs to %s)\n", refEntity, refEntity)
-			}
-		}
-
-		// Check for indexes
-		hasIndexes := false
-		for _, field := range parsedFields {
-			if field.HasIndex {
-				hasIndexes = true
-				fmt.Printf("  - Index on %s field\n", field.Name)
-			}
-		}
-
-		if !hasAssociations && !hasIndexes {
-			fmt.Printf("  - Basic CRUD entity with validation\n")
-		}
-
-		fmt.Printf("  - Soft deletes enabled\n")
-		fmt.Printf("  - JSON serialization ready\n")
-		fmt.Printf("  - Validation tags included\n")
+		printFieldSummary(parsedFields)
+		printEntityFeatures(parsedFields)
 	}
 }
-func createPackage(packageName string) {
-	// Convert to lowercase for package name
-	pkgName := strings.ToLower(packageName)
-	entityName := toPascalCase(packageName)
-
-	// Create package directory
-	packageDir := filepath.Join("internal", pkgName)
-	if err := os.MkdirAll(packageDir, 0755); err != nil {
-		fmt.Printf("❌ Failed to create package directory: %v\n", err)
+
+func createPackage(packageName, fieldList string, crud bool) {
+	data := generator.PackageData{
+		PackageName: strings.ToLower(packageName),
+		EntityName:  generator.ToPascalCase(packageName),
+		Fields:      generator.ParseFields(fieldList),
+		CRUD:        crud,
+	}
+
+	files, err := generator.Package(data)
+	if err != nil {
+		fmt.Printf("❌ Failed to generate package: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Check if package already exists
-	files := []string{"handler.go", "port.go", "repository.go", "usecase.go"}
 	for _, file := range files {
-		if _, err := os.Stat(filepath.Join(packageDir, file)); err == nil {
-			fmt.Printf("❌ Package '%s' already exists (found %s)\n", pkgName, file)
+		if _, err := os.Stat(file.Path); err == nil && !*force && !*skipExisting {
+			fmt.Printf("❌ Package '%s' already exists (found %s, use -force to overwrite or -skip-existing to add the missing files)\n", data.PackageName, filepath.Base(file.Path))
 			os.Exit(1)
 		}
 	}
 
-	packageData := PackageData{
-		PackageName: pkgName,
-		EntityName:  entityName,
+	var written []generator.File
+	for _, file := range files {
+		if keepExisting(file.Path) {
+			continue
+		}
+		if err := writeGeneratedFile(file); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		written = append(written, file)
 	}
 
-	// Create handler.go
-	if err := createFileFromTemplate(
-		filepath.Join(packageDir, "handler.go"),
-		handlerTemplate,
-		packageData,
-	); err != nil {
-		fmt.Printf("❌ Failed to create handler.go: %v\n", err)
-		os.Exit(1)
+	fmt.Printf("✅ Package created: internal/%s/\n", data.PackageName)
+	fmt.Printf("📁 Files created:\n")
+	for _, file := range written {
+		fmt.Printf("  - %s\n", file.Path)
 	}
+	fmt.Printf("🎯 Entity: %s\n", data.EntityName)
 
-	// Create port.go
-	if err := createFileFromTemplate(
-		filepath.Join(packageDir, "port.go"),
-		portTemplate,
-		packageData,
-	); err != nil {
-		fmt.Printf("❌ Failed to create port.go: %v\n", err)
-		os.Exit(1)
+	if crud {
+		entityPath := filepath.Join("internal", "entity", data.PackageName+".go")
+		if _, err := os.Stat(entityPath); err != nil {
+			fmt.Printf("⚠️  %s not found; create the entity with make:model -name=%s and the same -fields\n", entityPath, data.EntityName)
+		}
+		wirePackage(data)
+		printSwaggerHint()
 	}
+}
 
-	// Create repository.go
-	if err := createFileFromTemplate(
-		filepath.Join(packageDir, "repository.go"),
-		repositoryTemplate,
-		packageData,
-	); err != nil {
-		fmt.Printf("❌ Failed to create repository.go: %v\n", err)
-		os.Exit(1)
+// printFieldSummary lists parsed fields with their index/FK options
+func printFieldSummary(fields []generator.Field) {
+	if len(fields) == 0 {
+		return
 	}
 
-	// Create usecase.go
-	if err := createFileFromTemplate(
-		filepath.Join(packageDir, "usecase.go"),
-		usecaseTemplate,
-		packageData,
-	); err != nil {
-		fmt.Printf("❌ Failed to create usecase.go: %v\n", err)
-		os.Exit(1)
-	}
+	fmt.Printf("📋 Fields:\n")
+	for _, field := range fields {
+		extras := []string{}
+		if field.HasIndex {
+			extras = append(extras, "indexed")
+		}
+		if field.IsForeignKey {
+			extras = append(extras, fmt.Sprintf("FK->%s", field.FKReference))
+		}
+		if field.Nullable {
+			extras = append(extras, "nullable")
+		}
+		if field.HasDefault {
+			extras = append(extras, "default "+field.Default)
+		}
+		if field.Relation != "" {
+			extras = append(extras, fmt.Sprintf("%s %s", field.Relation, field.Related))
+		}
 
-	fmt.Printf("✅ Package created: internal/%s/\n", pkgName)
-	fmt.Printf("📁 Files created:\n")
-	fmt.Printf("  - internal/%s/handler.go\n", pkgName)
-	fmt.Printf("  - internal/%s/port.go\n", pkgName)
-	fmt.Printf("  - internal/%s/repository.go\n", pkgName)
-	fmt.Printf("  - internal/%s/usecase.go\n", pkgName)
-	fmt.Printf("🎯 Entity: %s\n", entityName)
+		extraStr := ""
+		if len(extras) > 0 {
+			extraStr = fmt.Sprintf(" (%s)", strings.Join(extras, ", "))
+		}
+
+		if field.IsColumn() {
+			fmt.Printf("  - %s: %s%s\n", field.Name, field.Type, extraStr)
+		} else {
+			fmt.Printf("  - %s%s\n", field.Name, extraStr)
+		}
+	}
 }
 
-func createFileFromTemplate(filePath, templateContent string, data interface{}) error {
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
+// printEntityFeatures describes associations and indexes of a generated entity
+func printEntityFeatures(fields []generator.Field) {
+	if len(fields) == 0 {
+		return
 	}
-	defer file.Close()
 
-	tmpl := template.Must(template.New("template").Funcs(templateFuncs).Parse(templateContent))
-	return tmpl.Execute(file, data)
+	fmt.Printf("📋 Entity Features:\n")
+
+	// Check for associations
+	hasAssociations := false
+	for _, field := range fields {
+		if field.IsForeignKey {
+			hasAssociations = true
+			refEntity := generator.GetStructName(field.FKReference)
+			fmt.Printf("  - %s association (belongs to %s)\n", refEntity, refEntity)
+		}
+		if field.Relation != "" {
+			hasAssociations = true
+			fmt.Printf("  - %s association (%s %s)\n", field.Association(), field.Relation, field.Related)
+		}
+	}
+
+	// Check for indexes
+	hasIndexes := false
+	for _, field := range fields {
+		if field.HasIndex {
+			hasIndexes = true
+			fmt.Printf("  - Index on %s field\n", field.Name)
+		}
+	}
+
+	if !hasAssociations && !hasIndexes {
+		fmt.Printf("  - Basic CRUD entity with validation\n")
+	}
+
+	fmt.Printf("  - Soft deletes enabled\n")
+	fmt.Printf("  - JSON serialization ready\n")
+	fmt.Printf("  - Validation tags included\n")
+
+	printRelationHints(fields)
 }
 
-func runMigrations() {
-	fmt.Println("⬆️  Running migrations...")
+// printRelationHints reminds of the models relations point to, which must
+// exist for the entity to compile
+func printRelationHints(fields []generator.Field) {
+	var hints []string
+	for _, field := range fields {
+		switch field.Relation {
+		case generator.RelationHasOne, generator.RelationHasMany:
+			hints = append(hints, fmt.Sprintf("%s needs the foreign key back, e.g. make:model -name=%s -fields=\"<owner>:belongsTo=<this model>\"", field.Related, field.Related))
+		case generator.RelationBelongsTo, generator.RelationManyToMany:
+			hints = append(hints, fmt.Sprintf("entity.%s must exist", field.Related))
+		}
+	}
+	if len(hints) == 0 {
+		return
+	}
 
-	// Load configuration
-	cfg := config.Load()
+	fmt.Printf("💡 Relations:\n")
+	for _, hint := range hints {
+		fmt.Printf("  - %s\n", hint)
+	}
+}
 
-	// Initialize logger
-	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
-		fmt.Printf("❌ Failed to initialize logger: %v\n", err)
-		os.Exit(1)
+// runMigrations applies pending migrations. With -format=json it prints the
+// versions applied.
+func runMigrations() {
+	if !jsonOutput() {
+		fmt.Println("⬆️  Running migrations...")
 	}
+
+	cfg, db := bootstrap(jsonOutput())
 	defer logger.Sync()
+	notifyMigrationFailures(newNotifier(cfg))
 
-	// Initialize database
-	db, err := database.NewPostgresDB(&cfg.Database)
-	if err != nil {
-		fmt.Printf("❌ Failed to connect to database: %v\n", err)
-		os.Exit(1)
+	var pending []string
+	if jsonOutput() {
+		statuses, err := database.MigrationStatus(db)
+		if err != nil {
+			fail("Failed to get migration status", err)
+		}
+		pending = pendingVersions(statuses)
 	}
 
 	// Run migrations
 	if err := database.RunMigrations(db); err != nil {
-		fmt.Printf("❌ Migration failed: %v\n", err)
-		os.Exit(1)
+		fail("Migration failed", err)
 	}
 
+	if jsonOutput() {
+		printJSON(map[string][]string{"migrated": pending})
+		return
+	}
 	fmt.Println("✅ Migrations completed successfully")
 }
 
@@ -559,7 +859,7 @@ func rollbackMigrations(count int) {
 	fmt.Printf("⬇️  Rolling back %d migration(s)...\n", count)
 
 	// Load configuration
-	cfg := config.Load()
+	cfg := loadConfig()
 
 	// Initialize logger
 	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
@@ -575,6 +875,8 @@ func rollbackMigrations(count int) {
 		os.Exit(1)
 	}
 
+	notifyMigrationFailures(newNotifier(cfg))
+
 	// Rollback migrations
 	if err := database.RollbackMigrations(db, count); err != nil {
 		fmt.Printf("❌ Rollback failed: %v\n", err)
@@ -584,83 +886,128 @@ func rollbackMigrations(count int) {
 	fmt.Println("✅ Rollback completed successfully")
 }
 
+// showMigrationStatus prints which migrations are applied, as JSON with
+// -format=json, and exits with exitPending when any are not
 func showMigrationStatus() {
-	fmt.Println("📊 Checking migration status...")
-
-	// Load configuration
-	cfg := config.Load()
-
-	// Initialize logger
-	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
-		fmt.Printf("❌ Failed to initialize logger: %v\n", err)
-		os.Exit(1)
+	if !jsonOutput() {
+		fmt.Println("📊 Checking migration status...")
 	}
+
+	_, db := bootstrap(jsonOutput())
 	defer logger.Sync()
 
-	// Initialize database
-	db, err := database.NewPostgresDB(&cfg.Database)
+	statuses, err := database.MigrationStatus(db)
 	if err != nil {
-		fmt.Printf("❌ Failed to connect to database: %v\n", err)
-		os.Exit(1)
+		fail("Failed to get migration status", err)
 	}
+	pending := pendingVersions(statuses)
 
-	// Show migration status
-	if err := database.GetMigrationStatus(db); err != nil {
-		fmt.Printf("❌ Failed to get migration status: %v\n", err)
-		os.Exit(1)
+	if jsonOutput() {
+		printJSON(map[string]interface{}{
+			"migrations": statuses,
+			"applied":    len(statuses) - len(pending),
+			"pending":    len(pending),
+		})
+	} else if err := database.GetMigrationStatus(db, *verbose); err != nil {
+		fail("Failed to get migration status", err)
+	}
+
+	if len(pending) > 0 {
+		logger.Sync()
+		os.Exit(exitPending)
 	}
 }
 
-func runSeeders(seederName string) {
+// pendingVersions returns the versions of the migrations not applied yet
+func pendingVersions(statuses []migrations.MigrationStatus) []string {
+	pending := []string{}
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, status.Version)
+		}
+	}
+	return pending
+}
 
+// runSeeders runs the seeders, or lists them when the name is "list". With
+// -format=json it prints the seeders run, or listed with their dependencies.
+func runSeeders(seederName string) {
 	if seederName == "list" {
-		fmt.Println("📋 Listing seeders...")
-		// Load config และ database แล้วเรียก ListSeeders
-		cfg := config.Load()
-		if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
-			fmt.Printf("❌ Failed to initialize logger: %v\n", err)
-			os.Exit(1)
+		if !jsonOutput() {
+			fmt.Println("📋 Listing seeders...")
 		}
+		_, db := bootstrap(jsonOutput())
 		defer logger.Sync()
 
-		db, err := database.NewPostgresDB(&cfg.Database)
-		if err != nil {
-			fmt.Printf("❌ Failed to connect to database: %v\n", err)
-			os.Exit(1)
+		if jsonOutput() {
+			plan, err := database.SeederPlan(db, "")
+			if err != nil {
+				fail("Failed to list seeders", err)
+			}
+			var lastRuns map[string]seeders.SeederRecord
+			if *verbose {
+				if lastRuns, err = database.SeederHistory(db); err != nil {
+					fail("Failed to list seeders", err)
+				}
+			}
+			type lastRun struct {
+				RanAt      time.Time `json:"ran_at"`
+				ExecutedBy string    `json:"executed_by"`
+				Host       string    `json:"host"`
+				AppVersion string    `json:"app_version"`
+				DurationMs int64     `json:"duration_ms"`
+			}
+			type listed struct {
+				Name         string   `json:"name"`
+				Dependencies []string `json:"dependencies"`
+				LastRun      *lastRun `json:"last_run,omitempty"`
+			}
+			listedSeeders := make([]listed, 0, len(plan))
+			for _, seeder := range plan {
+				entry := listed{Name: seeder.Name(), Dependencies: append([]string{}, seeder.Dependencies()...)}
+				if run, ok := lastRuns[seeder.Name()]; ok {
+					entry.LastRun = &lastRun{RanAt: run.RanAt, ExecutedBy: run.ExecutedBy, Host: run.Host, AppVersion: run.AppVersion, DurationMs: run.DurationMs}
+				}
+				listedSeeders = append(listedSeeders, entry)
+			}
+			printJSON(map[string]interface{}{"seeders": listedSeeders})
+			return
 		}
 
-		if err := database.ListSeeders(db); err != nil {
-			fmt.Printf("❌ Failed to list seeders: %v\n", err)
-			os.Exit(1)
+		if err := database.ListSeeders(db, *verbose); err != nil {
+			fail("Failed to list seeders", err)
 		}
 		return
 	}
 
-	fmt.Println("🌱 Running seeders...")
-
-	// Load configuration
-	cfg := config.Load()
-
-	// Initialize logger
-	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
-		fmt.Printf("❌ Failed to initialize logger: %v\n", err)
-		os.Exit(1)
+	if !jsonOutput() {
+		fmt.Println("🌱 Running seeders...")
 	}
+
+	_, db := bootstrap(jsonOutput())
 	defer logger.Sync()
 
-	// Initialize database
-	db, err := database.NewPostgresDB(&cfg.Database)
-	if err != nil {
-		fmt.Printf("❌ Failed to connect to database: %v\n", err)
-		os.Exit(1)
+	var seeded []string
+	if jsonOutput() {
+		plan, err := database.SeederPlan(db, seederName)
+		if err != nil {
+			fail("Seeding failed", err)
+		}
+		seeded = make([]string, 0, len(plan))
+		for _, seeder := range plan {
+			seeded = append(seeded, seeder.Name())
+		}
 	}
 
 	// Run seeders
 	if err := database.SeedData(db, seederName); err != nil {
-		fmt.Printf("❌ Seeding failed: %v\n", err)
-		os.Exit(1)
+		fail("Seeding failed", err)
 	}
 
+	if jsonOutput() {
+		printJSON(map[string][]string{"seeded": seeded})
+		return
+	}
 	fmt.Println("✅ Seeding completed successfully")
 }
 
@@ -668,778 +1015,214 @@ func showHelp() {
 	fmt.Println("🎨 Go Clean Gin - Artisan CLI (Laravel Style)")
 	fmt.Println("")
 	fmt.Println("Usage:")
-	fmt.Println("  go run cmd/artisan/main.go -action=<action> [options]")
+	fmt.Println("  go run ./cmd/artisan -action=<action> [options]")
 	fmt.Println("")
 	fmt.Println("Available Actions:")
 	fmt.Println("  make:migration     Create a new migration file")
 	fmt.Println("  make:seeder        Create a new seeder file")
 	fmt.Println("  make:model         Create a new entity model file")
 	fmt.Println("  make:package       Create a new package with handler, usecase, repository, port")
+	fmt.Println("  make:enum          Create a typed string enum (-values=a,b,c)")
+	fmt.Println("  make:request       Create request and response DTOs in internal/<package>/dto.go")
+	fmt.Println("  make:policy        Create an entity policy with CanUpdate/CanDelete checks")
+	fmt.Println("  make:mock          Generate a testify mock for a port.go interface")
+	fmt.Println("  generate:mocks     Generate mocks for all port.go interfaces")
+	fmt.Println("  make:cache         Generate the read-through cache decorator of a port.go interface")
+	fmt.Println("  generate:caches    Generate cache decorators for all port.go interfaces with //cache: methods")
+	fmt.Println("  make:retry         Generate the transient database error retry decorator of a port.go interface")
+	fmt.Println("  generate:retries   Regenerate the retry decorators made with make:retry")
+	fmt.Println("  generate:types     Generate TypeScript interfaces of the entities and DTOs for frontends")
+	fmt.Println("  generate:proto     Generate proto3 messages of the entities and DTOs, with stable field numbers")
+	fmt.Println("  stub:publish       Copy the generator stubs to stubs/ for customizing (-force to overwrite)")
+	fmt.Println("  new                Start a project from this skeleton under a new module path")
 	fmt.Println("  migrate            Run pending migrations")
 	fmt.Println("  migrate:rollback   Rollback migrations")
-	fmt.Println("  migrate:status     Show migration status")
+	fmt.Println("  migrate:status     Show migration status (exits with 3 when migrations are pending)")
+	fmt.Println("  tenants:migrate    Run pending migrations on tenant databases (-name for one tenant)")
 	fmt.Println("  db:seed            Run database seeders")
+	fmt.Println("  route:list         List the HTTP routes with their handlers")
+	fmt.Println("  serve              Build and run the HTTP server (-watch for hot reload)")
+	fmt.Println("  user:create        Create a user (prompts for missing values)")
+	fmt.Println("  db:query           Run a SQL query and print the result")
+	fmt.Println("  db:tables          List database tables")
+	fmt.Println("  db:table           Show the columns of a table")
+	fmt.Println("  db:refresh-views   Refresh the named materialized views, or all of them")
+	fmt.Println("  archive:run        Move old rows of hot tables to their archive (-name for one table)")
+	fmt.Println("  retention:purge    Delete rows past their retention (-name for one rule, -dry-run to count them)")
+	fmt.Println("  schema:docs        Document tables, columns, indexes and foreign keys as Markdown or HTML")
+	fmt.Println("  db:backup          Back up the database with pg_dump")
+	fmt.Println("  db:restore         Restore the database from a backup with pg_restore")
+	fmt.Println("  db:anonymize       Replace personal data with fake data")
+	fmt.Println("  queue:work         Process background jobs")
+	fmt.Println("  schedule:run       Run scheduled tasks (-once to run them once and exit)")
+	fmt.Println("  deploy:notify      Post that a deploy started, finished or failed to the notification channel")
+	fmt.Println("  health             Check server readiness (or -db) and exit non-zero on failure")
+	fmt.Println("  loadtest:seed      Seed the load test user and -count products for the k6 profile")
+	fmt.Println("  products:rebuild-read-model  Rebuild the product listing read model from tb_products")
+	fmt.Println("  crypto:generate-key  Print a new encryption key for ENCRYPTION_KEYS (-name for its ID)")
+	fmt.Println("  crypto:rotate      Re-encrypt encrypted columns with the first of ENCRYPTION_KEYS")
+	fmt.Println("  publicid:backfill  Give public IDs to rows of PUBLIC_IDS entities created without one")
 	fmt.Println("")
 	fmt.Println("Options:")
 	fmt.Println("  -name string       Migration/Seeder/Model/Package name")
 	fmt.Println("  -table string      Table name")
 	fmt.Println("  -create            Create table migration")
-	fmt.Println("  -fields string     Fields (name:string,email:string)")
-	fmt.Println("  -count int         Number of migrations to rollback (default: 1)")
+	fmt.Println("  -crud              Generate CRUD for an existing entity and serve it (make:package)")
+	fmt.Println("  -all               Also generate migration, factory, seeder, CRUD package and routes (make:model)")
+	fmt.Println("  -fields string     Fields (name:string,email:string), modifiers (phone:string:nullable,active:bool:default=true)")
+	fmt.Println("                     and relations (user:belongsTo=User,tags:manyToMany=Tag)")
+	fmt.Println("  -count int         Number of migrations to rollback, or products to seed (default: 1)")
+	fmt.Println("  -values string     Comma-separated enum values (make:enum)")
+	fmt.Println("  -package string    Package of the DTOs (make:request, default: the name without its verb)")
+	fmt.Println("  -message string    Text posted with the deploy stage (deploy:notify)")
+	fmt.Println("  -sql string        SQL file the migration runs (make:migration)")
+	fmt.Println("  -down-sql string   SQL file the migration runs to roll back (make:migration)")
+	fmt.Println("  -view string       Materialized view the migration creates (make:migration)")
+	fmt.Println("  -interface string  Interface to mock, cache or retry (make:mock, make:cache, make:retry)")
+	fmt.Println("  -module string     Module path to import from (make:*, default: go.mod) or of the new project (new)")
+	fmt.Println("  -from string       Skeleton directory or git URL to start from (new, default: this project)")
+	fmt.Println("  -modules string    Optional modules to keep, e.g. reservation,report (new, default: all)")
+	fmt.Println("  -db string         Database of the new project (new, only postgres)")
+	fmt.Println("  -version           Show version information")
+	fmt.Println("  -watch             Rebuild and restart (serve), or regenerate types (generate:types), on file changes")
+	fmt.Println("  -app-port int      Internal app port when watching (default: SERVER_PORT+1)")
+	fmt.Println("  -email string      User email (user:create)")
+	fmt.Println("  -password string   User password (user:create)")
+	fmt.Println("  -role string       User role: user, admin (user:create, default: user)")
+	fmt.Println("  -format string     Output format: table, json (migrate, migrate:status, db:seed, route:list, db:*, default: table), or markdown, html, json (schema:docs)")
+	fmt.Println("  -write             Allow data-modifying statements in db:query")
+	fmt.Println("  -verbose           Show who ran each migration or seeder, from where and how long it took")
+	fmt.Println("  -output string     Backup to a local file instead of storage, or write schema:docs or generate:* to a file")
+	fmt.Println("  -mermaid           Include a Mermaid ER diagram in schema:docs")
+	fmt.Println("  -every duration    Run scheduled backups at this interval (e.g. 24h)")
+	fmt.Println("  -keep int          Number of backups to keep (default: BACKUP_KEEP)")
+	fmt.Println("  -force             Skip confirmation prompts, or overwrite existing files (make:*, stub:publish)")
+	fmt.Println("  -skip-existing     Keep existing files and generate only the missing ones (make:*)")
+	fmt.Println("  -dry-run           Show what make:* would write, with diffs of existing files, and write nothing; count what retention:purge would delete")
+	fmt.Println("  -queue string      Queues to process in priority order (default: QUEUE_DEFAULT)")
+	fmt.Println("  -concurrency int   Number of jobs processed in parallel (default: 1)")
+	fmt.Println("  -timeout duration  Maximum duration of a single job (e.g. 5m)")
+	fmt.Println("  -metrics-addr      Expose Prometheus metrics on this address (e.g. :9100)")
+	fmt.Println("  -once              Run all scheduled tasks once and exit")
+	fmt.Println("  -url string        Readiness URL to check (health)")
+	fmt.Println("  -db                Check the database directly (health)")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  # Create table migration")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:migration -name=create_users_table -create -table=users -fields=\"name:string,email:string\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:migration -name=create_users_table -create -table=users -fields=\"name:string,email:string\"")
 	fmt.Println("")
 	fmt.Println("  # Create entity model")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:model -name=User -fields=\"name:string,email:string,age:int\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:model -name=User -fields=\"name:string,email:string,age:int\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:model -name=Post -table=tb_posts -fields=\"title:string,user:belongsTo=User,tags:manyToMany=Tag\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:model -all -name=Post -table=tb_posts -fields=\"title:string,body:text,user:belongsTo=User\"")
 	fmt.Println("")
 	fmt.Println("  # Create package (handler, usecase, repository, port)")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:package -name=Product")
+	fmt.Println("  go run ./cmd/artisan -action=make:package -name=Product")
+	fmt.Println("  go run ./cmd/artisan -action=make:package -crud -name=Post -fields=\"title:string,body:text\"")
+	fmt.Println("")
+	fmt.Println("  # Create a typed enum")
+	fmt.Println("  go run ./cmd/artisan make:enum -name=OrderStatus -values=pending,paid,shipped")
+	fmt.Println("")
+	fmt.Println("  # Create request/response DTOs in internal/order/dto.go")
+	fmt.Println("  go run ./cmd/artisan make:request -name=CreateOrder -fields=\"customer_id:uuid,note:text:nullable\"")
+	fmt.Println("")
+	fmt.Println("  # Create a policy letting the user in user_id change a post")
+	fmt.Println("  go run ./cmd/artisan make:policy -name=Post -fields=\"user:belongsTo=User\"")
+	fmt.Println("")
+	fmt.Println("  # Generate testify mocks from port.go interfaces")
+	fmt.Println("  go run ./cmd/artisan make:mock -interface=ProductRepository")
+	fmt.Println("  go run ./cmd/artisan generate:mocks")
+	fmt.Println("")
+	fmt.Println("  # Cache the //cache:read methods of a repository")
+	fmt.Println("  go run ./cmd/artisan make:cache -interface=PostRepository")
+	fmt.Println("")
+	fmt.Println("  # Retry a repository's calls on deadlocks and serialization failures")
+	fmt.Println("  go run ./cmd/artisan make:retry -interface=PostRepository")
+	fmt.Println("")
+	fmt.Println("  # TypeScript types for the frontend, kept in step while developing")
+	fmt.Println("  go run ./cmd/artisan generate:types -output=../frontend/src/api.ts -watch")
+	fmt.Println("")
+	fmt.Println("  # Protobuf messages for the gRPC surface")
+	fmt.Println("  go run ./cmd/artisan generate:proto")
+	fmt.Println("")
+	fmt.Println("  # Customize generated code")
+	fmt.Println("  go run ./cmd/artisan stub:publish")
+	fmt.Println("")
+	fmt.Println("  # Start a new project from this skeleton")
+	fmt.Println("  go run ./cmd/artisan new ../shop -module=github.com/acme/shop")
+	fmt.Println("  go run ./cmd/artisan new ../shop -modules=auth,product,reservation -db=postgres")
 	fmt.Println("")
 	fmt.Println("  # Add column migration")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:migration -name=add_phone_to_users -table=users -fields=\"phone:string\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:migration -name=add_phone_to_users -table=users -fields=\"phone:string\"")
+	fmt.Println("")
+	fmt.Println("  # Migration of raw SQL files")
+	fmt.Println("  go run ./cmd/artisan -action=make:migration -name=add_sales_views -sql=up.sql -down-sql=down.sql")
+	fmt.Println("  go run ./cmd/artisan -action=make:migration -name=create_sales_per_day_view -view=mv_sales_per_day")
 	fmt.Println("")
 	fmt.Println("  # Run migrations")
-	fmt.Println("  go run cmd/artisan/main.go -action=migrate")
+	fmt.Println("  go run ./cmd/artisan -action=migrate")
+	fmt.Println("")
+	fmt.Println("  # Migration status as JSON, exiting with 3 when migrations are pending")
+	fmt.Println("  go run ./cmd/artisan migrate:status -format=json")
+	fmt.Println("")
+	fmt.Println("  # Who applied each migration, from which host and build")
+	fmt.Println("  go run ./cmd/artisan migrate:status -verbose")
+	fmt.Println("")
+	fmt.Println("  # Tell the team a deploy is rolling out")
+	fmt.Println("  go run ./cmd/artisan deploy:notify started -message=\"Rolling out to eu-west\"")
+	fmt.Println("")
+	fmt.Println("  # List routes")
+	fmt.Println("  go run ./cmd/artisan route:list")
+	fmt.Println("")
+	fmt.Println("  # Migrate the databases of tenants in TENANT_DATABASES_FILE")
+	fmt.Println("  go run ./cmd/artisan tenants:migrate")
 	fmt.Println("")
 	fmt.Println("  # Rollback last 2 migrations")
-	fmt.Println("  go run cmd/artisan/main.go -action=migrate:rollback -count=2")
+	fmt.Println("  go run ./cmd/artisan -action=migrate:rollback -count=2")
 	fmt.Println("")
 	fmt.Println("  # Create seeder")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:seeder -name=UserSeeder -table=users")
+	fmt.Println("  go run ./cmd/artisan -action=make:seeder -name=UserSeeder -table=users")
 	fmt.Println("")
 	fmt.Println("  # Create seeder with dependencies")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:seeder -name=ProductSeeder -table=products -deps=\"UserSeeder\"")
-	fmt.Println("  go run cmd/artisan/main.go -action=make:seeder -name=OrderSeeder -table=orders -deps=\"UserSeeder,ProductSeeder\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:seeder -name=ProductSeeder -table=products -deps=\"UserSeeder\"")
+	fmt.Println("  go run ./cmd/artisan -action=make:seeder -name=OrderSeeder -table=orders -deps=\"UserSeeder,ProductSeeder\"")
 	fmt.Println("")
 	fmt.Println("  # List all seeders")
-	fmt.Println("  go run cmd/artisan/main.go -action=db:seed -name=list")
-}
-
-// Helper types and functions
-type MigrationData struct {
-	ClassName   string
-	TableName   string
-	Timestamp   string
-	Description string
-	Fields      []Field
-	Version     string
-}
-
-type Field struct {
-	ClassName    string
-	Name         string
-	Type         string
-	HasIndex     bool
-	IsForeignKey bool
-	FKReference  string // table name that reference
-}
-
-type SeederData struct {
-	ClassName    string
-	TableName    string
-	Dependencies []string // add this field
-}
-
-type EntityData struct {
-	EntityName string
-	TableName  string
-	Fields     []Field
-}
-
-type PackageData struct {
-	PackageName string
-	EntityName  string
-}
-
-func parseFields(fieldList string) []Field {
-	var parsedFields []Field
-	if fieldList == "" {
-		return parsedFields
-	}
-
-	fieldPairs := strings.Split(fieldList, ",")
-
-	for _, pair := range fieldPairs {
-		// split field_name:type|options - use SplitN to split only the first ":"
-		mainParts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
-		if len(mainParts) < 2 {
-			continue
-		}
-
-		fieldName := strings.TrimSpace(mainParts[0])
-		typeAndOptions := strings.TrimSpace(mainParts[1])
-
-		// split type and options (type|index or type|fk:table)
-		typeParts := strings.Split(typeAndOptions, "|")
-		fieldType := strings.TrimSpace(typeParts[0])
-
-		field := Field{
-			Name:         fieldName,
-			Type:         fieldType,
-			HasIndex:     false,
-			IsForeignKey: false,
-			FKReference:  "",
-		}
-
-		// check options
-		if len(typeParts) > 1 {
-			for i := 1; i < len(typeParts); i++ {
-				option := strings.TrimSpace(typeParts[i])
-
-				if option == "index" {
-					field.HasIndex = true
-				} else if strings.HasPrefix(option, "fk:") {
-					field.IsForeignKey = true
-					field.FKReference = strings.TrimPrefix(option, "fk:")
-				}
-			}
-		}
-
-		parsedFields = append(parsedFields, field)
-	}
-
-	return parsedFields
-}
-
-// Template functions
-var templateFuncs = template.FuncMap{
-	"toSQLType":        toSQLType,
-	"toGoType":         toGoType,
-	"toPascalCase":     toPascalCase,
-	"getGormTag":       getGormTag,
-	"getValidationTag": getValidationTag,
-	"hasDecimalField":  hasDecimalField,
-	"getStructName":    getStructName,
-	"hasIndexField":    hasIndexField,
-	"hasFKField":       hasFKField,
-	"toLowerFirst":     toLowerFirst,
-}
-
-func toPascalCase(s string) string {
-	words := strings.FieldsFunc(s, func(c rune) bool {
-		return c == '_' || c == '-' || c == ' '
-	})
-
-	caser := cases.Title(language.English)
-	for i, word := range words {
-		words[i] = caser.String(strings.ToLower(word))
-	}
-
-	return strings.Join(words, "")
-}
-
-func toSnakeCase(s string) string {
-	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && 'A' <= r && r <= 'Z' {
-			result.WriteRune('_')
-		}
-		result.WriteRune(r)
-	}
-	return strings.ToLower(result.String())
-}
-
-func toSQLType(goType string) string {
-	switch strings.ToLower(goType) {
-	case "string":
-		return "VARCHAR(255)"
-	case "text":
-		return "TEXT"
-	case "int", "integer":
-		return "INTEGER"
-	case "int64", "bigint":
-		return "BIGINT"
-	case "float", "float64":
-		return "DOUBLE PRECISION"
-	case "decimal":
-		return "DECIMAL(10,2)"
-	case "bool", "boolean":
-		return "BOOLEAN"
-	case "uuid":
-		return "UUID"
-	case "timestamp", "time":
-		return "TIMESTAMP WITH TIME ZONE"
-	case "date":
-		return "DATE"
-	case "json", "jsonb":
-		return "JSONB"
-	default:
-		return "VARCHAR(255)"
-	}
-}
-
-func toGoType(fieldType string) string {
-	switch strings.ToLower(fieldType) {
-	case "string":
-		return "string"
-	case "text":
-		return "string"
-	case "int", "integer":
-		return "int"
-	case "int64", "bigint":
-		return "int64"
-	case "float", "float64":
-		return "float64"
-	case "decimal":
-		return "decimal.Decimal"
-	case "bool", "boolean":
-		return "bool"
-	case "uuid":
-		return "uuid.UUID"
-	case "timestamp", "time":
-		return "time.Time"
-	case "date":
-		return "time.Time"
-	case "json", "jsonb":
-		return "map[string]interface{}"
-	default:
-		return "string"
-	}
-}
-
-func getGormTag(field Field) string {
-	tags := []string{}
-
-	// Basic type tags
-	switch strings.ToLower(field.Type) {
-	case "string":
-		tags = append(tags, "not null")
-	case "text":
-		tags = append(tags, "type:text")
-	case "int", "integer":
-		tags = append(tags, "not null")
-	case "int64", "bigint":
-		tags = append(tags, "type:bigint", "not null")
-	case "float", "float64":
-		tags = append(tags, "type:double precision", "not null")
-	case "decimal":
-		tags = append(tags, "type:decimal(10,2)", "not null")
-	case "bool", "boolean":
-		tags = append(tags, "default:false")
-	case "uuid":
-		tags = append(tags, "type:uuid", "not null")
-	case "timestamp", "time":
-		tags = append(tags, "type:timestamp with time zone")
-	case "date":
-		tags = append(tags, "type:date")
-	case "json", "jsonb":
-		tags = append(tags, "type:jsonb", "default:'{}'")
-	default:
-		tags = append(tags, "not null")
-	}
-
-	// Add index tag
-	if field.HasIndex || field.IsForeignKey {
-		tags = append(tags, "index")
-	}
-
-	// Add foreign key constraint
-	if field.IsForeignKey {
-		tags = append(tags, "constraint:OnUpdate:CASCADE,OnDelete:SET NULL")
-	}
-
-	return strings.Join(tags, ";")
-}
-
-func getValidationTag(fieldType string) string {
-	switch strings.ToLower(fieldType) {
-	case "string":
-		return "required,min=1,max=255"
-	case "text":
-		return "required"
-	case "int", "integer":
-		return "required,min=0"
-	case "int64", "bigint":
-		return "required,min=0"
-	case "float", "float64":
-		return "required,min=0"
-	case "decimal":
-		return "required,min=0"
-	case "bool", "boolean":
-		return ""
-	case "uuid":
-		return "required"
-	case "timestamp", "time":
-		return ""
-	case "date":
-		return ""
-	case "json", "jsonb":
-		return ""
-	default:
-		return "required"
-	}
-}
-
-func hasDecimalField(fields []Field) bool {
-	for _, field := range fields {
-		if strings.ToLower(field.Type) == "decimal" {
-			return true
-		}
-	}
-	return false
-}
-
-func getStructName(tableName string) string {
-	// if table name start with tb_ then remove it
-	tableName = strings.TrimPrefix(tableName, "tb_")
-
-	tableName = singularize(tableName)
-
-	// convert to PascalCase
-	return toPascalCase(tableName)
-}
-
-// singularize convert plural to singular (simple format)
-func singularize(word string) string {
-	// basic rules for English pluralization
-	if strings.HasSuffix(word, "ies") {
-		// categories -> category, companies -> company
-		return strings.TrimSuffix(word, "ies") + "y"
-	}
-	if strings.HasSuffix(word, "es") && len(word) > 2 {
-		// boxes -> box, dishes -> dish
-		return strings.TrimSuffix(word, "es")
-	}
-	if strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
-		// users -> user, products -> product (but not address -> addres)
-		return strings.TrimSuffix(word, "s")
-	}
-
-	// if not match any rule then return original word
-	return word
-}
-
-func hasIndexField(fields []Field) bool {
-	for _, field := range fields {
-		if field.HasIndex {
-			return true
-		}
-	}
-	return false
-}
-
-func hasFKField(fields []Field) bool {
-	for _, field := range fields {
-		if field.IsForeignKey {
-			return true
-		}
-	}
-	return false
-}
-
-func toLowerFirst(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	return strings.ToLower(s[:1]) + s[1:]
-}
-
-// Templates
-const migrationTemplate = `package migrations
-
-import (
-	"gorm.io/gorm"
-)
-
-// {{.ClassName}} migration
-type {{.ClassName}} struct{}
-
-// Up runs the migration
-func (m *{{.ClassName}}) Up(db *gorm.DB) error {
-	// TODO: Implement your migration logic here
-	return nil
-}
-
-// Down rolls back the migration  
-func (m *{{.ClassName}}) Down(db *gorm.DB) error {
-	// TODO: Implement your rollback logic here
-	return nil
-}
-
-// Description returns migration description
-func (m *{{.ClassName}}) Description() string {
-	return "{{.Description}}"
-}
-
-// Version returns migration version
-func (m *{{.ClassName}}) Version() string {
-	return "{{.Version}}"
-}
-
-// Auto-register migration
-func init() {
-	Register(&{{.ClassName}}{})
-}
-`
-
-const createTableTemplate = `package migrations
-
-import (
-	"time"
-
-	"github.com/google/uuid"
-	"gorm.io/gorm"
-	{{- if hasDecimalField .Fields}}
-	"github.com/shopspring/decimal"
-	{{- end}}
-)
-
-// {{getStructName .TableName}} entity struct for migration
-type {{getStructName .TableName}} struct {
-	ID        uuid.UUID      ` + "`json:\"id\" gorm:\"type:uuid;primary_key;default:gen_random_uuid()\"`" + `
-	{{- range .Fields}}
-	{{toPascalCase .Name}} {{toGoType .Type}} ` + "`json:\"{{.Name}}\" gorm:\"{{getGormTag .}}\" validate:\"{{getValidationTag .Type}}\"`" + `
-	{{- end}}
-	{{- range .Fields}}
-	{{- if .IsForeignKey}}
-	{{getStructName .FKReference}} {{getStructName .FKReference}} ` + "`json:\"{{getStructName .FKReference | toLowerFirst}},omitempty\" gorm:\"foreignKey:{{toPascalCase .Name}};references:ID\"`" + `
-	{{- end}}
-	{{- end}}
-	CreatedAt time.Time      ` + "`json:\"created_at\"`" + `
-	UpdatedAt time.Time      ` + "`json:\"updated_at\"`" + `
-	DeletedAt gorm.DeletedAt ` + "`json:\"-\" gorm:\"index\"`" + `
-}
-
-// TableName returns the table name for GORM
-func ({{getStructName .TableName}}) TableName() string {
-	return "{{.TableName}}"
-}
-
-// {{.ClassName}} migration - Create {{.TableName}} table
-type {{.ClassName}} struct{}
-
-// Up creates the {{.TableName}} table using the {{getStructName .TableName}} struct
-func (m *{{.ClassName}}) Up(db *gorm.DB) error {
-	return db.AutoMigrate(&{{getStructName .TableName}}{})
-}
-
-// Down drops the {{.TableName}} table
-func (m *{{.ClassName}}) Down(db *gorm.DB) error {
-	return db.Migrator().DropTable(&{{getStructName .TableName}}{})
-}
-
-// Description returns migration description
-func (m *{{.ClassName}}) Description() string {
-	return "Create {{.TableName}} table"
-}
-
-// Version returns migration version
-func (m *{{.ClassName}}) Version() string {
-	return "{{.Version}}"
-}
-
-// Auto-register migration
-func init() {
-	Register(&{{.ClassName}}{})
-}
-`
-
-const alterTableTemplate = `package migrations
-
-import (
-	"gorm.io/gorm"
-	{{- if hasDecimalField .Fields}}
-	"github.com/shopspring/decimal"
-	{{- end}}
-)
-
-// {{.ClassName}} migration - Modify {{.TableName}} table
-type {{.ClassName}} struct{}
-
-{{- range .Fields}}
-// {{.ClassName}}{{toPascalCase .Name}} represents the new column structure
-type {{$.ClassName}}{{toPascalCase .Name}} struct {
-	{{toPascalCase .Name}} {{toGoType .Type}} ` + "`gorm:\"{{getGormTag .}}\"`" + `
-}
-
-func ({{$.ClassName}}{{toPascalCase .Name}}) TableName() string {
-	return "{{$.TableName}}"
-}
-{{- end}}
-
-// Up adds columns to the {{.TableName}} table
-func (m *{{.ClassName}}) Up(db *gorm.DB) error {
-	{{- range .Fields}}
-	// Add {{.Name}} column
-	if err := db.Migrator().AddColumn(&{{$.ClassName}}{{toPascalCase .Name}}{}, "{{.Name}}"); err != nil {
-		return err
-	}
-	{{- end}}
-	
-	return nil
-}
-
-// Down removes columns from the {{.TableName}} table
-func (m *{{.ClassName}}) Down(db *gorm.DB) error {
-	{{- range .Fields}}
-	// Drop {{.Name}} column
-	if err := db.Migrator().DropColumn(&{{$.ClassName}}{{toPascalCase .Name}}{}, "{{.Name}}"); err != nil {
-		return err
-	}
-	{{- end}}
-	
-	return nil
-}
-
-// Description returns migration description
-func (m *{{.ClassName}}) Description() string {
-	return "{{.Description}}"
-}
-
-// Version returns migration version
-func (m *{{.ClassName}}) Version() string {
-	return "{{.Version}}"
-}
-
-// Auto-register migration
-func init() {
-	Register(&{{.ClassName}}{})
-}
-`
-
-const seederTemplate = `package seeders
-
-import (
-	"gorm.io/gorm"
-	"go-clean-gin/internal/entity"
-	"go-clean-gin/pkg/logger"
-	"go.uber.org/zap"
-)
-
-// {{.ClassName}} seeds the {{.TableName}} table
-type {{.ClassName}} struct{}
-
-// Run executes the seeder
-func (s *{{.ClassName}}) Run(db *gorm.DB) error {
-	logger.Info("Running {{.ClassName}}...")
-
-	// Check if data already exists
-	{{- if .TableName}}
-	var count int64
-	if err := db.Raw("SELECT COUNT(*) FROM {{.TableName}}").Scan(&count).Error; err != nil {
-		return err
-	}
-
-	if count > 0 {
-		logger.Info("{{.TableName}} already exist, skipping {{.ClassName}}")
-		return nil
-	}
-	{{- end}}
-
-	// TODO: Implement your seeding logic here
-	// Example:
-	{{- if .Dependencies}}
-	//
-	// This seeder depends on: {{range $i, $dep := .Dependencies}}{{if $i}}, {{end}}{{$dep}}{{end}}
-	// You can safely reference data created by those seeders
-	//
-	{{- end}}
-	// data := []entity.Model{
-	//     {Field1: "value1", Field2: "value2"},
-	//     {Field1: "value3", Field2: "value4"},
-	// }
-	//
-	// return db.Create(&data).Error
-
-	logger.Info("{{.ClassName}} completed successfully")
-	return nil
-}
-
-// Name returns seeder name
-func (s *{{.ClassName}}) Name() string {
-	return "{{.ClassName}}"
-}
-
-// Dependencies returns list of seeders that must run before this seeder
-func (s *{{.ClassName}}) Dependencies() []string {
-	{{- if .Dependencies}}
-	return []string{
-		{{- range .Dependencies}}
-		"{{.}}",
-		{{- end}}
-	}
-	{{- else}}
-	return []string{} // No dependencies
-	{{- end}}
-}
-
-// Auto-register seeder
-func init() {
-	Register(&{{.ClassName}}{})
-}
-`
-
-// Fix entityTemplate - add association fields like createTableTemplate
-const entityTemplate = `package entity
-
-import (
-	"time"
-
-	"github.com/google/uuid"
-	{{- if hasDecimalField .Fields}}
-	"github.com/shopspring/decimal"
-	{{- end}}
-	"gorm.io/gorm"
-)
-
-// {{.EntityName}} represents a {{.EntityName}} entity
-type {{.EntityName}} struct {
-	ID        uuid.UUID      ` + "`json:\"id\" gorm:\"type:uuid;primary_key;default:gen_random_uuid()\"`" + `
-	{{- range .Fields}}
-	{{toPascalCase .Name}} {{toGoType .Type}} ` + "`json:\"{{.Name}}\" gorm:\"{{getGormTag .}}\" validate:\"{{getValidationTag .Type}}\"`" + `
-	{{- end}}
-	{{- range .Fields}}
-	{{- if .IsForeignKey}}
-	{{getStructName .FKReference}} {{getStructName .FKReference}} ` + "`json:\"{{getStructName .FKReference | toLowerFirst}},omitempty\" gorm:\"foreignKey:{{toPascalCase .Name}};references:ID\"`" + `
-	{{- end}}
-	{{- end}}
-	CreatedAt time.Time      ` + "`json:\"created_at\"`" + `
-	UpdatedAt time.Time      ` + "`json:\"updated_at\"`" + `
-	DeletedAt gorm.DeletedAt ` + "`json:\"-\" gorm:\"index\"`" + `
-}
-
-// TableName returns the table name for GORM
-func ({{.EntityName}}) TableName() string {
-	return "{{.TableName}}"
-}
-
-// Create{{.EntityName}}Request represents a request to create a {{.EntityName}}
-type Create{{.EntityName}}Request struct {
-	{{- range .Fields}}
-	{{toPascalCase .Name}} {{toGoType .Type}} ` + "`json:\"{{.Name}}\" validate:\"{{getValidationTag .Type}}\"`" + `
-	{{- end}}
-}
-
-// Update{{.EntityName}}Request represents a request to update a {{.EntityName}}
-type Update{{.EntityName}}Request struct {
-	{{- range .Fields}}
-	{{toPascalCase .Name}} *{{toGoType .Type}} ` + "`json:\"{{.Name}},omitempty\" validate:\"omitempty,{{getValidationTag .Type}}\"`" + `
-	{{- end}}
-}
-
-// {{.EntityName}}Filter represents filters for {{.EntityName}} queries
-type {{.EntityName}}Filter struct {
-	{{- range .Fields}}
-	{{- if eq .Type "string"}}
-	{{toPascalCase .Name}} string ` + "`form:\"{{.Name}}\"`" + `
-	{{- end}}
-	{{- end}}
-	Search string ` + "`form:\"search\"`" + `
-	Page   int    ` + "`form:\"page\" validate:\"min=1\"`" + `
-	Limit  int    ` + "`form:\"limit\" validate:\"min=1,max=100\"`" + `
-}
-
-
-`
-
-// Package templates - Simple structure without CRUD
-const handlerTemplate = `package {{.PackageName}}
-
-import (
-	"go-clean-gin/pkg/errors"
-	"go-clean-gin/pkg/logger"
-	"go-clean-gin/pkg/response"
-	"go-clean-gin/pkg/validator"
-
-	"github.com/gin-gonic/gin"
-	"go.uber.org/zap"
-)
-
-type {{.EntityName}}Handler struct {
-	usecase {{.EntityName}}Usecase
-}
-
-func New{{.EntityName}}Handler(usecase {{.EntityName}}Usecase) *{{.EntityName}}Handler {
-	return &{{.EntityName}}Handler{
-		usecase: usecase,
-	}
-}
-
-// TODO: Add your handler methods here
-// Example:
-// func (h *{{.EntityName}}Handler) SomeMethod(c *gin.Context) {
-//     // Implementation here
-// }
-`
-
-const portTemplate = `package {{.PackageName}}
-
-import (
-	"context"
-)
-
-// {{.EntityName}}Usecase defines the business logic interface for {{.PackageName}}
-type {{.EntityName}}Usecase interface {
-	// TODO: Add your usecase methods here
-	// Example:
-	// SomeMethod(ctx context.Context) error
-}
-
-// {{.EntityName}}Repository defines the data access interface for {{.PackageName}}
-type {{.EntityName}}Repository interface {
-	// TODO: Add your repository methods here
-	// Example:
-	// SomeMethod(ctx context.Context) error
-}
-`
-
-const repositoryTemplate = `package {{.PackageName}}
-
-import (
-	"context"
-
-	"gorm.io/gorm"
-)
-
-type {{.PackageName}}Repository struct {
-	db *gorm.DB
-}
-
-func New{{.EntityName}}Repository(db *gorm.DB) {{.EntityName}}Repository {
-	return &{{.PackageName}}Repository{
-		db: db,
-	}
-}
-
-// TODO: Add your repository methods here
-// Example:
-// func (r *{{.PackageName}}Repository) SomeMethod(ctx context.Context) error {
-//     return r.db.WithContext(ctx).Error
-// }
-`
-
-const usecaseTemplate = `package {{.PackageName}}
-
-import (
-	"context"
-	"go-clean-gin/pkg/errors"
-	"go-clean-gin/pkg/logger"
-
-	"go.uber.org/zap"
-)
-
-type {{.PackageName}}Usecase struct {
-	repo {{.EntityName}}Repository
-}
-
-func New{{.EntityName}}Usecase(repo {{.EntityName}}Repository) {{.EntityName}}Usecase {
-	return &{{.PackageName}}Usecase{
-		repo: repo,
-	}
+	fmt.Println("  go run ./cmd/artisan -action=db:seed -name=list")
+	fmt.Println("")
+	fmt.Println("  # Dev server with hot reload")
+	fmt.Println("  go run ./cmd/artisan serve -watch")
+	fmt.Println("")
+	fmt.Println("  # Bootstrap an admin user")
+	fmt.Println("  go run ./cmd/artisan user:create -email=admin@example.com -role=admin")
+	fmt.Println("")
+	fmt.Println("  # Inspect the database")
+	fmt.Println("  go run ./cmd/artisan db:tables")
+	fmt.Println("  go run ./cmd/artisan db:table users")
+	fmt.Println("  go run ./cmd/artisan db:query \"SELECT email, role FROM tb_users\" -format=json")
+	fmt.Println("  go run ./cmd/artisan db:refresh-views mv_products_by_category")
+	fmt.Println("")
+	fmt.Println("  # Document the schema with an ER diagram")
+	fmt.Println("  go run ./cmd/artisan schema:docs -mermaid -output=docs/schema.md")
+	fmt.Println("")
+	fmt.Println("  # Backup and restore")
+	fmt.Println("  go run ./cmd/artisan db:backup")
+	fmt.Println("  go run ./cmd/artisan db:backup -every=24h -keep=7")
+	fmt.Println("  go run ./cmd/artisan db:restore backups/go_clean_gin_2024_01_15_120000.dump")
+	fmt.Println("")
+	fmt.Println("  # Anonymize a restored production dump")
+	fmt.Println("  go run ./cmd/artisan db:anonymize")
+	fmt.Println("  go run ./cmd/artisan db:anonymize -name=list")
+	fmt.Println("")
+	fmt.Println("  # Background processing")
+	fmt.Println("  go run ./cmd/artisan queue:work -queue=high,default -concurrency=4 -metrics-addr=:9100")
+	fmt.Println("  go run ./cmd/artisan schedule:run -metrics-addr=:9101")
+	fmt.Println("  go run ./cmd/artisan schedule:run -name=list")
+	fmt.Println("")
+	fmt.Println("  # Container health probe")
+	fmt.Println("  ./artisan health")
+	fmt.Println("  ./artisan health -db -timeout=5s")
+	fmt.Println("")
+	fmt.Println("  # Prepare a database for the k6 load profile")
+	fmt.Println("  go run ./cmd/artisan loadtest:seed -count=10000")
 }
-
-// TODO: Add your usecase methods here
-// Example:
-// func (u *{{.PackageName}}Usecase) SomeMethod(ctx context.Context) error {
-//     logger.Info("Executing SomeMethod for {{.PackageName}}")
-//     
-//     if err := u.repo.SomeMethod(ctx); err != nil {
-//         logger.Error("Failed to execute SomeMethod", zap.Error(err))
-//         return errors.Wrap(err, errors.ErrInternal, "Failed to execute SomeMethod", 500)
-//     }
-//     
-//     return nil
-// }
-`
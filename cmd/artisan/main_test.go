@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseCommandLine parses args as the artisan options, restoring the
+// defaults when the test ends
+func parseCommandLine(t *testing.T, args ...string) {
+	t.Helper()
+
+	defaults := map[string]string{}
+	flag.VisitAll(func(f *flag.Flag) {
+		if !strings.HasPrefix(f.Name, "test.") {
+			defaults[f.Name] = f.Value.String()
+		}
+	})
+	t.Cleanup(func() {
+		for name, value := range defaults {
+			flag.Set(name, value)
+		}
+		flag.CommandLine.Parse(nil)
+	})
+
+	require.NoError(t, flag.CommandLine.Parse(args))
+}
+
+func TestArgOrName_OptionsAfterArgument(t *testing.T) {
+	tests := map[string][]string{
+		"before":  {"-format=json", "-write", "DELETE FROM tb_users"},
+		"after":   {"DELETE FROM tb_users", "-format=json", "-write"},
+		"between": {"-format=json", "DELETE FROM tb_users", "-write"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			parseCommandLine(t, args...)
+
+			assert.Equal(t, "DELETE FROM tb_users", argOrName())
+			assert.Equal(t, "json", *format)
+			assert.True(t, *write)
+		})
+	}
+}
+
+func TestArgOrName_RestoreForce(t *testing.T) {
+	parseCommandLine(t, "backup.sql", "-force")
+
+	assert.Equal(t, "backup.sql", argOrName())
+	assert.True(t, *force)
+}
+
+func TestArgOrName_FallsBackToName(t *testing.T) {
+	parseCommandLine(t, "-name=users", "-format=json")
+
+	assert.Equal(t, "users", argOrName())
+	assert.Equal(t, "json", *format)
+}
+
+func TestPositionalArgs_OptionsBetweenArguments(t *testing.T) {
+	parseCommandLine(t, "product_stats", "-format=json", "order_totals")
+
+	assert.Equal(t, []string{"product_stats", "order_totals"}, positionalArgs())
+	assert.Equal(t, "json", *format)
+}
@@ -0,0 +1,321 @@
+// cmd/artisan/new.go - Start a new project from the skeleton
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go-clean-gin/internal/generator"
+	"go-clean-gin/pkg/crypto"
+)
+
+// newProject copies the skeleton into dir under a new module path, which
+// defaults to the directory's name. The skeleton is the project artisan runs
+// in, or source: another directory or a git URL to clone. Optional modules
+// not in modules are left out, along with their migrations; empty keeps all.
+func newProject(dir, source, module, modules, db string) {
+	if module == "" {
+		module = filepath.Base(filepath.Clean(dir))
+	}
+	if err := generator.CheckModulePath(module); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if db != "postgres" {
+		fmt.Printf("❌ Unsupported database %q, only postgres is supported\n", db)
+		os.Exit(1)
+	}
+
+	var selected []string
+	if modules != "" {
+		selected = strings.Split(modules, ",")
+	}
+	kept, err := generator.ResolveModules(selected)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("💡 Available modules:")
+		for _, m := range generator.Modules {
+			fmt.Printf("   %-14s %s\n", m.Name, m.Description)
+		}
+		os.Exit(1)
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		fmt.Printf("❌ %s already exists and is not empty\n", dir)
+		os.Exit(1)
+	}
+
+	if isGitURL(source) {
+		fmt.Printf("📥 Cloning %s...\n", source)
+		if err := cloneSkeleton(source, dir); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		if source == "" {
+			root, err := findProjectRoot()
+			if err != nil {
+				fmt.Printf("❌ %v; or pass -from with the skeleton to copy\n", err)
+				os.Exit(1)
+			}
+			source = root
+		}
+		fmt.Printf("📂 Copying %s...\n", source)
+		if err := copySkeleton(source, dir); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	from := readModulePathFrom(filepath.Join(dir, "go.mod"))
+	if from == "" {
+		fmt.Printf("❌ %s has no go.mod with a module path; is it the skeleton?\n", source)
+		os.Exit(1)
+	}
+
+	left, err := selectModules(dir, kept)
+	if err != nil {
+		fmt.Printf("❌ Failed to leave out modules: %v\n", err)
+		os.Exit(1)
+	}
+
+	rewritten, err := renameModule(dir, from, module)
+	if err != nil {
+		fmt.Printf("❌ Failed to rename module: %v\n", err)
+		os.Exit(1)
+	}
+
+	envWritten, err := writeEnv(dir, module)
+	if err != nil {
+		fmt.Printf("❌ Failed to write .env: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Project created: %s\n", dir)
+	fmt.Printf("📦 Module: %s (was %s, %d files updated)\n", module, from, rewritten)
+	fmt.Printf("🧩 Modules: %s\n", strings.Join(append(append([]string{}, generator.CoreModules...), kept...), ", "))
+	if len(left) > 0 {
+		fmt.Printf("✂️  Left out: %s\n", strings.Join(left, ", "))
+	}
+	if envWritten {
+		fmt.Println("🔑 .env written with a new JWT secret and encryption key")
+	}
+	fmt.Println("💡 Next steps:")
+	fmt.Printf("   cd %s\n", dir)
+	fmt.Println("   go mod tidy && git init")
+	if len(left) > 0 {
+		fmt.Println("   make swagger   # the API docs still list the routes left out")
+	}
+	fmt.Println("   go run ./cmd/artisan migrate")
+}
+
+// selectModules deletes the paths of the optional modules not kept and strips
+// their code from the Go sources, along with the markers of the kept ones. It
+// returns the modules left out.
+func selectModules(dir string, kept []string) ([]string, error) {
+	keep := map[string]bool{}
+	for _, name := range kept {
+		keep[name] = true
+	}
+
+	var left []string
+	for _, module := range generator.Modules {
+		if keep[module.Name] {
+			continue
+		}
+		left = append(left, module.Name)
+		for _, path := range module.Paths {
+			if err := os.RemoveAll(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		stripped, err := generator.StripModules(content, func(name string) bool { return keep[name] })
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if bytes.Equal(stripped, content) {
+			return nil
+		}
+		return os.WriteFile(path, stripped, 0644)
+	})
+	return left, err
+}
+
+var envLine = regexp.MustCompile(`(?m)^(DB_NAME|JWT_SECRET|SIGNED_URL_SECRET|ENCRYPTION_KEYS)=.*$`)
+
+// writeEnv creates .env from .env.example with a database named after the
+// project and fresh secrets, unless the skeleton has no .env.example or the
+// project already has a .env. It reports whether it wrote one.
+func writeEnv(dir, module string) (bool, error) {
+	example, err := os.ReadFile(filepath.Join(dir, ".env.example"))
+	if err != nil {
+		return false, nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".env")); err == nil {
+		return false, nil
+	}
+
+	secret, urlSecret := make([]byte, 32), make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return false, err
+	}
+	if _, err := rand.Read(urlSecret); err != nil {
+		return false, err
+	}
+	key, err := crypto.GenerateKey("k" + time.Now().UTC().Format("20060102"))
+	if err != nil {
+		return false, err
+	}
+	values := map[string]string{
+		"DB_NAME":           generator.ToSnakeCase(strings.NewReplacer("-", "_", ".", "_").Replace(filepath.Base(module))),
+		"JWT_SECRET":        hex.EncodeToString(secret),
+		"SIGNED_URL_SECRET": hex.EncodeToString(urlSecret),
+		"ENCRYPTION_KEYS":   key,
+	}
+
+	env := envLine.ReplaceAllStringFunc(string(example), func(line string) string {
+		name := line[:strings.Index(line, "=")]
+		return name + "=" + values[name]
+	})
+	return true, os.WriteFile(filepath.Join(dir, ".env"), []byte(env), 0600)
+}
+
+func isGitURL(source string) bool {
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@") || strings.HasSuffix(source, ".git")
+}
+
+// cloneSkeleton clones the latest commit of a repository, without its history
+func cloneSkeleton(url, dir string) error {
+	cmd := exec.Command("git", "clone", "--depth", "1", url, dir)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+	return os.RemoveAll(filepath.Join(dir, ".git"))
+}
+
+// copySkeleton copies the files git tracks in src, so local files such as .env
+// and build output stay behind. Outside a repository every file but .git is
+// copied.
+func copySkeleton(src, dst string) error {
+	files, err := trackedFiles(src)
+	if err != nil {
+		files, err = allFiles(src, dst)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(src, file))
+		if err != nil || !info.Mode().IsRegular() {
+			// Deleted in the working tree but not yet committed
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(src, file))
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, file)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(target, content, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func trackedFiles(src string) ([]string, error) {
+	out, err := exec.Command("git", "-C", src, "ls-files", "-z").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range strings.Split(string(out), "\x00") {
+		if file != "" {
+			files = append(files, filepath.FromSlash(file))
+		}
+	}
+	return files, nil
+}
+
+// allFiles lists the files under src, skipping .git and dst should it be
+// inside src
+func allFiles(src, dst string) ([]string, error) {
+	skip, _ := filepath.Abs(dst)
+
+	var files []string
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if abs, _ := filepath.Abs(path); d.Name() == ".git" || abs == skip {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// renameModule rewrites the module path in every text file under dir and
+// reports how many files changed. Binary files are left alone.
+func renameModule(dir, from, to string) (int, error) {
+	rewritten := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(content, 0) >= 0 || !bytes.Contains(content, []byte(from)) {
+			return nil
+		}
+
+		updated := generator.RewriteModule(content, from, to)
+		if bytes.Equal(updated, content) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rewritten++
+		return os.WriteFile(path, updated, info.Mode().Perm())
+	})
+	return rewritten, err
+}
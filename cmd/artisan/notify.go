@@ -0,0 +1,104 @@
+// cmd/artisan/notify.go - Posting operational events to the notification channel
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/migrations"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/notify"
+	"go-clean-gin/pkg/version"
+
+	"go.uber.org/zap"
+)
+
+// newNotifier returns the channel configured in NOTIFY_*. A broken
+// configuration is reported and leaves notifications off rather than
+// failing the command they are about.
+func newNotifier(cfg *config.Config) notify.Notifier {
+	driver, err := notify.New(&cfg.Notify)
+	if err != nil {
+		logger.Warn("Notifications are disabled", zap.Error(err))
+		return notify.NullNotifier{}
+	}
+	return notify.NewChannel(driver, &cfg.Notify, cfg.Env, clock.New())
+}
+
+// postNotification posts msg, logging a failure to post since the command
+// goes on either way
+func postNotification(notifier notify.Notifier, msg notify.Message) {
+	if err := notifier.Notify(context.Background(), msg); err != nil {
+		logger.Warn("Failed to post notification", zap.String("title", msg.Title), zap.Error(err))
+	}
+}
+
+// notifyMigrationFailures posts the migrations that fail, or fail to roll back
+func notifyMigrationFailures(notifier notify.Notifier) {
+	migrations.Events.Listen(migrations.EventFailed, func(ctx context.Context, event events.Event) error {
+		e := event.(migrations.FailedEvent)
+		title := "Migration " + e.Version + " failed"
+		if e.Rollback {
+			title = "Rollback of migration " + e.Version + " failed"
+		}
+		return notifier.Notify(ctx, notify.Message{
+			Event: notify.EventMigrationFailed,
+			Level: notify.LevelError,
+			Title: title,
+			Text:  e.Err.Error(),
+		})
+	})
+}
+
+// deployStages are the deploy:notify stages and how they are posted
+var deployStages = map[string]notify.Level{
+	"started":  notify.LevelInfo,
+	"finished": notify.LevelSuccess,
+	"failed":   notify.LevelError,
+}
+
+// runDeployNotify posts that a deploy of this build started, finished or
+// failed, for deploy scripts to call around their steps
+func runDeployNotify(stage, message string) {
+	level, ok := deployStages[stage]
+	if !ok {
+		fmt.Println("❌ Deploy stage is required: started, finished or failed")
+		fmt.Println("Usage: go run ./cmd/artisan deploy:notify started [-message=\"Rolling out to eu-west\"]")
+		os.Exit(1)
+	}
+
+	cfg := config.Load()
+	if err := logger.Init(cfg.Log.Level, cfg.Log.Format); err != nil {
+		fmt.Printf("❌ Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	driver, err := notify.New(&cfg.Notify)
+	if err != nil {
+		fmt.Printf("❌ Invalid notification configuration: %v\n", err)
+		os.Exit(1)
+	}
+	channel := notify.NewChannel(driver, &cfg.Notify, cfg.Env, clock.New())
+
+	build := version.Get()
+	err = channel.Notify(context.Background(), notify.Message{
+		Event: notify.EventDeploy,
+		Level: level,
+		Title: "Deploy " + stage,
+		Text:  message,
+		Fields: []notify.Field{
+			{Name: "Version", Value: build.Version},
+			{Name: "Commit", Value: build.Commit},
+		},
+	})
+	if err != nil {
+		fmt.Printf("❌ Failed to post: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("📣 Deploy %s of %s notified\n", stage, build.Version)
+}
@@ -0,0 +1,47 @@
+// cmd/artisan/output.go - JSON output and exit codes for scripts
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes scripts can branch on. Invalid flags exit with 2, as the flag
+// package does.
+const (
+	exitFailure = 1 // the command failed
+	exitPending = 3 // migrate:status found pending migrations
+)
+
+// jsonOutput reports whether -format=json asked for machine-readable output
+func jsonOutput() bool {
+	return *format == "json"
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) {
+	if err := encodeJSON(os.Stdout, v); err != nil {
+		fmt.Printf("❌ Failed to encode JSON: %v\n", err)
+		os.Exit(exitFailure)
+	}
+}
+
+// encodeJSON writes v to w as indented JSON
+func encodeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// fail reports the error and exits with exitFailure. With JSON output it is
+// printed as {"error": "..."}, so scripts reading stdout always get JSON.
+func fail(message string, err error) {
+	if jsonOutput() {
+		printJSON(map[string]string{"error": fmt.Sprintf("%s: %v", message, err)})
+	} else {
+		fmt.Printf("❌ %s: %v\n", message, err)
+	}
+	os.Exit(exitFailure)
+}
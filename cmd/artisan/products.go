@@ -0,0 +1,29 @@
+// cmd/artisan/products.go - Product maintenance commands
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go-clean-gin/internal/product"
+	"go-clean-gin/pkg/logger"
+)
+
+// runRebuildReadModel rebuilds every row of the product listing read model,
+// e.g. after writing to tb_products outside the application
+func runRebuildReadModel() {
+	_, db := bootstrap(false)
+	defer logger.Sync()
+
+	fmt.Println("🔄 Rebuilding product read model...")
+	start := time.Now()
+
+	if err := product.NewProductReadRepository(db).RefreshProductListings(context.Background(), nil); err != nil {
+		fmt.Printf("❌ Failed to rebuild product read model: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Product read model rebuilt in %s\n", time.Since(start).Round(time.Millisecond))
+}
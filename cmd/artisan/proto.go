@@ -0,0 +1,93 @@
+// cmd/artisan/proto.go - Protobuf messages of the entities and DTOs
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-clean-gin/internal/generator"
+)
+
+// protoOutputPath is where generate:proto writes by default; the field
+// numbers are kept next to it
+const protoOutputPath = "proto/entity/v1/entity.proto"
+
+// runGenerateProto writes proto3 messages of the types in dirs, numbering
+// their fields from the numbers file next to the output
+func runGenerateProto(dirs []string, outputPath string) {
+	if len(dirs) == 0 {
+		dirs = []string{typesSourceDir}
+	}
+	if outputPath == "" {
+		outputPath = protoOutputPath
+	}
+	numbersPath := strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".numbers.json"
+
+	sources, err := readGoSources(dirs)
+	if err != nil {
+		fmt.Printf("❌ Failed to read sources: %v\n", err)
+		os.Exit(1)
+	}
+
+	numbers := &generator.ProtoNumbers{}
+	if data, err := os.ReadFile(numbersPath); err == nil {
+		if err := json.Unmarshal(data, numbers); err != nil {
+			fmt.Printf("❌ Invalid field numbers in %s: %v\n", numbersPath, err)
+			os.Exit(1)
+		}
+	} else if !os.IsNotExist(err) {
+		fmt.Printf("❌ Failed to read field numbers: %v\n", err)
+		os.Exit(1)
+	}
+
+	content, err := generator.Proto(sources, numbers, protoOptions(outputPath))
+	if err != nil {
+		fmt.Printf("❌ Failed to generate proto: %v\n", err)
+		os.Exit(1)
+	}
+
+	numbersJSON, err := json.MarshalIndent(numbers, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Failed to encode field numbers: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeGeneratedOutput(outputPath, content, "// Code generated by artisan generate:proto."); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if err := writeFile(numbersPath, append(numbersJSON, '\n')); err != nil {
+		fmt.Printf("❌ Failed to write field numbers: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// protoOptions names the package after the output directory, e.g.
+// proto/entity/v1 is package entity.v1, generated into gen/proto/entity/v1
+func protoOptions(outputPath string) generator.ProtoOptions {
+	dir := filepath.ToSlash(filepath.Dir(outputPath))
+	dir = strings.TrimPrefix(strings.TrimPrefix(dir, "./"), "proto/")
+
+	parts := strings.Split(dir, "/")
+	if dir == "." || dir == "proto" {
+		parts = []string{"api", "v1"}
+	}
+
+	// The Go package is named after the last two parts, e.g. entityv1
+	name := parts
+	if len(name) > 2 {
+		name = name[len(name)-2:]
+	}
+
+	module := *modulePath
+	if module == "" {
+		module = readModulePath()
+	}
+	return generator.ProtoOptions{
+		Package:   strings.Join(parts, "."),
+		GoPackage: module + "/gen/proto/" + strings.Join(parts, "/") + ";" + strings.Join(name, ""),
+	}
+}
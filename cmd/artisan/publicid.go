@@ -0,0 +1,75 @@
+// cmd/artisan/publicid.go - Public ID commands
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/publicid"
+	"go-clean-gin/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+// runBackfillPublicIDs gives public IDs to the rows of the entities in
+// PUBLIC_IDS created before they had one, in the main database and each
+// tenant database, then rebuilds the product read model that shows them
+func runBackfillPublicIDs() {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	specs, err := publicid.ParseSpecs(cfg.PublicID.Entities)
+	if err != nil {
+		fmt.Printf("❌ Invalid public IDs: %v\n", err)
+		os.Exit(1)
+	}
+	publicid.Configure(specs)
+	registry, err := tenancy.LoadRegistry(cfg.Tenancy.DatabasesFile)
+	if err != nil {
+		fmt.Printf("❌ Invalid tenant databases: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🔖 Backfilling public IDs...")
+	start := time.Now()
+
+	filled, err := backfillPublicIDs(db)
+	if err != nil {
+		fmt.Printf("❌ Backfill failed after %d row(s): %v\n", filled, err)
+		os.Exit(1)
+	}
+
+	for _, tenant := range registry.Tenants() {
+		dsn, _ := registry.DSN(tenant)
+		tenantDB, err := database.OpenPostgres(dsn, &cfg.Database)
+		if err != nil {
+			fmt.Printf("❌ Tenant %s: %v\n", tenant, err)
+			os.Exit(1)
+		}
+		n, err := backfillPublicIDs(tenantDB)
+		filled += n
+		if sqlDB, dbErr := tenantDB.DB(); dbErr == nil {
+			sqlDB.Close()
+		}
+		if err != nil {
+			fmt.Printf("❌ Backfill failed for tenant %s after %d row(s): %v\n", tenant, filled, err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("✅ Backfilled %d public ID(s) in %s\n", filled, time.Since(start).Round(time.Millisecond))
+}
+
+func backfillPublicIDs(db *gorm.DB) (int64, error) {
+	filled, err := publicid.Backfill(context.Background(), db, entity.PublicIDModels...)
+	if err != nil || filled == 0 {
+		return filled, err
+	}
+	return filled, product.NewProductReadRepository(db).RefreshProductListings(context.Background(), nil)
+}
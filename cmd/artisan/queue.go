@@ -0,0 +1,150 @@
+// cmd/artisan/queue.go - Queue worker and scheduler commands
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/jobs"
+	"go-clean-gin/pkg/cache"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/metrics"
+	"go-clean-gin/pkg/notify"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scheduler"
+)
+
+// runQueueWork processes jobs until SIGINT/SIGTERM, finishing in-flight jobs before exiting
+func runQueueWork(queues string, concurrency int, timeout time.Duration, metricsAddr string) {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	c := container.NewContainer(cfg, db)
+	jobs.RegisterListeners(c)
+
+	queueNames := splitList(queues)
+	if len(queueNames) == 0 {
+		queueNames = []string{cfg.Queue.Default}
+	}
+
+	worker := queue.NewWorker(c.Queue, queue.WorkerOptions{
+		Queues:       queueNames,
+		Concurrency:  concurrency,
+		PollInterval: cfg.Queue.PollInterval,
+		Backoff:      cfg.Queue.Backoff,
+		Timeout:      timeout,
+	})
+	jobs.RegisterHandlers(worker, c)
+	worker.OnFailed(func(ctx context.Context, job *queue.Job, err error) {
+		postNotification(c.Notifier, notify.Message{
+			Event: notify.EventJobFailed,
+			Level: notify.LevelError,
+			Title: "Job " + job.Type + " failed after " + strconv.Itoa(job.Attempts) + " attempt(s)",
+			Text:  err.Error(),
+			Fields: []notify.Field{
+				{Name: "Job ID", Value: job.ID.String()},
+				{Name: "Queue", Value: job.Queue},
+			},
+		})
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	metricsServer := startMetrics(metricsAddr)
+	defer stopMetrics(metricsServer)
+
+	// Jobs read through the repository caches too
+	if synced, ok := c.Cache.(*cache.SyncedStore); ok {
+		go synced.Listen(ctx)
+	}
+
+	fmt.Printf("👷 Processing queues [%s] with concurrency %d\n", strings.Join(queueNames, ", "), concurrency)
+	worker.Run(ctx)
+	fmt.Println("👋 Queue worker stopped")
+}
+
+// runSchedule runs scheduled tasks until SIGINT/SIGTERM, or once when once is set
+func runSchedule(once bool, taskName, metricsAddr string) {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	c := container.NewContainer(cfg, db)
+	jobs.RegisterListeners(c)
+
+	s := scheduler.New()
+	jobs.RegisterSchedule(s, c)
+
+	if taskName == "list" {
+		fmt.Println("📋 Scheduled tasks:")
+		for _, task := range s.Tasks() {
+			fmt.Printf("  - %-24s every %s\n", task.Name, task.Interval)
+		}
+		return
+	}
+
+	if taskName != "" {
+		if err := s.RunTask(context.Background(), taskName); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Task %s completed\n", taskName)
+		return
+	}
+
+	if once {
+		if err := s.RunAll(context.Background()); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Scheduled tasks completed")
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	metricsServer := startMetrics(metricsAddr)
+	defer stopMetrics(metricsServer)
+
+	fmt.Printf("⏰ Scheduler running %d task(s)\n", len(s.Tasks()))
+	s.Start(ctx)
+	fmt.Println("👋 Scheduler stopped")
+}
+
+// startMetrics exposes Prometheus metrics when addr is set
+func startMetrics(addr string) *http.Server {
+	if addr == "" {
+		return nil
+	}
+	fmt.Printf("📈 Metrics available at http://%s/metrics\n", addr)
+	return metrics.Serve(addr)
+}
+
+func stopMetrics(server *http.Server) {
+	if server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctx)
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,167 @@
+// cmd/artisan/resource.go - make:model -all, a model with everything around it
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-clean-gin/internal/generator"
+)
+
+// createResource generates the entity, its create-table migration, factory,
+// seeder and CRUD package, and serves the package under /api/v1. Nothing is
+// written when any of the files already exists, unless -force overwrites
+// them or -skip-existing keeps them.
+func createResource(modelName, table, fieldList string) {
+	entityName := generator.ToPascalCase(generator.ToSnakeCase(modelName))
+	if table == "" {
+		table = generator.Pluralize(generator.ToSnakeCase(entityName))
+	}
+	parsedFields := generator.ParseFields(fieldList)
+	model := generator.EntityData{
+		EntityName: entityName,
+		TableName:  table,
+		Fields:     parsedFields,
+	}
+	pkg := generator.PackageData{
+		PackageName: strings.ToLower(entityName),
+		EntityName:  entityName,
+		Fields:      parsedFields,
+		CRUD:        true,
+	}
+
+	migrationName := "create_" + strings.TrimPrefix(table, "tb_") + "_table"
+	timestamp := existingMigrationTimestamp(migrationName)
+	if timestamp == "" {
+		timestamp = time.Now().Format("2006_01_02_150405")
+	}
+	migration := generator.NewMigrationData(migrationName, table, parsedFields, timestamp)
+
+	seeder := generator.NewSeederData(entityName, table, "")
+	seeder.Factory = &model
+
+	files, err := resourceFiles(model, migration, seeder, pkg)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	for _, file := range files {
+		if _, err := os.Stat(file.Path); err == nil && !*force && !*skipExisting {
+			fmt.Printf("❌ %s already exists, nothing was generated (use -force to overwrite or -skip-existing to keep it)\n", file.Path)
+			os.Exit(1)
+		}
+	}
+
+	for _, file := range files {
+		if keepExisting(file.Path) {
+			continue
+		}
+		if err := writeGeneratedFile(file); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Created: %s\n", file.Path)
+	}
+
+	fmt.Printf("📝 Entity: %s\n", entityName)
+	fmt.Printf("🗂️  Table: %s\n", table)
+	printFieldSummary(parsedFields)
+
+	wirePackage(pkg)
+	printRelationHints(parsedFields)
+	printSwaggerHint()
+	fmt.Printf("💡 Run the migration with: go run ./cmd/artisan -action=migrate\n")
+}
+
+// existingMigrationTimestamp returns the timestamp of a migration generated
+// before with the name, so re-running -all overwrites or keeps it instead of
+// adding a second one
+func existingMigrationTimestamp(migrationName string) string {
+	const layout = "2006_01_02_150405"
+	matches, _ := filepath.Glob(filepath.Join("internal", "migrations", "*_"+migrationName+".go"))
+	for _, match := range matches {
+		base := filepath.Base(match)
+		if len(base) > len(layout) {
+			if _, err := time.Parse(layout, base[:len(layout)]); err == nil {
+				return base[:len(layout)]
+			}
+		}
+	}
+	return ""
+}
+
+func resourceFiles(model generator.EntityData, migration generator.MigrationData, seeder generator.SeederData, pkg generator.PackageData) ([]generator.File, error) {
+	var files []generator.File
+
+	entityFile, err := generator.Entity(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate entity: %w", err)
+	}
+	migrationFile, err := generator.Migration(migration, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate migration: %w", err)
+	}
+	factoryFile, err := generator.Factory(model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate factory: %w", err)
+	}
+	seederFile, err := generator.Seeder(seeder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate seeder: %w", err)
+	}
+	packageFiles, err := generator.Package(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate package: %w", err)
+	}
+
+	files = append(files, entityFile, migrationFile, factoryFile, seederFile)
+	return append(files, packageFiles...), nil
+}
+
+// printSwaggerHint points at regenerating the API docs, which pick up the
+// annotations of generated handlers and the entities they return
+func printSwaggerHint() {
+	fmt.Println("📖 Add the routes to docs/swagger.yaml with: make swagger")
+}
+
+// wirePackage adds the package to the container and router at their
+// artisan:insert markers. A file missing a marker is left unchanged, with the
+// code to add by hand printed.
+func wirePackage(pkg generator.PackageData) {
+	var paths []string
+	byPath := map[string][]generator.Wiring{}
+	for _, wiring := range generator.PackageWiring(pkg) {
+		if _, ok := byPath[wiring.Path]; !ok {
+			paths = append(paths, wiring.Path)
+		}
+		byPath[wiring.Path] = append(byPath[wiring.Path], wiring)
+	}
+
+	for _, path := range paths {
+		if err := wireFile(path, byPath[path]); err != nil {
+			fmt.Printf("⚠️  Could not wire %s: %v\n", path, err)
+			for _, wiring := range byPath[path] {
+				fmt.Printf("   Add above // artisan:insert %s:\n%s\n", wiring.Section, wiring.Code)
+			}
+			continue
+		}
+		fmt.Printf("🔌 Wired: %s\n", path)
+	}
+	fmt.Printf("🌐 Routes: /api/v1%s\n", pkg.Route())
+}
+
+func wireFile(path string, wirings []generator.Wiring) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for _, wiring := range wirings {
+		if content, err = generator.Wire(content, wiring.Section, wiring.Code); err != nil {
+			return err
+		}
+	}
+	return writeFile(path, content)
+}
@@ -0,0 +1,53 @@
+// cmd/artisan/retention.go - Deleting rows past their retention
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/jobs"
+	"go-clean-gin/pkg/logger"
+)
+
+// runPurge deletes the rows past their retention, for every retention rule or
+// only for rule, on the main database and each tenant database, as the daily
+// retention:purge task does. With dryRun it only counts them.
+func runPurge(rule string, dryRun bool) {
+	cfg, db := bootstrap(false)
+	defer logger.Sync()
+
+	c := container.NewContainer(cfg, db)
+
+	total := make(map[string]int64)
+	err := c.Tenants.Each(context.Background(), func(ctx context.Context) error {
+		deleted, err := jobs.Purge(ctx, c, rule, dryRun)
+		for name, n := range deleted {
+			total[name] += n
+		}
+		return err
+	})
+	if err != nil {
+		fmt.Printf("❌ Failed to purge: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(total) == 0 {
+		fmt.Println("📭 Nothing to purge: every retention is 0 or no rule has that name")
+		return
+	}
+	names := make([]string, 0, len(total))
+	for name := range total {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if dryRun {
+			fmt.Printf("🔍 Would delete %d row(s) of %s\n", total[name], name)
+		} else {
+			fmt.Printf("✅ Deleted %d row(s) of %s\n", total[name], name)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// cmd/artisan/retry.go - Retry decorator generation for port.go interfaces
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-clean-gin/internal/generator"
+)
+
+// createRetry generates the retry decorator of a single interface, e.g.
+// PostRepository
+func createRetry(interfaceName string) {
+	if interfaceName == "" {
+		fmt.Println("❌ Interface name is required")
+		fmt.Println("Usage: go run ./cmd/artisan make:retry -interface=PostRepository")
+		os.Exit(1)
+	}
+
+	ports, err := readPortFiles()
+	if err != nil {
+		fmt.Printf("❌ Failed to read port.go files: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, port := range ports {
+		names, err := generator.Interfaces(port)
+		if err != nil {
+			fmt.Printf("❌ Failed to parse %s: %v\n", port.Path, err)
+			os.Exit(1)
+		}
+		for _, name := range names {
+			if name != interfaceName {
+				continue
+			}
+			path, err := writeRetryDecorator(port, name)
+			if err != nil {
+				fmt.Printf("❌ Failed to generate retry decorator: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ Retry decorator created: %s\n", path)
+			return
+		}
+	}
+
+	fmt.Printf("❌ Interface %s not found in internal/*/port.go\n", interfaceName)
+	os.Exit(1)
+}
+
+// generateRetries regenerates the retry decorators made with make:retry, so
+// they follow changes to their interfaces
+func generateRetries() {
+	ports, err := readPortFiles()
+	if err != nil {
+		fmt.Printf("❌ Failed to read port.go files: %v\n", err)
+		os.Exit(1)
+	}
+
+	count := 0
+	for _, port := range ports {
+		names, err := generator.Interfaces(port)
+		if err != nil {
+			fmt.Printf("❌ Failed to parse %s: %v\n", port.Path, err)
+			os.Exit(1)
+		}
+		for _, name := range names {
+			path := filepath.Join(filepath.Dir(port.Path), "retrying_"+generator.ToSnakeCase(name)+".go")
+			existing, err := os.ReadFile(path)
+			if err != nil || !bytes.HasPrefix(existing, []byte(generator.RetryHeader)) {
+				continue
+			}
+
+			if _, err := writeRetryDecorator(port, name); err != nil {
+				fmt.Printf("❌ Failed to generate retry decorator for %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ %s → %s\n", name, path)
+			count++
+		}
+	}
+
+	if count == 0 {
+		fmt.Println("📭 No retry decorators in internal/*; create one with make:retry")
+		return
+	}
+	fmt.Printf("🔁 Regenerated %d retry decorator(s)\n", count)
+}
+
+// writeRetryDecorator renders the decorator into internal/<pkg>/retrying_<name>.go
+func writeRetryDecorator(port generator.File, name string) (string, error) {
+	file, err := generator.RetryDecorator(port, name)
+	if err != nil {
+		return "", err
+	}
+
+	// Never overwrite a hand-written file
+	if existing, err := os.ReadFile(file.Path); err == nil && !bytes.HasPrefix(existing, []byte(generator.RetryHeader)) {
+		return "", fmt.Errorf("%s exists and was not generated by artisan", file.Path)
+	}
+	return file.Path, writeFile(file.Path, file.Content)
+}
@@ -0,0 +1,71 @@
+// cmd/artisan/routes.go - List the HTTP routes of the server
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/router"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listedRoute is a route as route:list prints it
+type listedRoute struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Handler string `json:"handler"`
+}
+
+// listRoutes prints the routes the server registers, sorted by path, as a
+// table or as JSON
+func listRoutes() {
+	cfg, db := bootstrap(true)
+	defer logger.Sync()
+
+	// Keep gin's debug route log out of the output
+	gin.DefaultWriter = io.Discard
+	engine := router.SetupRouter(container.NewContainer(cfg, db))
+
+	routes := make([]listedRoute, 0, len(engine.Routes()))
+	for _, route := range engine.Routes() {
+		routes = append(routes, listedRoute{
+			Method:  route.Method,
+			Path:    route.Path,
+			Handler: handlerName(route.Handler),
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	if jsonOutput() {
+		printJSON(routes)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tHANDLER")
+	for _, route := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", route.Method, route.Path, route.Handler)
+	}
+	w.Flush()
+	fmt.Printf("(%d route(s))\n", len(routes))
+}
+
+// handlerName shortens a handler's function name to its package, e.g.
+// "product.(*ProductHandler).GetProducts"
+func handlerName(name string) string {
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
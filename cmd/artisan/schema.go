@@ -0,0 +1,69 @@
+// cmd/artisan/schema.go - Documentation of the migrated database schema
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-clean-gin/internal/schemadoc"
+	"go-clean-gin/pkg/logger"
+)
+
+// historyTables are the migration and seeder bookkeeping tables, left out of
+// the schema documentation
+var historyTables = []string{"migration_records", "seeder_records"}
+
+// runSchemaDocs documents the tables of the database as Markdown, HTML or
+// JSON, written to outputPath or printed
+func runSchemaDocs(format, outputPath string, mermaid bool) {
+	if format == "table" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "html" && format != "json" {
+		fmt.Printf("❌ Unsupported format for schema:docs: %s\n", format)
+		fmt.Println("Usage: artisan schema:docs [-format=markdown|html|json] [-mermaid] [-output=docs/schema.md]")
+		os.Exit(1)
+	}
+
+	_, db := bootstrap(true)
+	defer logger.Sync()
+
+	schema, err := schemadoc.Inspect(db, historyTables...)
+	if err != nil {
+		fail("Failed to read the schema", err)
+	}
+
+	if format == "json" && outputPath == "" {
+		printJSON(schema)
+		return
+	}
+
+	var buf bytes.Buffer
+	opts := schemadoc.Options{Mermaid: mermaid}
+	switch format {
+	case "markdown":
+		err = schemadoc.Markdown(&buf, schema, opts)
+	case "html":
+		err = schemadoc.HTML(&buf, schema, opts)
+	case "json":
+		err = encodeJSON(&buf, schema)
+	}
+	if err != nil {
+		fail("Failed to render the schema documentation", err)
+	}
+
+	if outputPath == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		fail("Failed to create directory", err)
+	}
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		fail("Failed to write the schema documentation", err)
+	}
+	fmt.Printf("📚 Documented %d tables in %s\n", len(schema.Tables), outputPath)
+}
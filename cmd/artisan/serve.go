@@ -0,0 +1,318 @@
+// cmd/artisan/serve.go - Development server with hot reload
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go-clean-gin/config"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	serveBinary        = "tmp/server"
+	serveDebounce      = 300 * time.Millisecond
+	serveReadyTimeout  = 30 * time.Second
+	serveStopTimeout   = 10 * time.Second
+	serveProxyWaitTime = 30 * time.Second
+)
+
+// directories that never trigger a rebuild
+var serveIgnoredDirs = map[string]bool{
+	".git":         true,
+	"bin":          true,
+	"tmp":          true,
+	"vendor":       true,
+	"node_modules": true,
+	"logs":         true,
+	"coverage":     true,
+}
+
+// devServer builds and supervises the application process
+type devServer struct {
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	done    chan struct{}
+	appPort int
+}
+
+func runServe(watch bool, appPort int) {
+	cfg := config.Load()
+
+	if !watch {
+		// Plain serve: build once and run on the configured port
+		server := &devServer{appPort: cfg.Server.Port}
+		if err := server.build(); err != nil {
+			fmt.Printf("❌ Build failed: %v\n", err)
+			os.Exit(1)
+		}
+		if err := server.start(); err != nil {
+			fmt.Printf("❌ Failed to start server: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("🚀 Server running on http://localhost:%d\n", cfg.Server.Port)
+		server.waitForSignal()
+		return
+	}
+
+	if appPort == 0 {
+		appPort = cfg.Server.Port + 1
+	}
+	if appPort == cfg.Server.Port {
+		fmt.Println("❌ -app-port must differ from SERVER_PORT when watching")
+		os.Exit(1)
+	}
+
+	server := &devServer{appPort: appPort}
+
+	// Initial build
+	fmt.Println("🔨 Building application...")
+	if err := server.build(); err != nil {
+		fmt.Printf("❌ Build failed: %v\n", err)
+	} else if err := server.start(); err != nil {
+		fmt.Printf("❌ Failed to start server: %v\n", err)
+	}
+
+	// Proxy keeps the public port open while the app restarts
+	proxy := newServeProxy(appPort)
+	proxyServer := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
+		Handler: proxy,
+	}
+	go func() {
+		if err := proxyServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("❌ Proxy failed: %v\n", err)
+			os.Exit(1)
+		}
+	}()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		fmt.Printf("❌ Failed to create file watcher: %v\n", err)
+		os.Exit(1)
+	}
+	defer watcher.Close()
+
+	if err := addWatchDirs(watcher, "."); err != nil {
+		fmt.Printf("❌ Failed to watch project: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("👀 Watching for changes (proxy http://localhost:%d → app :%d)\n", cfg.Server.Port, appPort)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = addWatchDirs(watcher, event.Name)
+				}
+			}
+			if shouldRebuild(event.Name) {
+				debounce = time.After(serveDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Printf("⚠️  Watcher error: %v\n", err)
+
+		case <-debounce:
+			debounce = nil
+			fmt.Println("🔄 Change detected, rebuilding...")
+			if err := server.build(); err != nil {
+				fmt.Printf("❌ Build failed, keeping previous version running: %v\n", err)
+				continue
+			}
+			server.stop()
+			if err := server.start(); err != nil {
+				fmt.Printf("❌ Failed to restart server: %v\n", err)
+				continue
+			}
+			fmt.Println("✅ Server restarted")
+
+		case <-quit:
+			fmt.Println("👋 Shutting down dev server...")
+			ctx, cancel := context.WithTimeout(context.Background(), serveStopTimeout)
+			_ = proxyServer.Shutdown(ctx)
+			cancel()
+			server.stop()
+			return
+		}
+	}
+}
+
+// build compiles the application into tmp/server
+func (s *devServer) build() error {
+	if err := os.MkdirAll(filepath.Dir(serveBinary), 0755); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "build", "-o", serveBinary, "./cmd")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// start launches the compiled binary and waits until it accepts connections
+func (s *devServer) start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd := exec.Command("./" + serveBinary)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "SERVER_PORT="+strconv.Itoa(s.appPort))
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+
+	s.cmd = cmd
+	s.done = done
+
+	return waitForPort(s.appPort, serveReadyTimeout, done)
+}
+
+// stop terminates the running process gracefully, killing it after a timeout
+func (s *devServer) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cmd == nil || s.cmd.Process == nil {
+		return
+	}
+
+	_ = s.cmd.Process.Signal(syscall.SIGTERM)
+
+	select {
+	case <-s.done:
+	case <-time.After(serveStopTimeout):
+		_ = s.cmd.Process.Kill()
+		<-s.done
+	}
+
+	s.cmd = nil
+	s.done = nil
+}
+
+// waitForSignal blocks until SIGINT/SIGTERM or the process exits
+func (s *devServer) waitForSignal() {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-quit:
+		s.stop()
+	case <-s.done:
+	}
+}
+
+// newServeProxy creates a reverse proxy that waits for the app to come back during restarts
+func newServeProxy(appPort int) *httputil.ReverseProxy {
+	target := &url.URL{Scheme: "http", Host: fmt.Sprintf("127.0.0.1:%d", appPort)}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	dialer := &net.Dialer{Timeout: time.Second}
+	proxy.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			deadline := time.Now().Add(serveProxyWaitTime)
+			for {
+				conn, err := dialer.DialContext(ctx, network, addr)
+				if err == nil {
+					return conn, nil
+				}
+				if time.Now().After(deadline) || ctx.Err() != nil {
+					return nil, err
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		},
+	}
+
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, fmt.Sprintf("dev server unavailable: %v", err), http.StatusBadGateway)
+	}
+
+	return proxy
+}
+
+// waitForPort polls the port until it accepts TCP connections
+func waitForPort(port int, timeout time.Duration, exited <-chan struct{}) error {
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-exited:
+			return fmt.Errorf("process exited before listening on %s", addr)
+		default:
+		}
+
+		conn, err := net.DialTimeout("tcp", addr, 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return fmt.Errorf("timed out waiting for %s", addr)
+}
+
+// addWatchDirs recursively registers directories with the watcher
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != "." && (serveIgnoredDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// shouldRebuild reports whether a changed file should trigger a rebuild
+func shouldRebuild(path string) bool {
+	base := filepath.Base(path)
+	if strings.HasSuffix(base, "_test.go") || strings.HasSuffix(base, "~") {
+		return false
+	}
+	switch filepath.Ext(base) {
+	case ".go", ".html", ".tmpl", ".tpl":
+		return true
+	}
+	return base == ".env" || base == "go.mod" || base == "go.sum"
+}
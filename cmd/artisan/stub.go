@@ -0,0 +1,85 @@
+// cmd/artisan/stub.go - Project root discovery and generator stubs
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go-clean-gin/internal/generator"
+)
+
+// enterProjectRoot moves to the directory of the nearest go.mod, so generators
+// write into the project from any of its subdirectories, and renders files
+// from the project's published stubs, if it has any, importing its packages
+// from its module path (or -module)
+func enterProjectRoot() {
+	root, err := findProjectRoot()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Chdir(root); err != nil {
+		fmt.Printf("❌ Failed to enter project root: %v\n", err)
+		os.Exit(1)
+	}
+
+	if info, err := os.Stat(generator.StubDir); err == nil && info.IsDir() {
+		generator.UseStubs(os.DirFS(generator.StubDir))
+	}
+
+	module := *modulePath
+	if module == "" {
+		module = readModulePath()
+	} else if err := generator.CheckModulePath(module); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	generator.UseModule(module)
+}
+
+// findProjectRoot walks up from the working directory to the one with go.mod
+func findProjectRoot() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for dir := cwd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return "", fmt.Errorf("no go.mod found in %s or its parents; run artisan inside the project", cwd)
+		}
+	}
+}
+
+// publishStubs copies the embedded stubs to the project for customizing.
+// Stubs already published are kept unless overwrite is set.
+func publishStubs(overwrite bool) {
+	files, err := generator.DefaultStubs()
+	if err != nil {
+		fmt.Printf("❌ Failed to read stubs: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(generator.StubDir, 0755); err != nil {
+		fmt.Printf("❌ Failed to create directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(file.Path); err == nil && !overwrite {
+			fmt.Printf("⏭️  Already published, skipping: %s\n", file.Path)
+			continue
+		}
+		if err := os.WriteFile(file.Path, file.Content, 0644); err != nil {
+			fmt.Printf("❌ Failed to publish %s: %v\n", file.Path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Published: %s\n", file.Path)
+	}
+
+	fmt.Printf("💡 make:* commands now render from %s/; delete a stub to go back to its default\n", generator.StubDir)
+}
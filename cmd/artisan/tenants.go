@@ -0,0 +1,74 @@
+// cmd/artisan/tenants.go - Migrations of tenant databases
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/notify"
+	"go-clean-gin/pkg/tenancy"
+)
+
+// runTenantsMigrate runs pending migrations on every tenant database in
+// TENANT_DATABASES_FILE, or only on the named tenant's. A failing tenant does
+// not stop the others.
+func runTenantsMigrate(tenant string) {
+	cfg, _ := bootstrap(false)
+	defer logger.Sync()
+
+	registry, err := tenancy.LoadRegistry(cfg.Tenancy.DatabasesFile)
+	if err != nil {
+		fmt.Printf("❌ Invalid tenant databases: %v\n", err)
+		os.Exit(1)
+	}
+
+	tenants := registry.Tenants()
+	if tenant != "" {
+		if _, ok := registry.DSN(tenant); !ok {
+			fmt.Printf("❌ Tenant %q has no database of its own\n", tenant)
+			os.Exit(1)
+		}
+		tenants = []string{tenant}
+	}
+	if len(tenants) == 0 {
+		fmt.Println("📭 No tenant databases configured (TENANT_DATABASES_FILE)")
+		return
+	}
+
+	notifier := newNotifier(cfg)
+	failed := 0
+	for _, id := range tenants {
+		fmt.Printf("⬆️  Migrating tenant %s...\n", id)
+		if err := migrateTenant(registry, id, &cfg.Database); err != nil {
+			fmt.Printf("❌ Tenant %s: %v\n", id, err)
+			postNotification(notifier, notify.Message{
+				Event: notify.EventMigrationFailed,
+				Level: notify.LevelError,
+				Title: "Migrations failed for tenant " + id,
+				Text:  err.Error(),
+			})
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("❌ Migrations failed for %d of %d tenant(s)\n", failed, len(tenants))
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Migrated %d tenant database(s)\n", len(tenants))
+}
+
+func migrateTenant(registry *tenancy.Registry, tenant string, cfg *config.DatabaseConfig) error {
+	dsn, _ := registry.DSN(tenant)
+	db, err := database.OpenPostgres(dsn, cfg)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	return database.RunMigrations(db)
+}
@@ -0,0 +1,157 @@
+// cmd/artisan/types.go - TypeScript declarations of the entities and DTOs
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"go-clean-gin/internal/generator"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Where generate:types reads from and writes to by default
+const (
+	typesSourceDir  = "internal/entity"
+	typesOutputPath = "web/types/api.ts"
+)
+
+// runGenerateTypes writes TypeScript declarations of the types in dirs, and
+// with watch regenerates them whenever a Go file in dirs changes
+func runGenerateTypes(dirs []string, outputPath string, watch bool) {
+	if len(dirs) == 0 {
+		dirs = []string{typesSourceDir}
+	}
+	if outputPath == "" {
+		outputPath = typesOutputPath
+	}
+
+	if err := generateTypes(dirs, outputPath); err != nil {
+		fmt.Printf("❌ Failed to generate types: %v\n", err)
+		os.Exit(1)
+	}
+	if !watch {
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		fmt.Printf("❌ Failed to create file watcher: %v\n", err)
+		os.Exit(1)
+	}
+	defer watcher.Close()
+
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			fmt.Printf("❌ Failed to watch %s: %v\n", dir, err)
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("👀 Watching %s for changes\n", strings.Join(dirs, ", "))
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if isTypesSource(event.Name) {
+				debounce = time.After(serveDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Printf("⚠️  Watcher error: %v\n", err)
+
+		case <-debounce:
+			debounce = nil
+			if err := generateTypes(dirs, outputPath); err != nil {
+				fmt.Printf("❌ Failed to generate types, keeping the previous ones: %v\n", err)
+			}
+
+		case <-quit:
+			return
+		}
+	}
+}
+
+// generateTypes converts the Go files in dirs and writes the declarations
+// to outputPath
+func generateTypes(dirs []string, outputPath string) error {
+	sources, err := readGoSources(dirs)
+	if err != nil {
+		return err
+	}
+
+	content, err := generator.TypeScript(sources)
+	if err != nil {
+		return err
+	}
+	return writeGeneratedOutput(outputPath, content, "// Code generated by artisan generate:types.")
+}
+
+// readGoSources reads the Go files in dirs, leaving out tests
+func readGoSources(dirs []string) ([]generator.File, error) {
+	var sources []generator.File
+	for _, dir := range dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			if !isTypesSource(path) {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, generator.File{Path: path, Content: content})
+		}
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no Go files found in %s", strings.Join(dirs, ", "))
+	}
+	return sources, nil
+}
+
+// writeGeneratedOutput writes a generated file unless the file there is
+// hand-written, which is one not starting with header
+func writeGeneratedOutput(path string, content []byte, header string) error {
+	existing, err := os.ReadFile(path)
+	if err == nil && !*force && !bytes.HasPrefix(existing, []byte(header)) {
+		return fmt.Errorf("%s exists and was not generated by artisan (use -force to overwrite it)", path)
+	}
+	if err == nil && bytes.Equal(existing, content) {
+		fmt.Printf("🟰 %s is up to date\n", path)
+		return nil
+	}
+
+	if err := writeFile(path, content); err != nil {
+		return err
+	}
+	if !*dryRun {
+		fmt.Printf("✅ Written %s\n", path)
+	}
+	return nil
+}
+
+// isTypesSource reports whether a file is a Go source generate:types reads
+func isTypesSource(path string) bool {
+	return strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go")
+}
@@ -0,0 +1,160 @@
+// cmd/artisan/user.go - User management commands
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go-clean-gin/internal/auth"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/validator"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+	"gorm.io/gorm"
+)
+
+// createUser creates a user directly in the database, prompting for missing values
+func createUser(email, password, role, username, firstName, lastName string) {
+	reader := bufio.NewReader(os.Stdin)
+
+	if email == "" {
+		email = prompt(reader, "Email")
+	}
+	if username == "" {
+		username = promptDefault(reader, "Username", strings.SplitN(email, "@", 2)[0])
+	}
+	if firstName == "" {
+		firstName = promptDefault(reader, "First name", username)
+	}
+	if lastName == "" {
+		lastName = promptDefault(reader, "Last name", "User")
+	}
+	if password == "" {
+		password = promptPassword(reader, "Password")
+		if confirm := promptPassword(reader, "Confirm password"); confirm != password {
+			fmt.Println("❌ Passwords do not match")
+			os.Exit(1)
+		}
+	}
+	if role == "" {
+		role = entity.RoleUser
+	}
+
+	if !slices.Contains(entity.ValidRoles, role) {
+		fmt.Printf("❌ Invalid role: %s (valid: %s)\n", role, strings.Join(entity.ValidRoles, ", "))
+		os.Exit(1)
+	}
+
+	req := entity.RegisterRequest{
+		Email:     email,
+		Username:  username,
+		Password:  password,
+		FirstName: firstName,
+		LastName:  lastName,
+	}
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		fmt.Println("❌ Validation failed:")
+		for field, msg := range fieldErrors {
+			fmt.Printf("  - %s: %s\n", field, msg)
+		}
+		os.Exit(1)
+	}
+
+	_, db := bootstrap(false)
+	defer logger.Sync()
+
+	ctx := context.Background()
+	repo := auth.NewAuthRepository(db)
+
+	if _, err := repo.GetUserByEmail(ctx, email); err == nil {
+		fmt.Printf("❌ User with email %s already exists\n", email)
+		os.Exit(1)
+	} else if err != gorm.ErrRecordNotFound {
+		fmt.Printf("❌ Failed to check existing user: %v\n", err)
+		os.Exit(1)
+	}
+
+	if _, err := repo.GetUserByUsername(ctx, username); err == nil {
+		fmt.Printf("❌ User with username %s already exists\n", username)
+		os.Exit(1)
+	} else if err != gorm.ErrRecordNotFound {
+		fmt.Printf("❌ Failed to check existing user: %v\n", err)
+		os.Exit(1)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		fmt.Printf("❌ Failed to hash password: %v\n", err)
+		os.Exit(1)
+	}
+
+	user := &entity.User{
+		Email:     email,
+		Username:  username,
+		Password:  string(hashedPassword),
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      role,
+		IsActive:  true,
+	}
+
+	if err := repo.CreateUser(ctx, user); err != nil {
+		fmt.Printf("❌ Failed to create user: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ User created: %s\n", user.Email)
+	fmt.Printf("🆔 ID: %s\n", user.ID)
+	fmt.Printf("👤 Username: %s\n", user.Username)
+	fmt.Printf("🔑 Role: %s\n", user.Role)
+}
+
+// prompt reads a required value from stdin
+func prompt(reader *bufio.Reader, label string) string {
+	for {
+		fmt.Printf("%s: ", label)
+		value, err := reader.ReadString('\n')
+		if err != nil && value == "" {
+			fmt.Printf("\n❌ %s is required\n", label)
+			os.Exit(1)
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+}
+
+// promptDefault reads a value from stdin, falling back to a default
+func promptDefault(reader *bufio.Reader, label, defaultValue string) string {
+	fmt.Printf("%s [%s]: ", label, defaultValue)
+	value, _ := reader.ReadString('\n')
+	if value = strings.TrimSpace(value); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// promptPassword reads a password without echoing it when stdin is a terminal
+func promptPassword(reader *bufio.Reader, label string) string {
+	fmt.Printf("%s: ", label)
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		password, err := term.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			fmt.Printf("❌ Failed to read password: %v\n", err)
+			os.Exit(1)
+		}
+		return string(password)
+	}
+
+	password, _ := reader.ReadString('\n')
+	return strings.TrimSpace(password)
+}
@@ -0,0 +1,40 @@
+// cmd/artisan/views.go - Materialized view commands
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/matview"
+)
+
+// runRefreshViews refreshes the named materialized views, or all of them,
+// e.g. right after a migration created one or to see a report up to date
+// before its scheduled refresh
+func runRefreshViews(names []string) {
+	_, db := bootstrap(false)
+	defer logger.Sync()
+
+	if len(names) == 0 {
+		var err error
+		if names, err = matview.Names(db); err != nil {
+			fmt.Printf("❌ Failed to list materialized views: %v\n", err)
+			os.Exit(1)
+		}
+		if len(names) == 0 {
+			fmt.Println("📭 No materialized views to refresh")
+			return
+		}
+	}
+
+	for _, name := range names {
+		start := time.Now()
+		if err := matview.Refresh(db, name); err != nil {
+			fmt.Printf("❌ Failed to refresh %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Refreshed %s in %s\n", name, time.Since(start).Round(time.Millisecond))
+	}
+}
@@ -11,13 +11,24 @@ import (
 
 	"go-clean-gin/config"
 	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/jobs"
 	"go-clean-gin/internal/router"
+	"go-clean-gin/pkg/cache"
 	"go-clean-gin/pkg/database"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/version"
 
 	"go.uber.org/zap"
 )
 
+// @title Go Clean Gin API
+// @version 1.0
+// @description Clean architecture REST API built with Gin and GORM
+// @BasePath /api/v1
+// @securityDefinitions.apikey Bearer
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the JWT token
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -28,7 +39,11 @@ func main() {
 	}
 	defer logger.Sync()
 
+	buildInfo := version.Get()
 	logger.Info("Starting application",
+		zap.String("version", buildInfo.Version),
+		zap.String("commit", buildInfo.Commit),
+		zap.String("build_time", buildInfo.BuildTime),
 		zap.String("env", cfg.Env),
 		zap.String("host", cfg.Server.Host),
 		zap.Int("port", cfg.Server.Port),
@@ -54,6 +69,14 @@ func main() {
 
 	// Initialize dependency injection container
 	containerInstance := container.NewContainer(cfg, db)
+	jobs.RegisterListeners(containerInstance)
+
+	// Drop the cached reads other instances invalidate
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	if synced, ok := containerInstance.Cache.(*cache.SyncedStore); ok {
+		go synced.Listen(listenCtx)
+	}
 
 	// Setup routes
 	routerInstance := router.SetupRouter(containerInstance)
@@ -94,7 +117,9 @@ func main() {
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
-	// Close database connection
+	// Close database connections, after the listener gives its back
+	stopListening()
+	containerInstance.Tenants.Close()
 	sqlDB, err := db.DB()
 	if err == nil {
 		if err := sqlDB.Close(); err != nil {
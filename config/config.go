@@ -11,12 +11,55 @@ import (
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	JWT      JWTConfig
-	Log      LogConfig
-	Email    EmailConfig
-	Env      string
+	Database      DatabaseConfig
+	Server        ServerConfig
+	JWT           JWTConfig
+	SignedURL     SignedURLConfig
+	SignedRequest SignedRequestConfig
+	Throttle      ThrottleConfig
+	Log           LogConfig
+	Email         EmailConfig
+	Storage       StorageConfig
+	Backup        BackupConfig
+	Queue         QueueConfig
+	Stock         StockConfig
+	Reservation   ReservationConfig
+	Currency      CurrencyConfig
+	Exchange      ExchangeConfig
+	Listing       ListingConfig
+	Health        HealthConfig
+	LoadShed      LoadShedConfig
+	Concurrency   ConcurrencyConfig
+	Account       AccountConfig
+	Consent       ConsentConfig
+	Avatar        AvatarConfig
+	Quota         QuotaConfig
+	OIDC          OIDCConfig
+	SSO           SSOConfig
+	AuthBackend   AuthBackendConfig
+	SCIM          SCIMConfig
+	Org           OrganizationConfig
+	Tenancy       TenancyConfig
+	Crypto        CryptoConfig
+	PublicID      PublicIDConfig
+	Settings      SettingsConfig
+	Activity      ActivityConfig
+	SavedSearch   SavedSearchConfig
+	Export        ExportConfig
+	Import        ImportConfig
+	Scanner       ScannerConfig
+	Images        ProductImageConfig
+	Static        StaticConfig
+	Maintenance   MaintenanceConfig
+	Notify        NotifyConfig
+	Cache         CacheConfig
+	Counter       CounterConfig
+	Products      ProductConfig
+	Report        ReportConfig
+	Partition     PartitionConfig
+	Archive       ArchiveConfig
+	Retention     RetentionConfig
+	Env           string
 }
 
 type DatabaseConfig struct {
@@ -30,6 +73,16 @@ type DatabaseConfig struct {
 	MaxIdleConns    int    // 🆕 เพิ่มใหม่ - connection pool
 	MaxOpenConns    int    // 🆕 เพิ่มใหม่ - connection pool
 	ConnMaxLifetime int    // 🆕 เพิ่มใหม่ - connection lifetime (minutes)
+	UUIDVersion     string // v4 (database default) or v7 (time-ordered, generated in Go)
+	RLS             bool   // set the row-level security session variables per transaction
+
+	// Repositories wrapped in their generated retry decorator make up to
+	// RetryAttempts attempts on serialization failures, deadlocks and lost
+	// connections, waiting a random delay up to RetryBaseDelay doubled per
+	// attempt and capped at RetryMaxDelay. 1 disables retries.
+	RetryAttempts  int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
 }
 
 type ServerConfig struct {
@@ -39,9 +92,28 @@ type ServerConfig struct {
 	WriteTimeout time.Duration
 }
 
+// JWTConfig sets the session lifetimes. Access tokens last ExpirationHours.
+// Refresh tokens last RefreshTTL, or RememberMeTTL when the user asked to be
+// remembered, and every refresh slides that window forward; no session outlives
+// MaxLifetime from its login.
 type JWTConfig struct {
 	Secret          string
 	ExpirationHours int
+	RefreshTTL      time.Duration
+	RememberMeTTL   time.Duration
+	MaxLifetime     time.Duration
+}
+
+// ThrottleConfig limits login and register attempts per client IP and per
+// email, and availability checks per client IP, within Window. A limit of 0
+// disables that throttle.
+type ThrottleConfig struct {
+	Window            time.Duration
+	LoginPerIP        int
+	LoginPerEmail     int
+	RegisterPerIP     int
+	RegisterPerEmail  int
+	AvailabilityPerIP int
 }
 
 type LogConfig struct {
@@ -50,6 +122,7 @@ type LogConfig struct {
 }
 
 type EmailConfig struct {
+	Driver             string // smtp, log (writes mail to the logger), null, or array (in-memory, for tests)
 	Host               string
 	Port               int
 	Username           string
@@ -62,12 +135,424 @@ type EmailConfig struct {
 	InsecureSkipVerify bool
 }
 
+type StorageConfig struct {
+	Driver    string
+	LocalPath string
+}
+
+type BackupConfig struct {
+	Dir  string // storage prefix for backups
+	Keep int    // number of backups to keep (0 = keep all)
+}
+
+type QueueConfig struct {
+	Driver       string        // database or array (in-memory, for tests)
+	Default      string        // queue used when none is given
+	MaxAttempts  int           // attempts before a job is marked as failed
+	RetryAfter   time.Duration // reserved jobs are released after this long (crashed worker)
+	Backoff      time.Duration // delay before retrying a failed attempt
+	PollInterval time.Duration // idle wait between polls when all queues are empty
+}
+
+// StockConfig controls low-stock alerts. Products without their own
+// low_stock_threshold use LowThreshold; 0 alerts only for products that set one.
+type StockConfig struct {
+	LowThreshold  int
+	AlertInterval time.Duration // how often the scheduler scans for low stock
+	AlertEmail    bool          // email the product owner
+	AlertWebhook  string        // POST alerts to this URL when set
+}
+
+// ReservationConfig controls how long reserved stock is held before the
+// scheduler returns it to the product
+type ReservationConfig struct {
+	TTL          time.Duration
+	ReapInterval time.Duration // how often the scheduler expires reservations
+}
+
+// CurrencyConfig lists the currencies prices may use. Entries are ISO 4217
+// codes, optionally with the number of decimal places ("XAU:4").
+type CurrencyConfig struct {
+	Default   string
+	Supported []string
+}
+
+// ExchangeConfig selects where exchange rates for display prices come from.
+// The fixed driver uses Rates ("EUR:0.92") from the default currency; the api
+// driver fetches APIURL, where {base} is replaced with the source currency.
+type ExchangeConfig struct {
+	Driver   string // fixed or api
+	Rates    []string
+	APIURL   string
+	Timeout  time.Duration
+	CacheTTL time.Duration // how long api rates are reused
+}
+
+// Listing count modes
+const (
+	CountExact    = "exact"
+	CountEstimate = "estimate"
+)
+
+// ListingConfig controls how paginated listings count their rows. In estimate
+// mode, listings the planner expects to match at least EstimateThreshold rows
+// report its estimate instead of running COUNT(*); smaller ones are counted.
+type ListingConfig struct {
+	CountMode         string // exact or estimate
+	EstimateThreshold int64
+}
+
+// HealthConfig controls the circuit breakers around soft dependencies (mail,
+// exchange rates). A breaker opens after BreakerFailures consecutive failures
+// and fails calls fast for BreakerCooldown before trying the dependency again.
+type HealthConfig struct {
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// LoadShedConfig makes the API answer 503 right away while it is overloaded:
+// when more than MaxInFlight requests are being served, or when at least
+// MaxPoolWaits requests had to wait for a database connection within the last
+// Window. A limit of 0 disables that check.
+type LoadShedConfig struct {
+	MaxInFlight  int
+	MaxPoolWaits int64
+	Window       time.Duration // how often pool waits are sampled, and how long shedding lasts
+}
+
+// ConcurrencyConfig caps the requests served at once by heavy route groups.
+// A request over the cap waits up to Wait for a slot and is then rejected with
+// 429. A limit of 0 disables it.
+type ConcurrencyConfig struct {
+	Exports int // CSV exports
+	Reports int // admin reports
+	Wait    time.Duration
+}
+
+// AccountConfig controls self-serve account changes. Emailed links point at
+// URL. Export download links expire after ExportTTL, when the export files are
+// removed; email change links expire after EmailChangeTTL and password reset
+// links after PasswordResetTTL.
+type AccountConfig struct {
+	URL              string // public base URL of the API, used in emailed links
+	ExportDir        string // storage prefix for data exports
+	ExportTTL        time.Duration
+	EmailChangeTTL   time.Duration
+	PasswordResetTTL time.Duration
+}
+
+// ConsentConfig lists the policies every user must accept, as "name:version"
+// pairs. Publishing a new version requires everyone to accept it again.
+type ConsentConfig struct {
+	Policies []string
+}
+
+// AvatarConfig limits avatar uploads. Uploads larger than MaxBytes or wider or
+// taller than MaxDimension pixels are rejected; thumbnails are ThumbnailSize
+// pixels square.
+type AvatarConfig struct {
+	Dir           string // storage prefix for avatars
+	MaxBytes      int64
+	MaxDimension  int
+	ThumbnailSize int
+}
+
+// QuotaConfig caps the metered API requests each user makes per UTC day and
+// per UTC month. A limit of 0 disables that window.
+type QuotaConfig struct {
+	Daily   int64
+	Monthly int64
+}
+
+// OIDCConfig makes the app an OpenID Connect provider for the registered
+// Clients, given as "client_id:client_secret:redirect_uri". Clients without a
+// secret are public and rely on PKCE alone. Tokens are signed with the RSA key
+// in SigningKeyFile; without one a key is generated at startup, so tokens stop
+// verifying after a restart.
+type OIDCConfig struct {
+	Issuer         string // defaults to APP_URL
+	AuthorizeURL   string // sign-in page of the web app that completes authorization
+	Clients        []string
+	SigningKeyFile string
+	CodeTTL        time.Duration
+	TokenTTL       time.Duration
+}
+
+// SSOConfig lets users sign in through external OpenID Connect identity
+// providers, described in the YAML ConnectionsFile. The IdPs redirect to
+// CallbackURL, a page of the web app that completes the sign-in.
+type SSOConfig struct {
+	ConnectionsFile string
+	CallbackURL     string
+	StateTTL        time.Duration // how long a started sign-in can be completed
+	Timeout         time.Duration // for requests to the IdPs
+}
+
+// AuthBackendConfig lists where passwords are checked at login, tried in
+// order: "local" (the users table) and "ldap". A user unknown to one backend,
+// or one that cannot be reached, falls through to the next.
+type AuthBackendConfig struct {
+	Backends []string
+	LDAP     LDAPConfig
+}
+
+// LDAPConfig finds users by searching BaseDN with UserFilter, where {email}
+// stands for the login email, as BindDN, then checks the password by binding
+// as the entry found. Users are created on their first login from the
+// attributes named here.
+type LDAPConfig struct {
+	URL                string // ldap:// or ldaps://
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string
+	UsernameAttribute  string
+	FirstNameAttribute string
+	LastNameAttribute  string
+	Timeout            time.Duration
+}
+
+// SCIMConfig enables the SCIM 2.0 provisioning API for identity providers
+// presenting Token as a bearer token; without a token the API is off. URL is
+// where the API is served, for resource locations.
+type SCIMConfig struct {
+	Token string
+	URL   string
+}
+
+// OrganizationConfig controls invitations to organizations. Invitation links
+// point at InviteURL, a page of the web app that registers the invitee or
+// accepts the invitation for the signed-in user; they expire after InviteTTL.
+// Invitations are deleted InviteRetention after expiring, and can be resent
+// until then.
+type OrganizationConfig struct {
+	InviteURL       string
+	InviteTTL       time.Duration
+	InviteRetention time.Duration
+}
+
+// SignedURLConfig holds the key of the HMAC signature on links handed out
+// without authentication, such as emailed download links. It is kept apart
+// from the JWT secret so rotating one does not invalidate the other.
+type SignedURLConfig struct {
+	Secret string
+}
+
+// SignedRequestConfig lists the keys API clients sign requests with, as
+// "id:scope|scope:secret" entries ("*" for every scope). Signed requests are
+// accepted within Window of their timestamp, once each. Routes of the
+// Required scopes only accept signed requests; the others accept both.
+type SignedRequestConfig struct {
+	Keys     []string
+	Window   time.Duration
+	Required []string
+}
+
+// CryptoConfig holds the keys of columns encrypted at rest, as "id:base64key"
+// entries with the primary key first, from ENCRYPTION_KEYS or one per line in
+// KeysFile (e.g. written by a KMS or secret manager agent). Older keys stay
+// listed until crypto:rotate has re-encrypted their values.
+type CryptoConfig struct {
+	Keys     []string
+	KeysFile string
+}
+
+// TenancyConfig routes large tenants to databases of their own, listed with
+// their DSNs in the YAML DatabasesFile. Requests name their tenant in Header;
+// tenants not in the file use the main database. A tenant's pool is opened on
+// first use, holds at most MaxOpenConns connections, and is closed after
+// IdleTimeout unused; at most MaxPools are kept open at once.
+type TenancyConfig struct {
+	DatabasesFile string
+	Header        string
+	MaxOpenConns  int
+	MaxIdleConns  int
+	MaxPools      int
+	IdleTimeout   time.Duration
+}
+
+// PublicIDConfig lists the entities given short public IDs for API URLs, as
+// "entity:prefix:length" entries (e.g. "product:prd_:12"). URLs accept the
+// UUID of any entity as well; entities not listed get no new public IDs.
+type PublicIDConfig struct {
+	Entities []string
+}
+
+// SettingsConfig sets how long runtime settings are cached. Changes made
+// through one instance apply there at once, and on other instances within
+// CacheTTL; 0 reads them from the database every time.
+type SettingsConfig struct {
+	CacheTTL time.Duration
+}
+
+// ActivityConfig sets how long activity feed entries are kept; older ones are
+// pruned daily. 0 keeps them forever.
+type ActivityConfig struct {
+	Retention time.Duration
+}
+
+// SavedSearchConfig caps the saved searches each user keeps and sets how
+// often those with notifications on are checked for new products
+type SavedSearchConfig struct {
+	MaxPerUser    int
+	CheckInterval time.Duration
+}
+
+// ExportConfig controls export jobs. Files are kept under Dir for Retention
+// after they are built; each download link from the status endpoint is valid
+// for LinkTTL, or until the file is removed if that is sooner.
+type ExportConfig struct {
+	Dir       string // storage prefix for export files
+	Retention time.Duration
+	LinkTTL   time.Duration
+}
+
+// ImportConfig controls import jobs. Uploads up to MaxBytes are kept under Dir
+// until Retention after they were uploaded; committing applies BatchSize rows
+// per transaction.
+type ImportConfig struct {
+	Dir       string // storage prefix for uploaded import files
+	MaxBytes  int64
+	BatchSize int
+	Retention time.Duration
+}
+
+// ScannerConfig selects the virus scanner uploads pass before they are
+// stored: none, clamav (a clamd daemon at ClamAVAddress, tcp://host:port or
+// unix:///path) or eicar, which only detects the EICAR test file. Infected
+// uploads are kept under QuarantineDir for review.
+type ScannerConfig struct {
+	Driver        string
+	ClamAVAddress string
+	Timeout       time.Duration
+	QuarantineDir string // storage prefix for infected uploads
+}
+
+// ProductImageConfig limits product image uploads. Uploads larger than
+// MaxBytes or wider or taller than MaxDimension pixels are rejected, and a
+// product has at most MaxPerProduct images, kept under Dir.
+type ProductImageConfig struct {
+	Dir           string // storage prefix for product images and their variants
+	MaxBytes      int64
+	MaxDimension  int
+	MaxPerProduct int
+}
+
+// StaticConfig serves a frontend from the API's own server, read from Dir or,
+// with Embed, from the build compiled into the binary (see package web). With
+// SPA, page routes that match no file get index.html. Files are cached for
+// MaxAge, those under ImmutablePrefix (content-hashed builds) for a year, and
+// index.html is always revalidated.
+type StaticConfig struct {
+	Dir             string
+	Embed           bool
+	SPA             bool
+	MaxAge          time.Duration
+	ImmutablePrefix string
+}
+
+// MaintenanceConfig takes the whole server down for maintenance: every request
+// but the health checks gets a 503 with Retry-After, as a page for browsers.
+// Message replaces the default explanation.
+type MaintenanceConfig struct {
+	Enabled    bool
+	Message    string
+	RetryAfter time.Duration
+}
+
+// NotifyConfig configures the chat channel operational events are posted to
+type NotifyConfig struct {
+	Driver     string        // slack, teams, log (writes to the logger), null, or array (in-memory, for tests)
+	WebhookURL string        // incoming webhook of the Slack or Teams channel
+	Events     []string      // events to post: migration_failed, job_failed, panic, deploy
+	Throttle   time.Duration // minimum gap between identical messages, so a panic loop posts once
+	Timeout    time.Duration
+}
+
+// CacheConfig sets the store of the generated repository cache decorators.
+// Reads marked //cache:read are kept for TTL; writes marked
+// //cache:invalidate drop every cached read of their repository at once on
+// instances sharing the store, on others through Postgres NOTIFY with Sync,
+// and within TTL without.
+type CacheConfig struct {
+	Driver     string // memory (per instance), or none to read through every time
+	TTL        time.Duration
+	MaxEntries int  // entries the memory store holds before it is cleared
+	Sync       bool // send invalidations of the memory store to other instances
+}
+
+// CounterConfig controls pkg/counter. Increments are spread over Shards rows
+// per counted item, so concurrent requests rarely wait on each other, and
+// the scheduler adds them to the counted rows every FlushInterval, up to
+// FlushBatch pending rows per statement.
+type CounterConfig struct {
+	Shards        int
+	FlushInterval time.Duration
+	FlushBatch    int
+}
+
+// ProductConfig lists the attribute keys products may carry and listings may
+// filter on, e.g. ?attributes[color]=red
+type ProductConfig struct {
+	AttributeKeys []string
+}
+
+// ReportConfig controls how often the scheduler refreshes the materialized
+// views reports read from, so reports lag the data by up to RefreshInterval
+type ReportConfig struct {
+	RefreshInterval time.Duration
+}
+
+// PartitionConfig controls the monthly partitions of tb_audit_logs and
+// tb_activities. The scheduler keeps Premake months of partitions ahead and
+// drops those older than the table's retention: AUDIT_RETENTION, or
+// ACTIVITY_RETENTION for activities. 0 keeps them forever.
+type PartitionConfig struct {
+	Premake int
+}
+
+// ArchiveConfig controls pkg/archive. The daily archive task moves closed
+// reservations older than ReservationsAfter out of tb_reservations, Batch rows
+// per transaction, to tb_reservations_archive, or with Destination "storage"
+// to JSON lines files in storage. 0 keeps them in place.
+type ArchiveConfig struct {
+	Destination       string
+	Batch             int
+	ReservationsAfter time.Duration
+}
+
+// RetentionConfig controls pkg/retention. The daily retention task deletes
+// audit logs, notifications and soft-deleted products older than their
+// retention, Batch rows per transaction; 0 keeps them forever. With DryRun it
+// only logs how many rows it would delete.
+type RetentionConfig struct {
+	AuditLogs       time.Duration
+	Notifications   time.Duration
+	DeletedProducts time.Duration
+	Batch           int
+	DryRun          bool
+}
+
 func Load() *Config {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	env := getEnv("ENV", "development")
+
+	// Tests capture mail, jobs and notifications in memory and development logs mail
+	// unless a driver is set explicitly
+	mailDriver, queueDriver, notifyDriver := "smtp", "database", "null"
+	switch env {
+	case "test":
+		mailDriver, queueDriver, notifyDriver = "array", "array", "array"
+	case "development":
+		mailDriver = "log"
+	}
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -80,6 +565,11 @@ func Load() *Config {
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),    // 🆕 เพิ่มใหม่
 			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 100),   // 🆕 เพิ่มใหม่
 			ConnMaxLifetime: getEnvAsInt("DB_CONN_MAX_LIFETIME", 60), // 🆕 เพิ่มใหม่ (60 นาที)
+			UUIDVersion:     getEnv("DB_UUID_VERSION", "v4"),
+			RLS:             getEnvAsBool("DB_RLS", false),
+			RetryAttempts:   getEnvAsInt("DB_RETRY_ATTEMPTS", 3),
+			RetryBaseDelay:  getEnvAsDuration("DB_RETRY_BASE_DELAY", 50*time.Millisecond),
+			RetryMaxDelay:   getEnvAsDuration("DB_RETRY_MAX_DELAY", time.Second),
 		},
 		Server: ServerConfig{
 			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
@@ -90,12 +580,32 @@ func Load() *Config {
 		JWT: JWTConfig{
 			Secret:          getEnv("JWT_SECRET", "your-super-secret-jwt-key"),
 			ExpirationHours: getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
+			RefreshTTL:      getEnvAsDuration("JWT_REFRESH_TTL", 7*24*time.Hour),
+			RememberMeTTL:   getEnvAsDuration("JWT_REMEMBER_ME_TTL", 30*24*time.Hour),
+			MaxLifetime:     getEnvAsDuration("JWT_SESSION_MAX_LIFETIME", 90*24*time.Hour),
+		},
+		SignedURL: SignedURLConfig{
+			Secret: getEnv("SIGNED_URL_SECRET", "your-super-secret-signed-url-key"),
+		},
+		SignedRequest: SignedRequestConfig{
+			Keys:     getEnvAsList("SIGNED_REQUEST_KEYS", nil),
+			Window:   getEnvAsDuration("SIGNED_REQUEST_WINDOW", 5*time.Minute),
+			Required: getEnvAsList("SIGNED_REQUEST_REQUIRED_SCOPES", nil),
+		},
+		Throttle: ThrottleConfig{
+			Window:            getEnvAsDuration("AUTH_THROTTLE_WINDOW", time.Minute),
+			LoginPerIP:        getEnvAsInt("AUTH_LOGIN_MAX_PER_IP", 20),
+			LoginPerEmail:     getEnvAsInt("AUTH_LOGIN_MAX_PER_EMAIL", 5),
+			RegisterPerIP:     getEnvAsInt("AUTH_REGISTER_MAX_PER_IP", 10),
+			RegisterPerEmail:  getEnvAsInt("AUTH_REGISTER_MAX_PER_EMAIL", 3),
+			AvailabilityPerIP: getEnvAsInt("AUTH_AVAILABILITY_MAX_PER_IP", 30),
 		},
 		Log: LogConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
 		Email: EmailConfig{
+			Driver:             getEnv("MAIL_DRIVER", mailDriver),
 			Host:               getEnv("SMTP_HOST", "smtp.gmail.com"),
 			Port:               getEnvAsInt("SMTP_PORT", 587),
 			Username:           getEnv("SMTP_USERNAME", ""),
@@ -107,7 +617,220 @@ func Load() *Config {
 			RetryDelay:         getEnvAsDuration("EMAIL_RETRY_DELAY", 1*time.Second),
 			InsecureSkipVerify: getEnvAsBool("EMAIL_INSECURE_SKIP_VERIFY", false),
 		},
-		Env: getEnv("ENV", "development"),
+		Storage: StorageConfig{
+			Driver:    getEnv("STORAGE_DRIVER", "local"),
+			LocalPath: getEnv("STORAGE_LOCAL_PATH", "./storage"),
+		},
+		Backup: BackupConfig{
+			Dir:  getEnv("BACKUP_DIR", "backups"),
+			Keep: getEnvAsInt("BACKUP_KEEP", 7),
+		},
+		Queue: QueueConfig{
+			Driver:       getEnv("QUEUE_DRIVER", queueDriver),
+			Default:      getEnv("QUEUE_DEFAULT", "default"),
+			MaxAttempts:  getEnvAsInt("QUEUE_MAX_ATTEMPTS", 3),
+			RetryAfter:   getEnvAsDuration("QUEUE_RETRY_AFTER", 90*time.Second),
+			Backoff:      getEnvAsDuration("QUEUE_BACKOFF", 10*time.Second),
+			PollInterval: getEnvAsDuration("QUEUE_POLL_INTERVAL", time.Second),
+		},
+		Stock: StockConfig{
+			LowThreshold:  getEnvAsInt("STOCK_LOW_THRESHOLD", 5),
+			AlertInterval: getEnvAsDuration("STOCK_ALERT_INTERVAL", time.Hour),
+			AlertEmail:    getEnvAsBool("STOCK_ALERT_EMAIL", true),
+			AlertWebhook:  getEnv("STOCK_ALERT_WEBHOOK_URL", ""),
+		},
+		Reservation: ReservationConfig{
+			TTL:          getEnvAsDuration("RESERVATION_TTL", 15*time.Minute),
+			ReapInterval: getEnvAsDuration("RESERVATION_REAP_INTERVAL", time.Minute),
+		},
+		Currency: CurrencyConfig{
+			Default:   getEnv("DEFAULT_CURRENCY", "USD"),
+			Supported: getEnvAsList("CURRENCIES", []string{"USD", "EUR", "GBP", "JPY", "THB"}),
+		},
+		Exchange: ExchangeConfig{
+			Driver:   getEnv("EXCHANGE_DRIVER", "fixed"),
+			Rates:    getEnvAsList("EXCHANGE_RATES", nil),
+			APIURL:   getEnv("EXCHANGE_API_URL", "https://open.er-api.com/v6/latest/{base}"),
+			Timeout:  getEnvAsDuration("EXCHANGE_TIMEOUT", 5*time.Second),
+			CacheTTL: getEnvAsDuration("EXCHANGE_CACHE_TTL", time.Hour),
+		},
+		Listing: ListingConfig{
+			CountMode:         getEnv("LISTING_COUNT_MODE", CountExact),
+			EstimateThreshold: int64(getEnvAsInt("LISTING_ESTIMATE_THRESHOLD", 100000)),
+		},
+		Health: HealthConfig{
+			BreakerFailures: getEnvAsInt("BREAKER_FAILURES", 5),
+			BreakerCooldown: getEnvAsDuration("BREAKER_COOLDOWN", 30*time.Second),
+		},
+		LoadShed: LoadShedConfig{
+			MaxInFlight:  getEnvAsInt("SHED_MAX_IN_FLIGHT", 0),
+			MaxPoolWaits: int64(getEnvAsInt("SHED_MAX_POOL_WAITS", 0)),
+			Window:       getEnvAsDuration("SHED_WINDOW", time.Second),
+		},
+		Concurrency: ConcurrencyConfig{
+			Exports: getEnvAsInt("CONCURRENCY_EXPORTS", 2),
+			Reports: getEnvAsInt("CONCURRENCY_REPORTS", 4),
+			Wait:    getEnvAsDuration("CONCURRENCY_WAIT", 2*time.Second),
+		},
+		Account: AccountConfig{
+			URL:              strings.TrimRight(getEnv("APP_URL", "http://localhost:8080"), "/"),
+			ExportDir:        getEnv("ACCOUNT_EXPORT_DIR", "exports"),
+			ExportTTL:        getEnvAsDuration("ACCOUNT_EXPORT_TTL", 24*time.Hour),
+			EmailChangeTTL:   getEnvAsDuration("ACCOUNT_EMAIL_CHANGE_TTL", 24*time.Hour),
+			PasswordResetTTL: getEnvAsDuration("ACCOUNT_PASSWORD_RESET_TTL", time.Hour),
+		},
+		Consent: ConsentConfig{
+			Policies: getEnvAsList("CONSENT_POLICIES", nil),
+		},
+		Quota: QuotaConfig{
+			Daily:   int64(getEnvAsInt("QUOTA_DAILY", 10000)),
+			Monthly: int64(getEnvAsInt("QUOTA_MONTHLY", 200000)),
+		},
+		OIDC: OIDCConfig{
+			Issuer:         strings.TrimRight(getEnv("OIDC_ISSUER", getEnv("APP_URL", "http://localhost:8080")), "/"),
+			AuthorizeURL:   getEnv("OIDC_AUTHORIZE_URL", ""),
+			Clients:        getEnvAsList("OIDC_CLIENTS", nil),
+			SigningKeyFile: getEnv("OIDC_SIGNING_KEY_FILE", ""),
+			CodeTTL:        getEnvAsDuration("OIDC_CODE_TTL", 5*time.Minute),
+			TokenTTL:       getEnvAsDuration("OIDC_TOKEN_TTL", time.Hour),
+		},
+		SSO: SSOConfig{
+			ConnectionsFile: getEnv("SSO_CONNECTIONS_FILE", ""),
+			CallbackURL:     getEnv("SSO_CALLBACK_URL", strings.TrimRight(getEnv("APP_URL", "http://localhost:8080"), "/")+"/sso/callback"),
+			StateTTL:        getEnvAsDuration("SSO_STATE_TTL", 10*time.Minute),
+			Timeout:         getEnvAsDuration("SSO_TIMEOUT", 10*time.Second),
+		},
+		AuthBackend: AuthBackendConfig{
+			Backends: getEnvAsList("AUTH_BACKENDS", []string{"local"}),
+			LDAP: LDAPConfig{
+				URL:                getEnv("LDAP_URL", ""),
+				StartTLS:           getEnvAsBool("LDAP_START_TLS", false),
+				InsecureSkipVerify: getEnvAsBool("LDAP_INSECURE_SKIP_VERIFY", false),
+				BindDN:             getEnv("LDAP_BIND_DN", ""),
+				BindPassword:       getEnv("LDAP_BIND_PASSWORD", ""),
+				BaseDN:             getEnv("LDAP_BASE_DN", ""),
+				UserFilter:         getEnv("LDAP_USER_FILTER", "(&(objectClass=person)(mail={email}))"),
+				UsernameAttribute:  getEnv("LDAP_USERNAME_ATTRIBUTE", "uid"),
+				FirstNameAttribute: getEnv("LDAP_FIRST_NAME_ATTRIBUTE", "givenName"),
+				LastNameAttribute:  getEnv("LDAP_LAST_NAME_ATTRIBUTE", "sn"),
+				Timeout:            getEnvAsDuration("LDAP_TIMEOUT", 5*time.Second),
+			},
+		},
+		SCIM: SCIMConfig{
+			Token: getEnv("SCIM_TOKEN", ""),
+			URL:   strings.TrimRight(getEnv("APP_URL", "http://localhost:8080"), "/") + "/scim/v2",
+		},
+		Org: OrganizationConfig{
+			InviteURL:       getEnv("ORG_INVITE_URL", strings.TrimRight(getEnv("APP_URL", "http://localhost:8080"), "/")+"/invitations/accept"),
+			InviteTTL:       getEnvAsDuration("ORG_INVITE_TTL", 7*24*time.Hour),
+			InviteRetention: getEnvAsDuration("ORG_INVITE_RETENTION", 30*24*time.Hour),
+		},
+		Crypto: CryptoConfig{
+			Keys:     getEnvAsList("ENCRYPTION_KEYS", nil),
+			KeysFile: getEnv("ENCRYPTION_KEYS_FILE", ""),
+		},
+		PublicID: PublicIDConfig{
+			Entities: getEnvAsList("PUBLIC_IDS", []string{"product:prd_:12", "organization:org_:12", "reservation:rsv_:12"}),
+		},
+		Settings: SettingsConfig{
+			CacheTTL: getEnvAsDuration("SETTINGS_CACHE_TTL", 30*time.Second),
+		},
+		Activity: ActivityConfig{
+			Retention: getEnvAsDuration("ACTIVITY_RETENTION", 90*24*time.Hour),
+		},
+		SavedSearch: SavedSearchConfig{
+			MaxPerUser:    getEnvAsInt("SAVED_SEARCHES_PER_USER", 20),
+			CheckInterval: getEnvAsDuration("SAVED_SEARCH_CHECK_INTERVAL", time.Hour),
+		},
+		Export: ExportConfig{
+			Dir:       getEnv("EXPORT_DIR", "export-jobs"),
+			Retention: getEnvAsDuration("EXPORT_RETENTION", 24*time.Hour),
+			LinkTTL:   getEnvAsDuration("EXPORT_LINK_TTL", 15*time.Minute),
+		},
+		Import: ImportConfig{
+			Dir:       getEnv("IMPORT_DIR", "imports"),
+			MaxBytes:  int64(getEnvAsInt("IMPORT_MAX_BYTES", 10<<20)),
+			BatchSize: getEnvAsInt("IMPORT_BATCH_SIZE", 500),
+			Retention: getEnvAsDuration("IMPORT_RETENTION", 24*time.Hour),
+		},
+		Scanner: ScannerConfig{
+			Driver:        getEnv("SCANNER_DRIVER", "none"),
+			ClamAVAddress: getEnv("CLAMAV_ADDRESS", "tcp://localhost:3310"),
+			Timeout:       getEnvAsDuration("SCANNER_TIMEOUT", 30*time.Second),
+			QuarantineDir: getEnv("SCANNER_QUARANTINE_DIR", "quarantine"),
+		},
+		Images: ProductImageConfig{
+			Dir:           getEnv("PRODUCT_IMAGE_DIR", "product-images"),
+			MaxBytes:      int64(getEnvAsInt("PRODUCT_IMAGE_MAX_BYTES", 10<<20)),
+			MaxDimension:  getEnvAsInt("PRODUCT_IMAGE_MAX_DIMENSION", 6000),
+			MaxPerProduct: getEnvAsInt("PRODUCT_IMAGES_PER_PRODUCT", 10),
+		},
+		Static: StaticConfig{
+			Dir:             getEnv("STATIC_DIR", ""),
+			Embed:           getEnvAsBool("STATIC_EMBED", false),
+			SPA:             getEnvAsBool("STATIC_SPA", true),
+			MaxAge:          getEnvAsDuration("STATIC_CACHE_MAX_AGE", time.Hour),
+			ImmutablePrefix: getEnv("STATIC_IMMUTABLE_PREFIX", "assets/"),
+		},
+		Maintenance: MaintenanceConfig{
+			Enabled:    getEnvAsBool("MAINTENANCE_MODE", false),
+			Message:    getEnv("MAINTENANCE_MESSAGE", ""),
+			RetryAfter: getEnvAsDuration("MAINTENANCE_RETRY_AFTER", 10*time.Minute),
+		},
+		Notify: NotifyConfig{
+			Driver:     getEnv("NOTIFY_DRIVER", notifyDriver),
+			WebhookURL: getEnv("NOTIFY_WEBHOOK_URL", ""),
+			Events:     getEnvAsList("NOTIFY_EVENTS", []string{"migration_failed", "job_failed", "panic", "deploy"}),
+			Throttle:   getEnvAsDuration("NOTIFY_THROTTLE", time.Minute),
+			Timeout:    getEnvAsDuration("NOTIFY_TIMEOUT", 5*time.Second),
+		},
+		Cache: CacheConfig{
+			Driver:     getEnv("CACHE_DRIVER", "none"),
+			TTL:        getEnvAsDuration("CACHE_TTL", time.Minute),
+			MaxEntries: getEnvAsInt("CACHE_MAX_ENTRIES", 10000),
+			Sync:       getEnvAsBool("CACHE_SYNC", true),
+		},
+		Counter: CounterConfig{
+			Shards:        getEnvAsInt("COUNTER_SHARDS", 8),
+			FlushInterval: getEnvAsDuration("COUNTER_FLUSH_INTERVAL", time.Minute),
+			FlushBatch:    getEnvAsInt("COUNTER_FLUSH_BATCH", 1000),
+		},
+		Products: ProductConfig{
+			AttributeKeys: getEnvAsList("PRODUCT_ATTRIBUTES", []string{"color", "size", "material"}),
+		},
+		Report: ReportConfig{
+			RefreshInterval: getEnvAsDuration("REPORT_REFRESH_INTERVAL", 15*time.Minute),
+		},
+		Partition: PartitionConfig{
+			Premake: getEnvAsInt("PARTITION_PREMAKE", 3),
+		},
+		Archive: ArchiveConfig{
+			Destination:       getEnv("ARCHIVE_DESTINATION", "table"),
+			Batch:             getEnvAsInt("ARCHIVE_BATCH", 1000),
+			ReservationsAfter: getEnvAsDuration("ARCHIVE_RESERVATIONS_AFTER", 0),
+		},
+		Retention: RetentionConfig{
+			AuditLogs:       getEnvAsDuration("AUDIT_RETENTION", 180*24*time.Hour),
+			Notifications:   getEnvAsDuration("NOTIFICATION_RETENTION", 90*24*time.Hour),
+			DeletedProducts: getEnvAsDuration("DELETED_PRODUCT_RETENTION", 30*24*time.Hour),
+			Batch:           getEnvAsInt("RETENTION_BATCH", 1000),
+			DryRun:          getEnvAsBool("RETENTION_DRY_RUN", false),
+		},
+		Tenancy: TenancyConfig{
+			DatabasesFile: getEnv("TENANT_DATABASES_FILE", ""),
+			Header:        getEnv("TENANT_HEADER", "X-Tenant-ID"),
+			MaxOpenConns:  getEnvAsInt("TENANT_DB_MAX_OPEN_CONNS", 10),
+			MaxIdleConns:  getEnvAsInt("TENANT_DB_MAX_IDLE_CONNS", 2),
+			MaxPools:      getEnvAsInt("TENANT_MAX_POOLS", 20),
+			IdleTimeout:   getEnvAsDuration("TENANT_IDLE_TIMEOUT", 10*time.Minute),
+		},
+		Avatar: AvatarConfig{
+			Dir:           getEnv("AVATAR_DIR", "avatars"),
+			MaxBytes:      int64(getEnvAsInt("AVATAR_MAX_BYTES", 2<<20)),
+			MaxDimension:  getEnvAsInt("AVATAR_MAX_DIMENSION", 4096),
+			ThumbnailSize: getEnvAsInt("AVATAR_THUMBNAIL_SIZE", 128),
+		},
+		Env: env,
 	}
 }
 
@@ -136,6 +859,19 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvAsList(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return defaultValue
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		return strings.ToLower(value) == "true"
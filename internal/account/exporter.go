@@ -0,0 +1,34 @@
+package account
+
+import (
+	"context"
+	"io"
+
+	"go-clean-gin/internal/entity"
+)
+
+// Exporter builds account export jobs: everything stored about the user who
+// requested it, like the emailed export
+type Exporter struct {
+	usecase AccountUsecase
+}
+
+func NewExporter(usecase AccountUsecase) *Exporter {
+	return &Exporter{usecase: usecase}
+}
+
+func (e *Exporter) Formats() []string {
+	return []string{entity.ExportFormatJSON, entity.ExportFormatZIP}
+}
+
+func (e *Exporter) AdminOnly() bool {
+	return false
+}
+
+func (e *Exporter) Params() interface{} {
+	return nil
+}
+
+func (e *Exporter) Export(ctx context.Context, export *entity.Export, params interface{}, w io.Writer) error {
+	return e.usecase.WriteExport(ctx, export.UserID, export.Format, w)
+}
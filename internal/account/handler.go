@@ -0,0 +1,167 @@
+package account
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AccountHandler struct {
+	usecase AccountUsecase
+}
+
+func NewAccountHandler(usecase AccountUsecase) *AccountHandler {
+	return &AccountHandler{
+		usecase: usecase,
+	}
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Delete the current user's account after confirming their password. The account is signed out at once and its personal data is anonymized in the background.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.DeleteAccountRequest true "Password confirmation"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/account [delete]
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.DeleteAccountRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	if err := h.usecase.DeleteAccount(c.Request.Context(), userID, &req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to delete account", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to delete account", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Account deleted successfully", nil)
+}
+
+// RequestExport godoc
+// @Summary Export account data
+// @Description Start exporting everything stored about the current user (profile, products, audit trail). A download link is emailed when the export is ready.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param format query string false "Export format" Enums(json, zip) default(json)
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/account/export [get]
+func (h *AccountHandler) RequestExport(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.AccountExportRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	if err := h.usecase.RequestExport(c.Request.Context(), userID, &req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to request export", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to request export", nil)
+		}
+		return
+	}
+
+	response.Success(c, 202, "Export started, a download link will be emailed when it is ready", nil)
+}
+
+// DownloadExport godoc
+// @Summary Download account export
+// @Description Download a finished export through the signed link from the export email. Altered or expired links are rejected with 403 LINK_INVALID.
+// @Tags auth
+// @Produce application/json
+// @Produce application/zip
+// @Param file query string true "Export file"
+// @Param expires query int true "Link expiry, Unix seconds"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/account/export/download [get]
+func (h *AccountHandler) DownloadExport(c *gin.Context) {
+	file := c.Query("file")
+	if file == "" {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid download link", nil)
+		return
+	}
+
+	reader, err := h.usecase.OpenExport(c.Request.Context(), file)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to open export", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to download export", nil)
+		}
+		return
+	}
+	defer reader.Close()
+
+	contentType := "application/json"
+	if path.Ext(file) == "."+entity.ExportFormatZIP {
+		contentType = "application/zip"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, path.Base(file)))
+	c.Status(200)
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to stream export", zap.Error(err))
+	}
+}
@@ -0,0 +1,102 @@
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/account"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Request(http.MethodDelete, "/api/v1/auth/account", entity.DeleteAccountRequest{
+		Password: apitest.DefaultPassword,
+	}).Do().AssertStatus(http.StatusOK)
+
+	// The token no longer resolves to a user
+	api.As(user).Get("/api/v1/auth/profile").Do().
+		AssertStatus(http.StatusUnauthorized)
+
+	jobs := api.Container.Queue.(*queue.ArrayQueue).Pushed()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, account.JobAnonymize, jobs[0].Type)
+	}
+}
+
+func TestAccountHandler_DeleteAccount_WrongPassword(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Request(http.MethodDelete, "/api/v1/auth/account", entity.DeleteAccountRequest{
+		Password: "not-my-password",
+	}).Do().
+		AssertStatus(http.StatusUnauthorized).
+		AssertErrorCode(errors.ErrInvalidCredentials)
+}
+
+func TestAccountHandler_RequestExport(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Get("/api/v1/auth/account/export").Query("format", "zip").Do().
+		AssertStatus(http.StatusAccepted)
+
+	jobs := api.Container.Queue.(*queue.ArrayQueue).Pushed()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, account.JobExport, jobs[0].Type)
+	}
+}
+
+func TestAccountHandler_DownloadExport_InvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Get("/api/v1/auth/account/export/download").
+		Query("file", "exports/someone/20240101T000000Z.json").
+		Query("expires", "4102444800").
+		Query("signature", "deadbeef").
+		Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrLinkInvalid)
+}
+
+func TestAccountHandler_DownloadExport(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	ctx := context.Background()
+
+	file := "exports/someone/20240101T000000Z.json"
+	require.NoError(t, api.Container.Storage.Put(ctx, file, strings.NewReader(`{"profile":{}}`)))
+
+	link, _, err := api.Container.Signer.Sign("/api/v1/auth/account/export/download?file="+url.QueryEscape(file), time.Hour)
+	require.NoError(t, err)
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+
+	res := api.WithoutContract().Get(parsed.Path)
+	for key, values := range parsed.Query() {
+		res.Query(key, values[0])
+	}
+	res.Do().AssertStatus(http.StatusOK)
+}
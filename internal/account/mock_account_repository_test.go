@@ -0,0 +1,66 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package account
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountRepository is a testify mock of AccountRepository
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAccountRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAccountRepository) GetProductsByOwner(ctx context.Context, userID uuid.UUID) ([]*entity.Product, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAccountRepository) GetAuditLogsByActor(ctx context.Context, userID uuid.UUID) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.AuditLog
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.AuditLog)
+	}
+
+	return r0, args.Error(1)
+}
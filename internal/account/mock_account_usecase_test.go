@@ -0,0 +1,76 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package account
+
+import (
+	"context"
+	"io"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountUsecase is a testify mock of AccountUsecase
+type MockAccountUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAccountUsecase) DeleteAccount(ctx context.Context, userID uuid.UUID, req *entity.DeleteAccountRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) DeprovisionAccount(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) AnonymizeAccount(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) RequestExport(ctx context.Context, userID uuid.UUID, req *entity.AccountExportRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) BuildExport(ctx context.Context, userID uuid.UUID, format string) (*entity.AccountExportLink, error) {
+	args := m.Called(ctx, userID, format)
+
+	var r0 *entity.AccountExportLink
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AccountExportLink)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAccountUsecase) WriteExport(ctx context.Context, userID uuid.UUID, format string, w io.Writer) error {
+	args := m.Called(ctx, userID, format, w)
+	return args.Error(0)
+}
+
+func (m *MockAccountUsecase) OpenExport(ctx context.Context, file string) (io.ReadCloser, error) {
+	args := m.Called(ctx, file)
+
+	var r0 io.ReadCloser
+	if v := args.Get(0); v != nil {
+		r0 = v.(io.ReadCloser)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAccountUsecase) PruneExports(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
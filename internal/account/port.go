@@ -0,0 +1,31 @@
+package account
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// AccountUsecase defines the business logic interface for self-serve account
+// deletion and data export
+type AccountUsecase interface {
+	DeleteAccount(ctx context.Context, userID uuid.UUID, req *entity.DeleteAccountRequest) error
+	DeprovisionAccount(ctx context.Context, userID uuid.UUID) error
+	AnonymizeAccount(ctx context.Context, userID uuid.UUID) error
+	RequestExport(ctx context.Context, userID uuid.UUID, req *entity.AccountExportRequest) error
+	BuildExport(ctx context.Context, userID uuid.UUID, format string) (*entity.AccountExportLink, error)
+	WriteExport(ctx context.Context, userID uuid.UUID, format string, w io.Writer) error
+	OpenExport(ctx context.Context, file string) (io.ReadCloser, error)
+	PruneExports(ctx context.Context) (int, error)
+}
+
+// AccountRepository defines the data access interface for account deletion and export
+type AccountRepository interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	AnonymizeUser(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetProductsByOwner(ctx context.Context, userID uuid.UUID) ([]*entity.Product, error)
+	GetAuditLogsByActor(ctx context.Context, userID uuid.UUID) ([]*entity.AuditLog, error)
+}
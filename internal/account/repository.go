@@ -0,0 +1,122 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{
+		db: db,
+	}
+}
+
+func (r *accountRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// DeleteUser soft deletes the user, which signs them out everywhere
+func (r *accountRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Where("id = ?", userID).Delete(&entity.User{}).Error
+}
+
+// AnonymizeUser scrubs the personal data of a deleted user: the user row, the
+// owner name copied into product listings, the IPs in the audit trail, and the
+// user's notifications and refresh tokens. Products and audit entries
+// themselves are kept. It reports false when the user does not exist or has
+// not been deleted.
+func (r *accountRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	anonymized := false
+
+	err := tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Placeholders keep the unique email and username indexes satisfied
+		result := tx.Unscoped().Model(&entity.User{}).
+			Where("id = ? AND deleted_at IS NOT NULL", userID).
+			UpdateColumns(map[string]interface{}{
+				"email":      fmt.Sprintf("deleted-%s@deleted.invalid", userID),
+				"username":   fmt.Sprintf("deleted-%s", userID),
+				"first_name": "Deleted",
+				"last_name":  "User",
+				"password":   "",
+				"is_active":  false,
+
+				"pending_email":            nil,
+				"pending_email_expires_at": nil,
+				"email_change_old_token":   "",
+				"email_change_new_token":   "",
+
+				"password_reset_token":      "",
+				"password_reset_expires_at": nil,
+
+				"avatar_url":        "",
+				"avatar_path":       "",
+				"avatar_thumb_path": "",
+
+				"external_id": "",
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		anonymized = true
+
+		if err := tx.Model(&entity.ProductReadModel{}).Where("created_by = ?", userID).
+			UpdateColumn("owner_name", "Deleted User").Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		// artisan:module oidc
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.OIDCAuthorizationCode{}).Error; err != nil {
+			return err
+		}
+		// artisan:end
+		if err := tx.Model(&entity.AuditLog{}).Where("actor_id = ?", userID).
+			UpdateColumn("ip", "").Error; err != nil {
+			return err
+		}
+		// artisan:module notification
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.Notification{}).Error; err != nil {
+			return err
+		}
+		// artisan:end
+		return nil
+	})
+
+	return anonymized, err
+}
+
+func (r *accountRepository) GetProductsByOwner(ctx context.Context, userID uuid.UUID) ([]*entity.Product, error) {
+	var products []*entity.Product
+	err := tenancy.Conn(ctx, r.db).Where("created_by = ?", userID).Order("created_at").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *accountRepository) GetAuditLogsByActor(ctx context.Context, userID uuid.UUID) ([]*entity.AuditLog, error) {
+	var logs []*entity.AuditLog
+	err := tenancy.Conn(ctx, r.db).Where("actor_id = ?", userID).Order("created_at").Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
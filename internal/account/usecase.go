@@ -0,0 +1,356 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/signedurl"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Job types pushed by the account usecase; their handlers are registered in
+// internal/jobs
+const (
+	JobAnonymize = "account:anonymize"
+	JobExport    = "account:export"
+)
+
+// AnonymizePayload is the payload of a JobAnonymize job
+type AnonymizePayload struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// ExportPayload is the payload of a JobExport job
+type ExportPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	Format string    `json:"format"`
+}
+
+// exportTimeFormat names export files after the time they were built, which
+// PruneExports reads back
+const exportTimeFormat = "20060102T150405Z"
+
+type accountUsecase struct {
+	repo    AccountRepository
+	config  *config.Config
+	queue   queue.Queue
+	storage storage.Storage
+	signer  *signedurl.Signer
+	clock   clock.Clock
+}
+
+func NewAccountUsecase(repo AccountRepository, config *config.Config, jobQueue queue.Queue, store storage.Storage, signer *signedurl.Signer, clk clock.Clock) AccountUsecase {
+	return &accountUsecase{
+		repo:    repo,
+		config:  config,
+		queue:   jobQueue,
+		storage: store,
+		signer:  signer,
+		clock:   clk,
+	}
+}
+
+// DeleteAccount deletes the user's account after checking their password
+func (u *accountUsecase) DeleteAccount(ctx context.Context, userID uuid.UUID, req *entity.DeleteAccountRequest) error {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return errors.ErrInvalidCredentialsError
+	}
+
+	return u.DeprovisionAccount(ctx, userID)
+}
+
+// DeprovisionAccount soft deletes the user and queues the anonymization of
+// their personal data. Identity providers deprovision users through this.
+func (u *accountUsecase) DeprovisionAccount(ctx context.Context, userID uuid.UUID) error {
+	// Queue first: the job retries until the user is deleted, so a failed
+	// delete below leaves no account that was deleted but never anonymized
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobAnonymize, AnonymizePayload{UserID: userID}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue account anonymization", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete account", 500)
+	}
+
+	if err := u.repo.DeleteUser(ctx, userID); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete user", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete account", 500)
+	}
+
+	logger.FromContext(ctx).Info("Account deleted", zap.String("user_id", userID.String()))
+	return nil
+}
+
+// AnonymizeAccount scrubs a deleted user's personal data and removes their
+// exports and avatar. It fails while the user is not deleted yet, so the job retries.
+func (u *accountUsecase) AnonymizeAccount(ctx context.Context, userID uuid.UUID) error {
+	anonymized, err := u.repo.AnonymizeUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("anonymize user %s: %w", userID, err)
+	}
+
+	if !anonymized {
+		if _, err := u.repo.GetUserByID(ctx, userID); err == nil {
+			return fmt.Errorf("user %s is not deleted", userID)
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+		// Unknown or already anonymized; files may still be left over
+	}
+
+	// Exports, export job files and avatar files are kept per user, so the
+	// prefixes cover them all
+	prefixes := []string{
+		u.exportPrefix(userID),
+		fmt.Sprintf("%s/%s/", u.config.Export.Dir, userID),
+		fmt.Sprintf("%s/%s/", u.config.Import.Dir, userID),
+		fmt.Sprintf("%s/%s/", u.config.Avatar.Dir, userID),
+	}
+	var files []string
+	for _, prefix := range prefixes {
+		found, err := u.storage.List(ctx, prefix)
+		if err != nil {
+			return fmt.Errorf("list files of user %s: %w", userID, err)
+		}
+		files = append(files, found...)
+	}
+	for _, file := range files {
+		if err := u.storage.Delete(ctx, file); err != nil {
+			return fmt.Errorf("delete %s: %w", file, err)
+		}
+	}
+
+	logger.FromContext(ctx).Info("Account anonymized",
+		zap.String("user_id", userID.String()),
+		zap.Bool("anonymized", anonymized),
+		zap.Int("files_deleted", len(files)),
+	)
+	return nil
+}
+
+// RequestExport queues building the user's data export; the download link is
+// emailed when it is ready
+func (u *accountUsecase) RequestExport(ctx context.Context, userID uuid.UUID, req *entity.AccountExportRequest) error {
+	if _, err := u.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = entity.ExportFormatJSON
+	}
+
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobExport, ExportPayload{UserID: userID, Format: format}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue account export", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to request export", 500)
+	}
+
+	logger.FromContext(ctx).Info("Account export requested",
+		zap.String("user_id", userID.String()), zap.String("format", format))
+	return nil
+}
+
+// BuildExport writes the user's data to storage and returns a signed link to
+// it. It returns nil when the user has been deleted in the meantime.
+func (u *accountUsecase) BuildExport(ctx context.Context, userID uuid.UUID, format string) (*entity.AccountExportLink, error) {
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if format != entity.ExportFormatZIP {
+		format = entity.ExportFormatJSON
+	}
+
+	now := u.clock.Now()
+	var buf bytes.Buffer
+	if err := u.writeExport(ctx, user, format, now, &buf); err != nil {
+		return nil, err
+	}
+	data := buf.Bytes()
+
+	file := u.exportPrefix(userID) + now.UTC().Format(exportTimeFormat) + "." + format
+	if err := u.storage.Put(ctx, file, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("store export: %w", err)
+	}
+
+	link, expiresAt, err := u.signer.Sign(
+		u.config.Account.URL+"/api/v1/auth/account/export/download?"+url.Values{"file": {file}}.Encode(),
+		u.config.Account.ExportTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign export link: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("Account export built",
+		zap.String("user_id", userID.String()), zap.String("file", file), zap.Int("bytes", len(data)))
+
+	return &entity.AccountExportLink{
+		Email:     user.Email,
+		URL:       link,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// WriteExport writes the user's data to w as JSON, or as a ZIP of JSON files.
+// Export jobs build account exports with it.
+func (u *accountUsecase) WriteExport(ctx context.Context, userID uuid.UUID, format string, w io.Writer) error {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return u.writeExport(ctx, user, format, u.clock.Now(), w)
+}
+
+func (u *accountUsecase) writeExport(ctx context.Context, user *entity.User, format string, now time.Time, w io.Writer) error {
+	products, err := u.repo.GetProductsByOwner(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	logs, err := u.repo.GetAuditLogsByActor(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	export := &entity.AccountExport{
+		Profile:    user,
+		Products:   products,
+		AuditLogs:  logs,
+		ExportedAt: now,
+	}
+
+	var data []byte
+	switch format {
+	case entity.ExportFormatZIP:
+		data, err = encodeZIP(export)
+	default:
+		data, err = json.MarshalIndent(export, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("encode export: %w", err)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// OpenExport opens an export. The download route checks the link's signature;
+// the prefix check keeps a signed link from reaching other stored files.
+func (u *accountUsecase) OpenExport(ctx context.Context, file string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(file, u.config.Account.ExportDir+"/") {
+		return nil, errors.ErrLinkInvalidError
+	}
+
+	reader, err := u.storage.Get(ctx, file)
+	if err == storage.ErrNotFound {
+		return nil, errors.ErrExportNotFoundError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to open export", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to open export", 500)
+	}
+	return reader, nil
+}
+
+// PruneExports deletes exports whose download link has expired
+func (u *accountUsecase) PruneExports(ctx context.Context) (int, error) {
+	files, err := u.storage.List(ctx, u.config.Account.ExportDir+"/")
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := u.clock.Now().Add(-u.config.Account.ExportTTL)
+	deleted := 0
+	for _, file := range files {
+		name := path.Base(file)
+		builtAt, err := time.Parse(exportTimeFormat, strings.TrimSuffix(name, path.Ext(name)))
+		if err != nil || !builtAt.Before(cutoff) {
+			continue
+		}
+
+		if err := u.storage.Delete(ctx, file); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (u *accountUsecase) getUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
+	}
+	return user, nil
+}
+
+func (u *accountUsecase) exportPrefix(userID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/", u.config.Account.ExportDir, userID)
+}
+
+// encodeZIP packs each part of the export into its own JSON file
+func encodeZIP(export *entity.AccountExport) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	parts := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"products.json", export.Products},
+		{"audit_logs.json", export.AuditLogs},
+	}
+
+	for _, part := range parts {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     part.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.MarshalIndent(part.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
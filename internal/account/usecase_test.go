@@ -0,0 +1,190 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/signedurl"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func newTestUsecase(t *testing.T, clk clock.Clock) (*MockAccountRepository, *queue.ArrayQueue, AccountUsecase) {
+	cfg := &config.Config{
+		JWT:     config.JWTConfig{Secret: "test-secret"},
+		Queue:   config.QueueConfig{Default: "default"},
+		Account: config.AccountConfig{URL: "http://api.test", ExportDir: "exports", ExportTTL: time.Hour},
+		Avatar:  config.AvatarConfig{Dir: "avatars"},
+	}
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockRepo := new(MockAccountRepository)
+	jobQueue := queue.NewArrayQueue(&cfg.Queue)
+	return mockRepo, jobQueue, NewAccountUsecase(mockRepo, cfg, jobQueue, store, signedurl.New("test-secret", clk), clk)
+}
+
+func TestAccountUsecase_DeleteAccount(t *testing.T) {
+	mockRepo, jobQueue, usecase := newTestUsecase(t, clock.New())
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Password: string(hashed)}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("DeleteUser", mock.Anything, user.ID).Return(nil)
+
+	err := usecase.DeleteAccount(context.Background(), user.ID, &entity.DeleteAccountRequest{Password: "password123"})
+
+	assert.NoError(t, err)
+	if pushed := jobQueue.Pushed(); assert.Len(t, pushed, 1) {
+		assert.Equal(t, JobAnonymize, pushed[0].Type)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAccountUsecase_DeleteAccount_WrongPassword(t *testing.T) {
+	mockRepo, jobQueue, usecase := newTestUsecase(t, clock.New())
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Password: string(hashed)}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	err := usecase.DeleteAccount(context.Background(), user.ID, &entity.DeleteAccountRequest{Password: "wrong"})
+
+	assert.Equal(t, errors.ErrInvalidCredentialsError, err)
+	assert.Empty(t, jobQueue.Pushed())
+	mockRepo.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+}
+
+func TestAccountUsecase_AnonymizeAccount_NotDeletedYet(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase(t, clock.New())
+
+	userID := uuid.New()
+	mockRepo.On("AnonymizeUser", mock.Anything, userID).Return(false, nil)
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+
+	err := usecase.AnonymizeAccount(context.Background(), userID)
+
+	assert.Error(t, err)
+}
+
+func TestAccountUsecase_AnonymizeAccount_DeletesExports(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	mockRepo, _, usecase := newTestUsecase(t, clock.NewFake(now))
+
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+	mockRepo.On("GetProductsByOwner", mock.Anything, user.ID).Return([]*entity.Product{}, nil)
+	mockRepo.On("GetAuditLogsByActor", mock.Anything, user.ID).Return([]*entity.AuditLog{}, nil)
+	mockRepo.On("AnonymizeUser", mock.Anything, user.ID).Return(true, nil)
+
+	link, err := usecase.BuildExport(context.Background(), user.ID, entity.ExportFormatJSON)
+	require.NoError(t, err)
+
+	require.NoError(t, usecase.AnonymizeAccount(context.Background(), user.ID))
+
+	_, err = usecase.OpenExport(context.Background(), parseLink(t, link.URL).Query().Get("file"))
+	assert.Equal(t, errors.ErrExportNotFoundError, err)
+}
+
+func TestAccountUsecase_BuildExport_SignedLink(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	mockRepo, _, usecase := newTestUsecase(t, clk)
+
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("GetProductsByOwner", mock.Anything, user.ID).Return([]*entity.Product{{Name: "Keyboard"}}, nil)
+	mockRepo.On("GetAuditLogsByActor", mock.Anything, user.ID).Return([]*entity.AuditLog{}, nil)
+
+	link, err := usecase.BuildExport(context.Background(), user.ID, entity.ExportFormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, link.Email)
+	assert.Equal(t, now.Add(time.Hour), link.ExpiresAt)
+
+	parsed := parseLink(t, link.URL)
+	signer := signedurl.New("test-secret", clk)
+	require.NoError(t, signer.Verify(parsed))
+
+	reader, err := usecase.OpenExport(context.Background(), parsed.Query().Get("file"))
+	require.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Contains(t, string(data), `"Keyboard"`)
+
+	// Signed links only reach exports
+	_, err = usecase.OpenExport(context.Background(), "avatars/someone/1.png")
+	assert.Equal(t, errors.ErrLinkInvalidError, err)
+
+	// Tampered links and expired links are rejected
+	tampered := *parsed
+	query := tampered.Query()
+	query.Set("file", "exports/someone-else/20240315T120000Z.json")
+	tampered.RawQuery = query.Encode()
+	assert.Equal(t, signedurl.ErrInvalidSignature, signer.Verify(&tampered))
+
+	clk.Advance(time.Hour + time.Second)
+	assert.Equal(t, signedurl.ErrExpired, signer.Verify(parsed))
+
+	// The expired export is pruned
+	deleted, err := usecase.PruneExports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
+
+func TestAccountUsecase_BuildExport_DeletedUser(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase(t, clock.New())
+
+	userID := uuid.New()
+	mockRepo.On("GetUserByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+	link, err := usecase.BuildExport(context.Background(), userID, entity.ExportFormatZIP)
+
+	assert.NoError(t, err)
+	assert.Nil(t, link)
+}
+
+func TestAccountUsecase_WriteExport_ZIP(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase(t, clock.New())
+
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("GetProductsByOwner", mock.Anything, user.ID).Return([]*entity.Product{{Name: "Keyboard"}}, nil)
+	mockRepo.On("GetAuditLogsByActor", mock.Anything, user.ID).Return([]*entity.AuditLog{}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, usecase.WriteExport(context.Background(), user.ID, entity.ExportFormatZIP, &buf))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{"profile.json", "products.json", "audit_logs.json"}, names)
+}
+
+func parseLink(t *testing.T, link string) *url.URL {
+	t.Helper()
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/auth/account/export/download", parsed.Path)
+	return parsed
+}
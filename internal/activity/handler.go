@@ -0,0 +1,200 @@
+package activity
+
+import (
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ActivityHandler struct {
+	usecase ActivityUsecase
+}
+
+func NewActivityHandler(usecase ActivityUsecase) *ActivityHandler {
+	return &ActivityHandler{
+		usecase: usecase,
+	}
+}
+
+// GetMyActivities godoc
+// @Summary Get my activity
+// @Description Get the current user's activity feed, newest first
+// @Tags activities
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param verb query string false "Only activities with this verb" Enums(created, updated, deleted, reserved, committed, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /activities [get]
+func (h *ActivityHandler) GetMyActivities(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter entity.ActivityFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	activities, total, err := h.usecase.GetUserActivities(c.Request.Context(), userID, &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get activities", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get activities", nil)
+		}
+		return
+	}
+
+	response.SuccessWithMeta(c, 200, "Activities retrieved successfully", activities, meta(filter, total, activities))
+}
+
+// GetProductActivities godoc
+// @Summary Get product activity
+// @Description Get a product's activity feed, newest first: its changes and the reservations of its stock. Only users who may modify the product see it.
+// @Tags activities
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Product ID or public ID"
+// @Param verb query string false "Only activities with this verb" Enums(created, updated, deleted, reserved, committed, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/{id}/activities [get]
+func (h *ActivityHandler) GetProductActivities(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter entity.ActivityFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	activities, total, err := h.usecase.GetProductActivities(c.Request.Context(), productID, userID, &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get product activities", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get activities", nil)
+		}
+		return
+	}
+
+	response.SuccessWithMeta(c, 200, "Activities retrieved successfully", activities, meta(filter, total, activities))
+}
+
+// GetUserActivities godoc
+// @Summary Get user activity
+// @Description Get any user's activity feed, newest first. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param verb query string false "Only activities with this verb" Enums(created, updated, deleted, reserved, committed, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/{id}/activities [get]
+func (h *ActivityHandler) GetUserActivities(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	var filter entity.ActivityFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	activities, total, err := h.usecase.GetUserActivities(c.Request.Context(), userID, &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get user activities", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get activities", nil)
+		}
+		return
+	}
+
+	response.SuccessWithMeta(c, 200, "Activities retrieved successfully", activities, meta(filter, total, activities))
+}
+
+// meta is the pagination meta of a page of activities
+func meta(filter entity.ActivityFilter, total int64, activities []*entity.Activity) *response.Meta {
+	return pagination.Meta(filter.Params, total, len(activities), func() (time.Time, uuid.UUID) {
+		last := activities[len(activities)-1]
+		return last.CreatedAt, last.ID
+	})
+}
@@ -0,0 +1,86 @@
+package activity_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func verbs(activities []entity.Activity) []string {
+	var verbs []string
+	for _, a := range activities {
+		verbs = append(verbs, a.Verb)
+	}
+	return verbs
+}
+
+func TestActivityHandler_Feeds(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	customer := api.CreateUser()
+
+	var product entity.Product
+	api.As(owner).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Lamp",
+		Price:    money.MustParse("25", "USD"),
+		Stock:    5,
+		Category: "home",
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&product)
+
+	name := "Desk lamp"
+	api.As(owner).Put("/api/v1/products/"+product.ID.String(), entity.UpdateProductRequest{Name: &name}).Do().
+		AssertStatus(http.StatusOK)
+
+	api.As(customer).Post("/api/v1/reservations", entity.CreateReservationRequest{ProductID: product.ID, Quantity: 1}).Do().
+		AssertStatus(http.StatusCreated)
+
+	// Newest first
+	var activities []entity.Activity
+	api.As(owner).Get("/api/v1/products/" + product.ID.String() + "/activities").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&activities)
+	assert.Equal(t, []string{entity.ActivityReserved, entity.ActivityUpdated, entity.ActivityCreated}, verbs(activities))
+	assert.Equal(t, customer.ID, activities[0].ActorID)
+
+	api.As(owner).Get("/api/v1/products/"+product.ID.String()+"/activities").
+		Query("verb", entity.ActivityUpdated).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&activities)
+	require.Len(t, activities, 1)
+
+	// Customers don't see who else reserved the product
+	api.As(customer).Get("/api/v1/products/" + product.ID.String() + "/activities").Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrInvalidOwner)
+
+	api.As(customer).Get("/api/v1/activities").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&activities)
+	assert.Equal(t, []string{entity.ActivityReserved}, verbs(activities))
+
+	api.As(api.CreateAdmin()).Get("/api/v1/admin/users/" + owner.ID.String() + "/activities").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&activities)
+	assert.Equal(t, []string{entity.ActivityUpdated, entity.ActivityCreated}, verbs(activities))
+}
+
+func TestActivityHandler_InvalidVerb(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.As(api.CreateUser()).Get("/api/v1/activities").Query("verb", "liked").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("verb")
+}
@@ -0,0 +1,75 @@
+package activity
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/internal/reservation"
+	"go-clean-gin/pkg/events"
+)
+
+// ProductData is the data of activities on products
+type ProductData struct {
+	Name string `json:"name"`
+}
+
+// ReservationData is the data of activities on reservations
+type ReservationData struct {
+	Quantity int `json:"quantity"`
+}
+
+// RegisterListeners records the activities of application events. The events
+// of each module share their fields, so one listener converts them all.
+func RegisterListeners(bus *events.Bus, usecase ActivityUsecase) {
+	productActivity := func(verb string) events.Listener {
+		return func(ctx context.Context, event events.Event) error {
+			var e product.CreatedEvent
+			switch ev := event.(type) {
+			case product.CreatedEvent:
+				e = ev
+			case product.UpdatedEvent:
+				e = product.CreatedEvent(ev)
+			case product.DeletedEvent:
+				e = product.CreatedEvent(ev)
+			}
+
+			return usecase.Record(ctx, &entity.Activity{
+				ActorID:    e.ActorID,
+				Verb:       verb,
+				ObjectType: entity.ActivityProduct,
+				ObjectID:   e.ProductID,
+			}, ProductData{Name: e.Name})
+		}
+	}
+
+	bus.Listen(product.EventCreated, productActivity(entity.ActivityCreated))
+	bus.Listen(product.EventUpdated, productActivity(entity.ActivityUpdated))
+	bus.Listen(product.EventDeleted, productActivity(entity.ActivityDeleted))
+
+	reservationActivity := func(verb string) events.Listener {
+		return func(ctx context.Context, event events.Event) error {
+			var e reservation.ReservedEvent
+			switch ev := event.(type) {
+			case reservation.ReservedEvent:
+				e = ev
+			case reservation.CommittedEvent:
+				e = reservation.ReservedEvent(ev)
+			case reservation.CancelledEvent:
+				e = reservation.ReservedEvent(ev)
+			}
+
+			return usecase.Record(ctx, &entity.Activity{
+				ActorID:    e.UserID,
+				Verb:       verb,
+				ObjectType: entity.ActivityReservation,
+				ObjectID:   e.ReservationID,
+				TargetType: entity.ActivityProduct,
+				TargetID:   &e.ProductID,
+			}, ReservationData{Quantity: e.Quantity})
+		}
+	}
+
+	bus.Listen(reservation.EventReserved, reservationActivity(entity.ActivityReserved))
+	bus.Listen(reservation.EventCommitted, reservationActivity(entity.ActivityCommitted))
+	bus.Listen(reservation.EventCancelled, reservationActivity(entity.ActivityCancelled))
+}
@@ -0,0 +1,66 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package activity
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockActivityRepository is a testify mock of ActivityRepository
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityRepository) CreateActivity(ctx context.Context, activity *entity.Activity) error {
+	args := m.Called(ctx, activity)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) GetActorActivities(ctx context.Context, actorID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	args := m.Called(ctx, actorID, filter)
+
+	var r0 []*entity.Activity
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Activity)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockActivityRepository) GetSubjectActivities(ctx context.Context, subjectType string, subjectID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	args := m.Called(ctx, subjectType, subjectID, filter)
+
+	var r0 []*entity.Activity
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Activity)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockActivityRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,65 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package activity
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockActivityUsecase is a testify mock of ActivityUsecase
+type MockActivityUsecase struct {
+	mock.Mock
+}
+
+func (m *MockActivityUsecase) Record(ctx context.Context, activity *entity.Activity, data interface{}) error {
+	args := m.Called(ctx, activity, data)
+	return args.Error(0)
+}
+
+func (m *MockActivityUsecase) GetUserActivities(ctx context.Context, userID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	args := m.Called(ctx, userID, filter)
+
+	var r0 []*entity.Activity
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Activity)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockActivityUsecase) GetProductActivities(ctx context.Context, productID uuid.UUID, userID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	args := m.Called(ctx, productID, userID, filter)
+
+	var r0 []*entity.Activity
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Activity)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockActivityUsecase) PruneActivities(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
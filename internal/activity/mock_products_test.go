@@ -0,0 +1,20 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package activity
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProducts is a testify mock of Products
+type MockProducts struct {
+	mock.Mock
+}
+
+func (m *MockProducts) CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,31 @@
+package activity
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityUsecase defines the business logic interface for the activity feed
+type ActivityUsecase interface {
+	Record(ctx context.Context, activity *entity.Activity, data interface{}) error
+	GetUserActivities(ctx context.Context, userID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error)
+	GetProductActivities(ctx context.Context, productID uuid.UUID, userID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error)
+	PruneActivities(ctx context.Context) (int64, error)
+}
+
+// ActivityRepository defines the data access interface for activities
+type ActivityRepository interface {
+	CreateActivity(ctx context.Context, activity *entity.Activity) error
+	GetActorActivities(ctx context.Context, actorID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error)
+	GetSubjectActivities(ctx context.Context, subjectType string, subjectID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error)
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// Products checks who may see a product's feed, implemented by
+// product.ProductUsecase
+type Products interface {
+	CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error
+}
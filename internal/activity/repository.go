@@ -0,0 +1,65 @@
+package activity
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type activityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return &activityRepository{
+		db: db,
+	}
+}
+
+func (r *activityRepository) CreateActivity(ctx context.Context, activity *entity.Activity) error {
+	return tenancy.Conn(ctx, r.db).Create(activity).Error
+}
+
+func (r *activityRepository) GetActorActivities(ctx context.Context, actorID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	query := tenancy.Conn(ctx, r.db).Model(&entity.Activity{}).Where("actor_id = ?", actorID)
+	return r.page(query, filter)
+}
+
+// GetSubjectActivities returns the activities whose object or target is the
+// given subject, e.g. a product's updates along with its reservations
+func (r *activityRepository) GetSubjectActivities(ctx context.Context, subjectType string, subjectID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	query := tenancy.Conn(ctx, r.db).Model(&entity.Activity{}).
+		Where("(object_type = ? AND object_id = ?) OR (target_type = ? AND target_id = ?)",
+			subjectType, subjectID, subjectType, subjectID)
+	return r.page(query, filter)
+}
+
+func (r *activityRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("created_at < ?", cutoff).Delete(&entity.Activity{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *activityRepository) page(query *gorm.DB, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	var activities []*entity.Activity
+	var total int64
+
+	if filter.Verb != "" {
+		query = query.Where("verb = ?", filter.Verb)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := pagination.Apply(query, filter.Params, "created_at", "id").Find(&activities).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return activities, total, nil
+}
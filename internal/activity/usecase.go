@@ -0,0 +1,97 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type activityUsecase struct {
+	repo     ActivityRepository
+	products Products
+	config   *config.Config
+	clock    clock.Clock
+}
+
+func NewActivityUsecase(repo ActivityRepository, products Products, config *config.Config, clk clock.Clock) ActivityUsecase {
+	return &activityUsecase{
+		repo:     repo,
+		products: products,
+		config:   config,
+		clock:    clk,
+	}
+}
+
+// Record adds the activity to the feed; data is encoded as JSON
+func (u *activityUsecase) Record(ctx context.Context, activity *entity.Activity, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrInternal, "Failed to encode activity data", 500)
+	}
+
+	activity.Data = entity.JSON(encoded)
+	activity.CreatedAt = u.clock.Now()
+
+	if err := u.repo.CreateActivity(ctx, activity); err != nil {
+		logger.FromContext(ctx).Error("Failed to record activity", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to record activity", 500)
+	}
+
+	return nil
+}
+
+// GetUserActivities lists what the user did, newest first
+func (u *activityUsecase) GetUserActivities(ctx context.Context, userID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+
+	activities, total, err := u.repo.GetActorActivities(ctx, userID, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get activities", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get activities", 500)
+	}
+
+	return activities, total, nil
+}
+
+// GetProductActivities lists what happened to the product, newest first. The
+// feed shows who reserved its stock, so only users who may modify the product
+// see it.
+func (u *activityUsecase) GetProductActivities(ctx context.Context, productID uuid.UUID, userID uuid.UUID, filter *entity.ActivityFilter) ([]*entity.Activity, int64, error) {
+	if err := u.products.CheckOwner(ctx, productID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	filter.Normalize(pagination.DefaultLimit)
+
+	activities, total, err := u.repo.GetSubjectActivities(ctx, entity.ActivityProduct, productID, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get activities", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get activities", 500)
+	}
+
+	return activities, total, nil
+}
+
+// PruneActivities deletes activities older than ACTIVITY_RETENTION, if set
+func (u *activityUsecase) PruneActivities(ctx context.Context) (int64, error) {
+	retention := u.config.Activity.Retention
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := u.repo.DeleteBefore(ctx, u.clock.Now().Add(-retention))
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to prune activities", zap.Error(err))
+		return 0, errors.Wrap(err, errors.ErrInternal, "Failed to prune activities", 500)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,127 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/internal/reservation"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	repo     *MockActivityRepository
+	products *MockProducts
+	usecase  ActivityUsecase
+}
+
+func newTestUsecase(retention time.Duration) *testDeps {
+	d := &testDeps{
+		repo:     new(MockActivityRepository),
+		products: new(MockProducts),
+	}
+	cfg := &config.Config{Activity: config.ActivityConfig{Retention: retention}}
+	d.usecase = NewActivityUsecase(d.repo, d.products, cfg, clock.NewFake(testNow))
+	return d
+}
+
+func TestActivityListeners_RecordEvents(t *testing.T) {
+	d := newTestUsecase(0)
+	bus := events.NewBus()
+	RegisterListeners(bus, d.usecase)
+
+	var recorded []*entity.Activity
+	d.repo.On("CreateActivity", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(1).(*entity.Activity))
+	}).Return(nil)
+
+	userID := uuid.New()
+	productID := uuid.New()
+	reservationID := uuid.New()
+	bus.Dispatch(context.Background(), product.UpdatedEvent{ProductID: productID, Name: "Widget", ActorID: userID})
+	bus.Dispatch(context.Background(), reservation.CancelledEvent{
+		ReservationID: reservationID,
+		ProductID:     productID,
+		UserID:        userID,
+		Quantity:      2,
+	})
+
+	require.Len(t, recorded, 2)
+
+	updated := recorded[0]
+	assert.Equal(t, userID, updated.ActorID)
+	assert.Equal(t, entity.ActivityUpdated, updated.Verb)
+	assert.Equal(t, entity.ActivityProduct, updated.ObjectType)
+	assert.Equal(t, productID, updated.ObjectID)
+	assert.Empty(t, updated.TargetType)
+	assert.JSONEq(t, `{"name": "Widget"}`, string(updated.Data))
+	assert.Equal(t, testNow, updated.CreatedAt)
+
+	cancelled := recorded[1]
+	assert.Equal(t, entity.ActivityCancelled, cancelled.Verb)
+	assert.Equal(t, entity.ActivityReservation, cancelled.ObjectType)
+	assert.Equal(t, reservationID, cancelled.ObjectID)
+	assert.Equal(t, entity.ActivityProduct, cancelled.TargetType)
+	assert.Equal(t, &productID, cancelled.TargetID)
+
+	var data ReservationData
+	require.NoError(t, json.Unmarshal(cancelled.Data, &data))
+	assert.Equal(t, 2, data.Quantity)
+}
+
+func TestActivityUsecase_GetProductActivities_OwnersOnly(t *testing.T) {
+	d := newTestUsecase(0)
+	productID := uuid.New()
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	d.products.On("CheckOwner", mock.Anything, productID, otherID).Return(errors.ErrInvalidOwnerError)
+	d.products.On("CheckOwner", mock.Anything, productID, ownerID).Return(nil)
+	d.repo.On("GetSubjectActivities", mock.Anything, entity.ActivityProduct, productID, mock.Anything).
+		Return([]*entity.Activity{{ObjectID: productID}}, int64(1), nil).Once()
+
+	_, _, err := d.usecase.GetProductActivities(context.Background(), productID, otherID, &entity.ActivityFilter{})
+	assert.Equal(t, errors.ErrInvalidOwnerError, err)
+
+	activities, total, err := d.usecase.GetProductActivities(context.Background(), productID, ownerID, &entity.ActivityFilter{})
+	require.NoError(t, err)
+	assert.Len(t, activities, 1)
+	assert.Equal(t, int64(1), total)
+	d.repo.AssertExpectations(t)
+}
+
+func TestActivityUsecase_PruneActivities(t *testing.T) {
+	t.Run("deletes entries older than the retention", func(t *testing.T) {
+		d := newTestUsecase(90 * 24 * time.Hour)
+		d.repo.On("DeleteBefore", mock.Anything, testNow.Add(-90*24*time.Hour)).Return(int64(3), nil).Once()
+
+		deleted, err := d.usecase.PruneActivities(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+		d.repo.AssertExpectations(t)
+	})
+
+	t.Run("keeps everything without a retention", func(t *testing.T) {
+		d := newTestUsecase(0)
+
+		deleted, err := d.usecase.PruneActivities(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+		d.repo.AssertNotCalled(t, "DeleteBefore", mock.Anything, mock.Anything)
+	})
+}
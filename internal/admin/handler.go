@@ -0,0 +1,198 @@
+package admin
+
+import (
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AdminHandler struct {
+	usecase AdminUsecase
+}
+
+func NewAdminHandler(usecase AdminUsecase) *AdminHandler {
+	return &AdminHandler{
+		usecase: usecase,
+	}
+}
+
+// Dashboard godoc
+// @Summary Get dashboard
+// @Description Get user, product and job counts for the admin dashboard. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/dashboard [get]
+func (h *AdminHandler) Dashboard(c *gin.Context) {
+	dashboard, err := h.usecase.Dashboard(c.Request.Context())
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get dashboard", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get dashboard", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Dashboard retrieved successfully", dashboard)
+}
+
+// RecentSignups godoc
+// @Summary Get recent signups
+// @Description Get the most recently registered users, newest first. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Number of users, at most 100" default(10)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/users/recent [get]
+func (h *AdminHandler) RecentSignups(c *gin.Context) {
+	var query entity.RecentUsersQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(query); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	users, err := h.usecase.RecentSignups(c.Request.Context(), &query)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get recent signups", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get recent signups", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Recent signups retrieved successfully", users)
+}
+
+// GetFailedJobs godoc
+// @Summary Get failed jobs
+// @Description Get background jobs that used up their attempts, newest first. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param queue query string false "Only jobs of this queue"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/jobs/failed [get]
+func (h *AdminHandler) GetFailedJobs(c *gin.Context) {
+	var filter entity.FailedJobFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	jobs, total, err := h.usecase.GetFailedJobs(c.Request.Context(), &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get failed jobs", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get failed jobs", nil)
+		}
+		return
+	}
+
+	meta := pagination.Meta(filter.Params, total, len(jobs), func() (time.Time, uuid.UUID) {
+		last := jobs[len(jobs)-1]
+		return last.CreatedAt, last.ID
+	})
+	response.SuccessWithMeta(c, 200, "Failed jobs retrieved successfully", jobs, meta)
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit trail
+// @Description Get the recorded write requests, newest first. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param actor_id query string false "Only requests made by this user"
+// @Param method query string false "Only requests with this method" Enums(POST, PUT, PATCH, DELETE)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/audit-logs [get]
+func (h *AdminHandler) GetAuditLogs(c *gin.Context) {
+	var filter entity.AuditLogFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	logs, total, err := h.usecase.GetAuditLogs(c.Request.Context(), &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get audit logs", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get audit logs", nil)
+		}
+		return
+	}
+
+	meta := pagination.Meta(filter.Params, total, len(logs), func() (time.Time, uuid.UUID) {
+		last := logs[len(logs)-1]
+		return last.CreatedAt, last.ID
+	})
+	response.SuccessWithMeta(c, 200, "Audit logs retrieved successfully", logs, meta)
+}
@@ -0,0 +1,82 @@
+package admin_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminHandler_Dashboard(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+	api.CreateUser()
+
+	var dashboard entity.AdminDashboard
+	api.As(admin).Get("/api/v1/admin/dashboard").Do().
+		AssertStatus(http.StatusOK).
+		AssertSuccess().
+		Decode(&dashboard)
+
+	assert.GreaterOrEqual(t, dashboard.Users.Total, int64(2))
+	assert.GreaterOrEqual(t, dashboard.Users.Admins, int64(1))
+	assert.GreaterOrEqual(t, dashboard.Users.NewLast7d, int64(2))
+}
+
+func TestAdminHandler_RecentSignups(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	var users []entity.User
+	api.As(admin).Get("/api/v1/admin/users/recent").Query("limit", "1").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&users)
+
+	assert.Len(t, users, 1)
+}
+
+func TestAdminHandler_GetAuditLogs_RecordsWrites(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	api.As(admin).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Audited",
+		Price:    money.MustParse("10", "USD"),
+		Category: "audit-test",
+	}).Do().AssertStatus(http.StatusCreated)
+
+	var logs []entity.AuditLog
+	api.As(admin).Get("/api/v1/admin/audit-logs").
+		Query("actor_id", admin.ID.String()).
+		Query("method", http.MethodPost).
+		Do().
+		AssertStatus(http.StatusOK).
+		Decode(&logs)
+
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, "/api/v1/products", logs[0].Route)
+		assert.Equal(t, http.StatusCreated, logs[0].Status)
+	}
+}
+
+func TestAdminHandler_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Get("/api/v1/admin/dashboard").Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrForbidden)
+}
@@ -0,0 +1,78 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/queue"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAdminRepository is a testify mock of AdminRepository
+type MockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminRepository) UserStats(ctx context.Context, now time.Time) (*entity.UserStats, error) {
+	args := m.Called(ctx, now)
+
+	var r0 *entity.UserStats
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.UserStats)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAdminRepository) ProductStats(ctx context.Context) (*entity.ProductStats, error) {
+	args := m.Called(ctx)
+
+	var r0 *entity.ProductStats
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.ProductStats)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAdminRepository) JobStats(ctx context.Context) (*entity.JobStats, error) {
+	args := m.Called(ctx)
+
+	var r0 *entity.JobStats
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.JobStats)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAdminRepository) RecentUsers(ctx context.Context, limit int) ([]*entity.User, error) {
+	args := m.Called(ctx, limit)
+
+	var r0 []*entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAdminRepository) GetFailedJobs(ctx context.Context, filter *entity.FailedJobFilter) ([]*queue.Job, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*queue.Job
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*queue.Job)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
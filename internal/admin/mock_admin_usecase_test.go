@@ -0,0 +1,71 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package admin
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/queue"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAdminUsecase is a testify mock of AdminUsecase
+type MockAdminUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAdminUsecase) Dashboard(ctx context.Context) (*entity.AdminDashboard, error) {
+	args := m.Called(ctx)
+
+	var r0 *entity.AdminDashboard
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AdminDashboard)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAdminUsecase) RecentSignups(ctx context.Context, query *entity.RecentUsersQuery) ([]*entity.User, error) {
+	args := m.Called(ctx, query)
+
+	var r0 []*entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAdminUsecase) GetFailedJobs(ctx context.Context, filter *entity.FailedJobFilter) ([]*queue.Job, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*queue.Job
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*queue.Job)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockAdminUsecase) GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.AuditLog
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.AuditLog)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
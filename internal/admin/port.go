@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/queue"
+	"time"
+)
+
+// AdminUsecase defines the business logic interface for the admin dashboard
+type AdminUsecase interface {
+	Dashboard(ctx context.Context) (*entity.AdminDashboard, error)
+	RecentSignups(ctx context.Context, query *entity.RecentUsersQuery) ([]*entity.User, error)
+	GetFailedJobs(ctx context.Context, filter *entity.FailedJobFilter) ([]*queue.Job, int64, error)
+	GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error)
+}
+
+// AdminRepository defines the aggregate queries behind the admin dashboard
+type AdminRepository interface {
+	UserStats(ctx context.Context, now time.Time) (*entity.UserStats, error)
+	ProductStats(ctx context.Context) (*entity.ProductStats, error)
+	JobStats(ctx context.Context) (*entity.JobStats, error)
+	RecentUsers(ctx context.Context, limit int) ([]*entity.User, error)
+	GetFailedJobs(ctx context.Context, filter *entity.FailedJobFilter) ([]*queue.Job, int64, error)
+}
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{
+		db: db,
+	}
+}
+
+// UserStats counts users, with the signups of the 7 and 30 days before now
+func (r *adminRepository) UserStats(ctx context.Context, now time.Time) (*entity.UserStats, error) {
+	var stats entity.UserStats
+	err := tenancy.Conn(ctx, r.db).Model(&entity.User{}).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_active) AS active,
+			COUNT(*) FILTER (WHERE role = ?) AS admins,
+			COUNT(*) FILTER (WHERE created_at >= ?) AS new_last_7d,
+			COUNT(*) FILTER (WHERE created_at >= ?) AS new_last_30d`,
+			entity.RoleAdmin, now.AddDate(0, 0, -7), now.AddDate(0, 0, -30)).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *adminRepository) ProductStats(ctx context.Context) (*entity.ProductStats, error) {
+	var stats entity.ProductStats
+	err := tenancy.Conn(ctx, r.db).Model(&entity.Product{}).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE is_active) AS active,
+			COUNT(*) FILTER (WHERE stock = 0) AS out_of_stock`).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// JobStats counts the jobs of the database queue. Reserved jobs are being
+// processed, or were held by a worker that crashed and will be released.
+func (r *adminRepository) JobStats(ctx context.Context) (*entity.JobStats, error) {
+	var stats entity.JobStats
+	err := tenancy.Conn(ctx, r.db).Model(&queue.Job{}).
+		Select(`COUNT(*) FILTER (WHERE failed_at IS NULL AND reserved_at IS NULL) AS pending,
+			COUNT(*) FILTER (WHERE failed_at IS NULL AND reserved_at IS NOT NULL) AS reserved,
+			COUNT(*) FILTER (WHERE failed_at IS NOT NULL) AS failed`).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (r *adminRepository) RecentUsers(ctx context.Context, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	err := tenancy.Conn(ctx, r.db).Order("created_at DESC").Order("id DESC").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *adminRepository) GetFailedJobs(ctx context.Context, filter *entity.FailedJobFilter) ([]*queue.Job, int64, error) {
+	var jobs []*queue.Job
+	var total int64
+
+	query := tenancy.Conn(ctx, r.db).Model(&queue.Job{}).Where("failed_at IS NOT NULL")
+
+	if filter.Queue != "" {
+		query = query.Where("queue = ?", filter.Queue)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := pagination.Apply(query, filter.Params, "created_at", "id").Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
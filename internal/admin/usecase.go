@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"go-clean-gin/internal/audit"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/queue"
+
+	"go.uber.org/zap"
+)
+
+// recentSignupsLimit is the number of signups returned when no limit is given
+const recentSignupsLimit = 10
+
+type adminUsecase struct {
+	repo  AdminRepository
+	audit audit.AuditUsecase
+	clock clock.Clock
+}
+
+func NewAdminUsecase(repo AdminRepository, auditUsecase audit.AuditUsecase, clk clock.Clock) AdminUsecase {
+	return &adminUsecase{
+		repo:  repo,
+		audit: auditUsecase,
+		clock: clk,
+	}
+}
+
+// Dashboard gathers the user, product and job counts
+func (u *adminUsecase) Dashboard(ctx context.Context) (*entity.AdminDashboard, error) {
+	now := u.clock.Now()
+
+	users, err := u.repo.UserStats(ctx, now)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user stats", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get dashboard", 500)
+	}
+
+	products, err := u.repo.ProductStats(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get product stats", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get dashboard", 500)
+	}
+
+	jobs, err := u.repo.JobStats(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get job stats", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get dashboard", 500)
+	}
+
+	return &entity.AdminDashboard{
+		Users:       *users,
+		Products:    *products,
+		Jobs:        *jobs,
+		GeneratedAt: now,
+	}, nil
+}
+
+func (u *adminUsecase) RecentSignups(ctx context.Context, query *entity.RecentUsersQuery) ([]*entity.User, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = recentSignupsLimit
+	}
+
+	users, err := u.repo.RecentUsers(ctx, limit)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get recent signups", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get recent signups", 500)
+	}
+
+	return users, nil
+}
+
+func (u *adminUsecase) GetFailedJobs(ctx context.Context, filter *entity.FailedJobFilter) ([]*queue.Job, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+
+	jobs, total, err := u.repo.GetFailedJobs(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get failed jobs", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get failed jobs", 500)
+	}
+
+	return jobs, total, nil
+}
+
+func (u *adminUsecase) GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
+	return u.audit.GetAuditLogs(ctx, filter)
+}
@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminUsecase_Dashboard(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	now := time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC)
+	usecase := NewAdminUsecase(mockRepo, nil, clock.NewFake(now))
+
+	mockRepo.On("UserStats", mock.Anything, now).Return(&entity.UserStats{Total: 3, NewLast7d: 1}, nil)
+	mockRepo.On("ProductStats", mock.Anything).Return(&entity.ProductStats{Total: 5, OutOfStock: 2}, nil)
+	mockRepo.On("JobStats", mock.Anything).Return(&entity.JobStats{Failed: 1}, nil)
+
+	dashboard, err := usecase.Dashboard(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), dashboard.Users.Total)
+	assert.Equal(t, int64(2), dashboard.Products.OutOfStock)
+	assert.Equal(t, int64(1), dashboard.Jobs.Failed)
+	assert.Equal(t, now, dashboard.GeneratedAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAdminUsecase_Dashboard_RepositoryError(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, nil, clock.New())
+
+	mockRepo.On("UserStats", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	_, err := usecase.Dashboard(context.Background())
+
+	if assert.IsType(t, &errors.AppError{}, err) {
+		assert.Equal(t, errors.ErrInternal, err.(*errors.AppError).Code)
+	}
+	mockRepo.AssertNotCalled(t, "ProductStats", mock.Anything)
+}
+
+func TestAdminUsecase_RecentSignups_DefaultLimit(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, nil, clock.New())
+
+	mockRepo.On("RecentUsers", mock.Anything, recentSignupsLimit).Return([]*entity.User{}, nil)
+
+	_, err := usecase.RecentSignups(context.Background(), &entity.RecentUsersQuery{})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAdminUsecase_GetFailedJobs_NormalizesPaging(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, nil, clock.New())
+
+	mockRepo.On("GetFailedJobs", mock.Anything, mock.MatchedBy(func(filter *entity.FailedJobFilter) bool {
+		return filter.Page == 1 && filter.Limit == pagination.DefaultLimit
+	})).Return(nil, int64(0), nil)
+
+	_, _, err := usecase.GetFailedJobs(context.Background(), &entity.FailedJobFilter{})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package anonymizers
+
+import (
+	"fmt"
+	"strings"
+
+	"go-clean-gin/pkg/faker"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// UserAnonymizer scrubs personal data from the users table
+type UserAnonymizer struct{}
+
+// Anonymize replaces names, emails and usernames, resets every password to
+// "password" and drops pending email changes, then updates the owner names copied into the product listings
+func (a *UserAnonymizer) Anonymize(db *gorm.DB) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	err = AnonymizeColumns(db, a.Table(), []Column{
+		{Name: "first_name", Fake: func(f *faker.Faker) interface{} { return f.FirstName() }},
+		{Name: "last_name", Fake: func(f *faker.Faker) interface{} { return f.LastName() }},
+		// Token suffix keeps unique indexes satisfied
+		{Name: "email", Fake: func(f *faker.Faker) interface{} {
+			return fmt.Sprintf("user_%s@example.com", f.Token(6))
+		}},
+		{Name: "username", Fake: func(f *faker.Faker) interface{} {
+			return fmt.Sprintf("%s_%s", strings.ToLower(f.FirstName()), f.Token(4))
+		}},
+		{Name: "password", Fake: func(f *faker.Faker) interface{} { return string(hashedPassword) }},
+		{Name: "pending_email", Fake: func(f *faker.Faker) interface{} { return nil }},
+		{Name: "email_change_old_token", Fake: func(f *faker.Faker) interface{} { return "" }},
+		{Name: "email_change_new_token", Fake: func(f *faker.Faker) interface{} { return "" }},
+	})
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(`
+		UPDATE tb_product_read_models r
+		SET owner_name = TRIM(u.first_name || ' ' || u.last_name)
+		FROM tb_users u
+		WHERE u.id = r.created_by
+	`).Error
+}
+
+// Name returns anonymizer name
+func (a *UserAnonymizer) Name() string {
+	return "UserAnonymizer"
+}
+
+// Table returns the table this anonymizer scrubs
+func (a *UserAnonymizer) Table() string {
+	return "tb_users"
+}
+
+// Auto-register anonymizer
+func init() {
+	Register(&UserAnonymizer{})
+}
@@ -0,0 +1,37 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package audit
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditUsecase is a testify mock of AuditUsecase
+type MockAuditUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAuditUsecase) Record(ctx context.Context, log *entity.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockAuditUsecase) GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.AuditLog
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.AuditLog)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+)
+
+// AuditUsecase defines the business logic interface for the audit trail
+type AuditUsecase interface {
+	Record(ctx context.Context, log *entity.AuditLog) error
+	GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error)
+}
+
+// AuditRepository defines the data access interface for audit logs
+type AuditRepository interface {
+	CreateAuditLog(ctx context.Context, log *entity.AuditLog) error
+	GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error)
+}
@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (r *auditRepository) CreateAuditLog(ctx context.Context, log *entity.AuditLog) error {
+	return tenancy.Conn(ctx, r.db).Create(log).Error
+}
+
+func (r *auditRepository) GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
+	var logs []*entity.AuditLog
+	var total int64
+
+	query := tenancy.Conn(ctx, r.db).Model(&entity.AuditLog{})
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := pagination.Apply(query, filter.Params, "created_at", "id").Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
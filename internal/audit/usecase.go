@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+
+	"go.uber.org/zap"
+)
+
+type auditUsecase struct {
+	repo  AuditRepository
+	clock clock.Clock
+}
+
+func NewAuditUsecase(repo AuditRepository, clk clock.Clock) AuditUsecase {
+	return &auditUsecase{
+		repo:  repo,
+		clock: clk,
+	}
+}
+
+// Record stores log, stamped with the current time
+func (u *auditUsecase) Record(ctx context.Context, log *entity.AuditLog) error {
+	log.CreatedAt = u.clock.Now()
+
+	if err := u.repo.CreateAuditLog(ctx, log); err != nil {
+		logger.FromContext(ctx).Error("Failed to record audit log", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to record audit log", 500)
+	}
+	return nil
+}
+
+func (u *auditUsecase) GetAuditLogs(ctx context.Context, filter *entity.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+
+	logs, total, err := u.repo.GetAuditLogs(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get audit logs", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get audit logs", 500)
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/ldap"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// ErrSkipBackend is returned by a backend that does not know the user, or
+// cannot be reached; Login then tries the next backend
+var ErrSkipBackend = stderrors.New("auth: backend cannot authenticate this user")
+
+// usernameUnsafe matches what cannot be part of a provisioned username
+var usernameUnsafe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// NewBackends creates the backends named in AUTH_BACKENDS, in order
+func NewBackends(cfg *config.Config, repo AuthRepository) ([]Backend, error) {
+	var backends []Backend
+	for _, name := range cfg.AuthBackend.Backends {
+		switch name {
+		case "local":
+			backends = append(backends, NewLocalBackend(repo))
+		case "ldap":
+			ldapCfg := cfg.AuthBackend.LDAP
+			if ldapCfg.URL == "" || ldapCfg.BaseDN == "" {
+				return nil, fmt.Errorf("the ldap auth backend needs LDAP_URL and LDAP_BASE_DN")
+			}
+			if !strings.Contains(ldapCfg.UserFilter, "{email}") {
+				return nil, fmt.Errorf("LDAP_USER_FILTER must contain {email}")
+			}
+			directory := ldap.NewDirectory(ldap.Config{
+				URL:                ldapCfg.URL,
+				StartTLS:           ldapCfg.StartTLS,
+				InsecureSkipVerify: ldapCfg.InsecureSkipVerify,
+				BindDN:             ldapCfg.BindDN,
+				BindPassword:       ldapCfg.BindPassword,
+				BaseDN:             ldapCfg.BaseDN,
+				UserFilter:         ldapCfg.UserFilter,
+				Attributes:         []string{ldapCfg.UsernameAttribute, ldapCfg.FirstNameAttribute, ldapCfg.LastNameAttribute},
+				Timeout:            ldapCfg.Timeout,
+			})
+			backends = append(backends, NewLDAPBackend(repo, directory, ldapCfg))
+		default:
+			return nil, fmt.Errorf("unsupported auth backend: %s", name)
+		}
+	}
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no auth backends configured")
+	}
+	return backends, nil
+}
+
+// localBackend checks the password hash in the users table
+type localBackend struct {
+	repo AuthRepository
+}
+
+func NewLocalBackend(repo AuthRepository) Backend {
+	return &localBackend{repo: repo}
+}
+
+func (b *localBackend) Authenticate(ctx context.Context, email, password string) (*entity.User, error) {
+	user, err := b.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrSkipBackend
+		}
+		logger.FromContext(ctx).Error("Failed to get user by email", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, errors.ErrInvalidCredentialsError
+	}
+	return user, nil
+}
+
+// ldapBackend checks the password against an LDAP directory. Users the
+// directory knows are authenticated by it alone; they are created locally on
+// their first login, with an unusable local password.
+type ldapBackend struct {
+	repo      AuthRepository
+	directory Directory
+	cfg       config.LDAPConfig
+}
+
+func NewLDAPBackend(repo AuthRepository, directory Directory, cfg config.LDAPConfig) Backend {
+	return &ldapBackend{
+		repo:      repo,
+		directory: directory,
+		cfg:       cfg,
+	}
+}
+
+func (b *ldapBackend) Authenticate(ctx context.Context, email, password string) (*entity.User, error) {
+	entry, err := b.directory.Authenticate(ctx, email, password)
+	switch {
+	case stderrors.Is(err, ldap.ErrNoSuchUser):
+		return nil, ErrSkipBackend
+	case stderrors.Is(err, ldap.ErrInvalidCredentials):
+		return nil, errors.ErrInvalidCredentialsError
+	case err != nil:
+		logger.FromContext(ctx).Error("LDAP directory unavailable", zap.Error(err))
+		return nil, ErrSkipBackend
+	}
+
+	user, err := b.repo.GetUserByEmail(ctx, email)
+	if err == nil {
+		return user, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		logger.FromContext(ctx).Error("Failed to get user by email", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
+	}
+	return b.provision(ctx, email, entry)
+}
+
+// provision creates the local user for a directory entry
+func (b *ldapBackend) provision(ctx context.Context, email string, entry *ldap.Entry) (*entity.User, error) {
+	// A deactivated account is not found by email but still holds it
+	taken, err := b.repo.EmailTaken(ctx, email)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check email", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to check existing user", 500)
+	}
+	if taken {
+		return nil, errors.ErrInvalidCredentialsError
+	}
+
+	localPart, _, _ := strings.Cut(email, "@")
+	name := entry.Value(b.cfg.UsernameAttribute)
+	if name == "" {
+		name = localPart
+	}
+	username, err := b.freeUsername(ctx, name)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to pick username", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create user", 500)
+	}
+
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create user", 500)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(password)), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to hash password", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to hash password", 500)
+	}
+
+	firstName := entry.Value(b.cfg.FirstNameAttribute)
+	if firstName == "" {
+		firstName = localPart
+	}
+	user := &entity.User{
+		Email:     email,
+		Username:  username,
+		Password:  string(hashedPassword),
+		FirstName: firstName,
+		LastName:  entry.Value(b.cfg.LastNameAttribute),
+		Role:      entity.RoleUser,
+		IsActive:  true,
+	}
+	if err := b.repo.CreateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to create user", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create user", 500)
+	}
+
+	logger.FromContext(ctx).Info("LDAP user provisioned",
+		zap.String("user_id", user.ID.String()), zap.String("dn", entry.DN))
+	return user, nil
+}
+
+// freeUsername derives a username from the directory's, adding a random
+// suffix when it is taken
+func (b *ldapBackend) freeUsername(ctx context.Context, name string) (string, error) {
+	base := strings.Trim(usernameUnsafe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if len(base) < 3 {
+		base = "user_" + base
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		taken, err := b.repo.UsernameTaken(ctx, candidate)
+		if err != nil || !taken {
+			return candidate, err
+		}
+
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		candidate = base + "_" + hex.EncodeToString(suffix)
+	}
+	return "", fmt.Errorf("no free username for %s", name)
+}
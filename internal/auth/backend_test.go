@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/ldap"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var testLDAPConfig = config.LDAPConfig{
+	UsernameAttribute:  "uid",
+	FirstNameAttribute: "givenName",
+	LastNameAttribute:  "sn",
+}
+
+// newBackendTestUsecase logs in with LDAP first, then local passwords
+func newBackendTestUsecase() (*MockAuthRepository, *MockDirectory, AuthUsecase) {
+	mockRepo := new(MockAuthRepository)
+	mockDirectory := new(MockDirectory)
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpirationHours: 1}}
+	backends := []Backend{NewLDAPBackend(mockRepo, mockDirectory, testLDAPConfig), NewLocalBackend(mockRepo)}
+	mockRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+	return mockRepo, mockDirectory, NewAuthUsecase(mockRepo, cfg, nil, clock.New(), backends)
+}
+
+func login(usecase AuthUsecase, email, password string) (*entity.AuthResponse, error) {
+	return usecase.Login(context.Background(), &entity.LoginRequest{Email: email, Password: password})
+}
+
+func TestLogin_LDAPProvisionsUser(t *testing.T) {
+	mockRepo, mockDirectory, usecase := newBackendTestUsecase()
+	entry := &ldap.Entry{
+		DN:         "uid=jane.doe,ou=people,dc=acme,dc=com",
+		Attributes: map[string][]string{"uid": {"jane.doe"}, "givenname": {"Jane"}, "sn": {"Doe"}},
+	}
+	mockDirectory.On("Authenticate", mock.Anything, "jane@acme.com", "secret").Return(entry, nil)
+	mockRepo.On("GetUserByEmail", mock.Anything, "jane@acme.com").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("EmailTaken", mock.Anything, "jane@acme.com").Return(false, nil)
+	mockRepo.On("UsernameTaken", mock.Anything, "jane_doe").Return(false, nil)
+	var created *entity.User
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.User) }).
+		Return(nil)
+
+	result, err := login(usecase, "jane@acme.com", "secret")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Token)
+	assert.Equal(t, "jane_doe", created.Username)
+	assert.Equal(t, "Jane", created.FirstName)
+	assert.Equal(t, "Doe", created.LastName)
+	assert.Equal(t, entity.RoleUser, created.Role)
+	assert.Error(t, bcrypt.CompareHashAndPassword([]byte(created.Password), []byte("secret")),
+		"the directory password is not stored")
+}
+
+func TestLogin_LDAPExistingUser(t *testing.T) {
+	mockRepo, mockDirectory, usecase := newBackendTestUsecase()
+	user := &entity.User{ID: uuid.New(), Email: "jane@acme.com"}
+	mockDirectory.On("Authenticate", mock.Anything, user.Email, "secret").Return(&ldap.Entry{}, nil)
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+
+	result, err := login(usecase, user.Email, "secret")
+
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.User.ID)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestLogin_LDAPWrongPassword(t *testing.T) {
+	mockRepo, mockDirectory, usecase := newBackendTestUsecase()
+	mockDirectory.On("Authenticate", mock.Anything, "jane@acme.com", "wrong").Return(nil, ldap.ErrInvalidCredentials)
+
+	_, err := login(usecase, "jane@acme.com", "wrong")
+
+	assert.Equal(t, errors.ErrInvalidCredentialsError, err)
+	mockRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestLogin_FallsBackToLocal(t *testing.T) {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Email: "local@example.com", Password: string(hashed)}
+
+	for name, directoryErr := range map[string]error{
+		"unknown to the directory": ldap.ErrNoSuchUser,
+		"directory unreachable":    fmt.Errorf("ldap: dial tcp: connection refused"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockRepo, mockDirectory, usecase := newBackendTestUsecase()
+			mockDirectory.On("Authenticate", mock.Anything, user.Email, mock.Anything).Return(nil, directoryErr)
+			mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+
+			_, err := login(usecase, user.Email, "password123")
+			require.NoError(t, err)
+
+			_, err = login(usecase, user.Email, "wrong")
+			assert.Equal(t, errors.ErrInvalidCredentialsError, err)
+		})
+	}
+}
+
+func TestLogin_UnknownToAllBackends(t *testing.T) {
+	mockRepo, mockDirectory, usecase := newBackendTestUsecase()
+	mockDirectory.On("Authenticate", mock.Anything, "nobody@example.com", "secret").Return(nil, ldap.ErrNoSuchUser)
+	mockRepo.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := login(usecase, "nobody@example.com", "secret")
+
+	assert.Equal(t, errors.ErrInvalidCredentialsError, err)
+}
+
+func TestNewBackends(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	ldapCfg := config.LDAPConfig{URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com", UserFilter: "(mail={email})"}
+
+	backends, err := NewBackends(&config.Config{AuthBackend: config.AuthBackendConfig{Backends: []string{"ldap", "local"}, LDAP: ldapCfg}}, mockRepo)
+	require.NoError(t, err)
+	assert.Len(t, backends, 2)
+
+	for _, cfg := range []config.AuthBackendConfig{
+		{Backends: nil},
+		{Backends: []string{"kerberos"}},
+		{Backends: []string{"ldap"}},
+		{Backends: []string{"ldap"}, LDAP: config.LDAPConfig{URL: ldapCfg.URL, BaseDN: ldapCfg.BaseDN, UserFilter: "(mail=jane)"}},
+	} {
+		_, err := NewBackends(&config.Config{AuthBackend: cfg}, mockRepo)
+		assert.Error(t, err, cfg.Backends)
+	}
+}
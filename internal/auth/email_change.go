@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// RequestEmailChange stores email as the user's pending email and mails a
+// confirmation link to both the current and the new address. The change is
+// applied once both links are used. A new request replaces a pending one.
+func (u *authUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, req *entity.ChangeEmailRequest) (*entity.User, error) {
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, errors.ErrInvalidCredentialsError
+	}
+
+	newEmail := strings.TrimSpace(req.Email)
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, errors.New(errors.ErrBadRequest, "New email is the same as the current one", 400)
+	}
+	if err := u.checkEmailAvailable(ctx, newEmail); err != nil {
+		return nil, err
+	}
+
+	oldToken, err := newSecretToken()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+	newToken, err := newSecretToken()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+
+	expiresAt := u.clock.Now().Add(u.config.Account.EmailChangeTTL)
+	user.PendingEmail = &newEmail
+	user.PendingEmailExpiresAt = &expiresAt
+	user.EmailChangeOldToken = hashSecretToken(oldToken)
+	user.EmailChangeNewToken = hashSecretToken(newToken)
+
+	if err := u.repo.UpdateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to store pending email", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to request email change", 500)
+	}
+
+	expires := expiresAt.UTC().Format("2006-01-02 15:04 MST")
+	if err := u.mail.SendEmail([]string{user.Email}, "Confirm your email change",
+		fmt.Sprintf(`<p>A request was made to change your account email to <strong>%s</strong>.</p>
+<p><a href="%s">Confirm the change</a> before %s. If this was not you, change your password; the email stays as it is.</p>`,
+			html.EscapeString(newEmail), html.EscapeString(u.emailChangeURL(oldToken)), expires), nil); err != nil {
+		logger.FromContext(ctx).Error("Failed to mail current address", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to send confirmation email", 500)
+	}
+	if err := u.mail.SendEmail([]string{newEmail}, "Confirm your new email",
+		fmt.Sprintf(`<p><a href="%s">Confirm this address</a> for your account before %s.</p>`,
+			html.EscapeString(u.emailChangeURL(newToken)), expires), nil); err != nil {
+		logger.FromContext(ctx).Error("Failed to mail new address", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to send confirmation email", 500)
+	}
+
+	logger.FromContext(ctx).Info("Email change requested", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// ConfirmEmailChange uses one of the two confirmation tokens and applies the
+// pending email once both have been used
+func (u *authUsecase) ConfirmEmailChange(ctx context.Context, token string) (*entity.User, error) {
+	tokenHash := hashSecretToken(token)
+
+	user, err := u.repo.GetUserByEmailChangeToken(ctx, tokenHash)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrEmailChangeInvalidError
+		}
+		logger.FromContext(ctx).Error("Failed to get user by email change token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to confirm email change", 500)
+	}
+
+	if user.PendingEmail == nil || user.PendingEmailExpiresAt == nil || u.clock.Now().After(*user.PendingEmailExpiresAt) {
+		return nil, errors.ErrEmailChangeInvalidError
+	}
+
+	if user.EmailChangeOldToken == tokenHash {
+		user.EmailChangeOldToken = ""
+	} else {
+		user.EmailChangeNewToken = ""
+	}
+
+	if user.EmailChangeOldToken != "" || user.EmailChangeNewToken != "" {
+		if err := u.repo.UpdateUser(ctx, user); err != nil {
+			logger.FromContext(ctx).Error("Failed to confirm email change", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to confirm email change", 500)
+		}
+		return user, nil
+	}
+
+	// The address may have been taken since the change was requested
+	if err := u.checkEmailAvailable(ctx, *user.PendingEmail); err != nil {
+		return nil, err
+	}
+	user.Email = *user.PendingEmail
+	clearPendingEmail(user)
+
+	// Sessions started with the old address are signed out
+	if err := u.repo.ChangeEmail(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to apply email change", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to confirm email change", 500)
+	}
+
+	logger.FromContext(ctx).Info("Email changed", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// CancelEmailChange discards the pending email and its confirmation links
+func (u *authUsecase) CancelEmailChange(ctx context.Context, userID uuid.UUID) error {
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.PendingEmail == nil {
+		return nil
+	}
+
+	clearPendingEmail(user)
+	if err := u.repo.UpdateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to cancel email change", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to cancel email change", 500)
+	}
+	return nil
+}
+
+// checkEmailAvailable fails when any user holds email, including deactivated
+// and deleted ones, since the unique index still covers them. Callers have
+// already ruled out the user's own current email.
+func (u *authUsecase) checkEmailAvailable(ctx context.Context, email string) error {
+	taken, err := u.repo.EmailTaken(ctx, email)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check existing user by email", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to check existing user", 500)
+	}
+	if taken {
+		return errors.New(errors.ErrUserExists,
+			fmt.Sprintf("User with email %s already exists", email), 409)
+	}
+	return nil
+}
+
+// emailChangeURL is the confirmation page, which works without a frontend
+func (u *authUsecase) emailChangeURL(token string) string {
+	return u.config.Account.URL + "/auth/email/confirm?" + url.Values{"token": {token}}.Encode()
+}
+
+func clearPendingEmail(user *entity.User) {
+	user.PendingEmail = nil
+	user.PendingEmailExpiresAt = nil
+	user.EmailChangeOldToken = ""
+	user.EmailChangeNewToken = ""
+}
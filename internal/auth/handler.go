@@ -2,9 +2,16 @@
 package auth
 
 import (
+	"math"
+	"strconv"
+	"time"
+
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/internal/pages"
 	"go-clean-gin/pkg/errors"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/patch"
 	"go-clean-gin/pkg/response"
 	"go-clean-gin/pkg/validator"
 
@@ -14,12 +21,16 @@ import (
 )
 
 type AuthHandler struct {
-	usecase AuthUsecase
+	usecase  AuthUsecase
+	throttle *Throttle
 }
 
-func NewAuthHandler(usecase AuthUsecase) *AuthHandler {
+// NewAuthHandler creates the auth handler. A nil throttle disables the
+// login and register attempt limits.
+func NewAuthHandler(usecase AuthUsecase, throttle *Throttle) *AuthHandler {
 	return &AuthHandler{
-		usecase: usecase,
+		usecase:  usecase,
+		throttle: throttle,
 	}
 }
 
@@ -33,13 +44,14 @@ func NewAuthHandler(usecase AuthUsecase) *AuthHandler {
 // @Success 201 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req entity.RegisterRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
 		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
 		return
 	}
@@ -49,9 +61,17 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	if h.throttle != nil {
+		if wait, ok := h.throttle.Register(c.ClientIP(), req.Email); !ok {
+			logger.FromContext(c.Request.Context()).Warn("Register throttled", zap.String("ip", c.ClientIP()))
+			tooManyRequests(c, wait)
+			return
+		}
+	}
+
 	authResponse, err := h.usecase.Register(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("Failed to register user", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to register user", zap.Error(err))
 
 		// Handle specific errors
 		if appErr, ok := err.(*errors.AppError); ok {
@@ -67,7 +87,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Login user
-// @Description Login with email and password
+// @Description Login with email and password. With remember_me the refresh token lasts longer.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -75,13 +95,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 429 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req entity.LoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
 		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
 		return
 	}
@@ -91,9 +112,17 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if h.throttle != nil {
+		if wait, ok := h.throttle.Login(c.ClientIP(), req.Email); !ok {
+			logger.FromContext(c.Request.Context()).Warn("Login throttled", zap.String("ip", c.ClientIP()))
+			tooManyRequests(c, wait)
+			return
+		}
+	}
+
 	authResponse, err := h.usecase.Login(c.Request.Context(), &req)
 	if err != nil {
-		logger.Error("Failed to login", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to login", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -103,9 +132,103 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if h.throttle != nil {
+		h.throttle.ClearLogin(req.Email)
+	}
+
 	response.Success(c, 200, "Login successful", authResponse)
 }
 
+// Refresh godoc
+// @Summary Refresh session
+// @Description Trade a refresh token for a new access token and refresh token. The refresh token can be used once; the session's expiry slides forward up to its maximum lifetime.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body entity.RefreshRequest true "Refresh token"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req entity.RefreshRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	authResponse, err := h.usecase.Refresh(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to refresh session", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to refresh session", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Session refreshed successfully", authResponse)
+}
+
+// CheckAvailability godoc
+// @Summary Check email and username availability
+// @Description Check whether an email and/or username can still be registered, so registration forms can validate as the user types. Limited per client IP.
+// @Tags auth
+// @Produce json
+// @Param email query string false "Email to check"
+// @Param username query string false "Username to check"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/availability [get]
+func (h *AuthHandler) CheckAvailability(c *gin.Context) {
+	var req entity.AvailabilityRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	if h.throttle != nil {
+		if wait, ok := h.throttle.Availability(c.ClientIP()); !ok {
+			logger.FromContext(c.Request.Context()).Warn("Availability check throttled", zap.String("ip", c.ClientIP()))
+			tooManyRequests(c, wait)
+			return
+		}
+	}
+
+	result, err := h.usecase.CheckAvailability(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to check availability", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to check availability", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Availability checked successfully", result)
+}
+
 // Profile godoc
 // @Summary Get user profile
 // @Description Get current user profile
@@ -132,7 +255,7 @@ func (h *AuthHandler) Profile(c *gin.Context) {
 
 	user, err := h.usecase.GetUserByID(c.Request.Context(), userIDParsed)
 	if err != nil {
-		logger.Error("Failed to get user profile", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to get user profile", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -144,3 +267,405 @@ func (h *AuthHandler) Profile(c *gin.Context) {
 
 	response.Success(c, 200, "Profile retrieved successfully", user)
 }
+
+// UpdateProfile godoc
+// @Summary Update user profile
+// @Description Change the current user's username and names; fields left out keep their value
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.UpdateProfileRequest true "Profile fields to change"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/profile [put]
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdateProfileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	h.updateProfile(c, userID, &req)
+}
+
+// PatchProfile godoc
+// @Summary Patch user profile
+// @Description Patch the current user's profile, the fields of UpdateProfileRequest, with a JSON Merge Patch (application/merge-patch+json) or a JSON Patch (application/json-patch+json). Fields cannot be removed; a failing test operation answers 409.
+// @Tags auth
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security Bearer
+// @Param patch body object true "Merge patch or JSON Patch"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/profile [patch]
+func (h *AuthHandler) PatchProfile(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.usecase.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get user profile", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get user profile", nil)
+		}
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	var req entity.UpdateProfileRequest
+	if err := patch.Apply(c.ContentType(), entity.NewUpdateProfileRequest(user), body, &req); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 400, errors.ErrBadRequest, "Invalid patch", err.Error())
+		}
+		return
+	}
+
+	h.updateProfile(c, userID, &req)
+}
+
+// updateProfile validates the update request, however it was built, and
+// applies it
+func (h *AuthHandler) updateProfile(c *gin.Context, userID uuid.UUID, req *entity.UpdateProfileRequest) {
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	user, err := h.usecase.UpdateProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update profile", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to update profile", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Profile updated successfully", user)
+}
+
+// RequestEmailChange godoc
+// @Summary Request email change
+// @Description Start changing the current user's email. Confirmation links are mailed to both the current and the new address; the email changes once both are used.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.ChangeEmailRequest true "New email and password confirmation"
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/email/change [post]
+func (h *AuthHandler) RequestEmailChange(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.ChangeEmailRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	user, err := h.usecase.RequestEmailChange(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to request email change", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to request email change", nil)
+		}
+		return
+	}
+
+	response.Success(c, 202, "Confirmation links sent to both addresses", user)
+}
+
+// ConfirmEmailChange godoc
+// @Summary Confirm email change
+// @Description Confirm an email change through a link mailed to the current or the new address. The email changes once both links are used.
+// @Tags auth
+// @Produce json
+// @Param token query string true "Confirmation token"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/email/confirm [get]
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		appErr := errors.ErrEmailChangeInvalidError
+		response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+		return
+	}
+
+	user, err := h.usecase.ConfirmEmailChange(c.Request.Context(), token)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to confirm email change", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to confirm email change", nil)
+		}
+		return
+	}
+
+	message := "Email changed successfully"
+	if user.PendingEmail != nil {
+		message = "Address confirmed, waiting for the other confirmation"
+	}
+	response.Success(c, 200, message, gin.H{
+		"email":         user.Email,
+		"pending_email": user.PendingEmail,
+	})
+}
+
+// CancelEmailChange godoc
+// @Summary Cancel email change
+// @Description Discard the current user's pending email change
+// @Tags auth
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/email/change [delete]
+func (h *AuthHandler) CancelEmailChange(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.CancelEmailChange(c.Request.Context(), userID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to cancel email change", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to cancel email change", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Email change cancelled", nil)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Mail a password reset link to the user with the email. The response is the same whether or not the email is registered. The link opens a reset page served by the API and expires after ACCOUNT_PASSWORD_RESET_TTL.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body entity.ForgotPasswordRequest true "Account email"
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req entity.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	if h.throttle != nil {
+		if wait, ok := h.throttle.PasswordReset(c.ClientIP(), req.Email); !ok {
+			logger.FromContext(c.Request.Context()).Warn("Password reset throttled", zap.String("ip", c.ClientIP()))
+			tooManyRequests(c, wait)
+			return
+		}
+	}
+
+	if err := h.usecase.RequestPasswordReset(c.Request.Context(), &req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to request password reset", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to request password reset", nil)
+		}
+		return
+	}
+
+	response.Success(c, 202, "If the email is registered, a reset link has been sent to it", nil)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token of a password reset link. The link stops working and every session of the user is signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body entity.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req entity.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	if err := h.usecase.ResetPassword(c.Request.Context(), &req); err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to reset password", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to reset password", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Password reset successfully", nil)
+}
+
+// ConfirmEmailChangePage confirms an email change from a mailed link and
+// shows the outcome as a page
+func (h *AuthHandler) ConfirmEmailChangePage(c *gin.Context) {
+	user, err := h.usecase.ConfirmEmailChange(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to confirm email change", zap.Error(err))
+		errorPage(c, err, "Email not changed")
+		return
+	}
+
+	if user.PendingEmail != nil {
+		pages.Message(c, 200, "Address confirmed",
+			"Thanks! Your email changes once the link sent to the other address has been used as well.")
+		return
+	}
+	pages.Message(c, 200, "Email changed", "Your account email is now "+user.Email+".")
+}
+
+// PasswordResetPage shows the form for a new password when the reset link can
+// still be used
+func (h *AuthHandler) PasswordResetPage(c *gin.Context) {
+	token := c.Query("token")
+	if err := h.usecase.CheckPasswordResetToken(c.Request.Context(), token); err != nil {
+		errorPage(c, err, "Password not reset")
+		return
+	}
+
+	pages.PasswordReset(c, 200, token, nil)
+}
+
+// SubmitPasswordReset handles the reset page's form. Invalid passwords show the
+// form again with the errors.
+func (h *AuthHandler) SubmitPasswordReset(c *gin.Context) {
+	var req entity.ResetPasswordRequest
+	if err := c.ShouldBind(&req); err != nil {
+		pages.Message(c, 400, "Password not reset", "The form could not be read, please try again.")
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		if _, ok := fieldErrors["token"]; ok {
+			errorPage(c, errors.ErrPasswordResetInvalidError, "Password not reset")
+			return
+		}
+		pages.PasswordReset(c, 400, req.Token, passwordFormErrors(fieldErrors))
+		return
+	}
+
+	if err := h.usecase.ResetPassword(c.Request.Context(), &req); err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to reset password", zap.Error(err))
+		errorPage(c, err, "Password not reset")
+		return
+	}
+
+	pages.Message(c, 200, "Password changed", "Your password has been changed and you have been signed out everywhere. You can now sign in with the new password.")
+}
+
+// passwordFormErrors phrases the field errors of the reset form for people
+// rather than API clients
+func passwordFormErrors(fieldErrors map[string]string) []string {
+	var messages []string
+	if _, ok := fieldErrors["password"]; ok {
+		messages = append(messages, "The password must be at least 6 characters long.")
+	}
+	if _, ok := fieldErrors["password_confirmation"]; ok {
+		messages = append(messages, "The passwords do not match.")
+	}
+	return messages
+}
+
+// errorPage shows a usecase error as a page, with its message when it is an
+// AppError
+func errorPage(c *gin.Context, err error, title string) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		pages.Message(c, appErr.StatusCode, title, appErr.Message+".")
+		return
+	}
+	pages.Message(c, 500, title, "Something went wrong, please try again later.")
+}
+
+// tooManyRequests rejects a throttled attempt and tells the client when to retry.
+func tooManyRequests(c *gin.Context, wait time.Duration) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+
+	appErr := errors.ErrTooManyRequestsError
+	response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, gin.H{"retry_after": seconds})
+}
@@ -0,0 +1,30 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/test/apitest"
+	"go-clean-gin/test/loadtest"
+)
+
+// BenchmarkAuthHandler_Login measures the login request path. apitest hashes
+// passwords at bcrypt.MinCost, so hashing cost is excluded; use the k6 profile
+// against a seeded server for end-to-end numbers.
+func BenchmarkAuthHandler_Login(b *testing.B) {
+	api := apitest.New(b).WithoutContract()
+	user := api.CreateUser()
+
+	req := entity.LoginRequest{Email: user.Email, Password: apitest.DefaultPassword}
+	var latencies loadtest.Latencies
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		latencies.Time(func() {
+			api.Post("/api/v1/auth/login", req).Do().
+				AssertStatus(http.StatusOK)
+		})
+	}
+	latencies.Report(b)
+}
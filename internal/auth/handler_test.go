@@ -0,0 +1,220 @@
+package auth_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/patch"
+	"go-clean-gin/test/apitest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_CheckAvailability(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var result entity.AvailabilityResponse
+	api.Get("/api/v1/auth/availability").
+		Query("email", user.Email).
+		Query("username", "free_"+user.Username).
+		Do().
+		AssertStatus(http.StatusOK).
+		Decode(&result)
+
+	require.NotNil(t, result.EmailAvailable)
+	require.NotNil(t, result.UsernameAvailable)
+	assert.False(t, *result.EmailAvailable)
+	assert.True(t, *result.UsernameAvailable)
+}
+
+func TestAuthHandler_CheckAvailability_NothingToCheck(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Get("/api/v1/auth/availability").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("Email")
+}
+
+func TestAuthHandler_UpdateProfile(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+	other := api.CreateUser()
+
+	firstName := "Johnny"
+	var profile entity.User
+	api.As(user).Put("/api/v1/auth/profile", entity.UpdateProfileRequest{FirstName: &firstName}).Do().
+		AssertStatus(http.StatusOK).
+		AssertSuccess().
+		Decode(&profile)
+	assert.Equal(t, "Johnny", profile.FirstName)
+	assert.Equal(t, user.LastName, profile.LastName)
+
+	api.As(user).Patch("/api/v1/auth/profile", []byte(`[{"op": "copy", "from": "/first_name", "path": "/last_name"}]`)).
+		Header("Content-Type", patch.JSONPatch).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&profile)
+	assert.Equal(t, "Johnny", profile.LastName)
+
+	api.As(user).Patch("/api/v1/auth/profile", []byte(`{"username": "`+other.Username+`"}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusConflict).
+		AssertErrorCode(errors.ErrUserExists)
+
+	api.As(user).Patch("/api/v1/auth/profile", []byte(`{"email": "new@example.com"}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrPatchInvalid)
+
+	api.As(user).Patch("/api/v1/auth/profile", []byte(`{"first_name": ""}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("first_name")
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var login entity.AuthResponse
+	api.Post("/api/v1/auth/login", entity.LoginRequest{
+		Email:      user.Email,
+		Password:   apitest.DefaultPassword,
+		RememberMe: true,
+	}).Do().AssertStatus(http.StatusOK).Decode(&login)
+	require.NotEmpty(t, login.RefreshToken)
+
+	var refreshed entity.AuthResponse
+	api.Post("/api/v1/auth/refresh", entity.RefreshRequest{RefreshToken: login.RefreshToken}).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&refreshed)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+
+	// The new access token works
+	api.WithToken(refreshed.Token).Get("/api/v1/auth/profile").Do().
+		AssertStatus(http.StatusOK)
+
+	// A refresh token can only be used once
+	api.Post("/api/v1/auth/refresh", entity.RefreshRequest{RefreshToken: login.RefreshToken}).Do().
+		AssertStatus(http.StatusUnauthorized).
+		AssertErrorCode(errors.ErrTokenInvalid)
+}
+
+// resetToken gives a user a password reset link that is valid for an hour
+func resetToken(u *entity.User) {
+	sum := sha256.Sum256([]byte("reset-token"))
+	expiresAt := time.Now().Add(time.Hour)
+	u.PasswordResetToken = hex.EncodeToString(sum[:])
+	u.PasswordResetExpiresAt = &expiresAt
+}
+
+func TestAuthHandler_ForgotPassword_UnknownEmail(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	// Unknown emails get the same answer, so registered ones can't be probed
+	api.Post("/api/v1/auth/password/forgot", entity.ForgotPasswordRequest{Email: "nobody@example.com"}).Do().
+		AssertStatus(http.StatusAccepted)
+}
+
+func TestAuthHandler_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser(resetToken)
+
+	api.Post("/api/v1/auth/password/reset", entity.ResetPasswordRequest{
+		Token:                "reset-token",
+		Password:             "new-password",
+		PasswordConfirmation: "other-password",
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("password_confirmation")
+
+	api.Post("/api/v1/auth/password/reset", entity.ResetPasswordRequest{
+		Token:                "reset-token",
+		Password:             "new-password",
+		PasswordConfirmation: "new-password",
+	}).Do().AssertStatus(http.StatusOK)
+
+	api.Post("/api/v1/auth/login", entity.LoginRequest{Email: user.Email, Password: "new-password"}).Do().
+		AssertStatus(http.StatusOK)
+
+	// The link works once
+	api.Post("/api/v1/auth/password/reset", entity.ResetPasswordRequest{
+		Token:                "reset-token",
+		Password:             "another-password",
+		PasswordConfirmation: "another-password",
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrPasswordResetInvalid)
+}
+
+func TestAuthHandler_PasswordResetPage(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser(resetToken)
+
+	res := api.Get("/auth/password/reset").Query("token", "reset-token").Do().AssertStatus(http.StatusOK)
+	assert.Contains(t, res.Recorder.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, res.Body(), `name="token" value="reset-token"`)
+
+	form := url.Values{"token": {"reset-token"}, "password": {"new-password"}, "password_confirmation": {"typo"}}
+	res = api.Request(http.MethodPost, "/auth/password/reset", []byte(form.Encode())).
+		Header("Content-Type", "application/x-www-form-urlencoded").
+		Do().
+		AssertStatus(http.StatusBadRequest)
+	assert.Contains(t, res.Body(), "The passwords do not match.")
+
+	form.Set("password_confirmation", "new-password")
+	res = api.Request(http.MethodPost, "/auth/password/reset", []byte(form.Encode())).
+		Header("Content-Type", "application/x-www-form-urlencoded").
+		Do().
+		AssertStatus(http.StatusOK)
+	assert.Contains(t, res.Body(), "Password changed")
+
+	api.Post("/api/v1/auth/login", entity.LoginRequest{Email: user.Email, Password: "new-password"}).Do().
+		AssertStatus(http.StatusOK)
+
+	res = api.Get("/auth/password/reset").Query("token", "reset-token").Do().AssertStatus(http.StatusBadRequest)
+	assert.Contains(t, res.Body(), "Reset link is invalid or has expired")
+}
+
+func TestAuthHandler_ConfirmEmailChangePage(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	pending := "changed_" + uuid.NewString()[:8] + "@example.com"
+	sum := sha256.Sum256([]byte("confirm-token"))
+	expiresAt := time.Now().Add(time.Hour)
+	api.CreateUser(func(u *entity.User) {
+		u.PendingEmail = &pending
+		u.PendingEmailExpiresAt = &expiresAt
+		u.EmailChangeNewToken = hex.EncodeToString(sum[:])
+	})
+
+	res := api.Get("/auth/email/confirm").Query("token", "confirm-token").Do().AssertStatus(http.StatusOK)
+	assert.Contains(t, res.Body(), "Your account email is now "+pending)
+
+	res = api.Get("/auth/email/confirm").Query("token", "confirm-token").Do().AssertStatus(http.StatusBadRequest)
+	assert.Contains(t, res.Body(), "Confirmation link is invalid or has expired")
+}
@@ -0,0 +1,142 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuthRepository is a testify mock of AuthRepository
+type MockAuthRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	args := m.Called(ctx, email)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	args := m.Called(ctx, username)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetUserByEmailChangeToken(ctx context.Context, tokenHash string) (*entity.User, error) {
+	args := m.Called(ctx, tokenHash)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) GetUserByPasswordResetToken(ctx context.Context, tokenHash string) (*entity.User, error) {
+	args := m.Called(ctx, tokenHash)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) ResetPassword(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) ChangeEmail(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) EmailTaken(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	args := m.Called(ctx, username)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) ConsumeRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+
+	var r0 *entity.RefreshToken
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.RefreshToken)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthRepository) DeleteExpiredRefreshTokens(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
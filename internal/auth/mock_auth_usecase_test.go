@@ -0,0 +1,158 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuthUsecase is a testify mock of AuthUsecase
+type MockAuthUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAuthUsecase) Register(ctx context.Context, req *entity.RegisterRequest) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) Login(ctx context.Context, req *entity.LoginRequest) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *entity.UpdateProfileRequest) (*entity.User, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) ValidateToken(ctx context.Context, token string) (*entity.User, error) {
+	args := m.Called(ctx, token)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, req *entity.ChangeEmailRequest) (*entity.User, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) ConfirmEmailChange(ctx context.Context, token string) (*entity.User, error) {
+	args := m.Called(ctx, token)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) CancelEmailChange(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) RequestPasswordReset(ctx context.Context, req *entity.ForgotPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) CheckPasswordResetToken(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) ResetPassword(ctx context.Context, req *entity.ResetPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) CheckAvailability(ctx context.Context, req *entity.AvailabilityRequest) (*entity.AvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.AvailabilityResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AvailabilityResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) Refresh(ctx context.Context, req *entity.RefreshRequest) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) StartSession(ctx context.Context, user *entity.User, rememberMe bool) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, user, rememberMe)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAuthUsecase) PruneRefreshTokens(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,27 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBackend is a testify mock of Backend
+type MockBackend struct {
+	mock.Mock
+}
+
+func (m *MockBackend) Authenticate(ctx context.Context, email string, password string) (*entity.User, error) {
+	args := m.Called(ctx, email, password)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
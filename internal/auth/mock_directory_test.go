@@ -0,0 +1,27 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+
+	"go-clean-gin/pkg/ldap"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDirectory is a testify mock of Directory
+type MockDirectory struct {
+	mock.Mock
+}
+
+func (m *MockDirectory) Authenticate(ctx context.Context, email string, password string) (*ldap.Entry, error) {
+	args := m.Called(ctx, email, password)
+
+	var r0 *ldap.Entry
+	if v := args.Get(0); v != nil {
+		r0 = v.(*ldap.Entry)
+	}
+
+	return r0, args.Error(1)
+}
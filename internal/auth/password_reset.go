@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// RequestPasswordReset mails a reset link to the user with the email. It
+// succeeds whether or not there is such a user, so it cannot be used to find
+// out which emails are registered. A new request replaces the previous link.
+func (u *authUsecase) RequestPasswordReset(ctx context.Context, req *entity.ForgotPasswordRequest) error {
+	user, err := u.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			logger.FromContext(ctx).Info("Password reset requested for unknown email")
+			return nil
+		}
+		logger.FromContext(ctx).Error("Failed to get user by email", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to request password reset", 500)
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+
+	expiresAt := u.clock.Now().Add(u.config.Account.PasswordResetTTL)
+	user.PasswordResetToken = hashSecretToken(token)
+	user.PasswordResetExpiresAt = &expiresAt
+
+	if err := u.repo.UpdateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to store password reset token", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to request password reset", 500)
+	}
+
+	if err := u.mail.SendEmail([]string{user.Email}, "Reset your password",
+		fmt.Sprintf(`<p>A password reset was requested for your account.</p>
+<p><a href="%s">Choose a new password</a> before %s. If this was not you, ignore this email; your password stays as it is.</p>`,
+			html.EscapeString(u.passwordResetURL(token)), expiresAt.UTC().Format("2006-01-02 15:04 MST")), nil); err != nil {
+		logger.FromContext(ctx).Error("Failed to mail password reset link", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to send password reset email", 500)
+	}
+
+	logger.FromContext(ctx).Info("Password reset requested", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// CheckPasswordResetToken reports whether a reset link can still be used, so
+// the reset form is only shown for links that work
+func (u *authUsecase) CheckPasswordResetToken(ctx context.Context, token string) error {
+	_, err := u.getUserByPasswordResetToken(ctx, token)
+	return err
+}
+
+// ResetPassword sets a new password with the token of a reset link. The link
+// stops working and every session of the user is signed out.
+func (u *authUsecase) ResetPassword(ctx context.Context, req *entity.ResetPasswordRequest) error {
+	user, err := u.getUserByPasswordResetToken(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to hash password", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to hash password", 500)
+	}
+
+	user.Password = string(hashedPassword)
+	user.PasswordResetToken = ""
+	user.PasswordResetExpiresAt = nil
+
+	if err := u.repo.ResetPassword(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to reset password", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to reset password", 500)
+	}
+
+	logger.FromContext(ctx).Info("Password reset", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+func (u *authUsecase) getUserByPasswordResetToken(ctx context.Context, token string) (*entity.User, error) {
+	if token == "" {
+		return nil, errors.ErrPasswordResetInvalidError
+	}
+
+	user, err := u.repo.GetUserByPasswordResetToken(ctx, hashSecretToken(token))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrPasswordResetInvalidError
+		}
+		logger.FromContext(ctx).Error("Failed to get user by password reset token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to reset password", 500)
+	}
+
+	if user.PasswordResetExpiresAt == nil || u.clock.Now().After(*user.PasswordResetExpiresAt) {
+		return nil, errors.ErrPasswordResetInvalidError
+	}
+	return user, nil
+}
+
+// passwordResetURL is the reset page, which works without a frontend
+func (u *authUsecase) passwordResetURL(token string) string {
+	return u.config.Account.URL + "/auth/password/reset?" + url.Values{"token": {token}}.Encode()
+}
@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/ldap"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,7 +14,18 @@ type AuthUsecase interface {
 	Register(ctx context.Context, req *entity.RegisterRequest) (*entity.AuthResponse, error)
 	Login(ctx context.Context, req *entity.LoginRequest) (*entity.AuthResponse, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req *entity.UpdateProfileRequest) (*entity.User, error)
 	ValidateToken(ctx context.Context, token string) (*entity.User, error)
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, req *entity.ChangeEmailRequest) (*entity.User, error)
+	ConfirmEmailChange(ctx context.Context, token string) (*entity.User, error)
+	CancelEmailChange(ctx context.Context, userID uuid.UUID) error
+	RequestPasswordReset(ctx context.Context, req *entity.ForgotPasswordRequest) error
+	CheckPasswordResetToken(ctx context.Context, token string) error
+	ResetPassword(ctx context.Context, req *entity.ResetPasswordRequest) error
+	CheckAvailability(ctx context.Context, req *entity.AvailabilityRequest) (*entity.AvailabilityResponse, error)
+	Refresh(ctx context.Context, req *entity.RefreshRequest) (*entity.AuthResponse, error)
+	StartSession(ctx context.Context, user *entity.User, rememberMe bool) (*entity.AuthResponse, error)
+	PruneRefreshTokens(ctx context.Context) (int64, error)
 }
 
 // AuthRepository defines the data access interface for authentication
@@ -22,4 +35,24 @@ type AuthRepository interface {
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 	UpdateUser(ctx context.Context, user *entity.User) error
+	GetUserByEmailChangeToken(ctx context.Context, tokenHash string) (*entity.User, error)
+	GetUserByPasswordResetToken(ctx context.Context, tokenHash string) (*entity.User, error)
+	ResetPassword(ctx context.Context, user *entity.User) error
+	ChangeEmail(ctx context.Context, user *entity.User) error
+	EmailTaken(ctx context.Context, email string) (bool, error)
+	UsernameTaken(ctx context.Context, username string) (bool, error)
+	CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error
+	ConsumeRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	DeleteExpiredRefreshTokens(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Backend checks a user's password where it lives and returns the local user.
+// A backend that cannot decide returns ErrSkipBackend.
+type Backend interface {
+	Authenticate(ctx context.Context, email, password string) (*entity.User, error)
+}
+
+// Directory checks passwords against an LDAP directory
+type Directory interface {
+	Authenticate(ctx context.Context, email, password string) (*ldap.Entry, error)
 }
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// UpdateProfile changes the username and names of the user. A new username
+// must not be held by any other user, deactivated and deleted ones included.
+func (u *authUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *entity.UpdateProfileRequest) (*entity.User, error) {
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Username != nil && *req.Username != user.Username {
+		taken, err := u.repo.UsernameTaken(ctx, *req.Username)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to check username availability", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to check existing user", 500)
+		}
+		if taken {
+			return nil, errors.New(errors.ErrUserExists,
+				fmt.Sprintf("User with username %s already exists", *req.Username), 409)
+		}
+		user.Username = *req.Username
+	}
+	if req.FirstName != nil {
+		user.FirstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		user.LastName = *req.LastName
+	}
+
+	if err := u.repo.UpdateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to update profile", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update profile", 500)
+	}
+
+	logger.FromContext(ctx).Info("Profile updated successfully", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Refresh trades a refresh token for a new access token and a new refresh
+// token. The new refresh token gets a full TTL again, capped by the session's
+// MaxLifetime; the old one stops working.
+func (u *authUsecase) Refresh(ctx context.Context, req *entity.RefreshRequest) (*entity.AuthResponse, error) {
+	stored, err := u.repo.ConsumeRefreshToken(ctx, hashSecretToken(req.RefreshToken))
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrTokenInvalidError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to consume refresh token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to refresh session", 500)
+	}
+
+	if !u.clock.Now().Before(stored.ExpiresAt) {
+		return nil, errors.ErrTokenExpiredError
+	}
+
+	user, err := u.repo.GetUserByID(ctx, stored.UserID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrTokenInvalidError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to refresh session", 500)
+	}
+
+	return u.issueSession(ctx, user, stored.RememberMe, stored.StartedAt)
+}
+
+// StartSession signs a user in who was authenticated elsewhere, such as by an
+// SSO identity provider
+func (u *authUsecase) StartSession(ctx context.Context, user *entity.User, rememberMe bool) (*entity.AuthResponse, error) {
+	return u.issueSession(ctx, user, rememberMe, u.clock.Now())
+}
+
+// PruneRefreshTokens deletes refresh tokens that can no longer be used
+func (u *authUsecase) PruneRefreshTokens(ctx context.Context) (int64, error) {
+	return u.repo.DeleteExpiredRefreshTokens(ctx, u.clock.Now())
+}
+
+// issueSession signs an access token and stores a refresh token for a session
+// that started at startedAt
+func (u *authUsecase) issueSession(ctx context.Context, user *entity.User, rememberMe bool, startedAt time.Time) (*entity.AuthResponse, error) {
+	token, err := u.generateToken(ctx, user.ID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to generate token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+
+	refreshToken, err := newSecretToken()
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to generate refresh token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+
+	stored := &entity.RefreshToken{
+		UserID:     user.ID,
+		TokenHash:  hashSecretToken(refreshToken),
+		RememberMe: rememberMe,
+		StartedAt:  startedAt,
+		ExpiresAt:  u.refreshExpiry(rememberMe, startedAt),
+	}
+	if err := u.repo.CreateRefreshToken(ctx, stored); err != nil {
+		logger.FromContext(ctx).Error("Failed to store refresh token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+
+	return &entity.AuthResponse{
+		User:             user,
+		Token:            token,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// refreshExpiry slides the refresh window forward from now without letting
+// the session outlive MaxLifetime
+func (u *authUsecase) refreshExpiry(rememberMe bool, startedAt time.Time) time.Time {
+	ttl := u.config.JWT.RefreshTTL
+	if rememberMe {
+		ttl = u.config.JWT.RememberMeTTL
+	}
+
+	expiresAt := u.clock.Now().Add(ttl)
+	if u.config.JWT.MaxLifetime > 0 {
+		if limit := startedAt.Add(u.config.JWT.MaxLifetime); expiresAt.After(limit) {
+			expiresAt = limit
+		}
+	}
+	return expiresAt
+}
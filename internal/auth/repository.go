@@ -3,9 +3,12 @@ package auth
 import (
 	"context"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type authRepository struct {
@@ -19,12 +22,12 @@ func NewAuthRepository(db *gorm.DB) AuthRepository {
 }
 
 func (r *authRepository) CreateUser(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return tenancy.Conn(ctx, r.db).Create(user).Error
 }
 
 func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("email = ? AND is_active = ?", email, true).First(&user).Error
+	err := tenancy.Conn(ctx, r.db).Where("email = ? AND is_active = ?", email, true).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +36,7 @@ func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*ent
 
 func (r *authRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("id = ? AND is_active = ?", userID, true).First(&user).Error
+	err := tenancy.Conn(ctx, r.db).Where("id = ? AND is_active = ?", userID, true).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +45,7 @@ func (r *authRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*en
 
 func (r *authRepository) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("username = ? AND is_active = ?", username, true).First(&user).Error
+	err := tenancy.Conn(ctx, r.db).Where("username = ? AND is_active = ?", username, true).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -50,5 +53,88 @@ func (r *authRepository) GetUserByUsername(ctx context.Context, username string)
 }
 
 func (r *authRepository) UpdateUser(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return tenancy.Conn(ctx, r.db).Save(user).Error
+}
+
+// ResetPassword saves the user with the new password and deletes their
+// refresh tokens, signing out every session
+func (r *authRepository) ResetPassword(ctx context.Context, user *entity.User) error {
+	return r.saveAndRevokeRefreshTokens(ctx, user)
+}
+
+func (r *authRepository) ChangeEmail(ctx context.Context, user *entity.User) error {
+	return r.saveAndRevokeRefreshTokens(ctx, user)
+}
+
+// saveAndRevokeRefreshTokens saves the user and deletes their refresh tokens
+// in one transaction, signing them out everywhere
+func (r *authRepository) saveAndRevokeRefreshTokens(ctx context.Context, user *entity.User) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", user.ID).Delete(&entity.RefreshToken{}).Error
+	})
+}
+
+func (r *authRepository) GetUserByEmailChangeToken(ctx context.Context, tokenHash string) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).
+		Where("(email_change_old_token = ? OR email_change_new_token = ?) AND is_active = ?", tokenHash, tokenHash, true).
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *authRepository) GetUserByPasswordResetToken(ctx context.Context, tokenHash string) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).
+		Where("password_reset_token = ? AND is_active = ?", tokenHash, true).
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// EmailTaken reports whether any user holds the email. Deactivated and deleted
+// users count, since the unique index still covers them.
+func (r *authRepository) EmailTaken(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Unscoped().Model(&entity.User{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+// UsernameTaken reports whether any user holds the username, like EmailTaken
+func (r *authRepository) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Unscoped().Model(&entity.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *authRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	return tenancy.Conn(ctx, r.db).Create(token).Error
+}
+
+// ConsumeRefreshToken deletes the token and returns it, so a token can be
+// used once even when two refreshes race
+func (r *authRepository) ConsumeRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	result := tenancy.Conn(ctx, r.db).Clauses(clause.Returning{}).
+		Where("token_hash = ?", tokenHash).
+		Delete(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &token, nil
+}
+
+func (r *authRepository) DeleteExpiredRefreshTokens(ctx context.Context, before time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("expires_at < ?", before).Delete(&entity.RefreshToken{})
+	return result.RowsAffected, result.Error
 }
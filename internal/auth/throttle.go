@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+)
+
+// Throttle counts login, register and password reset attempts per client IP
+// and per email, and availability checks per client IP, in fixed windows. It is kept in memory, so limits apply per instance.
+type Throttle struct {
+	cfg   config.ThrottleConfig
+	clock clock.Clock
+
+	mu      sync.Mutex
+	windows map[string]*throttleWindow
+	sweepAt time.Time
+}
+
+type throttleWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+func NewThrottle(cfg config.ThrottleConfig, clk clock.Clock) *Throttle {
+	return &Throttle{
+		cfg:     cfg,
+		clock:   clk,
+		windows: make(map[string]*throttleWindow),
+	}
+}
+
+// Login records a login attempt and reports how long the caller must wait
+// when the IP or email has exceeded its limit.
+func (t *Throttle) Login(ip, email string) (time.Duration, bool) {
+	return t.attempt(
+		throttleKey{"login:ip:" + ip, t.cfg.LoginPerIP},
+		throttleKey{"login:email:" + normalizeEmail(email), t.cfg.LoginPerEmail},
+	)
+}
+
+// Register records a registration attempt for the IP and email.
+func (t *Throttle) Register(ip, email string) (time.Duration, bool) {
+	return t.attempt(
+		throttleKey{"register:ip:" + ip, t.cfg.RegisterPerIP},
+		throttleKey{"register:email:" + normalizeEmail(email), t.cfg.RegisterPerEmail},
+	)
+}
+
+// PasswordReset records a password reset request for the IP and email. It
+// shares the register limits: both mail an address on the caller's behalf.
+func (t *Throttle) PasswordReset(ip, email string) (time.Duration, bool) {
+	return t.attempt(
+		throttleKey{"password_reset:ip:" + ip, t.cfg.RegisterPerIP},
+		throttleKey{"password_reset:email:" + normalizeEmail(email), t.cfg.RegisterPerEmail},
+	)
+}
+
+// Availability records an availability check for the IP. Checks are not
+// counted per identifier: probing many identifiers from one address is
+// exactly what the limit is for.
+func (t *Throttle) Availability(ip string) (time.Duration, bool) {
+	return t.attempt(throttleKey{"availability:ip:" + ip, t.cfg.AvailabilityPerIP})
+}
+
+// ClearLogin forgets failed attempts for an email after a successful login.
+func (t *Throttle) ClearLogin(email string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.windows, "login:email:"+normalizeEmail(email))
+}
+
+type throttleKey struct {
+	key   string
+	limit int
+}
+
+func (t *Throttle) attempt(keys ...throttleKey) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	t.sweep(now)
+
+	var retryAfter time.Duration
+	allowed := true
+	for _, k := range keys {
+		if k.limit <= 0 {
+			continue
+		}
+
+		w, ok := t.windows[k.key]
+		if !ok || !now.Before(w.resetAt) {
+			w = &throttleWindow{resetAt: now.Add(t.cfg.Window)}
+			t.windows[k.key] = w
+		}
+		w.count++
+
+		if w.count > k.limit {
+			allowed = false
+			if wait := w.resetAt.Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	return retryAfter, allowed
+}
+
+// sweep drops expired windows at most once per window so the map does not
+// grow with every address that ever hit the endpoints.
+func (t *Throttle) sweep(now time.Time) {
+	if now.Before(t.sweepAt) {
+		return
+	}
+	for key, w := range t.windows {
+		if !now.Before(w.resetAt) {
+			delete(t.windows, key)
+		}
+	}
+	t.sweepAt = now.Add(t.cfg.Window)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
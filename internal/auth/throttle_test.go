@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestThrottle(clk clock.Clock) *Throttle {
+	return NewThrottle(config.ThrottleConfig{
+		Window:            time.Minute,
+		LoginPerIP:        5,
+		LoginPerEmail:     2,
+		RegisterPerIP:     2,
+		RegisterPerEmail:  0,
+		AvailabilityPerIP: 3,
+	}, clk)
+}
+
+func TestThrottle_Login_PerEmail(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	throttle := newTestThrottle(clk)
+
+	_, ok := throttle.Login("10.0.0.1", "user@example.com")
+	assert.True(t, ok)
+	_, ok = throttle.Login("10.0.0.2", "USER@example.com ")
+	assert.True(t, ok)
+
+	clk.Advance(20 * time.Second)
+	wait, ok := throttle.Login("10.0.0.3", "user@example.com")
+	assert.False(t, ok)
+	assert.Equal(t, 40*time.Second, wait)
+
+	// Other emails are unaffected
+	_, ok = throttle.Login("10.0.0.3", "other@example.com")
+	assert.True(t, ok)
+
+	// The window resets
+	clk.Advance(40 * time.Second)
+	_, ok = throttle.Login("10.0.0.3", "user@example.com")
+	assert.True(t, ok)
+}
+
+func TestThrottle_Login_PerIP(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	throttle := newTestThrottle(clk)
+
+	for i := 0; i < 5; i++ {
+		_, ok := throttle.Login("10.0.0.1", string(rune('a'+i))+"@example.com")
+		assert.True(t, ok)
+	}
+
+	_, ok := throttle.Login("10.0.0.1", "fresh@example.com")
+	assert.False(t, ok)
+
+	_, ok = throttle.Login("10.0.0.2", "fresh@example.com")
+	assert.True(t, ok)
+}
+
+func TestThrottle_ClearLogin(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	throttle := newTestThrottle(clk)
+
+	throttle.Login("10.0.0.1", "user@example.com")
+	throttle.Login("10.0.0.1", "user@example.com")
+	throttle.ClearLogin("user@example.com")
+
+	_, ok := throttle.Login("10.0.0.1", "user@example.com")
+	assert.True(t, ok)
+}
+
+func TestThrottle_Register_DisabledLimit(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	throttle := newTestThrottle(clk)
+
+	// RegisterPerEmail is 0, so only the IP limit applies
+	_, ok := throttle.Register("10.0.0.1", "user@example.com")
+	assert.True(t, ok)
+	_, ok = throttle.Register("10.0.0.2", "user@example.com")
+	assert.True(t, ok)
+	_, ok = throttle.Register("10.0.0.1", "user@example.com")
+	assert.True(t, ok)
+	_, ok = throttle.Register("10.0.0.1", "user@example.com")
+	assert.False(t, ok)
+}
+
+func TestThrottle_Availability_PerIP(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	throttle := newTestThrottle(clk)
+
+	for i := 0; i < 3; i++ {
+		_, ok := throttle.Availability("10.0.0.1")
+		assert.True(t, ok)
+	}
+
+	wait, ok := throttle.Availability("10.0.0.1")
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	_, ok = throttle.Availability("10.0.0.2")
+	assert.True(t, ok)
+}
@@ -7,9 +7,11 @@ import (
 
 	"go-clean-gin/config"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
 	"go-clean-gin/pkg/errors"
 	"go-clean-gin/pkg/logger"
 	"go-clean-gin/pkg/mail"
+	"go-clean-gin/pkg/tenancy"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -19,16 +21,22 @@ import (
 )
 
 type authUsecase struct {
-	repo   AuthRepository
-	config *config.Config
-	mail   *mail.Mailer
+	repo     AuthRepository
+	config   *config.Config
+	mail     mail.Sender
+	clock    clock.Clock
+	backends []Backend
 }
 
-func NewAuthUsecase(repo AuthRepository, config *config.Config, mail *mail.Mailer) AuthUsecase {
+// NewAuthUsecase creates the usecase; logins are checked by the backends in
+// order
+func NewAuthUsecase(repo AuthRepository, config *config.Config, mail mail.Sender, clk clock.Clock, backends []Backend) AuthUsecase {
 	return &authUsecase{
-		repo:   repo,
-		config: config,
-		mail:   mail,
+		repo:     repo,
+		config:   config,
+		mail:     mail,
+		clock:    clk,
+		backends: backends,
 	}
 }
 
@@ -36,7 +44,7 @@ func (u *authUsecase) Register(ctx context.Context, req *entity.RegisterRequest)
 	// Check if user already exists
 	existingUser, err := u.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil && err != gorm.ErrRecordNotFound {
-		logger.Error("Failed to check existing user by email", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to check existing user by email", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to check existing user", 500)
 	}
 	if existingUser != nil {
@@ -47,7 +55,7 @@ func (u *authUsecase) Register(ctx context.Context, req *entity.RegisterRequest)
 	// Check username
 	existingUser, err = u.repo.GetUserByUsername(ctx, req.Username)
 	if err != nil && err != gorm.ErrRecordNotFound {
-		logger.Error("Failed to check existing user by username", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to check existing user by username", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to check existing user", 500)
 	}
 	if existingUser != nil {
@@ -58,7 +66,7 @@ func (u *authUsecase) Register(ctx context.Context, req *entity.RegisterRequest)
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		logger.Error("Failed to hash password", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to hash password", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to hash password", 500)
 	}
 
@@ -69,58 +77,39 @@ func (u *authUsecase) Register(ctx context.Context, req *entity.RegisterRequest)
 		Password:  string(hashedPassword),
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		Role:      entity.RoleUser,
 		IsActive:  true,
 	}
 
 	if err := u.repo.CreateUser(ctx, user); err != nil {
-		logger.Error("Failed to create user", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to create user", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create user", 500)
 	}
 
-	// Generate token
-	token, err := u.generateToken(user.ID)
-	if err != nil {
-		logger.Error("Failed to generate token", zap.Error(err))
-		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
-	}
+	logger.FromContext(ctx).Info("User registered successfully", zap.String("user_id", user.ID.String()))
 
-	logger.Info("User registered successfully", zap.String("user_id", user.ID.String()))
-
-	return &entity.AuthResponse{
-		User:  user,
-		Token: token,
-	}, nil
+	return u.issueSession(ctx, user, false, u.clock.Now())
 }
 
+// Login checks the credentials with each backend in turn until one knows the
+// user
 func (u *authUsecase) Login(ctx context.Context, req *entity.LoginRequest) (*entity.AuthResponse, error) {
-	// Get user by email
-	user, err := u.repo.GetUserByEmail(ctx, req.Email)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.ErrInvalidCredentialsError
+	for _, backend := range u.backends {
+		user, err := backend.Authenticate(ctx, req.Email, req.Password)
+		if err == ErrSkipBackend {
+			continue
+		}
+		if err != nil {
+			return nil, err
 		}
-		logger.Error("Failed to get user by email", zap.Error(err))
-		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
-	}
 
-	// Check password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.ErrInvalidCredentialsError
-	}
+		logger.FromContext(ctx).Info("User logged in successfully",
+			zap.String("user_id", user.ID.String()), zap.Bool("remember_me", req.RememberMe))
 
-	// Generate token
-	token, err := u.generateToken(user.ID)
-	if err != nil {
-		logger.Error("Failed to generate token", zap.Error(err))
-		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+		return u.issueSession(ctx, user, req.RememberMe, u.clock.Now())
 	}
 
-	logger.Info("User logged in successfully", zap.String("user_id", user.ID.String()))
-
-	return &entity.AuthResponse{
-		User:  user,
-		Token: token,
-	}, nil
+	return nil, errors.ErrInvalidCredentialsError
 }
 
 func (u *authUsecase) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
@@ -129,19 +118,47 @@ func (u *authUsecase) GetUserByID(ctx context.Context, userID uuid.UUID) (*entit
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrUserNotFoundError
 		}
-		logger.Error("Failed to get user by ID", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
 	}
 	return user, nil
 }
 
+// CheckAvailability reports whether the requested email and username are free
+// to register. Only the identifiers present in the request are checked.
+func (u *authUsecase) CheckAvailability(ctx context.Context, req *entity.AvailabilityRequest) (*entity.AvailabilityResponse, error) {
+	result := &entity.AvailabilityResponse{}
+
+	if req.Email != "" {
+		taken, err := u.repo.EmailTaken(ctx, req.Email)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to check email availability", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to check availability", 500)
+		}
+		available := !taken
+		result.EmailAvailable = &available
+	}
+
+	if req.Username != "" {
+		taken, err := u.repo.UsernameTaken(ctx, req.Username)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to check username availability", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to check availability", 500)
+		}
+		available := !taken
+		result.UsernameAvailable = &available
+	}
+
+	return result, nil
+}
+
 func (u *authUsecase) ValidateToken(ctx context.Context, tokenString string) (*entity.User, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(u.config.JWT.Secret), nil
-	})
+	}, jwt.WithTimeFunc(u.clock.Now))
 
 	if err != nil {
 		return nil, errors.ErrTokenInvalidError.WithDetails(err.Error())
@@ -158,6 +175,12 @@ func (u *authUsecase) ValidateToken(ctx context.Context, tokenString string) (*e
 			return nil, errors.ErrTokenInvalidError.WithDetails("Invalid user ID in token")
 		}
 
+		// User IDs are only unique within a tenant's database
+		tenant, _ := claims["tenant"].(string)
+		if tenant != tenancy.FromContext(ctx) {
+			return nil, errors.ErrTokenInvalidError.WithDetails("Token was issued for another tenant")
+		}
+
 		user, err := u.repo.GetUserByID(ctx, userID)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
@@ -172,11 +195,16 @@ func (u *authUsecase) ValidateToken(ctx context.Context, tokenString string) (*e
 	return nil, errors.ErrTokenInvalidError
 }
 
-func (u *authUsecase) generateToken(userID uuid.UUID) (string, error) {
+// generateToken signs an access token for the user, bound to the tenant of ctx
+func (u *authUsecase) generateToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	now := u.clock.Now()
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
-		"exp":     time.Now().Add(time.Duration(u.config.JWT.ExpirationHours) * time.Hour).Unix(),
-		"iat":     time.Now().Unix(),
+		"exp":     now.Add(time.Duration(u.config.JWT.ExpirationHours) * time.Hour).Unix(),
+		"iat":     now.Unix(),
+	}
+	if tenant := tenancy.FromContext(ctx); tenant != "" {
+		claims["tenant"] = tenant
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
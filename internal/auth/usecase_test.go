@@ -2,47 +2,25 @@ package auth
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"go-clean-gin/config"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/mail"
+	"go-clean-gin/pkg/tenancy"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
-// Mock repository
-type MockAuthRepository struct {
-	mock.Mock
-}
-
-func (m *MockAuthRepository) CreateUser(ctx context.Context, user *entity.User) error {
-	args := m.Called(ctx, user)
-	return args.Error(0)
-}
-
-func (m *MockAuthRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	args := m.Called(ctx, email)
-	return args.Get(0).(*entity.User), args.Error(1)
-}
-
-func (m *MockAuthRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
-	args := m.Called(ctx, userID)
-	return args.Get(0).(*entity.User), args.Error(1)
-}
-
-func (m *MockAuthRepository) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
-	args := m.Called(ctx, username)
-	return args.Get(0).(*entity.User), args.Error(1)
-}
-
-func (m *MockAuthRepository) UpdateUser(ctx context.Context, user *entity.User) error {
-	args := m.Called(ctx, user)
-	return args.Error(0)
-}
-
 func TestAuthUsecase_Register_Success(t *testing.T) {
 	mockRepo := new(MockAuthRepository)
 	cfg := &config.Config{
@@ -51,7 +29,7 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 			ExpirationHours: 24,
 		},
 	}
-	usecase := NewAuthUsecase(mockRepo, cfg, nil)
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clock.New(), []Backend{NewLocalBackend(mockRepo)})
 
 	req := &entity.RegisterRequest{
 		Email:     "test@example.com",
@@ -65,6 +43,7 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 	mockRepo.On("GetUserByEmail", mock.Anything, req.Email).Return((*entity.User)(nil), gorm.ErrRecordNotFound)
 	mockRepo.On("GetUserByUsername", mock.Anything, req.Username).Return((*entity.User)(nil), gorm.ErrRecordNotFound)
 	mockRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+	mockRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
 
 	// Test
 	result, err := usecase.Register(context.Background(), req)
@@ -74,6 +53,7 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, req.Email, result.User.Email)
 	assert.NotEmpty(t, result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -85,7 +65,7 @@ func TestAuthUsecase_Register_EmailExists(t *testing.T) {
 			ExpirationHours: 24,
 		},
 	}
-	usecase := NewAuthUsecase(mockRepo, cfg, nil)
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clock.New(), []Backend{NewLocalBackend(mockRepo)})
 
 	req := &entity.RegisterRequest{
 		Email:     "test@example.com",
@@ -112,3 +92,430 @@ func TestAuthUsecase_Register_EmailExists(t *testing.T) {
 	assert.Contains(t, err.Error(), "already exists")
 	mockRepo.AssertExpectations(t)
 }
+
+func newRefreshTestUsecase(clk clock.Clock) (*MockAuthRepository, AuthUsecase) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:          "test-secret",
+			ExpirationHours: 1,
+			RefreshTTL:      24 * time.Hour,
+			RememberMeTTL:   30 * 24 * time.Hour,
+			MaxLifetime:     40 * 24 * time.Hour,
+		},
+	}
+	return mockRepo, NewAuthUsecase(mockRepo, cfg, nil, clk, []Backend{NewLocalBackend(mockRepo)})
+}
+
+func TestAuthUsecase_Login_RememberMe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo, usecase := newRefreshTestUsecase(clock.NewFake(now))
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com", Password: string(hashed)}
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).Return(nil)
+
+	for _, tt := range []struct {
+		rememberMe bool
+		expiresAt  time.Time
+	}{
+		{false, now.Add(24 * time.Hour)},
+		{true, now.Add(30 * 24 * time.Hour)},
+	} {
+		result, err := usecase.Login(context.Background(), &entity.LoginRequest{
+			Email:      user.Email,
+			Password:   "password123",
+			RememberMe: tt.rememberMe,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, tt.expiresAt, result.RefreshExpiresAt)
+	}
+}
+
+func TestAuthUsecase_Refresh_SlidesUpToMaxLifetime(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(startedAt)
+	mockRepo, usecase := newRefreshTestUsecase(clk)
+
+	user := &entity.User{ID: uuid.New()}
+	stored := &entity.RefreshToken{
+		UserID:     user.ID,
+		RememberMe: true,
+		StartedAt:  startedAt,
+		ExpiresAt:  startedAt.Add(30 * 24 * time.Hour),
+	}
+	mockRepo.On("ConsumeRefreshToken", mock.Anything, hashSecretToken("refresh")).Return(stored, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	var created *entity.RefreshToken
+	mockRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*entity.RefreshToken")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.RefreshToken) }).
+		Return(nil)
+
+	// 20 days in, a full 30 days would pass the 40 day lifetime
+	clk.Advance(20 * 24 * time.Hour)
+	result, err := usecase.Refresh(context.Background(), &entity.RefreshRequest{RefreshToken: "refresh"})
+
+	require.NoError(t, err)
+	assert.Equal(t, startedAt.Add(40*24*time.Hour), result.RefreshExpiresAt)
+	assert.NotEqual(t, "refresh", result.RefreshToken)
+	require.NotNil(t, created)
+	assert.True(t, created.RememberMe)
+	assert.Equal(t, startedAt, created.StartedAt)
+	assert.Equal(t, hashSecretToken(result.RefreshToken), created.TokenHash)
+}
+
+func TestAuthUsecase_Refresh_Rejected(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo, usecase := newRefreshTestUsecase(clock.NewFake(now))
+
+	expired := &entity.RefreshToken{UserID: uuid.New(), StartedAt: now.Add(-48 * time.Hour), ExpiresAt: now}
+	mockRepo.On("ConsumeRefreshToken", mock.Anything, hashSecretToken("expired")).Return(expired, nil)
+	mockRepo.On("ConsumeRefreshToken", mock.Anything, hashSecretToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := usecase.Refresh(context.Background(), &entity.RefreshRequest{RefreshToken: "expired"})
+	assert.Equal(t, errors.ErrTokenExpiredError, err)
+
+	_, err = usecase.Refresh(context.Background(), &entity.RefreshRequest{RefreshToken: "unknown"})
+	assert.Equal(t, errors.ErrTokenInvalidError, err)
+
+	mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_CheckAvailability(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	usecase := NewAuthUsecase(mockRepo, &config.Config{}, nil, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	mockRepo.On("EmailTaken", mock.Anything, "taken@example.com").Return(true, nil)
+	mockRepo.On("UsernameTaken", mock.Anything, "newname").Return(false, nil)
+
+	result, err := usecase.CheckAvailability(context.Background(), &entity.AvailabilityRequest{
+		Email:    "taken@example.com",
+		Username: "newname",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.EmailAvailable)
+	require.NotNil(t, result.UsernameAvailable)
+	assert.False(t, *result.EmailAvailable)
+	assert.True(t, *result.UsernameAvailable)
+
+	// Identifiers that were not asked about are left out
+	result, err = usecase.CheckAvailability(context.Background(), &entity.AvailabilityRequest{Username: "newname"})
+	require.NoError(t, err)
+	assert.Nil(t, result.EmailAvailable)
+	mockRepo.AssertNumberOfCalls(t, "EmailTaken", 1)
+}
+
+func TestAuthUsecase_UpdateProfile(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	usecase := NewAuthUsecase(mockRepo, &config.Config{}, nil, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	user := &entity.User{ID: uuid.New(), Username: "johnd", FirstName: "John", LastName: "Doe"}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UsernameTaken", mock.Anything, "taken").Return(true, nil)
+	mockRepo.On("UpdateUser", mock.Anything, user).Return(nil)
+
+	taken := "taken"
+	_, err := usecase.UpdateProfile(context.Background(), user.ID, &entity.UpdateProfileRequest{Username: &taken})
+	var appErr *errors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errors.ErrUserExists, appErr.Code)
+
+	// Keeping the current username needs no availability check
+	username, firstName := "johnd", "Johnny"
+	updated, err := usecase.UpdateProfile(context.Background(), user.ID, &entity.UpdateProfileRequest{
+		Username:  &username,
+		FirstName: &firstName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Johnny", updated.FirstName)
+	assert.Equal(t, "Doe", updated.LastName)
+	mockRepo.AssertNumberOfCalls(t, "UsernameTaken", 1)
+	mockRepo.AssertNumberOfCalls(t, "UpdateUser", 1)
+}
+
+func TestAuthUsecase_ValidateToken_Expiry(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:          "test-secret",
+			ExpirationHours: 1,
+		},
+	}
+	clk := clock.NewFake(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clk, []Backend{NewLocalBackend(mockRepo)})
+
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+	token, err := usecase.(*authUsecase).generateToken(context.Background(), user.ID)
+	assert.NoError(t, err)
+
+	// Mock expectations
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+
+	// Still valid just before expiry
+	clk.Advance(59 * time.Minute)
+	result, err := usecase.ValidateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, result.ID)
+
+	// Expired once the clock passes exp
+	clk.Advance(2 * time.Minute)
+	result, err = usecase.ValidateToken(context.Background(), token)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_ValidateToken_Tenant(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpirationHours: 1}}
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com"}
+	acme := tenancy.WithTenant(context.Background(), "acme")
+	token, err := usecase.(*authUsecase).generateToken(acme, user.ID)
+	require.NoError(t, err)
+
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+	result, err := usecase.ValidateToken(acme, token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, result.ID)
+
+	// The same user ID in another tenant's database, or the main one, is
+	// another user
+	for _, ctx := range []context.Context{
+		tenancy.WithTenant(context.Background(), "globex"),
+		context.Background(),
+	} {
+		result, err = usecase.ValidateToken(ctx, token)
+		assert.Equal(t, errors.ErrTokenInvalid, err.(*errors.AppError).Code)
+		assert.Nil(t, result)
+	}
+
+	mainToken, err := usecase.(*authUsecase).generateToken(context.Background(), user.ID)
+	require.NoError(t, err)
+	_, err = usecase.ValidateToken(acme, mainToken)
+	assert.Equal(t, errors.ErrTokenInvalid, err.(*errors.AppError).Code)
+	mockRepo.AssertExpectations(t)
+}
+
+// mailedTokens pulls the tokens out of the mailed links,
+// keyed by recipient
+func mailedTokens(t *testing.T, mailer *mail.ArrayMailer) map[string]string {
+	t.Helper()
+
+	tokens := make(map[string]string)
+	for _, msg := range mailer.Sent() {
+		_, rest, ok := strings.Cut(msg.Body, "token=")
+		require.True(t, ok, msg.Body)
+		token, _, _ := strings.Cut(rest, `"`)
+		tokens[msg.To[0]] = token
+	}
+	return tokens
+}
+
+func TestAuthUsecase_EmailChange_RequiresBothAddresses(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", EmailChangeTTL: time.Hour},
+	}
+	mailer := mail.NewArrayMailer(&config.EmailConfig{})
+	usecase := NewAuthUsecase(mockRepo, cfg, mailer, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Email: "old@example.com", Password: string(hashed)}
+
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("EmailTaken", mock.Anything, "new@example.com").Return(false, nil)
+	mockRepo.On("UpdateUser", mock.Anything, user).Return(nil)
+	mockRepo.On("GetUserByEmailChangeToken", mock.Anything, mock.Anything).Return(user, nil)
+	mockRepo.On("ChangeEmail", mock.Anything, user).Return(nil).Once()
+
+	_, err := usecase.RequestEmailChange(context.Background(), user.ID, &entity.ChangeEmailRequest{
+		Email:    "new@example.com",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", *user.PendingEmail)
+
+	tokens := mailedTokens(t, mailer)
+	require.Len(t, tokens, 2)
+
+	// The current address alone does not change the email
+	result, err := usecase.ConfirmEmailChange(context.Background(), tokens["old@example.com"])
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.com", result.Email)
+	mockRepo.AssertNotCalled(t, "ChangeEmail", mock.Anything, mock.Anything)
+
+	// Applying the change goes through ChangeEmail, which revokes the
+	// refresh tokens
+	result, err = usecase.ConfirmEmailChange(context.Background(), tokens["new@example.com"])
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", result.Email)
+	assert.Nil(t, result.PendingEmail)
+	assert.Empty(t, result.EmailChangeOldToken)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_RequestEmailChange_TakenByDeactivatedUser(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", EmailChangeTTL: time.Hour},
+	}
+	mailer := mail.NewArrayMailer(&config.EmailConfig{})
+	usecase := NewAuthUsecase(mockRepo, cfg, mailer, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Email: "old@example.com", Password: string(hashed)}
+
+	// GetUserByEmail skips deactivated users; the unique index does not
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("EmailTaken", mock.Anything, "inactive@example.com").Return(true, nil)
+
+	_, err := usecase.RequestEmailChange(context.Background(), user.ID, &entity.ChangeEmailRequest{
+		Email:    "inactive@example.com",
+		Password: "password123",
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrUserExists, err.(*errors.AppError).Code)
+	assert.Nil(t, user.PendingEmail)
+	assert.Empty(t, mailer.Sent())
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_ConfirmEmailChange_TakenMeanwhile(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", EmailChangeTTL: time.Hour},
+	}
+	clk := clock.NewFake(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clk, []Backend{NewLocalBackend(mockRepo)})
+
+	pending := "new@example.com"
+	expiresAt := clk.Now().Add(time.Hour)
+	user := &entity.User{
+		ID:                    uuid.New(),
+		Email:                 "old@example.com",
+		PendingEmail:          &pending,
+		PendingEmailExpiresAt: &expiresAt,
+		EmailChangeNewToken:   hashSecretToken("token"),
+	}
+	mockRepo.On("GetUserByEmailChangeToken", mock.Anything, hashSecretToken("token")).Return(user, nil)
+	mockRepo.On("EmailTaken", mock.Anything, pending).Return(true, nil)
+
+	_, err := usecase.ConfirmEmailChange(context.Background(), "token")
+
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrUserExists, err.(*errors.AppError).Code)
+	assert.Equal(t, "old@example.com", user.Email)
+	mockRepo.AssertNotCalled(t, "ChangeEmail", mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_ConfirmEmailChange_Expired(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", EmailChangeTTL: time.Hour},
+	}
+	clk := clock.NewFake(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clk, []Backend{NewLocalBackend(mockRepo)})
+
+	pending := "new@example.com"
+	expiresAt := clk.Now().Add(time.Hour)
+	user := &entity.User{
+		ID:                    uuid.New(),
+		Email:                 "old@example.com",
+		PendingEmail:          &pending,
+		PendingEmailExpiresAt: &expiresAt,
+		EmailChangeNewToken:   hashSecretToken("token"),
+	}
+	mockRepo.On("GetUserByEmailChangeToken", mock.Anything, hashSecretToken("token")).Return(user, nil)
+
+	clk.Advance(time.Hour + time.Minute)
+	_, err := usecase.ConfirmEmailChange(context.Background(), "token")
+
+	assert.Equal(t, errors.ErrEmailChangeInvalidError, err)
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_PasswordReset(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", PasswordResetTTL: time.Hour},
+	}
+	mailer := mail.NewArrayMailer(&config.EmailConfig{})
+	usecase := NewAuthUsecase(mockRepo, cfg, mailer, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := &entity.User{ID: uuid.New(), Email: "user@example.com", Password: string(hashed), IsActive: true}
+
+	mockRepo.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("UpdateUser", mock.Anything, user).Return(nil)
+	mockRepo.On("GetUserByPasswordResetToken", mock.Anything, mock.Anything).Return(user, nil)
+	mockRepo.On("ResetPassword", mock.Anything, user).Return(nil)
+
+	require.NoError(t, usecase.RequestPasswordReset(context.Background(), &entity.ForgotPasswordRequest{Email: user.Email}))
+
+	msg := mail.AssertSent(t, mailer, user.Email, "Reset your password")
+	assert.Contains(t, msg.Body, "http://api.test/auth/password/reset?token=")
+	token := mailedTokens(t, mailer)[user.Email]
+	assert.Equal(t, hashSecretToken(token), user.PasswordResetToken)
+
+	require.NoError(t, usecase.CheckPasswordResetToken(context.Background(), token))
+	require.NoError(t, usecase.ResetPassword(context.Background(), &entity.ResetPasswordRequest{
+		Token:                token,
+		Password:             "new-password",
+		PasswordConfirmation: "new-password",
+	}))
+
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("new-password")))
+	assert.Empty(t, user.PasswordResetToken)
+	assert.Nil(t, user.PasswordResetExpiresAt)
+}
+
+func TestAuthUsecase_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", PasswordResetTTL: time.Hour},
+	}
+	mailer := mail.NewArrayMailer(&config.EmailConfig{})
+	usecase := NewAuthUsecase(mockRepo, cfg, mailer, clock.New(), []Backend{NewLocalBackend(mockRepo)})
+
+	mockRepo.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return((*entity.User)(nil), gorm.ErrRecordNotFound)
+
+	err := usecase.RequestPasswordReset(context.Background(), &entity.ForgotPasswordRequest{Email: "nobody@example.com"})
+
+	assert.NoError(t, err)
+	mail.AssertSentCount(t, mailer, 0)
+}
+
+func TestAuthUsecase_ResetPassword_Expired(t *testing.T) {
+	mockRepo := new(MockAuthRepository)
+	cfg := &config.Config{
+		Account: config.AccountConfig{URL: "http://api.test", PasswordResetTTL: time.Hour},
+	}
+	clk := clock.NewFake(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC))
+	usecase := NewAuthUsecase(mockRepo, cfg, nil, clk, []Backend{NewLocalBackend(mockRepo)})
+
+	expiresAt := clk.Now().Add(time.Hour)
+	user := &entity.User{
+		ID:                     uuid.New(),
+		Email:                  "user@example.com",
+		PasswordResetToken:     hashSecretToken("token"),
+		PasswordResetExpiresAt: &expiresAt,
+	}
+	mockRepo.On("GetUserByPasswordResetToken", mock.Anything, hashSecretToken("token")).Return(user, nil)
+
+	clk.Advance(time.Hour + time.Minute)
+	err := usecase.ResetPassword(context.Background(), &entity.ResetPasswordRequest{
+		Token:                "token",
+		Password:             "new-password",
+		PasswordConfirmation: "new-password",
+	})
+
+	assert.Equal(t, errors.ErrPasswordResetInvalidError, err)
+	mockRepo.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+}
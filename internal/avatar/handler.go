@@ -0,0 +1,154 @@
+package avatar
+
+import (
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AvatarHandler struct {
+	usecase AvatarUsecase
+}
+
+func NewAvatarHandler(usecase AvatarUsecase) *AvatarHandler {
+	return &AvatarHandler{
+		usecase: usecase,
+	}
+}
+
+// UploadAvatar godoc
+// @Summary Upload avatar
+// @Description Upload a JPEG or PNG avatar for the current user, replacing any previous one. Uploads are virus scanned first; infected ones are rejected with 422 FILE_INFECTED. A thumbnail is generated in the background.
+// @Tags auth
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param avatar formData file true "Avatar image (JPEG or PNG)"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Failure 503 {object} response.Response
+// @Router /auth/avatar [put]
+func (h *AvatarHandler) UploadAvatar(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			appErr := errors.ErrFileTooLargeError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			return
+		}
+		response.Error(c, 400, errors.ErrBadRequest, "Avatar file is required", err.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to open upload", zap.Error(err))
+		response.Error(c, 500, errors.ErrInternal, "Failed to upload avatar", nil)
+		return
+	}
+	defer file.Close()
+
+	user, err := h.usecase.Upload(c.Request.Context(), userID, file)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to upload avatar", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to upload avatar", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Avatar uploaded successfully", user)
+}
+
+// RemoveAvatar godoc
+// @Summary Remove avatar
+// @Description Remove the current user's avatar
+// @Tags auth
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/avatar [delete]
+func (h *AvatarHandler) RemoveAvatar(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.Remove(c.Request.Context(), userID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to remove avatar", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to remove avatar", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Avatar removed successfully", nil)
+}
+
+// GetAvatar godoc
+// @Summary Get user avatar
+// @Description Get a user's avatar image. With size=thumb the thumbnail is returned once it has been generated, the full avatar until then.
+// @Tags users
+// @Produce image/jpeg
+// @Produce image/png
+// @Param id path string true "User ID"
+// @Param size query string false "Image size" Enums(thumb)
+// @Success 200 {file} file "Avatar image"
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /users/{id}/avatar [get]
+func (h *AvatarHandler) GetAvatar(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	reader, contentType, err := h.usecase.Open(c.Request.Context(), userID, c.Query("size"))
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get avatar", nil)
+		}
+		return
+	}
+	defer reader.Close()
+
+	// Avatar URLs carry a version, so a cached image is never stale
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Status(200)
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to stream avatar", zap.Error(err))
+	}
+}
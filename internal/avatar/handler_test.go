@@ -0,0 +1,90 @@
+package avatar_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/avatar"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartAvatar builds an upload form holding data as the avatar file
+func multipartAvatar(t *testing.T, data []byte) ([]byte, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	return body.Bytes(), form.FormDataContentType()
+}
+
+func TestAvatarHandler_UploadAndGet(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewGray(image.Rect(0, 0, 32, 32))))
+	body, contentType := multipartAvatar(t, img.Bytes())
+
+	var updated entity.User
+	api.As(user).Request(http.MethodPut, "/api/v1/auth/avatar", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusOK).
+		Decode(&updated)
+	assert.NotEmpty(t, updated.AvatarURL)
+
+	jobs := api.Container.Queue.(*queue.ArrayQueue).Pushed()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, avatar.JobThumbnail, jobs[0].Type)
+	}
+
+	// The thumbnail has not been generated yet, so the full avatar is served
+	res := api.WithoutContract().Get("/api/v1/users/"+user.ID.String()+"/avatar").Query("size", "thumb").Do().
+		AssertStatus(http.StatusOK)
+	assert.Equal(t, "image/png", res.Recorder.Header().Get("Content-Type"))
+
+	api.As(user).Delete("/api/v1/auth/avatar").Do().AssertStatus(http.StatusOK)
+	api.Get("/api/v1/users/" + user.ID.String() + "/avatar").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrAvatarNotFound)
+}
+
+func TestAvatarHandler_Upload_InvalidImage(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+	body, contentType := multipartAvatar(t, []byte("not an image"))
+
+	api.As(user).Request(http.MethodPut, "/api/v1/auth/avatar", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrInvalidImage)
+}
+
+func TestAvatarHandler_Remove_NoAvatar(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Delete("/api/v1/auth/avatar").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrAvatarNotFound)
+}
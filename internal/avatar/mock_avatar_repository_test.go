@@ -0,0 +1,44 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package avatar
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAvatarRepository is a testify mock of AvatarRepository
+type MockAvatarRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvatarRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAvatarRepository) UpdateAvatar(ctx context.Context, userID uuid.UUID, url string, path string) error {
+	args := m.Called(ctx, userID, url, path)
+	return args.Error(0)
+}
+
+func (m *MockAvatarRepository) SetThumbnail(ctx context.Context, userID uuid.UUID, path string, thumbPath string) (bool, error) {
+	args := m.Called(ctx, userID, path, thumbPath)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,55 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package avatar
+
+import (
+	"context"
+	"io"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAvatarUsecase is a testify mock of AvatarUsecase
+type MockAvatarUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAvatarUsecase) Upload(ctx context.Context, userID uuid.UUID, file io.Reader) (*entity.User, error) {
+	args := m.Called(ctx, userID, file)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockAvatarUsecase) Remove(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAvatarUsecase) Open(ctx context.Context, userID uuid.UUID, size string) (io.ReadCloser, string, error) {
+	args := m.Called(ctx, userID, size)
+
+	var r0 io.ReadCloser
+	if v := args.Get(0); v != nil {
+		r0 = v.(io.ReadCloser)
+	}
+
+	var r1 string
+	if v := args.Get(1); v != nil {
+		r1 = v.(string)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockAvatarUsecase) GenerateThumbnail(ctx context.Context, userID uuid.UUID, path string) error {
+	args := m.Called(ctx, userID, path)
+	return args.Error(0)
+}
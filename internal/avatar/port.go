@@ -0,0 +1,24 @@
+package avatar
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// AvatarUsecase defines the business logic interface for user avatars
+type AvatarUsecase interface {
+	Upload(ctx context.Context, userID uuid.UUID, file io.Reader) (*entity.User, error)
+	Remove(ctx context.Context, userID uuid.UUID) error
+	Open(ctx context.Context, userID uuid.UUID, size string) (io.ReadCloser, string, error)
+	GenerateThumbnail(ctx context.Context, userID uuid.UUID, path string) error
+}
+
+// AvatarRepository defines the data access interface for user avatars
+type AvatarRepository interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	UpdateAvatar(ctx context.Context, userID uuid.UUID, url, path string) error
+	SetThumbnail(ctx context.Context, userID uuid.UUID, path, thumbPath string) (bool, error)
+}
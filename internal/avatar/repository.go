@@ -0,0 +1,52 @@
+package avatar
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type avatarRepository struct {
+	db *gorm.DB
+}
+
+func NewAvatarRepository(db *gorm.DB) AvatarRepository {
+	return &avatarRepository{
+		db: db,
+	}
+}
+
+func (r *avatarRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateAvatar points the user at a new avatar and drops the old thumbnail;
+// empty values remove the avatar
+func (r *avatarRepository) UpdateAvatar(ctx context.Context, userID uuid.UUID, url, path string) error {
+	return tenancy.Conn(ctx, r.db).Model(&entity.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"avatar_url":        url,
+			"avatar_path":       path,
+			"avatar_thumb_path": "",
+		}).Error
+}
+
+// SetThumbnail records the thumbnail of the avatar at path. It reports false
+// when the user has replaced or removed that avatar in the meantime.
+func (r *avatarRepository) SetThumbnail(ctx context.Context, userID uuid.UUID, path, thumbPath string) (bool, error) {
+	result := tenancy.Conn(ctx, r.db).Model(&entity.User{}).
+		Where("id = ? AND avatar_path = ?", userID, path).
+		Update("avatar_thumb_path", thumbPath)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
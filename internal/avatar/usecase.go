@@ -0,0 +1,266 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/imaging"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// JobThumbnail is pushed after an upload; its handler is registered in
+// internal/jobs
+const JobThumbnail = "avatar:thumbnail"
+
+// ThumbnailPayload is the payload of a JobThumbnail job
+type ThumbnailPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+	Path   string    `json:"path"`
+}
+
+// SizeThumb asks Open for the thumbnail instead of the full avatar
+const SizeThumb = "thumb"
+
+// extensions maps the formats imaging decodes to file extensions
+var extensions = map[string]string{
+	imaging.FormatJPEG: ".jpg",
+	imaging.FormatPNG:  ".png",
+}
+
+type avatarUsecase struct {
+	repo    AvatarRepository
+	config  *config.Config
+	queue   queue.Queue
+	storage storage.Storage
+	guard   *scanner.Guard
+	clock   clock.Clock
+}
+
+func NewAvatarUsecase(repo AvatarRepository, config *config.Config, jobQueue queue.Queue, store storage.Storage, guard *scanner.Guard, clk clock.Clock) AvatarUsecase {
+	return &avatarUsecase{
+		repo:    repo,
+		config:  config,
+		queue:   jobQueue,
+		storage: store,
+		guard:   guard,
+		clock:   clk,
+	}
+}
+
+// Upload scans and validates the image, stores it re-encoded (which drops metadata such
+// as EXIF locations), replaces the user's previous avatar and queues the
+// thumbnail. Until the thumbnail exists the full avatar is served for both
+// sizes.
+func (u *avatarUsecase) Upload(ctx context.Context, userID uuid.UUID, upload io.Reader) (*entity.User, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload, u.config.Avatar.MaxBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrBadRequest, "Failed to read upload", 400)
+	}
+	if int64(len(data)) > u.config.Avatar.MaxBytes {
+		return nil, errors.ErrFileTooLargeError
+	}
+
+	version := strconv.FormatInt(u.clock.Now().UnixNano(), 10)
+	if err := u.guard.Check(ctx, u.prefix(userID)+version, bytes.NewReader(data)); err != nil {
+		if stderrors.Is(err, scanner.ErrInfected) {
+			return nil, errors.ErrFileInfectedError
+		}
+		logger.FromContext(ctx).Error("Failed to scan avatar", zap.Error(err))
+		return nil, errors.ErrScanUnavailableError
+	}
+
+	img, format, err := imaging.Decode(data, u.config.Avatar.MaxDimension)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Rejected avatar upload", zap.Error(err))
+		return nil, errors.ErrInvalidImageError
+	}
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, img, format); err != nil {
+		logger.FromContext(ctx).Error("Failed to encode avatar", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload avatar", 500)
+	}
+
+	file := u.prefix(userID) + version + extensions[format]
+	if err := u.storage.Put(ctx, file, &buf); err != nil {
+		logger.FromContext(ctx).Error("Failed to store avatar", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload avatar", 500)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/users/%s/avatar?v=%s", u.config.Account.URL, userID, version)
+	if err := u.repo.UpdateAvatar(ctx, userID, url, file); err != nil {
+		logger.FromContext(ctx).Error("Failed to update avatar", zap.Error(err))
+		u.deleteFiles(ctx, file)
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload avatar", 500)
+	}
+	u.deleteFiles(ctx, user.AvatarPath, user.AvatarThumbPath)
+
+	// The avatar works without a thumbnail, so a failed push only costs the
+	// smaller image
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobThumbnail, ThumbnailPayload{UserID: userID, Path: file}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue avatar thumbnail", zap.Error(err))
+	}
+
+	logger.FromContext(ctx).Info("Avatar uploaded",
+		zap.String("user_id", userID.String()), zap.String("file", file), zap.Int("bytes", buf.Len()))
+
+	user.AvatarURL = url
+	user.AvatarPath = file
+	user.AvatarThumbPath = ""
+	return user, nil
+}
+
+// Remove deletes the user's avatar and its thumbnail
+func (u *avatarUsecase) Remove(ctx context.Context, userID uuid.UUID) error {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.AvatarPath == "" {
+		return errors.ErrAvatarNotFoundError
+	}
+
+	if err := u.repo.UpdateAvatar(ctx, userID, "", ""); err != nil {
+		logger.FromContext(ctx).Error("Failed to remove avatar", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to remove avatar", 500)
+	}
+	u.deleteFiles(ctx, user.AvatarPath, user.AvatarThumbPath)
+
+	logger.FromContext(ctx).Info("Avatar removed", zap.String("user_id", userID.String()))
+	return nil
+}
+
+// Open opens the user's avatar, or its thumbnail when size is SizeThumb and the
+// thumbnail has been generated, and returns its content type
+func (u *avatarUsecase) Open(ctx context.Context, userID uuid.UUID, size string) (io.ReadCloser, string, error) {
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, "", errors.ErrAvatarNotFoundError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, "", errors.Wrap(err, errors.ErrInternal, "Failed to get avatar", 500)
+	}
+	if user.AvatarPath == "" {
+		return nil, "", errors.ErrAvatarNotFoundError
+	}
+
+	file := user.AvatarPath
+	if size == SizeThumb && user.AvatarThumbPath != "" {
+		file = user.AvatarThumbPath
+	}
+
+	reader, err := u.storage.Get(ctx, file)
+	if err == storage.ErrNotFound {
+		return nil, "", errors.ErrAvatarNotFoundError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to open avatar", zap.Error(err))
+		return nil, "", errors.Wrap(err, errors.ErrInternal, "Failed to get avatar", 500)
+	}
+	return reader, contentType(file), nil
+}
+
+// GenerateThumbnail writes the thumbnail of the avatar stored at file. An
+// avatar that has been replaced or removed since the upload is skipped.
+func (u *avatarUsecase) GenerateThumbnail(ctx context.Context, userID uuid.UUID, file string) error {
+	reader, err := u.storage.Get(ctx, file)
+	if err == storage.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open avatar %s: %w", file, err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("read avatar %s: %w", file, err)
+	}
+
+	img, format, err := imaging.Decode(data, 0)
+	if err != nil {
+		return fmt.Errorf("decode avatar %s: %w", file, err)
+	}
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, imaging.Thumbnail(img, u.config.Avatar.ThumbnailSize), format); err != nil {
+		return fmt.Errorf("encode thumbnail: %w", err)
+	}
+
+	ext := path.Ext(file)
+	thumb := strings.TrimSuffix(file, ext) + "_thumb" + ext
+	if err := u.storage.Put(ctx, thumb, &buf); err != nil {
+		return fmt.Errorf("store thumbnail: %w", err)
+	}
+
+	current, err := u.repo.SetThumbnail(ctx, userID, file, thumb)
+	if err != nil {
+		return fmt.Errorf("set thumbnail of user %s: %w", userID, err)
+	}
+	if !current {
+		u.deleteFiles(ctx, thumb)
+		return nil
+	}
+
+	logger.FromContext(ctx).Info("Avatar thumbnail generated",
+		zap.String("user_id", userID.String()), zap.String("file", thumb))
+	return nil
+}
+
+func (u *avatarUsecase) getUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
+	}
+	return user, nil
+}
+
+func (u *avatarUsecase) prefix(userID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/", u.config.Avatar.Dir, userID)
+}
+
+// deleteFiles removes replaced avatar files. A file left behind only wastes
+// space, so failures are logged rather than returned.
+func (u *avatarUsecase) deleteFiles(ctx context.Context, files ...string) {
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		if err := u.storage.Delete(ctx, file); err != nil {
+			logger.FromContext(ctx).Warn("Failed to delete avatar file", zap.String("file", file), zap.Error(err))
+		}
+	}
+}
+
+func contentType(file string) string {
+	if path.Ext(file) == extensions[imaging.FormatPNG] {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
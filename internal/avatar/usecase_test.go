@@ -0,0 +1,198 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestUsecase(t *testing.T) (*MockAvatarRepository, *queue.ArrayQueue, storage.Storage, AvatarUsecase) {
+	cfg := &config.Config{
+		Queue:   config.QueueConfig{Default: "default"},
+		Account: config.AccountConfig{URL: "http://api.test"},
+		Avatar:  config.AvatarConfig{Dir: "avatars", MaxBytes: 1 << 20, MaxDimension: 1000, ThumbnailSize: 64},
+	}
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	mockRepo := new(MockAvatarRepository)
+	jobQueue := queue.NewArrayQueue(&cfg.Queue)
+	clk := clock.NewFake(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	guard := scanner.NewGuard(scanner.EICARScanner{}, store, "quarantine")
+	return mockRepo, jobQueue, store, NewAvatarUsecase(mockRepo, cfg, jobQueue, store, guard, clk)
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestAvatarUsecase_Upload(t *testing.T) {
+	mockRepo, jobQueue, store, usecase := newTestUsecase(t)
+	ctx := context.Background()
+
+	user := &entity.User{ID: uuid.New()}
+	user.AvatarPath = "avatars/" + user.ID.String() + "/1.png"
+	require.NoError(t, store.Put(ctx, user.AvatarPath, strings.NewReader("old")))
+
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	mockRepo.On("UpdateAvatar", mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+
+	updated, err := usecase.Upload(ctx, user.ID, bytes.NewReader(encodePNG(t, 300, 200)))
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(updated.AvatarURL, "http://api.test/api/v1/users/"+user.ID.String()+"/avatar?v="))
+	assert.True(t, strings.HasSuffix(updated.AvatarPath, ".png"))
+
+	exists, _ := store.Exists(ctx, updated.AvatarPath)
+	assert.True(t, exists)
+	exists, _ = store.Exists(ctx, "avatars/"+user.ID.String()+"/1.png")
+	assert.False(t, exists, "the replaced avatar is deleted")
+
+	if pushed := jobQueue.Pushed(); assert.Len(t, pushed, 1) {
+		assert.Equal(t, JobThumbnail, pushed[0].Type)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAvatarUsecase_Upload_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected *errors.AppError
+	}{
+		{"not an image", []byte("definitely not an image"), errors.ErrInvalidImageError},
+		{"dimensions too large", encodePNG(t, 1001, 10), errors.ErrInvalidImageError},
+		{"file too large", make([]byte, 1<<20+1), errors.ErrFileTooLargeError},
+		{"infected", []byte(scanner.EICARTestFile), errors.ErrFileInfectedError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, jobQueue, _, usecase := newTestUsecase(t)
+			user := &entity.User{ID: uuid.New()}
+			mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+			_, err := usecase.Upload(context.Background(), user.ID, bytes.NewReader(tt.data))
+
+			assert.Equal(t, tt.expected, err)
+			assert.Empty(t, jobQueue.Pushed())
+			mockRepo.AssertNotCalled(t, "UpdateAvatar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAvatarUsecase_Upload_Quarantined(t *testing.T) {
+	mockRepo, _, store, usecase := newTestUsecase(t)
+	ctx := context.Background()
+	user := &entity.User{ID: uuid.New()}
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	_, err := usecase.Upload(ctx, user.ID, strings.NewReader(scanner.EICARTestFile))
+	assert.Equal(t, errors.ErrFileInfectedError, err)
+
+	quarantined, err := store.List(ctx, "quarantine/avatars/"+user.ID.String()+"/")
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+	stored, err := store.List(ctx, "avatars/")
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestAvatarUsecase_GenerateThumbnail(t *testing.T) {
+	mockRepo, _, store, usecase := newTestUsecase(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	file := "avatars/" + userID.String() + "/1.png"
+	require.NoError(t, store.Put(ctx, file, bytes.NewReader(encodePNG(t, 300, 200))))
+
+	thumb := "avatars/" + userID.String() + "/1_thumb.png"
+	mockRepo.On("SetThumbnail", mock.Anything, userID, file, thumb).Return(true, nil)
+
+	require.NoError(t, usecase.GenerateThumbnail(ctx, userID, file))
+
+	reader, err := store.Get(ctx, thumb)
+	require.NoError(t, err)
+	defer reader.Close()
+	img, err := png.Decode(reader)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAvatarUsecase_GenerateThumbnail_Replaced(t *testing.T) {
+	mockRepo, _, store, usecase := newTestUsecase(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	file := "avatars/" + userID.String() + "/1.png"
+	require.NoError(t, store.Put(ctx, file, bytes.NewReader(encodePNG(t, 100, 100))))
+	mockRepo.On("SetThumbnail", mock.Anything, userID, file, mock.Anything).Return(false, nil)
+
+	require.NoError(t, usecase.GenerateThumbnail(ctx, userID, file))
+
+	exists, _ := store.Exists(ctx, "avatars/"+userID.String()+"/1_thumb.png")
+	assert.False(t, exists, "a thumbnail of a replaced avatar is not kept")
+}
+
+func TestAvatarUsecase_Open_FallsBackWithoutThumbnail(t *testing.T) {
+	mockRepo, _, store, usecase := newTestUsecase(t)
+	ctx := context.Background()
+
+	user := &entity.User{ID: uuid.New()}
+	user.AvatarPath = "avatars/" + user.ID.String() + "/1.jpg"
+	require.NoError(t, store.Put(ctx, user.AvatarPath, strings.NewReader("full")))
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	reader, contentType, err := usecase.Open(ctx, user.ID, SizeThumb)
+
+	require.NoError(t, err)
+	defer reader.Close()
+	data, _ := io.ReadAll(reader)
+	assert.Equal(t, "full", string(data))
+	assert.Equal(t, "image/jpeg", contentType)
+}
+
+func TestAvatarUsecase_Open_NotFound(t *testing.T) {
+	mockRepo, _, _, usecase := newTestUsecase(t)
+
+	withoutAvatar := &entity.User{ID: uuid.New()}
+	mockRepo.On("GetUserByID", mock.Anything, withoutAvatar.ID).Return(withoutAvatar, nil)
+	unknown := uuid.New()
+	mockRepo.On("GetUserByID", mock.Anything, unknown).Return(nil, gorm.ErrRecordNotFound)
+
+	for _, userID := range []uuid.UUID{withoutAvatar.ID, unknown} {
+		_, _, err := usecase.Open(context.Background(), userID, "")
+		assert.Equal(t, errors.ErrAvatarNotFoundError, err)
+	}
+}
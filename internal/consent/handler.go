@@ -0,0 +1,103 @@
+package consent
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ConsentHandler struct {
+	usecase ConsentUsecase
+}
+
+func NewConsentHandler(usecase ConsentUsecase) *ConsentHandler {
+	return &ConsentHandler{
+		usecase: usecase,
+	}
+}
+
+// GetPolicies godoc
+// @Summary Get policies
+// @Description Get the policies every user must accept, with their current version and whether the current user accepted it
+// @Tags consents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /consents/policies [get]
+func (h *ConsentHandler) GetPolicies(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	policies, err := h.usecase.GetPolicies(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get policies", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get policies", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Policies retrieved successfully", policies)
+}
+
+// AcceptPolicy godoc
+// @Summary Accept policy
+// @Description Accept the current version of a policy
+// @Tags consents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.AcceptPolicyRequest true "Policy and version"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /consents [post]
+func (h *ConsentHandler) AcceptPolicy(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.AcceptPolicyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	consent, err := h.usecase.Accept(c.Request.Context(), userID, &req, c.ClientIP())
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to accept policy", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to accept policy", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "Policy accepted successfully", consent)
+}
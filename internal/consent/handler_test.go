@@ -0,0 +1,43 @@
+package consent_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Not parallel: the required policies come from the environment
+func TestConsentHandler_RequiredBeforeUse(t *testing.T) {
+	t.Setenv("CONSENT_POLICIES", "terms:v2")
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Get("/api/v1/notifications").Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrConsentRequired)
+
+	var policies []entity.PolicyStatus
+	api.As(user).Get("/api/v1/consents/policies").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&policies)
+	if assert.Len(t, policies, 1) {
+		assert.Equal(t, "terms", policies[0].Name)
+		assert.False(t, policies[0].Accepted)
+	}
+
+	api.As(user).Post("/api/v1/consents", entity.AcceptPolicyRequest{Policy: "terms", Version: "v1"}).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrPolicyNotFound)
+
+	api.As(user).Post("/api/v1/consents", entity.AcceptPolicyRequest{Policy: "terms", Version: "v2"}).Do().
+		AssertStatus(http.StatusCreated)
+
+	api.As(user).Get("/api/v1/notifications").Do().
+		AssertStatus(http.StatusOK)
+}
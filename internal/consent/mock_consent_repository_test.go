@@ -0,0 +1,33 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package consent
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockConsentRepository is a testify mock of ConsentRepository
+type MockConsentRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsentRepository) CreateConsent(ctx context.Context, consent *entity.Consent) error {
+	args := m.Called(ctx, consent)
+	return args.Error(0)
+}
+
+func (m *MockConsentRepository) GetConsents(ctx context.Context, userID uuid.UUID) ([]*entity.Consent, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.Consent
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Consent)
+	}
+
+	return r0, args.Error(1)
+}
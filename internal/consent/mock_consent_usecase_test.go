@@ -0,0 +1,50 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package consent
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockConsentUsecase is a testify mock of ConsentUsecase
+type MockConsentUsecase struct {
+	mock.Mock
+}
+
+func (m *MockConsentUsecase) GetPolicies(ctx context.Context, userID uuid.UUID) ([]*entity.PolicyStatus, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.PolicyStatus
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.PolicyStatus)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockConsentUsecase) Accept(ctx context.Context, userID uuid.UUID, req *entity.AcceptPolicyRequest, ip string) (*entity.Consent, error) {
+	args := m.Called(ctx, userID, req, ip)
+
+	var r0 *entity.Consent
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Consent)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockConsentUsecase) Pending(ctx context.Context, userID uuid.UUID) ([]entity.Policy, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []entity.Policy
+	if v := args.Get(0); v != nil {
+		r0 = v.([]entity.Policy)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,21 @@
+package consent
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// ConsentUsecase defines the business logic interface for policy consents
+type ConsentUsecase interface {
+	GetPolicies(ctx context.Context, userID uuid.UUID) ([]*entity.PolicyStatus, error)
+	Accept(ctx context.Context, userID uuid.UUID, req *entity.AcceptPolicyRequest, ip string) (*entity.Consent, error)
+	Pending(ctx context.Context, userID uuid.UUID) ([]entity.Policy, error)
+}
+
+// ConsentRepository defines the data access interface for policy consents
+type ConsentRepository interface {
+	CreateConsent(ctx context.Context, consent *entity.Consent) error
+	GetConsents(ctx context.Context, userID uuid.UUID) ([]*entity.Consent, error)
+}
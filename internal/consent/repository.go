@@ -0,0 +1,35 @@
+package consent
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type consentRepository struct {
+	db *gorm.DB
+}
+
+func NewConsentRepository(db *gorm.DB) ConsentRepository {
+	return &consentRepository{
+		db: db,
+	}
+}
+
+// CreateConsent stores the consent; accepting a version twice keeps the first
+func (r *consentRepository) CreateConsent(ctx context.Context, consent *entity.Consent) error {
+	return tenancy.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(consent).Error
+}
+
+func (r *consentRepository) GetConsents(ctx context.Context, userID uuid.UUID) ([]*entity.Consent, error) {
+	var consents []*entity.Consent
+	err := tenancy.Conn(ctx, r.db).Where("user_id = ?", userID).Order("accepted_at").Find(&consents).Error
+	if err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
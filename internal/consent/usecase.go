@@ -0,0 +1,147 @@
+package consent
+
+import (
+	"context"
+	"fmt"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type consentUsecase struct {
+	repo     ConsentRepository
+	policies []entity.Policy
+	clock    clock.Clock
+}
+
+// ParsePolicies parses the configured "name:version" pairs
+func ParsePolicies(specs []string) ([]entity.Policy, error) {
+	policies := make([]entity.Policy, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+
+	for _, spec := range specs {
+		name, version, ok := strings.Cut(spec, ":")
+		name, version = strings.TrimSpace(name), strings.TrimSpace(version)
+		if !ok || name == "" || version == "" {
+			return nil, fmt.Errorf("invalid policy %q, expected name:version", spec)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("policy %q is listed twice", name)
+		}
+		seen[name] = true
+
+		policies = append(policies, entity.Policy{Name: name, Version: version})
+	}
+	return policies, nil
+}
+
+// NewConsentUsecase creates the usecase for the current versions of the
+// required policies
+func NewConsentUsecase(repo ConsentRepository, policies []entity.Policy, clk clock.Clock) ConsentUsecase {
+	return &consentUsecase{
+		repo:     repo,
+		policies: policies,
+		clock:    clk,
+	}
+}
+
+// GetPolicies lists the required policies and whether the user accepted them
+func (u *consentUsecase) GetPolicies(ctx context.Context, userID uuid.UUID) ([]*entity.PolicyStatus, error) {
+	accepted, err := u.accepted(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*entity.PolicyStatus, 0, len(u.policies))
+	for _, policy := range u.policies {
+		status := &entity.PolicyStatus{Policy: policy}
+		if consent, ok := accepted[policy]; ok {
+			status.Accepted = true
+			status.AcceptedAt = &consent.AcceptedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Accept records that the user accepted the current version of a policy
+func (u *consentUsecase) Accept(ctx context.Context, userID uuid.UUID, req *entity.AcceptPolicyRequest, ip string) (*entity.Consent, error) {
+	current, ok := u.current(req.Policy)
+	if !ok || current.Version != req.Version {
+		details := map[string]string{"policy": req.Policy}
+		if ok {
+			details["current_version"] = current.Version
+		}
+		return nil, errors.New(errors.ErrPolicyNotFound, "Policy version not found", 404).WithDetails(details)
+	}
+
+	consent := &entity.Consent{
+		UserID:     userID,
+		Policy:     req.Policy,
+		Version:    req.Version,
+		IP:         ip,
+		AcceptedAt: u.clock.Now(),
+	}
+
+	if err := u.repo.CreateConsent(ctx, consent); err != nil {
+		logger.FromContext(ctx).Error("Failed to create consent", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to accept policy", 500)
+	}
+
+	logger.FromContext(ctx).Info("Policy accepted",
+		zap.String("user_id", userID.String()),
+		zap.String("policy", req.Policy),
+		zap.String("version", req.Version),
+	)
+	return consent, nil
+}
+
+// Pending returns the required policies whose current version the user has
+// not accepted
+func (u *consentUsecase) Pending(ctx context.Context, userID uuid.UUID) ([]entity.Policy, error) {
+	if len(u.policies) == 0 {
+		return nil, nil
+	}
+
+	accepted, err := u.accepted(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []entity.Policy
+	for _, policy := range u.policies {
+		if _, ok := accepted[policy]; !ok {
+			pending = append(pending, policy)
+		}
+	}
+	return pending, nil
+}
+
+// accepted indexes the user's consents by the policy version they accepted
+func (u *consentUsecase) accepted(ctx context.Context, userID uuid.UUID) (map[entity.Policy]*entity.Consent, error) {
+	consents, err := u.repo.GetConsents(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get consents", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get consents", 500)
+	}
+
+	accepted := make(map[entity.Policy]*entity.Consent, len(consents))
+	for _, consent := range consents {
+		accepted[entity.Policy{Name: consent.Policy, Version: consent.Version}] = consent
+	}
+	return accepted, nil
+}
+
+func (u *consentUsecase) current(name string) (entity.Policy, bool) {
+	for _, policy := range u.policies {
+		if policy.Name == name {
+			return policy, true
+		}
+	}
+	return entity.Policy{}, false
+}
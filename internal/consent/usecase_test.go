@@ -0,0 +1,91 @@
+package consent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParsePolicies(t *testing.T) {
+	policies, err := ParsePolicies([]string{"terms:2026-10-01", " privacy : v2 "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Policy{
+		{Name: "terms", Version: "2026-10-01"},
+		{Name: "privacy", Version: "v2"},
+	}, policies)
+
+	for _, specs := range [][]string{{"terms"}, {"terms:"}, {"terms:v1", "terms:v2"}} {
+		_, err := ParsePolicies(specs)
+		assert.Error(t, err, specs)
+	}
+}
+
+func TestConsentUsecase_Pending(t *testing.T) {
+	mockRepo := new(MockConsentRepository)
+	policies := []entity.Policy{{Name: "terms", Version: "v2"}, {Name: "privacy", Version: "v1"}}
+	usecase := NewConsentUsecase(mockRepo, policies, clock.New())
+
+	userID := uuid.New()
+	mockRepo.On("GetConsents", mock.Anything, userID).Return([]*entity.Consent{
+		{Policy: "terms", Version: "v1"}, // an older version does not count
+		{Policy: "privacy", Version: "v1"},
+	}, nil)
+
+	pending, err := usecase.Pending(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Policy{{Name: "terms", Version: "v2"}}, pending)
+}
+
+func TestConsentUsecase_Pending_NoPolicies(t *testing.T) {
+	mockRepo := new(MockConsentRepository)
+	usecase := NewConsentUsecase(mockRepo, nil, clock.New())
+
+	pending, err := usecase.Pending(context.Background(), uuid.New())
+
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	mockRepo.AssertNotCalled(t, "GetConsents", mock.Anything, mock.Anything)
+}
+
+func TestConsentUsecase_Accept(t *testing.T) {
+	mockRepo := new(MockConsentRepository)
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	usecase := NewConsentUsecase(mockRepo, []entity.Policy{{Name: "terms", Version: "v2"}}, clock.NewFake(now))
+
+	userID := uuid.New()
+	mockRepo.On("CreateConsent", mock.Anything, mock.MatchedBy(func(consent *entity.Consent) bool {
+		return consent.UserID == userID && consent.Version == "v2" && consent.AcceptedAt.Equal(now)
+	})).Return(nil)
+
+	_, err := usecase.Accept(context.Background(), userID, &entity.AcceptPolicyRequest{Policy: "terms", Version: "v2"}, "127.0.0.1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestConsentUsecase_Accept_OutdatedVersion(t *testing.T) {
+	mockRepo := new(MockConsentRepository)
+	usecase := NewConsentUsecase(mockRepo, []entity.Policy{{Name: "terms", Version: "v2"}}, clock.New())
+
+	for _, req := range []*entity.AcceptPolicyRequest{
+		{Policy: "terms", Version: "v1"},
+		{Policy: "cookies", Version: "v1"},
+	} {
+		_, err := usecase.Accept(context.Background(), uuid.New(), req, "")
+
+		if assert.IsType(t, &errors.AppError{}, err) {
+			assert.Equal(t, errors.ErrPolicyNotFound, err.(*errors.AppError).Code)
+		}
+	}
+	mockRepo.AssertNotCalled(t, "CreateConsent", mock.Anything, mock.Anything)
+}
@@ -1,72 +1,580 @@
 package container
 
 import (
+	"context"
+
 	"go-clean-gin/config"
+	"go-clean-gin/internal/account"
+	"go-clean-gin/internal/activity" // artisan:module activity
+	"go-clean-gin/internal/admin"    // artisan:module admin
+	"go-clean-gin/internal/audit"
 	"go-clean-gin/internal/auth"
+	"go-clean-gin/internal/avatar" // artisan:module avatar
+	"go-clean-gin/internal/consent"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/export"       // artisan:module export
+	"go-clean-gin/internal/imports"      // artisan:module imports
+	"go-clean-gin/internal/invitation"   // artisan:module invitation
+	"go-clean-gin/internal/notification" // artisan:module notification
+	"go-clean-gin/internal/oidc"         // artisan:module oidc
+	"go-clean-gin/internal/organization"
 	"go-clean-gin/internal/product"
+	"go-clean-gin/internal/productimage" // artisan:module productimage
+	"go-clean-gin/internal/quota"
+	"go-clean-gin/internal/report"      // artisan:module report
+	"go-clean-gin/internal/reservation" // artisan:module reservation
+	"go-clean-gin/internal/savedsearch"
+	"go-clean-gin/internal/scim" // artisan:module scim
+	"go-clean-gin/internal/setting"
+	"go-clean-gin/internal/sso"   // artisan:module sso
+	"go-clean-gin/internal/store" // artisan:module store
+	// artisan:insert imports
+	"go-clean-gin/pkg/archive"
+	"go-clean-gin/pkg/cache"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/counter"
+	"go-clean-gin/pkg/crypto"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/dbretry"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/exchange"
+	"go-clean-gin/pkg/health"
 	"go-clean-gin/pkg/logger"
 	"go-clean-gin/pkg/mail"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/notify"
+	"go-clean-gin/pkg/oidcclient" // artisan:module sso
+	"go-clean-gin/pkg/publicid"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/retention"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/signedrequest"
+	"go-clean-gin/pkg/signedurl"
+	"go-clean-gin/pkg/storage"
+	"go-clean-gin/pkg/tenancy"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// Breakers of the soft dependencies, reported on /health/ready
+const (
+	BreakerMail     = "mail"
+	BreakerExchange = "exchange"
+)
+
 type Container struct {
-	Config *config.Config
-	DB     *gorm.DB
-	Mail   *mail.Mailer
+	Config    *config.Config
+	DB        *gorm.DB
+	Mail      mail.Sender
+	Notifier  notify.Notifier
+	Queue     queue.Queue
+	Storage   storage.Storage
+	Scanner   *scanner.Guard
+	Signer    *signedurl.Signer
+	Verifier  *signedrequest.Verifier
+	Clock     clock.Clock
+	Events    *events.Bus
+	Health    *health.Registry
+	Tenants   *tenancy.Manager
+	PublicIDs *publicid.Resolver
+	Cache     cache.Store // of the generated repository cache decorators
+	Counter   *counter.Counter
+	Archiver  *archive.Archiver
+	Purger    *retention.Purger
 
 	// Repositories
-	AuthRepo    auth.AuthRepository
-	ProductRepo product.ProductRepository
+	AuthRepo         auth.AuthRepository
+	ProductRepo      product.ProductRepository
+	ProductReadRepo  product.ProductReadRepository
+	ReservationRepo  reservation.ReservationRepository   // artisan:module reservation
+	NotificationRepo notification.NotificationRepository // artisan:module notification
+	ReportRepo       report.ReportRepository             // artisan:module report
+	AuditRepo        audit.AuditRepository
+	AdminRepo        admin.AdminRepository // artisan:module admin
+	AccountRepo      account.AccountRepository
+	ConsentRepo      consent.ConsentRepository
+	AvatarRepo       avatar.AvatarRepository // artisan:module avatar
+	QuotaRepo        quota.QuotaRepository
+	OIDCRepo         oidc.OIDCRepository // artisan:module oidc
+	SSORepo          sso.SSORepository   // artisan:module sso
+	SCIMRepo         scim.SCIMRepository // artisan:module scim
+	OrganizationRepo organization.OrganizationRepository
+	InvitationRepo   invitation.InvitationRepository // artisan:module invitation
+	SettingRepo      setting.SettingRepository
+	ActivityRepo     activity.ActivityRepository // artisan:module activity
+	SavedSearchRepo  savedsearch.SavedSearchRepository
+	ExportRepo       export.ExportRepository             // artisan:module export
+	ImportRepo       imports.ImportRepository            // artisan:module imports
+	ProductImageRepo productimage.ProductImageRepository // artisan:module productimage
 
 	// Usecases
-	AuthUsecase    auth.AuthUsecase
-	ProductUsecase product.ProductUsecase
+	AuthUsecase         auth.AuthUsecase
+	ProductUsecase      product.ProductUsecase
+	ReservationUsecase  reservation.ReservationUsecase   // artisan:module reservation
+	NotificationUsecase notification.NotificationUsecase // artisan:module notification
+	ReportUsecase       report.ReportUsecase             // artisan:module report
+	AuditUsecase        audit.AuditUsecase
+	AdminUsecase        admin.AdminUsecase // artisan:module admin
+	AccountUsecase      account.AccountUsecase
+	ConsentUsecase      consent.ConsentUsecase
+	AvatarUsecase       avatar.AvatarUsecase // artisan:module avatar
+	QuotaUsecase        quota.QuotaUsecase
+	OIDCUsecase         oidc.OIDCUsecase // artisan:module oidc
+	SSOUsecase          sso.SSOUsecase   // artisan:module sso
+	SCIMUsecase         scim.SCIMUsecase // artisan:module scim
+	OrganizationUsecase organization.OrganizationUsecase
+	InvitationUsecase   invitation.InvitationUsecase // artisan:module invitation
+	SettingUsecase      setting.SettingUsecase
+	ActivityUsecase     activity.ActivityUsecase // artisan:module activity
+	SavedSearchUsecase  savedsearch.SavedSearchUsecase
+	ExportUsecase       export.ExportUsecase             // artisan:module export
+	ImportUsecase       imports.ImportUsecase            // artisan:module imports
+	ProductImageUsecase productimage.ProductImageUsecase // artisan:module productimage
 
 	// Handlers
-	AuthHandler    *auth.AuthHandler
-	ProductHandler *product.ProductHandler
+	AuthHandler         *auth.AuthHandler
+	ProductHandler      *product.ProductHandler
+	ReservationHandler  *reservation.ReservationHandler   // artisan:module reservation
+	NotificationHandler *notification.NotificationHandler // artisan:module notification
+	ReportHandler       *report.ReportHandler             // artisan:module report
+	AdminHandler        *admin.AdminHandler               // artisan:module admin
+	AccountHandler      *account.AccountHandler
+	ConsentHandler      *consent.ConsentHandler
+	AvatarHandler       *avatar.AvatarHandler // artisan:module avatar
+	QuotaHandler        *quota.QuotaHandler
+	OIDCHandler         *oidc.OIDCHandler // artisan:module oidc
+	SSOHandler          *sso.SSOHandler   // artisan:module sso
+	SCIMHandler         *scim.SCIMHandler // artisan:module scim
+	OrganizationHandler *organization.OrganizationHandler
+	InvitationHandler   *invitation.InvitationHandler // artisan:module invitation
+	SettingHandler      *setting.SettingHandler
+	ActivityHandler     *activity.ActivityHandler // artisan:module activity
+	SavedSearchHandler  *savedsearch.SavedSearchHandler
+	ExportHandler       *export.ExportHandler             // artisan:module export
+	ImportHandler       *imports.ImportHandler            // artisan:module imports
+	ProductImageHandler *productimage.ProductImageHandler // artisan:module productimage
+
+	// Store
+	// artisan:module store
+	StoreRepo    store.StoreRepository
+	StoreUsecase store.StoreUsecase
+	StoreHandler *store.StoreHandler
+	// artisan:end
+
+	// artisan:insert fields
 }
 
 func NewContainer(cfg *config.Config, db *gorm.DB) *Container {
 
-	mail, err := mail.NewGomail(&cfg.Email)
+	if err := money.Configure(cfg.Currency.Default, cfg.Currency.Supported); err != nil {
+		logger.Fatal("Invalid currency configuration", zap.Error(err))
+	}
+
+	policies, err := consent.ParsePolicies(cfg.Consent.Policies)
+	if err != nil {
+		logger.Fatal("Invalid consent policies", zap.Error(err))
+	}
+
+	// artisan:module oidc
+	oidcClients, err := oidc.ParseClients(cfg.OIDC.Clients)
+	if err != nil {
+		logger.Fatal("Invalid OIDC clients", zap.Error(err))
+	}
+	oidcKey, err := oidc.LoadSigningKey(cfg.OIDC.SigningKeyFile)
+	if err != nil {
+		logger.Fatal("Failed to load OIDC signing key", zap.Error(err))
+	}
+	if cfg.OIDC.SigningKeyFile == "" && len(oidcClients) > 0 {
+		logger.Warn("OIDC_SIGNING_KEY_FILE is not set; tokens issued to OIDC clients stop verifying after a restart")
+	}
+	// artisan:end
+
+	// artisan:module sso
+	ssoConnections, err := sso.LoadConnections(cfg.SSO.ConnectionsFile)
+	if err != nil {
+		logger.Fatal("Invalid SSO connections", zap.Error(err))
+	}
+	// artisan:end
+
+	keyring, err := crypto.LoadKeys(cfg.Crypto.Keys, cfg.Crypto.KeysFile)
+	if err != nil {
+		logger.Fatal("Invalid encryption keys", zap.Error(err))
+	}
+	if keyring.Primary() == "" {
+		logger.Warn("ENCRYPTION_KEYS is not set; writing encrypted columns will fail")
+	}
+	crypto.Configure(keyring)
+
+	publicIDSpecs, err := publicid.ParseSpecs(cfg.PublicID.Entities)
+	if err != nil {
+		logger.Fatal("Invalid public IDs", zap.Error(err))
+	}
+	publicid.Configure(publicIDSpecs)
+	publicIDs, err := publicid.NewResolver(db, entity.PublicIDModels...)
+	if err != nil {
+		logger.Fatal("Failed to initialize public IDs", zap.Error(err))
+	}
+
+	tenantRegistry, err := tenancy.LoadRegistry(cfg.Tenancy.DatabasesFile)
+	if err != nil {
+		logger.Fatal("Invalid tenant databases", zap.Error(err))
+	}
+
+	clk := clock.New()
+	bus := events.NewBus()
+	breakers := health.NewRegistry(cfg.Health, clk)
+
+	// Tenant databases get the main database's settings with smaller pools,
+	// since a process may hold many of them
+	tenantDB := cfg.Database
+	tenantDB.MaxOpenConns = cfg.Tenancy.MaxOpenConns
+	tenantDB.MaxIdleConns = cfg.Tenancy.MaxIdleConns
+	tenants := tenancy.NewManager(tenantRegistry, func(dsn string) (*gorm.DB, error) {
+		return database.OpenPostgres(dsn, &tenantDB)
+	}, tenancy.Options{MaxPools: cfg.Tenancy.MaxPools, IdleTimeout: cfg.Tenancy.IdleTimeout}, clk)
+	go tenants.Run(context.Background())
+
+	sender, err := mail.New(&cfg.Email)
 	if err != nil {
 		logger.Fatal("Failed to initialize email", zap.Error(err))
 	}
+	mail := mail.NewBreaker(sender, breakers.Breaker(BreakerMail))
+
+	// SMTP connects on first send; check it in the background so an
+	// unreachable server is reported without blocking startup
+	go func() {
+		if err := mail.TestConnection(); err != nil {
+			logger.Warn("Email connection failed, sending will be retried per message",
+				zap.String("driver", cfg.Email.Driver), zap.Error(err))
+			return
+		}
+		logger.Info("Email connection successful", zap.String("driver", cfg.Email.Driver))
+	}()
+
+	notifyDriver, err := notify.New(&cfg.Notify)
+	if err != nil {
+		logger.Fatal("Failed to initialize notifications", zap.Error(err))
+	}
+	notifier := notify.NewChannel(notifyDriver, &cfg.Notify, cfg.Env, clk)
+
+	jobQueue, err := queue.New(&cfg.Queue, db)
+	if err != nil {
+		logger.Fatal("Failed to initialize queue", zap.Error(err))
+	}
+
+	fileStore, err := storage.New(&cfg.Storage)
+	if err != nil {
+		logger.Fatal("Failed to initialize storage", zap.Error(err))
+	}
+
+	virusScanner, err := scanner.New(&cfg.Scanner)
+	if err != nil {
+		logger.Fatal("Failed to initialize virus scanner", zap.Error(err))
+	}
+	guard := scanner.NewGuard(virusScanner, fileStore, cfg.Scanner.QuarantineDir)
+
+	signer := signedurl.New(cfg.SignedURL.Secret, clk)
+
+	signingKeys, err := signedrequest.ParseKeys(cfg.SignedRequest.Keys)
+	if err != nil {
+		logger.Fatal("Failed to load request signing keys", zap.Error(err))
+	}
+	verifier := signedrequest.NewVerifier(signingKeys, signedrequest.NewMemoryNonceStore(clk), cfg.SignedRequest.Window, cfg.SignedRequest.Required, clk)
+
+	rates, err := exchange.New(&cfg.Exchange, clk, breakers.Breaker(BreakerExchange))
+	if err != nil {
+		logger.Fatal("Failed to initialize exchange rates", zap.Error(err))
+	}
+
+	cacheStore, err := cache.New(&cfg.Cache, clk)
+	if err != nil {
+		logger.Fatal("Failed to initialize cache", zap.Error(err))
+	}
+	if memory, ok := cacheStore.(*cache.MemoryStore); ok && cfg.Cache.Sync {
+		cacheStore = cache.NewSyncedStore(memory, db)
+	}
+
+	// Repositories retry deadlocks, serialization failures and lost connections
+	dbRetrier := dbretry.New(&cfg.Database)
 
-	if err := mail.TestConnection(); err != nil {
-		logger.Fatal("Failed to test email connection", zap.Error(err))
+	// Product views and other frequent counts, flushed by the scheduler
+	counters := counter.New(db, &cfg.Counter)
+
+	// Old rows of hot tables, moved out by the scheduler
+	var archiveStore storage.Storage
+	if cfg.Archive.Destination == "storage" {
+		archiveStore = fileStore
 	}
+	archiver := archive.New(db, archiveStore, cfg.Archive.Batch)
 
-	logger.Info("Email connection successful")
+	// Rows past their retention, deleted by the scheduler
+	purger := retention.New(db, cfg.Retention.Batch)
 
 	// Auth
 	authRepo := auth.NewAuthRepository(db)
-	authUsecase := auth.NewAuthUsecase(authRepo, cfg, mail)
-	authHandler := auth.NewAuthHandler(authUsecase)
+	authBackends, err := auth.NewBackends(cfg, authRepo)
+	if err != nil {
+		logger.Fatal("Invalid auth backends", zap.Error(err))
+	}
+	authUsecase := auth.NewAuthUsecase(authRepo, cfg, mail, clk, authBackends)
+	authHandler := auth.NewAuthHandler(authUsecase, auth.NewThrottle(cfg.Throttle, clk))
+
+	// Organization
+	organizationRepo := organization.NewOrganizationRepository(db)
+	organizationUsecase := organization.NewOrganizationUsecase(organizationRepo)
+	organizationHandler := organization.NewOrganizationHandler(organizationUsecase)
+
+	// artisan:module invitation
+	// Invitation
+	invitationRepo := invitation.NewInvitationRepository(db)
+	invitationUsecase := invitation.NewInvitationUsecase(invitationRepo, organizationUsecase, authUsecase, cfg, mail, clk)
+	invitationHandler := invitation.NewInvitationHandler(invitationUsecase)
+	// artisan:end
+
+	// Setting
+	settingRepo := setting.NewSettingRepository(db)
+	settingUsecase := setting.NewSettingUsecase(settingRepo, cfg, clk)
+	settingHandler := setting.NewSettingHandler(settingUsecase)
 
 	// Product
-	productRepo := product.NewProductRepository(db)
-	productUsecase := product.NewProductUsecase(productRepo)
+	productRepo := product.NewRetryingProductRepository(product.NewProductRepository(db), dbRetrier)
+	productReadRepo := product.NewRetryingProductReadRepository(product.NewProductReadRepository(db), dbRetrier)
+	productUsecase := product.NewProductUsecase(productRepo, productReadRepo, cfg, bus, clk, rates, organizationUsecase, settingUsecase, counters)
 	productHandler := product.NewProductHandler(productUsecase)
+	product.RegisterProjector(bus, productReadRepo)
+
+	// artisan:module reservation
+	// Reservation
+	reservationRepo := reservation.NewRetryingReservationRepository(reservation.NewReservationRepository(db), dbRetrier)
+	reservationUsecase := reservation.NewReservationUsecase(reservationRepo, cfg, bus, clk)
+	reservationHandler := reservation.NewReservationHandler(reservationUsecase)
+	// artisan:end
+
+	// artisan:module notification
+	// Notification
+	notificationRepo := notification.NewNotificationRepository(db)
+	notificationUsecase := notification.NewNotificationUsecase(notificationRepo, clk)
+	notificationHandler := notification.NewNotificationHandler(notificationUsecase)
+	notification.RegisterListeners(bus, notificationUsecase)
+	// artisan:end
+
+	// artisan:module activity
+	// Activity
+	activityRepo := activity.NewActivityRepository(db)
+	activityUsecase := activity.NewActivityUsecase(activityRepo, productUsecase, cfg, clk)
+	activityHandler := activity.NewActivityHandler(activityUsecase)
+	activity.RegisterListeners(bus, activityUsecase)
+	// artisan:end
+
+	// Saved search
+	savedSearchRepo := savedsearch.NewSavedSearchRepository(db)
+	savedSearchUsecase := savedsearch.NewSavedSearchUsecase(savedSearchRepo, productUsecase, cfg, bus, clk)
+	savedSearchHandler := savedsearch.NewSavedSearchHandler(savedSearchUsecase)
+
+	// artisan:module report
+	// Report
+	reportRepo := report.NewReportRepository(db)
+	reportUsecase := report.NewReportUsecase(reportRepo, clk)
+	reportHandler := report.NewReportHandler(reportUsecase)
+	// artisan:end
+
+	// Audit
+	auditRepo := audit.NewAuditRepository(db)
+	auditUsecase := audit.NewAuditUsecase(auditRepo, clk)
+
+	// artisan:module admin
+	// Admin
+	adminRepo := admin.NewAdminRepository(db)
+	adminUsecase := admin.NewAdminUsecase(adminRepo, auditUsecase, clk)
+	adminHandler := admin.NewAdminHandler(adminUsecase)
+	// artisan:end
+
+	// Account
+	accountRepo := account.NewAccountRepository(db)
+	accountUsecase := account.NewAccountUsecase(accountRepo, cfg, jobQueue, fileStore, signer, clk)
+	accountHandler := account.NewAccountHandler(accountUsecase)
+
+	// Consent
+	consentRepo := consent.NewConsentRepository(db)
+	consentUsecase := consent.NewConsentUsecase(consentRepo, policies, clk)
+	consentHandler := consent.NewConsentHandler(consentUsecase)
+
+	// artisan:module avatar
+	// Avatar
+	avatarRepo := avatar.NewAvatarRepository(db)
+	avatarUsecase := avatar.NewAvatarUsecase(avatarRepo, cfg, jobQueue, fileStore, guard, clk)
+	avatarHandler := avatar.NewAvatarHandler(avatarUsecase)
+	// artisan:end
+
+	// Quota
+	quotaRepo := quota.NewQuotaRepository(db)
+	quotaUsecase := quota.NewQuotaUsecase(quotaRepo, cfg, clk)
+	quotaHandler := quota.NewQuotaHandler(quotaUsecase)
+
+	// artisan:module oidc
+	// OpenID Connect provider
+	oidcRepo := oidc.NewOIDCRepository(db)
+	oidcUsecase := oidc.NewOIDCUsecase(oidcRepo, cfg, oidcClients, oidcKey, clk)
+	oidcHandler := oidc.NewOIDCHandler(oidcUsecase)
+	// artisan:end
+
+	// artisan:module sso
+	// Single sign-on with external identity providers
+	ssoProviders := make(map[string]sso.IdentityProvider, len(ssoConnections))
+	for _, conn := range ssoConnections {
+		ssoProviders[conn.Name] = oidcclient.New(conn.Issuer, conn.ClientID, conn.ClientSecret, cfg.SSO.Timeout, clk)
+	}
+	ssoRepo := sso.NewSSORepository(db)
+	ssoUsecase := sso.NewSSOUsecase(ssoRepo, cfg, ssoConnections, ssoProviders, authUsecase, clk)
+	ssoHandler := sso.NewSSOHandler(ssoUsecase)
+	// artisan:end
+
+	// artisan:module scim
+	scimRepo := scim.NewSCIMRepository(db)
+	scimUsecase := scim.NewSCIMUsecase(scimRepo, cfg, accountUsecase)
+	scimHandler := scim.NewSCIMHandler(scimUsecase)
+	// artisan:end
+
+	// artisan:module export
+	// Export jobs, built by the exporter registered for their kind
+	exporters := map[string]export.Exporter{
+		entity.ExportKindProducts:           product.NewExporter(productUsecase),
+		entity.ExportKindAccount:            account.NewExporter(accountUsecase),
+		entity.ExportKindProductsByCategory: report.NewExporter(reportUsecase, entity.ExportKindProductsByCategory), // artisan:module report
+		entity.ExportKindRegistrations:      report.NewExporter(reportUsecase, entity.ExportKindRegistrations),      // artisan:module report
+		entity.ExportKindStockValue:         report.NewExporter(reportUsecase, entity.ExportKindStockValue),         // artisan:module report
+	}
+	exportRepo := export.NewExportRepository(db)
+	exportUsecase := export.NewExportUsecase(exportRepo, exporters, cfg, jobQueue, fileStore, signer, clk)
+	exportHandler := export.NewExportHandler(exportUsecase)
+	// artisan:end
+
+	// artisan:module imports
+	// Import jobs, validated and applied by the importer registered for their kind
+	importers := map[string]imports.Importer{
+		entity.ImportKindProducts: product.NewImporter(productUsecase),
+	}
+	importRepo := imports.NewImportRepository(db)
+	importUsecase := imports.NewImportUsecase(importRepo, importers, cfg, jobQueue, fileStore, guard, clk)
+	importHandler := imports.NewImportHandler(importUsecase)
+	// artisan:end
+
+	// artisan:module productimage
+	// Product images, with variants rendered by a queue job
+	productImageRepo := productimage.NewProductImageRepository(db)
+	productImageUsecase := productimage.NewProductImageUsecase(productImageRepo, productUsecase, cfg, jobQueue, fileStore, guard)
+	productImageHandler := productimage.NewProductImageHandler(productImageUsecase)
+	// artisan:end
+
+	// artisan:module store
+	// Stores, an example of latitude and longitude fields searched by distance
+	storeRepo := store.NewCachedStoreRepository(store.NewRetryingStoreRepository(store.NewStoreRepository(db), dbRetrier), cacheStore, cfg.Cache.TTL)
+	storeUsecase := store.NewStoreUsecase(storeRepo)
+	storeHandler := store.NewStoreHandler(storeUsecase)
+	// artisan:end
+
+	// artisan:insert constructors
 
 	return &Container{
-		Config: cfg,
-		DB:     db,
-		Mail:   mail,
+		Config:    cfg,
+		DB:        db,
+		Mail:      mail,
+		Notifier:  notifier,
+		Queue:     jobQueue,
+		Storage:   fileStore,
+		Scanner:   guard,
+		Signer:    signer,
+		Verifier:  verifier,
+		Clock:     clk,
+		Events:    bus,
+		Health:    breakers,
+		Tenants:   tenants,
+		PublicIDs: publicIDs,
+		Cache:     cacheStore,
+		Counter:   counters,
+		Archiver:  archiver,
+		Purger:    purger,
 
 		// Repositories
-		AuthRepo:    authRepo,
-		ProductRepo: productRepo,
+		AuthRepo:         authRepo,
+		ProductRepo:      productRepo,
+		ProductReadRepo:  productReadRepo,
+		ReservationRepo:  reservationRepo,  // artisan:module reservation
+		NotificationRepo: notificationRepo, // artisan:module notification
+		ReportRepo:       reportRepo,       // artisan:module report
+		AuditRepo:        auditRepo,
+		AdminRepo:        adminRepo, // artisan:module admin
+		AccountRepo:      accountRepo,
+		ConsentRepo:      consentRepo,
+		AvatarRepo:       avatarRepo, // artisan:module avatar
+		QuotaRepo:        quotaRepo,
+		OIDCRepo:         oidcRepo, // artisan:module oidc
+		SSORepo:          ssoRepo,  // artisan:module sso
+		SCIMRepo:         scimRepo, // artisan:module scim
+		OrganizationRepo: organizationRepo,
+		InvitationRepo:   invitationRepo, // artisan:module invitation
+		SettingRepo:      settingRepo,
+		ActivityRepo:     activityRepo, // artisan:module activity
+		SavedSearchRepo:  savedSearchRepo,
+		ExportRepo:       exportRepo,       // artisan:module export
+		ImportRepo:       importRepo,       // artisan:module imports
+		ProductImageRepo: productImageRepo, // artisan:module productimage
 
 		// Usecases
-		AuthUsecase:    authUsecase,
-		ProductUsecase: productUsecase,
+		AuthUsecase:         authUsecase,
+		ProductUsecase:      productUsecase,
+		ReservationUsecase:  reservationUsecase,  // artisan:module reservation
+		NotificationUsecase: notificationUsecase, // artisan:module notification
+		ReportUsecase:       reportUsecase,       // artisan:module report
+		AuditUsecase:        auditUsecase,
+		AdminUsecase:        adminUsecase, // artisan:module admin
+		AccountUsecase:      accountUsecase,
+		ConsentUsecase:      consentUsecase,
+		AvatarUsecase:       avatarUsecase, // artisan:module avatar
+		QuotaUsecase:        quotaUsecase,
+		OIDCUsecase:         oidcUsecase, // artisan:module oidc
+		SSOUsecase:          ssoUsecase,  // artisan:module sso
+		SCIMUsecase:         scimUsecase, // artisan:module scim
+		OrganizationUsecase: organizationUsecase,
+		InvitationUsecase:   invitationUsecase, // artisan:module invitation
+		SettingUsecase:      settingUsecase,
+		ActivityUsecase:     activityUsecase, // artisan:module activity
+		SavedSearchUsecase:  savedSearchUsecase,
+		ExportUsecase:       exportUsecase,       // artisan:module export
+		ImportUsecase:       importUsecase,       // artisan:module imports
+		ProductImageUsecase: productImageUsecase, // artisan:module productimage
 
 		// Handlers
-		AuthHandler:    authHandler,
-		ProductHandler: productHandler,
+		AuthHandler:         authHandler,
+		ProductHandler:      productHandler,
+		ReservationHandler:  reservationHandler,  // artisan:module reservation
+		NotificationHandler: notificationHandler, // artisan:module notification
+		ReportHandler:       reportHandler,       // artisan:module report
+		AdminHandler:        adminHandler,        // artisan:module admin
+		AccountHandler:      accountHandler,
+		ConsentHandler:      consentHandler,
+		AvatarHandler:       avatarHandler, // artisan:module avatar
+		QuotaHandler:        quotaHandler,
+		OIDCHandler:         oidcHandler, // artisan:module oidc
+		SSOHandler:          ssoHandler,  // artisan:module sso
+		SCIMHandler:         scimHandler, // artisan:module scim
+		OrganizationHandler: organizationHandler,
+		InvitationHandler:   invitationHandler, // artisan:module invitation
+		SettingHandler:      settingHandler,
+		ActivityHandler:     activityHandler, // artisan:module activity
+		SavedSearchHandler:  savedSearchHandler,
+		ExportHandler:       exportHandler,       // artisan:module export
+		ImportHandler:       importHandler,       // artisan:module imports
+		ProductImageHandler: productImageHandler, // artisan:module productimage
+
+		// Store
+		// artisan:module store
+		StoreRepo:    storeRepo,
+		StoreUsecase: storeUsecase,
+		StoreHandler: storeHandler,
+		// artisan:end
+
+		// artisan:insert values
 	}
 }
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// Export formats
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+	ExportFormatCSV  = "csv"
+)
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type AccountExportRequest struct {
+	Format string `form:"format" validate:"omitempty,oneof=json zip"`
+}
+
+// AccountExport is everything the service stores about a user
+type AccountExport struct {
+	Profile    *User       `json:"profile"`
+	Products   []*Product  `json:"products"`
+	AuditLogs  []*AuditLog `json:"audit_logs"`
+	ExportedAt time.Time   `json:"exported_at"`
+}
+
+// AccountExportLink is a finished export, downloadable until ExpiresAt
+type AccountExportLink struct {
+	Email     string
+	URL       string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+// Activity verbs
+const (
+	ActivityCreated   = "created"
+	ActivityUpdated   = "updated"
+	ActivityDeleted   = "deleted"
+	ActivityReserved  = "reserved"
+	ActivityCommitted = "committed"
+	ActivityCancelled = "cancelled"
+)
+
+// Types of the objects and targets of activities
+const (
+	ActivityProduct     = "product"
+	ActivityReservation = "reservation"
+)
+
+// Activity is an entry of the activity feed: the actor did verb to the object,
+// optionally within a target, e.g. a user reserved a reservation of a product.
+// Data keeps what the feed shows about the object, such as its name at the
+// time, so entries still read well after it is gone.
+type Activity struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID    uuid.UUID  `json:"actor_id" gorm:"type:uuid;not null;index:idx_tb_activities_actor_created,priority:1"`
+	Verb       string     `json:"verb" gorm:"not null"`
+	ObjectType string     `json:"object_type" gorm:"not null;index:idx_tb_activities_object,priority:1"`
+	ObjectID   uuid.UUID  `json:"object_id" gorm:"type:uuid;not null;index:idx_tb_activities_object,priority:2"`
+	TargetType string     `json:"target_type,omitempty" gorm:"not null;default:'';index:idx_tb_activities_target,priority:1"`
+	TargetID   *uuid.UUID `json:"target_id,omitempty" gorm:"type:uuid;index:idx_tb_activities_target,priority:2"`
+	Data       JSON       `json:"data" gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"index:idx_tb_activities_actor_created,priority:2;index"`
+}
+
+func (Activity) TableName() string {
+	return "tb_activities"
+}
+
+type ActivityFilter struct {
+	Verb string `form:"verb" validate:"omitempty,oneof=created updated deleted reserved committed cancelled"`
+
+	pagination.Params
+}
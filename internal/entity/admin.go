@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+)
+
+// AdminDashboard summarizes the service for the admin dashboard
+type AdminDashboard struct {
+	Users       UserStats    `json:"users"`
+	Products    ProductStats `json:"products"`
+	Jobs        JobStats     `json:"jobs"`
+	GeneratedAt time.Time    `json:"generated_at"`
+}
+
+// UserStats counts registered users; deleted users are not counted
+type UserStats struct {
+	Total      int64 `json:"total"`
+	Active     int64 `json:"active"`
+	Admins     int64 `json:"admins"`
+	NewLast7d  int64 `json:"new_last_7d" gorm:"column:new_last_7d"`
+	NewLast30d int64 `json:"new_last_30d" gorm:"column:new_last_30d"`
+}
+
+// ProductStats counts products; deleted products are not counted
+type ProductStats struct {
+	Total      int64 `json:"total"`
+	Active     int64 `json:"active"`
+	OutOfStock int64 `json:"out_of_stock"`
+}
+
+// JobStats counts background jobs by state
+type JobStats struct {
+	Pending  int64 `json:"pending"`
+	Reserved int64 `json:"reserved"`
+	Failed   int64 `json:"failed"`
+}
+
+type RecentUsersQuery struct {
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type FailedJobFilter struct {
+	Queue string `form:"queue"`
+
+	pagination.Params
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog records a write request made to the API: who made it, what it
+// targeted and how it ended
+type AuditLog struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID   *uuid.UUID `json:"actor_id" gorm:"type:uuid;index:idx_tb_audit_logs_actor_created,priority:1"` // nil for anonymous requests such as login
+	Method    string     `json:"method" gorm:"not null"`
+	Route     string     `json:"route" gorm:"not null"` // route pattern, e.g. /api/v1/products/:id
+	Path      string     `json:"path" gorm:"not null"`
+	Status    int        `json:"status" gorm:"not null"`
+	IP        string     `json:"ip"`
+	RequestID string     `json:"request_id"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index;index:idx_tb_audit_logs_actor_created,priority:2"`
+}
+
+func (AuditLog) TableName() string {
+	return "tb_audit_logs"
+}
+
+type AuditLogFilter struct {
+	ActorID *uuid.UUID `form:"actor_id"`
+	Method  string     `form:"method" validate:"omitempty,oneof=POST PUT PATCH DELETE"`
+
+	pagination.Params
+}
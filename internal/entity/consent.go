@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Consent records that a user accepted a version of a policy
+type Consent struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_tb_consents_user_policy_version,priority:1"`
+	Policy     string    `json:"policy" gorm:"not null;uniqueIndex:idx_tb_consents_user_policy_version,priority:2"`
+	Version    string    `json:"version" gorm:"not null;uniqueIndex:idx_tb_consents_user_policy_version,priority:3"`
+	IP         string    `json:"ip"`
+	AcceptedAt time.Time `json:"accepted_at" gorm:"not null"`
+}
+
+func (Consent) TableName() string {
+	return "tb_consents"
+}
+
+// Policy is a version of a document users must accept, such as the terms of service
+type Policy struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// PolicyStatus is a required policy and whether the user accepted its current version
+type PolicyStatus struct {
+	Policy
+	Accepted   bool       `json:"accepted"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+type AcceptPolicyRequest struct {
+	Policy  string `json:"policy" validate:"required"`
+	Version string `json:"version" validate:"required"`
+}
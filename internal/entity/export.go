@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Export kinds
+const (
+	ExportKindProducts           = "products"
+	ExportKindAccount            = "account"
+	ExportKindProductsByCategory = "report.products_by_category"
+	ExportKindRegistrations      = "report.registrations"
+	ExportKindStockValue         = "report.stock_value"
+)
+
+// Export statuses
+const (
+	ExportPending   = "pending"
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// Export is a file built in the background for its requester. Params holds
+// the kind's own request parameters, such as the product filter. File is the
+// storage path of the finished file, which is removed at ExpiresAt along with
+// the export.
+type Export struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Kind        string     `json:"kind" gorm:"not null"`
+	Format      string     `json:"format" gorm:"not null"`
+	Params      JSON       `json:"params" gorm:"type:jsonb;not null;default:'{}'"`
+	Status      string     `json:"status" gorm:"not null;default:'pending'"`
+	File        string     `json:"-" gorm:"not null;default:''"`
+	Size        int64      `json:"size" gorm:"not null;default:0"`
+	Error       string     `json:"error,omitempty" gorm:"not null;default:''"`
+	DownloadURL string     `json:"download_url,omitempty" gorm:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null;index"`
+}
+
+func (Export) TableName() string {
+	return "tb_exports"
+}
+
+type CreateExportRequest struct {
+	Kind   string `json:"kind" validate:"required,oneof=products account report.products_by_category report.registrations report.stock_value"`
+	Format string `json:"format" validate:"omitempty,oneof=json zip csv"`
+	Params JSON   `json:"params"`
+}
+
+// ProductExportParams selects the products of a products export, like the
+// filter of a saved search
+type ProductExportParams struct {
+	SavedSearchFilter
+}
+
+// RegistrationExportParams selects the days of a registrations report
+// export; both ends are inclusive and default like the report's
+type RegistrationExportParams struct {
+	From string `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To   string `json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Import kinds
+const (
+	ImportKindProducts = "products"
+)
+
+// Import statuses. An upload is validated in the background and waits as
+// validated until its owner commits it.
+const (
+	ImportPending    = "pending"
+	ImportValidating = "validating"
+	ImportValidated  = "validated"
+	ImportCommitting = "committing"
+	ImportCommitted  = "committed"
+	ImportFailed     = "failed"
+)
+
+// Import is a CSV file uploaded to create records of its kind. Validation
+// fills in the row counts and the row-level Errors; committing applies the
+// valid rows, counting them in ImportedRows. Error is set when the file as a
+// whole can't be imported.
+type Import struct {
+	ID           uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID       uuid.UUID    `json:"user_id" gorm:"type:uuid;not null;index"`
+	Kind         string       `json:"kind" gorm:"not null"`
+	Filename     string       `json:"filename" gorm:"not null;default:''"`
+	File         string       `json:"-" gorm:"not null"`
+	Status       string       `json:"status" gorm:"not null;default:'pending'"`
+	TotalRows    int          `json:"total_rows" gorm:"not null;default:0"`
+	ValidRows    int          `json:"valid_rows" gorm:"not null;default:0"`
+	InvalidRows  int          `json:"invalid_rows" gorm:"not null;default:0"`
+	ImportedRows int          `json:"imported_rows" gorm:"not null;default:0"`
+	Errors       ImportErrors `json:"errors" gorm:"type:jsonb;not null;default:'[]'"`
+	Error        string       `json:"error,omitempty" gorm:"not null;default:''"`
+	CreatedAt    time.Time    `json:"created_at"`
+	ValidatedAt  *time.Time   `json:"validated_at"`
+	CommittedAt  *time.Time   `json:"committed_at"`
+	ExpiresAt    time.Time    `json:"expires_at" gorm:"not null;index"`
+}
+
+func (Import) TableName() string {
+	return "tb_imports"
+}
+
+// ImportRowError is why a row can't be imported. Row is the line in the file,
+// the header being line 1; Field is the column, when one is at fault.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportErrors is the error report of an import, stored as JSON
+type ImportErrors []ImportRowError
+
+func (e ImportErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (e *ImportErrors) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into ImportErrors", value)
+	}
+}
+
+type CreateImportRequest struct {
+	Kind string `form:"kind" validate:"required,oneof=products"`
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+// Notification types
+const (
+	NotificationProductOutOfStock = "product.out_of_stock"
+	NotificationProductLowStock   = "product.low_stock"
+	NotificationSavedSearchMatch  = "saved_search.matched"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index:idx_tb_notifications_user_created,priority:1"`
+	Type      string     `json:"type" gorm:"not null"`
+	Payload   JSON       `json:"payload" gorm:"type:jsonb;not null;default:'{}'"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index:idx_tb_notifications_user_created,priority:2"`
+}
+
+func (Notification) TableName() string {
+	return "tb_notifications"
+}
+
+type NotificationFilter struct {
+	Unread bool `form:"unread"`
+
+	pagination.Params
+}
+
+// JSON is raw JSON stored in a jsonb column and emitted as-is in responses
+type JSON json.RawMessage
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append((*j)[:0], data...)
+	return nil
+}
+
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return "{}", nil
+	}
+	return string(j), nil
+}
+
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDC scopes the provider supports. openid is required; profile and email
+// add the matching claims to the ID token and userinfo.
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// OIDCClient is an application registered to sign users in with this app.
+// A client without a secret is public and relies on PKCE alone.
+type OIDCClient struct {
+	ID          string
+	Secret      string
+	RedirectURI string
+}
+
+// OIDCAuthorizationCode is an issued authorization code waiting to be traded
+// for tokens. It can be used once; only the SHA-256 of the code is stored.
+type OIDCAuthorizationCode struct {
+	CodeHash      string    `gorm:"primaryKey"`
+	ClientID      string    `gorm:"not null"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	RedirectURI   string    `gorm:"not null"`
+	Scope         string    `gorm:"not null"`
+	Nonce         string
+	CodeChallenge string    `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+func (OIDCAuthorizationCode) TableName() string {
+	return "tb_oidc_authorization_codes"
+}
+
+// AuthorizeRequest is an authorization request of a client, forwarded by the
+// web app once the user has signed in and agreed
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type" validate:"required,eq=code"`
+	ClientID            string `json:"client_id" validate:"required"`
+	RedirectURI         string `json:"redirect_uri" validate:"required,url"`
+	Scope               string `json:"scope" validate:"required"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	CodeChallenge       string `json:"code_challenge" validate:"required,min=43,max=128"`
+	CodeChallengeMethod string `json:"code_challenge_method" validate:"required,eq=S256"`
+}
+
+// AuthorizeResponse is where the web app sends the user back to the client
+type AuthorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// TokenRequest is the form a client posts to trade an authorization code for
+// tokens. The client secret can also be sent with HTTP Basic authentication.
+type TokenRequest struct {
+	GrantType    string `form:"grant_type" validate:"required"`
+	Code         string `form:"code" validate:"required"`
+	RedirectURI  string `form:"redirect_uri" validate:"required"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	CodeVerifier string `form:"code_verifier" validate:"required,min=43,max=128"`
+}
+
+// TokenResponse is the OAuth 2.0 token response
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+// OAuthError is the error body of the token and userinfo endpoints, which
+// OAuth clients expect instead of the API's usual envelope
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// UserInfo holds the claims about a user released for the granted scopes
+type UserInfo struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email,omitempty"`
+	Name              string `json:"name,omitempty"`
+	GivenName         string `json:"given_name,omitempty"`
+	FamilyName        string `json:"family_name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+}
+
+// OIDCDiscovery is the provider's OpenID Connect discovery document
+type OIDCDiscovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// JSONWebKey is a public signing key in JWK format
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JSONWebKeySet is the provider's public keys, for clients verifying tokens
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
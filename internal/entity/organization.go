@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Organization roles, from least to most privileged. Members manage the
+// organization's products they created; admins manage all of its products,
+// its members and invitations; owners also manage admins and owners, and can
+// delete the organization.
+const (
+	OrgRoleMember = "member"
+	OrgRoleAdmin  = "admin"
+	OrgRoleOwner  = "owner"
+)
+
+// ValidOrgRoles lists the organization roles, least privileged first
+var ValidOrgRoles = []string{OrgRoleMember, OrgRoleAdmin, OrgRoleOwner}
+
+// Organization is a team that owns products together
+type Organization struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PublicID  *string        `json:"public_id,omitempty" gorm:"size:32;uniqueIndex;publicid:organization"` // short ID for URLs, see pkg/publicid
+	Name      string         `json:"name" gorm:"not null"`
+	CreatedBy uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (Organization) TableName() string {
+	return "tb_organizations"
+}
+
+// Membership is a user's role in an organization
+type Membership struct {
+	OrganizationID uuid.UUID     `json:"organization_id" gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID     `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	Role           string        `json:"role" gorm:"not null"`
+	Organization   *Organization `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"` // loaded when listing the user's organizations
+	User           *User         `json:"user,omitempty" gorm:"foreignKey:UserID"`                 // loaded when listing the members
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func (Membership) TableName() string {
+	return "tb_organization_members"
+}
+
+// OrgInvitation invites an email to join an organization with a role. The
+// emailed token is stored as SHA-256.
+type OrgInvitation struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;index"`
+	Email          string     `json:"email" gorm:"not null"`
+	Role           string     `json:"role" gorm:"not null"`
+	TokenHash      string     `json:"-" gorm:"not null;uniqueIndex"`
+	InvitedBy      uuid.UUID  `json:"invited_by" gorm:"type:uuid;not null"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (OrgInvitation) TableName() string {
+	return "tb_organization_invitations"
+}
+
+type OrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=member admin owner"`
+}
+
+type InviteMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=member admin owner"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// RegisterInvitationRequest registers the invited email and joins the
+// organization in one step
+type RegisterInvitationRequest struct {
+	Token     string `json:"token" validate:"required"`
+	Username  string `json:"username" validate:"required,min=3,max=50"`
+	Password  string `json:"password" validate:"required,min=6"`
+	FirstName string `json:"first_name" validate:"required,min=1,max=100"`
+	LastName  string `json:"last_name" validate:"required,min=1,max=100"`
+}
+
+// InvitationRegistration is the session of a user registered through an
+// invitation, with their new membership
+type InvitationRegistration struct {
+	*AuthResponse
+	Membership *Membership `json:"membership"`
+}
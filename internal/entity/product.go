@@ -1,54 +1,272 @@
 package entity
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
 	"time"
 
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/pagination"
+
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
 type Product struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name        string         `json:"name" gorm:"not null" validate:"required,min=1,max=255"`
-	Description string         `json:"description" gorm:"type:text"`
-	Price       float64        `json:"price" gorm:"not null" validate:"required,min=0"`
-	Stock       int            `json:"stock" gorm:"not null;default:0" validate:"min=0"`
-	Category    string         `json:"category" gorm:"not null" validate:"required"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
-	User        User           `json:"user,omitempty" gorm:"foreignKey:CreatedBy"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PublicID          *string        `json:"public_id,omitempty" gorm:"size:32;uniqueIndex;publicid:product"` // short ID for URLs, see pkg/publicid
+	Name              string         `json:"name" gorm:"not null" validate:"required,min=1,max=255"`
+	Description       string         `json:"description" gorm:"type:text"`
+	Price             money.Money    `json:"price" gorm:"embedded;embeddedPrefix:price_" validate:"money"`
+	DisplayPrice      *money.Money   `json:"display_price,omitempty" gorm:"-"` // price converted to the requested ?currency=
+	Stock             int            `json:"stock" gorm:"not null;default:0" validate:"min=0"`
+	Category          string         `json:"category" gorm:"not null" validate:"required"`
+	IsActive          bool           `json:"is_active" gorm:"default:true"`
+	LowStockThreshold *int           `json:"low_stock_threshold" validate:"omitempty,min=0"`
+	LowStockAlertedAt *time.Time     `json:"low_stock_alerted_at,omitempty"`
+	Attributes        Attributes     `json:"attributes" gorm:"type:jsonb;not null;default:'{}'"`
+	ViewCount         int64          `json:"view_count" gorm:"->"` // read-only: views are added by the scheduler, see pkg/counter
+	CreatedBy         uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	User              User           `json:"user,omitempty" gorm:"foreignKey:CreatedBy"`
+	OrganizationID    *uuid.UUID     `json:"organization_id" gorm:"type:uuid;index"` // owning organization; nil for products owned by their creator alone
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 func (Product) TableName() string {
 	return "tb_products"
 }
 
+// Attributes are the free-form properties of a product, such as its color or
+// size, kept in a JSONB column and filtered with ?attributes[color]=red. Only
+// the keys listed in PRODUCT_ATTRIBUTES are accepted.
+type Attributes map[string]string
+
+// Value stores no attributes as {} rather than null, so containment queries
+// treat them alike
+func (a Attributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (a *Attributes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("cannot scan %T into Attributes", value)
+	}
+}
+
+// Priced is implemented by product representations that can show their price
+// converted to another currency
+type Priced interface {
+	PriceOf() money.Money
+	SetDisplayPrice(price money.Money)
+}
+
+func (p *Product) SetDisplayPrice(price money.Money) {
+	p.DisplayPrice = &price
+}
+
+func (p *Product) PriceOf() money.Money {
+	return p.Price
+}
+
+// ProductReadModel is the denormalized product listing GET /products is served
+// from, so listings need no joins. Rows are rebuilt from tb_products and
+// tb_users when a product changes; never write them directly.
+type ProductReadModel struct {
+	ID             uuid.UUID        `json:"id" gorm:"column:product_id;type:uuid;primary_key"`
+	PublicID       *string          `json:"public_id,omitempty" gorm:"size:32"`
+	Name           string           `json:"name"`
+	Description    string           `json:"description"`
+	Price          money.Money      `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	DisplayPrice   *money.Money     `json:"display_price,omitempty" gorm:"-"`
+	Stock          int              `json:"stock"`
+	Category       string           `json:"category"`
+	CategoryPath   string           `json:"category_path"` // full category path; categories are flat, so it equals category for now
+	IsActive       bool             `json:"is_active"`
+	CreatedBy      uuid.UUID        `json:"created_by" gorm:"type:uuid"`
+	OwnerName      string           `json:"owner_name"`
+	OrganizationID *uuid.UUID       `json:"organization_id" gorm:"type:uuid"`
+	User           *User            `json:"user,omitempty" gorm:"foreignKey:CreatedBy"` // loaded only with ?include=user
+	Rating         *decimal.Decimal `json:"rating"`                                     // average rating, null until the product is rated
+	RatingCount    int              `json:"rating_count"`
+	Attributes     Attributes       `json:"attributes" gorm:"type:jsonb"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+func (ProductReadModel) TableName() string {
+	return "tb_product_read_models"
+}
+
+func (p *ProductReadModel) SetDisplayPrice(price money.Money) {
+	p.DisplayPrice = &price
+}
+
+func (p *ProductReadModel) PriceOf() money.Money {
+	return p.Price
+}
+
 type CreateProductRequest struct {
-	Name        string  `json:"name" validate:"required,min=1,max=255"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,min=0"`
-	Stock       int     `json:"stock" validate:"min=0"`
-	Category    string  `json:"category" validate:"required"`
+	Name              string      `json:"name" validate:"required,min=1,max=255"`
+	Description       string      `json:"description"`
+	Price             money.Money `json:"price" validate:"money"`
+	Stock             int         `json:"stock" validate:"min=0"`
+	Category          string      `json:"category" validate:"required"`
+	LowStockThreshold *int        `json:"low_stock_threshold,omitempty" validate:"omitempty,min=0"`
+	Attributes        Attributes  `json:"attributes,omitempty" validate:"max=20,dive,keys,min=1,max=50,endkeys,max=255"`
+	OrganizationID    *uuid.UUID  `json:"organization_id,omitempty"` // create the product for an organization the user belongs to
 }
 
 type UpdateProductRequest struct {
-	Name        *string  `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
-	Description *string  `json:"description,omitempty"`
-	Price       *float64 `json:"price,omitempty" validate:"omitempty,min=0"`
-	Stock       *int     `json:"stock,omitempty" validate:"omitempty,min=0"`
-	Category    *string  `json:"category,omitempty"`
-	IsActive    *bool    `json:"is_active,omitempty"`
+	Name              *string      `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description       *string      `json:"description,omitempty"`
+	Price             *money.Money `json:"price,omitempty" validate:"omitempty,money"`
+	Stock             *int         `json:"stock,omitempty" validate:"omitempty,min=0"`
+	Category          *string      `json:"category,omitempty"`
+	IsActive          *bool        `json:"is_active,omitempty"`
+	LowStockThreshold *int         `json:"low_stock_threshold,omitempty" validate:"omitempty,min=0"`
+	Attributes        *Attributes  `json:"attributes,omitempty" validate:"omitempty,max=20,dive,keys,min=1,max=50,endkeys,max=255"` // replaces every attribute
+}
+
+// NewUpdateProductRequest returns the update request that sets every field to
+// the product's current value, the document PATCH /products/:id patches
+func NewUpdateProductRequest(product *Product) *UpdateProductRequest {
+	name, description, price := product.Name, product.Description, product.Price
+	stock, category, isActive := product.Stock, product.Category, product.IsActive
+	req := &UpdateProductRequest{
+		Name:        &name,
+		Description: &description,
+		Price:       &price,
+		Stock:       &stock,
+		Category:    &category,
+		IsActive:    &isActive,
+	}
+	if product.LowStockThreshold != nil {
+		threshold := *product.LowStockThreshold
+		req.LowStockThreshold = &threshold
+	}
+	attributes := maps.Clone(product.Attributes)
+	if attributes == nil {
+		attributes = Attributes{}
+	}
+	req.Attributes = &attributes
+	return req
 }
 
+// CounterProductViews is the pkg/counter counter of product views, keyed by
+// product ID
+const CounterProductViews = "product_views"
+
+// Bulk product actions, the :action of POST /products/bulk/:action
+const (
+	BulkDelete     = "delete"
+	BulkRestore    = "restore"
+	BulkActivate   = "activate"
+	BulkDeactivate = "deactivate"
+)
+
+// Reasons a product of a bulk action was skipped or failed
+const (
+	BulkReasonNotFound  = "not_found" // no such product
+	BulkReasonUnchanged = "unchanged" // already deleted, restored, active or inactive
+	BulkReasonDeleted   = "deleted"   // deleted products are restored before they are changed
+	BulkReasonForbidden = "forbidden" // the user may not change the product
+	BulkReasonError     = "error"     // the product could not be read or written
+)
+
+// BulkProductRequest picks the products of a bulk action: those listed in
+// IDs, or every product matching Filter
+type BulkProductRequest struct {
+	IDs    []uuid.UUID        `json:"ids" validate:"required_without=Filter,max=1000"`
+	Filter *BulkProductFilter `json:"filter" validate:"required_without=IDs"`
+}
+
+// BulkProductFilter matches the products of a bulk action. It needs at least
+// one condition, so no action reaches every product by accident.
+type BulkProductFilter struct {
+	Category       string     `json:"category" filter:"category"`
+	OrganizationID *uuid.UUID `json:"organization_id" filter:"organization_id"`
+	IsActive       *bool      `json:"is_active" filter:"is_active"`
+}
+
+// Empty reports whether the filter has no condition
+func (f *BulkProductFilter) Empty() bool {
+	return f.Category == "" && f.OrganizationID == nil && f.IsActive == nil
+}
+
+// BulkProductResult summarizes a bulk action. Counts cover every product
+// picked; Items lists the skipped and failed ones, up to a limit.
+type BulkProductResult struct {
+	Action    string            `json:"action"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Skipped   int               `json:"skipped"`
+	Items     []BulkProductItem `json:"items"`
+	Truncated bool              `json:"truncated,omitempty"` // more products were skipped or failed than Items lists
+}
+
+// BulkProductItem is a product a bulk action skipped or failed
+type BulkProductItem struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"` // skipped or failed
+	Reason string    `json:"reason"`
+}
+
+// Relations product listings can load with ?include=
+const (
+	ProductIncludeUser = "user"
+)
+
 type ProductFilter struct {
-	Category string  `form:"category"`
-	MinPrice float64 `form:"min_price"`
-	MaxPrice float64 `form:"max_price"`
-	IsActive *bool   `form:"is_active"`
-	Search   string  `form:"search"`
-	Page     int     `form:"page" validate:"min=1"`
-	Limit    int     `form:"limit" validate:"min=1,max=100"`
+	Category       string          `form:"category" filter:"category"`
+	MinPrice       decimal.Decimal `form:"min_price" filter:"price_amount,gte"`
+	MaxPrice       decimal.Decimal `form:"max_price" filter:"price_amount,lte"`
+	IsActive       *bool           `form:"is_active" filter:"is_active"`
+	Search         string          `form:"search" filter:"name|description,ilike"`
+	OrganizationID string          `form:"organization_id" validate:"omitempty,uuid" filter:"organization_id"`
+	Currency       string          `form:"currency" validate:"omitempty,currency"`
+	Include        string          `form:"include" validate:"omitempty,list=user"`                                                  // comma-separated relations to load
+	Attributes     Attributes      `form:"-" validate:"max=20,dive,keys,min=1,max=50,endkeys,max=255" filter:"attributes,contains"` // from ?attributes[key]=value
+
+	// Set by saved search checks to find products created in a window
+	CreatedAfter  *time.Time `form:"-" filter:"created_at,gt"`
+	CreatedBefore *time.Time `form:"-" filter:"created_at,lte"`
+
+	pagination.Params
+}
+
+// Includes returns the relations requested with ?include=
+func (f *ProductFilter) Includes() []string {
+	if f.Include == "" {
+		return nil
+	}
+	return strings.Split(f.Include, ",")
+}
+
+// Total is the number of rows a listing matches. Estimated totals come from
+// planner statistics and can be off by a few percent.
+type Total struct {
+	Count     int64
+	Estimated bool
+}
+
+type ProductQuery struct {
+	Currency string `form:"currency" validate:"omitempty,currency"`
 }
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductImage is an image of a product. URL serves the uploaded image, and
+// Variants the URL of each predefined size, which a background job renders
+// after the upload; until it has, a variant's URL serves the original.
+type ProductImage struct {
+	ID           uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductID    uuid.UUID     `json:"product_id" gorm:"type:uuid;not null;index"`
+	URL          string        `json:"url" gorm:"not null"`
+	Path         string        `json:"-" gorm:"not null"`
+	Width        int           `json:"width" gorm:"not null"`
+	Height       int           `json:"height" gorm:"not null"`
+	Variants     ImageVariants `json:"variants" gorm:"type:jsonb;not null;default:'{}'"`
+	VariantPaths ImageVariants `json:"-" gorm:"type:jsonb;not null;default:'{}'"` // files of the rendered variants
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+func (ProductImage) TableName() string {
+	return "tb_product_images"
+}
+
+// ImageVariants maps variant names to URLs or files, stored as JSON
+type ImageVariants map[string]string
+
+func (v ImageVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (v *ImageVariants) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case []byte:
+		return json.Unmarshal(data, v)
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	default:
+		return fmt.Errorf("cannot scan %T into ImageVariants", value)
+	}
+}
@@ -0,0 +1,10 @@
+package entity
+
+// PublicIDModels are the models addressed by short public IDs in URLs (the
+// publicid tag setting); add new ones so resolution and publicid:backfill
+// cover them
+var PublicIDModels = []interface{}{
+	&Product{},
+	&Organization{},
+	&Reservation{}, // artisan:module reservation
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Quota periods
+const (
+	QuotaDaily   = "daily"
+	QuotaMonthly = "monthly"
+)
+
+// APIUsage counts a user's metered requests in one quota period, starting at
+// PeriodStart (UTC midnight, or the first of the month)
+type APIUsage struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Period      string    `gorm:"primaryKey"`
+	PeriodStart time.Time `gorm:"primaryKey"`
+	Count       int64     `gorm:"not null;default:0"`
+}
+
+func (APIUsage) TableName() string {
+	return "tb_api_usage"
+}
+
+// QuotaWindow is a user's usage against one quota period. Limit is 0 when the
+// period is not limited.
+type QuotaWindow struct {
+	Period    string    `json:"period"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// Exceeded reports whether the window is limited and used up
+func (w QuotaWindow) Exceeded() bool {
+	return w.Limit > 0 && w.Used > w.Limit
+}
+
+// QuotaUsage is a user's usage against every quota period
+type QuotaUsage struct {
+	Daily   QuotaWindow `json:"daily"`
+	Monthly QuotaWindow `json:"monthly"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/money"
+)
+
+// Report formats
+const (
+	ReportFormatJSON = "json"
+	ReportFormatCSV  = "csv"
+)
+
+// CategoryReport counts the products in one category
+type CategoryReport struct {
+	Category     string `json:"category"`
+	ProductCount int64  `json:"product_count"`
+	ActiveCount  int64  `json:"active_count"`
+	TotalStock   int64  `json:"total_stock"`
+}
+
+// RegistrationReport counts the users registered on one day (YYYY-MM-DD)
+type RegistrationReport struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// StockValueReport totals the stock on hand priced in one currency
+type StockValueReport struct {
+	Currency     string      `json:"currency"`
+	ProductCount int64       `json:"product_count"`
+	TotalStock   int64       `json:"total_stock"`
+	TotalValue   money.Money `json:"total_value"`
+}
+
+type ReportQuery struct {
+	Format string `form:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// RegistrationReportFilter selects the days to report; both ends are inclusive
+type RegistrationReportFilter struct {
+	From   time.Time `form:"from" time_format:"2006-01-02"`
+	To     time.Time `form:"to" time_format:"2006-01-02"`
+	Format string    `form:"format" validate:"omitempty,oneof=json csv"`
+}
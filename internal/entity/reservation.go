@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+// Reservation statuses. Stock is taken from the product when a reservation is
+// made; released and expired reservations give it back, committed ones keep it.
+const (
+	ReservationActive    = "active"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+type Reservation struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PublicID  *string    `json:"public_id,omitempty" gorm:"size:32;uniqueIndex;publicid:reservation"` // short ID for URLs, see pkg/publicid
+	ProductID uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Quantity  int        `json:"quantity" gorm:"not null"`
+	Status    string     `json:"status" gorm:"not null;default:active;index:idx_tb_reservations_status_expires,priority:1"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index:idx_tb_reservations_status_expires,priority:2"`
+	ClosedAt  *time.Time `json:"closed_at"` // when the reservation was committed, released or expired
+	Product   *Product   `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (Reservation) TableName() string {
+	return "tb_reservations"
+}
+
+type CreateReservationRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+type ReservationFilter struct {
+	Status   string `form:"status" validate:"omitempty,oneof=active committed released expired"`
+	Archived bool   `form:"archived"` // list archived reservations instead
+
+	pagination.Params
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SavedSearch is a named product filter a user keeps, to list its products
+// again with GET /products?saved_filter=<id> or to be notified of new ones.
+// CheckedAt is when new matches were last looked for.
+type SavedSearch struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID         `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name      string            `json:"name" gorm:"not null"`
+	Filter    SavedSearchFilter `json:"filter" gorm:"type:jsonb;not null;default:'{}'"`
+	Notify    bool              `json:"notify" gorm:"not null;default:false"`
+	CheckedAt *time.Time        `json:"checked_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func (SavedSearch) TableName() string {
+	return "tb_saved_searches"
+}
+
+// SavedSearchFilter is the part of a product listing query a saved search
+// keeps; paging and display options are left to each request
+type SavedSearchFilter struct {
+	Category       string           `json:"category,omitempty" validate:"max=100"`
+	MinPrice       *decimal.Decimal `json:"min_price,omitempty"`
+	MaxPrice       *decimal.Decimal `json:"max_price,omitempty"`
+	IsActive       *bool            `json:"is_active,omitempty"`
+	Search         string           `json:"search,omitempty" validate:"max=255"`
+	OrganizationID string           `json:"organization_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// Values returns the filter as product listing query parameters
+func (f SavedSearchFilter) Values() url.Values {
+	values := url.Values{}
+	if f.Category != "" {
+		values.Set("category", f.Category)
+	}
+	if f.MinPrice != nil {
+		values.Set("min_price", f.MinPrice.String())
+	}
+	if f.MaxPrice != nil {
+		values.Set("max_price", f.MaxPrice.String())
+	}
+	if f.IsActive != nil {
+		values.Set("is_active", strconv.FormatBool(*f.IsActive))
+	}
+	if f.Search != "" {
+		values.Set("search", f.Search)
+	}
+	if f.OrganizationID != "" {
+		values.Set("organization_id", f.OrganizationID)
+	}
+	return values
+}
+
+// ProductFilter returns the product filter the saved search stands for
+func (f SavedSearchFilter) ProductFilter() *ProductFilter {
+	filter := &ProductFilter{
+		Category:       f.Category,
+		IsActive:       f.IsActive,
+		Search:         f.Search,
+		OrganizationID: f.OrganizationID,
+	}
+	if f.MinPrice != nil {
+		filter.MinPrice = *f.MinPrice
+	}
+	if f.MaxPrice != nil {
+		filter.MaxPrice = *f.MaxPrice
+	}
+	return filter
+}
+
+func (f SavedSearchFilter) Value() (driver.Value, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (f *SavedSearchFilter) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("cannot scan %T into SavedSearchFilter", value)
+	}
+}
+
+type SavedSearchRequest struct {
+	Name   string            `json:"name" validate:"required,min=1,max=100"`
+	Filter SavedSearchFilter `json:"filter"`
+	Notify bool              `json:"notify"`
+}
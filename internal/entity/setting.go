@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+// Setting scopes, from broadest to narrowest. A setting's value at a scope
+// overrides those of the broader scopes.
+const (
+	SettingScopeGlobal = "global"
+	SettingScopeTenant = "tenant" // scope_id is the tenant ID
+	SettingScopeUser   = "user"   // scope_id is the user ID
+)
+
+// Keys of the settings read by the app; their definitions are in the setting
+// package
+const (
+	SettingProductsPageSize   = "products.page_size"
+	SettingMaintenanceMessage = "maintenance.message"
+	SettingFeaturesBeta       = "features.beta"
+)
+
+// Setting value types
+const (
+	SettingString   = "string"
+	SettingInt      = "int"
+	SettingBool     = "bool"
+	SettingDuration = "duration"
+)
+
+// Setting is a value stored for a setting at one scope. Settings without a
+// stored value use their definition's default.
+type Setting struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Scope     string     `json:"scope" gorm:"not null;uniqueIndex:idx_tb_settings_scope_key,priority:1"`
+	ScopeID   string     `json:"scope_id" gorm:"not null;default:'';uniqueIndex:idx_tb_settings_scope_key,priority:2"` // empty for global settings
+	Key       string     `json:"key" gorm:"not null;uniqueIndex:idx_tb_settings_scope_key,priority:3"`
+	Value     string     `json:"value" gorm:"type:text;not null"`
+	UpdatedBy *uuid.UUID `json:"updated_by" gorm:"type:uuid"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (Setting) TableName() string {
+	return "tb_settings"
+}
+
+// SettingChange records a change to a stored setting value. OldValue is nil
+// when the value was first set at the scope, NewValue when it was reset.
+type SettingChange struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Scope     string     `json:"scope" gorm:"not null"`
+	ScopeID   string     `json:"scope_id" gorm:"not null;default:''"`
+	Key       string     `json:"key" gorm:"not null;index"`
+	OldValue  *string    `json:"old_value" gorm:"type:text"`
+	NewValue  *string    `json:"new_value" gorm:"type:text"`
+	ChangedBy *uuid.UUID `json:"changed_by" gorm:"type:uuid"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index"`
+}
+
+func (SettingChange) TableName() string {
+	return "tb_setting_changes"
+}
+
+// SettingDefinition describes a setting: its type, default, the scopes it may
+// be set at and, for int settings, its bounds. Public settings are readable
+// without being an admin, e.g. by clients showing a maintenance message.
+type SettingDefinition struct {
+	Key         string
+	Type        string
+	Default     string
+	Scopes      []string
+	Public      bool
+	Min         *int
+	Max         *int
+	Description string
+}
+
+// AllowsScope reports whether the setting may be set at scope
+func (d *SettingDefinition) AllowsScope(scope string) bool {
+	for _, s := range d.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// SettingValue is a setting's effective value at a scope, and the scope it
+// comes from: the scope itself, a broader one, or "default"
+type SettingValue struct {
+	Key         string   `json:"key"`
+	Type        string   `json:"type"`
+	Value       string   `json:"value"`
+	Source      string   `json:"source"`
+	Default     string   `json:"default"`
+	Scopes      []string `json:"scopes"`
+	Description string   `json:"description"`
+}
+
+// SettingQuery selects the scope whose effective settings are listed or
+// reset, global when none is given. ScopeID names the tenant or user.
+type SettingQuery struct {
+	Scope   string `form:"scope" validate:"omitempty,oneof=global tenant user"`
+	ScopeID string `form:"scope_id" validate:"max=255"`
+}
+
+// UpdateSettingRequest sets a setting at a scope
+type UpdateSettingRequest struct {
+	Scope   string `json:"scope" validate:"required,oneof=global tenant user"`
+	ScopeID string `json:"scope_id" validate:"max=255"`
+	Value   string `json:"value" validate:"max=2000"`
+}
+
+// UpdatePreferenceRequest sets one of the current user's preferences, a
+// setting at the user scope
+type UpdatePreferenceRequest struct {
+	Value string `json:"value" validate:"max=2000"`
+}
+
+// SettingChangeFilter selects recorded changes. Scope and ScopeID are set by
+// the usecase, not the query, to keep tenants to their own changes.
+type SettingChangeFilter struct {
+	Key     string `form:"key"`
+	Scope   string `form:"-"`
+	ScopeID string `form:"-"`
+
+	pagination.Params
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSOConnection is an external OpenID Connect identity provider users can
+// sign in with. Users whose email domain is in Domains are sent to it, and
+// only emails in those domains are accepted from it. RoleMapping maps IdP
+// groups, read from the GroupsClaim of the ID token, to roles; with a
+// mapping the role of the users it provisioned is updated on every sign-in.
+type SSOConnection struct {
+	Name         string            `yaml:"name"`
+	Issuer       string            `yaml:"issuer"`
+	ClientID     string            `yaml:"client_id"`
+	ClientSecret string            `yaml:"client_secret"`
+	Domains      []string          `yaml:"domains"`
+	GroupsClaim  string            `yaml:"groups_claim"`
+	RoleMapping  map[string]string `yaml:"role_mapping"`
+	DefaultRole  string            `yaml:"default_role"`
+}
+
+// SSOLogin is a started sign-in, waiting for the IdP to redirect back. It can
+// be completed once; only the SHA-256 of the state is stored, and the nonce
+// and PKCE verifier are encrypted.
+type SSOLogin struct {
+	StateHash    string    `gorm:"primaryKey"`
+	Connection   string    `gorm:"not null"`
+	Nonce        string    `gorm:"not null;serializer:encrypted"`
+	CodeVerifier string    `gorm:"not null;serializer:encrypted"`
+	RememberMe   bool      `gorm:"not null;default:false"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+func (SSOLogin) TableName() string {
+	return "tb_sso_logins"
+}
+
+// SSOIdentity links a user to the identity provider account that
+// provisioned them. Sign-ins are matched on the issuer and subject, never on
+// the email alone, so an IdP cannot sign in accounts it did not create.
+type SSOIdentity struct {
+	Issuer     string    `gorm:"primaryKey"`
+	Subject    string    `gorm:"primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Connection string    `gorm:"not null"`
+	CreatedAt  time.Time
+}
+
+func (SSOIdentity) TableName() string {
+	return "tb_sso_identities"
+}
+
+// SSOStartRequest picks the connection by name or by the email's domain
+type SSOStartRequest struct {
+	Connection string `json:"connection" validate:"required_without=Email"`
+	Email      string `json:"email" validate:"required_without=Connection,omitempty,email"`
+	RememberMe bool   `json:"remember_me"`
+}
+
+// SSOStartResponse is where to send the user to sign in
+type SSOStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// SSOCallbackRequest carries the parameters the IdP redirected back with
+type SSOCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store represents a Store entity
+type Store struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name      string         `json:"name" gorm:"not null" validate:"required,min=1,max=255"`
+	Address   string         `json:"address" gorm:"not null" validate:"required,min=1,max=255"`
+	Latitude  float64        `json:"latitude" gorm:"type:double precision;not null" validate:"latitude"`
+	Longitude float64        `json:"longitude" gorm:"type:double precision;not null" validate:"longitude"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for GORM
+func (Store) TableName() string {
+	return "tb_stores"
+}
+
+// CreateStoreRequest represents a request to create a Store
+type CreateStoreRequest struct {
+	Name      string  `json:"name" validate:"required,min=1,max=255"`
+	Address   string  `json:"address" validate:"required,min=1,max=255"`
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
+}
+
+// UpdateStoreRequest represents a request to update a Store
+type UpdateStoreRequest struct {
+	Name      *string  `json:"name,omitempty" validate:"omitempty,required,min=1,max=255"`
+	Address   *string  `json:"address,omitempty" validate:"omitempty,required,min=1,max=255"`
+	Latitude  *float64 `json:"latitude,omitempty" validate:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"omitempty,longitude"`
+}
+
+// StoreFilter represents filters for Store queries. Near and Radius, in
+// meters, keep the stores within Radius of the lat,lng in Near.
+type StoreFilter struct {
+	Name    string  `form:"name" filter:"name"`
+	Address string  `form:"address" filter:"address"`
+	Search  string  `form:"search" filter:"name|address,ilike"`
+	Near    string  `form:"near" validate:"omitempty,latlng"`
+	Radius  float64 `form:"radius" validate:"required_with=Near,omitempty,gte=1,lte=20000000"`
+
+	pagination.Params
+}
@@ -14,19 +14,55 @@ type User struct {
 	Password  string         `json:"-" gorm:"not null" validate:"required,min=6"`
 	FirstName string         `json:"first_name" gorm:"not null" validate:"required,min=1,max=100"`
 	LastName  string         `json:"last_name" gorm:"not null" validate:"required,min=1,max=100"`
+	Role      string         `json:"role" gorm:"not null;default:user"`
 	IsActive  bool           `json:"is_active" gorm:"default:true"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// PendingEmail replaces Email once the links mailed to both addresses are
+	// confirmed; the tokens are stored as SHA-256 and cleared as they are used
+	PendingEmail          *string    `json:"pending_email,omitempty"`
+	PendingEmailExpiresAt *time.Time `json:"pending_email_expires_at,omitempty"`
+	EmailChangeOldToken   string     `json:"-" gorm:"index:idx_tb_users_email_change_old_token"`
+	EmailChangeNewToken   string     `json:"-" gorm:"index:idx_tb_users_email_change_new_token"`
+
+	// PasswordResetToken is the SHA-256 of the token in the last reset link
+	// mailed to the user, cleared once used
+	PasswordResetToken     string     `json:"-" gorm:"index:idx_tb_users_password_reset_token"`
+	PasswordResetExpiresAt *time.Time `json:"-"`
+
+	// AvatarURL serves the avatar; the storage paths are internal. The
+	// thumbnail path stays empty until the thumbnail job has run.
+	AvatarURL       string `json:"avatar_url,omitempty"`
+	AvatarPath      string `json:"-"`
+	AvatarThumbPath string `json:"-"`
+
+	// ExternalID is the identity provider's ID of a user provisioned over SCIM
+	ExternalID string `json:"-" gorm:"index:idx_tb_users_external_id"`
 }
 
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// ValidRoles lists the roles a user can be assigned
+var ValidRoles = []string{RoleUser, RoleAdmin}
+
 func (User) TableName() string {
 	return "tb_users"
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required"`
+	RememberMe bool   `json:"remember_me"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 type RegisterRequest struct {
@@ -37,7 +73,72 @@ type RegisterRequest struct {
 	LastName  string `json:"last_name" validate:"required,min=1,max=100"`
 }
 
+// UpdateProfileRequest changes the names of the current user; fields left
+// out keep their value. Email changes go through ChangeEmailRequest.
+type UpdateProfileRequest struct {
+	Username  *string `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
+	FirstName *string `json:"first_name,omitempty" validate:"omitempty,min=1,max=100"`
+	LastName  *string `json:"last_name,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// NewUpdateProfileRequest returns the update request that sets every field to
+// the user's current value, the document PATCH /auth/profile patches
+func NewUpdateProfileRequest(user *User) *UpdateProfileRequest {
+	username, firstName, lastName := user.Username, user.FirstName, user.LastName
+	return &UpdateProfileRequest{Username: &username, FirstName: &firstName, LastName: &lastName}
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// ForgotPasswordRequest asks for a password reset link to be mailed
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest sets a new password with the token of a reset link.
+// It is sent as JSON or by the reset page's form.
+type ResetPasswordRequest struct {
+	Token                string `json:"token" form:"token" validate:"required"`
+	Password             string `json:"password" form:"password" validate:"required,min=6"`
+	PasswordConfirmation string `json:"password_confirmation" form:"password_confirmation" validate:"required,eqfield=Password"`
+}
+
+// AvailabilityRequest asks whether an email and/or username can still be
+// registered; at least one is required
+type AvailabilityRequest struct {
+	Email    string `form:"email" validate:"required_without=Username,omitempty,email"`
+	Username string `form:"username" validate:"required_without=Email,omitempty,min=3,max=50"`
+}
+
+// AvailabilityResponse reports each identifier that was asked about
+type AvailabilityResponse struct {
+	EmailAvailable    *bool `json:"email_available,omitempty"`
+	UsernameAvailable *bool `json:"username_available,omitempty"`
+}
+
 type AuthResponse struct {
-	User  *User  `json:"user"`
-	Token string `json:"token"`
+	User             *User     `json:"user"`
+	Token            string    `json:"token"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// RefreshToken is a stored refresh token. Each refresh replaces it with a new
+// one that keeps the session's RememberMe and StartedAt; only the SHA-256 of
+// the token is stored.
+type RefreshToken struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash  string    `gorm:"not null;uniqueIndex"`
+	RememberMe bool      `gorm:"not null;default:false"`
+	StartedAt  time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	CreatedAt  time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "tb_refresh_tokens"
 }
@@ -0,0 +1,183 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// contentTypes maps export formats to the Content-Type of their downloads
+var contentTypes = map[string]string{
+	entity.ExportFormatCSV:  "text/csv; charset=utf-8",
+	entity.ExportFormatJSON: "application/json",
+	entity.ExportFormatZIP:  "application/zip",
+}
+
+type ExportHandler struct {
+	usecase ExportUsecase
+}
+
+func NewExportHandler(usecase ExportUsecase) *ExportHandler {
+	return &ExportHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateExport godoc
+// @Summary Start an export
+// @Description Queue building an export file: products (admin, csv), account (the current user's data, json or zip) or a report (admin, csv or json). Poll GET /exports/{id} for its status and download link.
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.CreateExportRequest true "Export"
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /exports [post]
+func (h *ExportHandler) CreateExport(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.CreateExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	export, err := h.usecase.CreateExport(c.Request.Context(), user.ID, user.Role, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to create export", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to create export", nil)
+		}
+		return
+	}
+
+	response.Success(c, 202, "Export started", export)
+}
+
+// GetExport godoc
+// @Summary Get an export
+// @Description Get the status of one of the current user's exports. Completed exports carry a signed download_url, valid for a short time; fetch the export again for a fresh one.
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Export ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /exports/{id} [get]
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid export ID", err.Error())
+		return
+	}
+
+	export, err := h.usecase.GetExport(c.Request.Context(), user.ID, exportID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get export", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get export", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Export retrieved successfully", export)
+}
+
+// DownloadExport godoc
+// @Summary Download an export
+// @Description Download a completed export through the signed download_url of its status. Altered or expired links are rejected with 403 LINK_INVALID.
+// @Tags exports
+// @Produce text/csv
+// @Produce application/json
+// @Produce application/zip
+// @Param id path string true "Export ID"
+// @Param expires query int true "Link expiry, Unix seconds"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file "Export file"
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /exports/{id}/download [get]
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid export ID", err.Error())
+		return
+	}
+
+	export, reader, err := h.usecase.OpenExport(c.Request.Context(), exportID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Failed to open export", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to download export", nil)
+		}
+		return
+	}
+	defer reader.Close()
+
+	filename := fmt.Sprintf("%s-%s.%s", export.Kind, export.CreatedAt.UTC().Format("20060102T150405Z"), export.Format)
+
+	c.Header("Content-Type", contentTypes[export.Format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(200)
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to stream export", zap.Error(err))
+	}
+}
+
+// currentUser reads the authenticated user set by AuthMiddleware and writes
+// the error response when it is missing
+func currentUser(c *gin.Context) (*entity.User, bool) {
+	value, exists := c.Get("user")
+	user, ok := value.(*entity.User)
+	if !exists || !ok {
+		response.Error(c, 401, errors.ErrUnauthorized, "User not found in context", nil)
+		return nil, false
+	}
+
+	return user, true
+}
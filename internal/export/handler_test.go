@@ -0,0 +1,138 @@
+package export_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/export"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// download fetches a signed download link
+func download(t *testing.T, api *apitest.API, link string) *apitest.Response {
+	t.Helper()
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+
+	req := api.WithoutContract().Get(parsed.Path)
+	for key, values := range parsed.Query() {
+		req.Query(key, values[0])
+	}
+	return req.Do()
+}
+
+func TestExportHandler_AccountExport(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var created entity.Export
+	api.As(user).Post("/api/v1/exports", entity.CreateExportRequest{Kind: entity.ExportKindAccount}).Do().
+		AssertStatus(http.StatusAccepted).
+		Decode(&created)
+	assert.Equal(t, entity.ExportPending, created.Status)
+	assert.Equal(t, entity.ExportFormatJSON, created.Format)
+
+	jobs := api.Container.Queue.(*queue.ArrayQueue).Pushed()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, export.JobBuild, jobs[0].Type)
+
+	// Run the job the worker would
+	require.NoError(t, api.Container.ExportUsecase.BuildExport(context.Background(), created.ID))
+
+	var status entity.Export
+	api.As(user).Get("/api/v1/exports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&status)
+	assert.Equal(t, entity.ExportCompleted, status.Status)
+	require.NotEmpty(t, status.DownloadURL)
+
+	res := download(t, api, status.DownloadURL).AssertStatus(http.StatusOK)
+	assert.Contains(t, res.Body(), user.Email)
+
+	// Other users don't see the export
+	api.As(api.CreateUser()).Get("/api/v1/exports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrExportNotFound)
+}
+
+func TestExportHandler_ProductsExport(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	for _, category := range []string{"home", "garden"} {
+		api.As(admin).Post("/api/v1/products", entity.CreateProductRequest{
+			Name:     "Lamp",
+			Price:    money.MustParse("25", "USD"),
+			Stock:    5,
+			Category: category,
+		}).Do().
+			AssertStatus(http.StatusCreated)
+	}
+
+	// Products exports are for admins
+	api.As(api.CreateUser()).Post("/api/v1/exports", entity.CreateExportRequest{Kind: entity.ExportKindProducts}).Do().
+		AssertStatus(http.StatusForbidden)
+
+	var created entity.Export
+	api.As(admin).Post("/api/v1/exports", entity.CreateExportRequest{
+		Kind:   entity.ExportKindProducts,
+		Params: entity.JSON(`{"category": "garden"}`),
+	}).Do().
+		AssertStatus(http.StatusAccepted).
+		Decode(&created)
+	require.NoError(t, api.Container.ExportUsecase.BuildExport(context.Background(), created.ID))
+
+	var status entity.Export
+	api.As(admin).Get("/api/v1/exports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&status)
+
+	res := download(t, api, status.DownloadURL).AssertStatus(http.StatusOK)
+	assert.Contains(t, res.Body(), "garden")
+	assert.NotContains(t, res.Body(), "home")
+}
+
+func TestExportHandler_Invalid(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	api.As(admin).Post("/api/v1/exports", entity.CreateExportRequest{Kind: "orders"}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("kind")
+
+	api.As(admin).Post("/api/v1/exports", entity.CreateExportRequest{
+		Kind:   entity.ExportKindStockValue,
+		Format: entity.ExportFormatZIP,
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrExportInvalid)
+
+	api.As(admin).Post("/api/v1/exports", entity.CreateExportRequest{
+		Kind:   entity.ExportKindRegistrations,
+		Params: entity.JSON(`{"from": "March 1st"}`),
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrExportInvalid)
+
+	api.Get("/api/v1/exports/00000000-0000-0000-0000-000000000000/download").
+		Query("expires", "4102444800").
+		Query("signature", "deadbeef").Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrLinkInvalid)
+}
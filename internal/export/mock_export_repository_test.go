@@ -0,0 +1,55 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package export
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExportRepository is a testify mock of ExportRepository
+type MockExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockExportRepository) CreateExport(ctx context.Context, export *entity.Export) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockExportRepository) GetExportByID(ctx context.Context, exportID uuid.UUID) (*entity.Export, error) {
+	args := m.Called(ctx, exportID)
+
+	var r0 *entity.Export
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Export)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockExportRepository) UpdateExport(ctx context.Context, export *entity.Export) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockExportRepository) GetExpiredExports(ctx context.Context, before time.Time, limit int) ([]*entity.Export, error) {
+	args := m.Called(ctx, before, limit)
+
+	var r0 []*entity.Export
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Export)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockExportRepository) DeleteExport(ctx context.Context, exportID uuid.UUID) error {
+	args := m.Called(ctx, exportID)
+	return args.Error(0)
+}
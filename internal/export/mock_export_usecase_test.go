@@ -0,0 +1,72 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package export
+
+import (
+	"context"
+	"io"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExportUsecase is a testify mock of ExportUsecase
+type MockExportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockExportUsecase) CreateExport(ctx context.Context, userID uuid.UUID, role string, req *entity.CreateExportRequest) (*entity.Export, error) {
+	args := m.Called(ctx, userID, role, req)
+
+	var r0 *entity.Export
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Export)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockExportUsecase) GetExport(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (*entity.Export, error) {
+	args := m.Called(ctx, userID, exportID)
+
+	var r0 *entity.Export
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Export)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockExportUsecase) BuildExport(ctx context.Context, exportID uuid.UUID) error {
+	args := m.Called(ctx, exportID)
+	return args.Error(0)
+}
+
+func (m *MockExportUsecase) OpenExport(ctx context.Context, exportID uuid.UUID) (*entity.Export, io.ReadCloser, error) {
+	args := m.Called(ctx, exportID)
+
+	var r0 *entity.Export
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Export)
+	}
+
+	var r1 io.ReadCloser
+	if v := args.Get(1); v != nil {
+		r1 = v.(io.ReadCloser)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockExportUsecase) PruneExports(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
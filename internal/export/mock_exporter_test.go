@@ -0,0 +1,55 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package export
+
+import (
+	"context"
+	"io"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExporter is a testify mock of Exporter
+type MockExporter struct {
+	mock.Mock
+}
+
+func (m *MockExporter) Formats() []string {
+	args := m.Called()
+
+	var r0 []string
+	if v := args.Get(0); v != nil {
+		r0 = v.([]string)
+	}
+
+	return r0
+}
+
+func (m *MockExporter) AdminOnly() bool {
+	args := m.Called()
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0
+}
+
+func (m *MockExporter) Params() interface{} {
+	args := m.Called()
+
+	var r0 interface{}
+	if v := args.Get(0); v != nil {
+		r0 = v.(interface{})
+	}
+
+	return r0
+}
+
+func (m *MockExporter) Export(ctx context.Context, export *entity.Export, params interface{}, w io.Writer) error {
+	args := m.Called(ctx, export, params, w)
+	return args.Error(0)
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportUsecase defines the business logic interface for export jobs
+type ExportUsecase interface {
+	CreateExport(ctx context.Context, userID uuid.UUID, role string, req *entity.CreateExportRequest) (*entity.Export, error)
+	GetExport(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (*entity.Export, error)
+	BuildExport(ctx context.Context, exportID uuid.UUID) error
+	OpenExport(ctx context.Context, exportID uuid.UUID) (*entity.Export, io.ReadCloser, error)
+	PruneExports(ctx context.Context) (int, error)
+}
+
+// ExportRepository defines the data access interface for export jobs
+type ExportRepository interface {
+	CreateExport(ctx context.Context, export *entity.Export) error
+	GetExportByID(ctx context.Context, exportID uuid.UUID) (*entity.Export, error)
+	UpdateExport(ctx context.Context, export *entity.Export) error
+	GetExpiredExports(ctx context.Context, before time.Time, limit int) ([]*entity.Export, error)
+	DeleteExport(ctx context.Context, exportID uuid.UUID) error
+}
+
+// Exporter writes the file of one kind of export. Exporters live with the data
+// they export and are registered by kind in the container.
+type Exporter interface {
+	// Formats lists the formats the exporter writes, the default first
+	Formats() []string
+	// AdminOnly reports whether only admins may request the export
+	AdminOnly() bool
+	// Params returns a new value for the request parameters to be decoded
+	// into and validated, or nil when the export takes none
+	Params() interface{}
+	// Export writes the file in export.Format to w. params is the value from
+	// Params, filled in from export.Params.
+	Export(ctx context.Context, export *entity.Export, params interface{}, w io.Writer) error
+}
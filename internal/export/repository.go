@@ -0,0 +1,52 @@
+package export
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) ExportRepository {
+	return &exportRepository{
+		db: db,
+	}
+}
+
+func (r *exportRepository) CreateExport(ctx context.Context, export *entity.Export) error {
+	return tenancy.Conn(ctx, r.db).Create(export).Error
+}
+
+func (r *exportRepository) GetExportByID(ctx context.Context, exportID uuid.UUID) (*entity.Export, error) {
+	var export entity.Export
+	if err := tenancy.Conn(ctx, r.db).First(&export, "id = ?", exportID).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *exportRepository) UpdateExport(ctx context.Context, export *entity.Export) error {
+	return tenancy.Conn(ctx, r.db).Save(export).Error
+}
+
+// GetExpiredExports returns up to limit exports that expired before the given time
+func (r *exportRepository) GetExpiredExports(ctx context.Context, before time.Time, limit int) ([]*entity.Export, error) {
+	var exports []*entity.Export
+	err := tenancy.Conn(ctx, r.db).
+		Where("expires_at < ?", before).
+		Order("expires_at").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *exportRepository) DeleteExport(ctx context.Context, exportID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Delete(&entity.Export{}, "id = ?", exportID).Error
+}
@@ -0,0 +1,318 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/signedurl"
+	"go-clean-gin/pkg/storage"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// JobBuild is the job type building an export; its handler is registered in
+// internal/jobs
+const JobBuild = "export:build"
+
+// BuildPayload is the payload of a JobBuild job
+type BuildPayload struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// pruneBatchSize is the number of expired exports removed per query round
+const pruneBatchSize = 100
+
+type exportUsecase struct {
+	repo      ExportRepository
+	exporters map[string]Exporter
+	config    *config.Config
+	queue     queue.Queue
+	storage   storage.Storage
+	signer    *signedurl.Signer
+	clock     clock.Clock
+}
+
+func NewExportUsecase(repo ExportRepository, exporters map[string]Exporter, config *config.Config, jobQueue queue.Queue, store storage.Storage, signer *signedurl.Signer, clk clock.Clock) ExportUsecase {
+	return &exportUsecase{
+		repo:      repo,
+		exporters: exporters,
+		config:    config,
+		queue:     jobQueue,
+		storage:   store,
+		signer:    signer,
+		clock:     clk,
+	}
+}
+
+// CreateExport checks the request against the kind's exporter and queues
+// building the file. Exports that are never built still expire after the
+// retention, so they don't pile up.
+func (u *exportUsecase) CreateExport(ctx context.Context, userID uuid.UUID, role string, req *entity.CreateExportRequest) (*entity.Export, error) {
+	exporter, ok := u.exporters[req.Kind]
+	if !ok {
+		return nil, errors.New(errors.ErrExportInvalid, "Unknown export kind", 400).
+			WithDetails(map[string]interface{}{"kind": req.Kind})
+	}
+	if exporter.AdminOnly() && role != entity.RoleAdmin {
+		return nil, errors.ErrForbiddenError
+	}
+
+	format := req.Format
+	if format == "" {
+		format = exporter.Formats()[0]
+	}
+	if !slices.Contains(exporter.Formats(), format) {
+		return nil, errors.New(errors.ErrExportInvalid, "Format not available for this export", 400).
+			WithDetails(map[string]interface{}{"formats": exporter.Formats()})
+	}
+
+	if _, err := decodeParams(exporter, req.Params); err != nil {
+		return nil, err
+	}
+
+	now := u.clock.Now()
+	export := &entity.Export{
+		UserID:    userID,
+		Kind:      req.Kind,
+		Format:    format,
+		Params:    req.Params,
+		Status:    entity.ExportPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(u.config.Export.Retention),
+	}
+
+	if err := u.repo.CreateExport(ctx, export); err != nil {
+		logger.FromContext(ctx).Error("Failed to create export", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create export", 500)
+	}
+
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobBuild, BuildPayload{ExportID: export.ID}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue export", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create export", 500)
+	}
+
+	logger.FromContext(ctx).Info("Export requested",
+		zap.String("export_id", export.ID.String()), zap.String("kind", export.Kind), zap.String("format", format))
+	return export, nil
+}
+
+// GetExport returns one of the user's exports, with a signed download link
+// once it is completed. Other users' exports are reported as not found.
+func (u *exportUsecase) GetExport(ctx context.Context, userID uuid.UUID, exportID uuid.UUID) (*entity.Export, error) {
+	export, err := u.getExport(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.UserID != userID {
+		return nil, errors.ErrExportNotFoundError
+	}
+
+	if export.Status == entity.ExportCompleted {
+		ttl := min(u.config.Export.LinkTTL, export.ExpiresAt.Sub(u.clock.Now()))
+		if ttl <= 0 {
+			return nil, errors.ErrExportNotFoundError
+		}
+
+		link, _, err := u.signer.Sign(fmt.Sprintf("%s/api/v1/exports/%s/download", u.config.Account.URL, export.ID), ttl)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to sign export link", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get export", 500)
+		}
+		export.DownloadURL = link
+	}
+
+	return export, nil
+}
+
+// BuildExport runs the kind's exporter into storage. A failure marks the
+// export failed and is returned so the job is retried, which starts it over.
+func (u *exportUsecase) BuildExport(ctx context.Context, exportID uuid.UUID) error {
+	export, err := u.repo.GetExportByID(ctx, exportID)
+	if err == gorm.ErrRecordNotFound {
+		// Pruned before a worker got to it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if export.Status == entity.ExportCompleted {
+		return nil
+	}
+
+	exporter, ok := u.exporters[export.Kind]
+	if !ok {
+		return fmt.Errorf("no exporter for kind %q", export.Kind)
+	}
+	params, err := decodeParams(exporter, export.Params)
+	if err != nil {
+		return err
+	}
+
+	started := u.clock.Now()
+	export.Status = entity.ExportRunning
+	export.StartedAt = &started
+	export.Error = ""
+	if err := u.repo.UpdateExport(ctx, export); err != nil {
+		return err
+	}
+
+	file := fmt.Sprintf("%s/%s/%s.%s", u.config.Export.Dir, export.UserID, export.ID, export.Format)
+	size, err := u.store(ctx, file, func(w io.Writer) error {
+		return exporter.Export(ctx, export, params, w)
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to build export",
+			zap.String("export_id", export.ID.String()), zap.Error(err))
+
+		export.Status = entity.ExportFailed
+		export.Error = "The export could not be built"
+		if appErr, ok := err.(*errors.AppError); ok && appErr.StatusCode < 500 {
+			export.Error = appErr.Message
+		}
+		if updateErr := u.repo.UpdateExport(ctx, export); updateErr != nil {
+			logger.FromContext(ctx).Error("Failed to mark export failed", zap.Error(updateErr))
+		}
+		return err
+	}
+
+	completed := u.clock.Now()
+	export.Status = entity.ExportCompleted
+	export.File = file
+	export.Size = size
+	export.CompletedAt = &completed
+	export.ExpiresAt = completed.Add(u.config.Export.Retention)
+	if err := u.repo.UpdateExport(ctx, export); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Export built",
+		zap.String("export_id", export.ID.String()), zap.String("file", file), zap.Int64("bytes", size))
+	return nil
+}
+
+// OpenExport opens the file of a completed export. The download route checks
+// the link's signature, which stands in for authentication.
+func (u *exportUsecase) OpenExport(ctx context.Context, exportID uuid.UUID) (*entity.Export, io.ReadCloser, error) {
+	export, err := u.getExport(ctx, exportID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != entity.ExportCompleted {
+		return nil, nil, errors.ErrExportNotFoundError
+	}
+
+	reader, err := u.storage.Get(ctx, export.File)
+	if err == storage.ErrNotFound {
+		return nil, nil, errors.ErrExportNotFoundError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to open export", zap.Error(err))
+		return nil, nil, errors.Wrap(err, errors.ErrInternal, "Failed to open export", 500)
+	}
+	return export, reader, nil
+}
+
+// PruneExports deletes expired exports and their files
+func (u *exportUsecase) PruneExports(ctx context.Context) (int, error) {
+	now := u.clock.Now()
+	deleted := 0
+
+	for {
+		exports, err := u.repo.GetExpiredExports(ctx, now, pruneBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+
+		for _, export := range exports {
+			if export.File != "" {
+				if err := u.storage.Delete(ctx, export.File); err != nil {
+					return deleted, err
+				}
+			}
+			if err := u.repo.DeleteExport(ctx, export.ID); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+
+		if len(exports) < pruneBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+// store streams what write writes into the storage object at file and returns
+// its size. Nothing is stored when write fails.
+func (u *exportUsecase) store(ctx context.Context, file string, write func(io.Writer) error) (int64, error) {
+	pr, pw := io.Pipe()
+	counter := &countingWriter{w: pw}
+
+	go func() {
+		pw.CloseWithError(write(counter))
+	}()
+
+	err := u.storage.Put(ctx, file, pr)
+	// Unblocks the writer when storage gave up before reading everything
+	pr.CloseWithError(err)
+	if err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+func (u *exportUsecase) getExport(ctx context.Context, exportID uuid.UUID) (*entity.Export, error) {
+	export, err := u.repo.GetExportByID(ctx, exportID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrExportNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get export", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get export", 500)
+	}
+	return export, nil
+}
+
+// decodeParams decodes and validates the request parameters of an export
+func decodeParams(exporter Exporter, raw entity.JSON) (interface{}, error) {
+	params := exporter.Params()
+	if params == nil {
+		return nil, nil
+	}
+
+	if len(raw) > 0 && strings.TrimSpace(string(raw)) != "null" {
+		if err := json.Unmarshal(raw, params); err != nil {
+			return nil, errors.New(errors.ErrExportInvalid, "Invalid export parameters", 400).
+				WithDetails(map[string]interface{}{"params": err.Error()})
+		}
+	}
+
+	if fieldErrors := validator.ValidateStruct(params); fieldErrors != nil {
+		return nil, errors.New(errors.ErrExportInvalid, "Invalid export parameters", 400).WithDetails(fieldErrors)
+	}
+	return params, nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/signedurl"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+type testParams struct {
+	Category string `json:"category" validate:"max=5"`
+}
+
+type testDeps struct {
+	repo     *MockExportRepository
+	exporter *MockExporter
+	queue    *queue.ArrayQueue
+	storage  storage.Storage
+	signer   *signedurl.Signer
+	clock    *clock.Fake
+	usecase  ExportUsecase
+}
+
+func newTestUsecase(t *testing.T) *testDeps {
+	cfg := &config.Config{
+		Queue:   config.QueueConfig{Default: "default"},
+		Account: config.AccountConfig{URL: "http://api.test"},
+		Export:  config.ExportConfig{Dir: "export-jobs", Retention: 24 * time.Hour, LinkTTL: 15 * time.Minute},
+	}
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	d := &testDeps{
+		repo:     new(MockExportRepository),
+		exporter: new(MockExporter),
+		queue:    queue.NewArrayQueue(&cfg.Queue),
+		storage:  store,
+		clock:    clock.NewFake(testNow),
+	}
+	d.signer = signedurl.New("test-secret", d.clock)
+	d.exporter.On("Formats").Return([]string{entity.ExportFormatCSV, entity.ExportFormatJSON}).Maybe()
+	d.exporter.On("Params").Return(&testParams{}).Maybe()
+
+	exporters := map[string]Exporter{entity.ExportKindProducts: d.exporter}
+	d.usecase = NewExportUsecase(d.repo, exporters, cfg, d.queue, store, d.signer, d.clock)
+	return d
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestExportUsecase_CreateExport(t *testing.T) {
+	d := newTestUsecase(t)
+	d.exporter.On("AdminOnly").Return(false)
+	d.repo.On("CreateExport", mock.Anything, mock.Anything).Return(nil)
+	userID := uuid.New()
+
+	export, err := d.usecase.CreateExport(context.Background(), userID, entity.RoleUser, &entity.CreateExportRequest{
+		Kind:   entity.ExportKindProducts,
+		Params: entity.JSON(`{"category": "home"}`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, export.UserID)
+	assert.Equal(t, entity.ExportFormatCSV, export.Format)
+	assert.Equal(t, entity.ExportPending, export.Status)
+	assert.Equal(t, testNow.Add(24*time.Hour), export.ExpiresAt)
+	if pushed := d.queue.Pushed(); assert.Len(t, pushed, 1) {
+		assert.Equal(t, JobBuild, pushed[0].Type)
+	}
+}
+
+func TestExportUsecase_CreateExport_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		req  entity.CreateExportRequest
+		code string
+	}{
+		{"unknown kind", entity.RoleAdmin, entity.CreateExportRequest{Kind: entity.ExportKindAccount}, errors.ErrExportInvalid},
+		{"admin only", entity.RoleUser, entity.CreateExportRequest{Kind: entity.ExportKindProducts}, errors.ErrForbidden},
+		{"format", entity.RoleAdmin, entity.CreateExportRequest{Kind: entity.ExportKindProducts, Format: entity.ExportFormatZIP}, errors.ErrExportInvalid},
+		{"params", entity.RoleAdmin, entity.CreateExportRequest{Kind: entity.ExportKindProducts, Params: entity.JSON(`{"category": "furniture"}`)}, errors.ErrExportInvalid},
+		{"malformed params", entity.RoleAdmin, entity.CreateExportRequest{Kind: entity.ExportKindProducts, Params: entity.JSON(`[]`)}, errors.ErrExportInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(t)
+			d.exporter.On("AdminOnly").Return(true)
+
+			_, err := d.usecase.CreateExport(context.Background(), uuid.New(), tt.role, &tt.req)
+
+			assertCode(t, err, tt.code)
+			assert.Empty(t, d.queue.Pushed())
+			d.repo.AssertNotCalled(t, "CreateExport", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestExportUsecase_BuildExport(t *testing.T) {
+	d := newTestUsecase(t)
+	export := &entity.Export{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Kind:   entity.ExportKindProducts,
+		Format: entity.ExportFormatCSV,
+		Params: entity.JSON(`{"category": "home"}`),
+		Status: entity.ExportPending,
+	}
+	d.repo.On("GetExportByID", mock.Anything, export.ID).Return(export, nil)
+	d.repo.On("UpdateExport", mock.Anything, export).Return(nil)
+	d.exporter.On("Export", mock.Anything, export, &testParams{Category: "home"}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(3).(io.Writer), "id,name\n")
+		}).Return(nil)
+
+	require.NoError(t, d.usecase.BuildExport(context.Background(), export.ID))
+
+	assert.Equal(t, entity.ExportCompleted, export.Status)
+	assert.Equal(t, fmt.Sprintf("export-jobs/%s/%s.csv", export.UserID, export.ID), export.File)
+	assert.Equal(t, int64(8), export.Size)
+	assert.Equal(t, testNow.Add(24*time.Hour), export.ExpiresAt)
+
+	_, reader, err := d.usecase.OpenExport(context.Background(), export.ID)
+	require.NoError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n", string(data))
+}
+
+func TestExportUsecase_BuildExport_Failure(t *testing.T) {
+	d := newTestUsecase(t)
+	export := &entity.Export{ID: uuid.New(), UserID: uuid.New(), Kind: entity.ExportKindProducts, Format: entity.ExportFormatCSV}
+	d.repo.On("GetExportByID", mock.Anything, export.ID).Return(export, nil)
+	d.repo.On("UpdateExport", mock.Anything, export).Return(nil)
+	d.exporter.On("Export", mock.Anything, export, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(3).(io.Writer), "partial")
+		}).Return(fmt.Errorf("connection reset"))
+
+	err := d.usecase.BuildExport(context.Background(), export.ID)
+
+	assert.Error(t, err)
+	assert.Equal(t, entity.ExportFailed, export.Status)
+	assert.Equal(t, "The export could not be built", export.Error)
+	assert.Empty(t, export.File)
+
+	files, err := d.storage.List(context.Background(), "export-jobs/")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestExportUsecase_GetExport(t *testing.T) {
+	d := newTestUsecase(t)
+	completedAt := testNow.Add(-time.Hour)
+	export := &entity.Export{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		Status:      entity.ExportCompleted,
+		CompletedAt: &completedAt,
+		ExpiresAt:   testNow.Add(5 * time.Minute),
+	}
+	d.repo.On("GetExportByID", mock.Anything, export.ID).Return(export, nil)
+
+	_, err := d.usecase.GetExport(context.Background(), uuid.New(), export.ID)
+	assert.Equal(t, errors.ErrExportNotFoundError, err)
+
+	got, err := d.usecase.GetExport(context.Background(), export.UserID, export.ID)
+	require.NoError(t, err)
+
+	// The link ends with the file when that is sooner than the link TTL
+	link, err := url.Parse(got.DownloadURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/exports/"+export.ID.String()+"/download", link.Path)
+	assert.NoError(t, d.signer.Verify(link))
+	d.clock.Advance(6 * time.Minute)
+	assert.Error(t, d.signer.Verify(link))
+}
+
+func TestExportUsecase_PruneExports(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+
+	built := &entity.Export{ID: uuid.New(), File: "export-jobs/someone/built.csv"}
+	pending := &entity.Export{ID: uuid.New()}
+	require.NoError(t, d.storage.Put(ctx, built.File, strings.NewReader("id")))
+	d.repo.On("GetExpiredExports", mock.Anything, testNow, pruneBatchSize).Return([]*entity.Export{built, pending}, nil)
+	d.repo.On("DeleteExport", mock.Anything, built.ID).Return(nil).Once()
+	d.repo.On("DeleteExport", mock.Anything, pending.ID).Return(nil).Once()
+
+	deleted, err := d.usecase.PruneExports(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	exists, err := d.storage.Exists(ctx, built.File)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	d.repo.AssertExpectations(t)
+}
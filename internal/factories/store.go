@@ -0,0 +1,46 @@
+package factories
+
+import (
+	"fmt"
+
+	"go-clean-gin/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// StoreFactory makes store entities with sample values, numbered
+// so that every one is different
+type StoreFactory struct {
+	sequence int
+}
+
+func NewStoreFactory() *StoreFactory {
+	return &StoreFactory{}
+}
+
+// Make returns a new store without saving it, changed by the overrides
+func (f *StoreFactory) Make(overrides ...func(*entity.Store)) *entity.Store {
+	f.sequence++
+	store := &entity.Store{
+		Name:      fmt.Sprintf("Name %d", f.sequence),
+		Address:   fmt.Sprintf("Address %d", f.sequence),
+		Latitude:  float64(f.sequence%181 - 90),
+		Longitude: float64(f.sequence%361 - 180),
+	}
+	for _, override := range overrides {
+		override(store)
+	}
+	return store
+}
+
+// Create saves count store entities made like Make
+func (f *StoreFactory) Create(db *gorm.DB, count int, overrides ...func(*entity.Store)) ([]*entity.Store, error) {
+	models := make([]*entity.Store, count)
+	for i := range models {
+		models[i] = f.Make(overrides...)
+	}
+	if err := db.Create(models).Error; err != nil {
+		return nil, err
+	}
+	return models, nil
+}
@@ -0,0 +1,383 @@
+// internal/generator/cache.go - Read-through cache decorators for repository interfaces
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CacheHeader starts every generated cache decorator, so regenerating one
+// never overwrites a hand-written file
+const CacheHeader = "// Code generated by artisan make:cache. DO NOT EDIT."
+
+// Directives marking the methods of a port.go interface for the decorator
+const (
+	// CacheRead caches the method's results until TTL or an invalidating write
+	CacheRead = "//cache:read"
+	// CacheInvalidate drops every cached read of the repository after the call
+	CacheInvalidate = "//cache:invalidate"
+)
+
+// portFile is a parsed port.go
+type portFile struct {
+	path string
+	file *ast.File
+	fset *token.FileSet
+}
+
+func parsePort(port File) (*portFile, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, port.Path, port.Content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	return &portFile{path: port.Path, file: file, fset: fset}, nil
+}
+
+// CachedInterfaces returns the interfaces of a port.go file with a method
+// marked //cache:read or //cache:invalidate
+func CachedInterfaces(port File) ([]string, error) {
+	p, err := parsePort(port)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, spec := range p.interfaces() {
+		for _, field := range spec.Type.(*ast.InterfaceType).Methods.List {
+			if cacheDirective(field) != "" {
+				names = append(names, spec.Name.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+// CacheDecorator renders Cached<Interface>, which wraps any implementation of
+// the interface of a port.go file. Methods marked //cache:read go through
+// pkg/cache; those marked //cache:invalidate drop the cached reads after
+// calling through; the others just call through. Cached methods take a
+// context.Context and return their results and an error, which is never
+// cached.
+func CacheDecorator(port File, iface string) (File, error) {
+	p, err := parsePort(port)
+	if err != nil {
+		return File{}, err
+	}
+
+	var ifaceType *ast.InterfaceType
+	for _, spec := range p.interfaces() {
+		if spec.Name.Name == iface {
+			ifaceType = spec.Type.(*ast.InterfaceType)
+		}
+	}
+	if ifaceType == nil {
+		return File{}, fmt.Errorf("interface %s not found in %s", iface, port.Path)
+	}
+
+	name := "Cached" + iface
+	used := map[string]bool{}
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// %s caches the reads of %s marked\n", name, iface)
+	fmt.Fprintf(&body, "// %s, and drops them on the writes marked %s\n", CacheRead, CacheInvalidate)
+	fmt.Fprintf(&body, "type %s struct {\n\tnext  %s\n\tcache *cache.Decorator\n}\n\n", name, iface)
+	fmt.Fprintf(&body, "// New%s wraps next, keeping reads in store for ttl\n", name)
+	fmt.Fprintf(&body, "func New%s(next %s, store cache.Store, ttl time.Duration) %s {\n", name, iface, iface)
+	fmt.Fprintf(&body, "\treturn &%s{next: next, cache: cache.NewDecorator(%q, store, ttl)}\n}\n", name, p.file.Name.Name+"."+iface)
+
+	for _, field := range ifaceType.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return File{}, fmt.Errorf("%s embeds %s; embedded interfaces are not supported", iface, p.expr(field.Type))
+		}
+		collectQualifiers(funcType, used)
+
+		for _, method := range field.Names {
+			m, err := p.newDecoratedMethod(method.Name, funcType, cacheDirective(field))
+			if err != nil {
+				return File{}, fmt.Errorf("%s.%s: %w", iface, method.Name, err)
+			}
+			m.writeCached(&body, name)
+		}
+	}
+
+	source, err := format.Source(append(p.header(CacheHeader, used, "time", modulePath+"/pkg/cache"), body.Bytes()...))
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		Path:    filepath.Join(filepath.Dir(port.Path), "cached_"+ToSnakeCase(iface)+".go"),
+		Content: source,
+	}, nil
+}
+
+func (p *portFile) interfaces() []*ast.TypeSpec {
+	var specs []*ast.TypeSpec
+	for _, decl := range p.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.IsExported() {
+				specs = append(specs, typeSpec)
+			}
+		}
+	}
+	return specs
+}
+
+// header is the generated comment, package clause and imports: those of the
+// port file the signatures use, plus the decorator's own, grouped like the
+// rest of the repo
+func (p *portFile) header(generated string, used map[string]bool, imports ...string) []byte {
+	groups := make([][]string, 3)
+	seen := map[string]bool{}
+	add := func(path, spec string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		switch {
+		case path == modulePath || strings.HasPrefix(path, modulePath+"/"):
+			groups[1] = append(groups[1], spec)
+		case strings.Contains(strings.Split(path, "/")[0], "."):
+			groups[2] = append(groups[2], spec)
+		default:
+			groups[0] = append(groups[0], spec)
+		}
+	}
+
+	for _, spec := range p.file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		parts := strings.Split(path, "/")
+		name := parts[len(parts)-1]
+		if len(parts) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+			name = parts[len(parts)-2]
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if used[name] {
+			if spec.Name != nil {
+				add(path, spec.Name.Name+" "+spec.Path.Value)
+			} else {
+				add(path, spec.Path.Value)
+			}
+		}
+	}
+	for _, path := range imports {
+		add(path, strconv.Quote(path))
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\nimport (\n", generated, p.file.Name.Name)
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		for _, spec := range group {
+			fmt.Fprintf(&out, "\t%s\n", spec)
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(")\n\n")
+	return out.Bytes()
+}
+
+func (p *portFile) expr(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, p.fset, expr)
+	return buf.String()
+}
+
+// cacheDirective returns the cache directive in a method's comment, or ""
+func cacheDirective(field *ast.Field) string {
+	if field.Doc == nil {
+		return ""
+	}
+	for _, comment := range field.Doc.List {
+		if text := strings.TrimSpace(comment.Text); text == CacheRead || text == CacheInvalidate {
+			return text
+		}
+	}
+	return ""
+}
+
+// collectQualifiers records the package qualifiers a signature references
+func collectQualifiers(node ast.Node, used map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+}
+
+// decoratedMethod is a method of a generated decorator
+type decoratedMethod struct {
+	name      string
+	directive string
+	params    []string // "name type"
+	callArgs  []string // names passed on, with ... for a variadic parameter
+	keyArgs   []string // names making up the cache key
+	ctx       string   // name of the context parameter
+	results   []string // types
+}
+
+// reservedNames are the decorators' own identifiers, which parameters are
+// renamed away from
+func reservedNames(results int) map[string]bool {
+	reserved := map[string]bool{"c": true, "key": true, "err": true}
+	for i := 0; i < results; i++ {
+		reserved["r"+strconv.Itoa(i)] = true
+	}
+	return reserved
+}
+
+func (p *portFile) newDecoratedMethod(name string, funcType *ast.FuncType, directive string) (*decoratedMethod, error) {
+	m := &decoratedMethod{name: name, directive: directive}
+	if funcType.Results != nil {
+		for _, result := range funcType.Results.List {
+			count := len(result.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				m.results = append(m.results, p.expr(result.Type))
+			}
+		}
+	}
+
+	reserved := reservedNames(len(m.results))
+	index := 0
+	for _, param := range funcType.Params.List {
+		typ := p.expr(param.Type)
+		names := param.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("_")}
+		}
+		for _, ident := range names {
+			n := ident.Name
+			if n == "_" || reserved[n] {
+				n = "arg" + strconv.Itoa(index)
+			}
+			index++
+
+			m.params = append(m.params, n+" "+typ)
+			call := n
+			if _, variadic := param.Type.(*ast.Ellipsis); variadic {
+				call += "..."
+			}
+			m.callArgs = append(m.callArgs, call)
+
+			switch {
+			case typ == "context.Context" && m.ctx == "":
+				m.ctx = n
+			case directive == CacheRead && !cacheable(param.Type):
+				return nil, fmt.Errorf("parameter %s of type %s cannot be part of a cache key", n, typ)
+			default:
+				m.keyArgs = append(m.keyArgs, n)
+			}
+		}
+	}
+
+	if directive != "" && m.ctx == "" {
+		return nil, fmt.Errorf("%s needs a context.Context parameter", directive)
+	}
+	if directive == CacheRead && (len(m.results) < 2 || m.results[len(m.results)-1] != "error") {
+		return nil, fmt.Errorf("%s needs results followed by an error", directive)
+	}
+	return m, nil
+}
+
+// cacheable reports whether parameter types can be encoded into a key, and
+// so also hold nothing a retry would call twice: no funcs or channels
+func cacheable(node ast.Node) bool {
+	ok := true
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncType, *ast.ChanType:
+			ok = false
+		}
+		return ok
+	})
+	return ok
+}
+
+// writeSignature starts the method on the receiver and returns the names
+// of its results: r0, r1, ... and err for a last error
+func (m *decoratedMethod) writeSignature(b *bytes.Buffer, receiver string) []string {
+	signature := strings.Join(m.results, ", ")
+	if len(m.results) > 1 {
+		signature = "(" + signature + ")"
+	}
+	fmt.Fprintf(b, "\nfunc (c *%s) %s(%s) %s {\n", receiver, m.name, strings.Join(m.params, ", "), signature)
+
+	names := make([]string, len(m.results))
+	for i, typ := range m.results {
+		names[i] = "r" + strconv.Itoa(i)
+		if i == len(m.results)-1 && typ == "error" {
+			names[i] = "err"
+		}
+	}
+	return names
+}
+
+// call is the call of the same method on the wrapped implementation
+func (m *decoratedMethod) call() string {
+	return fmt.Sprintf("c.next.%s(%s)", m.name, strings.Join(m.callArgs, ", "))
+}
+
+// returnsError reports whether the last result is an error
+func (m *decoratedMethod) returnsError() bool {
+	return len(m.results) > 0 && m.results[len(m.results)-1] == "error"
+}
+
+func (m *decoratedMethod) writeCached(b *bytes.Buffer, receiver string) {
+	names := m.writeSignature(b, receiver)
+	call := m.call()
+
+	switch {
+	case m.directive == CacheRead:
+		values := names[:len(names)-1]
+		pointers := make([]string, len(values))
+		for i, value := range values {
+			fmt.Fprintf(b, "\tvar %s %s\n", value, m.results[i])
+			pointers[i] = "&" + value
+		}
+		keyArgs := append([]string{m.ctx, strconv.Quote(m.name)}, m.keyArgs...)
+		fmt.Fprintf(b, "\tkey := c.cache.Key(%s)\n", strings.Join(keyArgs, ", "))
+		fmt.Fprintf(b, "\tif c.cache.Load(%s, key, %s) {\n", m.ctx, strings.Join(pointers, ", "))
+		fmt.Fprintf(b, "\t\treturn %s, nil\n\t}\n\n", strings.Join(values, ", "))
+		fmt.Fprintf(b, "\t%s := %s\n", strings.Join(names, ", "), call)
+		fmt.Fprintf(b, "\tif err == nil {\n\t\tc.cache.Save(%s, key, %s)\n\t}\n", m.ctx, strings.Join(values, ", "))
+		fmt.Fprintf(b, "\treturn %s\n}\n", strings.Join(names, ", "))
+
+	case m.directive == CacheInvalidate && len(names) > 0:
+		fmt.Fprintf(b, "\t%s := %s\n", strings.Join(names, ", "), call)
+		fmt.Fprintf(b, "\tc.cache.Invalidate(%s)\n", m.ctx)
+		fmt.Fprintf(b, "\treturn %s\n}\n", strings.Join(names, ", "))
+
+	case m.directive == CacheInvalidate:
+		fmt.Fprintf(b, "\t%s\n\tc.cache.Invalidate(%s)\n}\n", call, m.ctx)
+
+	case len(names) > 0:
+		fmt.Fprintf(b, "\treturn %s\n}\n", call)
+
+	default:
+		fmt.Fprintf(b, "\t%s\n}\n", call)
+	}
+}
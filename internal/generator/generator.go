@@ -0,0 +1,644 @@
+// Package generator renders the source files produced by the artisan make:*
+// commands. It performs no I/O so generated code can be tested against golden files.
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// File is a generated source file, with Path relative to the project root
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// MigrationData is the template data for migrations
+type MigrationData struct {
+	ClassName   string
+	TableName   string
+	Timestamp   string
+	Description string
+	Fields      []Field
+	Version     string
+}
+
+// Field is a column parsed from the -fields flag, or a relation to another
+// model
+type Field struct {
+	ClassName    string
+	Name         string
+	Type         string
+	HasIndex     bool
+	IsForeignKey bool
+	FKReference  string // table name that reference
+	Relation     string // one of the Relation* kinds, empty for plain columns
+	Related      string // model the relation points to
+	Nullable     bool   // the column may be NULL
+	Default      string // column default, when HasDefault
+	HasDefault   bool
+}
+
+// IsPointer reports whether the field's Go type is a pointer, for columns
+// that may be NULL and those with a default, which GORM only applies to nil
+func (f Field) IsPointer() bool {
+	return (f.Nullable || f.HasDefault) && !strings.HasPrefix(toGoType(f.Type), "map[")
+}
+
+// Relation kinds accepted in -fields as name:kind=Model
+const (
+	RelationBelongsTo  = "belongsTo"
+	RelationHasOne     = "hasOne"
+	RelationHasMany    = "hasMany"
+	RelationManyToMany = "manyToMany"
+)
+
+// IsColumn reports whether the field is a column of the model's own table. Of
+// the relations only belongsTo is, as its foreign key; the others are stored
+// in the related or a join table.
+func (f Field) IsColumn() bool {
+	return f.Relation == "" || f.Relation == RelationBelongsTo
+}
+
+// Association is the name of the struct field holding the related model(s),
+// User for user:belongsTo=User and Tags for tags:manyToMany=Tag
+func (f Field) Association() string {
+	return ToPascalCase(f.AssociationJSON())
+}
+
+// AssociationJSON is the JSON name of the association
+func (f Field) AssociationJSON() string {
+	return strings.TrimSuffix(f.Name, "_id")
+}
+
+// JoinTable is the table of a manyToMany relation
+type JoinTable struct {
+	StructName   string // PostTag
+	TableName    string // tb_post_tags
+	OwnerField   string // PostId
+	RelatedField string // TagId
+}
+
+// ForeignKey is a constraint added after the tables are created, so that
+// migrating doesn't depend on the referenced table's struct
+type ForeignKey struct {
+	Name       string
+	Table      string
+	Column     string
+	References string
+	OnDelete   string // CASCADE, or SET NULL for nullable columns
+}
+
+// GeoIndex is the earthdistance index of a table's latitude and longitude
+// columns, which proximity searches with earth_box use
+type GeoIndex struct {
+	Name      string
+	Table     string
+	Latitude  string
+	Longitude string
+}
+
+// SeederData is the template data for seeders
+type SeederData struct {
+	ClassName    string
+	TableName    string
+	Dependencies []string // add this field
+	// Factory is the entity seeded with its factory, nil for an empty seeder
+	Factory *EntityData
+}
+
+// EntityData is the template data for entities
+type EntityData struct {
+	EntityName string
+	TableName  string
+	Fields     []Field
+}
+
+// References are the foreign keys of the columns referencing other tables,
+// except nullable ones, which may be left NULL
+func (d EntityData) References() []ForeignKey {
+	var keys []ForeignKey
+	for _, field := range d.Fields {
+		switch {
+		case field.Nullable:
+		case field.IsForeignKey:
+			keys = append(keys, ForeignKey{Table: d.TableName, Column: field.Name, References: field.FKReference})
+		case field.Relation == RelationBelongsTo:
+			keys = append(keys, ForeignKey{Table: d.TableName, Column: field.Name, References: relatedTable(d.TableName, field)})
+		}
+	}
+	return keys
+}
+
+// SearchFilter is the queryfilter tag of the filter's search, matching the
+// text columns, or "" when there are none
+func (d EntityData) SearchFilter() string {
+	columns := searchColumns(d.Fields)
+	if len(columns) == 0 {
+		return ""
+	}
+	return strings.Join(columns, "|") + ",ilike"
+}
+
+// searchColumns are the text columns of the fields
+func searchColumns(fields []Field) []string {
+	var columns []string
+	for _, field := range fields {
+		if field.Type == "string" || field.Type == "text" {
+			columns = append(columns, field.Name)
+		}
+	}
+	return columns
+}
+
+// SampledFields are the columns a factory fills in. Foreign keys are left for
+// the caller to set, and nullable columns and those with a default as nil.
+func (d EntityData) SampledFields() []Field {
+	var fields []Field
+	for _, field := range d.Fields {
+		if field.Relation == "" && !field.IsForeignKey && !field.IsPointer() && sampleValue(field) != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// FactoryUses reports whether a sample value of the factory uses the package
+func (d EntityData) FactoryUses(pkg string) bool {
+	for _, field := range d.SampledFields() {
+		if strings.HasPrefix(sampleValue(field), pkg+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageData is the template data for packages
+type PackageData struct {
+	PackageName string
+	EntityName  string
+	Fields      []Field
+	// CRUD generates create, list, get, update and delete for the entity
+	// instead of an empty package
+	CRUD bool
+}
+
+// Owner is the first belongsTo User field, the one generated policies let
+// modify the entity, or nil
+func (d PackageData) Owner() *Field {
+	for i, field := range d.Fields {
+		if field.Relation == RelationBelongsTo && field.Related == "User" {
+			return &d.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Plural names the entity's collection, e.g. Posts in GetPosts, or
+// EquipmentList for an uncountable entity so the names of the two differ
+func (d PackageData) Plural() string {
+	if plural := Pluralize(d.EntityName); plural != d.EntityName {
+		return plural
+	}
+	return d.EntityName + "List"
+}
+
+// Route is the path the package is served under, e.g. /blog-posts
+func (d PackageData) Route() string {
+	return "/" + strings.ReplaceAll(Pluralize(ToSnakeCase(d.EntityName)), "_", "-")
+}
+
+// SearchColumns are the text columns the filter's search matches
+func (d PackageData) SearchColumns() []string {
+	return searchColumns(d.Fields)
+}
+
+// Preloads returns the associations the repository can preload
+func (d PackageData) Preloads() []string {
+	var preloads []string
+	for _, field := range d.Fields {
+		if field.Relation != "" {
+			preloads = append(preloads, field.Association())
+		}
+	}
+	return preloads
+}
+
+// RequestData is the template data for request and response DTOs
+type RequestData struct {
+	PackageName string
+	Name        string // CreateOrder for CreateOrderRequest and CreateOrderResponse
+	Fields      []Field
+}
+
+// NewRequestData builds DTO data for the name, with or without the Request
+// suffix. The package defaults to the name without its leading verb, order
+// for CreateOrder.
+func NewRequestData(name, packageName string, fields []Field) RequestData {
+	name = strings.TrimSuffix(ToPascalCase(ToSnakeCase(name)), "Request")
+	if packageName == "" {
+		words := strings.Split(ToSnakeCase(name), "_")
+		if len(words) > 1 {
+			words = words[1:]
+		}
+		packageName = strings.Join(words, "")
+	}
+	return RequestData{PackageName: strings.ToLower(packageName), Name: name, Fields: fields}
+}
+
+// Columns are the fields carried in the DTOs; of the relations only
+// belongsTo keys are
+func (d RequestData) Columns() []Field {
+	var columns []Field
+	for _, field := range d.Fields {
+		if field.IsColumn() {
+			columns = append(columns, field)
+		}
+	}
+	return columns
+}
+
+// Uses reports whether a field's type is from the package
+func (d RequestData) Uses(pkg string) bool {
+	for _, field := range d.Columns() {
+		if strings.Contains(goFieldType(field), pkg+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// EnumData is the template data for enums
+type EnumData struct {
+	TypeName string
+	Values   []EnumValue
+}
+
+// EnumValue is a single enum member
+type EnumValue struct {
+	ConstName string
+	Value     string
+}
+
+// OneOf returns the values in the format of the validator oneof tag
+func (d EnumData) OneOf() string {
+	values := make([]string, len(d.Values))
+	for i, v := range d.Values {
+		values[i] = v.Value
+	}
+	return strings.Join(values, " ")
+}
+
+var enumValuePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// NewEnumData builds enum data from a comma-separated list of lowercase values
+func NewEnumData(typeName, valuesStr string) (EnumData, error) {
+	data := EnumData{TypeName: ToPascalCase(ToSnakeCase(typeName))}
+	seen := make(map[string]bool)
+
+	for _, value := range strings.Split(valuesStr, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !enumValuePattern.MatchString(value) {
+			return EnumData{}, fmt.Errorf("invalid enum value %q: use lowercase letters, digits and underscores", value)
+		}
+		if seen[value] {
+			return EnumData{}, fmt.Errorf("duplicate enum value %q", value)
+		}
+		seen[value] = true
+
+		data.Values = append(data.Values, EnumValue{
+			ConstName: data.TypeName + ToPascalCase(value),
+			Value:     value,
+		})
+	}
+
+	if len(data.Values) == 0 {
+		return EnumData{}, fmt.Errorf("enum %s needs at least one value", data.TypeName)
+	}
+	return data, nil
+}
+
+// JoinTables returns the join tables of the manyToMany relations
+func (d MigrationData) JoinTables() []JoinTable {
+	owner := GetStructName(d.TableName)
+
+	var tables []JoinTable
+	for _, field := range d.Fields {
+		if field.Relation != RelationManyToMany {
+			continue
+		}
+		tables = append(tables, JoinTable{
+			StructName:   owner + field.Related,
+			TableName:    joinTableName(d.TableName, field),
+			OwnerField:   owner + "Id",
+			RelatedField: field.Related + "Id",
+		})
+	}
+	return tables
+}
+
+// ForeignKeys returns the constraints of the belongsTo columns and of the
+// join tables. Referenced tables default to the plural of the related model,
+// with the tb_ prefix when the table has it; |fk:table overrides it.
+func (d MigrationData) ForeignKeys() []ForeignKey {
+	owner := ToSnakeCase(GetStructName(d.TableName))
+
+	var keys []ForeignKey
+	add := func(table, column, references string, nullable bool) {
+		onDelete := "CASCADE"
+		if nullable {
+			onDelete = "SET NULL"
+		}
+		keys = append(keys, ForeignKey{
+			Name:       fmt.Sprintf("fk_%s_%s", table, strings.TrimSuffix(column, "_id")),
+			Table:      table,
+			Column:     column,
+			References: references,
+			OnDelete:   onDelete,
+		})
+	}
+	for _, field := range d.Fields {
+		switch field.Relation {
+		case RelationBelongsTo:
+			add(d.TableName, field.Name, relatedTable(d.TableName, field), field.Nullable)
+		case RelationManyToMany:
+			join := joinTableName(d.TableName, field)
+			add(join, owner+"_id", d.TableName, false)
+			add(join, ToSnakeCase(field.Related)+"_id", relatedTable(d.TableName, field), false)
+		}
+	}
+	return keys
+}
+
+// GeoIndex returns the index of the first latitude and longitude columns,
+// or nil when the table lacks either
+func (d MigrationData) GeoIndex() *GeoIndex {
+	var latitude, longitude string
+	for _, field := range d.Fields {
+		switch strings.ToLower(field.Type) {
+		case "latitude":
+			if latitude == "" {
+				latitude = field.Name
+			}
+		case "longitude":
+			if longitude == "" {
+				longitude = field.Name
+			}
+		}
+	}
+	if latitude == "" || longitude == "" {
+		return nil
+	}
+	return &GeoIndex{
+		Name:      fmt.Sprintf("idx_%s_location", d.TableName),
+		Table:     d.TableName,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}
+}
+
+// joinTableName names the join table of a manyToMany relation after the
+// owner and the field, e.g. tb_post_tags for tags on tb_posts
+func joinTableName(ownerTable string, field Field) string {
+	prefix := ""
+	if strings.HasPrefix(ownerTable, "tb_") {
+		prefix = "tb_"
+	}
+	return prefix + ToSnakeCase(GetStructName(ownerTable)) + "_" + field.Name
+}
+
+func relatedTable(ownerTable string, field Field) string {
+	if field.FKReference != "" {
+		return field.FKReference
+	}
+	prefix := ""
+	if strings.HasPrefix(ownerTable, "tb_") {
+		prefix = "tb_"
+	}
+	return prefix + Pluralize(ToSnakeCase(field.Related))
+}
+
+// NewMigrationData builds migration data; timestamp uses the 2006_01_02_150405 layout
+func NewMigrationData(migrationName, tableName string, fields []Field, timestamp string) MigrationData {
+	return MigrationData{
+		ClassName:   ToPascalCase(migrationName),
+		TableName:   tableName,
+		Timestamp:   timestamp,
+		Description: migrationName,
+		Fields:      fields,
+		Version:     fmt.Sprintf("%s_%s", timestamp, migrationName),
+	}
+}
+
+// NewSeederData builds seeder data, adding the Seeder suffix to the name and dependencies
+func NewSeederData(seederName, tableName, depsStr string) SeederData {
+	if !strings.HasSuffix(seederName, "Seeder") {
+		seederName += "Seeder"
+	}
+
+	var dependencies []string
+	for _, dep := range strings.Split(depsStr, ",") {
+		dep = strings.TrimSpace(dep)
+		if dep == "" {
+			continue
+		}
+		if !strings.HasSuffix(dep, "Seeder") {
+			dep += "Seeder"
+		}
+		dependencies = append(dependencies, dep)
+	}
+
+	return SeederData{
+		ClassName:    seederName,
+		TableName:    tableName,
+		Dependencies: dependencies,
+	}
+}
+
+// SQLMigrationData is the template data for migrations of raw SQL
+type SQLMigrationData struct {
+	MigrationData
+	HasDown bool
+}
+
+// UpPath is where the up SQL is kept, relative to the migrations
+func (d SQLMigrationData) UpPath() string {
+	return "sql/" + d.Version + ".up.sql"
+}
+
+// DownPath is where the down SQL is kept, relative to the migrations
+func (d SQLMigrationData) DownPath() string {
+	return "sql/" + d.Version + ".down.sql"
+}
+
+// SQLMigration renders a migration executing the up SQL, and the down SQL to
+// roll back when there is any. The SQL is copied next to it under sql/ and
+// embedded, so the binary carries it.
+func SQLMigration(data MigrationData, up, down []byte) ([]File, error) {
+	if len(bytes.TrimSpace(up)) == 0 {
+		return nil, fmt.Errorf("the up SQL is empty")
+	}
+
+	sqlData := SQLMigrationData{MigrationData: data, HasDown: len(bytes.TrimSpace(down)) > 0}
+	content, err := render("sql_migration", sqlData)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join("internal", "migrations")
+	files := []File{
+		{Path: filepath.Join(dir, data.Version+".go"), Content: content},
+		{Path: filepath.Join(dir, filepath.FromSlash(sqlData.UpPath())), Content: up},
+	}
+	if sqlData.HasDown {
+		files = append(files, File{Path: filepath.Join(dir, filepath.FromSlash(sqlData.DownPath())), Content: down})
+	}
+	return files, nil
+}
+
+// Migration renders a create-table migration when create is set, an alter-table
+// migration when only a table is given, and an empty migration otherwise
+func Migration(data MigrationData, create bool) (File, error) {
+	stub := "migration"
+	if create && data.TableName != "" {
+		stub = "create_table"
+	} else if data.TableName != "" {
+		stub = "alter_table"
+	}
+
+	content, err := render(stub, data)
+	return File{
+		Path:    filepath.Join("internal", "migrations", fmt.Sprintf("%s_%s.go", data.Timestamp, ToSnakeCase(data.Description))),
+		Content: content,
+	}, err
+}
+
+// ViewMigration renders a migration creating the materialized view named in
+// data.TableName
+func ViewMigration(data MigrationData) (File, error) {
+	content, err := render("create_view", data)
+	return File{
+		Path:    filepath.Join("internal", "migrations", fmt.Sprintf("%s_%s.go", data.Timestamp, ToSnakeCase(data.Description))),
+		Content: content,
+	}, err
+}
+
+// Entity renders an entity with its request and filter structs
+func Entity(data EntityData) (File, error) {
+	content, err := render("entity", data)
+	return File{
+		Path:    filepath.Join("internal", "entity", strings.ToLower(data.EntityName)+".go"),
+		Content: content,
+	}, err
+}
+
+// Enum renders a typed string enum in the entity package
+func Enum(data EnumData) (File, error) {
+	content, err := render("enum", data)
+	return File{
+		Path:    filepath.Join("internal", "entity", ToSnakeCase(data.TypeName)+".go"),
+		Content: content,
+	}, err
+}
+
+// Seeder renders a self-registering seeder
+func Seeder(data SeederData) (File, error) {
+	content, err := render("seeder", data)
+	return File{
+		Path:    filepath.Join("internal", "seeders", ToSnakeCase(data.ClassName)+".go"),
+		Content: content,
+	}, err
+}
+
+// Factory renders a factory making sample entities, for seeders and tests
+func Factory(data EntityData) (File, error) {
+	content, err := render("factory", data)
+	return File{
+		Path:    filepath.Join("internal", "factories", strings.ToLower(data.EntityName)+".go"),
+		Content: content,
+	}, err
+}
+
+// Request renders request and response DTOs into the package's dto.go. When
+// existing holds the current dto.go, they are added to it.
+func Request(data RequestData, existing []byte) (File, error) {
+	path := filepath.Join("internal", data.PackageName, "dto.go")
+	content, err := render("request", data)
+	if err != nil || existing == nil {
+		return File{Path: path, Content: content}, err
+	}
+
+	merged, err := appendDecls(existing, content)
+	return File{Path: path, Content: merged}, err
+}
+
+// Package renders the handler, port, repository and usecase of a package
+func Package(data PackageData) ([]File, error) {
+	stubs := []string{"handler", "port", "repository", "usecase"}
+
+	files := make([]File, 0, len(stubs))
+	for _, stub := range stubs {
+		name := stub
+		if data.CRUD {
+			name = "crud_" + stub
+		}
+		content, err := render(name, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{
+			Path:    filepath.Join("internal", data.PackageName, stub+".go"),
+			Content: content,
+		})
+	}
+
+	// CRUD repositories are served through their cache and retry decorators
+	if data.CRUD {
+		for _, decorate := range []func(File, string) (File, error){CacheDecorator, RetryDecorator} {
+			decorator, err := decorate(files[1], data.EntityName+"Repository")
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, decorator)
+		}
+	}
+	return files, nil
+}
+
+// Policy renders the entity's policy into the package
+func Policy(data PackageData) (File, error) {
+	content, err := render("policy", data)
+	return File{Path: filepath.Join("internal", data.PackageName, "policy.go"), Content: content}, err
+}
+
+// render executes a stub and gofmts the result
+func render(name string, data interface{}) ([]byte, error) {
+	text, err := readStub(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse stub %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return buf.Bytes(), fmt.Errorf("generated %s is not valid Go: %w", name, err)
+	}
+	return formatted, nil
+}
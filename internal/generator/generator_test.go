@@ -0,0 +1,858 @@
+package generator
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run `go test ./internal/generator -update` after intentional template changes
+var update = flag.Bool("update", false, "update golden files")
+
+const testTimestamp = "2024_01_15_120000"
+
+// Representative field set: every mapped type plus index and FK options
+const testFields = "name:string,description:text,price:decimal,stock:int,views:bigint,rating:float,is_active:bool," +
+	"external_id:uuid,published_at:timestamp,release_date:date,metadata:jsonb,sku:string|index,user_id:uuid|fk:users"
+
+// Relations of every kind, one to a table named with |fk
+const testRelations = "title:string,user:belongsTo=User,editor:belongsTo=User|fk:tb_accounts," +
+	"profile:hasOne=PostProfile,comments:hasMany=Comment,tags:manyToMany=Tag"
+
+// Nullable columns and defaults of several types
+const testModifiers = "title:string,phone:string:nullable,active:bool:default=true,status:string:default=draft," +
+	"score:int:nullable:default=0,published_at:timestamp:default=now,metadata:jsonb:nullable,editor:belongsTo=User:nullable"
+
+func TestGenerator_Golden(t *testing.T) {
+	fields := ParseFields(testFields)
+	relations := ParseFields(testRelations)
+	modifiers := ParseFields(testModifiers)
+
+	cases := []struct {
+		golden   string
+		path     string
+		generate func() ([]File, error)
+	}{
+		{
+			golden: "migration_create_table",
+			path:   "internal/migrations/2024_01_15_120000_create_products_table.go",
+			generate: single(func() (File, error) {
+				return Migration(NewMigrationData("create_products_table", "products", fields, testTimestamp), true)
+			}),
+		},
+		{
+			golden: "migration_relations",
+			path:   "internal/migrations/2024_01_15_120000_create_posts_table.go",
+			generate: single(func() (File, error) {
+				return Migration(NewMigrationData("create_posts_table", "tb_posts", relations, testTimestamp), true)
+			}),
+		},
+		{
+			golden: "migration_modifiers",
+			path:   "internal/migrations/2024_01_15_120000_create_articles_table.go",
+			generate: single(func() (File, error) {
+				return Migration(NewMigrationData("create_articles_table", "tb_articles", modifiers, testTimestamp), true)
+			}),
+		},
+		{
+			golden: "migration_geo",
+			path:   "internal/migrations/2024_01_15_120000_create_stores_table.go",
+			generate: single(func() (File, error) {
+				return Migration(NewMigrationData("create_stores_table", "tb_stores", ParseFields("name:string,latitude:latitude,longitude:longitude"), testTimestamp), true)
+			}),
+		},
+		{
+			golden: "migration_alter_table",
+			path:   "internal/migrations/2024_01_15_120000_add_phone_to_users.go",
+			generate: single(func() (File, error) {
+				return Migration(NewMigrationData("add_phone_to_users", "users", ParseFields("phone:string|index,verified:bool"), testTimestamp), false)
+			}),
+		},
+		{
+			golden: "migration_empty",
+			path:   "internal/migrations/2024_01_15_120000_backfill_slugs.go",
+			generate: single(func() (File, error) {
+				return Migration(NewMigrationData("backfill_slugs", "", nil, testTimestamp), false)
+			}),
+		},
+		{
+			golden: "migration_view",
+			path:   "internal/migrations/2024_01_15_120000_create_sales_per_day_view.go",
+			generate: single(func() (File, error) {
+				return ViewMigration(NewMigrationData("create_sales_per_day_view", "mv_sales_per_day", nil, testTimestamp))
+			}),
+		},
+		{
+			golden: "migration_sql",
+			path:   "internal/migrations/",
+			generate: func() ([]File, error) {
+				data := NewMigrationData("add_reporting_views", "", nil, testTimestamp)
+				return SQLMigration(data, []byte("CREATE VIEW sales AS SELECT 1;\n"), []byte("DROP VIEW sales;\n"))
+			},
+		},
+		{
+			golden: "migration_sql_no_down",
+			path:   "internal/migrations/",
+			generate: func() ([]File, error) {
+				data := NewMigrationData("add_reporting_views", "", nil, testTimestamp)
+				return SQLMigration(data, []byte("CREATE VIEW sales AS SELECT 1;\n"), []byte("\n"))
+			},
+		},
+		{
+			golden: "entity",
+			path:   "internal/entity/product.go",
+			generate: single(func() (File, error) {
+				return Entity(EntityData{EntityName: "Product", TableName: "tb_products", Fields: fields})
+			}),
+		},
+		{
+			golden: "entity_relations",
+			path:   "internal/entity/post.go",
+			generate: single(func() (File, error) {
+				return Entity(EntityData{EntityName: "Post", TableName: "tb_posts", Fields: relations})
+			}),
+		},
+		{
+			golden: "entity_modifiers",
+			path:   "internal/entity/article.go",
+			generate: single(func() (File, error) {
+				return Entity(EntityData{EntityName: "Article", TableName: "tb_articles", Fields: modifiers})
+			}),
+		},
+		{
+			golden: "enum",
+			path:   "internal/entity/order_status.go",
+			generate: single(func() (File, error) {
+				data, err := NewEnumData("OrderStatus", "pending, paid,shipped,in_transit")
+				if err != nil {
+					return File{}, err
+				}
+				return Enum(data)
+			}),
+		},
+		{
+			golden: "seeder",
+			path:   "internal/seeders/order_seeder.go",
+			generate: single(func() (File, error) {
+				return Seeder(NewSeederData("Order", "tb_orders", "User, ProductSeeder"))
+			}),
+		},
+		{
+			golden: "package",
+			path:   "internal/order",
+			generate: func() ([]File, error) {
+				return Package(PackageData{PackageName: "order", EntityName: "Order"})
+			},
+		},
+		{
+			golden: "package_relations",
+			path:   "internal/post",
+			generate: func() ([]File, error) {
+				return Package(PackageData{PackageName: "post", EntityName: "Post", Fields: relations})
+			},
+		},
+		{
+			golden: "package_crud",
+			path:   "internal/blogpost",
+			generate: func() ([]File, error) {
+				return Package(PackageData{PackageName: "blogpost", EntityName: "BlogPost", Fields: ParseFields("title:string,body:text,views:int,author:belongsTo=User"), CRUD: true})
+			},
+		},
+		{
+			golden: "request",
+			path:   "internal/article/dto.go",
+			generate: single(func() (File, error) {
+				return Request(NewRequestData("CreateArticleRequest", "", modifiers), nil)
+			}),
+		},
+		{
+			golden: "request_append",
+			path:   "internal/order/dto.go",
+			generate: single(func() (File, error) {
+				existing := []byte("package order\n\nimport (\n\t\"strings\"\n\n\t\"github.com/google/uuid\"\n)\n\n" +
+					"// Ref is kept\nvar Ref = strings.ToUpper(uuid.NewString())\n")
+				return Request(NewRequestData("UpdateOrder", "", ParseFields("ref:uuid,total:decimal,due:date:nullable")), existing)
+			}),
+		},
+		{
+			golden: "policy",
+			path:   "internal/post/policy.go",
+			generate: single(func() (File, error) {
+				return Policy(PackageData{PackageName: "post", EntityName: "Post", Fields: relations})
+			}),
+		},
+		{
+			golden: "policy_nullable_owner",
+			path:   "internal/article/policy.go",
+			generate: single(func() (File, error) {
+				return Policy(PackageData{PackageName: "article", EntityName: "Article", Fields: ParseFields("title:string,author:belongsTo=User:nullable")})
+			}),
+		},
+		{
+			golden: "policy_no_owner",
+			path:   "internal/blogpost/policy.go",
+			generate: single(func() (File, error) {
+				return Policy(PackageData{PackageName: "blogpost", EntityName: "BlogPost"})
+			}),
+		},
+		{
+			golden: "factory",
+			path:   "internal/factories/product.go",
+			generate: single(func() (File, error) {
+				return Factory(EntityData{EntityName: "Product", TableName: "tb_products", Fields: fields})
+			}),
+		},
+		{
+			golden: "seeder_factory",
+			path:   "internal/seeders/post_seeder.go",
+			generate: single(func() (File, error) {
+				data := NewSeederData("Post", "tb_posts", "")
+				data.Factory = &EntityData{EntityName: "Post", TableName: "tb_posts", Fields: relations}
+				return Seeder(data)
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.golden, func(t *testing.T) {
+			files, err := tc.generate()
+			require.NoError(t, err)
+			require.NotEmpty(t, files)
+
+			var combined strings.Builder
+			for _, file := range files {
+				assert.True(t, strings.HasPrefix(filepath.ToSlash(file.Path), tc.path), "unexpected path %s", file.Path)
+				if filepath.Ext(file.Path) == ".go" {
+					assertValidGo(t, file)
+				}
+
+				combined.WriteString("// ==== " + filepath.ToSlash(file.Path) + " ====\n")
+				combined.Write(file.Content)
+			}
+
+			assertGolden(t, tc.golden, combined.String())
+		})
+	}
+}
+
+func TestSQLMigration_EmptyUp(t *testing.T) {
+	_, err := SQLMigration(NewMigrationData("add_reporting_views", "", nil, testTimestamp), []byte(" \n"), nil)
+	assert.Error(t, err)
+}
+
+func TestWire(t *testing.T) {
+	content := []byte("package router\n\nfunc routes() {\n\tif true {\n\t\tfirst()\n\n\t\t// artisan:insert routes\n\t}\n}\n")
+
+	wired, err := Wire(content, "routes", "// Second\nsecond()\n")
+	require.NoError(t, err)
+	wired, err = Wire(wired, "routes", "third()")
+	require.NoError(t, err)
+	assert.Equal(t, "package router\n\nfunc routes() {\n\tif true {\n\t\tfirst()\n\n\t\t// Second\n\t\tsecond()\n\n\t\tthird()\n\t\t// artisan:insert routes\n\t}\n}\n", string(wired))
+
+	rewired, err := Wire(wired, "routes", "// Second\n  second()")
+	require.NoError(t, err)
+	assert.Equal(t, string(wired), string(rewired))
+
+	_, err = Wire(content, "fields", "x int")
+	assert.Error(t, err)
+}
+
+func TestPackageWiring_MarkersExist(t *testing.T) {
+	wirings := PackageWiring(PackageData{PackageName: "blogpost", EntityName: "BlogPost", CRUD: true})
+	assert.Equal(t, "/blog-posts", PackageData{EntityName: "BlogPost"}.Route())
+
+	contents := map[string][]byte{}
+	for _, wiring := range wirings {
+		if _, ok := contents[wiring.Path]; !ok {
+			content, err := os.ReadFile(filepath.Join("..", "..", wiring.Path))
+			if os.IsNotExist(err) {
+				t.Skipf("%s not in this tree", wiring.Path)
+			}
+			require.NoError(t, err)
+			contents[wiring.Path] = content
+		}
+
+		wired, err := Wire(contents[wiring.Path], wiring.Section, wiring.Code)
+		require.NoError(t, err, "%s: %s", wiring.Path, wiring.Section)
+		contents[wiring.Path] = wired
+	}
+}
+
+func TestUseStubs_OverridesPublishedStubsOnly(t *testing.T) {
+	UseStubs(fstest.MapFS{
+		"seeder.stub": {Data: []byte("package seeders\n\n// {{.ClassName}} is customized\ntype {{.ClassName}} struct{}\n")},
+	})
+	t.Cleanup(func() { UseStubs(nil) })
+
+	seeder, err := Seeder(NewSeederData("Product", "products", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "package seeders\n\n// ProductSeeder is customized\ntype ProductSeeder struct{}\n", string(seeder.Content))
+
+	// Stubs that were not published keep their defaults
+	enum, err := Enum(EnumData{TypeName: "Status", Values: []EnumValue{{ConstName: "StatusOpen", Value: "open"}}})
+	require.NoError(t, err)
+	assert.Contains(t, string(enum.Content), "func ParseStatus(")
+}
+
+func TestDefaultStubs(t *testing.T) {
+	files, err := DefaultStubs()
+	require.NoError(t, err)
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = filepath.ToSlash(file.Path)
+		assert.NotEmpty(t, file.Content)
+	}
+	assert.Contains(t, paths, "stubs/create_table.stub")
+	assert.Contains(t, paths, "stubs/usecase.stub")
+}
+
+func TestUseModule_ImportsFromModule(t *testing.T) {
+	UseModule("example.com/widgets")
+	t.Cleanup(func() { UseModule("") })
+
+	file, err := Entity(EntityData{EntityName: "Product", TableName: "tb_products"})
+	require.NoError(t, err)
+	assert.Contains(t, string(file.Content), `"example.com/widgets/pkg/pagination"`)
+	assert.NotContains(t, string(file.Content), DefaultModule)
+}
+
+func TestRewriteModule(t *testing.T) {
+	input := "module skeleton\n\n" +
+		"import \"skeleton/pkg/logger\"\n" +
+		"LDFLAGS := -X skeleton/pkg/version.Version=$(VERSION)\n" +
+		"git clone https://example.com/skeleton/archive\n" +
+		"docker build -t skeleton .\n"
+
+	expected := "module example.com/widgets\n\n" +
+		"import \"example.com/widgets/pkg/logger\"\n" +
+		"LDFLAGS := -X example.com/widgets/pkg/version.Version=$(VERSION)\n" +
+		"git clone https://example.com/skeleton/archive\n" +
+		"docker build -t skeleton .\n"
+
+	assert.Equal(t, expected, string(RewriteModule([]byte(input), "skeleton", "example.com/widgets")))
+}
+
+func TestCheckModulePath(t *testing.T) {
+	for _, path := range []string{"shop", "github.com/acme/shop", "example.com/a-b/c_d.v2"} {
+		assert.NoError(t, CheckModulePath(path), path)
+	}
+	for _, path := range []string{"", "/shop", "shop/", "github.com//shop", "../shop", "my shop"} {
+		assert.Error(t, CheckModulePath(path), path)
+	}
+}
+
+func TestResolveModules(t *testing.T) {
+	kept, err := ResolveModules([]string{"auth", "product", " Activity"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"activity", "reservation"}, kept)
+
+	all, err := ResolveModules(nil)
+	require.NoError(t, err)
+	assert.Len(t, all, len(Modules))
+
+	_, err = ResolveModules([]string{"billing"})
+	assert.Error(t, err)
+}
+
+func TestStripModules(t *testing.T) {
+	input := "package container\n\n" +
+		"import (\n" +
+		"\t\"example.com/shop/internal/report\" // artisan:module report\n" +
+		"\t\"example.com/shop/internal/reservation\" // artisan:module reservation\n" +
+		")\n\n" +
+		"// Mentions \"// artisan:module report\" without being one\n" +
+		"var x = 1\n\n" +
+		"func wire() {\n" +
+		"\t// artisan:module reservation\n" +
+		"\treservation.Wire()\n" +
+		"\t// artisan:module report\n" +
+		"\treport.Wire()\n" +
+		"\t// artisan:end\n" +
+		"\t// artisan:end\n" +
+		"\t// artisan:module report\n" +
+		"\treport.Wire()\n" +
+		"\t// artisan:end\n" +
+		"}\n"
+
+	expected := "package container\n\n" +
+		"import (\n" +
+		"\t\"example.com/shop/internal/reservation\"\n" +
+		")\n\n" +
+		"// Mentions \"// artisan:module report\" without being one\n" +
+		"var x = 1\n\n" +
+		"func wire() {\n" +
+		"\treservation.Wire()\n" +
+		"}\n"
+
+	out, err := StripModules([]byte(input), func(name string) bool { return name == "reservation" })
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+
+	_, err = StripModules([]byte("package x\n// artisan:module report\n"), func(string) bool { return true })
+	assert.Error(t, err)
+}
+
+func TestModules_PathsExist(t *testing.T) {
+	root := filepath.Join("..", "..")
+	for _, module := range Modules {
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(module.Paths[0]))); os.IsNotExist(err) {
+			continue // left out of this project by artisan new
+		}
+		for _, path := range module.Paths {
+			_, err := os.Stat(filepath.Join(root, filepath.FromSlash(path)))
+			assert.NoError(t, err, "%s: %s", module.Name, path)
+		}
+		for _, required := range module.Requires {
+			_, ok := FindModule(required)
+			assert.True(t, ok, "%s requires unknown module %s", module.Name, required)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields := ParseFields("title:string, user_id:uuid|fk:tb_users|index ,invalid")
+
+	require.Len(t, fields, 2)
+	assert.Equal(t, Field{Name: "title", Type: "string"}, fields[0])
+	assert.Equal(t, Field{Name: "user_id", Type: "uuid", HasIndex: true, IsForeignKey: true, FKReference: "tb_users"}, fields[1])
+}
+
+func TestParseFields_Relations(t *testing.T) {
+	fields := ParseFields("user:belongsTo=User, tags:manytomany=tag,owner_id:belongsTo=User|fk:tb_accounts,x:likes=User,y:hasMany=")
+
+	require.Len(t, fields, 3)
+	assert.Equal(t, Field{Name: "user_id", Type: "uuid", HasIndex: true, Relation: RelationBelongsTo, Related: "User"}, fields[0])
+	assert.Equal(t, Field{Name: "tags", Relation: RelationManyToMany, Related: "Tag"}, fields[1])
+	assert.Equal(t, Field{Name: "owner_id", Type: "uuid", HasIndex: true, Relation: RelationBelongsTo, Related: "User", FKReference: "tb_accounts"}, fields[2])
+	assert.Equal(t, "Owner", fields[2].Association())
+}
+
+func TestParseFields_Modifiers(t *testing.T) {
+	fields := ParseFields("phone:string:nullable|index,active:bool:default=true,note:text:Nullable:default=it's," +
+		"stock:int:default=many,flag:bool:default=yes please,tag:string:unique,data:json:default={}")
+
+	require.Len(t, fields, 3)
+	assert.Equal(t, Field{Name: "phone", Type: "string", Nullable: true, HasIndex: true}, fields[0])
+	assert.Equal(t, Field{Name: "active", Type: "bool", Default: "true", HasDefault: true}, fields[1])
+	assert.Equal(t, Field{Name: "note", Type: "text", Nullable: true, Default: "it's", HasDefault: true}, fields[2])
+
+	assert.Equal(t, "*string", goFieldType(fields[0]))
+	assert.Equal(t, "index", getGormTag(fields[0]))
+	assert.Equal(t, "omitempty,min=1,max=255", getFieldValidationTag(fields[0]))
+	assert.Equal(t, "default:true", getGormTag(fields[1]))
+	assert.Equal(t, "type:text;default:'it''s'", getGormTag(fields[2]))
+}
+
+func TestNewRequestData_Package(t *testing.T) {
+	cases := map[string]string{
+		"CreateOrder":            "order",
+		"UpdateOrderItemRequest": "orderitem",
+		"Login":                  "login",
+		"create_blog_post":       "blogpost",
+	}
+
+	for name, pkg := range cases {
+		assert.Equal(t, pkg, NewRequestData(name, "", nil).PackageName, name)
+	}
+	assert.Equal(t, "auth", NewRequestData("Login", "Auth", nil).PackageName)
+	assert.Equal(t, "UpdateOrderItem", NewRequestData("UpdateOrderItemRequest", "", nil).Name)
+}
+
+func TestRequest_AlreadyDeclared(t *testing.T) {
+	data := NewRequestData("CreateOrder", "", ParseFields("note:string"))
+	file, err := Request(data, nil)
+	require.NoError(t, err)
+
+	_, err = Request(data, file.Content)
+	assert.ErrorContains(t, err, "CreateOrderRequest is already declared")
+
+	_, err = Request(data, []byte("package shop\n"))
+	assert.Error(t, err)
+}
+
+func TestNewEnumData_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"empty":     " , ",
+		"duplicate": "paid,paid",
+		"uppercase": "Paid",
+		"space":     "on hold",
+	}
+
+	for name, values := range cases {
+		_, err := NewEnumData("OrderStatus", values)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestGetStructName(t *testing.T) {
+	cases := map[string]string{
+		"tb_users":    "User",
+		"categories":  "Category",
+		"boxes":       "Box",
+		"order_items": "OrderItem",
+		"address":     "Address",
+		"addresses":   "Address",
+		"tb_articles": "Article",
+	}
+
+	for table, expected := range cases {
+		assert.Equal(t, expected, GetStructName(table), table)
+	}
+}
+
+func single(generate func() (File, error)) func() ([]File, error) {
+	return func() ([]File, error) {
+		file, err := generate()
+		if err != nil {
+			return nil, err
+		}
+		return []File{file}, nil
+	}
+}
+
+// assertValidGo parses the file and checks every import is referenced,
+// the most common way a template produces code that doesn't compile
+func assertValidGo(t *testing.T, file File) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file.Path, file.Content, 0)
+	require.NoError(t, err, "generated %s does not parse:\n%s", file.Path, file.Content)
+
+	used := map[string]bool{}
+	ast.Inspect(parsed, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	for _, spec := range parsed.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" {
+			continue
+		}
+		assert.True(t, used[name], "%s imports %s but never uses it", file.Path, path)
+	}
+}
+
+func assertGolden(t *testing.T, name, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0755))
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file, run with -update")
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nm\nn\n"
+
+	assert.Equal(t, `--- x.go
++++ x.go
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -9,5 +9,5 @@
+ i
+ j
+ k
+-l
+ m
++n
+`, Diff("x.go", []byte(before), []byte(after)))
+
+	assert.Empty(t, Diff("x.go", []byte(before), []byte(before)))
+	assert.Equal(t, "--- x.go\n+++ x.go\n@@ -0,0 +1,2 @@\n+a\n+b\n", Diff("x.go", nil, []byte("a\nb\n")))
+}
+
+func TestInflection(t *testing.T) {
+	cases := map[string]string{
+		"user":         "users",
+		"category":     "categories",
+		"day":          "days",
+		"person":       "people",
+		"child":        "children",
+		"status":       "statuses",
+		"bus":          "buses",
+		"address":      "addresses",
+		"box":          "boxes",
+		"dish":         "dishes",
+		"match":        "matches",
+		"cache":        "caches",
+		"house":        "houses",
+		"size":         "sizes",
+		"quiz":         "quizzes",
+		"leaf":         "leaves",
+		"hero":         "heroes",
+		"photo":        "photos",
+		"movie":        "movies",
+		"analysis":     "analyses",
+		"article":      "articles",
+		"response":     "responses",
+		"equipment":    "equipment",
+		"news":         "news",
+		"order_item":   "order_items",
+		"sales_person": "sales_people",
+		"BlogPost":     "BlogPosts",
+		"Person":       "People",
+		"blog-post":    "blog-posts",
+	}
+
+	for singular, plural := range cases {
+		assert.Equal(t, plural, Pluralize(singular), "Pluralize(%q)", singular)
+		assert.Equal(t, singular, Singularize(plural), "Singularize(%q)", plural)
+		assert.Equal(t, singular, Singularize(singular), "Singularize(%q)", singular)
+	}
+}
+
+// Every mapping of the TypeScript generator: enums, embedding, pointers,
+// omitempty, external types and the types it leaves out
+const testTypeScriptSource = `package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"go-clean-gin/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderStatus is a string enum
+type OrderStatus string
+
+const (
+	OrderStatusPending OrderStatus = "pending"
+	OrderStatusPaid    OrderStatus = "paid"
+	OrderStatusShipped             = OrderStatus("shipped")
+)
+
+type Priority int
+
+const (
+	PriorityLow  Priority = 1
+	PriorityHigh Priority = 2
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+)
+
+type Timestamps struct {
+	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
+	UpdatedAt time.Time ` + "`json:\"updated_at\"`" + `
+}
+
+// Order is placed by a customer.
+// It ships once paid.
+type Order struct {
+	ID        uuid.UUID         ` + "`json:\"id\"`" + `
+	Status    OrderStatus       ` + "`json:\"status\"`" + `
+	Priority  *Priority         ` + "`json:\"priority\"`" + `
+	Total     money.Money       ` + "`json:\"total\"`" + `
+	Note      *string           ` + "`json:\"note,omitempty\"`" + ` // shown to the courier
+	Count     int64             ` + "`json:\"count,string\"`" + `
+	Tags      []string          ` + "`json:\"tags\"`" + `
+	Lines     []*OrderLine      ` + "`json:\"lines\"`" + `
+	Meta      map[string]any    ` + "`json:\"meta\"`" + `
+	Raw       json.RawMessage   ` + "`json:\"raw\"`" + `
+	Signature []byte            ` + "`json:\"signature\"`" + `
+	Address   struct {
+		City string ` + "`json:\"city\"`" + `
+	} ` + "`json:\"address\"`" + `
+	Payload   Payload           ` + "`json:\"payload\"`" + `
+	Secret    string            ` + "`json:\"-\"`" + `
+	DeletedAt gorm.DeletedAt    ` + "`json:\"-\"`" + `
+	internal  string
+	Timestamps
+}
+
+type OrderLine struct {
+	SKU      string ` + "`json:\"sku\"`" + `
+	Quantity int    ` + "`json:\"quantity\"`" + `
+}
+
+type OrderLines []OrderLine
+
+// Payload marshals itself
+type Payload []byte
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	return p, nil
+}
+
+type OrderFilter struct {
+	Status string ` + "`form:\"status\"`" + `
+}
+
+type Shippable interface {
+	Ship() error
+}
+`
+
+func TestTypeScript_Golden(t *testing.T) {
+	content, err := TypeScript([]File{{Path: "order.go", Content: []byte(testTypeScriptSource)}})
+	require.NoError(t, err)
+
+	assertGolden(t, "typescript", string(content))
+}
+
+func TestTypeScript_DuplicateType(t *testing.T) {
+	source := []byte("package entity\n\ntype Order struct{}\n")
+	_, err := TypeScript([]File{{Path: "a.go", Content: source}, {Path: "b.go", Content: source}})
+	assert.ErrorContains(t, err, "type Order is declared twice")
+}
+
+func TestProto_Golden(t *testing.T) {
+	numbers := &ProtoNumbers{}
+	content, err := Proto([]File{{Path: "order.go", Content: []byte(testTypeScriptSource)}}, numbers, ProtoOptions{
+		Package:   "entity.v1",
+		GoPackage: "go-clean-gin/gen/proto/entity/v1;entityv1",
+	})
+	require.NoError(t, err)
+
+	assertGolden(t, "proto", string(content))
+	assert.Equal(t, map[string]int{"sku": 1, "quantity": 2}, numbers.Messages["OrderLine"])
+	assert.Equal(t, map[string]int{"city": 1}, numbers.Messages["Order.Address"])
+}
+
+func TestProto_NumbersStayStable(t *testing.T) {
+	before := "package entity\n\ntype Status string\n\nconst (\n\tStatusDraft Status = \"draft\"\n\tStatusLive Status = \"live\"\n)\n\n" +
+		"type Post struct {\n\tID string `json:\"id\"`\n\tTitle string `json:\"title\"`\n\tBody string `json:\"body\"`\n}\n"
+	after := "package entity\n\ntype Status string\n\nconst (\n\tStatusLive Status = \"live\"\n\tStatusArchived Status = \"archived\"\n)\n\n" +
+		"type Post struct {\n\tSlug string `json:\"slug\"`\n\tID string `json:\"id\"`\n\tBody string `json:\"body\"`\n}\n"
+
+	numbers := &ProtoNumbers{}
+	_, err := Proto([]File{{Path: "post.go", Content: []byte(before)}}, numbers, ProtoOptions{Package: "entity.v1"})
+	require.NoError(t, err)
+
+	content, err := Proto([]File{{Path: "post.go", Content: []byte(after)}}, numbers, ProtoOptions{Package: "entity.v1"})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "message Post {\n  string slug = 4;\n  string id = 1;\n  string body = 3;\n  reserved 2;\n  reserved \"title\";\n}\n")
+	assert.Contains(t, string(content), "enum Status {\n  STATUS_UNSPECIFIED = 0;\n  STATUS_LIVE = 2;\n  STATUS_ARCHIVED = 3;\n  reserved 1;\n  reserved \"STATUS_DRAFT\";\n}\n")
+}
+
+const testCachePort = `package order
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// Unmarked interfaces get no decorator
+type OrderUsecase interface {
+	Place(ctx context.Context, order *entity.Order) error
+}
+
+type OrderRepository interface {
+	//cache:read
+	Find(ctx context.Context, key string, ids ...uuid.UUID) ([]*entity.Order, int64, error)
+	//cache:invalidate
+	Cancel(ctx context.Context, id uuid.UUID)
+	//cache:invalidate
+	Save(ctx context.Context, order *entity.Order) (int64, error)
+	Each(ctx context.Context, fn func(*entity.Order) error) error
+}
+`
+
+func TestCacheDecorator_Golden(t *testing.T) {
+	port := File{Path: "internal/order/port.go", Content: []byte(testCachePort)}
+
+	names, err := CachedInterfaces(port)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OrderRepository"}, names)
+
+	file, err := CacheDecorator(port, "OrderRepository")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("internal", "order", "cached_order_repository.go"), file.Path)
+	assertValidGo(t, file)
+	assertGolden(t, "cache_decorator", string(file.Content))
+}
+
+func TestCacheDecorator_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"no error":          "//cache:read\n\tGet(ctx context.Context, id string) string",
+		"no results":        "//cache:read\n\tGet(ctx context.Context, id string) error",
+		"no context":        "//cache:read\n\tGet(id string) (string, error)",
+		"callback argument": "//cache:read\n\tGet(ctx context.Context, fn func()) (string, error)",
+	}
+	for name, method := range cases {
+		t.Run(name, func(t *testing.T) {
+			port := File{Path: "port.go", Content: []byte("package order\n\nimport \"context\"\n\ntype Repo interface {\n\t" + method + "\n}\n")}
+			_, err := CacheDecorator(port, "Repo")
+			assert.Error(t, err)
+		})
+	}
+}
+
+const testRetryPort = `package order
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type OrderRepository interface {
+	Save(ctx context.Context, order *entity.Order) error
+	//cache:read
+	Find(ctx context.Context, key string, ids ...uuid.UUID) ([]*entity.Order, int64, error)
+	//retry:idempotent
+	MarkShipped(ctx context.Context, c uuid.UUID) (int64, error)
+	Each(ctx context.Context, fn func(*entity.Order) error) error
+	Count(key string) (int64, error)
+	Close()
+}
+`
+
+func TestRetryDecorator_Golden(t *testing.T) {
+	port := File{Path: "internal/order/port.go", Content: []byte(testRetryPort)}
+
+	names, err := Interfaces(port)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OrderRepository"}, names)
+
+	file, err := RetryDecorator(port, "OrderRepository")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("internal", "order", "retrying_order_repository.go"), file.Path)
+	assertValidGo(t, file)
+	assertGolden(t, "retry_decorator", string(file.Content))
+}
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+)
+
+// goDecl is an exported type declaration of the scanned sources
+type goDecl struct {
+	Name string
+	Doc  string
+	Expr ast.Expr
+}
+
+// goConst is a constant of a declared type, with its value unquoted
+type goConst struct {
+	Name     string
+	Value    string
+	IsString bool
+}
+
+// goTypes are the exported types of Go sources, as the type generators read
+// them
+type goTypes struct {
+	decls      []goDecl
+	declared   map[string]goDecl
+	consts     map[string][]goConst // enum values by type
+	marshalers map[string]bool      // types with their own MarshalJSON
+}
+
+// parseGoTypes collects the exported types, enum constants and JSON
+// marshalers of the sources
+func parseGoTypes(sources []File) (*goTypes, error) {
+	types := &goTypes{
+		declared:   make(map[string]goDecl),
+		consts:     make(map[string][]goConst),
+		marshalers: make(map[string]bool),
+	}
+
+	fset := token.NewFileSet()
+	for _, source := range sources {
+		file, err := parser.ParseFile(fset, source.Path, source.Content, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if err := types.collect(file); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+func (t *goTypes) collect(file *ast.File) error {
+	for _, d := range file.Decls {
+		switch decl := d.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil && decl.Name.Name == "MarshalJSON" {
+				t.marshalers[receiverName(decl.Recv.List[0].Type)] = true
+			}
+
+		case *ast.GenDecl:
+			switch decl.Tok {
+			case token.TYPE:
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					if !spec.Name.IsExported() || spec.TypeParams != nil {
+						continue
+					}
+					if _, ok := t.declared[spec.Name.Name]; ok {
+						return fmt.Errorf("type %s is declared twice", spec.Name.Name)
+					}
+					doc := spec.Doc
+					if doc == nil && len(decl.Specs) == 1 {
+						doc = decl.Doc
+					}
+					goDecl := goDecl{Name: spec.Name.Name, Doc: doc.Text(), Expr: spec.Type}
+					t.declared[goDecl.Name] = goDecl
+					t.decls = append(t.decls, goDecl)
+				}
+
+			case token.CONST:
+				for _, spec := range decl.Specs {
+					t.collectConsts(spec.(*ast.ValueSpec))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// collectConsts records constants declared with a type, `A Status = "a"`, or
+// converted to one, `A = Status("a")`. Values that are not literals, such as
+// iota, are skipped.
+func (t *goTypes) collectConsts(spec *ast.ValueSpec) {
+	for i, value := range spec.Values {
+		if i >= len(spec.Names) {
+			break
+		}
+		typeName := ""
+		if ident, ok := spec.Type.(*ast.Ident); ok {
+			typeName = ident.Name
+		}
+		if call, ok := value.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if ident, ok := call.Fun.(*ast.Ident); ok {
+				typeName = ident.Name
+				value = call.Args[0]
+			}
+		}
+
+		lit, ok := value.(*ast.BasicLit)
+		if typeName == "" || !ok {
+			continue
+		}
+		switch lit.Kind {
+		case token.STRING:
+			if s, err := strconv.Unquote(lit.Value); err == nil {
+				t.consts[typeName] = append(t.consts[typeName], goConst{Name: spec.Names[i].Name, Value: s, IsString: true})
+			}
+		case token.INT, token.FLOAT:
+			t.consts[typeName] = append(t.consts[typeName], goConst{Name: spec.Names[i].Name, Value: lit.Value})
+		}
+	}
+}
+
+// isData reports whether a declaration is data serialized as JSON:
+// interfaces are Go behavior, and structs without json tags are not
+// serialized as JSON
+func (t *goTypes) isData(decl goDecl) bool {
+	if t.marshalers[decl.Name] {
+		return true
+	}
+	switch expr := decl.Expr.(type) {
+	case *ast.InterfaceType:
+		return false
+	case *ast.StructType:
+		for _, field := range expr.Fields.List {
+			if jsonTag(field) != "" {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// jsonTag returns the json struct tag of a field
+func jsonTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tag).Get("json")
+}
+
+// receiverName returns the type name of a method receiver
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+// internal/generator/inflect.go - English plurals for table, route and variable names
+package generator
+
+import (
+	"strings"
+	"unicode"
+)
+
+// uncountables are the same in the singular and the plural
+var uncountables = map[string]bool{
+	"audio": true, "data": true, "deer": true, "equipment": true, "feedback": true, "fish": true,
+	"information": true, "inventory": true, "metadata": true, "money": true, "news": true,
+	"police": true, "rice": true, "series": true, "sheep": true, "software": true, "species": true,
+	"staff": true,
+}
+
+// irregulars maps singulars to the plurals the suffix rules get wrong one way
+// or the other, like tie, which isn't ty
+var irregulars = map[string]string{
+	"analysis": "analyses", "axis": "axes", "cache": "caches", "calf": "calves", "child": "children",
+	"cookie": "cookies", "crisis": "crises", "diagnosis": "diagnoses", "echo": "echoes",
+	"foot": "feet", "goose": "geese", "half": "halves", "hero": "heroes", "knife": "knives",
+	"leaf": "leaves", "life": "lives", "loaf": "loaves", "man": "men", "mouse": "mice",
+	"movie": "movies", "niche": "niches", "ox": "oxen", "person": "people", "potato": "potatoes", "quiz": "quizzes",
+	"shelf": "shelves", "thesis": "theses", "thief": "thieves", "tie": "ties", "tomato": "tomatoes",
+	"tooth": "teeth", "veto": "vetoes", "wife": "wives", "wolf": "wolves", "woman": "women",
+	"zombie": "zombies",
+}
+
+// irregularSingulars is irregulars the other way round
+var irregularSingulars = func() map[string]string {
+	singulars := make(map[string]string, len(irregulars))
+	for singular, plural := range irregulars {
+		singulars[plural] = singular
+	}
+	return singulars
+}()
+
+// Pluralize returns the plural of a name, inflecting its last word and
+// keeping its case and separators: category → categories, BlogPost →
+// BlogPosts, sales_person → sales_people
+func Pluralize(name string) string {
+	return inflectLastWord(name, pluralizeWord)
+}
+
+// Singularize returns the singular of a name, the reverse of Pluralize:
+// categories → category, order_items → order_item, People → Person
+func Singularize(name string) string {
+	return inflectLastWord(name, singularizeWord)
+}
+
+// inflectLastWord applies inflect to the lowercased last word of a snake_case,
+// kebab-case or PascalCase name
+func inflectLastWord(name string, inflect func(string) string) string {
+	start := strings.LastIndexAny(name, "_- ") + 1
+	for i := len(name) - 1; i > start; i-- {
+		if unicode.IsUpper(rune(name[i])) && !unicode.IsUpper(rune(name[i-1])) {
+			start = i
+			break
+		}
+	}
+
+	prefix, word := name[:start], name[start:]
+	if word == "" {
+		return name
+	}
+	inflected := inflect(strings.ToLower(word))
+	switch {
+	case len(word) > 1 && word == strings.ToUpper(word):
+		inflected = strings.ToUpper(inflected)
+	case unicode.IsUpper(rune(word[0])):
+		inflected = strings.ToUpper(inflected[:1]) + inflected[1:]
+	}
+	return prefix + inflected
+}
+
+func pluralizeWord(word string) string {
+	if uncountables[word] {
+		return word
+	}
+	if plural, ok := irregulars[word]; ok {
+		return plural
+	}
+	if _, ok := irregularSingulars[word]; ok {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !isVowel(word[len(word)-2]):
+		// category -> categories
+		return strings.TrimSuffix(word, "y") + "ies"
+	case hasAnySuffix(word, "s", "x", "z", "ch", "sh"):
+		// status -> statuses, box -> boxes, dish -> dishes
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+func singularizeWord(word string) string {
+	if uncountables[word] {
+		return word
+	}
+	if singular, ok := irregularSingulars[word]; ok {
+		return singular
+	}
+	if _, ok := irregulars[word]; ok {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		// categories -> category
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "es") && hasEsPlural(strings.TrimSuffix(word, "es")):
+		// addresses -> address, statuses -> status, boxes -> box (but not articles -> articl)
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "is"), isLatinUs(word):
+		// address, analysis and status are singular already
+		return word
+	case strings.HasSuffix(word, "s") && len(word) > 1:
+		// users -> user
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// hasEsPlural reports whether a singular takes -es rather than -s
+func hasEsPlural(singular string) bool {
+	return hasAnySuffix(singular, "ss", "x", "zz", "ch", "sh") || isLatinUs(singular)
+}
+
+// isLatinUs reports whether the word ends in the -us of status and bus,
+// rather than a word ending in -use, like house
+func isLatinUs(word string) bool {
+	return strings.HasSuffix(word, "us") && len(word) > 2 && !isVowel(word[len(word)-3])
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+func hasAnySuffix(word string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
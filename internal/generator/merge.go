@@ -0,0 +1,171 @@
+// internal/generator/merge.go - Add generated declarations to an existing file
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// appendDecls adds the declarations of generated to the end of existing and
+// the imports it lacks to their group: the standard library, this module or
+// third-party. Both must be files of the same package, and a type or function
+// existing already declares is an error.
+func appendDecls(existing, generated []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	current, err := parser.ParseFile(fset, "existing.go", existing, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	added, err := parser.ParseFile(fset, "generated.go", generated, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if current.Name.Name != added.Name.Name {
+		return nil, fmt.Errorf("file is package %s, not %s", current.Name.Name, added.Name.Name)
+	}
+
+	declared := map[string]bool{}
+	for _, name := range declaredNames(current) {
+		declared[name] = true
+	}
+	for _, name := range declaredNames(added) {
+		if declared[name] {
+			return nil, fmt.Errorf("%s is already declared", name)
+		}
+	}
+
+	// What follows the generated imports is added as it is, comments and all
+	body := generated[offset(fset, added.Name.End()):]
+	for _, decl := range added.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			body = generated[offset(fset, gen.End()):]
+		}
+	}
+
+	out := addImports(fset, current, existing, added.Imports)
+	out = append([]byte(strings.TrimRight(string(out), "\n")+"\n"), body...)
+	return format.Source(out)
+}
+
+// declaredNames are the top-level types and functions of a file in order, with
+// methods as Type.Method
+func declaredNames(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) == 1 {
+				recv := decl.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					name = ident.Name + "." + name
+				}
+			}
+			names = append(names, name)
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok {
+					names = append(names, spec.Name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// addImports inserts the imports file lacks after the last of its imports in
+// the same group, or as a new group before the groups that follow it
+func addImports(fset *token.FileSet, file *ast.File, src []byte, imports []*ast.ImportSpec) []byte {
+	have := map[string]bool{}
+	first, last := map[int]token.Pos{}, map[int]token.Pos{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		have[path] = true
+		if !first[importGroup(path)].IsValid() {
+			first[importGroup(path)] = spec.Pos()
+		}
+		last[importGroup(path)] = spec.End()
+	}
+
+	missing := map[int][]string{}
+	for _, spec := range imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if !have[path] {
+			missing[importGroup(path)] = append(missing[importGroup(path)], spec.Path.Value)
+		}
+	}
+	if len(missing) == 0 {
+		return src
+	}
+
+	var block *ast.GenDecl
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Lparen.IsValid() {
+			block = gen
+			break
+		}
+	}
+
+	type insertion struct {
+		at   int
+		text string
+	}
+	var insertions []insertion
+	var groups []int
+	for group := range missing {
+		groups = append(groups, group)
+	}
+	sort.Ints(groups)
+	for _, group := range groups {
+		paths := missing[group]
+		next := token.NoPos
+		for later := group + 1; later <= 2 && !next.IsValid(); later++ {
+			next = first[later]
+		}
+		switch {
+		case last[group].IsValid():
+			insertions = append(insertions, insertion{offset(fset, last[group]), "\n\t" + strings.Join(paths, "\n\t")})
+		case block != nil && next.IsValid():
+			at := offset(fset, next)
+			insertions = append(insertions, insertion{at, strings.Join(paths, "\n\t") + "\n\n\t"})
+		case block != nil:
+			insertions = append(insertions, insertion{offset(fset, block.Rparen), "\n\t" + strings.Join(paths, "\n\t") + "\n"})
+		default:
+			insertions = append(insertions, insertion{offset(fset, file.Name.End()), "\n\nimport (\n\t" + strings.Join(paths, "\n\t") + "\n)"})
+		}
+	}
+
+	// Insert from the end so earlier offsets stay valid
+	sort.SliceStable(insertions, func(i, j int) bool { return insertions[i].at > insertions[j].at })
+	out := string(src)
+	for _, ins := range insertions {
+		out = out[:ins.at] + ins.text + out[ins.at:]
+	}
+	return []byte(out)
+}
+
+// importGroup orders imports as the repo does: the standard library, then
+// this module, then third-party packages
+func importGroup(path string) int {
+	switch {
+	case path == modulePath || strings.HasPrefix(path, modulePath+"/"):
+		return 1
+	case !strings.Contains(strings.SplitN(path, "/", 2)[0], "."):
+		return 0
+	default:
+		return 2
+	}
+}
+
+func offset(fset *token.FileSet, pos token.Pos) int {
+	return fset.Position(pos).Offset
+}
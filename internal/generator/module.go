@@ -0,0 +1,55 @@
+// internal/generator/module.go - Module path of the generated imports
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultModule is the skeleton's own module path
+const DefaultModule = "go-clean-gin"
+
+// modulePath starts the imports of the project's packages in generated files
+var modulePath = DefaultModule
+
+// UseModule sets the module path generated files import the project's
+// packages from, normally the one in go.mod. Empty restores DefaultModule.
+func UseModule(path string) {
+	if path == "" {
+		path = DefaultModule
+	}
+	modulePath = path
+}
+
+var modulePathPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*(/[A-Za-z0-9._~-]+)*$`)
+
+// CheckModulePath rejects what go.mod would not accept as a module path
+func CheckModulePath(path string) error {
+	if !modulePathPattern.MatchString(path) || strings.Contains(path, "..") || strings.HasSuffix(path, ".") {
+		return fmt.Errorf("invalid module path %q, use e.g. github.com/acme/shop", path)
+	}
+	return nil
+}
+
+// importPrefix matches the module path where it starts a package path, as in
+// a quoted import or a -X linker flag. Occurrences inside longer paths, such
+// as a repository URL, and bare names, such as a Docker image, are left alone.
+func importPrefix(path string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[\s"'=])` + regexp.QuoteMeta(path) + `(/|")`)
+}
+
+// RewriteModule replaces the module path in go.mod, Go sources and build files
+// such as a Makefile, for copying the skeleton under a new module path
+func RewriteModule(content []byte, from, to string) []byte {
+	pattern := importPrefix(from)
+	lines := strings.SplitAfter(string(content), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "module "+from {
+			lines[i] = strings.Replace(line, from, to, 1)
+			continue
+		}
+		lines[i] = pattern.ReplaceAllString(line, "${1}"+to+"${2}")
+	}
+	return []byte(strings.Join(lines, ""))
+}
@@ -0,0 +1,278 @@
+// internal/generator/modules.go - Optional features artisan new can leave out
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Module is an optional feature of the skeleton. Leaving it out of a new
+// project deletes the paths it owns and the code marked with its name:
+//
+//	// artisan:module reservation
+//	reservationRepo := reservation.NewReservationRepository(db)
+//	// artisan:end
+//
+// or, for a single line, a trailing "// artisan:module reservation".
+type Module struct {
+	Name        string
+	Description string
+	// Requires are the optional modules this one builds on
+	Requires []string
+	// Paths are the files and directories it owns, relative to the root
+	Paths []string
+}
+
+// CoreModules are the features every project keeps, since the others build
+// on them. They may be named in a selection but can't be left out.
+var CoreModules = []string{"auth", "account", "audit", "consent", "organization", "product", "quota", "savedsearch", "setting"}
+
+// Modules are the optional features, kept in sync with the markers in the code
+var Modules = []Module{
+	{
+		Name:        "activity",
+		Description: "Activity feeds of users and products",
+		Requires:    []string{"reservation"},
+		Paths: []string{
+			"internal/activity",
+			"internal/entity/activity.go",
+			"internal/migrations/2026_10_17_030000_create_activities_table.go",
+		},
+	},
+	{
+		Name:        "admin",
+		Description: "Admin dashboard",
+		Paths:       []string{"internal/admin", "internal/entity/admin.go"},
+	},
+	{
+		Name:        "avatar",
+		Description: "User avatars with thumbnails",
+		Paths:       []string{"internal/avatar"},
+	},
+	{
+		Name:        "export",
+		Description: "Background CSV exports",
+		Paths: []string{
+			"internal/export",
+			"internal/migrations/2026_10_17_050000_create_exports_table.go",
+		},
+	},
+	{
+		Name:        "imports",
+		Description: "Background CSV imports of products",
+		Paths: []string{
+			"internal/imports",
+			"internal/migrations/2026_10_17_060000_create_imports_table.go",
+		},
+	},
+	{
+		Name:        "invitation",
+		Description: "Organization invitations",
+		Paths:       []string{"internal/invitation"},
+	},
+	{
+		Name:        "notification",
+		Description: "In-app notifications",
+		Paths: []string{
+			"internal/notification",
+			"internal/migrations/2026_10_16_100000_create_notifications_table.go",
+		},
+	},
+	{
+		Name:        "oidc",
+		Description: "OpenID Connect provider",
+		Paths: []string{
+			"internal/oidc",
+			"internal/entity/oidc.go",
+			"internal/migrations/2026_10_16_210000_create_oidc_authorization_codes_table.go",
+		},
+	},
+	{
+		Name:        "productimage",
+		Description: "Product images with WebP variants",
+		Paths: []string{
+			"internal/productimage",
+			"internal/entity/product_image.go",
+			"internal/migrations/2026_10_17_070000_create_product_images_table.go",
+		},
+	},
+	{
+		Name:        "report",
+		Description: "Admin reports",
+		Paths: []string{
+			"internal/report",
+			"internal/entity/report.go",
+			"internal/migrations/2026_10_17_130000_create_products_by_category_view.go",
+		},
+	},
+	{
+		Name:        "reservation",
+		Description: "Stock reservations",
+		Paths: []string{
+			"internal/reservation",
+			"internal/entity/reservation.go",
+			"internal/migrations/2026_10_16_130000_create_reservations_table.go",
+			"internal/migrations/2026_10_17_150000_create_reservations_archive_table.go",
+		},
+	},
+	{
+		Name:        "scim",
+		Description: "SCIM provisioning",
+		Paths:       []string{"internal/scim", "internal/entity/scim.go"},
+	},
+	{
+		Name:        "sso",
+		Description: "Single sign-on with external identity providers",
+		Paths: []string{
+			"internal/sso",
+			"internal/entity/sso.go",
+			"internal/migrations/2026_10_16_220000_create_sso_logins_table.go",
+			"internal/migrations/2026_10_17_160000_create_sso_identities_table.go",
+		},
+	},
+	{
+		Name:        "store",
+		Description: "Example stores searched by distance",
+		Paths: []string{
+			"internal/store",
+			"internal/entity/store.go",
+			"internal/factories/store.go",
+			"internal/seeders/store_seeder.go",
+			"internal/migrations/2026_10_17_110000_create_stores_table.go",
+		},
+	},
+}
+
+// FindModule returns the optional module with the name
+func FindModule(name string) (Module, bool) {
+	for _, module := range Modules {
+		if module.Name == name {
+			return module, true
+		}
+	}
+	return Module{}, false
+}
+
+// ResolveModules returns the optional modules to keep for a selection, with
+// the ones they require, sorted. Core modules may be named and are skipped;
+// an empty selection keeps every module.
+func ResolveModules(names []string) ([]string, error) {
+	if len(names) == 0 {
+		all := make([]string, len(Modules))
+		for i, module := range Modules {
+			all[i] = module.Name
+		}
+		sort.Strings(all)
+		return all, nil
+	}
+
+	keep := map[string]bool{}
+	var add func(name string) error
+	add = func(name string) error {
+		if keep[name] || isCoreModule(name) {
+			return nil
+		}
+		module, ok := FindModule(name)
+		if !ok {
+			return fmt.Errorf("unknown module %q", name)
+		}
+		keep[name] = true
+		for _, required := range module.Requires {
+			if err := add(required); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, name := range names {
+		if err := add(strings.ToLower(strings.TrimSpace(name))); err != nil {
+			return nil, err
+		}
+	}
+
+	kept := make([]string, 0, len(keep))
+	for name := range keep {
+		kept = append(kept, name)
+	}
+	sort.Strings(kept)
+	return kept, nil
+}
+
+func isCoreModule(name string) bool {
+	for _, core := range CoreModules {
+		if core == name {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	moduleMarker = "// artisan:module "
+	endMarker    = "// artisan:end"
+)
+
+// moduleName is what may follow a marker; anything else, such as the closing
+// quote of a string, means the line only mentions one
+var moduleName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// StripModules removes the code marked for modules keep rejects, and the
+// markers of the others, from a Go source file. Blocks may nest. The result
+// is gofmt'ed, so fields and comments around removed lines line up again.
+func StripModules(content []byte, keep func(name string) bool) ([]byte, error) {
+	if !strings.Contains(string(content), "artisan:") {
+		return content, nil
+	}
+
+	var (
+		out   []string
+		stack []bool // whether each open block is kept
+	)
+	skipping := func() bool {
+		for _, kept := range stack {
+			if !kept {
+				return true
+			}
+		}
+		return false
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, moduleMarker) && moduleName.MatchString(trimmed[len(moduleMarker):]):
+			stack = append(stack, keep(trimmed[len(moduleMarker):]))
+			continue
+		case trimmed == endMarker:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: %s without %s", i+1, endMarker, strings.TrimSpace(moduleMarker))
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if skipping() {
+			continue
+		}
+		// A trailing marker follows code, not another comment
+		if at := strings.Index(line, moduleMarker); at > 0 && !strings.HasPrefix(strings.TrimSpace(line[:at]), "//") {
+			if name := strings.TrimSpace(line[at+len(moduleMarker):]); moduleName.MatchString(name) {
+				if !keep(name) {
+					continue
+				}
+				line = strings.TrimRight(line[:at], " \t") + "\n"
+			}
+		}
+		out = append(out, line)
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%s without %s", strings.TrimSpace(moduleMarker), endMarker)
+	}
+
+	return format.Source([]byte(strings.Join(out, "")))
+}
@@ -0,0 +1,481 @@
+// internal/generator/naming.go - Field parsing, naming and type mapping helpers
+package generator
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// ParseFields parses a field list like "name:string,user_id:uuid|fk:users,email:string|index".
+// Modifiers follow the type: "phone:string:nullable,active:bool:default=true".
+func ParseFields(fieldList string) []Field {
+	var parsedFields []Field
+	if fieldList == "" {
+		return parsedFields
+	}
+
+	fieldPairs := strings.Split(fieldList, ",")
+
+	for _, pair := range fieldPairs {
+		// split field_name:type|options - use SplitN to split only the first ":"
+		mainParts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(mainParts) < 2 {
+			continue
+		}
+
+		fieldName := strings.TrimSpace(mainParts[0])
+		typeAndOptions := strings.TrimSpace(mainParts[1])
+
+		// split type and options (type|index or type|fk:table)
+		typeParts := strings.Split(typeAndOptions, "|")
+		// and the type's modifiers (type:nullable:default=value)
+		modifiers := strings.Split(typeParts[0], ":")
+		fieldType := strings.TrimSpace(modifiers[0])
+
+		field := Field{
+			Name:         fieldName,
+			Type:         fieldType,
+			HasIndex:     false,
+			IsForeignKey: false,
+			FKReference:  "",
+		}
+
+		// relations (user:belongsTo=User, tags:manyToMany=Tag)
+		if kind, related, ok := strings.Cut(fieldType, "="); ok {
+			relation, known := parseRelation(kind)
+			if !known || related == "" {
+				continue
+			}
+			field.Type = ""
+			field.Relation = relation
+			field.Related = ToPascalCase(ToSnakeCase(strings.TrimSpace(related)))
+			if relation == RelationBelongsTo {
+				// the foreign key column
+				field.Name = strings.TrimSuffix(fieldName, "_id") + "_id"
+				field.Type = "uuid"
+				field.HasIndex = true
+			}
+		}
+
+		if !parseModifiers(&field, modifiers[1:]) {
+			continue
+		}
+
+		// check options
+		if len(typeParts) > 1 {
+			for i := 1; i < len(typeParts); i++ {
+				option := strings.TrimSpace(typeParts[i])
+
+				if option == "index" {
+					field.HasIndex = true
+				} else if strings.HasPrefix(option, "fk:") {
+					// a relation's fk only names the referenced table
+					field.IsForeignKey = field.Relation == ""
+					field.FKReference = strings.TrimPrefix(option, "fk:")
+				}
+			}
+		}
+
+		parsedFields = append(parsedFields, field)
+	}
+
+	return parsedFields
+}
+
+// parseModifiers applies nullable and default=value to the field, reporting
+// whether they are valid for its type
+func parseModifiers(field *Field, modifiers []string) bool {
+	for _, modifier := range modifiers {
+		modifier = strings.TrimSpace(modifier)
+		switch {
+		case strings.EqualFold(modifier, "nullable"):
+			field.Nullable = true
+		case strings.HasPrefix(modifier, "default="):
+			value := strings.TrimPrefix(modifier, "default=")
+			if !validDefault(field.Type, value) {
+				return false
+			}
+			field.Default = value
+			field.HasDefault = true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validDefault reports whether value is a default of the type that fits in a
+// gorm tag
+func validDefault(fieldType, value string) bool {
+	if strings.ContainsAny(value, ";\"`\\") {
+		return false
+	}
+	switch strings.ToLower(fieldType) {
+	case "int", "integer", "int64", "bigint":
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case "float", "float64", "decimal", "latitude", "longitude":
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case "bool", "boolean":
+		_, err := strconv.ParseBool(value)
+		return err == nil
+	case "json", "jsonb":
+		return false
+	}
+	return true
+}
+
+// sqlDefault is the column default of the field as SQL: numbers and booleans
+// as they are, now for timestamps and dates as CURRENT_TIMESTAMP, and anything
+// else quoted
+func sqlDefault(field Field) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer", "int64", "bigint", "float", "float64", "decimal", "latitude", "longitude":
+		return field.Default
+	case "bool", "boolean":
+		b, _ := strconv.ParseBool(field.Default)
+		return strconv.FormatBool(b)
+	case "timestamp", "time", "date":
+		if strings.EqualFold(field.Default, "now") {
+			return "CURRENT_TIMESTAMP"
+		}
+	}
+	return "'" + strings.ReplaceAll(field.Default, "'", "''") + "'"
+}
+
+// parseRelation returns the relation kind named case-insensitively
+func parseRelation(kind string) (string, bool) {
+	for _, relation := range []string{RelationBelongsTo, RelationHasOne, RelationHasMany, RelationManyToMany} {
+		if strings.EqualFold(strings.TrimSpace(kind), relation) {
+			return relation, true
+		}
+	}
+	return "", false
+}
+
+// Template functions
+var templateFuncs = template.FuncMap{
+	"toSQLType":             toSQLType,
+	"toGoType":              toGoType,
+	"goFieldType":           goFieldType,
+	"toPascalCase":          ToPascalCase,
+	"toSnakeCase":           ToSnakeCase,
+	"words":                 words,
+	"humanize":              humanize,
+	"sampleValue":           sampleValue,
+	"getGormTag":            getGormTag,
+	"getValidationTag":      getValidationTag,
+	"getFieldValidationTag": getFieldValidationTag,
+	"hasDecimalField":       hasDecimalField,
+	"getStructName":         GetStructName,
+	"hasIndexField":         hasIndexField,
+	"hasFKField":            hasFKField,
+	"toLowerFirst":          toLowerFirst,
+	"pluralize":             Pluralize,
+	"singularize":           Singularize,
+	"joinTable":             joinTableName,
+	"module":                func() string { return modulePath },
+}
+
+// ToPascalCase converts snake_case, kebab-case or spaced words to PascalCase
+func ToPascalCase(s string) string {
+	words := strings.FieldsFunc(s, func(c rune) bool {
+		return c == '_' || c == '-' || c == ' '
+	})
+
+	caser := cases.Title(language.English)
+	for i, word := range words {
+		words[i] = caser.String(strings.ToLower(word))
+	}
+
+	return strings.Join(words, "")
+}
+
+// ToSnakeCase converts PascalCase to snake_case
+func ToSnakeCase(s string) string {
+	var result strings.Builder
+	for i, r := range s {
+		if i > 0 && 'A' <= r && r <= 'Z' {
+			result.WriteRune('_')
+		}
+		result.WriteRune(r)
+	}
+	return strings.ToLower(result.String())
+}
+
+func toSQLType(goType string) string {
+	switch strings.ToLower(goType) {
+	case "string":
+		return "VARCHAR(255)"
+	case "text":
+		return "TEXT"
+	case "int", "integer":
+		return "INTEGER"
+	case "int64", "bigint":
+		return "BIGINT"
+	case "float", "float64", "latitude", "longitude":
+		return "DOUBLE PRECISION"
+	case "decimal":
+		return "DECIMAL(10,2)"
+	case "bool", "boolean":
+		return "BOOLEAN"
+	case "uuid":
+		return "UUID"
+	case "timestamp", "time":
+		return "TIMESTAMP WITH TIME ZONE"
+	case "date":
+		return "DATE"
+	case "json", "jsonb":
+		return "JSONB"
+	default:
+		return "VARCHAR(255)"
+	}
+}
+
+func toGoType(fieldType string) string {
+	switch strings.ToLower(fieldType) {
+	case "string":
+		return "string"
+	case "text":
+		return "string"
+	case "int", "integer":
+		return "int"
+	case "int64", "bigint":
+		return "int64"
+	case "float", "float64", "latitude", "longitude":
+		return "float64"
+	case "decimal":
+		return "decimal.Decimal"
+	case "bool", "boolean":
+		return "bool"
+	case "uuid":
+		return "uuid.UUID"
+	case "timestamp", "time":
+		return "time.Time"
+	case "date":
+		return "time.Time"
+	case "json", "jsonb":
+		return "map[string]interface{}"
+	default:
+		return "string"
+	}
+}
+
+// goFieldType is the Go type of the field's column, a pointer when the column
+// may be NULL or has a default, so nil leaves it to the database
+func goFieldType(field Field) string {
+	goType := toGoType(field.Type)
+	if field.IsPointer() {
+		return "*" + goType
+	}
+	return goType
+}
+
+func getGormTag(field Field) string {
+	tags := []string{}
+
+	// Basic type tags
+	switch strings.ToLower(field.Type) {
+	case "string":
+		tags = append(tags, "not null")
+	case "text":
+		tags = append(tags, "type:text")
+	case "int", "integer":
+		tags = append(tags, "not null")
+	case "int64", "bigint":
+		tags = append(tags, "type:bigint", "not null")
+	case "float", "float64", "latitude", "longitude":
+		tags = append(tags, "type:double precision", "not null")
+	case "decimal":
+		tags = append(tags, "type:decimal(10,2)", "not null")
+	case "bool", "boolean":
+		tags = append(tags, "default:false")
+	case "uuid":
+		tags = append(tags, "type:uuid", "not null")
+	case "timestamp", "time":
+		tags = append(tags, "type:timestamp with time zone")
+	case "date":
+		tags = append(tags, "type:date")
+	case "json", "jsonb":
+		tags = append(tags, "type:jsonb", "default:'{}'")
+	default:
+		tags = append(tags, "not null")
+	}
+
+	// Nullable columns drop NOT NULL and the type's default; a default of
+	// the field's own replaces the latter
+	if field.Nullable || field.HasDefault {
+		kept := tags[:0]
+		for _, tag := range tags {
+			if (field.Nullable && tag == "not null") || strings.HasPrefix(tag, "default:") {
+				continue
+			}
+			kept = append(kept, tag)
+		}
+		tags = kept
+	}
+	if field.HasDefault {
+		tags = append(tags, "default:"+sqlDefault(field))
+	}
+
+	// Add index tag
+	if field.HasIndex || field.IsForeignKey {
+		tags = append(tags, "index")
+	}
+
+	// Add foreign key constraint
+	if field.IsForeignKey {
+		tags = append(tags, "constraint:OnUpdate:CASCADE,OnDelete:SET NULL")
+	}
+
+	return strings.Join(tags, ";")
+}
+
+func getValidationTag(fieldType string) string {
+	switch strings.ToLower(fieldType) {
+	case "string":
+		return "required,min=1,max=255"
+	case "text":
+		return "required"
+	case "int", "integer":
+		return "required,min=0"
+	case "int64", "bigint":
+		return "required,min=0"
+	case "float", "float64":
+		return "required,min=0"
+	case "decimal":
+		return "required,min=0"
+	case "latitude":
+		// 0 is a coordinate like any other, so not required
+		return "latitude"
+	case "longitude":
+		return "longitude"
+	case "bool", "boolean":
+		return ""
+	case "uuid":
+		return "required"
+	case "timestamp", "time":
+		return ""
+	case "date":
+		return ""
+	case "json", "jsonb":
+		return ""
+	default:
+		return "required"
+	}
+}
+
+// getFieldValidationTag is getValidationTag for the field, optional when the
+// column may be NULL or has a default
+func getFieldValidationTag(field Field) string {
+	tag := getValidationTag(field.Type)
+	if !field.IsPointer() {
+		return tag
+	}
+
+	rules := []string{"omitempty"}
+	for _, rule := range strings.Split(tag, ",") {
+		if rule != "" && rule != "required" {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 1 {
+		return ""
+	}
+	return strings.Join(rules, ",")
+}
+
+func hasDecimalField(fields []Field) bool {
+	for _, field := range fields {
+		if strings.ToLower(field.Type) == "decimal" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStructName derives the entity struct name from a table name (tb_users -> User)
+func GetStructName(tableName string) string {
+	// if table name start with tb_ then remove it
+	tableName = strings.TrimPrefix(tableName, "tb_")
+
+	tableName = Singularize(tableName)
+
+	// convert to PascalCase
+	return ToPascalCase(tableName)
+}
+
+func hasIndexField(fields []Field) bool {
+	for _, field := range fields {
+		if field.HasIndex {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFKField(fields []Field) bool {
+	for _, field := range fields {
+		if field.IsForeignKey {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleValue is the Go expression a factory fills the column with, numbered
+// by the factory's sequence where it can be. Empty leaves the zero value.
+func sampleValue(field Field) string {
+	switch strings.ToLower(field.Type) {
+	case "int", "integer":
+		return "f.sequence"
+	case "int64", "bigint":
+		return "int64(f.sequence)"
+	case "float", "float64":
+		return "float64(f.sequence)"
+	case "decimal":
+		return "decimal.NewFromInt(int64(f.sequence))"
+	case "latitude":
+		return "float64(f.sequence%181 - 90)"
+	case "longitude":
+		return "float64(f.sequence%361 - 180)"
+	case "bool", "boolean":
+		return "true"
+	case "uuid":
+		return "ids.New()"
+	case "timestamp", "time", "date":
+		return "time.Now().UTC()"
+	case "json", "jsonb":
+		return ""
+	}
+	if strings.Contains(field.Name, "email") {
+		return `fmt.Sprintf("` + field.Name + `%d@example.com", f.sequence)`
+	}
+	return `fmt.Sprintf("` + humanize(ToPascalCase(field.Name)) + ` %d", f.sequence)`
+}
+
+// words spells out a name in lowercase, BlogPost as "blog post"
+func words(s string) string {
+	return strings.ReplaceAll(ToSnakeCase(s), "_", " ")
+}
+
+// humanize spells out a name at the start of a sentence, "Blog post"
+func humanize(s string) string {
+	w := words(s)
+	if w == "" {
+		return w
+	}
+	return strings.ToUpper(w[:1]) + w[1:]
+}
+
+func toLowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,429 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const protoHeader = "// Code generated by artisan generate:proto. DO NOT EDIT.\n"
+
+// ProtoNumbers are the field numbers of the generated messages and the
+// values of the generated enums, by message or enum and then by name. They
+// are kept in a file next to the .proto so a number is never changed or
+// reused: new fields get the next free number, and the numbers of removed
+// fields stay reserved.
+type ProtoNumbers struct {
+	Messages map[string]map[string]int `json:"messages"`
+	Enums    map[string]map[string]int `json:"enums"`
+}
+
+// ProtoOptions name the package of the generated file
+type ProtoOptions struct {
+	Package   string // e.g. entity.v1
+	GoPackage string // the go_package option, e.g. go-clean-gin/gen/proto/entity/v1;entityv1
+}
+
+// protoScalars maps Go's predeclared types to proto3 scalars
+var protoScalars = map[string]string{
+	"string": "string", "bool": "bool",
+	"int": "int64", "int8": "int32", "int16": "int32", "int32": "int32", "int64": "int64",
+	"uint": "uint64", "uint8": "uint32", "uint16": "uint32", "uint32": "uint32", "uint64": "uint64",
+	"float32": "float", "float64": "double", "byte": "uint32", "rune": "int32",
+}
+
+// protoExternalTypes maps types of other packages by how they marshal to JSON
+var protoExternalTypes = map[string]string{
+	"time.Time":       "google.protobuf.Timestamp",
+	"uuid.UUID":       "string",
+	"decimal.Decimal": "string",
+	"json.RawMessage": "google.protobuf.Value",
+	"gorm.DeletedAt":  "google.protobuf.Timestamp",
+	"money.Money":     "Money",
+}
+
+// protoImports are the files declaring the well-known types
+var protoImports = map[string]string{
+	"google.protobuf.Timestamp": "google/protobuf/timestamp.proto",
+	"google.protobuf.Value":     "google/protobuf/struct.proto",
+}
+
+// protoPreludes declare the external types mapped to messages of their own
+var protoPreludes = map[string]string{
+	"Money": "message Money {\n  string amount = 1;\n  string currency = 2;\n}\n",
+}
+
+// protoField is the proto type of a Go field
+type protoField struct {
+	Type     string
+	Repeated bool
+	Map      bool
+	Scalar   bool // may be marked optional: a scalar or enum
+	Message  *ast.StructType
+}
+
+type protoConverter struct {
+	*goTypes
+	numbers  *ProtoNumbers
+	messages map[string]bool
+	enums    map[string]bool
+	imports  map[string]bool
+	preludes map[string]bool
+}
+
+// Proto converts the exported types of Go sources into proto3 messages for a
+// gRPC surface, so it is generated from the same entities and DTOs as the
+// HTTP API. Structs with json tags become messages with the JSON field
+// names; string and number types with constants become enums. Field and
+// enum numbers come from numbers, to which new ones are added.
+func Proto(sources []File, numbers *ProtoNumbers, opts ProtoOptions) ([]byte, error) {
+	types, err := parseGoTypes(sources)
+	if err != nil {
+		return nil, err
+	}
+	if numbers.Messages == nil {
+		numbers.Messages = make(map[string]map[string]int)
+	}
+	if numbers.Enums == nil {
+		numbers.Enums = make(map[string]map[string]int)
+	}
+
+	c := &protoConverter{
+		goTypes:  types,
+		numbers:  numbers,
+		messages: make(map[string]bool),
+		enums:    make(map[string]bool),
+		imports:  make(map[string]bool),
+		preludes: make(map[string]bool),
+	}
+	for _, decl := range c.decls {
+		if !c.isData(decl) {
+			continue
+		}
+		if _, ok := decl.Expr.(*ast.StructType); ok && !c.marshalers[decl.Name] {
+			c.messages[decl.Name] = true
+		} else if len(c.consts[decl.Name]) > 0 {
+			c.enums[decl.Name] = true
+		}
+	}
+
+	var body strings.Builder
+	for _, decl := range c.decls {
+		switch {
+		case c.messages[decl.Name]:
+			body.WriteString("\n")
+			writeProtoDoc(&body, decl.Doc, "")
+			c.writeMessage(&body, decl.Name, decl.Name, decl.Expr.(*ast.StructType), "")
+		case c.enums[decl.Name]:
+			body.WriteString("\n")
+			writeProtoDoc(&body, decl.Doc, "")
+			c.writeEnum(&body, decl.Name)
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString(protoHeader)
+	out.WriteString("\nsyntax = \"proto3\";\n\npackage " + opts.Package + ";\n")
+	if len(c.imports) > 0 {
+		var imports []string
+		for path := range c.imports {
+			imports = append(imports, path)
+		}
+		sort.Strings(imports)
+		out.WriteString("\n")
+		for _, path := range imports {
+			out.WriteString("import \"" + path + "\";\n")
+		}
+	}
+	if opts.GoPackage != "" {
+		out.WriteString("\noption go_package = \"" + opts.GoPackage + "\";\n")
+	}
+	for _, name := range []string{"Money"} {
+		if _, declared := c.declared[name]; c.preludes[name] && !declared {
+			out.WriteString("\n" + protoPreludes[name])
+		}
+	}
+	out.WriteString(body.String())
+	return []byte(out.String()), nil
+}
+
+// writeMessage writes a message with its numbered fields, nested messages of
+// inline structs, and the numbers of removed fields reserved. key is the
+// message in ProtoNumbers, e.g. Order.Address for a nested one.
+func (c *protoConverter) writeMessage(b *strings.Builder, name, key string, st *ast.StructType, indent string) {
+	numbers := c.numbers.Messages[key]
+	if numbers == nil {
+		numbers = make(map[string]int)
+		c.numbers.Messages[key] = numbers
+	}
+
+	var fields, nested strings.Builder
+	present := make(map[string]bool)
+	c.writeFields(&fields, &nested, st, key, numbers, present, indent+"  ")
+
+	b.WriteString(indent + "message " + name + " {\n")
+	b.WriteString(nested.String())
+	b.WriteString(fields.String())
+	writeReserved(b, numbers, present, indent+"  ")
+	b.WriteString(indent + "}\n")
+}
+
+// writeFields writes the JSON fields of a struct, flattening embedded structs
+// since messages have no inheritance
+func (c *protoConverter) writeFields(fields, nested *strings.Builder, st *ast.StructType, key string, numbers map[string]int, present map[string]bool, indent string) {
+	for _, field := range st.Fields.List {
+		tag := jsonTag(field)
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if len(field.Names) == 0 && name == "" {
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if ident, ok := typ.(*ast.Ident); ok && c.messages[ident.Name] {
+				embedded := c.declared[ident.Name].Expr.(*ast.StructType)
+				c.writeFields(fields, nested, embedded, key, numbers, present, indent)
+			}
+			continue
+		}
+		if len(field.Names) > 0 && !field.Names[0].IsExported() {
+			continue
+		}
+
+		var names []string
+		if name != "" {
+			names = []string{name}
+		} else {
+			for _, ident := range field.Names {
+				names = append(names, ident.Name)
+			}
+		}
+
+		typ := c.protoType(field.Type)
+		if strings.Contains(","+opts+",", ",string,") {
+			typ = protoField{Type: "string", Scalar: true}
+		}
+
+		doc := field.Doc.Text()
+		if doc == "" {
+			doc = field.Comment.Text()
+		}
+		for _, n := range names {
+			n = protoFieldName(n)
+			present[n] = true
+
+			fieldType := typ.Type
+			if typ.Message != nil {
+				fieldType = ToPascalCase(n)
+				c.writeMessage(nested, fieldType, key+"."+fieldType, typ.Message, indent)
+			}
+
+			label := ""
+			switch {
+			case typ.Repeated:
+				label = "repeated "
+			case typ.Scalar && isPointer(field.Type):
+				label = "optional "
+			}
+			writeProtoDoc(fields, doc, indent)
+			fmt.Fprintf(fields, "%s%s%s %s = %d;\n", indent, label, fieldType, n, nextNumber(numbers, n))
+		}
+	}
+}
+
+// protoType returns the proto type of a Go type expression. What proto3
+// cannot express, such as a list of lists, is a google.protobuf.Value.
+func (c *protoConverter) protoType(expr ast.Expr) protoField {
+	value := func() protoField {
+		c.imports[protoImports["google.protobuf.Value"]] = true
+		return protoField{Type: "google.protobuf.Value"}
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if scalar, ok := protoScalars[t.Name]; ok {
+			return protoField{Type: scalar, Scalar: true}
+		}
+		switch {
+		case c.messages[t.Name]:
+			return protoField{Type: t.Name}
+		case c.enums[t.Name]:
+			return protoField{Type: t.Name, Scalar: true}
+		case c.marshalers[t.Name]:
+			return value()
+		}
+		// Other declared types are their underlying type, e.g. a slice
+		if decl, ok := c.declared[t.Name]; ok {
+			if _, isStruct := decl.Expr.(*ast.StructType); !isStruct {
+				return c.protoType(decl.Expr)
+			}
+		}
+		return value()
+
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return value()
+		}
+		typ, ok := protoExternalTypes[pkg.Name+"."+t.Sel.Name]
+		if !ok {
+			return value()
+		}
+		if path, ok := protoImports[typ]; ok {
+			c.imports[path] = true
+		}
+		if _, ok := protoPreludes[typ]; ok {
+			c.preludes[typ] = true
+		}
+		return protoField{Type: typ, Scalar: !strings.Contains(typ, ".") && typ != "Money"}
+
+	case *ast.StarExpr:
+		return c.protoType(t.X)
+
+	case *ast.ArrayType:
+		// []byte marshals as a base64 string, which is how JSON carries bytes
+		if ident, ok := t.Elt.(*ast.Ident); ok && (ident.Name == "byte" || ident.Name == "uint8") {
+			return protoField{Type: "bytes", Scalar: true}
+		}
+		elem := c.protoType(t.Elt)
+		if elem.Repeated || elem.Map || elem.Message != nil {
+			return value()
+		}
+		return protoField{Type: elem.Type, Repeated: true}
+
+	case *ast.MapType:
+		elem := c.protoType(t.Value)
+		if elem.Repeated || elem.Map || elem.Message != nil {
+			elem = value()
+		}
+		return protoField{Type: "map<string, " + elem.Type + ">", Map: true}
+
+	case *ast.StructType:
+		return protoField{Message: t}
+	}
+	return value()
+}
+
+// writeEnum writes an enum of the constants of a type. String enums are
+// numbered from ProtoNumbers, number enums by their values. The zero value
+// is UNSPECIFIED unless a constant is zero, as proto3 requires one.
+func (c *protoConverter) writeEnum(b *strings.Builder, name string) {
+	prefix := strings.ToUpper(ToSnakeCase(name)) + "_"
+	consts := c.consts[name]
+
+	numbers := c.numbers.Enums[name]
+	if numbers == nil && consts[0].IsString {
+		numbers = make(map[string]int)
+		c.numbers.Enums[name] = numbers
+	}
+
+	type enumValue struct {
+		Name   string
+		Number int
+	}
+	var values []enumValue
+	present := make(map[string]bool)
+	hasZero := false
+	for _, value := range consts {
+		valueName := prefix + strings.ToUpper(ToSnakeCase(strings.TrimPrefix(value.Name, name)))
+		number := 0
+		if value.IsString {
+			present[valueName] = true
+			number = nextNumber(numbers, valueName)
+		} else {
+			n, err := strconv.Atoi(value.Value)
+			if err != nil {
+				continue
+			}
+			number = n
+		}
+		hasZero = hasZero || number == 0
+		values = append(values, enumValue{Name: valueName, Number: number})
+	}
+
+	b.WriteString("enum " + name + " {\n")
+	if !hasZero {
+		fmt.Fprintf(b, "  %sUNSPECIFIED = 0;\n", prefix)
+	}
+	for _, value := range values {
+		fmt.Fprintf(b, "  %s = %d;\n", value.Name, value.Number)
+	}
+	if numbers != nil {
+		writeReserved(b, numbers, present, "  ")
+	}
+	b.WriteString("}\n")
+}
+
+// nextNumber returns the number of a name, assigning the next free one to a
+// new name
+func nextNumber(numbers map[string]int, name string) int {
+	if number, ok := numbers[name]; ok {
+		return number
+	}
+	number := 1
+	for _, n := range numbers {
+		if n >= number {
+			number = n + 1
+		}
+	}
+	numbers[name] = number
+	return number
+}
+
+// writeReserved reserves the numbers and names of removed fields or values
+func writeReserved(b *strings.Builder, numbers map[string]int, present map[string]bool, indent string) {
+	var removed []string
+	for name := range numbers {
+		if !present[name] {
+			removed = append(removed, name)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	sort.Slice(removed, func(i, j int) bool { return numbers[removed[i]] < numbers[removed[j]] })
+
+	nums := make([]string, len(removed))
+	names := make([]string, len(removed))
+	for i, name := range removed {
+		nums[i] = strconv.Itoa(numbers[name])
+		names[i] = strconv.Quote(name)
+	}
+	fmt.Fprintf(b, "%sreserved %s;\n", indent, strings.Join(nums, ", "))
+	fmt.Fprintf(b, "%sreserved %s;\n", indent, strings.Join(names, ", "))
+}
+
+// protoFieldName makes a JSON name a valid proto field name
+func protoFieldName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, ToSnakeCase(name))
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "f_" + name
+	}
+	return name
+}
+
+// writeProtoDoc writes a Go comment as a proto comment
+func writeProtoDoc(b *strings.Builder, doc, indent string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		b.WriteString(strings.TrimRight(indent+"// "+line, " ") + "\n")
+	}
+}
+
+func isPointer(expr ast.Expr) bool {
+	_, ok := expr.(*ast.StarExpr)
+	return ok
+}
@@ -0,0 +1,133 @@
+// internal/generator/retry.go - Retry decorators for repository interfaces
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"path/filepath"
+	"strings"
+)
+
+// RetryHeader starts every generated retry decorator, so regenerating one
+// never overwrites a hand-written file
+const RetryHeader = "// Code generated by artisan make:retry. DO NOT EDIT."
+
+// RetryIdempotent marks a method that may run twice, so the decorator also
+// retries it when the connection was lost after its statement was sent.
+// Methods marked //cache:read are idempotent too.
+const RetryIdempotent = "//retry:idempotent"
+
+// Interfaces returns the exported interfaces of a port.go file
+func Interfaces(port File) ([]string, error) {
+	p, err := parsePort(port)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, spec := range p.interfaces() {
+		names = append(names, spec.Name.Name)
+	}
+	return names, nil
+}
+
+// RetryDecorator renders Retrying<Interface>, which wraps any implementation
+// of the interface of a port.go file in pkg/dbretry. Methods taking a
+// context.Context and returning an error last are retried on transient
+// database errors; those taking callbacks or channels, whose side effects
+// cannot be undone, and the others just call through.
+func RetryDecorator(port File, iface string) (File, error) {
+	p, err := parsePort(port)
+	if err != nil {
+		return File{}, err
+	}
+
+	var ifaceType *ast.InterfaceType
+	for _, spec := range p.interfaces() {
+		if spec.Name.Name == iface {
+			ifaceType = spec.Type.(*ast.InterfaceType)
+		}
+	}
+	if ifaceType == nil {
+		return File{}, fmt.Errorf("interface %s not found in %s", iface, port.Path)
+	}
+
+	name := "Retrying" + iface
+	used := map[string]bool{}
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// %s retries the calls of %s failing\n", name, iface)
+	fmt.Fprintf(&body, "// with a transient database error. Only methods marked %s\n", RetryIdempotent)
+	fmt.Fprintf(&body, "// or %s are retried after a lost connection.\n", CacheRead)
+	fmt.Fprintf(&body, "type %s struct {\n\tnext    %s\n\tretrier *dbretry.Retrier\n}\n\n", name, iface)
+	fmt.Fprintf(&body, "// New%s wraps next, retrying its calls with retrier\n", name)
+	fmt.Fprintf(&body, "func New%s(next %s, retrier *dbretry.Retrier) %s {\n", name, iface, iface)
+	fmt.Fprintf(&body, "\treturn &%s{next: next, retrier: retrier}\n}\n", name)
+
+	for _, field := range ifaceType.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return File{}, fmt.Errorf("%s embeds %s; embedded interfaces are not supported", iface, p.expr(field.Type))
+		}
+		collectQualifiers(funcType, used)
+
+		for _, method := range field.Names {
+			m, err := p.newDecoratedMethod(method.Name, funcType, "")
+			if err != nil {
+				return File{}, fmt.Errorf("%s.%s: %w", iface, method.Name, err)
+			}
+			retried := m.ctx != "" && m.returnsError() && cacheable(funcType.Params)
+			idempotent := hasDirective(field, RetryIdempotent) || cacheDirective(field) == CacheRead
+			m.writeRetrying(&body, name, p.file.Name.Name+"."+iface+"."+method.Name, retried, idempotent)
+		}
+	}
+
+	source, err := format.Source(append(p.header(RetryHeader, used, modulePath+"/pkg/dbretry"), body.Bytes()...))
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		Path:    filepath.Join(filepath.Dir(port.Path), "retrying_"+ToSnakeCase(iface)+".go"),
+		Content: source,
+	}, nil
+}
+
+// hasDirective reports whether a method's comment holds the directive
+func hasDirective(field *ast.Field, directive string) bool {
+	if field.Doc == nil {
+		return false
+	}
+	for _, comment := range field.Doc.List {
+		if strings.TrimSpace(comment.Text) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *decoratedMethod) writeRetrying(b *bytes.Buffer, receiver, operation string, retried, idempotent bool) {
+	names := m.writeSignature(b, receiver)
+	call := m.call()
+
+	switch {
+	case retried && len(names) == 1:
+		fmt.Fprintf(b, "\treturn c.retrier.Do(%s, %q, %t, func() error {\n", m.ctx, operation, idempotent)
+		fmt.Fprintf(b, "\t\treturn %s\n\t})\n}\n", call)
+
+	case retried:
+		values := names[:len(names)-1]
+		for i, value := range values {
+			fmt.Fprintf(b, "\tvar %s %s\n", value, m.results[i])
+		}
+		fmt.Fprintf(b, "\terr := c.retrier.Do(%s, %q, %t, func() (err error) {\n", m.ctx, operation, idempotent)
+		fmt.Fprintf(b, "\t\t%s = %s\n\t\treturn err\n\t})\n", strings.Join(names, ", "), call)
+		fmt.Fprintf(b, "\treturn %s\n}\n", strings.Join(names, ", "))
+
+	case len(names) > 0:
+		fmt.Fprintf(b, "\treturn %s\n}\n", call)
+
+	default:
+		fmt.Fprintf(b, "\t%s\n}\n", call)
+	}
+}
@@ -0,0 +1,70 @@
+// internal/generator/templates.go - Source templates for the make:* commands
+package generator
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+)
+
+// StubDir is where stub:publish copies the templates, relative to the project
+// root. Stubs edited there replace the embedded ones.
+const StubDir = "stubs"
+
+// defaultStubs are the templates compiled into artisan, one <name>.stub per
+// generated file, so it works outside the repository
+//
+//go:embed stubs/*.stub
+var defaultStubs embed.FS
+
+// published holds the project's own stubs, if any
+var published fs.FS
+
+// UseStubs renders files from the stubs in dir, such as those published to
+// StubDir. A stub missing from dir keeps its default; nil restores all
+// defaults.
+func UseStubs(dir fs.FS) {
+	published = dir
+}
+
+// DefaultStubs returns the embedded stubs as files under StubDir, ready to be
+// published and customized
+func DefaultStubs() ([]File, error) {
+	entries, err := defaultStubs.ReadDir("stubs")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(entries))
+	for _, entry := range entries {
+		content, err := defaultStubs.ReadFile(path.Join("stubs", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: filepath.Join(StubDir, entry.Name()), Content: content})
+	}
+	return files, nil
+}
+
+// readStub returns the published stub with the name, or the default one
+func readStub(name string) (string, error) {
+	file := name + ".stub"
+	if published != nil {
+		content, err := fs.ReadFile(published, file)
+		if err == nil {
+			return string(content), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("read stub %s: %w", file, err)
+		}
+	}
+
+	content, err := defaultStubs.ReadFile(path.Join("stubs", file))
+	if err != nil {
+		return "", fmt.Errorf("unknown stub %s: %w", name, err)
+	}
+	return string(content), nil
+}
@@ -0,0 +1,260 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const typeScriptHeader = "// Code generated by artisan generate:types. DO NOT EDIT.\n"
+
+// tsBasicTypes maps Go's predeclared types to TypeScript
+var tsBasicTypes = map[string]string{
+	"string": "string", "bool": "boolean",
+	"int": "number", "int8": "number", "int16": "number", "int32": "number", "int64": "number",
+	"uint": "number", "uint8": "number", "uint16": "number", "uint32": "number", "uint64": "number",
+	"float32": "number", "float64": "number", "byte": "number", "rune": "number",
+	"any": "unknown", "error": "unknown",
+}
+
+// tsExternalTypes maps types of other packages by how they marshal to JSON
+var tsExternalTypes = map[string]string{
+	"time.Time":       "string",
+	"uuid.UUID":       "string",
+	"decimal.Decimal": "string",
+	"json.RawMessage": "unknown",
+	"gorm.DeletedAt":  "string | null",
+	"money.Money":     "Money",
+}
+
+// tsPreludes declare the external types mapped to declarations of their own
+var tsPreludes = map[string]string{
+	"Money": "export interface Money {\n  amount: string;\n  currency: string;\n}\n",
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+type tsConverter struct {
+	*goTypes
+	emitted  map[string]bool
+	preludes map[string]bool
+}
+
+// TypeScript converts the exported types of Go sources into TypeScript
+// declarations for frontends. Structs with json tags become interfaces with
+// the JSON field names, omitempty fields optional and pointers nullable;
+// string and number types with constants become unions of their values.
+// Structs without json tags, such as query filters, are left out.
+func TypeScript(sources []File) ([]byte, error) {
+	types, err := parseGoTypes(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &tsConverter{goTypes: types, emitted: make(map[string]bool), preludes: make(map[string]bool)}
+	for _, decl := range c.decls {
+		c.emitted[decl.Name] = c.isData(decl)
+	}
+
+	var body strings.Builder
+	for _, decl := range c.decls {
+		if c.emitted[decl.Name] {
+			body.WriteString("\n")
+			c.writeDecl(&body, decl)
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString(typeScriptHeader)
+	for _, name := range []string{"Money"} {
+		if _, declared := c.declared[name]; c.preludes[name] && !declared {
+			out.WriteString("\n" + tsPreludes[name])
+		}
+	}
+	out.WriteString(body.String())
+	return []byte(out.String()), nil
+}
+
+func (c *tsConverter) writeDecl(b *strings.Builder, decl goDecl) {
+	writeDoc(b, decl.Doc, "")
+
+	// Types marshaling themselves could be anything
+	if c.marshalers[decl.Name] {
+		fmt.Fprintf(b, "export type %s = unknown;\n", decl.Name)
+		return
+	}
+
+	if st, ok := decl.Expr.(*ast.StructType); ok {
+		var fields strings.Builder
+		extends := c.writeFields(&fields, st, "  ")
+		if len(extends) > 0 {
+			fmt.Fprintf(b, "export interface %s extends %s {\n", decl.Name, strings.Join(extends, ", "))
+		} else {
+			fmt.Fprintf(b, "export interface %s {\n", decl.Name)
+		}
+		b.WriteString(fields.String())
+		b.WriteString("}\n")
+		return
+	}
+
+	if consts := c.consts[decl.Name]; len(consts) > 0 {
+		values := make([]string, len(consts))
+		for i, value := range consts {
+			values[i] = value.Value
+			if value.IsString {
+				values[i] = strconv.Quote(value.Value)
+			}
+		}
+		fmt.Fprintf(b, "export type %s = %s;\n", decl.Name, strings.Join(values, " | "))
+		fmt.Fprintf(b, "export const %sValues: %s[] = [%s];\n", decl.Name, decl.Name, strings.Join(values, ", "))
+		return
+	}
+
+	fmt.Fprintf(b, "export type %s = %s;\n", decl.Name, c.tsType(decl.Expr, ""))
+}
+
+// writeFields writes the JSON fields of a struct and returns the declared
+// types it embeds, which the interface extends
+func (c *tsConverter) writeFields(b *strings.Builder, st *ast.StructType, indent string) []string {
+	var extends []string
+	for _, field := range st.Fields.List {
+		tag := jsonTag(field)
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		optional := strings.Contains(","+opts+",", ",omitempty,")
+
+		// Embedded structs without a JSON name are flattened into the struct
+		if len(field.Names) == 0 && name == "" {
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if ident, ok := typ.(*ast.Ident); ok && c.emitted[ident.Name] {
+				extends = append(extends, ident.Name)
+			}
+			continue
+		}
+
+		var names []string
+		if name != "" {
+			names = []string{name}
+		} else {
+			for _, ident := range field.Names {
+				if ident.IsExported() {
+					names = append(names, ident.Name)
+				}
+			}
+		}
+		if len(field.Names) > 0 && !field.Names[0].IsExported() {
+			continue
+		}
+
+		var typ string
+		switch {
+		case strings.Contains(","+opts+",", ",string,"):
+			typ = "string"
+		case optional:
+			// An omitted nil pointer is never null
+			expr := field.Type
+			if star, ok := expr.(*ast.StarExpr); ok {
+				expr = star.X
+			}
+			typ = c.tsType(expr, indent)
+		default:
+			typ = c.tsType(field.Type, indent)
+		}
+
+		doc := field.Doc.Text()
+		if doc == "" {
+			doc = field.Comment.Text()
+		}
+		for _, n := range names {
+			writeDoc(b, doc, indent)
+			if !tsIdentifier.MatchString(n) {
+				n = strconv.Quote(n)
+			}
+			if optional {
+				n += "?"
+			}
+			fmt.Fprintf(b, "%s%s: %s;\n", indent, n, typ)
+		}
+	}
+	return extends
+}
+
+// tsType returns the TypeScript type of a Go type expression
+func (c *tsConverter) tsType(expr ast.Expr, indent string) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ts, ok := tsBasicTypes[t.Name]; ok {
+			return ts
+		}
+		if c.emitted[t.Name] {
+			return t.Name
+		}
+		return "unknown"
+
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "unknown"
+		}
+		ts, ok := tsExternalTypes[pkg.Name+"."+t.Sel.Name]
+		if !ok {
+			return "unknown"
+		}
+		if _, ok := tsPreludes[ts]; ok {
+			c.preludes[ts] = true
+		}
+		return ts
+
+	case *ast.StarExpr:
+		ts := c.tsType(t.X, indent)
+		if strings.HasSuffix(ts, " | null") || ts == "unknown" {
+			return ts
+		}
+		return ts + " | null"
+
+	case *ast.ArrayType:
+		// []byte marshals as a base64 string
+		if ident, ok := t.Elt.(*ast.Ident); ok && (ident.Name == "byte" || ident.Name == "uint8") {
+			return "string"
+		}
+		elem := c.tsType(t.Elt, indent)
+		if strings.Contains(elem, " ") && !strings.HasPrefix(elem, "{") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+
+	case *ast.MapType:
+		return "Record<string, " + c.tsType(t.Value, indent) + ">"
+
+	case *ast.StructType:
+		var fields strings.Builder
+		c.writeFields(&fields, t, indent+"  ")
+		return "{\n" + fields.String() + indent + "}"
+	}
+	return "unknown"
+}
+
+// writeDoc writes a Go comment as a JSDoc comment
+func writeDoc(b *strings.Builder, doc, indent string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	lines := strings.Split(doc, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+}
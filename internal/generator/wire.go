@@ -0,0 +1,96 @@
+// internal/generator/wire.go - Register generated packages in the container and router
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strings"
+)
+
+// insertMarker is the comment generated code is added above, e.g.
+// "// artisan:insert routes" in the router
+const insertMarker = "// artisan:insert "
+
+// Wiring is code to add to a file at one of its insert markers
+type Wiring struct {
+	Path    string
+	Section string
+	Code    string
+}
+
+// ContainerPath and RouterPath are the files packages are wired into
+var (
+	ContainerPath = filepath.Join("internal", "container", "container.go")
+	RouterPath    = filepath.Join("internal", "router", "router.go")
+)
+
+// PackageWiring returns the code that builds a CRUD package in the container
+// and serves its handler under /api/v1
+func PackageWiring(data PackageData) []Wiring {
+	pkg, entity := data.PackageName, data.EntityName
+	local := toLowerFirst(entity)
+
+	return []Wiring{
+		{ContainerPath, "imports", fmt.Sprintf("%q", modulePath+"/internal/"+pkg)},
+		{ContainerPath, "fields", fmt.Sprintf(`// %[1]s
+%[1]sRepo %[2]s.%[1]sRepository
+%[1]sUsecase %[2]s.%[1]sUsecase
+%[1]sHandler *%[2]s.%[1]sHandler
+`, entity, pkg)},
+		{ContainerPath, "constructors", fmt.Sprintf(`// %[1]s
+%[3]sRepo := %[2]s.NewCached%[1]sRepository(%[2]s.NewRetrying%[1]sRepository(%[2]s.New%[1]sRepository(db), dbRetrier), cacheStore, cfg.Cache.TTL)
+%[3]sUsecase := %[2]s.New%[1]sUsecase(%[3]sRepo)
+%[3]sHandler := %[2]s.New%[1]sHandler(%[3]sUsecase)
+`, entity, pkg, local)},
+		{ContainerPath, "values", fmt.Sprintf(`// %[1]s
+%[1]sRepo: %[2]sRepo,
+%[1]sUsecase: %[2]sUsecase,
+%[1]sHandler: %[2]sHandler,
+`, entity, local)},
+		{RouterPath, "routes", fmt.Sprintf(`// %[1]s routes (protected)
+%[2]sRoutes := v1.Group("%[3]s")
+%[2]sRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+{
+	%[2]sRoutes.POST("", container.%[1]sHandler.Create%[1]s)
+	%[2]sRoutes.GET("", container.%[1]sHandler.Get%[4]s)
+	%[2]sRoutes.GET("/:id", container.%[1]sHandler.Get%[1]s)
+	%[2]sRoutes.PUT("/:id", container.%[1]sHandler.Update%[1]s)
+	%[2]sRoutes.DELETE("/:id", container.%[1]sHandler.Delete%[1]s)
+}
+`, entity, local, data.Route(), data.Plural())},
+	}
+}
+
+// Wire adds code above the insert marker of the section, indented like the
+// marker, and gofmts the result. Code the file already has is not added
+// again, so regenerating a package leaves its wiring alone.
+func Wire(content []byte, section, code string) ([]byte, error) {
+	if strings.Contains(withoutSpace(string(content)), withoutSpace(code)) {
+		return content, nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != insertMarker+section {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		var added strings.Builder
+		for _, codeLine := range strings.Split(code, "\n") {
+			if codeLine != "" {
+				added.WriteString(indent)
+			}
+			added.WriteString(codeLine + "\n")
+		}
+
+		out := strings.Join(lines[:i], "") + added.String() + strings.Join(lines[i:], "")
+		return format.Source([]byte(out))
+	}
+	return nil, fmt.Errorf("no %q marker", insertMarker+section)
+}
+
+func withoutSpace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
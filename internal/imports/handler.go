@@ -0,0 +1,198 @@
+package imports
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ImportHandler struct {
+	usecase ImportUsecase
+}
+
+func NewImportHandler(usecase ImportUsecase) *ImportHandler {
+	return &ImportHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateImport godoc
+// @Summary Upload an import
+// @Description Upload a CSV file to import, such as products. Uploads are virus scanned first; infected ones are rejected with 422 FILE_INFECTED. The file is validated in the background; poll GET /imports/{id} for the row-level error report, then commit the import to apply its valid rows.
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param kind formData string true "Import kind (products)"
+// @Param file formData file true "CSV file"
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Failure 503 {object} response.Response
+// @Router /imports [post]
+func (h *ImportHandler) CreateImport(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			appErr := errors.ErrFileTooLargeError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			return
+		}
+		response.Error(c, 400, errors.ErrBadRequest, "Import file is required", err.Error())
+		return
+	}
+
+	var req entity.CreateImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind form", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to open upload", zap.Error(err))
+		response.Error(c, 500, errors.ErrInternal, "Failed to create import", nil)
+		return
+	}
+	defer file.Close()
+
+	imp, err := h.usecase.CreateImport(c.Request.Context(), user.ID, user.Role, &req, header.Filename, file)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to create import", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to create import", nil)
+		}
+		return
+	}
+
+	response.Success(c, 202, "Import uploaded", imp)
+}
+
+// GetImport godoc
+// @Summary Get an import
+// @Description Get the status of one of the current user's imports. Validated imports carry the row counts and the errors of the invalid rows, by line and column; the header is line 1.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Import ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /imports/{id} [get]
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	importID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid import ID", err.Error())
+		return
+	}
+
+	imp, err := h.usecase.GetImport(c.Request.Context(), user.ID, importID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get import", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get import", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Import retrieved successfully", imp)
+}
+
+// CommitImport godoc
+// @Summary Commit an import
+// @Description Apply the valid rows of a validated import in the background; invalid rows are skipped. An import whose commit failed part way can be committed again and resumes where it stopped.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Import ID"
+// @Success 202 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /imports/{id}/commit [post]
+func (h *ImportHandler) CommitImport(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	importID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid import ID", err.Error())
+		return
+	}
+
+	imp, err := h.usecase.CommitImport(c.Request.Context(), user.ID, importID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to commit import", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to commit import", nil)
+		}
+		return
+	}
+
+	response.Success(c, 202, "Import committed", imp)
+}
+
+// currentUser reads the authenticated user set by AuthMiddleware and writes
+// the error response when it is missing
+func currentUser(c *gin.Context) (*entity.User, bool) {
+	value, exists := c.Get("user")
+	user, ok := value.(*entity.User)
+	if !exists || !ok {
+		response.Error(c, 401, errors.ErrUnauthorized, "User not found in context", nil)
+		return nil, false
+	}
+
+	return user, true
+}
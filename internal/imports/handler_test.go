@@ -0,0 +1,138 @@
+package imports_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/imports"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartImport builds an upload form for an import of the given kind
+func multipartImport(t *testing.T, kind, csv string) ([]byte, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	require.NoError(t, form.WriteField("kind", kind))
+	part, err := form.CreateFormFile("file", "products.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(csv))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	return body.Bytes(), form.FormDataContentType()
+}
+
+func TestImportHandler_ProductsImport(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+	body, contentType := multipartImport(t, entity.ImportKindProducts,
+		"name,category,price_amount,price_currency,stock,description\n"+
+			"Lamp,home,25.00,USD,5,Desk lamp\n"+
+			",home,10.00,USD,1,\n"+
+			"Rug,home,cheap,USD,2,\n"+
+			"Chair,home,40.00,USD,3,\n")
+
+	var created entity.Import
+	api.As(user).Request(http.MethodPost, "/api/v1/imports", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusAccepted).
+		Decode(&created)
+	assert.Equal(t, entity.ImportPending, created.Status)
+	assert.Equal(t, "products.csv", created.Filename)
+
+	jobs := api.Container.Queue.(*queue.ArrayQueue).Pushed()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, imports.JobValidate, jobs[0].Type)
+
+	// Nothing can be committed before it is validated
+	api.As(user).Post("/api/v1/imports/"+created.ID.String()+"/commit", nil).Do().
+		AssertStatus(http.StatusConflict).
+		AssertErrorCode(errors.ErrImportNotReady)
+
+	// Run the jobs the worker would
+	require.NoError(t, api.Container.ImportUsecase.ValidateImport(context.Background(), created.ID))
+
+	var validated entity.Import
+	api.As(user).Get("/api/v1/imports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&validated)
+	assert.Equal(t, entity.ImportValidated, validated.Status)
+	assert.Equal(t, 2, validated.ValidRows)
+	assert.Equal(t, 2, validated.InvalidRows)
+	require.Len(t, validated.Errors, 2)
+	assert.Equal(t, entity.ImportRowError{Row: 3, Field: "name", Message: "name is required"}, validated.Errors[0])
+	assert.Equal(t, 4, validated.Errors[1].Row)
+	assert.Equal(t, "price_amount", validated.Errors[1].Field)
+
+	api.As(user).Post("/api/v1/imports/"+created.ID.String()+"/commit", nil).Do().
+		AssertStatus(http.StatusAccepted)
+	require.NoError(t, api.Container.ImportUsecase.ApplyImport(context.Background(), created.ID))
+
+	var committed entity.Import
+	api.As(user).Get("/api/v1/imports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&committed)
+	assert.Equal(t, entity.ImportCommitted, committed.Status)
+	assert.Equal(t, 2, committed.ImportedRows)
+
+	var products []entity.ProductReadModel
+	api.Get("/api/v1/products").Query("search", "Lamp").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&products)
+	require.NotEmpty(t, products)
+	assert.Equal(t, user.ID, products[0].CreatedBy)
+
+	// Other users don't see the import
+	api.As(api.CreateUser()).Get("/api/v1/imports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrImportNotFound)
+}
+
+func TestImportHandler_Invalid(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	body, contentType := multipartImport(t, "orders", "id\n1\n")
+	api.As(user).Request(http.MethodPost, "/api/v1/imports", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("kind")
+
+	body, contentType = multipartImport(t, entity.ImportKindProducts, scanner.EICARTestFile)
+	api.As(user).Request(http.MethodPost, "/api/v1/imports", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusUnprocessableEntity).
+		AssertErrorCode(errors.ErrFileInfected)
+
+	body, contentType = multipartImport(t, entity.ImportKindProducts, "name,stock\nLamp,5\n")
+	var created entity.Import
+	api.As(user).Request(http.MethodPost, "/api/v1/imports", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusAccepted).
+		Decode(&created)
+	require.NoError(t, api.Container.ImportUsecase.ValidateImport(context.Background(), created.ID))
+
+	var failed entity.Import
+	api.As(user).Get("/api/v1/imports/" + created.ID.String()).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&failed)
+	assert.Equal(t, entity.ImportFailed, failed.Status)
+	assert.Equal(t, "The file is missing columns", failed.Error)
+}
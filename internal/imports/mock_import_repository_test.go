@@ -0,0 +1,55 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package imports
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImportRepository is a testify mock of ImportRepository
+type MockImportRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportRepository) CreateImport(ctx context.Context, imp *entity.Import) error {
+	args := m.Called(ctx, imp)
+	return args.Error(0)
+}
+
+func (m *MockImportRepository) GetImportByID(ctx context.Context, importID uuid.UUID) (*entity.Import, error) {
+	args := m.Called(ctx, importID)
+
+	var r0 *entity.Import
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Import)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockImportRepository) UpdateImport(ctx context.Context, imp *entity.Import) error {
+	args := m.Called(ctx, imp)
+	return args.Error(0)
+}
+
+func (m *MockImportRepository) GetExpiredImports(ctx context.Context, before time.Time, limit int) ([]*entity.Import, error) {
+	args := m.Called(ctx, before, limit)
+
+	var r0 []*entity.Import
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Import)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockImportRepository) DeleteImport(ctx context.Context, importID uuid.UUID) error {
+	args := m.Called(ctx, importID)
+	return args.Error(0)
+}
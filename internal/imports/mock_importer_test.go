@@ -0,0 +1,59 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package imports
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImporter is a testify mock of Importer
+type MockImporter struct {
+	mock.Mock
+}
+
+func (m *MockImporter) Columns() []string {
+	args := m.Called()
+
+	var r0 []string
+	if v := args.Get(0); v != nil {
+		r0 = v.([]string)
+	}
+
+	return r0
+}
+
+func (m *MockImporter) AdminOnly() bool {
+	args := m.Called()
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0
+}
+
+func (m *MockImporter) Parse(ctx context.Context, imp *entity.Import, row map[string]string) (interface{}, map[string]string) {
+	args := m.Called(ctx, imp, row)
+
+	var r0 interface{}
+	if v := args.Get(0); v != nil {
+		r0 = v.(interface{})
+	}
+
+	var r1 map[string]string
+	if v := args.Get(1); v != nil {
+		r1 = v.(map[string]string)
+	}
+
+	return r0, r1
+}
+
+func (m *MockImporter) Apply(ctx context.Context, imp *entity.Import, rows []interface{}) error {
+	args := m.Called(ctx, imp, rows)
+	return args.Error(0)
+}
@@ -0,0 +1,43 @@
+package imports
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportUsecase defines the business logic interface for import jobs
+type ImportUsecase interface {
+	CreateImport(ctx context.Context, userID uuid.UUID, role string, req *entity.CreateImportRequest, filename string, file io.ReadSeeker) (*entity.Import, error)
+	GetImport(ctx context.Context, userID uuid.UUID, importID uuid.UUID) (*entity.Import, error)
+	CommitImport(ctx context.Context, userID uuid.UUID, importID uuid.UUID) (*entity.Import, error)
+	ValidateImport(ctx context.Context, importID uuid.UUID) error
+	ApplyImport(ctx context.Context, importID uuid.UUID) error
+	PruneImports(ctx context.Context) (int, error)
+}
+
+// ImportRepository defines the data access interface for import jobs
+type ImportRepository interface {
+	CreateImport(ctx context.Context, imp *entity.Import) error
+	GetImportByID(ctx context.Context, importID uuid.UUID) (*entity.Import, error)
+	UpdateImport(ctx context.Context, imp *entity.Import) error
+	GetExpiredImports(ctx context.Context, before time.Time, limit int) ([]*entity.Import, error)
+	DeleteImport(ctx context.Context, importID uuid.UUID) error
+}
+
+// Importer reads and applies the rows of one kind of import. Importers live
+// with the data they import and are registered by kind in the container.
+type Importer interface {
+	// Columns lists the CSV columns every file must have
+	Columns() []string
+	// AdminOnly reports whether only admins may import
+	AdminOnly() bool
+	// Parse turns a row, keyed by column, into the value Apply takes. Invalid
+	// rows are reported with their errors by column instead.
+	Parse(ctx context.Context, imp *entity.Import, row map[string]string) (interface{}, map[string]string)
+	// Apply applies parsed rows all at once: either every row is applied or none
+	Apply(ctx context.Context, imp *entity.Import, rows []interface{}) error
+}
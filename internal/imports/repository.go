@@ -0,0 +1,52 @@
+package imports
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type importRepository struct {
+	db *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) ImportRepository {
+	return &importRepository{
+		db: db,
+	}
+}
+
+func (r *importRepository) CreateImport(ctx context.Context, imp *entity.Import) error {
+	return tenancy.Conn(ctx, r.db).Create(imp).Error
+}
+
+func (r *importRepository) GetImportByID(ctx context.Context, importID uuid.UUID) (*entity.Import, error) {
+	var imp entity.Import
+	if err := tenancy.Conn(ctx, r.db).First(&imp, "id = ?", importID).Error; err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+func (r *importRepository) UpdateImport(ctx context.Context, imp *entity.Import) error {
+	return tenancy.Conn(ctx, r.db).Save(imp).Error
+}
+
+// GetExpiredImports returns up to limit imports that expired before the given time
+func (r *importRepository) GetExpiredImports(ctx context.Context, before time.Time, limit int) ([]*entity.Import, error) {
+	var imports []*entity.Import
+	err := tenancy.Conn(ctx, r.db).
+		Where("expires_at < ?", before).
+		Order("expires_at").
+		Limit(limit).
+		Find(&imports).Error
+	return imports, err
+}
+
+func (r *importRepository) DeleteImport(ctx context.Context, importID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Delete(&entity.Import{}, "id = ?", importID).Error
+}
@@ -0,0 +1,460 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Job types run by the import workers; their handlers are registered in
+// internal/jobs
+const (
+	JobValidate = "import:validate"
+	JobCommit   = "import:commit"
+)
+
+// Payload is the payload of JobValidate and JobCommit jobs
+type Payload struct {
+	ImportID uuid.UUID `json:"import_id"`
+}
+
+// maxRowErrors caps the row errors kept in an import's report; the counts
+// still cover every row
+const maxRowErrors = 1000
+
+// pruneBatchSize is the number of expired imports removed per query round
+const pruneBatchSize = 100
+
+// utf8BOM is skipped at the start of files saved by spreadsheet programs
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type importUsecase struct {
+	repo      ImportRepository
+	importers map[string]Importer
+	config    *config.Config
+	queue     queue.Queue
+	storage   storage.Storage
+	guard     *scanner.Guard
+	clock     clock.Clock
+}
+
+func NewImportUsecase(repo ImportRepository, importers map[string]Importer, config *config.Config, jobQueue queue.Queue, store storage.Storage, guard *scanner.Guard, clk clock.Clock) ImportUsecase {
+	return &importUsecase{
+		repo:      repo,
+		importers: importers,
+		config:    config,
+		queue:     jobQueue,
+		storage:   store,
+		guard:     guard,
+		clock:     clk,
+	}
+}
+
+// CreateImport scans and stores the uploaded file and queues its validation.
+// Nothing is imported until the owner commits the validated import.
+func (u *importUsecase) CreateImport(ctx context.Context, userID uuid.UUID, role string, req *entity.CreateImportRequest, filename string, file io.ReadSeeker) (*entity.Import, error) {
+	importer, ok := u.importers[req.Kind]
+	if !ok {
+		return nil, errors.New(errors.ErrImportInvalid, "Unknown import kind", 400).
+			WithDetails(map[string]interface{}{"kind": req.Kind})
+	}
+	if importer.AdminOnly() && role != entity.RoleAdmin {
+		return nil, errors.ErrForbiddenError
+	}
+
+	now := u.clock.Now()
+	imp := &entity.Import{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Kind:      req.Kind,
+		Filename:  filename,
+		Status:    entity.ImportPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(u.config.Import.Retention),
+	}
+	imp.File = fmt.Sprintf("%s/%s/%s.csv", u.config.Import.Dir, userID, imp.ID)
+
+	if err := u.guard.Check(ctx, imp.File, file); err != nil {
+		if stderrors.Is(err, scanner.ErrInfected) {
+			return nil, errors.ErrFileInfectedError
+		}
+		logger.FromContext(ctx).Error("Failed to scan import", zap.Error(err))
+		return nil, errors.ErrScanUnavailableError
+	}
+
+	if err := u.storage.Put(ctx, imp.File, file); err != nil {
+		logger.FromContext(ctx).Error("Failed to store import", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create import", 500)
+	}
+
+	if err := u.repo.CreateImport(ctx, imp); err != nil {
+		logger.FromContext(ctx).Error("Failed to create import", zap.Error(err))
+		_ = u.storage.Delete(ctx, imp.File)
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create import", 500)
+	}
+
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobValidate, Payload{ImportID: imp.ID}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue import validation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create import", 500)
+	}
+
+	logger.FromContext(ctx).Info("Import uploaded",
+		zap.String("import_id", imp.ID.String()), zap.String("kind", imp.Kind))
+	return imp, nil
+}
+
+// GetImport returns one of the user's imports with its error report. Other
+// users' imports are reported as not found.
+func (u *importUsecase) GetImport(ctx context.Context, userID uuid.UUID, importID uuid.UUID) (*entity.Import, error) {
+	imp, err := u.getImport(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if imp.UserID != userID {
+		return nil, errors.ErrImportNotFoundError
+	}
+	return imp, nil
+}
+
+// CommitImport queues applying the valid rows of a validated import. A commit
+// that failed part way can be committed again; it resumes after the rows
+// already imported.
+func (u *importUsecase) CommitImport(ctx context.Context, userID uuid.UUID, importID uuid.UUID) (*entity.Import, error) {
+	imp, err := u.GetImport(ctx, userID, importID)
+	if err != nil {
+		return nil, err
+	}
+	if !committable(imp) {
+		return nil, errors.ErrImportNotReadyError
+	}
+	if imp.ValidRows == 0 {
+		return nil, errors.New(errors.ErrImportInvalid, "The import has no valid rows", 400)
+	}
+
+	imp.Status = entity.ImportCommitting
+	imp.Error = ""
+	if err := u.repo.UpdateImport(ctx, imp); err != nil {
+		logger.FromContext(ctx).Error("Failed to commit import", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to commit import", 500)
+	}
+
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobCommit, Payload{ImportID: imp.ID}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue import commit", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to commit import", 500)
+	}
+
+	logger.FromContext(ctx).Info("Import committed", zap.String("import_id", imp.ID.String()))
+	return imp, nil
+}
+
+// ValidateImport parses every row of the file, counting the valid ones and
+// reporting why the others can't be imported. Files that can't be read as a
+// whole, such as ones missing a column, fail the import.
+func (u *importUsecase) ValidateImport(ctx context.Context, importID uuid.UUID) error {
+	imp, err := u.repo.GetImportByID(ctx, importID)
+	if err == gorm.ErrRecordNotFound {
+		// Pruned before a worker got to it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !validatable(imp) {
+		return nil
+	}
+
+	importer, ok := u.importers[imp.Kind]
+	if !ok {
+		return fmt.Errorf("no importer for kind %q", imp.Kind)
+	}
+
+	imp.Status = entity.ImportValidating
+	if err := u.repo.UpdateImport(ctx, imp); err != nil {
+		return err
+	}
+
+	imp.TotalRows, imp.ValidRows, imp.InvalidRows = 0, 0, 0
+	imp.Errors = entity.ImportErrors{}
+	err = u.eachRow(ctx, imp, importer, func(line int, row map[string]string) error {
+		imp.TotalRows++
+		_, fieldErrors := importer.Parse(ctx, imp, row)
+		if fieldErrors == nil {
+			imp.ValidRows++
+			return nil
+		}
+
+		imp.InvalidRows++
+		fields := make([]string, 0, len(fieldErrors))
+		for field := range fieldErrors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if len(imp.Errors) < maxRowErrors {
+				imp.Errors = append(imp.Errors, entity.ImportRowError{Row: line, Field: field, Message: fieldErrors[field]})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return u.fail(ctx, imp, "The file could not be validated", err)
+	}
+
+	validated := u.clock.Now()
+	imp.Status = entity.ImportValidated
+	imp.ValidatedAt = &validated
+	if err := u.repo.UpdateImport(ctx, imp); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Import validated",
+		zap.String("import_id", imp.ID.String()), zap.Int("valid", imp.ValidRows), zap.Int("invalid", imp.InvalidRows))
+	return nil
+}
+
+// ApplyImport applies the valid rows of a committed import in batches of
+// IMPORT_BATCH_SIZE, recording progress after each. A failure marks the
+// import failed and is returned so the job is retried, which resumes after
+// the batches already applied.
+func (u *importUsecase) ApplyImport(ctx context.Context, importID uuid.UUID) error {
+	imp, err := u.repo.GetImportByID(ctx, importID)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if imp.Status != entity.ImportCommitting && !committable(imp) {
+		return nil
+	}
+
+	importer, ok := u.importers[imp.Kind]
+	if !ok {
+		return fmt.Errorf("no importer for kind %q", imp.Kind)
+	}
+
+	imp.Status = entity.ImportCommitting
+	skip := imp.ImportedRows
+	batch := make([]interface{}, 0, u.config.Import.BatchSize)
+
+	apply := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := importer.Apply(ctx, imp, batch); err != nil {
+			return err
+		}
+		imp.ImportedRows += len(batch)
+		batch = batch[:0]
+		return u.repo.UpdateImport(ctx, imp)
+	}
+
+	err = u.eachRow(ctx, imp, importer, func(line int, row map[string]string) error {
+		parsed, fieldErrors := importer.Parse(ctx, imp, row)
+		if fieldErrors != nil {
+			return nil
+		}
+		if skip > 0 {
+			skip--
+			return nil
+		}
+
+		batch = append(batch, parsed)
+		if len(batch) < u.config.Import.BatchSize {
+			return nil
+		}
+		return apply()
+	})
+	if err == nil {
+		err = apply()
+	}
+	if err != nil {
+		message := fmt.Sprintf("The import stopped after %d rows", imp.ImportedRows)
+		return u.fail(ctx, imp, message, err)
+	}
+
+	committed := u.clock.Now()
+	imp.Status = entity.ImportCommitted
+	imp.CommittedAt = &committed
+	if err := u.repo.UpdateImport(ctx, imp); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Import applied",
+		zap.String("import_id", imp.ID.String()), zap.Int("rows", imp.ImportedRows))
+	return nil
+}
+
+// PruneImports deletes expired imports and their files
+func (u *importUsecase) PruneImports(ctx context.Context) (int, error) {
+	now := u.clock.Now()
+	deleted := 0
+
+	for {
+		imports, err := u.repo.GetExpiredImports(ctx, now, pruneBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+
+		for _, imp := range imports {
+			if err := u.storage.Delete(ctx, imp.File); err != nil && err != storage.ErrNotFound {
+				return deleted, err
+			}
+			if err := u.repo.DeleteImport(ctx, imp.ID); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+
+		if len(imports) < pruneBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+// eachRow calls fn with every row of the import's file, keyed by column, and
+// the line it is on. Files missing one of the importer's columns or that are
+// not valid CSV are rejected with an IMPORT_INVALID error.
+func (u *importUsecase) eachRow(ctx context.Context, imp *entity.Import, importer Importer, fn func(line int, row map[string]string) error) error {
+	reader, err := u.storage.Get(ctx, imp.File)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	br := bufio.NewReader(reader)
+	if prefix, _ := br.Peek(len(utf8BOM)); bytes.Equal(prefix, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return errors.New(errors.ErrImportInvalid, "The file is empty", 400)
+	}
+	if err != nil {
+		return invalidCSV(err)
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	var missing []string
+	for _, column := range importer.Columns() {
+		if !slices.Contains(header, column) {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New(errors.ErrImportInvalid, "The file is missing columns", 400).
+			WithDetails(map[string]interface{}{"missing": missing})
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return invalidCSV(err)
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = strings.TrimSpace(record[i])
+			}
+		}
+
+		line, _ := cr.FieldPos(0)
+		if err := fn(line, row); err != nil {
+			return err
+		}
+	}
+}
+
+// fail marks the import failed. Errors about the file itself are final and
+// are not returned, since retrying the job can't fix them; others are returned
+// so the job is retried.
+func (u *importUsecase) fail(ctx context.Context, imp *entity.Import, message string, err error) error {
+	logger.FromContext(ctx).Error("Import failed",
+		zap.String("import_id", imp.ID.String()), zap.Error(err))
+
+	imp.Status = entity.ImportFailed
+	imp.Error = message
+	final := false
+	if appErr, ok := err.(*errors.AppError); ok && appErr.StatusCode < 500 {
+		imp.Error = appErr.Message
+		final = true
+	}
+	if updateErr := u.repo.UpdateImport(ctx, imp); updateErr != nil {
+		logger.FromContext(ctx).Error("Failed to mark import failed", zap.Error(updateErr))
+	}
+
+	if final {
+		return nil
+	}
+	return err
+}
+
+func (u *importUsecase) getImport(ctx context.Context, importID uuid.UUID) (*entity.Import, error) {
+	imp, err := u.repo.GetImportByID(ctx, importID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrImportNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get import", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get import", 500)
+	}
+	return imp, nil
+}
+
+// validatable reports whether an import still has to be validated: it is
+// waiting for it, or its validation was interrupted or failed
+func validatable(imp *entity.Import) bool {
+	return imp.Status == entity.ImportPending || imp.Status == entity.ImportValidating ||
+		(imp.Status == entity.ImportFailed && imp.ValidatedAt == nil)
+}
+
+// committable reports whether an import can be committed: it was validated
+// and either not committed yet or its commit failed part way
+func committable(imp *entity.Import) bool {
+	return imp.Status == entity.ImportValidated ||
+		(imp.Status == entity.ImportFailed && imp.ValidatedAt != nil)
+}
+
+// invalidCSV reports a file that is not valid CSV
+func invalidCSV(err error) error {
+	var parseErr *csv.ParseError
+	if stderrors.As(err, &parseErr) {
+		return errors.New(errors.ErrImportInvalid, fmt.Sprintf("The file is not valid CSV: line %d: %v", parseErr.Line, parseErr.Err), 400)
+	}
+	return err
+}
@@ -0,0 +1,270 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	repo     *MockImportRepository
+	importer *MockImporter
+	queue    *queue.ArrayQueue
+	storage  storage.Storage
+	clock    *clock.Fake
+	usecase  ImportUsecase
+}
+
+func newTestUsecase(t *testing.T) *testDeps {
+	cfg := &config.Config{
+		Queue:  config.QueueConfig{Default: "default"},
+		Import: config.ImportConfig{Dir: "imports", BatchSize: 2, Retention: 24 * time.Hour},
+	}
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	d := &testDeps{
+		repo:     new(MockImportRepository),
+		importer: new(MockImporter),
+		queue:    queue.NewArrayQueue(&cfg.Queue),
+		storage:  store,
+		clock:    clock.NewFake(testNow),
+	}
+	d.importer.On("Columns").Return([]string{"name", "stock"}).Maybe()
+
+	importers := map[string]Importer{entity.ImportKindProducts: testImporter{d.importer}}
+	guard := scanner.NewGuard(scanner.EICARScanner{}, store, "quarantine")
+	d.usecase = NewImportUsecase(d.repo, importers, cfg, d.queue, store, guard, d.clock)
+	return d
+}
+
+// testImporter parses rows to their name; a stock that isn't a number makes
+// a row invalid
+type testImporter struct {
+	*MockImporter
+}
+
+func (i testImporter) Parse(ctx context.Context, imp *entity.Import, row map[string]string) (interface{}, map[string]string) {
+	if row["stock"] == "" || strings.Trim(row["stock"], "0123456789") != "" {
+		return nil, map[string]string{"stock": "stock must be a whole number"}
+	}
+	return row["name"], nil
+}
+
+// stored returns an import of the given CSV, as uploaded
+func (d *testDeps) stored(t *testing.T, csv string) *entity.Import {
+	imp := &entity.Import{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Kind:   entity.ImportKindProducts,
+		Status: entity.ImportPending,
+	}
+	imp.File = fmt.Sprintf("imports/%s/%s.csv", imp.UserID, imp.ID)
+	require.NoError(t, d.storage.Put(context.Background(), imp.File, strings.NewReader(csv)))
+	d.repo.On("GetImportByID", mock.Anything, imp.ID).Return(imp, nil)
+	d.repo.On("UpdateImport", mock.Anything, imp).Return(nil)
+	return imp
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestImportUsecase_CreateImport(t *testing.T) {
+	d := newTestUsecase(t)
+	d.importer.On("AdminOnly").Return(false)
+	d.repo.On("CreateImport", mock.Anything, mock.Anything).Return(nil)
+	userID := uuid.New()
+
+	imp, err := d.usecase.CreateImport(context.Background(), userID, entity.RoleUser,
+		&entity.CreateImportRequest{Kind: entity.ImportKindProducts}, "products.csv", strings.NewReader("name,stock\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.ImportPending, imp.Status)
+	assert.Equal(t, fmt.Sprintf("imports/%s/%s.csv", userID, imp.ID), imp.File)
+	assert.Equal(t, testNow.Add(24*time.Hour), imp.ExpiresAt)
+	exists, err := d.storage.Exists(context.Background(), imp.File)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	if pushed := d.queue.Pushed(); assert.Len(t, pushed, 1) {
+		assert.Equal(t, JobValidate, pushed[0].Type)
+	}
+}
+
+func TestImportUsecase_CreateImport_AdminOnly(t *testing.T) {
+	d := newTestUsecase(t)
+	d.importer.On("AdminOnly").Return(true)
+
+	_, err := d.usecase.CreateImport(context.Background(), uuid.New(), entity.RoleUser,
+		&entity.CreateImportRequest{Kind: entity.ImportKindProducts}, "products.csv", strings.NewReader("name,stock\n"))
+
+	assertCode(t, err, errors.ErrForbidden)
+	assert.Empty(t, d.queue.Pushed())
+}
+
+func TestImportUsecase_CreateImport_Infected(t *testing.T) {
+	d := newTestUsecase(t)
+	d.importer.On("AdminOnly").Return(false)
+	userID := uuid.New()
+
+	_, err := d.usecase.CreateImport(context.Background(), userID, entity.RoleUser,
+		&entity.CreateImportRequest{Kind: entity.ImportKindProducts}, "products.csv", strings.NewReader("name,stock\n"+scanner.EICARTestFile+",1\n"))
+
+	assert.Equal(t, errors.ErrFileInfectedError, err)
+	assert.Empty(t, d.queue.Pushed())
+	d.repo.AssertNotCalled(t, "CreateImport", mock.Anything, mock.Anything)
+
+	quarantined, err := d.storage.List(context.Background(), "quarantine/imports/"+userID.String()+"/")
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+	stored, err := d.storage.List(context.Background(), "imports/")
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func TestImportUsecase_ValidateImport(t *testing.T) {
+	d := newTestUsecase(t)
+	imp := d.stored(t, "\xef\xbb\xbfName, Stock\nLamp,5\nChair,many\nDesk,\nShelf,2\n")
+
+	require.NoError(t, d.usecase.ValidateImport(context.Background(), imp.ID))
+
+	assert.Equal(t, entity.ImportValidated, imp.Status)
+	assert.Equal(t, 4, imp.TotalRows)
+	assert.Equal(t, 2, imp.ValidRows)
+	assert.Equal(t, 2, imp.InvalidRows)
+	assert.Equal(t, entity.ImportErrors{
+		{Row: 3, Field: "stock", Message: "stock must be a whole number"},
+		{Row: 4, Field: "stock", Message: "stock must be a whole number"},
+	}, imp.Errors)
+	require.NotNil(t, imp.ValidatedAt)
+}
+
+func TestImportUsecase_ValidateImport_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		message string
+	}{
+		{"missing column", "name,price\nLamp,5\n", "The file is missing columns"},
+		{"empty", "", "The file is empty"},
+		{"malformed", "name,stock\n\"Lamp,5\n", "The file is not valid CSV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(t)
+			imp := d.stored(t, tt.csv)
+
+			// The file won't get better, so the job is not retried
+			require.NoError(t, d.usecase.ValidateImport(context.Background(), imp.ID))
+
+			assert.Equal(t, entity.ImportFailed, imp.Status)
+			assert.Contains(t, imp.Error, tt.message)
+		})
+	}
+}
+
+func TestImportUsecase_CommitImport(t *testing.T) {
+	d := newTestUsecase(t)
+	imp := d.stored(t, "name,stock\nLamp,5\n")
+	imp.Status = entity.ImportValidating
+
+	_, err := d.usecase.CommitImport(context.Background(), uuid.New(), imp.ID)
+	assert.Equal(t, errors.ErrImportNotFoundError, err)
+
+	_, err = d.usecase.CommitImport(context.Background(), imp.UserID, imp.ID)
+	assert.Equal(t, errors.ErrImportNotReadyError, err)
+
+	validated := testNow
+	imp.Status = entity.ImportValidated
+	imp.ValidatedAt = &validated
+	_, err = d.usecase.CommitImport(context.Background(), imp.UserID, imp.ID)
+	assertCode(t, err, errors.ErrImportInvalid)
+
+	imp.ValidRows = 1
+	_, err = d.usecase.CommitImport(context.Background(), imp.UserID, imp.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ImportCommitting, imp.Status)
+	if pushed := d.queue.Pushed(); assert.Len(t, pushed, 1) {
+		assert.Equal(t, JobCommit, pushed[0].Type)
+	}
+}
+
+func TestImportUsecase_ApplyImport(t *testing.T) {
+	d := newTestUsecase(t)
+	imp := d.stored(t, "name,stock\nLamp,5\nChair,many\nDesk,1\nShelf,2\nBed,3\n")
+	imp.Status = entity.ImportCommitting
+	d.importer.On("Apply", mock.Anything, imp, []interface{}{"Lamp", "Desk"}).Return(nil).Once()
+	d.importer.On("Apply", mock.Anything, imp, []interface{}{"Shelf", "Bed"}).Return(nil).Once()
+
+	require.NoError(t, d.usecase.ApplyImport(context.Background(), imp.ID))
+
+	assert.Equal(t, entity.ImportCommitted, imp.Status)
+	assert.Equal(t, 4, imp.ImportedRows)
+	require.NotNil(t, imp.CommittedAt)
+	d.importer.AssertExpectations(t)
+}
+
+func TestImportUsecase_ApplyImport_Resume(t *testing.T) {
+	d := newTestUsecase(t)
+	imp := d.stored(t, "name,stock\nLamp,5\nDesk,1\nShelf,2\n")
+	validated := testNow
+	imp.Status = entity.ImportCommitting
+	imp.ValidatedAt = &validated
+	d.importer.On("Apply", mock.Anything, imp, []interface{}{"Lamp", "Desk"}).Return(nil).Once()
+	d.importer.On("Apply", mock.Anything, imp, []interface{}{"Shelf"}).Return(fmt.Errorf("connection reset")).Once()
+
+	// A failed batch is retried, without applying the batches before it again
+	err := d.usecase.ApplyImport(context.Background(), imp.ID)
+	assert.Error(t, err)
+	assert.Equal(t, entity.ImportFailed, imp.Status)
+	assert.Equal(t, "The import stopped after 2 rows", imp.Error)
+
+	d.importer.On("Apply", mock.Anything, imp, []interface{}{"Shelf"}).Return(nil).Once()
+	require.NoError(t, d.usecase.ApplyImport(context.Background(), imp.ID))
+	assert.Equal(t, entity.ImportCommitted, imp.Status)
+	assert.Equal(t, 3, imp.ImportedRows)
+	d.importer.AssertExpectations(t)
+}
+
+func TestImportUsecase_PruneImports(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+
+	imp := &entity.Import{ID: uuid.New(), File: "imports/someone/expired.csv"}
+	gone := &entity.Import{ID: uuid.New(), File: "imports/someone/gone.csv"}
+	require.NoError(t, d.storage.Put(ctx, imp.File, strings.NewReader("name")))
+	d.repo.On("GetExpiredImports", mock.Anything, testNow, pruneBatchSize).Return([]*entity.Import{imp, gone}, nil)
+	d.repo.On("DeleteImport", mock.Anything, imp.ID).Return(nil).Once()
+	d.repo.On("DeleteImport", mock.Anything, gone.ID).Return(nil).Once()
+
+	deleted, err := d.usecase.PruneImports(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	exists, err := d.storage.Exists(ctx, imp.File)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	d.repo.AssertExpectations(t)
+}
@@ -0,0 +1,301 @@
+package invitation
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type InvitationHandler struct {
+	usecase InvitationUsecase
+}
+
+func NewInvitationHandler(usecase InvitationUsecase) *InvitationHandler {
+	return &InvitationHandler{
+		usecase: usecase,
+	}
+}
+
+// InviteMember godoc
+// @Summary Invite a member
+// @Description Email an invitation to join the organization with a role; requires the admin role, or the owner role to invite owners
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Param request body entity.InviteMemberRequest true "Invitation"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/invitations [post]
+func (h *InvitationHandler) InviteMember(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	invitation, err := h.usecase.InviteMember(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to invite member", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to invite member", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "Invitation sent successfully", invitation)
+}
+
+// GetInvitations godoc
+// @Summary List pending invitations
+// @Description List the organization's invitations that were not accepted yet, expired ones included; requires the admin role
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/invitations [get]
+func (h *InvitationHandler) GetInvitations(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.usecase.GetInvitations(c.Request.Context(), orgID, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get invitations", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get invitations", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Invitations retrieved successfully", invitations)
+}
+
+// ResendInvitation godoc
+// @Summary Resend an invitation
+// @Description Email a pending invitation again with a new link and expiry; the previous link stops working
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/invitations/{invitation_id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid invitation ID", err.Error())
+		return
+	}
+
+	invitation, err := h.usecase.ResendInvitation(c.Request.Context(), orgID, userID, invitationID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to resend invitation", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to resend invitation", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Invitation resent successfully", invitation)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Delete a pending invitation so its link stops working
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/invitations/{invitation_id} [delete]
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid invitation ID", err.Error())
+		return
+	}
+
+	if err := h.usecase.RevokeInvitation(c.Request.Context(), orgID, userID, invitationID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to revoke invitation", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to revoke invitation", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Invitation revoked successfully", nil)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Join an organization with the token from an invitation email sent to the current user's address
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.AcceptInvitationRequest true "Invitation token"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	membership, err := h.usecase.AcceptInvitation(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to accept invitation", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to accept invitation", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Invitation accepted", membership)
+}
+
+// Register godoc
+// @Summary Register through an invitation
+// @Description Register the invited email address and join the organization with the invited role, signing in
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body entity.RegisterInvitationRequest true "Invitation token and new account"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/register/invitation [post]
+func (h *InvitationHandler) Register(c *gin.Context) {
+	var req entity.RegisterInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	registration, err := h.usecase.Register(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to register invitee", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to register user", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "User registered successfully", registration)
+}
+
+// orgAndUser reads the organization ID from the path and the authenticated
+// user, writing the error response when either is missing
+func orgAndUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid organization ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	return orgID, userID, ok
+}
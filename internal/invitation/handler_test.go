@@ -0,0 +1,164 @@
+package invitation_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/test/apitest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createOrganization creates an organization owned by the user
+func createOrganization(api *apitest.API, owner *entity.User) entity.Organization {
+	var org entity.Organization
+	api.As(owner).Post("/api/v1/organizations", entity.OrganizationRequest{Name: "Acme"}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&org)
+	return org
+}
+
+// storeInvitation stores an invitation with a known token, since the mailed
+// one is not readable here
+func storeInvitation(t *testing.T, api *apitest.API, orgID, invitedBy uuid.UUID, email string) string {
+	token := uuid.NewString()
+	sum := sha256.Sum256([]byte(token))
+	require.NoError(t, api.DB.Create(&entity.OrgInvitation{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           entity.OrgRoleMember,
+		TokenHash:      hex.EncodeToString(sum[:]),
+		InvitedBy:      invitedBy,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}).Error)
+	return token
+}
+
+func TestInvitationHandler_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	invitee := api.CreateUser()
+	org := createOrganization(api, owner)
+	token := storeInvitation(t, api, org.ID, owner.ID, invitee.Email)
+
+	// The invitation is for someone else
+	api.As(api.CreateUser()).Post("/api/v1/organizations/invitations/accept", entity.AcceptInvitationRequest{Token: token}).Do().
+		AssertStatus(http.StatusForbidden)
+
+	api.As(invitee).Post("/api/v1/organizations/invitations/accept", entity.AcceptInvitationRequest{Token: token}).Do().
+		AssertStatus(http.StatusOK)
+	api.As(invitee).Post("/api/v1/organizations/invitations/accept", entity.AcceptInvitationRequest{Token: token}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrInvitationInvalid)
+
+	// Members cannot invite
+	api.As(invitee).Post("/api/v1/organizations/"+org.ID.String()+"/invitations",
+		entity.InviteMemberRequest{Email: "someone@example.com", Role: entity.OrgRoleMember}).Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrOrganizationForbidden)
+}
+
+func TestInvitationHandler_RegisterThroughInvitation(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	org := createOrganization(api, owner)
+	email := "invitee_" + uuid.NewString()[:8] + "@example.com"
+	token := storeInvitation(t, api, org.ID, owner.ID, email)
+
+	req := entity.RegisterInvitationRequest{
+		Token:     token,
+		Username:  "invitee_" + uuid.NewString()[:8],
+		Password:  "password123",
+		FirstName: "Ivy",
+		LastName:  "Invitee",
+	}
+	var registration entity.InvitationRegistration
+	api.Post("/api/v1/auth/register/invitation", req).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&registration)
+
+	assert.Equal(t, email, registration.User.Email)
+	assert.Equal(t, org.ID, registration.Membership.OrganizationID)
+
+	var orgs []entity.Membership
+	api.WithToken(registration.Token).Get("/api/v1/organizations").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&orgs)
+	require.Len(t, orgs, 1)
+	assert.Equal(t, entity.OrgRoleMember, orgs[0].Role)
+
+	// The link works once
+	req.Username = "other_" + uuid.NewString()[:8]
+	api.Post("/api/v1/auth/register/invitation", req).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrInvitationInvalid)
+}
+
+func TestInvitationHandler_ResendAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	org := createOrganization(api, owner)
+	base := "/api/v1/organizations/" + org.ID.String() + "/invitations"
+
+	var invitation entity.OrgInvitation
+	api.As(owner).Post(base, entity.InviteMemberRequest{Email: "jane@example.com", Role: entity.OrgRoleAdmin}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&invitation)
+
+	var invitations []entity.OrgInvitation
+	api.As(owner).Get(base).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&invitations)
+	require.Len(t, invitations, 1)
+
+	var resent entity.OrgInvitation
+	api.As(owner).Post(base+"/"+invitation.ID.String()+"/resend", nil).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&resent)
+	assert.Equal(t, invitation.ID, resent.ID)
+
+	api.As(owner).Delete(base + "/" + invitation.ID.String()).Do().
+		AssertStatus(http.StatusOK)
+	api.As(owner).Delete(base + "/" + invitation.ID.String()).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrInvitationNotFound)
+
+	// Outsiders cannot list invitations
+	api.As(api.CreateUser()).Get(base).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrOrganizationNotFound)
+}
+
+func TestInvitationHandler_OrganizationByPublicID(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	org := createOrganization(api, owner)
+	require.NotNil(t, org.PublicID)
+	assert.Regexp(t, `^org_[0-9A-Za-z]{12}$`, *org.PublicID)
+
+	var invitations []entity.OrgInvitation
+	api.As(owner).Get("/api/v1/organizations/" + *org.PublicID + "/invitations").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&invitations)
+	assert.Empty(t, invitations)
+
+	// A well-formed public ID of no organization is not found
+	api.As(owner).Get("/api/v1/organizations/org_000000000000/invitations").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrOrganizationNotFound)
+}
@@ -0,0 +1,126 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package invitation
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInvitationRepository is a testify mock of InvitationRepository
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationRepository) GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	args := m.Called(ctx, orgID)
+
+	var r0 *entity.Organization
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Organization)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) IsMemberEmail(ctx context.Context, orgID uuid.UUID, email string) (bool, error) {
+	args := m.Called(ctx, orgID, email)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) IsMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) CreateInvitation(ctx context.Context, invitation *entity.OrgInvitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) GetInvitations(ctx context.Context, orgID uuid.UUID) ([]*entity.OrgInvitation, error) {
+	args := m.Called(ctx, orgID)
+
+	var r0 []*entity.OrgInvitation
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.OrgInvitation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetInvitation(ctx context.Context, orgID uuid.UUID, invitationID uuid.UUID) (*entity.OrgInvitation, error) {
+	args := m.Called(ctx, orgID, invitationID)
+
+	var r0 *entity.OrgInvitation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.OrgInvitation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*entity.OrgInvitation, error) {
+	args := m.Called(ctx, tokenHash)
+
+	var r0 *entity.OrgInvitation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.OrgInvitation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationRepository) RenewInvitation(ctx context.Context, invitation *entity.OrgInvitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	args := m.Called(ctx, invitationID)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) AcceptInvitation(ctx context.Context, invitation *entity.OrgInvitation, membership *entity.Membership) error {
+	args := m.Called(ctx, invitation, membership)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) DeleteExpiredInvitations(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,88 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package invitation
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInvitationUsecase is a testify mock of InvitationUsecase
+type MockInvitationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockInvitationUsecase) InviteMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, req *entity.InviteMemberRequest) (*entity.OrgInvitation, error) {
+	args := m.Called(ctx, orgID, userID, req)
+
+	var r0 *entity.OrgInvitation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.OrgInvitation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationUsecase) GetInvitations(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) ([]*entity.OrgInvitation, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 []*entity.OrgInvitation
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.OrgInvitation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationUsecase) ResendInvitation(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, invitationID uuid.UUID) (*entity.OrgInvitation, error) {
+	args := m.Called(ctx, orgID, userID, invitationID)
+
+	var r0 *entity.OrgInvitation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.OrgInvitation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationUsecase) RevokeInvitation(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, invitationID uuid.UUID) error {
+	args := m.Called(ctx, orgID, userID, invitationID)
+	return args.Error(0)
+}
+
+func (m *MockInvitationUsecase) AcceptInvitation(ctx context.Context, userID uuid.UUID, req *entity.AcceptInvitationRequest) (*entity.Membership, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationUsecase) Register(ctx context.Context, req *entity.RegisterInvitationRequest) (*entity.InvitationRegistration, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.InvitationRegistration
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.InvitationRegistration)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockInvitationUsecase) PruneInvitations(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,26 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package invitation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrganizationRoles is a testify mock of OrganizationRoles
+type MockOrganizationRoles struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRoles) MemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 string
+	if v := args.Get(0); v != nil {
+		r0 = v.(string)
+	}
+
+	return r0, args.Error(1)
+}
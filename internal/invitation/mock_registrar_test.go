@@ -0,0 +1,27 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package invitation
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRegistrar is a testify mock of Registrar
+type MockRegistrar struct {
+	mock.Mock
+}
+
+func (m *MockRegistrar) Register(ctx context.Context, req *entity.RegisterRequest) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,50 @@
+package invitation
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationUsecase defines the business logic interface for organization
+// invitations
+type InvitationUsecase interface {
+	InviteMember(ctx context.Context, orgID, userID uuid.UUID, req *entity.InviteMemberRequest) (*entity.OrgInvitation, error)
+	GetInvitations(ctx context.Context, orgID, userID uuid.UUID) ([]*entity.OrgInvitation, error)
+	ResendInvitation(ctx context.Context, orgID, userID, invitationID uuid.UUID) (*entity.OrgInvitation, error)
+	RevokeInvitation(ctx context.Context, orgID, userID, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, userID uuid.UUID, req *entity.AcceptInvitationRequest) (*entity.Membership, error)
+	Register(ctx context.Context, req *entity.RegisterInvitationRequest) (*entity.InvitationRegistration, error)
+	PruneInvitations(ctx context.Context) (int64, error)
+}
+
+// InvitationRepository defines the data access interface for organization
+// invitations
+type InvitationRepository interface {
+	GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error)
+	IsMemberEmail(ctx context.Context, orgID uuid.UUID, email string) (bool, error)
+	IsMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error)
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	CreateInvitation(ctx context.Context, invitation *entity.OrgInvitation) error
+	GetInvitations(ctx context.Context, orgID uuid.UUID) ([]*entity.OrgInvitation, error)
+	GetInvitation(ctx context.Context, orgID, invitationID uuid.UUID) (*entity.OrgInvitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*entity.OrgInvitation, error)
+	RenewInvitation(ctx context.Context, invitation *entity.OrgInvitation) error
+	DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, invitation *entity.OrgInvitation, membership *entity.Membership) error
+	DeleteExpiredInvitations(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OrganizationRoles looks up the inviter's role in the organization,
+// implemented by organization.OrganizationUsecase. Non-members get
+// ErrOrganizationNotFoundError.
+type OrganizationRoles interface {
+	MemberRole(ctx context.Context, orgID, userID uuid.UUID) (string, error)
+}
+
+// Registrar registers invitees, implemented by auth.AuthUsecase
+type Registrar interface {
+	Register(ctx context.Context, req *entity.RegisterRequest) (*entity.AuthResponse, error)
+}
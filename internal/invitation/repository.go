@@ -0,0 +1,139 @@
+package invitation
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+func (r *invitationRepository) GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	var org entity.Organization
+	err := tenancy.Conn(ctx, r.db).Where("id = ?", orgID).First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// IsMemberEmail reports whether the user with the email is a member
+func (r *invitationRepository) IsMemberEmail(ctx context.Context, orgID uuid.UUID, email string) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Model(&entity.Membership{}).
+		Joins("JOIN tb_users u ON u.id = tb_organization_members.user_id AND u.deleted_at IS NULL").
+		Where("tb_organization_members.organization_id = ? AND LOWER(u.email) = LOWER(?)", orgID, email).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *invitationRepository) IsMember(ctx context.Context, orgID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Model(&entity.Membership{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *invitationRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *invitationRepository) CreateInvitation(ctx context.Context, invitation *entity.OrgInvitation) error {
+	return tenancy.Conn(ctx, r.db).Create(invitation).Error
+}
+
+// GetInvitations lists the organization's pending invitations, expired ones
+// included, newest first
+func (r *invitationRepository) GetInvitations(ctx context.Context, orgID uuid.UUID) ([]*entity.OrgInvitation, error) {
+	var invitations []*entity.OrgInvitation
+	err := tenancy.Conn(ctx, r.db).
+		Where("organization_id = ? AND accepted_at IS NULL", orgID).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *invitationRepository) GetInvitation(ctx context.Context, orgID, invitationID uuid.UUID) (*entity.OrgInvitation, error) {
+	var invitation entity.OrgInvitation
+	err := tenancy.Conn(ctx, r.db).Where("id = ? AND organization_id = ?", invitationID, orgID).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*entity.OrgInvitation, error) {
+	var invitation entity.OrgInvitation
+	err := tenancy.Conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// RenewInvitation stores the invitation's new token and expiry, unless it
+// was accepted meanwhile, which is reported as gorm.ErrRecordNotFound
+func (r *invitationRepository) RenewInvitation(ctx context.Context, invitation *entity.OrgInvitation) error {
+	result := tenancy.Conn(ctx, r.db).Model(&entity.OrgInvitation{}).
+		Where("id = ? AND accepted_at IS NULL", invitation.ID).
+		Updates(map[string]interface{}{
+			"token_hash": invitation.TokenHash,
+			"expires_at": invitation.ExpiresAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *invitationRepository) DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Delete(&entity.OrgInvitation{}, invitationID).Error
+}
+
+// AcceptInvitation marks the invitation accepted and adds the member. An
+// invitation accepted concurrently is reported as gorm.ErrRecordNotFound.
+func (r *invitationRepository) AcceptInvitation(ctx context.Context, invitation *entity.OrgInvitation, membership *entity.Membership) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.OrgInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Update("accepted_at", invitation.AcceptedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(membership).Error
+	})
+}
+
+// DeleteExpiredInvitations deletes invitations, accepted or not, that
+// expired before the time
+func (r *invitationRepository) DeleteExpiredInvitations(ctx context.Context, before time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("expires_at < ?", before).Delete(&entity.OrgInvitation{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,312 @@
+package invitation
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/mail"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type invitationUsecase struct {
+	repo      InvitationRepository
+	orgs      OrganizationRoles
+	registrar Registrar
+	config    *config.Config
+	mail      mail.Sender
+	clock     clock.Clock
+}
+
+func NewInvitationUsecase(repo InvitationRepository, orgs OrganizationRoles, registrar Registrar, config *config.Config, mail mail.Sender, clk clock.Clock) InvitationUsecase {
+	return &invitationUsecase{
+		repo:      repo,
+		orgs:      orgs,
+		registrar: registrar,
+		config:    config,
+		mail:      mail,
+		clock:     clk,
+	}
+}
+
+// InviteMember mails an invitation to join with the role. Admins invite
+// members and admins; inviting owners takes an owner.
+func (u *invitationUsecase) InviteMember(ctx context.Context, orgID, userID uuid.UUID, req *entity.InviteMemberRequest) (*entity.OrgInvitation, error) {
+	actorRole, err := u.authorize(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Role == entity.OrgRoleOwner && actorRole != entity.OrgRoleOwner {
+		return nil, errors.ErrOrganizationForbiddenError
+	}
+
+	org, err := u.repo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get organization", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to invite member", 500)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	isMember, err := u.repo.IsMemberEmail(ctx, orgID, email)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check membership", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to invite member", 500)
+	}
+	if isMember {
+		return nil, errors.ErrMemberExistsError
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+
+	invitation := &entity.OrgInvitation{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           req.Role,
+		TokenHash:      hashSecretToken(token),
+		InvitedBy:      userID,
+		ExpiresAt:      u.clock.Now().Add(u.config.Org.InviteTTL),
+	}
+	if err := u.repo.CreateInvitation(ctx, invitation); err != nil {
+		logger.FromContext(ctx).Error("Failed to create invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to invite member", 500)
+	}
+
+	if err := u.sendInvitation(org, invitation, token); err != nil {
+		logger.FromContext(ctx).Error("Failed to mail invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to send invitation email", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization invitation sent",
+		zap.String("organization_id", orgID.String()), zap.String("invitation_id", invitation.ID.String()))
+	return invitation, nil
+}
+
+// GetInvitations lists the organization's pending invitations for its admins
+func (u *invitationUsecase) GetInvitations(ctx context.Context, orgID, userID uuid.UUID) ([]*entity.OrgInvitation, error) {
+	if _, err := u.authorize(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := u.repo.GetInvitations(ctx, orgID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get invitations", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get invitations", 500)
+	}
+	return invitations, nil
+}
+
+// ResendInvitation mails a pending invitation again with a fresh token and
+// expiry; the link sent before stops working
+func (u *invitationUsecase) ResendInvitation(ctx context.Context, orgID, userID, invitationID uuid.UUID) (*entity.OrgInvitation, error) {
+	invitation, err := u.getPendingInvitation(ctx, orgID, userID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := u.repo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get organization", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to resend invitation", 500)
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to generate token", 500)
+	}
+	invitation.TokenHash = hashSecretToken(token)
+	invitation.ExpiresAt = u.clock.Now().Add(u.config.Org.InviteTTL)
+
+	if err := u.repo.RenewInvitation(ctx, invitation); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvitationAcceptedError
+		}
+		logger.FromContext(ctx).Error("Failed to renew invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to resend invitation", 500)
+	}
+
+	if err := u.sendInvitation(org, invitation, token); err != nil {
+		logger.FromContext(ctx).Error("Failed to mail invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to send invitation email", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization invitation resent",
+		zap.String("organization_id", orgID.String()), zap.String("invitation_id", invitation.ID.String()))
+	return invitation, nil
+}
+
+// RevokeInvitation deletes a pending invitation so its link stops working
+func (u *invitationUsecase) RevokeInvitation(ctx context.Context, orgID, userID, invitationID uuid.UUID) error {
+	invitation, err := u.getPendingInvitation(ctx, orgID, userID, invitationID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteInvitation(ctx, invitation.ID); err != nil {
+		logger.FromContext(ctx).Error("Failed to revoke invitation", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to revoke invitation", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization invitation revoked",
+		zap.String("organization_id", orgID.String()), zap.String("invitation_id", invitation.ID.String()))
+	return nil
+}
+
+// AcceptInvitation adds the user to the organization with the invited role.
+// The invitation is for one email address and can be used once.
+func (u *invitationUsecase) AcceptInvitation(ctx context.Context, userID uuid.UUID, req *entity.AcceptInvitationRequest) (*entity.Membership, error) {
+	invitation, err := u.usableInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to accept invitation", 500)
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errors.New(errors.ErrForbidden, "This invitation is for another email address", 403)
+	}
+
+	isMember, err := u.repo.IsMember(ctx, invitation.OrganizationID, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check membership", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to accept invitation", 500)
+	}
+	if isMember {
+		return nil, errors.ErrMemberExistsError
+	}
+
+	return u.accept(ctx, invitation, userID)
+}
+
+// Register creates an account for the invited email and joins the
+// organization with the invited role. The invitee is signed in, as after
+// registering normally. Emails that already have an account sign in and
+// accept instead.
+func (u *invitationUsecase) Register(ctx context.Context, req *entity.RegisterInvitationRequest) (*entity.InvitationRegistration, error) {
+	invitation, err := u.usableInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.registrar.Register(ctx, &entity.RegisterRequest{
+		Email:     invitation.Email,
+		Username:  req.Username,
+		Password:  req.Password,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := u.accept(ctx, invitation, session.User.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.InvitationRegistration{AuthResponse: session, Membership: membership}, nil
+}
+
+// PruneInvitations deletes invitations that expired longer ago than the
+// retention, so they can no longer be resent
+func (u *invitationUsecase) PruneInvitations(ctx context.Context) (int64, error) {
+	return u.repo.DeleteExpiredInvitations(ctx, u.clock.Now().Add(-u.config.Org.InviteRetention))
+}
+
+// authorize returns the user's role, failing unless they are an admin or
+// owner of the organization. Non-members get not found.
+func (u *invitationUsecase) authorize(ctx context.Context, orgID, userID uuid.UUID) (string, error) {
+	role, err := u.orgs.MemberRole(ctx, orgID, userID)
+	if err != nil {
+		return "", err
+	}
+	if slices.Index(entity.ValidOrgRoles, role) < slices.Index(entity.ValidOrgRoles, entity.OrgRoleAdmin) {
+		return "", errors.ErrOrganizationForbiddenError
+	}
+	return role, nil
+}
+
+// getPendingInvitation finds an invitation of the organization that was not
+// accepted yet, for an admin to manage; invitations of owners take an owner.
+// Expired ones are returned so they can be resent.
+func (u *invitationUsecase) getPendingInvitation(ctx context.Context, orgID, userID, invitationID uuid.UUID) (*entity.OrgInvitation, error) {
+	actorRole, err := u.authorize(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := u.repo.GetInvitation(ctx, orgID, invitationID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvitationNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get invitation", 500)
+	}
+	if invitation.Role == entity.OrgRoleOwner && actorRole != entity.OrgRoleOwner {
+		return nil, errors.ErrOrganizationForbiddenError
+	}
+	if invitation.AcceptedAt != nil {
+		return nil, errors.ErrInvitationAcceptedError
+	}
+	return invitation, nil
+}
+
+// usableInvitation finds the invitation of the token, failing unless it is
+// unused and unexpired
+func (u *invitationUsecase) usableInvitation(ctx context.Context, token string) (*entity.OrgInvitation, error) {
+	invitation, err := u.repo.GetInvitationByTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvitationInvalidError
+		}
+		logger.FromContext(ctx).Error("Failed to get invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to accept invitation", 500)
+	}
+	if invitation.AcceptedAt != nil || !u.clock.Now().Before(invitation.ExpiresAt) {
+		return nil, errors.ErrInvitationInvalidError
+	}
+	return invitation, nil
+}
+
+// accept uses up the invitation and adds the user as a member
+func (u *invitationUsecase) accept(ctx context.Context, invitation *entity.OrgInvitation, userID uuid.UUID) (*entity.Membership, error) {
+	now := u.clock.Now()
+	invitation.AcceptedAt = &now
+	membership := &entity.Membership{OrganizationID: invitation.OrganizationID, UserID: userID, Role: invitation.Role}
+	if err := u.repo.AcceptInvitation(ctx, invitation, membership); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvitationInvalidError
+		}
+		logger.FromContext(ctx).Error("Failed to accept invitation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to accept invitation", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization invitation accepted",
+		zap.String("organization_id", invitation.OrganizationID.String()), zap.String("user_id", userID.String()))
+	return membership, nil
+}
+
+func (u *invitationUsecase) sendInvitation(org *entity.Organization, invitation *entity.OrgInvitation, token string) error {
+	link := u.config.Org.InviteURL + "?" + url.Values{"token": {token}}.Encode()
+	return u.mail.SendEmail([]string{invitation.Email}, "You are invited to join "+org.Name,
+		fmt.Sprintf(`<p>You are invited to join <strong>%s</strong> as %s.</p>
+<p><a href="%s">Accept the invitation</a> before %s to register with this email address, or to join with the account you already have.</p>`,
+			html.EscapeString(org.Name), invitation.Role, html.EscapeString(link),
+			invitation.ExpiresAt.UTC().Format("2006-01-02 15:04 MST")), nil)
+}
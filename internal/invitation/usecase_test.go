@@ -0,0 +1,290 @@
+package invitation
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/mail"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type testDeps struct {
+	repo      *MockInvitationRepository
+	orgs      *MockOrganizationRoles
+	registrar *MockRegistrar
+	mail      *mail.ArrayMailer
+	clock     *clock.Fake
+	usecase   InvitationUsecase
+	orgID     uuid.UUID
+}
+
+func newTestUsecase() *testDeps {
+	cfg := &config.Config{
+		Org: config.OrganizationConfig{
+			InviteURL:       "https://app.example.com/invitations/accept",
+			InviteTTL:       7 * 24 * time.Hour,
+			InviteRetention: 30 * 24 * time.Hour,
+		},
+	}
+	d := &testDeps{
+		repo:      new(MockInvitationRepository),
+		orgs:      new(MockOrganizationRoles),
+		registrar: new(MockRegistrar),
+		mail:      mail.NewArrayMailer(&config.EmailConfig{}),
+		clock:     clock.NewFake(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)),
+		orgID:     uuid.New(),
+	}
+	d.usecase = NewInvitationUsecase(d.repo, d.orgs, d.registrar, cfg, d.mail, d.clock)
+	d.repo.On("GetOrganizationByID", mock.Anything, d.orgID).Return(&entity.Organization{ID: d.orgID, Name: "Acme"}, nil).Maybe()
+	return d
+}
+
+// member stubs the user's role in the organization
+func (d *testDeps) member(role string) uuid.UUID {
+	userID := uuid.New()
+	d.orgs.On("MemberRole", mock.Anything, d.orgID, userID).Return(role, nil)
+	return userID
+}
+
+// mailedToken reads the token from the invitation link of the last email
+func (d *testDeps) mailedToken(t *testing.T) string {
+	t.Helper()
+	sent := d.mail.Sent()
+	require.NotEmpty(t, sent)
+	body := sent[len(sent)-1].Body
+	link := body[strings.Index(body, "https://app.example.com/invitations/accept?"):]
+	link = link[:strings.Index(link, `"`)]
+	parsed, err := url.Parse(strings.ReplaceAll(link, "&amp;", "&"))
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func assertCode(t *testing.T, code string, err error) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestInvitationUsecase_InviteMember_MailsToken(t *testing.T) {
+	d := newTestUsecase()
+	adminID := d.member(entity.OrgRoleAdmin)
+	d.repo.On("IsMemberEmail", mock.Anything, d.orgID, "jane@example.com").Return(false, nil)
+
+	var stored *entity.OrgInvitation
+	d.repo.On("CreateInvitation", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.OrgInvitation) }).
+		Return(nil).Once()
+
+	invitation, err := d.usecase.InviteMember(context.Background(), d.orgID, adminID,
+		&entity.InviteMemberRequest{Email: " Jane@Example.com ", Role: entity.OrgRoleMember})
+
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", invitation.Email)
+	assert.Equal(t, d.clock.Now().Add(7*24*time.Hour), stored.ExpiresAt)
+	require.Len(t, d.mail.Sent(), 1)
+	assert.Equal(t, []string{"jane@example.com"}, d.mail.Sent()[0].To)
+	assert.Equal(t, stored.TokenHash, hashSecretToken(d.mailedToken(t)))
+}
+
+func TestInvitationUsecase_InviteMember_Forbidden(t *testing.T) {
+	tests := []struct {
+		name     string
+		actor    string
+		role     string
+		wantCode string
+	}{
+		{name: "member invites", actor: entity.OrgRoleMember, role: entity.OrgRoleMember, wantCode: errors.ErrOrganizationForbidden},
+		{name: "admin invites an owner", actor: entity.OrgRoleAdmin, role: entity.OrgRoleOwner, wantCode: errors.ErrOrganizationForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase()
+			actorID := d.member(tt.actor)
+
+			_, err := d.usecase.InviteMember(context.Background(), d.orgID, actorID,
+				&entity.InviteMemberRequest{Email: "jane@example.com", Role: tt.role})
+
+			assertCode(t, tt.wantCode, err)
+			assert.Empty(t, d.mail.Sent())
+		})
+	}
+}
+
+func TestInvitationUsecase_ResendInvitation_RenewsToken(t *testing.T) {
+	d := newTestUsecase()
+	adminID := d.member(entity.OrgRoleAdmin)
+	invitation := &entity.OrgInvitation{
+		ID:             uuid.New(),
+		OrganizationID: d.orgID,
+		Email:          "jane@example.com",
+		Role:           entity.OrgRoleMember,
+		TokenHash:      hashSecretToken("old"),
+		ExpiresAt:      d.clock.Now().Add(-time.Hour),
+	}
+	d.repo.On("GetInvitation", mock.Anything, d.orgID, invitation.ID).Return(invitation, nil)
+	d.repo.On("RenewInvitation", mock.Anything, invitation).Return(nil).Once()
+
+	resent, err := d.usecase.ResendInvitation(context.Background(), d.orgID, adminID, invitation.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, d.clock.Now().Add(7*24*time.Hour), resent.ExpiresAt)
+	assert.NotEqual(t, hashSecretToken("old"), resent.TokenHash)
+	assert.Equal(t, resent.TokenHash, hashSecretToken(d.mailedToken(t)))
+}
+
+func TestInvitationUsecase_RevokeInvitation(t *testing.T) {
+	acceptedAt := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		actor      string
+		invitation *entity.OrgInvitation
+		findErr    error
+		wantCode   string
+	}{
+		{name: "admin revokes", actor: entity.OrgRoleAdmin, invitation: &entity.OrgInvitation{Role: entity.OrgRoleAdmin}},
+		{name: "admin cannot revoke owner invitations", actor: entity.OrgRoleAdmin, invitation: &entity.OrgInvitation{Role: entity.OrgRoleOwner}, wantCode: errors.ErrOrganizationForbidden},
+		{name: "accepted", actor: entity.OrgRoleOwner, invitation: &entity.OrgInvitation{Role: entity.OrgRoleMember, AcceptedAt: &acceptedAt}, wantCode: errors.ErrInvitationAccepted},
+		{name: "unknown", actor: entity.OrgRoleOwner, findErr: gorm.ErrRecordNotFound, wantCode: errors.ErrInvitationNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase()
+			actorID := d.member(tt.actor)
+			invitationID := uuid.New()
+			if tt.invitation != nil {
+				tt.invitation.ID = invitationID
+			}
+			d.repo.On("GetInvitation", mock.Anything, d.orgID, invitationID).Return(tt.invitation, tt.findErr)
+			d.repo.On("DeleteInvitation", mock.Anything, invitationID).Return(nil).Maybe()
+
+			err := d.usecase.RevokeInvitation(context.Background(), d.orgID, actorID, invitationID)
+
+			if tt.wantCode != "" {
+				assertCode(t, tt.wantCode, err)
+				d.repo.AssertNotCalled(t, "DeleteInvitation", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			d.repo.AssertCalled(t, "DeleteInvitation", mock.Anything, invitationID)
+		})
+	}
+}
+
+func TestInvitationUsecase_AcceptInvitation(t *testing.T) {
+	d := newTestUsecase()
+	userID := uuid.New()
+	invitation := &entity.OrgInvitation{
+		ID:             uuid.New(),
+		OrganizationID: d.orgID,
+		Email:          "jane@example.com",
+		Role:           entity.OrgRoleAdmin,
+		ExpiresAt:      d.clock.Now().Add(time.Hour),
+	}
+	d.repo.On("GetInvitationByTokenHash", mock.Anything, hashSecretToken("token")).Return(invitation, nil)
+	d.repo.On("GetUserByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "Jane@example.com"}, nil)
+	d.repo.On("IsMember", mock.Anything, d.orgID, userID).Return(false, nil)
+	d.repo.On("AcceptInvitation", mock.Anything, invitation, mock.Anything).Return(nil).Once()
+
+	membership, err := d.usecase.AcceptInvitation(context.Background(), userID, &entity.AcceptInvitationRequest{Token: "token"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrgRoleAdmin, membership.Role)
+	assert.Equal(t, d.clock.Now(), *invitation.AcceptedAt)
+}
+
+func TestInvitationUsecase_AcceptInvitation_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		expires  time.Duration
+		accepted bool
+		wantCode string
+	}{
+		{name: "expired", email: "jane@example.com", expires: -time.Minute, wantCode: errors.ErrInvitationInvalid},
+		{name: "already accepted", email: "jane@example.com", expires: time.Hour, accepted: true, wantCode: errors.ErrInvitationInvalid},
+		{name: "another email", email: "john@example.com", expires: time.Hour, wantCode: errors.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase()
+			userID := uuid.New()
+			invitation := &entity.OrgInvitation{OrganizationID: d.orgID, Email: "jane@example.com", ExpiresAt: d.clock.Now().Add(tt.expires)}
+			if tt.accepted {
+				acceptedAt := d.clock.Now()
+				invitation.AcceptedAt = &acceptedAt
+			}
+			d.repo.On("GetInvitationByTokenHash", mock.Anything, hashSecretToken("token")).Return(invitation, nil)
+			d.repo.On("GetUserByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: tt.email}, nil)
+
+			_, err := d.usecase.AcceptInvitation(context.Background(), userID, &entity.AcceptInvitationRequest{Token: "token"})
+
+			assertCode(t, tt.wantCode, err)
+			d.repo.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInvitationUsecase_Register_UsesInvitedEmail(t *testing.T) {
+	d := newTestUsecase()
+	userID := uuid.New()
+	invitation := &entity.OrgInvitation{
+		ID:             uuid.New(),
+		OrganizationID: d.orgID,
+		Email:          "jane@example.com",
+		Role:           entity.OrgRoleMember,
+		ExpiresAt:      d.clock.Now().Add(time.Hour),
+	}
+	d.repo.On("GetInvitationByTokenHash", mock.Anything, hashSecretToken("token")).Return(invitation, nil)
+	d.registrar.On("Register", mock.Anything, mock.MatchedBy(func(req *entity.RegisterRequest) bool {
+		return req.Email == "jane@example.com" && req.Username == "jane"
+	})).Return(&entity.AuthResponse{User: &entity.User{ID: userID, Email: "jane@example.com"}, Token: "jwt"}, nil).Once()
+	d.repo.On("AcceptInvitation", mock.Anything, invitation, mock.MatchedBy(func(m *entity.Membership) bool {
+		return m.UserID == userID && m.Role == entity.OrgRoleMember
+	})).Return(nil).Once()
+
+	registration, err := d.usecase.Register(context.Background(), &entity.RegisterInvitationRequest{
+		Token: "token", Username: "jane", Password: "password123", FirstName: "Jane", LastName: "Doe",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "jwt", registration.Token)
+	assert.Equal(t, d.orgID, registration.Membership.OrganizationID)
+	d.registrar.AssertExpectations(t)
+	d.repo.AssertExpectations(t)
+}
+
+func TestInvitationUsecase_Register_InvalidToken(t *testing.T) {
+	d := newTestUsecase()
+	d.repo.On("GetInvitationByTokenHash", mock.Anything, hashSecretToken("token")).Return((*entity.OrgInvitation)(nil), gorm.ErrRecordNotFound)
+
+	_, err := d.usecase.Register(context.Background(), &entity.RegisterInvitationRequest{Token: "token"})
+
+	assertCode(t, errors.ErrInvitationInvalid, err)
+	d.registrar.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+}
+
+func TestInvitationUsecase_PruneInvitations(t *testing.T) {
+	d := newTestUsecase()
+	d.repo.On("DeleteExpiredInvitations", mock.Anything, d.clock.Now().Add(-30*24*time.Hour)).Return(int64(2), nil).Once()
+
+	deleted, err := d.usecase.PruneInvitations(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
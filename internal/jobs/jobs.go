@@ -0,0 +1,505 @@
+// internal/jobs/jobs.go - Application job handlers and scheduled tasks
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+
+	"go-clean-gin/internal/account"
+	"go-clean-gin/internal/avatar" // artisan:module avatar
+	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/export"  // artisan:module export
+	"go-clean-gin/internal/imports" // artisan:module imports
+	"go-clean-gin/internal/product"
+	"go-clean-gin/internal/productimage" // artisan:module productimage
+	"go-clean-gin/internal/reservation"  // artisan:module reservation
+	"go-clean-gin/pkg/archive"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/health"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/partition"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/retention"
+	"go-clean-gin/pkg/scheduler"
+	"go-clean-gin/pkg/tenancy"
+
+	"go.uber.org/zap"
+)
+
+// Job types
+const (
+	SendEmail   = "mail:send"
+	SendWebhook = "webhook:send"
+)
+
+// SendEmailPayload is the payload of a SendEmail job
+type SendEmailPayload struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// SendWebhookPayload is the payload of a SendWebhook job
+type SendWebhookPayload struct {
+	URL   string      `json:"url"`
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// webhookTimeout bounds a single webhook delivery attempt
+const webhookTimeout = 10 * time.Second
+
+// failedJobRetention is how long failed jobs are kept for inspection
+const failedJobRetention = 7 * 24 * time.Hour
+
+// RegisterHandlers registers all job handlers on the worker
+func RegisterHandlers(w *queue.Worker, c *container.Container) {
+	// Jobs run on the database of the tenant they were pushed for
+	handle := func(jobType string, handler queue.Handler) {
+		w.Handle(jobType, func(ctx context.Context, job *queue.Job) error {
+			ctx, release, err := c.Tenants.Acquire(tenancy.WithTenant(ctx, job.Tenant))
+			if err != nil {
+				return err
+			}
+			defer release()
+			return handler(ctx, job)
+		})
+	}
+
+	handle(SendEmail, func(ctx context.Context, job *queue.Job) error {
+		var payload SendEmailPayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		err := c.Mail.SendEmail(payload.To, payload.Subject, payload.Body, nil)
+		if errors.Is(err, health.ErrOpen) {
+			// The mail server is down: keep the email until the breaker
+			// lets a trial through instead of using up its attempts
+			return queue.Defer(max(c.Health.Breaker(container.BreakerMail).RetryAfter(), c.Config.Queue.Backoff), err)
+		}
+		return err
+	})
+
+	handle(SendWebhook, func(ctx context.Context, job *queue.Job) error {
+		var payload SendWebhookPayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return sendWebhook(ctx, payload)
+	})
+
+	handle(account.JobAnonymize, func(ctx context.Context, job *queue.Job) error {
+		var payload account.AnonymizePayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return c.AccountUsecase.AnonymizeAccount(ctx, payload.UserID)
+	})
+
+	handle(account.JobExport, func(ctx context.Context, job *queue.Job) error {
+		var payload account.ExportPayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+
+		link, err := c.AccountUsecase.BuildExport(ctx, payload.UserID, payload.Format)
+		if err != nil || link == nil {
+			return err
+		}
+
+		return c.Queue.Push(ctx, c.Config.Queue.Default, SendEmail, SendEmailPayload{
+			To:      []string{link.Email},
+			Subject: "Your data export is ready",
+			Body: fmt.Sprintf(`<p>Your data export is ready. <a href="%s">Download it</a> before %s.</p>`,
+				html.EscapeString(link.URL), link.ExpiresAt.UTC().Format(time.RFC1123)),
+		})
+	})
+
+	// artisan:module export
+	handle(export.JobBuild, func(ctx context.Context, job *queue.Job) error {
+		var payload export.BuildPayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return c.ExportUsecase.BuildExport(ctx, payload.ExportID)
+	})
+	// artisan:end
+
+	// artisan:module imports
+	handle(imports.JobValidate, func(ctx context.Context, job *queue.Job) error {
+		var payload imports.Payload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return c.ImportUsecase.ValidateImport(ctx, payload.ImportID)
+	})
+
+	handle(imports.JobCommit, func(ctx context.Context, job *queue.Job) error {
+		var payload imports.Payload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return c.ImportUsecase.ApplyImport(ctx, payload.ImportID)
+	})
+	// artisan:end
+
+	// artisan:module avatar
+	handle(avatar.JobThumbnail, func(ctx context.Context, job *queue.Job) error {
+		var payload avatar.ThumbnailPayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return c.AvatarUsecase.GenerateThumbnail(ctx, payload.UserID, payload.Path)
+	})
+	// artisan:end
+
+	// artisan:module productimage
+	handle(productimage.JobVariants, func(ctx context.Context, job *queue.Job) error {
+		var payload productimage.VariantsPayload
+		if err := job.Unmarshal(&payload); err != nil {
+			return err
+		}
+		return c.ProductImageUsecase.GenerateVariants(ctx, payload.ImageID)
+	})
+	// artisan:end
+}
+
+// RegisterListeners turns application events into queued work. Call it in
+// every process that dispatches events, after the container is built.
+func RegisterListeners(c *container.Container) {
+	c.Events.Listen(product.EventLowStock, func(ctx context.Context, event events.Event) error {
+		e := event.(product.LowStockEvent)
+
+		if c.Config.Stock.AlertEmail && e.OwnerEmail != "" {
+			err := c.Queue.Push(ctx, c.Config.Queue.Default, SendEmail, SendEmailPayload{
+				To:      []string{e.OwnerEmail},
+				Subject: fmt.Sprintf("Low stock: %s", e.Name),
+				Body: fmt.Sprintf("<p><strong>%s</strong> is running low: %d left (threshold %d).</p>",
+					html.EscapeString(e.Name), e.Stock, e.Threshold),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if c.Config.Stock.AlertWebhook != "" {
+			return c.Queue.Push(ctx, c.Config.Queue.Default, SendWebhook, SendWebhookPayload{
+				URL:   c.Config.Stock.AlertWebhook,
+				Event: e.EventName(),
+				Data: map[string]interface{}{
+					"product_id": e.ProductID,
+					"name":       e.Name,
+					"stock":      e.Stock,
+					"threshold":  e.Threshold,
+					"owner_id":   e.OwnerID,
+				},
+			})
+		}
+
+		return nil
+	})
+}
+
+// RegisterSchedule registers all scheduled tasks
+func RegisterSchedule(s *scheduler.Scheduler, c *container.Container) {
+	// Tasks on application data run on the main database and then on each
+	// tenant database
+	every := func(interval time.Duration, name string, run scheduler.TaskFunc) {
+		s.Every(interval, name, func(ctx context.Context) error {
+			return c.Tenants.Each(ctx, run)
+		})
+	}
+
+	every(c.Config.Stock.AlertInterval, "products:low-stock", func(ctx context.Context) error {
+		_, err := c.ProductUsecase.CheckLowStock(ctx)
+		return err
+	})
+
+	every(c.Config.Counter.FlushInterval, "products:flush-views", func(ctx context.Context) error {
+		_, err := c.ProductUsecase.FlushViews(ctx)
+		return err
+	})
+
+	// artisan:module reservation
+	every(c.Config.Reservation.ReapInterval, "reservations:expire", func(ctx context.Context) error {
+		_, err := c.ReservationUsecase.ExpireReservations(ctx)
+		return err
+	})
+	// artisan:end
+
+	// artisan:module report
+	every(c.Config.Report.RefreshInterval, "reports:refresh-views", func(ctx context.Context) error {
+		return c.ReportUsecase.RefreshViews(ctx)
+	})
+	// artisan:end
+
+	every(c.Config.SavedSearch.CheckInterval, "saved-searches:check", func(ctx context.Context) error {
+		_, err := c.SavedSearchUsecase.CheckSavedSearches(ctx)
+		return err
+	})
+
+	every(time.Hour, "auth:prune-refresh-tokens", func(ctx context.Context) error {
+		deleted, err := c.AuthUsecase.PruneRefreshTokens(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired refresh tokens", zap.Int64("deleted", deleted))
+		}
+		return nil
+	})
+
+	// artisan:module oidc
+	every(time.Hour, "oidc:prune-codes", func(ctx context.Context) error {
+		deleted, err := c.OIDCUsecase.PruneCodes(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired OIDC authorization codes", zap.Int64("deleted", deleted))
+		}
+		return nil
+	})
+	// artisan:end
+
+	// artisan:module sso
+	every(time.Hour, "sso:prune-logins", func(ctx context.Context) error {
+		deleted, err := c.SSOUsecase.PruneLogins(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired SSO logins", zap.Int64("deleted", deleted))
+		}
+		return nil
+	})
+	// artisan:end
+
+	every(time.Hour, "account:prune-exports", func(ctx context.Context) error {
+		deleted, err := c.AccountUsecase.PruneExports(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired account exports", zap.Int("deleted", deleted))
+		}
+		return nil
+	})
+
+	// artisan:module export
+	every(time.Hour, "exports:prune", func(ctx context.Context) error {
+		deleted, err := c.ExportUsecase.PruneExports(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired export jobs", zap.Int("deleted", deleted))
+		}
+		return nil
+	})
+	// artisan:end
+
+	// artisan:module imports
+	every(time.Hour, "imports:prune", func(ctx context.Context) error {
+		deleted, err := c.ImportUsecase.PruneImports(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired import jobs", zap.Int("deleted", deleted))
+		}
+		return nil
+	})
+	// artisan:end
+
+	// artisan:module invitation
+	every(time.Hour, "invitations:prune", func(ctx context.Context) error {
+		deleted, err := c.InvitationUsecase.PruneInvitations(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned expired organization invitations", zap.Int64("deleted", deleted))
+		}
+		return nil
+	})
+	// artisan:end
+
+	every(24*time.Hour, "quota:prune-usage", func(ctx context.Context) error {
+		deleted, err := c.QuotaUsecase.PruneUsage(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned old API usage counters", zap.Int64("deleted", deleted))
+		}
+		return nil
+	})
+
+	// artisan:module activity
+	every(24*time.Hour, "activities:prune", func(ctx context.Context) error {
+		deleted, err := c.ActivityUsecase.PruneActivities(ctx)
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Info("Pruned old activities", zap.Int64("deleted", deleted))
+		}
+		return nil
+	})
+	// artisan:end
+
+	every(24*time.Hour, "partitions:maintain", func(ctx context.Context) error {
+		return maintainPartitions(ctx, c)
+	})
+
+	every(24*time.Hour, "archive:run", func(ctx context.Context) error {
+		_, err := Archive(ctx, c, "")
+		return err
+	})
+
+	every(24*time.Hour, "retention:purge", func(ctx context.Context) error {
+		_, err := Purge(ctx, c, "", c.Config.Retention.DryRun)
+		return err
+	})
+
+	if dbQueue, ok := c.Queue.(*queue.DatabaseQueue); ok {
+		s.Every(24*time.Hour, "queue:prune-failed", func(ctx context.Context) error {
+			deleted, err := dbQueue.PruneFailed(ctx, failedJobRetention)
+			if err != nil {
+				return err
+			}
+			logger.Info("Pruned failed jobs", zap.Int64("deleted", deleted))
+			return nil
+		})
+	}
+}
+
+// partitionedTables are the tables partitioned by month of created_at, with
+// how long their rows are kept
+func partitionedTables(c *container.Container) map[partition.Table]time.Duration {
+	return map[partition.Table]time.Duration{
+		{Name: entity.AuditLog{}.TableName(), Column: "created_at", Interval: partition.Monthly}: c.Config.Retention.AuditLogs,
+		{Name: entity.Activity{}.TableName(), Column: "created_at", Interval: partition.Monthly}: c.Config.Activity.Retention, // artisan:module activity
+	}
+}
+
+// maintainPartitions creates the coming months' partitions of the
+// partitioned tables and drops the expired ones
+func maintainPartitions(ctx context.Context, c *container.Container) error {
+	db := tenancy.Conn(ctx, c.DB)
+	now := c.Clock.Now()
+	for table, retention := range partitionedTables(c) {
+		created, dropped, err := partition.Maintain(db, table, now, c.Config.Partition.Premake, retention)
+		if err != nil {
+			return fmt.Errorf("partitions of %s: %w", table.Name, err)
+		}
+		if created > 0 || dropped > 0 {
+			logger.Info("Maintained partitions", zap.String("table", table.Name),
+				zap.Int("created", created), zap.Int("dropped", dropped))
+		}
+	}
+	return nil
+}
+
+// archivedTables are the tables whose old rows are archived, with the age
+// rows are archived at; 0 keeps them
+func archivedTables(c *container.Container) map[archive.Table]time.Duration {
+	return map[archive.Table]time.Duration{
+		reservation.ArchiveTable: c.Config.Archive.ReservationsAfter, // artisan:module reservation
+	}
+}
+
+// Archive moves the rows old enough to archive out of the archived tables, or
+// only out of the table named only, and returns how many it moved by table
+func Archive(ctx context.Context, c *container.Container, only string) (map[string]int64, error) {
+	now := c.Clock.Now()
+	moved := make(map[string]int64)
+	for table, after := range archivedTables(c) {
+		if after <= 0 || (only != "" && table.Name != only) {
+			continue
+		}
+		n, err := c.Archiver.Archive(ctx, table, now.Add(-after))
+		moved[table.Name] = n
+		if err != nil {
+			return moved, fmt.Errorf("archiving %s: %w", table.Name, err)
+		}
+		if n > 0 {
+			logger.Info("Archived rows", zap.String("table", table.Name), zap.Int64("rows", n))
+		}
+	}
+	return moved, nil
+}
+
+// retentionRules are the rules of the retention task, with how long each
+// table's rows are kept
+func retentionRules(c *container.Container) []retention.Rule {
+	return []retention.Rule{
+		{Name: "audit_logs", Table: entity.AuditLog{}.TableName(), Column: "created_at", After: c.Config.Retention.AuditLogs},
+		{Name: "notifications", Table: entity.Notification{}.TableName(), Column: "created_at", After: c.Config.Retention.Notifications}, // artisan:module notification
+		{Name: "deleted_products", Table: entity.Product{}.TableName(), Column: "deleted_at", After: c.Config.Retention.DeletedProducts},
+	}
+}
+
+// Purge deletes the rows past their retention for each retention rule, or
+// only for the rule named only, and returns how many it deleted by rule. With
+// dryRun it deletes nothing and returns how many rows it would delete.
+func Purge(ctx context.Context, c *container.Container, only string, dryRun bool) (map[string]int64, error) {
+	now := c.Clock.Now()
+	deleted := make(map[string]int64)
+	for _, rule := range retentionRules(c) {
+		if rule.After <= 0 || (only != "" && rule.Name != only) {
+			continue
+		}
+		n, err := c.Purger.Purge(ctx, rule, now, dryRun)
+		deleted[rule.Name] = n
+		if err != nil {
+			return deleted, fmt.Errorf("purging %s: %w", rule.Name, err)
+		}
+		if dryRun {
+			logger.Info("Rows past their retention (dry run)", zap.String("rule", rule.Name), zap.Int64("rows", n))
+		} else if n > 0 {
+			logger.Info("Purged rows past their retention", zap.String("rule", rule.Name), zap.Int64("rows", n))
+		}
+	}
+	return deleted, nil
+}
+
+// sendWebhook POSTs the event as JSON; any non-2xx response fails the attempt
+// so the queue retries it
+func sendWebhook(ctx context.Context, payload SendWebhookPayload) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event": payload.Event,
+		"data":  payload.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, payload.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", payload.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go-clean-gin/internal/audit"
+	"go-clean-gin/internal/entity"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Audit records every write request (POST, PUT, PATCH, DELETE) in the audit
+// trail once it has been handled, with the user set by AuthMiddleware as the
+// actor. Reads are not recorded. A failure to record is logged and does not
+// affect the response.
+func Audit(usecase audit.AuditUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		log := &entity.AuditLog{
+			Method:    c.Request.Method,
+			Route:     route,
+			Path:      c.Request.URL.Path,
+			Status:    c.Writer.Status(),
+			IP:        c.ClientIP(),
+			RequestID: c.GetString("request_id"),
+		}
+		if userID, err := uuid.Parse(c.GetString("user_id")); err == nil {
+			log.ActorID = &userID
+		}
+
+		// Usecase errors are logged there
+		_ = usecase.Record(c.Request.Context(), log)
+	}
+}
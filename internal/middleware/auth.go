@@ -1,24 +1,34 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
-	"go-clean-gin/internal/auth"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/rls"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-func AuthMiddleware(authUsecase auth.AuthUsecase) gin.HandlerFunc {
+// TokenValidator resolves an access token to its user, as auth.AuthUsecase does
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*entity.User, error)
+}
+
+// AuthMiddleware authenticates the request's bearer token and sets the user
+// as "user" and their ID as "user_id"; handlers read the ID with
+// CurrentUserID
+func AuthMiddleware(authUsecase TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Authorization header is required",
-			})
+			response.Error(c, http.StatusUnauthorized, errors.ErrUnauthorized, "Authorization header is required", nil)
 			c.Abort()
 			return
 		}
@@ -26,10 +36,7 @@ func AuthMiddleware(authUsecase auth.AuthUsecase) gin.HandlerFunc {
 		// Check if token starts with "Bearer "
 		tokenParts := strings.SplitN(authHeader, " ", 2)
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid authorization header format",
-			})
+			response.Error(c, http.StatusUnauthorized, errors.ErrUnauthorized, "Invalid authorization header format", nil)
 			c.Abort()
 			return
 		}
@@ -37,11 +44,8 @@ func AuthMiddleware(authUsecase auth.AuthUsecase) gin.HandlerFunc {
 		token := tokenParts[1]
 		user, err := authUsecase.ValidateToken(c.Request.Context(), token)
 		if err != nil {
-			logger.Error("Token validation failed", zap.Error(err))
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid or expired token",
-			})
+			logger.FromContext(c.Request.Context()).Error("Token validation failed", zap.Error(err))
+			response.Error(c, http.StatusUnauthorized, errors.ErrUnauthorized, "Invalid or expired token", nil)
 			c.Abort()
 			return
 		}
@@ -49,6 +53,47 @@ func AuthMiddleware(authUsecase auth.AuthUsecase) gin.HandlerFunc {
 		// Set user information in context
 		c.Set("user_id", user.ID.String())
 		c.Set("user", user)
+		ctx := logger.With(c.Request.Context(), zap.String("user_id", user.ID.String()))
+		c.Request = c.Request.WithContext(rls.WithUser(ctx, user.ID.String(), user.Role))
 		c.Next()
 	}
 }
+
+// CurrentUserID returns the ID of the user set by AuthMiddleware. When there
+// is none it writes the error response and returns false.
+func CurrentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, 401, errors.ErrUnauthorized, "User not found in context", nil)
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid user ID", err.Error())
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// RequireRole allows the request only when the user set by AuthMiddleware has
+// one of roles. Use it after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := c.Get("user")
+		if ok {
+			if u, isUser := user.(*entity.User); isUser {
+				for _, role := range roles {
+					if u.Role == role {
+						c.Next()
+						return
+					}
+				}
+			}
+		}
+
+		response.Error(c, http.StatusForbidden, errors.ErrForbidden, "You do not have permission to access this resource", nil)
+		c.Abort()
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	id := uuid.New()
+
+	tests := []struct {
+		name     string
+		userID   any
+		wantOK   bool
+		wantCode int
+	}{
+		{name: "set by AuthMiddleware", userID: id.String(), wantOK: true, wantCode: http.StatusOK},
+		{name: "missing", wantCode: http.StatusUnauthorized},
+		{name: "invalid", userID: "not-a-uuid", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			if tt.userID != nil {
+				c.Set("user_id", tt.userID)
+			}
+
+			got, ok := CurrentUserID(c)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantOK {
+				assert.Equal(t, id, got)
+			} else {
+				assert.Equal(t, uuid.Nil, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/metrics"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ConcurrencyLimit lets at most limit requests through the route group at
+// once. A request over the limit waits up to wait for a slot and is then
+// rejected with 429 and Retry-After. Each call creates its own limit, so use
+// one per group. A limit of 0 disables it.
+func ConcurrencyLimit(group string, limit int, wait time.Duration) gin.HandlerFunc {
+	if limit <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	slots := make(chan struct{}, limit)
+
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			if !waitForSlot(c, slots, wait) {
+				logger.FromContext(c.Request.Context()).Warn("Concurrency limit reached",
+					zap.String("group", group), zap.Int("limit", limit))
+				metrics.RequestsOverConcurrency.WithLabelValues(group).Inc()
+
+				seconds := int(math.Max(1, math.Ceil(wait.Seconds())))
+				c.Header("Retry-After", strconv.Itoa(seconds))
+
+				appErr := errors.ErrTooManyConcurrentError
+				response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, gin.H{"retry_after": seconds})
+				c.Abort()
+				return
+			}
+		}
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
+
+// waitForSlot waits up to wait for a free slot, giving up early when the
+// client goes away
+func waitForSlot(c *gin.Context, slots chan struct{}, wait time.Duration) bool {
+	if wait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Request.Context().Done():
+		return false
+	}
+}
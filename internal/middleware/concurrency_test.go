@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/notify"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newConcurrencyRouter(limit int, wait time.Duration) (*gin.Engine, chan struct{}, chan struct{}) {
+	gin.SetMode(gin.TestMode)
+	entered, release := make(chan struct{}), make(chan struct{})
+
+	router := gin.New()
+	router.Use(Recovery(notify.NullNotifier{}))
+	router.Use(ConcurrencyLimit("exports", limit, wait))
+	router.GET("/block", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router, entered, release
+}
+
+func TestConcurrencyLimit_WaitsForSlot(t *testing.T) {
+	router, entered, release := newConcurrencyRouter(1, 5*time.Second)
+
+	first := make(chan int)
+	go func() { first <- serve(router, "/block").Code }()
+	<-entered
+
+	second := make(chan int)
+	go func() { second <- serve(router, "/").Code }()
+
+	// Let the second request queue, then free the slot within its window
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	assert.Equal(t, http.StatusOK, <-first)
+	assert.Equal(t, http.StatusOK, <-second)
+}
+
+func TestConcurrencyLimit_RejectsAfterWindow(t *testing.T) {
+	router, entered, release := newConcurrencyRouter(1, 100*time.Millisecond)
+
+	first := make(chan int)
+	go func() { first <- serve(router, "/block").Code }()
+	<-entered
+
+	start := time.Now()
+	w := serve(router, "/")
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "the request waits out the window")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"retry_after":1`)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-first)
+	assert.Equal(t, http.StatusOK, serve(router, "/").Code, "the slot is released once the request finishes")
+}
+
+func TestConcurrencyLimit_RejectsWhenClientGoesAway(t *testing.T) {
+	router, entered, release := newConcurrencyRouter(1, time.Minute)
+	defer close(release)
+
+	go serve(router, "/block")
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestConcurrencyLimit_ReleasedAfterPanic(t *testing.T) {
+	router, _, _ := newConcurrencyRouter(1, 0)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusInternalServerError, serve(router, "/panic").Code)
+	}
+	assert.Equal(t, http.StatusOK, serve(router, "/").Code)
+}
+
+func TestConcurrencyLimit_Disabled(t *testing.T) {
+	router, entered, release := newConcurrencyRouter(0, 0)
+
+	done := make(chan int)
+	go func() { done <- serve(router, "/block").Code }()
+	<-entered
+
+	assert.Equal(t, http.StatusOK, serve(router, "/").Code)
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PendingConsents lists the policies a user has yet to accept, as
+// consent.ConsentUsecase does
+type PendingConsents interface {
+	Pending(ctx context.Context, userID uuid.UUID) ([]entity.Policy, error)
+}
+
+// RequireConsent allows the request only when the user set by AuthMiddleware
+// has accepted the current version of every required policy. Otherwise it
+// responds 403 CONSENT_REQUIRED listing the policies to accept. Use it after
+// AuthMiddleware.
+func RequireConsent(usecase PendingConsents) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := c.Get("user")
+		u, isUser := user.(*entity.User)
+		if !ok || !isUser {
+			response.Error(c, http.StatusUnauthorized, errors.ErrUnauthorized, "User not found in context", nil)
+			c.Abort()
+			return
+		}
+
+		pending, err := usecase.Pending(c.Request.Context(), u.ID)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("Failed to check consents", zap.Error(err))
+			response.Error(c, http.StatusInternalServerError, errors.ErrInternal, "Failed to check consents", nil)
+			c.Abort()
+			return
+		}
+
+		if len(pending) > 0 {
+			appErr := errors.ErrConsentRequiredError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, gin.H{"policies": pending})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
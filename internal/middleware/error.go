@@ -23,7 +23,7 @@ func ErrorHandler() gin.HandlerFunc {
 			switch e := err.Err.(type) {
 			case *errors.AppError:
 				// Handle application errors
-				logger.Error("Application error",
+				logger.FromContext(c.Request.Context()).Error("Application error",
 					zap.String("code", e.Code),
 					zap.String("message", e.Message),
 					zap.Int("status", e.StatusCode),
@@ -34,7 +34,7 @@ func ErrorHandler() gin.HandlerFunc {
 				response.Error(c, e.StatusCode, e.Code, e.Message, e.Details)
 			default:
 				// Handle unknown errors
-				logger.Error("Unknown error",
+				logger.FromContext(c.Request.Context()).Error("Unknown error",
 					zap.String("path", c.Request.URL.Path),
 					zap.Error(err.Err),
 				)
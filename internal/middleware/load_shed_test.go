@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/notify"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func newLoadShedRouter(maxInFlight int) (*gin.Engine, chan struct{}, chan struct{}) {
+	gin.SetMode(gin.TestMode)
+	entered, release := make(chan struct{}), make(chan struct{})
+
+	router := gin.New()
+	router.Use(Recovery(notify.NullNotifier{}))
+	router.Use(LoadShed(nil, config.LoadShedConfig{MaxInFlight: maxInFlight, Window: 10 * time.Second}, clock.New()))
+	router.GET("/block", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router, entered, release
+}
+
+func TestLoadShed_InFlightCap(t *testing.T) {
+	router, entered, release := newLoadShedRouter(1)
+
+	done := make(chan int)
+	go func() { done <- serve(router, "/block").Code }()
+	<-entered
+
+	w := serve(router, "/")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "10", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"retry_after":10`)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+
+	assert.Equal(t, http.StatusOK, serve(router, "/").Code, "the slot is released once the request finishes")
+}
+
+func TestLoadShed_ReleasedAfterPanic(t *testing.T) {
+	router, _, _ := newLoadShedRouter(1)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusInternalServerError, serve(router, "/panic").Code)
+	}
+	assert.Equal(t, http.StatusOK, serve(router, "/").Code)
+}
+
+func TestLoadShed_Disabled(t *testing.T) {
+	router, entered, release := newLoadShedRouter(0)
+
+	done := make(chan int)
+	go func() { done <- serve(router, "/block").Code }()
+	<-entered
+
+	assert.Equal(t, http.StatusOK, serve(router, "/").Code)
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+}
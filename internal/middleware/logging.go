@@ -9,8 +9,7 @@ import (
 
 func Logging() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		logger.Info("HTTP Request",
-			zap.String("method", param.Method),
+		logger.FromContext(param.Request.Context()).Info("HTTP Request",
 			zap.String("path", param.Path),
 			zap.Int("status", param.StatusCode),
 			zap.Duration("latency", param.Latency),
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/pages"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Maintenance rejects every request with 503 and Retry-After while
+// cfg.Enabled. Browsers get the maintenance page and API clients the usual
+// error. Health checks keep answering so orchestrators don't restart the
+// instance.
+func Maintenance(cfg config.MaintenanceConfig) gin.HandlerFunc {
+	seconds := int(math.Max(1, math.Ceil(cfg.RetryAfter.Seconds())))
+
+	return func(c *gin.Context) {
+		if !cfg.Enabled || strings.HasPrefix(c.Request.URL.Path, "/health") {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		appErr := errors.ErrMaintenanceError
+
+		if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+			pages.Maintenance(c, appErr.StatusCode, cfg.Message)
+			c.Abort()
+			return
+		}
+
+		details := gin.H{"retry_after": seconds}
+		if cfg.Message != "" {
+			details["message"] = cfg.Message
+		}
+		response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, details)
+		c.Abort()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	stderrors "errors"
+
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/publicid"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PublicID resolves the public ID of entity in the route parameter param to
+// the row's UUID before the handler parses it, so URLs may name the entity by
+// either. A public ID no row has becomes the nil UUID, which the handler
+// reports as not found like any unknown ID; values that are neither are left
+// for the handler to reject.
+func PublicID(resolver *publicid.Resolver, entity, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok, err := resolver.Resolve(c.Request.Context(), entity, c.Param(param))
+		if err != nil && !stderrors.Is(err, publicid.ErrNotFound) {
+			logger.FromContext(c.Request.Context()).Error("Failed to resolve public ID",
+				zap.String("entity", entity), zap.Error(err))
+			response.Error(c, 500, errors.ErrInternal, "Failed to resolve ID", nil)
+			c.Abort()
+			return
+		}
+		if !ok {
+			c.Next()
+			return
+		}
+		if err != nil {
+			id = uuid.Nil
+		}
+
+		for i := range c.Params {
+			if c.Params[i].Key == param {
+				c.Params[i].Value = id.String()
+			}
+		}
+		c.Next()
+	}
+}
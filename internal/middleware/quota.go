@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// QuotaConsumer counts a request against a user's quotas, as
+// quota.QuotaUsecase does
+type QuotaConsumer interface {
+	Consume(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error)
+}
+
+// Quota counts the request against the daily and monthly quotas of the user
+// set by AuthMiddleware and reports the usage in X-Quota-* headers. Once a
+// quota is used up it responds 429 QUOTA_EXCEEDED with Retry-After until the
+// quota resets. When the usage cannot be counted the request is let through.
+// Use it after AuthMiddleware.
+func Quota(usecase QuotaConsumer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := c.Get("user")
+		u, isUser := user.(*entity.User)
+		if !ok || !isUser {
+			response.Error(c, http.StatusUnauthorized, errors.ErrUnauthorized, "User not found in context", nil)
+			c.Abort()
+			return
+		}
+
+		usage, err := usecase.Consume(c.Request.Context(), u.ID)
+		if usage != nil {
+			setQuotaHeaders(c, "Daily", usage.Daily)
+			setQuotaHeaders(c, "Monthly", usage.Monthly)
+		}
+
+		if appErr, isAppErr := err.(*errors.AppError); isAppErr && appErr.Code == errors.ErrQuotaExceeded {
+			if details, ok := appErr.Details.(map[string]interface{}); ok {
+				if seconds, ok := details["retry_after"].(int); ok {
+					c.Header("Retry-After", strconv.Itoa(seconds))
+				}
+			}
+
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("Failed to count API usage", zap.Error(err))
+		}
+
+		c.Next()
+	}
+}
+
+// setQuotaHeaders writes a limited window's usage as X-Quota-<name>-* headers
+func setQuotaHeaders(c *gin.Context, name string, window entity.QuotaWindow) {
+	if window.Limit <= 0 {
+		return
+	}
+	c.Header("X-Quota-"+name+"-Limit", strconv.FormatInt(window.Limit, 10))
+	c.Header("X-Quota-"+name+"-Remaining", strconv.FormatInt(window.Remaining, 10))
+	c.Header("X-Quota-"+name+"-Reset", strconv.FormatInt(window.ResetAt.Unix(), 10))
+}
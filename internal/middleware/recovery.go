@@ -1,28 +1,54 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"runtime/debug"
 
+	"go-clean-gin/pkg/errors"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/notify"
+	"go-clean-gin/pkg/response"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func Recovery() gin.HandlerFunc {
+// Recovery turns a panic into a 500 response, logs it with the stack and
+// posts it to the notification channel in the background
+func Recovery(notifier notify.Notifier) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		logger.Error("Panic recovered",
+		log := logger.FromContext(c.Request.Context())
+		log.Error("Panic recovered",
 			zap.Any("error", recovered),
 			zap.String("path", c.Request.URL.Path),
-			zap.String("method", c.Request.Method),
 			zap.String("stack", string(debug.Stack())),
 		)
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Internal server error",
-			"message": "Something went wrong",
-		})
+		// The route, rather than the path, so panics of one handler are
+		// throttled together
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		msg := notify.Message{
+			Event:  notify.EventPanic,
+			Level:  notify.LevelError,
+			Title:  fmt.Sprintf("Panic in %s %s", c.Request.Method, route),
+			Text:   fmt.Sprint(recovered),
+			Fields: []notify.Field{{Name: "Path", Value: c.Request.URL.Path}},
+		}
+		if requestID := c.GetString("request_id"); requestID != "" {
+			msg.Fields = append(msg.Fields, notify.Field{Name: "Request ID", Value: requestID})
+		}
+		go func() {
+			if err := notifier.Notify(context.Background(), msg); err != nil {
+				log.Warn("Failed to post panic notification", zap.Error(err))
+			}
+		}()
+
+		response.Error(c, http.StatusInternalServerError, errors.ErrInternal, "Something went wrong", nil)
 		c.Abort()
 	})
 }
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"go-clean-gin/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader carries the request ID in from proxies and back to clients
+const RequestIDHeader = "X-Request-ID"
+
+// RequestLogger attaches a logger tagged with the request ID, method and route
+// to the request context. Handlers, usecases and repositories log through
+// logger.FromContext(ctx) so every line of a request can be correlated;
+// AuthMiddleware adds the user ID once the token is validated.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx := logger.With(c.Request.Context(),
+			zap.String("request_id", requestID),
+			zap.String("method", c.Request.Method),
+			zap.String("route", route),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
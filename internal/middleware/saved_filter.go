@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SavedFilters loads the filter of a saved search, as
+// savedsearch.SavedSearchUsecase does
+type SavedFilters interface {
+	GetFilter(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearchFilter, error)
+}
+
+// SavedFilter applies the saved search named by the saved_filter query
+// parameter of product listings: its filter becomes the query, and the other
+// parameters of the request override it. Requests without one pass through.
+func SavedFilter(usecase SavedFilters) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Read the URL directly: c.Query would cache the query before it is rewritten
+		query := c.Request.URL.Query()
+		id := query.Get("saved_filter")
+		if id == "" {
+			c.Next()
+			return
+		}
+
+		searchID, err := uuid.Parse(id)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, errors.ErrBadRequest, "Invalid saved filter ID", err.Error())
+			c.Abort()
+			return
+		}
+
+		filter, err := usecase.GetFilter(c.Request.Context(), searchID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.StatusCode < 500 {
+				response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+			} else {
+				logger.FromContext(c.Request.Context()).Error("Failed to load saved filter", zap.Error(err))
+				response.Error(c, http.StatusInternalServerError, errors.ErrInternal, "Failed to load saved filter", nil)
+			}
+			c.Abort()
+			return
+		}
+
+		values := filter.Values()
+		query.Del("saved_filter")
+		for key, v := range query {
+			values[key] = v
+		}
+		c.Request.URL.RawQuery = values.Encode()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/signedrequest"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxSignedBody caps the body read to check a signature
+const maxSignedBody = 10 << 20
+
+// SignedRequest checks the X-Signature-* headers of requests to routes of the
+// scope. Signed requests must carry a valid signature, a fresh timestamp and
+// a nonce not used before; unsigned ones are let through unless the verifier
+// requires signing for the scope. Rejected requests get 401
+// REQUEST_SIGNATURE_INVALID. The signing key's ID is set as "signing_key".
+func SignedRequest(verifier *signedrequest.Verifier, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !signedrequest.Signed(c.Request) && !verifier.Required(scope) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBody))
+			var tooLarge *http.MaxBytesError
+			if stderrors.As(err, &tooLarge) {
+				appErr := errors.ErrFileTooLargeError
+				response.Error(c, appErr.StatusCode, appErr.Code, "Request body is too large", nil)
+				c.Abort()
+				return
+			}
+			if err != nil {
+				response.Error(c, http.StatusBadRequest, errors.ErrBadRequest, "Failed to read request body", nil)
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		keyID, err := verifier.Verify(c.Request.Context(), c.Request, body, scope)
+		if err != nil && !signedrequest.Rejected(err) {
+			logger.FromContext(c.Request.Context()).Error("Failed to verify signed request", zap.Error(err))
+			response.Error(c, http.StatusInternalServerError, errors.ErrInternal, "Failed to verify the request signature", nil)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Rejected signed request",
+				zap.String("scope", scope), zap.String("key", c.GetHeader(signedrequest.KeyHeader)), zap.Error(err))
+
+			appErr := errors.ErrRequestSignatureInvalidError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			c.Abort()
+			return
+		}
+
+		c.Set("signing_key", keyID)
+		c.Next()
+	}
+}
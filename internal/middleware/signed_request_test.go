@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/signedrequest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingNonces fails every lookup, as a shared store that is down would
+type failingNonces struct{}
+
+func (failingNonces) Claim(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	return false, assert.AnError
+}
+
+func newSignedRequestRouter(t *testing.T, nonces signedrequest.NonceStore) (*gin.Engine, *clock.Fake) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	keys, err := signedrequest.ParseKeys([]string{"ci:admin|scim:secret"})
+	require.NoError(t, err)
+	clk := clock.NewFake(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	if nonces == nil {
+		nonces = signedrequest.NewMemoryNonceStore(clk)
+	}
+	verifier := signedrequest.NewVerifier(keys, nonces, 5*time.Minute, []string{"scim"}, clk)
+
+	router := gin.New()
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, c.GetString("signing_key")+":"+string(body))
+	}
+	router.POST("/admin", SignedRequest(verifier, "admin"), echo)
+	router.POST("/scim", SignedRequest(verifier, "scim"), echo)
+	return router, clk
+}
+
+func sendSigned(router *gin.Engine, clk clock.Clock, path, nonce, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	timestamp := strconv.FormatInt(clk.Now().Unix(), 10)
+	r.Header.Set(signedrequest.KeyHeader, "ci")
+	r.Header.Set(signedrequest.TimestampHeader, timestamp)
+	r.Header.Set(signedrequest.NonceHeader, nonce)
+	r.Header.Set(signedrequest.SignatureHeader, signedrequest.Sign([]byte("secret"), http.MethodPost, path, timestamp, nonce, []byte(body)))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestSignedRequest_Accepts(t *testing.T) {
+	router, clk := newSignedRequestRouter(t, nil)
+
+	w := sendSigned(router, clk, "/admin", "n1", `{"a":1}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `ci:{"a":1}`, w.Body.String(), "the handler gets the key and the body")
+}
+
+func TestSignedRequest_RejectsReplay(t *testing.T) {
+	router, clk := newSignedRequestRouter(t, nil)
+
+	require.Equal(t, http.StatusOK, sendSigned(router, clk, "/admin", "n1", `{}`).Code)
+
+	w := sendSigned(router, clk, "/admin", "n1", `{}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "REQUEST_SIGNATURE_INVALID")
+}
+
+func TestSignedRequest_RejectsTamperedBody(t *testing.T) {
+	router, clk := newSignedRequestRouter(t, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/admin", strings.NewReader(`{"a":2}`))
+	timestamp := strconv.FormatInt(clk.Now().Unix(), 10)
+	r.Header.Set(signedrequest.KeyHeader, "ci")
+	r.Header.Set(signedrequest.TimestampHeader, timestamp)
+	r.Header.Set(signedrequest.NonceHeader, "n1")
+	r.Header.Set(signedrequest.SignatureHeader, signedrequest.Sign([]byte("secret"), http.MethodPost, "/admin", timestamp, "n1", []byte(`{"a":1}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSignedRequest_Unsigned(t *testing.T) {
+	router, _ := newSignedRequestRouter(t, nil)
+
+	optional := httptest.NewRecorder()
+	router.ServeHTTP(optional, httptest.NewRequest(http.MethodPost, "/admin", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusOK, optional.Code, "signing is optional for admin")
+
+	required := httptest.NewRecorder()
+	router.ServeHTTP(required, httptest.NewRequest(http.MethodPost, "/scim", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusUnauthorized, required.Code, "signing is required for scim")
+}
+
+func TestSignedRequest_NonceStoreFailure(t *testing.T) {
+	router, clk := newSignedRequestRouter(t, failingNonces{})
+
+	w := sendSigned(router, clk, "/admin", "n1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/signedurl"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequireSignature allows the request only when its URL was signed by signer
+// and has not expired, and responds 403 LINK_INVALID otherwise. Use it on
+// routes reached through links handed out by email.
+func RequireSignature(signer *signedurl.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := signer.Verify(c.Request.URL); err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Rejected signed URL",
+				zap.String("path", c.Request.URL.Path), zap.Error(err))
+
+			appErr := errors.ErrLinkInvalidError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
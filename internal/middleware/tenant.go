@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Tenant runs the request against the database of the tenant named in the
+// header. Requests without the header stay on the main database, and tenants
+// not in the registry are rejected. Tokens are bound to the tenant they were
+// issued for, so the header can't move a user to another tenant's database.
+func Tenant(tenants *tenancy.Manager, header string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenant := c.GetHeader(header)
+		if tenant == "" {
+			c.Next()
+			return
+		}
+		if _, ok := tenants.Registry().DSN(tenant); !ok {
+			appErr := errors.ErrTenantNotFoundError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			c.Abort()
+			return
+		}
+
+		ctx, release, err := tenants.Acquire(tenancy.WithTenant(c.Request.Context(), tenant))
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Error("Failed to acquire tenant database",
+				zap.String("tenant", tenant), zap.Error(err))
+			appErr := errors.ErrTenantUnavailableError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			c.Abort()
+			return
+		}
+		defer release()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
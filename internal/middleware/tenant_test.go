@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTenantRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	registry, err := tenancy.NewRegistry([]tenancy.Tenant{{ID: "acme", DSN: "host=acme"}})
+	require.NoError(t, err)
+	open := func(dsn string) (*gorm.DB, error) {
+		return gorm.Open(postgres.New(postgres.Config{DSN: dsn}), &gorm.Config{DisableAutomaticPing: true})
+	}
+	tenants := tenancy.NewManager(registry, open, tenancy.Options{}, clock.New())
+
+	router := gin.New()
+	router.Use(Tenant(tenants, "X-Tenant-ID"))
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, tenancy.FromContext(c.Request.Context()))
+	})
+	return router
+}
+
+func TestTenant(t *testing.T) {
+	router := newTenantRouter(t)
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+		tenant string
+	}{
+		{name: "no header stays on the main database", status: http.StatusOK},
+		{name: "listed tenant", header: "acme", status: http.StatusOK, tenant: "acme"},
+		{name: "unknown tenant is rejected", header: "globex", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.tenant, recorder.Body.String())
+			} else {
+				assert.Contains(t, recorder.Body.String(), "TENANT_NOT_FOUND")
+			}
+		})
+	}
+}
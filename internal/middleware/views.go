@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CountViews records a view of the resource whose ID is in the route
+// parameter param, through record, for every request answered 200. Place it
+// after PublicID so public IDs are counted under the row's UUID. A failure to
+// record is logged by record and does not affect the response.
+func CountViews(record func(ctx context.Context, id uuid.UUID) error, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() != http.StatusOK {
+			return
+		}
+		id, err := uuid.Parse(c.Param(param))
+		if err != nil || id == uuid.Nil {
+			return
+		}
+
+		_ = record(c.Request.Context(), id)
+	}
+}
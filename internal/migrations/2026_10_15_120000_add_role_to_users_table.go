@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddRoleToUsersTable migration - Modify tb_users table
+type AddRoleToUsersTable struct{}
+
+// AddRoleToUsersTableRole represents the new column structure
+type AddRoleToUsersTableRole struct {
+	Role string `gorm:"not null;default:user"`
+}
+
+func (AddRoleToUsersTableRole) TableName() string {
+	return "tb_users"
+}
+
+// Up adds columns to the tb_users table
+func (m *AddRoleToUsersTable) Up(db *gorm.DB) error {
+	// Add role column
+	if err := db.Migrator().AddColumn(&AddRoleToUsersTableRole{}, "role"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Down removes columns from the tb_users table
+func (m *AddRoleToUsersTable) Down(db *gorm.DB) error {
+	// Drop role column
+	if err := db.Migrator().DropColumn(&AddRoleToUsersTableRole{}, "role"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Description returns migration description
+func (m *AddRoleToUsersTable) Description() string {
+	return "add_role_to_users_table"
+}
+
+// Version returns migration version
+func (m *AddRoleToUsersTable) Version() string {
+	return "2026_10_15_120000_add_role_to_users_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddRoleToUsersTable{})
+}
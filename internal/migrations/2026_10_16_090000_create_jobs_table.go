@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Job struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Queue       string    `gorm:"not null;index:idx_tb_jobs_queue_available,priority:1"`
+	Type        string    `gorm:"not null"`
+	Payload     string    `gorm:"type:jsonb;not null"`
+	Attempts    int       `gorm:"not null;default:0"`
+	MaxAttempts int       `gorm:"not null;default:3"`
+	AvailableAt time.Time `gorm:"not null;index:idx_tb_jobs_queue_available,priority:2"`
+	ReservedAt  *time.Time
+	FailedAt    *time.Time `gorm:"index"`
+	LastError   string     `gorm:"type:text"`
+	CreatedAt   time.Time
+}
+
+func (Job) TableName() string {
+	return "tb_jobs"
+}
+
+// CreateJobsTable migration - Create jobs table for the database queue driver
+type CreateJobsTable struct{}
+
+// Up creates the jobs table
+func (m *CreateJobsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Job{})
+}
+
+// Down drops the jobs table
+func (m *CreateJobsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Job{})
+}
+
+// Description returns migration description
+func (m *CreateJobsTable) Description() string {
+	return "Create jobs table"
+}
+
+// Version returns migration version
+func (m *CreateJobsTable) Version() string {
+	return "2026_10_16_090000_create_jobs_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateJobsTable{})
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Notification struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index:idx_tb_notifications_user_created,priority:1"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Type      string    `gorm:"not null"`
+	Payload   string    `gorm:"type:jsonb;not null;default:'{}'"`
+	ReadAt    *time.Time
+	CreatedAt time.Time `gorm:"index:idx_tb_notifications_user_created,priority:2"`
+}
+
+func (Notification) TableName() string {
+	return "tb_notifications"
+}
+
+// CreateNotificationsTable migration - Create notifications table for the in-app notification center
+type CreateNotificationsTable struct{}
+
+// Up creates the notifications table
+func (m *CreateNotificationsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Notification{})
+}
+
+// Down drops the notifications table
+func (m *CreateNotificationsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Notification{})
+}
+
+// Description returns migration description
+func (m *CreateNotificationsTable) Description() string {
+	return "Create notifications table"
+}
+
+// Version returns migration version
+func (m *CreateNotificationsTable) Version() string {
+	return "2026_10_16_100000_create_notifications_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateNotificationsTable{})
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AddLowStockToProductsTable migration - Modify tb_products table
+type AddLowStockToProductsTable struct{}
+
+// AddLowStockToProductsTableColumns represents the new column structure.
+// A NULL threshold falls back to STOCK_LOW_THRESHOLD; low_stock_alerted_at
+// keeps the scheduler from alerting twice for the same shortage.
+type AddLowStockToProductsTableColumns struct {
+	LowStockThreshold *int
+	LowStockAlertedAt *time.Time
+}
+
+func (AddLowStockToProductsTableColumns) TableName() string {
+	return "tb_products"
+}
+
+// Up adds columns to the tb_products table
+func (m *AddLowStockToProductsTable) Up(db *gorm.DB) error {
+	for _, column := range []string{"low_stock_threshold", "low_stock_alerted_at"} {
+		if err := db.Migrator().AddColumn(&AddLowStockToProductsTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down removes columns from the tb_products table
+func (m *AddLowStockToProductsTable) Down(db *gorm.DB) error {
+	for _, column := range []string{"low_stock_alerted_at", "low_stock_threshold"} {
+		if err := db.Migrator().DropColumn(&AddLowStockToProductsTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Description returns migration description
+func (m *AddLowStockToProductsTable) Description() string {
+	return "add_low_stock_to_products_table"
+}
+
+// Version returns migration version
+func (m *AddLowStockToProductsTable) Version() string {
+	return "2026_10_16_110000_add_low_stock_to_products_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddLowStockToProductsTable{})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"go-clean-gin/pkg/money"
+
+	"gorm.io/gorm"
+)
+
+// ConvertProductPriceToMoney migration - Replace the float price with an exact amount and currency
+type ConvertProductPriceToMoney struct{}
+
+// Up moves tb_products.price into price_amount (NUMERIC, rounded to cents) and
+// price_currency (the configured default currency), then drops the float column
+func (m *ConvertProductPriceToMoney) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []struct {
+			sql  string
+			args []interface{}
+		}{
+			{`ALTER TABLE tb_products ADD COLUMN price_amount NUMERIC(19,4), ADD COLUMN price_currency CHAR(3)`, nil},
+			{`UPDATE tb_products SET price_amount = ROUND(price::numeric, 2), price_currency = ?`, []interface{}{money.DefaultCurrency()}},
+			{`ALTER TABLE tb_products ALTER COLUMN price_amount SET NOT NULL, ALTER COLUMN price_currency SET NOT NULL`, nil},
+			{`ALTER TABLE tb_products DROP COLUMN price`, nil},
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement.sql, statement.args...).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down restores the float price column. Currencies are discarded.
+func (m *ConvertProductPriceToMoney) Down(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`ALTER TABLE tb_products ADD COLUMN price DOUBLE PRECISION`,
+			`UPDATE tb_products SET price = price_amount::double precision`,
+			`ALTER TABLE tb_products ALTER COLUMN price SET NOT NULL`,
+			`ALTER TABLE tb_products DROP COLUMN price_amount, DROP COLUMN price_currency`,
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Description returns migration description
+func (m *ConvertProductPriceToMoney) Description() string {
+	return "convert_product_price_to_money"
+}
+
+// Version returns migration version
+func (m *ConvertProductPriceToMoney) Version() string {
+	return "2026_10_16_120000_convert_product_price_to_money"
+}
+
+// Auto-register migration
+func init() {
+	Register(&ConvertProductPriceToMoney{})
+}
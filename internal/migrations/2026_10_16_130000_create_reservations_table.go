@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Reservation struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Quantity  int       `gorm:"not null;check:chk_tb_reservations_quantity,quantity > 0"`
+	Status    string    `gorm:"not null;default:active;index:idx_tb_reservations_status_expires,priority:1"`
+	ExpiresAt time.Time `gorm:"not null;index:idx_tb_reservations_status_expires,priority:2"`
+	ClosedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Reservation) TableName() string {
+	return "tb_reservations"
+}
+
+// CreateReservationsTable migration - Create reservations table for stock held in carts
+type CreateReservationsTable struct{}
+
+// Up creates the reservations table. Foreign keys are added in SQL: declaring
+// the Product relation would make AutoMigrate sync tb_products against the
+// original products migration struct.
+func (m *CreateReservationsTable) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&Reservation{}); err != nil {
+			return err
+		}
+
+		statements := []string{
+			`ALTER TABLE tb_reservations ADD CONSTRAINT fk_tb_reservations_product FOREIGN KEY (product_id) REFERENCES tb_products(id) ON DELETE CASCADE`,
+			`ALTER TABLE tb_reservations ADD CONSTRAINT fk_tb_reservations_user FOREIGN KEY (user_id) REFERENCES tb_users(id) ON DELETE CASCADE`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down drops the reservations table
+func (m *CreateReservationsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Reservation{})
+}
+
+// Description returns migration description
+func (m *CreateReservationsTable) Description() string {
+	return "Create reservations table"
+}
+
+// Version returns migration version
+func (m *CreateReservationsTable) Version() string {
+	return "2026_10_16_130000_create_reservations_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateReservationsTable{})
+}
@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type ProductReadModel struct {
+	ProductID     uuid.UUID        `gorm:"type:uuid;primary_key"`
+	Name          string           `gorm:"not null"`
+	Description   string           `gorm:"type:text"`
+	PriceAmount   decimal.Decimal  `gorm:"type:numeric(19,4);not null;index"`
+	PriceCurrency string           `gorm:"type:char(3);not null"`
+	Stock         int              `gorm:"not null;default:0"`
+	Category      string           `gorm:"not null;index"`
+	CategoryPath  string           `gorm:"not null"`
+	IsActive      bool             `gorm:"not null;default:true"`
+	CreatedBy     uuid.UUID        `gorm:"type:uuid;not null"`
+	OwnerName     string           `gorm:"not null"`
+	Rating        *decimal.Decimal `gorm:"type:numeric(3,2)"`
+	RatingCount   int              `gorm:"not null;default:0"`
+	CreatedAt     time.Time        `gorm:"index"`
+	UpdatedAt     time.Time
+}
+
+func (ProductReadModel) TableName() string {
+	return "tb_product_read_models"
+}
+
+// CreateProductReadModelsTable migration - Create the denormalized product listing read model
+type CreateProductReadModelsTable struct{}
+
+// Up creates the read model table and fills it from the current products
+func (m *CreateProductReadModelsTable) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&ProductReadModel{}); err != nil {
+			return err
+		}
+
+		statements := []string{
+			`ALTER TABLE tb_product_read_models ADD CONSTRAINT fk_tb_product_read_models_product FOREIGN KEY (product_id) REFERENCES tb_products(id) ON DELETE CASCADE`,
+			`INSERT INTO tb_product_read_models (
+				product_id, name, description, price_amount, price_currency, stock,
+				category, category_path, is_active, created_by, owner_name, created_at, updated_at
+			)
+			SELECT p.id, p.name, p.description, p.price_amount, p.price_currency, p.stock,
+				p.category, p.category, p.is_active, p.created_by, TRIM(u.first_name || ' ' || u.last_name),
+				p.created_at, p.updated_at
+			FROM tb_products p
+			JOIN tb_users u ON u.id = p.created_by
+			WHERE p.deleted_at IS NULL`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down drops the read model table
+func (m *CreateProductReadModelsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&ProductReadModel{})
+}
+
+// Description returns migration description
+func (m *CreateProductReadModelsTable) Description() string {
+	return "Create product read models table"
+}
+
+// Version returns migration version
+func (m *CreateProductReadModelsTable) Version() string {
+	return "2026_10_16_140000_create_product_read_models_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateProductReadModelsTable{})
+}
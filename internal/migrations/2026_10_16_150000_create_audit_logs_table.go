@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditLog struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID   *uuid.UUID `gorm:"type:uuid;index:idx_tb_audit_logs_actor_created,priority:1"`
+	Method    string     `gorm:"not null"`
+	Route     string     `gorm:"not null"`
+	Path      string     `gorm:"not null"`
+	Status    int        `gorm:"not null"`
+	IP        string
+	RequestID string
+	CreatedAt time.Time `gorm:"index;index:idx_tb_audit_logs_actor_created,priority:2"`
+}
+
+func (AuditLog) TableName() string {
+	return "tb_audit_logs"
+}
+
+// CreateAuditLogsTable migration - Create audit logs table for the admin audit trail
+type CreateAuditLogsTable struct{}
+
+// Up creates the audit logs table
+func (m *CreateAuditLogsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&AuditLog{})
+}
+
+// Down drops the audit logs table
+func (m *CreateAuditLogsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&AuditLog{})
+}
+
+// Description returns migration description
+func (m *CreateAuditLogsTable) Description() string {
+	return "Create audit logs table"
+}
+
+// Version returns migration version
+func (m *CreateAuditLogsTable) Version() string {
+	return "2026_10_16_150000_create_audit_logs_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateAuditLogsTable{})
+}
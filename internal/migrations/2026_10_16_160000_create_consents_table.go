@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Consent struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tb_consents_user_policy_version,priority:1"`
+	Policy     string    `gorm:"not null;uniqueIndex:idx_tb_consents_user_policy_version,priority:2"`
+	Version    string    `gorm:"not null;uniqueIndex:idx_tb_consents_user_policy_version,priority:3"`
+	IP         string
+	AcceptedAt time.Time `gorm:"not null"`
+}
+
+func (Consent) TableName() string {
+	return "tb_consents"
+}
+
+// CreateConsentsTable migration - Create consents table recording accepted policy versions
+type CreateConsentsTable struct{}
+
+// Up creates the consents table
+func (m *CreateConsentsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Consent{})
+}
+
+// Down drops the consents table
+func (m *CreateConsentsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Consent{})
+}
+
+// Description returns migration description
+func (m *CreateConsentsTable) Description() string {
+	return "Create consents table"
+}
+
+// Version returns migration version
+func (m *CreateConsentsTable) Version() string {
+	return "2026_10_16_160000_create_consents_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateConsentsTable{})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AddPendingEmailToUsersTable migration - Modify tb_users table
+type AddPendingEmailToUsersTable struct{}
+
+// AddPendingEmailToUsersTableColumns represents the new column structure. An
+// email change waits in pending_email until the hashed tokens mailed to the
+// current and the new address have both been used, or it expires.
+type AddPendingEmailToUsersTableColumns struct {
+	PendingEmail          *string
+	PendingEmailExpiresAt *time.Time
+	EmailChangeOldToken   string `gorm:"index:idx_tb_users_email_change_old_token"`
+	EmailChangeNewToken   string `gorm:"index:idx_tb_users_email_change_new_token"`
+}
+
+func (AddPendingEmailToUsersTableColumns) TableName() string {
+	return "tb_users"
+}
+
+// Up adds columns to the tb_users table
+func (m *AddPendingEmailToUsersTable) Up(db *gorm.DB) error {
+	for _, column := range []string{"pending_email", "pending_email_expires_at", "email_change_old_token", "email_change_new_token"} {
+		if err := db.Migrator().AddColumn(&AddPendingEmailToUsersTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	for _, index := range []string{"idx_tb_users_email_change_old_token", "idx_tb_users_email_change_new_token"} {
+		if err := db.Migrator().CreateIndex(&AddPendingEmailToUsersTableColumns{}, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down removes columns from the tb_users table
+func (m *AddPendingEmailToUsersTable) Down(db *gorm.DB) error {
+	for _, column := range []string{"email_change_new_token", "email_change_old_token", "pending_email_expires_at", "pending_email"} {
+		if err := db.Migrator().DropColumn(&AddPendingEmailToUsersTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Description returns migration description
+func (m *AddPendingEmailToUsersTable) Description() string {
+	return "add_pending_email_to_users_table"
+}
+
+// Version returns migration version
+func (m *AddPendingEmailToUsersTable) Version() string {
+	return "2026_10_16_170000_add_pending_email_to_users_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddPendingEmailToUsersTable{})
+}
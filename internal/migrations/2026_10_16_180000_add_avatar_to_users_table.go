@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddAvatarToUsersTable migration - Modify tb_users table
+type AddAvatarToUsersTable struct{}
+
+// AddAvatarToUsersTableColumns represents the new column structure
+type AddAvatarToUsersTableColumns struct {
+	AvatarURL       string
+	AvatarPath      string
+	AvatarThumbPath string
+}
+
+func (AddAvatarToUsersTableColumns) TableName() string {
+	return "tb_users"
+}
+
+// Up adds columns to the tb_users table
+func (m *AddAvatarToUsersTable) Up(db *gorm.DB) error {
+	for _, column := range []string{"avatar_url", "avatar_path", "avatar_thumb_path"} {
+		if err := db.Migrator().AddColumn(&AddAvatarToUsersTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Down removes columns from the tb_users table
+func (m *AddAvatarToUsersTable) Down(db *gorm.DB) error {
+	for _, column := range []string{"avatar_thumb_path", "avatar_path", "avatar_url"} {
+		if err := db.Migrator().DropColumn(&AddAvatarToUsersTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Description returns migration description
+func (m *AddAvatarToUsersTable) Description() string {
+	return "add_avatar_to_users_table"
+}
+
+// Version returns migration version
+func (m *AddAvatarToUsersTable) Version() string {
+	return "2026_10_16_180000_add_avatar_to_users_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddAvatarToUsersTable{})
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefreshToken struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash  string    `gorm:"not null;uniqueIndex"`
+	RememberMe bool      `gorm:"not null;default:false"`
+	StartedAt  time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	CreatedAt  time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "tb_refresh_tokens"
+}
+
+// CreateRefreshTokensTable migration - Create refresh tokens table backing sliding sessions
+type CreateRefreshTokensTable struct{}
+
+// Up creates the refresh tokens table
+func (m *CreateRefreshTokensTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&RefreshToken{})
+}
+
+// Down drops the refresh tokens table
+func (m *CreateRefreshTokensTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&RefreshToken{})
+}
+
+// Description returns migration description
+func (m *CreateRefreshTokensTable) Description() string {
+	return "Create refresh tokens table"
+}
+
+// Version returns migration version
+func (m *CreateRefreshTokensTable) Version() string {
+	return "2026_10_16_190000_create_refresh_tokens_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateRefreshTokensTable{})
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type APIUsage struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Period      string    `gorm:"primaryKey"`
+	PeriodStart time.Time `gorm:"primaryKey"`
+	Count       int64     `gorm:"not null;default:0"`
+}
+
+func (APIUsage) TableName() string {
+	return "tb_api_usage"
+}
+
+// CreateAPIUsageTable migration - Create API usage table counting requests per quota period
+type CreateAPIUsageTable struct{}
+
+// Up creates the API usage table
+func (m *CreateAPIUsageTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&APIUsage{})
+}
+
+// Down drops the API usage table
+func (m *CreateAPIUsageTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&APIUsage{})
+}
+
+// Description returns migration description
+func (m *CreateAPIUsageTable) Description() string {
+	return "Create API usage table"
+}
+
+// Version returns migration version
+func (m *CreateAPIUsageTable) Version() string {
+	return "2026_10_16_200000_create_api_usage_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateAPIUsageTable{})
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OIDCAuthorizationCode struct {
+	CodeHash      string    `gorm:"primaryKey"`
+	ClientID      string    `gorm:"not null"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	RedirectURI   string    `gorm:"not null"`
+	Scope         string    `gorm:"not null"`
+	Nonce         string
+	CodeChallenge string    `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	CreatedAt     time.Time
+}
+
+func (OIDCAuthorizationCode) TableName() string {
+	return "tb_oidc_authorization_codes"
+}
+
+// CreateOIDCAuthorizationCodesTable migration - Create table of authorization codes issued to OIDC clients
+type CreateOIDCAuthorizationCodesTable struct{}
+
+// Up creates the OIDC authorization codes table
+func (m *CreateOIDCAuthorizationCodesTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&OIDCAuthorizationCode{})
+}
+
+// Down drops the OIDC authorization codes table
+func (m *CreateOIDCAuthorizationCodesTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&OIDCAuthorizationCode{})
+}
+
+// Description returns migration description
+func (m *CreateOIDCAuthorizationCodesTable) Description() string {
+	return "Create OIDC authorization codes table"
+}
+
+// Version returns migration version
+func (m *CreateOIDCAuthorizationCodesTable) Version() string {
+	return "2026_10_16_210000_create_oidc_authorization_codes_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateOIDCAuthorizationCodesTable{})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SSOLogin struct {
+	StateHash    string    `gorm:"primaryKey"`
+	Connection   string    `gorm:"not null"`
+	Nonce        string    `gorm:"not null"`
+	CodeVerifier string    `gorm:"not null"`
+	RememberMe   bool      `gorm:"not null;default:false"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+func (SSOLogin) TableName() string {
+	return "tb_sso_logins"
+}
+
+// CreateSSOLoginsTable migration - Create table of sign-ins started with external identity providers
+type CreateSSOLoginsTable struct{}
+
+// Up creates the SSO logins table
+func (m *CreateSSOLoginsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&SSOLogin{})
+}
+
+// Down drops the SSO logins table
+func (m *CreateSSOLoginsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&SSOLogin{})
+}
+
+// Description returns migration description
+func (m *CreateSSOLoginsTable) Description() string {
+	return "Create SSO logins table"
+}
+
+// Version returns migration version
+func (m *CreateSSOLoginsTable) Version() string {
+	return "2026_10_16_220000_create_sso_logins_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateSSOLoginsTable{})
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddExternalIDToUsersTable migration - Modify tb_users table
+type AddExternalIDToUsersTable struct{}
+
+// AddExternalIDToUsersTableColumns represents the new column structure
+type AddExternalIDToUsersTableColumns struct {
+	ExternalID string `gorm:"index:idx_tb_users_external_id"`
+}
+
+func (AddExternalIDToUsersTableColumns) TableName() string {
+	return "tb_users"
+}
+
+// Up adds columns to the tb_users table
+func (m *AddExternalIDToUsersTable) Up(db *gorm.DB) error {
+	if err := db.Migrator().AddColumn(&AddExternalIDToUsersTableColumns{}, "external_id"); err != nil {
+		return err
+	}
+	return db.Migrator().CreateIndex(&AddExternalIDToUsersTableColumns{}, "idx_tb_users_external_id")
+}
+
+// Down removes columns from the tb_users table
+func (m *AddExternalIDToUsersTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropColumn(&AddExternalIDToUsersTableColumns{}, "external_id")
+}
+
+// Description returns migration description
+func (m *AddExternalIDToUsersTable) Description() string {
+	return "add_external_id_to_users_table"
+}
+
+// Version returns migration version
+func (m *AddExternalIDToUsersTable) Version() string {
+	return "2026_10_16_230000_add_external_id_to_users_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddExternalIDToUsersTable{})
+}
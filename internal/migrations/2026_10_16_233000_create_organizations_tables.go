@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Organization struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name      string    `gorm:"not null"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (Organization) TableName() string {
+	return "tb_organizations"
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	Role           string    `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (OrganizationMember) TableName() string {
+	return "tb_organization_members"
+}
+
+type OrganizationInvitation struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Email          string    `gorm:"not null"`
+	Role           string    `gorm:"not null"`
+	TokenHash      string    `gorm:"not null;uniqueIndex"`
+	InvitedBy      uuid.UUID `gorm:"type:uuid;not null"`
+	ExpiresAt      time.Time `gorm:"not null"`
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+}
+
+func (OrganizationInvitation) TableName() string {
+	return "tb_organization_invitations"
+}
+
+// CreateOrganizationsTables migration - Create organizations with their members and invitations
+type CreateOrganizationsTables struct{}
+
+// Up creates the organization tables. Foreign keys are added in SQL, as in
+// the reservations migration.
+func (m *CreateOrganizationsTables) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&Organization{}, &OrganizationMember{}, &OrganizationInvitation{}); err != nil {
+			return err
+		}
+
+		statements := []string{
+			`ALTER TABLE tb_organization_members ADD CONSTRAINT fk_tb_organization_members_organization FOREIGN KEY (organization_id) REFERENCES tb_organizations(id) ON DELETE CASCADE`,
+			`ALTER TABLE tb_organization_members ADD CONSTRAINT fk_tb_organization_members_user FOREIGN KEY (user_id) REFERENCES tb_users(id) ON DELETE CASCADE`,
+			`ALTER TABLE tb_organization_invitations ADD CONSTRAINT fk_tb_organization_invitations_organization FOREIGN KEY (organization_id) REFERENCES tb_organizations(id) ON DELETE CASCADE`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down drops the organization tables
+func (m *CreateOrganizationsTables) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&OrganizationInvitation{}, &OrganizationMember{}, &Organization{})
+}
+
+// Description returns migration description
+func (m *CreateOrganizationsTables) Description() string {
+	return "Create organizations, members and invitations tables"
+}
+
+// Version returns migration version
+func (m *CreateOrganizationsTables) Version() string {
+	return "2026_10_16_233000_create_organizations_tables"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateOrganizationsTables{})
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AddOrganizationToProductsTable migration - Modify tb_products and its read model
+type AddOrganizationToProductsTable struct{}
+
+// AddOrganizationToProductsTableColumns represents the new column structure
+type AddOrganizationToProductsTableColumns struct {
+	OrganizationID *uuid.UUID `gorm:"type:uuid;index:idx_tb_products_organization_id"`
+}
+
+func (AddOrganizationToProductsTableColumns) TableName() string {
+	return "tb_products"
+}
+
+// AddOrganizationToProductReadModelsColumns represents the new read model column
+type AddOrganizationToProductReadModelsColumns struct {
+	OrganizationID *uuid.UUID `gorm:"type:uuid;index:idx_tb_product_read_models_organization_id"`
+}
+
+func (AddOrganizationToProductReadModelsColumns) TableName() string {
+	return "tb_product_read_models"
+}
+
+// Up adds the owning organization to products and their read model. Existing
+// products stay owned by their creator alone.
+func (m *AddOrganizationToProductsTable) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().AddColumn(&AddOrganizationToProductsTableColumns{}, "organization_id"); err != nil {
+			return err
+		}
+		if err := tx.Migrator().CreateIndex(&AddOrganizationToProductsTableColumns{}, "idx_tb_products_organization_id"); err != nil {
+			return err
+		}
+		if err := tx.Exec(`ALTER TABLE tb_products ADD CONSTRAINT fk_tb_products_organization FOREIGN KEY (organization_id) REFERENCES tb_organizations(id)`).Error; err != nil {
+			return err
+		}
+		if err := tx.Migrator().AddColumn(&AddOrganizationToProductReadModelsColumns{}, "organization_id"); err != nil {
+			return err
+		}
+		return tx.Migrator().CreateIndex(&AddOrganizationToProductReadModelsColumns{}, "idx_tb_product_read_models_organization_id")
+	})
+}
+
+// Down removes the organization columns
+func (m *AddOrganizationToProductsTable) Down(db *gorm.DB) error {
+	if err := db.Migrator().DropColumn(&AddOrganizationToProductReadModelsColumns{}, "organization_id"); err != nil {
+		return err
+	}
+	return db.Migrator().DropColumn(&AddOrganizationToProductsTableColumns{}, "organization_id")
+}
+
+// Description returns migration description
+func (m *AddOrganizationToProductsTable) Description() string {
+	return "add_organization_to_products_table"
+}
+
+// Version returns migration version
+func (m *AddOrganizationToProductsTable) Version() string {
+	return "2026_10_16_234000_add_organization_to_products_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddOrganizationToProductsTable{})
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddTenantToJobsTable migration - Modify tb_jobs table
+type AddTenantToJobsTable struct{}
+
+// AddTenantToJobsTableColumns represents the new column structure
+type AddTenantToJobsTableColumns struct {
+	Tenant string `gorm:"not null;default:''"`
+}
+
+func (AddTenantToJobsTableColumns) TableName() string {
+	return "tb_jobs"
+}
+
+// Up adds columns to the tb_jobs table
+func (m *AddTenantToJobsTable) Up(db *gorm.DB) error {
+	return db.Migrator().AddColumn(&AddTenantToJobsTableColumns{}, "tenant")
+}
+
+// Down removes columns from the tb_jobs table
+func (m *AddTenantToJobsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropColumn(&AddTenantToJobsTableColumns{}, "tenant")
+}
+
+// Description returns migration description
+func (m *AddTenantToJobsTable) Description() string {
+	return "add_tenant_to_jobs_table"
+}
+
+// Version returns migration version
+func (m *AddTenantToJobsTable) Version() string {
+	return "2026_10_16_235000_add_tenant_to_jobs_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddTenantToJobsTable{})
+}
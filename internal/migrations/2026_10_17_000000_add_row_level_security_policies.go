@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddRowLevelSecurityPolicies migration - Row-level security on user-owned tables
+type AddRowLevelSecurityPolicies struct{}
+
+// rlsOwnedTables are the tables whose rows belong to the user in user_id.
+// Notifications are created for other users, e.g. product owners, so anyone
+// may insert them.
+var rlsOwnedTables = []struct {
+	table     string
+	anyInsert bool
+}{
+	{table: "tb_notifications", anyInsert: true}, // artisan:module notification
+	{table: "tb_reservations"},                   // artisan:module reservation
+	{table: "tb_consents"},
+	{table: "tb_api_usage"},
+}
+
+// Up enables row-level security keyed by the app.user_id session variable.
+// Rows are visible to their owner and to admins (app.user_role), and to
+// every session with app.rls_bypass on, as jobs, scheduled tasks and apps
+// running without DB_RLS connect. Otherwise, including when the variables
+// are unset, no row is. FORCE makes the policies apply to the table owner
+// too; superusers and BYPASSRLS roles still skip them. There is no tenant
+// policy: each tenant has its own database (pkg/tenancy), so rows never need
+// to be told apart by tenant.
+func (m *AddRowLevelSecurityPolicies) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`CREATE OR REPLACE FUNCTION app_rls_owner(owner uuid) RETURNS boolean
+			LANGUAGE sql STABLE AS $$
+				SELECT coalesce(current_setting('app.rls_bypass', true) = 'on', false)
+					OR coalesce(current_setting('app.user_role', true) = 'admin', false)
+					OR coalesce(owner::text = nullif(current_setting('app.user_id', true), ''), false)
+			$$`,
+		}
+		for _, owned := range rlsOwnedTables {
+			statements = append(statements,
+				fmt.Sprintf(`ALTER TABLE %s ENABLE ROW LEVEL SECURITY`, owned.table),
+				fmt.Sprintf(`ALTER TABLE %s FORCE ROW LEVEL SECURITY`, owned.table),
+				fmt.Sprintf(`CREATE POLICY %s_owner ON %s USING (app_rls_owner(user_id))`, owned.table, owned.table),
+			)
+			if owned.anyInsert {
+				statements = append(statements,
+					fmt.Sprintf(`CREATE POLICY %s_insert ON %s FOR INSERT WITH CHECK (true)`, owned.table, owned.table))
+			}
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down drops the policies and disables row-level security
+func (m *AddRowLevelSecurityPolicies) Down(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var statements []string
+		for _, owned := range rlsOwnedTables {
+			statements = append(statements,
+				fmt.Sprintf(`DROP POLICY IF EXISTS %s_insert ON %s`, owned.table, owned.table),
+				fmt.Sprintf(`DROP POLICY IF EXISTS %s_owner ON %s`, owned.table, owned.table),
+				fmt.Sprintf(`ALTER TABLE %s NO FORCE ROW LEVEL SECURITY`, owned.table),
+				fmt.Sprintf(`ALTER TABLE %s DISABLE ROW LEVEL SECURITY`, owned.table),
+			)
+		}
+		statements = append(statements, `DROP FUNCTION IF EXISTS app_rls_owner(uuid)`)
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Description returns migration description
+func (m *AddRowLevelSecurityPolicies) Description() string {
+	return "Add row-level security policies on user-owned tables"
+}
+
+// Version returns migration version
+func (m *AddRowLevelSecurityPolicies) Version() string {
+	return "2026_10_17_000000_add_row_level_security_policies"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddRowLevelSecurityPolicies{})
+}
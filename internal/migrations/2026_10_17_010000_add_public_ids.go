@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddPublicIDs migration - Short public IDs on entities addressed in URLs
+type AddPublicIDs struct{}
+
+// publicIDTables are the tables of the entities with public IDs. The product
+// read model carries its product's public ID without an index of its own.
+var publicIDTables = []struct {
+	table  string
+	unique bool
+}{
+	{table: "tb_products", unique: true},
+	{table: "tb_product_read_models"},
+	{table: "tb_organizations", unique: true},
+	{table: "tb_reservations", unique: true}, // artisan:module reservation
+}
+
+// Up adds the nullable public_id columns, unique where public IDs are looked
+// up. Existing rows get theirs from the publicid:backfill artisan command.
+func (m *AddPublicIDs) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var statements []string
+		for _, t := range publicIDTables {
+			statements = append(statements, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN public_id varchar(32)`, t.table))
+			if t.unique {
+				statements = append(statements,
+					fmt.Sprintf(`CREATE UNIQUE INDEX idx_%s_public_id ON %s (public_id)`, t.table, t.table))
+			}
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down drops the public_id columns and their indexes
+func (m *AddPublicIDs) Down(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range publicIDTables {
+			if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS public_id`, t.table)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Description returns migration description
+func (m *AddPublicIDs) Description() string {
+	return "Add public IDs to products, organizations and reservations"
+}
+
+// Version returns migration version
+func (m *AddPublicIDs) Version() string {
+	return "2026_10_17_010000_add_public_ids"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddPublicIDs{})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Setting struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Scope     string     `gorm:"not null;uniqueIndex:idx_tb_settings_scope_key,priority:1"`
+	ScopeID   string     `gorm:"not null;default:'';uniqueIndex:idx_tb_settings_scope_key,priority:2"`
+	Key       string     `gorm:"not null;uniqueIndex:idx_tb_settings_scope_key,priority:3"`
+	Value     string     `gorm:"type:text;not null"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Setting) TableName() string {
+	return "tb_settings"
+}
+
+type SettingChange struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Scope     string     `gorm:"not null"`
+	ScopeID   string     `gorm:"not null;default:''"`
+	Key       string     `gorm:"not null;index"`
+	OldValue  *string    `gorm:"type:text"`
+	NewValue  *string    `gorm:"type:text"`
+	ChangedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time  `gorm:"index"`
+}
+
+func (SettingChange) TableName() string {
+	return "tb_setting_changes"
+}
+
+// CreateSettingsTables migration - Create runtime settings and their change history
+type CreateSettingsTables struct{}
+
+// Up creates the settings tables. Changes keep no foreign key to the user who
+// made them, so the history outlives deleted accounts.
+func (m *CreateSettingsTables) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Setting{}, &SettingChange{})
+}
+
+// Down drops the settings tables
+func (m *CreateSettingsTables) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&SettingChange{}, &Setting{})
+}
+
+// Description returns migration description
+func (m *CreateSettingsTables) Description() string {
+	return "Create settings and setting changes tables"
+}
+
+// Version returns migration version
+func (m *CreateSettingsTables) Version() string {
+	return "2026_10_17_020000_create_settings_tables"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateSettingsTables{})
+}
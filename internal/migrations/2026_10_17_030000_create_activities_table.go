@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Activity struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_tb_activities_actor_created,priority:1"`
+	Actor      User       `gorm:"foreignKey:ActorID;constraint:OnDelete:CASCADE"`
+	Verb       string     `gorm:"not null"`
+	ObjectType string     `gorm:"not null;index:idx_tb_activities_object,priority:1"`
+	ObjectID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_tb_activities_object,priority:2"`
+	TargetType string     `gorm:"not null;default:'';index:idx_tb_activities_target,priority:1"`
+	TargetID   *uuid.UUID `gorm:"type:uuid;index:idx_tb_activities_target,priority:2"`
+	Data       string     `gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt  time.Time  `gorm:"index:idx_tb_activities_actor_created,priority:2;index"`
+}
+
+func (Activity) TableName() string {
+	return "tb_activities"
+}
+
+// CreateActivitiesTable migration - Create activities table for the activity feed
+type CreateActivitiesTable struct{}
+
+// Up creates the activities table. Objects and targets keep no foreign key,
+// so entries outlive the products and reservations they are about; a deleted
+// actor's entries go with them.
+func (m *CreateActivitiesTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Activity{})
+}
+
+// Down drops the activities table
+func (m *CreateActivitiesTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Activity{})
+}
+
+// Description returns migration description
+func (m *CreateActivitiesTable) Description() string {
+	return "Create activities table"
+}
+
+// Version returns migration version
+func (m *CreateActivitiesTable) Version() string {
+	return "2026_10_17_030000_create_activities_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateActivitiesTable{})
+}
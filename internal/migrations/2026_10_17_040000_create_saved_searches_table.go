@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedSearch struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Name      string    `gorm:"not null"`
+	Filter    string    `gorm:"type:jsonb;not null;default:'{}'"`
+	Notify    bool      `gorm:"not null;default:false"`
+	CheckedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SavedSearch) TableName() string {
+	return "tb_saved_searches"
+}
+
+// CreateSavedSearchesTable migration - Create saved searches table for named product filters
+type CreateSavedSearchesTable struct{}
+
+// Up creates the saved searches table
+func (m *CreateSavedSearchesTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&SavedSearch{})
+}
+
+// Down drops the saved searches table
+func (m *CreateSavedSearchesTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&SavedSearch{})
+}
+
+// Description returns migration description
+func (m *CreateSavedSearchesTable) Description() string {
+	return "Create saved searches table"
+}
+
+// Version returns migration version
+func (m *CreateSavedSearchesTable) Version() string {
+	return "2026_10_17_040000_create_saved_searches_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateSavedSearchesTable{})
+}
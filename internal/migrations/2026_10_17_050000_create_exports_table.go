@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Export struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	User        User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Kind        string    `gorm:"not null"`
+	Format      string    `gorm:"not null"`
+	Params      string    `gorm:"type:jsonb;not null;default:'{}'"`
+	Status      string    `gorm:"not null;default:'pending'"`
+	File        string    `gorm:"not null;default:''"`
+	Size        int64     `gorm:"not null;default:0"`
+	Error       string    `gorm:"not null;default:''"`
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (Export) TableName() string {
+	return "tb_exports"
+}
+
+// CreateExportsTable migration - Create exports table for background export jobs
+type CreateExportsTable struct{}
+
+// Up creates the exports table
+func (m *CreateExportsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Export{})
+}
+
+// Down drops the exports table
+func (m *CreateExportsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Export{})
+}
+
+// Description returns migration description
+func (m *CreateExportsTable) Description() string {
+	return "Create exports table"
+}
+
+// Version returns migration version
+func (m *CreateExportsTable) Version() string {
+	return "2026_10_17_050000_create_exports_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateExportsTable{})
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Import struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	User         User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Kind         string    `gorm:"not null"`
+	Filename     string    `gorm:"not null;default:''"`
+	File         string    `gorm:"not null"`
+	Status       string    `gorm:"not null;default:'pending'"`
+	TotalRows    int       `gorm:"not null;default:0"`
+	ValidRows    int       `gorm:"not null;default:0"`
+	InvalidRows  int       `gorm:"not null;default:0"`
+	ImportedRows int       `gorm:"not null;default:0"`
+	Errors       string    `gorm:"type:jsonb;not null;default:'[]'"`
+	Error        string    `gorm:"not null;default:''"`
+	CreatedAt    time.Time
+	ValidatedAt  *time.Time
+	CommittedAt  *time.Time
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
+
+func (Import) TableName() string {
+	return "tb_imports"
+}
+
+// CreateImportsTable migration - Create imports table for background import jobs
+type CreateImportsTable struct{}
+
+// Up creates the imports table
+func (m *CreateImportsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&Import{})
+}
+
+// Down drops the imports table
+func (m *CreateImportsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Import{})
+}
+
+// Description returns migration description
+func (m *CreateImportsTable) Description() string {
+	return "Create imports table"
+}
+
+// Version returns migration version
+func (m *CreateImportsTable) Version() string {
+	return "2026_10_17_060000_create_imports_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateImportsTable{})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductImage struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	URL          string    `gorm:"not null"`
+	Path         string    `gorm:"not null"`
+	Width        int       `gorm:"not null"`
+	Height       int       `gorm:"not null"`
+	Variants     string    `gorm:"type:jsonb;not null;default:'{}'"`
+	VariantPaths string    `gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt    time.Time
+}
+
+func (ProductImage) TableName() string {
+	return "tb_product_images"
+}
+
+// CreateProductImagesTable migration - Create product images table for uploaded images and their variants
+type CreateProductImagesTable struct{}
+
+// Up creates the product images table. The foreign key is added in SQL, as
+// for reservations, so tb_products isn't synced against an old struct.
+func (m *CreateProductImagesTable) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&ProductImage{}); err != nil {
+			return err
+		}
+		return tx.Exec(`ALTER TABLE tb_product_images ADD CONSTRAINT fk_tb_product_images_product FOREIGN KEY (product_id) REFERENCES tb_products(id) ON DELETE CASCADE`).Error
+	})
+}
+
+// Down drops the product images table
+func (m *CreateProductImagesTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&ProductImage{})
+}
+
+// Description returns migration description
+func (m *CreateProductImagesTable) Description() string {
+	return "Create product images table"
+}
+
+// Version returns migration version
+func (m *CreateProductImagesTable) Version() string {
+	return "2026_10_17_070000_create_product_images_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateProductImagesTable{})
+}
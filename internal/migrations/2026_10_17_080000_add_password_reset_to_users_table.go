@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AddPasswordResetToUsersTable migration - Modify tb_users table
+type AddPasswordResetToUsersTable struct{}
+
+// AddPasswordResetToUsersTableColumns represents the new column structure. A
+// reset link's token is stored hashed until it is used or expires.
+type AddPasswordResetToUsersTableColumns struct {
+	PasswordResetToken     string `gorm:"index:idx_tb_users_password_reset_token"`
+	PasswordResetExpiresAt *time.Time
+}
+
+func (AddPasswordResetToUsersTableColumns) TableName() string {
+	return "tb_users"
+}
+
+// Up adds columns to the tb_users table
+func (m *AddPasswordResetToUsersTable) Up(db *gorm.DB) error {
+	for _, column := range []string{"password_reset_token", "password_reset_expires_at"} {
+		if err := db.Migrator().AddColumn(&AddPasswordResetToUsersTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return db.Migrator().CreateIndex(&AddPasswordResetToUsersTableColumns{}, "idx_tb_users_password_reset_token")
+}
+
+// Down removes columns from the tb_users table
+func (m *AddPasswordResetToUsersTable) Down(db *gorm.DB) error {
+	for _, column := range []string{"password_reset_expires_at", "password_reset_token"} {
+		if err := db.Migrator().DropColumn(&AddPasswordResetToUsersTableColumns{}, column); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Description returns migration description
+func (m *AddPasswordResetToUsersTable) Description() string {
+	return "add_password_reset_to_users_table"
+}
+
+// Version returns migration version
+func (m *AddPasswordResetToUsersTable) Version() string {
+	return "2026_10_17_080000_add_password_reset_to_users_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddPasswordResetToUsersTable{})
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddViewCountToProductsTable migration - Modify tb_products table
+type AddViewCountToProductsTable struct{}
+
+// AddViewCountToProductsTableColumns represents the new column structure.
+// Views are counted in tb_counter_deltas and added here by the scheduler.
+type AddViewCountToProductsTableColumns struct {
+	ViewCount int64 `gorm:"not null;default:0"`
+}
+
+func (AddViewCountToProductsTableColumns) TableName() string {
+	return "tb_products"
+}
+
+// Up adds columns to the tb_products table
+func (m *AddViewCountToProductsTable) Up(db *gorm.DB) error {
+	return db.Migrator().AddColumn(&AddViewCountToProductsTableColumns{}, "view_count")
+}
+
+// Down removes columns from the tb_products table
+func (m *AddViewCountToProductsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropColumn(&AddViewCountToProductsTableColumns{}, "view_count")
+}
+
+// Description returns migration description
+func (m *AddViewCountToProductsTable) Description() string {
+	return "add_view_count_to_products_table"
+}
+
+// Version returns migration version
+func (m *AddViewCountToProductsTable) Version() string {
+	return "2026_10_17_090000_add_view_count_to_products_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddViewCountToProductsTable{})
+}
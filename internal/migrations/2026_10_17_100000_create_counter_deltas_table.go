@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// CounterDelta is a pending increment of one shard of a counted item, see
+// pkg/counter
+type CounterDelta struct {
+	Name  string `gorm:"primaryKey"`
+	Key   string `gorm:"primaryKey"`
+	Shard int    `gorm:"primaryKey"`
+	Delta int64  `gorm:"not null;default:0"`
+}
+
+func (CounterDelta) TableName() string {
+	return "tb_counter_deltas"
+}
+
+// CreateCounterDeltasTable migration - Create counter deltas table buffering counter increments until they are flushed
+type CreateCounterDeltasTable struct{}
+
+// Up creates the counter deltas table
+func (m *CreateCounterDeltasTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&CounterDelta{})
+}
+
+// Down drops the counter deltas table
+func (m *CreateCounterDeltasTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&CounterDelta{})
+}
+
+// Description returns migration description
+func (m *CreateCounterDeltasTable) Description() string {
+	return "Create counter deltas table"
+}
+
+// Version returns migration version
+func (m *CreateCounterDeltasTable) Version() string {
+	return "2026_10_17_100000_create_counter_deltas_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateCounterDeltasTable{})
+}
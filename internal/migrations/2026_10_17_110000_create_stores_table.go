@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store entity struct for migration
+type Store struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name      string         `json:"name" gorm:"not null" validate:"required,min=1,max=255"`
+	Address   string         `json:"address" gorm:"not null" validate:"required,min=1,max=255"`
+	Latitude  float64        `json:"latitude" gorm:"type:double precision;not null" validate:"latitude"`
+	Longitude float64        `json:"longitude" gorm:"type:double precision;not null" validate:"longitude"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TableName returns the table name for GORM
+func (Store) TableName() string {
+	return "tb_stores"
+}
+
+// CreateStoresTable migration - Create tb_stores table
+type CreateStoresTable struct{}
+
+// Up creates the tb_stores table using the Store struct.
+// The location is indexed with earthdistance for proximity searches.
+func (m *CreateStoresTable) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&Store{}); err != nil {
+			return err
+		}
+		for _, statement := range []string{
+			`CREATE EXTENSION IF NOT EXISTS cube`,
+			`CREATE EXTENSION IF NOT EXISTS earthdistance`,
+			`CREATE INDEX IF NOT EXISTS idx_tb_stores_location ON tb_stores USING gist (ll_to_earth(latitude, longitude))`,
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down drops the tb_stores table
+func (m *CreateStoresTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&Store{})
+}
+
+// Description returns migration description
+func (m *CreateStoresTable) Description() string {
+	return "Create tb_stores table"
+}
+
+// Version returns migration version
+func (m *CreateStoresTable) Version() string {
+	return "2026_10_17_110000_create_stores_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateStoresTable{})
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// AddAttributesToProductsTable migration - Modify tb_products and its read model
+type AddAttributesToProductsTable struct{}
+
+// AddAttributesToProductsTableColumns represents the new column structure
+type AddAttributesToProductsTableColumns struct {
+	Attributes string `gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+func (AddAttributesToProductsTableColumns) TableName() string {
+	return "tb_products"
+}
+
+// AddAttributesToProductReadModelsColumns represents the new read model column
+type AddAttributesToProductReadModelsColumns struct {
+	Attributes string `gorm:"type:jsonb;not null;default:'{}'"`
+}
+
+func (AddAttributesToProductReadModelsColumns) TableName() string {
+	return "tb_product_read_models"
+}
+
+// Up adds the attributes to products and their read model. Listings filter
+// them with @>, which the jsonb_path_ops GIN indexes answer.
+func (m *AddAttributesToProductsTable) Up(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().AddColumn(&AddAttributesToProductsTableColumns{}, "attributes"); err != nil {
+			return err
+		}
+		if err := tx.Migrator().AddColumn(&AddAttributesToProductReadModelsColumns{}, "attributes"); err != nil {
+			return err
+		}
+		for _, statement := range []string{
+			`CREATE INDEX IF NOT EXISTS idx_tb_products_attributes ON tb_products USING gin (attributes jsonb_path_ops)`,
+			`CREATE INDEX IF NOT EXISTS idx_tb_product_read_models_attributes ON tb_product_read_models USING gin (attributes jsonb_path_ops)`,
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down removes the attributes columns, and their indexes with them
+func (m *AddAttributesToProductsTable) Down(db *gorm.DB) error {
+	if err := db.Migrator().DropColumn(&AddAttributesToProductReadModelsColumns{}, "attributes"); err != nil {
+		return err
+	}
+	return db.Migrator().DropColumn(&AddAttributesToProductsTableColumns{}, "attributes")
+}
+
+// Description returns migration description
+func (m *AddAttributesToProductsTable) Description() string {
+	return "add_attributes_to_products_table"
+}
+
+// Version returns migration version
+func (m *AddAttributesToProductsTable) Version() string {
+	return "2026_10_17_120000_add_attributes_to_products_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&AddAttributesToProductsTable{})
+}
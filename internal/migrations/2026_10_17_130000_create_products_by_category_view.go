@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"go-clean-gin/pkg/matview"
+
+	"gorm.io/gorm"
+)
+
+// CreateProductsByCategoryView migration - Create the materialized view behind
+// the products-by-category report
+type CreateProductsByCategoryView struct{}
+
+// Up creates mv_products_by_category, refreshed by reports:refresh-views
+func (m *CreateProductsByCategoryView) Up(db *gorm.DB) error {
+	return matview.Create(db, "mv_products_by_category", `
+		SELECT category,
+			COUNT(*) AS product_count,
+			COUNT(*) FILTER (WHERE is_active) AS active_count,
+			COALESCE(SUM(stock), 0) AS total_stock
+		FROM tb_products
+		WHERE deleted_at IS NULL
+		GROUP BY category`, "category")
+}
+
+// Down drops the view
+func (m *CreateProductsByCategoryView) Down(db *gorm.DB) error {
+	return matview.Drop(db, "mv_products_by_category")
+}
+
+// Description returns migration description
+func (m *CreateProductsByCategoryView) Description() string {
+	return "create_products_by_category_view"
+}
+
+// Version returns migration version
+func (m *CreateProductsByCategoryView) Version() string {
+	return "2026_10_17_130000_create_products_by_category_view"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateProductsByCategoryView{})
+}
@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"go-clean-gin/pkg/partition"
+
+	"gorm.io/gorm"
+)
+
+// PartitionAuditLogsAndActivities migration - Partition the audit log and
+// activity tables by month
+type PartitionAuditLogsAndActivities struct{}
+
+// Up partitions tb_audit_logs and tb_activities by created_at, so expired
+// months are dropped instead of deleted row by row. Both are rewritten, which
+// takes a while on large tables; partitions:maintain creates later months.
+func (m *PartitionAuditLogsAndActivities) Up(db *gorm.DB) error {
+	now := time.Now()
+
+	auditLogs := partition.Table{Name: "tb_audit_logs", Column: "created_at", Interval: partition.Monthly}
+	if err := partition.Convert(db, auditLogs, now); err != nil {
+		return err
+	}
+	for _, index := range []string{"idx_tb_audit_logs_actor_created", "idx_tb_audit_logs_created_at"} {
+		if err := db.Migrator().CreateIndex(&AuditLog{}, index); err != nil {
+			return err
+		}
+	}
+
+	// artisan:module activity
+	activities := partition.Table{Name: "tb_activities", Column: "created_at", Interval: partition.Monthly}
+	if err := partition.Convert(db, activities, now); err != nil {
+		return err
+	}
+	for _, index := range []string{
+		"idx_tb_activities_actor_created", "idx_tb_activities_object",
+		"idx_tb_activities_target", "idx_tb_activities_created_at",
+	} {
+		if err := db.Migrator().CreateIndex(&Activity{}, index); err != nil {
+			return err
+		}
+	}
+	if err := db.Migrator().CreateConstraint(&Activity{}, "Actor"); err != nil {
+		return err
+	}
+	// artisan:end
+	return nil
+}
+
+// Down copies the rows back into plain tables
+func (m *PartitionAuditLogsAndActivities) Down(db *gorm.DB) error {
+	// artisan:module activity
+	if err := unpartition(db, &Activity{}, "tb_activities"); err != nil {
+		return err
+	}
+	// artisan:end
+	return unpartition(db, &AuditLog{}, "tb_audit_logs")
+}
+
+// unpartition replaces the partitioned table with a plain one migrated from
+// model, keeping its rows
+func unpartition(db *gorm.DB, model interface{}, table string) error {
+	rows := table + "_rows"
+	statements := []string{
+		fmt.Sprintf("CREATE TEMPORARY TABLE %s ON COMMIT DROP AS SELECT * FROM %s", rows, table),
+		fmt.Sprintf("DROP TABLE %s", table),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	if err := db.AutoMigrate(model); err != nil {
+		return err
+	}
+	return db.Exec(fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", table, rows)).Error
+}
+
+// Description returns migration description
+func (m *PartitionAuditLogsAndActivities) Description() string {
+	return "partition_audit_logs_and_activities"
+}
+
+// Version returns migration version
+func (m *PartitionAuditLogsAndActivities) Version() string {
+	return "2026_10_17_140000_partition_audit_logs_and_activities"
+}
+
+// Auto-register migration
+func init() {
+	Register(&PartitionAuditLogsAndActivities{})
+}
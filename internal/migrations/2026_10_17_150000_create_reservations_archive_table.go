@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+// CreateReservationsArchiveTable migration - Create the archive of closed
+// reservations
+type CreateReservationsArchiveTable struct{}
+
+// Up creates tb_reservations_archive with the columns, defaults and indexes
+// of tb_reservations, and its row-level security, so archived reservations
+// stay visible only to their owner and admins
+func (m *CreateReservationsArchiveTable) Up(db *gorm.DB) error {
+	for _, statement := range []string{
+		`CREATE TABLE tb_reservations_archive (LIKE tb_reservations INCLUDING ALL)`,
+		`ALTER TABLE tb_reservations_archive ENABLE ROW LEVEL SECURITY`,
+		`ALTER TABLE tb_reservations_archive FORCE ROW LEVEL SECURITY`,
+		`CREATE POLICY tb_reservations_archive_owner ON tb_reservations_archive USING (app_rls_owner(user_id))`,
+	} {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down drops the archive, and the reservations in it
+func (m *CreateReservationsArchiveTable) Down(db *gorm.DB) error {
+	return db.Exec(`DROP TABLE IF EXISTS tb_reservations_archive`).Error
+}
+
+// Description returns migration description
+func (m *CreateReservationsArchiveTable) Description() string {
+	return "create_reservations_archive_table"
+}
+
+// Version returns migration version
+func (m *CreateReservationsArchiveTable) Version() string {
+	return "2026_10_17_150000_create_reservations_archive_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateReservationsArchiveTable{})
+}
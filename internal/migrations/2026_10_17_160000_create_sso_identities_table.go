@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SSOIdentity struct {
+	Issuer     string    `gorm:"primaryKey"`
+	Subject    string    `gorm:"primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	User       User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Connection string    `gorm:"not null"`
+	CreatedAt  time.Time
+}
+
+func (SSOIdentity) TableName() string {
+	return "tb_sso_identities"
+}
+
+// CreateSSOIdentitiesTable migration - Create table linking users to the identity provider accounts that provisioned them
+type CreateSSOIdentitiesTable struct{}
+
+// Up creates the SSO identities table
+func (m *CreateSSOIdentitiesTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&SSOIdentity{})
+}
+
+// Down drops the SSO identities table
+func (m *CreateSSOIdentitiesTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable(&SSOIdentity{})
+}
+
+// Description returns migration description
+func (m *CreateSSOIdentitiesTable) Description() string {
+	return "Create SSO identities table"
+}
+
+// Version returns migration version
+func (m *CreateSSOIdentitiesTable) Version() string {
+	return "2026_10_17_160000_create_sso_identities_table"
+}
+
+// Auto-register migration
+func init() {
+	Register(&CreateSSOIdentitiesTable{})
+}
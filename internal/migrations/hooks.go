@@ -0,0 +1,103 @@
+// internal/migrations/hooks.go - Hooks and events around each migration
+package migrations
+
+import (
+	"time"
+
+	"go-clean-gin/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// Optional interfaces a migration implements to run work around Up and Down,
+// outside their transaction. A failing Before hook stops the migration before
+// it starts; a failing After hook stops the run after the migration has been
+// recorded, e.g. once its tables exist but a materialized view is stale.
+type (
+	BeforeUpHook interface {
+		BeforeUp(db *gorm.DB) error
+	}
+	AfterUpHook interface {
+		AfterUp(db *gorm.DB) error
+	}
+	BeforeDownHook interface {
+		BeforeDown(db *gorm.DB) error
+	}
+	AfterDownHook interface {
+		AfterDown(db *gorm.DB) error
+	}
+)
+
+// Event names dispatched around each migration
+const (
+	EventMigrating   = "migration.migrating"
+	EventMigrated    = "migration.migrated"
+	EventRollingBack = "migration.rolling_back"
+	EventRolledBack  = "migration.rolled_back"
+	EventFailed      = "migration.failed"
+)
+
+// Events is the bus migration events are dispatched on, unless a manager is
+// given another with SetEvents. Listeners registered from init() hear the
+// migrations artisan runs:
+//
+//	func init() {
+//		migrations.Events.Listen(migrations.EventMigrated, notifySlack)
+//	}
+var Events = events.NewBus()
+
+// MigratingEvent is dispatched before a migration runs
+type MigratingEvent struct {
+	Version     string
+	Description string
+}
+
+func (MigratingEvent) EventName() string {
+	return EventMigrating
+}
+
+// MigratedEvent is dispatched after a migration and its AfterUp hook ran
+type MigratedEvent struct {
+	Version     string
+	Description string
+	Duration    time.Duration
+}
+
+func (MigratedEvent) EventName() string {
+	return EventMigrated
+}
+
+// RollingBackEvent is dispatched before a migration is rolled back
+type RollingBackEvent struct {
+	Version     string
+	Description string
+}
+
+func (RollingBackEvent) EventName() string {
+	return EventRollingBack
+}
+
+// RolledBackEvent is dispatched after a migration was rolled back and its
+// AfterDown hook ran
+type RolledBackEvent struct {
+	Version     string
+	Description string
+	Duration    time.Duration
+}
+
+func (RolledBackEvent) EventName() string {
+	return EventRolledBack
+}
+
+// FailedEvent is dispatched when a migration, its rollback or one of their
+// hooks fails
+type FailedEvent struct {
+	Version     string
+	Description string
+	Rollback    bool
+	Err         error
+}
+
+func (FailedEvent) EventName() string {
+	return EventFailed
+}
@@ -2,11 +2,15 @@
 package migrations
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
 
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/events"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/version"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -26,12 +30,33 @@ type MigrationRecord struct {
 	Version     string    `gorm:"uniqueIndex;not null"`
 	Description string    `gorm:"not null"`
 	AppliedAt   time.Time `gorm:"not null"`
+
+	// Who applied the migration, from where, and how long it took
+	ExecutedBy string `gorm:"not null;default:''"`
+	Host       string `gorm:"not null;default:''"`
+	AppVersion string `gorm:"not null;default:''"`
+	DurationMs int64  `gorm:"not null;default:0"`
+}
+
+// MigrationStatus is whether a migration has been applied, and when, by whom
+// and where
+type MigrationStatus struct {
+	Version     string     `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	ExecutedBy  string     `json:"executed_by,omitempty"`
+	Host        string     `json:"host,omitempty"`
+	AppVersion  string     `json:"app_version,omitempty"`
+	DurationMs  int64      `json:"duration_ms,omitempty"`
 }
 
 // MigrationManager จัดการ migrations
 type MigrationManager struct {
 	db         *gorm.DB
 	migrations map[string]Migration
+	clock      clock.Clock
+	events     *events.Bus
 }
 
 // Global migration manager instance
@@ -43,6 +68,8 @@ func NewMigrationManager(db *gorm.DB) *MigrationManager {
 	manager := &MigrationManager{
 		db:         db,
 		migrations: make(map[string]Migration),
+		clock:      clock.New(),
+		events:     Events,
 	}
 
 	// Register all migrations that were registered during init()
@@ -68,6 +95,16 @@ func Register(migration Migration) {
 	}
 }
 
+// SetClock replaces the clock used for AppliedAt
+func (mm *MigrationManager) SetClock(c clock.Clock) {
+	mm.clock = c
+}
+
+// SetEvents replaces the bus migration events are dispatched on
+func (mm *MigrationManager) SetEvents(bus *events.Bus) {
+	mm.events = bus
+}
+
 // RegisterMigration ลงทะเบียน migration
 func (mm *MigrationManager) RegisterMigration(migration Migration) {
 	mm.migrations[migration.Version()] = migration
@@ -115,6 +152,7 @@ func (mm *MigrationManager) RunMigrations() error {
 			zap.String("description", migration.Description()))
 
 		if err := mm.runSingleMigration(migration); err != nil {
+			mm.events.Dispatch(context.Background(), FailedEvent{Version: version, Description: migration.Description(), Err: err})
 			return fmt.Errorf("migration %s failed: %w", version, err)
 		}
 
@@ -167,6 +205,7 @@ func (mm *MigrationManager) RollbackMigrations(count int) error {
 			zap.String("description", record.Description))
 
 		if err := mm.rollbackSingleMigration(migration, record); err != nil {
+			mm.events.Dispatch(context.Background(), FailedEvent{Version: record.Version, Description: record.Description, Rollback: true, Err: err})
 			return fmt.Errorf("rollback failed for migration %s: %w", record.Version, err)
 		}
 
@@ -179,17 +218,17 @@ func (mm *MigrationManager) RollbackMigrations(count int) error {
 	return nil
 }
 
-// GetMigrationStatus แสดงสถานะ migrations
-func (mm *MigrationManager) GetMigrationStatus() error {
+// Status returns the status of every registered migration, by version
+func (mm *MigrationManager) Status() ([]MigrationStatus, error) {
 	// Create migrations table if not exists
 	if err := mm.db.AutoMigrate(&MigrationRecord{}); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
 	// Get applied migrations
 	var appliedRecords []MigrationRecord
 	if err := mm.db.Order("applied_at ASC").Find(&appliedRecords).Error; err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
 	appliedMap := make(map[string]MigrationRecord)
@@ -204,6 +243,33 @@ func (mm *MigrationManager) GetMigrationStatus() error {
 	}
 	sort.Strings(versions)
 
+	statuses := make([]MigrationStatus, 0, len(versions))
+	for _, version := range versions {
+		status := MigrationStatus{
+			Version:     version,
+			Description: mm.migrations[version].Description(),
+		}
+		if record, applied := appliedMap[version]; applied {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+			status.ExecutedBy = record.ExecutedBy
+			status.Host = record.Host
+			status.AppVersion = record.AppVersion
+			status.DurationMs = record.DurationMs
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// GetMigrationStatus แสดงสถานะ migrations; verbose adds who applied each one,
+// from where and how long it took
+func (mm *MigrationManager) GetMigrationStatus(verbose bool) error {
+	statuses, err := mm.Status()
+	if err != nil {
+		return err
+	}
+
 	// Show status
 	appliedCount := 0
 	pendingCount := 0
@@ -211,19 +277,27 @@ func (mm *MigrationManager) GetMigrationStatus() error {
 	logger.Info("Migration Status:")
 	logger.Info("================")
 
-	for _, version := range versions {
-		migration := mm.migrations[version]
-		if record, applied := appliedMap[version]; applied {
+	for _, status := range statuses {
+		if status.Applied {
 			appliedCount++
-			logger.Info("✅ APPLIED",
-				zap.String("version", version),
-				zap.String("description", migration.Description()),
-				zap.Time("applied_at", record.AppliedAt))
+			fields := []zap.Field{
+				zap.String("version", status.Version),
+				zap.String("description", status.Description),
+				zap.Time("applied_at", *status.AppliedAt),
+			}
+			if verbose {
+				fields = append(fields,
+					zap.String("executed_by", status.ExecutedBy),
+					zap.String("host", status.Host),
+					zap.String("app_version", status.AppVersion),
+					zap.Duration("duration", time.Duration(status.DurationMs)*time.Millisecond))
+			}
+			logger.Info("✅ APPLIED", fields...)
 		} else {
 			pendingCount++
 			logger.Info("⏳ PENDING",
-				zap.String("version", version),
-				zap.String("description", migration.Description()))
+				zap.String("version", status.Version),
+				zap.String("description", status.Description))
 		}
 	}
 
@@ -231,13 +305,43 @@ func (mm *MigrationManager) GetMigrationStatus() error {
 	logger.Info("Summary",
 		zap.Int("applied", appliedCount),
 		zap.Int("pending", pendingCount),
-		zap.Int("total", len(versions)))
+		zap.Int("total", len(statuses)))
 
 	return nil
 }
 
-// runSingleMigration รัน migration เดียวใน transaction
+// runSingleMigration รัน migration เดียวใน transaction, with its hooks and
+// events around it
 func (mm *MigrationManager) runSingleMigration(migration Migration) error {
+	ctx := context.Background()
+	started := mm.clock.Now()
+	mm.events.Dispatch(ctx, MigratingEvent{Version: migration.Version(), Description: migration.Description()})
+
+	if hook, ok := migration.(BeforeUpHook); ok {
+		if err := hook.BeforeUp(mm.db); err != nil {
+			return fmt.Errorf("before-up hook failed: %w", err)
+		}
+	}
+
+	if err := mm.upInTransaction(migration, started); err != nil {
+		return err
+	}
+
+	if hook, ok := migration.(AfterUpHook); ok {
+		if err := hook.AfterUp(mm.db); err != nil {
+			return fmt.Errorf("migration applied, but its after-up hook failed: %w", err)
+		}
+	}
+
+	mm.events.Dispatch(ctx, MigratedEvent{
+		Version:     migration.Version(),
+		Description: migration.Description(),
+		Duration:    mm.clock.Now().Sub(started),
+	})
+	return nil
+}
+
+func (mm *MigrationManager) upInTransaction(migration Migration, started time.Time) error {
 	// Start transaction
 	tx := mm.db.Begin()
 	if tx.Error != nil {
@@ -251,10 +355,16 @@ func (mm *MigrationManager) runSingleMigration(migration Migration) error {
 	}
 
 	// Record migration
+	executor := version.CurrentExecutor()
+	now := mm.clock.Now()
 	record := MigrationRecord{
 		Version:     migration.Version(),
 		Description: migration.Description(),
-		AppliedAt:   time.Now().UTC(),
+		AppliedAt:   now.UTC(),
+		ExecutedBy:  executor.User,
+		Host:        executor.Host,
+		AppVersion:  executor.Version,
+		DurationMs:  now.Sub(started).Milliseconds(),
 	}
 
 	if err := tx.Create(&record).Error; err != nil {
@@ -270,8 +380,38 @@ func (mm *MigrationManager) runSingleMigration(migration Migration) error {
 	return nil
 }
 
-// rollbackSingleMigration rollback migration เดียว
+// rollbackSingleMigration rollback migration เดียว, with its hooks and events
+// around it
 func (mm *MigrationManager) rollbackSingleMigration(migration Migration, record MigrationRecord) error {
+	ctx := context.Background()
+	started := mm.clock.Now()
+	mm.events.Dispatch(ctx, RollingBackEvent{Version: record.Version, Description: record.Description})
+
+	if hook, ok := migration.(BeforeDownHook); ok {
+		if err := hook.BeforeDown(mm.db); err != nil {
+			return fmt.Errorf("before-down hook failed: %w", err)
+		}
+	}
+
+	if err := mm.downInTransaction(migration, record); err != nil {
+		return err
+	}
+
+	if hook, ok := migration.(AfterDownHook); ok {
+		if err := hook.AfterDown(mm.db); err != nil {
+			return fmt.Errorf("migration rolled back, but its after-down hook failed: %w", err)
+		}
+	}
+
+	mm.events.Dispatch(ctx, RolledBackEvent{
+		Version:     record.Version,
+		Description: record.Description,
+		Duration:    mm.clock.Now().Sub(started),
+	})
+	return nil
+}
+
+func (mm *MigrationManager) downInTransaction(migration Migration, record MigrationRecord) error {
 	// Start transaction
 	tx := mm.db.Begin()
 	if tx.Error != nil {
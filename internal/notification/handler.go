@@ -0,0 +1,159 @@
+package notification
+
+import (
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	usecase NotificationUsecase
+}
+
+func NewNotificationHandler(usecase NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		usecase: usecase,
+	}
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Get the current user's notifications, newest first
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param unread query boolean false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter entity.NotificationFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	notifications, total, err := h.usecase.GetNotifications(c.Request.Context(), userID, &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get notifications", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get notifications", nil)
+		}
+		return
+	}
+
+	meta := pagination.Meta(filter.Params, total, len(notifications), func() (time.Time, uuid.UUID) {
+		last := notifications[len(notifications)-1]
+		return last.CreatedAt, last.ID
+	})
+	response.SuccessWithMeta(c, 200, "Notifications retrieved successfully", notifications, meta)
+}
+
+// MarkRead godoc
+// @Summary Mark notification as read
+// @Description Mark one of the current user's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Notification ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /notifications/{id}/read [patch]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid notification ID", err.Error())
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.MarkRead(c.Request.Context(), userID, notificationID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to mark notification read", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to mark notification read", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Notification marked as read", nil)
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark all of the current user's unread notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.usecase.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to mark notifications read", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to mark notifications read", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Notifications marked as read", gin.H{"updated": updated})
+}
@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/internal/savedsearch"
+	"go-clean-gin/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+// ProductOutOfStockPayload is the payload of a product.out_of_stock notification
+type ProductOutOfStockPayload struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name"`
+}
+
+// ProductLowStockPayload is the payload of a product.low_stock notification
+type ProductLowStockPayload struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Name      string    `json:"name"`
+	Stock     int       `json:"stock"`
+	Threshold int       `json:"threshold"`
+}
+
+// SavedSearchMatchedPayload is the payload of a saved_search.matched
+// notification. ProductIDs holds the newest of the Count new products.
+type SavedSearchMatchedPayload struct {
+	SavedSearchID uuid.UUID   `json:"saved_search_id"`
+	Name          string      `json:"name"`
+	Count         int64       `json:"count"`
+	ProductIDs    []uuid.UUID `json:"product_ids"`
+}
+
+// RegisterListeners creates notifications from application events
+func RegisterListeners(bus *events.Bus, usecase NotificationUsecase) {
+	bus.Listen(product.EventOutOfStock, func(ctx context.Context, event events.Event) error {
+		e := event.(product.OutOfStockEvent)
+		_, err := usecase.Notify(ctx, e.OwnerID, entity.NotificationProductOutOfStock, ProductOutOfStockPayload{
+			ProductID: e.ProductID,
+			Name:      e.Name,
+		})
+		return err
+	})
+
+	bus.Listen(product.EventLowStock, func(ctx context.Context, event events.Event) error {
+		e := event.(product.LowStockEvent)
+		_, err := usecase.Notify(ctx, e.OwnerID, entity.NotificationProductLowStock, ProductLowStockPayload{
+			ProductID: e.ProductID,
+			Name:      e.Name,
+			Stock:     e.Stock,
+			Threshold: e.Threshold,
+		})
+		return err
+	})
+
+	bus.Listen(savedsearch.EventMatched, func(ctx context.Context, event events.Event) error {
+		e := event.(savedsearch.MatchedEvent)
+		_, err := usecase.Notify(ctx, e.UserID, entity.NotificationSavedSearchMatch, SavedSearchMatchedPayload{
+			SavedSearchID: e.SavedSearchID,
+			Name:          e.Name,
+			Count:         e.Count,
+			ProductIDs:    e.ProductIDs,
+		})
+		return err
+	})
+}
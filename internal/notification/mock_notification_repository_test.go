@@ -0,0 +1,61 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package notification
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationRepository is a testify mock of NotificationRepository
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification *entity.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetNotifications(ctx context.Context, userID uuid.UUID, filter *entity.NotificationFilter) ([]*entity.Notification, int64, error) {
+	args := m.Called(ctx, userID, filter)
+
+	var r0 []*entity.Notification
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Notification)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID, readAt time.Time) (int64, error) {
+	args := m.Called(ctx, userID, notificationID, readAt)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	args := m.Called(ctx, userID, readAt)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
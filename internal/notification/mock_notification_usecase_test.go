@@ -0,0 +1,60 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package notification
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationUsecase is a testify mock of NotificationUsecase
+type MockNotificationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockNotificationUsecase) Notify(ctx context.Context, userID uuid.UUID, notificationType string, payload interface{}) (*entity.Notification, error) {
+	args := m.Called(ctx, userID, notificationType, payload)
+
+	var r0 *entity.Notification
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Notification)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockNotificationUsecase) GetNotifications(ctx context.Context, userID uuid.UUID, filter *entity.NotificationFilter) ([]*entity.Notification, int64, error) {
+	args := m.Called(ctx, userID, filter)
+
+	var r0 []*entity.Notification
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Notification)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockNotificationUsecase) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationUsecase) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
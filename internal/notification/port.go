@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationUsecase defines the business logic interface for in-app notifications
+type NotificationUsecase interface {
+	Notify(ctx context.Context, userID uuid.UUID, notificationType string, payload interface{}) (*entity.Notification, error)
+	GetNotifications(ctx context.Context, userID uuid.UUID, filter *entity.NotificationFilter) ([]*entity.Notification, int64, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// NotificationRepository defines the data access interface for notifications
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *entity.Notification) error
+	GetNotifications(ctx context.Context, userID uuid.UUID, filter *entity.NotificationFilter) ([]*entity.Notification, int64, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID, readAt time.Time) (int64, error)
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+}
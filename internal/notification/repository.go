@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) CreateNotification(ctx context.Context, notification *entity.Notification) error {
+	return tenancy.Conn(ctx, r.db).Create(notification).Error
+}
+
+func (r *notificationRepository) GetNotifications(ctx context.Context, userID uuid.UUID, filter *entity.NotificationFilter) ([]*entity.Notification, int64, error) {
+	var notifications []*entity.Notification
+	var total int64
+
+	query := tenancy.Conn(ctx, r.db).Model(&entity.Notification{}).Where("user_id = ?", userID)
+
+	if filter.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := pagination.Apply(query, filter.Params, "created_at", "id").Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+// MarkRead returns the number of rows updated, 0 when the notification does not
+// exist or belongs to someone else. Already read notifications keep their read_at.
+func (r *notificationRepository) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID, readAt time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	return result.RowsAffected, result.Error
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type notificationUsecase struct {
+	repo  NotificationRepository
+	clock clock.Clock
+}
+
+func NewNotificationUsecase(repo NotificationRepository, clk clock.Clock) NotificationUsecase {
+	return &notificationUsecase{
+		repo:  repo,
+		clock: clk,
+	}
+}
+
+// Notify stores a notification for userID; payload is encoded as JSON
+func (u *notificationUsecase) Notify(ctx context.Context, userID uuid.UUID, notificationType string, payload interface{}) (*entity.Notification, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to encode notification payload", 500)
+	}
+
+	notification := &entity.Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Payload:   entity.JSON(data),
+		CreatedAt: u.clock.Now(),
+	}
+
+	if err := u.repo.CreateNotification(ctx, notification); err != nil {
+		logger.FromContext(ctx).Error("Failed to create notification", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create notification", 500)
+	}
+
+	logger.FromContext(ctx).Info("Notification created",
+		zap.String("notification_id", notification.ID.String()),
+		zap.String("type", notificationType),
+	)
+	return notification, nil
+}
+
+func (u *notificationUsecase) GetNotifications(ctx context.Context, userID uuid.UUID, filter *entity.NotificationFilter) ([]*entity.Notification, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+
+	notifications, total, err := u.repo.GetNotifications(ctx, userID, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get notifications", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get notifications", 500)
+	}
+
+	return notifications, total, nil
+}
+
+func (u *notificationUsecase) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uuid.UUID) error {
+	updated, err := u.repo.MarkRead(ctx, userID, notificationID, u.clock.Now())
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to mark notification read", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to mark notification read", 500)
+	}
+	if updated == 0 {
+		return errors.ErrNotificationNotFoundError
+	}
+	return nil
+}
+
+func (u *notificationUsecase) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	updated, err := u.repo.MarkAllRead(ctx, userID, u.clock.Now())
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to mark notifications read", zap.Error(err))
+		return 0, errors.Wrap(err, errors.ErrInternal, "Failed to mark notifications read", 500)
+	}
+	return updated, nil
+}
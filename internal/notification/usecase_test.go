@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationUsecase_Notify_Success(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usecase := NewNotificationUsecase(mockRepo, clock.NewFake(now))
+
+	userID := uuid.New()
+	mockRepo.On("CreateNotification", mock.Anything, mock.AnythingOfType("*entity.Notification")).Return(nil)
+
+	result, err := usecase.Notify(context.Background(), userID, "greeting", map[string]string{"text": "hello"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, result.UserID)
+	assert.Equal(t, "greeting", result.Type)
+	assert.JSONEq(t, `{"text":"hello"}`, string(result.Payload))
+	assert.Equal(t, now, result.CreatedAt)
+	assert.Nil(t, result.ReadAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNotificationUsecase_GetNotifications_DefaultPagination(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	usecase := NewNotificationUsecase(mockRepo, clock.New())
+
+	userID := uuid.New()
+	filter := &entity.NotificationFilter{Unread: true}
+	mockRepo.On("GetNotifications", mock.Anything, userID, filter).Return([]*entity.Notification{}, int64(0), nil)
+
+	_, _, err := usecase.GetNotifications(context.Background(), userID, filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, filter.Page)
+	assert.Equal(t, 20, filter.Limit)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNotificationUsecase_MarkRead_NotFound(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usecase := NewNotificationUsecase(mockRepo, clock.NewFake(now))
+
+	userID := uuid.New()
+	notificationID := uuid.New()
+	mockRepo.On("MarkRead", mock.Anything, userID, notificationID, now).Return(int64(0), nil)
+
+	err := usecase.MarkRead(context.Background(), userID, notificationID)
+
+	assert.Equal(t, errors.ErrNotificationNotFoundError, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRegisterListeners_ProductOutOfStock(t *testing.T) {
+	mockRepo := new(MockNotificationRepository)
+	usecase := NewNotificationUsecase(mockRepo, clock.New())
+	bus := events.NewBus()
+	RegisterListeners(bus, usecase)
+
+	ownerID := uuid.New()
+	productID := uuid.New()
+
+	var created *entity.Notification
+	mockRepo.On("CreateNotification", mock.Anything, mock.AnythingOfType("*entity.Notification")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Notification) }).
+		Return(nil)
+
+	bus.Dispatch(context.Background(), product.OutOfStockEvent{ProductID: productID, Name: "Widget", OwnerID: ownerID})
+
+	if assert.NotNil(t, created) {
+		assert.Equal(t, ownerID, created.UserID)
+		assert.Equal(t, entity.NotificationProductOutOfStock, created.Type)
+		assert.JSONEq(t, `{"product_id":"`+productID.String()+`","name":"Widget"}`, string(created.Payload))
+	}
+	mockRepo.AssertExpectations(t)
+}
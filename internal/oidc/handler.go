@@ -0,0 +1,187 @@
+package oidc
+
+import (
+	"net/http"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// oauthErrors maps error codes to the OAuth 2.0 error names clients expect
+var oauthErrors = map[string]string{
+	errors.ErrInvalidClient:        "invalid_client",
+	errors.ErrInvalidGrant:         "invalid_grant",
+	errors.ErrUnsupportedGrantType: "unsupported_grant_type",
+	errors.ErrInvalidScope:         "invalid_scope",
+	errors.ErrTokenInvalid:         "invalid_token",
+}
+
+type OIDCHandler struct {
+	usecase OIDCUsecase
+}
+
+func NewOIDCHandler(usecase OIDCUsecase) *OIDCHandler {
+	return &OIDCHandler{
+		usecase: usecase,
+	}
+}
+
+// Discovery serves the OpenID Connect discovery document
+func (h *OIDCHandler) Discovery(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, h.usecase.Discovery())
+}
+
+// JWKS serves the public keys clients verify tokens with
+func (h *OIDCHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, h.usecase.JWKS())
+}
+
+// Authorize godoc
+// @Summary Authorize OIDC client
+// @Description Issue an authorization code to a registered client for the current user. The web app's sign-in page calls this with the client's authorization request once the user has agreed, then sends the user to redirect_to. PKCE with S256 is required.
+// @Tags oauth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.AuthorizeRequest true "Authorization request"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /oauth/authorize [post]
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.AuthorizeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	result, err := h.usecase.Authorize(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to authorize client", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to authorize client", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Client authorized successfully", result)
+}
+
+// Token godoc
+// @Summary Exchange authorization code
+// @Description OAuth 2.0 token endpoint. Trades an authorization code and its PKCE verifier for an access token and an ID token. Confidential clients authenticate with HTTP Basic or client_secret. Errors use the OAuth error format.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code"
+// @Param code formData string true "Authorization code"
+// @Param redirect_uri formData string true "Redirect URI the code was issued for"
+// @Param client_id formData string false "Client ID, unless sent with HTTP Basic"
+// @Param client_secret formData string false "Client secret, unless sent with HTTP Basic"
+// @Param code_verifier formData string true "PKCE code verifier"
+// @Success 200 {object} entity.TokenResponse
+// @Failure 400 {object} entity.OAuthError
+// @Failure 401 {object} entity.OAuthError
+// @Failure 500 {object} entity.OAuthError
+// @Router /oauth/token [post]
+func (h *OIDCHandler) Token(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	var req entity.TokenRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, entity.OAuthError{Error: "invalid_request", ErrorDescription: "Invalid request body"})
+		return
+	}
+
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		req.ClientID, req.ClientSecret = id, secret
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		c.JSON(http.StatusBadRequest, entity.OAuthError{Error: "invalid_request", ErrorDescription: "Missing or invalid parameters"})
+		return
+	}
+
+	tokens, err := h.usecase.Token(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Token request rejected", zap.Error(err))
+		oauthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// UserInfo godoc
+// @Summary Get OIDC user info
+// @Description OpenID Connect userinfo endpoint. Returns the claims about the user released for the access token's scopes. Errors use the OAuth error format.
+// @Tags oauth
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} entity.UserInfo
+// @Failure 401 {object} entity.OAuthError
+// @Failure 500 {object} entity.OAuthError
+// @Router /oauth/userinfo [get]
+func (h *OIDCHandler) UserInfo(c *gin.Context) {
+	accessToken, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || accessToken == "" {
+		c.Header("WWW-Authenticate", `Bearer`)
+		c.JSON(http.StatusUnauthorized, entity.OAuthError{Error: "invalid_request", ErrorDescription: "Bearer access token is required"})
+		return
+	}
+
+	info, err := h.usecase.UserInfo(c.Request.Context(), accessToken)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Userinfo request rejected", zap.Error(err))
+		if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrTokenInvalid {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		}
+		oauthError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// oauthError writes a usecase error in the OAuth error format
+func oauthError(c *gin.Context, err error) {
+	appErr, ok := err.(*errors.AppError)
+	name, known := "", false
+	if ok {
+		name, known = oauthErrors[appErr.Code]
+	}
+	if !known {
+		c.JSON(http.StatusInternalServerError, entity.OAuthError{Error: "server_error"})
+		return
+	}
+
+	c.JSON(appErr.StatusCode, entity.OAuthError{Error: name, ErrorDescription: appErr.Message})
+}
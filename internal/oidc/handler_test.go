@@ -0,0 +1,88 @@
+package oidc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCHandler_Discovery(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	res := api.Get("/.well-known/openid-configuration").Do().AssertStatus(http.StatusOK)
+
+	var discovery entity.OIDCDiscovery
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &discovery))
+	issuer := api.Container.Config.OIDC.Issuer
+	assert.Equal(t, issuer, discovery.Issuer)
+	assert.Equal(t, issuer+"/api/v1/oauth/token", discovery.TokenEndpoint)
+	assert.Equal(t, []string{"S256"}, discovery.CodeChallengeMethodsSupported)
+
+	res = api.Get("/.well-known/jwks.json").Do().AssertStatus(http.StatusOK)
+	var keys entity.JSONWebKeySet
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &keys))
+	if assert.Len(t, keys.Keys, 1) {
+		assert.Equal(t, "RS256", keys.Keys[0].Algorithm)
+	}
+}
+
+func TestOIDCHandler_Authorize_UnknownClient(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Post("/api/v1/oauth/authorize", entity.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            "unknown",
+		RedirectURI:         "https://tool.example.com/callback",
+		Scope:               "openid",
+		CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeMethod: "S256",
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrInvalidClient)
+}
+
+func TestOIDCHandler_Token_OAuthErrors(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {"code"},
+		"redirect_uri":  {"https://tool.example.com/callback"},
+		"client_id":     {"unknown"},
+		"code_verifier": {"dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"},
+	}
+
+	res := api.Request(http.MethodPost, "/api/v1/oauth/token", []byte(form.Encode())).
+		Header("Content-Type", "application/x-www-form-urlencoded").
+		Do().
+		AssertStatus(http.StatusUnauthorized)
+
+	var oauthErr entity.OAuthError
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &oauthErr))
+	assert.Equal(t, "invalid_client", oauthErr.Error)
+	assert.Equal(t, "no-store", res.Recorder.Header().Get("Cache-Control"))
+}
+
+func TestOIDCHandler_UserInfo_InvalidToken(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	res := api.WithToken("not-a-token").Get("/api/v1/oauth/userinfo").Do().
+		AssertStatus(http.StatusUnauthorized)
+	assert.Equal(t, `Bearer error="invalid_token"`, res.Recorder.Header().Get("WWW-Authenticate"))
+}
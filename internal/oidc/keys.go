@@ -0,0 +1,95 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+)
+
+// signingKeyBits is the size of keys generated when none is configured
+const signingKeyBits = 2048
+
+// ParseClients parses the configured "client_id:client_secret:redirect_uri"
+// entries. The redirect URI is everything after the second colon.
+func ParseClients(specs []string) ([]entity.OIDCClient, error) {
+	clients := make([]entity.OIDCClient, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, ":", 3)
+		if len(parts) != 3 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[2]) == "" {
+			return nil, fmt.Errorf("invalid OIDC client %q, expected client_id:client_secret:redirect_uri", spec)
+		}
+		id := strings.TrimSpace(parts[0])
+		if seen[id] {
+			return nil, fmt.Errorf("OIDC client %q is listed twice", id)
+		}
+		seen[id] = true
+
+		clients = append(clients, entity.OIDCClient{
+			ID:          id,
+			Secret:      strings.TrimSpace(parts[1]),
+			RedirectURI: strings.TrimSpace(parts[2]),
+		})
+	}
+	return clients, nil
+}
+
+// LoadSigningKey reads a PEM-encoded RSA private key (PKCS#1 or PKCS#8) from
+// path. With an empty path a new key is generated.
+func LoadSigningKey(path string) (*rsa.PrivateKey, error) {
+	if path == "" {
+		return rsa.GenerateKey(rand.Reader, signingKeyBits)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s does not contain a PEM block", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s does not contain an RSA private key: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain an RSA private key", path)
+	}
+	return key, nil
+}
+
+// keyID derives a stable key ID from the public key, so clients caching the
+// key set notice when the key changes
+func keyID(key *rsa.PublicKey) string {
+	sum := sha256.Sum256(x509.MarshalPKCS1PublicKey(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// publicJWK returns the public half of key as a JWK
+func publicJWK(key *rsa.PublicKey) entity.JSONWebKey {
+	return entity.JSONWebKey{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: "RS256",
+		KeyID:     keyID(key),
+		Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
@@ -0,0 +1,56 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOIDCRepository is a testify mock of OIDCRepository
+type MockOIDCRepository struct {
+	mock.Mock
+}
+
+func (m *MockOIDCRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOIDCRepository) CreateCode(ctx context.Context, code *entity.OIDCAuthorizationCode) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
+func (m *MockOIDCRepository) ConsumeCode(ctx context.Context, codeHash string) (*entity.OIDCAuthorizationCode, error) {
+	args := m.Called(ctx, codeHash)
+
+	var r0 *entity.OIDCAuthorizationCode
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.OIDCAuthorizationCode)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOIDCRepository) DeleteExpiredCodes(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
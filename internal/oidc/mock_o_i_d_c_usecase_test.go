@@ -0,0 +1,83 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package oidc
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOIDCUsecase is a testify mock of OIDCUsecase
+type MockOIDCUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOIDCUsecase) Discovery() *entity.OIDCDiscovery {
+	args := m.Called()
+
+	var r0 *entity.OIDCDiscovery
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.OIDCDiscovery)
+	}
+
+	return r0
+}
+
+func (m *MockOIDCUsecase) JWKS() *entity.JSONWebKeySet {
+	args := m.Called()
+
+	var r0 *entity.JSONWebKeySet
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.JSONWebKeySet)
+	}
+
+	return r0
+}
+
+func (m *MockOIDCUsecase) Authorize(ctx context.Context, userID uuid.UUID, req *entity.AuthorizeRequest) (*entity.AuthorizeResponse, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.AuthorizeResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthorizeResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOIDCUsecase) Token(ctx context.Context, req *entity.TokenRequest) (*entity.TokenResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.TokenResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.TokenResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOIDCUsecase) UserInfo(ctx context.Context, accessToken string) (*entity.UserInfo, error) {
+	args := m.Called(ctx, accessToken)
+
+	var r0 *entity.UserInfo
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.UserInfo)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOIDCUsecase) PruneCodes(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
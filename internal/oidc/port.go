@@ -0,0 +1,27 @@
+package oidc
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDCUsecase defines the business logic interface for the OpenID Connect provider
+type OIDCUsecase interface {
+	Discovery() *entity.OIDCDiscovery
+	JWKS() *entity.JSONWebKeySet
+	Authorize(ctx context.Context, userID uuid.UUID, req *entity.AuthorizeRequest) (*entity.AuthorizeResponse, error)
+	Token(ctx context.Context, req *entity.TokenRequest) (*entity.TokenResponse, error)
+	UserInfo(ctx context.Context, accessToken string) (*entity.UserInfo, error)
+	PruneCodes(ctx context.Context) (int64, error)
+}
+
+// OIDCRepository defines the data access interface for authorization codes
+type OIDCRepository interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	CreateCode(ctx context.Context, code *entity.OIDCAuthorizationCode) error
+	ConsumeCode(ctx context.Context, codeHash string) (*entity.OIDCAuthorizationCode, error)
+	DeleteExpiredCodes(ctx context.Context, before time.Time) (int64, error)
+}
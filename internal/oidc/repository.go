@@ -0,0 +1,56 @@
+package oidc
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type oidcRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCRepository(db *gorm.DB) OIDCRepository {
+	return &oidcRepository{
+		db: db,
+	}
+}
+
+func (r *oidcRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).Where("id = ? AND is_active = ?", userID, true).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *oidcRepository) CreateCode(ctx context.Context, code *entity.OIDCAuthorizationCode) error {
+	return tenancy.Conn(ctx, r.db).Create(code).Error
+}
+
+// ConsumeCode deletes the code and returns it, so a code can be traded once
+// even when two token requests race
+func (r *oidcRepository) ConsumeCode(ctx context.Context, codeHash string) (*entity.OIDCAuthorizationCode, error) {
+	var code entity.OIDCAuthorizationCode
+	result := tenancy.Conn(ctx, r.db).Clauses(clause.Returning{}).
+		Where("code_hash = ?", codeHash).
+		Delete(&code)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &code, nil
+}
+
+func (r *oidcRepository) DeleteExpiredCodes(ctx context.Context, before time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("expires_at < ?", before).Delete(&entity.OIDCAuthorizationCode{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,365 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// GrantAuthorizationCode is the only grant type the token endpoint accepts
+const GrantAuthorizationCode = "authorization_code"
+
+// accessTokenType marks access tokens, so an ID token cannot be used as one
+const accessTokenType = "at+jwt"
+
+// supportedScopes lists the scopes a client can request
+var supportedScopes = []string{entity.ScopeOpenID, entity.ScopeProfile, entity.ScopeEmail}
+
+type oidcUsecase struct {
+	repo    OIDCRepository
+	config  *config.Config
+	clients map[string]entity.OIDCClient
+	key     *rsa.PrivateKey
+	keyID   string
+	clock   clock.Clock
+}
+
+// NewOIDCUsecase creates the provider for the registered clients, signing
+// tokens with key
+func NewOIDCUsecase(repo OIDCRepository, config *config.Config, clients []entity.OIDCClient, key *rsa.PrivateKey, clk clock.Clock) OIDCUsecase {
+	byID := make(map[string]entity.OIDCClient, len(clients))
+	for _, client := range clients {
+		byID[client.ID] = client
+	}
+
+	return &oidcUsecase{
+		repo:    repo,
+		config:  config,
+		clients: byID,
+		key:     key,
+		keyID:   keyID(&key.PublicKey),
+		clock:   clk,
+	}
+}
+
+// Discovery describes the provider's endpoints and capabilities
+func (u *oidcUsecase) Discovery() *entity.OIDCDiscovery {
+	issuer := u.config.OIDC.Issuer
+	authorizeURL := u.config.OIDC.AuthorizeURL
+	if authorizeURL == "" {
+		authorizeURL = issuer + "/oauth/authorize"
+	}
+
+	return &entity.OIDCDiscovery{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             authorizeURL,
+		TokenEndpoint:                     issuer + "/api/v1/oauth/token",
+		UserinfoEndpoint:                  issuer + "/api/v1/oauth/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   supportedScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{GrantAuthorizationCode},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "nonce",
+			"email", "name", "given_name", "family_name", "preferred_username", "picture",
+		},
+	}
+}
+
+// JWKS returns the public key tokens are signed with
+func (u *oidcUsecase) JWKS() *entity.JSONWebKeySet {
+	return &entity.JSONWebKeySet{Keys: []entity.JSONWebKey{publicJWK(&u.key.PublicKey)}}
+}
+
+// Authorize issues an authorization code to the client for the signed-in
+// user and returns where to send the user back to
+func (u *oidcUsecase) Authorize(ctx context.Context, userID uuid.UUID, req *entity.AuthorizeRequest) (*entity.AuthorizeResponse, error) {
+	client, ok := u.clients[req.ClientID]
+	if !ok {
+		return nil, errors.New(errors.ErrInvalidClient, "Unknown client", 400)
+	}
+	if req.RedirectURI != client.RedirectURI {
+		return nil, errors.ErrInvalidRedirectURIError
+	}
+
+	scopes, ok := parseScope(req.Scope)
+	if !ok {
+		return nil, errors.ErrInvalidScopeError
+	}
+
+	code, err := newSecretToken()
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to generate authorization code", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to authorize client", 500)
+	}
+
+	stored := &entity.OIDCAuthorizationCode{
+		CodeHash:      hashSecretToken(code),
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   client.RedirectURI,
+		Scope:         strings.Join(scopes, " "),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     u.clock.Now().Add(u.config.OIDC.CodeTTL),
+	}
+	if err := u.repo.CreateCode(ctx, stored); err != nil {
+		logger.FromContext(ctx).Error("Failed to store authorization code", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to authorize client", 500)
+	}
+
+	redirect, err := url.Parse(client.RedirectURI)
+	if err != nil {
+		return nil, errors.ErrInvalidRedirectURIError
+	}
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("iss", u.config.OIDC.Issuer)
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	redirect.RawQuery = query.Encode()
+
+	logger.FromContext(ctx).Info("Authorization code issued",
+		zap.String("client_id", client.ID), zap.String("user_id", userID.String()))
+
+	return &entity.AuthorizeResponse{RedirectTo: redirect.String()}, nil
+}
+
+// Token trades an authorization code for an access token and an ID token.
+// The code must be used by the client it was issued to, with the same
+// redirect URI and the PKCE verifier of its challenge.
+func (u *oidcUsecase) Token(ctx context.Context, req *entity.TokenRequest) (*entity.TokenResponse, error) {
+	if req.GrantType != GrantAuthorizationCode {
+		return nil, errors.ErrUnsupportedGrantTypeError
+	}
+
+	client, ok := u.clients[req.ClientID]
+	if !ok {
+		return nil, errors.ErrInvalidClientError
+	}
+	if client.Secret != "" && subtle.ConstantTimeCompare([]byte(req.ClientSecret), []byte(client.Secret)) != 1 {
+		return nil, errors.ErrInvalidClientError
+	}
+
+	stored, err := u.repo.ConsumeCode(ctx, hashSecretToken(req.Code))
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrInvalidGrantError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to consume authorization code", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to issue tokens", 500)
+	}
+
+	if !u.clock.Now().Before(stored.ExpiresAt) ||
+		stored.ClientID != client.ID ||
+		stored.RedirectURI != req.RedirectURI ||
+		subtle.ConstantTimeCompare([]byte(pkceChallenge(req.CodeVerifier)), []byte(stored.CodeChallenge)) != 1 {
+		return nil, errors.ErrInvalidGrantError
+	}
+
+	user, err := u.repo.GetUserByID(ctx, stored.UserID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrInvalidGrantError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to issue tokens", 500)
+	}
+
+	now := u.clock.Now()
+	expiresAt := now.Add(u.config.OIDC.TokenTTL)
+
+	accessClaims := jwt.MapClaims{
+		"iss":       u.config.OIDC.Issuer,
+		"sub":       user.ID.String(),
+		"aud":       client.ID,
+		"client_id": client.ID,
+		"scope":     stored.Scope,
+		"iat":       now.Unix(),
+		"exp":       expiresAt.Unix(),
+	}
+	if tenant := tenancy.FromContext(ctx); tenant != "" {
+		accessClaims["tenant"] = tenant
+	}
+	accessToken, err := u.sign(accessTokenType, accessClaims)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to sign access token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to issue tokens", 500)
+	}
+
+	idClaims := jwt.MapClaims{
+		"iss": u.config.OIDC.Issuer,
+		"sub": user.ID.String(),
+		"aud": client.ID,
+		"iat": now.Unix(),
+		"exp": expiresAt.Unix(),
+	}
+	if stored.Nonce != "" {
+		idClaims["nonce"] = stored.Nonce
+	}
+	addUserClaims(idClaims, userInfo(user, strings.Fields(stored.Scope)))
+
+	idToken, err := u.sign("JWT", idClaims)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to sign ID token", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to issue tokens", 500)
+	}
+
+	return &entity.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(u.config.OIDC.TokenTTL.Seconds()),
+		IDToken:     idToken,
+		Scope:       stored.Scope,
+	}, nil
+}
+
+// UserInfo returns the claims about the user an access token was issued for,
+// limited to the scopes it was granted
+func (u *oidcUsecase) UserInfo(ctx context.Context, accessToken string) (*entity.UserInfo, error) {
+	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		if token.Header["typ"] != accessTokenType {
+			return nil, fmt.Errorf("not an access token")
+		}
+		return &u.key.PublicKey, nil
+	}, jwt.WithIssuer(u.config.OIDC.Issuer), jwt.WithExpirationRequired(), jwt.WithTimeFunc(u.clock.Now))
+	if err != nil {
+		return nil, errors.ErrTokenInvalidError
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.ErrTokenInvalidError
+	}
+	subject, _ := claims.GetSubject()
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return nil, errors.ErrTokenInvalidError
+	}
+	scope, _ := claims["scope"].(string)
+	// User IDs are only unique within a tenant's database
+	if tenant, _ := claims["tenant"].(string); tenant != tenancy.FromContext(ctx) {
+		return nil, errors.ErrTokenInvalidError
+	}
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrTokenInvalidError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user info", 500)
+	}
+
+	return userInfo(user, strings.Fields(scope)), nil
+}
+
+// PruneCodes deletes authorization codes that can no longer be used
+func (u *oidcUsecase) PruneCodes(ctx context.Context) (int64, error) {
+	return u.repo.DeleteExpiredCodes(ctx, u.clock.Now())
+}
+
+// sign signs claims with the provider key, setting the typ header
+func (u *oidcUsecase) sign(typ string, claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["typ"] = typ
+	token.Header["kid"] = u.keyID
+	return token.SignedString(u.key)
+}
+
+// parseScope splits a requested scope into the distinct scopes, in the order
+// given. It fails unless openid is requested and every scope is supported.
+func parseScope(scope string) ([]string, bool) {
+	var scopes []string
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(supportedScopes, s) {
+			return nil, false
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes, slices.Contains(scopes, entity.ScopeOpenID)
+}
+
+// userInfo releases the user's claims for the granted scopes
+func userInfo(user *entity.User, scopes []string) *entity.UserInfo {
+	info := &entity.UserInfo{Subject: user.ID.String()}
+	if slices.Contains(scopes, entity.ScopeProfile) {
+		info.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		info.GivenName = user.FirstName
+		info.FamilyName = user.LastName
+		info.PreferredUsername = user.Username
+		info.Picture = user.AvatarURL
+	}
+	if slices.Contains(scopes, entity.ScopeEmail) {
+		info.Email = user.Email
+	}
+	return info
+}
+
+// addUserClaims adds the released user claims to an ID token
+func addUserClaims(claims jwt.MapClaims, info *entity.UserInfo) {
+	for name, value := range map[string]string{
+		"email":              info.Email,
+		"name":               info.Name,
+		"given_name":         info.GivenName,
+		"family_name":        info.FamilyName,
+		"preferred_username": info.PreferredUsername,
+		"picture":            info.Picture,
+	} {
+		if value != "" {
+			claims[name] = value
+		}
+	}
+}
+
+// pkceChallenge is the S256 code challenge of a verifier
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// newSecretToken returns a random authorization code
+func newSecretToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// hashSecretToken is what is stored, so a database leak does not leak usable
+// codes
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
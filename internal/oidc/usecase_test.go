@@ -0,0 +1,255 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const (
+	testIssuer   = "https://id.example.com"
+	testRedirect = "https://tool.example.com/callback"
+	testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk-verifier"
+)
+
+var testKey = func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+func newTestUsecase() (*MockOIDCRepository, *clock.Fake, OIDCUsecase) {
+	cfg := &config.Config{
+		OIDC: config.OIDCConfig{Issuer: testIssuer, CodeTTL: 5 * time.Minute, TokenTTL: time.Hour},
+	}
+	clients := []entity.OIDCClient{
+		{ID: "tool", Secret: "tool-secret", RedirectURI: testRedirect},
+		{ID: "spa", RedirectURI: "https://spa.example.com/callback"},
+	}
+
+	mockRepo := new(MockOIDCRepository)
+	clk := clock.NewFake(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	return mockRepo, clk, NewOIDCUsecase(mockRepo, cfg, clients, testKey, clk)
+}
+
+func testUser() *entity.User {
+	return &entity.User{ID: uuid.New(), Email: "jane@example.com", Username: "jane", FirstName: "Jane", LastName: "Doe"}
+}
+
+func authorizeRequest() *entity.AuthorizeRequest {
+	return &entity.AuthorizeRequest{
+		ResponseType:        "code",
+		ClientID:            "tool",
+		RedirectURI:         testRedirect,
+		Scope:               "openid email",
+		State:               "xyz",
+		Nonce:               "n-0S6_WzA2Mj",
+		CodeChallenge:       pkceChallenge(testVerifier),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+// authorize runs Authorize and returns the issued code and what was stored
+func authorize(t *testing.T, mockRepo *MockOIDCRepository, usecase OIDCUsecase, user *entity.User) (string, *entity.OIDCAuthorizationCode) {
+	var stored *entity.OIDCAuthorizationCode
+	mockRepo.On("CreateCode", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.OIDCAuthorizationCode) }).
+		Return(nil).Once()
+
+	result, err := usecase.Authorize(context.Background(), user.ID, authorizeRequest())
+	require.NoError(t, err)
+
+	redirect, err := url.Parse(result.RedirectTo)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result.RedirectTo, testRedirect+"?"))
+	assert.Equal(t, "xyz", redirect.Query().Get("state"))
+	assert.Equal(t, testIssuer, redirect.Query().Get("iss"))
+	return redirect.Query().Get("code"), stored
+}
+
+func tokenRequest(code string) *entity.TokenRequest {
+	return &entity.TokenRequest{
+		GrantType:    GrantAuthorizationCode,
+		Code:         code,
+		RedirectURI:  testRedirect,
+		ClientID:     "tool",
+		ClientSecret: "tool-secret",
+		CodeVerifier: testVerifier,
+	}
+}
+
+func TestOIDCUsecase_AuthorizationCodeFlow(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase()
+	ctx := context.Background()
+	user := testUser()
+
+	code, stored := authorize(t, mockRepo, usecase, user)
+	assert.Equal(t, hashSecretToken(code), stored.CodeHash, "only the hash of the code is stored")
+	assert.Equal(t, "openid email", stored.Scope)
+
+	mockRepo.On("ConsumeCode", mock.Anything, hashSecretToken(code)).Return(stored, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	tokens, err := usecase.Token(ctx, tokenRequest(code))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, int64(3600), tokens.ExpiresIn)
+
+	idToken, err := jwt.Parse(tokens.IDToken, func(*jwt.Token) (interface{}, error) { return &testKey.PublicKey, nil },
+		jwt.WithTimeFunc(func() time.Time { return time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC) }))
+	require.NoError(t, err)
+	claims := idToken.Claims.(jwt.MapClaims)
+	assert.Equal(t, testIssuer, claims["iss"])
+	assert.Equal(t, user.ID.String(), claims["sub"])
+	assert.Equal(t, "tool", claims["aud"])
+	assert.Equal(t, "n-0S6_WzA2Mj", claims["nonce"])
+	assert.Equal(t, "jane@example.com", claims["email"])
+	assert.NotContains(t, claims, "name", "profile claims need the profile scope")
+	assert.Equal(t, keyID(&testKey.PublicKey), idToken.Header["kid"])
+
+	info, err := usecase.UserInfo(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.UserInfo{Subject: user.ID.String(), Email: "jane@example.com"}, info)
+}
+
+func TestOIDCUsecase_Authorize_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*entity.AuthorizeRequest)
+		expected string
+	}{
+		{"unknown client", func(r *entity.AuthorizeRequest) { r.ClientID = "unknown" }, errors.ErrInvalidClient},
+		{"unregistered redirect", func(r *entity.AuthorizeRequest) { r.RedirectURI = "https://evil.example.com/cb" }, errors.ErrInvalidRedirectURI},
+		{"missing openid", func(r *entity.AuthorizeRequest) { r.Scope = "email" }, errors.ErrInvalidScope},
+		{"unsupported scope", func(r *entity.AuthorizeRequest) { r.Scope = "openid admin" }, errors.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, _, usecase := newTestUsecase()
+			req := authorizeRequest()
+			tt.modify(req)
+
+			_, err := usecase.Authorize(context.Background(), uuid.New(), req)
+
+			require.IsType(t, &errors.AppError{}, err)
+			assert.Equal(t, tt.expected, err.(*errors.AppError).Code)
+			mockRepo.AssertNotCalled(t, "CreateCode", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOIDCUsecase_Token_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*entity.TokenRequest)
+		advance  time.Duration
+		expected *errors.AppError
+	}{
+		{"wrong verifier", func(r *entity.TokenRequest) { r.CodeVerifier = strings.Repeat("a", 43) }, 0, errors.ErrInvalidGrantError},
+		{"other redirect", func(r *entity.TokenRequest) { r.RedirectURI = testRedirect + "/other" }, 0, errors.ErrInvalidGrantError},
+		{"expired code", func(*entity.TokenRequest) {}, 5 * time.Minute, errors.ErrInvalidGrantError},
+		{"issued to another client", func(r *entity.TokenRequest) { r.ClientID, r.ClientSecret = "spa", "" }, 0, errors.ErrInvalidGrantError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, clk, usecase := newTestUsecase()
+			code, stored := authorize(t, mockRepo, usecase, testUser())
+			mockRepo.On("ConsumeCode", mock.Anything, hashSecretToken(code)).Return(stored, nil)
+			clk.Advance(tt.advance)
+
+			req := tokenRequest(code)
+			tt.modify(req)
+			_, err := usecase.Token(context.Background(), req)
+
+			assert.Equal(t, tt.expected, err)
+			mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOIDCUsecase_Token_ClientAndGrant(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase()
+	ctx := context.Background()
+
+	req := tokenRequest("code")
+	req.ClientSecret = "wrong"
+	_, err := usecase.Token(ctx, req)
+	assert.Equal(t, errors.ErrInvalidClientError, err)
+
+	req = tokenRequest("code")
+	req.GrantType = "password"
+	_, err = usecase.Token(ctx, req)
+	assert.Equal(t, errors.ErrUnsupportedGrantTypeError, err)
+
+	mockRepo.On("ConsumeCode", mock.Anything, hashSecretToken("used")).Return(nil, gorm.ErrRecordNotFound)
+	_, err = usecase.Token(ctx, tokenRequest("used"))
+	assert.Equal(t, errors.ErrInvalidGrantError, err, "a code can be used once")
+}
+
+func TestOIDCUsecase_UserInfo_RejectsIDToken(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase()
+	user := testUser()
+
+	code, stored := authorize(t, mockRepo, usecase, user)
+	mockRepo.On("ConsumeCode", mock.Anything, hashSecretToken(code)).Return(stored, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	tokens, err := usecase.Token(context.Background(), tokenRequest(code))
+	require.NoError(t, err)
+
+	_, err = usecase.UserInfo(context.Background(), tokens.IDToken)
+	assert.Equal(t, errors.ErrTokenInvalidError, err)
+}
+
+func TestParseClients(t *testing.T) {
+	clients, err := ParseClients([]string{"tool:secret:https://tool.example.com:8443/cb", "spa::https://spa.example.com/cb"})
+	require.NoError(t, err)
+	assert.Equal(t, []entity.OIDCClient{
+		{ID: "tool", Secret: "secret", RedirectURI: "https://tool.example.com:8443/cb"},
+		{ID: "spa", RedirectURI: "https://spa.example.com/cb"},
+	}, clients)
+
+	for _, specs := range [][]string{{"tool"}, {"tool:secret"}, {":secret:https://x"}, {"a::https://x", "a::https://y"}} {
+		_, err := ParseClients(specs)
+		assert.Error(t, err, specs)
+	}
+}
+
+func TestOIDCUsecase_UserInfo_RejectsOtherTenant(t *testing.T) {
+	mockRepo, _, usecase := newTestUsecase()
+	user := testUser()
+	acme := tenancy.WithTenant(context.Background(), "acme")
+
+	code, stored := authorize(t, mockRepo, usecase, user)
+	mockRepo.On("ConsumeCode", mock.Anything, hashSecretToken(code)).Return(stored, nil)
+	mockRepo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	tokens, err := usecase.Token(acme, tokenRequest(code))
+	require.NoError(t, err)
+
+	_, err = usecase.UserInfo(acme, tokens.AccessToken)
+	require.NoError(t, err)
+	_, err = usecase.UserInfo(tenancy.WithTenant(context.Background(), "globex"), tokens.AccessToken)
+	assert.Equal(t, errors.ErrTokenInvalidError, err)
+	_, err = usecase.UserInfo(context.Background(), tokens.AccessToken)
+	assert.Equal(t, errors.ErrTokenInvalidError, err)
+}
@@ -0,0 +1,380 @@
+package organization
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type OrganizationHandler struct {
+	usecase OrganizationUsecase
+}
+
+func NewOrganizationHandler(usecase OrganizationUsecase) *OrganizationHandler {
+	return &OrganizationHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create an organization; the current user becomes its owner
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.OrganizationRequest true "Organization"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	org, err := h.usecase.CreateOrganization(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to create organization", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to create organization", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "Organization created successfully", org)
+}
+
+// GetOrganizations godoc
+// @Summary List my organizations
+// @Description List the organizations the current user belongs to, with their role in each
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations [get]
+func (h *OrganizationHandler) GetOrganizations(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	memberships, err := h.usecase.GetOrganizations(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get organizations", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get organizations", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Organizations retrieved successfully", memberships)
+}
+
+// GetOrganization godoc
+// @Summary Get an organization
+// @Description Get an organization the current user belongs to
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.usecase.GetOrganization(c.Request.Context(), orgID, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get organization", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get organization", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Organization retrieved successfully", org)
+}
+
+// UpdateOrganization godoc
+// @Summary Rename an organization
+// @Description Rename an organization; requires the admin or owner role
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Param request body entity.OrganizationRequest true "Organization"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	org, err := h.usecase.UpdateOrganization(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update organization", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to update organization", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Organization updated successfully", org)
+}
+
+// DeleteOrganization godoc
+// @Summary Delete an organization
+// @Description Delete an organization that owns no products; requires the owner role
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteOrganization(c.Request.Context(), orgID, userID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to delete organization", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to delete organization", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Organization deleted successfully", nil)
+}
+
+// GetMembers godoc
+// @Summary List members
+// @Description List an organization's members and their roles
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/members [get]
+func (h *OrganizationHandler) GetMembers(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.usecase.GetMembers(c.Request.Context(), orgID, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get members", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get members", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Members retrieved successfully", members)
+}
+
+// UpdateMember godoc
+// @Summary Change a member's role
+// @Description Change a member's role; requires the admin role, or the owner role to make or unmake owners
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Param user_id path string true "Member's user ID"
+// @Param request body entity.UpdateMemberRequest true "Role"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/members/{user_id} [put]
+func (h *OrganizationHandler) UpdateMember(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req entity.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	member, err := h.usecase.UpdateMember(c.Request.Context(), orgID, userID, memberID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update member", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to update member", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Member updated successfully", member)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member
+// @Description Remove a member, or leave with your own user ID; removing others requires the admin role
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID or public ID"
+// @Param user_id path string true "Member's user ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /organizations/{id}/members/{user_id} [delete]
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	orgID, userID, ok := orgAndUser(c)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	if err := h.usecase.RemoveMember(c.Request.Context(), orgID, userID, memberID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to remove member", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to remove member", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Member removed successfully", nil)
+}
+
+// orgAndUser reads the organization ID from the path and the authenticated
+// user, writing the error response when either is missing
+func orgAndUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid organization ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	return orgID, userID, ok
+}
@@ -0,0 +1,84 @@
+package organization_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationHandler_CreateAndList(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+
+	var org entity.Organization
+	api.As(owner).Post("/api/v1/organizations", entity.OrganizationRequest{Name: "Acme"}).Do().
+		AssertStatus(http.StatusCreated).
+		AssertSuccess().
+		Decode(&org)
+
+	var orgs []entity.Membership
+	api.As(owner).Get("/api/v1/organizations").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&orgs)
+	require.Len(t, orgs, 1)
+	assert.Equal(t, entity.OrgRoleOwner, orgs[0].Role)
+
+	// Outsiders cannot tell the organization exists
+	api.As(api.CreateUser()).Get("/api/v1/organizations/" + org.ID.String()).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrOrganizationNotFound)
+
+	// The only owner cannot leave
+	api.As(owner).Delete("/api/v1/organizations/" + org.ID.String() + "/members/" + owner.ID.String()).Do().
+		AssertStatus(http.StatusConflict).
+		AssertErrorCode(errors.ErrLastOwner)
+}
+
+func TestOrganizationHandler_ManageMemberProducts(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	member := api.CreateUser()
+
+	var org entity.Organization
+	api.As(owner).Post("/api/v1/organizations", entity.OrganizationRequest{Name: "Acme"}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&org)
+	require.NoError(t, api.DB.Create(&entity.Membership{OrganizationID: org.ID, UserID: member.ID, Role: entity.OrgRoleMember}).Error)
+
+	// Members cannot rename the organization
+	api.As(member).Put("/api/v1/organizations/"+org.ID.String(), entity.OrganizationRequest{Name: "Mine"}).Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrOrganizationForbidden)
+
+	// A member's product can be edited by the organization owner
+	var product entity.Product
+	api.As(member).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:           "Keyboard",
+		Price:          money.MustParse("49.99", "USD"),
+		Stock:          2,
+		Category:       "electronics",
+		OrganizationID: &org.ID,
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&product)
+
+	name := "Mechanical Keyboard"
+	api.As(owner).Put("/api/v1/products/"+product.ID.String(), entity.UpdateProductRequest{Name: &name}).Do().
+		AssertStatus(http.StatusOK)
+
+	// An organization that owns products cannot be deleted
+	api.As(owner).Delete("/api/v1/organizations/" + org.ID.String()).Do().
+		AssertStatus(http.StatusConflict).
+		AssertErrorCode(errors.ErrOrganizationNotEmpty)
+}
@@ -0,0 +1,108 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package organization
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrganizationRepository is a testify mock of OrganizationRepository
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) CreateOrganization(ctx context.Context, org *entity.Organization, owner *entity.Membership) error {
+	args := m.Called(ctx, org, owner)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	args := m.Called(ctx, orgID)
+
+	var r0 *entity.Organization
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Organization)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) UpdateOrganization(ctx context.Context, org *entity.Organization) error {
+	args := m.Called(ctx, org)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) DeleteOrganization(ctx context.Context, orgID uuid.UUID) error {
+	args := m.Called(ctx, orgID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) CountProducts(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, orgID)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMembership(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*entity.Membership, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 *entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMemberships(ctx context.Context, userID uuid.UUID) ([]*entity.Membership, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) GetMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.Membership, error) {
+	args := m.Called(ctx, orgID)
+
+	var r0 []*entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationRepository) UpdateMembership(ctx context.Context, membership *entity.Membership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) DeleteMembership(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, orgID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) CountOwners(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, orgID)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,104 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package organization
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrganizationUsecase is a testify mock of OrganizationUsecase
+type MockOrganizationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationUsecase) CreateOrganization(ctx context.Context, userID uuid.UUID, req *entity.OrganizationRequest) (*entity.Organization, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.Organization
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Organization)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationUsecase) GetOrganizations(ctx context.Context, userID uuid.UUID) ([]*entity.Membership, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationUsecase) GetOrganization(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*entity.Organization, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 *entity.Organization
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Organization)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationUsecase) UpdateOrganization(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, req *entity.OrganizationRequest) (*entity.Organization, error) {
+	args := m.Called(ctx, orgID, userID, req)
+
+	var r0 *entity.Organization
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Organization)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationUsecase) DeleteOrganization(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, orgID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationUsecase) GetMembers(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) ([]*entity.Membership, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 []*entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationUsecase) UpdateMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, memberID uuid.UUID, req *entity.UpdateMemberRequest) (*entity.Membership, error) {
+	args := m.Called(ctx, orgID, userID, memberID, req)
+
+	var r0 *entity.Membership
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Membership)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockOrganizationUsecase) RemoveMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, memberID uuid.UUID) error {
+	args := m.Called(ctx, orgID, userID, memberID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationUsecase) MemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (string, error) {
+	args := m.Called(ctx, orgID, userID)
+
+	var r0 string
+	if v := args.Get(0); v != nil {
+		r0 = v.(string)
+	}
+
+	return r0, args.Error(1)
+}
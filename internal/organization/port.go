@@ -0,0 +1,36 @@
+package organization
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationUsecase defines the business logic interface for organizations
+type OrganizationUsecase interface {
+	CreateOrganization(ctx context.Context, userID uuid.UUID, req *entity.OrganizationRequest) (*entity.Organization, error)
+	GetOrganizations(ctx context.Context, userID uuid.UUID) ([]*entity.Membership, error)
+	GetOrganization(ctx context.Context, orgID, userID uuid.UUID) (*entity.Organization, error)
+	UpdateOrganization(ctx context.Context, orgID, userID uuid.UUID, req *entity.OrganizationRequest) (*entity.Organization, error)
+	DeleteOrganization(ctx context.Context, orgID, userID uuid.UUID) error
+	GetMembers(ctx context.Context, orgID, userID uuid.UUID) ([]*entity.Membership, error)
+	UpdateMember(ctx context.Context, orgID, userID, memberID uuid.UUID, req *entity.UpdateMemberRequest) (*entity.Membership, error)
+	RemoveMember(ctx context.Context, orgID, userID, memberID uuid.UUID) error
+	MemberRole(ctx context.Context, orgID, userID uuid.UUID) (string, error)
+}
+
+// OrganizationRepository defines the data access interface for organizations
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, org *entity.Organization, owner *entity.Membership) error
+	GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error)
+	UpdateOrganization(ctx context.Context, org *entity.Organization) error
+	DeleteOrganization(ctx context.Context, orgID uuid.UUID) error
+	CountProducts(ctx context.Context, orgID uuid.UUID) (int64, error)
+	GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error)
+	GetMemberships(ctx context.Context, userID uuid.UUID) ([]*entity.Membership, error)
+	GetMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.Membership, error)
+	UpdateMembership(ctx context.Context, membership *entity.Membership) error
+	DeleteMembership(ctx context.Context, orgID, userID uuid.UUID) error
+	CountOwners(ctx context.Context, orgID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,119 @@
+package organization
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+// CreateOrganization creates the organization with its first owner
+func (r *organizationRepository) CreateOrganization(ctx context.Context, org *entity.Organization, owner *entity.Membership) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = org.ID
+		return tx.Create(owner).Error
+	})
+}
+
+func (r *organizationRepository) GetOrganizationByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	var org entity.Organization
+	err := tenancy.Conn(ctx, r.db).Where("id = ?", orgID).First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *organizationRepository) UpdateOrganization(ctx context.Context, org *entity.Organization) error {
+	return tenancy.Conn(ctx, r.db).Save(org).Error
+}
+
+// DeleteOrganization soft-deletes the organization and removes its members
+// and invitations
+func (r *organizationRepository) DeleteOrganization(ctx context.Context, orgID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", orgID).Delete(&entity.OrgInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("organization_id = ?", orgID).Delete(&entity.Membership{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Organization{}, orgID).Error
+	})
+}
+
+func (r *organizationRepository) CountProducts(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Model(&entity.Product{}).Where("organization_id = ?", orgID).Count(&count).Error
+	return count, err
+}
+
+func (r *organizationRepository) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error) {
+	var membership entity.Membership
+	err := tenancy.Conn(ctx, r.db).Where("organization_id = ? AND user_id = ?", orgID, userID).First(&membership).Error
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// GetMemberships lists the user's memberships with their organizations
+func (r *organizationRepository) GetMemberships(ctx context.Context, userID uuid.UUID) ([]*entity.Membership, error) {
+	var memberships []*entity.Membership
+	err := tenancy.Conn(ctx, r.db).
+		Joins("Organization").
+		Where("tb_organization_members.user_id = ?", userID).
+		Order("tb_organization_members.created_at").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *organizationRepository) GetMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.Membership, error) {
+	var members []*entity.Membership
+	err := tenancy.Conn(ctx, r.db).Preload("User").
+		Where("organization_id = ?", orgID).
+		Order("created_at").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) UpdateMembership(ctx context.Context, membership *entity.Membership) error {
+	return tenancy.Conn(ctx, r.db).Model(membership).
+		Where("organization_id = ? AND user_id = ?", membership.OrganizationID, membership.UserID).
+		Update("role", membership.Role).Error
+}
+
+func (r *organizationRepository) DeleteMembership(ctx context.Context, orgID, userID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Delete(&entity.Membership{}).Error
+}
+
+func (r *organizationRepository) CountOwners(ctx context.Context, orgID uuid.UUID) (int64, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Model(&entity.Membership{}).
+		Where("organization_id = ? AND role = ?", orgID, entity.OrgRoleOwner).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,248 @@
+package organization
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type organizationUsecase struct {
+	repo OrganizationRepository
+}
+
+func NewOrganizationUsecase(repo OrganizationRepository) OrganizationUsecase {
+	return &organizationUsecase{
+		repo: repo,
+	}
+}
+
+// CreateOrganization creates an organization owned by the user
+func (u *organizationUsecase) CreateOrganization(ctx context.Context, userID uuid.UUID, req *entity.OrganizationRequest) (*entity.Organization, error) {
+	org := &entity.Organization{Name: strings.TrimSpace(req.Name), CreatedBy: userID}
+	owner := &entity.Membership{UserID: userID, Role: entity.OrgRoleOwner}
+
+	if err := u.repo.CreateOrganization(ctx, org, owner); err != nil {
+		logger.FromContext(ctx).Error("Failed to create organization", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create organization", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization created", zap.String("organization_id", org.ID.String()))
+	return org, nil
+}
+
+// GetOrganizations lists the organizations the user belongs to, with their
+// role in each
+func (u *organizationUsecase) GetOrganizations(ctx context.Context, userID uuid.UUID) ([]*entity.Membership, error) {
+	memberships, err := u.repo.GetMemberships(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get organizations", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get organizations", 500)
+	}
+	return memberships, nil
+}
+
+func (u *organizationUsecase) GetOrganization(ctx context.Context, orgID, userID uuid.UUID) (*entity.Organization, error) {
+	if _, err := u.authorize(ctx, orgID, userID, entity.OrgRoleMember); err != nil {
+		return nil, err
+	}
+	return u.getOrganization(ctx, orgID)
+}
+
+func (u *organizationUsecase) UpdateOrganization(ctx context.Context, orgID, userID uuid.UUID, req *entity.OrganizationRequest) (*entity.Organization, error) {
+	if _, err := u.authorize(ctx, orgID, userID, entity.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	org, err := u.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	org.Name = strings.TrimSpace(req.Name)
+	if err := u.repo.UpdateOrganization(ctx, org); err != nil {
+		logger.FromContext(ctx).Error("Failed to update organization", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update organization", 500)
+	}
+	return org, nil
+}
+
+// DeleteOrganization deletes an organization that owns no products, with its
+// members and invitations
+func (u *organizationUsecase) DeleteOrganization(ctx context.Context, orgID, userID uuid.UUID) error {
+	if _, err := u.authorize(ctx, orgID, userID, entity.OrgRoleOwner); err != nil {
+		return err
+	}
+
+	products, err := u.repo.CountProducts(ctx, orgID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to count organization products", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete organization", 500)
+	}
+	if products > 0 {
+		return errors.ErrOrganizationNotEmptyError
+	}
+
+	if err := u.repo.DeleteOrganization(ctx, orgID); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete organization", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete organization", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization deleted", zap.String("organization_id", orgID.String()))
+	return nil
+}
+
+func (u *organizationUsecase) GetMembers(ctx context.Context, orgID, userID uuid.UUID) ([]*entity.Membership, error) {
+	if _, err := u.authorize(ctx, orgID, userID, entity.OrgRoleMember); err != nil {
+		return nil, err
+	}
+
+	members, err := u.repo.GetMembers(ctx, orgID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get members", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get members", 500)
+	}
+	return members, nil
+}
+
+// UpdateMember changes a member's role. Admins manage members; only owners
+// can make or unmake owners, and the last owner cannot step down.
+func (u *organizationUsecase) UpdateMember(ctx context.Context, orgID, userID, memberID uuid.UUID, req *entity.UpdateMemberRequest) (*entity.Membership, error) {
+	actor, err := u.authorize(ctx, orgID, userID, entity.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := u.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if (member.Role == entity.OrgRoleOwner || req.Role == entity.OrgRoleOwner) && actor.Role != entity.OrgRoleOwner {
+		return nil, errors.ErrOrganizationForbiddenError
+	}
+	if member.Role == req.Role {
+		return member, nil
+	}
+	if member.Role == entity.OrgRoleOwner {
+		if err := u.checkOtherOwner(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = req.Role
+	if err := u.repo.UpdateMembership(ctx, member); err != nil {
+		logger.FromContext(ctx).Error("Failed to update member", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update member", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization member role changed",
+		zap.String("organization_id", orgID.String()), zap.String("user_id", memberID.String()), zap.String("role", req.Role))
+	return member, nil
+}
+
+// RemoveMember removes a member, or lets the user leave. Removing an owner
+// takes an owner, and the last owner cannot leave.
+func (u *organizationUsecase) RemoveMember(ctx context.Context, orgID, userID, memberID uuid.UUID) error {
+	minRole := entity.OrgRoleAdmin
+	if memberID == userID {
+		minRole = entity.OrgRoleMember
+	}
+	actor, err := u.authorize(ctx, orgID, userID, minRole)
+	if err != nil {
+		return err
+	}
+
+	member, err := u.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Role == entity.OrgRoleOwner {
+		if actor.Role != entity.OrgRoleOwner {
+			return errors.ErrOrganizationForbiddenError
+		}
+		if err := u.checkOtherOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := u.repo.DeleteMembership(ctx, orgID, memberID); err != nil {
+		logger.FromContext(ctx).Error("Failed to remove member", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to remove member", 500)
+	}
+
+	logger.FromContext(ctx).Info("Organization member removed",
+		zap.String("organization_id", orgID.String()), zap.String("user_id", memberID.String()))
+	return nil
+}
+
+// MemberRole returns the user's role in the organization; non-members get
+// ErrOrganizationNotFoundError
+func (u *organizationUsecase) MemberRole(ctx context.Context, orgID, userID uuid.UUID) (string, error) {
+	membership, err := u.authorize(ctx, orgID, userID, entity.OrgRoleMember)
+	if err != nil {
+		return "", err
+	}
+	return membership.Role, nil
+}
+
+// authorize returns the user's membership, failing unless their role is at
+// least minRole. Non-members get not found, so organizations stay private.
+func (u *organizationUsecase) authorize(ctx context.Context, orgID, userID uuid.UUID, minRole string) (*entity.Membership, error) {
+	membership, err := u.repo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrOrganizationNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get membership", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get organization", 500)
+	}
+
+	if slices.Index(entity.ValidOrgRoles, membership.Role) < slices.Index(entity.ValidOrgRoles, minRole) {
+		return nil, errors.ErrOrganizationForbiddenError
+	}
+	return membership, nil
+}
+
+func (u *organizationUsecase) getOrganization(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	org, err := u.repo.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrOrganizationNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get organization", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get organization", 500)
+	}
+	return org, nil
+}
+
+func (u *organizationUsecase) getMember(ctx context.Context, orgID, memberID uuid.UUID) (*entity.Membership, error) {
+	member, err := u.repo.GetMembership(ctx, orgID, memberID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrMemberNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get member", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get member", 500)
+	}
+	return member, nil
+}
+
+// checkOtherOwner fails when the organization has a single owner
+func (u *organizationUsecase) checkOtherOwner(ctx context.Context, orgID uuid.UUID) error {
+	owners, err := u.repo.CountOwners(ctx, orgID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to count owners", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to update member", 500)
+	}
+	if owners <= 1 {
+		return errors.ErrLastOwnerError
+	}
+	return nil
+}
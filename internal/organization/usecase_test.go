@@ -0,0 +1,129 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type testDeps struct {
+	repo    *MockOrganizationRepository
+	usecase OrganizationUsecase
+	orgID   uuid.UUID
+}
+
+func newTestUsecase() *testDeps {
+	d := &testDeps{
+		repo:  new(MockOrganizationRepository),
+		orgID: uuid.New(),
+	}
+	d.usecase = NewOrganizationUsecase(d.repo)
+	return d
+}
+
+// member stubs the user's membership with the role
+func (d *testDeps) member(role string) uuid.UUID {
+	userID := uuid.New()
+	d.repo.On("GetMembership", mock.Anything, d.orgID, userID).
+		Return(&entity.Membership{OrganizationID: d.orgID, UserID: userID, Role: role}, nil)
+	return userID
+}
+
+func assertCode(t *testing.T, code string, err error) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestOrganizationUsecase_CreateOrganization_MakesOwner(t *testing.T) {
+	d := newTestUsecase()
+	userID := uuid.New()
+
+	d.repo.On("CreateOrganization", mock.Anything, mock.Anything, mock.MatchedBy(func(owner *entity.Membership) bool {
+		return owner.UserID == userID && owner.Role == entity.OrgRoleOwner
+	})).Return(nil).Once()
+
+	org, err := d.usecase.CreateOrganization(context.Background(), userID, &entity.OrganizationRequest{Name: "  Acme  "})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", org.Name)
+	assert.Equal(t, userID, org.CreatedBy)
+	d.repo.AssertExpectations(t)
+}
+
+func TestOrganizationUsecase_GetOrganization_NonMember(t *testing.T) {
+	d := newTestUsecase()
+	userID := uuid.New()
+	d.repo.On("GetMembership", mock.Anything, d.orgID, userID).Return((*entity.Membership)(nil), gorm.ErrRecordNotFound)
+
+	_, err := d.usecase.GetOrganization(context.Background(), d.orgID, userID)
+
+	assertCode(t, errors.ErrOrganizationNotFound, err)
+}
+
+func TestOrganizationUsecase_UpdateMember_Roles(t *testing.T) {
+	tests := []struct {
+		name     string
+		actor    string
+		target   string
+		newRole  string
+		owners   int64
+		wantCode string
+	}{
+		{name: "admin promotes member", actor: entity.OrgRoleAdmin, target: entity.OrgRoleMember, newRole: entity.OrgRoleAdmin},
+		{name: "member cannot change roles", actor: entity.OrgRoleMember, target: entity.OrgRoleMember, newRole: entity.OrgRoleAdmin, wantCode: errors.ErrOrganizationForbidden},
+		{name: "admin cannot make owners", actor: entity.OrgRoleAdmin, target: entity.OrgRoleMember, newRole: entity.OrgRoleOwner, wantCode: errors.ErrOrganizationForbidden},
+		{name: "admin cannot demote owners", actor: entity.OrgRoleAdmin, target: entity.OrgRoleOwner, newRole: entity.OrgRoleMember, wantCode: errors.ErrOrganizationForbidden},
+		{name: "owner demotes another owner", actor: entity.OrgRoleOwner, target: entity.OrgRoleOwner, newRole: entity.OrgRoleAdmin, owners: 2},
+		{name: "last owner stays", actor: entity.OrgRoleOwner, target: entity.OrgRoleOwner, newRole: entity.OrgRoleAdmin, owners: 1, wantCode: errors.ErrLastOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase()
+			actorID := d.member(tt.actor)
+			targetID := d.member(tt.target)
+			d.repo.On("CountOwners", mock.Anything, d.orgID).Return(tt.owners, nil).Maybe()
+			d.repo.On("UpdateMembership", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			member, err := d.usecase.UpdateMember(context.Background(), d.orgID, actorID, targetID, &entity.UpdateMemberRequest{Role: tt.newRole})
+
+			if tt.wantCode != "" {
+				assertCode(t, tt.wantCode, err)
+				d.repo.AssertNotCalled(t, "UpdateMembership", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.newRole, member.Role)
+		})
+	}
+}
+
+func TestOrganizationUsecase_RemoveMember_Leave(t *testing.T) {
+	d := newTestUsecase()
+	userID := d.member(entity.OrgRoleMember)
+	d.repo.On("DeleteMembership", mock.Anything, d.orgID, userID).Return(nil).Once()
+
+	require.NoError(t, d.usecase.RemoveMember(context.Background(), d.orgID, userID, userID))
+	d.repo.AssertExpectations(t)
+}
+
+func TestOrganizationUsecase_DeleteOrganization_NotEmpty(t *testing.T) {
+	d := newTestUsecase()
+	ownerID := d.member(entity.OrgRoleOwner)
+	d.repo.On("CountProducts", mock.Anything, d.orgID).Return(int64(3), nil)
+
+	err := d.usecase.DeleteOrganization(context.Background(), d.orgID, ownerID)
+
+	assertCode(t, errors.ErrOrganizationNotEmpty, err)
+	d.repo.AssertNotCalled(t, "DeleteOrganization", mock.Anything, mock.Anything)
+}
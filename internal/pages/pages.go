@@ -0,0 +1,89 @@
+// Package pages renders the few HTML pages the API serves itself, so the
+// links in its emails work without a frontend
+package pages
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed templates/*.html
+var files embed.FS
+
+// Page names
+const (
+	PageMessage       = "message"
+	PagePasswordReset = "password_reset"
+	PageMaintenance   = "maintenance"
+)
+
+// templates holds each page parsed together with the layout
+var templates = parse(PageMessage, PagePasswordReset, PageMaintenance)
+
+// contentSecurityPolicy allows the pages' inline styles and forms posting back
+// to the server, and nothing else
+const contentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'"
+
+// Data is what the pages show. Token and Errors are only used by the password
+// reset form.
+type Data struct {
+	Title   string
+	Message string
+	Token   string
+	Errors  []string
+}
+
+func parse(names ...string) map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		parsed[name] = template.Must(template.ParseFS(files, "templates/layout.html", "templates/"+name+".html"))
+	}
+	return parsed
+}
+
+// Render writes a page. Pages carry tokens in their URLs, so they are never
+// cached and never sent as a referrer.
+func Render(c *gin.Context, status int, name string, data Data) {
+	tmpl, ok := templates[name]
+	if !ok {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		c.Error(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Content-Security-Policy", contentSecurityPolicy)
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// Message renders a page with a title and a message, such as the outcome of a
+// link from an email
+func Message(c *gin.Context, status int, title, message string) {
+	Render(c, status, PageMessage, Data{Title: title, Message: message})
+}
+
+// PasswordReset renders the form that sets a new password with the token of a
+// reset link, listing any errors of the last attempt
+func PasswordReset(c *gin.Context, status int, token string, errors []string) {
+	Render(c, status, PagePasswordReset, Data{Title: "Reset your password", Token: token, Errors: errors})
+}
+
+// Maintenance renders the maintenance page. An empty message shows the
+// default one.
+func Maintenance(c *gin.Context, status int, message string) {
+	if message == "" {
+		message = "We're carrying out planned maintenance and will be back shortly."
+	}
+	Render(c, status, PageMaintenance, Data{Title: "Down for maintenance", Message: message})
+}
@@ -0,0 +1,60 @@
+package pages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func render(fn func(c *gin.Context)) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	fn(c)
+	return recorder
+}
+
+func TestMessage_EscapesContent(t *testing.T) {
+	recorder := render(func(c *gin.Context) {
+		Message(c, http.StatusOK, "Email changed", "<script>alert(1)</script>")
+	})
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+	assert.Contains(t, recorder.Header().Get("Content-Security-Policy"), "default-src 'none'")
+	assert.Contains(t, recorder.Body.String(), "<title>Email changed</title>")
+	assert.Contains(t, recorder.Body.String(), "&lt;script&gt;")
+	assert.NotContains(t, recorder.Body.String(), "<script>")
+}
+
+func TestPasswordReset_RendersFormWithErrors(t *testing.T) {
+	recorder := render(func(c *gin.Context) {
+		PasswordReset(c, http.StatusBadRequest, `tok"en`, []string{"Passwords do not match"})
+	})
+
+	body := recorder.Body.String()
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, body, `<form method="post">`)
+	assert.Contains(t, body, `name="token" value="tok&#34;en"`)
+	assert.Contains(t, body, "<li>Passwords do not match</li>")
+}
+
+func TestMaintenance_DefaultMessage(t *testing.T) {
+	recorder := render(func(c *gin.Context) {
+		Maintenance(c, http.StatusServiceUnavailable, "")
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "planned maintenance")
+
+	recorder = render(func(c *gin.Context) {
+		Maintenance(c, http.StatusServiceUnavailable, "Upgrading the database")
+	})
+	assert.Contains(t, recorder.Body.String(), "Upgrading the database")
+	assert.NotContains(t, recorder.Body.String(), "planned maintenance")
+}
@@ -0,0 +1,31 @@
+package product
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+)
+
+// checkAttributes rejects attribute keys missing from PRODUCT_ATTRIBUTES, on
+// products and in listing filters alike, naming the first unknown key in
+// order
+func (u *productUsecase) checkAttributes(attributes entity.Attributes) error {
+	allowed := u.config.Products.AttributeKeys
+
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !slices.Contains(allowed, key) {
+			return errors.New(errors.ErrUnknownAttribute, fmt.Sprintf("Unknown attribute %s", key), 400).
+				WithDetails(map[string][]string{"allowed": allowed})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package product
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/policy"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// bulkBatchSize is the number of products bulk actions read and write per
+// statement
+const bulkBatchSize = 100
+
+// bulkItemLimit caps the skipped and failed products a bulk result lists
+const bulkItemLimit = 100
+
+// Statuses of the products a bulk result lists
+const (
+	bulkSkipped = "skipped"
+	bulkFailed  = "failed"
+)
+
+// BulkProducts applies the action to the products the request picks, in
+// batches of bulkBatchSize, and summarizes the outcome. Each product is
+// checked against the policy as a single update or delete would be: listed
+// products the user may not change fail, while matched ones are skipped,
+// since a filter may also match other users' products. A batch whose write
+// fails fails as a whole and the next batch still runs. A filter's batches
+// are read one after another, so failing to read one stops the action with an
+// error, keeping the batches already applied.
+func (u *productUsecase) BulkProducts(ctx context.Context, action string, req *entity.BulkProductRequest, userID uuid.UUID) (*entity.BulkProductResult, error) {
+	if err := validateBulkRequest(action, req); err != nil {
+		return nil, err
+	}
+
+	productPolicy := NewProductPolicy(newMemberRoles(u.orgs))
+	ability := productPolicy.CanUpdate
+	if action == entity.BulkDelete || action == entity.BulkRestore {
+		ability = productPolicy.CanDelete
+	}
+	run := &bulkRun{
+		usecase: u,
+		action:  action,
+		userID:  userID,
+		ability: ability,
+		result:  &entity.BulkProductResult{Action: action, Items: []entity.BulkProductItem{}},
+	}
+
+	if req.Filter == nil {
+		run.applyIDs(ctx, req.IDs)
+	} else if err := run.applyFilter(ctx, req.Filter); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("Bulk product action applied",
+		zap.String("action", action),
+		zap.Int("succeeded", run.result.Succeeded),
+		zap.Int("failed", run.result.Failed),
+		zap.Int("skipped", run.result.Skipped),
+	)
+	return run.result, nil
+}
+
+func validateBulkRequest(action string, req *entity.BulkProductRequest) error {
+	switch action {
+	case entity.BulkDelete, entity.BulkRestore, entity.BulkActivate, entity.BulkDeactivate:
+	default:
+		return errors.New(errors.ErrBadRequest, "Bulk action must be one of: delete, restore, activate, deactivate", 400)
+	}
+
+	switch {
+	case len(req.IDs) > 0 && req.Filter != nil:
+		return errors.New(errors.ErrBadRequest, "Pick products by ids or by filter, not both", 400)
+	case req.Filter != nil && req.Filter.Empty():
+		return errors.New(errors.ErrBadRequest, "filter needs at least one of category, organization_id, is_active", 400)
+	case len(req.IDs) == 0 && req.Filter == nil:
+		return errors.New(errors.ErrBadRequest, "Pick products by ids or by filter", 400)
+	}
+	return nil
+}
+
+// bulkRun is one bulk action in progress
+type bulkRun struct {
+	usecase *productUsecase
+	action  string
+	userID  uuid.UUID
+	ability policy.Ability[*entity.Product]
+	result  *entity.BulkProductResult
+}
+
+// applyIDs applies the action to the listed products, in the order listed
+func (r *bulkRun) applyIDs(ctx context.Context, ids []uuid.UUID) {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	for start := 0; start < len(unique); start += bulkBatchSize {
+		batch := unique[start:min(start+bulkBatchSize, len(unique))]
+
+		found, err := r.usecase.repo.GetProductsByIDs(ctx, batch)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to get products for bulk action", zap.Error(err))
+			for _, id := range batch {
+				r.record(id, bulkFailed, entity.BulkReasonError)
+			}
+			continue
+		}
+
+		byID := make(map[uuid.UUID]*entity.Product, len(found))
+		for _, product := range found {
+			byID[product.ID] = product
+		}
+		products := make([]*entity.Product, 0, len(batch))
+		for _, id := range batch {
+			if product, ok := byID[id]; ok {
+				products = append(products, product)
+			} else {
+				r.record(id, bulkSkipped, entity.BulkReasonNotFound)
+			}
+		}
+		r.apply(ctx, products, bulkFailed)
+	}
+}
+
+// applyFilter applies the action to every product matching the filter. Only
+// deleted products can be restored, so restoring reads those and the other
+// actions the rest.
+func (r *bulkRun) applyFilter(ctx context.Context, filter *entity.BulkProductFilter) error {
+	var afterID uuid.UUID
+	for {
+		products, err := r.usecase.repo.GetBulkProducts(ctx, filter, r.action == entity.BulkRestore, afterID, bulkBatchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to get products for bulk action", zap.Error(err))
+			return errors.Wrap(err, errors.ErrInternal, "Failed to get products", 500)
+		}
+
+		r.apply(ctx, products, bulkSkipped)
+
+		if len(products) < bulkBatchSize {
+			return nil
+		}
+		afterID = products[len(products)-1].ID
+	}
+}
+
+// apply writes one batch: the products the action changes and the user may
+// change. Those the user may not change are recorded with deniedStatus.
+func (r *bulkRun) apply(ctx context.Context, products []*entity.Product, deniedStatus string) {
+	targets := make([]*entity.Product, 0, len(products))
+	for _, product := range products {
+		if reason := bulkSkipReason(r.action, product); reason != "" {
+			r.record(product.ID, bulkSkipped, reason)
+			continue
+		}
+
+		allowed, err := r.ability(ctx, r.userID, product)
+		switch {
+		case err != nil:
+			logger.FromContext(ctx).Error("Failed to authorize bulk action", zap.String("product_id", product.ID.String()), zap.Error(err))
+			r.record(product.ID, bulkFailed, entity.BulkReasonError)
+		case !allowed:
+			r.record(product.ID, deniedStatus, entity.BulkReasonForbidden)
+		default:
+			targets = append(targets, product)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, len(targets))
+	for i, product := range targets {
+		ids[i] = product.ID
+	}
+	if err := r.write(ctx, ids); err != nil {
+		logger.FromContext(ctx).Error("Failed to apply bulk action", zap.String("action", r.action), zap.Error(err))
+		for _, id := range ids {
+			r.record(id, bulkFailed, entity.BulkReasonError)
+		}
+		return
+	}
+
+	r.result.Succeeded += len(targets)
+	for _, product := range targets {
+		r.usecase.events.Dispatch(ctx, ChangedEvent{ProductID: product.ID})
+		if r.action == entity.BulkDelete {
+			r.usecase.events.Dispatch(ctx, DeletedEvent{ProductID: product.ID, Name: product.Name, ActorID: r.userID})
+		} else {
+			r.usecase.events.Dispatch(ctx, UpdatedEvent{ProductID: product.ID, Name: product.Name, ActorID: r.userID})
+		}
+	}
+}
+
+func (r *bulkRun) write(ctx context.Context, ids []uuid.UUID) error {
+	switch r.action {
+	case entity.BulkDelete:
+		return r.usecase.repo.DeleteProducts(ctx, ids)
+	case entity.BulkRestore:
+		return r.usecase.repo.RestoreProducts(ctx, ids)
+	default:
+		return r.usecase.repo.SetProductsActive(ctx, ids, r.action == entity.BulkActivate)
+	}
+}
+
+// record counts a skipped or failed product, listing it while there is room
+func (r *bulkRun) record(id uuid.UUID, status, reason string) {
+	if status == bulkFailed {
+		r.result.Failed++
+	} else {
+		r.result.Skipped++
+	}
+
+	if len(r.result.Items) < bulkItemLimit {
+		r.result.Items = append(r.result.Items, entity.BulkProductItem{ID: id, Status: status, Reason: reason})
+	} else {
+		r.result.Truncated = true
+	}
+}
+
+// bulkSkipReason returns why the action leaves the product as it is, or ""
+// when it changes it
+func bulkSkipReason(action string, product *entity.Product) string {
+	deleted := product.DeletedAt.Valid
+	switch action {
+	case entity.BulkDelete:
+		if deleted {
+			return entity.BulkReasonUnchanged
+		}
+	case entity.BulkRestore:
+		if !deleted {
+			return entity.BulkReasonUnchanged
+		}
+	default:
+		if deleted {
+			return entity.BulkReasonDeleted
+		}
+		if product.IsActive == (action == entity.BulkActivate) {
+			return entity.BulkReasonUnchanged
+		}
+	}
+	return ""
+}
+
+// memberRoles remembers the roles looked up during one bulk action, so the
+// products of an organization cost one query rather than one each. Failed
+// lookups other than refusals are not remembered.
+type memberRoles struct {
+	orgs  OrganizationRoles
+	roles map[[2]uuid.UUID]memberRole
+}
+
+type memberRole struct {
+	role string
+	err  error
+}
+
+func newMemberRoles(orgs OrganizationRoles) *memberRoles {
+	return &memberRoles{orgs: orgs, roles: map[[2]uuid.UUID]memberRole{}}
+}
+
+func (m *memberRoles) MemberRole(ctx context.Context, orgID, userID uuid.UUID) (string, error) {
+	key := [2]uuid.UUID{orgID, userID}
+	if cached, ok := m.roles[key]; ok {
+		return cached.role, cached.err
+	}
+
+	role, err := m.orgs.MemberRole(ctx, orgID, userID)
+	if appErr, ok := err.(*errors.AppError); err == nil || ok && appErr.StatusCode < 500 {
+		m.roles[key] = memberRole{role: role, err: err}
+	}
+	return role, err
+}
@@ -0,0 +1,26 @@
+package product
+
+import (
+	"strconv"
+	"time"
+
+	"go-clean-gin/internal/entity"
+)
+
+var productCSVHeader = []string{
+	"id", "name", "category", "price_amount", "price_currency", "stock", "is_active", "created_by", "created_at",
+}
+
+func productRecord(p *entity.Product) []string {
+	return []string{
+		p.ID.String(),
+		p.Name,
+		p.Category,
+		p.Price.FormatAmount(),
+		p.Price.Currency,
+		strconv.Itoa(p.Stock),
+		strconv.FormatBool(p.IsActive),
+		p.CreatedBy.String(),
+		p.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,81 @@
+package product
+
+import "github.com/google/uuid"
+
+// Event names published by the product module
+const (
+	EventChanged    = "product.changed"
+	EventCreated    = "product.created"
+	EventUpdated    = "product.updated"
+	EventDeleted    = "product.deleted"
+	EventOutOfStock = "product.out_of_stock"
+	EventLowStock   = "product.low_stock"
+)
+
+// ChangedEvent is dispatched whenever a product is created, updated or deleted,
+// or its stock moves, so projections such as the listing read model can refresh
+type ChangedEvent struct {
+	ProductID uuid.UUID
+}
+
+func (ChangedEvent) EventName() string {
+	return EventChanged
+}
+
+// CreatedEvent is dispatched when a user creates a product
+type CreatedEvent struct {
+	ProductID uuid.UUID
+	Name      string
+	ActorID   uuid.UUID
+}
+
+func (CreatedEvent) EventName() string {
+	return EventCreated
+}
+
+// UpdatedEvent is dispatched when a user updates a product
+type UpdatedEvent struct {
+	ProductID uuid.UUID
+	Name      string
+	ActorID   uuid.UUID
+}
+
+func (UpdatedEvent) EventName() string {
+	return EventUpdated
+}
+
+// DeletedEvent is dispatched when a user deletes a product
+type DeletedEvent struct {
+	ProductID uuid.UUID
+	Name      string
+	ActorID   uuid.UUID
+}
+
+func (DeletedEvent) EventName() string {
+	return EventDeleted
+}
+
+// OutOfStockEvent is dispatched when an update takes a product's stock to zero
+type OutOfStockEvent struct {
+	ProductID uuid.UUID
+	Name      string
+	OwnerID   uuid.UUID
+}
+
+func (OutOfStockEvent) EventName() string {
+	return EventOutOfStock
+}
+
+// LowStockEvent is dispatched by the low-stock scan once per shortage
+type LowStockEvent struct {
+	ProductID  uuid.UUID
+	Name       string
+	Stock      int
+	Threshold  int
+	OwnerID    uuid.UUID
+	OwnerEmail string
+}
+
+func (LowStockEvent) EventName() string {
+	return EventLowStock
+}
@@ -0,0 +1,50 @@
+package product
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+
+	"go-clean-gin/internal/entity"
+)
+
+// Exporter builds products export jobs: the CSV of GET /products/export for
+// the products matching the params
+type Exporter struct {
+	usecase ProductUsecase
+}
+
+func NewExporter(usecase ProductUsecase) *Exporter {
+	return &Exporter{usecase: usecase}
+}
+
+func (e *Exporter) Formats() []string {
+	return []string{entity.ExportFormatCSV}
+}
+
+func (e *Exporter) AdminOnly() bool {
+	return true
+}
+
+func (e *Exporter) Params() interface{} {
+	return &entity.ProductExportParams{}
+}
+
+func (e *Exporter) Export(ctx context.Context, export *entity.Export, params interface{}, w io.Writer) error {
+	filter := params.(*entity.ProductExportParams).ProductFilter()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(productCSVHeader); err != nil {
+		return err
+	}
+
+	err := e.usecase.ExportProducts(ctx, filter, func(product *entity.Product) error {
+		return cw.Write(productRecord(product))
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -1,11 +1,15 @@
 package product
 
 import (
+	"encoding/csv"
 	"go-clean-gin/internal/entity"
 	"go-clean-gin/pkg/errors"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/patch"
 	"go-clean-gin/pkg/response"
 	"go-clean-gin/pkg/validator"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -33,13 +37,15 @@ func NewProductHandler(usecase ProductUsecase) *ProductHandler {
 // @Success 201 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /products [post]
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var req entity.CreateProductRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
 		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
 		return
 	}
@@ -63,7 +69,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 	product, err := h.usecase.CreateProduct(c.Request.Context(), &req, userID)
 	if err != nil {
-		logger.Error("Failed to create product", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to create product", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -78,7 +84,7 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 
 // GetProducts godoc
 // @Summary Get products with filters
-// @Description Get products with optional filters and pagination
+// @Description Get products with optional filters and pagination. Filter by attributes with attributes[key]=value, e.g. attributes[color]=red; products match when they have every attribute given.
 // @Tags products
 // @Accept json
 // @Produce json
@@ -87,20 +93,27 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 // @Param max_price query number false "Maximum price filter"
 // @Param is_active query boolean false "Filter by active status"
 // @Param search query string false "Search in name and description"
+// @Param organization_id query string false "Filter by owning organization"
+// @Param saved_filter query string false "Apply a saved search's filter; other parameters override it"
+// @Param currency query string false "Also return prices converted to this currency as display_price"
+// @Param include query string false "Comma-separated relations to load: user"
 // @Param page query int false "Page number" default(1)
-// @Param limit query int false "Items per page" default(10)
+// @Param limit query int false "Items per page, at most 100" default(10)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
+// @Failure 503 {object} response.Response
 // @Router /products [get]
 func (h *ProductHandler) GetProducts(c *gin.Context) {
 	var filter entity.ProductFilter
 
 	if err := c.ShouldBindQuery(&filter); err != nil {
-		logger.Error("Failed to bind query", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
 		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
 		return
 	}
+	filter.Attributes = c.QueryMap("attributes")
 
 	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
 		response.ValidationError(c, "Validation failed", fieldErrors)
@@ -109,7 +122,7 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 
 	products, total, err := h.usecase.GetProducts(c.Request.Context(), &filter)
 	if err != nil {
-		logger.Error("Failed to get products", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to get products", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -119,7 +132,25 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		return
 	}
 
-	meta := response.Pagination(filter.Page, filter.Limit, total)
+	meta := pagination.Meta(filter.Params, total.Count, len(products), func() (time.Time, uuid.UUID) {
+		last := products[len(products)-1]
+		return last.CreatedAt, last.ID
+	})
+	meta.TotalIsEstimate = total.Estimated
+	if filter.Currency != "" {
+		items := make([]entity.Priced, len(products))
+		for i, product := range products {
+			items[i] = product
+		}
+
+		conversion, err := h.usecase.ConvertPrices(c.Request.Context(), filter.Currency, items...)
+		if err != nil {
+			conversionError(c, err)
+			return
+		}
+		meta.Exchange = conversion
+	}
+
 	response.SuccessWithMeta(c, 200, "Products retrieved successfully", products, meta)
 }
 
@@ -129,11 +160,13 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 // @Tags products
 // @Accept json
 // @Produce json
-// @Param id path string true "Product ID"
+// @Param id path string true "Product ID or public ID"
+// @Param currency query string false "Also return the price converted to this currency as display_price"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
+// @Failure 503 {object} response.Response
 // @Router /products/{id} [get]
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	productIDStr := c.Param("id")
@@ -143,9 +176,20 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
+	var query entity.ProductQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(query); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
 	product, err := h.usecase.GetProductByID(c.Request.Context(), productID)
 	if err != nil {
-		logger.Error("Failed to get product", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to get product", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -155,6 +199,17 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
+	if query.Currency != "" {
+		conversion, err := h.usecase.ConvertPrices(c.Request.Context(), query.Currency, product)
+		if err != nil {
+			conversionError(c, err)
+			return
+		}
+
+		response.SuccessWithMeta(c, 200, "Product retrieved successfully", product, &response.Meta{Exchange: conversion})
+		return
+	}
+
 	response.Success(c, 200, "Product retrieved successfully", product)
 }
 
@@ -165,12 +220,13 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security Bearer
-// @Param id path string true "Product ID"
+// @Param id path string true "Product ID or public ID"
 // @Param product body entity.UpdateProductRequest true "Update product"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /products/{id} [put]
@@ -184,11 +240,76 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 	var req entity.UpdateProductRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	h.updateProduct(c, productID, &req)
+}
+
+// PatchProduct godoc
+// @Summary Patch product
+// @Description Patch product by ID, the fields of UpdateProductRequest, with a JSON Merge Patch (application/merge-patch+json) or a JSON Patch (application/json-patch+json). Fields cannot be removed; a failing test operation answers 409. The patched product is validated and authorized like an update.
+// @Tags products
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Product ID or public ID"
+// @Param patch body object true "Merge patch or JSON Patch"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 415 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/{id} [patch]
+func (h *ProductHandler) PatchProduct(c *gin.Context) {
+	productIDStr := c.Param("id")
+	productID, err := uuid.Parse(productIDStr)
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	product, err := h.usecase.GetProductByID(c.Request.Context(), productID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get product", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get product", nil)
+		}
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
 		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
+	var req entity.UpdateProductRequest
+	if err := patch.Apply(c.ContentType(), entity.NewUpdateProductRequest(product), body, &req); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 400, errors.ErrBadRequest, "Invalid patch", err.Error())
+		}
+		return
+	}
+
+	h.updateProduct(c, productID, &req)
+}
+
+// updateProduct validates the update request, however it was built, and
+// applies it as the current user
+func (h *ProductHandler) updateProduct(c *gin.Context, productID uuid.UUID, req *entity.UpdateProductRequest) {
 	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
 		response.ValidationError(c, "Validation failed", fieldErrors)
 		return
@@ -206,9 +327,9 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := h.usecase.UpdateProduct(c.Request.Context(), productID, &req, userID)
+	product, err := h.usecase.UpdateProduct(c.Request.Context(), productID, req, userID)
 	if err != nil {
-		logger.Error("Failed to update product", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to update product", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -228,11 +349,12 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security Bearer
-// @Param id path string true "Product ID"
+// @Param id path string true "Product ID or public ID"
 // @Success 200 {object} response.Response
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
 // @Failure 404 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /products/{id} [delete]
@@ -258,7 +380,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 
 	err = h.usecase.DeleteProduct(c.Request.Context(), productID, userID)
 	if err != nil {
-		logger.Error("Failed to delete product", zap.Error(err))
+		logger.FromContext(c.Request.Context()).Error("Failed to delete product", zap.Error(err))
 
 		if appErr, ok := err.(*errors.AppError); ok {
 			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
@@ -270,3 +392,140 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 
 	response.Success(c, 200, "Product deleted successfully", nil)
 }
+
+// BulkProducts godoc
+// @Summary Apply an action to many products
+// @Description Delete, restore, activate or deactivate the listed products, or every product matching the filter, in batches. Products the action would not change, missing ones and matched products the user may not change are skipped; listed products the user may not change and failed writes fail.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param action path string true "delete, restore, activate or deactivate"
+// @Param request body entity.BulkProductRequest true "Product IDs (at most 1000) or a filter"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/bulk/{action} [post]
+func (h *ProductHandler) BulkProducts(c *gin.Context) {
+	var req entity.BulkProductRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, 401, errors.ErrUnauthorized, "User not found in context", nil)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid user ID", err.Error())
+		return
+	}
+
+	result, err := h.usecase.BulkProducts(c.Request.Context(), c.Param("action"), &req, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to apply bulk product action", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to apply bulk product action", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Bulk product action applied", result)
+}
+
+// ExportProducts godoc
+// @Summary Export products as CSV
+// @Description Stream every product matching the filters as CSV, ignoring pagination
+// @Tags products
+// @Produce text/csv
+// @Security Bearer
+// @Param category query string false "Filter by category"
+// @Param min_price query number false "Minimum price filter"
+// @Param max_price query number false "Maximum price filter"
+// @Param is_active query boolean false "Filter by active status"
+// @Param search query string false "Search in name and description"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/export [get]
+func (h *ProductHandler) ExportProducts(c *gin.Context) {
+	var filter entity.ProductFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	// Headers are sent with the first product, so a failing first query can
+	// still be reported as a JSON error
+	w := csv.NewWriter(c.Writer)
+	started := false
+	start := func() {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="products.csv"`)
+		c.Status(200)
+		_ = w.Write(productCSVHeader)
+		started = true
+	}
+
+	err := h.usecase.ExportProducts(c.Request.Context(), &filter, func(product *entity.Product) error {
+		if !started {
+			start()
+		}
+		return w.Write(productRecord(product))
+	})
+	if err != nil {
+		if started {
+			// The CSV is partly sent already; all that is left is to truncate it
+			logger.FromContext(c.Request.Context()).Error("Product export interrupted", zap.Error(err))
+			w.Flush()
+			return
+		}
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to export products", nil)
+		}
+		return
+	}
+
+	if !started {
+		start()
+	}
+	w.Flush()
+}
+
+func conversionError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+	} else {
+		response.Error(c, 500, errors.ErrInternal, "Failed to convert prices", nil)
+	}
+}
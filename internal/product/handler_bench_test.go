@@ -0,0 +1,69 @@
+package product_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+	"go-clean-gin/test/loadtest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkProductHandler_GetProducts covers the first and the last page so
+// regressions in the OFFSET pagination and COUNT(*) queries show up as the
+// table grows.
+func BenchmarkProductHandler_GetProducts(b *testing.B) {
+	const limit = 10
+
+	for _, size := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("products=%d", size), func(b *testing.B) {
+			api := apitest.New(b).WithoutContract()
+			owner := api.CreateUser()
+			require.NoError(b, loadtest.SeedProducts(api.DB, owner.ID, size))
+
+			for _, page := range []int{1, size / limit} {
+				b.Run(fmt.Sprintf("page=%d", page), func(b *testing.B) {
+					var latencies loadtest.Latencies
+
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						latencies.Time(func() {
+							api.Get("/api/v1/products").
+								Query("page", strconv.Itoa(page)).
+								Query("limit", strconv.Itoa(limit)).
+								Do().
+								AssertStatus(http.StatusOK)
+						})
+					}
+					latencies.Report(b)
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkProductHandler_CreateProduct(b *testing.B) {
+	api := apitest.New(b).WithoutContract()
+	user := api.As(api.CreateUser())
+
+	var latencies loadtest.Latencies
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		latencies.Time(func() {
+			user.Post("/api/v1/products", entity.CreateProductRequest{
+				Name:     fmt.Sprintf("Benchmark product %d", i),
+				Price:    money.MustParse("19.99", "USD"),
+				Stock:    10,
+				Category: "Electronics",
+			}).Do().
+				AssertStatus(http.StatusCreated)
+		})
+	}
+	latencies.Report(b)
+}
@@ -0,0 +1,300 @@
+package product_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/patch"
+	"go-clean-gin/test/apitest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductHandler_CreateProduct(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var product entity.Product
+	api.As(user).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Keyboard",
+		Price:    money.MustParse("49.99", "USD"),
+		Stock:    5,
+		Category: "electronics",
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		AssertSuccess().
+		Decode(&product)
+
+	assert.Equal(t, "Keyboard", product.Name)
+	assert.Equal(t, user.ID, product.CreatedBy)
+}
+
+func TestProductHandler_CreateProduct_RequiresAuth(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Post("/api/v1/products", entity.CreateProductRequest{Name: "Keyboard"}).Do().
+		AssertStatus(http.StatusUnauthorized).
+		AssertErrorCode(errors.ErrUnauthorized)
+}
+
+func TestProductHandler_CreateProduct_Validation(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Post("/api/v1/products", map[string]interface{}{"price": map[string]interface{}{"amount": "10"}}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("name")
+}
+
+func TestProductHandler_CreateProduct_PriceDecimals(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Post("/api/v1/products", map[string]interface{}{
+		"name":     "Keyboard",
+		"category": "electronics",
+		"price":    map[string]interface{}{"amount": "49.999", "currency": "USD"},
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("price")
+}
+
+func TestProductHandler_GetProduct_ConvertsCurrency(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var created entity.Product
+	api.As(user).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Keyboard",
+		Price:    money.MustParse("10", "USD"),
+		Category: "electronics",
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&created)
+
+	var product entity.Product
+	res := api.Get("/api/v1/products/"+created.ID.String()).Query("currency", "EUR").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&product)
+
+	assert.Equal(t, "10.00 USD", product.Price.String())
+	if assert.NotNil(t, product.DisplayPrice) {
+		assert.Equal(t, "9.00 EUR", product.DisplayPrice.String())
+	}
+	if exchange := res.Meta().Exchange; assert.NotNil(t, exchange) {
+		assert.Equal(t, "EUR", exchange.Currency)
+		assert.Len(t, exchange.Rates, 1)
+	}
+}
+
+func TestProductHandler_GetProducts_UnsupportedCurrency(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Get("/api/v1/products").Query("currency", "XYZ").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("currency")
+}
+
+func TestProductHandler_GetProducts_InvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Get("/api/v1/products").Query("cursor", "not-a-cursor").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("cursor")
+}
+
+func TestProductHandler_PatchProduct(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+
+	var created entity.Product
+	api.As(owner).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Keyboard",
+		Price:    money.MustParse("49.99", "USD"),
+		Stock:    10,
+		Category: "electronics",
+	}).Do().AssertStatus(http.StatusCreated).Decode(&created)
+	path := "/api/v1/products/" + created.ID.String()
+
+	var product entity.Product
+	api.As(owner).Patch(path, []byte(`{"price": {"amount": "39.99"}, "is_active": false}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&product)
+	assert.Equal(t, "39.99 USD", product.Price.String())
+	assert.False(t, product.IsActive)
+	assert.Equal(t, "Keyboard", product.Name)
+
+	api.As(owner).Patch(path, []byte(`[{"op": "test", "path": "/stock", "value": 10}, {"op": "replace", "path": "/stock", "value": 9}]`)).
+		Header("Content-Type", patch.JSONPatch).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&product)
+	assert.Equal(t, 9, product.Stock)
+
+	api.As(owner).Patch(path, []byte(`[{"op": "test", "path": "/stock", "value": 10}, {"op": "replace", "path": "/stock", "value": 0}]`)).
+		Header("Content-Type", patch.JSONPatch).Do().
+		AssertStatus(http.StatusConflict).
+		AssertErrorCode(errors.ErrPatchTestFailed)
+
+	api.As(owner).Patch(path, []byte(`{"name": null}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrPatchInvalid)
+
+	api.As(owner).Patch(path, []byte(`{"created_by": "`+uuid.NewString()+`"}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrPatchInvalid)
+
+	api.As(owner).Patch(path, []byte(`{"stock": -1}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("stock")
+
+	api.As(owner).Patch(path, map[string]interface{}{"stock": 1}).Do().
+		AssertStatus(http.StatusUnsupportedMediaType).
+		AssertErrorCode(errors.ErrUnsupportedMediaType)
+
+	api.As(api.CreateUser()).Patch(path, []byte(`{"stock": 1}`)).
+		Header("Content-Type", patch.MergePatch).Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrInvalidOwner)
+}
+
+func TestProductHandler_BulkProducts(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var ids []uuid.UUID
+	for _, name := range []string{"Ball", "Kite"} {
+		var product entity.Product
+		api.As(user).Post("/api/v1/products", entity.CreateProductRequest{
+			Name:     name,
+			Price:    money.MustParse("5", "USD"),
+			Category: "bulk-toys",
+		}).Do().AssertStatus(http.StatusCreated).Decode(&product)
+		ids = append(ids, product.ID)
+	}
+
+	var result entity.BulkProductResult
+	api.As(user).Post("/api/v1/products/bulk/deactivate", entity.BulkProductRequest{
+		Filter: &entity.BulkProductFilter{Category: "bulk-toys"},
+	}).Do().
+		AssertStatus(http.StatusOK).
+		AssertSuccess().
+		Decode(&result)
+	assert.Equal(t, 2, result.Succeeded)
+
+	api.As(user).Post("/api/v1/products/bulk/delete", entity.BulkProductRequest{IDs: ids}).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&result)
+	assert.Equal(t, 2, result.Succeeded)
+
+	api.Get("/api/v1/products/" + ids[0].String()).Do().
+		AssertStatus(http.StatusNotFound)
+
+	api.As(user).Post("/api/v1/products/bulk/archive", entity.BulkProductRequest{IDs: ids}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrBadRequest)
+}
+
+func TestProductHandler_ExportProducts(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	for _, name := range []string{"Keyboard", "Mouse"} {
+		api.As(admin).Post("/api/v1/products", entity.CreateProductRequest{
+			Name:     name,
+			Price:    money.MustParse("10", "USD"),
+			Category: "export-test",
+		}).Do().AssertStatus(http.StatusCreated)
+	}
+
+	res := api.As(admin).WithoutContract().Get("/api/v1/products/export").Query("category", "export-test").Do().
+		AssertStatus(http.StatusOK)
+
+	assert.Equal(t, "text/csv; charset=utf-8", res.Recorder.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(res.Body()), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "id,name,category,price_amount,price_currency,stock,is_active,created_by,created_at", lines[0])
+	}
+
+	api.As(api.CreateUser()).Get("/api/v1/products/export").Do().
+		AssertStatus(http.StatusForbidden)
+}
+
+func TestProductHandler_GetProducts_Attributes(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+
+	for name, attributes := range map[string]entity.Attributes{
+		"Red shirt":  {"color": "red", "size": "M"},
+		"Blue shirt": {"color": "blue", "size": "M"},
+	} {
+		api.As(owner).Post("/api/v1/products", entity.CreateProductRequest{
+			Name:       name,
+			Price:      money.MustParse("15", "USD"),
+			Category:   "attributes-test",
+			Attributes: attributes,
+		}).Do().AssertStatus(http.StatusCreated)
+	}
+
+	var products []entity.ProductReadModel
+	api.Get("/api/v1/products").Query("category", "attributes-test").Query("attributes[color]", "red").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&products)
+	if assert.Len(t, products, 1) {
+		assert.Equal(t, "Red shirt", products[0].Name)
+		assert.Equal(t, entity.Attributes{"color": "red", "size": "M"}, products[0].Attributes)
+	}
+
+	api.Get("/api/v1/products").Query("category", "attributes-test").Query("attributes[size]", "M").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&products)
+	assert.Len(t, products, 2)
+
+	api.Get("/api/v1/products").Query("attributes[flavor]", "mint").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrUnknownAttribute)
+
+	api.As(owner).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:       "Mint shirt",
+		Price:      money.MustParse("15", "USD"),
+		Category:   "attributes-test",
+		Attributes: entity.Attributes{"flavor": "mint"},
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrUnknownAttribute)
+}
@@ -0,0 +1,87 @@
+package product
+
+import (
+	"context"
+	"strconv"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/validator"
+)
+
+// importColumns are the columns every products import needs; description and
+// low_stock_threshold are optional, and other columns, such as those of a
+// products export, are ignored
+var importColumns = []string{"name", "category", "price_amount", "price_currency", "stock"}
+
+// Importer applies products imports, creating products owned by the user who
+// uploaded the file
+type Importer struct {
+	usecase ProductUsecase
+}
+
+func NewImporter(usecase ProductUsecase) *Importer {
+	return &Importer{usecase: usecase}
+}
+
+func (i *Importer) Columns() []string {
+	return importColumns
+}
+
+func (i *Importer) AdminOnly() bool {
+	return false
+}
+
+// Parse reads a row into a CreateProductRequest and validates it like the
+// create product endpoint does. Errors are keyed by column.
+func (i *Importer) Parse(ctx context.Context, imp *entity.Import, row map[string]string) (interface{}, map[string]string) {
+	fieldErrors := map[string]string{}
+	req := &entity.CreateProductRequest{
+		Name:        row["name"],
+		Description: row["description"],
+		Category:    row["category"],
+	}
+
+	price, err := money.Parse(row["price_amount"], row["price_currency"])
+	if err != nil {
+		fieldErrors["price_amount"] = "price_amount must be a number"
+	}
+	req.Price = price
+
+	if req.Stock, err = strconv.Atoi(row["stock"]); err != nil {
+		fieldErrors["stock"] = "stock must be a whole number"
+	}
+
+	if value := row["low_stock_threshold"]; value != "" {
+		threshold, err := strconv.Atoi(value)
+		if err != nil {
+			fieldErrors["low_stock_threshold"] = "low_stock_threshold must be a whole number"
+		}
+		req.LowStockThreshold = &threshold
+	}
+
+	for field, message := range validator.ValidateStruct(req) {
+		if field == "price" {
+			// Money is one field of the request but two columns
+			field = "price_amount"
+		}
+		if _, exists := fieldErrors[field]; !exists {
+			fieldErrors[field] = message
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, fieldErrors
+	}
+	return req, nil
+}
+
+func (i *Importer) Apply(ctx context.Context, imp *entity.Import, rows []interface{}) error {
+	reqs := make([]*entity.CreateProductRequest, 0, len(rows))
+	for _, row := range rows {
+		reqs = append(reqs, row.(*entity.CreateProductRequest))
+	}
+
+	_, err := i.usecase.ImportProducts(ctx, reqs, imp.UserID)
+	return err
+}
@@ -0,0 +1,55 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductReadRepository is a testify mock of ProductReadRepository
+type MockProductReadRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductReadRepository) GetProductListings(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.ProductReadModel
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.ProductReadModel)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductReadRepository) CountProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductReadRepository) EstimateProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductReadRepository) RefreshProductListings(ctx context.Context, productIDs []uuid.UUID) error {
+	args := m.Called(ctx, productIDs)
+	return args.Error(0)
+}
@@ -0,0 +1,167 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductRepository is a testify mock of ProductRepository
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) CreateProducts(ctx context.Context, products []*entity.Product) error {
+	args := m.Called(ctx, products)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, productID)
+
+	var r0 *entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Product)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockProductRepository) UpdateProduct(ctx context.Context, product *entity.Product) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) FindProducts(ctx context.Context, s ProductSpec) ([]*entity.Product, error) {
+	args := m.Called(ctx, s)
+
+	var r0 []*entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) CountProducts(ctx context.Context, s ProductSpec) (int64, error) {
+	args := m.Called(ctx, s)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) EachProduct(ctx context.Context, filter *entity.ProductFilter, batchSize int, fn func(*entity.Product) error) error {
+	args := m.Called(ctx, filter, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetLowStockProducts(ctx context.Context, defaultThreshold int) ([]*entity.Product, error) {
+	args := m.Called(ctx, defaultThreshold)
+
+	var r0 []*entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) MarkLowStockAlerted(ctx context.Context, productIDs []uuid.UUID, alertedAt time.Time) error {
+	args := m.Called(ctx, productIDs, alertedAt)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ResetLowStockAlerts(ctx context.Context, defaultThreshold int) (int64, error) {
+	args := m.Called(ctx, defaultThreshold)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]*entity.Product, error) {
+	args := m.Called(ctx, productIDs)
+
+	var r0 []*entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) GetBulkProducts(ctx context.Context, filter *entity.BulkProductFilter, deleted bool, afterID uuid.UUID, limit int) ([]*entity.Product, error) {
+	args := m.Called(ctx, filter, deleted, afterID, limit)
+
+	var r0 []*entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductRepository) DeleteProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	args := m.Called(ctx, productIDs)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) RestoreProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	args := m.Called(ctx, productIDs)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) SetProductsActive(ctx context.Context, productIDs []uuid.UUID, active bool) error {
+	args := m.Called(ctx, productIDs, active)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) FlushProductViews(ctx context.Context, limit int) (int, error) {
+	args := m.Called(ctx, limit)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
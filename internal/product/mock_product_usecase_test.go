@@ -0,0 +1,142 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/exchange"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductUsecase is a testify mock of ProductUsecase
+type MockProductUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProductUsecase) CreateProduct(ctx context.Context, req *entity.CreateProductRequest, userID uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, req, userID)
+
+	var r0 *entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, productID)
+
+	var r0 *entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, entity.Total, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.ProductReadModel
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.ProductReadModel)
+	}
+
+	var r1 entity.Total
+	if v := args.Get(1); v != nil {
+		r1 = v.(entity.Total)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockProductUsecase) UpdateProduct(ctx context.Context, productID uuid.UUID, req *entity.UpdateProductRequest, userID uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, productID, req, userID)
+
+	var r0 *entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) DeleteProduct(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
+
+func (m *MockProductUsecase) CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
+
+func (m *MockProductUsecase) CheckLowStock(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) ConvertPrices(ctx context.Context, currency string, items ...entity.Priced) (*exchange.Conversion, error) {
+	args := m.Called(ctx, currency, items)
+
+	var r0 *exchange.Conversion
+	if v := args.Get(0); v != nil {
+		r0 = v.(*exchange.Conversion)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) ExportProducts(ctx context.Context, filter *entity.ProductFilter, fn func(*entity.Product) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
+func (m *MockProductUsecase) ImportProducts(ctx context.Context, reqs []*entity.CreateProductRequest, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, reqs, userID)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) BulkProducts(ctx context.Context, action string, req *entity.BulkProductRequest, userID uuid.UUID) (*entity.BulkProductResult, error) {
+	args := m.Called(ctx, action, req, userID)
+
+	var r0 *entity.BulkProductResult
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.BulkProductResult)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductUsecase) RecordView(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+
+func (m *MockProductUsecase) FlushViews(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,26 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSettings is a testify mock of Settings
+type MockSettings struct {
+	mock.Mock
+}
+
+func (m *MockSettings) Int(ctx context.Context, key string, userID uuid.UUID) int {
+	args := m.Called(ctx, key, userID)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0
+}
@@ -0,0 +1,19 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockViewCounter is a testify mock of ViewCounter
+type MockViewCounter struct {
+	mock.Mock
+}
+
+func (m *MockViewCounter) Incr(ctx context.Context, name string, key string, n int64) error {
+	args := m.Called(ctx, name, key, n)
+	return args.Error(0)
+}
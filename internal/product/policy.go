@@ -0,0 +1,42 @@
+package product
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// ProductPolicy decides who may modify products: their creator, and for
+// products owned by an organization, its admins and owners too. Creators who
+// left the organization lose access to its products.
+type ProductPolicy struct {
+	orgs OrganizationRoles
+}
+
+func NewProductPolicy(orgs OrganizationRoles) *ProductPolicy {
+	return &ProductPolicy{orgs: orgs}
+}
+
+// CanUpdate reports whether the user may change the product
+func (p *ProductPolicy) CanUpdate(ctx context.Context, userID uuid.UUID, product *entity.Product) (bool, error) {
+	if product.OrganizationID == nil {
+		return product.CreatedBy == userID, nil
+	}
+
+	role, err := p.orgs.MemberRole(ctx, *product.OrganizationID, userID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok && appErr.StatusCode < 500 {
+			return false, nil
+		}
+		return false, err
+	}
+	return role != entity.OrgRoleMember || product.CreatedBy == userID, nil
+}
+
+// CanDelete reports whether the user may delete the product, which whoever
+// may change it can
+func (p *ProductPolicy) CanDelete(ctx context.Context, userID uuid.UUID, product *entity.Product) (bool, error) {
+	return p.CanUpdate(ctx, userID, product)
+}
@@ -0,0 +1,55 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProductPolicy_CanUpdate(t *testing.T) {
+	orgID := uuid.New()
+	creatorID := uuid.New()
+	failure := fmt.Errorf("connection refused")
+
+	tests := []struct {
+		name    string
+		orgID   *uuid.UUID
+		userID  uuid.UUID
+		role    string
+		roleErr error
+		allowed bool
+		err     error
+	}{
+		{name: "creator of a personal product", userID: creatorID, allowed: true},
+		{name: "other user, personal product", userID: uuid.New()},
+		{name: "organization owner", orgID: &orgID, userID: uuid.New(), role: entity.OrgRoleOwner, allowed: true},
+		{name: "creator who is a member", orgID: &orgID, userID: creatorID, role: entity.OrgRoleMember, allowed: true},
+		{name: "other member", orgID: &orgID, userID: uuid.New(), role: entity.OrgRoleMember},
+		{name: "creator who left", orgID: &orgID, userID: creatorID, roleErr: errors.ErrOrganizationNotFoundError},
+		{name: "role lookup failed", orgID: &orgID, userID: creatorID, roleErr: failure, err: failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrgs := new(MockOrganizationRoles)
+			mockOrgs.On("MemberRole", mock.Anything, orgID, tt.userID).Return(tt.role, tt.roleErr).Maybe()
+			policy := NewProductPolicy(mockOrgs)
+			product := &entity.Product{ID: uuid.New(), CreatedBy: creatorID, OrganizationID: tt.orgID}
+
+			allowed, err := policy.CanUpdate(context.Background(), tt.userID, product)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.allowed, allowed)
+
+			allowed, err = policy.CanDelete(context.Background(), tt.userID, product)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package product
 import (
 	"context"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/exchange"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,17 +13,79 @@ import (
 type ProductUsecase interface {
 	CreateProduct(ctx context.Context, req *entity.CreateProductRequest, userID uuid.UUID) (*entity.Product, error)
 	GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error)
-	GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int64, error)
+	GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, entity.Total, error)
 	UpdateProduct(ctx context.Context, productID uuid.UUID, req *entity.UpdateProductRequest, userID uuid.UUID) (*entity.Product, error)
 	DeleteProduct(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error
+	CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error
+	CheckLowStock(ctx context.Context) (int, error)
+	ConvertPrices(ctx context.Context, currency string, items ...entity.Priced) (*exchange.Conversion, error)
+	ExportProducts(ctx context.Context, filter *entity.ProductFilter, fn func(*entity.Product) error) error
+	ImportProducts(ctx context.Context, reqs []*entity.CreateProductRequest, userID uuid.UUID) (int, error)
+	BulkProducts(ctx context.Context, action string, req *entity.BulkProductRequest, userID uuid.UUID) (*entity.BulkProductResult, error)
+	RecordView(ctx context.Context, productID uuid.UUID) error
+	FlushViews(ctx context.Context) (int, error)
 }
 
-// ProductRepository defines the data access interface for products
+// ProductRepository defines the data access interface for products. The
+// container serves it through RetryingProductRepository, generated with
+// artisan make:retry.
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *entity.Product) error
+	CreateProducts(ctx context.Context, products []*entity.Product) error
+	//retry:idempotent
 	GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error)
+	//retry:idempotent
 	GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int64, error)
 	UpdateProduct(ctx context.Context, product *entity.Product) error
 	DeleteProduct(ctx context.Context, productID uuid.UUID) error
-	GetProductsByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Product, error)
+	//retry:idempotent
+	FindProducts(ctx context.Context, s ProductSpec) ([]*entity.Product, error)
+	//retry:idempotent
+	CountProducts(ctx context.Context, s ProductSpec) (int64, error)
+	EachProduct(ctx context.Context, filter *entity.ProductFilter, batchSize int, fn func(*entity.Product) error) error
+	//retry:idempotent
+	GetLowStockProducts(ctx context.Context, defaultThreshold int) ([]*entity.Product, error)
+	//retry:idempotent
+	MarkLowStockAlerted(ctx context.Context, productIDs []uuid.UUID, alertedAt time.Time) error
+	ResetLowStockAlerts(ctx context.Context, defaultThreshold int) (int64, error)
+	//retry:idempotent
+	GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]*entity.Product, error)
+	//retry:idempotent
+	GetBulkProducts(ctx context.Context, filter *entity.BulkProductFilter, deleted bool, afterID uuid.UUID, limit int) ([]*entity.Product, error)
+	//retry:idempotent
+	DeleteProducts(ctx context.Context, productIDs []uuid.UUID) error
+	//retry:idempotent
+	RestoreProducts(ctx context.Context, productIDs []uuid.UUID) error
+	//retry:idempotent
+	SetProductsActive(ctx context.Context, productIDs []uuid.UUID, active bool) error
+	FlushProductViews(ctx context.Context, limit int) (int, error)
+}
+
+// ProductReadRepository defines the data access interface for the product
+// listing read model, served through RetryingProductReadRepository
+type ProductReadRepository interface {
+	//retry:idempotent
+	GetProductListings(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, error)
+	//retry:idempotent
+	CountProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error)
+	//retry:idempotent
+	EstimateProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error)
+	//retry:idempotent
+	RefreshProductListings(ctx context.Context, productIDs []uuid.UUID) error
+}
+
+// ViewCounter counts product views, implemented by *counter.Counter
+type ViewCounter interface {
+	Incr(ctx context.Context, name, key string, n int64) error
+}
+
+// Settings reads runtime settings, implemented by setting.SettingUsecase
+type Settings interface {
+	Int(ctx context.Context, key string, userID uuid.UUID) int
+}
+
+// OrganizationRoles looks up users' roles in the organizations that own
+// products, implemented by organization.OrganizationUsecase
+type OrganizationRoles interface {
+	MemberRole(ctx context.Context, orgID, userID uuid.UUID) (string, error)
 }
@@ -0,0 +1,18 @@
+package product
+
+import (
+	"context"
+	"go-clean-gin/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+// RegisterProjector keeps the product listing read model in step with
+// tb_products. Dispatch is synchronous, so a listing requested after a write
+// already reflects it.
+func RegisterProjector(bus *events.Bus, readRepo ProductReadRepository) {
+	bus.Listen(EventChanged, func(ctx context.Context, event events.Event) error {
+		e := event.(ChangedEvent)
+		return readRepo.RefreshProductListings(ctx, []uuid.UUID{e.ProductID})
+	})
+}
@@ -0,0 +1,117 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// refreshReadModelsSQL projects live products and their owners into
+// tb_product_read_models; the caller appends the product filter
+const refreshReadModelsSQL = `
+	INSERT INTO tb_product_read_models (
+		product_id, public_id, name, description, price_amount, price_currency, stock,
+		category, category_path, is_active, created_by, owner_name, organization_id, attributes, created_at, updated_at
+	)
+	SELECT p.id, p.public_id, p.name, p.description, p.price_amount, p.price_currency, p.stock,
+		p.category, p.category, p.is_active, p.created_by, TRIM(u.first_name || ' ' || u.last_name),
+		p.organization_id, p.attributes, p.created_at, p.updated_at
+	FROM tb_products p
+	JOIN tb_users u ON u.id = p.created_by
+	WHERE p.deleted_at IS NULL`
+
+type productReadRepository struct {
+	db *gorm.DB
+}
+
+func NewProductReadRepository(db *gorm.DB) ProductReadRepository {
+	return &productReadRepository{
+		db: db,
+	}
+}
+
+func (r *productReadRepository) GetProductListings(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, error) {
+	var products []*entity.ProductReadModel
+
+	query := applyProductFilter(tenancy.Conn(ctx, r.db).Model(&entity.ProductReadModel{}), filter)
+
+	query = pagination.Apply(query, filter.Params, "created_at", "product_id")
+	query = applyIncludes(query, filter.Includes())
+	if err := query.Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *productReadRepository) CountProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error) {
+	var total int64
+	err := applyProductFilter(tenancy.Conn(ctx, r.db).Model(&entity.ProductReadModel{}), filter).Count(&total).Error
+	return total, err
+}
+
+// EstimateProductListings returns the planner's estimate of the rows filter
+// matches, read from EXPLAIN without running the query. Without filters it is
+// derived from pg_class.reltuples, so it is only as fresh as the last ANALYZE.
+func (r *productReadRepository) EstimateProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error) {
+	db := tenancy.Conn(ctx, r.db)
+
+	// Build the listing query without running it. The statement already has
+	// Postgres placeholders, so it is run on the connection rather than Raw.
+	stmt := applyProductFilter(db.Session(&gorm.Session{DryRun: true}).Model(&entity.ProductReadModel{}), filter).
+		Select("product_id").Find(&[]*entity.ProductReadModel{}).Statement
+
+	rows, err := db.Statement.ConnPool.QueryContext(ctx, "EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var plan []byte
+	if rows.Next() {
+		if err := rows.Scan(&plan); err != nil {
+			return 0, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return 0, err
+	}
+	if len(explained) == 0 {
+		return 0, errors.New("empty query plan")
+	}
+
+	return int64(explained[0].Plan.Rows), nil
+}
+
+// RefreshProductListings rebuilds the read model rows of productIDs, or of
+// every product when productIDs is empty. Deleted products lose their row.
+func (r *productReadRepository) RefreshProductListings(ctx context.Context, productIDs []uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if len(productIDs) == 0 {
+			if err := tx.Exec("DELETE FROM tb_product_read_models").Error; err != nil {
+				return err
+			}
+			return tx.Exec(refreshReadModelsSQL).Error
+		}
+
+		if err := tx.Exec("DELETE FROM tb_product_read_models WHERE product_id IN ?", productIDs).Error; err != nil {
+			return err
+		}
+		return tx.Exec(refreshReadModelsSQL+" AND p.id IN ?", productIDs).Error
+	})
+}
@@ -0,0 +1,149 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/test/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductReadRepository_RefreshProductListings(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	readRepo := NewProductReadRepository(db)
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	product := &entity.Product{
+		Name:      "Listed",
+		Price:     money.MustParse("5", "USD"),
+		Category:  "read-model-test",
+		IsActive:  true,
+		CreatedBy: user.ID,
+	}
+	require.NoError(t, repo.CreateProduct(ctx, product))
+	require.NoError(t, readRepo.RefreshProductListings(ctx, []uuid.UUID{product.ID}))
+
+	filter := &entity.ProductFilter{Category: "read-model-test", Params: pagination.Params{Page: 1, Limit: 10}}
+	listings, err := readRepo.GetProductListings(ctx, filter)
+	require.NoError(t, err)
+	if assert.Len(t, listings, 1) {
+		assert.Equal(t, "Test User", listings[0].OwnerName)
+		assert.Equal(t, "5.00 USD", listings[0].Price.String())
+		assert.Nil(t, listings[0].User)
+	}
+
+	require.NoError(t, repo.DeleteProduct(ctx, product.ID))
+	require.NoError(t, readRepo.RefreshProductListings(ctx, []uuid.UUID{product.ID}))
+
+	total, err := readRepo.CountProductListings(ctx, filter)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestProductReadRepository_GetProductListings_IncludeQueryCount(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+
+	// One owner per product: a per-row preload would show up as extra queries
+	for i := 0; i < 5; i++ {
+		require.NoError(t, repo.CreateProduct(ctx, &entity.Product{
+			Name:      fmt.Sprintf("Product %d", i),
+			Price:     money.MustParse("1", "USD"),
+			Category:  "include-test",
+			IsActive:  true,
+			CreatedBy: createTestUser(t, db).ID,
+		}))
+	}
+
+	counted, counter := testdb.CountQueries(db)
+	readRepo := NewProductReadRepository(counted)
+	require.NoError(t, readRepo.RefreshProductListings(ctx, nil))
+
+	filter := &entity.ProductFilter{Category: "include-test", Params: pagination.Params{Page: 1, Limit: 10}}
+
+	counter.Reset()
+	listings, err := readRepo.GetProductListings(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, listings, 5)
+	assert.Equal(t, 1, counter.Count(), "page only: %v", counter.Queries())
+
+	filter.Include = entity.ProductIncludeUser
+	counter.Reset()
+	listings, err = readRepo.GetProductListings(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, listings, 5)
+	assert.Equal(t, 2, counter.Count(), "page and one batch of users: %v", counter.Queries())
+	for _, listing := range listings {
+		if assert.NotNil(t, listing.User) {
+			assert.Equal(t, listing.CreatedBy, listing.User.ID)
+		}
+	}
+}
+
+func TestProductReadRepository_EstimateProductListings(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	readRepo := NewProductReadRepository(db)
+
+	active := true
+	estimate, err := readRepo.EstimateProductListings(context.Background(), &entity.ProductFilter{
+		Category: "estimate-test",
+		IsActive: &active,
+		Search:   "phone",
+		MinPrice: money.MustParse("1", "USD").Amount,
+	})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, estimate, int64(0))
+}
+
+func TestProductReadRepository_GetProductListings_Cursor(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	readRepo := NewProductReadRepository(db)
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		product := &entity.Product{
+			Name:      fmt.Sprintf("Product %d", i),
+			Price:     money.MustParse("1", "USD"),
+			Category:  "cursor-test",
+			IsActive:  true,
+			CreatedBy: user.ID,
+		}
+		require.NoError(t, repo.CreateProduct(ctx, product))
+		ids = append(ids, product.ID)
+	}
+	require.NoError(t, readRepo.RefreshProductListings(ctx, ids))
+
+	filter := &entity.ProductFilter{Category: "cursor-test", Params: pagination.Params{Page: 1, Limit: 2}}
+	first, err := readRepo.GetProductListings(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	last := first[len(first)-1]
+	filter.Cursor = pagination.EncodeCursor(last.CreatedAt, last.ID)
+	second, err := readRepo.GetProductListings(ctx, filter)
+	require.NoError(t, err)
+	if assert.Len(t, second, 1) {
+		assert.NotContains(t, []uuid.UUID{first[0].ID, first[1].ID}, second[0].ID)
+	}
+}
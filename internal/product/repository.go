@@ -2,8 +2,15 @@ package product
 
 import (
 	"context"
-	"fmt"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/counter"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/queryfilter"
+	"go-clean-gin/pkg/spec"
+	"go-clean-gin/pkg/tenancy"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -20,12 +27,18 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 }
 
 func (r *productRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
-	return r.db.WithContext(ctx).Create(product).Error
+	return tenancy.Conn(ctx, r.db).Create(product).Error
+}
+
+// CreateProducts inserts products in one statement, so either all are created
+// or none
+func (r *productRepository) CreateProducts(ctx context.Context, products []*entity.Product) error {
+	return tenancy.Conn(ctx, r.db).Create(products).Error
 }
 
 func (r *productRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
 	var product entity.Product
-	err := r.db.WithContext(ctx).Preload("User").Where("id = ?", productID).First(&product).Error
+	err := tenancy.Conn(ctx, r.db).Preload("User").Where("id = ?", productID).First(&product).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,43 +49,14 @@ func (r *productRepository) GetProducts(ctx context.Context, filter *entity.Prod
 	var products []*entity.Product
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Product{}).Preload("User")
-
-	// Apply filters
-	if filter.Category != "" {
-		query = query.Where("category = ?", filter.Category)
-	}
-
-	if filter.MinPrice > 0 {
-		query = query.Where("price >= ?", filter.MinPrice)
-	}
-
-	if filter.MaxPrice > 0 {
-		query = query.Where("price <= ?", filter.MaxPrice)
-	}
-
-	if filter.IsActive != nil {
-		query = query.Where("is_active = ?", *filter.IsActive)
-	}
-
-	if filter.Search != "" {
-		searchTerm := fmt.Sprintf("%%%s%%", filter.Search)
-		query = query.Where("name ILIKE ? OR description ILIKE ?", searchTerm, searchTerm)
-	}
+	query := applyProductFilter(tenancy.Conn(ctx, r.db).Model(&entity.Product{}).Preload("User"), filter)
 
 	// Get total count
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Apply pagination
-	if filter.Page > 0 && filter.Limit > 0 {
-		offset := (filter.Page - 1) * filter.Limit
-		query = query.Offset(offset).Limit(filter.Limit)
-	}
-
-	// Order by created_at desc
-	query = query.Order("created_at DESC")
+	query = pagination.Apply(query, filter.Params, "created_at", "id")
 
 	if err := query.Find(&products).Error; err != nil {
 		return nil, 0, err
@@ -82,18 +66,217 @@ func (r *productRepository) GetProducts(ctx context.Context, filter *entity.Prod
 }
 
 func (r *productRepository) UpdateProduct(ctx context.Context, product *entity.Product) error {
-	return r.db.WithContext(ctx).Save(product).Error
+	return tenancy.Conn(ctx, r.db).Save(product).Error
 }
 
 func (r *productRepository) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Product{}, productID).Error
+	return tenancy.Conn(ctx, r.db).Delete(&entity.Product{}, productID).Error
+}
+
+// FindProducts returns the products satisfying the specification with their
+// creators, newest first. A nil specification matches every product.
+func (r *productRepository) FindProducts(ctx context.Context, s ProductSpec) ([]*entity.Product, error) {
+	var products []*entity.Product
+	query := spec.Where(tenancy.Conn(ctx, r.db).Preload("User"), s, productClause)
+	if err := query.Order("created_at DESC").Order("id").Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
 }
 
-func (r *productRepository) GetProductsByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Product, error) {
+// CountProducts returns the number of products satisfying the specification
+func (r *productRepository) CountProducts(ctx context.Context, s ProductSpec) (int64, error) {
+	var count int64
+	err := spec.Where(tenancy.Conn(ctx, r.db).Model(&entity.Product{}), s, productClause).Count(&count).Error
+	return count, err
+}
+
+// lowStockThreshold is the effective threshold expression. With no default,
+// products that do not set their own threshold never match.
+func lowStockThreshold(defaultThreshold int) (string, []interface{}) {
+	if defaultThreshold <= 0 {
+		return "low_stock_threshold", nil
+	}
+	return "COALESCE(low_stock_threshold, ?)", []interface{}{defaultThreshold}
+}
+
+// GetLowStockProducts returns active products at or below their threshold that
+// have not been alerted yet
+func (r *productRepository) GetLowStockProducts(ctx context.Context, defaultThreshold int) ([]*entity.Product, error) {
+	threshold, args := lowStockThreshold(defaultThreshold)
+
 	var products []*entity.Product
-	err := r.db.WithContext(ctx).Preload("User").Where("created_by = ?", userID).Find(&products).Error
+	err := tenancy.Conn(ctx, r.db).Preload("User").
+		Where("is_active = ? AND low_stock_alerted_at IS NULL", true).
+		Where("stock <= "+threshold, args...).
+		Order("stock ASC").
+		Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
 	return products, nil
 }
+
+func (r *productRepository) MarkLowStockAlerted(ctx context.Context, productIDs []uuid.UUID, alertedAt time.Time) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	return tenancy.Conn(ctx, r.db).Model(&entity.Product{}).
+		Where("id IN ?", productIDs).
+		Update("low_stock_alerted_at", alertedAt).Error
+}
+
+// ResetLowStockAlerts re-arms alerts for products restocked above their threshold
+func (r *productRepository) ResetLowStockAlerts(ctx context.Context, defaultThreshold int) (int64, error) {
+	threshold, args := lowStockThreshold(defaultThreshold)
+
+	result := tenancy.Conn(ctx, r.db).Model(&entity.Product{}).
+		Where("low_stock_alerted_at IS NOT NULL").
+		Where("((low_stock_threshold IS NULL AND ? <= 0) OR stock > "+threshold+")", append([]interface{}{defaultThreshold}, args...)...).
+		Update("low_stock_alerted_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// EachProduct calls fn for every product matching filter, in id order, ignoring
+// pagination. Products are read in batches of batchSize with keyset pagination
+// (id > last id seen), so memory use stays constant and the last batch costs as
+// much as the first. An error from fn stops the iteration and is returned.
+func (r *productRepository) EachProduct(ctx context.Context, filter *entity.ProductFilter, batchSize int, fn func(*entity.Product) error) error {
+	var lastID uuid.UUID
+	batch := make([]*entity.Product, 0, batchSize)
+
+	for {
+		query := applyProductFilter(tenancy.Conn(ctx, r.db).Model(&entity.Product{}), filter)
+		if lastID != uuid.Nil {
+			query = query.Where("id > ?", lastID)
+		}
+
+		batch = batch[:0]
+		if err := query.Order("id").Limit(batchSize).Find(&batch).Error; err != nil {
+			return err
+		}
+
+		for _, product := range batch {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// GetProductsByIDs returns the products with the IDs, soft-deleted ones
+// included, in no particular order
+func (r *productRepository) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if err := tenancy.Conn(ctx, r.db).Unscoped().Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetBulkProducts returns up to limit products matching filter with IDs after
+// afterID, in id order: soft-deleted ones when deleted is set, the others
+// when not. Passing the last ID of a batch as afterID reads the next one.
+func (r *productRepository) GetBulkProducts(ctx context.Context, filter *entity.BulkProductFilter, deleted bool, afterID uuid.UUID, limit int) ([]*entity.Product, error) {
+	query := tenancy.Conn(ctx, r.db)
+	if deleted {
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+	query = queryfilter.Apply(query, filter)
+	if afterID != uuid.Nil {
+		query = query.Where("id > ?", afterID)
+	}
+
+	var products []*entity.Product
+	if err := query.Order("id").Limit(limit).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// DeleteProducts soft-deletes the products in one statement
+func (r *productRepository) DeleteProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Where("id IN ?", productIDs).Delete(&entity.Product{}).Error
+}
+
+// RestoreProducts undoes the soft deletion of the products in one statement
+func (r *productRepository) RestoreProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Unscoped().Model(&entity.Product{}).
+		Where("id IN ? AND deleted_at IS NOT NULL", productIDs).
+		Update("deleted_at", nil).Error
+}
+
+// SetProductsActive activates or deactivates the products in one statement
+func (r *productRepository) SetProductsActive(ctx context.Context, productIDs []uuid.UUID, active bool) error {
+	return tenancy.Conn(ctx, r.db).Model(&entity.Product{}).
+		Where("id IN ?", productIDs).
+		Update("is_active", active).Error
+}
+
+// FlushProductViews adds up to limit pending view count deltas to their
+// products in one transaction and returns the number of deltas drained.
+// updated_at is left alone, since a view does not change the product.
+func (r *productRepository) FlushProductViews(ctx context.Context, limit int) (int, error) {
+	var drained int
+	err := tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		views, n, err := counter.Drain(tx, entity.CounterProductViews, limit)
+		if err != nil {
+			return err
+		}
+		drained = n
+
+		// In key order, so concurrent flushes lock products alike
+		keys := make([]string, 0, len(views))
+		for key := range views {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		rows := make([]string, 0, len(keys))
+		args := make([]interface{}, 0, 2*len(keys))
+		for _, key := range keys {
+			// Deltas of keys that are not product IDs are dropped
+			if id, err := uuid.Parse(key); err == nil {
+				rows = append(rows, "(?, ?)")
+				args = append(args, id, views[key])
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Exec(`
+			UPDATE tb_products AS p SET view_count = p.view_count + v.views::bigint
+			FROM (VALUES `+strings.Join(rows, ", ")+`) AS v(id, views) WHERE p.id = v.id::uuid`, args...).Error
+	})
+	return drained, err
+}
+
+// productIncludes maps the ?include= values of product listings to the GORM
+// relations they load
+var productIncludes = map[string]string{
+	entity.ProductIncludeUser: "User",
+}
+
+// applyIncludes preloads the requested relations. GORM loads each relation for
+// the whole page with one IN query, so the query count does not grow with the
+// page size.
+func applyIncludes(query *gorm.DB, includes []string) *gorm.DB {
+	for _, include := range includes {
+		if relation, ok := productIncludes[include]; ok {
+			query = query.Preload(relation)
+		}
+	}
+	return query
+}
+
+// applyProductFilter adds the listing filters shared by tb_products and its
+// read model, which use the same column names, from the filter tags of
+// entity.ProductFilter
+func applyProductFilter(query *gorm.DB, filter *entity.ProductFilter) *gorm.DB {
+	return queryfilter.Apply(query, filter)
+}
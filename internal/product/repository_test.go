@@ -0,0 +1,355 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/counter"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/spec"
+	"go-clean-gin/test/fixtures"
+	"go-clean-gin/test/testdb"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createTestUser(t *testing.T, db *gorm.DB) *entity.User {
+	t.Helper()
+
+	suffix := uuid.NewString()[:8]
+	user := &entity.User{
+		Email:     "user_" + suffix + "@example.com",
+		Username:  "user_" + suffix,
+		Password:  "hashed",
+		FirstName: "Test",
+		LastName:  "User",
+		Role:      entity.RoleUser,
+	}
+	require.NoError(t, db.Create(user).Error)
+	return user
+}
+
+func TestProductRepository_CreateAndGet(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	user := createTestUser(t, db)
+
+	product := &entity.Product{
+		Name:      "Test Product",
+		Price:     money.MustParse("99.99", "USD"),
+		Stock:     10,
+		Category:  "isolation-test",
+		IsActive:  true,
+		CreatedBy: user.ID,
+	}
+	require.NoError(t, repo.CreateProduct(context.Background(), product))
+
+	found, err := repo.GetProductByID(context.Background(), product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Product", found.Name)
+	assert.Equal(t, user.ID, found.User.ID)
+}
+
+func TestProductRepository_GetProducts_IsolatedPerTest(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	user := createTestUser(t, db)
+
+	for _, name := range []string{"First", "Second"} {
+		require.NoError(t, repo.CreateProduct(context.Background(), &entity.Product{
+			Name:      name,
+			Price:     money.MustParse("1", "USD"),
+			Category:  "isolation-test",
+			IsActive:  true,
+			CreatedBy: user.ID,
+		}))
+	}
+
+	// Products created by other tests are rolled back or invisible to this transaction
+	products, total, err := repo.GetProducts(context.Background(), &entity.ProductFilter{
+		Category: "isolation-test",
+		Params:   pagination.Params{Page: 1, Limit: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, products, 2)
+}
+
+func TestProductRepository_GetProducts_Fixtures(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	fixtures.Load(t, db, "testdata/fixtures")
+	repo := NewProductRepository(db)
+
+	active := true
+	products, total, err := repo.GetProducts(context.Background(), &entity.ProductFilter{
+		Category: "fixture-peripherals",
+		IsActive: &active,
+		Params:   pagination.Params{Page: 1, Limit: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, products, 2)
+}
+
+func TestProductRepository_LowStockAlerts(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	threshold := func(n int) *int { return &n }
+	create := func(name string, stock int, lowStockThreshold *int, active bool) *entity.Product {
+		product := &entity.Product{
+			Name:              name,
+			Price:             money.MustParse("1", "USD"),
+			Stock:             stock,
+			Category:          "low-stock-test",
+			IsActive:          true,
+			LowStockThreshold: lowStockThreshold,
+			CreatedBy:         user.ID,
+		}
+		require.NoError(t, repo.CreateProduct(ctx, product))
+		if !active {
+			require.NoError(t, db.Model(product).Update("is_active", false).Error)
+		}
+		return product
+	}
+
+	usesDefault := create("Uses default", 2, nil, true)
+	ownThreshold := create("Own threshold", 8, threshold(10), true)
+	create("Above own threshold", 3, threshold(1), true)
+	create("Inactive", 0, nil, false)
+
+	lowStockIDs := func() []uuid.UUID {
+		products, err := repo.GetLowStockProducts(ctx, 5)
+		require.NoError(t, err)
+
+		var ids []uuid.UUID
+		for _, p := range products {
+			if p.CreatedBy == user.ID {
+				ids = append(ids, p.ID)
+			}
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(t, []uuid.UUID{usesDefault.ID, ownThreshold.ID}, lowStockIDs())
+
+	require.NoError(t, repo.MarkLowStockAlerted(ctx, []uuid.UUID{usesDefault.ID, ownThreshold.ID}, time.Now()))
+	assert.Empty(t, lowStockIDs())
+
+	// Restocking above the threshold re-arms the alert; a later shortage alerts again
+	require.NoError(t, db.Model(usesDefault).Update("stock", 20).Error)
+	_, err := repo.ResetLowStockAlerts(ctx, 5)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Model(usesDefault).Update("stock", 1).Error)
+	assert.Equal(t, []uuid.UUID{usesDefault.ID}, lowStockIDs())
+
+	// Without a default only products with their own threshold match
+	products, err := repo.GetLowStockProducts(ctx, 0)
+	require.NoError(t, err)
+	for _, p := range products {
+		assert.NotNil(t, p.LowStockThreshold)
+	}
+}
+
+func TestProductRepository_EachProduct(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	var created []uuid.UUID
+	for i := 0; i < 5; i++ {
+		product := &entity.Product{
+			Name:      "Exported",
+			Price:     money.MustParse("1", "USD"),
+			Category:  "each-test",
+			IsActive:  true,
+			CreatedBy: user.ID,
+		}
+		require.NoError(t, repo.CreateProduct(ctx, product))
+		created = append(created, product.ID)
+	}
+
+	// A batch size that does not divide the total covers the short last batch
+	var seen []uuid.UUID
+	err := repo.EachProduct(ctx, &entity.ProductFilter{Category: "each-test", Params: pagination.Params{Page: 2, Limit: 1}}, 2, func(p *entity.Product) error {
+		seen = append(seen, p.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, created, seen)
+	assert.IsIncreasing(t, uuidStrings(seen))
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.EachProduct(ctx, &entity.ProductFilter{Category: "each-test"}, 2, func(p *entity.Product) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestProductRepository_FindProducts(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	create := func(name, price string, active bool) *entity.Product {
+		product := &entity.Product{
+			Name:      name,
+			Price:     money.MustParse(price, "USD"),
+			Category:  "spec-test",
+			IsActive:  true,
+			CreatedBy: user.ID,
+		}
+		require.NoError(t, repo.CreateProduct(ctx, product))
+		if !active {
+			require.NoError(t, db.Model(product).Update("is_active", false).Error)
+		}
+		return product
+	}
+	cheap := create("Cheap", "5", true)
+	create("Expensive", "500", true)
+	inactive := create("Inactive", "8", false)
+
+	s := spec.And(OwnedBy(user.ID), ByCategory("spec-test"), PriceBetween(decimal.NewFromInt(1), decimal.NewFromInt(10)))
+	products, err := repo.FindProducts(ctx, s)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{cheap.ID, inactive.ID}, productIDs(products))
+	assert.Equal(t, user.ID, products[0].User.ID)
+
+	products, err = repo.FindProducts(ctx, spec.And(s, spec.Not(Active())))
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{inactive.ID}, productIDs(products))
+
+	count, err := repo.CountProducts(ctx, spec.And(OwnedBy(user.ID), Active()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestProductRepository_BulkActions(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	var products []*entity.Product
+	for _, name := range []string{"A", "B", "C"} {
+		product := &entity.Product{Name: name, Price: money.MustParse("1", "USD"), Category: "bulk-test", IsActive: true, CreatedBy: user.ID}
+		require.NoError(t, repo.CreateProduct(ctx, product))
+		products = append(products, product)
+	}
+	ids := productIDs(products)
+	filter := &entity.BulkProductFilter{Category: "bulk-test"}
+
+	require.NoError(t, repo.DeleteProducts(ctx, ids[:2]))
+	live, err := repo.GetBulkProducts(ctx, filter, false, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{ids[2]}, productIDs(live))
+
+	deleted, err := repo.GetBulkProducts(ctx, filter, true, uuid.Nil, 1)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	next, err := repo.GetBulkProducts(ctx, filter, true, deleted[0].ID, 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, ids[:2], append(productIDs(deleted), productIDs(next)...))
+
+	found, err := repo.GetProductsByIDs(ctx, ids)
+	require.NoError(t, err)
+	assert.Len(t, found, 3, "soft-deleted products are found too")
+
+	require.NoError(t, repo.RestoreProducts(ctx, ids[:1]))
+	require.NoError(t, repo.SetProductsActive(ctx, ids, false))
+	live, err = repo.GetBulkProducts(ctx, filter, false, uuid.Nil, 10)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{ids[0], ids[2]}, productIDs(live))
+	for _, product := range live {
+		assert.False(t, product.IsActive)
+	}
+}
+
+func TestProductRepository_FlushProductViews(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewProductRepository(db)
+	views := counter.New(db, &config.CounterConfig{Shards: 4})
+	user := createTestUser(t, db)
+	ctx := context.Background()
+
+	product := &entity.Product{Name: "Viewed", Price: money.MustParse("1", "USD"), Category: "views-test", IsActive: true, CreatedBy: user.ID}
+	require.NoError(t, repo.CreateProduct(ctx, product))
+	created, err := repo.GetProductByID(ctx, product.ID)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, views.Incr(ctx, entity.CounterProductViews, product.ID.String(), 1))
+	}
+	require.NoError(t, views.Incr(ctx, entity.CounterProductViews, "not-a-product", 1))
+
+	// Other tests may record views too, so drain until a batch comes back short
+	for {
+		n, err := repo.FlushProductViews(ctx, 2)
+		require.NoError(t, err)
+		if n < 2 {
+			break
+		}
+	}
+
+	found, err := repo.GetProductByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), found.ViewCount)
+	assert.True(t, created.UpdatedAt.Equal(found.UpdatedAt), "views leave updated_at alone")
+
+	// Saving the product keeps the flushed count
+	found.Name = "Viewed again"
+	found.ViewCount = 0
+	require.NoError(t, repo.UpdateProduct(ctx, found))
+	found, err = repo.GetProductByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), found.ViewCount)
+}
+
+func productIDs(products []*entity.Product) []uuid.UUID {
+	ids := make([]uuid.UUID, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
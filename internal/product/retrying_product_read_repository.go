@@ -0,0 +1,58 @@
+// Code generated by artisan make:retry. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/dbretry"
+
+	"github.com/google/uuid"
+)
+
+// RetryingProductReadRepository retries the calls of ProductReadRepository failing
+// with a transient database error. Only methods marked //retry:idempotent
+// or //cache:read are retried after a lost connection.
+type RetryingProductReadRepository struct {
+	next    ProductReadRepository
+	retrier *dbretry.Retrier
+}
+
+// NewRetryingProductReadRepository wraps next, retrying its calls with retrier
+func NewRetryingProductReadRepository(next ProductReadRepository, retrier *dbretry.Retrier) ProductReadRepository {
+	return &RetryingProductReadRepository{next: next, retrier: retrier}
+}
+
+func (c *RetryingProductReadRepository) GetProductListings(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, error) {
+	var r0 []*entity.ProductReadModel
+	err := c.retrier.Do(ctx, "product.ProductReadRepository.GetProductListings", true, func() (err error) {
+		r0, err = c.next.GetProductListings(ctx, filter)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductReadRepository) CountProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "product.ProductReadRepository.CountProductListings", true, func() (err error) {
+		r0, err = c.next.CountProductListings(ctx, filter)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductReadRepository) EstimateProductListings(ctx context.Context, filter *entity.ProductFilter) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "product.ProductReadRepository.EstimateProductListings", true, func() (err error) {
+		r0, err = c.next.EstimateProductListings(ctx, filter)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductReadRepository) RefreshProductListings(ctx context.Context, productIDs []uuid.UUID) error {
+	return c.retrier.Do(ctx, "product.ProductReadRepository.RefreshProductListings", true, func() error {
+		return c.next.RefreshProductListings(ctx, productIDs)
+	})
+}
@@ -0,0 +1,160 @@
+// Code generated by artisan make:retry. DO NOT EDIT.
+
+package product
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/dbretry"
+
+	"github.com/google/uuid"
+)
+
+// RetryingProductRepository retries the calls of ProductRepository failing
+// with a transient database error. Only methods marked //retry:idempotent
+// or //cache:read are retried after a lost connection.
+type RetryingProductRepository struct {
+	next    ProductRepository
+	retrier *dbretry.Retrier
+}
+
+// NewRetryingProductRepository wraps next, retrying its calls with retrier
+func NewRetryingProductRepository(next ProductRepository, retrier *dbretry.Retrier) ProductRepository {
+	return &RetryingProductRepository{next: next, retrier: retrier}
+}
+
+func (c *RetryingProductRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.CreateProduct", false, func() error {
+		return c.next.CreateProduct(ctx, product)
+	})
+}
+
+func (c *RetryingProductRepository) CreateProducts(ctx context.Context, products []*entity.Product) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.CreateProducts", false, func() error {
+		return c.next.CreateProducts(ctx, products)
+	})
+}
+
+func (c *RetryingProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	var r0 *entity.Product
+	err := c.retrier.Do(ctx, "product.ProductRepository.GetProductByID", true, func() (err error) {
+		r0, err = c.next.GetProductByID(ctx, productID)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int64, error) {
+	var r0 []*entity.Product
+	var r1 int64
+	err := c.retrier.Do(ctx, "product.ProductRepository.GetProducts", true, func() (err error) {
+		r0, r1, err = c.next.GetProducts(ctx, filter)
+		return err
+	})
+	return r0, r1, err
+}
+
+func (c *RetryingProductRepository) UpdateProduct(ctx context.Context, product *entity.Product) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.UpdateProduct", false, func() error {
+		return c.next.UpdateProduct(ctx, product)
+	})
+}
+
+func (c *RetryingProductRepository) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.DeleteProduct", false, func() error {
+		return c.next.DeleteProduct(ctx, productID)
+	})
+}
+
+func (c *RetryingProductRepository) FindProducts(ctx context.Context, s ProductSpec) ([]*entity.Product, error) {
+	var r0 []*entity.Product
+	err := c.retrier.Do(ctx, "product.ProductRepository.FindProducts", true, func() (err error) {
+		r0, err = c.next.FindProducts(ctx, s)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) CountProducts(ctx context.Context, s ProductSpec) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "product.ProductRepository.CountProducts", true, func() (err error) {
+		r0, err = c.next.CountProducts(ctx, s)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) EachProduct(ctx context.Context, filter *entity.ProductFilter, batchSize int, fn func(*entity.Product) error) error {
+	return c.next.EachProduct(ctx, filter, batchSize, fn)
+}
+
+func (c *RetryingProductRepository) GetLowStockProducts(ctx context.Context, defaultThreshold int) ([]*entity.Product, error) {
+	var r0 []*entity.Product
+	err := c.retrier.Do(ctx, "product.ProductRepository.GetLowStockProducts", true, func() (err error) {
+		r0, err = c.next.GetLowStockProducts(ctx, defaultThreshold)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) MarkLowStockAlerted(ctx context.Context, productIDs []uuid.UUID, alertedAt time.Time) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.MarkLowStockAlerted", true, func() error {
+		return c.next.MarkLowStockAlerted(ctx, productIDs, alertedAt)
+	})
+}
+
+func (c *RetryingProductRepository) ResetLowStockAlerts(ctx context.Context, defaultThreshold int) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "product.ProductRepository.ResetLowStockAlerts", false, func() (err error) {
+		r0, err = c.next.ResetLowStockAlerts(ctx, defaultThreshold)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) GetProductsByIDs(ctx context.Context, productIDs []uuid.UUID) ([]*entity.Product, error) {
+	var r0 []*entity.Product
+	err := c.retrier.Do(ctx, "product.ProductRepository.GetProductsByIDs", true, func() (err error) {
+		r0, err = c.next.GetProductsByIDs(ctx, productIDs)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) GetBulkProducts(ctx context.Context, filter *entity.BulkProductFilter, deleted bool, afterID uuid.UUID, limit int) ([]*entity.Product, error) {
+	var r0 []*entity.Product
+	err := c.retrier.Do(ctx, "product.ProductRepository.GetBulkProducts", true, func() (err error) {
+		r0, err = c.next.GetBulkProducts(ctx, filter, deleted, afterID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingProductRepository) DeleteProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.DeleteProducts", true, func() error {
+		return c.next.DeleteProducts(ctx, productIDs)
+	})
+}
+
+func (c *RetryingProductRepository) RestoreProducts(ctx context.Context, productIDs []uuid.UUID) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.RestoreProducts", true, func() error {
+		return c.next.RestoreProducts(ctx, productIDs)
+	})
+}
+
+func (c *RetryingProductRepository) SetProductsActive(ctx context.Context, productIDs []uuid.UUID, active bool) error {
+	return c.retrier.Do(ctx, "product.ProductRepository.SetProductsActive", true, func() error {
+		return c.next.SetProductsActive(ctx, productIDs, active)
+	})
+}
+
+func (c *RetryingProductRepository) FlushProductViews(ctx context.Context, limit int) (int, error) {
+	var r0 int
+	err := c.retrier.Do(ctx, "product.ProductRepository.FlushProductViews", false, func() (err error) {
+		r0, err = c.next.FlushProductViews(ctx, limit)
+		return err
+	})
+	return r0, err
+}
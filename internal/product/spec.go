@@ -0,0 +1,127 @@
+package product
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/spec"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm/clause"
+)
+
+// ProductSpec is a condition on products, composed with spec.And, spec.Or
+// and spec.Not and queried with ProductRepository.FindProducts
+type ProductSpec = spec.Spec[*entity.Product]
+
+// CategorySpec matches products in a category
+type CategorySpec struct {
+	Category string
+}
+
+// PriceRangeSpec matches products priced from Min to Max inclusive. Amounts
+// are compared as they are, whatever their currency, like the listing filter.
+type PriceRangeSpec struct {
+	Min, Max decimal.Decimal
+}
+
+// ActiveSpec matches products that are for sale
+type ActiveSpec struct{}
+
+// OwnerSpec matches products created by a user
+type OwnerSpec struct {
+	UserID uuid.UUID
+}
+
+// OrganizationSpec matches products owned by an organization
+type OrganizationSpec struct {
+	OrganizationID uuid.UUID
+}
+
+// LowStockSpec matches products at or below their low stock threshold, or
+// DefaultThreshold when they have none. With no default, products without a
+// threshold of their own never match.
+type LowStockSpec struct {
+	DefaultThreshold int
+}
+
+// ByCategory matches products in the category
+func ByCategory(category string) ProductSpec {
+	return CategorySpec{Category: category}
+}
+
+// PriceBetween matches products priced from min to max inclusive
+func PriceBetween(min, max decimal.Decimal) ProductSpec {
+	return PriceRangeSpec{Min: min, Max: max}
+}
+
+// Active matches products that are for sale
+func Active() ProductSpec {
+	return ActiveSpec{}
+}
+
+// OwnedBy matches products created by the user
+func OwnedBy(userID uuid.UUID) ProductSpec {
+	return OwnerSpec{UserID: userID}
+}
+
+// InOrganization matches products owned by the organization
+func InOrganization(orgID uuid.UUID) ProductSpec {
+	return OrganizationSpec{OrganizationID: orgID}
+}
+
+// LowStock matches products at or below their low stock threshold
+func LowStock(defaultThreshold int) ProductSpec {
+	return LowStockSpec{DefaultThreshold: defaultThreshold}
+}
+
+func (s CategorySpec) IsSatisfiedBy(p *entity.Product) bool {
+	return p.Category == s.Category
+}
+
+func (s PriceRangeSpec) IsSatisfiedBy(p *entity.Product) bool {
+	return p.Price.Amount.GreaterThanOrEqual(s.Min) && p.Price.Amount.LessThanOrEqual(s.Max)
+}
+
+func (ActiveSpec) IsSatisfiedBy(p *entity.Product) bool {
+	return p.IsActive
+}
+
+func (s OwnerSpec) IsSatisfiedBy(p *entity.Product) bool {
+	return p.CreatedBy == s.UserID
+}
+
+func (s OrganizationSpec) IsSatisfiedBy(p *entity.Product) bool {
+	return p.OrganizationID != nil && *p.OrganizationID == s.OrganizationID
+}
+
+func (s LowStockSpec) IsSatisfiedBy(p *entity.Product) bool {
+	if p.LowStockThreshold != nil {
+		return p.Stock <= *p.LowStockThreshold
+	}
+	return s.DefaultThreshold > 0 && p.Stock <= s.DefaultThreshold
+}
+
+// productClause translates the product specifications to conditions on
+// tb_products
+func productClause(s ProductSpec) (clause.Expression, error) {
+	switch s := s.(type) {
+	case CategorySpec:
+		return clause.Eq{Column: "category", Value: s.Category}, nil
+	case PriceRangeSpec:
+		return clause.Expr{SQL: "price_amount BETWEEN ? AND ?", Vars: []interface{}{s.Min, s.Max}}, nil
+	case ActiveSpec:
+		return clause.Eq{Column: "is_active", Value: true}, nil
+	case OwnerSpec:
+		return clause.Eq{Column: "created_by", Value: s.UserID}, nil
+	case OrganizationSpec:
+		// Never NULL, so that spec.Not matches products without an organization
+		return clause.Expr{SQL: "organization_id IS NOT NULL AND organization_id = ?", Vars: []interface{}{s.OrganizationID}}, nil
+	case LowStockSpec:
+		if s.DefaultThreshold <= 0 {
+			return clause.Expr{SQL: "low_stock_threshold IS NOT NULL AND stock <= low_stock_threshold"}, nil
+		}
+		threshold, args := lowStockThreshold(s.DefaultThreshold)
+		return clause.Expr{SQL: "stock <= " + threshold, Vars: args}, nil
+	}
+	return nil, spec.Unsupported(s)
+}
@@ -0,0 +1,86 @@
+package product
+
+import (
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/spec"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestProductSpec_IsSatisfiedBy(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.New()
+	threshold := 3
+	lamp := &entity.Product{Name: "Lamp", Category: "home", Price: money.MustParse("25", "USD"), Stock: 2, IsActive: true, OrganizationID: &orgID}
+	desk := &entity.Product{Name: "Desk", Category: "office", Price: money.MustParse("150", "USD"), Stock: 4, LowStockThreshold: &threshold}
+	chair := &entity.Product{Name: "Chair", Category: "office", Price: money.MustParse("80", "USD"), Stock: 1, IsActive: true}
+	products := []*entity.Product{lamp, desk, chair}
+
+	tests := []struct {
+		name string
+		spec ProductSpec
+		want []*entity.Product
+	}{
+		{"category and price", spec.And(ByCategory("office"), PriceBetween(decimal.NewFromInt(1), decimal.NewFromInt(100))), []*entity.Product{chair}},
+		{"or", spec.Or(ByCategory("home"), spec.Not(Active())), []*entity.Product{lamp, desk}},
+		{"organization", InOrganization(orgID), []*entity.Product{lamp}},
+		{"not in organization", spec.Not(InOrganization(orgID)), []*entity.Product{desk, chair}},
+		{"low stock with default", LowStock(2), []*entity.Product{lamp, chair}},
+		{"low stock without default", LowStock(0), nil},
+		{"empty and", spec.And[*entity.Product](), products},
+		{"empty or", spec.Or[*entity.Product](), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, spec.Filter(tt.spec, products))
+		})
+	}
+}
+
+func TestProductSpec_Clause(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	s := spec.And(
+		ByCategory("office"),
+		PriceBetween(decimal.NewFromInt(1), decimal.NewFromInt(10)),
+		spec.Or(OwnedBy(userID), spec.Not(Active())),
+	)
+
+	var products []*entity.Product
+	stmt := spec.Where(db.Model(&entity.Product{}), s, productClause).Find(&products).Statement
+	assert.Equal(t,
+		`SELECT * FROM "tb_products" WHERE ("category" = $1 AND (price_amount BETWEEN $2 AND $3) AND ("created_by" = $4 OR "is_active" <> $5)) AND "tb_products"."deleted_at" IS NULL`,
+		stmt.SQL.String())
+	assert.Equal(t, []interface{}{"office", decimal.NewFromInt(1), decimal.NewFromInt(10), userID, true}, stmt.Vars)
+
+	t.Run("negated expressions", func(t *testing.T) {
+		orgID := uuid.New()
+		stmt := spec.Where(db.Model(&entity.Product{}), spec.Not(InOrganization(orgID)), productClause).Find(&products).Statement
+		assert.Equal(t,
+			`SELECT * FROM "tb_products" WHERE NOT (organization_id IS NOT NULL AND organization_id = $1) AND "tb_products"."deleted_at" IS NULL`,
+			stmt.SQL.String())
+	})
+
+	t.Run("unsupported specification", func(t *testing.T) {
+		unknown := spec.And(ByCategory("office"), otherSpec{})
+		err := spec.Where(db.Model(&entity.Product{}), unknown, productClause).Find(&products).Error
+		assert.ErrorContains(t, err, "is not supported")
+	})
+}
+
+type otherSpec struct{}
+
+func (otherSpec) IsSatisfiedBy(*entity.Product) bool { return true }
@@ -2,49 +2,93 @@ package product
 
 import (
 	"context"
+	"fmt"
+	"go-clean-gin/config"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
 	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/exchange"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/policy"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// exportBatchSize is the number of products read per query when exporting
+const exportBatchSize = 500
+
 type productUsecase struct {
-	repo ProductRepository
+	repo     ProductRepository
+	readRepo ProductReadRepository
+	config   *config.Config
+	events   *events.Bus
+	clock    clock.Clock
+	rates    exchange.Provider
+	orgs     OrganizationRoles
+	policy   *ProductPolicy
+	settings Settings
+	views    ViewCounter
 }
 
-func NewProductUsecase(repo ProductRepository) ProductUsecase {
+func NewProductUsecase(repo ProductRepository, readRepo ProductReadRepository, config *config.Config, bus *events.Bus, clk clock.Clock, rates exchange.Provider, orgs OrganizationRoles, settings Settings, views ViewCounter) ProductUsecase {
 	return &productUsecase{
-		repo: repo,
+		repo:     repo,
+		readRepo: readRepo,
+		config:   config,
+		events:   bus,
+		clock:    clk,
+		rates:    rates,
+		orgs:     orgs,
+		policy:   NewProductPolicy(orgs),
+		settings: settings,
+		views:    views,
 	}
 }
 
+// CreateProduct creates a product owned by the user, or by the organization
+// in the request, which the user must belong to
 func (u *productUsecase) CreateProduct(ctx context.Context, req *entity.CreateProductRequest, userID uuid.UUID) (*entity.Product, error) {
+	if err := u.checkAttributes(req.Attributes); err != nil {
+		return nil, err
+	}
+	if req.OrganizationID != nil {
+		if _, err := u.orgs.MemberRole(ctx, *req.OrganizationID, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	product := &entity.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		Category:    req.Category,
-		IsActive:    true,
-		CreatedBy:   userID,
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             normalizePrice(req.Price),
+		Stock:             req.Stock,
+		Category:          req.Category,
+		IsActive:          true,
+		LowStockThreshold: req.LowStockThreshold,
+		Attributes:        req.Attributes,
+		CreatedBy:         userID,
+		OrganizationID:    req.OrganizationID,
 	}
 
 	if err := u.repo.CreateProduct(ctx, product); err != nil {
-		logger.Error("Failed to create product", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to create product", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create product", 500)
 	}
 
 	// Get the created product with user data
 	createdProduct, err := u.repo.GetProductByID(ctx, product.ID)
 	if err != nil {
-		logger.Error("Failed to get created product", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to get created product", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get created product", 500)
 	}
 
-	logger.Info("Product created successfully", zap.String("product_id", product.ID.String()))
+	logger.FromContext(ctx).Info("Product created successfully", zap.String("product_id", product.ID.String()))
+	u.events.Dispatch(ctx, ChangedEvent{ProductID: product.ID})
+	u.events.Dispatch(ctx, CreatedEvent{ProductID: product.ID, Name: product.Name, ActorID: userID})
 	return createdProduct, nil
 }
 
@@ -54,34 +98,58 @@ func (u *productUsecase) GetProductByID(ctx context.Context, productID uuid.UUID
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrProductNotFoundError
 		}
-		logger.Error("Failed to get product", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to get product", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get product", 500)
 	}
 
 	return product, nil
 }
 
-func (u *productUsecase) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int64, error) {
-	// Set default pagination if not provided
-	if filter.Page <= 0 {
-		filter.Page = 1
+// GetProducts lists products from the read model, which carries the owner's
+// name instead of the owner
+func (u *productUsecase) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, entity.Total, error) {
+	if err := u.checkAttributes(filter.Attributes); err != nil {
+		return nil, entity.Total{}, err
 	}
-	if filter.Limit <= 0 {
-		filter.Limit = 10
-	}
-	if filter.Limit > 100 {
-		filter.Limit = 100
+
+	// The page size is a tenant-wide setting; listings are public, so no user
+	filter.Normalize(u.settings.Int(ctx, entity.SettingProductsPageSize, uuid.Nil))
+
+	total, err := u.countProducts(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to count products", zap.Error(err))
+		return nil, entity.Total{}, errors.Wrap(err, errors.ErrInternal, "Failed to get products", 500)
 	}
 
-	products, total, err := u.repo.GetProducts(ctx, filter)
+	products, err := u.readRepo.GetProductListings(ctx, filter)
 	if err != nil {
-		logger.Error("Failed to get products", zap.Error(err))
-		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get products", 500)
+		logger.FromContext(ctx).Error("Failed to get products", zap.Error(err))
+		return nil, entity.Total{}, errors.Wrap(err, errors.ErrInternal, "Failed to get products", 500)
 	}
 
 	return products, total, nil
 }
 
+// countProducts counts the listing, or in estimate mode reports the planner's
+// estimate when it reaches the threshold. A failed estimate falls back to
+// counting.
+func (u *productUsecase) countProducts(ctx context.Context, filter *entity.ProductFilter) (entity.Total, error) {
+	if u.config.Listing.CountMode == config.CountEstimate {
+		estimate, err := u.readRepo.EstimateProductListings(ctx, filter)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Failed to estimate products, counting instead", zap.Error(err))
+		} else if estimate >= u.config.Listing.EstimateThreshold {
+			return entity.Total{Count: estimate, Estimated: true}, nil
+		}
+	}
+
+	count, err := u.readRepo.CountProductListings(ctx, filter)
+	if err != nil {
+		return entity.Total{}, err
+	}
+	return entity.Total{Count: count}, nil
+}
+
 func (u *productUsecase) UpdateProduct(ctx context.Context, productID uuid.UUID, req *entity.UpdateProductRequest, userID uuid.UUID) (*entity.Product, error) {
 	// Get existing product
 	existingProduct, err := u.repo.GetProductByID(ctx, productID)
@@ -89,15 +157,16 @@ func (u *productUsecase) UpdateProduct(ctx context.Context, productID uuid.UUID,
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.ErrProductNotFoundError
 		}
-		logger.Error("Failed to get product for update", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to get product for update", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get product", 500)
 	}
 
-	// Check if user is the owner of the product
-	if existingProduct.CreatedBy != userID {
-		return nil, errors.ErrInvalidOwnerError
+	if err := policy.Gate(ctx, u.policy.CanUpdate, userID, existingProduct, errors.ErrInvalidOwnerError); err != nil {
+		return nil, err
 	}
 
+	previousStock := existingProduct.Stock
+
 	// Update fields if provided
 	if req.Name != nil {
 		existingProduct.Name = *req.Name
@@ -106,7 +175,7 @@ func (u *productUsecase) UpdateProduct(ctx context.Context, productID uuid.UUID,
 		existingProduct.Description = *req.Description
 	}
 	if req.Price != nil {
-		existingProduct.Price = *req.Price
+		existingProduct.Price = normalizePrice(*req.Price)
 	}
 	if req.Stock != nil {
 		existingProduct.Stock = *req.Stock
@@ -117,13 +186,41 @@ func (u *productUsecase) UpdateProduct(ctx context.Context, productID uuid.UUID,
 	if req.IsActive != nil {
 		existingProduct.IsActive = *req.IsActive
 	}
+	if req.LowStockThreshold != nil {
+		existingProduct.LowStockThreshold = req.LowStockThreshold
+	}
+	if req.Attributes != nil {
+		// Keys dropped from PRODUCT_ATTRIBUTES may stay, so a patch of other
+		// fields keeps working
+		added := entity.Attributes{}
+		for key, value := range *req.Attributes {
+			if _, ok := existingProduct.Attributes[key]; !ok {
+				added[key] = value
+			}
+		}
+		if err := u.checkAttributes(added); err != nil {
+			return nil, err
+		}
+		existingProduct.Attributes = *req.Attributes
+	}
 
 	if err := u.repo.UpdateProduct(ctx, existingProduct); err != nil {
-		logger.Error("Failed to update product", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to update product", zap.Error(err))
 		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update product", 500)
 	}
 
-	logger.Info("Product updated successfully", zap.String("product_id", productID.String()))
+	logger.FromContext(ctx).Info("Product updated successfully", zap.String("product_id", productID.String()))
+	u.events.Dispatch(ctx, ChangedEvent{ProductID: productID})
+	u.events.Dispatch(ctx, UpdatedEvent{ProductID: productID, Name: existingProduct.Name, ActorID: userID})
+
+	if previousStock > 0 && existingProduct.Stock == 0 {
+		u.events.Dispatch(ctx, OutOfStockEvent{
+			ProductID: existingProduct.ID,
+			Name:      existingProduct.Name,
+			OwnerID:   existingProduct.CreatedBy,
+		})
+	}
+
 	return existingProduct, nil
 }
 
@@ -134,20 +231,175 @@ func (u *productUsecase) DeleteProduct(ctx context.Context, productID uuid.UUID,
 		if err == gorm.ErrRecordNotFound {
 			return errors.ErrProductNotFoundError
 		}
-		logger.Error("Failed to get product for deletion", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to get product for deletion", zap.Error(err))
 		return errors.Wrap(err, errors.ErrInternal, "Failed to get product", 500)
 	}
 
-	// Check if user is the owner of the product
-	if existingProduct.CreatedBy != userID {
-		return errors.ErrInvalidOwnerError
+	if err := policy.Gate(ctx, u.policy.CanDelete, userID, existingProduct, errors.ErrInvalidOwnerError); err != nil {
+		return err
 	}
 
 	if err := u.repo.DeleteProduct(ctx, productID); err != nil {
-		logger.Error("Failed to delete product", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to delete product", zap.Error(err))
 		return errors.Wrap(err, errors.ErrInternal, "Failed to delete product", 500)
 	}
 
-	logger.Info("Product deleted successfully", zap.String("product_id", productID.String()))
+	logger.FromContext(ctx).Info("Product deleted successfully", zap.String("product_id", productID.String()))
+	u.events.Dispatch(ctx, ChangedEvent{ProductID: productID})
+	u.events.Dispatch(ctx, DeletedEvent{ProductID: productID, Name: existingProduct.Name, ActorID: userID})
+	return nil
+}
+
+// CheckOwner returns nil when the user may modify the product, for modules
+// that show what only its owners may see
+func (u *productUsecase) CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	product, err := u.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrProductNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get product", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to get product", 500)
+	}
+
+	return policy.Gate(ctx, u.policy.CanUpdate, userID, product, errors.ErrInvalidOwnerError)
+}
+
+// CheckLowStock re-arms alerts for restocked products, then dispatches a
+// LowStockEvent for each product newly at or below its threshold. It returns
+// the number of alerts raised.
+func (u *productUsecase) CheckLowStock(ctx context.Context) (int, error) {
+	defaultThreshold := u.config.Stock.LowThreshold
+
+	if _, err := u.repo.ResetLowStockAlerts(ctx, defaultThreshold); err != nil {
+		logger.FromContext(ctx).Error("Failed to reset low stock alerts", zap.Error(err))
+		return 0, errors.Wrap(err, errors.ErrInternal, "Failed to reset low stock alerts", 500)
+	}
+
+	products, err := u.repo.GetLowStockProducts(ctx, defaultThreshold)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get low stock products", zap.Error(err))
+		return 0, errors.Wrap(err, errors.ErrInternal, "Failed to get low stock products", 500)
+	}
+	if len(products) == 0 {
+		return 0, nil
+	}
+
+	// Mark before dispatching so a failing listener cannot cause repeat alerts
+	productIDs := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+	if err := u.repo.MarkLowStockAlerted(ctx, productIDs, u.clock.Now()); err != nil {
+		logger.FromContext(ctx).Error("Failed to mark low stock alerts", zap.Error(err))
+		return 0, errors.Wrap(err, errors.ErrInternal, "Failed to mark low stock alerts", 500)
+	}
+
+	for _, product := range products {
+		threshold := defaultThreshold
+		if product.LowStockThreshold != nil {
+			threshold = *product.LowStockThreshold
+		}
+
+		u.events.Dispatch(ctx, LowStockEvent{
+			ProductID:  product.ID,
+			Name:       product.Name,
+			Stock:      product.Stock,
+			Threshold:  threshold,
+			OwnerID:    product.CreatedBy,
+			OwnerEmail: product.User.Email,
+		})
+	}
+
+	logger.FromContext(ctx).Info("Low stock alerts raised", zap.Int("count", len(products)))
+	return len(products), nil
+}
+
+// ConvertPrices sets the display price of each item to its price in currency
+// and returns the rates used, one per source currency
+func (u *productUsecase) ConvertPrices(ctx context.Context, currency string, items ...entity.Priced) (*exchange.Conversion, error) {
+	conversion := &exchange.Conversion{Currency: currency, Rates: []exchange.Rate{}}
+	rates := make(map[string]exchange.Rate)
+
+	for _, item := range items {
+		price := item.PriceOf()
+		from := price.Currency
+		if from == currency {
+			item.SetDisplayPrice(price)
+			continue
+		}
+
+		rate, ok := rates[from]
+		if !ok {
+			latest, err := u.rates.Latest(ctx, from)
+			if err == nil {
+				rate, err = latest.Rate(currency)
+			}
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to get exchange rate",
+					zap.String("from", from), zap.String("to", currency), zap.Error(err))
+				return nil, errors.Wrap(err, errors.ErrExchangeRateUnavailable,
+					fmt.Sprintf("Exchange rate from %s to %s is not available", from, currency), 503)
+			}
+
+			rates[from] = rate
+			conversion.Rates = append(conversion.Rates, rate)
+		}
+
+		item.SetDisplayPrice(money.New(price.Amount.Mul(rate.Value), currency).Round())
+	}
+
+	return conversion, nil
+}
+
+// ExportProducts calls fn for every product matching filter, reading them in
+// batches so exports of any size use constant memory. Pagination is ignored.
+func (u *productUsecase) ExportProducts(ctx context.Context, filter *entity.ProductFilter, fn func(*entity.Product) error) error {
+	if err := u.repo.EachProduct(ctx, filter, exportBatchSize, fn); err != nil {
+		logger.FromContext(ctx).Error("Failed to export products", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to export products", 500)
+	}
 	return nil
 }
+
+// ImportProducts creates products owned by the user from import rows, all of
+// them or none, and returns how many were created
+func (u *productUsecase) ImportProducts(ctx context.Context, reqs []*entity.CreateProductRequest, userID uuid.UUID) (int, error) {
+	products := make([]*entity.Product, 0, len(reqs))
+	for _, req := range reqs {
+		products = append(products, &entity.Product{
+			Name:              req.Name,
+			Description:       req.Description,
+			Price:             normalizePrice(req.Price),
+			Stock:             req.Stock,
+			Category:          req.Category,
+			IsActive:          true,
+			LowStockThreshold: req.LowStockThreshold,
+			CreatedBy:         userID,
+		})
+	}
+	if len(products) == 0 {
+		return 0, nil
+	}
+
+	if err := u.repo.CreateProducts(ctx, products); err != nil {
+		logger.FromContext(ctx).Error("Failed to import products", zap.Error(err))
+		return 0, errors.Wrap(err, errors.ErrInternal, "Failed to import products", 500)
+	}
+
+	for _, product := range products {
+		u.events.Dispatch(ctx, ChangedEvent{ProductID: product.ID})
+		u.events.Dispatch(ctx, CreatedEvent{ProductID: product.ID, Name: product.Name, ActorID: userID})
+	}
+
+	logger.FromContext(ctx).Info("Products imported", zap.Int("count", len(products)))
+	return len(products), nil
+}
+
+// normalizePrice applies the default currency and rounds to its minor units
+func normalizePrice(price money.Money) money.Money {
+	if price.Currency == "" {
+		price.Currency = money.DefaultCurrency()
+	}
+	return price.Round()
+}
@@ -3,59 +3,33 @@ package product
 import (
 	"context"
 	"testing"
+	"time"
 
+	"go-clean-gin/config"
 	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/exchange"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/pagination"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
-// Mock repository
-type MockProductRepository struct {
-	mock.Mock
-}
-
-func (m *MockProductRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
-	args := m.Called(ctx, product)
-	return args.Error(0)
-}
-
-func (m *MockProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
-	args := m.Called(ctx, productID)
-	return args.Get(0).(*entity.Product), args.Error(1)
-}
-
-func (m *MockProductRepository) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int64, error) {
-	args := m.Called(ctx, filter)
-	return args.Get(0).([]*entity.Product), args.Get(1).(int64), args.Error(2)
-}
-
-func (m *MockProductRepository) UpdateProduct(ctx context.Context, product *entity.Product) error {
-	args := m.Called(ctx, product)
-	return args.Error(0)
-}
-
-func (m *MockProductRepository) DeleteProduct(ctx context.Context, productID uuid.UUID) error {
-	args := m.Called(ctx, productID)
-	return args.Error(0)
-}
-
-func (m *MockProductRepository) GetProductsByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Product, error) {
-	args := m.Called(ctx, userID)
-	return args.Get(0).([]*entity.Product), args.Error(1)
-}
-
 func TestProductUsecase_CreateProduct_Success(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	usecase := NewProductUsecase(mockRepo)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
 
 	userID := uuid.New()
 	req := &entity.CreateProductRequest{
 		Name:        "Test Product",
 		Description: "Test Description",
-		Price:       99.99,
+		Price:       money.MustParse("99.99", "USD"),
 		Stock:       10,
 		Category:    "electronics",
 	}
@@ -88,13 +62,13 @@ func TestProductUsecase_CreateProduct_Success(t *testing.T) {
 
 func TestProductUsecase_GetProductByID_Success(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	usecase := NewProductUsecase(mockRepo)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
 
 	productID := uuid.New()
 	product := &entity.Product{
 		ID:       productID,
 		Name:     "Test Product",
-		Price:    99.99,
+		Price:    money.MustParse("99.99", "USD"),
 		IsActive: true,
 	}
 
@@ -113,7 +87,7 @@ func TestProductUsecase_GetProductByID_Success(t *testing.T) {
 
 func TestProductUsecase_GetProductByID_NotFound(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	usecase := NewProductUsecase(mockRepo)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
 
 	productID := uuid.New()
 
@@ -132,7 +106,7 @@ func TestProductUsecase_GetProductByID_NotFound(t *testing.T) {
 
 func TestProductUsecase_UpdateProduct_Unauthorized(t *testing.T) {
 	mockRepo := new(MockProductRepository)
-	usecase := NewProductUsecase(mockRepo)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
 
 	productID := uuid.New()
 	userID := uuid.New()
@@ -157,11 +131,535 @@ func TestProductUsecase_UpdateProduct_Unauthorized(t *testing.T) {
 	// Assertions
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "unauthorized")
+	assert.Equal(t, errors.ErrInvalidOwner, err.(*errors.AppError).Code)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestProductUsecase_UpdateProduct_OrganizationRoles(t *testing.T) {
+	orgID := uuid.New()
+	creatorID := uuid.New()
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		role    string
+		roleErr error
+		wantErr bool
+	}{
+		{name: "creator who is a member", userID: creatorID, role: entity.OrgRoleMember},
+		{name: "organization admin", userID: uuid.New(), role: entity.OrgRoleAdmin},
+		{name: "other member", userID: uuid.New(), role: entity.OrgRoleMember, wantErr: true},
+		{name: "creator who left", userID: creatorID, roleErr: errors.ErrOrganizationNotFoundError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockProductRepository)
+			mockOrgs := new(MockOrganizationRoles)
+			usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, mockOrgs, nil, nil)
+
+			productID := uuid.New()
+			existing := &entity.Product{ID: productID, Name: "Widget", CreatedBy: creatorID, OrganizationID: &orgID}
+			mockRepo.On("GetProductByID", mock.Anything, productID).Return(existing, nil)
+			mockRepo.On("UpdateProduct", mock.Anything, existing).Return(nil).Maybe()
+			mockOrgs.On("MemberRole", mock.Anything, orgID, tt.userID).Return(tt.role, tt.roleErr)
+
+			_, err := usecase.UpdateProduct(context.Background(), productID, &entity.UpdateProductRequest{Name: stringPtr("Gadget")}, tt.userID)
+
+			if tt.wantErr {
+				assert.Equal(t, errors.ErrInvalidOwner, err.(*errors.AppError).Code)
+				mockRepo.AssertNotCalled(t, "UpdateProduct", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProductUsecase_CreateProduct_NotOrganizationMember(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	mockOrgs := new(MockOrganizationRoles)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, mockOrgs, nil, nil)
+
+	orgID := uuid.New()
+	userID := uuid.New()
+	mockOrgs.On("MemberRole", mock.Anything, orgID, userID).Return("", errors.ErrOrganizationNotFoundError)
+
+	_, err := usecase.CreateProduct(context.Background(), &entity.CreateProductRequest{
+		Name:           "Widget",
+		Price:          money.MustParse("9.99", "USD"),
+		Category:       "tools",
+		OrganizationID: &orgID,
+	}, userID)
+
+	assert.Equal(t, errors.ErrOrganizationNotFoundError, err)
+	mockRepo.AssertNotCalled(t, "CreateProduct", mock.Anything, mock.Anything)
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestProductUsecase_UpdateProduct_DispatchesOutOfStock(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	bus := events.NewBus()
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, bus, clock.New(), nil, nil, nil, nil)
+
+	var dispatched []OutOfStockEvent
+	bus.Listen(EventOutOfStock, func(ctx context.Context, event events.Event) error {
+		dispatched = append(dispatched, event.(OutOfStockEvent))
+		return nil
+	})
+
+	ownerID := uuid.New()
+	productID := uuid.New()
+	existing := &entity.Product{ID: productID, Name: "Widget", Stock: 3, CreatedBy: ownerID}
+
+	mockRepo.On("GetProductByID", mock.Anything, productID).Return(existing, nil)
+	mockRepo.On("UpdateProduct", mock.Anything, existing).Return(nil)
+
+	zero := 0
+	_, err := usecase.UpdateProduct(context.Background(), productID, &entity.UpdateProductRequest{Stock: &zero}, ownerID)
+	assert.NoError(t, err)
+
+	// Already at zero: no second event
+	_, err = usecase.UpdateProduct(context.Background(), productID, &entity.UpdateProductRequest{Stock: &zero}, ownerID)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []OutOfStockEvent{{ProductID: productID, Name: "Widget", OwnerID: ownerID}}, dispatched)
+}
+
+func TestProductUsecase_GetProducts_ReadsListings(t *testing.T) {
+	mockReadRepo := new(MockProductReadRepository)
+	settings := new(MockSettings)
+	settings.On("Int", mock.Anything, entity.SettingProductsPageSize, uuid.Nil).Return(10)
+	usecase := NewProductUsecase(new(MockProductRepository), mockReadRepo, &config.Config{}, events.NewBus(), clock.New(), nil, nil, settings, nil)
+
+	listings := []*entity.ProductReadModel{{ID: uuid.New(), Name: "Widget", OwnerName: "Jane Doe"}}
+	filter := &entity.ProductFilter{Params: pagination.Params{Limit: 500}}
+	mockReadRepo.On("CountProductListings", mock.Anything, filter).Return(int64(1), nil)
+	mockReadRepo.On("GetProductListings", mock.Anything, filter).Return(listings, nil)
+
+	result, total, err := usecase.GetProducts(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, listings, result)
+	assert.Equal(t, entity.Total{Count: 1}, total)
+	assert.Equal(t, 1, filter.Page)
+	assert.Equal(t, 100, filter.Limit)
+	mockReadRepo.AssertExpectations(t)
+}
+
+func TestProductUsecase_GetProducts_EstimatedTotal(t *testing.T) {
+	cfg := &config.Config{Listing: config.ListingConfig{CountMode: config.CountEstimate, EstimateThreshold: 1000}}
+
+	tests := []struct {
+		name     string
+		estimate int64
+		err      error
+		want     entity.Total
+	}{
+		{name: "above threshold", estimate: 250000, want: entity.Total{Count: 250000, Estimated: true}},
+		{name: "below threshold", estimate: 40, want: entity.Total{Count: 42}},
+		{name: "estimate fails", err: assert.AnError, want: entity.Total{Count: 42}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReadRepo := new(MockProductReadRepository)
+			settings := new(MockSettings)
+			settings.On("Int", mock.Anything, entity.SettingProductsPageSize, uuid.Nil).Return(10)
+			usecase := NewProductUsecase(new(MockProductRepository), mockReadRepo, cfg, events.NewBus(), clock.New(), nil, nil, settings, nil)
+
+			filter := &entity.ProductFilter{Params: pagination.Params{Page: 1, Limit: 10}}
+			mockReadRepo.On("EstimateProductListings", mock.Anything, filter).Return(tt.estimate, tt.err)
+			mockReadRepo.On("CountProductListings", mock.Anything, filter).Return(int64(42), nil).Maybe()
+			mockReadRepo.On("GetProductListings", mock.Anything, filter).Return([]*entity.ProductReadModel{}, nil)
+
+			_, total, err := usecase.GetProducts(context.Background(), filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, total)
+			if tt.want.Estimated {
+				mockReadRepo.AssertNotCalled(t, "CountProductListings", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProductUsecase_DispatchesChanged(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	bus := events.NewBus()
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, bus, clock.New(), nil, nil, nil, nil)
+
+	var changed []uuid.UUID
+	bus.Listen(EventChanged, func(ctx context.Context, event events.Event) error {
+		changed = append(changed, event.(ChangedEvent).ProductID)
+		return nil
+	})
+	var actions []events.Event
+	for _, name := range []string{EventUpdated, EventDeleted} {
+		bus.Listen(name, func(ctx context.Context, event events.Event) error {
+			actions = append(actions, event)
+			return nil
+		})
+	}
+
+	ownerID := uuid.New()
+	productID := uuid.New()
+	existing := &entity.Product{ID: productID, Name: "Widget", CreatedBy: ownerID}
+
+	mockRepo.On("GetProductByID", mock.Anything, productID).Return(existing, nil)
+	mockRepo.On("UpdateProduct", mock.Anything, existing).Return(nil)
+	mockRepo.On("DeleteProduct", mock.Anything, productID).Return(nil)
+
+	_, err := usecase.UpdateProduct(context.Background(), productID, &entity.UpdateProductRequest{Name: stringPtr("Gadget")}, ownerID)
+	assert.NoError(t, err)
+	assert.NoError(t, usecase.DeleteProduct(context.Background(), productID, ownerID))
+
+	assert.Equal(t, []uuid.UUID{productID, productID}, changed)
+	assert.Equal(t, []events.Event{
+		UpdatedEvent{ProductID: productID, Name: "Gadget", ActorID: ownerID},
+		DeletedEvent{ProductID: productID, Name: "Gadget", ActorID: ownerID},
+	}, actions)
+}
+
+func TestProductUsecase_CheckLowStock(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	bus := events.NewBus()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Stock: config.StockConfig{LowThreshold: 5}}
+	usecase := NewProductUsecase(mockRepo, nil, cfg, bus, clock.NewFake(now), nil, nil, nil, nil)
+
+	var dispatched []LowStockEvent
+	bus.Listen(EventLowStock, func(ctx context.Context, event events.Event) error {
+		dispatched = append(dispatched, event.(LowStockEvent))
+		return nil
+	})
+
+	ownerID := uuid.New()
+	own := 10
+	products := []*entity.Product{
+		{ID: uuid.New(), Name: "Default threshold", Stock: 2, CreatedBy: ownerID, User: entity.User{Email: "owner@example.com"}},
+		{ID: uuid.New(), Name: "Own threshold", Stock: 8, LowStockThreshold: &own, CreatedBy: ownerID},
+	}
+
+	mockRepo.On("ResetLowStockAlerts", mock.Anything, 5).Return(int64(0), nil)
+	mockRepo.On("GetLowStockProducts", mock.Anything, 5).Return(products, nil)
+	mockRepo.On("MarkLowStockAlerted", mock.Anything, []uuid.UUID{products[0].ID, products[1].ID}, now).Return(nil)
+
+	count, err := usecase.CheckLowStock(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	if assert.Len(t, dispatched, 2) {
+		assert.Equal(t, 5, dispatched[0].Threshold)
+		assert.Equal(t, "owner@example.com", dispatched[0].OwnerEmail)
+		assert.Equal(t, 10, dispatched[1].Threshold)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProductUsecase_CreateProduct_DefaultsCurrency(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+	req := &entity.CreateProductRequest{
+		Name:     "Cable",
+		Price:    money.MustParse("4.5", ""),
+		Category: "Electronics",
+	}
+
+	var created *entity.Product
+	mockRepo.On("CreateProduct", mock.Anything, mock.AnythingOfType("*entity.Product")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Product) }).
+		Return(nil)
+	mockRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(&entity.Product{}, nil)
+
+	_, err := usecase.CreateProduct(context.Background(), req, uuid.New())
+
+	assert.NoError(t, err)
+	assert.Equal(t, money.DefaultCurrency(), created.Price.Currency)
+	assert.Equal(t, "4.50 "+money.DefaultCurrency(), created.Price.String())
+}
+
+func TestProductUsecase_ConvertPrices(t *testing.T) {
+	asOf := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rates := exchange.NewFixed("USD", map[string]decimal.Decimal{
+		"EUR": decimal.RequireFromString("0.9"),
+		"JPY": decimal.NewFromInt(150),
+	}, asOf)
+	usecase := NewProductUsecase(new(MockProductRepository), nil, &config.Config{}, events.NewBus(), clock.New(), rates, nil, nil, nil)
+
+	product := &entity.Product{Price: money.MustParse("10", "USD")}
+	listings := []*entity.ProductReadModel{
+		{Price: money.MustParse("1500", "JPY")},
+		{Price: money.MustParse("4.50", "EUR")},
+		{Price: money.MustParse("20", "USD")},
+	}
+
+	conversion, err := usecase.ConvertPrices(context.Background(), "EUR", product, listings[0], listings[1], listings[2])
+
+	assert.NoError(t, err)
+	assert.Equal(t, "9.00 EUR", product.DisplayPrice.String())
+	assert.Equal(t, "9.00 EUR", listings[0].DisplayPrice.String())
+	assert.Equal(t, "4.50 EUR", listings[1].DisplayPrice.String())
+	assert.Equal(t, "18.00 EUR", listings[2].DisplayPrice.String())
+	assert.Equal(t, "EUR", conversion.Currency)
+	if assert.Len(t, conversion.Rates, 2) {
+		assert.Equal(t, "USD", conversion.Rates[0].From)
+		assert.Equal(t, "JPY", conversion.Rates[1].From)
+		assert.Equal(t, asOf, conversion.Rates[1].AsOf)
+	}
+}
+
+func TestProductUsecase_ConvertPrices_RateUnavailable(t *testing.T) {
+	rates := exchange.NewFixed("USD", map[string]decimal.Decimal{"EUR": decimal.RequireFromString("0.9")}, time.Now())
+	usecase := NewProductUsecase(new(MockProductRepository), nil, &config.Config{}, events.NewBus(), clock.New(), rates, nil, nil, nil)
+
+	_, err := usecase.ConvertPrices(context.Background(), "GBP", &entity.Product{Price: money.MustParse("10", "USD")})
+
+	if assert.IsType(t, &errors.AppError{}, err) {
+		assert.Equal(t, errors.ErrExchangeRateUnavailable, err.(*errors.AppError).Code)
+		assert.Equal(t, 503, err.(*errors.AppError).StatusCode)
+	}
+}
+
+func TestProductUsecase_ExportProducts(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+	products := []*entity.Product{{Name: "Keyboard"}, {Name: "Mouse"}}
+	filter := &entity.ProductFilter{Category: "peripherals"}
+	mockRepo.On("EachProduct", mock.Anything, filter, exportBatchSize, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(3).(func(*entity.Product) error)
+			for _, product := range products {
+				if fn(product) != nil {
+					return
+				}
+			}
+		}).
+		Return(nil)
+
+	var names []string
+	err := usecase.ExportProducts(context.Background(), filter, func(product *entity.Product) error {
+		names = append(names, product.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Keyboard", "Mouse"}, names)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProductUsecase_GetProducts_PageSizeSetting(t *testing.T) {
+	mockReadRepo := new(MockProductReadRepository)
+	settings := new(MockSettings)
+	settings.On("Int", mock.Anything, entity.SettingProductsPageSize, uuid.Nil).Return(25)
+	usecase := NewProductUsecase(new(MockProductRepository), mockReadRepo, &config.Config{}, events.NewBus(), clock.New(), nil, nil, settings, nil)
+
+	filter := &entity.ProductFilter{}
+	mockReadRepo.On("CountProductListings", mock.Anything, filter).Return(int64(0), nil)
+	mockReadRepo.On("GetProductListings", mock.Anything, filter).Return([]*entity.ProductReadModel{}, nil)
+
+	_, _, err := usecase.GetProducts(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 25, filter.Limit)
+}
+
+func TestProductUsecase_BulkProducts_ByIDs(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	mockOrgs := new(MockOrganizationRoles)
+	bus := events.NewBus()
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, bus, clock.New(), nil, mockOrgs, nil, nil)
+
+	var deleted []uuid.UUID
+	bus.Listen(EventDeleted, func(ctx context.Context, event events.Event) error {
+		deleted = append(deleted, event.(DeletedEvent).ProductID)
+		return nil
+	})
+
+	userID := uuid.New()
+	orgID := uuid.New()
+	own := &entity.Product{ID: uuid.New(), Name: "Own", CreatedBy: userID}
+	orgProduct := &entity.Product{ID: uuid.New(), Name: "Org", CreatedBy: uuid.New(), OrganizationID: &orgID}
+	otherOrgProduct := &entity.Product{ID: uuid.New(), Name: "Org 2", CreatedBy: uuid.New(), OrganizationID: &orgID}
+	others := &entity.Product{ID: uuid.New(), Name: "Others", CreatedBy: uuid.New()}
+	gone := &entity.Product{ID: uuid.New(), Name: "Gone", CreatedBy: userID, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	missing := uuid.New()
+
+	ids := []uuid.UUID{own.ID, orgProduct.ID, otherOrgProduct.ID, others.ID, gone.ID, missing, own.ID}
+	mockRepo.On("GetProductsByIDs", mock.Anything, ids[:6]).
+		Return([]*entity.Product{others, gone, orgProduct, own, otherOrgProduct}, nil)
+	mockOrgs.On("MemberRole", mock.Anything, orgID, userID).Return(entity.OrgRoleAdmin, nil).Once()
+	mockRepo.On("DeleteProducts", mock.Anything, []uuid.UUID{own.ID, orgProduct.ID, otherOrgProduct.ID}).Return(nil)
+
+	result, err := usecase.BulkProducts(context.Background(), entity.BulkDelete, &entity.BulkProductRequest{IDs: ids}, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.BulkProductResult{
+		Action:    entity.BulkDelete,
+		Succeeded: 3,
+		Failed:    1,
+		Skipped:   2,
+		Items: []entity.BulkProductItem{
+			{ID: missing, Status: "skipped", Reason: entity.BulkReasonNotFound},
+			{ID: others.ID, Status: "failed", Reason: entity.BulkReasonForbidden},
+			{ID: gone.ID, Status: "skipped", Reason: entity.BulkReasonUnchanged},
+		},
+	}, result)
+	assert.Equal(t, []uuid.UUID{own.ID, orgProduct.ID, otherOrgProduct.ID}, deleted)
+	mockRepo.AssertExpectations(t)
+	mockOrgs.AssertExpectations(t)
+}
+
+func TestProductUsecase_BulkProducts_ByFilter(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+	userID := uuid.New()
+	filter := &entity.BulkProductFilter{Category: "toys"}
+
+	first := make([]*entity.Product, bulkBatchSize)
+	var firstIDs []uuid.UUID
+	for i := range first {
+		first[i] = &entity.Product{ID: uuid.New(), CreatedBy: userID, IsActive: true}
+		firstIDs = append(firstIDs, first[i].ID)
+	}
+	inactive := &entity.Product{ID: uuid.New(), CreatedBy: userID}
+	others := &entity.Product{ID: uuid.New(), CreatedBy: uuid.New(), IsActive: true}
+	failing := &entity.Product{ID: uuid.New(), CreatedBy: userID, IsActive: true}
+
+	mockRepo.On("GetBulkProducts", mock.Anything, filter, false, uuid.Nil, bulkBatchSize).Return(first, nil)
+	mockRepo.On("GetBulkProducts", mock.Anything, filter, false, first[len(first)-1].ID, bulkBatchSize).
+		Return([]*entity.Product{inactive, others, failing}, nil)
+	mockRepo.On("SetProductsActive", mock.Anything, firstIDs, false).Return(nil)
+	mockRepo.On("SetProductsActive", mock.Anything, []uuid.UUID{failing.ID}, false).Return(assert.AnError)
+
+	result, err := usecase.BulkProducts(context.Background(), entity.BulkDeactivate, &entity.BulkProductRequest{Filter: filter}, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, bulkBatchSize, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, []entity.BulkProductItem{
+		{ID: inactive.ID, Status: "skipped", Reason: entity.BulkReasonUnchanged},
+		{ID: others.ID, Status: "skipped", Reason: entity.BulkReasonForbidden},
+		{ID: failing.ID, Status: "failed", Reason: entity.BulkReasonError},
+	}, result.Items)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProductUsecase_BulkProducts_InvalidRequest(t *testing.T) {
+	active := true
+	tests := map[string]struct {
+		action string
+		req    *entity.BulkProductRequest
+	}{
+		"unknown action":  {action: "archive", req: &entity.BulkProductRequest{IDs: []uuid.UUID{uuid.New()}}},
+		"ids and filter":  {action: entity.BulkDelete, req: &entity.BulkProductRequest{IDs: []uuid.UUID{uuid.New()}, Filter: &entity.BulkProductFilter{IsActive: &active}}},
+		"empty filter":    {action: entity.BulkDelete, req: &entity.BulkProductRequest{Filter: &entity.BulkProductFilter{}}},
+		"nothing to pick": {action: entity.BulkRestore, req: &entity.BulkProductRequest{}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(MockProductRepository)
+			usecase := NewProductUsecase(mockRepo, nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+			_, err := usecase.BulkProducts(context.Background(), tt.action, tt.req, uuid.New())
+
+			assert.Equal(t, errors.ErrBadRequest, err.(*errors.AppError).Code)
+			mockRepo.AssertNotCalled(t, "GetProductsByIDs", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestProductUsecase_RecordView(t *testing.T) {
+	views := new(MockViewCounter)
+	usecase := NewProductUsecase(new(MockProductRepository), nil, &config.Config{}, events.NewBus(), clock.New(), nil, nil, nil, views)
+
+	productID := uuid.New()
+	views.On("Incr", mock.Anything, entity.CounterProductViews, productID.String(), int64(1)).Return(nil)
+
+	assert.NoError(t, usecase.RecordView(context.Background(), productID))
+	views.AssertExpectations(t)
+}
+
+func TestProductUsecase_FlushViews(t *testing.T) {
+	mockRepo := new(MockProductRepository)
+	cfg := &config.Config{Counter: config.CounterConfig{FlushBatch: 100}}
+	usecase := NewProductUsecase(mockRepo, nil, cfg, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+	// Full batches are followed by another until one comes back short
+	mockRepo.On("FlushProductViews", mock.Anything, 100).Return(100, nil).Twice()
+	mockRepo.On("FlushProductViews", mock.Anything, 100).Return(30, nil).Once()
+
+	flushed, err := usecase.FlushViews(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 230, flushed)
+	mockRepo.AssertExpectations(t)
+
+	mockRepo = new(MockProductRepository)
+	usecase = NewProductUsecase(mockRepo, nil, cfg, events.NewBus(), clock.New(), nil, nil, nil, nil)
+	mockRepo.On("FlushProductViews", mock.Anything, 100).Return(0, gorm.ErrInvalidTransaction)
+
+	_, err = usecase.FlushViews(context.Background())
+	var appErr *errors.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, errors.ErrInternal, appErr.Code)
+	}
+}
+
+func TestProductUsecase_Attributes(t *testing.T) {
+	cfg := &config.Config{Products: config.ProductConfig{AttributeKeys: []string{"color", "size"}}}
+
+	t.Run("create rejects unknown keys", func(t *testing.T) {
+		usecase := NewProductUsecase(new(MockProductRepository), nil, cfg, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+		_, err := usecase.CreateProduct(context.Background(), &entity.CreateProductRequest{
+			Name:       "Shirt",
+			Attributes: entity.Attributes{"color": "red", "flavor": "mint"},
+		}, uuid.New())
+		var appErr *errors.AppError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, errors.ErrUnknownAttribute, appErr.Code)
+			assert.Equal(t, "Unknown attribute flavor", appErr.Message)
+		}
+	})
+
+	t.Run("listing rejects unknown keys", func(t *testing.T) {
+		usecase := NewProductUsecase(new(MockProductRepository), nil, cfg, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+		_, _, err := usecase.GetProducts(context.Background(), &entity.ProductFilter{Attributes: entity.Attributes{"flavor": "mint"}})
+		var appErr *errors.AppError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, errors.ErrUnknownAttribute, appErr.Code)
+		}
+	})
+
+	t.Run("update keeps keys no longer allowed", func(t *testing.T) {
+		mockRepo := new(MockProductRepository)
+		usecase := NewProductUsecase(mockRepo, nil, cfg, events.NewBus(), clock.New(), nil, nil, nil, nil)
+
+		userID, productID := uuid.New(), uuid.New()
+		existing := &entity.Product{ID: productID, CreatedBy: userID, Attributes: entity.Attributes{"fit": "slim"}}
+		mockRepo.On("GetProductByID", mock.Anything, productID).Return(existing, nil)
+		mockRepo.On("UpdateProduct", mock.Anything, existing).Return(nil)
+
+		attributes := entity.Attributes{"fit": "slim", "color": "red"}
+		product, err := usecase.UpdateProduct(context.Background(), productID, &entity.UpdateProductRequest{Attributes: &attributes}, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, attributes, product.Attributes)
+
+		attributes = entity.Attributes{"fit": "slim", "flavor": "mint"}
+		_, err = usecase.UpdateProduct(context.Background(), productID, &entity.UpdateProductRequest{Attributes: &attributes}, userID)
+		var appErr *errors.AppError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, errors.ErrUnknownAttribute, appErr.Code)
+		}
+	})
+}
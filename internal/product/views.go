@@ -0,0 +1,42 @@
+package product
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RecordView counts a view of the product. It shows in the product's
+// view_count once the scheduler has flushed views, see FlushViews.
+func (u *productUsecase) RecordView(ctx context.Context, productID uuid.UUID) error {
+	if err := u.views.Incr(ctx, entity.CounterProductViews, productID.String(), 1); err != nil {
+		logger.FromContext(ctx).Error("Failed to record product view", zap.String("product_id", productID.String()), zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to record view", 500)
+	}
+	return nil
+}
+
+// FlushViews adds the recorded views to their products, COUNTER_FLUSH_BATCH
+// pending deltas per transaction, and returns the number of deltas flushed.
+// It stops after a short batch, leaving views recorded meanwhile to the next
+// flush.
+func (u *productUsecase) FlushViews(ctx context.Context) (int, error) {
+	batch := max(u.config.Counter.FlushBatch, 1)
+
+	flushed := 0
+	for {
+		n, err := u.repo.FlushProductViews(ctx, batch)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to flush product views", zap.Error(err))
+			return flushed, errors.Wrap(err, errors.ErrInternal, "Failed to flush product views", 500)
+		}
+		flushed += n
+		if n < batch {
+			return flushed, nil
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package productimage
+
+import (
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ProductImageHandler struct {
+	usecase ProductImageUsecase
+}
+
+func NewProductImageHandler(usecase ProductImageUsecase) *ProductImageHandler {
+	return &ProductImageHandler{
+		usecase: usecase,
+	}
+}
+
+// UploadImage godoc
+// @Summary Upload a product image
+// @Description Upload a JPEG or PNG image of a product. Uploads are virus scanned first; infected ones are rejected with 422 FILE_INFECTED. The thumb (150x150, cropped), medium (600) and large (1200) WebP variants are rendered in the background; their URLs serve the original until then.
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Product ID"
+// @Param image formData file true "Image (JPEG or PNG)"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Failure 422 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Failure 503 {object} response.Response
+// @Router /products/{id}/images [post]
+func (h *ProductImageHandler) UploadImage(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			appErr := errors.ErrFileTooLargeError
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, nil)
+			return
+		}
+		response.Error(c, 400, errors.ErrBadRequest, "Image file is required", err.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to open upload", zap.Error(err))
+		response.Error(c, 500, errors.ErrInternal, "Failed to upload image", nil)
+		return
+	}
+	defer file.Close()
+
+	image, err := h.usecase.Upload(c.Request.Context(), productID, userID, file)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to upload product image", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to upload image", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "Image uploaded successfully", image)
+}
+
+// GetImages godoc
+// @Summary List product images
+// @Description List the images of a product, oldest first, with the URLs of their variants
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/{id}/images [get]
+func (h *ProductImageHandler) GetImages(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid product ID", err.Error())
+		return
+	}
+
+	images, err := h.usecase.GetImages(c.Request.Context(), productID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get product images", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get images", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Images retrieved successfully", images)
+}
+
+// DeleteImage godoc
+// @Summary Delete a product image
+// @Description Delete a product image and its variants
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Product ID"
+// @Param image_id path string true "Image ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/{id}/images/{image_id} [delete]
+func (h *ProductImageHandler) DeleteImage(c *gin.Context) {
+	productID, imageID, ok := imageIDs(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), productID, imageID, userID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to delete product image", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to delete image", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Image deleted successfully", nil)
+}
+
+// GetImage godoc
+// @Summary Get a product image
+// @Description Get a product image as uploaded (original) or one of its WebP variants. A variant that hasn't been rendered yet serves the original.
+// @Tags products
+// @Produce image/jpeg
+// @Produce image/png
+// @Produce image/webp
+// @Param id path string true "Product ID"
+// @Param image_id path string true "Image ID"
+// @Param variant path string true "Variant" Enums(original, thumb, medium, large)
+// @Success 200 {file} file "Image"
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /products/{id}/images/{image_id}/{variant} [get]
+func (h *ProductImageHandler) GetImage(c *gin.Context) {
+	productID, imageID, ok := imageIDs(c)
+	if !ok {
+		return
+	}
+
+	reader, contentType, err := h.usecase.Open(c.Request.Context(), productID, imageID, c.Param("variant"))
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get image", nil)
+		}
+		return
+	}
+	defer reader.Close()
+
+	// A variant changes once when it is rendered, so it is cached briefly
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Status(200)
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to stream product image", zap.Error(err))
+	}
+}
+
+// imageIDs parses the product and image IDs of the route and writes the
+// error response when one is invalid
+func imageIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid product ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	imageID, err := uuid.Parse(c.Param("image_id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid image ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return productID, imageID, true
+}
@@ -0,0 +1,115 @@
+package productimage_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/productimage"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartImage builds an upload form holding data as the image file
+func multipartImage(t *testing.T, data []byte) ([]byte, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("image", "lamp.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	return body.Bytes(), form.FormDataContentType()
+}
+
+func createProduct(api *apitest.API, owner *entity.User) entity.Product {
+	var product entity.Product
+	api.As(owner).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Lamp",
+		Price:    money.MustParse("25", "USD"),
+		Stock:    5,
+		Category: "home",
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&product)
+	return product
+}
+
+func TestProductImageHandler_UploadAndGet(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	product := createProduct(api, owner)
+	images := "/api/v1/products/" + product.ID.String() + "/images"
+
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewGray(image.Rect(0, 0, 400, 300))))
+	body, contentType := multipartImage(t, img.Bytes())
+
+	// Only the product's owners may add images
+	api.As(api.CreateUser()).Request(http.MethodPost, images, body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrInvalidOwner)
+
+	var uploaded entity.ProductImage
+	api.As(owner).Request(http.MethodPost, images, body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&uploaded)
+	assert.Equal(t, 400, uploaded.Width)
+	assert.Len(t, uploaded.Variants, 3)
+
+	jobs := api.Container.Queue.(*queue.ArrayQueue).Pushed()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, productimage.JobVariants, jobs[0].Type)
+
+	thumb := images + "/" + uploaded.ID.String() + "/thumb"
+	res := api.WithoutContract().Get(thumb).Do().AssertStatus(http.StatusOK)
+	assert.Equal(t, "image/png", res.Recorder.Header().Get("Content-Type"), "the original is served until the variant is rendered")
+
+	// Run the job the worker would
+	require.NoError(t, api.Container.ProductImageUsecase.GenerateVariants(context.Background(), uploaded.ID))
+	res = api.WithoutContract().Get(thumb).Do().AssertStatus(http.StatusOK)
+	assert.Equal(t, "image/webp", res.Recorder.Header().Get("Content-Type"))
+
+	var listed []entity.ProductImage
+	api.Get(images).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&listed)
+	require.Len(t, listed, 1)
+	assert.Equal(t, uploaded.Variants, listed[0].Variants)
+
+	api.As(owner).Delete(images + "/" + uploaded.ID.String()).Do().AssertStatus(http.StatusOK)
+	api.Get(thumb).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrProductImageNotFound)
+}
+
+func TestProductImageHandler_Upload_InvalidImage(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+	product := createProduct(api, owner)
+	body, contentType := multipartImage(t, []byte("not an image"))
+
+	api.As(owner).Request(http.MethodPost, "/api/v1/products/"+product.ID.String()+"/images", body).
+		Header("Content-Type", contentType).
+		Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrInvalidImage)
+}
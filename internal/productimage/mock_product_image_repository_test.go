@@ -0,0 +1,71 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package productimage
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductImageRepository is a testify mock of ProductImageRepository
+type MockProductImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductImageRepository) CreateImage(ctx context.Context, image *entity.ProductImage) error {
+	args := m.Called(ctx, image)
+	return args.Error(0)
+}
+
+func (m *MockProductImageRepository) GetImageByID(ctx context.Context, imageID uuid.UUID) (*entity.ProductImage, error) {
+	args := m.Called(ctx, imageID)
+
+	var r0 *entity.ProductImage
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.ProductImage)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductImageRepository) GetProductImages(ctx context.Context, productID uuid.UUID) ([]*entity.ProductImage, error) {
+	args := m.Called(ctx, productID)
+
+	var r0 []*entity.ProductImage
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.ProductImage)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductImageRepository) CountProductImages(ctx context.Context, productID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, productID)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductImageRepository) SetVariantPaths(ctx context.Context, imageID uuid.UUID, paths entity.ImageVariants) (bool, error) {
+	args := m.Called(ctx, imageID, paths)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductImageRepository) DeleteImage(ctx context.Context, imageID uuid.UUID) error {
+	args := m.Called(ctx, imageID)
+	return args.Error(0)
+}
@@ -0,0 +1,66 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package productimage
+
+import (
+	"context"
+	"io"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductImageUsecase is a testify mock of ProductImageUsecase
+type MockProductImageUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProductImageUsecase) Upload(ctx context.Context, productID uuid.UUID, userID uuid.UUID, file io.Reader) (*entity.ProductImage, error) {
+	args := m.Called(ctx, productID, userID, file)
+
+	var r0 *entity.ProductImage
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.ProductImage)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductImageUsecase) GetImages(ctx context.Context, productID uuid.UUID) ([]*entity.ProductImage, error) {
+	args := m.Called(ctx, productID)
+
+	var r0 []*entity.ProductImage
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.ProductImage)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProductImageUsecase) Delete(ctx context.Context, productID uuid.UUID, imageID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, imageID, userID)
+	return args.Error(0)
+}
+
+func (m *MockProductImageUsecase) Open(ctx context.Context, productID uuid.UUID, imageID uuid.UUID, variant string) (io.ReadCloser, string, error) {
+	args := m.Called(ctx, productID, imageID, variant)
+
+	var r0 io.ReadCloser
+	if v := args.Get(0); v != nil {
+		r0 = v.(io.ReadCloser)
+	}
+
+	var r1 string
+	if v := args.Get(1); v != nil {
+		r1 = v.(string)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockProductImageUsecase) GenerateVariants(ctx context.Context, imageID uuid.UUID) error {
+	args := m.Called(ctx, imageID)
+	return args.Error(0)
+}
@@ -0,0 +1,33 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package productimage
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProducts is a testify mock of Products
+type MockProducts struct {
+	mock.Mock
+}
+
+func (m *MockProducts) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	args := m.Called(ctx, productID)
+
+	var r0 *entity.Product
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Product)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockProducts) CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	args := m.Called(ctx, productID, userID)
+	return args.Error(0)
+}
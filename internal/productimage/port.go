@@ -0,0 +1,35 @@
+package productimage
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// ProductImageUsecase defines the business logic interface for product images
+type ProductImageUsecase interface {
+	Upload(ctx context.Context, productID uuid.UUID, userID uuid.UUID, file io.Reader) (*entity.ProductImage, error)
+	GetImages(ctx context.Context, productID uuid.UUID) ([]*entity.ProductImage, error)
+	Delete(ctx context.Context, productID uuid.UUID, imageID uuid.UUID, userID uuid.UUID) error
+	Open(ctx context.Context, productID uuid.UUID, imageID uuid.UUID, variant string) (io.ReadCloser, string, error)
+	GenerateVariants(ctx context.Context, imageID uuid.UUID) error
+}
+
+// ProductImageRepository defines the data access interface for product images
+type ProductImageRepository interface {
+	CreateImage(ctx context.Context, image *entity.ProductImage) error
+	GetImageByID(ctx context.Context, imageID uuid.UUID) (*entity.ProductImage, error)
+	GetProductImages(ctx context.Context, productID uuid.UUID) ([]*entity.ProductImage, error)
+	CountProductImages(ctx context.Context, productID uuid.UUID) (int64, error)
+	SetVariantPaths(ctx context.Context, imageID uuid.UUID, paths entity.ImageVariants) (bool, error)
+	DeleteImage(ctx context.Context, imageID uuid.UUID) error
+}
+
+// Products looks up the products images belong to and who may change them,
+// implemented by product.ProductUsecase
+type Products interface {
+	GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error)
+	CheckOwner(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error
+}
@@ -0,0 +1,66 @@
+package productimage
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type productImageRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImageRepository(db *gorm.DB) ProductImageRepository {
+	return &productImageRepository{
+		db: db,
+	}
+}
+
+func (r *productImageRepository) CreateImage(ctx context.Context, image *entity.ProductImage) error {
+	return tenancy.Conn(ctx, r.db).Create(image).Error
+}
+
+func (r *productImageRepository) GetImageByID(ctx context.Context, imageID uuid.UUID) (*entity.ProductImage, error) {
+	var image entity.ProductImage
+	if err := tenancy.Conn(ctx, r.db).First(&image, "id = ?", imageID).Error; err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// GetProductImages returns the product's images, oldest first
+func (r *productImageRepository) GetProductImages(ctx context.Context, productID uuid.UUID) ([]*entity.ProductImage, error) {
+	var images []*entity.ProductImage
+	err := tenancy.Conn(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order("created_at, id").
+		Find(&images).Error
+	return images, err
+}
+
+func (r *productImageRepository) CountProductImages(ctx context.Context, productID uuid.UUID) (int64, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Model(&entity.ProductImage{}).
+		Where("product_id = ?", productID).
+		Count(&count).Error
+	return count, err
+}
+
+// SetVariantPaths records the rendered variants of an image. It reports false
+// when the image was deleted in the meantime.
+func (r *productImageRepository) SetVariantPaths(ctx context.Context, imageID uuid.UUID, paths entity.ImageVariants) (bool, error) {
+	result := tenancy.Conn(ctx, r.db).Model(&entity.ProductImage{}).
+		Where("id = ?", imageID).
+		Update("variant_paths", paths)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *productImageRepository) DeleteImage(ctx context.Context, imageID uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Delete(&entity.ProductImage{}, "id = ?", imageID).Error
+}
@@ -0,0 +1,325 @@
+package productimage
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"path"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/imaging"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// JobVariants is pushed after an upload; its handler is registered in
+// internal/jobs
+const JobVariants = "product_image:variants"
+
+// VariantsPayload is the payload of a JobVariants job
+type VariantsPayload struct {
+	ImageID uuid.UUID `json:"image_id"`
+}
+
+// VariantOriginal asks Open for the uploaded image itself
+const VariantOriginal = "original"
+
+// Variants are the sizes every product image is rendered at, as WebP
+var Variants = []imaging.Variant{
+	{Name: "thumb", Width: 150, Height: 150, Crop: true},
+	{Name: "medium", Width: 600, Height: 600},
+	{Name: "large", Width: 1200, Height: 1200},
+}
+
+// extensions maps the formats images are stored in to file extensions
+var extensions = map[string]string{
+	imaging.FormatJPEG: ".jpg",
+	imaging.FormatPNG:  ".png",
+	imaging.FormatWebP: ".webp",
+}
+
+// contentTypes maps file extensions to the content type they are served as
+var contentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+}
+
+type productImageUsecase struct {
+	repo     ProductImageRepository
+	products Products
+	config   *config.Config
+	queue    queue.Queue
+	storage  storage.Storage
+	guard    *scanner.Guard
+}
+
+func NewProductImageUsecase(repo ProductImageRepository, products Products, config *config.Config, jobQueue queue.Queue, store storage.Storage, guard *scanner.Guard) ProductImageUsecase {
+	return &productImageUsecase{
+		repo:     repo,
+		products: products,
+		config:   config,
+		queue:    jobQueue,
+		storage:  store,
+		guard:    guard,
+	}
+}
+
+// Upload scans and validates the image, stores it re-encoded (which drops
+// metadata such as EXIF locations) and queues its variants. The variant URLs
+// are returned right away; until the job has rendered them they serve the
+// original.
+func (u *productImageUsecase) Upload(ctx context.Context, productID uuid.UUID, userID uuid.UUID, upload io.Reader) (*entity.ProductImage, error) {
+	if err := u.products.CheckOwner(ctx, productID, userID); err != nil {
+		return nil, err
+	}
+
+	count, err := u.repo.CountProductImages(ctx, productID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to count product images", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload image", 500)
+	}
+	if count >= int64(u.config.Images.MaxPerProduct) {
+		return nil, errors.ErrProductImageLimitError.WithDetails(map[string]interface{}{
+			"max_images": u.config.Images.MaxPerProduct,
+		})
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload, u.config.Images.MaxBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrBadRequest, "Failed to read upload", 400)
+	}
+	if int64(len(data)) > u.config.Images.MaxBytes {
+		return nil, errors.ErrFileTooLargeError
+	}
+
+	image := &entity.ProductImage{ID: uuid.New(), ProductID: productID}
+	prefix := u.prefix(image)
+	if err := u.guard.Check(ctx, prefix+VariantOriginal, bytes.NewReader(data)); err != nil {
+		if stderrors.Is(err, scanner.ErrInfected) {
+			return nil, errors.ErrFileInfectedError
+		}
+		logger.FromContext(ctx).Error("Failed to scan product image", zap.Error(err))
+		return nil, errors.ErrScanUnavailableError
+	}
+
+	img, format, err := imaging.Decode(data, u.config.Images.MaxDimension)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Rejected product image upload", zap.Error(err))
+		return nil, errors.ErrInvalidImageError
+	}
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, img, format); err != nil {
+		logger.FromContext(ctx).Error("Failed to encode product image", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload image", 500)
+	}
+
+	image.Path = prefix + VariantOriginal + extensions[format]
+	if err := u.storage.Put(ctx, image.Path, &buf); err != nil {
+		logger.FromContext(ctx).Error("Failed to store product image", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload image", 500)
+	}
+
+	base := fmt.Sprintf("%s/api/v1/products/%s/images/%s/", u.config.Account.URL, productID, image.ID)
+	image.URL = base + VariantOriginal
+	image.Width = img.Bounds().Dx()
+	image.Height = img.Bounds().Dy()
+	image.Variants = entity.ImageVariants{}
+	for _, variant := range Variants {
+		image.Variants[variant.Name] = base + variant.Name
+	}
+
+	if err := u.repo.CreateImage(ctx, image); err != nil {
+		logger.FromContext(ctx).Error("Failed to create product image", zap.Error(err))
+		u.deleteFiles(ctx, image)
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to upload image", 500)
+	}
+
+	// Variants fall back to the original, so a failed push only costs the
+	// smaller images
+	if err := u.queue.Push(ctx, u.config.Queue.Default, JobVariants, VariantsPayload{ImageID: image.ID}); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue product image variants", zap.Error(err))
+	}
+
+	logger.FromContext(ctx).Info("Product image uploaded",
+		zap.String("product_id", productID.String()), zap.String("file", image.Path), zap.Int("bytes", buf.Len()))
+	return image, nil
+}
+
+// GetImages lists the images of a product, oldest first
+func (u *productImageUsecase) GetImages(ctx context.Context, productID uuid.UUID) ([]*entity.ProductImage, error) {
+	if _, err := u.products.GetProductByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	images, err := u.repo.GetProductImages(ctx, productID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get product images", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get images", 500)
+	}
+	return images, nil
+}
+
+// Delete removes a product image and its variants
+func (u *productImageUsecase) Delete(ctx context.Context, productID uuid.UUID, imageID uuid.UUID, userID uuid.UUID) error {
+	if err := u.products.CheckOwner(ctx, productID, userID); err != nil {
+		return err
+	}
+
+	image, err := u.getImage(ctx, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteImage(ctx, imageID); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete product image", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete image", 500)
+	}
+	u.deleteFiles(ctx, image)
+
+	logger.FromContext(ctx).Info("Product image deleted",
+		zap.String("product_id", productID.String()), zap.String("image_id", imageID.String()))
+	return nil
+}
+
+// Open opens a variant of a product image, or the original when variant is
+// VariantOriginal or the variant hasn't been rendered yet, and returns its
+// content type
+func (u *productImageUsecase) Open(ctx context.Context, productID uuid.UUID, imageID uuid.UUID, variant string) (io.ReadCloser, string, error) {
+	if variant != VariantOriginal && !isVariant(variant) {
+		return nil, "", errors.ErrProductImageNotFoundError
+	}
+
+	image, err := u.getImage(ctx, productID, imageID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file := image.Path
+	if rendered, ok := image.VariantPaths[variant]; ok {
+		file = rendered
+	}
+
+	reader, err := u.storage.Get(ctx, file)
+	if err == storage.ErrNotFound {
+		return nil, "", errors.ErrProductImageNotFoundError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to open product image", zap.Error(err))
+		return nil, "", errors.Wrap(err, errors.ErrInternal, "Failed to get image", 500)
+	}
+	return reader, contentTypes[path.Ext(file)], nil
+}
+
+// GenerateVariants renders the variants of an uploaded image as WebP. An
+// image deleted since the upload is skipped.
+func (u *productImageUsecase) GenerateVariants(ctx context.Context, imageID uuid.UUID) error {
+	image, err := u.repo.GetImageByID(ctx, imageID)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get product image %s: %w", imageID, err)
+	}
+
+	reader, err := u.storage.Get(ctx, image.Path)
+	if err == storage.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open product image %s: %w", image.Path, err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("read product image %s: %w", image.Path, err)
+	}
+
+	img, _, err := imaging.Decode(data, 0)
+	if err != nil {
+		return fmt.Errorf("decode product image %s: %w", image.Path, err)
+	}
+
+	paths := entity.ImageVariants{}
+	for _, variant := range Variants {
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, variant.Apply(img), imaging.FormatWebP); err != nil {
+			return fmt.Errorf("encode %s variant: %w", variant.Name, err)
+		}
+
+		file := u.prefix(image) + variant.Name + extensions[imaging.FormatWebP]
+		if err := u.storage.Put(ctx, file, &buf); err != nil {
+			return fmt.Errorf("store %s variant: %w", variant.Name, err)
+		}
+		paths[variant.Name] = file
+	}
+
+	current, err := u.repo.SetVariantPaths(ctx, imageID, paths)
+	if err != nil {
+		return fmt.Errorf("set variants of product image %s: %w", imageID, err)
+	}
+	if !current {
+		u.deleteFiles(ctx, image)
+		return nil
+	}
+
+	logger.FromContext(ctx).Info("Product image variants generated",
+		zap.String("image_id", imageID.String()), zap.Int("variants", len(paths)))
+	return nil
+}
+
+// getImage returns the image when it belongs to the product
+func (u *productImageUsecase) getImage(ctx context.Context, productID uuid.UUID, imageID uuid.UUID) (*entity.ProductImage, error) {
+	image, err := u.repo.GetImageByID(ctx, imageID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrProductImageNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get product image", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get image", 500)
+	}
+	if image.ProductID != productID {
+		return nil, errors.ErrProductImageNotFoundError
+	}
+	return image, nil
+}
+
+// prefix is where an image and its variants are stored
+func (u *productImageUsecase) prefix(image *entity.ProductImage) string {
+	return fmt.Sprintf("%s/%s/%s/", u.config.Images.Dir, image.ProductID, image.ID)
+}
+
+// deleteFiles removes an image's files. A file left behind only wastes space,
+// so failures are logged rather than returned.
+func (u *productImageUsecase) deleteFiles(ctx context.Context, image *entity.ProductImage) {
+	files, err := u.storage.List(ctx, u.prefix(image))
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to list product image files", zap.Error(err))
+		return
+	}
+	for _, file := range files {
+		if err := u.storage.Delete(ctx, file); err != nil {
+			logger.FromContext(ctx).Warn("Failed to delete product image file", zap.String("file", file), zap.Error(err))
+		}
+	}
+}
+
+func isVariant(name string) bool {
+	for _, variant := range Variants {
+		if variant.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+package productimage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/queue"
+	"go-clean-gin/pkg/scanner"
+	"go-clean-gin/pkg/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type testDeps struct {
+	repo     *MockProductImageRepository
+	products *MockProducts
+	queue    *queue.ArrayQueue
+	storage  storage.Storage
+	usecase  ProductImageUsecase
+}
+
+func newTestUsecase(t *testing.T) *testDeps {
+	cfg := &config.Config{
+		Queue:   config.QueueConfig{Default: "default"},
+		Account: config.AccountConfig{URL: "http://api.test"},
+		Images:  config.ProductImageConfig{Dir: "product-images", MaxBytes: 1 << 20, MaxDimension: 2000, MaxPerProduct: 2},
+	}
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	d := &testDeps{
+		repo:     new(MockProductImageRepository),
+		products: new(MockProducts),
+		queue:    queue.NewArrayQueue(&cfg.Queue),
+		storage:  store,
+	}
+	guard := scanner.NewGuard(scanner.EICARScanner{}, store, "quarantine")
+	d.usecase = NewProductImageUsecase(d.repo, d.products, cfg, d.queue, store, guard)
+	return d
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProductImageUsecase_Upload(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+	productID, userID := uuid.New(), uuid.New()
+	d.products.On("CheckOwner", mock.Anything, productID, userID).Return(nil)
+	d.repo.On("CountProductImages", mock.Anything, productID).Return(int64(1), nil)
+	d.repo.On("CreateImage", mock.Anything, mock.Anything).Return(nil)
+
+	image, err := d.usecase.Upload(ctx, productID, userID, bytes.NewReader(encodePNG(t, 300, 200)))
+
+	require.NoError(t, err)
+	base := "http://api.test/api/v1/products/" + productID.String() + "/images/" + image.ID.String() + "/"
+	assert.Equal(t, base+"original", image.URL)
+	assert.Equal(t, entity.ImageVariants{
+		"thumb":  base + "thumb",
+		"medium": base + "medium",
+		"large":  base + "large",
+	}, image.Variants)
+	assert.Equal(t, 300, image.Width)
+	assert.Equal(t, 200, image.Height)
+	assert.Equal(t, "product-images/"+productID.String()+"/"+image.ID.String()+"/original.png", image.Path)
+
+	exists, _ := d.storage.Exists(ctx, image.Path)
+	assert.True(t, exists)
+	if pushed := d.queue.Pushed(); assert.Len(t, pushed, 1) {
+		assert.Equal(t, JobVariants, pushed[0].Type)
+	}
+	d.repo.AssertExpectations(t)
+}
+
+func TestProductImageUsecase_Upload_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		count    int64
+		expected string
+	}{
+		{"not an image", []byte("definitely not an image"), 0, errors.ErrInvalidImage},
+		{"dimensions too large", encodePNG(t, 2001, 10), 0, errors.ErrInvalidImage},
+		{"file too large", make([]byte, 1<<20+1), 0, errors.ErrFileTooLarge},
+		{"infected", []byte(scanner.EICARTestFile), 0, errors.ErrFileInfected},
+		{"too many images", encodePNG(t, 10, 10), 2, errors.ErrProductImageLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(t)
+			productID, userID := uuid.New(), uuid.New()
+			d.products.On("CheckOwner", mock.Anything, productID, userID).Return(nil)
+			d.repo.On("CountProductImages", mock.Anything, productID).Return(tt.count, nil)
+
+			_, err := d.usecase.Upload(context.Background(), productID, userID, bytes.NewReader(tt.data))
+
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, "expected an AppError, got %v", err)
+			assert.Equal(t, tt.expected, appErr.Code)
+			assert.Empty(t, d.queue.Pushed())
+			d.repo.AssertNotCalled(t, "CreateImage", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestProductImageUsecase_Upload_NotOwner(t *testing.T) {
+	d := newTestUsecase(t)
+	productID, userID := uuid.New(), uuid.New()
+	d.products.On("CheckOwner", mock.Anything, productID, userID).Return(errors.ErrInvalidOwnerError)
+
+	_, err := d.usecase.Upload(context.Background(), productID, userID, bytes.NewReader(encodePNG(t, 10, 10)))
+
+	assert.Equal(t, errors.ErrInvalidOwnerError, err)
+	d.repo.AssertNotCalled(t, "CreateImage", mock.Anything, mock.Anything)
+}
+
+func TestProductImageUsecase_GenerateVariants(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+
+	image := &entity.ProductImage{ID: uuid.New(), ProductID: uuid.New()}
+	prefix := "product-images/" + image.ProductID.String() + "/" + image.ID.String() + "/"
+	image.Path = prefix + "original.png"
+	require.NoError(t, d.storage.Put(ctx, image.Path, bytes.NewReader(encodePNG(t, 1600, 800))))
+	d.repo.On("GetImageByID", mock.Anything, image.ID).Return(image, nil)
+	d.repo.On("SetVariantPaths", mock.Anything, image.ID, entity.ImageVariants{
+		"thumb":  prefix + "thumb.webp",
+		"medium": prefix + "medium.webp",
+		"large":  prefix + "large.webp",
+	}).Return(true, nil)
+
+	require.NoError(t, d.usecase.GenerateVariants(ctx, image.ID))
+
+	reader, err := d.storage.Get(ctx, prefix+"medium.webp")
+	require.NoError(t, err)
+	defer reader.Close()
+	header := make([]byte, 30)
+	_, err = io.ReadFull(reader, header)
+	require.NoError(t, err)
+	assert.Equal(t, "RIFF", string(header[0:4]))
+	assert.Equal(t, "WEBPVP8L", string(header[8:16]))
+	// The width and height less one are packed into 14 bits each after the signature byte
+	size := uint32(header[21]) | uint32(header[22])<<8 | uint32(header[23])<<16 | uint32(header[24])<<24
+	assert.Equal(t, 600, int(size&0x3fff)+1)
+	assert.Equal(t, 300, int(size>>14&0x3fff)+1)
+	d.repo.AssertExpectations(t)
+}
+
+func TestProductImageUsecase_GenerateVariants_Deleted(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+
+	image := &entity.ProductImage{ID: uuid.New(), ProductID: uuid.New()}
+	prefix := "product-images/" + image.ProductID.String() + "/" + image.ID.String() + "/"
+	image.Path = prefix + "original.png"
+	require.NoError(t, d.storage.Put(ctx, image.Path, bytes.NewReader(encodePNG(t, 100, 100))))
+	d.repo.On("GetImageByID", mock.Anything, image.ID).Return(image, nil)
+	d.repo.On("SetVariantPaths", mock.Anything, image.ID, mock.Anything).Return(false, nil)
+
+	require.NoError(t, d.usecase.GenerateVariants(ctx, image.ID))
+
+	files, err := d.storage.List(ctx, prefix)
+	require.NoError(t, err)
+	assert.Empty(t, files, "variants of a deleted image are not kept")
+
+	gone := uuid.New()
+	d.repo.On("GetImageByID", mock.Anything, gone).Return(nil, gorm.ErrRecordNotFound)
+	assert.NoError(t, d.usecase.GenerateVariants(ctx, gone))
+}
+
+func TestProductImageUsecase_Open(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+
+	image := &entity.ProductImage{ID: uuid.New(), ProductID: uuid.New(), Path: "product-images/p/i/original.jpg"}
+	require.NoError(t, d.storage.Put(ctx, image.Path, strings.NewReader("original")))
+	d.repo.On("GetImageByID", mock.Anything, image.ID).Return(image, nil)
+
+	// Until the variants are rendered the original is served
+	reader, contentType, err := d.usecase.Open(ctx, image.ProductID, image.ID, "thumb")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "original", string(data))
+	assert.Equal(t, "image/jpeg", contentType)
+
+	image.VariantPaths = entity.ImageVariants{"thumb": "product-images/p/i/thumb.webp"}
+	require.NoError(t, d.storage.Put(ctx, "product-images/p/i/thumb.webp", strings.NewReader("thumb")))
+	reader, contentType, err = d.usecase.Open(ctx, image.ProductID, image.ID, "thumb")
+	require.NoError(t, err)
+	data, _ = io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "thumb", string(data))
+	assert.Equal(t, "image/webp", contentType)
+
+	_, _, err = d.usecase.Open(ctx, image.ProductID, image.ID, "huge")
+	assert.Equal(t, errors.ErrProductImageNotFoundError, err)
+	_, _, err = d.usecase.Open(ctx, uuid.New(), image.ID, VariantOriginal)
+	assert.Equal(t, errors.ErrProductImageNotFoundError, err)
+}
+
+func TestProductImageUsecase_Delete(t *testing.T) {
+	d := newTestUsecase(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	image := &entity.ProductImage{ID: uuid.New(), ProductID: uuid.New()}
+	prefix := "product-images/" + image.ProductID.String() + "/" + image.ID.String() + "/"
+	image.Path = prefix + "original.png"
+	for _, file := range []string{image.Path, prefix + "thumb.webp"} {
+		require.NoError(t, d.storage.Put(ctx, file, strings.NewReader("image")))
+	}
+	d.products.On("CheckOwner", mock.Anything, image.ProductID, userID).Return(nil)
+	d.repo.On("GetImageByID", mock.Anything, image.ID).Return(image, nil)
+	d.repo.On("DeleteImage", mock.Anything, image.ID).Return(nil)
+
+	require.NoError(t, d.usecase.Delete(ctx, image.ProductID, image.ID, userID))
+
+	files, err := d.storage.List(ctx, prefix)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	d.repo.AssertExpectations(t)
+}
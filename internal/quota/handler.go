@@ -0,0 +1,53 @@
+package quota
+
+import (
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type QuotaHandler struct {
+	usecase QuotaUsecase
+}
+
+func NewQuotaHandler(usecase QuotaUsecase) *QuotaHandler {
+	return &QuotaHandler{
+		usecase: usecase,
+	}
+}
+
+// GetUsage godoc
+// @Summary Get API usage
+// @Description Get the current user's usage against the daily and monthly API quotas. A limit of 0 means the period is not limited. Checking usage is not counted.
+// @Tags usage
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /usage [get]
+func (h *QuotaHandler) GetUsage(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	usage, err := h.usecase.GetUsage(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get usage", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get usage", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Usage retrieved successfully", usage)
+}
@@ -0,0 +1,48 @@
+package quota_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaHandler_GetUsage(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+	client := api.As(user)
+
+	res := client.Get("/api/v1/notifications").Do().AssertStatus(http.StatusOK)
+	remaining := strconv.FormatInt(api.Container.Config.Quota.Daily-1, 10)
+	assert.Equal(t, remaining, res.Recorder.Header().Get("X-Quota-Daily-Remaining"))
+
+	var usage entity.QuotaUsage
+	client.Get("/api/v1/usage").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&usage)
+	assert.Equal(t, int64(1), usage.Daily.Used, "checking usage is not counted")
+	assert.Equal(t, int64(1), usage.Monthly.Used)
+}
+
+func TestQuotaHandler_QuotaExceeded(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	api.Container.Config.Quota.Daily = 1
+	user := api.CreateUser()
+	client := api.As(user)
+
+	client.Get("/api/v1/notifications").Do().AssertStatus(http.StatusOK)
+	res := client.Get("/api/v1/notifications").Do().
+		AssertStatus(http.StatusTooManyRequests).
+		AssertErrorCode(errors.ErrQuotaExceeded)
+	assert.Equal(t, "0", res.Recorder.Header().Get("X-Quota-Daily-Remaining"))
+	assert.NotEmpty(t, res.Recorder.Header().Get("Retry-After"))
+}
@@ -0,0 +1,49 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package quota
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQuotaRepository is a testify mock of QuotaRepository
+type MockQuotaRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuotaRepository) Increment(ctx context.Context, userID uuid.UUID, period string, periodStart time.Time) (int64, error) {
+	args := m.Called(ctx, userID, period, periodStart)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockQuotaRepository) GetCount(ctx context.Context, userID uuid.UUID, period string, periodStart time.Time) (int64, error) {
+	args := m.Called(ctx, userID, period, periodStart)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockQuotaRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
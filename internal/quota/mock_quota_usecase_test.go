@@ -0,0 +1,50 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package quota
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQuotaUsecase is a testify mock of QuotaUsecase
+type MockQuotaUsecase struct {
+	mock.Mock
+}
+
+func (m *MockQuotaUsecase) Consume(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.QuotaUsage
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.QuotaUsage)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockQuotaUsecase) GetUsage(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.QuotaUsage
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.QuotaUsage)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockQuotaUsecase) PruneUsage(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,23 @@
+package quota
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotaUsecase defines the business logic interface for per-user API quotas
+type QuotaUsecase interface {
+	Consume(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error)
+	GetUsage(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error)
+	PruneUsage(ctx context.Context) (int64, error)
+}
+
+// QuotaRepository defines the data access interface for API usage counters
+type QuotaRepository interface {
+	Increment(ctx context.Context, userID uuid.UUID, period string, periodStart time.Time) (int64, error)
+	GetCount(ctx context.Context, userID uuid.UUID, period string, periodStart time.Time) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,46 @@
+package quota
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type quotaRepository struct {
+	db *gorm.DB
+}
+
+func NewQuotaRepository(db *gorm.DB) QuotaRepository {
+	return &quotaRepository{
+		db: db,
+	}
+}
+
+// Increment adds a request to the period's counter and returns the new count.
+// The upsert keeps concurrent requests from losing counts.
+func (r *quotaRepository) Increment(ctx context.Context, userID uuid.UUID, period string, periodStart time.Time) (int64, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Raw(`
+		INSERT INTO tb_api_usage (user_id, period, period_start, count) VALUES (?, ?, ?, 1)
+		ON CONFLICT (user_id, period, period_start) DO UPDATE SET count = tb_api_usage.count + 1
+		RETURNING count`, userID, period, periodStart).Scan(&count).Error
+	return count, err
+}
+
+func (r *quotaRepository) GetCount(ctx context.Context, userID uuid.UUID, period string, periodStart time.Time) (int64, error) {
+	var usage entity.APIUsage
+	err := tenancy.Conn(ctx, r.db).
+		Where("user_id = ? AND period = ? AND period_start = ?", userID, period, periodStart).
+		Limit(1).Find(&usage).Error
+	return usage.Count, err
+}
+
+// DeleteBefore deletes counters of periods that started before the cutoff
+func (r *quotaRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("period_start < ?", before).Delete(&entity.APIUsage{})
+	return result.RowsAffected, result.Error
+}
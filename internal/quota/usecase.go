@@ -0,0 +1,118 @@
+package quota
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// usageRetention is how long counters are kept after their period started;
+// long enough to cover the previous month
+const usageRetention = 62 * 24 * time.Hour
+
+type quotaUsecase struct {
+	repo   QuotaRepository
+	config *config.Config
+	clock  clock.Clock
+}
+
+func NewQuotaUsecase(repo QuotaRepository, config *config.Config, clk clock.Clock) QuotaUsecase {
+	return &quotaUsecase{
+		repo:   repo,
+		config: config,
+		clock:  clk,
+	}
+}
+
+// Consume counts a request against every limited period and returns the
+// usage. When a period is used up it also returns a QUOTA_EXCEEDED error
+// naming the period, when it resets and the seconds until then.
+func (u *quotaUsecase) Consume(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error) {
+	usage := u.windows()
+
+	for _, window := range []*entity.QuotaWindow{&usage.Daily, &usage.Monthly} {
+		if window.Limit <= 0 {
+			continue
+		}
+
+		used, err := u.repo.Increment(ctx, userID, window.Period, periodStart(window))
+		if err != nil {
+			return nil, err
+		}
+		setUsed(window, used)
+	}
+
+	for _, window := range []entity.QuotaWindow{usage.Daily, usage.Monthly} {
+		if window.Exceeded() {
+			logger.FromContext(ctx).Warn("API quota exceeded",
+				zap.String("user_id", userID.String()), zap.String("period", window.Period), zap.Int64("limit", window.Limit))
+			retryAfter := int(math.Max(1, math.Ceil(window.ResetAt.Sub(u.clock.Now()).Seconds())))
+			return usage, errors.New(errors.ErrQuotaExceeded, errors.ErrQuotaExceededError.Message, errors.ErrQuotaExceededError.StatusCode).
+				WithDetails(map[string]interface{}{"period": window.Period, "reset_at": window.ResetAt, "retry_after": retryAfter})
+		}
+	}
+
+	return usage, nil
+}
+
+// GetUsage returns the user's usage without counting a request
+func (u *quotaUsecase) GetUsage(ctx context.Context, userID uuid.UUID) (*entity.QuotaUsage, error) {
+	usage := u.windows()
+
+	for _, window := range []*entity.QuotaWindow{&usage.Daily, &usage.Monthly} {
+		used, err := u.repo.GetCount(ctx, userID, window.Period, periodStart(window))
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to get API usage", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get usage", 500)
+		}
+		setUsed(window, used)
+	}
+
+	return usage, nil
+}
+
+// PruneUsage deletes counters of periods that ended long ago
+func (u *quotaUsecase) PruneUsage(ctx context.Context) (int64, error) {
+	return u.repo.DeleteBefore(ctx, u.clock.Now().Add(-usageRetention))
+}
+
+// windows returns the current UTC day and month with their limits and nothing
+// used yet
+func (u *quotaUsecase) windows() *entity.QuotaUsage {
+	now := u.clock.Now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	usage := &entity.QuotaUsage{
+		Daily:   entity.QuotaWindow{Period: entity.QuotaDaily, Limit: u.config.Quota.Daily, ResetAt: day.AddDate(0, 0, 1)},
+		Monthly: entity.QuotaWindow{Period: entity.QuotaMonthly, Limit: u.config.Quota.Monthly, ResetAt: month.AddDate(0, 1, 0)},
+	}
+	setUsed(&usage.Daily, 0)
+	setUsed(&usage.Monthly, 0)
+	return usage
+}
+
+// periodStart is where the window's counter is kept
+func periodStart(window *entity.QuotaWindow) time.Time {
+	if window.Period == entity.QuotaMonthly {
+		return window.ResetAt.AddDate(0, -1, 0)
+	}
+	return window.ResetAt.AddDate(0, 0, -1)
+}
+
+func setUsed(window *entity.QuotaWindow, used int64) {
+	window.Used = used
+	window.Remaining = 0
+	if window.Limit > used {
+		window.Remaining = window.Limit - used
+	}
+}
@@ -0,0 +1,113 @@
+package quota
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNow   = time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC)
+	testDay   = time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	testMonth = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func newTestUsecase(daily, monthly int64) (*MockQuotaRepository, QuotaUsecase) {
+	cfg := &config.Config{Quota: config.QuotaConfig{Daily: daily, Monthly: monthly}}
+	mockRepo := new(MockQuotaRepository)
+	return mockRepo, NewQuotaUsecase(mockRepo, cfg, clock.NewFake(testNow))
+}
+
+func TestQuotaUsecase_Consume(t *testing.T) {
+	mockRepo, usecase := newTestUsecase(100, 1000)
+	userID := uuid.New()
+
+	mockRepo.On("Increment", mock.Anything, userID, entity.QuotaDaily, testDay).Return(int64(10), nil)
+	mockRepo.On("Increment", mock.Anything, userID, entity.QuotaMonthly, testMonth).Return(int64(500), nil)
+
+	usage, err := usecase.Consume(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.QuotaWindow{
+		Period: entity.QuotaDaily, Limit: 100, Used: 10, Remaining: 90, ResetAt: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+	}, usage.Daily)
+	assert.Equal(t, entity.QuotaWindow{
+		Period: entity.QuotaMonthly, Limit: 1000, Used: 500, Remaining: 500, ResetAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, usage.Monthly)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestQuotaUsecase_Consume_Exceeded(t *testing.T) {
+	mockRepo, usecase := newTestUsecase(100, 1000)
+	userID := uuid.New()
+
+	mockRepo.On("Increment", mock.Anything, userID, entity.QuotaDaily, testDay).Return(int64(101), nil)
+	mockRepo.On("Increment", mock.Anything, userID, entity.QuotaMonthly, testMonth).Return(int64(600), nil)
+
+	usage, err := usecase.Consume(context.Background(), userID)
+
+	var appErr *errors.AppError
+	require.True(t, stderrors.As(err, &appErr))
+	assert.Equal(t, errors.ErrQuotaExceeded, appErr.Code)
+	assert.Equal(t, 429, appErr.StatusCode)
+	assert.Equal(t, map[string]interface{}{
+		"period":      entity.QuotaDaily,
+		"reset_at":    time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+		"retry_after": int((5*time.Hour + 30*time.Minute).Seconds()),
+	}, appErr.Details)
+
+	require.NotNil(t, usage, "the usage is returned for the response headers")
+	assert.Zero(t, usage.Daily.Remaining)
+	assert.Nil(t, errors.ErrQuotaExceededError.Details, "the predefined error is not modified")
+}
+
+func TestQuotaUsecase_Consume_DisabledWindowsNotCounted(t *testing.T) {
+	mockRepo, usecase := newTestUsecase(0, 1000)
+	userID := uuid.New()
+
+	mockRepo.On("Increment", mock.Anything, userID, entity.QuotaMonthly, testMonth).Return(int64(1), nil)
+
+	usage, err := usecase.Consume(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Zero(t, usage.Daily.Limit)
+	assert.Equal(t, int64(999), usage.Monthly.Remaining)
+	mockRepo.AssertNotCalled(t, "Increment", mock.Anything, userID, entity.QuotaDaily, mock.Anything)
+}
+
+func TestQuotaUsecase_GetUsage(t *testing.T) {
+	mockRepo, usecase := newTestUsecase(100, 1000)
+	userID := uuid.New()
+
+	mockRepo.On("GetCount", mock.Anything, userID, entity.QuotaDaily, testDay).Return(int64(100), nil)
+	mockRepo.On("GetCount", mock.Anything, userID, entity.QuotaMonthly, testMonth).Return(int64(0), nil)
+
+	usage, err := usecase.GetUsage(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), usage.Daily.Remaining)
+	assert.Equal(t, int64(1000), usage.Monthly.Remaining)
+	mockRepo.AssertNotCalled(t, "Increment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQuotaUsecase_PruneUsage(t *testing.T) {
+	mockRepo, usecase := newTestUsecase(100, 1000)
+
+	mockRepo.On("DeleteBefore", mock.Anything, testNow.Add(-usageRetention)).Return(int64(3), nil)
+
+	deleted, err := usecase.PruneUsage(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
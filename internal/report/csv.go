@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	categoryCSVHeader     = []string{"category", "product_count", "active_count", "total_stock"}
+	registrationCSVHeader = []string{"date", "count"}
+	stockValueCSVHeader   = []string{"currency", "product_count", "total_stock", "total_value"}
+)
+
+// writeCSV sends records as a CSV attachment named filename
+func writeCSV(c *gin.Context, filename string, header []string, records [][]string) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(200)
+
+	_ = encodeCSV(c.Writer, header, records)
+}
+
+func encodeCSV(w io.Writer, header []string, records [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	return cw.WriteAll(records)
+}
+
+func categoryRecords(rows []*entity.CategoryReport) [][]string {
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{
+			row.Category,
+			strconv.FormatInt(row.ProductCount, 10),
+			strconv.FormatInt(row.ActiveCount, 10),
+			strconv.FormatInt(row.TotalStock, 10),
+		}
+	}
+	return records
+}
+
+func registrationRecords(rows []*entity.RegistrationReport) [][]string {
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{row.Date, strconv.FormatInt(row.Count, 10)}
+	}
+	return records
+}
+
+func stockValueRecords(rows []*entity.StockValueReport) [][]string {
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{
+			row.Currency,
+			strconv.FormatInt(row.ProductCount, 10),
+			strconv.FormatInt(row.TotalStock, 10),
+			row.TotalValue.FormatAmount(),
+		}
+	}
+	return records
+}
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"go-clean-gin/internal/entity"
+)
+
+// Exporter builds export jobs of one report, as CSV or JSON like the report
+// endpoints
+type Exporter struct {
+	usecase ReportUsecase
+	kind    string
+}
+
+// NewExporter creates the exporter of the report export kind, one of
+// entity.ExportKindProductsByCategory, ExportKindRegistrations and
+// ExportKindStockValue
+func NewExporter(usecase ReportUsecase, kind string) *Exporter {
+	return &Exporter{usecase: usecase, kind: kind}
+}
+
+func (e *Exporter) Formats() []string {
+	return []string{entity.ExportFormatCSV, entity.ExportFormatJSON}
+}
+
+func (e *Exporter) AdminOnly() bool {
+	return true
+}
+
+func (e *Exporter) Params() interface{} {
+	if e.kind == entity.ExportKindRegistrations {
+		return &entity.RegistrationExportParams{}
+	}
+	return nil
+}
+
+func (e *Exporter) Export(ctx context.Context, export *entity.Export, params interface{}, w io.Writer) error {
+	var (
+		rows    interface{}
+		header  []string
+		records [][]string
+	)
+
+	switch e.kind {
+	case entity.ExportKindProductsByCategory:
+		report, err := e.usecase.ProductsByCategory(ctx)
+		if err != nil {
+			return err
+		}
+		rows, header, records = report, categoryCSVHeader, categoryRecords(report)
+	case entity.ExportKindRegistrations:
+		filter, err := registrationFilter(params.(*entity.RegistrationExportParams))
+		if err != nil {
+			return err
+		}
+		report, err := e.usecase.RegistrationsPerDay(ctx, filter)
+		if err != nil {
+			return err
+		}
+		rows, header, records = report, registrationCSVHeader, registrationRecords(report)
+	case entity.ExportKindStockValue:
+		report, err := e.usecase.StockValue(ctx)
+		if err != nil {
+			return err
+		}
+		rows, header, records = report, stockValueCSVHeader, stockValueRecords(report)
+	default:
+		return fmt.Errorf("unknown report export kind %q", e.kind)
+	}
+
+	if export.Format == entity.ExportFormatJSON {
+		return json.NewEncoder(w).Encode(rows)
+	}
+	return encodeCSV(w, header, records)
+}
+
+// registrationFilter parses the days of the params, validated as dates
+func registrationFilter(params *entity.RegistrationExportParams) (*entity.RegistrationReportFilter, error) {
+	filter := &entity.RegistrationReportFilter{}
+	for _, day := range []struct {
+		value string
+		into  *time.Time
+	}{{params.From, &filter.From}, {params.To, &filter.To}} {
+		if day.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, day.value)
+		if err != nil {
+			return nil, err
+		}
+		*day.into = parsed
+	}
+	return filter, nil
+}
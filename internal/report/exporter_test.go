@@ -0,0 +1,41 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_Registrations(t *testing.T) {
+	usecase := new(MockReportUsecase)
+	exporter := NewExporter(usecase, entity.ExportKindRegistrations)
+
+	filter := &entity.RegistrationReportFilter{
+		From: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+	}
+	usecase.On("RegistrationsPerDay", mock.Anything, filter).Return([]*entity.RegistrationReport{
+		{Date: "2024-03-01", Count: 3},
+		{Date: "2024-03-02", Count: 0},
+	}, nil)
+
+	params := exporter.Params().(*entity.RegistrationExportParams)
+	params.From, params.To = "2024-03-01", "2024-03-02"
+
+	var csv bytes.Buffer
+	err := exporter.Export(context.Background(), &entity.Export{Format: entity.ExportFormatCSV}, params, &csv)
+	require.NoError(t, err)
+	assert.Equal(t, "date,count\n2024-03-01,3\n2024-03-02,0\n", csv.String())
+
+	var json bytes.Buffer
+	err = exporter.Export(context.Background(), &entity.Export{Format: entity.ExportFormatJSON}, params, &json)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"date": "2024-03-01", "count": 3}, {"date": "2024-03-02", "count": 0}]`, json.String())
+}
@@ -0,0 +1,159 @@
+package report
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	usecase ReportUsecase
+}
+
+func NewReportHandler(usecase ReportUsecase) *ReportHandler {
+	return &ReportHandler{
+		usecase: usecase,
+	}
+}
+
+// ProductsByCategory godoc
+// @Summary Products per category
+// @Description Count products, active products and stock per category
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security Bearer
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reports/products-by-category [get]
+func (h *ReportHandler) ProductsByCategory(c *gin.Context) {
+	var query entity.ReportQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	rows, err := h.usecase.ProductsByCategory(c.Request.Context())
+	if err != nil {
+		reportError(c, err, "Failed to get products by category")
+		return
+	}
+
+	if query.Format == entity.ReportFormatCSV {
+		writeCSV(c, "products-by-category.csv", categoryCSVHeader, categoryRecords(rows))
+		return
+	}
+
+	response.Success(c, 200, "Report generated successfully", rows)
+}
+
+// RegistrationsPerDay godoc
+// @Summary Registrations per day
+// @Description Count user registrations per day, including days without any. Defaults to the last 30 days.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security Bearer
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), default today"
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reports/registrations [get]
+func (h *ReportHandler) RegistrationsPerDay(c *gin.Context) {
+	var filter entity.RegistrationReportFilter
+	if !bindQuery(c, &filter) {
+		return
+	}
+
+	rows, err := h.usecase.RegistrationsPerDay(c.Request.Context(), &filter)
+	if err != nil {
+		reportError(c, err, "Failed to get registrations per day")
+		return
+	}
+
+	if filter.Format == entity.ReportFormatCSV {
+		writeCSV(c, "registrations.csv", registrationCSVHeader, registrationRecords(rows))
+		return
+	}
+
+	response.Success(c, 200, "Report generated successfully", rows)
+}
+
+// StockValue godoc
+// @Summary Stock value totals
+// @Description Total stock and stock value (price x stock) per price currency
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security Bearer
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reports/stock-value [get]
+func (h *ReportHandler) StockValue(c *gin.Context) {
+	var query entity.ReportQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	rows, err := h.usecase.StockValue(c.Request.Context())
+	if err != nil {
+		reportError(c, err, "Failed to get stock value")
+		return
+	}
+
+	if query.Format == entity.ReportFormatCSV {
+		writeCSV(c, "stock-value.csv", stockValueCSVHeader, stockValueRecords(rows))
+		return
+	}
+
+	response.Success(c, 200, "Report generated successfully", rows)
+}
+
+// bindQuery binds and validates query parameters, writing the error response
+// when they are invalid
+func bindQuery(c *gin.Context, query interface{}) bool {
+	if err := c.ShouldBindQuery(query); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return false
+	}
+
+	if fieldErrors := validator.ValidateStruct(query); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return false
+	}
+
+	return true
+}
+
+func reportError(c *gin.Context, err error, message string) {
+	logger.FromContext(c.Request.Context()).Error(message, zap.Error(err))
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+	} else {
+		response.Error(c, 500, errors.ErrInternal, message, nil)
+	}
+}
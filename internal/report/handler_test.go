@@ -0,0 +1,75 @@
+package report_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportHandler_ProductsByCategory(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	for _, name := range []string{"Keyboard", "Mouse"} {
+		api.As(admin).Post("/api/v1/products", entity.CreateProductRequest{
+			Name:     name,
+			Price:    money.MustParse("10", "USD"),
+			Stock:    3,
+			Category: "report-test",
+		}).Do().AssertStatus(http.StatusCreated)
+	}
+	// The report reads a materialized view, as of its last refresh
+	require.NoError(t, api.Container.ReportUsecase.RefreshViews(context.Background()))
+
+	var rows []entity.CategoryReport
+	api.As(admin).Get("/api/v1/reports/products-by-category").Do().
+		AssertStatus(http.StatusOK).
+		AssertSuccess().
+		Decode(&rows)
+
+	var found *entity.CategoryReport
+	for i := range rows {
+		if rows[i].Category == "report-test" {
+			found = &rows[i]
+		}
+	}
+	if assert.NotNil(t, found) {
+		assert.Equal(t, int64(2), found.ProductCount)
+		assert.Equal(t, int64(6), found.TotalStock)
+	}
+}
+
+func TestReportHandler_StockValue_CSV(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	res := api.As(admin).WithoutContract().Get("/api/v1/reports/stock-value").Query("format", "csv").Do().
+		AssertStatus(http.StatusOK)
+
+	assert.Equal(t, "text/csv; charset=utf-8", res.Recorder.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(res.Body(), "currency,product_count,total_stock,total_value\n"))
+}
+
+func TestReportHandler_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Get("/api/v1/reports/stock-value").Do().
+		AssertStatus(http.StatusForbidden).
+		AssertErrorCode(errors.ErrForbidden)
+}
@@ -0,0 +1,55 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package report
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportRepository is a testify mock of ReportRepository
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) ProductsByCategory(ctx context.Context) ([]*entity.CategoryReport, error) {
+	args := m.Called(ctx)
+
+	var r0 []*entity.CategoryReport
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.CategoryReport)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReportRepository) RegistrationsPerDay(ctx context.Context, from time.Time, to time.Time) ([]*entity.RegistrationReport, error) {
+	args := m.Called(ctx, from, to)
+
+	var r0 []*entity.RegistrationReport
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.RegistrationReport)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReportRepository) StockValue(ctx context.Context) ([]*entity.StockValueReport, error) {
+	args := m.Called(ctx)
+
+	var r0 []*entity.StockValueReport
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.StockValueReport)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReportRepository) RefreshViews(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,54 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package report
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportUsecase is a testify mock of ReportUsecase
+type MockReportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockReportUsecase) ProductsByCategory(ctx context.Context) ([]*entity.CategoryReport, error) {
+	args := m.Called(ctx)
+
+	var r0 []*entity.CategoryReport
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.CategoryReport)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReportUsecase) RegistrationsPerDay(ctx context.Context, filter *entity.RegistrationReportFilter) ([]*entity.RegistrationReport, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.RegistrationReport
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.RegistrationReport)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReportUsecase) StockValue(ctx context.Context) ([]*entity.StockValueReport, error) {
+	args := m.Called(ctx)
+
+	var r0 []*entity.StockValueReport
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.StockValueReport)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReportUsecase) RefreshViews(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,23 @@
+package report
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+)
+
+// ReportUsecase defines the business logic interface for reports
+type ReportUsecase interface {
+	ProductsByCategory(ctx context.Context) ([]*entity.CategoryReport, error)
+	RegistrationsPerDay(ctx context.Context, filter *entity.RegistrationReportFilter) ([]*entity.RegistrationReport, error)
+	StockValue(ctx context.Context) ([]*entity.StockValueReport, error)
+	RefreshViews(ctx context.Context) error
+}
+
+// ReportRepository defines the read queries behind the reports
+type ReportRepository interface {
+	ProductsByCategory(ctx context.Context) ([]*entity.CategoryReport, error)
+	RegistrationsPerDay(ctx context.Context, from, to time.Time) ([]*entity.RegistrationReport, error)
+	StockValue(ctx context.Context) ([]*entity.StockValueReport, error)
+	RefreshViews(ctx context.Context) error
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/matview"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// viewProductsByCategory is the materialized view of the products-by-category
+// report, created by a migration
+const viewProductsByCategory = "mv_products_by_category"
+
+// views are the materialized views RefreshViews refreshes
+var views = []string{viewProductsByCategory}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{
+		db: db,
+	}
+}
+
+// ProductsByCategory reads mv_products_by_category, as of its last refresh
+func (r *reportRepository) ProductsByCategory(ctx context.Context) ([]*entity.CategoryReport, error) {
+	var rows []*entity.CategoryReport
+	err := tenancy.Conn(ctx, r.db).Table(viewProductsByCategory).
+		Order("category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// RegistrationsPerDay returns one row per day from from to to, including days
+// without registrations. Days follow the database session time zone.
+func (r *reportRepository) RegistrationsPerDay(ctx context.Context, from, to time.Time) ([]*entity.RegistrationReport, error) {
+	var rows []*entity.RegistrationReport
+	err := tenancy.Conn(ctx, r.db).Raw(`
+		SELECT to_char(d.day, 'YYYY-MM-DD') AS date, COUNT(u.id) AS count
+		FROM generate_series(?::date, ?::date, interval '1 day') AS d(day)
+		LEFT JOIN tb_users u
+			ON u.created_at >= d.day AND u.created_at < d.day + interval '1 day' AND u.deleted_at IS NULL
+		GROUP BY d.day
+		ORDER BY d.day`,
+		from.Format("2006-01-02"), to.Format("2006-01-02"),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *reportRepository) StockValue(ctx context.Context) ([]*entity.StockValueReport, error) {
+	var rows []struct {
+		Currency     string
+		ProductCount int64
+		TotalStock   int64
+		TotalValue   decimal.Decimal
+	}
+	err := tenancy.Conn(ctx, r.db).Model(&entity.Product{}).
+		Select(`price_currency AS currency,
+			COUNT(*) AS product_count,
+			COALESCE(SUM(stock), 0) AS total_stock,
+			COALESCE(SUM(price_amount * stock), 0) AS total_value`).
+		Group("price_currency").
+		Order("price_currency").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entity.StockValueReport, len(rows))
+	for i, row := range rows {
+		reports[i] = &entity.StockValueReport{
+			Currency:     row.Currency,
+			ProductCount: row.ProductCount,
+			TotalStock:   row.TotalStock,
+			TotalValue:   money.New(row.TotalValue, row.Currency).Round(),
+		}
+	}
+	return reports, nil
+}
+
+// RefreshViews refreshes the materialized views behind the reports
+func (r *reportRepository) RefreshViews(ctx context.Context) error {
+	db := tenancy.Conn(ctx, r.db)
+	for _, view := range views {
+		if err := matview.Refresh(db, view); err != nil {
+			return err
+		}
+	}
+	return nil
+}
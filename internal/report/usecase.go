@@ -0,0 +1,85 @@
+package report
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultRegistrationDays is the range reported when no dates are given
+	defaultRegistrationDays = 30
+	// maxRegistrationDays bounds a single registrations report
+	maxRegistrationDays = 366
+)
+
+type reportUsecase struct {
+	repo  ReportRepository
+	clock clock.Clock
+}
+
+func NewReportUsecase(repo ReportRepository, clk clock.Clock) ReportUsecase {
+	return &reportUsecase{
+		repo:  repo,
+		clock: clk,
+	}
+}
+
+func (u *reportUsecase) ProductsByCategory(ctx context.Context) ([]*entity.CategoryReport, error) {
+	rows, err := u.repo.ProductsByCategory(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get products by category", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get products by category", 500)
+	}
+	return rows, nil
+}
+
+// RegistrationsPerDay defaults to the last 30 days up to today and rejects
+// ranges that are reversed or longer than a year
+func (u *reportUsecase) RegistrationsPerDay(ctx context.Context, filter *entity.RegistrationReportFilter) ([]*entity.RegistrationReport, error) {
+	if filter.To.IsZero() {
+		now := u.clock.Now().UTC()
+		filter.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, -(defaultRegistrationDays - 1))
+	}
+
+	if filter.To.Before(filter.From) {
+		return nil, errors.New(errors.ErrBadRequest, "to must not be before from", 400)
+	}
+	if filter.To.Sub(filter.From) >= maxRegistrationDays*24*time.Hour {
+		return nil, errors.New(errors.ErrBadRequest, "Date range must not exceed 366 days", 400)
+	}
+
+	rows, err := u.repo.RegistrationsPerDay(ctx, filter.From, filter.To)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get registrations per day", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get registrations per day", 500)
+	}
+	return rows, nil
+}
+
+func (u *reportUsecase) StockValue(ctx context.Context) ([]*entity.StockValueReport, error) {
+	rows, err := u.repo.StockValue(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get stock value", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get stock value", 500)
+	}
+	return rows, nil
+}
+
+// RefreshViews brings the reports read from materialized views up to date,
+// see reports:refresh-views
+func (u *reportUsecase) RefreshViews(ctx context.Context) error {
+	if err := u.repo.RefreshViews(ctx); err != nil {
+		logger.FromContext(ctx).Error("Failed to refresh report views", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to refresh report views", 500)
+	}
+	return nil
+}
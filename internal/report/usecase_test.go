@@ -0,0 +1,79 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReportUsecase_RegistrationsPerDay_DefaultRange(t *testing.T) {
+	mockRepo := new(MockReportRepository)
+	now := time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC)
+	usecase := NewReportUsecase(mockRepo, clock.NewFake(now))
+
+	from := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("RegistrationsPerDay", mock.Anything, from, to).Return([]*entity.RegistrationReport{}, nil)
+
+	_, err := usecase.RegistrationsPerDay(context.Background(), &entity.RegistrationReportFilter{})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReportUsecase_RegistrationsPerDay_InvalidRange(t *testing.T) {
+	mockRepo := new(MockReportRepository)
+	usecase := NewReportUsecase(mockRepo, clock.New())
+
+	tests := []struct {
+		name   string
+		filter *entity.RegistrationReportFilter
+	}{
+		{
+			name: "reversed",
+			filter: &entity.RegistrationReportFilter{
+				From: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "longer than a year",
+			filter: &entity.RegistrationReportFilter{
+				From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usecase.RegistrationsPerDay(context.Background(), tt.filter)
+
+			if assert.IsType(t, &errors.AppError{}, err) {
+				assert.Equal(t, errors.ErrBadRequest, err.(*errors.AppError).Code)
+			}
+		})
+	}
+	mockRepo.AssertNotCalled(t, "RegistrationsPerDay", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReportUsecase_RefreshViews_Error(t *testing.T) {
+	mockRepo := new(MockReportRepository)
+	usecase := NewReportUsecase(mockRepo, clock.New())
+
+	mockRepo.On("RefreshViews", mock.Anything).Return(assert.AnError)
+
+	err := usecase.RefreshViews(context.Background())
+
+	if assert.IsType(t, &errors.AppError{}, err) {
+		assert.Equal(t, errors.ErrInternal, err.(*errors.AppError).Code)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package reservation
+
+import "github.com/google/uuid"
+
+// Event names published by the reservation module. Stock moves are published
+// as product.ChangedEvent too, for the product projections.
+const (
+	EventReserved  = "reservation.reserved"
+	EventCommitted = "reservation.committed"
+	EventCancelled = "reservation.cancelled"
+)
+
+// ReservedEvent is dispatched when a user reserves stock of a product
+type ReservedEvent struct {
+	ReservationID uuid.UUID
+	ProductID     uuid.UUID
+	UserID        uuid.UUID
+	Quantity      int
+}
+
+func (ReservedEvent) EventName() string {
+	return EventReserved
+}
+
+// CommittedEvent is dispatched when a user checks out a reservation
+type CommittedEvent struct {
+	ReservationID uuid.UUID
+	ProductID     uuid.UUID
+	UserID        uuid.UUID
+	Quantity      int
+}
+
+func (CommittedEvent) EventName() string {
+	return EventCommitted
+}
+
+// CancelledEvent is dispatched when a user cancels a reservation. Expired
+// reservations are released without one.
+type CancelledEvent struct {
+	ReservationID uuid.UUID
+	ProductID     uuid.UUID
+	UserID        uuid.UUID
+	Quantity      int
+}
+
+func (CancelledEvent) EventName() string {
+	return EventCancelled
+}
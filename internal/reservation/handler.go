@@ -0,0 +1,222 @@
+package reservation
+
+import (
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ReservationHandler struct {
+	usecase ReservationUsecase
+}
+
+func NewReservationHandler(usecase ReservationUsecase) *ReservationHandler {
+	return &ReservationHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateReservation godoc
+// @Summary Reserve product stock
+// @Description Hold stock for the current user (add to cart) until checkout, cancel or expiry
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param reservation body entity.CreateReservationRequest true "Create reservation"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reservations [post]
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	var req entity.CreateReservationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.usecase.Reserve(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to reserve stock", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to reserve stock", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "Stock reserved successfully", reservation)
+}
+
+// GetReservations godoc
+// @Summary Get reservations
+// @Description Get the current user's reservations, newest first. Closed reservations are archived after ARCHIVE_RESERVATIONS_AFTER and listed with archived=true.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Filter by status" Enums(active, committed, released, expired)
+// @Param archived query bool false "List archived reservations instead"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reservations [get]
+func (h *ReservationHandler) GetReservations(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter entity.ReservationFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	reservations, total, err := h.usecase.GetReservations(c.Request.Context(), userID, &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get reservations", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get reservations", nil)
+		}
+		return
+	}
+
+	meta := pagination.Meta(filter.Params, total, len(reservations), func() (time.Time, uuid.UUID) {
+		last := reservations[len(reservations)-1]
+		return last.CreatedAt, last.ID
+	})
+	response.SuccessWithMeta(c, 200, "Reservations retrieved successfully", reservations, meta)
+}
+
+// CommitReservation godoc
+// @Summary Commit reservation
+// @Description Complete checkout for one of the current user's active reservations
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Reservation ID or public ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reservations/{id}/commit [post]
+func (h *ReservationHandler) CommitReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid reservation ID", err.Error())
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.usecase.CommitReservation(c.Request.Context(), userID, reservationID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to commit reservation", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to commit reservation", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Reservation committed successfully", reservation)
+}
+
+// CancelReservation godoc
+// @Summary Cancel reservation
+// @Description Release one of the current user's active reservations back to stock
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Reservation ID or public ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /reservations/{id}/cancel [post]
+func (h *ReservationHandler) CancelReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid reservation ID", err.Error())
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.usecase.CancelReservation(c.Request.Context(), userID, reservationID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to cancel reservation", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to cancel reservation", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Reservation cancelled successfully", reservation)
+}
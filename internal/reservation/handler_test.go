@@ -0,0 +1,65 @@
+package reservation_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationHandler_ReserveAndCommit(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var product entity.Product
+	api.As(user).Post("/api/v1/products", entity.CreateProductRequest{
+		Name:     "Keyboard",
+		Price:    money.MustParse("49.99", "USD"),
+		Stock:    2,
+		Category: "electronics",
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&product)
+
+	var reservation entity.Reservation
+	api.As(user).Post("/api/v1/reservations", entity.CreateReservationRequest{ProductID: product.ID, Quantity: 2}).Do().
+		AssertStatus(http.StatusCreated).
+		AssertSuccess().
+		Decode(&reservation)
+
+	assert.Equal(t, entity.ReservationActive, reservation.Status)
+
+	// Everything is held by the first cart
+	api.As(user).Post("/api/v1/reservations", entity.CreateReservationRequest{ProductID: product.ID, Quantity: 1}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrInsufficientStock)
+
+	api.As(user).Post("/api/v1/reservations/"+reservation.ID.String()+"/commit", nil).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&reservation)
+
+	assert.Equal(t, entity.ReservationCommitted, reservation.Status)
+
+	api.As(user).Post("/api/v1/reservations/"+reservation.ID.String()+"/cancel", nil).Do().
+		AssertStatus(http.StatusConflict).
+		AssertErrorCode(errors.ErrReservationNotActive)
+}
+
+func TestReservationHandler_CreateReservation_Validation(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Post("/api/v1/reservations", map[string]interface{}{"quantity": 0}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation).
+		AssertFieldError("product_id")
+}
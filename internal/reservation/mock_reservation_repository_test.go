@@ -0,0 +1,83 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package reservation
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReservationRepository is a testify mock of ReservationRepository
+type MockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationRepository) CreateReservation(ctx context.Context, reservation *entity.Reservation) error {
+	args := m.Called(ctx, reservation)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*entity.Reservation, error) {
+	args := m.Called(ctx, reservationID)
+
+	var r0 *entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Reservation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReservationRepository) GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error) {
+	args := m.Called(ctx, userID, filter)
+
+	var r0 []*entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Reservation)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockReservationRepository) CommitReservation(ctx context.Context, reservationID uuid.UUID, committedAt time.Time) (int64, error) {
+	args := m.Called(ctx, reservationID, committedAt)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReservationRepository) ReleaseReservation(ctx context.Context, reservationID uuid.UUID, status string, releasedAt time.Time) (int64, error) {
+	args := m.Called(ctx, reservationID, status, releasedAt)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReservationRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.Reservation, error) {
+	args := m.Called(ctx, now, limit)
+
+	var r0 []*entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Reservation)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,77 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package reservation
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReservationUsecase is a testify mock of ReservationUsecase
+type MockReservationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockReservationUsecase) Reserve(ctx context.Context, userID uuid.UUID, req *entity.CreateReservationRequest) (*entity.Reservation, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Reservation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReservationUsecase) GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error) {
+	args := m.Called(ctx, userID, filter)
+
+	var r0 []*entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Reservation)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockReservationUsecase) CommitReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error) {
+	args := m.Called(ctx, userID, reservationID)
+
+	var r0 *entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Reservation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReservationUsecase) CancelReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error) {
+	args := m.Called(ctx, userID, reservationID)
+
+	var r0 *entity.Reservation
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Reservation)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockReservationUsecase) ExpireReservations(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
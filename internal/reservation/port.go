@@ -0,0 +1,32 @@
+package reservation
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationUsecase defines the business logic interface for stock reservations
+type ReservationUsecase interface {
+	Reserve(ctx context.Context, userID uuid.UUID, req *entity.CreateReservationRequest) (*entity.Reservation, error)
+	GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error)
+	CommitReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error)
+	CancelReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error)
+	ExpireReservations(ctx context.Context) (int, error)
+}
+
+// ReservationRepository defines the data access interface for reservations,
+// served through RetryingReservationRepository
+type ReservationRepository interface {
+	CreateReservation(ctx context.Context, reservation *entity.Reservation) error
+	//retry:idempotent
+	GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*entity.Reservation, error)
+	//retry:idempotent
+	GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error)
+	CommitReservation(ctx context.Context, reservationID uuid.UUID, committedAt time.Time) (int64, error)
+	ReleaseReservation(ctx context.Context, reservationID uuid.UUID, status string, releasedAt time.Time) (int64, error)
+	//retry:idempotent
+	GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.Reservation, error)
+}
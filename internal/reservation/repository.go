@@ -0,0 +1,169 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/archive"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArchiveTable is the archive closed reservations are moved to once older
+// than ARCHIVE_RESERVATIONS_AFTER; lookups by ID fall back to it
+var ArchiveTable = archive.Table{Name: entity.Reservation{}.TableName(), Column: "closed_at"}
+
+// errInsufficientStock is returned by CreateReservation when the product has
+// less stock left than the reservation asks for
+var errInsufficientStock = errors.New("insufficient stock")
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &reservationRepository{
+		db: db,
+	}
+}
+
+// CreateReservation takes the quantity from the product's stock and stores the
+// reservation in one transaction. The product row is locked so concurrent
+// reservations for the same product queue up instead of overselling.
+func (r *reservationRepository) CreateReservation(ctx context.Context, reservation *entity.Reservation) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var product entity.Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "stock").
+			Where("id = ? AND is_active = ?", reservation.ProductID, true).
+			First(&product).Error
+		if err != nil {
+			return err
+		}
+
+		if product.Stock < reservation.Quantity {
+			return errInsufficientStock
+		}
+
+		err = tx.Model(&entity.Product{}).
+			Where("id = ?", reservation.ProductID).
+			Update("stock", gorm.Expr("stock - ?", reservation.Quantity)).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(reservation).Error
+	})
+}
+
+func (r *reservationRepository) GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*entity.Reservation, error) {
+	var reservation entity.Reservation
+	err := tenancy.Conn(ctx, r.db).Preload("Product").Where("id = ?", reservationID).First(&reservation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = tenancy.Conn(ctx, r.db).Table(ArchiveTable.ArchiveName()).
+			Preload("Product").Where("id = ?", reservationID).First(&reservation).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+func (r *reservationRepository) GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error) {
+	var reservations []*entity.Reservation
+	var total int64
+
+	query := tenancy.Conn(ctx, r.db).Model(&entity.Reservation{})
+	if filter.Archived {
+		query = query.Table(ArchiveTable.ArchiveName())
+	}
+	query = query.Where("user_id = ?", userID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := pagination.Apply(query.Preload("Product"), filter.Params, "created_at", "id").Find(&reservations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reservations, total, nil
+}
+
+// CommitReservation returns the number of rows updated, 0 when the reservation
+// is no longer active or has expired. Committed stock stays taken.
+func (r *reservationRepository) CommitReservation(ctx context.Context, reservationID uuid.UUID, committedAt time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Model(&entity.Reservation{}).
+		Where("id = ? AND status = ? AND expires_at > ?", reservationID, entity.ReservationActive, committedAt).
+		Updates(map[string]interface{}{
+			"status":    entity.ReservationCommitted,
+			"closed_at": committedAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// ReleaseReservation closes an active reservation with status (released or
+// expired) and returns its quantity to the product's stock. It returns 0 when
+// the reservation is no longer active.
+func (r *reservationRepository) ReleaseReservation(ctx context.Context, reservationID uuid.UUID, status string, releasedAt time.Time) (int64, error) {
+	var released int64
+
+	err := tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var reservation entity.Reservation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ?", reservationID, entity.ReservationActive).
+			First(&reservation).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&reservation).Updates(map[string]interface{}{
+			"status":    status,
+			"closed_at": releasedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// Unscoped: stock is returned even if the product was deleted meanwhile
+		err = tx.Unscoped().Model(&entity.Product{}).
+			Where("id = ?", reservation.ProductID).
+			Update("stock", gorm.Expr("stock + ?", reservation.Quantity)).Error
+		if err != nil {
+			return err
+		}
+
+		released = 1
+		return nil
+	})
+
+	return released, err
+}
+
+// GetExpiredReservations returns up to limit active reservations that expired
+// at or before now, oldest first
+func (r *reservationRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.Reservation, error) {
+	var reservations []*entity.Reservation
+	err := tenancy.Conn(ctx, r.db).
+		Where("status = ? AND expires_at <= ?", entity.ReservationActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
@@ -0,0 +1,141 @@
+package reservation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/archive"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/test/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createTestProduct(t *testing.T, db *gorm.DB, stock int) (*entity.User, *entity.Product) {
+	t.Helper()
+
+	suffix := uuid.NewString()[:8]
+	user := &entity.User{
+		Email:     "user_" + suffix + "@example.com",
+		Username:  "user_" + suffix,
+		Password:  "hashed",
+		FirstName: "Test",
+		LastName:  "User",
+		Role:      entity.RoleUser,
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	product := &entity.Product{
+		Name:      "Reserved Product",
+		Price:     money.MustParse("10", "USD"),
+		Stock:     stock,
+		Category:  "reservation-test",
+		IsActive:  true,
+		CreatedBy: user.ID,
+	}
+	require.NoError(t, db.Create(product).Error)
+	return user, product
+}
+
+func currentStock(t *testing.T, db *gorm.DB, productID uuid.UUID) int {
+	t.Helper()
+
+	var product entity.Product
+	require.NoError(t, db.Unscoped().Select("stock").Where("id = ?", productID).First(&product).Error)
+	return product.Stock
+}
+
+func TestReservationRepository_CreateReservation_TakesStock(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewReservationRepository(db)
+	user, product := createTestProduct(t, db, 3)
+	ctx := context.Background()
+
+	first := &entity.Reservation{ProductID: product.ID, UserID: user.ID, Quantity: 2, ExpiresAt: time.Now().Add(time.Minute)}
+	require.NoError(t, repo.CreateReservation(ctx, first))
+	assert.Equal(t, 1, currentStock(t, db, product.ID))
+
+	second := &entity.Reservation{ProductID: product.ID, UserID: user.ID, Quantity: 2, ExpiresAt: time.Now().Add(time.Minute)}
+	assert.Equal(t, errInsufficientStock, repo.CreateReservation(ctx, second))
+	assert.Equal(t, 1, currentStock(t, db, product.ID))
+}
+
+func TestReservationRepository_ReleaseAndCommit(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewReservationRepository(db)
+	user, product := createTestProduct(t, db, 5)
+	ctx := context.Background()
+	now := time.Now()
+
+	released := &entity.Reservation{ProductID: product.ID, UserID: user.ID, Quantity: 2, ExpiresAt: now.Add(time.Minute)}
+	committed := &entity.Reservation{ProductID: product.ID, UserID: user.ID, Quantity: 1, ExpiresAt: now.Add(time.Minute)}
+	require.NoError(t, repo.CreateReservation(ctx, released))
+	require.NoError(t, repo.CreateReservation(ctx, committed))
+	assert.Equal(t, 2, currentStock(t, db, product.ID))
+
+	count, err := repo.ReleaseReservation(ctx, released.ID, entity.ReservationReleased, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, 4, currentStock(t, db, product.ID))
+
+	// Releasing twice must not return the stock twice
+	count, err = repo.ReleaseReservation(ctx, released.ID, entity.ReservationReleased, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Equal(t, 4, currentStock(t, db, product.ID))
+
+	count, err = repo.CommitReservation(ctx, committed.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, 4, currentStock(t, db, product.ID))
+
+	count, err = repo.ReleaseReservation(ctx, committed.ID, entity.ReservationExpired, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestReservationRepository_ArchivedFallback(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	repo := NewReservationRepository(db)
+	user, product := createTestProduct(t, db, 5)
+	ctx := context.Background()
+	// Far enough in the past that no other test's reservation is archived
+	closedAt := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	reservation := &entity.Reservation{ProductID: product.ID, UserID: user.ID, Quantity: 1, ExpiresAt: time.Now().Add(time.Minute)}
+	require.NoError(t, repo.CreateReservation(ctx, reservation))
+	count, err := repo.ReleaseReservation(ctx, reservation.ID, entity.ReservationReleased, closedAt)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	moved, err := archive.New(db, nil, 10).Archive(ctx, ArchiveTable, closedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+
+	found, err := repo.GetReservationByID(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReservationReleased, found.Status)
+	assert.Equal(t, product.ID, found.Product.ID)
+
+	live, _, err := repo.GetReservations(ctx, user.ID, &entity.ReservationFilter{Params: pagination.Params{Page: 1, Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, live)
+
+	archived, _, err := repo.GetReservations(ctx, user.ID, &entity.ReservationFilter{Archived: true, Params: pagination.Params{Page: 1, Limit: 10}})
+	require.NoError(t, err)
+	if assert.Len(t, archived, 1) {
+		assert.Equal(t, reservation.ID, archived[0].ID)
+	}
+}
@@ -0,0 +1,78 @@
+// Code generated by artisan make:retry. DO NOT EDIT.
+
+package reservation
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/dbretry"
+
+	"github.com/google/uuid"
+)
+
+// RetryingReservationRepository retries the calls of ReservationRepository failing
+// with a transient database error. Only methods marked //retry:idempotent
+// or //cache:read are retried after a lost connection.
+type RetryingReservationRepository struct {
+	next    ReservationRepository
+	retrier *dbretry.Retrier
+}
+
+// NewRetryingReservationRepository wraps next, retrying its calls with retrier
+func NewRetryingReservationRepository(next ReservationRepository, retrier *dbretry.Retrier) ReservationRepository {
+	return &RetryingReservationRepository{next: next, retrier: retrier}
+}
+
+func (c *RetryingReservationRepository) CreateReservation(ctx context.Context, reservation *entity.Reservation) error {
+	return c.retrier.Do(ctx, "reservation.ReservationRepository.CreateReservation", false, func() error {
+		return c.next.CreateReservation(ctx, reservation)
+	})
+}
+
+func (c *RetryingReservationRepository) GetReservationByID(ctx context.Context, reservationID uuid.UUID) (*entity.Reservation, error) {
+	var r0 *entity.Reservation
+	err := c.retrier.Do(ctx, "reservation.ReservationRepository.GetReservationByID", true, func() (err error) {
+		r0, err = c.next.GetReservationByID(ctx, reservationID)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingReservationRepository) GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error) {
+	var r0 []*entity.Reservation
+	var r1 int64
+	err := c.retrier.Do(ctx, "reservation.ReservationRepository.GetReservations", true, func() (err error) {
+		r0, r1, err = c.next.GetReservations(ctx, userID, filter)
+		return err
+	})
+	return r0, r1, err
+}
+
+func (c *RetryingReservationRepository) CommitReservation(ctx context.Context, reservationID uuid.UUID, committedAt time.Time) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "reservation.ReservationRepository.CommitReservation", false, func() (err error) {
+		r0, err = c.next.CommitReservation(ctx, reservationID, committedAt)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingReservationRepository) ReleaseReservation(ctx context.Context, reservationID uuid.UUID, status string, releasedAt time.Time) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "reservation.ReservationRepository.ReleaseReservation", false, func() (err error) {
+		r0, err = c.next.ReleaseReservation(ctx, reservationID, status, releasedAt)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingReservationRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.Reservation, error) {
+	var r0 []*entity.Reservation
+	err := c.retrier.Do(ctx, "reservation.ReservationRepository.GetExpiredReservations", true, func() (err error) {
+		r0, err = c.next.GetExpiredReservations(ctx, now, limit)
+		return err
+	})
+	return r0, err
+}
@@ -0,0 +1,214 @@
+package reservation
+
+import (
+	"context"
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// expireBatchSize is the number of expired reservations released per query
+const expireBatchSize = 100
+
+type reservationUsecase struct {
+	repo   ReservationRepository
+	config *config.Config
+	events *events.Bus
+	clock  clock.Clock
+}
+
+func NewReservationUsecase(repo ReservationRepository, config *config.Config, bus *events.Bus, clk clock.Clock) ReservationUsecase {
+	return &reservationUsecase{
+		repo:   repo,
+		config: config,
+		events: bus,
+		clock:  clk,
+	}
+}
+
+// Reserve holds quantity of a product for the user until the reservation is
+// committed, cancelled or expires after RESERVATION_TTL
+func (u *reservationUsecase) Reserve(ctx context.Context, userID uuid.UUID, req *entity.CreateReservationRequest) (*entity.Reservation, error) {
+	reservation := &entity.Reservation{
+		ProductID: req.ProductID,
+		UserID:    userID,
+		Quantity:  req.Quantity,
+		Status:    entity.ReservationActive,
+		ExpiresAt: u.clock.Now().Add(u.config.Reservation.TTL),
+	}
+
+	if err := u.repo.CreateReservation(ctx, reservation); err != nil {
+		switch err {
+		case gorm.ErrRecordNotFound:
+			return nil, errors.ErrProductNotFoundError
+		case errInsufficientStock:
+			return nil, errors.ErrInsufficientStockError
+		}
+		logger.FromContext(ctx).Error("Failed to create reservation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create reservation", 500)
+	}
+
+	logger.FromContext(ctx).Info("Stock reserved",
+		zap.String("reservation_id", reservation.ID.String()),
+		zap.String("product_id", reservation.ProductID.String()),
+		zap.Int("quantity", reservation.Quantity),
+	)
+	u.events.Dispatch(ctx, product.ChangedEvent{ProductID: reservation.ProductID})
+	u.events.Dispatch(ctx, ReservedEvent{
+		ReservationID: reservation.ID,
+		ProductID:     reservation.ProductID,
+		UserID:        userID,
+		Quantity:      reservation.Quantity,
+	})
+	return reservation, nil
+}
+
+func (u *reservationUsecase) GetReservations(ctx context.Context, userID uuid.UUID, filter *entity.ReservationFilter) ([]*entity.Reservation, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+
+	reservations, total, err := u.repo.GetReservations(ctx, userID, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get reservations", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get reservations", 500)
+	}
+
+	return reservations, total, nil
+}
+
+// CommitReservation completes checkout for the reservation; the stock stays taken
+func (u *reservationUsecase) CommitReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := u.getActiveReservation(ctx, userID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.clock.Now()
+	if !now.Before(reservation.ExpiresAt) {
+		// Expired but not reaped yet: give the stock back now
+		if released, err := u.repo.ReleaseReservation(ctx, reservationID, entity.ReservationExpired, now); err != nil {
+			logger.FromContext(ctx).Error("Failed to expire reservation", zap.Error(err))
+		} else if released > 0 {
+			u.events.Dispatch(ctx, product.ChangedEvent{ProductID: reservation.ProductID})
+		}
+		return nil, errors.ErrReservationExpiredError
+	}
+
+	committed, err := u.repo.CommitReservation(ctx, reservationID, now)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to commit reservation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to commit reservation", 500)
+	}
+	if committed == 0 {
+		// Cancelled or expired since it was read
+		return nil, errors.ErrReservationNotActiveError
+	}
+
+	reservation.Status = entity.ReservationCommitted
+	reservation.ClosedAt = &now
+
+	logger.FromContext(ctx).Info("Reservation committed", zap.String("reservation_id", reservationID.String()))
+	u.events.Dispatch(ctx, CommittedEvent{
+		ReservationID: reservation.ID,
+		ProductID:     reservation.ProductID,
+		UserID:        userID,
+		Quantity:      reservation.Quantity,
+	})
+	return reservation, nil
+}
+
+// CancelReservation releases the reserved stock back to the product
+func (u *reservationUsecase) CancelReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := u.getActiveReservation(ctx, userID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.clock.Now()
+	released, err := u.repo.ReleaseReservation(ctx, reservationID, entity.ReservationReleased, now)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to release reservation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to release reservation", 500)
+	}
+	if released == 0 {
+		return nil, errors.ErrReservationNotActiveError
+	}
+
+	reservation.Status = entity.ReservationReleased
+	reservation.ClosedAt = &now
+
+	logger.FromContext(ctx).Info("Reservation cancelled", zap.String("reservation_id", reservationID.String()))
+	u.events.Dispatch(ctx, product.ChangedEvent{ProductID: reservation.ProductID})
+	u.events.Dispatch(ctx, CancelledEvent{
+		ReservationID: reservation.ID,
+		ProductID:     reservation.ProductID,
+		UserID:        userID,
+		Quantity:      reservation.Quantity,
+	})
+	return reservation, nil
+}
+
+// ExpireReservations returns the stock of every expired active reservation to
+// its product and returns how many were expired
+func (u *reservationUsecase) ExpireReservations(ctx context.Context) (int, error) {
+	now := u.clock.Now()
+	expired := 0
+
+	for {
+		reservations, err := u.repo.GetExpiredReservations(ctx, now, expireBatchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to get expired reservations", zap.Error(err))
+			return expired, errors.Wrap(err, errors.ErrInternal, "Failed to get expired reservations", 500)
+		}
+
+		for _, reservation := range reservations {
+			released, err := u.repo.ReleaseReservation(ctx, reservation.ID, entity.ReservationExpired, now)
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to expire reservation", zap.Error(err))
+				return expired, errors.Wrap(err, errors.ErrInternal, "Failed to expire reservation", 500)
+			}
+			if released > 0 {
+				expired++
+				u.events.Dispatch(ctx, product.ChangedEvent{ProductID: reservation.ProductID})
+			}
+		}
+
+		if len(reservations) < expireBatchSize {
+			break
+		}
+	}
+
+	if expired > 0 {
+		logger.FromContext(ctx).Info("Reservations expired", zap.Int("count", expired))
+	}
+	return expired, nil
+}
+
+// getActiveReservation loads the user's reservation and checks it is still active
+func (u *reservationUsecase) getActiveReservation(ctx context.Context, userID uuid.UUID, reservationID uuid.UUID) (*entity.Reservation, error) {
+	reservation, err := u.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrReservationNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get reservation", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get reservation", 500)
+	}
+
+	if reservation.UserID != userID {
+		return nil, errors.ErrReservationNotFoundError
+	}
+	if reservation.Status != entity.ReservationActive {
+		return nil, errors.ErrReservationNotActiveError
+	}
+
+	return reservation, nil
+}
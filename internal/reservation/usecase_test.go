@@ -0,0 +1,143 @@
+package reservation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestUsecase(repo ReservationRepository, now time.Time) ReservationUsecase {
+	cfg := &config.Config{Reservation: config.ReservationConfig{TTL: 15 * time.Minute}}
+	return NewReservationUsecase(repo, cfg, events.NewBus(), clock.NewFake(now))
+}
+
+func TestReservationUsecase_Reserve_Success(t *testing.T) {
+	mockRepo := new(MockReservationRepository)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usecase := newTestUsecase(mockRepo, now)
+
+	userID := uuid.New()
+	req := &entity.CreateReservationRequest{ProductID: uuid.New(), Quantity: 2}
+	mockRepo.On("CreateReservation", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(nil)
+
+	result, err := usecase.Reserve(context.Background(), userID, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, result.UserID)
+	assert.Equal(t, entity.ReservationActive, result.Status)
+	assert.Equal(t, now.Add(15*time.Minute), result.ExpiresAt)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReservationUsecase_Reserve_InsufficientStock(t *testing.T) {
+	mockRepo := new(MockReservationRepository)
+	usecase := newTestUsecase(mockRepo, time.Now())
+
+	req := &entity.CreateReservationRequest{ProductID: uuid.New(), Quantity: 5}
+	mockRepo.On("CreateReservation", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(errInsufficientStock)
+
+	result, err := usecase.Reserve(context.Background(), uuid.New(), req)
+
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrInsufficientStockError, err)
+}
+
+func TestReservationUsecase_CommitReservation_Expired(t *testing.T) {
+	mockRepo := new(MockReservationRepository)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usecase := newTestUsecase(mockRepo, now)
+
+	userID := uuid.New()
+	reservation := &entity.Reservation{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    entity.ReservationActive,
+		ExpiresAt: now.Add(-time.Second),
+	}
+	mockRepo.On("GetReservationByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	mockRepo.On("ReleaseReservation", mock.Anything, reservation.ID, entity.ReservationExpired, now).Return(int64(1), nil)
+
+	result, err := usecase.CommitReservation(context.Background(), userID, reservation.ID)
+
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrReservationExpiredError, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CommitReservation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReservationUsecase_CancelReservation_OtherUser(t *testing.T) {
+	mockRepo := new(MockReservationRepository)
+	usecase := newTestUsecase(mockRepo, time.Now())
+
+	reservation := &entity.Reservation{ID: uuid.New(), UserID: uuid.New(), Status: entity.ReservationActive}
+	mockRepo.On("GetReservationByID", mock.Anything, reservation.ID).Return(reservation, nil)
+
+	result, err := usecase.CancelReservation(context.Background(), uuid.New(), reservation.ID)
+
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrReservationNotFoundError, err)
+	mockRepo.AssertNotCalled(t, "ReleaseReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReservationUsecase_ExpireReservations(t *testing.T) {
+	mockRepo := new(MockReservationRepository)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usecase := newTestUsecase(mockRepo, now)
+
+	expired := []*entity.Reservation{{ID: uuid.New()}, {ID: uuid.New()}}
+	mockRepo.On("GetExpiredReservations", mock.Anything, now, expireBatchSize).Return(expired, nil)
+	mockRepo.On("ReleaseReservation", mock.Anything, expired[0].ID, entity.ReservationExpired, now).Return(int64(1), nil)
+	// Cancelled by its owner between the query and the release
+	mockRepo.On("ReleaseReservation", mock.Anything, expired[1].ID, entity.ReservationExpired, now).Return(int64(0), nil)
+
+	count, err := usecase.ExpireReservations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReservationUsecase_CancelReservation_DispatchesCancelled(t *testing.T) {
+	mockRepo := new(MockReservationRepository)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	bus := events.NewBus()
+	usecase := NewReservationUsecase(mockRepo, &config.Config{}, bus, clock.NewFake(now))
+
+	var cancelled []events.Event
+	bus.Listen(EventCancelled, func(ctx context.Context, event events.Event) error {
+		cancelled = append(cancelled, event)
+		return nil
+	})
+
+	userID := uuid.New()
+	reservation := &entity.Reservation{
+		ID:        uuid.New(),
+		ProductID: uuid.New(),
+		UserID:    userID,
+		Quantity:  3,
+		Status:    entity.ReservationActive,
+		ExpiresAt: now.Add(time.Minute),
+	}
+	mockRepo.On("GetReservationByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	mockRepo.On("ReleaseReservation", mock.Anything, reservation.ID, entity.ReservationReleased, now).Return(int64(1), nil)
+
+	_, err := usecase.CancelReservation(context.Background(), userID, reservation.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []events.Event{CancelledEvent{
+		ReservationID: reservation.ID,
+		ProductID:     reservation.ProductID,
+		UserID:        userID,
+		Quantity:      3,
+	}}, cancelled)
+}
@@ -1,17 +1,38 @@
 package router
 
 import (
+	"io/fs"
+	"os"
+	"strings"
+
+	"go-clean-gin/config"
 	"go-clean-gin/internal/container"
+	"go-clean-gin/internal/entity"
 	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/database"
+	"go-clean-gin/pkg/errors"
 	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/static"
+	"go-clean-gin/pkg/version"
+	"go-clean-gin/web"
 
 	"github.com/gin-gonic/gin"
 )
 
+// multipartOverhead is the room an upload's body gets beyond the file itself
+// for the multipart boundaries and headers
+const multipartOverhead = 64 << 10
+
+// apiPrefixes are the paths unknown routes answer JSON under, never the
+// frontend
+var apiPrefixes = []string{"/api/", "/scim/", "/.well-known/"}
+
 func SetupRouter(container *container.Container) *gin.Engine {
 	// Set Gin mode based on environment
 	if container.Config.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
+	} else if container.Config.Env == "test" {
+		gin.SetMode(gin.TestMode)
 	} else {
 		gin.SetMode(gin.DebugMode)
 	}
@@ -20,62 +41,418 @@ func SetupRouter(container *container.Container) *gin.Engine {
 
 	// Global middleware
 	router.Use(middleware.CORS())
-	router.Use(middleware.Recovery())
+	router.Use(middleware.RequestLogger())
+	router.Use(middleware.Recovery(container.Notifier))
 	router.Use(middleware.Logging())
 	router.Use(middleware.Helmet())
 	router.Use(middleware.ErrorHandler()) // Add error handler middleware
+	router.Use(middleware.Maintenance(container.Config.Maintenance))
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
+		buildInfo := version.Get()
 		response.Success(c, 200, "Server is running", gin.H{
-			"status":  "OK",
-			"version": "1.0.0",
-			"env":     container.Config.Env,
+			"status":     "OK",
+			"version":    buildInfo.Version,
+			"commit":     buildInfo.Commit,
+			"build_time": buildInfo.BuildTime,
+			"env":        container.Config.Env,
+		})
+	})
+
+	// Liveness probe: the process is up and serving requests
+	router.GET("/health/live", func(c *gin.Context) {
+		response.Success(c, 200, "Server is alive", gin.H{"status": "OK"})
+	})
+
+	// Readiness probe: the database is reachable. Soft dependencies with an
+	// open breaker only degrade the service, so they are reported but do not
+	// fail the probe.
+	router.GET("/health/ready", func(c *gin.Context) {
+		breakers := container.Health.States()
+
+		if err := database.HealthCheck(container.DB); err != nil {
+			response.Error(c, 503, errors.ErrUnavailable, "Service is not ready", gin.H{
+				"database":     err.Error(),
+				"dependencies": breakers,
+			})
+			return
+		}
+
+		status := "OK"
+		if len(container.Health.Degraded()) > 0 {
+			status = "DEGRADED"
+		}
+		response.Success(c, 200, "Service is ready", gin.H{
+			"status":       status,
+			"database":     "OK",
+			"dependencies": breakers,
 		})
 	})
 
-	// 404 handler
+	// Version endpoint
+	router.GET("/version", func(c *gin.Context) {
+		response.Success(c, 200, "Version retrieved successfully", version.Get())
+	})
+
+	// artisan:module oidc
+	// OpenID Connect discovery, at the issuer root
+	router.GET("/.well-known/openid-configuration", container.OIDCHandler.Discovery)
+	router.GET("/.well-known/jwks.json", container.OIDCHandler.JWKS)
+	// artisan:end
+
+	// artisan:module scim
+	// SCIM provisioning for identity providers, authenticated with SCIM_TOKEN
+	scimRoutes := router.Group("/scim/v2", container.SCIMHandler.Authenticate, middleware.SignedRequest(container.Verifier, "scim"))
+	{
+		scimRoutes.GET("/ServiceProviderConfig", container.SCIMHandler.ServiceProviderConfig)
+		scimRoutes.GET("/Users", container.SCIMHandler.ListUsers)
+		scimRoutes.POST("/Users", container.SCIMHandler.CreateUser)
+		scimRoutes.GET("/Users/:id", container.SCIMHandler.GetUser)
+		scimRoutes.PUT("/Users/:id", container.SCIMHandler.ReplaceUser)
+		scimRoutes.PATCH("/Users/:id", container.SCIMHandler.PatchUser)
+		scimRoutes.DELETE("/Users/:id", container.SCIMHandler.DeleteUser)
+		scimRoutes.GET("/Groups", container.SCIMHandler.ListGroups)
+		scimRoutes.GET("/Groups/:id", container.SCIMHandler.GetGroup)
+		scimRoutes.PUT("/Groups/:id", container.SCIMHandler.ReplaceGroup)
+		scimRoutes.PATCH("/Groups/:id", container.SCIMHandler.PatchGroup)
+	}
+	// artisan:end
+
+	// Pages the links in emails open, so those flows work without a frontend
+	pageRoutes := router.Group("/auth")
+	{
+		pageRoutes.GET("/email/confirm", container.AuthHandler.ConfirmEmailChangePage)
+		pageRoutes.GET("/password/reset", container.AuthHandler.PasswordResetPage)
+		pageRoutes.POST("/password/reset", container.AuthHandler.SubmitPasswordReset)
+	}
+
+	// 404 handler. With a frontend configured, paths outside the API are
+	// served from it first.
+	frontend := staticFiles(container.Config.Static)
 	router.NoRoute(func(c *gin.Context) {
+		if frontend != nil && !isAPIPath(c.Request.URL.Path) && frontend.Serve(c) {
+			return
+		}
 		response.Error(c, 404, "NOT_FOUND", "Route not found", gin.H{
 			"path":   c.Request.URL.Path,
 			"method": c.Request.Method,
 		})
 	})
 
-	// API v1 routes
+	// API v1 routes. Health checks stay outside load shedding so probes keep
+	// answering while the API is overloaded. Shed requests are not audited.
+	// Everything after Tenant runs on the database of the request's tenant.
 	v1 := router.Group("/api/v1")
+	v1.Use(middleware.LoadShed(container.DB, container.Config.LoadShed, container.Clock))
+	v1.Use(middleware.Tenant(container.Tenants, container.Config.Tenancy.Header))
+	v1.Use(middleware.Audit(container.AuditUsecase))
+
+	// Routes behind requireConsent need the current policies accepted. Auth,
+	// account and consent routes stay open so users can always read and
+	// accept the policies, or leave.
+	requireConsent := middleware.RequireConsent(container.ConsentUsecase)
+	// Routes behind requireQuota count against the user's API quotas. Admin
+	// and report routes are not metered.
+	requireQuota := middleware.Quota(container.QuotaUsecase)
 	{
 		// Auth routes (public)
 		authRoutes := v1.Group("/auth")
 		{
 			authRoutes.POST("/register", container.AuthHandler.Register)
+			authRoutes.POST("/register/invitation", container.InvitationHandler.Register) // artisan:module invitation
 			authRoutes.POST("/login", container.AuthHandler.Login)
+			authRoutes.POST("/refresh", container.AuthHandler.Refresh)
+			authRoutes.GET("/availability", container.AuthHandler.CheckAvailability)
+			authRoutes.POST("/password/forgot", container.AuthHandler.ForgotPassword)
+			authRoutes.POST("/password/reset", container.AuthHandler.ResetPassword)
+			authRoutes.POST("/sso/start", container.SSOHandler.Start)       // artisan:module sso
+			authRoutes.POST("/sso/callback", container.SSOHandler.Callback) // artisan:module sso
+			// Links from the export and email change emails
+			authRoutes.GET("/account/export/download", middleware.RequireSignature(container.Signer), container.AccountHandler.DownloadExport)
+			authRoutes.GET("/email/confirm", container.AuthHandler.ConfirmEmailChange)
 
 			// Protected auth routes
 			authProtected := authRoutes.Group("/")
 			authProtected.Use(middleware.AuthMiddleware(container.AuthUsecase))
 			{
 				authProtected.GET("/profile", container.AuthHandler.Profile)
+				authProtected.PUT("/profile", container.AuthHandler.UpdateProfile)
+				authProtected.PATCH("/profile", container.AuthHandler.PatchProfile)
+				authProtected.DELETE("/account", container.AccountHandler.DeleteAccount)
+				authProtected.GET("/account/export", container.AccountHandler.RequestExport)
+				authProtected.POST("/email/change", container.AuthHandler.RequestEmailChange)
+				authProtected.DELETE("/email/change", container.AuthHandler.CancelEmailChange)
+				// artisan:module avatar
+				authProtected.PUT("/avatar",
+					middleware.BodyLimit(container.Config.Avatar.MaxBytes+multipartOverhead),
+					container.AvatarHandler.UploadAvatar)
+				// artisan:end
+				authProtected.DELETE("/avatar", container.AvatarHandler.RemoveAvatar) // artisan:module avatar
 			}
 		}
 
+		// artisan:module oidc
+		// OpenID Connect provider routes. Token and userinfo authenticate the
+		// client and its access token themselves.
+		oauthRoutes := v1.Group("/oauth")
+		{
+			oauthRoutes.POST("/authorize", middleware.AuthMiddleware(container.AuthUsecase), container.OIDCHandler.Authorize)
+			oauthRoutes.POST("/token", container.OIDCHandler.Token)
+			oauthRoutes.GET("/userinfo", container.OIDCHandler.UserInfo)
+		}
+		// artisan:end
+
+		// artisan:module avatar
+		// User routes (public)
+		userRoutes := v1.Group("/users")
+		{
+			userRoutes.GET("/:id/avatar", container.AvatarHandler.GetAvatar)
+		}
+		// artisan:end
+
+		// Entities with public IDs are addressed by either those or their UUIDs
+		productID := middleware.PublicID(container.PublicIDs, "product", "id")
+		organizationID := middleware.PublicID(container.PublicIDs, "organization", "id")
+		reservationID := middleware.PublicID(container.PublicIDs, "reservation", "id") // artisan:module reservation
+
 		// Product routes
 		productRoutes := v1.Group("/products")
 		{
 			// Public product routes
-			productRoutes.GET("", container.ProductHandler.GetProducts)
-			productRoutes.GET("/:id", container.ProductHandler.GetProduct)
+			productRoutes.GET("", middleware.SavedFilter(container.SavedSearchUsecase), container.ProductHandler.GetProducts)
+			productRoutes.GET("/:id", productID, middleware.CountViews(container.ProductUsecase.RecordView, "id"), container.ProductHandler.GetProduct)
+			productRoutes.GET("/:id/images", productID, container.ProductImageHandler.GetImages)                   // artisan:module productimage
+			productRoutes.GET("/:id/images/:image_id/:variant", productID, container.ProductImageHandler.GetImage) // artisan:module productimage
 
 			// Protected product routes
 			productProtected := productRoutes.Group("/")
-			productProtected.Use(middleware.AuthMiddleware(container.AuthUsecase))
+			productProtected.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
 			{
 				productProtected.POST("", container.ProductHandler.CreateProduct)
-				productProtected.PUT("/:id", container.ProductHandler.UpdateProduct)
-				productProtected.DELETE("/:id", container.ProductHandler.DeleteProduct)
+				productProtected.PUT("/:id", productID, container.ProductHandler.UpdateProduct)
+				productProtected.PATCH("/:id", productID, container.ProductHandler.PatchProduct)
+				productProtected.DELETE("/:id", productID, container.ProductHandler.DeleteProduct)
+				productProtected.POST("/bulk/:action", container.ProductHandler.BulkProducts)
+				productProtected.GET("/:id/activities", productID, container.ActivityHandler.GetProductActivities) // artisan:module activity
+				// artisan:module productimage
+				productProtected.POST("/:id/images",
+					middleware.BodyLimit(container.Config.Images.MaxBytes+multipartOverhead),
+					productID, container.ProductImageHandler.UploadImage)
+				// artisan:end
+				productProtected.DELETE("/:id/images/:image_id", productID, container.ProductImageHandler.DeleteImage) // artisan:module productimage
 			}
+
+			// Admin product routes
+			productAdmin := productRoutes.Group("/")
+			productAdmin.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, middleware.RequireRole(entity.RoleAdmin))
+			{
+				productAdmin.GET("/export",
+					middleware.ConcurrencyLimit("exports", container.Config.Concurrency.Exports, container.Config.Concurrency.Wait),
+					container.ProductHandler.ExportProducts)
+			}
+		}
+
+		// Organization routes (protected). Roles within each organization are
+		// checked by the usecase.
+		organizationRoutes := v1.Group("/organizations")
+		organizationRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			organizationRoutes.POST("", container.OrganizationHandler.CreateOrganization)
+			organizationRoutes.GET("", container.OrganizationHandler.GetOrganizations)
+			organizationRoutes.POST("/invitations/accept", container.InvitationHandler.AcceptInvitation) // artisan:module invitation
+
+			organization := organizationRoutes.Group("/:id", organizationID)
+			organization.GET("", container.OrganizationHandler.GetOrganization)
+			organization.PUT("", container.OrganizationHandler.UpdateOrganization)
+			organization.DELETE("", container.OrganizationHandler.DeleteOrganization)
+			organization.GET("/members", container.OrganizationHandler.GetMembers)
+			organization.PUT("/members/:user_id", container.OrganizationHandler.UpdateMember)
+			organization.DELETE("/members/:user_id", container.OrganizationHandler.RemoveMember)
+			organization.GET("/invitations", container.InvitationHandler.GetInvitations)                          // artisan:module invitation
+			organization.POST("/invitations", container.InvitationHandler.InviteMember)                           // artisan:module invitation
+			organization.POST("/invitations/:invitation_id/resend", container.InvitationHandler.ResendInvitation) // artisan:module invitation
+			organization.DELETE("/invitations/:invitation_id", container.InvitationHandler.RevokeInvitation)      // artisan:module invitation
+		}
+
+		// Consent routes (protected)
+		consentRoutes := v1.Group("/consents")
+		consentRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase))
+		{
+			consentRoutes.GET("/policies", container.ConsentHandler.GetPolicies)
+			consentRoutes.POST("", container.ConsentHandler.AcceptPolicy)
 		}
+
+		// artisan:module reservation
+		// Reservation routes (protected)
+		reservationRoutes := v1.Group("/reservations")
+		reservationRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			reservationRoutes.POST("", container.ReservationHandler.CreateReservation)
+			reservationRoutes.GET("", container.ReservationHandler.GetReservations)
+			reservationRoutes.POST("/:id/commit", reservationID, container.ReservationHandler.CommitReservation)
+			reservationRoutes.POST("/:id/cancel", reservationID, container.ReservationHandler.CancelReservation)
+		}
+		// artisan:end
+
+		// artisan:module notification
+		// Notification routes (protected)
+		notificationRoutes := v1.Group("/notifications")
+		notificationRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			notificationRoutes.GET("", container.NotificationHandler.GetNotifications)
+			notificationRoutes.PATCH("/:id/read", container.NotificationHandler.MarkRead)
+			notificationRoutes.POST("/read-all", container.NotificationHandler.MarkAllRead)
+		}
+		// artisan:end
+
+		// Saved search routes (protected)
+		savedSearchRoutes := v1.Group("/saved-searches")
+		savedSearchRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			savedSearchRoutes.POST("", container.SavedSearchHandler.CreateSavedSearch)
+			savedSearchRoutes.GET("", container.SavedSearchHandler.GetSavedSearches)
+			savedSearchRoutes.GET("/:id", container.SavedSearchHandler.GetSavedSearch)
+			savedSearchRoutes.PUT("/:id", container.SavedSearchHandler.UpdateSavedSearch)
+			savedSearchRoutes.DELETE("/:id", container.SavedSearchHandler.DeleteSavedSearch)
+		}
+
+		// artisan:module export
+		// Export routes (protected). Downloads are authorized by the signed
+		// link from the export's status instead.
+		exportRoutes := v1.Group("/exports")
+		{
+			exportRoutes.GET("/:id/download", middleware.RequireSignature(container.Signer), container.ExportHandler.DownloadExport)
+
+			exportProtected := exportRoutes.Group("/")
+			exportProtected.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+			{
+				exportProtected.POST("", container.ExportHandler.CreateExport)
+				exportProtected.GET("/:id", container.ExportHandler.GetExport)
+			}
+		}
+		// artisan:end
+
+		// artisan:module imports
+		// Import routes (protected)
+		importRoutes := v1.Group("/imports")
+		importRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			importRoutes.POST("",
+				middleware.BodyLimit(container.Config.Import.MaxBytes+multipartOverhead),
+				container.ImportHandler.CreateImport)
+			importRoutes.GET("/:id", container.ImportHandler.GetImport)
+			importRoutes.POST("/:id/commit", container.ImportHandler.CommitImport)
+		}
+		// artisan:end
+
+		// artisan:module activity
+		// Activity routes (protected)
+		activityRoutes := v1.Group("/activities")
+		activityRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			activityRoutes.GET("", container.ActivityHandler.GetMyActivities)
+		}
+		// artisan:end
+
+		// Setting routes. Public settings are readable without signing in;
+		// users set their own preferences.
+		settingRoutes := v1.Group("/settings")
+		{
+			settingRoutes.GET("", container.SettingHandler.GetPublicSettings)
+
+			settingProtected := settingRoutes.Group("/me")
+			settingProtected.Use(middleware.AuthMiddleware(container.AuthUsecase))
+			{
+				settingProtected.GET("", container.SettingHandler.GetPreferences)
+				settingProtected.PUT("/:key", container.SettingHandler.UpdatePreference)
+				settingProtected.DELETE("/:key", container.SettingHandler.ResetPreference)
+			}
+		}
+
+		// Usage routes (protected, not metered)
+		usageRoutes := v1.Group("/usage")
+		usageRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase))
+		{
+			usageRoutes.GET("", container.QuotaHandler.GetUsage)
+		}
+
+		// artisan:module report
+		// Report routes (admin only)
+		reportRoutes := v1.Group("/reports")
+		reportRoutes.Use(
+			middleware.AuthMiddleware(container.AuthUsecase),
+			requireConsent,
+			middleware.RequireRole(entity.RoleAdmin),
+			middleware.ConcurrencyLimit("reports", container.Config.Concurrency.Reports, container.Config.Concurrency.Wait),
+		)
+		{
+			reportRoutes.GET("/products-by-category", container.ReportHandler.ProductsByCategory)
+			reportRoutes.GET("/registrations", container.ReportHandler.RegistrationsPerDay)
+			reportRoutes.GET("/stock-value", container.ReportHandler.StockValue)
+		}
+		// artisan:end
+
+		// Admin dashboard routes (admin only)
+		adminRoutes := v1.Group("/admin")
+		adminRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, middleware.RequireRole(entity.RoleAdmin),
+			middleware.SignedRequest(container.Verifier, "admin"))
+		{
+			adminRoutes.GET("/dashboard", container.AdminHandler.Dashboard)                       // artisan:module admin
+			adminRoutes.GET("/users/recent", container.AdminHandler.RecentSignups)                // artisan:module admin
+			adminRoutes.GET("/users/:id/activities", container.ActivityHandler.GetUserActivities) // artisan:module activity
+			adminRoutes.GET("/jobs/failed", container.AdminHandler.GetFailedJobs)                 // artisan:module admin
+			adminRoutes.GET("/audit-logs", container.AdminHandler.GetAuditLogs)                   // artisan:module admin
+			adminRoutes.GET("/settings", container.SettingHandler.GetSettings)
+			adminRoutes.GET("/settings/changes", container.SettingHandler.GetSettingChanges)
+			adminRoutes.PUT("/settings/:key", container.SettingHandler.UpdateSetting)
+			adminRoutes.DELETE("/settings/:key", container.SettingHandler.ResetSetting)
+		}
+
+		// artisan:module store
+		// Store routes (protected)
+		storeRoutes := v1.Group("/stores")
+		storeRoutes.Use(middleware.AuthMiddleware(container.AuthUsecase), requireConsent, requireQuota)
+		{
+			storeRoutes.POST("", container.StoreHandler.CreateStore)
+			storeRoutes.GET("", container.StoreHandler.GetStores)
+			storeRoutes.GET("/:id", container.StoreHandler.GetStore)
+			storeRoutes.PUT("/:id", container.StoreHandler.UpdateStore)
+			storeRoutes.DELETE("/:id", container.StoreHandler.DeleteStore)
+		}
+		// artisan:end
+
+		// artisan:insert routes
 	}
 
 	return router
 }
+
+// staticFiles returns the handler of the configured frontend, or nil when
+// there is none
+func staticFiles(cfg config.StaticConfig) *static.Handler {
+	var files fs.FS
+	switch {
+	case cfg.Embed:
+		files = web.Dist()
+	case cfg.Dir != "":
+		files = os.DirFS(cfg.Dir)
+	default:
+		return nil
+	}
+
+	return static.New(files, static.Options{
+		SPA:             cfg.SPA,
+		MaxAge:          cfg.MaxAge,
+		ImmutablePrefix: cfg.ImmutablePrefix,
+	})
+}
+
+func isAPIPath(path string) bool {
+	for _, prefix := range apiPrefixes {
+		if strings.HasPrefix(path+"/", prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package savedsearch
+
+import "github.com/google/uuid"
+
+// Event names published by the saved search module
+const (
+	EventMatched = "saved_search.matched"
+)
+
+// MatchedEvent is dispatched when products matching a saved search with
+// notifications on were created since it was last checked. ProductIDs holds
+// the newest of them.
+type MatchedEvent struct {
+	SavedSearchID uuid.UUID
+	UserID        uuid.UUID
+	Name          string
+	Count         int64
+	ProductIDs    []uuid.UUID
+}
+
+func (MatchedEvent) EventName() string {
+	return EventMatched
+}
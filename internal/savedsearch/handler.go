@@ -0,0 +1,242 @@
+package savedsearch
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SavedSearchHandler struct {
+	usecase SavedSearchUsecase
+}
+
+func NewSavedSearchHandler(usecase SavedSearchUsecase) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateSavedSearch godoc
+// @Summary Save a search
+// @Description Save a named product filter for the current user, optionally notifying them of new products matching it
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.SavedSearchRequest true "Saved search"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /saved-searches [post]
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	search, err := h.usecase.CreateSavedSearch(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to create saved search", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to create saved search", nil)
+		}
+		return
+	}
+
+	response.Success(c, 201, "Saved search created successfully", search)
+}
+
+// GetSavedSearches godoc
+// @Summary Get saved searches
+// @Description Get the current user's saved searches by name
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /saved-searches [get]
+func (h *SavedSearchHandler) GetSavedSearches(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	searches, err := h.usecase.GetSavedSearches(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get saved searches", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get saved searches", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Saved searches retrieved successfully", searches)
+}
+
+// GetSavedSearch godoc
+// @Summary Get a saved search
+// @Description Get one of the current user's saved searches
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Saved search ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /saved-searches/{id} [get]
+func (h *SavedSearchHandler) GetSavedSearch(c *gin.Context) {
+	searchID, userID, ok := searchAndUser(c)
+	if !ok {
+		return
+	}
+
+	search, err := h.usecase.GetSavedSearch(c.Request.Context(), userID, searchID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get saved search", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get saved search", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Saved search retrieved successfully", search)
+}
+
+// UpdateSavedSearch godoc
+// @Summary Update a saved search
+// @Description Replace the name, filter and notification choice of one of the current user's saved searches
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Saved search ID"
+// @Param request body entity.SavedSearchRequest true "Saved search"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /saved-searches/{id} [put]
+func (h *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	searchID, userID, ok := searchAndUser(c)
+	if !ok {
+		return
+	}
+
+	var req entity.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	search, err := h.usecase.UpdateSavedSearch(c.Request.Context(), userID, searchID, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update saved search", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to update saved search", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Saved search updated successfully", search)
+}
+
+// DeleteSavedSearch godoc
+// @Summary Delete a saved search
+// @Description Delete one of the current user's saved searches
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Saved search ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /saved-searches/{id} [delete]
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	searchID, userID, ok := searchAndUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteSavedSearch(c.Request.Context(), userID, searchID); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to delete saved search", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to delete saved search", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Saved search deleted successfully", nil)
+}
+
+// searchAndUser reads the saved search ID from the path and the authenticated
+// user, writing the error response when either is missing
+func searchAndUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	searchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid saved search ID", err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	return searchID, userID, ok
+}
@@ -0,0 +1,120 @@
+package savedsearch_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchHandler_CRUD(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	var search entity.SavedSearch
+	api.As(user).Post("/api/v1/saved-searches", entity.SavedSearchRequest{
+		Name:   "Lamps",
+		Filter: entity.SavedSearchFilter{Category: "home", Search: "lamp"},
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&search)
+	assert.Equal(t, "Lamps", search.Name)
+	assert.False(t, search.Notify)
+
+	api.As(user).Put("/api/v1/saved-searches/"+search.ID.String(), entity.SavedSearchRequest{
+		Name:   "Desk lamps",
+		Filter: entity.SavedSearchFilter{Category: "office", Search: "lamp"},
+		Notify: true,
+	}).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&search)
+	assert.Equal(t, "office", search.Filter.Category)
+	assert.True(t, search.Notify)
+
+	var searches []entity.SavedSearch
+	api.As(user).Get("/api/v1/saved-searches").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&searches)
+	require.Len(t, searches, 1)
+	assert.Equal(t, "Desk lamps", searches[0].Name)
+
+	// Other users don't see the search
+	api.As(api.CreateUser()).Get("/api/v1/saved-searches/" + search.ID.String()).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrSavedSearchNotFound)
+
+	api.As(user).Delete("/api/v1/saved-searches/" + search.ID.String()).Do().
+		AssertStatus(http.StatusOK)
+	api.As(user).Get("/api/v1/saved-searches/" + search.ID.String()).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrSavedSearchNotFound)
+}
+
+func TestSavedSearchHandler_Invalid(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.As(api.CreateUser()).Post("/api/v1/saved-searches", entity.SavedSearchRequest{
+		Filter: entity.SavedSearchFilter{Category: "home"},
+	}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("name")
+
+	api.Post("/api/v1/saved-searches", entity.SavedSearchRequest{Name: "Lamps"}).Do().
+		AssertStatus(http.StatusUnauthorized)
+}
+
+func TestSavedSearchHandler_SavedFilter(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	owner := api.CreateUser()
+
+	for _, category := range []string{"home", "garden"} {
+		api.As(owner).Post("/api/v1/products", entity.CreateProductRequest{
+			Name:     "Lamp",
+			Price:    money.MustParse("25", "USD"),
+			Stock:    5,
+			Category: category,
+		}).Do().
+			AssertStatus(http.StatusCreated)
+	}
+
+	var search entity.SavedSearch
+	api.As(owner).Post("/api/v1/saved-searches", entity.SavedSearchRequest{
+		Name:   "Garden",
+		Filter: entity.SavedSearchFilter{Category: "garden"},
+	}).Do().
+		AssertStatus(http.StatusCreated).
+		Decode(&search)
+
+	// The saved filter applies to anyone listing products with its ID
+	var products []entity.ProductReadModel
+	api.Get("/api/v1/products").
+		Query("saved_filter", search.ID.String()).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&products)
+	require.Len(t, products, 1)
+	assert.Equal(t, "garden", products[0].Category)
+
+	// Request parameters win over the saved ones
+	api.Get("/api/v1/products").
+		Query("saved_filter", search.ID.String()).
+		Query("category", "home").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&products)
+	require.Len(t, products, 1)
+	assert.Equal(t, "home", products[0].Category)
+
+	api.Get("/api/v1/products").Query("saved_filter", "nope").Do().
+		AssertStatus(http.StatusBadRequest)
+}
@@ -0,0 +1,32 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package savedsearch
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockListings is a testify mock of Listings
+type MockListings struct {
+	mock.Mock
+}
+
+func (m *MockListings) GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, entity.Total, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.ProductReadModel
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.ProductReadModel)
+	}
+
+	var r1 entity.Total
+	if v := args.Get(1); v != nil {
+		r1 = v.(entity.Total)
+	}
+
+	return r0, r1, args.Error(2)
+}
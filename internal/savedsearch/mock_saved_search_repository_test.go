@@ -0,0 +1,82 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package savedsearch
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSavedSearchRepository is a testify mock of SavedSearchRepository
+type MockSavedSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedSearchRepository) CreateSavedSearch(ctx context.Context, search *entity.SavedSearch) error {
+	args := m.Called(ctx, search)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchRepository) CountSavedSearches(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.SavedSearch
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.SavedSearch)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) GetSavedSearchByID(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearch, error) {
+	args := m.Called(ctx, searchID)
+
+	var r0 *entity.SavedSearch
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SavedSearch)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) UpdateSavedSearch(ctx context.Context, search *entity.SavedSearch) error {
+	args := m.Called(ctx, search)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchRepository) DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID, searchID)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchRepository) EachNotifyingSearch(ctx context.Context, batchSize int, fn func(*entity.SavedSearch) error) error {
+	args := m.Called(ctx, batchSize, fn)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchRepository) MarkChecked(ctx context.Context, searchID uuid.UUID, checkedAt time.Time) error {
+	args := m.Called(ctx, searchID, checkedAt)
+	return args.Error(0)
+}
@@ -0,0 +1,88 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package savedsearch
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSavedSearchUsecase is a testify mock of SavedSearchUsecase
+type MockSavedSearchUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSavedSearchUsecase) CreateSavedSearch(ctx context.Context, userID uuid.UUID, req *entity.SavedSearchRequest) (*entity.SavedSearch, error) {
+	args := m.Called(ctx, userID, req)
+
+	var r0 *entity.SavedSearch
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SavedSearch)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchUsecase) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.SavedSearch
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.SavedSearch)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchUsecase) GetSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) (*entity.SavedSearch, error) {
+	args := m.Called(ctx, userID, searchID)
+
+	var r0 *entity.SavedSearch
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SavedSearch)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchUsecase) UpdateSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID, req *entity.SavedSearchRequest) (*entity.SavedSearch, error) {
+	args := m.Called(ctx, userID, searchID, req)
+
+	var r0 *entity.SavedSearch
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SavedSearch)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchUsecase) DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) error {
+	args := m.Called(ctx, userID, searchID)
+	return args.Error(0)
+}
+
+func (m *MockSavedSearchUsecase) GetFilter(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearchFilter, error) {
+	args := m.Called(ctx, searchID)
+
+	var r0 *entity.SavedSearchFilter
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SavedSearchFilter)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSavedSearchUsecase) CheckSavedSearches(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,38 @@
+package savedsearch
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedSearchUsecase defines the business logic interface for saved product searches
+type SavedSearchUsecase interface {
+	CreateSavedSearch(ctx context.Context, userID uuid.UUID, req *entity.SavedSearchRequest) (*entity.SavedSearch, error)
+	GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error)
+	GetSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) (*entity.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID, req *entity.SavedSearchRequest) (*entity.SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) error
+	GetFilter(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearchFilter, error)
+	CheckSavedSearches(ctx context.Context) (int, error)
+}
+
+// SavedSearchRepository defines the data access interface for saved searches
+type SavedSearchRepository interface {
+	CreateSavedSearch(ctx context.Context, search *entity.SavedSearch) error
+	CountSavedSearches(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error)
+	GetSavedSearchByID(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, search *entity.SavedSearch) error
+	DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) (int64, error)
+	EachNotifyingSearch(ctx context.Context, batchSize int, fn func(*entity.SavedSearch) error) error
+	MarkChecked(ctx context.Context, searchID uuid.UUID, checkedAt time.Time) error
+}
+
+// Listings lists products matching a filter, implemented by
+// product.ProductUsecase
+type Listings interface {
+	GetProducts(ctx context.Context, filter *entity.ProductFilter) ([]*entity.ProductReadModel, entity.Total, error)
+}
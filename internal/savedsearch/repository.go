@@ -0,0 +1,92 @@
+package savedsearch
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type savedSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) SavedSearchRepository {
+	return &savedSearchRepository{
+		db: db,
+	}
+}
+
+func (r *savedSearchRepository) CreateSavedSearch(ctx context.Context, search *entity.SavedSearch) error {
+	return tenancy.Conn(ctx, r.db).Create(search).Error
+}
+
+func (r *savedSearchRepository) CountSavedSearches(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Model(&entity.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *savedSearchRepository) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	var searches []*entity.SavedSearch
+	err := tenancy.Conn(ctx, r.db).Where("user_id = ?", userID).Order("name, id").Find(&searches).Error
+	return searches, err
+}
+
+func (r *savedSearchRepository) GetSavedSearchByID(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearch, error) {
+	var search entity.SavedSearch
+	if err := tenancy.Conn(ctx, r.db).First(&search, "id = ?", searchID).Error; err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *savedSearchRepository) UpdateSavedSearch(ctx context.Context, search *entity.SavedSearch) error {
+	return tenancy.Conn(ctx, r.db).Save(search).Error
+}
+
+// DeleteSavedSearch returns the number of rows deleted, 0 when the search does
+// not exist or belongs to someone else
+func (r *savedSearchRepository) DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("id = ? AND user_id = ?", searchID, userID).Delete(&entity.SavedSearch{})
+	return result.RowsAffected, result.Error
+}
+
+// EachNotifyingSearch calls fn for every saved search with notifications on,
+// loading batchSize at a time in id order
+func (r *savedSearchRepository) EachNotifyingSearch(ctx context.Context, batchSize int, fn func(*entity.SavedSearch) error) error {
+	var lastID uuid.UUID
+	batch := make([]*entity.SavedSearch, 0, batchSize)
+
+	for {
+		query := tenancy.Conn(ctx, r.db).Where("notify = ?", true)
+		if lastID != uuid.Nil {
+			query = query.Where("id > ?", lastID)
+		}
+
+		batch = batch[:0]
+		if err := query.Order("id").Limit(batchSize).Find(&batch).Error; err != nil {
+			return err
+		}
+
+		for _, search := range batch {
+			if err := fn(search); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+func (r *savedSearchRepository) MarkChecked(ctx context.Context, searchID uuid.UUID, checkedAt time.Time) error {
+	return tenancy.Conn(ctx, r.db).Model(&entity.SavedSearch{}).
+		Where("id = ?", searchID).
+		Update("checked_at", checkedAt).Error
+}
@@ -0,0 +1,207 @@
+package savedsearch
+
+import (
+	"context"
+	"fmt"
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// checkBatchSize is the number of saved searches loaded at a time by checks
+const checkBatchSize = 100
+
+// matchesListed is the number of new products named in a match notification
+const matchesListed = 10
+
+type savedSearchUsecase struct {
+	repo     SavedSearchRepository
+	listings Listings
+	config   *config.Config
+	events   *events.Bus
+	clock    clock.Clock
+}
+
+func NewSavedSearchUsecase(repo SavedSearchRepository, listings Listings, config *config.Config, bus *events.Bus, clk clock.Clock) SavedSearchUsecase {
+	return &savedSearchUsecase{
+		repo:     repo,
+		listings: listings,
+		config:   config,
+		events:   bus,
+		clock:    clk,
+	}
+}
+
+// CreateSavedSearch saves the filter for the user, up to SAVED_SEARCHES_PER_USER.
+// Notifications cover products created from now on.
+func (u *savedSearchUsecase) CreateSavedSearch(ctx context.Context, userID uuid.UUID, req *entity.SavedSearchRequest) (*entity.SavedSearch, error) {
+	count, err := u.repo.CountSavedSearches(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to count saved searches", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create saved search", 500)
+	}
+	if limit := u.config.SavedSearch.MaxPerUser; limit > 0 && count >= int64(limit) {
+		return nil, errors.New(errors.ErrSavedSearchLimit, fmt.Sprintf("You can keep at most %d saved searches", limit), 400).
+			WithDetails(map[string]interface{}{"limit": limit})
+	}
+
+	now := u.clock.Now()
+	search := &entity.SavedSearch{
+		UserID:    userID,
+		Name:      req.Name,
+		Filter:    req.Filter,
+		Notify:    req.Notify,
+		CheckedAt: &now,
+	}
+
+	if err := u.repo.CreateSavedSearch(ctx, search); err != nil {
+		logger.FromContext(ctx).Error("Failed to create saved search", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create saved search", 500)
+	}
+
+	logger.FromContext(ctx).Info("Saved search created", zap.String("saved_search_id", search.ID.String()))
+	return search, nil
+}
+
+func (u *savedSearchUsecase) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	searches, err := u.repo.GetSavedSearches(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get saved searches", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get saved searches", 500)
+	}
+
+	return searches, nil
+}
+
+// GetSavedSearch returns one of the user's saved searches. Other users'
+// searches are reported as not found.
+func (u *savedSearchUsecase) GetSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) (*entity.SavedSearch, error) {
+	search, err := u.getSavedSearch(ctx, searchID)
+	if err != nil {
+		return nil, err
+	}
+	if search.UserID != userID {
+		return nil, errors.ErrSavedSearchNotFoundError
+	}
+
+	return search, nil
+}
+
+// UpdateSavedSearch replaces the search's name, filter and notification
+// choice. Turning notifications on covers products created from then on.
+func (u *savedSearchUsecase) UpdateSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID, req *entity.SavedSearchRequest) (*entity.SavedSearch, error) {
+	search, err := u.GetSavedSearch(ctx, userID, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Notify && !search.Notify {
+		now := u.clock.Now()
+		search.CheckedAt = &now
+	}
+	search.Name = req.Name
+	search.Filter = req.Filter
+	search.Notify = req.Notify
+
+	if err := u.repo.UpdateSavedSearch(ctx, search); err != nil {
+		logger.FromContext(ctx).Error("Failed to update saved search", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update saved search", 500)
+	}
+
+	logger.FromContext(ctx).Info("Saved search updated", zap.String("saved_search_id", searchID.String()))
+	return search, nil
+}
+
+func (u *savedSearchUsecase) DeleteSavedSearch(ctx context.Context, userID uuid.UUID, searchID uuid.UUID) error {
+	deleted, err := u.repo.DeleteSavedSearch(ctx, userID, searchID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to delete saved search", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete saved search", 500)
+	}
+	if deleted == 0 {
+		return errors.ErrSavedSearchNotFoundError
+	}
+
+	logger.FromContext(ctx).Info("Saved search deleted", zap.String("saved_search_id", searchID.String()))
+	return nil
+}
+
+// GetFilter returns the filter of any saved search, for product listings
+// applying it. Its random ID is what lets a search be shared, like a link.
+func (u *savedSearchUsecase) GetFilter(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearchFilter, error) {
+	search, err := u.getSavedSearch(ctx, searchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &search.Filter, nil
+}
+
+// CheckSavedSearches looks for products created since each saved search with
+// notifications on was last checked, and dispatches a MatchedEvent for each
+// search with new ones. It returns the number of events dispatched.
+func (u *savedSearchUsecase) CheckSavedSearches(ctx context.Context) (int, error) {
+	matched := 0
+
+	err := u.repo.EachNotifyingSearch(ctx, checkBatchSize, func(search *entity.SavedSearch) error {
+		// Products created from now on are left to the next check
+		now := u.clock.Now()
+
+		filter := search.Filter.ProductFilter()
+		filter.CreatedAfter = search.CheckedAt
+		filter.CreatedBefore = &now
+		filter.Limit = matchesListed
+
+		products, total, err := u.listings.GetProducts(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		if len(products) > 0 {
+			productIDs := make([]uuid.UUID, len(products))
+			for i, product := range products {
+				productIDs[i] = product.ID
+			}
+
+			matched++
+			u.events.Dispatch(ctx, MatchedEvent{
+				SavedSearchID: search.ID,
+				UserID:        search.UserID,
+				Name:          search.Name,
+				Count:         total.Count,
+				ProductIDs:    productIDs,
+			})
+		}
+
+		return u.repo.MarkChecked(ctx, search.ID, now)
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check saved searches", zap.Error(err))
+		return matched, errors.Wrap(err, errors.ErrInternal, "Failed to check saved searches", 500)
+	}
+
+	if matched > 0 {
+		logger.FromContext(ctx).Info("Saved searches matched new products", zap.Int("count", matched))
+	}
+	return matched, nil
+}
+
+func (u *savedSearchUsecase) getSavedSearch(ctx context.Context, searchID uuid.UUID) (*entity.SavedSearch, error) {
+	search, err := u.repo.GetSavedSearchByID(ctx, searchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrSavedSearchNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get saved search", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get saved search", 500)
+	}
+
+	return search, nil
+}
@@ -0,0 +1,184 @@
+package savedsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	repo     *MockSavedSearchRepository
+	listings *MockListings
+	bus      *events.Bus
+	usecase  SavedSearchUsecase
+}
+
+func newTestUsecase(maxPerUser int) *testDeps {
+	d := &testDeps{
+		repo:     new(MockSavedSearchRepository),
+		listings: new(MockListings),
+		bus:      events.NewBus(),
+	}
+	cfg := &config.Config{SavedSearch: config.SavedSearchConfig{MaxPerUser: maxPerUser}}
+	d.usecase = NewSavedSearchUsecase(d.repo, d.listings, cfg, d.bus, clock.NewFake(testNow))
+	return d
+}
+
+// each makes the mocked EachNotifyingSearch call fn with searches
+func (d *testDeps) each(searches ...*entity.SavedSearch) {
+	d.repo.On("EachNotifyingSearch", mock.Anything, checkBatchSize, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(2).(func(*entity.SavedSearch) error)
+		for _, search := range searches {
+			if err := fn(search); err != nil {
+				return
+			}
+		}
+	}).Return(nil)
+}
+
+func TestSavedSearchUsecase_CreateSavedSearch(t *testing.T) {
+	t.Run("starts checking from now", func(t *testing.T) {
+		d := newTestUsecase(2)
+		userID := uuid.New()
+		d.repo.On("CountSavedSearches", mock.Anything, userID).Return(int64(1), nil)
+		d.repo.On("CreateSavedSearch", mock.Anything, mock.Anything).Return(nil)
+
+		search, err := d.usecase.CreateSavedSearch(context.Background(), userID, &entity.SavedSearchRequest{
+			Name:   "Cheap lamps",
+			Filter: entity.SavedSearchFilter{Category: "home", Search: "lamp"},
+			Notify: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, search.UserID)
+		assert.Equal(t, "home", search.Filter.Category)
+		assert.Equal(t, &testNow, search.CheckedAt)
+	})
+
+	t.Run("refuses over the limit", func(t *testing.T) {
+		d := newTestUsecase(2)
+		userID := uuid.New()
+		d.repo.On("CountSavedSearches", mock.Anything, userID).Return(int64(2), nil)
+
+		_, err := d.usecase.CreateSavedSearch(context.Background(), userID, &entity.SavedSearchRequest{Name: "More"})
+
+		appErr, ok := err.(*errors.AppError)
+		require.True(t, ok)
+		assert.Equal(t, errors.ErrSavedSearchLimit, appErr.Code)
+		assert.Equal(t, 400, appErr.StatusCode)
+		d.repo.AssertNotCalled(t, "CreateSavedSearch", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSavedSearchUsecase_GetSavedSearch_OtherUser(t *testing.T) {
+	d := newTestUsecase(0)
+	search := &entity.SavedSearch{ID: uuid.New(), UserID: uuid.New()}
+	d.repo.On("GetSavedSearchByID", mock.Anything, search.ID).Return(search, nil)
+
+	_, err := d.usecase.GetSavedSearch(context.Background(), uuid.New(), search.ID)
+	assert.Equal(t, errors.ErrSavedSearchNotFoundError, err)
+
+	// Anyone with the ID can apply the filter
+	filter, err := d.usecase.GetFilter(context.Background(), search.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &search.Filter, filter)
+}
+
+func TestSavedSearchUsecase_UpdateSavedSearch_TurnOnNotify(t *testing.T) {
+	d := newTestUsecase(0)
+	checkedAt := testNow.Add(-24 * time.Hour)
+	search := &entity.SavedSearch{ID: uuid.New(), UserID: uuid.New(), Name: "Lamps", CheckedAt: &checkedAt}
+	d.repo.On("GetSavedSearchByID", mock.Anything, search.ID).Return(search, nil)
+	d.repo.On("UpdateSavedSearch", mock.Anything, search).Return(nil)
+
+	updated, err := d.usecase.UpdateSavedSearch(context.Background(), search.UserID, search.ID, &entity.SavedSearchRequest{
+		Name:   "Desk lamps",
+		Notify: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Desk lamps", updated.Name)
+	assert.True(t, updated.Notify)
+	assert.Equal(t, &testNow, updated.CheckedAt)
+}
+
+func TestSavedSearchUsecase_DeleteSavedSearch_NotFound(t *testing.T) {
+	d := newTestUsecase(0)
+	userID := uuid.New()
+	searchID := uuid.New()
+	d.repo.On("DeleteSavedSearch", mock.Anything, userID, searchID).Return(int64(0), nil)
+
+	err := d.usecase.DeleteSavedSearch(context.Background(), userID, searchID)
+
+	assert.Equal(t, errors.ErrSavedSearchNotFoundError, err)
+}
+
+func TestSavedSearchUsecase_CheckSavedSearches(t *testing.T) {
+	d := newTestUsecase(0)
+	checkedAt := testNow.Add(-time.Hour)
+	matching := &entity.SavedSearch{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Name:      "Lamps",
+		Filter:    entity.SavedSearchFilter{Category: "home"},
+		Notify:    true,
+		CheckedAt: &checkedAt,
+	}
+	quiet := &entity.SavedSearch{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Filter:    entity.SavedSearchFilter{Category: "garden"},
+		Notify:    true,
+		CheckedAt: &checkedAt,
+	}
+	d.each(matching, quiet)
+
+	productID := uuid.New()
+	d.listings.On("GetProducts", mock.Anything, mock.MatchedBy(func(f *entity.ProductFilter) bool {
+		return f.Category == "home"
+	})).Return([]*entity.ProductReadModel{{ID: productID}}, entity.Total{Count: 12}, nil)
+	d.listings.On("GetProducts", mock.Anything, mock.MatchedBy(func(f *entity.ProductFilter) bool {
+		return f.Category == "garden"
+	})).Return([]*entity.ProductReadModel{}, entity.Total{}, nil)
+	d.repo.On("MarkChecked", mock.Anything, matching.ID, testNow).Return(nil).Once()
+	d.repo.On("MarkChecked", mock.Anything, quiet.ID, testNow).Return(nil).Once()
+
+	var dispatched []MatchedEvent
+	d.bus.Listen(EventMatched, func(ctx context.Context, event events.Event) error {
+		dispatched = append(dispatched, event.(MatchedEvent))
+		return nil
+	})
+
+	matched, err := d.usecase.CheckSavedSearches(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, matched)
+	require.Len(t, dispatched, 1)
+	assert.Equal(t, MatchedEvent{
+		SavedSearchID: matching.ID,
+		UserID:        matching.UserID,
+		Name:          "Lamps",
+		Count:         12,
+		ProductIDs:    []uuid.UUID{productID},
+	}, dispatched[0])
+
+	// Only products created between the two checks are new
+	filter := d.listings.Calls[0].Arguments.Get(1).(*entity.ProductFilter)
+	assert.Equal(t, &checkedAt, filter.CreatedAfter)
+	assert.Equal(t, testNow, *filter.CreatedBefore)
+	assert.Equal(t, matchesListed, filter.Limit)
+	d.repo.AssertExpectations(t)
+}
@@ -0,0 +1,114 @@
+package schemadoc
+
+import (
+	"embed"
+	htmltemplate "html/template"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Options are the parts of the documentation that can be turned on
+type Options struct {
+	// Mermaid adds an ER diagram of the tables and their foreign keys
+	Mermaid bool
+}
+
+// page is the template data of both formats
+type page struct {
+	*Schema
+	Options
+	Diagram string
+}
+
+var funcs = map[string]interface{}{
+	"join":         strings.Join,
+	"cell":         markdownCell,
+	"referencedBy": func(s *Schema, name string) map[string][]ForeignKey { return s.ReferencedBy(name) },
+}
+
+var (
+	markdownTemplate = template.Must(template.New("markdown.tmpl").Funcs(funcs).ParseFS(templates, "templates/markdown.tmpl"))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("html.tmpl").Funcs(funcs).ParseFS(templates, "templates/html.tmpl"))
+)
+
+// Markdown writes the documentation of the schema as Markdown, with the
+// diagram in a mermaid code block GitHub and GitLab render
+func Markdown(w io.Writer, s *Schema, opts Options) error {
+	return markdownTemplate.Execute(w, newPage(s, opts))
+}
+
+// HTML writes the documentation of the schema as a standalone HTML page. The
+// diagram is drawn by Mermaid, loaded from a CDN.
+func HTML(w io.Writer, s *Schema, opts Options) error {
+	return htmlTemplate.Execute(w, newPage(s, opts))
+}
+
+func newPage(s *Schema, opts Options) page {
+	p := page{Schema: s, Options: opts}
+	if opts.Mermaid {
+		p.Diagram = Mermaid(s)
+	}
+	return p
+}
+
+// Mermaid returns the ER diagram of the schema in Mermaid syntax. Foreign
+// keys to tables outside the schema are left out.
+func Mermaid(s *Schema) string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+
+	for _, table := range s.Tables {
+		b.WriteString("    " + table.Name + " {\n")
+		for _, column := range table.Columns {
+			b.WriteString("        " + mermaidType(column.Type) + " " + column.Name)
+			if keys := table.Keys(column); len(keys) > 0 {
+				b.WriteString(" " + strings.Join(keys, ", "))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, table := range s.Tables {
+		for _, fk := range table.ForeignKeys {
+			if _, ok := s.Table(fk.ReferencedTable); !ok {
+				continue
+			}
+
+			// A foreign key that may be null is an optional parent
+			parent := "||"
+			for _, name := range fk.Columns {
+				if column, ok := table.Column(name); ok && column.Nullable {
+					parent = "|o"
+				}
+			}
+			b.WriteString("    " + fk.ReferencedTable + " " + parent + "--o{ " + table.Name + " : \"" + strings.Join(fk.Columns, ", ") + "\"\n")
+		}
+	}
+	return b.String()
+}
+
+var nonWord = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// mermaidType turns a Postgres type into the single word Mermaid accepts,
+// e.g. "character varying(255)" into "character_varying"
+func mermaidType(t string) string {
+	t = strings.ReplaceAll(t, "[]", "_array")
+	if i := strings.Index(t, "("); i >= 0 {
+		if j := strings.Index(t[i:], ")"); j >= 0 {
+			t = t[:i] + t[i+j+1:]
+		}
+	}
+	return strings.Trim(nonWord.ReplaceAllString(strings.TrimSpace(t), "_"), "_")
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
@@ -0,0 +1,105 @@
+package schemadoc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A user with products, one of them an optional editor
+func testSchema() *Schema {
+	return &Schema{
+		Database: "shop",
+		Tables: []Table{
+			{
+				Name: "tb_products",
+				Columns: []Column{
+					{Name: "id", Type: "uuid", PrimaryKey: true, Default: "gen_random_uuid()"},
+					{Name: "name", Type: "character varying(255)", Comment: "Shown | in listings"},
+					{Name: "tags", Type: "text[]", Nullable: true},
+					{Name: "user_id", Type: "uuid"},
+					{Name: "editor_id", Type: "uuid", Nullable: true},
+				},
+				Indexes: []Index{
+					{Name: "tb_products_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+					{Name: "idx_tb_products_lower_name", Columns: []string{"lower((name)::text)"}},
+				},
+				ForeignKeys: []ForeignKey{
+					{Name: "fk_products_editor", Columns: []string{"editor_id"}, ReferencedTable: "tb_users", ReferencedColumns: []string{"id"}, OnDelete: "SET NULL"},
+					{Name: "fk_products_user", Columns: []string{"user_id"}, ReferencedTable: "tb_users", ReferencedColumns: []string{"id"}, OnDelete: "CASCADE"},
+					{Name: "fk_products_region", Columns: []string{"region_id"}, ReferencedTable: "tb_regions", ReferencedColumns: []string{"id"}, OnDelete: "NO ACTION"},
+				},
+			},
+			{
+				Name:    "tb_users",
+				Comment: "Accounts that sign in",
+				Columns: []Column{
+					{Name: "id", Type: "uuid", PrimaryKey: true},
+					{Name: "email", Type: "character varying(255)"},
+					{Name: "created_at", Type: "timestamp with time zone", Nullable: true},
+				},
+				Indexes: []Index{
+					{Name: "tb_users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+					{Name: "idx_tb_users_email", Columns: []string{"email"}, Unique: true},
+				},
+			},
+		},
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	expected := `erDiagram
+    tb_products {
+        uuid id PK
+        character_varying name
+        text_array tags
+        uuid user_id FK
+        uuid editor_id FK
+    }
+    tb_users {
+        uuid id PK
+        character_varying email UK
+        timestamp_with_time_zone created_at
+    }
+    tb_users |o--o{ tb_products : "editor_id"
+    tb_users ||--o{ tb_products : "user_id"
+`
+	assert.Equal(t, expected, Mermaid(testSchema()))
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Markdown(&buf, testSchema(), Options{Mermaid: true}))
+	out := buf.String()
+
+	assert.Contains(t, out, "# Database Schema: shop")
+	assert.Contains(t, out, "| [tb_users](#tb_users) | 3 | Accounts that sign in |")
+	assert.Contains(t, out, "```mermaid\nerDiagram\n")
+	assert.Contains(t, out, "| `name` | character varying(255) | no |  |  | Shown \\| in listings |")
+	assert.Contains(t, out, "| `id` | uuid | no | `gen_random_uuid()` | PK |  |")
+	assert.Contains(t, out, "| `idx_tb_products_lower_name` | lower((name)::text) | no |")
+	assert.Contains(t, out, "| `fk_products_user` | user_id | [tb_users](#tb_users) (id) | CASCADE |")
+	assert.Contains(t, out, "**Referenced By**\n\n- [tb_products](#tb_products) (editor_id; user_id)")
+}
+
+func TestMarkdown_WithoutMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Markdown(&buf, testSchema(), Options{}))
+
+	assert.NotContains(t, buf.String(), "mermaid")
+	assert.NotContains(t, buf.String(), "ER Diagram")
+}
+
+func TestHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, HTML(&buf, testSchema(), Options{Mermaid: true}))
+	out := buf.String()
+
+	assert.Contains(t, out, `<h2 id="tb_users">tb_users</h2>`)
+	assert.Contains(t, out, `<pre class="mermaid">`)
+	// Mermaid reads the diagram from the text of the element, so it is escaped
+	assert.Contains(t, out, "tb_users |o--o{ tb_products : &#34;editor_id&#34;")
+	assert.Contains(t, out, `<a href="#tb_users">tb_users</a> (id)`)
+}
@@ -0,0 +1,328 @@
+// Package schemadoc documents the live database schema: it reads the tables,
+// columns, indexes and foreign keys the migrations created and renders them
+// as Markdown or HTML, with an optional Mermaid ER diagram.
+package schemadoc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Schema is the documented part of a database
+type Schema struct {
+	Database string  `json:"database"`
+	Tables   []Table `json:"tables"`
+}
+
+// Table is a table with its columns, indexes and foreign keys
+type Table struct {
+	Name        string       `json:"name"`
+	Comment     string       `json:"comment,omitempty"`
+	Columns     []Column     `json:"columns"`
+	Indexes     []Index      `json:"indexes"`
+	ForeignKeys []ForeignKey `json:"foreign_keys"`
+}
+
+// Column is a table column, with its type as Postgres formats it
+type Column struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Nullable   bool   `json:"nullable"`
+	Default    string `json:"default,omitempty"`
+	PrimaryKey bool   `json:"primary_key"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// Index is an index over columns or expressions
+type Index struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+	Primary bool     `json:"primary"`
+}
+
+// ForeignKey is a foreign key constraint
+type ForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+	OnDelete          string   `json:"on_delete"`
+}
+
+// Table returns the table with the name
+func (s *Schema) Table(name string) (Table, bool) {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table, true
+		}
+	}
+	return Table{}, false
+}
+
+// ReferencedBy returns the foreign keys of other tables pointing at the
+// table, by the table they belong to
+func (s *Schema) ReferencedBy(name string) map[string][]ForeignKey {
+	refs := make(map[string][]ForeignKey)
+	for _, table := range s.Tables {
+		for _, fk := range table.ForeignKeys {
+			if fk.ReferencedTable == name {
+				refs[table.Name] = append(refs[table.Name], fk)
+			}
+		}
+	}
+	return refs
+}
+
+// Column returns the column with the name
+func (t Table) Column(name string) (Column, bool) {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// IsForeignKey reports whether the column is part of a foreign key
+func (t Table) IsForeignKey(column string) bool {
+	for _, fk := range t.ForeignKeys {
+		for _, name := range fk.Columns {
+			if name == column {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsUnique reports whether a unique index covers exactly the column
+func (t Table) IsUnique(column string) bool {
+	for _, index := range t.Indexes {
+		if index.Unique && !index.Primary && len(index.Columns) == 1 && index.Columns[0] == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys the column is part of: PK, FK and UK
+func (t Table) Keys(column Column) []string {
+	var keys []string
+	if column.PrimaryKey {
+		keys = append(keys, "PK")
+	}
+	if t.IsForeignKey(column.Name) {
+		keys = append(keys, "FK")
+	}
+	if t.IsUnique(column.Name) {
+		keys = append(keys, "UK")
+	}
+	return keys
+}
+
+// onDeleteActions names the pg_constraint.confdeltype codes
+var onDeleteActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+// Inspect reads the tables of the public schema of a Postgres database,
+// leaving out the tables named in exclude
+func Inspect(db *gorm.DB, exclude ...string) (*Schema, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+
+	schema := &Schema{}
+	if err := db.Raw("SELECT current_database()").Scan(&schema.Database).Error; err != nil {
+		return nil, fmt.Errorf("failed to read database name: %w", err)
+	}
+
+	var tables []struct {
+		Name    string
+		Comment string
+	}
+	err := db.Raw(`
+		SELECT c.relname AS name, COALESCE(obj_description(c.oid, 'pg_class'), '') AS comment
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p')
+		ORDER BY c.relname`).Scan(&tables).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tables: %w", err)
+	}
+
+	byName := make(map[string]*Table)
+	for _, t := range tables {
+		if excluded[t.Name] {
+			continue
+		}
+		schema.Tables = append(schema.Tables, Table{Name: t.Name, Comment: t.Comment, Columns: []Column{}, Indexes: []Index{}, ForeignKeys: []ForeignKey{}})
+	}
+	for i := range schema.Tables {
+		byName[schema.Tables[i].Name] = &schema.Tables[i]
+	}
+
+	if err := inspectColumns(db, byName); err != nil {
+		return nil, err
+	}
+	if err := inspectIndexes(db, byName); err != nil {
+		return nil, err
+	}
+	if err := inspectForeignKeys(db, byName); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func inspectColumns(db *gorm.DB, tables map[string]*Table) error {
+	var columns []struct {
+		TableName string
+		Name      string
+		Type      string
+		Nullable  bool
+		Default   string
+		Comment   string
+	}
+	err := db.Raw(`
+		SELECT c.relname AS table_name,
+		       a.attname AS name,
+		       format_type(a.atttypid, a.atttypmod) AS type,
+		       NOT a.attnotnull AS nullable,
+		       COALESCE(pg_get_expr(d.adbin, d.adrelid), '') AS "default",
+		       COALESCE(col_description(c.oid, a.attnum), '') AS comment
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY c.relname, a.attnum`).Scan(&columns).Error
+	if err != nil {
+		return fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	for _, c := range columns {
+		if table, ok := tables[c.TableName]; ok {
+			table.Columns = append(table.Columns, Column{
+				Name:     c.Name,
+				Type:     c.Type,
+				Nullable: c.Nullable,
+				Default:  c.Default,
+				Comment:  c.Comment,
+			})
+		}
+	}
+	return nil
+}
+
+func inspectIndexes(db *gorm.DB, tables map[string]*Table) error {
+	var indexes []struct {
+		TableName string
+		Name      string
+		Columns   string
+		IsUnique  bool
+		IsPrimary bool
+	}
+	err := db.Raw(`
+		SELECT t.relname AS table_name,
+		       i.relname AS name,
+		       array_to_string(ARRAY(
+		           SELECT pg_get_indexdef(ix.indexrelid, k + 1, true)
+		           FROM generate_subscripts(ix.indkey, 1) AS k
+		           ORDER BY k
+		       ), E'\n') AS columns,
+		       ix.indisunique AS is_unique,
+		       ix.indisprimary AS is_primary
+		FROM pg_index ix
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = 'public'
+		ORDER BY t.relname, i.relname`).Scan(&indexes).Error
+	if err != nil {
+		return fmt.Errorf("failed to read indexes: %w", err)
+	}
+
+	for _, ix := range indexes {
+		table, ok := tables[ix.TableName]
+		if !ok {
+			continue
+		}
+		index := Index{Name: ix.Name, Columns: strings.Split(ix.Columns, "\n"), Unique: ix.IsUnique, Primary: ix.IsPrimary}
+		table.Indexes = append(table.Indexes, index)
+
+		if index.Primary {
+			for i := range table.Columns {
+				for _, name := range index.Columns {
+					if table.Columns[i].Name == name {
+						table.Columns[i].PrimaryKey = true
+					}
+				}
+			}
+		}
+	}
+
+	// The primary key first, then by name
+	for _, table := range tables {
+		sort.SliceStable(table.Indexes, func(i, j int) bool {
+			return table.Indexes[i].Primary && !table.Indexes[j].Primary
+		})
+	}
+	return nil
+}
+
+func inspectForeignKeys(db *gorm.DB, tables map[string]*Table) error {
+	var foreignKeys []struct {
+		TableName         string
+		Name              string
+		Columns           string
+		ReferencedTable   string
+		ReferencedColumns string
+		OnDelete          string
+	}
+	err := db.Raw(`
+		SELECT c.relname AS table_name,
+		       con.conname AS name,
+		       array_to_string(ARRAY(
+		           SELECT a.attname FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+		           JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		           ORDER BY k.ord
+		       ), E'\n') AS columns,
+		       r.relname AS referenced_table,
+		       array_to_string(ARRAY(
+		           SELECT a.attname FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+		           JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+		           ORDER BY k.ord
+		       ), E'\n') AS referenced_columns,
+		       con.confdeltype::text AS on_delete
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_class r ON r.oid = con.confrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE con.contype = 'f' AND n.nspname = 'public'
+		ORDER BY c.relname, con.conname`).Scan(&foreignKeys).Error
+	if err != nil {
+		return fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+
+	for _, fk := range foreignKeys {
+		if table, ok := tables[fk.TableName]; ok {
+			table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+				Name:              fk.Name,
+				Columns:           strings.Split(fk.Columns, "\n"),
+				ReferencedTable:   fk.ReferencedTable,
+				ReferencedColumns: strings.Split(fk.ReferencedColumns, "\n"),
+				OnDelete:          onDeleteActions[fk.OnDelete],
+			})
+		}
+	}
+	return nil
+}
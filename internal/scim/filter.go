@@ -0,0 +1,86 @@
+package scim
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+)
+
+// filterPattern matches attribute eq "value", the filter identity providers
+// send to find a user or group before creating it
+var filterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// memberPathPattern matches the path removing one member from a group
+var memberPathPattern = regexp.MustCompile(`^(?i:members)\[(?i:value)\s+(?i:eq)\s+"([^"]+)"\]$`)
+
+// parseFilter parses a filter on one of the attributes, named in lowercase;
+// an empty filter is nil. The emails attribute means emails.value.
+func parseFilter(filter string, attributes ...string) (*entity.SCIMFilter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+
+	match := filterPattern.FindStringSubmatch(filter)
+	if match == nil {
+		return nil, errors.ErrSCIMInvalidFilterError
+	}
+	attribute := strings.ToLower(match[1])
+	if attribute == "emails" {
+		attribute = "emails.value"
+	}
+	if !slices.Contains(attributes, attribute) {
+		return nil, errors.New(errors.ErrSCIMInvalidFilter, "Filtering on "+match[1]+" is not supported", 400)
+	}
+
+	value, err := strconv.Unquote(match[2])
+	if err != nil {
+		return nil, errors.ErrSCIMInvalidFilterError
+	}
+	return &entity.SCIMFilter{Attribute: attribute, Value: value}, nil
+}
+
+// excludes reports whether the comma-separated attributes name attribute
+func excludes(attributes, attribute string) bool {
+	for _, name := range strings.Split(attributes, ",") {
+		if strings.EqualFold(strings.TrimSpace(name), attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidValue(detail string) *errors.AppError {
+	return errors.New(errors.ErrSCIMInvalidValue, detail, 400)
+}
+
+func invalidPath(path string) *errors.AppError {
+	return errors.New(errors.ErrSCIMInvalidPath, "Unsupported attribute path "+path, 400)
+}
+
+func stringValue(path string, value json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return "", invalidValue(path + " must be a string")
+	}
+	return s, nil
+}
+
+// boolValue accepts a boolean, or the "True" and "False" strings some
+// identity providers send
+func boolValue(path string, value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	if s, err := stringValue(path, value); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, invalidValue(path + " must be a boolean")
+}
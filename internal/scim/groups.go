@@ -0,0 +1,188 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListGroups lists the roles as groups
+func (u *scimUsecase) ListGroups(ctx context.Context, query *entity.SCIMListQuery) (*entity.SCIMListResponse, error) {
+	filter, err := parseFilter(query.Filter, "id", "displayname")
+	if err != nil {
+		return nil, err
+	}
+	startIndex, count := page(query)
+
+	var roles []string
+	for _, role := range entity.ValidRoles {
+		if filter == nil || strings.EqualFold(role, filter.Value) {
+			roles = append(roles, role)
+		}
+	}
+
+	total := int64(len(roles))
+	roles = roles[min(startIndex-1, len(roles)):]
+	roles = roles[:min(count, len(roles))]
+
+	withMembers := !excludes(query.ExcludedAttributes, "members")
+	resources := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		group, err := u.GetGroup(ctx, role, withMembers)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, group)
+	}
+	return listResponse(total, startIndex, resources), nil
+}
+
+// GetGroup returns the role as a group, its members being the users holding
+// it
+func (u *scimUsecase) GetGroup(ctx context.Context, id string, withMembers bool) (*entity.SCIMGroup, error) {
+	if !slices.Contains(entity.ValidRoles, id) {
+		return nil, errors.New(errors.ErrNotFound, "Group not found", 404)
+	}
+
+	group := &entity.SCIMGroup{
+		Schemas:     []string{entity.SCIMSchemaGroup},
+		ID:          id,
+		DisplayName: id,
+		Meta: &entity.SCIMMeta{
+			ResourceType: "Group",
+			Location:     u.config.SCIM.URL + "/Groups/" + id,
+		},
+	}
+	if !withMembers {
+		return group, nil
+	}
+
+	users, err := u.repo.ListUsersByRole(ctx, id)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list group members", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get group", 500)
+	}
+	for _, user := range users {
+		group.Members = append(group.Members, entity.SCIMMember{
+			Value:   user.ID.String(),
+			Display: user.Username,
+			Ref:     u.config.SCIM.URL + "/Users/" + user.ID.String(),
+		})
+	}
+	return group, nil
+}
+
+// ReplaceGroup gives the role to exactly the members; users that lose it go
+// back to the user role
+func (u *scimUsecase) ReplaceGroup(ctx context.Context, id string, req *entity.SCIMGroup) (*entity.SCIMGroup, error) {
+	if _, err := u.GetGroup(ctx, id, false); err != nil {
+		return nil, err
+	}
+	if req.DisplayName != "" && req.DisplayName != id {
+		return nil, invalidValue("Groups cannot be renamed")
+	}
+
+	userIDs, err := memberIDs(req.Members)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.updateMembers(ctx, id, "replace", userIDs); err != nil {
+		return nil, err
+	}
+	return u.GetGroup(ctx, id, true)
+}
+
+// PatchGroup adds and removes members, the way identity providers push
+// group membership changes
+func (u *scimUsecase) PatchGroup(ctx context.Context, id string, req *entity.SCIMPatchRequest) error {
+	if _, err := u.GetGroup(ctx, id, false); err != nil {
+		return err
+	}
+
+	for _, op := range req.Operations {
+		kind := strings.ToLower(op.Op)
+		var members []entity.SCIMMember
+
+		switch path := strings.ToLower(op.Path); {
+		case kind == "remove" && memberPathPattern.MatchString(op.Path):
+			members = []entity.SCIMMember{{Value: memberPathPattern.FindStringSubmatch(op.Path)[1]}}
+		case path == "members" && kind == "remove" && len(op.Value) == 0:
+			kind = "replace"
+		case path == "members":
+			if json.Unmarshal(op.Value, &members) != nil {
+				return invalidValue("members must be a list")
+			}
+		case path == "displayname":
+			if name, err := stringValue(op.Path, op.Value); err != nil || name != id {
+				return invalidValue("Groups cannot be renamed")
+			}
+			continue
+		case path == "" && kind != "remove":
+			var attributes struct {
+				DisplayName string              `json:"displayName"`
+				Members     []entity.SCIMMember `json:"members"`
+			}
+			if json.Unmarshal(op.Value, &attributes) != nil {
+				return invalidValue("Patch value must be an object")
+			}
+			if attributes.DisplayName != "" && attributes.DisplayName != id {
+				return invalidValue("Groups cannot be renamed")
+			}
+			members = attributes.Members
+		default:
+			return invalidPath(op.Path)
+		}
+
+		userIDs, err := memberIDs(members)
+		if err != nil {
+			return err
+		}
+		if err := u.updateMembers(ctx, id, kind, userIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *scimUsecase) updateMembers(ctx context.Context, role, kind string, userIDs []uuid.UUID) error {
+	var err error
+	switch kind {
+	case "add":
+		err = u.repo.AddToRole(ctx, role, userIDs)
+	case "remove":
+		err = u.repo.RemoveFromRole(ctx, role, userIDs)
+	case "replace":
+		err = u.repo.ReplaceRoleMembers(ctx, role, userIDs)
+	default:
+		return invalidValue("Unsupported patch op " + kind)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to update group members", zap.String("group", role), zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to update group", 500)
+	}
+
+	logger.FromContext(ctx).Info("Group membership updated over SCIM",
+		zap.String("group", role), zap.String("op", kind), zap.Int("members", len(userIDs)))
+	return nil
+}
+
+// memberIDs parses the user IDs of the members
+func memberIDs(members []entity.SCIMMember) ([]uuid.UUID, error) {
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member.Value)
+		if err != nil {
+			return nil, invalidValue("Unknown member " + member.Value)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
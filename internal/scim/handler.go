@@ -0,0 +1,261 @@
+package scim
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// contentType is the media type of SCIM requests and responses
+const contentType = "application/scim+json"
+
+// scimTypes maps error codes to the SCIM error types identity providers expect
+var scimTypes = map[string]string{
+	errors.ErrSCIMInvalidFilter: "invalidFilter",
+	errors.ErrSCIMInvalidValue:  "invalidValue",
+	errors.ErrSCIMInvalidPath:   "invalidPath",
+	errors.ErrUserExists:        "uniqueness",
+}
+
+// serviceProviderConfig tells identity providers which SCIM features are
+// supported
+var serviceProviderConfig = gin.H{
+	"schemas":        []string{entity.SCIMSchemaConfig},
+	"patch":          gin.H{"supported": true},
+	"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+	"filter":         gin.H{"supported": true, "maxResults": maxCount},
+	"changePassword": gin.H{"supported": false},
+	"sort":           gin.H{"supported": false},
+	"etag":           gin.H{"supported": false},
+	"authenticationSchemes": []gin.H{{
+		"type":        "oauthbearertoken",
+		"name":        "Bearer token",
+		"description": "The SCIM_TOKEN shared with the identity provider",
+		"primary":     true,
+	}},
+}
+
+type SCIMHandler struct {
+	usecase SCIMUsecase
+}
+
+func NewSCIMHandler(usecase SCIMUsecase) *SCIMHandler {
+	return &SCIMHandler{
+		usecase: usecase,
+	}
+}
+
+// Authenticate lets through requests carrying the SCIM bearer token
+func (h *SCIMHandler) Authenticate(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || !h.usecase.VerifyToken(token) {
+		c.Header("WWW-Authenticate", `Bearer realm="SCIM"`)
+		writeError(c, errors.New(errors.ErrUnauthorized, "A valid SCIM bearer token is required", 401))
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// ServiceProviderConfig serves the supported SCIM features
+func (h *SCIMHandler) ServiceProviderConfig(c *gin.Context) {
+	write(c, http.StatusOK, serviceProviderConfig)
+}
+
+// ListUsers lists users, optionally filtered by id, externalId, userName or
+// emails
+func (h *SCIMHandler) ListUsers(c *gin.Context) {
+	var query entity.SCIMListQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	list, err := h.usecase.ListUsers(c.Request.Context(), &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, list)
+}
+
+func (h *SCIMHandler) GetUser(c *gin.Context) {
+	user, err := h.usecase.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, user)
+}
+
+func (h *SCIMHandler) CreateUser(c *gin.Context) {
+	var req entity.SCIMUser
+	if !bindBody(c, &req) {
+		return
+	}
+
+	user, err := h.usecase.CreateUser(c.Request.Context(), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Header("Location", user.Meta.Location)
+	write(c, http.StatusCreated, user)
+}
+
+func (h *SCIMHandler) ReplaceUser(c *gin.Context) {
+	var req entity.SCIMUser
+	if !bindBody(c, &req) {
+		return
+	}
+
+	user, err := h.usecase.ReplaceUser(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, user)
+}
+
+func (h *SCIMHandler) PatchUser(c *gin.Context) {
+	var req entity.SCIMPatchRequest
+	if !bindBody(c, &req) {
+		return
+	}
+
+	user, err := h.usecase.PatchUser(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, user)
+}
+
+// DeleteUser deprovisions the user
+func (h *SCIMHandler) DeleteUser(c *gin.Context) {
+	if err := h.usecase.DeleteUser(c.Request.Context(), c.Param("id")); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListGroups lists the roles as groups
+func (h *SCIMHandler) ListGroups(c *gin.Context) {
+	var query entity.SCIMListQuery
+	if !bindQuery(c, &query) {
+		return
+	}
+
+	list, err := h.usecase.ListGroups(c.Request.Context(), &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, list)
+}
+
+func (h *SCIMHandler) GetGroup(c *gin.Context) {
+	withMembers := !excludes(c.Query("excludedAttributes"), "members")
+
+	group, err := h.usecase.GetGroup(c.Request.Context(), c.Param("id"), withMembers)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, group)
+}
+
+func (h *SCIMHandler) ReplaceGroup(c *gin.Context) {
+	var req entity.SCIMGroup
+	if !bindBody(c, &req) {
+		return
+	}
+
+	group, err := h.usecase.ReplaceGroup(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	write(c, http.StatusOK, group)
+}
+
+// PatchGroup updates the group's members; it responds without a body, as
+// identity providers patch large groups
+func (h *SCIMHandler) PatchGroup(c *gin.Context) {
+	var req entity.SCIMPatchRequest
+	if !bindBody(c, &req) {
+		return
+	}
+
+	if err := h.usecase.PatchGroup(c.Request.Context(), c.Param("id"), &req); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func bindBody(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		writeError(c, errors.New(errors.ErrSCIMInvalidValue, "Invalid request body", 400))
+		return false
+	}
+	return valid(c, req)
+}
+
+func bindQuery(c *gin.Context, query *entity.SCIMListQuery) bool {
+	if err := c.ShouldBindQuery(query); err != nil {
+		writeError(c, errors.New(errors.ErrSCIMInvalidValue, "Invalid query parameters", 400))
+		return false
+	}
+	return valid(c, query)
+}
+
+// valid validates the request, writing the first failed field as the error
+func valid(c *gin.Context, req interface{}) bool {
+	fieldErrors := validator.ValidateStruct(req)
+	if fieldErrors == nil {
+		return true
+	}
+
+	fields := make([]string, 0, len(fieldErrors))
+	for field := range fieldErrors {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	writeError(c, invalidValue(fieldErrors[fields[0]]))
+	return false
+}
+
+// write responds with the SCIM media type; gin keeps a Content-Type that is
+// already set
+func write(c *gin.Context, status int, body interface{}) {
+	c.Header("Content-Type", contentType)
+	c.JSON(status, body)
+}
+
+// writeError writes a usecase error in the SCIM error format
+func writeError(c *gin.Context, err error) {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		appErr = errors.Wrap(err, errors.ErrInternal, "Internal server error", 500)
+	}
+	if appErr.StatusCode >= 500 {
+		logger.FromContext(c.Request.Context()).Error("SCIM request failed", zap.Error(err))
+	}
+
+	write(c, appErr.StatusCode, entity.SCIMError{
+		Schemas:  []string{entity.SCIMSchemaError},
+		Status:   strconv.Itoa(appErr.StatusCode),
+		SCIMType: scimTypes[appErr.Code],
+		Detail:   appErr.Message,
+	})
+}
@@ -0,0 +1,70 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIMHandler_RequiresToken(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	api.Container.Config.SCIM.Token = "scim-secret"
+
+	res := api.Get("/scim/v2/Users").Header("Authorization", "Bearer wrong").Do().
+		AssertStatus(http.StatusUnauthorized)
+
+	var scimErr entity.SCIMError
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &scimErr))
+	assert.Equal(t, "401", scimErr.Status)
+	assert.Contains(t, res.Recorder.Header().Get("Content-Type"), "application/scim+json")
+}
+
+func TestSCIMHandler_ProvisionUser(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	api.Container.Config.SCIM.Token = "scim-secret"
+	auth := "Bearer scim-secret"
+
+	body := entity.SCIMUser{
+		Schemas:  []string{entity.SCIMSchemaUser},
+		UserName: "provisioned@acme.com",
+		Name:     &entity.SCIMName{GivenName: "Pat", FamilyName: "Provisioned"},
+	}
+	var created entity.SCIMUser
+	res := api.Post("/scim/v2/Users", body).Header("Authorization", auth).Do().
+		AssertStatus(http.StatusCreated)
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &created))
+	assert.NotEmpty(t, created.ID)
+
+	// Provisioning the same user again conflicts
+	res = api.Post("/scim/v2/Users", body).Header("Authorization", auth).Do().
+		AssertStatus(http.StatusConflict)
+	var scimErr entity.SCIMError
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &scimErr))
+	assert.Equal(t, "uniqueness", scimErr.SCIMType)
+
+	var list struct {
+		TotalResults int               `json:"totalResults"`
+		Resources    []entity.SCIMUser `json:"Resources"`
+	}
+	res = api.Get("/scim/v2/Users").Query("filter", `userName eq "PROVISIONED@acme.com"`).
+		Header("Authorization", auth).Do().
+		AssertStatus(http.StatusOK)
+	require.NoError(t, json.Unmarshal([]byte(res.Body()), &list))
+	require.Equal(t, 1, list.TotalResults)
+	assert.Equal(t, created.ID, list.Resources[0].ID)
+
+	api.Delete("/scim/v2/Users/"+created.ID).Header("Authorization", auth).Do().
+		AssertStatus(http.StatusNoContent)
+	api.Get("/scim/v2/Users/"+created.ID).Header("Authorization", auth).Do().
+		AssertStatus(http.StatusNotFound)
+}
@@ -0,0 +1,20 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package scim
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccountDeprovisioner is a testify mock of AccountDeprovisioner
+type MockAccountDeprovisioner struct {
+	mock.Mock
+}
+
+func (m *MockAccountDeprovisioner) DeprovisionAccount(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,102 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package scim
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSCIMRepository is a testify mock of SCIMRepository
+type MockSCIMRepository struct {
+	mock.Mock
+}
+
+func (m *MockSCIMRepository) ListUsers(ctx context.Context, filter *entity.SCIMFilter, offset int, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+
+	var r0 []*entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.User)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockSCIMRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMRepository) EmailTaken(ctx context.Context, email string, exceptID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, email, exceptID)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMRepository) UsernameTaken(ctx context.Context, username string, exceptID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, username, exceptID)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockSCIMRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockSCIMRepository) ListUsersByRole(ctx context.Context, role string) ([]*entity.User, error) {
+	args := m.Called(ctx, role)
+
+	var r0 []*entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMRepository) AddToRole(ctx context.Context, role string, userIDs []uuid.UUID) error {
+	args := m.Called(ctx, role, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockSCIMRepository) RemoveFromRole(ctx context.Context, role string, userIDs []uuid.UUID) error {
+	args := m.Called(ctx, role, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockSCIMRepository) ReplaceRoleMembers(ctx context.Context, role string, userIDs []uuid.UUID) error {
+	args := m.Called(ctx, role, userIDs)
+	return args.Error(0)
+}
@@ -0,0 +1,125 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package scim
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSCIMUsecase is a testify mock of SCIMUsecase
+type MockSCIMUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSCIMUsecase) VerifyToken(token string) bool {
+	args := m.Called(token)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0
+}
+
+func (m *MockSCIMUsecase) ListUsers(ctx context.Context, query *entity.SCIMListQuery) (*entity.SCIMListResponse, error) {
+	args := m.Called(ctx, query)
+
+	var r0 *entity.SCIMListResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMListResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) GetUser(ctx context.Context, id string) (*entity.SCIMUser, error) {
+	args := m.Called(ctx, id)
+
+	var r0 *entity.SCIMUser
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMUser)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) CreateUser(ctx context.Context, req *entity.SCIMUser) (*entity.SCIMUser, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.SCIMUser
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMUser)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) ReplaceUser(ctx context.Context, id string, req *entity.SCIMUser) (*entity.SCIMUser, error) {
+	args := m.Called(ctx, id, req)
+
+	var r0 *entity.SCIMUser
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMUser)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) PatchUser(ctx context.Context, id string, req *entity.SCIMPatchRequest) (*entity.SCIMUser, error) {
+	args := m.Called(ctx, id, req)
+
+	var r0 *entity.SCIMUser
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMUser)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSCIMUsecase) ListGroups(ctx context.Context, query *entity.SCIMListQuery) (*entity.SCIMListResponse, error) {
+	args := m.Called(ctx, query)
+
+	var r0 *entity.SCIMListResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMListResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) GetGroup(ctx context.Context, id string, withMembers bool) (*entity.SCIMGroup, error) {
+	args := m.Called(ctx, id, withMembers)
+
+	var r0 *entity.SCIMGroup
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMGroup)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) ReplaceGroup(ctx context.Context, id string, req *entity.SCIMGroup) (*entity.SCIMGroup, error) {
+	args := m.Called(ctx, id, req)
+
+	var r0 *entity.SCIMGroup
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SCIMGroup)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSCIMUsecase) PatchGroup(ctx context.Context, id string, req *entity.SCIMPatchRequest) error {
+	args := m.Called(ctx, id, req)
+	return args.Error(0)
+}
@@ -0,0 +1,137 @@
+package scim
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+)
+
+// ignoredAttributes are accepted but not stored
+var ignoredAttributes = []string{"displayname", "name.formatted", "nickname", "title", "preferredlanguage", "locale", "timezone"}
+
+// patchUser applies one patch operation to the user
+func patchUser(user *entity.User, op entity.SCIMPatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return invalidValue("Unsupported patch op " + op.Op)
+	}
+
+	if op.Path != "" {
+		if kind == "remove" {
+			return removeUserAttribute(user, op.Path)
+		}
+		return setUserAttribute(user, op.Path, op.Value)
+	}
+	if kind == "remove" {
+		return invalidValue("Remove needs a path")
+	}
+
+	// Without a path the value holds the attributes, possibly as name.givenName
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &attributes); err != nil {
+		return invalidValue("Patch value must be an object")
+	}
+	for path, value := range attributes {
+		if err := setUserAttribute(user, path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setUserAttribute(user *entity.User, path string, value json.RawMessage) error {
+	var err error
+	switch attr := strings.ToLower(path); {
+	case attr == "username":
+		user.Username, err = stringValue(path, value)
+	case attr == "externalid":
+		user.ExternalID, err = stringValue(path, value)
+	case attr == "active":
+		user.IsActive, err = boolValue(path, value)
+	case attr == "name.givenname":
+		user.FirstName, err = stringValue(path, value)
+	case attr == "name.familyname":
+		user.LastName, err = stringValue(path, value)
+	case attr == "name":
+		var name entity.SCIMName
+		if json.Unmarshal(value, &name) != nil {
+			return invalidValue("name must be an object")
+		}
+		applyName(user, &name)
+	case attr == "emails":
+		var emails []entity.SCIMEmail
+		if json.Unmarshal(value, &emails) != nil {
+			return invalidValue("emails must be a list")
+		}
+		if email := primaryEmail(emails); email != "" {
+			user.Email = email
+		}
+	case strings.HasPrefix(attr, "emails[") && strings.HasSuffix(attr, "].value"):
+		user.Email, err = stringValue(path, value)
+	case attr == "roles":
+		var roles []entity.SCIMRole
+		if json.Unmarshal(value, &roles) != nil {
+			return invalidValue("roles must be a list")
+		}
+		if len(roles) > 0 {
+			user.Role, err = pickRole(roles)
+		}
+	case slices.Contains(ignoredAttributes, attr):
+	default:
+		return invalidPath(path)
+	}
+	return err
+}
+
+func removeUserAttribute(user *entity.User, path string) error {
+	switch attr := strings.ToLower(path); {
+	case attr == "externalid":
+		user.ExternalID = ""
+	case attr == "name.familyname":
+		user.LastName = ""
+	case attr == "roles":
+		user.Role = entity.RoleUser
+	case slices.Contains(ignoredAttributes, attr):
+	default:
+		return invalidPath(path)
+	}
+	return nil
+}
+
+func applyName(user *entity.User, name *entity.SCIMName) {
+	if name.GivenName != "" {
+		user.FirstName = name.GivenName
+	}
+	if name.FamilyName != "" {
+		user.LastName = name.FamilyName
+	}
+}
+
+// primaryEmail picks the primary email, or the first one
+func primaryEmail(emails []entity.SCIMEmail) string {
+	for _, email := range emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+// pickRole picks the primary role, or the first one
+func pickRole(roles []entity.SCIMRole) (string, error) {
+	role := roles[0].Value
+	for _, r := range roles {
+		if r.Primary {
+			role = r.Value
+		}
+	}
+	if !slices.Contains(entity.ValidRoles, role) {
+		return "", invalidValue("Unknown role " + role + "; roles are " + strings.Join(entity.ValidRoles, ", "))
+	}
+	return role, nil
+}
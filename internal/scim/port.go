@@ -0,0 +1,43 @@
+package scim
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// SCIMUsecase defines the business logic interface for SCIM provisioning
+type SCIMUsecase interface {
+	VerifyToken(token string) bool
+	ListUsers(ctx context.Context, query *entity.SCIMListQuery) (*entity.SCIMListResponse, error)
+	GetUser(ctx context.Context, id string) (*entity.SCIMUser, error)
+	CreateUser(ctx context.Context, req *entity.SCIMUser) (*entity.SCIMUser, error)
+	ReplaceUser(ctx context.Context, id string, req *entity.SCIMUser) (*entity.SCIMUser, error)
+	PatchUser(ctx context.Context, id string, req *entity.SCIMPatchRequest) (*entity.SCIMUser, error)
+	DeleteUser(ctx context.Context, id string) error
+	ListGroups(ctx context.Context, query *entity.SCIMListQuery) (*entity.SCIMListResponse, error)
+	GetGroup(ctx context.Context, id string, withMembers bool) (*entity.SCIMGroup, error)
+	ReplaceGroup(ctx context.Context, id string, req *entity.SCIMGroup) (*entity.SCIMGroup, error)
+	PatchGroup(ctx context.Context, id string, req *entity.SCIMPatchRequest) error
+}
+
+// SCIMRepository defines the data access interface for SCIM provisioning
+type SCIMRepository interface {
+	ListUsers(ctx context.Context, filter *entity.SCIMFilter, offset, limit int) ([]*entity.User, int64, error)
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	EmailTaken(ctx context.Context, email string, exceptID uuid.UUID) (bool, error)
+	UsernameTaken(ctx context.Context, username string, exceptID uuid.UUID) (bool, error)
+	CreateUser(ctx context.Context, user *entity.User) error
+	UpdateUser(ctx context.Context, user *entity.User) error
+	ListUsersByRole(ctx context.Context, role string) ([]*entity.User, error)
+	AddToRole(ctx context.Context, role string, userIDs []uuid.UUID) error
+	RemoveFromRole(ctx context.Context, role string, userIDs []uuid.UUID) error
+	ReplaceRoleMembers(ctx context.Context, role string, userIDs []uuid.UUID) error
+}
+
+// AccountDeprovisioner deletes the accounts of deprovisioned users,
+// implemented by account.AccountUsecase
+type AccountDeprovisioner interface {
+	DeprovisionAccount(ctx context.Context, userID uuid.UUID) error
+}
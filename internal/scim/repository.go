@@ -0,0 +1,123 @@
+package scim
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// filterConditions maps the filterable attributes to conditions; userName
+// and emails compare case-insensitively, as SCIM defines them
+var filterConditions = map[string]string{
+	"id":           "id = ?",
+	"externalid":   "external_id = ?",
+	"username":     "LOWER(username) = LOWER(?)",
+	"emails.value": "LOWER(email) = LOWER(?)",
+}
+
+type scimRepository struct {
+	db *gorm.DB
+}
+
+func NewSCIMRepository(db *gorm.DB) SCIMRepository {
+	return &scimRepository{
+		db: db,
+	}
+}
+
+// ListUsers lists users, deactivated ones included, oldest first
+func (r *scimRepository) ListUsers(ctx context.Context, filter *entity.SCIMFilter, offset, limit int) ([]*entity.User, int64, error) {
+	query := tenancy.Conn(ctx, r.db).Model(&entity.User{})
+	if filter != nil {
+		query = query.Where(filterConditions[filter.Attribute], filter.Value)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []*entity.User
+	if limit == 0 {
+		return users, total, nil
+	}
+	err := query.Order("created_at ASC, id ASC").Offset(offset).Limit(limit).Find(&users).Error
+	return users, total, err
+}
+
+// GetUserByID finds the user, deactivated or not
+func (r *scimRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).Where("id = ?", userID).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// EmailTaken reports whether a user other than exceptID holds the email.
+// Deleted users count, since the unique index still covers them.
+func (r *scimRepository) EmailTaken(ctx context.Context, email string, exceptID uuid.UUID) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Unscoped().Model(&entity.User{}).
+		Where("email = ? AND id <> ?", email, exceptID).Count(&count).Error
+	return count > 0, err
+}
+
+// UsernameTaken reports whether a user other than exceptID holds the username
+func (r *scimRepository) UsernameTaken(ctx context.Context, username string, exceptID uuid.UUID) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Unscoped().Model(&entity.User{}).
+		Where("username = ? AND id <> ?", username, exceptID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *scimRepository) CreateUser(ctx context.Context, user *entity.User) error {
+	return tenancy.Conn(ctx, r.db).Create(user).Error
+}
+
+func (r *scimRepository) UpdateUser(ctx context.Context, user *entity.User) error {
+	return tenancy.Conn(ctx, r.db).Save(user).Error
+}
+
+func (r *scimRepository) ListUsersByRole(ctx context.Context, role string) ([]*entity.User, error) {
+	var users []*entity.User
+	err := tenancy.Conn(ctx, r.db).Where("role = ?", role).Order("created_at ASC, id ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *scimRepository) AddToRole(ctx context.Context, role string, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return tenancy.Conn(ctx, r.db).Model(&entity.User{}).Where("id IN ?", userIDs).Update("role", role).Error
+}
+
+// RemoveFromRole puts the users that hold the role back to the user role
+func (r *scimRepository) RemoveFromRole(ctx context.Context, role string, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return tenancy.Conn(ctx, r.db).Model(&entity.User{}).
+		Where("id IN ? AND role = ?", userIDs, role).Update("role", entity.RoleUser).Error
+}
+
+// ReplaceRoleMembers gives the role to exactly the users listed
+func (r *scimRepository) ReplaceRoleMembers(ctx context.Context, role string, userIDs []uuid.UUID) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		demote := tx.Model(&entity.User{}).Where("role = ?", role)
+		if len(userIDs) > 0 {
+			demote = demote.Where("id NOT IN ?", userIDs)
+		}
+		if err := demote.Update("role", entity.RoleUser).Error; err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+		return tx.Model(&entity.User{}).Where("id IN ?", userIDs).Update("role", role).Error
+	})
+}
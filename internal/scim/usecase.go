@@ -0,0 +1,289 @@
+package scim
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Page sizes of list responses
+const (
+	defaultCount = 100
+	maxCount     = 200
+)
+
+type scimUsecase struct {
+	repo     SCIMRepository
+	config   *config.Config
+	accounts AccountDeprovisioner
+}
+
+func NewSCIMUsecase(repo SCIMRepository, config *config.Config, accounts AccountDeprovisioner) SCIMUsecase {
+	return &scimUsecase{
+		repo:     repo,
+		config:   config,
+		accounts: accounts,
+	}
+}
+
+// VerifyToken checks the identity provider's bearer token; without a
+// configured token nothing is accepted
+func (u *scimUsecase) VerifyToken(token string) bool {
+	expected := u.config.SCIM.Token
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+func (u *scimUsecase) ListUsers(ctx context.Context, query *entity.SCIMListQuery) (*entity.SCIMListResponse, error) {
+	filter, err := parseFilter(query.Filter, "id", "externalid", "username", "emails.value")
+	if err != nil {
+		return nil, err
+	}
+	startIndex, count := page(query)
+
+	users, total, err := u.repo.ListUsers(ctx, filter, startIndex-1, count)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list SCIM users", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to list users", 500)
+	}
+
+	resources := make([]interface{}, len(users))
+	for i, user := range users {
+		resources[i] = u.toSCIMUser(user)
+	}
+	return listResponse(total, startIndex, resources), nil
+}
+
+func (u *scimUsecase) GetUser(ctx context.Context, id string) (*entity.SCIMUser, error) {
+	user, err := u.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.toSCIMUser(user), nil
+}
+
+// CreateUser provisions a user. They get an unusable random password, so
+// they sign in through single sign-on or a directory.
+func (u *scimUsecase) CreateUser(ctx context.Context, req *entity.SCIMUser) (*entity.SCIMUser, error) {
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create user", 500)
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(password)), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to hash password", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to hash password", 500)
+	}
+
+	user := &entity.User{Password: string(hashedPassword), Role: entity.RoleUser, IsActive: true}
+	if err := applyUser(user, req); err != nil {
+		return nil, err
+	}
+	if err := u.checkUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.CreateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to create SCIM user", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create user", 500)
+	}
+
+	logger.FromContext(ctx).Info("User provisioned over SCIM",
+		zap.String("user_id", user.ID.String()), zap.String("external_id", user.ExternalID))
+	return u.toSCIMUser(user), nil
+}
+
+// ReplaceUser replaces the user's attributes. An omitted active flag or role
+// is left as it is.
+func (u *scimUsecase) ReplaceUser(ctx context.Context, id string, req *entity.SCIMUser) (*entity.SCIMUser, error) {
+	user, err := u.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.FirstName, user.LastName, user.ExternalID = "", "", ""
+	if err := applyUser(user, req); err != nil {
+		return nil, err
+	}
+	return u.saveUser(ctx, user)
+}
+
+// PatchUser applies the operations in order; identity providers deactivate
+// users by replacing active
+func (u *scimUsecase) PatchUser(ctx context.Context, id string, req *entity.SCIMPatchRequest) (*entity.SCIMUser, error) {
+	user, err := u.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range req.Operations {
+		if err := patchUser(user, op); err != nil {
+			return nil, err
+		}
+	}
+	return u.saveUser(ctx, user)
+}
+
+// DeleteUser deprovisions the user: their account is deleted and anonymized
+// like a self-serve deletion
+func (u *scimUsecase) DeleteUser(ctx context.Context, id string) error {
+	user, err := u.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	return u.accounts.DeprovisionAccount(ctx, user.ID)
+}
+
+func (u *scimUsecase) saveUser(ctx context.Context, user *entity.User) (*entity.SCIMUser, error) {
+	if user.FirstName == "" {
+		user.FirstName = user.Username
+	}
+	if err := u.checkUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.UpdateUser(ctx, user); err != nil {
+		logger.FromContext(ctx).Error("Failed to update SCIM user", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update user", 500)
+	}
+
+	logger.FromContext(ctx).Info("User updated over SCIM",
+		zap.String("user_id", user.ID.String()), zap.Bool("active", user.IsActive), zap.String("role", user.Role))
+	return u.toSCIMUser(user), nil
+}
+
+// checkUser validates the mapped attributes and that the email and username
+// are free
+func (u *scimUsecase) checkUser(ctx context.Context, user *entity.User) error {
+	if len(user.Username) < 3 || len(user.Username) > 50 {
+		return invalidValue("userName must be 3 to 50 characters")
+	}
+	if !strings.Contains(user.Email, "@") {
+		return invalidValue("A valid email is required")
+	}
+
+	emailTaken, err := u.repo.EmailTaken(ctx, user.Email, user.ID)
+	if err == nil && emailTaken {
+		return errors.New(errors.ErrUserExists, "A user with this email already exists", 409)
+	}
+	var usernameTaken bool
+	if err == nil {
+		usernameTaken, err = u.repo.UsernameTaken(ctx, user.Username, user.ID)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check existing user", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to check existing user", 500)
+	}
+	if usernameTaken {
+		return errors.New(errors.ErrUserExists, "A user with this userName already exists", 409)
+	}
+	return nil
+}
+
+func (u *scimUsecase) getUser(ctx context.Context, id string) (*entity.User, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.ErrUserNotFoundError
+	}
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUserNotFoundError
+		}
+		logger.FromContext(ctx).Error("Failed to get user by ID", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get user", 500)
+	}
+	return user, nil
+}
+
+func (u *scimUsecase) toSCIMUser(user *entity.User) *entity.SCIMUser {
+	active := user.IsActive
+	created, lastModified := user.CreatedAt, user.UpdatedAt
+	return &entity.SCIMUser{
+		Schemas:     []string{entity.SCIMSchemaUser},
+		ID:          user.ID.String(),
+		ExternalID:  user.ExternalID,
+		UserName:    user.Username,
+		Name:        &entity.SCIMName{GivenName: user.FirstName, FamilyName: user.LastName},
+		DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+		Emails:      []entity.SCIMEmail{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Roles:       []entity.SCIMRole{{Value: user.Role, Primary: true}},
+		Groups: []entity.SCIMGroupRef{{
+			Value:   user.Role,
+			Display: user.Role,
+			Ref:     u.config.SCIM.URL + "/Groups/" + user.Role,
+		}},
+		Meta: &entity.SCIMMeta{
+			ResourceType: "User",
+			Created:      &created,
+			LastModified: &lastModified,
+			Location:     u.config.SCIM.URL + "/Users/" + user.ID.String(),
+		},
+	}
+}
+
+// applyUser maps a SCIM user onto the user. Without emails, a userName that
+// is an email is used.
+func applyUser(user *entity.User, req *entity.SCIMUser) error {
+	user.Username = req.UserName
+	user.ExternalID = req.ExternalID
+
+	if email := primaryEmail(req.Emails); email != "" {
+		user.Email = email
+	} else if strings.Contains(req.UserName, "@") {
+		user.Email = req.UserName
+	}
+
+	if req.Name != nil {
+		applyName(user, req.Name)
+	}
+	if user.FirstName == "" {
+		user.FirstName = req.UserName
+	}
+	if req.Active != nil {
+		user.IsActive = *req.Active
+	}
+	if len(req.Roles) > 0 {
+		role, err := pickRole(req.Roles)
+		if err != nil {
+			return err
+		}
+		user.Role = role
+	}
+	return nil
+}
+
+// page returns the 1-based start index and page size of a list query
+func page(query *entity.SCIMListQuery) (int, int) {
+	startIndex, count := query.StartIndex, defaultCount
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if query.Count != nil {
+		count = min(*query.Count, maxCount)
+	}
+	return startIndex, count
+}
+
+func listResponse(total int64, startIndex int, resources []interface{}) *entity.SCIMListResponse {
+	return &entity.SCIMListResponse{
+		Schemas:      []string{entity.SCIMSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
@@ -0,0 +1,239 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSCIMURL = "https://app.example.com/scim/v2"
+
+type testDeps struct {
+	repo     *MockSCIMRepository
+	accounts *MockAccountDeprovisioner
+	usecase  SCIMUsecase
+}
+
+func newTestUsecase() *testDeps {
+	cfg := &config.Config{
+		SCIM: config.SCIMConfig{Token: "scim-secret", URL: testSCIMURL},
+	}
+	d := &testDeps{
+		repo:     new(MockSCIMRepository),
+		accounts: new(MockAccountDeprovisioner),
+	}
+	d.usecase = NewSCIMUsecase(d.repo, cfg, d.accounts)
+	return d
+}
+
+func (d *testDeps) existingUser() *entity.User {
+	user := &entity.User{
+		ID:        uuid.New(),
+		Email:     "jane@acme.com",
+		Username:  "jane@acme.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Role:      entity.RoleUser,
+		IsActive:  true,
+	}
+	d.repo.On("GetUserByID", mock.Anything, user.ID).Return(user, nil).Once()
+	return user
+}
+
+func (d *testDeps) freeNames() {
+	d.repo.On("EmailTaken", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	d.repo.On("UsernameTaken", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+}
+
+func TestSCIMUsecase_VerifyToken(t *testing.T) {
+	d := newTestUsecase()
+
+	assert.True(t, d.usecase.VerifyToken("scim-secret"))
+	assert.False(t, d.usecase.VerifyToken("wrong"))
+	assert.False(t, NewSCIMUsecase(d.repo, &config.Config{}, d.accounts).VerifyToken(""))
+}
+
+func TestSCIMUsecase_CreateUser(t *testing.T) {
+	d := newTestUsecase()
+	d.freeNames()
+
+	var created *entity.User
+	d.repo.On("CreateUser", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entity.User)
+			created.ID = uuid.New()
+		}).Return(nil).Once()
+
+	user, err := d.usecase.CreateUser(context.Background(), &entity.SCIMUser{
+		UserName:   "jane@acme.com",
+		ExternalID: "00u1abcd",
+		Name:       &entity.SCIMName{GivenName: "Jane", FamilyName: "Doe"},
+		Emails:     []entity.SCIMEmail{{Value: "jane.doe@acme.com", Primary: true}},
+		Roles:      []entity.SCIMRole{{Value: entity.RoleAdmin}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@acme.com", created.Email)
+	assert.Equal(t, "00u1abcd", created.ExternalID)
+	assert.Equal(t, entity.RoleAdmin, created.Role)
+	assert.True(t, created.IsActive)
+	assert.NotEmpty(t, created.Password)
+	assert.Equal(t, "Jane Doe", user.DisplayName)
+	assert.Equal(t, testSCIMURL+"/Users/"+created.ID.String(), user.Meta.Location)
+	assert.Equal(t, entity.RoleAdmin, user.Groups[0].Value)
+}
+
+func TestSCIMUsecase_CreateUser_Conflict(t *testing.T) {
+	d := newTestUsecase()
+	d.repo.On("EmailTaken", mock.Anything, "jane@acme.com", uuid.Nil).Return(true, nil).Once()
+
+	_, err := d.usecase.CreateUser(context.Background(), &entity.SCIMUser{UserName: "jane@acme.com"})
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrUserExists, appErr.Code)
+	assert.Equal(t, 409, appErr.StatusCode)
+	d.repo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestSCIMUsecase_CreateUser_UnknownRole(t *testing.T) {
+	d := newTestUsecase()
+
+	_, err := d.usecase.CreateUser(context.Background(), &entity.SCIMUser{
+		UserName: "jane@acme.com",
+		Roles:    []entity.SCIMRole{{Value: "owner"}},
+	})
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrSCIMInvalidValue, appErr.Code)
+}
+
+func TestSCIMUsecase_PatchUser_Deactivate(t *testing.T) {
+	d := newTestUsecase()
+	user := d.existingUser()
+	d.freeNames()
+	d.repo.On("UpdateUser", mock.Anything, user).Return(nil).Once()
+
+	// Azure AD sends booleans as strings and paths inside a pathless value
+	result, err := d.usecase.PatchUser(context.Background(), user.ID.String(), &entity.SCIMPatchRequest{
+		Operations: []entity.SCIMPatchOperation{
+			{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+			{Op: "replace", Value: json.RawMessage(`{"name.givenName": "Janet", "displayName": "Janet Doe"}`)},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+	assert.Equal(t, "Janet", user.FirstName)
+	assert.False(t, *result.Active)
+}
+
+func TestSCIMUsecase_PatchUser_UnknownPath(t *testing.T) {
+	d := newTestUsecase()
+	user := d.existingUser()
+
+	_, err := d.usecase.PatchUser(context.Background(), user.ID.String(), &entity.SCIMPatchRequest{
+		Operations: []entity.SCIMPatchOperation{{Op: "replace", Path: "password", Value: json.RawMessage(`"secret"`)}},
+	})
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrSCIMInvalidPath, appErr.Code)
+	d.repo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestSCIMUsecase_DeleteUser(t *testing.T) {
+	d := newTestUsecase()
+	user := d.existingUser()
+	d.accounts.On("DeprovisionAccount", mock.Anything, user.ID).Return(nil).Once()
+
+	require.NoError(t, d.usecase.DeleteUser(context.Background(), user.ID.String()))
+	d.accounts.AssertExpectations(t)
+}
+
+func TestSCIMUsecase_GetUser_NotFound(t *testing.T) {
+	d := newTestUsecase()
+
+	_, err := d.usecase.GetUser(context.Background(), "not-a-uuid")
+
+	assert.Equal(t, errors.ErrUserNotFoundError, err)
+}
+
+func TestSCIMUsecase_PatchGroup(t *testing.T) {
+	d := newTestUsecase()
+	added, removed := uuid.New(), uuid.New()
+	d.repo.On("AddToRole", mock.Anything, entity.RoleAdmin, []uuid.UUID{added}).Return(nil).Once()
+	d.repo.On("RemoveFromRole", mock.Anything, entity.RoleAdmin, []uuid.UUID{removed}).Return(nil).Once()
+
+	err := d.usecase.PatchGroup(context.Background(), entity.RoleAdmin, &entity.SCIMPatchRequest{
+		Operations: []entity.SCIMPatchOperation{
+			{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "` + added.String() + `"}]`)},
+			{Op: "remove", Path: `members[value eq "` + removed.String() + `"]`},
+		},
+	})
+
+	require.NoError(t, err)
+	d.repo.AssertExpectations(t)
+}
+
+func TestSCIMUsecase_PatchGroup_Rename(t *testing.T) {
+	d := newTestUsecase()
+
+	err := d.usecase.PatchGroup(context.Background(), entity.RoleAdmin, &entity.SCIMPatchRequest{
+		Operations: []entity.SCIMPatchOperation{{Op: "replace", Path: "displayName", Value: json.RawMessage(`"owners"`)}},
+	})
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrSCIMInvalidValue, appErr.Code)
+}
+
+func TestSCIMUsecase_GetGroup_Unknown(t *testing.T) {
+	d := newTestUsecase()
+
+	_, err := d.usecase.GetGroup(context.Background(), "owners", true)
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, 404, appErr.StatusCode)
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter  string
+		want    *entity.SCIMFilter
+		wantErr bool
+	}{
+		{filter: "", want: nil},
+		{filter: `userName eq "jane@acme.com"`, want: &entity.SCIMFilter{Attribute: "username", Value: "jane@acme.com"}},
+		{filter: `emails EQ "jane@acme.com"`, want: &entity.SCIMFilter{Attribute: "emails.value", Value: "jane@acme.com"}},
+		{filter: `externalId eq "a \"quoted\" id"`, want: &entity.SCIMFilter{Attribute: "externalid", Value: `a "quoted" id`}},
+		{filter: `userName sw "jane"`, wantErr: true},
+		{filter: `userName eq "jane" and active eq true`, wantErr: true},
+		{filter: `title eq "Engineer"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := parseFilter(tt.filter, "id", "externalid", "username", "emails.value")
+			if tt.wantErr {
+				appErr, ok := err.(*errors.AppError)
+				require.True(t, ok)
+				assert.Equal(t, errors.ErrSCIMInvalidFilter, appErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
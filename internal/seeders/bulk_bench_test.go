@@ -0,0 +1,70 @@
+package seeders_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/database/bulk"
+	"go-clean-gin/pkg/ids"
+	"go-clean-gin/test/testdb"
+
+	"github.com/stretchr/testify/require"
+)
+
+const benchRows = 1000
+
+// userRows builds n tb_users rows unique to the run
+func userRows(run, n int) []map[string]interface{} {
+	now := time.Now().UTC()
+	rows := make([]map[string]interface{}, n)
+	for i := range rows {
+		name := fmt.Sprintf("bench_%d_%d_%d", now.UnixNano(), run, i)
+		rows[i] = map[string]interface{}{
+			"id":         ids.New().String(),
+			"email":      name + "@example.com",
+			"username":   name,
+			"password":   "hashed",
+			"first_name": "Bench",
+			"last_name":  "User",
+			"role":       "user",
+			"is_active":  true,
+			"created_at": now,
+			"updated_at": now,
+		}
+	}
+	return rows
+}
+
+// BenchmarkInsert_RowByRow is how the seeders inserted before bulk.Insert
+func BenchmarkInsert_RowByRow(b *testing.B) {
+	db := testdb.New(b)
+
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		rows := userRows(n, benchRows)
+		b.StartTimer()
+
+		for _, row := range rows {
+			require.NoError(b, db.Exec(`
+				INSERT INTO tb_users (id, email, username, password, first_name, last_name, role, is_active, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`, row["id"], row["email"], row["username"], row["password"], row["first_name"],
+				row["last_name"], row["role"], row["is_active"], row["created_at"], row["updated_at"]).Error)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N*benchRows), "µs/row")
+}
+
+func BenchmarkInsert_Bulk(b *testing.B) {
+	db := testdb.New(b)
+
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		rows := userRows(n, benchRows)
+		b.StartTimer()
+
+		require.NoError(b, bulk.Insert(db, "tb_users", rows))
+	}
+	b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N*benchRows), "µs/row")
+}
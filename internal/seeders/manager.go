@@ -4,8 +4,10 @@ package seeders
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/version"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -18,6 +20,18 @@ type Seeder interface {
 	Dependencies() []string // เพิ่ม method สำหรับ dependencies
 }
 
+// SeederRecord represents a seeder run in the seeder history: who ran it,
+// from where, and how long it took
+type SeederRecord struct {
+	ID         uint      `gorm:"primaryKey"`
+	Name       string    `gorm:"index;not null"`
+	RanAt      time.Time `gorm:"not null"`
+	ExecutedBy string    `gorm:"not null;default:''"`
+	Host       string    `gorm:"not null;default:''"`
+	AppVersion string    `gorm:"not null;default:''"`
+	DurationMs int64     `gorm:"not null;default:0"`
+}
+
 // SeederManager จัดการ seeders
 type SeederManager struct {
 	db      *gorm.DB
@@ -70,6 +84,11 @@ func (sm *SeederManager) RunSeeders(seederName string) error {
 		return nil
 	}
 
+	// Create seeder history table if not exists
+	if err := sm.db.AutoMigrate(&SeederRecord{}); err != nil {
+		return fmt.Errorf("failed to create seeder history table: %w", err)
+	}
+
 	logger.Info("Starting database seeding...",
 		zap.Int("total_seeders", len(sm.seeders)))
 
@@ -99,7 +118,7 @@ func (sm *SeederManager) RunSeeders(seederName string) error {
 	for _, seeder := range orderedSeeders {
 		logger.Info("Running seeder", zap.String("name", seeder.Name()))
 
-		if err := seeder.Run(sm.db); err != nil {
+		if err := sm.runSeeder(seeder); err != nil {
 			logger.Error("Seeder failed",
 				zap.String("name", seeder.Name()),
 				zap.Error(err))
@@ -139,7 +158,7 @@ func (sm *SeederManager) RunSpecificSeeder(seederName string) error {
 	for _, seeder := range toRun {
 		logger.Info("Running seeder", zap.String("name", seeder.Name()))
 
-		if err := seeder.Run(sm.db); err != nil {
+		if err := sm.runSeeder(seeder); err != nil {
 			logger.Error("Seeder failed",
 				zap.String("name", seeder.Name()),
 				zap.Error(err))
@@ -152,6 +171,65 @@ func (sm *SeederManager) RunSpecificSeeder(seederName string) error {
 	return nil
 }
 
+// runSeeder runs a seeder and records the run in the seeder history
+func (sm *SeederManager) runSeeder(seeder Seeder) error {
+	started := time.Now()
+	if err := seeder.Run(sm.db); err != nil {
+		return err
+	}
+
+	executor := version.CurrentExecutor()
+	record := SeederRecord{
+		Name:       seeder.Name(),
+		RanAt:      started.UTC(),
+		ExecutedBy: executor.User,
+		Host:       executor.Host,
+		AppVersion: executor.Version,
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if err := sm.db.Create(&record).Error; err != nil {
+		return fmt.Errorf("seeded, but failed to record the run: %w", err)
+	}
+	return nil
+}
+
+// LastRuns returns the latest recorded run of each seeder, by name
+func (sm *SeederManager) LastRuns() (map[string]SeederRecord, error) {
+	// Create seeder history table if not exists
+	if err := sm.db.AutoMigrate(&SeederRecord{}); err != nil {
+		return nil, fmt.Errorf("failed to create seeder history table: %w", err)
+	}
+
+	var records []SeederRecord
+	if err := sm.db.Order("ran_at ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to get seeder history: %w", err)
+	}
+
+	lastRuns := make(map[string]SeederRecord, len(records))
+	for _, record := range records {
+		lastRuns[record.Name] = record
+	}
+	return lastRuns, nil
+}
+
+// Plan returns the seeders RunSeeders runs for the name, in order: all of
+// them when it is empty, otherwise the one named and its dependencies
+func (sm *SeederManager) Plan(seederName string) ([]Seeder, error) {
+	if seederName == "" {
+		return sm.resolveDependencies()
+	}
+	if !strings.HasSuffix(seederName, "Seeder") {
+		seederName += "Seeder"
+	}
+
+	for _, seeder := range sm.seeders {
+		if seeder.Name() == seederName {
+			return sm.resolveDependenciesFor(seeder)
+		}
+	}
+	return nil, fmt.Errorf("seeder %s not found", seederName)
+}
+
 // resolveDependencies เรียงลำดับ seeders ตาม dependencies
 func (sm *SeederManager) resolveDependencies() ([]Seeder, error) {
 	// สร้าง map สำหรับการค้นหา seeder
@@ -274,14 +352,23 @@ func (sm *SeederManager) topologicalSort(seederMap map[string]Seeder) ([]Seeder,
 	return result, nil
 }
 
-// ListSeeders แสดงรายการ seeders ทั้งหมด พร้อม dependencies
-func (sm *SeederManager) ListSeeders() {
+// ListSeeders แสดงรายการ seeders ทั้งหมด พร้อม dependencies; verbose adds
+// the last recorded run of each
+func (sm *SeederManager) ListSeeders(verbose bool) error {
 	logger.Info("Registered Seeders:")
 	logger.Info("==================")
 
 	if len(sm.seeders) == 0 {
 		logger.Info("No seeders registered")
-		return
+		return nil
+	}
+
+	var lastRuns map[string]SeederRecord
+	if verbose {
+		var err error
+		if lastRuns, err = sm.LastRuns(); err != nil {
+			return err
+		}
 	}
 
 	// เรียงลำดับตาม dependencies
@@ -300,8 +387,23 @@ func (sm *SeederManager) ListSeeders() {
 		} else {
 			logger.Info(fmt.Sprintf("%d. %s", i+1, seeder.Name()))
 		}
+
+		if !verbose {
+			continue
+		}
+		if run, ok := lastRuns[seeder.Name()]; ok {
+			logger.Info("   last run",
+				zap.Time("ran_at", run.RanAt),
+				zap.String("executed_by", run.ExecutedBy),
+				zap.String("host", run.Host),
+				zap.String("app_version", run.AppVersion),
+				zap.Duration("duration", time.Duration(run.DurationMs)*time.Millisecond))
+		} else {
+			logger.Info("   never run")
+		}
 	}
 
 	logger.Info("==================")
 	logger.Info("Total seeders", zap.Int("count", len(sm.seeders)))
+	return nil
 }
@@ -1,10 +1,12 @@
 package seeders
 
 import (
+	"go-clean-gin/pkg/database/bulk"
+	"go-clean-gin/pkg/ids"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/money"
 	"time"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -37,77 +39,89 @@ func (s *ProductSeeder) Run(db *gorm.DB) error {
 	// Create sample products
 	products := []map[string]interface{}{
 		{
-			"id":          uuid.New().String(),
-			"name":        "MacBook Pro 16",
-			"description": "Apple MacBook Pro 16-inch with M2 Pro chip",
-			"price":       2499.99,
-			"stock":       10,
-			"category":    "Electronics",
-			"is_active":   true,
-			"created_by":  adminUserID,
-			"created_at":  time.Now().UTC(),
-			"updated_at":  time.Now().UTC(),
+			"id":           ids.New().String(),
+			"name":         "MacBook Pro 16",
+			"description":  "Apple MacBook Pro 16-inch with M2 Pro chip",
+			"price_amount": "2499.99",
+			"stock":        10,
+			"category":     "Electronics",
+			"is_active":    true,
+			"created_by":   adminUserID,
+			"created_at":   time.Now().UTC(),
+			"updated_at":   time.Now().UTC(),
 		},
 		{
-			"id":          uuid.New().String(),
-			"name":        "iPhone 15 Pro",
-			"description": "Latest iPhone with titanium design",
-			"price":       999.99,
-			"stock":       25,
-			"category":    "Electronics",
-			"is_active":   true,
-			"created_by":  adminUserID,
-			"created_at":  time.Now().UTC(),
-			"updated_at":  time.Now().UTC(),
+			"id":           ids.New().String(),
+			"name":         "iPhone 15 Pro",
+			"description":  "Latest iPhone with titanium design",
+			"price_amount": "999.99",
+			"stock":        25,
+			"category":     "Electronics",
+			"is_active":    true,
+			"created_by":   adminUserID,
+			"created_at":   time.Now().UTC(),
+			"updated_at":   time.Now().UTC(),
 		},
 		{
-			"id":          uuid.New().String(),
-			"name":        "Nike Air Force 1",
-			"description": "Classic white sneakers",
-			"price":       90.00,
-			"stock":       50,
-			"category":    "Fashion",
-			"is_active":   true,
-			"created_by":  adminUserID,
-			"created_at":  time.Now().UTC(),
-			"updated_at":  time.Now().UTC(),
+			"id":           ids.New().String(),
+			"name":         "Nike Air Force 1",
+			"description":  "Classic white sneakers",
+			"price_amount": "90.00",
+			"stock":        50,
+			"category":     "Fashion",
+			"is_active":    true,
+			"created_by":   adminUserID,
+			"created_at":   time.Now().UTC(),
+			"updated_at":   time.Now().UTC(),
 		},
 		{
-			"id":          uuid.New().String(),
-			"name":        "The Go Programming Language",
-			"description": "Comprehensive guide to Go programming",
-			"price":       45.99,
-			"stock":       100,
-			"category":    "Books",
-			"is_active":   true,
-			"created_by":  adminUserID,
-			"created_at":  time.Now().UTC(),
-			"updated_at":  time.Now().UTC(),
+			"id":           ids.New().String(),
+			"name":         "The Go Programming Language",
+			"description":  "Comprehensive guide to Go programming",
+			"price_amount": "45.99",
+			"stock":        100,
+			"category":     "Books",
+			"is_active":    true,
+			"created_by":   adminUserID,
+			"created_at":   time.Now().UTC(),
+			"updated_at":   time.Now().UTC(),
 		},
 		{
-			"id":          uuid.New().String(),
-			"name":        "Wireless Mouse",
-			"description": "Ergonomic wireless mouse with long battery life",
-			"price":       29.99,
-			"stock":       75,
-			"category":    "Electronics",
-			"is_active":   true,
-			"created_by":  adminUserID,
-			"created_at":  time.Now().UTC(),
-			"updated_at":  time.Now().UTC(),
+			"id":           ids.New().String(),
+			"name":         "Wireless Mouse",
+			"description":  "Ergonomic wireless mouse with long battery life",
+			"price_amount": "29.99",
+			"stock":        75,
+			"category":     "Electronics",
+			"is_active":    true,
+			"created_by":   adminUserID,
+			"created_at":   time.Now().UTC(),
+			"updated_at":   time.Now().UTC(),
 		},
 	}
 
-	// Insert products
 	for _, product := range products {
-		if err := db.Exec(`
-			INSERT INTO tb_products (id, name, description, price, stock, category, is_active, created_by, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		`, product["id"], product["name"], product["description"], product["price"],
-			product["stock"], product["category"], product["is_active"],
-			product["created_by"], product["created_at"], product["updated_at"]).Error; err != nil {
-			return err
-		}
+		product["price_currency"] = money.DefaultCurrency()
+	}
+	if err := bulk.Insert(db, "tb_products", products); err != nil {
+		return err
+	}
+
+	// Bulk inserts bypass the product events, so project the listings here
+	if err := db.Exec(`
+		INSERT INTO tb_product_read_models (
+			product_id, name, description, price_amount, price_currency, stock,
+			category, category_path, is_active, created_by, owner_name, created_at, updated_at
+		)
+		SELECT p.id, p.name, p.description, p.price_amount, p.price_currency, p.stock,
+			p.category, p.category, p.is_active, p.created_by, TRIM(u.first_name || ' ' || u.last_name),
+			p.created_at, p.updated_at
+		FROM tb_products p
+		JOIN tb_users u ON u.id = p.created_by
+		WHERE p.deleted_at IS NULL
+		ON CONFLICT (product_id) DO NOTHING
+	`).Error; err != nil {
+		return err
 	}
 
 	logger.Info("ProductSeeder completed successfully")
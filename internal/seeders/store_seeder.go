@@ -0,0 +1,49 @@
+package seeders
+
+import (
+	"go-clean-gin/internal/factories"
+	"go-clean-gin/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// StoreSeeder seeds the tb_stores table
+type StoreSeeder struct{}
+
+// Run executes the seeder
+func (s *StoreSeeder) Run(db *gorm.DB) error {
+	logger.Info("Running StoreSeeder...")
+
+	// Check if data already exists
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM tb_stores").Scan(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		logger.Info("tb_stores already exist, skipping StoreSeeder")
+		return nil
+	}
+
+	if _, err := factories.NewStoreFactory().Create(db, 10); err != nil {
+		return err
+	}
+
+	logger.Info("StoreSeeder completed successfully")
+	return nil
+}
+
+// Name returns seeder name
+func (s *StoreSeeder) Name() string {
+	return "StoreSeeder"
+}
+
+// Dependencies returns list of seeders that must run before this seeder
+func (s *StoreSeeder) Dependencies() []string {
+	return []string{} // No dependencies
+}
+
+// Auto-register seeder
+func init() {
+	Register(&StoreSeeder{})
+}
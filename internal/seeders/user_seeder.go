@@ -1,10 +1,11 @@
 package seeders
 
 import (
+	"go-clean-gin/pkg/database/bulk"
+	"go-clean-gin/pkg/ids"
 	"go-clean-gin/pkg/logger"
 	"time"
 
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -37,50 +38,45 @@ func (s *UserSeeder) Run(db *gorm.DB) error {
 	// Create sample users
 	users := []map[string]interface{}{
 		{
-			"id":         uuid.New().String(),
+			"id":         ids.New().String(),
 			"email":      "admin@example.com",
 			"username":   "admin",
 			"password":   string(hashedPassword),
 			"first_name": "Admin",
 			"last_name":  "User",
+			"role":       "admin",
 			"is_active":  true,
 			"created_at": time.Now().UTC(),
 			"updated_at": time.Now().UTC(),
 		},
 		{
-			"id":         uuid.New().String(),
+			"id":         ids.New().String(),
 			"email":      "john@example.com",
 			"username":   "johndoe",
 			"password":   string(hashedPassword),
 			"first_name": "John",
 			"last_name":  "Doe",
+			"role":       "user",
 			"is_active":  true,
 			"created_at": time.Now().UTC(),
 			"updated_at": time.Now().UTC(),
 		},
 		{
-			"id":         uuid.New().String(),
+			"id":         ids.New().String(),
 			"email":      "jane@example.com",
 			"username":   "janedoe",
 			"password":   string(hashedPassword),
 			"first_name": "Jane",
 			"last_name":  "Doe",
+			"role":       "user",
 			"is_active":  true,
 			"created_at": time.Now().UTC(),
 			"updated_at": time.Now().UTC(),
 		},
 	}
 
-	// Insert users
-	for _, user := range users {
-		if err := db.Exec(`
-			INSERT INTO tb_users (id, email, username, password, first_name, last_name, is_active, created_at, updated_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-		`, user["id"], user["email"], user["username"], user["password"],
-			user["first_name"], user["last_name"], user["is_active"],
-			user["created_at"], user["updated_at"]).Error; err != nil {
-			return err
-		}
+	if err := bulk.Insert(db, "tb_users", users); err != nil {
+		return err
 	}
 
 	logger.Info("UserSeeder completed successfully", zap.Int("users_created", len(users)))
@@ -0,0 +1,49 @@
+package setting
+
+import "go-clean-gin/internal/entity"
+
+var (
+	minPageSize = 1
+	maxPageSize = 100
+)
+
+// Definitions lists every setting. Add new settings here with their key in
+// the entity package; stored values of settings no longer listed are ignored.
+var Definitions = []entity.SettingDefinition{
+	{
+		Key:         entity.SettingProductsPageSize,
+		Type:        entity.SettingInt,
+		Default:     "10",
+		Scopes:      []string{entity.SettingScopeGlobal, entity.SettingScopeTenant},
+		Public:      true,
+		Min:         &minPageSize,
+		Max:         &maxPageSize,
+		Description: "Page size of product listings when a request gives none",
+	},
+	{
+		Key:         entity.SettingMaintenanceMessage,
+		Type:        entity.SettingString,
+		Default:     "",
+		Scopes:      []string{entity.SettingScopeGlobal, entity.SettingScopeTenant},
+		Public:      true,
+		Description: "Message clients show in a banner, e.g. about planned maintenance; empty for none",
+	},
+	{
+		Key:         entity.SettingFeaturesBeta,
+		Type:        entity.SettingBool,
+		Default:     "false",
+		Scopes:      []string{entity.SettingScopeGlobal, entity.SettingScopeTenant, entity.SettingScopeUser},
+		Public:      true,
+		Description: "Show beta features in clients; users may opt in themselves",
+	},
+}
+
+// definition returns the definition of key, or nil for unknown keys
+func definition(key string) *entity.SettingDefinition {
+	for i := range Definitions {
+		if Definitions[i].Key == key {
+			return &Definitions[i]
+		}
+	}
+	return nil
+}
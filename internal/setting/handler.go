@@ -0,0 +1,358 @@
+package setting
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/middleware"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SettingHandler struct {
+	usecase SettingUsecase
+}
+
+func NewSettingHandler(usecase SettingUsecase) *SettingHandler {
+	return &SettingHandler{
+		usecase: usecase,
+	}
+}
+
+// GetPublicSettings godoc
+// @Summary Get public settings
+// @Description Get the public settings, such as the maintenance message and feature toggles, as they apply to the request's tenant
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /settings [get]
+func (h *SettingHandler) GetPublicSettings(c *gin.Context) {
+	settings, err := h.usecase.GetPublicSettings(c.Request.Context(), uuid.Nil)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get settings", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get settings", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Settings retrieved successfully", settings)
+}
+
+// GetPreferences godoc
+// @Summary Get my settings
+// @Description Get the public settings as they apply to the current user, including their preferences
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /settings/me [get]
+func (h *SettingHandler) GetPreferences(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.usecase.GetPublicSettings(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get settings", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get settings", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Settings retrieved successfully", settings)
+}
+
+// UpdatePreference godoc
+// @Summary Set a preference
+// @Description Set a public setting that allows the user scope for the current user only
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param key path string true "Setting key"
+// @Param request body entity.UpdatePreferenceRequest true "Value"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /settings/me/{key} [put]
+func (h *SettingHandler) UpdatePreference(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdatePreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	setting, err := h.usecase.UpdatePreference(c.Request.Context(), c.Param("key"), &req, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update preference", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to update preference", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Preference updated successfully", setting)
+}
+
+// ResetPreference godoc
+// @Summary Reset a preference
+// @Description Remove the current user's value of a setting, so the tenant or global value applies again
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param key path string true "Setting key"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /settings/me/{key} [delete]
+func (h *SettingHandler) ResetPreference(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	setting, err := h.usecase.ResetPreference(c.Request.Context(), c.Param("key"), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to reset preference", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to reset preference", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Preference reset successfully", setting)
+}
+
+// GetSettings godoc
+// @Summary Get settings
+// @Description Get every setting's effective value at a scope and where it comes from. Admin only; admins of a tenant see the tenant's scope only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param scope query string false "Scope, global when not given" Enums(global, tenant, user)
+// @Param scope_id query string false "Tenant or user ID of the scope"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/settings [get]
+func (h *SettingHandler) GetSettings(c *gin.Context) {
+	var query entity.SettingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(query); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	settings, err := h.usecase.GetSettings(c.Request.Context(), &query)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get settings", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get settings", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Settings retrieved successfully", settings)
+}
+
+// UpdateSetting godoc
+// @Summary Update a setting
+// @Description Set a setting at a scope; the change is recorded. Admin only; admins of a tenant set the tenant's scope only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param key path string true "Setting key"
+// @Param request body entity.UpdateSettingRequest true "Scope and value"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/settings/{key} [put]
+func (h *SettingHandler) UpdateSetting(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdateSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	setting, err := h.usecase.UpdateSetting(c.Request.Context(), c.Param("key"), &req, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update setting", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to update setting", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Setting updated successfully", setting)
+}
+
+// ResetSetting godoc
+// @Summary Reset a setting
+// @Description Remove a setting's value at a scope, so it inherits from the broader scopes again; the change is recorded. Admin only; admins of a tenant reset the tenant's scope only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param key path string true "Setting key"
+// @Param scope query string false "Scope, global when not given" Enums(global, tenant, user)
+// @Param scope_id query string false "Tenant or user ID of the scope"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/settings/{key} [delete]
+func (h *SettingHandler) ResetSetting(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+
+	var query entity.SettingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(query); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	setting, err := h.usecase.ResetSetting(c.Request.Context(), c.Param("key"), &query, userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to reset setting", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to reset setting", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Setting reset successfully", setting)
+}
+
+// GetSettingChanges godoc
+// @Summary Get setting changes
+// @Description Get the recorded changes to settings, newest first. Admin only; admins of a tenant see the changes to the tenant's scope only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param key query string false "Only changes to this setting"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /admin/settings/changes [get]
+func (h *SettingHandler) GetSettingChanges(c *gin.Context) {
+	var filter entity.SettingChangeFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	changes, total, err := h.usecase.GetSettingChanges(c.Request.Context(), &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get setting changes", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to get setting changes", nil)
+		}
+		return
+	}
+
+	meta := pagination.Meta(filter.Params, total, len(changes), func() (time.Time, uuid.UUID) {
+		last := changes[len(changes)-1]
+		return last.CreatedAt, last.ID
+	})
+	response.SuccessWithMeta(c, 200, "Setting changes retrieved successfully", changes, meta)
+}
@@ -0,0 +1,127 @@
+package setting_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// find returns the value of key among values
+func find(t *testing.T, values []entity.SettingValue, key string) entity.SettingValue {
+	t.Helper()
+	for _, v := range values {
+		if v.Key == key {
+			return v
+		}
+	}
+	require.Failf(t, "setting not listed", "key %s", key)
+	return entity.SettingValue{}
+}
+
+func TestSettingHandler_UpdateSetting_RecordsChanges(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	var value entity.SettingValue
+	api.As(admin).Put("/api/v1/admin/settings/"+entity.SettingProductsPageSize,
+		entity.UpdateSettingRequest{Scope: entity.SettingScopeTenant, ScopeID: "acme", Value: "25"}).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&value)
+	assert.Equal(t, "25", value.Value)
+	assert.Equal(t, entity.SettingScopeTenant, value.Source)
+
+	var values []entity.SettingValue
+	api.As(admin).Get("/api/v1/admin/settings").
+		Query("scope", entity.SettingScopeTenant).
+		Query("scope_id", "acme").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&values)
+	assert.Equal(t, "25", find(t, values, entity.SettingProductsPageSize).Value)
+
+	api.As(admin).Delete("/api/v1/admin/settings/"+entity.SettingProductsPageSize).
+		Query("scope", entity.SettingScopeTenant).
+		Query("scope_id", "acme").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&value)
+	assert.Equal(t, "10", value.Value)
+
+	var changes []entity.SettingChange
+	api.As(admin).Get("/api/v1/admin/settings/changes").
+		Query("key", entity.SettingProductsPageSize).Do().
+		AssertStatus(http.StatusOK).
+		Decode(&changes)
+	require.Len(t, changes, 2)
+	for _, change := range changes {
+		assert.Equal(t, admin.ID, *change.ChangedBy)
+	}
+}
+
+func TestSettingHandler_UpdateSetting_Invalid(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	admin := api.CreateAdmin()
+
+	api.As(admin).Put("/api/v1/admin/settings/"+entity.SettingProductsPageSize,
+		entity.UpdateSettingRequest{Scope: entity.SettingScopeGlobal, Value: "1000"}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrSettingInvalid)
+
+	api.As(admin).Put("/api/v1/admin/settings/unknown.key",
+		entity.UpdateSettingRequest{Scope: entity.SettingScopeGlobal, Value: "1"}).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrSettingNotFound)
+
+	api.As(api.CreateUser()).Put("/api/v1/admin/settings/"+entity.SettingProductsPageSize,
+		entity.UpdateSettingRequest{Scope: entity.SettingScopeGlobal, Value: "20"}).Do().
+		AssertStatus(http.StatusForbidden)
+}
+
+func TestSettingHandler_Preferences(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.Get("/api/v1/settings/me").Do().
+		AssertStatus(http.StatusUnauthorized)
+
+	api.As(user).Put("/api/v1/settings/me/"+entity.SettingFeaturesBeta,
+		entity.UpdatePreferenceRequest{Value: "true"}).Do().
+		AssertStatus(http.StatusOK)
+
+	// Only settings allowing the user scope are preferences
+	api.As(user).Put("/api/v1/settings/me/"+entity.SettingProductsPageSize,
+		entity.UpdatePreferenceRequest{Value: "50"}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrSettingInvalid)
+
+	var values []entity.SettingValue
+	api.As(user).Get("/api/v1/settings/me").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&values)
+	beta := find(t, values, entity.SettingFeaturesBeta)
+	assert.Equal(t, "true", beta.Value)
+	assert.Equal(t, entity.SettingScopeUser, beta.Source)
+
+	// Others and anonymous requests don't see the preference
+	api.Get("/api/v1/settings").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&values)
+	assert.Equal(t, "false", find(t, values, entity.SettingFeaturesBeta).Value)
+
+	api.As(user).Delete("/api/v1/settings/me/" + entity.SettingFeaturesBeta).Do().
+		AssertStatus(http.StatusOK)
+	api.As(user).Get("/api/v1/settings/me").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&values)
+	assert.Equal(t, "false", find(t, values, entity.SettingFeaturesBeta).Value)
+}
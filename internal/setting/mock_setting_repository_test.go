@@ -0,0 +1,59 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package setting
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSettingRepository is a testify mock of SettingRepository
+type MockSettingRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingRepository) GetSettings(ctx context.Context, scope string, scopeID string) ([]*entity.Setting, error) {
+	args := m.Called(ctx, scope, scopeID)
+
+	var r0 []*entity.Setting
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Setting)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingRepository) SaveSetting(ctx context.Context, setting *entity.Setting, change *entity.SettingChange) error {
+	args := m.Called(ctx, setting, change)
+	return args.Error(0)
+}
+
+func (m *MockSettingRepository) DeleteSetting(ctx context.Context, scope string, scopeID string, key string, change *entity.SettingChange) (bool, error) {
+	args := m.Called(ctx, scope, scopeID, key, change)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingRepository) GetSettingChanges(ctx context.Context, filter *entity.SettingChangeFilter) ([]*entity.SettingChange, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.SettingChange
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.SettingChange)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
@@ -0,0 +1,144 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package setting
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSettingUsecase is a testify mock of SettingUsecase
+type MockSettingUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSettingUsecase) GetSettings(ctx context.Context, query *entity.SettingQuery) ([]*entity.SettingValue, error) {
+	args := m.Called(ctx, query)
+
+	var r0 []*entity.SettingValue
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.SettingValue)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingUsecase) UpdateSetting(ctx context.Context, key string, req *entity.UpdateSettingRequest, actorID uuid.UUID) (*entity.SettingValue, error) {
+	args := m.Called(ctx, key, req, actorID)
+
+	var r0 *entity.SettingValue
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SettingValue)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingUsecase) ResetSetting(ctx context.Context, key string, query *entity.SettingQuery, actorID uuid.UUID) (*entity.SettingValue, error) {
+	args := m.Called(ctx, key, query, actorID)
+
+	var r0 *entity.SettingValue
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SettingValue)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingUsecase) GetSettingChanges(ctx context.Context, filter *entity.SettingChangeFilter) ([]*entity.SettingChange, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.SettingChange
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.SettingChange)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockSettingUsecase) GetPublicSettings(ctx context.Context, userID uuid.UUID) ([]*entity.SettingValue, error) {
+	args := m.Called(ctx, userID)
+
+	var r0 []*entity.SettingValue
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.SettingValue)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingUsecase) UpdatePreference(ctx context.Context, key string, req *entity.UpdatePreferenceRequest, userID uuid.UUID) (*entity.SettingValue, error) {
+	args := m.Called(ctx, key, req, userID)
+
+	var r0 *entity.SettingValue
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SettingValue)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingUsecase) ResetPreference(ctx context.Context, key string, userID uuid.UUID) (*entity.SettingValue, error) {
+	args := m.Called(ctx, key, userID)
+
+	var r0 *entity.SettingValue
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SettingValue)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSettingUsecase) String(ctx context.Context, key string, userID uuid.UUID) string {
+	args := m.Called(ctx, key, userID)
+
+	var r0 string
+	if v := args.Get(0); v != nil {
+		r0 = v.(string)
+	}
+
+	return r0
+}
+
+func (m *MockSettingUsecase) Int(ctx context.Context, key string, userID uuid.UUID) int {
+	args := m.Called(ctx, key, userID)
+
+	var r0 int
+	if v := args.Get(0); v != nil {
+		r0 = v.(int)
+	}
+
+	return r0
+}
+
+func (m *MockSettingUsecase) Bool(ctx context.Context, key string, userID uuid.UUID) bool {
+	args := m.Called(ctx, key, userID)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0
+}
+
+func (m *MockSettingUsecase) Duration(ctx context.Context, key string, userID uuid.UUID) time.Duration {
+	args := m.Called(ctx, key, userID)
+
+	var r0 time.Duration
+	if v := args.Get(0); v != nil {
+		r0 = v.(time.Duration)
+	}
+
+	return r0
+}
@@ -0,0 +1,35 @@
+package setting
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SettingUsecase defines the business logic interface for runtime settings.
+// A setting resolves at the user scope, then the tenant of ctx, then the
+// global scope, then its default. Pass uuid.Nil when there is no user.
+type SettingUsecase interface {
+	GetSettings(ctx context.Context, query *entity.SettingQuery) ([]*entity.SettingValue, error)
+	UpdateSetting(ctx context.Context, key string, req *entity.UpdateSettingRequest, actorID uuid.UUID) (*entity.SettingValue, error)
+	ResetSetting(ctx context.Context, key string, query *entity.SettingQuery, actorID uuid.UUID) (*entity.SettingValue, error)
+	GetSettingChanges(ctx context.Context, filter *entity.SettingChangeFilter) ([]*entity.SettingChange, int64, error)
+	GetPublicSettings(ctx context.Context, userID uuid.UUID) ([]*entity.SettingValue, error)
+	UpdatePreference(ctx context.Context, key string, req *entity.UpdatePreferenceRequest, userID uuid.UUID) (*entity.SettingValue, error)
+	ResetPreference(ctx context.Context, key string, userID uuid.UUID) (*entity.SettingValue, error)
+	String(ctx context.Context, key string, userID uuid.UUID) string
+	Int(ctx context.Context, key string, userID uuid.UUID) int
+	Bool(ctx context.Context, key string, userID uuid.UUID) bool
+	Duration(ctx context.Context, key string, userID uuid.UUID) time.Duration
+}
+
+// SettingRepository defines the data access interface for stored settings
+// and their change history
+type SettingRepository interface {
+	GetSettings(ctx context.Context, scope, scopeID string) ([]*entity.Setting, error)
+	SaveSetting(ctx context.Context, setting *entity.Setting, change *entity.SettingChange) error
+	DeleteSetting(ctx context.Context, scope, scopeID, key string, change *entity.SettingChange) (bool, error)
+	GetSettingChanges(ctx context.Context, filter *entity.SettingChangeFilter) ([]*entity.SettingChange, int64, error)
+}
@@ -0,0 +1,109 @@
+package setting
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// settingRepository keeps settings in the main database, even for tenants
+// with a database of their own, since global settings apply to every tenant
+type settingRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingRepository(db *gorm.DB) SettingRepository {
+	return &settingRepository{
+		db: db,
+	}
+}
+
+func (r *settingRepository) GetSettings(ctx context.Context, scope, scopeID string) ([]*entity.Setting, error) {
+	var settings []*entity.Setting
+	err := r.db.WithContext(ctx).
+		Where("scope = ? AND scope_id = ?", scope, scopeID).
+		Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveSetting stores the setting's value at its scope and records the change
+// with the value it replaced. Saving the value already stored records nothing.
+func (r *settingRepository) SaveSetting(ctx context.Context, setting *entity.Setting, change *entity.SettingChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing entity.Setting
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ? AND scope_id = ? AND key = ?", setting.Scope, setting.ScopeID, setting.Key).
+			Limit(1).Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if existing.ID != uuid.Nil {
+			if existing.Value == setting.Value {
+				*setting = existing
+				return nil
+			}
+			change.OldValue = &existing.Value
+			setting.ID = existing.ID
+			setting.CreatedAt = existing.CreatedAt
+			if err := tx.Save(setting).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Create(setting).Error; err != nil {
+			return err
+		}
+
+		change.NewValue = &setting.Value
+		return tx.Create(change).Error
+	})
+}
+
+// DeleteSetting removes the value stored at the scope, recording the change,
+// and reports whether there was one
+func (r *settingRepository) DeleteSetting(ctx context.Context, scope, scopeID, key string, change *entity.SettingChange) (bool, error) {
+	deleted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var removed []entity.Setting
+		err := tx.Clauses(clause.Returning{}).
+			Where("scope = ? AND scope_id = ? AND key = ?", scope, scopeID, key).
+			Delete(&removed).Error
+		if err != nil || len(removed) == 0 {
+			return err
+		}
+
+		deleted = true
+		change.OldValue = &removed[0].Value
+		return tx.Create(change).Error
+	})
+	return deleted, err
+}
+
+func (r *settingRepository) GetSettingChanges(ctx context.Context, filter *entity.SettingChangeFilter) ([]*entity.SettingChange, int64, error) {
+	var changes []*entity.SettingChange
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.SettingChange{})
+	if filter.Key != "" {
+		query = query.Where("key = ?", filter.Key)
+	}
+	if filter.Scope != "" {
+		query = query.Where("scope = ? AND scope_id = ?", filter.Scope, filter.ScopeID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := pagination.Apply(query, filter.Params, "created_at", "id").Find(&changes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return changes, total, nil
+}
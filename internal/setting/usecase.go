@@ -0,0 +1,420 @@
+package setting
+
+import (
+	"context"
+	"fmt"
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/tenancy"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// sourceDefault is the source of settings with no stored value in scope
+const sourceDefault = "default"
+
+// maxCachedScopes bounds the cache, which holds an entry per user who read a
+// setting; it is emptied when full
+const maxCachedScopes = 10000
+
+// scope is a scope with the tenant or user it belongs to
+type scope struct {
+	name string
+	id   string
+}
+
+type cachedScope struct {
+	values   map[string]string
+	loadedAt time.Time
+}
+
+type settingUsecase struct {
+	repo   SettingRepository
+	config *config.Config
+	clock  clock.Clock
+
+	mu    sync.Mutex
+	cache map[scope]cachedScope
+}
+
+func NewSettingUsecase(repo SettingRepository, config *config.Config, clk clock.Clock) SettingUsecase {
+	return &settingUsecase{
+		repo:   repo,
+		config: config,
+		clock:  clk,
+		cache:  make(map[scope]cachedScope),
+	}
+}
+
+// GetSettings lists every setting's effective value at the scope of the
+// query. The user scope inherits from the tenant of ctx.
+func (u *settingUsecase) GetSettings(ctx context.Context, query *entity.SettingQuery) ([]*entity.SettingValue, error) {
+	s, err := scopeOf(ctx, query.Scope, query.ScopeID)
+	if err != nil {
+		return nil, err
+	}
+	return u.values(ctx, u.chain(ctx, s), false)
+}
+
+// UpdateSetting stores the value at the scope of the request, recording the
+// change. Values are checked against the setting's type and normalized, e.g.
+// "1" becomes "true" for bool settings.
+func (u *settingUsecase) UpdateSetting(ctx context.Context, key string, req *entity.UpdateSettingRequest, actorID uuid.UUID) (*entity.SettingValue, error) {
+	s, err := scopeOf(ctx, req.Scope, req.ScopeID)
+	if err != nil {
+		return nil, err
+	}
+	return u.save(ctx, key, s, req.Value, actorID, false)
+}
+
+// ResetSetting removes the value stored at the scope of the query, so the
+// setting inherits again; the returned value is the inherited one
+func (u *settingUsecase) ResetSetting(ctx context.Context, key string, query *entity.SettingQuery, actorID uuid.UUID) (*entity.SettingValue, error) {
+	s, err := scopeOf(ctx, query.Scope, query.ScopeID)
+	if err != nil {
+		return nil, err
+	}
+	return u.reset(ctx, key, s, actorID, false)
+}
+
+// GetSettingChanges lists the recorded changes; requests routed to a tenant
+// see only the changes to the tenant's scope
+func (u *settingUsecase) GetSettingChanges(ctx context.Context, filter *entity.SettingChangeFilter) ([]*entity.SettingChange, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+	filter.Scope, filter.ScopeID = "", ""
+	if tenant := tenancy.FromContext(ctx); tenant != "" {
+		filter.Scope, filter.ScopeID = entity.SettingScopeTenant, tenant
+	}
+
+	changes, total, err := u.repo.GetSettingChanges(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get setting changes", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get setting changes", 500)
+	}
+
+	return changes, total, nil
+}
+
+// GetPublicSettings lists the public settings as they apply to the user, or
+// to anonymous requests when userID is uuid.Nil
+func (u *settingUsecase) GetPublicSettings(ctx context.Context, userID uuid.UUID) ([]*entity.SettingValue, error) {
+	return u.values(ctx, u.requestChain(ctx, userID), true)
+}
+
+// UpdatePreference stores one of the user's preferences: a public setting
+// that may be set at the user scope
+func (u *settingUsecase) UpdatePreference(ctx context.Context, key string, req *entity.UpdatePreferenceRequest, userID uuid.UUID) (*entity.SettingValue, error) {
+	return u.save(ctx, key, userScope(userID), req.Value, userID, true)
+}
+
+// ResetPreference removes the user's preference, so the setting inherits again
+func (u *settingUsecase) ResetPreference(ctx context.Context, key string, userID uuid.UUID) (*entity.SettingValue, error) {
+	return u.reset(ctx, key, userScope(userID), userID, true)
+}
+
+// String returns the setting's effective value for the user. Settings that
+// cannot be read fall back to their default, so a failing settings table
+// doesn't fail the requests reading them.
+func (u *settingUsecase) String(ctx context.Context, key string, userID uuid.UUID) string {
+	def := definition(key)
+	if def == nil {
+		logger.FromContext(ctx).Error("Unknown setting", zap.String("key", key))
+		return ""
+	}
+
+	value, _, err := u.resolve(ctx, def, u.requestChain(ctx, userID))
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to read setting, using its default", zap.String("key", key), zap.Error(err))
+		return def.Default
+	}
+	return value
+}
+
+func (u *settingUsecase) Int(ctx context.Context, key string, userID uuid.UUID) int {
+	n, _ := strconv.Atoi(u.String(ctx, key, userID))
+	return n
+}
+
+func (u *settingUsecase) Bool(ctx context.Context, key string, userID uuid.UUID) bool {
+	b, _ := strconv.ParseBool(u.String(ctx, key, userID))
+	return b
+}
+
+func (u *settingUsecase) Duration(ctx context.Context, key string, userID uuid.UUID) time.Duration {
+	d, _ := time.ParseDuration(u.String(ctx, key, userID))
+	return d
+}
+
+func (u *settingUsecase) save(ctx context.Context, key string, s scope, value string, actorID uuid.UUID, preference bool) (*entity.SettingValue, error) {
+	def, err := settable(key, s, preference)
+	if err != nil {
+		return nil, err
+	}
+	value, err = normalize(def, value)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := &entity.Setting{Scope: s.name, ScopeID: s.id, Key: key, Value: value, UpdatedBy: &actorID}
+	change := &entity.SettingChange{Scope: s.name, ScopeID: s.id, Key: key, ChangedBy: &actorID, CreatedAt: u.clock.Now()}
+	if err := u.repo.SaveSetting(ctx, setting, change); err != nil {
+		logger.FromContext(ctx).Error("Failed to save setting", zap.String("key", key), zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to save setting", 500)
+	}
+	u.invalidate(s)
+
+	logger.FromContext(ctx).Info("Setting changed",
+		zap.String("key", key), zap.String("scope", s.name), zap.String("scope_id", s.id))
+	return u.value(ctx, def, u.chain(ctx, s))
+}
+
+func (u *settingUsecase) reset(ctx context.Context, key string, s scope, actorID uuid.UUID, preference bool) (*entity.SettingValue, error) {
+	def, err := settable(key, s, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &entity.SettingChange{Scope: s.name, ScopeID: s.id, Key: key, ChangedBy: &actorID, CreatedAt: u.clock.Now()}
+	deleted, err := u.repo.DeleteSetting(ctx, s.name, s.id, key, change)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to reset setting", zap.String("key", key), zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to reset setting", 500)
+	}
+	u.invalidate(s)
+
+	if deleted {
+		logger.FromContext(ctx).Info("Setting reset",
+			zap.String("key", key), zap.String("scope", s.name), zap.String("scope_id", s.id))
+	}
+
+	return u.value(ctx, def, u.chain(ctx, s))
+}
+
+// chain returns the scopes a setting at s resolves through, narrowest first.
+// Users inherit from the tenant of ctx.
+func (u *settingUsecase) chain(ctx context.Context, s scope) []scope {
+	global := scope{name: entity.SettingScopeGlobal}
+	switch s.name {
+	case entity.SettingScopeUser:
+		if tenant := tenancy.FromContext(ctx); tenant != "" {
+			return []scope{s, {name: entity.SettingScopeTenant, id: tenant}, global}
+		}
+		return []scope{s, global}
+	case entity.SettingScopeTenant:
+		return []scope{s, global}
+	default:
+		return []scope{global}
+	}
+}
+
+// requestChain returns the scopes settings resolve through for a request by
+// the user, or an anonymous one when userID is uuid.Nil
+func (u *settingUsecase) requestChain(ctx context.Context, userID uuid.UUID) []scope {
+	if userID != uuid.Nil {
+		return u.chain(ctx, userScope(userID))
+	}
+	if tenant := tenancy.FromContext(ctx); tenant != "" {
+		return u.chain(ctx, scope{name: entity.SettingScopeTenant, id: tenant})
+	}
+	return u.chain(ctx, scope{name: entity.SettingScopeGlobal})
+}
+
+func (u *settingUsecase) values(ctx context.Context, chain []scope, publicOnly bool) ([]*entity.SettingValue, error) {
+	values := make([]*entity.SettingValue, 0, len(Definitions))
+	for i := range Definitions {
+		def := &Definitions[i]
+		if publicOnly && !def.Public {
+			continue
+		}
+		value, err := u.value(ctx, def, chain)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (u *settingUsecase) value(ctx context.Context, def *entity.SettingDefinition, chain []scope) (*entity.SettingValue, error) {
+	value, source, err := u.resolve(ctx, def, chain)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get settings", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get settings", 500)
+	}
+
+	return &entity.SettingValue{
+		Key:         def.Key,
+		Type:        def.Type,
+		Value:       value,
+		Source:      source,
+		Default:     def.Default,
+		Scopes:      def.Scopes,
+		Description: def.Description,
+	}, nil
+}
+
+// resolve returns the value of the narrowest scope in chain that stores one,
+// and the scope's name. Stored values the definition no longer accepts, e.g.
+// after its bounds changed, are skipped.
+func (u *settingUsecase) resolve(ctx context.Context, def *entity.SettingDefinition, chain []scope) (string, string, error) {
+	for _, s := range chain {
+		if !def.AllowsScope(s.name) {
+			continue
+		}
+		values, err := u.load(ctx, s)
+		if err != nil {
+			return "", "", err
+		}
+		if value, ok := values[def.Key]; ok {
+			if _, err := normalize(def, value); err == nil {
+				return value, s.name, nil
+			}
+		}
+	}
+	return def.Default, sourceDefault, nil
+}
+
+// load returns the values stored at the scope, from the cache when they were
+// loaded within the cache TTL
+func (u *settingUsecase) load(ctx context.Context, s scope) (map[string]string, error) {
+	ttl := u.config.Settings.CacheTTL
+	now := u.clock.Now()
+
+	u.mu.Lock()
+	cached, ok := u.cache[s]
+	u.mu.Unlock()
+	if ok && now.Sub(cached.loadedAt) < ttl {
+		return cached.values, nil
+	}
+
+	settings, err := u.repo.GetSettings(ctx, s.name, s.id)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		values[setting.Key] = setting.Value
+	}
+
+	if ttl > 0 {
+		u.mu.Lock()
+		if len(u.cache) >= maxCachedScopes {
+			u.cache = make(map[scope]cachedScope)
+		}
+		u.cache[s] = cachedScope{values: values, loadedAt: now}
+		u.mu.Unlock()
+	}
+	return values, nil
+}
+
+func (u *settingUsecase) invalidate(s scope) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.cache, s)
+}
+
+// settable returns the definition of key if it may be set at the scope.
+// Preferences are the public settings users may set for themselves.
+func settable(key string, s scope, preference bool) (*entity.SettingDefinition, error) {
+	def := definition(key)
+	if def == nil || preference && !def.Public {
+		return nil, errors.ErrSettingNotFoundError
+	}
+	if !def.AllowsScope(s.name) {
+		return nil, errors.New(errors.ErrSettingInvalid, fmt.Sprintf("Setting cannot be set at the %s scope", s.name), 400).
+			WithDetails(map[string]interface{}{"scopes": def.Scopes})
+	}
+	return def, nil
+}
+
+// scopeOf checks the scope and the tenant or user it names. No scope means
+// the global one, or the tenant's for requests routed to a tenant.
+//
+// Settings live in the main database, so only requests that are not routed
+// to a tenant may manage the global scope, other tenants or users; an admin
+// of a tenant manages the tenant's scope only.
+func scopeOf(ctx context.Context, name, id string) (scope, error) {
+	tenant := tenancy.FromContext(ctx)
+	if tenant != "" {
+		if name == "" && id == "" {
+			name, id = entity.SettingScopeTenant, tenant
+		}
+		if name != entity.SettingScopeTenant || id != tenant {
+			return scope{}, errors.New(errors.ErrForbidden, "Admins of a tenant can only manage the tenant's settings", 403)
+		}
+	}
+
+	switch name {
+	case "", entity.SettingScopeGlobal:
+		if id != "" {
+			return scope{}, errors.New(errors.ErrSettingInvalid, "The global scope takes no scope_id", 400)
+		}
+		return scope{name: entity.SettingScopeGlobal}, nil
+	case entity.SettingScopeTenant:
+		if id == "" {
+			return scope{}, errors.New(errors.ErrSettingInvalid, "The tenant scope needs the tenant ID in scope_id", 400)
+		}
+	case entity.SettingScopeUser:
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return scope{}, errors.New(errors.ErrSettingInvalid, "The user scope needs the user ID in scope_id", 400)
+		}
+		id = userID.String()
+	default:
+		return scope{}, errors.New(errors.ErrSettingInvalid, "Unknown scope "+name, 400)
+	}
+	return scope{name: name, id: id}, nil
+}
+
+func userScope(userID uuid.UUID) scope {
+	return scope{name: entity.SettingScopeUser, id: userID.String()}
+}
+
+// normalize checks value against the setting's type and bounds and returns
+// it in canonical form
+func normalize(def *entity.SettingDefinition, value string) (string, error) {
+	invalid := func(message string) error {
+		return errors.New(errors.ErrSettingInvalid, message, 400).WithDetails(map[string]interface{}{"type": def.Type})
+	}
+
+	switch def.Type {
+	case entity.SettingInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", invalid("Value must be a whole number")
+		}
+		if def.Min != nil && n < *def.Min || def.Max != nil && n > *def.Max {
+			return "", invalid(fmt.Sprintf("Value must be between %s and %s", bound(def.Min), bound(def.Max)))
+		}
+		return strconv.Itoa(n), nil
+	case entity.SettingBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", invalid("Value must be true or false")
+		}
+		return strconv.FormatBool(b), nil
+	case entity.SettingDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return "", invalid("Value must be a duration such as 30s or 2h")
+		}
+		return d.String(), nil
+	default:
+		return value, nil
+	}
+}
+
+func bound(n *int) string {
+	if n == nil {
+		return "any"
+	}
+	return strconv.Itoa(*n)
+}
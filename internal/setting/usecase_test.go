@@ -0,0 +1,317 @@
+package setting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testDeps struct {
+	repo    *MockSettingRepository
+	clock   *clock.Fake
+	usecase SettingUsecase
+}
+
+func newTestUsecase(cacheTTL time.Duration) *testDeps {
+	d := &testDeps{
+		repo:  new(MockSettingRepository),
+		clock: clock.NewFake(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)),
+	}
+	cfg := &config.Config{Settings: config.SettingsConfig{CacheTTL: cacheTTL}}
+	d.usecase = NewSettingUsecase(d.repo, cfg, d.clock)
+	return d
+}
+
+// stored stubs the values stored at the scope
+func (d *testDeps) stored(scope, scopeID string, values map[string]string) {
+	var settings []*entity.Setting
+	for key, value := range values {
+		settings = append(settings, &entity.Setting{Scope: scope, ScopeID: scopeID, Key: key, Value: value})
+	}
+	d.repo.On("GetSettings", mock.Anything, scope, scopeID).Return(settings, nil)
+}
+
+func assertCode(t *testing.T, code string, err error) {
+	t.Helper()
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestSettingUsecase_Int_ResolvesNarrowestScope(t *testing.T) {
+	userID := uuid.New()
+	ctx := tenancy.WithTenant(context.Background(), "acme")
+
+	tests := []struct {
+		name   string
+		global map[string]string
+		tenant map[string]string
+		want   int
+	}{
+		{name: "default", want: 10},
+		{name: "global", global: map[string]string{entity.SettingProductsPageSize: "20"}, want: 20},
+		{
+			name:   "tenant overrides global",
+			global: map[string]string{entity.SettingProductsPageSize: "20"},
+			tenant: map[string]string{entity.SettingProductsPageSize: "30"},
+			want:   30,
+		},
+		{
+			name:   "invalid stored value is skipped",
+			global: map[string]string{entity.SettingProductsPageSize: "20"},
+			tenant: map[string]string{entity.SettingProductsPageSize: "500"},
+			want:   20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(0)
+			d.stored(entity.SettingScopeGlobal, "", tt.global)
+			d.stored(entity.SettingScopeTenant, "acme", tt.tenant)
+
+			// products.page_size cannot be set per user, so the user scope is never read
+			assert.Equal(t, tt.want, d.usecase.Int(ctx, entity.SettingProductsPageSize, userID))
+			d.repo.AssertNotCalled(t, "GetSettings", mock.Anything, entity.SettingScopeUser, mock.Anything)
+		})
+	}
+}
+
+func TestSettingUsecase_Bool_UserPreferenceOverridesTenant(t *testing.T) {
+	d := newTestUsecase(0)
+	userID := uuid.New()
+	ctx := tenancy.WithTenant(context.Background(), "acme")
+
+	d.stored(entity.SettingScopeUser, userID.String(), map[string]string{entity.SettingFeaturesBeta: "true"})
+	d.stored(entity.SettingScopeTenant, "acme", map[string]string{entity.SettingFeaturesBeta: "false"})
+	d.stored(entity.SettingScopeGlobal, "", nil)
+
+	assert.True(t, d.usecase.Bool(ctx, entity.SettingFeaturesBeta, userID))
+	assert.False(t, d.usecase.Bool(ctx, entity.SettingFeaturesBeta, uuid.Nil))
+}
+
+func TestSettingUsecase_String_FallsBackToDefaultOnError(t *testing.T) {
+	d := newTestUsecase(0)
+	d.repo.On("GetSettings", mock.Anything, entity.SettingScopeGlobal, "").Return(nil, assert.AnError)
+
+	assert.Equal(t, "10", d.usecase.String(context.Background(), entity.SettingProductsPageSize, uuid.Nil))
+	assert.Equal(t, "", d.usecase.String(context.Background(), "unknown.key", uuid.Nil))
+}
+
+func TestSettingUsecase_Cache(t *testing.T) {
+	d := newTestUsecase(30 * time.Second)
+	d.stored(entity.SettingScopeGlobal, "", map[string]string{entity.SettingProductsPageSize: "20"})
+	ctx := context.Background()
+
+	assert.Equal(t, 20, d.usecase.Int(ctx, entity.SettingProductsPageSize, uuid.Nil))
+	assert.Equal(t, 20, d.usecase.Int(ctx, entity.SettingProductsPageSize, uuid.Nil))
+	d.repo.AssertNumberOfCalls(t, "GetSettings", 1)
+
+	d.clock.Advance(30 * time.Second)
+	assert.Equal(t, 20, d.usecase.Int(ctx, entity.SettingProductsPageSize, uuid.Nil))
+	d.repo.AssertNumberOfCalls(t, "GetSettings", 2)
+
+	// a write drops the scope from the cache
+	d.repo.On("SaveSetting", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	_, err := d.usecase.UpdateSetting(ctx, entity.SettingProductsPageSize,
+		&entity.UpdateSettingRequest{Scope: entity.SettingScopeGlobal, Value: "20"}, uuid.New())
+	require.NoError(t, err)
+	d.repo.AssertNumberOfCalls(t, "GetSettings", 3)
+}
+
+func TestSettingUsecase_UpdateSetting_Normalizes(t *testing.T) {
+	d := newTestUsecase(0)
+	actorID := uuid.New()
+	d.stored(entity.SettingScopeTenant, "acme", map[string]string{entity.SettingFeaturesBeta: "true"})
+	d.repo.On("SaveSetting", mock.Anything, mock.MatchedBy(func(s *entity.Setting) bool {
+		return s.Scope == entity.SettingScopeTenant && s.ScopeID == "acme" && s.Value == "true" && *s.UpdatedBy == actorID
+	}), mock.MatchedBy(func(c *entity.SettingChange) bool {
+		return c.Key == entity.SettingFeaturesBeta && *c.ChangedBy == actorID
+	})).Return(nil).Once()
+
+	value, err := d.usecase.UpdateSetting(context.Background(), entity.SettingFeaturesBeta,
+		&entity.UpdateSettingRequest{Scope: entity.SettingScopeTenant, ScopeID: "acme", Value: "1"}, actorID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "true", value.Value)
+	assert.Equal(t, entity.SettingScopeTenant, value.Source)
+	d.repo.AssertExpectations(t)
+}
+
+func TestSettingUsecase_UpdateSetting_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		req  entity.UpdateSettingRequest
+		code string
+	}{
+		{name: "unknown key", key: "unknown.key", req: entity.UpdateSettingRequest{Scope: "global", Value: "x"}, code: errors.ErrSettingNotFound},
+		{name: "not a number", key: entity.SettingProductsPageSize, req: entity.UpdateSettingRequest{Scope: "global", Value: "ten"}, code: errors.ErrSettingInvalid},
+		{name: "out of bounds", key: entity.SettingProductsPageSize, req: entity.UpdateSettingRequest{Scope: "global", Value: "101"}, code: errors.ErrSettingInvalid},
+		{name: "not a bool", key: entity.SettingFeaturesBeta, req: entity.UpdateSettingRequest{Scope: "global", Value: "maybe"}, code: errors.ErrSettingInvalid},
+		{name: "scope not allowed", key: entity.SettingProductsPageSize, req: entity.UpdateSettingRequest{Scope: "user", ScopeID: uuid.NewString(), Value: "20"}, code: errors.ErrSettingInvalid},
+		{name: "global with scope_id", key: entity.SettingFeaturesBeta, req: entity.UpdateSettingRequest{Scope: "global", ScopeID: "acme", Value: "true"}, code: errors.ErrSettingInvalid},
+		{name: "tenant without scope_id", key: entity.SettingFeaturesBeta, req: entity.UpdateSettingRequest{Scope: "tenant", Value: "true"}, code: errors.ErrSettingInvalid},
+		{name: "user without user ID", key: entity.SettingFeaturesBeta, req: entity.UpdateSettingRequest{Scope: "user", ScopeID: "bob", Value: "true"}, code: errors.ErrSettingInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(0)
+
+			_, err := d.usecase.UpdateSetting(context.Background(), tt.key, &tt.req, uuid.New())
+
+			assertCode(t, tt.code, err)
+			d.repo.AssertNotCalled(t, "SaveSetting", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSettingUsecase_UpdatePreference_OnlyPublicUserSettings(t *testing.T) {
+	d := newTestUsecase(0)
+	userID := uuid.New()
+
+	_, err := d.usecase.UpdatePreference(context.Background(), entity.SettingProductsPageSize,
+		&entity.UpdatePreferenceRequest{Value: "20"}, userID)
+	assertCode(t, errors.ErrSettingInvalid, err)
+
+	d.stored(entity.SettingScopeUser, userID.String(), map[string]string{entity.SettingFeaturesBeta: "true"})
+	d.repo.On("SaveSetting", mock.Anything, mock.MatchedBy(func(s *entity.Setting) bool {
+		return s.Scope == entity.SettingScopeUser && s.ScopeID == userID.String()
+	}), mock.Anything).Return(nil).Once()
+
+	value, err := d.usecase.UpdatePreference(context.Background(), entity.SettingFeaturesBeta,
+		&entity.UpdatePreferenceRequest{Value: "true"}, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.SettingScopeUser, value.Source)
+	d.repo.AssertExpectations(t)
+}
+
+func TestSettingUsecase_ResetSetting_ReturnsInheritedValue(t *testing.T) {
+	d := newTestUsecase(0)
+	d.stored(entity.SettingScopeTenant, "acme", nil)
+	d.stored(entity.SettingScopeGlobal, "", map[string]string{entity.SettingProductsPageSize: "20"})
+	d.repo.On("DeleteSetting", mock.Anything, entity.SettingScopeTenant, "acme", entity.SettingProductsPageSize, mock.Anything).
+		Return(true, nil).Once()
+
+	value, err := d.usecase.ResetSetting(context.Background(), entity.SettingProductsPageSize,
+		&entity.SettingQuery{Scope: entity.SettingScopeTenant, ScopeID: "acme"}, uuid.New())
+
+	require.NoError(t, err)
+	assert.Equal(t, "20", value.Value)
+	assert.Equal(t, entity.SettingScopeGlobal, value.Source)
+	d.repo.AssertExpectations(t)
+}
+
+func TestSettingUsecase_GetPublicSettings(t *testing.T) {
+	d := newTestUsecase(0)
+	d.stored(entity.SettingScopeGlobal, "", map[string]string{entity.SettingMaintenanceMessage: "Back soon"})
+
+	values, err := d.usecase.GetPublicSettings(context.Background(), uuid.Nil)
+
+	require.NoError(t, err)
+	byKey := make(map[string]*entity.SettingValue)
+	for _, v := range values {
+		byKey[v.Key] = v
+	}
+	assert.Equal(t, "Back soon", byKey[entity.SettingMaintenanceMessage].Value)
+	assert.Equal(t, sourceDefault, byKey[entity.SettingFeaturesBeta].Source)
+}
+
+func TestSettingUsecase_TenantAdminLimitedToTenantScope(t *testing.T) {
+	ctx := tenancy.WithTenant(context.Background(), "acme")
+
+	tests := []struct {
+		name  string
+		query entity.SettingQuery
+	}{
+		{name: "global", query: entity.SettingQuery{Scope: entity.SettingScopeGlobal}},
+		{name: "other tenant", query: entity.SettingQuery{Scope: entity.SettingScopeTenant, ScopeID: "globex"}},
+		{name: "user", query: entity.SettingQuery{Scope: entity.SettingScopeUser, ScopeID: uuid.NewString()}},
+		{name: "tenant without scope_id", query: entity.SettingQuery{Scope: entity.SettingScopeTenant}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(0)
+
+			_, err := d.usecase.GetSettings(ctx, &tt.query)
+			assertCode(t, errors.ErrForbidden, err)
+
+			_, err = d.usecase.UpdateSetting(ctx, entity.SettingFeaturesBeta,
+				&entity.UpdateSettingRequest{Scope: tt.query.Scope, ScopeID: tt.query.ScopeID, Value: "true"}, uuid.New())
+			assertCode(t, errors.ErrForbidden, err)
+
+			_, err = d.usecase.ResetSetting(ctx, entity.SettingFeaturesBeta, &tt.query, uuid.New())
+			assertCode(t, errors.ErrForbidden, err)
+
+			d.repo.AssertNotCalled(t, "GetSettings", mock.Anything, mock.Anything, mock.Anything)
+			d.repo.AssertNotCalled(t, "SaveSetting", mock.Anything, mock.Anything, mock.Anything)
+			d.repo.AssertNotCalled(t, "DeleteSetting", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSettingUsecase_TenantAdminManagesOwnTenant(t *testing.T) {
+	d := newTestUsecase(0)
+	ctx := tenancy.WithTenant(context.Background(), "acme")
+	d.stored(entity.SettingScopeTenant, "acme", map[string]string{entity.SettingFeaturesBeta: "true"})
+	d.stored(entity.SettingScopeGlobal, "", nil)
+	d.repo.On("SaveSetting", mock.Anything, mock.MatchedBy(func(s *entity.Setting) bool {
+		return s.Scope == entity.SettingScopeTenant && s.ScopeID == "acme"
+	}), mock.Anything).Return(nil).Once()
+
+	_, err := d.usecase.UpdateSetting(ctx, entity.SettingFeaturesBeta,
+		&entity.UpdateSettingRequest{Scope: entity.SettingScopeTenant, ScopeID: "acme", Value: "true"}, uuid.New())
+	require.NoError(t, err)
+
+	// No scope means the tenant's
+	values, err := d.usecase.GetSettings(ctx, &entity.SettingQuery{})
+	require.NoError(t, err)
+	for _, v := range values {
+		if v.Key == entity.SettingFeaturesBeta {
+			assert.Equal(t, entity.SettingScopeTenant, v.Source)
+		}
+	}
+	d.repo.AssertNotCalled(t, "GetSettings", mock.Anything, entity.SettingScopeUser, mock.Anything)
+	d.repo.AssertExpectations(t)
+}
+
+func TestSettingUsecase_GetSettingChanges_FiltersByTenant(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		scope   string
+		scopeID string
+	}{
+		{name: "main database", ctx: context.Background()},
+		{name: "tenant", ctx: tenancy.WithTenant(context.Background(), "acme"), scope: entity.SettingScopeTenant, scopeID: "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase(0)
+			d.repo.On("GetSettingChanges", mock.Anything, mock.MatchedBy(func(f *entity.SettingChangeFilter) bool {
+				return f.Scope == tt.scope && f.ScopeID == tt.scopeID
+			})).Return([]*entity.SettingChange{}, int64(0), nil).Once()
+
+			// Scope and ScopeID are not taken from the caller
+			_, _, err := d.usecase.GetSettingChanges(tt.ctx, &entity.SettingChangeFilter{Scope: entity.SettingScopeTenant, ScopeID: "globex"})
+
+			require.NoError(t, err)
+			d.repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package sso
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go-clean-gin/internal/entity"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConnections reads the connections from the YAML file at path:
+//
+//	connections:
+//	  - name: acme
+//	    issuer: https://login.acme.com
+//	    client_id: our-app
+//	    client_secret: secret
+//	    domains: [acme.com]
+//	    groups_claim: groups
+//	    role_mapping:
+//	      app-admins: admin
+//
+// Every connection needs domains: it may only sign in emails of domains its
+// organization owns. An empty path means no connections.
+func LoadConnections(path string) ([]entity.SSOConnection, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Connections []entity.SSOConnection `yaml:"connections"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	names := make(map[string]bool)
+	domains := make(map[string]string)
+	for i := range file.Connections {
+		conn := &file.Connections[i]
+		if conn.Name == "" || conn.Issuer == "" || conn.ClientID == "" {
+			return nil, fmt.Errorf("%s: connection %d needs a name, issuer and client_id", path, i+1)
+		}
+		if names[conn.Name] {
+			return nil, fmt.Errorf("%s: connection %q is listed twice", path, conn.Name)
+		}
+		names[conn.Name] = true
+
+		if len(conn.Domains) == 0 {
+			return nil, fmt.Errorf("%s: connection %q needs the domains whose emails it may sign in", path, conn.Name)
+		}
+		for j, domain := range conn.Domains {
+			domain = strings.ToLower(strings.TrimSpace(domain))
+			if domain == "" {
+				return nil, fmt.Errorf("%s: connection %q lists an empty domain", path, conn.Name)
+			}
+			if other, ok := domains[domain]; ok {
+				return nil, fmt.Errorf("%s: domain %q belongs to both %q and %q", path, domain, other, conn.Name)
+			}
+			domains[domain] = conn.Name
+			conn.Domains[j] = domain
+		}
+
+		if conn.DefaultRole == "" {
+			conn.DefaultRole = entity.RoleUser
+		}
+		for _, role := range append([]string{conn.DefaultRole}, mapValues(conn.RoleMapping)...) {
+			if !slices.Contains(entity.ValidRoles, role) {
+				return nil, fmt.Errorf("%s: connection %q maps to unknown role %q", path, conn.Name, role)
+			}
+		}
+	}
+	return file.Connections, nil
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,107 @@
+package sso
+
+import (
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SSOHandler struct {
+	usecase SSOUsecase
+}
+
+func NewSSOHandler(usecase SSOUsecase) *SSOHandler {
+	return &SSOHandler{
+		usecase: usecase,
+	}
+}
+
+// Start godoc
+// @Summary Start single sign-on
+// @Description Start signing in with an external identity provider, picked by connection name or by the email's domain. Send the user to the returned authorization_url.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body entity.SSOStartRequest true "Connection or email"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Failure 503 {object} response.Response
+// @Router /auth/sso/start [post]
+func (h *SSOHandler) Start(c *gin.Context) {
+	var req entity.SSOStartRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	result, err := h.usecase.Start(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to start sign-in", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to start sign-in", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Sign-in started", result)
+}
+
+// Callback godoc
+// @Summary Complete single sign-on
+// @Description Complete signing in with the code and state the identity provider redirected back with. Users are created on their first sign-in.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body entity.SSOCallbackRequest true "Code and state from the identity provider"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /auth/sso/callback [post]
+func (h *SSOHandler) Callback(c *gin.Context) {
+	var req entity.SSOCallbackRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	result, err := h.usecase.Callback(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to complete sign-in", zap.Error(err))
+
+		if appErr, ok := err.(*errors.AppError); ok {
+			response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(c, 500, errors.ErrInternal, "Failed to complete sign-in", nil)
+		}
+		return
+	}
+
+	response.Success(c, 200, "Login successful", result)
+}
@@ -0,0 +1,40 @@
+package sso_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/test/apitest"
+)
+
+func TestSSOHandler_Start_UnknownConnection(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Post("/api/v1/auth/sso/start", entity.SSOStartRequest{Email: "jane@unknown.example.com"}).Do().
+		AssertStatus(http.StatusNotFound).
+		AssertErrorCode(errors.ErrSSOConnectionNotFound)
+}
+
+func TestSSOHandler_Start_Validation(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Post("/api/v1/auth/sso/start", entity.SSOStartRequest{}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrValidation)
+}
+
+func TestSSOHandler_Callback_UnknownState(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+
+	api.Post("/api/v1/auth/sso/callback", entity.SSOCallbackRequest{Code: "code", State: "unknown"}).Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertErrorCode(errors.ErrSSOStateInvalid)
+}
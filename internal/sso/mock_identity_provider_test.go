@@ -0,0 +1,38 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package sso
+
+import (
+	"context"
+
+	"go-clean-gin/pkg/oidcclient"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIdentityProvider is a testify mock of IdentityProvider
+type MockIdentityProvider struct {
+	mock.Mock
+}
+
+func (m *MockIdentityProvider) AuthCodeURL(ctx context.Context, redirectURI string, state string, nonce string, codeChallenge string, scopes []string) (string, error) {
+	args := m.Called(ctx, redirectURI, state, nonce, codeChallenge, scopes)
+
+	var r0 string
+	if v := args.Get(0); v != nil {
+		r0 = v.(string)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockIdentityProvider) Exchange(ctx context.Context, code string, codeVerifier string, redirectURI string, nonce string) (*oidcclient.Claims, error) {
+	args := m.Called(ctx, code, codeVerifier, redirectURI, nonce)
+
+	var r0 *oidcclient.Claims
+	if v := args.Get(0); v != nil {
+		r0 = v.(*oidcclient.Claims)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,88 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package sso
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSSORepository is a testify mock of SSORepository
+type MockSSORepository struct {
+	mock.Mock
+}
+
+func (m *MockSSORepository) GetUserByIdentity(ctx context.Context, connection string, issuer string, subject string) (*entity.User, error) {
+	args := m.Called(ctx, connection, issuer, subject)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSSORepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	args := m.Called(ctx, email)
+
+	var r0 *entity.User
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.User)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSSORepository) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	args := m.Called(ctx, username)
+
+	var r0 bool
+	if v := args.Get(0); v != nil {
+		r0 = v.(bool)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSSORepository) CreateUser(ctx context.Context, user *entity.User, identity *entity.SSOIdentity) error {
+	args := m.Called(ctx, user, identity)
+	return args.Error(0)
+}
+
+func (m *MockSSORepository) UpdateRole(ctx context.Context, userID uuid.UUID, role string) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockSSORepository) CreateLogin(ctx context.Context, login *entity.SSOLogin) error {
+	args := m.Called(ctx, login)
+	return args.Error(0)
+}
+
+func (m *MockSSORepository) ConsumeLogin(ctx context.Context, stateHash string) (*entity.SSOLogin, error) {
+	args := m.Called(ctx, stateHash)
+
+	var r0 *entity.SSOLogin
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SSOLogin)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSSORepository) DeleteExpiredLogins(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,49 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package sso
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSSOUsecase is a testify mock of SSOUsecase
+type MockSSOUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSSOUsecase) Start(ctx context.Context, req *entity.SSOStartRequest) (*entity.SSOStartResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.SSOStartResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.SSOStartResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSSOUsecase) Callback(ctx context.Context, req *entity.SSOCallbackRequest) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockSSOUsecase) PruneLogins(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,27 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package sso
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSessionStarter is a testify mock of SessionStarter
+type MockSessionStarter struct {
+	mock.Mock
+}
+
+func (m *MockSessionStarter) StartSession(ctx context.Context, user *entity.User, rememberMe bool) (*entity.AuthResponse, error) {
+	args := m.Called(ctx, user, rememberMe)
+
+	var r0 *entity.AuthResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.AuthResponse)
+	}
+
+	return r0, args.Error(1)
+}
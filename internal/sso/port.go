@@ -0,0 +1,42 @@
+package sso
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/oidcclient"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSOUsecase defines the business logic interface for single sign-on
+type SSOUsecase interface {
+	Start(ctx context.Context, req *entity.SSOStartRequest) (*entity.SSOStartResponse, error)
+	Callback(ctx context.Context, req *entity.SSOCallbackRequest) (*entity.AuthResponse, error)
+	PruneLogins(ctx context.Context) (int64, error)
+}
+
+// SSORepository defines the data access interface for single sign-on
+type SSORepository interface {
+	GetUserByIdentity(ctx context.Context, connection, issuer, subject string) (*entity.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	UsernameTaken(ctx context.Context, username string) (bool, error)
+	CreateUser(ctx context.Context, user *entity.User, identity *entity.SSOIdentity) error
+	UpdateRole(ctx context.Context, userID uuid.UUID, role string) error
+	CreateLogin(ctx context.Context, login *entity.SSOLogin) error
+	ConsumeLogin(ctx context.Context, stateHash string) (*entity.SSOLogin, error)
+	DeleteExpiredLogins(ctx context.Context, before time.Time) (int64, error)
+}
+
+// IdentityProvider is an external OpenID Connect provider, implemented by
+// oidcclient.Provider
+type IdentityProvider interface {
+	AuthCodeURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string, scopes []string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, redirectURI, nonce string) (*oidcclient.Claims, error)
+}
+
+// SessionStarter signs in users the identity provider authenticated,
+// implemented by auth.AuthUsecase
+type SessionStarter interface {
+	StartSession(ctx context.Context, user *entity.User, rememberMe bool) (*entity.AuthResponse, error)
+}
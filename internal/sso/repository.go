@@ -0,0 +1,95 @@
+package sso
+
+import (
+	"context"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/tenancy"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ssoRepository struct {
+	db *gorm.DB
+}
+
+func NewSSORepository(db *gorm.DB) SSORepository {
+	return &ssoRepository{
+		db: db,
+	}
+}
+
+// GetUserByIdentity finds the user the connection provisioned for the IdP
+// account, deactivated or not
+func (r *ssoRepository) GetUserByIdentity(ctx context.Context, connection, issuer, subject string) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).
+		Joins("JOIN tb_sso_identities i ON i.user_id = tb_users.id").
+		Where("i.connection = ? AND i.issuer = ? AND i.subject = ?", connection, issuer, subject).
+		First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByEmail finds the user with the email, deactivated or not, so an
+// existing account is refused instead of provisioned again
+func (r *ssoRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	var user entity.User
+	err := tenancy.Conn(ctx, r.db).Where("email = ?", email).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UsernameTaken reports whether any user holds the username. Deleted users
+// count, since the unique index still covers them.
+func (r *ssoRepository) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	var count int64
+	err := tenancy.Conn(ctx, r.db).Unscoped().Model(&entity.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+// CreateUser creates the user linked to the IdP account that signed in
+func (r *ssoRepository) CreateUser(ctx context.Context, user *entity.User, identity *entity.SSOIdentity) error {
+	return tenancy.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
+func (r *ssoRepository) UpdateRole(ctx context.Context, userID uuid.UUID, role string) error {
+	return tenancy.Conn(ctx, r.db).Model(&entity.User{}).Where("id = ?", userID).Update("role", role).Error
+}
+
+func (r *ssoRepository) CreateLogin(ctx context.Context, login *entity.SSOLogin) error {
+	return tenancy.Conn(ctx, r.db).Create(login).Error
+}
+
+// ConsumeLogin deletes the started sign-in and returns it, so it can be
+// completed once even when two callbacks race
+func (r *ssoRepository) ConsumeLogin(ctx context.Context, stateHash string) (*entity.SSOLogin, error) {
+	var login entity.SSOLogin
+	result := tenancy.Conn(ctx, r.db).Clauses(clause.Returning{}).
+		Where("state_hash = ?", stateHash).
+		Delete(&login)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &login, nil
+}
+
+func (r *ssoRepository) DeleteExpiredLogins(ctx context.Context, before time.Time) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Where("expires_at < ?", before).Delete(&entity.SSOLogin{})
+	return result.RowsAffected, result.Error
+}
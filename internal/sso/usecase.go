@@ -0,0 +1,310 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// scopes requested from the identity providers
+var scopes = []string{"openid", "email", "profile"}
+
+// usernameUnsafe matches what cannot be part of a provisioned username
+var usernameUnsafe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+type ssoUsecase struct {
+	repo        SSORepository
+	config      *config.Config
+	connections []entity.SSOConnection
+	providers   map[string]IdentityProvider
+	sessions    SessionStarter
+	clock       clock.Clock
+}
+
+// NewSSOUsecase creates the usecase for the connections, with the identity
+// provider of each connection keyed by its name
+func NewSSOUsecase(repo SSORepository, config *config.Config, connections []entity.SSOConnection, providers map[string]IdentityProvider, sessions SessionStarter, clk clock.Clock) SSOUsecase {
+	return &ssoUsecase{
+		repo:        repo,
+		config:      config,
+		connections: connections,
+		providers:   providers,
+		sessions:    sessions,
+		clock:       clk,
+	}
+}
+
+// Start begins a sign-in with the connection named in the request, or the
+// one for the email's domain, and returns where to send the user
+func (u *ssoUsecase) Start(ctx context.Context, req *entity.SSOStartRequest) (*entity.SSOStartResponse, error) {
+	conn, ok := u.connection(req)
+	if !ok {
+		return nil, errors.ErrSSOConnectionNotFoundError
+	}
+
+	var state, nonce, verifier string
+	for _, token := range []*string{&state, &nonce, &verifier} {
+		var err error
+		if *token, err = newSecretToken(); err != nil {
+			logger.FromContext(ctx).Error("Failed to generate SSO state", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to start sign-in", 500)
+		}
+	}
+
+	login := &entity.SSOLogin{
+		StateHash:    hashSecretToken(state),
+		Connection:   conn.Name,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		RememberMe:   req.RememberMe,
+		ExpiresAt:    u.clock.Now().Add(u.config.SSO.StateTTL),
+	}
+	if err := u.repo.CreateLogin(ctx, login); err != nil {
+		logger.FromContext(ctx).Error("Failed to store SSO login", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to start sign-in", 500)
+	}
+
+	authURL, err := u.providers[conn.Name].AuthCodeURL(ctx, u.config.SSO.CallbackURL, state, nonce, pkceChallenge(verifier), scopes)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to reach identity provider", zap.String("connection", conn.Name), zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrUnavailable, "Identity provider is not available", 503)
+	}
+
+	return &entity.SSOStartResponse{AuthorizationURL: authURL}, nil
+}
+
+// Callback completes a sign-in once the identity provider redirected back.
+// Users are provisioned on their first sign-in and matched on their IdP
+// account afterwards; an existing account with the same email is refused
+// rather than taken over. With a role mapping the role of provisioned users
+// follows their IdP groups.
+func (u *ssoUsecase) Callback(ctx context.Context, req *entity.SSOCallbackRequest) (*entity.AuthResponse, error) {
+	login, err := u.repo.ConsumeLogin(ctx, hashSecretToken(req.State))
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrSSOStateInvalidError
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to consume SSO login", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+	}
+	if !u.clock.Now().Before(login.ExpiresAt) {
+		return nil, errors.ErrSSOStateInvalidError
+	}
+
+	conn, ok := u.connectionByName(login.Connection)
+	if !ok {
+		return nil, errors.ErrSSOStateInvalidError
+	}
+
+	claims, err := u.providers[conn.Name].Exchange(ctx, req.Code, login.CodeVerifier, u.config.SSO.CallbackURL, login.Nonce)
+	if err != nil {
+		logger.FromContext(ctx).Warn("SSO code exchange failed", zap.String("connection", conn.Name), zap.Error(err))
+		return nil, errors.ErrSSOFailedError
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if claims.Subject == "" || email == "" || claims.EmailVerified == nil || !*claims.EmailVerified {
+		logger.FromContext(ctx).Warn("SSO identity has no verified email", zap.String("connection", conn.Name))
+		return nil, errors.ErrSSOFailedError
+	}
+	if _, domain, _ := strings.Cut(email, "@"); !slices.Contains(conn.Domains, domain) {
+		logger.FromContext(ctx).Warn("SSO email outside the connection's domains",
+			zap.String("connection", conn.Name), zap.String("domain", domain))
+		return nil, errors.ErrSSODomainNotAllowedError
+	}
+
+	role := mapRole(conn, claims.Strings(conn.GroupsClaim))
+
+	user, err := u.repo.GetUserByIdentity(ctx, conn.Name, conn.Issuer, claims.Subject)
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		user, err = u.provision(ctx, conn, claims.Subject, email, role, claims.GivenName, claims.FamilyName)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		logger.FromContext(ctx).Error("Failed to get user by SSO identity", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+	case !user.IsActive:
+		return nil, errors.New(errors.ErrForbidden, "Account is deactivated", 403)
+	case len(conn.RoleMapping) > 0 && user.Role != role:
+		if err := u.repo.UpdateRole(ctx, user.ID, role); err != nil {
+			logger.FromContext(ctx).Error("Failed to update role from SSO groups", zap.Error(err))
+			return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+		}
+		logger.FromContext(ctx).Info("Role updated from SSO groups",
+			zap.String("user_id", user.ID.String()), zap.String("from", user.Role), zap.String("to", role))
+		user.Role = role
+	}
+
+	return u.sessions.StartSession(ctx, user, login.RememberMe)
+}
+
+// PruneLogins deletes started sign-ins that can no longer be completed
+func (u *ssoUsecase) PruneLogins(ctx context.Context) (int64, error) {
+	return u.repo.DeleteExpiredLogins(ctx, u.clock.Now())
+}
+
+// provision creates the user on their first sign-in, linked to their IdP
+// account. They get an unusable random password, so they sign in through the
+// IdP only. An account that already holds the email was not created by the
+// connection and is refused.
+func (u *ssoUsecase) provision(ctx context.Context, conn *entity.SSOConnection, subject, email, role, givenName, familyName string) (*entity.User, error) {
+	_, err := u.repo.GetUserByEmail(ctx, email)
+	if err == nil {
+		logger.FromContext(ctx).Warn("SSO email belongs to an account the connection did not provision",
+			zap.String("connection", conn.Name))
+		return nil, errors.ErrSSOAccountExistsError
+	}
+	if err != gorm.ErrRecordNotFound {
+		logger.FromContext(ctx).Error("Failed to get user by email", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+	}
+
+	localPart, _, _ := strings.Cut(email, "@")
+
+	username, err := u.username(ctx, localPart)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to pick username", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+	}
+
+	password, err := newSecretToken()
+	if err == nil {
+		var hashed []byte
+		hashed, err = bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		password = string(hashed)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to hash password", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+	}
+
+	if givenName == "" {
+		givenName = localPart
+	}
+	user := &entity.User{
+		Email:     email,
+		Username:  username,
+		Password:  password,
+		FirstName: givenName,
+		LastName:  familyName,
+		Role:      role,
+		IsActive:  true,
+	}
+	identity := &entity.SSOIdentity{Issuer: conn.Issuer, Subject: subject, Connection: conn.Name}
+	if err := u.repo.CreateUser(ctx, user, identity); err != nil {
+		logger.FromContext(ctx).Error("Failed to provision SSO user", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to complete sign-in", 500)
+	}
+
+	logger.FromContext(ctx).Info("SSO user provisioned",
+		zap.String("user_id", user.ID.String()), zap.String("connection", conn.Name), zap.String("role", role))
+	return user, nil
+}
+
+// username derives a free username from the email's local part, adding a
+// random suffix when it is taken
+func (u *ssoUsecase) username(ctx context.Context, localPart string) (string, error) {
+	base := strings.Trim(usernameUnsafe.ReplaceAllString(strings.ToLower(localPart), "_"), "_")
+	if len(base) < 3 {
+		base = "user_" + base
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+
+	candidate := base
+	for attempt := 0; attempt < 5; attempt++ {
+		taken, err := u.repo.UsernameTaken(ctx, candidate)
+		if err != nil || !taken {
+			return candidate, err
+		}
+
+		suffix, err := newSecretToken()
+		if err != nil {
+			return "", err
+		}
+		candidate = base + "_" + suffix[:6]
+	}
+	return "", errors.New(errors.ErrConflict, "No free username", 409)
+}
+
+// connection finds the connection named in the request, or the one for the
+// email's domain
+func (u *ssoUsecase) connection(req *entity.SSOStartRequest) (*entity.SSOConnection, bool) {
+	if req.Connection != "" {
+		return u.connectionByName(req.Connection)
+	}
+
+	_, domain, _ := strings.Cut(strings.ToLower(req.Email), "@")
+	for i := range u.connections {
+		if slices.Contains(u.connections[i].Domains, domain) {
+			return &u.connections[i], true
+		}
+	}
+	return nil, false
+}
+
+func (u *ssoUsecase) connectionByName(name string) (*entity.SSOConnection, bool) {
+	for i := range u.connections {
+		if u.connections[i].Name == name {
+			return &u.connections[i], true
+		}
+	}
+	return nil, false
+}
+
+// mapRole picks the most privileged role the groups map to, in the order of
+// entity.ValidRoles, or the connection's default role
+func mapRole(conn *entity.SSOConnection, groups []string) string {
+	role := conn.DefaultRole
+	best := -1
+	for _, group := range groups {
+		mapped, ok := conn.RoleMapping[group]
+		if !ok {
+			continue
+		}
+		if rank := slices.Index(entity.ValidRoles, mapped); rank > best {
+			role, best = mapped, rank
+		}
+	}
+	return role
+}
+
+// pkceChallenge is the S256 code challenge of a verifier
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// newSecretToken returns a random state, nonce or verifier
+func newSecretToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// hashSecretToken is what is stored, so a database leak does not leak usable
+// states
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
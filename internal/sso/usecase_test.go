@@ -0,0 +1,306 @@
+package sso
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/oidcclient"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testCallback = "https://app.example.com/sso/callback"
+
+type testDeps struct {
+	repo     *MockSSORepository
+	provider *MockIdentityProvider
+	sessions *MockSessionStarter
+	clock    *clock.Fake
+	usecase  SSOUsecase
+}
+
+func newTestUsecase() *testDeps {
+	cfg := &config.Config{
+		SSO: config.SSOConfig{CallbackURL: testCallback, StateTTL: 10 * time.Minute},
+	}
+	connections := []entity.SSOConnection{{
+		Name:        "acme",
+		Issuer:      "https://login.acme.com",
+		ClientID:    "our-app",
+		Domains:     []string{"acme.com"},
+		GroupsClaim: "groups",
+		RoleMapping: map[string]string{"app-admins": entity.RoleAdmin, "app-users": entity.RoleUser},
+		DefaultRole: entity.RoleUser,
+	}}
+
+	d := &testDeps{
+		repo:     new(MockSSORepository),
+		provider: new(MockIdentityProvider),
+		sessions: new(MockSessionStarter),
+		clock:    clock.NewFake(time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)),
+	}
+	providers := map[string]IdentityProvider{"acme": d.provider}
+	d.usecase = NewSSOUsecase(d.repo, cfg, connections, providers, d.sessions, d.clock)
+	return d
+}
+
+// pendingLogin stubs a started sign-in for the state
+func (d *testDeps) pendingLogin(state string) *entity.SSOLogin {
+	login := &entity.SSOLogin{
+		StateHash:    hashSecretToken(state),
+		Connection:   "acme",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		RememberMe:   true,
+		ExpiresAt:    d.clock.Now().Add(10 * time.Minute),
+	}
+	d.repo.On("ConsumeLogin", mock.Anything, hashSecretToken(state)).Return(login, nil).Once()
+	return login
+}
+
+func (d *testDeps) identity(claims map[string]interface{}) {
+	d.provider.On("Exchange", mock.Anything, "code", "verifier", testCallback, "nonce").
+		Return(oidcclient.ParseClaims(claims), nil).Once()
+}
+
+func TestSSOUsecase_Start_ByEmailDomain(t *testing.T) {
+	d := newTestUsecase()
+
+	var stored *entity.SSOLogin
+	d.repo.On("CreateLogin", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.SSOLogin) }).
+		Return(nil)
+	var state, challenge string
+	d.provider.On("AuthCodeURL", mock.Anything, testCallback, mock.Anything, mock.Anything, mock.Anything, scopes).
+		Run(func(args mock.Arguments) { state, challenge = args.String(2), args.String(4) }).
+		Return("https://login.acme.com/authorize?client_id=our-app", nil)
+
+	result, err := d.usecase.Start(context.Background(), &entity.SSOStartRequest{Email: "Jane@ACME.com", RememberMe: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://login.acme.com/authorize?client_id=our-app", result.AuthorizationURL)
+	assert.Equal(t, "acme", stored.Connection)
+	assert.Equal(t, hashSecretToken(state), stored.StateHash, "only the hash of the state is stored")
+	assert.Equal(t, pkceChallenge(stored.CodeVerifier), challenge)
+	assert.True(t, stored.RememberMe)
+	assert.Equal(t, d.clock.Now().Add(10*time.Minute), stored.ExpiresAt)
+}
+
+func TestSSOUsecase_Start_UnknownConnection(t *testing.T) {
+	d := newTestUsecase()
+
+	for _, req := range []*entity.SSOStartRequest{{Connection: "globex"}, {Email: "jane@example.com"}} {
+		_, err := d.usecase.Start(context.Background(), req)
+		assert.Equal(t, errors.ErrSSOConnectionNotFoundError, err)
+	}
+	d.repo.AssertNotCalled(t, "CreateLogin", mock.Anything, mock.Anything)
+}
+
+func TestSSOUsecase_Callback_ProvisionsUser(t *testing.T) {
+	d := newTestUsecase()
+	d.pendingLogin("state")
+	d.identity(map[string]interface{}{
+		"sub":            "idp-1",
+		"email":          "Jane.Doe@acme.com",
+		"email_verified": true,
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+		"groups":         []interface{}{"app-users", "app-admins"},
+	})
+	d.repo.On("GetUserByIdentity", mock.Anything, "acme", "https://login.acme.com", "idp-1").Return(nil, gorm.ErrRecordNotFound)
+	d.repo.On("GetUserByEmail", mock.Anything, "jane.doe@acme.com").Return(nil, gorm.ErrRecordNotFound)
+	d.repo.On("UsernameTaken", mock.Anything, "jane_doe").Return(true, nil).Once()
+	d.repo.On("UsernameTaken", mock.Anything, mock.Anything).Return(false, nil).Once()
+
+	var created *entity.User
+	var identity *entity.SSOIdentity
+	d.repo.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created, identity = args.Get(1).(*entity.User), args.Get(2).(*entity.SSOIdentity)
+		}).
+		Return(nil)
+	d.sessions.On("StartSession", mock.Anything, mock.Anything, true).Return(&entity.AuthResponse{Token: "token"}, nil)
+
+	result, err := d.usecase.Callback(context.Background(), &entity.SSOCallbackRequest{Code: "code", State: "state"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", result.Token)
+	assert.Equal(t, "jane.doe@acme.com", created.Email)
+	assert.Regexp(t, `^jane_doe_[0-9a-f]{6}$`, created.Username, "a taken username gets a suffix")
+	assert.Equal(t, "Jane", created.FirstName)
+	assert.Equal(t, "Doe", created.LastName)
+	assert.Equal(t, entity.RoleAdmin, created.Role, "the most privileged mapped group wins")
+	assert.True(t, created.IsActive)
+	assert.NotEmpty(t, created.Password)
+	assert.Equal(t, &entity.SSOIdentity{Issuer: "https://login.acme.com", Subject: "idp-1", Connection: "acme"}, identity)
+}
+
+func TestSSOUsecase_Callback_UpdatesMappedRole(t *testing.T) {
+	d := newTestUsecase()
+	d.pendingLogin("state")
+	d.identity(map[string]interface{}{"sub": "idp-1", "email": "jane@acme.com", "email_verified": true, "groups": "app-users"})
+
+	user := &entity.User{ID: uuid.New(), Email: "jane@acme.com", Role: entity.RoleAdmin, IsActive: true}
+	d.repo.On("GetUserByIdentity", mock.Anything, "acme", "https://login.acme.com", "idp-1").Return(user, nil)
+	d.repo.On("UpdateRole", mock.Anything, user.ID, entity.RoleUser).Return(nil)
+	d.sessions.On("StartSession", mock.Anything, user, true).Return(&entity.AuthResponse{}, nil)
+
+	_, err := d.usecase.Callback(context.Background(), &entity.SSOCallbackRequest{Code: "code", State: "state"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.RoleUser, user.Role)
+	d.repo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	d.repo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestSSOUsecase_Callback_SignsInProvisionedUser(t *testing.T) {
+	d := newTestUsecase()
+	d.pendingLogin("state")
+	d.identity(map[string]interface{}{"sub": "idp-1", "email": "jane@acme.com", "email_verified": true, "groups": "app-admins"})
+
+	user := &entity.User{ID: uuid.New(), Email: "jane@acme.com", Role: entity.RoleAdmin, IsActive: true}
+	d.repo.On("GetUserByIdentity", mock.Anything, "acme", "https://login.acme.com", "idp-1").Return(user, nil)
+	d.sessions.On("StartSession", mock.Anything, user, true).Return(&entity.AuthResponse{Token: "token"}, nil)
+
+	result, err := d.usecase.Callback(context.Background(), &entity.SSOCallbackRequest{Code: "code", State: "state"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", result.Token)
+	d.repo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSSOUsecase_Callback_ExistingEmailNotProvisioned(t *testing.T) {
+	tests := map[string]*entity.User{
+		"local admin": {ID: uuid.New(), Email: "root@acme.com", Role: entity.RoleAdmin, IsActive: true},
+		"local user":  {ID: uuid.New(), Email: "root@acme.com", Role: entity.RoleUser, IsActive: true},
+		"deactivated": {ID: uuid.New(), Email: "root@acme.com", Role: entity.RoleUser, IsActive: false},
+	}
+	for name, existing := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := newTestUsecase()
+			d.pendingLogin("state")
+			// The IdP asserts an email it did not provision, e.g. a subject of
+			// another connection or an account created with a password
+			d.identity(map[string]interface{}{"sub": "idp-2", "email": "root@acme.com", "email_verified": true, "groups": "app-admins"})
+			d.repo.On("GetUserByIdentity", mock.Anything, "acme", "https://login.acme.com", "idp-2").Return(nil, gorm.ErrRecordNotFound)
+			d.repo.On("GetUserByEmail", mock.Anything, "root@acme.com").Return(existing, nil)
+
+			_, err := d.usecase.Callback(context.Background(), &entity.SSOCallbackRequest{Code: "code", State: "state"})
+
+			assert.Equal(t, errors.ErrSSOAccountExistsError, err)
+			d.repo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+			d.repo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+			d.sessions.AssertNotCalled(t, "StartSession", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSSOUsecase_Callback_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   map[string]interface{}
+		expected *errors.AppError
+	}{
+		{"email outside domains", map[string]interface{}{"sub": "1", "email": "jane@example.com", "email_verified": true}, errors.ErrSSODomainNotAllowedError},
+		{"unverified email", map[string]interface{}{"sub": "1", "email": "jane@acme.com", "email_verified": false}, errors.ErrSSOFailedError},
+		{"verification not asserted", map[string]interface{}{"sub": "1", "email": "jane@acme.com"}, errors.ErrSSOFailedError},
+		{"verification not a boolean", map[string]interface{}{"sub": "1", "email": "jane@acme.com", "email_verified": "true"}, errors.ErrSSOFailedError},
+		{"no email", map[string]interface{}{"sub": "1", "email_verified": true}, errors.ErrSSOFailedError},
+		{"no subject", map[string]interface{}{"email": "jane@acme.com", "email_verified": true}, errors.ErrSSOFailedError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newTestUsecase()
+			d.pendingLogin("state")
+			d.identity(tt.claims)
+
+			_, err := d.usecase.Callback(context.Background(), &entity.SSOCallbackRequest{Code: "code", State: "state"})
+
+			assert.Equal(t, tt.expected, err)
+			d.repo.AssertNotCalled(t, "GetUserByIdentity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			d.repo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+			d.sessions.AssertNotCalled(t, "StartSession", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSSOUsecase_Callback_State(t *testing.T) {
+	d := newTestUsecase()
+	ctx := context.Background()
+
+	d.repo.On("ConsumeLogin", mock.Anything, hashSecretToken("used")).Return(nil, gorm.ErrRecordNotFound)
+	_, err := d.usecase.Callback(ctx, &entity.SSOCallbackRequest{Code: "code", State: "used"})
+	assert.Equal(t, errors.ErrSSOStateInvalidError, err, "a state can be used once")
+
+	d.pendingLogin("expired")
+	d.clock.Advance(10 * time.Minute)
+	_, err = d.usecase.Callback(ctx, &entity.SSOCallbackRequest{Code: "code", State: "expired"})
+	assert.Equal(t, errors.ErrSSOStateInvalidError, err)
+
+	d.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSSOUsecase_Callback_DeactivatedUser(t *testing.T) {
+	d := newTestUsecase()
+	d.pendingLogin("state")
+	d.identity(map[string]interface{}{"sub": "idp-1", "email": "jane@acme.com", "email_verified": true})
+	d.repo.On("GetUserByIdentity", mock.Anything, "acme", "https://login.acme.com", "idp-1").
+		Return(&entity.User{ID: uuid.New(), Email: "jane@acme.com", IsActive: false}, nil)
+
+	_, err := d.usecase.Callback(context.Background(), &entity.SSOCallbackRequest{Code: "code", State: "state"})
+
+	require.IsType(t, &errors.AppError{}, err)
+	assert.Equal(t, 403, err.(*errors.AppError).StatusCode)
+	d.sessions.AssertNotCalled(t, "StartSession", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoadConnections(t *testing.T) {
+	path := t.TempDir() + "/sso.yaml"
+	require.NoError(t, writeFile(path, `
+connections:
+  - name: acme
+    issuer: https://login.acme.com
+    client_id: our-app
+    domains: [ACME.com]
+    role_mapping:
+      app-admins: admin
+`))
+
+	connections, err := LoadConnections(path)
+	require.NoError(t, err)
+	require.Len(t, connections, 1)
+	assert.Equal(t, []string{"acme.com"}, connections[0].Domains)
+	assert.Equal(t, entity.RoleUser, connections[0].DefaultRole)
+
+	for _, invalid := range []string{
+		"connections:\n  - name: acme\n    issuer: https://login.acme.com\n",
+		"connections:\n  - {name: a, issuer: https://a, client_id: x, domains: [acme.com]}\n  - {name: b, issuer: https://b, client_id: x, domains: [acme.com]}\n",
+		"connections:\n  - {name: a, issuer: https://a, client_id: x, domains: [acme.com], role_mapping: {g: owner}}\n",
+		"connections:\n  - {name: a, issuer: https://a, client_id: x}\n",
+		"connections:\n  - {name: a, issuer: https://a, client_id: x, domains: []}\n",
+		"connections:\n  - {name: a, issuer: https://a, client_id: x, domains: [\"\"]}\n",
+	} {
+		require.NoError(t, writeFile(path, invalid))
+		_, err := LoadConnections(path)
+		assert.Error(t, err, invalid)
+	}
+
+	connections, err = LoadConnections("")
+	assert.NoError(t, err)
+	assert.Empty(t, connections)
+}
+
+func writeFile(path, content string) error {
+	return os.WriteFile(path, []byte(content), 0o600)
+}
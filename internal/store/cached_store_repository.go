@@ -0,0 +1,72 @@
+// Code generated by artisan make:cache. DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+// CachedStoreRepository caches the reads of StoreRepository marked
+// //cache:read, and drops them on the writes marked //cache:invalidate
+type CachedStoreRepository struct {
+	next  StoreRepository
+	cache *cache.Decorator
+}
+
+// NewCachedStoreRepository wraps next, keeping reads in store for ttl
+func NewCachedStoreRepository(next StoreRepository, store cache.Store, ttl time.Duration) StoreRepository {
+	return &CachedStoreRepository{next: next, cache: cache.NewDecorator("store.StoreRepository", store, ttl)}
+}
+
+func (c *CachedStoreRepository) CreateStore(ctx context.Context, store *entity.Store) error {
+	err := c.next.CreateStore(ctx, store)
+	c.cache.Invalidate(ctx)
+	return err
+}
+
+func (c *CachedStoreRepository) GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error) {
+	var r0 []*entity.Store
+	var r1 int64
+	key := c.cache.Key(ctx, "GetStores", filter)
+	if c.cache.Load(ctx, key, &r0, &r1) {
+		return r0, r1, nil
+	}
+
+	r0, r1, err := c.next.GetStores(ctx, filter)
+	if err == nil {
+		c.cache.Save(ctx, key, r0, r1)
+	}
+	return r0, r1, err
+}
+
+func (c *CachedStoreRepository) GetStoreByID(ctx context.Context, id uuid.UUID) (*entity.Store, error) {
+	var r0 *entity.Store
+	key := c.cache.Key(ctx, "GetStoreByID", id)
+	if c.cache.Load(ctx, key, &r0) {
+		return r0, nil
+	}
+
+	r0, err := c.next.GetStoreByID(ctx, id)
+	if err == nil {
+		c.cache.Save(ctx, key, r0)
+	}
+	return r0, err
+}
+
+func (c *CachedStoreRepository) UpdateStore(ctx context.Context, store *entity.Store) error {
+	err := c.next.UpdateStore(ctx, store)
+	c.cache.Invalidate(ctx)
+	return err
+}
+
+func (c *CachedStoreRepository) DeleteStore(ctx context.Context, id uuid.UUID) (int64, error) {
+	r0, err := c.next.DeleteStore(ctx, id)
+	c.cache.Invalidate(ctx)
+	return r0, err
+}
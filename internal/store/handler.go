@@ -0,0 +1,241 @@
+package store
+
+import (
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/response"
+	"go-clean-gin/pkg/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type StoreHandler struct {
+	usecase StoreUsecase
+}
+
+func NewStoreHandler(usecase StoreUsecase) *StoreHandler {
+	return &StoreHandler{
+		usecase: usecase,
+	}
+}
+
+// CreateStore godoc
+// @Summary Create a store
+// @Description Create a store from the fields in the request
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body entity.CreateStoreRequest true "Store"
+// @Success 201 {object} response.Response{data=entity.Store}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /stores [post]
+func (h *StoreHandler) CreateStore(c *gin.Context) {
+	var req entity.CreateStoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	store, err := h.usecase.CreateStore(c.Request.Context(), &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to create store", zap.Error(err))
+		errorResponse(c, err, "Failed to create store")
+		return
+	}
+
+	response.Success(c, 201, "Store created successfully", store)
+}
+
+// GetStores godoc
+// @Summary List stores
+// @Description List stores, newest first, with optional filters and pagination
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param name query string false "Filter by name"
+// @Param address query string false "Filter by address"
+// @Param search query string false "Search in name, address"
+// @Param near query string false "Only stores near this location, as lat,lng"
+// @Param radius query number false "Distance from near in meters, required with near"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page, at most 100" default(20)
+// @Param cursor query string false "Resume after the previous page, from meta.next_cursor"
+// @Success 200 {object} response.Response{data=[]entity.Store}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /stores [get]
+func (h *StoreHandler) GetStores(c *gin.Context) {
+	var filter entity.StoreFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind query", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(filter); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	stores, total, err := h.usecase.GetStores(c.Request.Context(), &filter)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get stores", zap.Error(err))
+		errorResponse(c, err, "Failed to get stores")
+		return
+	}
+
+	meta := pagination.Meta(filter.Params, total, len(stores), func() (time.Time, uuid.UUID) {
+		last := stores[len(stores)-1]
+		return last.CreatedAt, last.ID
+	})
+	response.SuccessWithMeta(c, 200, "Stores retrieved successfully", stores, meta)
+}
+
+// GetStore godoc
+// @Summary Get a store
+// @Description Get a store by ID
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Store ID"
+// @Success 200 {object} response.Response{data=entity.Store}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /stores/{id} [get]
+func (h *StoreHandler) GetStore(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	store, err := h.usecase.GetStore(c.Request.Context(), id)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to get store", zap.Error(err))
+		errorResponse(c, err, "Failed to get store")
+		return
+	}
+
+	response.Success(c, 200, "Store retrieved successfully", store)
+}
+
+// UpdateStore godoc
+// @Summary Update a store
+// @Description Change the fields given in the request
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Store ID"
+// @Param request body entity.UpdateStoreRequest true "Fields to change"
+// @Success 200 {object} response.Response{data=entity.Store}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /stores/{id} [put]
+func (h *StoreHandler) UpdateStore(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	var req entity.UpdateStoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to bind JSON", zap.Error(err))
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if fieldErrors := validator.ValidateStruct(req); fieldErrors != nil {
+		response.ValidationError(c, "Validation failed", fieldErrors)
+		return
+	}
+
+	store, err := h.usecase.UpdateStore(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to update store", zap.Error(err))
+		errorResponse(c, err, "Failed to update store")
+		return
+	}
+
+	response.Success(c, 200, "Store updated successfully", store)
+}
+
+// DeleteStore godoc
+// @Summary Delete a store
+// @Description Soft-delete a store; it no longer appears in lists or lookups
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Store ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /stores/{id} [delete]
+func (h *StoreHandler) DeleteStore(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteStore(c.Request.Context(), id); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Failed to delete store", zap.Error(err))
+		errorResponse(c, err, "Failed to delete store")
+		return
+	}
+
+	response.Success(c, 200, "Store deleted successfully", nil)
+}
+
+// pathID reads the store ID from the path, writing the error
+// response when it is not a UUID
+func pathID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, 400, errors.ErrBadRequest, "Invalid store ID", err.Error())
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// errorResponse writes the usecase's error, or a 500 with message
+func errorResponse(c *gin.Context, err error, message string) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		response.Error(c, appErr.StatusCode, appErr.Code, appErr.Message, appErr.Details)
+	} else {
+		response.Error(c, 500, errors.ErrInternal, message, nil)
+	}
+}
@@ -0,0 +1,56 @@
+package store_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/test/apitest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreHandler_GetStores_Near(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	for _, req := range []entity.CreateStoreRequest{
+		{Name: "Siam", Address: "Rama I Rd, Bangkok", Latitude: 13.7460, Longitude: 100.5340},
+		{Name: "Nimman", Address: "Nimmanhaemin Rd, Chiang Mai", Latitude: 18.7999, Longitude: 98.9679},
+	} {
+		api.As(user).Post("/api/v1/stores", req).Do().
+			AssertStatus(http.StatusCreated)
+	}
+
+	// Siam is about 4.6 km from the Grand Palace, Nimman about 580 km
+	var stores []entity.Store
+	api.As(user).Get("/api/v1/stores?near=13.7500,100.4913&radius=10000").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&stores)
+	require.Len(t, stores, 1)
+	assert.Equal(t, "Siam", stores[0].Name)
+
+	api.As(user).Get("/api/v1/stores?near=13.7500,100.4913&radius=1000").Do().
+		AssertStatus(http.StatusOK).
+		Decode(&stores)
+	assert.Empty(t, stores)
+}
+
+func TestStoreHandler_GetStores_InvalidNear(t *testing.T) {
+	t.Parallel()
+
+	api := apitest.New(t)
+	user := api.CreateUser()
+
+	api.As(user).Get("/api/v1/stores?near=13.75").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("near").
+		AssertFieldError("radius")
+
+	api.As(user).Get("/api/v1/stores?near=95,100&radius=1000").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertFieldError("near")
+}
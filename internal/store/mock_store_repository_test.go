@@ -0,0 +1,65 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStoreRepository is a testify mock of StoreRepository
+type MockStoreRepository struct {
+	mock.Mock
+}
+
+func (m *MockStoreRepository) CreateStore(ctx context.Context, store *entity.Store) error {
+	args := m.Called(ctx, store)
+	return args.Error(0)
+}
+
+func (m *MockStoreRepository) GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.Store
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Store)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockStoreRepository) GetStoreByID(ctx context.Context, id uuid.UUID) (*entity.Store, error) {
+	args := m.Called(ctx, id)
+
+	var r0 *entity.Store
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Store)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockStoreRepository) UpdateStore(ctx context.Context, store *entity.Store) error {
+	args := m.Called(ctx, store)
+	return args.Error(0)
+}
+
+func (m *MockStoreRepository) DeleteStore(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+
+	var r0 int64
+	if v := args.Get(0); v != nil {
+		r0 = v.(int64)
+	}
+
+	return r0, args.Error(1)
+}
@@ -0,0 +1,71 @@
+// Code generated by artisan make:mock. DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStoreUsecase is a testify mock of StoreUsecase
+type MockStoreUsecase struct {
+	mock.Mock
+}
+
+func (m *MockStoreUsecase) CreateStore(ctx context.Context, req *entity.CreateStoreRequest) (*entity.Store, error) {
+	args := m.Called(ctx, req)
+
+	var r0 *entity.Store
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Store)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockStoreUsecase) GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error) {
+	args := m.Called(ctx, filter)
+
+	var r0 []*entity.Store
+	if v := args.Get(0); v != nil {
+		r0 = v.([]*entity.Store)
+	}
+
+	var r1 int64
+	if v := args.Get(1); v != nil {
+		r1 = v.(int64)
+	}
+
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockStoreUsecase) GetStore(ctx context.Context, id uuid.UUID) (*entity.Store, error) {
+	args := m.Called(ctx, id)
+
+	var r0 *entity.Store
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Store)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockStoreUsecase) UpdateStore(ctx context.Context, id uuid.UUID, req *entity.UpdateStoreRequest) (*entity.Store, error) {
+	args := m.Called(ctx, id, req)
+
+	var r0 *entity.Store
+	if v := args.Get(0); v != nil {
+		r0 = v.(*entity.Store)
+	}
+
+	return r0, args.Error(1)
+}
+
+func (m *MockStoreUsecase) DeleteStore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
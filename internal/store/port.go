@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// StoreUsecase defines the business logic interface for store
+type StoreUsecase interface {
+	CreateStore(ctx context.Context, req *entity.CreateStoreRequest) (*entity.Store, error)
+	GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error)
+	GetStore(ctx context.Context, id uuid.UUID) (*entity.Store, error)
+	UpdateStore(ctx context.Context, id uuid.UUID, req *entity.UpdateStoreRequest) (*entity.Store, error)
+	DeleteStore(ctx context.Context, id uuid.UUID) error
+}
+
+// StoreRepository defines the data access interface for store.
+// Its cache decorator, CachedStoreRepository, is generated from the
+// //cache: directives with artisan make:cache, and its retry decorator,
+// RetryingStoreRepository, with artisan make:retry.
+type StoreRepository interface {
+	//cache:invalidate
+	CreateStore(ctx context.Context, store *entity.Store) error
+	//cache:read
+	GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error)
+	//cache:read
+	GetStoreByID(ctx context.Context, id uuid.UUID) (*entity.Store, error)
+	//cache:invalidate
+	UpdateStore(ctx context.Context, store *entity.Store) error
+	//cache:invalidate
+	DeleteStore(ctx context.Context, id uuid.UUID) (int64, error)
+}
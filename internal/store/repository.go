@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/geo"
+	"go-clean-gin/pkg/pagination"
+	"go-clean-gin/pkg/queryfilter"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type storeRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreRepository(db *gorm.DB) StoreRepository {
+	return &storeRepository{
+		db: db,
+	}
+}
+
+func (r *storeRepository) CreateStore(ctx context.Context, store *entity.Store) error {
+	return tenancy.Conn(ctx, r.db).Create(store).Error
+}
+
+func (r *storeRepository) GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error) {
+	var stores []*entity.Store
+	var total int64
+
+	query := tenancy.Conn(ctx, r.db).Model(&entity.Store{})
+	query = queryfilter.Apply(query, filter)
+	if filter.Near != "" {
+		center, err := geo.ParsePoint(filter.Near)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where(geo.Within("latitude", "longitude", center, filter.Radius))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = pagination.Apply(query, filter.Params, "created_at", "id")
+
+	if err := query.Find(&stores).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return stores, total, nil
+}
+
+func (r *storeRepository) GetStoreByID(ctx context.Context, id uuid.UUID) (*entity.Store, error) {
+	var store entity.Store
+	if err := tenancy.Conn(ctx, r.db).First(&store, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// UpdateStore saves the columns, leaving loaded associations as they are
+func (r *storeRepository) UpdateStore(ctx context.Context, store *entity.Store) error {
+	return tenancy.Conn(ctx, r.db).Omit(clause.Associations).Save(store).Error
+}
+
+// DeleteStore returns the number of rows deleted, 0 when there is no
+// such store
+func (r *storeRepository) DeleteStore(ctx context.Context, id uuid.UUID) (int64, error) {
+	result := tenancy.Conn(ctx, r.db).Delete(&entity.Store{}, "id = ?", id)
+	return result.RowsAffected, result.Error
+}
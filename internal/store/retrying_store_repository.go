@@ -0,0 +1,65 @@
+// Code generated by artisan make:retry. DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/dbretry"
+
+	"github.com/google/uuid"
+)
+
+// RetryingStoreRepository retries the calls of StoreRepository failing
+// with a transient database error. Only methods marked //retry:idempotent
+// or //cache:read are retried after a lost connection.
+type RetryingStoreRepository struct {
+	next    StoreRepository
+	retrier *dbretry.Retrier
+}
+
+// NewRetryingStoreRepository wraps next, retrying its calls with retrier
+func NewRetryingStoreRepository(next StoreRepository, retrier *dbretry.Retrier) StoreRepository {
+	return &RetryingStoreRepository{next: next, retrier: retrier}
+}
+
+func (c *RetryingStoreRepository) CreateStore(ctx context.Context, store *entity.Store) error {
+	return c.retrier.Do(ctx, "store.StoreRepository.CreateStore", false, func() error {
+		return c.next.CreateStore(ctx, store)
+	})
+}
+
+func (c *RetryingStoreRepository) GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error) {
+	var r0 []*entity.Store
+	var r1 int64
+	err := c.retrier.Do(ctx, "store.StoreRepository.GetStores", true, func() (err error) {
+		r0, r1, err = c.next.GetStores(ctx, filter)
+		return err
+	})
+	return r0, r1, err
+}
+
+func (c *RetryingStoreRepository) GetStoreByID(ctx context.Context, id uuid.UUID) (*entity.Store, error) {
+	var r0 *entity.Store
+	err := c.retrier.Do(ctx, "store.StoreRepository.GetStoreByID", true, func() (err error) {
+		r0, err = c.next.GetStoreByID(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (c *RetryingStoreRepository) UpdateStore(ctx context.Context, store *entity.Store) error {
+	return c.retrier.Do(ctx, "store.StoreRepository.UpdateStore", false, func() error {
+		return c.next.UpdateStore(ctx, store)
+	})
+}
+
+func (c *RetryingStoreRepository) DeleteStore(ctx context.Context, id uuid.UUID) (int64, error) {
+	var r0 int64
+	err := c.retrier.Do(ctx, "store.StoreRepository.DeleteStore", false, func() (err error) {
+		r0, err = c.next.DeleteStore(ctx, id)
+		return err
+	})
+	return r0, err
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type storeUsecase struct {
+	repo StoreRepository
+}
+
+func NewStoreUsecase(repo StoreRepository) StoreUsecase {
+	return &storeUsecase{
+		repo: repo,
+	}
+}
+
+func (u *storeUsecase) CreateStore(ctx context.Context, req *entity.CreateStoreRequest) (*entity.Store, error) {
+	store := &entity.Store{
+		Name:      req.Name,
+		Address:   req.Address,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	}
+
+	if err := u.repo.CreateStore(ctx, store); err != nil {
+		logger.FromContext(ctx).Error("Failed to create store", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to create store", 500)
+	}
+
+	logger.FromContext(ctx).Info("Store created", zap.String("store_id", store.ID.String()))
+	return store, nil
+}
+
+func (u *storeUsecase) GetStores(ctx context.Context, filter *entity.StoreFilter) ([]*entity.Store, int64, error) {
+	filter.Normalize(pagination.DefaultLimit)
+
+	stores, total, err := u.repo.GetStores(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get stores", zap.Error(err))
+		return nil, 0, errors.Wrap(err, errors.ErrInternal, "Failed to get stores", 500)
+	}
+
+	return stores, total, nil
+}
+
+func (u *storeUsecase) GetStore(ctx context.Context, id uuid.UUID) (*entity.Store, error) {
+	store, err := u.repo.GetStoreByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New(errors.ErrNotFound, "Store not found", 404)
+		}
+		logger.FromContext(ctx).Error("Failed to get store", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to get store", 500)
+	}
+
+	return store, nil
+}
+
+// UpdateStore changes the fields given in the request
+func (u *storeUsecase) UpdateStore(ctx context.Context, id uuid.UUID, req *entity.UpdateStoreRequest) (*entity.Store, error) {
+	store, err := u.GetStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		store.Name = *req.Name
+	}
+	if req.Address != nil {
+		store.Address = *req.Address
+	}
+	if req.Latitude != nil {
+		store.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		store.Longitude = *req.Longitude
+	}
+
+	if err := u.repo.UpdateStore(ctx, store); err != nil {
+		logger.FromContext(ctx).Error("Failed to update store", zap.Error(err))
+		return nil, errors.Wrap(err, errors.ErrInternal, "Failed to update store", 500)
+	}
+
+	logger.FromContext(ctx).Info("Store updated", zap.String("store_id", store.ID.String()))
+	return store, nil
+}
+
+func (u *storeUsecase) DeleteStore(ctx context.Context, id uuid.UUID) error {
+	deleted, err := u.repo.DeleteStore(ctx, id)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to delete store", zap.Error(err))
+		return errors.Wrap(err, errors.ErrInternal, "Failed to delete store", 500)
+	}
+	if deleted == 0 {
+		return errors.New(errors.ErrNotFound, "Store not found", 404)
+	}
+
+	logger.FromContext(ctx).Info("Store deleted", zap.String("store_id", id.String()))
+	return nil
+}
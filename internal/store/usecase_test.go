@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/pagination"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestStoreUsecase_CreateStore(t *testing.T) {
+	repo := new(MockStoreRepository)
+	usecase := NewStoreUsecase(repo)
+
+	repo.On("CreateStore", mock.Anything, mock.MatchedBy(func(store *entity.Store) bool {
+		return store.Name == "Siam" && store.Latitude == 13.746 && store.Longitude == 100.534
+	})).Return(nil)
+
+	store, err := usecase.CreateStore(context.Background(), &entity.CreateStoreRequest{
+		Name: "Siam", Address: "Rama I Rd, Bangkok", Latitude: 13.746, Longitude: 100.534,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Rama I Rd, Bangkok", store.Address)
+	repo.AssertExpectations(t)
+}
+
+func TestStoreUsecase_GetStores(t *testing.T) {
+	repo := new(MockStoreRepository)
+	usecase := NewStoreUsecase(repo)
+
+	filter := &entity.StoreFilter{Near: "13.75,100.49", Radius: 5000}
+	repo.On("GetStores", mock.Anything, filter).Return([]*entity.Store{{Name: "Siam"}}, int64(1), nil)
+
+	stores, total, err := usecase.GetStores(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Len(t, stores, 1)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, pagination.DefaultLimit, filter.Limit)
+}
+
+func TestStoreUsecase_GetStore_NotFound(t *testing.T) {
+	repo := new(MockStoreRepository)
+	usecase := NewStoreUsecase(repo)
+
+	id := uuid.New()
+	repo.On("GetStoreByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := usecase.GetStore(context.Background(), id)
+	var appErr *errors.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, 404, appErr.StatusCode)
+	}
+}
+
+func TestStoreUsecase_UpdateStore_MovesLocation(t *testing.T) {
+	repo := new(MockStoreRepository)
+	usecase := NewStoreUsecase(repo)
+
+	id := uuid.New()
+	repo.On("GetStoreByID", mock.Anything, id).Return(&entity.Store{ID: id, Name: "Siam", Latitude: 13.746, Longitude: 100.534}, nil)
+	repo.On("UpdateStore", mock.Anything, mock.Anything).Return(nil)
+
+	// 0 is a coordinate like any other
+	lat, lng := 0.0, 100.5
+	store, err := usecase.UpdateStore(context.Background(), id, &entity.UpdateStoreRequest{Latitude: &lat, Longitude: &lng})
+	assert.NoError(t, err)
+	assert.Equal(t, "Siam", store.Name)
+	assert.Equal(t, 0.0, store.Latitude)
+	assert.Equal(t, 100.5, store.Longitude)
+}
@@ -0,0 +1,143 @@
+// pkg/archive/archive.go - Moving old rows out of hot tables in batches
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"go-clean-gin/pkg/batch"
+	"go-clean-gin/pkg/storage"
+	"go-clean-gin/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+// Table is a hot table whose rows are archived once the timestamp in Column
+// is older than the cutoff. Rows with a NULL timestamp, e.g. reservations not
+// closed yet, are never archived.
+type Table struct {
+	Name   string // e.g. tb_reservations
+	Column string // e.g. closed_at
+}
+
+// ArchiveName is the table archived rows are moved to, e.g.
+// tb_reservations_archive. Create it in a migration with the same columns:
+//
+//	CREATE TABLE tb_reservations_archive (LIKE tb_reservations INCLUDING ALL)
+func (t Table) ArchiveName() string {
+	return t.Name + "_archive"
+}
+
+// Archiver moves rows older than a cutoff from hot tables, a batch per
+// transaction, so each batch locks few rows and a failure keeps the rest for
+// the next run. Rows go to the table's archive table, or with a storage to
+// JSON lines files under archive/<table>/, which nothing reads back.
+type Archiver struct {
+	db    *gorm.DB
+	store storage.Storage
+	batch int
+}
+
+// New creates an archiver moving batch rows at a time to archive tables, or
+// to store when it is not nil
+func New(db *gorm.DB, store storage.Storage, batch int) *Archiver {
+	if batch < 1 {
+		batch = 1000
+	}
+	return &Archiver{db: db, store: store, batch: batch}
+}
+
+// Archive moves the rows of table older than cutoff and returns how many it
+// moved. Rows locked by a request are skipped until the next run.
+func (a *Archiver) Archive(ctx context.Context, table Table, cutoff time.Time) (int64, error) {
+	db := tenancy.Conn(ctx, a.db)
+
+	key, err := batch.PrimaryKey(db, table.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	var columns []string
+	if a.store == nil {
+		err = db.Raw(`SELECT column_name FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? ORDER BY ordinal_position`,
+			table.Name).Scan(&columns).Error
+		if err != nil {
+			return 0, err
+		}
+		if len(columns) == 0 {
+			return 0, fmt.Errorf("archive: table %s not found", table.Name)
+		}
+	}
+
+	var moved int64
+	for {
+		var n int64
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			if a.store == nil {
+				n, err = a.toTable(tx, table, key, columns, cutoff)
+			} else {
+				n, err = a.toStorage(ctx, tx, table, key, cutoff)
+			}
+			return err
+		})
+		if err != nil {
+			return moved, err
+		}
+		moved += n
+		if n < int64(a.batch) {
+			return moved, nil
+		}
+	}
+}
+
+// batchSQL deletes the next batch of rows older than the cutoff, found by
+// their primary key key, returning what RETURNING lists
+func (a *Archiver) batchSQL(tx *gorm.DB, table Table, key []string, returning string) string {
+	return fmt.Sprintf("DELETE FROM %s AS r WHERE %s RETURNING %s",
+		tx.Statement.Quote(table.Name), batch.Oldest(tx, table.Name, table.Column, key), returning)
+}
+
+// toTable moves a batch to the archive table, naming the columns so a column
+// missing from the archive fails rather than filling the wrong one
+func (a *Archiver) toTable(tx *gorm.DB, table Table, key, columns []string, cutoff time.Time) (int64, error) {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = tx.Statement.Quote(column)
+	}
+	list := strings.Join(quoted, ", ")
+
+	result := tx.Exec(fmt.Sprintf("WITH moved AS (%s) INSERT INTO %s (%s) SELECT %s FROM moved",
+		a.batchSQL(tx, table, key, "r.*"), tx.Statement.Quote(table.ArchiveName()), list, list), cutoff, a.batch)
+	return result.RowsAffected, result.Error
+}
+
+// toStorage writes a batch to a JSON lines file before its transaction
+// commits, so a failed write keeps the rows; a failed commit after it leaves
+// the file holding rows that are still in the table
+func (a *Archiver) toStorage(ctx context.Context, tx *gorm.DB, table Table, key []string, cutoff time.Time) (int64, error) {
+	var rows []string
+	if err := tx.Raw(a.batchSQL(tx, table, key, "to_jsonb(r)::text"), cutoff, a.batch).Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	for _, row := range rows {
+		buf.WriteString(row)
+		buf.WriteByte('\n')
+	}
+	now := time.Now().UTC()
+	file := path.Join("archive", table.Name, now.Format("2006-01-02"), fmt.Sprintf("%d.jsonl", now.UnixNano()))
+	if err := a.store.Put(ctx, file, &buf); err != nil {
+		return 0, err
+	}
+	return int64(len(rows)), nil
+}
@@ -0,0 +1,51 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-gin/test/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiver_Archive_PartitionedTable(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	table := Table{Name: "tb_archive_" + uuid.NewString()[:8], Column: "closed_at"}
+	january := time.Date(2000, 1, 10, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2000, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, statement := range []string{
+		fmt.Sprintf("CREATE TABLE %s (id bigint, closed_at timestamptz NOT NULL, PRIMARY KEY (id, closed_at)) PARTITION BY RANGE (closed_at)", table.Name),
+		fmt.Sprintf("CREATE TABLE %s_p2000_01 PARTITION OF %s FOR VALUES FROM ('2000-01-01') TO ('2000-02-01')", table.Name, table.Name),
+		fmt.Sprintf("CREATE TABLE %s_p2000_02 PARTITION OF %s FOR VALUES FROM ('2000-02-01') TO ('2000-03-01')", table.Name, table.Name),
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", table.ArchiveName(), table.Name),
+	} {
+		require.NoError(t, db.Exec(statement).Error)
+	}
+	// Rows 1-3 in the January partition share their ctids with rows 4-6 in
+	// the February one
+	for id := 1; id <= 8; id++ {
+		closedAt := february
+		if id <= 3 {
+			closedAt = january
+		}
+		require.NoError(t, db.Exec(fmt.Sprintf("INSERT INTO %s (id, closed_at) VALUES (?, ?)", table.Name), id, closedAt).Error)
+	}
+
+	moved, err := New(db, nil, 2).Archive(context.Background(), table, time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), moved)
+
+	var live, archived []int64
+	require.NoError(t, db.Table(table.Name).Order("id").Pluck("id", &live).Error)
+	require.NoError(t, db.Table(table.ArchiveName()).Order("id").Pluck("id", &archived).Error)
+	assert.Equal(t, []int64{4, 5, 6, 7, 8}, live)
+	assert.Equal(t, []int64{1, 2, 3}, archived)
+}
@@ -0,0 +1,42 @@
+// pkg/batch/batch.go - Picking the oldest rows of a table a batch at a time
+package batch
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// PrimaryKey returns the primary key columns of table, in key order. Batches
+// are matched by primary key rather than ctid, which on a partitioned table
+// is only unique within a partition.
+func PrimaryKey(db *gorm.DB, table string) ([]string, error) {
+	var columns []string
+	err := db.Raw(`SELECT a.attname FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = ?::regclass AND i.indisprimary
+		ORDER BY array_position(i.indkey::int2[], a.attnum)`, table).Scan(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("batch: table %s has no primary key", table)
+	}
+	return columns, nil
+}
+
+// Oldest returns a condition matching the rows of table with key whose column
+// is before a cutoff, oldest first, up to a limit: bind the cutoff and the
+// limit to it. Its rows are locked, skipping those a request holds, so the
+// next run takes them instead.
+func Oldest(db *gorm.DB, table, column string, key []string) string {
+	quote := db.Statement.Quote
+	quoted := make([]string, len(key))
+	for i, k := range key {
+		quoted[i] = quote(k)
+	}
+	list := strings.Join(quoted, ", ")
+	return fmt.Sprintf("(%s) IN (SELECT %s FROM %s WHERE %s < ? ORDER BY %s LIMIT ? FOR UPDATE SKIP LOCKED)",
+		list, list, quote(table), quote(column), quote(column))
+}
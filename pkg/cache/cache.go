@@ -0,0 +1,44 @@
+// pkg/cache/cache.go - Key-value stores for cached repository reads
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+)
+
+// Store keeps encoded values by key. Implementations must be safe for
+// concurrent use; a shared store, such as Redis, or a SyncedStore makes
+// invalidation reach every instance at once.
+type Store interface {
+	// Get returns the value of key, and false when it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value of key for ttl, or until evicted when ttl is 0
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// New creates a store for the configured driver
+func New(cfg *config.CacheConfig, clk clock.Clock) (Store, error) {
+	switch cfg.Driver {
+	case "", "none":
+		return NullStore{}, nil
+	case "memory":
+		return NewMemoryStore(cfg.MaxEntries, clk), nil
+	default:
+		return nil, fmt.Errorf("unsupported cache driver: %s", cfg.Driver)
+	}
+}
+
+// NullStore keeps nothing, so every read goes through to the repository
+type NullStore struct{}
+
+func (NullStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (NullStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
@@ -0,0 +1,158 @@
+// pkg/cache/decorator.go - Read-through caching for generated repository decorators
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/rls"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Decorator caches the reads of one repository. The decorators artisan
+// make:cache generates call it around the methods of the repository:
+//
+//	key := d.Key(ctx, "GetPostByID", id)
+//	if d.Load(ctx, key, &post) { return post, nil }
+//	post, err := next.GetPostByID(ctx, id)
+//	if err == nil { d.Save(ctx, key, post) }
+//
+// Keys include the tenant and row-level security user of ctx, since both
+// change what a query returns, and a generation that Invalidate replaces, so
+// one write drops every cached read of the repository without listing them.
+// Values are gob-encoded, so callers get copies they may modify.
+type Decorator struct {
+	namespace string
+	store     Store
+	ttl       time.Duration
+}
+
+// NewDecorator caches reads in store for ttl under namespace, which names
+// the repository, e.g. "post.PostRepository"
+func NewDecorator(namespace string, store Store, ttl time.Duration) *Decorator {
+	return &Decorator{namespace: namespace, store: store, ttl: ttl}
+}
+
+// enabled reports whether reads are cached at all
+func (d *Decorator) enabled() bool {
+	_, null := d.store.(NullStore)
+	return !null && d.ttl > 0
+}
+
+// Key returns the cache key of a call to the method, or "" when it is not
+// cached: caching is off, the store failed or args cannot be encoded
+func (d *Decorator) Key(ctx context.Context, method string, args ...interface{}) string {
+	if !d.enabled() {
+		return ""
+	}
+
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		logger.FromContext(ctx).Debug("Arguments cannot be cached", zap.String("method", method), zap.Error(err))
+		return ""
+	}
+	generation, err := d.generation(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to read cache generation", zap.String("cache", d.namespace), zap.Error(err))
+		return ""
+	}
+
+	userID, role := rls.UserFromContext(ctx)
+	sum := sha256.Sum256(encoded)
+	return strings.Join([]string{d.namespace, generation, tenancy.FromContext(ctx), userID, role, method, hex.EncodeToString(sum[:])}, ":")
+}
+
+// Load decodes the cached results of key into results, pointers to the
+// method's results in order, and reports whether they were cached
+func (d *Decorator) Load(ctx context.Context, key string, results ...interface{}) bool {
+	if key == "" {
+		return false
+	}
+	value, ok, err := d.store.Get(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to read cache", zap.String("cache", d.namespace), zap.Error(err))
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	decoder := gob.NewDecoder(bytes.NewReader(value))
+	for _, result := range results {
+		if err := decoder.Decode(result); err != nil {
+			logger.FromContext(ctx).Warn("Failed to decode cached value", zap.String("cache", d.namespace), zap.Error(err))
+			return false
+		}
+	}
+	return true
+}
+
+// Save caches the results of key. Results gob cannot encode, such as nil
+// pointers, are not cached and are read again next time.
+func (d *Decorator) Save(ctx context.Context, key string, results ...interface{}) {
+	if key == "" {
+		return
+	}
+
+	var value bytes.Buffer
+	encoder := gob.NewEncoder(&value)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			logger.FromContext(ctx).Debug("Result cannot be cached", zap.String("cache", d.namespace), zap.Error(err))
+			return
+		}
+	}
+	if err := d.store.Set(ctx, key, value.Bytes(), d.ttl); err != nil {
+		logger.FromContext(ctx).Warn("Failed to write cache", zap.String("cache", d.namespace), zap.Error(err))
+	}
+}
+
+// Invalidate drops every cached read of the repository, for all tenants and
+// users, by starting a new generation, and with a SyncedStore on the other
+// instances too
+func (d *Decorator) Invalidate(ctx context.Context) {
+	if !d.enabled() {
+		return
+	}
+	if err := d.store.Set(ctx, generationKey(d.namespace), []byte(uuid.NewString()), 0); err != nil {
+		logger.FromContext(ctx).Warn("Failed to invalidate cache", zap.String("cache", d.namespace), zap.Error(err))
+	}
+	if synced, ok := d.store.(*SyncedStore); ok {
+		if err := synced.publish(ctx, d.namespace); err != nil {
+			logger.FromContext(ctx).Warn("Failed to publish cache invalidation", zap.String("cache", d.namespace), zap.Error(err))
+		}
+	}
+}
+
+// generation returns the current generation, starting one when the store
+// has none, e.g. after the memory store was cleared. A new generation rather
+// than a fixed first one keeps values of an evicted generation unreachable.
+func (d *Decorator) generation(ctx context.Context) (string, error) {
+	value, ok, err := d.store.Get(ctx, generationKey(d.namespace))
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return string(value), nil
+	}
+
+	generation := uuid.NewString()
+	if err := d.store.Set(ctx, generationKey(d.namespace), []byte(generation), 0); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
+
+func generationKey(namespace string) string {
+	return namespace + ":generation"
+}
@@ -0,0 +1,79 @@
+// pkg/cache/memory.go - In-process cache store
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+)
+
+// MemoryStore keeps values in the process. It holds up to maxEntries values
+// and is cleared when full, which only costs the reads made again.
+type MemoryStore struct {
+	maxEntries int
+	clock      clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // zero for no expiry
+}
+
+// NewMemoryStore creates an empty store; maxEntries of 0 or less is unbounded
+func NewMemoryStore(maxEntries int, clk clock.Clock) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		clock:      clk,
+		entries:    make(map[string]memoryEntry),
+	}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expiresAt.IsZero() && !s.clock.Now().Before(entry.expiresAt) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *MemoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[key]; !exists && s.maxEntries > 0 && len(s.entries) >= s.maxEntries {
+		s.entries = make(map[string]memoryEntry)
+	}
+
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = s.clock.Now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+// Clear drops every value
+func (s *MemoryStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]memoryEntry)
+}
+
+// Len returns the number of values held, including expired ones not yet read
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
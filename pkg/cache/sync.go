@@ -0,0 +1,130 @@
+// pkg/cache/sync.go - Invalidating the memory stores of every instance with Postgres NOTIFY
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Channel is the Postgres channel invalidations are sent on
+const Channel = "cache_invalidations"
+
+// listenRetry is how long the listener waits before reconnecting
+const listenRetry = 5 * time.Second
+
+// invalidation is the payload of a notification on Channel
+type invalidation struct {
+	Instance  string `json:"instance"`
+	Namespace string `json:"namespace"`
+}
+
+// SyncedStore is the memory store of one instance, kept in step with the
+// others: Decorator.Invalidate sends the namespace it invalidates on Channel,
+// and Listen invalidates the namespaces other instances send. Postgres
+// delivers a notification once its transaction commits, to every instance
+// listening on the database, so reads cached elsewhere go stale for about
+// the latency of the database rather than the cache TTL.
+type SyncedStore struct {
+	*MemoryStore
+	db       *gorm.DB
+	instance string
+}
+
+// NewSyncedStore syncs store with the other instances sharing db
+func NewSyncedStore(store *MemoryStore, db *gorm.DB) *SyncedStore {
+	return &SyncedStore{MemoryStore: store, db: db, instance: uuid.NewString()}
+}
+
+// publish tells the other instances to invalidate namespace. Notifications go
+// to the main database, whatever the tenant, since generations are shared
+// by all tenants.
+func (s *SyncedStore) publish(ctx context.Context, namespace string) error {
+	payload, err := json.Marshal(invalidation{Instance: s.instance, Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", Channel, string(payload)).Error
+}
+
+// Listen invalidates the namespaces other instances publish until ctx is
+// done, holding one connection of the pool. Invalidations sent while it is
+// not connected are lost, so it clears the store on each connection; run it
+// in a goroutine next to whatever serves cached reads.
+func (s *SyncedStore) Listen(ctx context.Context) {
+	for {
+		err := s.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Warn("Cache invalidation listener disconnected", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetry):
+		}
+	}
+}
+
+func (s *SyncedStore) listen(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		pgConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("cache sync needs the pgx driver")
+		}
+		if _, err := pgConn.Conn().Exec(ctx, "LISTEN "+Channel); err != nil {
+			return err
+		}
+		defer func() {
+			// Unlisten before the connection goes back to the pool, in case
+			// it still works
+			ctx, cancel := context.WithTimeout(context.Background(), listenRetry)
+			defer cancel()
+			_, _ = pgConn.Conn().Exec(ctx, "UNLISTEN "+Channel)
+		}()
+
+		s.Clear()
+		for {
+			notification, err := pgConn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			s.apply(notification.Payload)
+		}
+	})
+}
+
+// apply invalidates the namespace of a payload another instance sent
+func (s *SyncedStore) apply(payload string) {
+	var message invalidation
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		logger.Warn("Invalid cache invalidation", zap.String("payload", payload), zap.Error(err))
+		return
+	}
+	if message.Instance == s.instance {
+		return
+	}
+	// Not a call from a request, so its context is background
+	if err := s.MemoryStore.Set(context.Background(), generationKey(message.Namespace), []byte(uuid.NewString()), 0); err != nil {
+		logger.Warn("Failed to apply cache invalidation", zap.String("cache", message.Namespace), zap.Error(err))
+	}
+}
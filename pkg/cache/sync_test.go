@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// notifications records the pg_notify calls of a database that never
+// connects
+type notifications struct {
+	channels []string
+	payloads []string
+}
+
+func newNotifyDB(t *testing.T) (*gorm.DB, *notifications) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	sent := &notifications{}
+	err = db.Callback().Raw().After("gorm:raw").Register("test:notify", func(tx *gorm.DB) {
+		require.Equal(t, "SELECT pg_notify($1, $2)", tx.Statement.SQL.String())
+		sent.channels = append(sent.channels, tx.Statement.Vars[0].(string))
+		sent.payloads = append(sent.payloads, tx.Statement.Vars[1].(string))
+	})
+	require.NoError(t, err)
+	return db, sent
+}
+
+func newSyncedStore(t *testing.T) (*SyncedStore, *notifications) {
+	t.Helper()
+
+	db, sent := newNotifyDB(t)
+	return NewSyncedStore(NewMemoryStore(100, clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))), db), sent
+}
+
+func payload(t *testing.T, instance, namespace string) string {
+	t.Helper()
+
+	encoded, err := json.Marshal(invalidation{Instance: instance, Namespace: namespace})
+	require.NoError(t, err)
+	return string(encoded)
+}
+
+func TestSyncedStore_Apply(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newSyncedStore(t)
+	ctx := context.Background()
+	products := NewDecorator("product.ProductRepository", store, time.Minute)
+	users := NewDecorator("user.UserRepository", store, time.Minute)
+	productKey, userKey := products.Key(ctx, "GetByID", 1), users.Key(ctx, "GetByID", 1)
+
+	store.apply(payload(t, "other", "product.ProductRepository"))
+
+	assert.NotEqual(t, productKey, products.Key(ctx, "GetByID", 1), "a new generation")
+	assert.Equal(t, userKey, users.Key(ctx, "GetByID", 1), "other namespaces keep theirs")
+}
+
+func TestSyncedStore_Apply_IgnoresOwnInstance(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newSyncedStore(t)
+	ctx := context.Background()
+	products := NewDecorator("product.ProductRepository", store, time.Minute)
+	key := products.Key(ctx, "GetByID", 1)
+
+	store.apply(payload(t, store.instance, "product.ProductRepository"))
+
+	assert.Equal(t, key, products.Key(ctx, "GetByID", 1))
+}
+
+func TestSyncedStore_Apply_MalformedPayload(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newSyncedStore(t)
+	ctx := context.Background()
+	products := NewDecorator("product.ProductRepository", store, time.Minute)
+	key := products.Key(ctx, "GetByID", 1)
+	entries := store.Len()
+
+	for _, malformed := range []string{"", "not json", `["product.ProductRepository"]`} {
+		assert.NotPanics(t, func() { store.apply(malformed) })
+	}
+	assert.Equal(t, key, products.Key(ctx, "GetByID", 1))
+	assert.Equal(t, entries, store.Len())
+}
+
+func TestDecorator_Invalidate_Publishes(t *testing.T) {
+	t.Parallel()
+
+	store, sent := newSyncedStore(t)
+	ctx := context.Background()
+	products := NewDecorator("product.ProductRepository", store, time.Minute)
+	key := products.Key(ctx, "GetByID", 1)
+
+	products.Invalidate(ctx)
+
+	assert.NotEqual(t, key, products.Key(ctx, "GetByID", 1), "invalidated locally")
+	assert.Equal(t, []string{Channel}, sent.channels)
+	require.Len(t, sent.payloads, 1)
+	assert.JSONEq(t, payload(t, store.instance, "product.ProductRepository"), sent.payloads[0])
+
+	// The instance that sent it ignores it; another applies it
+	store.apply(sent.payloads[0])
+	assert.NotEqual(t, key, products.Key(ctx, "GetByID", 1))
+
+	other, _ := newSyncedStore(t)
+	otherProducts := NewDecorator("product.ProductRepository", other, time.Minute)
+	otherKey := otherProducts.Key(ctx, "GetByID", 1)
+	other.apply(sent.payloads[0])
+	assert.NotEqual(t, otherKey, otherProducts.Key(ctx, "GetByID", 1))
+}
+
+func TestDecorator_Invalidate_DisabledDoesNotPublish(t *testing.T) {
+	t.Parallel()
+
+	store, sent := newSyncedStore(t)
+	NewDecorator("product.ProductRepository", store, 0).Invalidate(context.Background())
+
+	assert.Empty(t, sent.payloads)
+}
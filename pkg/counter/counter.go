@@ -0,0 +1,76 @@
+// pkg/counter/counter.go - Contention-free counters flushed to their rows in batches
+package counter
+
+import (
+	"context"
+	"math/rand"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+// Delta is a pending increment of one shard of a counted item, the rows of
+// tb_counter_deltas
+type Delta struct {
+	Name  string `gorm:"primaryKey"` // the counter, e.g. product_views
+	Key   string `gorm:"primaryKey"` // the counted item, e.g. a product ID
+	Shard int    `gorm:"primaryKey"`
+	Delta int64  `gorm:"not null;default:0"`
+}
+
+func (Delta) TableName() string {
+	return "tb_counter_deltas"
+}
+
+// Counter counts frequent events, such as views, without every request
+// updating the counted row. Each increment is an upsert adding to one of
+// several shard rows of the item, picked at random, so concurrent requests
+// rarely wait on the same row lock. A scheduled flush drains the shards and
+// adds their sums to the counted rows, see Drain.
+type Counter struct {
+	db     *gorm.DB
+	shards int
+}
+
+// New creates a counter writing to tb_counter_deltas of the request's tenant
+// database
+func New(db *gorm.DB, cfg *config.CounterConfig) *Counter {
+	shards := cfg.Shards
+	if shards < 1 {
+		shards = 1
+	}
+	return &Counter{db: db, shards: shards}
+}
+
+// Incr adds n to the counter name of key
+func (c *Counter) Incr(ctx context.Context, name, key string, n int64) error {
+	return tenancy.Conn(ctx, c.db).Exec(`
+		INSERT INTO tb_counter_deltas (name, key, shard, delta) VALUES (?, ?, ?, ?)
+		ON CONFLICT (name, key, shard) DO UPDATE SET delta = tb_counter_deltas.delta + EXCLUDED.delta`,
+		name, key, rand.Intn(c.shards), n).Error
+}
+
+// Drain deletes up to limit pending delta rows of the counter name in tx and
+// returns their sums by key along with the number of rows deleted. Call it in
+// the transaction that adds the sums to the counted rows, so a failed flush
+// keeps its deltas. Rows locked by an increment or another flush are skipped
+// until the next flush.
+func Drain(tx *gorm.DB, name string, limit int) (map[string]int64, int, error) {
+	var rows []Delta
+	err := tx.Raw(`
+		DELETE FROM tb_counter_deltas WHERE (name, key, shard) IN (
+			SELECT name, key, shard FROM tb_counter_deltas
+			WHERE name = ? ORDER BY key, shard LIMIT ? FOR UPDATE SKIP LOCKED
+		) RETURNING name, key, shard, delta`, name, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sums := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		sums[row.Key] += row.Delta
+	}
+	return sums, len(rows), nil
+}
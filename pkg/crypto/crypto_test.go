@@ -0,0 +1,163 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T, id string) string {
+	t.Helper()
+
+	key, err := GenerateKey(id)
+	require.NoError(t, err)
+	return key
+}
+
+func newKeyring(t *testing.T, entries ...string) *Keyring {
+	t.Helper()
+
+	k, err := ParseKeys(entries)
+	require.NoError(t, err)
+	return k
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	k := newKeyring(t, newKey(t, "k1"))
+
+	ciphertext, err := k.Encrypt("+66 81 234 5678", "tb_users.phone")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ciphertext, "enc:v1:k1:"))
+	assert.NotContains(t, ciphertext, "234")
+
+	plaintext, err := k.Decrypt(ciphertext, "tb_users.phone")
+	require.NoError(t, err)
+	assert.Equal(t, "+66 81 234 5678", plaintext)
+
+	again, err := k.Encrypt("+66 81 234 5678", "tb_users.phone")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "each write uses a new nonce")
+}
+
+func TestKeyring_DecryptWithRetiredKey(t *testing.T) {
+	t.Parallel()
+
+	old, current := newKey(t, "old"), newKey(t, "new")
+	ciphertext, err := newKeyring(t, old).Encrypt("secret", "t.c")
+	require.NoError(t, err)
+
+	rotated := newKeyring(t, current, old)
+	assert.Equal(t, "new", rotated.Primary())
+
+	plaintext, err := rotated.Decrypt(ciphertext, "t.c")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	reencrypted, err := rotated.Encrypt(plaintext, "t.c")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(reencrypted, "enc:v1:new:"))
+}
+
+func TestKeyring_Decrypt_AdditionalDataMismatch(t *testing.T) {
+	t.Parallel()
+
+	k := newKeyring(t, newKey(t, "k1"))
+	ciphertext, err := k.Encrypt("secret", "tb_users.phone")
+	require.NoError(t, err)
+
+	_, err = k.Decrypt(ciphertext, "tb_users.email")
+	assert.Error(t, err)
+}
+
+func TestKeyring_Decrypt_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	ciphertext, err := newKeyring(t, newKey(t, "gone")).Encrypt("secret", "t.c")
+	require.NoError(t, err)
+
+	_, err = newKeyring(t, newKey(t, "k1")).Decrypt(ciphertext, "t.c")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	assert.Contains(t, err.Error(), `"gone"`)
+}
+
+func TestKeyring_Decrypt_Malformed(t *testing.T) {
+	t.Parallel()
+
+	k := newKeyring(t, newKey(t, "k1"))
+	for _, value := range []string{"enc:v1:k1:not base64!", "enc:v1:k1:AAAA"} {
+		_, err := k.Decrypt(value, "t.c")
+		assert.Error(t, err, value)
+	}
+}
+
+func TestKeyring_Decrypt_PlaintextFallback(t *testing.T) {
+	t.Parallel()
+
+	for _, k := range []*Keyring{newKeyring(t, newKey(t, "k1")), newKeyring(t)} {
+		plaintext, err := k.Decrypt("written before encryption", "t.c")
+		require.NoError(t, err)
+		assert.Equal(t, "written before encryption", plaintext)
+	}
+}
+
+func TestKeyring_Encrypt_Empty(t *testing.T) {
+	t.Parallel()
+
+	ciphertext, err := newKeyring(t, newKey(t, "k1")).Encrypt("", "t.c")
+	require.NoError(t, err)
+	assert.Empty(t, ciphertext)
+}
+
+func TestKeyring_Encrypt_NoKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := newKeyring(t).Encrypt("secret", "t.c")
+	assert.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestParseKeys_Invalid(t *testing.T) {
+	t.Parallel()
+
+	valid := newKey(t, "k1")
+	tests := map[string][]string{
+		"missing id":    {"AAAA"},
+		"empty id":      {":" + strings.TrimPrefix(valid, "k1:")},
+		"duplicate":     {valid, valid},
+		"not base64":    {"k1:not base64!"},
+		"wrong length":  {"k1:AAAA"},
+		"second broken": {valid, "k2"},
+	}
+	for name, entries := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseKeys(entries)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadKeys_File(t *testing.T) {
+	t.Parallel()
+
+	primary, fromFile := newKey(t, "k2"), newKey(t, "k1")
+	path := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(path, []byte("# retired\n\n"+fromFile+"\n"), 0o600))
+
+	k, err := LoadKeys([]string{primary}, path)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", k.Primary())
+
+	ciphertext, err := newKeyring(t, fromFile).Encrypt("secret", "t.c")
+	require.NoError(t, err)
+	plaintext, err := k.Decrypt(ciphertext, "t.c")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	_, err = LoadKeys(nil, filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
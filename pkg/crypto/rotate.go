@@ -0,0 +1,89 @@
+// pkg/crypto/rotate.go - Re-encryption of stored values with the primary key
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// rotateBatch is how many rows Rotate rewrites per query
+const rotateBatch = 500
+
+// Rotate re-encrypts the encrypted columns of the models with the keyring's
+// primary key: values encrypted with older keys, and plaintext written before
+// a column was encrypted. Rows are rewritten one at a time, so it can run
+// while the app serves traffic. It returns how many values it rewrote.
+func Rotate(ctx context.Context, db *gorm.DB, k *Keyring, models ...interface{}) (int64, error) {
+	if k.Primary() == "" {
+		return 0, ErrNoKey
+	}
+
+	var rotated int64
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return rotated, err
+		}
+		if len(stmt.Schema.PrimaryFields) != 1 {
+			return rotated, fmt.Errorf("%s: rotation needs a single-column primary key", stmt.Schema.Table)
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if !strings.EqualFold(field.TagSettings["SERIALIZER"], SerializerName) {
+				continue
+			}
+			n, err := rotateColumn(ctx, db, k, stmt.Schema.Table, stmt.Schema.PrimaryFields[0].DBName, field.DBName)
+			rotated += n
+			if err != nil {
+				return rotated, fmt.Errorf("%s.%s: %w", stmt.Schema.Table, field.DBName, err)
+			}
+		}
+	}
+	return rotated, nil
+}
+
+func rotateColumn(ctx context.Context, db *gorm.DB, k *Keyring, table, pk, column string) (int64, error) {
+	current := prefix + k.Primary() + ":"
+	additionalData := table + "." + column
+
+	var rotated int64
+	for {
+		var rows []struct {
+			ID    string
+			Value string
+		}
+		err := db.WithContext(ctx).Table(table).
+			Select("CAST(? AS text) AS id, ? AS value", clause.Column{Name: pk}, clause.Column{Name: column}).
+			Where("? <> '' AND left(?, ?) <> ?", clause.Column{Name: column}, clause.Column{Name: column}, len(current), current).
+			Limit(rotateBatch).
+			Scan(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return rotated, err
+		}
+
+		for _, row := range rows {
+			plaintext, err := k.Decrypt(row.Value, additionalData)
+			if err != nil {
+				return rotated, fmt.Errorf("row %s: %w", row.ID, err)
+			}
+			ciphertext, err := k.Encrypt(plaintext, additionalData)
+			if err != nil {
+				return rotated, err
+			}
+
+			// Only rewrite the value that was read, in case the app changed it since
+			result := db.WithContext(ctx).Table(table).
+				Where(clause.Eq{Column: clause.Column{Name: pk}, Value: row.ID}).
+				Where(clause.Eq{Column: clause.Column{Name: column}, Value: row.Value}).
+				Update(column, ciphertext)
+			if result.Error != nil {
+				return rotated, fmt.Errorf("row %s: %w", row.ID, result.Error)
+			}
+			rotated += result.RowsAffected
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go-clean-gin/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	require.NoError(t, db.Exec("CREATE TEMP TABLE tb_secret_records (id bigint PRIMARY KEY, phone text NOT NULL) ON COMMIT DROP").Error)
+
+	old, current := newKey(t, "old"), newKey(t, "new")
+	k := newKeyring(t, current, old)
+	const additionalData = "tb_secret_records.phone"
+
+	withOld, err := newKeyring(t, old).Encrypt("0800000002", additionalData)
+	require.NoError(t, err)
+	withCurrent, err := k.Encrypt("0800000003", additionalData)
+	require.NoError(t, err)
+	seed := map[int]string{1: "0800000001", 2: withOld, 3: withCurrent, 4: ""}
+	for id, phone := range seed {
+		require.NoError(t, db.Exec("INSERT INTO tb_secret_records (id, phone) VALUES (?, ?)", id, phone).Error)
+	}
+
+	rotated, err := Rotate(context.Background(), db, k, &secretRecord{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, rotated, "the plaintext and the value encrypted with the old key")
+
+	var rows []struct {
+		ID    int
+		Phone string
+	}
+	require.NoError(t, db.Table("tb_secret_records").Order("id").Find(&rows).Error)
+	require.Len(t, rows, 4)
+	for _, row := range rows[:3] {
+		assert.True(t, strings.HasPrefix(row.Phone, "enc:v1:new:"), "row %d: %s", row.ID, row.Phone)
+		plaintext, err := k.Decrypt(row.Phone, additionalData)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("080000000%d", row.ID), plaintext)
+	}
+	assert.Equal(t, withCurrent, rows[2].Phone, "values already on the primary key are left alone")
+	assert.Empty(t, rows[3].Phone)
+
+	rotated, err = Rotate(context.Background(), db, k, &secretRecord{})
+	require.NoError(t, err)
+	assert.Zero(t, rotated)
+}
+
+func TestRotate_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	require.NoError(t, db.Exec("CREATE TEMP TABLE tb_secret_records (id bigint PRIMARY KEY, phone text NOT NULL) ON COMMIT DROP").Error)
+
+	gone, err := newKeyring(t, newKey(t, "gone")).Encrypt("0800000001", "tb_secret_records.phone")
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("INSERT INTO tb_secret_records (id, phone) VALUES (1, ?)", gone).Error)
+
+	_, err = Rotate(context.Background(), db, newKeyring(t, newKey(t, "k1")), &secretRecord{})
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestRotate_NoKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := Rotate(context.Background(), nil, newKeyring(t), &secretRecord{})
+	assert.ErrorIs(t, err, ErrNoKey)
+}
@@ -0,0 +1,64 @@
+// pkg/crypto/serializer.go - GORM serializer for encrypted string columns
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName is the serializer of encrypted columns:
+//
+//	Phone string `gorm:"serializer:encrypted"`
+//
+// Values are encrypted with the configured keyring on write and decrypted on
+// read. Encrypted columns cannot be searched or indexed meaningfully, since
+// every write produces a different ciphertext.
+const SerializerName = "encrypted"
+
+func init() {
+	schema.RegisterSerializer(SerializerName, fieldSerializer{})
+}
+
+type fieldSerializer struct{}
+
+// Scan decrypts the column into the string field
+func (fieldSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("crypto: cannot decrypt %T into %s", dbValue, field.Name)
+	}
+
+	plaintext, err := Default().Decrypt(value, additionalData(field))
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", field.Schema.Table, field.DBName, err)
+	}
+	return field.Set(ctx, dst, plaintext)
+}
+
+// Value encrypts the string field for the column
+func (fieldSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	plaintext, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("crypto: %s must be a string to be encrypted, got %T", field.Name, fieldValue)
+	}
+
+	ciphertext, err := Default().Encrypt(plaintext, additionalData(field))
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s: %w", field.Schema.Table, field.DBName, err)
+	}
+	return ciphertext, nil
+}
+
+// additionalData binds ciphertexts to their table and column
+func additionalData(field *schema.Field) string {
+	return field.Schema.Table + "." + field.DBName
+}
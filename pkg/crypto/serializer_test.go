@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type secretRecord struct {
+	ID    uint
+	Phone string `gorm:"serializer:encrypted"`
+}
+
+func (secretRecord) TableName() string {
+	return "tb_secret_records"
+}
+
+// useKeyring configures k for the serializer until the test ends
+func useKeyring(t *testing.T, k *Keyring) {
+	t.Helper()
+
+	previous := Default()
+	Configure(k)
+	t.Cleanup(func() { Configure(previous) })
+}
+
+func phoneField(t *testing.T) *schema.Field {
+	t.Helper()
+
+	s, err := schema.Parse(&secretRecord{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+	return s.LookUpField("phone")
+}
+
+func TestSerializer_EncryptsOnWrite(t *testing.T) {
+	k := newKeyring(t, newKey(t, "k1"))
+	useKeyring(t, k)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, SkipDefaultTransaction: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	result := db.Create(&secretRecord{Phone: "0812345678"})
+	require.NoError(t, result.Error)
+	stmt := result.Statement
+	require.Len(t, stmt.Vars, 1)
+	valuer, ok := stmt.Vars[0].(driver.Valuer)
+	require.True(t, ok, "got %T", stmt.Vars[0])
+	value, err := valuer.Value()
+	require.NoError(t, err)
+	ciphertext, ok := value.(string)
+	require.True(t, ok, "got %T", value)
+	assert.True(t, strings.HasPrefix(ciphertext, "enc:v1:k1:"))
+
+	plaintext, err := k.Decrypt(ciphertext, "tb_secret_records.phone")
+	require.NoError(t, err)
+	assert.Equal(t, "0812345678", plaintext)
+}
+
+func TestSerializer_DecryptsOnRead(t *testing.T) {
+	k := newKeyring(t, newKey(t, "k1"))
+	useKeyring(t, k)
+
+	ciphertext, err := k.Encrypt("0812345678", "tb_secret_records.phone")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		dbValue interface{}
+		want    string
+	}{
+		"string":    {ciphertext, "0812345678"},
+		"bytes":     {[]byte(ciphertext), "0812345678"},
+		"plaintext": {"written before encryption", "written before encryption"},
+		"null":      {nil, ""},
+	}
+	field := phoneField(t)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var record secretRecord
+			require.NoError(t, fieldSerializer{}.Scan(context.Background(), field, reflect.ValueOf(&record).Elem(), tt.dbValue))
+			assert.Equal(t, tt.want, record.Phone)
+		})
+	}
+}
+
+func TestSerializer_ScanRejectsValueFromAnotherColumn(t *testing.T) {
+	k := newKeyring(t, newKey(t, "k1"))
+	useKeyring(t, k)
+
+	ciphertext, err := k.Encrypt("0812345678", "tb_users.phone")
+	require.NoError(t, err)
+
+	var record secretRecord
+	err = fieldSerializer{}.Scan(context.Background(), phoneField(t), reflect.ValueOf(&record).Elem(), ciphertext)
+	assert.ErrorContains(t, err, "tb_secret_records.phone")
+	assert.Empty(t, record.Phone)
+}
+
+func TestSerializer_Value_NoKey(t *testing.T) {
+	useKeyring(t, newKeyring(t))
+
+	_, err := fieldSerializer{}.Value(context.Background(), phoneField(t), reflect.Value{}, "0812345678")
+	assert.ErrorIs(t, err, ErrNoKey)
+}
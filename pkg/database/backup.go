@@ -0,0 +1,66 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"go-clean-gin/config"
+)
+
+// Backup streams a pg_dump of the database (custom format) into w
+func Backup(ctx context.Context, cfg *config.DatabaseConfig, w io.Writer) error {
+	args := append(connectionArgs(cfg), "--format=custom", "--no-owner", "--no-privileges")
+
+	cmd := exec.CommandContext(ctx, "pg_dump", args...)
+	cmd.Env = pgEnv(cfg)
+	cmd.Stdout = w
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_dump failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// Restore reads a custom-format dump from r and restores it into the database,
+// dropping existing objects first
+func Restore(ctx context.Context, cfg *config.DatabaseConfig, r io.Reader) error {
+	args := append(connectionArgs(cfg), "--clean", "--if-exists", "--no-owner", "--no-privileges", "--single-transaction")
+
+	cmd := exec.CommandContext(ctx, "pg_restore", args...)
+	cmd.Env = pgEnv(cfg)
+	cmd.Stdin = r
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_restore failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func connectionArgs(cfg *config.DatabaseConfig) []string {
+	return []string{
+		"--host=" + cfg.Host,
+		"--port=" + strconv.Itoa(cfg.Port),
+		"--username=" + cfg.User,
+		"--dbname=" + cfg.Name,
+	}
+}
+
+// pgEnv passes credentials through the environment instead of the command line
+func pgEnv(cfg *config.DatabaseConfig) []string {
+	return append(os.Environ(),
+		"PGPASSWORD="+cfg.Password,
+		"PGSSLMODE="+cfg.SSLMode,
+	)
+}
@@ -0,0 +1,36 @@
+// Package bulk inserts many rows at once for seeders and imports. It lives
+// apart from pkg/database, which runs the seeders and so cannot be imported
+// by them.
+package bulk
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// maxBindParams is the most placeholders Postgres accepts in one statement
+const maxBindParams = 65535
+
+// Insert inserts rows into table with multi-row INSERT statements, as many
+// rows per statement as the bind parameter limit allows, in one transaction.
+// Every row must have the same columns. Rows skip GORM models and hooks, so
+// set ids and timestamps in the maps.
+//
+// COPY FROM would be faster still, but it needs the raw pgx connection and so
+// cannot join the transaction a seeder or test is running in.
+func Insert(db *gorm.DB, table string, rows []map[string]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := len(rows[0])
+	for i, row := range rows {
+		if len(row) != columns {
+			return fmt.Errorf("bulk insert into %s: row %d has %d columns, expected %d", table, i, len(row), columns)
+		}
+	}
+
+	batchSize := maxBindParams / columns
+	return db.Table(table).CreateInBatches(rows, batchSize).Error
+}
@@ -0,0 +1,51 @@
+// pkg/database/ids.go - Go-side primary key generation
+package database
+
+import (
+	"reflect"
+
+	"go-clean-gin/pkg/ids"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+
+// registerIDCallback fills empty uuid primary keys with ids.New() before insert
+// when UUIDv7 is enabled (DB_UUID_VERSION=v7). With v4, and for raw SQL
+// inserts, the column default (gen_random_uuid()) is used, so entities and
+// migrations keep that default and existing tables need no changes either way.
+func registerIDCallback(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register("ids:generate", generateIDs)
+}
+
+func generateIDs(db *gorm.DB) {
+	if !ids.GeneratesInGo() || db.Statement.Schema == nil {
+		return
+	}
+
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil || field.FieldType != uuidType {
+		return
+	}
+
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			setID(db, field, reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		setID(db, field, value)
+	}
+}
+
+func setID(db *gorm.DB, field *schema.Field, value reflect.Value) {
+	if _, zero := field.ValueOf(db.Statement.Context, value); zero {
+		if err := field.Set(db.Statement.Context, value, ids.New()); err != nil {
+			db.AddError(err)
+		}
+	}
+}
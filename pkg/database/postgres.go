@@ -2,14 +2,23 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
+	"log"
+	"os"
 	"time"
 
 	"go-clean-gin/config"
+	"go-clean-gin/internal/anonymizers"
 	"go-clean-gin/internal/migrations"
 	"go-clean-gin/internal/seeders"
+	"go-clean-gin/pkg/ids"
 	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/publicid"
+	"go-clean-gin/pkg/rls"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -18,6 +27,10 @@ import (
 
 // NewPostgresDB creates a new PostgreSQL database connection
 func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	if err := ids.SetVersion(cfg.UUIDVersion); err != nil {
+		return nil, err
+	}
+
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=UTC",
 		cfg.Host,
@@ -28,6 +41,24 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 		cfg.SSLMode,
 	)
 
+	db, err := OpenPostgres(dsn, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Successfully connected to PostgreSQL database",
+		zap.String("host", cfg.Host),
+		zap.Int("port", cfg.Port),
+		zap.String("database", cfg.Name),
+		zap.Int("max_idle_conns", cfg.MaxIdleConns),
+		zap.Int("max_open_conns", cfg.MaxOpenConns))
+
+	return db, nil
+}
+
+// OpenPostgres connects to the database at dsn with the GORM settings and
+// connection pool limits of cfg
+func OpenPostgres(dsn string, cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	// Configure GORM logger based on config
 	var logLevel gormLogger.LogLevel
 	switch cfg.LogLevel {
@@ -43,7 +74,7 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 
 	// Configure GORM
 	gormConfig := &gorm.Config{
-		Logger: gormLogger.Default.LogMode(logLevel),
+		Logger: sqlLogger().LogMode(logLevel),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -51,12 +82,29 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 		CreateBatchSize:                          1000,
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
+	dialector, err := dialect(dsn, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, gormConfig)
 	if err != nil {
 		logger.Error("Failed to connect to database", zap.Error(err))
 		return nil, err
 	}
 
+	if err := registerIDCallback(db); err != nil {
+		return nil, fmt.Errorf("failed to register ID callback: %w", err)
+	}
+	if err := publicid.Register(db); err != nil {
+		return nil, fmt.Errorf("failed to register public ID callback: %w", err)
+	}
+	if cfg.RLS {
+		if err := rls.Register(db); err != nil {
+			return nil, fmt.Errorf("failed to register row-level security callbacks: %w", err)
+		}
+	}
+
 	// Get underlying sql.DB
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -72,19 +120,42 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
 		logger.Error("Failed to ping database", zap.Error(err))
+		sqlDB.Close()
 		return nil, err
 	}
 
-	logger.Info("Successfully connected to PostgreSQL database",
-		zap.String("host", cfg.Host),
-		zap.Int("port", cfg.Port),
-		zap.String("database", cfg.Name),
-		zap.Int("max_idle_conns", cfg.MaxIdleConns),
-		zap.Int("max_open_conns", cfg.MaxOpenConns))
-
 	return db, nil
 }
 
+// dialect returns the dialector for dsn. Without DB_RLS nothing sets the
+// row-level security session variables, so the connections bypass the
+// policies instead of seeing no rows.
+func dialect(dsn string, cfg *config.DatabaseConfig) (gorm.Dialector, error) {
+	if cfg.RLS {
+		return postgres.Open(dsn), nil
+	}
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	connConfig.RuntimeParams[rls.VarBypass] = "on"
+	return postgres.New(postgres.Config{Conn: stdlib.OpenDB(*connConfig)}), nil
+}
+
+// sqlLogger is GORM's default logger, writing to stderr instead of stdout
+// when the application logs do
+func sqlLogger() gormLogger.Interface {
+	if logger.Output != "stderr" {
+		return gormLogger.Default
+	}
+	return gormLogger.New(log.New(os.Stderr, "\r\n", log.LstdFlags), gormLogger.Config{
+		SlowThreshold: 200 * time.Millisecond,
+		LogLevel:      gormLogger.Warn,
+		Colorful:      true,
+	})
+}
+
 // RunMigrations runs database migrations using Laravel-style migration system
 func RunMigrations(db *gorm.DB) error {
 	logger.Info("Starting Laravel-style migrations...")
@@ -121,14 +192,15 @@ func RollbackMigrations(db *gorm.DB, count int) error {
 	return nil
 }
 
-// GetMigrationStatus returns the current migration status
-func GetMigrationStatus(db *gorm.DB) error {
+// GetMigrationStatus returns the current migration status, with who applied
+// each migration when verbose
+func GetMigrationStatus(db *gorm.DB, verbose bool) error {
 	// Create migration manager
 	migrationManager := migrations.NewMigrationManager(db)
 	migrations.SetGlobalManager(migrationManager)
 
 	// Get migration status
-	if err := migrationManager.GetMigrationStatus(); err != nil {
+	if err := migrationManager.GetMigrationStatus(verbose); err != nil {
 		logger.Error("Failed to get migration status", zap.Error(err))
 		return err
 	}
@@ -136,6 +208,22 @@ func GetMigrationStatus(db *gorm.DB) error {
 	return nil
 }
 
+// MigrationStatus returns whether each migration has been applied
+func MigrationStatus(db *gorm.DB) ([]migrations.MigrationStatus, error) {
+	migrationManager := migrations.NewMigrationManager(db)
+	migrations.SetGlobalManager(migrationManager)
+
+	return migrationManager.Status()
+}
+
+// SeederPlan returns the seeders SeedData runs for the name, in order
+func SeederPlan(db *gorm.DB, seederName string) ([]seeders.Seeder, error) {
+	seederManager := seeders.NewSeederManager(db)
+	seeders.SetGlobalSeederManager(seederManager)
+
+	return seederManager.Plan(seederName)
+}
+
 // SeedData seeds the database with initial data using Laravel-style seeders
 func SeedData(db *gorm.DB, seederName string) error {
 	logger.Info("Starting Laravel-style database seeding...")
@@ -172,14 +260,39 @@ func RunSpecificSeeder(db *gorm.DB, seederName string) error {
 	return nil
 }
 
-// ListSeeders lists all registered seeders
-func ListSeeders(db *gorm.DB) error {
+// ListSeeders lists all registered seeders, with their last run when verbose
+func ListSeeders(db *gorm.DB, verbose bool) error {
 	// Create seeder manager
 	seederManager := seeders.NewSeederManager(db)
 	seeders.SetGlobalSeederManager(seederManager)
 
 	// List seeders
-	seederManager.ListSeeders()
+	return seederManager.ListSeeders(verbose)
+}
+
+// SeederHistory returns the latest recorded run of each seeder, by name
+func SeederHistory(db *gorm.DB) (map[string]seeders.SeederRecord, error) {
+	return seeders.NewSeederManager(db).LastRuns()
+}
+
+// AnonymizeData replaces personal data with fake data using registered anonymizers
+func AnonymizeData(db *gorm.DB, anonymizerName string) error {
+	logger.Info("Starting data anonymization...")
+
+	anonymizerManager := anonymizers.NewAnonymizerManager(db)
+
+	if err := anonymizerManager.RunAnonymizers(anonymizerName); err != nil {
+		logger.Error("Failed to run anonymizers", zap.Error(err))
+		return err
+	}
+
+	logger.Info("Data anonymization completed successfully")
+	return nil
+}
+
+// ListAnonymizers lists all registered anonymizers
+func ListAnonymizers(db *gorm.DB) error {
+	anonymizers.NewAnonymizerManager(db).ListAnonymizers()
 	return nil
 }
 
@@ -198,13 +311,20 @@ func HealthCheck(db *gorm.DB) error {
 }
 
 // GetDatabaseStats returns database connection statistics
-func GetDatabaseStats(db *gorm.DB) error {
+func GetDatabaseStats(db *gorm.DB) (sql.DBStats, error) {
 	sqlDB, err := db.DB()
 	if err != nil {
-		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		return sql.DBStats{}, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
+	return sqlDB.Stats(), nil
+}
 
-	stats := sqlDB.Stats()
+// LogDatabaseStats logs database connection statistics
+func LogDatabaseStats(db *gorm.DB) error {
+	stats, err := GetDatabaseStats(db)
+	if err != nil {
+		return err
+	}
 
 	logger.Info("Database Connection Statistics",
 		zap.Int("max_open_connections", stats.MaxOpenConnections),
@@ -0,0 +1,178 @@
+// pkg/dbretry/dbretry.go - Retries of repository calls failing with transient database errors
+package dbretry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/metrics"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// Reasons a call is retried, the reason label of metrics.DBRetries
+const (
+	ReasonSerializationFailure = "serialization_failure"
+	ReasonDeadlock             = "deadlock"
+	ReasonConnection           = "connection"
+)
+
+// Retrier runs repository calls again when they fail with an error the next
+// attempt may not hit. The decorators artisan make:retry generates call it
+// around the methods of a repository:
+//
+//	err = r.Do(ctx, "post.PostRepository.GetPostByID", true, func() error {
+//		post, err = next.GetPostByID(ctx, id)
+//		return err
+//	})
+//
+// Each call must be a unit of work of its own: a repository method that runs
+// its statements in a transaction it begins and ends, as they all do, rather
+// than one statement of a transaction the caller holds open.
+type Retrier struct {
+	attempts  int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+// New creates a Retrier making up to cfg.RetryAttempts attempts per call
+func New(cfg *config.DatabaseConfig) *Retrier {
+	attempts := cfg.RetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &Retrier{
+		attempts:  attempts,
+		baseDelay: cfg.RetryBaseDelay,
+		maxDelay:  cfg.RetryMaxDelay,
+	}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not transient,
+// ctx is done or the attempts run out, and returns fn's last error.
+// operation names the call in logs and metrics. Idempotent calls are also
+// retried when the connection was lost after the statement was sent, since
+// running them twice does no harm.
+func (r *Retrier) Do(ctx context.Context, operation string, idempotent bool, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		reason := Reason(err, idempotent)
+		if reason == "" || ctx.Err() != nil {
+			return err
+		}
+		if attempt >= r.attempts {
+			if r.attempts > 1 {
+				metrics.DBRetriesExhausted.WithLabelValues(operation).Inc()
+			}
+			return err
+		}
+
+		delay := r.backoff(attempt)
+		metrics.DBRetries.WithLabelValues(operation, reason).Inc()
+		logger.FromContext(ctx).Warn("Retrying after transient database error",
+			zap.String("operation", operation),
+			zap.String("reason", reason),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+		if !sleep(ctx, delay) {
+			return err
+		}
+	}
+}
+
+// backoff returns a random delay up to baseDelay doubled for every attempt
+// made, capped at maxDelay. The jitter keeps the calls that deadlocked with
+// each other from colliding again on the next attempt.
+func (r *Retrier) backoff(attempt int) time.Duration {
+	if r.baseDelay <= 0 {
+		return 0
+	}
+	limit := r.baseDelay
+	for i := 1; i < attempt && (r.maxDelay <= 0 || limit < r.maxDelay); i++ {
+		limit *= 2
+	}
+	if r.maxDelay > 0 && limit > r.maxDelay {
+		limit = r.maxDelay
+	}
+	return time.Duration(rand.Int63n(int64(limit) + 1))
+}
+
+// sleep waits for d and reports whether ctx was still live when it ended
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Reason returns why err is worth another attempt, or "" when it is not.
+// Serialization failures and deadlocks roll the transaction back, so any
+// call may run again. After a lost connection only the calls whose
+// statement never reached the server may, unless they are idempotent.
+func Reason(err error, idempotent bool) string {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "40001":
+			return ReasonSerializationFailure
+		case pgErr.Code == "40P01":
+			return ReasonDeadlock
+		case idempotent && connectionLost(pgErr.Code):
+			return ReasonConnection
+		}
+		return ""
+	}
+
+	var safe interface{ SafeToRetry() bool }
+	if errors.As(err, &safe) && safe.SafeToRetry() {
+		return ReasonConnection
+	}
+	if !idempotent {
+		return ""
+	}
+
+	var netErr net.Error
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.As(err, &netErr) {
+		return ReasonConnection
+	}
+	return ""
+}
+
+// connectionLost reports whether the SQLSTATE code says the server closed or
+// refused the connection: class 08 and the shutdown codes of class 57
+func connectionLost(code string) bool {
+	switch code {
+	case "57P01", "57P02", "57P03":
+		return true
+	}
+	return strings.HasPrefix(code, "08")
+}
@@ -24,26 +24,117 @@ func (e *AppError) Error() string {
 // Error codes
 const (
 	// General errors
-	ErrInternal     = "INTERNAL_ERROR"
-	ErrNotFound     = "NOT_FOUND"
-	ErrBadRequest   = "BAD_REQUEST"
-	ErrUnauthorized = "UNAUTHORIZED"
-	ErrForbidden    = "FORBIDDEN"
-	ErrConflict     = "CONFLICT"
-	ErrValidation   = "VALIDATION_ERROR"
+	ErrInternal        = "INTERNAL_ERROR"
+	ErrNotFound        = "NOT_FOUND"
+	ErrBadRequest      = "BAD_REQUEST"
+	ErrUnauthorized    = "UNAUTHORIZED"
+	ErrForbidden       = "FORBIDDEN"
+	ErrConflict        = "CONFLICT"
+	ErrValidation      = "VALIDATION_ERROR"
+	ErrTooManyRequests = "TOO_MANY_REQUESTS"
+	ErrUnavailable     = "SERVICE_UNAVAILABLE"
+	ErrQuotaExceeded   = "QUOTA_EXCEEDED"
+	ErrTenantNotFound  = "TENANT_NOT_FOUND"
 
 	// Auth errors
-	ErrInvalidCredentials = "INVALID_CREDENTIALS"
-	ErrTokenExpired       = "TOKEN_EXPIRED"
-	ErrTokenInvalid       = "TOKEN_INVALID"
-	ErrUserExists         = "USER_EXISTS"
-	ErrUserNotFound       = "USER_NOT_FOUND"
+	ErrInvalidCredentials   = "INVALID_CREDENTIALS"
+	ErrTokenExpired         = "TOKEN_EXPIRED"
+	ErrTokenInvalid         = "TOKEN_INVALID"
+	ErrUserExists           = "USER_EXISTS"
+	ErrUserNotFound         = "USER_NOT_FOUND"
+	ErrEmailChangeInvalid   = "EMAIL_CHANGE_INVALID"
+	ErrPasswordResetInvalid = "PASSWORD_RESET_INVALID"
+	ErrInvalidImage         = "INVALID_IMAGE"
+	ErrFileTooLarge         = "FILE_TOO_LARGE"
+	ErrAvatarNotFound       = "AVATAR_NOT_FOUND"
+
+	// Upload scanning errors
+	ErrFileInfected    = "FILE_INFECTED"
+	ErrScanUnavailable = "SCAN_UNAVAILABLE"
+
+	// Signed URL errors
+	ErrLinkInvalid = "LINK_INVALID"
+
+	// Signed request errors
+	ErrRequestSignatureInvalid = "REQUEST_SIGNATURE_INVALID"
+
+	// OIDC provider errors
+	ErrInvalidClient        = "INVALID_CLIENT"
+	ErrInvalidRedirectURI   = "INVALID_REDIRECT_URI"
+	ErrInvalidScope         = "INVALID_SCOPE"
+	ErrInvalidGrant         = "INVALID_GRANT"
+	ErrUnsupportedGrantType = "UNSUPPORTED_GRANT_TYPE"
+
+	// SSO errors
+	ErrSSOConnectionNotFound = "SSO_CONNECTION_NOT_FOUND"
+	ErrSSOStateInvalid       = "SSO_STATE_INVALID"
+	ErrSSOFailed             = "SSO_FAILED"
+	ErrSSODomainNotAllowed   = "SSO_DOMAIN_NOT_ALLOWED"
+	ErrSSOAccountExists      = "SSO_ACCOUNT_EXISTS"
+
+	// SCIM errors
+	ErrSCIMInvalidFilter = "SCIM_INVALID_FILTER"
+	ErrSCIMInvalidValue  = "SCIM_INVALID_VALUE"
+	ErrSCIMInvalidPath   = "SCIM_INVALID_PATH"
+
+	// Patch errors
+	ErrUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrPatchInvalid         = "PATCH_INVALID"
+	ErrPatchTestFailed      = "PATCH_TEST_FAILED"
+
+	// Export errors
+	ErrExportNotFound = "EXPORT_NOT_FOUND"
+	ErrExportInvalid  = "EXPORT_INVALID"
+
+	// Import errors
+	ErrImportNotFound = "IMPORT_NOT_FOUND"
+	ErrImportInvalid  = "IMPORT_INVALID"
+	ErrImportNotReady = "IMPORT_NOT_READY"
+
+	// Consent errors
+	ErrConsentRequired = "CONSENT_REQUIRED"
+	ErrPolicyNotFound  = "POLICY_NOT_FOUND"
 
 	// Product errors
 	ErrProductNotFound   = "PRODUCT_NOT_FOUND"
 	ErrProductExists     = "PRODUCT_EXISTS"
 	ErrInsufficientStock = "INSUFFICIENT_STOCK"
 	ErrInvalidOwner      = "INVALID_OWNER"
+	ErrUnknownAttribute  = "UNKNOWN_ATTRIBUTE"
+
+	// Product image errors
+	ErrProductImageNotFound = "PRODUCT_IMAGE_NOT_FOUND"
+	ErrProductImageLimit    = "PRODUCT_IMAGE_LIMIT"
+
+	// Organization errors
+	ErrOrganizationNotFound  = "ORGANIZATION_NOT_FOUND"
+	ErrOrganizationForbidden = "ORGANIZATION_FORBIDDEN"
+	ErrOrganizationNotEmpty  = "ORGANIZATION_NOT_EMPTY"
+	ErrMemberNotFound        = "MEMBER_NOT_FOUND"
+	ErrMemberExists          = "MEMBER_EXISTS"
+	ErrLastOwner             = "LAST_OWNER"
+	ErrInvitationInvalid     = "INVITATION_INVALID"
+	ErrInvitationNotFound    = "INVITATION_NOT_FOUND"
+	ErrInvitationAccepted    = "INVITATION_ACCEPTED"
+
+	// Setting errors
+	ErrSettingNotFound = "SETTING_NOT_FOUND"
+	ErrSettingInvalid  = "SETTING_INVALID"
+
+	// Reservation errors
+	ErrReservationNotFound  = "RESERVATION_NOT_FOUND"
+	ErrReservationNotActive = "RESERVATION_NOT_ACTIVE"
+	ErrReservationExpired   = "RESERVATION_EXPIRED"
+
+	// Notification errors
+	ErrNotificationNotFound = "NOTIFICATION_NOT_FOUND"
+
+	// Saved search errors
+	ErrSavedSearchNotFound = "SAVED_SEARCH_NOT_FOUND"
+	ErrSavedSearchLimit    = "SAVED_SEARCH_LIMIT"
+
+	// Exchange errors
+	ErrExchangeRateUnavailable = "EXCHANGE_RATE_UNAVAILABLE"
 )
 
 // New creates a new AppError
@@ -73,22 +164,106 @@ func (e *AppError) WithDetails(details interface{}) *AppError {
 
 // Predefined errors
 var (
-	ErrInternalServer    = New(ErrInternal, "Internal server error", http.StatusInternalServerError)
-	ErrNotFoundError     = New(ErrNotFound, "Resource not found", http.StatusNotFound)
-	ErrBadRequestError   = New(ErrBadRequest, "Bad request", http.StatusBadRequest)
-	ErrUnauthorizedError = New(ErrUnauthorized, "Unauthorized", http.StatusUnauthorized)
-	ErrForbiddenError    = New(ErrForbidden, "Forbidden", http.StatusForbidden)
+	ErrInternalServer         = New(ErrInternal, "Internal server error", http.StatusInternalServerError)
+	ErrNotFoundError          = New(ErrNotFound, "Resource not found", http.StatusNotFound)
+	ErrBadRequestError        = New(ErrBadRequest, "Bad request", http.StatusBadRequest)
+	ErrUnauthorizedError      = New(ErrUnauthorized, "Unauthorized", http.StatusUnauthorized)
+	ErrForbiddenError         = New(ErrForbidden, "Forbidden", http.StatusForbidden)
+	ErrTooManyRequestsError   = New(ErrTooManyRequests, "Too many attempts, please try again later", http.StatusTooManyRequests)
+	ErrOverloadedError        = New(ErrUnavailable, "Service is overloaded, please try again later", http.StatusServiceUnavailable)
+	ErrTooManyConcurrentError = New(ErrTooManyRequests, "Too many requests in progress, please try again later", http.StatusTooManyRequests)
+	ErrQuotaExceededError     = New(ErrQuotaExceeded, "API quota exceeded, please try again after it resets", http.StatusTooManyRequests)
+	ErrTenantUnavailableError = New(ErrUnavailable, "Tenant database is unavailable, please try again later", http.StatusServiceUnavailable)
+	ErrTenantNotFoundError    = New(ErrTenantNotFound, "Tenant not found", http.StatusNotFound)
+	ErrMaintenanceError       = New(ErrUnavailable, "Service is down for maintenance, please try again later", http.StatusServiceUnavailable)
 
 	// Auth errors
-	ErrInvalidCredentialsError = New(ErrInvalidCredentials, "Invalid email or password", http.StatusUnauthorized)
-	ErrTokenExpiredError       = New(ErrTokenExpired, "Token has expired", http.StatusUnauthorized)
-	ErrTokenInvalidError       = New(ErrTokenInvalid, "Invalid token", http.StatusUnauthorized)
-	ErrUserExistsError         = New(ErrUserExists, "User already exists", http.StatusConflict)
-	ErrUserNotFoundError       = New(ErrUserNotFound, "User not found", http.StatusNotFound)
+	ErrInvalidCredentialsError   = New(ErrInvalidCredentials, "Invalid email or password", http.StatusUnauthorized)
+	ErrTokenExpiredError         = New(ErrTokenExpired, "Token has expired", http.StatusUnauthorized)
+	ErrTokenInvalidError         = New(ErrTokenInvalid, "Invalid token", http.StatusUnauthorized)
+	ErrUserExistsError           = New(ErrUserExists, "User already exists", http.StatusConflict)
+	ErrUserNotFoundError         = New(ErrUserNotFound, "User not found", http.StatusNotFound)
+	ErrEmailChangeInvalidError   = New(ErrEmailChangeInvalid, "Confirmation link is invalid or has expired", http.StatusBadRequest)
+	ErrPasswordResetInvalidError = New(ErrPasswordResetInvalid, "Reset link is invalid or has expired", http.StatusBadRequest)
+	ErrInvalidImageError         = New(ErrInvalidImage, "Image must be a JPEG or PNG within the size limits", http.StatusBadRequest)
+	ErrFileTooLargeError         = New(ErrFileTooLarge, "File is too large", http.StatusRequestEntityTooLarge)
+	ErrAvatarNotFoundError       = New(ErrAvatarNotFound, "Avatar not found", http.StatusNotFound)
+
+	// Upload scanning errors
+	ErrFileInfectedError    = New(ErrFileInfected, "The file failed a virus scan", http.StatusUnprocessableEntity)
+	ErrScanUnavailableError = New(ErrScanUnavailable, "Uploads can't be scanned right now, please try again later", http.StatusServiceUnavailable)
+
+	// Signed URL errors
+	ErrLinkInvalidError = New(ErrLinkInvalid, "Link is invalid or has expired", http.StatusForbidden)
+
+	// Signed request errors
+	ErrRequestSignatureInvalidError = New(ErrRequestSignatureInvalid, "Request signature is missing, invalid, expired or replayed", http.StatusUnauthorized)
+
+	// OIDC provider errors
+	ErrInvalidClientError        = New(ErrInvalidClient, "Unknown client or invalid client credentials", http.StatusUnauthorized)
+	ErrInvalidRedirectURIError   = New(ErrInvalidRedirectURI, "Redirect URI is not registered for the client", http.StatusBadRequest)
+	ErrInvalidScopeError         = New(ErrInvalidScope, "Scope must include openid and only supported scopes", http.StatusBadRequest)
+	ErrInvalidGrantError         = New(ErrInvalidGrant, "Authorization code is invalid or has expired", http.StatusBadRequest)
+	ErrUnsupportedGrantTypeError = New(ErrUnsupportedGrantType, "Only the authorization_code grant is supported", http.StatusBadRequest)
+
+	// SSO errors
+	ErrSSOConnectionNotFoundError = New(ErrSSOConnectionNotFound, "No single sign-on connection for this organization", http.StatusNotFound)
+	ErrSSOStateInvalidError       = New(ErrSSOStateInvalid, "Sign-in has expired or was already completed, please start again", http.StatusBadRequest)
+	ErrSSOFailedError             = New(ErrSSOFailed, "Sign-in with the identity provider failed", http.StatusUnauthorized)
+	ErrSSODomainNotAllowedError   = New(ErrSSODomainNotAllowed, "The identity provider is not allowed to sign in this email address", http.StatusForbidden)
+	ErrSSOAccountExistsError      = New(ErrSSOAccountExists, "An account with this email already exists, please sign in with its password", http.StatusConflict)
+
+	// SCIM errors
+	ErrSCIMInvalidFilterError = New(ErrSCIMInvalidFilter, `Only filters of the form attribute eq "value" are supported`, http.StatusBadRequest)
+
+	// Patch errors
+	ErrUnsupportedMediaTypeError = New(ErrUnsupportedMediaType, "PATCH accepts application/merge-patch+json or application/json-patch+json", http.StatusUnsupportedMediaType)
+
+	// Export errors
+	ErrExportNotFoundError = New(ErrExportNotFound, "Export not found", http.StatusNotFound)
+
+	// Import errors
+	ErrImportNotFoundError = New(ErrImportNotFound, "Import not found", http.StatusNotFound)
+	ErrImportNotReadyError = New(ErrImportNotReady, "Import has not been validated or was already committed", http.StatusConflict)
+
+	// Consent errors
+	ErrConsentRequiredError = New(ErrConsentRequired, "Please accept the latest policies to continue", http.StatusForbidden)
 
 	// Product errors
 	ErrProductNotFoundError   = New(ErrProductNotFound, "Product not found", http.StatusNotFound)
 	ErrProductExistsError     = New(ErrProductExists, "Product already exists", http.StatusConflict)
 	ErrInsufficientStockError = New(ErrInsufficientStock, "Insufficient stock", http.StatusBadRequest)
 	ErrInvalidOwnerError      = New(ErrInvalidOwner, "You can only modify your own resources", http.StatusForbidden)
+
+	// Product image errors
+	ErrProductImageNotFoundError = New(ErrProductImageNotFound, "Product image not found", http.StatusNotFound)
+	ErrProductImageLimitError    = New(ErrProductImageLimit, "Product already has the maximum number of images", http.StatusConflict)
+
+	// Organization errors
+	ErrOrganizationNotFoundError  = New(ErrOrganizationNotFound, "Organization not found", http.StatusNotFound)
+	ErrOrganizationForbiddenError = New(ErrOrganizationForbidden, "Your role in this organization does not allow this", http.StatusForbidden)
+	ErrOrganizationNotEmptyError  = New(ErrOrganizationNotEmpty, "Organization still owns products; delete them first", http.StatusConflict)
+	ErrMemberNotFoundError        = New(ErrMemberNotFound, "Member not found", http.StatusNotFound)
+	ErrMemberExistsError          = New(ErrMemberExists, "User is already a member of this organization", http.StatusConflict)
+	ErrLastOwnerError             = New(ErrLastOwner, "An organization needs at least one owner", http.StatusConflict)
+	ErrInvitationInvalidError     = New(ErrInvitationInvalid, "Invitation is invalid, was already used or has expired", http.StatusBadRequest)
+	ErrInvitationNotFoundError    = New(ErrInvitationNotFound, "Invitation not found", http.StatusNotFound)
+	ErrInvitationAcceptedError    = New(ErrInvitationAccepted, "Invitation was already accepted", http.StatusConflict)
+
+	// Setting errors
+	ErrSettingNotFoundError = New(ErrSettingNotFound, "Setting not found", http.StatusNotFound)
+
+	// Reservation errors
+	ErrReservationNotFoundError  = New(ErrReservationNotFound, "Reservation not found", http.StatusNotFound)
+	ErrReservationNotActiveError = New(ErrReservationNotActive, "Reservation is no longer active", http.StatusConflict)
+	ErrReservationExpiredError   = New(ErrReservationExpired, "Reservation has expired", http.StatusConflict)
+
+	// Notification errors
+	ErrNotificationNotFoundError = New(ErrNotificationNotFound, "Notification not found", http.StatusNotFound)
+
+	// Saved search errors
+	ErrSavedSearchNotFoundError = New(ErrSavedSearchNotFound, "Saved search not found", http.StatusNotFound)
+
+	// Exchange errors
+	ErrExchangeRateUnavailableError = New(ErrExchangeRateUnavailable, "Exchange rate is not available", http.StatusServiceUnavailable)
 )
@@ -0,0 +1,56 @@
+// pkg/events/events.go - In-process event bus
+package events
+
+import (
+	"context"
+	"sync"
+
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Event is anything published on the bus, identified by name
+type Event interface {
+	EventName() string
+}
+
+// Listener handles a dispatched event
+type Listener func(ctx context.Context, event Event) error
+
+// Bus delivers events to the listeners registered for their name. Dispatch is
+// synchronous; push slow work onto the queue from inside the listener.
+type Bus struct {
+	mu        sync.RWMutex
+	listeners map[string][]Listener
+}
+
+func NewBus() *Bus {
+	return &Bus{listeners: make(map[string][]Listener)}
+}
+
+// Listen registers listener for events named name
+func (b *Bus) Listen(name string, listener Listener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listeners[name] = append(b.listeners[name], listener)
+}
+
+// Dispatch calls every listener for the event in registration order. Listener
+// errors are logged and do not stop the remaining listeners or fail the caller,
+// since the action that raised the event has already happened.
+func (b *Bus) Dispatch(ctx context.Context, event Event) {
+	b.mu.RLock()
+	listeners := b.listeners[event.EventName()]
+	b.mu.RUnlock()
+
+	for _, listener := range listeners {
+		if err := listener(ctx, event); err != nil {
+			logger.FromContext(ctx).Error("Event listener failed",
+				zap.String("event", event.EventName()),
+				zap.Error(err),
+			)
+		}
+	}
+}
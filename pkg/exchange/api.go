@@ -0,0 +1,68 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// APIProvider fetches rates over HTTP from an ExchangeRate-API compatible
+// endpoint (https://open.er-api.com/v6/latest/{base}). The {base} placeholder
+// in the URL is replaced with the base currency.
+type APIProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewAPI returns a provider reading rates from url
+func NewAPI(url string, timeout time.Duration) *APIProvider {
+	return &APIProvider{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+type apiResponse struct {
+	Result    string                     `json:"result"`
+	ErrorType string                     `json:"error-type"`
+	BaseCode  string                     `json:"base_code"`
+	UpdatedAt int64                      `json:"time_last_update_unix"`
+	Rates     map[string]decimal.Decimal `json:"rates"`
+}
+
+func (p *APIProvider) Latest(ctx context.Context, base string) (*Rates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(p.url, "{base}", base), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchange: fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("exchange: rates endpoint responded with status %d", resp.StatusCode)
+	}
+
+	var body apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("exchange: decode rates: %w", err)
+	}
+	if body.Result != "" && body.Result != "success" {
+		return nil, fmt.Errorf("exchange: rates endpoint returned %s: %s", body.Result, body.ErrorType)
+	}
+	if body.BaseCode != "" && body.BaseCode != base {
+		return nil, fmt.Errorf("exchange: requested rates from %s, got %s", base, body.BaseCode)
+	}
+
+	return &Rates{
+		Base:   base,
+		Rates:  body.Rates,
+		AsOf:   time.Unix(body.UpdatedAt, 0).UTC(),
+		Source: "api",
+	}, nil
+}
@@ -0,0 +1,30 @@
+package exchange
+
+import (
+	"context"
+
+	"go-clean-gin/pkg/health"
+)
+
+// BreakerProvider stops calling provider while its breaker is open, so a rates
+// endpoint that is down costs requests nothing instead of a timeout each. Put
+// it under a CacheProvider to keep serving the last rates meanwhile.
+type BreakerProvider struct {
+	provider Provider
+	breaker  *health.Breaker
+}
+
+// NewBreaker wraps provider with breaker
+func NewBreaker(provider Provider, breaker *health.Breaker) *BreakerProvider {
+	return &BreakerProvider{provider: provider, breaker: breaker}
+}
+
+func (p *BreakerProvider) Latest(ctx context.Context, base string) (*Rates, error) {
+	var rates *Rates
+	err := p.breaker.Do(func() error {
+		var err error
+		rates, err = p.provider.Latest(ctx, base)
+		return err
+	})
+	return rates, err
+}
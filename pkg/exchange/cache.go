@@ -0,0 +1,60 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+)
+
+// CacheProvider keeps each base currency's rates for ttl
+type CacheProvider struct {
+	provider Provider
+	ttl      time.Duration
+	clock    clock.Clock
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	rates     *Rates
+	fetchedAt time.Time
+}
+
+// NewCache wraps provider with an in-memory cache
+func NewCache(provider Provider, ttl time.Duration, clk clock.Clock) *CacheProvider {
+	return &CacheProvider{
+		provider: provider,
+		ttl:      ttl,
+		clock:    clk,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+// Latest returns cached rates while they are fresh. When a refresh fails, the
+// stale rates are served instead; their AsOf tells clients how old they are.
+func (p *CacheProvider) Latest(ctx context.Context, base string) (*Rates, error) {
+	p.mu.Lock()
+	entry, ok := p.entries[base]
+	p.mu.Unlock()
+
+	if ok && p.clock.Since(entry.fetchedAt) < p.ttl {
+		return entry.rates, nil
+	}
+
+	rates, err := p.provider.Latest(ctx, base)
+	if err != nil {
+		if ok {
+			return entry.rates, nil
+		}
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.entries[base] = cacheEntry{rates: rates, fetchedAt: p.clock.Now()}
+	p.mu.Unlock()
+
+	return rates, nil
+}
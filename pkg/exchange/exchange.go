@@ -0,0 +1,100 @@
+// pkg/exchange/exchange.go - Currency exchange rates
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/health"
+	"go-clean-gin/pkg/money"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrRateNotFound is returned when a provider has no rate for a currency pair
+var ErrRateNotFound = errors.New("exchange: rate not found")
+
+// Provider returns the latest rates from a base currency
+type Provider interface {
+	Latest(ctx context.Context, base string) (*Rates, error)
+}
+
+// Rates is a table of rates from Base, as published at AsOf
+type Rates struct {
+	Base   string
+	Rates  map[string]decimal.Decimal
+	AsOf   time.Time
+	Source string
+}
+
+// Rate returns the rate from r.Base to currency
+func (r *Rates) Rate(currency string) (Rate, error) {
+	if currency == r.Base {
+		return Rate{From: r.Base, To: currency, Value: decimal.NewFromInt(1), AsOf: r.AsOf, Source: r.Source}, nil
+	}
+
+	value, ok := r.Rates[currency]
+	if !ok {
+		return Rate{}, fmt.Errorf("%w: %s to %s", ErrRateNotFound, r.Base, currency)
+	}
+	return Rate{From: r.Base, To: currency, Value: value, AsOf: r.AsOf, Source: r.Source}, nil
+}
+
+// Rate is a single conversion rate, reported alongside converted prices
+type Rate struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Value  decimal.Decimal `json:"rate"`
+	AsOf   time.Time       `json:"as_of"`
+	Source string          `json:"source"`
+}
+
+// Conversion describes the rates used to convert a response's prices
+type Conversion struct {
+	Currency string `json:"currency"`
+	Rates    []Rate `json:"rates"`
+}
+
+// New creates a provider for the configured driver. API calls go through
+// breaker and their rates are cached for cfg.CacheTTL.
+func New(cfg *config.ExchangeConfig, clk clock.Clock, breaker *health.Breaker) (Provider, error) {
+	switch cfg.Driver {
+	case "", "fixed":
+		rates, err := parseRates(cfg.Rates)
+		if err != nil {
+			return nil, err
+		}
+		return NewFixed(money.DefaultCurrency(), rates, clk.Now()), nil
+	case "api":
+		var provider Provider = NewBreaker(NewAPI(cfg.APIURL, cfg.Timeout), breaker)
+		if cfg.CacheTTL <= 0 {
+			return provider, nil
+		}
+		return NewCache(provider, cfg.CacheTTL, clk), nil
+	default:
+		return nil, fmt.Errorf("unsupported exchange driver: %s", cfg.Driver)
+	}
+}
+
+// parseRates reads "CODE:rate" entries
+func parseRates(entries []string) (map[string]decimal.Decimal, error) {
+	rates := make(map[string]decimal.Decimal, len(entries))
+	for _, entry := range entries {
+		code, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate %q, expected CODE:rate", entry)
+		}
+
+		rate, err := decimal.NewFromString(value)
+		if err != nil || !rate.IsPositive() {
+			return nil, fmt.Errorf("invalid exchange rate %q", entry)
+		}
+		rates[strings.ToUpper(code)] = rate
+	}
+	return rates, nil
+}
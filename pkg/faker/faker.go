@@ -0,0 +1,127 @@
+package faker
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+)
+
+var (
+	firstNames = []string{
+		"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+		"William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica",
+		"Thomas", "Sarah", "Charles", "Karen", "Somchai", "Suda", "Niran", "Malee",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+		"Rodriguez", "Martinez", "Hernandez", "Lopez", "Wilson", "Anderson", "Thomas", "Taylor",
+		"Moore", "Jackson", "Martin", "Lee", "Saetang", "Srisuk", "Chaiyaporn", "Wongsa",
+	}
+	words = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit",
+		"sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore",
+		"magna", "aliqua", "enim", "ad", "minim", "veniam", "quis", "nostrud",
+	}
+	domains = []string{"example.com", "example.org", "example.net"}
+)
+
+// Faker generates fake data from a seeded random source so output is reproducible
+type Faker struct {
+	rand *rand.Rand
+}
+
+// New creates a Faker with the given seed
+func New(seed int64) *Faker {
+	return &Faker{rand: rand.New(rand.NewSource(seed))}
+}
+
+// NewFor creates a Faker seeded from a key (e.g. a record ID), so the same key
+// always produces the same fake values
+func NewFor(key string) *Faker {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return New(int64(h.Sum64()))
+}
+
+// FirstName returns a random first name
+func (f *Faker) FirstName() string {
+	return f.Pick(firstNames)
+}
+
+// LastName returns a random last name
+func (f *Faker) LastName() string {
+	return f.Pick(lastNames)
+}
+
+// Name returns a random full name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username returns a random username
+func (f *Faker) Username() string {
+	return fmt.Sprintf("%s%d", strings.ToLower(f.FirstName()), f.Int(100, 99999))
+}
+
+// Email returns a random email address on a reserved example domain
+func (f *Faker) Email() string {
+	return fmt.Sprintf("%s.%s%d@%s",
+		strings.ToLower(f.FirstName()), strings.ToLower(f.LastName()), f.Int(1, 9999), f.Pick(domains))
+}
+
+// Phone returns a random phone number
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("+1-555-%03d-%04d", f.Int(0, 999), f.Int(0, 9999))
+}
+
+// Word returns a random lorem ipsum word
+func (f *Faker) Word() string {
+	return f.Pick(words)
+}
+
+// Sentence returns a sentence with the given number of words
+func (f *Faker) Sentence(wordCount int) string {
+	parts := make([]string, wordCount)
+	for i := range parts {
+		parts[i] = f.Word()
+	}
+	sentence := strings.Join(parts, " ")
+	if sentence == "" {
+		return sentence
+	}
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// Token returns a random hex string of n bytes
+func (f *Faker) Token(n int) string {
+	b := make([]byte, n)
+	f.rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
+
+// Int returns a random int in [min, max]
+func (f *Faker) Int(min, max int) int {
+	if max <= min {
+		return min
+	}
+	return min + f.rand.Intn(max-min+1)
+}
+
+// Float returns a random float64 in [min, max)
+func (f *Faker) Float(min, max float64) float64 {
+	return min + f.rand.Float64()*(max-min)
+}
+
+// Bool returns a random bool
+func (f *Faker) Bool() bool {
+	return f.rand.Intn(2) == 1
+}
+
+// Pick returns a random element of options
+func (f *Faker) Pick(options []string) string {
+	if len(options) == 0 {
+		return ""
+	}
+	return options[f.rand.Intn(len(options))]
+}
@@ -0,0 +1,234 @@
+// pkg/health/breaker.go - Circuit breakers for soft dependencies
+package health
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ErrOpen is returned without calling the dependency while its breaker is open
+var ErrOpen = errors.New("health: circuit open")
+
+// errPanicked is recorded for calls that panicked
+var errPanicked = errors.New("health: call panicked")
+
+// State is the state of a breaker
+type State string
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = "closed"
+	// StateOpen fails every call fast until the cooldown has passed
+	StateOpen State = "open"
+	// StateHalfOpen lets a single trial call through after the cooldown
+	StateHalfOpen State = "half-open"
+)
+
+// Breaker guards a soft dependency. After threshold consecutive failures it
+// opens and calls fail with ErrOpen for cooldown; the next call is a trial
+// that closes the breaker on success or reopens it on failure.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewBreaker creates a closed breaker. A threshold below 1 is treated as 1.
+func NewBreaker(name string, threshold int, cooldown time.Duration, clk clock.Clock) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Breaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clk,
+		state:     StateClosed,
+	}
+}
+
+// Name returns the dependency the breaker guards
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Do calls fn unless the breaker is open and records its outcome. An open
+// breaker returns an error wrapping ErrOpen. A panic in fn counts as a
+// failure and is passed on.
+func (b *Breaker) Do(fn func() error) error {
+	if !b.allow() {
+		return fmt.Errorf("%w: %s", ErrOpen, b.name)
+	}
+
+	returned := false
+	defer func() {
+		// Record the panic so a half-open breaker does not wait for a trial
+		// that never finishes
+		if !returned {
+			b.record(errPanicked)
+		}
+	}()
+
+	err := fn()
+	returned = true
+	b.record(err)
+	return err
+}
+
+// State returns the current state. An open breaker whose cooldown has passed
+// reports half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.clock.Since(b.openedAt) >= b.cooldown {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// RetryAfter returns how long until an open breaker lets a trial call through
+func (b *Breaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateOpen {
+		return 0
+	}
+	if remaining := b.cooldown - b.clock.Since(b.openedAt); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// allow reports whether a call may go through, starting a trial when an open
+// breaker's cooldown has passed
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.clock.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.trial = true
+		return true
+	case StateHalfOpen:
+		// Only one trial at a time; everyone else fails fast until it returns
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+
+	if err == nil {
+		if b.state != StateClosed {
+			logger.Info("Circuit closed", zap.String("dependency", b.name))
+		}
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		if b.state != StateOpen {
+			logger.Warn("Circuit opened", zap.String("dependency", b.name),
+				zap.Int("failures", b.failures), zap.Duration("cooldown", b.cooldown), zap.Error(err))
+		}
+		b.state = StateOpen
+		b.openedAt = b.clock.Now()
+	}
+}
+
+// Registry holds the breakers of every soft dependency so their states can be
+// reported together
+type Registry struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry creates a registry whose breakers use the configured threshold
+// and cooldown
+func NewRegistry(cfg config.HealthConfig, clk clock.Clock) *Registry {
+	return &Registry{
+		threshold: cfg.BreakerFailures,
+		cooldown:  cfg.BreakerCooldown,
+		clock:     clk,
+		breakers:  make(map[string]*Breaker),
+	}
+}
+
+// Breaker returns the breaker for name, creating it on first use
+func (r *Registry) Breaker(name string) *Breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	breaker, ok := r.breakers[name]
+	if !ok {
+		breaker = NewBreaker(name, r.threshold, r.cooldown, r.clock)
+		r.breakers[name] = breaker
+	}
+	return breaker
+}
+
+// States returns the state of every breaker by name
+func (r *Registry) States() map[string]State {
+	r.mu.Lock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		breakers = append(breakers, breaker)
+	}
+	r.mu.Unlock()
+
+	states := make(map[string]State, len(breakers))
+	for _, breaker := range breakers {
+		states[breaker.Name()] = breaker.State()
+	}
+	return states
+}
+
+// Degraded returns the names of the dependencies whose breaker is not closed,
+// sorted
+func (r *Registry) Degraded() []string {
+	var names []string
+	for name, state := range r.States() {
+		if state != StateClosed {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,153 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDown = errors.New("dependency down")
+
+func fail() error { return errDown }
+
+func succeed() error { return nil }
+
+// openBreaker returns a breaker with a threshold of 2 and a one minute
+// cooldown that has just opened
+func openBreaker(t *testing.T) (*Breaker, *clock.Fake) {
+	t.Helper()
+
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := NewBreaker("mail", 2, time.Minute, clk)
+	b.Do(fail)
+	b.Do(fail)
+	require.Equal(t, StateOpen, b.State())
+	return b, clk
+}
+
+func TestBreaker_TripsAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	b := NewBreaker("mail", 3, time.Minute, clk)
+
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.Equal(t, StateClosed, b.State())
+
+	// A success starts the count again
+	assert.NoError(t, b.Do(succeed))
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.Equal(t, StateOpen, b.State())
+	assert.Equal(t, time.Minute, b.RetryAfter())
+
+	called := false
+	err := b.Do(func() error { called = true; return nil })
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called, "an open breaker fails fast")
+}
+
+func TestBreaker_Cooldown(t *testing.T) {
+	t.Parallel()
+
+	b, clk := openBreaker(t)
+
+	clk.Advance(59 * time.Second)
+	assert.Equal(t, StateOpen, b.State())
+	assert.Equal(t, time.Second, b.RetryAfter())
+	assert.ErrorIs(t, b.Do(succeed), ErrOpen)
+
+	clk.Advance(time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.Zero(t, b.RetryAfter())
+}
+
+func TestBreaker_TrialSucceeds(t *testing.T) {
+	t.Parallel()
+
+	b, clk := openBreaker(t)
+	clk.Advance(time.Minute)
+
+	assert.NoError(t, b.Do(succeed))
+	assert.Equal(t, StateClosed, b.State())
+
+	// The failure count starts again
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_TrialFails(t *testing.T) {
+	t.Parallel()
+
+	b, clk := openBreaker(t)
+	clk.Advance(time.Minute)
+
+	assert.ErrorIs(t, b.Do(fail), errDown)
+	assert.Equal(t, StateOpen, b.State())
+	assert.Equal(t, time.Minute, b.RetryAfter(), "the cooldown starts again")
+	assert.ErrorIs(t, b.Do(succeed), ErrOpen)
+}
+
+func TestBreaker_OneTrialAtATime(t *testing.T) {
+	t.Parallel()
+
+	b, clk := openBreaker(t)
+	clk.Advance(time.Minute)
+
+	err := b.Do(func() error {
+		assert.ErrorIs(t, b.Do(succeed), ErrOpen, "other calls fail fast during the trial")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_TrialPanics(t *testing.T) {
+	t.Parallel()
+
+	b, clk := openBreaker(t)
+	clk.Advance(time.Minute)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		b.Do(func() error { panic("boom") })
+	})
+	assert.Equal(t, StateOpen, b.State(), "the panic counts as a failed trial")
+
+	clk.Advance(time.Minute)
+	assert.NoError(t, b.Do(succeed), "the next trial is let through")
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_PanicCountsAsFailure(t *testing.T) {
+	t.Parallel()
+
+	b := NewBreaker("mail", 1, time.Minute, clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	assert.Panics(t, func() {
+		b.Do(func() error { panic("boom") })
+	})
+	assert.Equal(t, StateOpen, b.State())
+}
+
+func TestRegistry_Degraded(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry(config.HealthConfig{BreakerFailures: 1, BreakerCooldown: time.Minute}, clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Same(t, r.Breaker("mail"), r.Breaker("mail"))
+
+	r.Breaker("mail").Do(fail)
+	r.Breaker("exchange").Do(succeed)
+
+	assert.Equal(t, map[string]State{"mail": StateOpen, "exchange": StateClosed}, r.States())
+	assert.Equal(t, []string{"mail"}, r.Degraded())
+}
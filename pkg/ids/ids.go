@@ -0,0 +1,57 @@
+// pkg/ids/ids.go - Primary key generation
+package ids
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supported UUID versions for primary keys
+const (
+	V4 = "v4" // random, generated by the database (gen_random_uuid())
+	V7 = "v7" // time-ordered, generated in Go for better index locality
+)
+
+var useV7 atomic.Bool
+
+// SetVersion selects the UUID version used by New and the GORM callback
+func SetVersion(version string) error {
+	switch version {
+	case "", V4:
+		useV7.Store(false)
+	case V7:
+		useV7.Store(true)
+	default:
+		return fmt.Errorf("unsupported UUID version: %s (valid: %s, %s)", version, V4, V7)
+	}
+	return nil
+}
+
+// GeneratesInGo reports whether IDs are generated in Go rather than by the database default
+func GeneratesInGo() bool {
+	return useV7.Load()
+}
+
+// New returns a new ID of the configured version
+func New() uuid.UUID {
+	if useV7.Load() {
+		return uuid.Must(uuid.NewV7())
+	}
+	return uuid.New()
+}
+
+// Timestamp returns the creation time embedded in a UUIDv7. Rows created before
+// switching to v7 have random v4 IDs, so ok is false and callers must fall back
+// to the created_at column.
+func Timestamp(id uuid.UUID) (t time.Time, ok bool) {
+	if id.Version() != 7 {
+		return time.Time{}, false
+	}
+	// The first 48 bits are the Unix time in milliseconds
+	ms := int64(binary.BigEndian.Uint64(id[:8]) >> 16)
+	return time.UnixMilli(ms).UTC(), true
+}
@@ -0,0 +1,165 @@
+// pkg/imaging/imaging.go - Validate uploaded images, resize and crop them
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Supported formats, as reported by image.Decode
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	// FormatWebP is only written, as lossless WebP; uploads can't be WebP
+	FormatWebP = "webp"
+)
+
+var (
+	// ErrUnsupportedFormat is returned for anything but a JPEG or PNG image
+	ErrUnsupportedFormat = errors.New("imaging: unsupported image format")
+	// ErrTooLarge is returned when an image is wider or taller than allowed
+	ErrTooLarge = errors.New("imaging: image dimensions too large")
+)
+
+// Decode decodes a JPEG or PNG image. The dimensions are checked against
+// maxDimension before the pixels are decoded, so a small file cannot claim a
+// huge image and exhaust memory. A maxDimension of 0 disables the check.
+func Decode(data []byte, maxDimension int) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedFormat
+	}
+	if format != FormatJPEG && format != FormatPNG {
+		return nil, "", ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, "", fmt.Errorf("imaging: empty image")
+	}
+	if maxDimension > 0 && (cfg.Width > maxDimension || cfg.Height > maxDimension) {
+		return nil, "", ErrTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("imaging: decode %s: %w", format, err)
+	}
+	return img, format, nil
+}
+
+// Encode writes img in format, JPEG at quality 85, PNG or lossless WebP
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	case FormatPNG:
+		return png.Encode(w, img)
+	case FormatWebP:
+		return EncodeWebP(w, img)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// Thumbnail crops the centre square of src and scales it down to size x size,
+// averaging the source pixels that fall into each thumbnail pixel. Images
+// smaller than size are cropped but not enlarged.
+func Thumbnail(src image.Image, size int) image.Image {
+	return Crop(src, size, size)
+}
+
+// Resize scales src down to fit within width x height, keeping its aspect
+// ratio. Images that already fit are copied, not enlarged.
+func Resize(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > width {
+		w, h = width, max(h*width/w, 1)
+	}
+	if h > height {
+		w, h = max(w*height/h, 1), height
+	}
+	return scale(src, bounds, w, h)
+}
+
+// Crop cuts the largest centred region with the aspect ratio of width x
+// height out of src and scales it down to that size. Images smaller than the
+// region are cropped but not enlarged.
+func Crop(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w*height > h*width {
+		w = max(h*width/height, 1)
+	} else {
+		h = max(w*height/width, 1)
+	}
+	crop := image.Rect(0, 0, w, h).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-w)/2,
+		bounds.Min.Y+(bounds.Dy()-h)/2,
+	))
+
+	if w < width {
+		width, height = w, h
+	}
+	return scale(src, crop, width, height)
+}
+
+// scale scales the area of src inside rect to width x height, averaging the
+// source pixels that fall into each destination pixel
+func scale(src image.Image, rect image.Rectangle, width, height int) image.Image {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	sw, sh := rect.Dx(), rect.Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := rect.Min.Y + y*sh/height
+		y1 := max(rect.Min.Y+(y+1)*sh/height, y0+1)
+
+		for x := 0; x < width; x++ {
+			x0 := rect.Min.X + x*sw/width
+			x1 := max(rect.Min.X+(x+1)*sw/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// Variant is a predefined size an image is rendered at
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+	// Crop fills the whole size, cutting off the edges that don't fit, instead
+	// of fitting the image within it
+	Crop bool
+}
+
+// Apply renders src at the variant's size
+func (v Variant) Apply(src image.Image) image.Image {
+	if v.Crop {
+		return Crop(src, v.Width, v.Height)
+	}
+	return Resize(src, v.Width, v.Height)
+}
@@ -0,0 +1,365 @@
+// pkg/imaging/webp.go - Lossless WebP encoding
+package imaging
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+)
+
+// webpMaxDimension is the largest width or height a WebP image can have
+const webpMaxDimension = 1 << 14
+
+// VP8L bitstream constants, see the WebP lossless bitstream specification
+const (
+	vp8lSignature         = 0x2f
+	vp8lSubtractGreen     = 2
+	vp8lGreenAlphabet     = 256 + 24 // literals and backward reference lengths, without a color cache
+	vp8lDistanceAlphabet  = 40
+	vp8lMaxCodeLength     = 15
+	vp8lMaxLengthCodeBits = 7
+)
+
+// vp8lCodeLengthOrder is the order code length code lengths are written in
+var vp8lCodeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// EncodeWebP writes img as a lossless WebP image. It applies the subtract
+// green transform and codes every pixel as a literal, so files are larger
+// than a full encoder's but decode with any WebP decoder.
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("imaging: empty image")
+	}
+	if width > webpMaxDimension || height > webpMaxDimension {
+		return ErrTooLarge
+	}
+
+	// Pixels as green, red, blue and alpha, the order they are coded in
+	pixels := make([][4]uint8, 0, width*height)
+	hasAlpha := false
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			// Subtract green: red and blue are stored relative to green
+			pixels = append(pixels, [4]uint8{c.G, c.R - c.G, c.B - c.G, c.A})
+			hasAlpha = hasAlpha || c.A != 0xff
+		}
+	}
+
+	var histograms [4][256]int
+	for _, p := range pixels {
+		for i, v := range p {
+			histograms[i][v]++
+		}
+	}
+	var codes [4]*prefixCode
+	for i := range histograms {
+		codes[i] = newPrefixCode(histograms[i][:], vp8lMaxCodeLength)
+	}
+
+	bw := &bitWriter{}
+	bw.writeBits(vp8lSignature, 8)
+	bw.writeBits(uint32(width-1), 14)
+	bw.writeBits(uint32(height-1), 14)
+	bw.writeBool(hasAlpha)
+	bw.writeBits(0, 3) // version
+
+	bw.writeBool(true) // a transform follows
+	bw.writeBits(vp8lSubtractGreen, 2)
+	bw.writeBool(false) // no more transforms
+
+	bw.writeBool(false) // no color cache
+	bw.writeBool(false) // one prefix code group for the whole image
+
+	for i, code := range codes {
+		alphabet := 256
+		if i == 0 {
+			alphabet = vp8lGreenAlphabet
+		}
+		code.writeTo(bw, alphabet)
+	}
+	// Backward references are never used, but the distance code is required
+	newPrefixCode(make([]int, vp8lDistanceAlphabet), vp8lMaxCodeLength).writeTo(bw, vp8lDistanceAlphabet)
+
+	for _, p := range pixels {
+		for i, v := range p {
+			codes[i].writeSymbol(bw, int(v))
+		}
+	}
+
+	data := bw.bytes()
+	chunk := len(data) + len(data)%2
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+chunk))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(data)))
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.Write(data)
+	if len(data)%2 == 1 {
+		buf.WriteByte(0)
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// bitWriter packs values least significant bit first, as VP8L reads them
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (b *bitWriter) writeBits(value uint32, n uint) {
+	b.acc |= uint64(value) << b.nbits
+	b.nbits += n
+	for b.nbits >= 8 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc >>= 8
+		b.nbits -= 8
+	}
+}
+
+func (b *bitWriter) writeBool(v bool) {
+	if v {
+		b.writeBits(1, 1)
+	} else {
+		b.writeBits(0, 1)
+	}
+}
+
+func (b *bitWriter) bytes() []byte {
+	if b.nbits > 0 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc, b.nbits = 0, 0
+	}
+	return b.buf
+}
+
+// prefixCode is a canonical Huffman code over the symbols of a histogram
+type prefixCode struct {
+	lengths []int
+	codes   []uint32 // bit-reversed, ready to be written least significant bit first
+	used    []int    // symbols with a code, in order
+}
+
+// newPrefixCode builds a canonical Huffman code for the symbols counted in
+// histogram with codes no longer than maxLength. Symbols that never occur get
+// no code.
+func newPrefixCode(histogram []int, maxLength int) *prefixCode {
+	c := &prefixCode{
+		lengths: huffmanLengths(histogram, maxLength),
+		codes:   make([]uint32, len(histogram)),
+	}
+	for symbol, length := range c.lengths {
+		if length > 0 {
+			c.used = append(c.used, symbol)
+		}
+	}
+	if len(c.used) < 2 {
+		// A single symbol takes no bits at all
+		return c
+	}
+
+	var counts [vp8lMaxCodeLength + 1]int
+	for _, length := range c.lengths {
+		counts[length]++
+	}
+	counts[0] = 0
+	var next [vp8lMaxCodeLength + 2]uint32
+	code := uint32(0)
+	for length := 1; length <= vp8lMaxCodeLength; length++ {
+		code = (code + uint32(counts[length-1])) << 1
+		next[length] = code
+	}
+	for symbol, length := range c.lengths {
+		if length > 0 {
+			c.codes[symbol] = reverseBits(next[length], length)
+			next[length]++
+		}
+	}
+	return c
+}
+
+func (c *prefixCode) writeSymbol(bw *bitWriter, symbol int) {
+	if len(c.used) < 2 {
+		return
+	}
+	bw.writeBits(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+// writeTo writes the code for an alphabet of the given size: as a simple
+// code when it has a single symbol, otherwise as code lengths, which are
+// themselves prefix coded
+func (c *prefixCode) writeTo(bw *bitWriter, alphabet int) {
+	if len(c.used) < 2 {
+		symbol := 0
+		if len(c.used) == 1 {
+			symbol = c.used[0]
+		}
+		bw.writeBool(true) // simple code
+		bw.writeBits(0, 1) // one symbol
+		if symbol < 2 {
+			bw.writeBits(0, 1) // 1-bit symbol
+			bw.writeBits(uint32(symbol), 1)
+		} else {
+			bw.writeBits(1, 1) // 8-bit symbol
+			bw.writeBits(uint32(symbol), 8)
+		}
+		return
+	}
+
+	// Code lengths are written with runs of zeros shortened: 17 repeats a
+	// zero 3-10 times, 18 11-138 times
+	type token struct{ symbol, extra, extraBits int }
+	var tokens []token
+	lengths := make([]int, alphabet)
+	copy(lengths, c.lengths)
+	for i := 0; i < len(lengths); {
+		if lengths[i] != 0 {
+			tokens = append(tokens, token{symbol: lengths[i]})
+			i++
+			continue
+		}
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == 0 && run < 138 {
+			run++
+		}
+		switch {
+		case run >= 11:
+			tokens = append(tokens, token{18, run - 11, 7})
+		case run >= 3:
+			tokens = append(tokens, token{17, run - 3, 3})
+		default:
+			run = 1
+			tokens = append(tokens, token{symbol: 0})
+		}
+		i += run
+	}
+
+	histogram := make([]int, len(vp8lCodeLengthOrder))
+	for _, t := range tokens {
+		histogram[t.symbol]++
+	}
+	lengthCode := newPrefixCode(histogram, vp8lMaxLengthCodeBits)
+	if len(lengthCode.used) == 1 {
+		// A lone symbol still needs a length to be declared; it takes no bits
+		lengthCode.lengths[lengthCode.used[0]] = 1
+	}
+
+	count := len(vp8lCodeLengthOrder)
+	for count > 4 && lengthCode.lengths[vp8lCodeLengthOrder[count-1]] == 0 {
+		count--
+	}
+
+	bw.writeBool(false) // normal code
+	bw.writeBits(uint32(count-4), 4)
+	for _, symbol := range vp8lCodeLengthOrder[:count] {
+		bw.writeBits(uint32(lengthCode.lengths[symbol]), 3)
+	}
+	bw.writeBool(false) // lengths for the whole alphabet follow
+	for _, t := range tokens {
+		lengthCode.writeSymbol(bw, t.symbol)
+		if t.extraBits > 0 {
+			bw.writeBits(uint32(t.extra), uint(t.extraBits))
+		}
+	}
+}
+
+// huffmanLengths returns Huffman code lengths for histogram, none longer than
+// maxLength. When the optimal code is too deep, rare symbols are counted as
+// more frequent until it fits.
+func huffmanLengths(histogram []int, maxLength int) []int {
+	lengths := make([]int, len(histogram))
+	used := 0
+	for _, n := range histogram {
+		if n > 0 {
+			used++
+		}
+	}
+	if used == 0 {
+		return lengths
+	}
+	if used == 1 {
+		for symbol, n := range histogram {
+			if n > 0 {
+				lengths[symbol] = 1
+			}
+		}
+		return lengths
+	}
+
+	for floor := 1; ; floor *= 2 {
+		h := &nodeHeap{}
+		for symbol, n := range histogram {
+			if n > 0 {
+				h.nodes = append(h.nodes, &huffmanNode{weight: max(n, floor), symbol: symbol})
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 1 {
+			a := heap.Pop(h).(*huffmanNode)
+			b := heap.Pop(h).(*huffmanNode)
+			heap.Push(h, &huffmanNode{weight: a.weight + b.weight, symbol: min(a.symbol, b.symbol), left: a, right: b})
+		}
+
+		deepest := 0
+		var walk func(n *huffmanNode, depth int)
+		walk = func(n *huffmanNode, depth int) {
+			if n.left == nil {
+				lengths[n.symbol] = depth
+				deepest = max(deepest, depth)
+				return
+			}
+			walk(n.left, depth+1)
+			walk(n.right, depth+1)
+		}
+		walk(heap.Pop(h).(*huffmanNode), 0)
+		if deepest <= maxLength {
+			return lengths
+		}
+	}
+}
+
+type huffmanNode struct {
+	weight      int
+	symbol      int // smallest symbol below the node, to break ties
+	left, right *huffmanNode
+}
+
+type nodeHeap struct {
+	nodes []*huffmanNode
+}
+
+func (h *nodeHeap) Len() int { return len(h.nodes) }
+func (h *nodeHeap) Less(i, j int) bool {
+	if h.nodes[i].weight != h.nodes[j].weight {
+		return h.nodes[i].weight < h.nodes[j].weight
+	}
+	return h.nodes[i].symbol < h.nodes[j].symbol
+}
+func (h *nodeHeap) Swap(i, j int)      { h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i] }
+func (h *nodeHeap) Push(x interface{}) { h.nodes = append(h.nodes, x.(*huffmanNode)) }
+func (h *nodeHeap) Pop() interface{} {
+	n := h.nodes[len(h.nodes)-1]
+	h.nodes = h.nodes[:len(h.nodes)-1]
+	return n
+}
+
+// reverseBits reverses the lowest n bits of code
+func reverseBits(code uint32, n int) uint32 {
+	var r uint32
+	for i := 0; i < n; i++ {
+		r = r<<1 | code&1
+		code >>= 1
+	}
+	return r
+}
@@ -0,0 +1,193 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// roundTrip encodes img and decodes it with golang.org/x/image/webp
+func roundTrip(t *testing.T, img image.Image) image.Image {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeWebP(&buf, img))
+
+	data := buf.Bytes()
+	require.Equal(t, "RIFF", string(data[0:4]))
+	assert.Equal(t, uint32(len(data)-8), binary.LittleEndian.Uint32(data[4:8]), "RIFF size")
+	require.Equal(t, "WEBPVP8L", string(data[8:16]))
+
+	config, err := webp.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, img.Bounds().Dx(), config.Width)
+	assert.Equal(t, img.Bounds().Dy(), config.Height)
+
+	decoded, err := webp.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return decoded
+}
+
+// assertSamePixels compares every pixel as non-premultiplied RGBA, which is
+// what a lossless encoding keeps
+func assertSamePixels(t *testing.T, want, got image.Image) {
+	t.Helper()
+
+	require.Equal(t, want.Bounds().Dx(), got.Bounds().Dx())
+	require.Equal(t, want.Bounds().Dy(), got.Bounds().Dy())
+
+	wantMin, gotMin := want.Bounds().Min, got.Bounds().Min
+	for y := 0; y < want.Bounds().Dy(); y++ {
+		for x := 0; x < want.Bounds().Dx(); x++ {
+			w := color.NRGBAModel.Convert(want.At(wantMin.X+x, wantMin.Y+y))
+			g := color.NRGBAModel.Convert(got.At(gotMin.X+x, gotMin.Y+y))
+			if w != g {
+				t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func filled(width, height int, fill func(x, y int) color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, fill(x, y))
+		}
+	}
+	return img
+}
+
+func TestEncodeWebP_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(1))
+	noise := filled(97, 61, func(x, y int) color.NRGBA {
+		return color.NRGBA{uint8(random.Intn(256)), uint8(random.Intn(256)), uint8(random.Intn(256)), 0xff}
+	})
+	translucentNoise := filled(40, 30, func(x, y int) color.NRGBA {
+		return color.NRGBA{uint8(random.Intn(256)), uint8(random.Intn(256)), uint8(random.Intn(256)), uint8(random.Intn(256))}
+	})
+
+	gray := image.NewGray(image.Rect(0, 0, 33, 17))
+	for i := range gray.Pix {
+		gray.Pix[i] = uint8(i * 7)
+	}
+
+	paletted := image.NewPaletted(image.Rect(0, 0, 20, 10), color.Palette{
+		color.NRGBA{0xff, 0, 0, 0xff}, color.NRGBA{0, 0xff, 0, 0x80}, color.NRGBA{0, 0, 0xff, 0},
+	})
+	for i := range paletted.Pix {
+		paletted.Pix[i] = uint8(i % 3)
+	}
+
+	// Bounds that do not start at the origin, as a sub-image's
+	offset := filled(50, 40, func(x, y int) color.NRGBA {
+		return color.NRGBA{uint8(x * 5), uint8(y * 6), uint8(x + y), 0xff}
+	}).SubImage(image.Rect(10, 5, 35, 30))
+
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{name: "single pixel", img: filled(1, 1, func(x, y int) color.NRGBA { return color.NRGBA{12, 34, 56, 0xff} })},
+		// Every channel has a single symbol, coded with zero bits
+		{name: "one color", img: filled(16, 9, func(x, y int) color.NRGBA { return color.NRGBA{0x20, 0x40, 0x60, 0xff} })},
+		// Two symbols per channel, the simple code with two symbols
+		{name: "two colors", img: filled(8, 8, func(x, y int) color.NRGBA {
+			if (x+y)%2 == 0 {
+				return color.NRGBA{0, 0, 0, 0xff}
+			}
+			return color.NRGBA{0xff, 0xff, 0xff, 0xff}
+		})},
+		{name: "gradient", img: filled(256, 3, func(x, y int) color.NRGBA {
+			return color.NRGBA{uint8(x), uint8(255 - x), uint8(x * y), 0xff}
+		})},
+		{name: "noise", img: noise},
+		{name: "alpha", img: translucentNoise},
+		{name: "fully transparent pixels keep their color", img: filled(4, 4, func(x, y int) color.NRGBA {
+			return color.NRGBA{uint8(x * 60), uint8(y * 60), 7, 0}
+		})},
+		{name: "gray", img: gray},
+		{name: "paletted", img: paletted},
+		{name: "offset bounds", img: offset},
+		{name: "tall and thin", img: filled(1, 300, func(x, y int) color.NRGBA { return color.NRGBA{uint8(y), 0, 0, 0xff} })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSamePixels(t, tt.img, roundTrip(t, tt.img))
+		})
+	}
+}
+
+// Fibonacci frequencies give a Huffman code deeper than the 15 bits VP8L
+// allows, so the lengths must be limited
+func TestEncodeWebP_LimitsCodeLengths(t *testing.T) {
+	t.Parallel()
+
+	var values []uint8
+	a, b := 1, 1
+	for v := 0; v < 25; v++ {
+		for i := 0; i < a; i++ {
+			values = append(values, uint8(v))
+		}
+		a, b = b, a+b
+	}
+	width := 512
+	height := (len(values) + width - 1) / width
+	img := filled(width, height, func(x, y int) color.NRGBA {
+		i := y*width + x
+		if i >= len(values) {
+			return color.NRGBA{0, 0, 0, 0xff}
+		}
+		return color.NRGBA{values[i], values[i], values[i], 0xff}
+	})
+
+	lengths := huffmanLengths(histogramOf(values), vp8lMaxCodeLength)
+	for symbol, length := range lengths {
+		assert.LessOrEqual(t, length, vp8lMaxCodeLength, "symbol %d", symbol)
+	}
+
+	assertSamePixels(t, img, roundTrip(t, img))
+}
+
+func histogramOf(values []uint8) []int {
+	histogram := make([]int, 256)
+	for _, v := range values {
+		histogram[v]++
+	}
+	return histogram
+}
+
+// hugeImage reports bounds larger than WebP allows without allocating them
+type hugeImage struct {
+	*image.Uniform
+	bounds image.Rectangle
+}
+
+func (h hugeImage) Bounds() image.Rectangle { return h.bounds }
+
+func TestEncodeWebP_Invalid(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	err := EncodeWebP(&buf, image.NewNRGBA(image.Rect(0, 0, 0, 10)))
+	assert.EqualError(t, err, "imaging: empty image")
+
+	huge := hugeImage{Uniform: image.NewUniform(color.White), bounds: image.Rect(0, 0, webpMaxDimension+1, 1)}
+	assert.ErrorIs(t, EncodeWebP(&buf, huge), ErrTooLarge)
+
+	largest := hugeImage{Uniform: image.NewUniform(color.White), bounds: image.Rect(0, 0, webpMaxDimension, 2)}
+	assertSamePixels(t, largest, roundTrip(t, largest))
+
+	assert.Zero(t, buf.Len(), "nothing is written for a rejected image")
+}
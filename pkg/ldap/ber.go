@@ -0,0 +1,179 @@
+// pkg/ldap/ber.go - The subset of ASN.1 BER that LDAP messages use
+package ldap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Universal tags of the types LDAP messages are built from
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+)
+
+// maxElementSize bounds what a server can make us allocate for one message
+const maxElementSize = 16 << 20
+
+// element is a decoded BER element. Constructed elements have their children
+// decoded; the tag is the whole identifier octet.
+type element struct {
+	tag      byte
+	value    []byte
+	children []element
+}
+
+func (e element) constructed() bool {
+	return e.tag&0x20 != 0
+}
+
+// int decodes an INTEGER or ENUMERATED value
+func (e element) int() int {
+	n := 0
+	for i, b := range e.value {
+		if i == 0 && b&0x80 != 0 {
+			n = -1
+		}
+		n = n<<8 | int(b)
+	}
+	return n
+}
+
+func (e element) string() string {
+	return string(e.value)
+}
+
+// tlv encodes an element from its tag and the concatenated content
+func tlv(tag byte, content ...[]byte) []byte {
+	length := 0
+	for _, c := range content {
+		length += len(c)
+	}
+
+	out := append([]byte{tag}, encodeLength(length)...)
+	for _, c := range content {
+		out = append(out, c...)
+	}
+	return out
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// berInt encodes a non-negative INTEGER or ENUMERATED value
+func berInt(tag byte, v int) []byte {
+	b := []byte{byte(v)}
+	for v >>= 8; v > 0; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return tlv(tag, b)
+}
+
+func berString(tag byte, s string) []byte {
+	return tlv(tag, []byte(s))
+}
+
+func berBool(v bool) []byte {
+	if v {
+		return tlv(tagBoolean, []byte{0xff})
+	}
+	return tlv(tagBoolean, []byte{0x00})
+}
+
+// readElement reads one element from the stream
+func readElement(r *bufio.Reader) (element, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return element{}, err
+	}
+	if tag&0x1f == 0x1f {
+		return element{}, fmt.Errorf("ldap: multi-byte tags are not supported")
+	}
+
+	first, err := r.ReadByte()
+	if err != nil {
+		return element{}, err
+	}
+	length := int(first)
+	if first&0x80 != 0 {
+		size := int(first & 0x7f)
+		if size == 0 || size > 4 {
+			return element{}, fmt.Errorf("ldap: unsupported length encoding")
+		}
+		length = 0
+		for i := 0; i < size; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return element{}, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxElementSize {
+		return element{}, fmt.Errorf("ldap: message of %d bytes is too large", length)
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return element{}, err
+	}
+	return parseElement(tag, value)
+}
+
+// decodeElement decodes the element at the start of data and returns it with
+// the number of bytes it took
+func decodeElement(data []byte) (element, int, error) {
+	if len(data) < 2 {
+		return element{}, 0, fmt.Errorf("ldap: truncated element")
+	}
+	tag, length, offset := data[0], int(data[1]), 2
+	if data[1]&0x80 != 0 {
+		size := int(data[1] & 0x7f)
+		if size == 0 || size > 4 || len(data) < 2+size {
+			return element{}, 0, fmt.Errorf("ldap: unsupported length encoding")
+		}
+		length = 0
+		for _, b := range data[2 : 2+size] {
+			length = length<<8 | int(b)
+		}
+		offset += size
+	}
+	if length < 0 || len(data)-offset < length {
+		return element{}, 0, fmt.Errorf("ldap: truncated element")
+	}
+
+	e, err := parseElement(tag, data[offset:offset+length])
+	return e, offset + length, err
+}
+
+func parseElement(tag byte, value []byte) (element, error) {
+	e := element{tag: tag, value: value}
+	if !e.constructed() {
+		return e, nil
+	}
+
+	for rest := value; len(rest) > 0; {
+		child, n, err := decodeElement(rest)
+		if err != nil {
+			return element{}, err
+		}
+		e.children = append(e.children, child)
+		rest = rest[n:]
+	}
+	return e, nil
+}
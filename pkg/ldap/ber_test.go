@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBER_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{0, 1, 127, 128, 255, 256, 65535, 65536} {
+		value := strings.Repeat("x", size)
+		encoded := berString(tagOctetString, value)
+
+		e, n, err := decodeElement(encoded)
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, len(encoded), n)
+		assert.Equal(t, byte(tagOctetString), e.tag)
+		assert.Equal(t, value, e.string())
+
+		read, err := readElement(bufio.NewReader(bytes.NewReader(encoded)))
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, value, read.string())
+	}
+}
+
+func TestBER_Int(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []int{0, 1, 127, 128, 255, 256, 65535, 1 << 24, 1<<31 - 1} {
+		e, _, err := decodeElement(berInt(tagInteger, v))
+		require.NoError(t, err)
+		assert.Equal(t, v, e.int(), "%d", v)
+	}
+
+	// Servers send negative values in two's complement
+	assert.Equal(t, -1, element{value: []byte{0xff}}.int())
+	assert.Equal(t, -128, element{value: []byte{0x80}}.int())
+	assert.Equal(t, 0, element{}.int())
+}
+
+func TestBER_Constructed(t *testing.T) {
+	t.Parallel()
+
+	encoded := tlv(tagSequence, berInt(tagInteger, 7), tlv(tagSequence, berString(tagOctetString, "a"), berBool(true)))
+
+	e, _, err := decodeElement(encoded)
+	require.NoError(t, err)
+	require.Len(t, e.children, 2)
+	assert.Equal(t, 7, e.children[0].int())
+	require.Len(t, e.children[1].children, 2)
+	assert.Equal(t, "a", e.children[1].children[0].string())
+	assert.Equal(t, []byte{0xff}, e.children[1].children[1].value)
+}
+
+func TestBER_Malformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "tag only", data: []byte{tagOctetString}},
+		{name: "value shorter than length", data: []byte{tagOctetString, 5, 'a', 'b'}},
+		{name: "indefinite length", data: []byte{tagOctetString, 0x80, 'a', 0, 0}},
+		{name: "length of five bytes", data: []byte{tagOctetString, 0x85, 0, 0, 0, 0, 1, 'a'}},
+		{name: "long length truncated", data: []byte{tagOctetString, 0x82, 0x01}},
+		{name: "long length past the data", data: []byte{tagOctetString, 0x82, 0x01, 0x00, 'a'}},
+		{name: "child past its parent", data: []byte{tagSequence, 3, tagOctetString, 5, 'a'}},
+		{name: "child without length", data: []byte{tagSequence, 1, tagOctetString}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeElement(tt.data)
+			assert.Error(t, err)
+
+			_, err = readElement(bufio.NewReader(bytes.NewReader(tt.data)))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReadElement_Rejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "multi-byte tag", data: []byte{0x1f, 0x81, 0x01, 0}},
+		{name: "too large", data: []byte{tagOctetString, 0x84, 0x7f, 0xff, 0xff, 0xff}},
+		{name: "empty", data: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readElement(bufio.NewReader(bytes.NewReader(tt.data)))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+// pkg/ldap/conn.go - A minimal LDAPv3 client: simple bind and search
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Application tags of the protocol operations used
+const (
+	opBindRequest      = 0x60
+	opBindResponse     = 0x61
+	opUnbindRequest    = 0x42
+	opSearchRequest    = 0x63
+	opSearchEntry      = 0x64
+	opSearchDone       = 0x65
+	opSearchReference  = 0x73
+	opExtendedRequest  = 0x77
+	opExtendedResponse = 0x78
+)
+
+const (
+	oidStartTLS       = "1.3.6.1.4.1.1466.20037"
+	scopeWholeSubtree = 2
+	derefAliasesNever = 0
+	// tagContext0 is the [0] tag of the simple password and the extended
+	// request name
+	tagContext0 = 0x80
+)
+
+// Result codes
+const (
+	ResultSuccess            = 0
+	ResultSizeLimitExceeded  = 4
+	ResultInvalidCredentials = 49
+)
+
+// Error is a result other than success returned by the server
+type Error struct {
+	ResultCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("ldap: result code %d: %s", e.ResultCode, e.Message)
+}
+
+// Entry is a search result. Attribute names are lowercased.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Value returns the first value of the attribute, or "" when it has none
+func (e *Entry) Value(name string) string {
+	if values := e.Attributes[strings.ToLower(name)]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// SearchRequest searches the subtree under BaseDN. A SizeLimit of 0 means the
+// server's limit.
+type SearchRequest struct {
+	BaseDN     string
+	Filter     string
+	Attributes []string
+	SizeLimit  int
+}
+
+// Conn is a connection to an LDAP server. Operations run one at a time and
+// honour the context's deadline.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	msgID  int
+}
+
+// Dial connects to an ldap:// or ldaps:// URL
+func Dial(ctx context.Context, rawURL string, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid URL: %w", err)
+	}
+
+	host, port := u.Hostname(), u.Port()
+	switch u.Scheme {
+	case "ldap":
+		if port == "" {
+			port = "389"
+		}
+	case "ldaps":
+		if port == "" {
+			port = "636"
+		}
+	default:
+		return nil, fmt.Errorf("ldap: unsupported URL scheme %q", u.Scheme)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("ldap: %w", err)
+	}
+
+	c := &Conn{conn: conn, reader: bufio.NewReader(conn)}
+	if u.Scheme == "ldaps" {
+		if err := c.upgrade(ctx, tlsConfig, host); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// StartTLS upgrades a plain connection to TLS
+func (c *Conn) StartTLS(ctx context.Context, tlsConfig *tls.Config) error {
+	op, err := c.do(ctx, tlv(opExtendedRequest, berString(tagContext0, oidStartTLS)), opExtendedResponse)
+	if err != nil {
+		return err
+	}
+	if err := resultError(op); err != nil {
+		return err
+	}
+
+	host, _, _ := net.SplitHostPort(c.conn.RemoteAddr().String())
+	return c.upgrade(ctx, tlsConfig, host)
+}
+
+// Bind authenticates the connection with a DN and password. An empty
+// password is an unauthenticated bind, which servers accept for any DN, so
+// callers checking passwords must reject it first.
+func (c *Conn) Bind(ctx context.Context, dn, password string) error {
+	op, err := c.do(ctx, tlv(opBindRequest,
+		berInt(tagInteger, 3),
+		berString(tagOctetString, dn),
+		berString(tagContext0, password),
+	), opBindResponse)
+	if err != nil {
+		return err
+	}
+	return resultError(op)
+}
+
+// Search returns the entries matching the request. Hitting the size limit is
+// not an error; the entries returned until then are.
+func (c *Conn) Search(ctx context.Context, req SearchRequest) ([]*Entry, error) {
+	filter, err := compileFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make([][]byte, len(req.Attributes))
+	for i, attr := range req.Attributes {
+		attributes[i] = berString(tagOctetString, attr)
+	}
+
+	id, err := c.send(ctx, tlv(opSearchRequest,
+		berString(tagOctetString, req.BaseDN),
+		berInt(tagEnumerated, scopeWholeSubtree),
+		berInt(tagEnumerated, derefAliasesNever),
+		berInt(tagInteger, req.SizeLimit),
+		berInt(tagInteger, 0),
+		berBool(false),
+		filter,
+		tlv(tagSequence, attributes...),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for {
+		op, err := c.receive(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.tag {
+		case opSearchEntry:
+			entry, err := parseEntry(op)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case opSearchReference:
+			// Referrals to other servers are not followed
+		case opSearchDone:
+			if err := resultError(op); err != nil {
+				if ldapErr, ok := err.(*Error); !ok || ldapErr.ResultCode != ResultSizeLimitExceeded {
+					return nil, err
+				}
+			}
+			return entries, nil
+		default:
+			return nil, fmt.Errorf("ldap: unexpected response 0x%02x to search", op.tag)
+		}
+	}
+}
+
+// Close unbinds and closes the connection
+func (c *Conn) Close() error {
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	c.send(context.Background(), tlv(opUnbindRequest))
+	return c.conn.Close()
+}
+
+func (c *Conn) upgrade(ctx context.Context, tlsConfig *tls.Config, host string) error {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = host
+	}
+
+	tlsConn := tls.Client(c.conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("ldap: TLS handshake: %w", err)
+	}
+	c.conn, c.reader = tlsConn, bufio.NewReader(tlsConn)
+	return nil
+}
+
+// do sends a request and reads its single response, which must have the
+// expected tag
+func (c *Conn) do(ctx context.Context, op []byte, expected byte) (element, error) {
+	id, err := c.send(ctx, op)
+	if err != nil {
+		return element{}, err
+	}
+	response, err := c.receive(id)
+	if err != nil {
+		return element{}, err
+	}
+	if response.tag != expected {
+		return element{}, fmt.Errorf("ldap: unexpected response 0x%02x", response.tag)
+	}
+	return response, nil
+}
+
+func (c *Conn) send(ctx context.Context, op []byte) (int, error) {
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	c.msgID++
+	if _, err := c.conn.Write(tlv(tagSequence, berInt(tagInteger, c.msgID), op)); err != nil {
+		return 0, fmt.Errorf("ldap: %w", err)
+	}
+	return c.msgID, nil
+}
+
+// receive reads messages until one for the request id and returns its
+// protocol operation
+func (c *Conn) receive(id int) (element, error) {
+	for {
+		message, err := readElement(c.reader)
+		if err != nil {
+			return element{}, fmt.Errorf("ldap: %w", err)
+		}
+		if message.tag != tagSequence || len(message.children) < 2 {
+			return element{}, fmt.Errorf("ldap: malformed message")
+		}
+
+		switch message.children[0].int() {
+		case id:
+			return message.children[1], nil
+		case 0:
+			// Notice of disconnection
+			if err := resultError(message.children[1]); err != nil {
+				return element{}, err
+			}
+			return element{}, fmt.Errorf("ldap: server closed the connection")
+		}
+	}
+}
+
+// resultError reads the LDAPResult at the start of a response
+func resultError(op element) error {
+	if len(op.children) < 3 {
+		return fmt.Errorf("ldap: malformed result")
+	}
+	if code := op.children[0].int(); code != ResultSuccess {
+		return &Error{ResultCode: code, Message: op.children[2].string()}
+	}
+	return nil
+}
+
+func parseEntry(op element) (*Entry, error) {
+	if len(op.children) < 2 {
+		return nil, fmt.Errorf("ldap: malformed search entry")
+	}
+
+	entry := &Entry{DN: op.children[0].string(), Attributes: make(map[string][]string)}
+	for _, attr := range op.children[1].children {
+		if len(attr.children) < 2 {
+			return nil, fmt.Errorf("ldap: malformed attribute in %s", entry.DN)
+		}
+		name := strings.ToLower(attr.children[0].string())
+		for _, value := range attr.children[1].children {
+			entry.Attributes[name] = append(entry.Attributes[name], value.string())
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,111 @@
+// pkg/ldap/directory.go - Authenticate users against a directory
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoSuchUser is returned when no entry matches the user filter
+	ErrNoSuchUser = errors.New("ldap: no such user")
+	// ErrInvalidCredentials is returned when the user's password is wrong
+	ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+)
+
+// Config describes a directory. Users are looked up by searching BaseDN with
+// UserFilter, in which {email} stands for the escaped email, bound as BindDN
+// (anonymously when empty), and authenticated by binding as the entry found.
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string
+	Attributes         []string // returned with the entry
+	Timeout            time.Duration
+}
+
+// Directory authenticates users with a new connection per login
+type Directory struct {
+	cfg Config
+}
+
+func NewDirectory(cfg Config) *Directory {
+	return &Directory{cfg: cfg}
+}
+
+// Authenticate checks the password of the user with the email and returns
+// their entry
+func (d *Directory) Authenticate(ctx context.Context, email, password string) (*Entry, error) {
+	if password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	if d.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.cfg.Timeout)
+		defer cancel()
+	}
+
+	conn, err := d.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := conn.Search(ctx, SearchRequest{
+		BaseDN:     d.cfg.BaseDN,
+		Filter:     strings.ReplaceAll(d.cfg.UserFilter, "{email}", EscapeFilter(email)),
+		Attributes: d.cfg.Attributes,
+		SizeLimit:  2,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(entries) {
+	case 0:
+		return nil, ErrNoSuchUser
+	case 1:
+	default:
+		return nil, fmt.Errorf("ldap: more than one entry matches %s", email)
+	}
+
+	if err := conn.Bind(ctx, entries[0].DN, password); err != nil {
+		var ldapErr *Error
+		if errors.As(err, &ldapErr) && ldapErr.ResultCode == ResultInvalidCredentials {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// connect dials the directory and binds as the search account
+func (d *Directory) connect(ctx context.Context) (*Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.cfg.InsecureSkipVerify}
+
+	conn, err := Dial(ctx, d.cfg.URL, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if d.cfg.StartTLS {
+		if err := conn.StartTLS(ctx, tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(ctx, d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap: bind as %s: %w", d.cfg.BindDN, err)
+		}
+	}
+	return conn, nil
+}
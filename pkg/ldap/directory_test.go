@@ -0,0 +1,382 @@
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serviceDN = "cn=service,dc=example,dc=com"
+	janeDN    = "uid=jane,ou=people,dc=example,dc=com"
+)
+
+// fakeServer is an LDAP server answering each request with the responses of
+// handle, sent under the request's message ID
+type fakeServer struct {
+	url    string
+	handle func(op element) [][]byte
+
+	mu       sync.Mutex
+	requests []element
+}
+
+func newFakeServer(t *testing.T, handle func(op element) [][]byte) *fakeServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeServer{url: "ldap://" + listener.Addr().String(), handle: handle}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		message, err := readElement(reader)
+		if err != nil || len(message.children) < 2 {
+			return
+		}
+		op := message.children[1]
+		if op.tag == opUnbindRequest {
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, op)
+		s.mu.Unlock()
+
+		for _, response := range s.handle(op) {
+			if _, err := conn.Write(tlv(tagSequence, berInt(tagInteger, message.children[0].int()), response)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *fakeServer) recorded() []element {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]element(nil), s.requests...)
+}
+
+// result encodes an LDAPResult response
+func result(tag byte, code int, message string) []byte {
+	return tlv(tag, berInt(tagEnumerated, code), berString(tagOctetString, ""), berString(tagOctetString, message))
+}
+
+func searchEntry(dn string, attributes map[string][]string) []byte {
+	var attrs [][]byte
+	for name, values := range attributes {
+		var encoded [][]byte
+		for _, v := range values {
+			encoded = append(encoded, berString(tagOctetString, v))
+		}
+		attrs = append(attrs, tlv(tagSequence, berString(tagOctetString, name), tlv(0x31, encoded...)))
+	}
+	return tlv(opSearchEntry, berString(tagOctetString, dn), tlv(tagSequence, attrs...))
+}
+
+// directory answers binds as the service account or jane with the passwords
+// "service" and "secret", and searches with entries
+func directory(entries ...[]byte) func(op element) [][]byte {
+	passwords := map[string]string{serviceDN: "service", janeDN: "secret"}
+
+	return func(op element) [][]byte {
+		switch op.tag {
+		case opBindRequest:
+			if want, ok := passwords[op.children[1].string()]; ok && op.children[2].string() == want {
+				return [][]byte{result(opBindResponse, ResultSuccess, "")}
+			}
+			return [][]byte{result(opBindResponse, ResultInvalidCredentials, "invalid credentials")}
+		case opSearchRequest:
+			return append(append([][]byte(nil), entries...), result(opSearchDone, ResultSuccess, ""))
+		}
+		return [][]byte{result(op.tag+1, 2, "unsupported")}
+	}
+}
+
+func dial(t *testing.T, s *fakeServer) *Conn {
+	t.Helper()
+
+	conn, err := Dial(context.Background(), s.url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestConn_Bind(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory())
+	conn := dial(t, s)
+
+	require.NoError(t, conn.Bind(testContext(t), janeDN, "secret"))
+
+	err := conn.Bind(testContext(t), janeDN, "wrong")
+	var ldapErr *Error
+	require.True(t, errors.As(err, &ldapErr))
+	assert.Equal(t, ResultInvalidCredentials, ldapErr.ResultCode)
+	assert.Equal(t, "invalid credentials", ldapErr.Message)
+
+	requests := s.recorded()
+	require.Len(t, requests, 2)
+	assert.Equal(t, 3, requests[0].children[0].int(), "LDAPv3")
+	assert.Equal(t, janeDN, requests[0].children[1].string())
+	assert.Equal(t, byte(tagContext0), requests[0].children[2].tag, "simple authentication")
+}
+
+func TestConn_Search(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory(
+		searchEntry(janeDN, map[string][]string{"Mail": {"jane@example.com"}, "memberOf": {"admins", "staff"}}),
+		tlv(opSearchReference, berString(tagOctetString, "ldap://other.example.com/")),
+	))
+	conn := dial(t, s)
+
+	entries, err := conn.Search(testContext(t), SearchRequest{
+		BaseDN:     "dc=example,dc=com",
+		Filter:     "(mail=jane@example.com)",
+		Attributes: []string{"mail", "memberOf"},
+		SizeLimit:  2,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "references are skipped")
+	assert.Equal(t, janeDN, entries[0].DN)
+	assert.Equal(t, "jane@example.com", entries[0].Value("MAIL"))
+	assert.Equal(t, []string{"admins", "staff"}, entries[0].Attributes["memberof"])
+	assert.Empty(t, entries[0].Value("cn"))
+
+	request := s.recorded()[0]
+	require.Len(t, request.children, 8)
+	assert.Equal(t, "dc=example,dc=com", request.children[0].string())
+	assert.Equal(t, 2, request.children[3].int(), "size limit")
+	assert.Equal(t, byte(filterEqualityMatch), request.children[6].tag)
+	require.Len(t, request.children[7].children, 2)
+	assert.Equal(t, "memberOf", request.children[7].children[1].string())
+}
+
+func TestConn_Search_SizeLimitExceeded(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, func(op element) [][]byte {
+		return [][]byte{
+			searchEntry(janeDN, nil),
+			searchEntry("uid=john,dc=example,dc=com", nil),
+			result(opSearchDone, ResultSizeLimitExceeded, "size limit exceeded"),
+		}
+	})
+
+	entries, err := dial(t, s).Search(testContext(t), SearchRequest{Filter: "(uid=*)", SizeLimit: 2})
+
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestConn_Search_Error(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, func(op element) [][]byte {
+		return [][]byte{result(opSearchDone, 32, "no such object")}
+	})
+
+	_, err := dial(t, s).Search(testContext(t), SearchRequest{Filter: "(uid=*)"})
+
+	var ldapErr *Error
+	require.True(t, errors.As(err, &ldapErr))
+	assert.Equal(t, 32, ldapErr.ResultCode)
+}
+
+func TestConn_Search_InvalidFilterNotSent(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory())
+
+	_, err := dial(t, s).Search(testContext(t), SearchRequest{Filter: "(mail=a"})
+
+	assert.Error(t, err)
+	assert.Empty(t, s.recorded())
+}
+
+func TestConn_MalformedResponses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		response []byte
+	}{
+		{name: "result too short", response: tlv(opBindResponse, berInt(tagEnumerated, 0))},
+		{name: "unexpected operation", response: result(opSearchDone, ResultSuccess, "")},
+		{name: "truncated element", response: []byte{opBindResponse, 0x10, tagEnumerated}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newFakeServer(t, func(op element) [][]byte { return [][]byte{tt.response} })
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			assert.Error(t, dial(t, s).Bind(ctx, janeDN, "secret"))
+		})
+	}
+}
+
+func TestConn_NoticeOfDisconnection(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		readElement(bufio.NewReader(conn))
+		// Message ID 0 is an unsolicited notification
+		conn.Write(tlv(tagSequence, berInt(tagInteger, 0), result(opExtendedResponse, 52, "server shutting down")))
+	}()
+
+	conn, err := Dial(context.Background(), "ldap://"+listener.Addr().String(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = conn.Bind(testContext(t), janeDN, "secret")
+	var ldapErr *Error
+	require.True(t, errors.As(err, &ldapErr))
+	assert.Equal(t, 52, ldapErr.ResultCode)
+}
+
+func TestDial_UnsupportedScheme(t *testing.T) {
+	t.Parallel()
+
+	_, err := Dial(context.Background(), "http://localhost", nil)
+	assert.Error(t, err)
+}
+
+func newDirectory(s *fakeServer) *Directory {
+	return NewDirectory(Config{
+		URL:          s.url,
+		BindDN:       serviceDN,
+		BindPassword: "service",
+		BaseDN:       "dc=example,dc=com",
+		UserFilter:   "(&(objectClass=person)(mail={email}))",
+		Attributes:   []string{"mail"},
+		Timeout:      5 * time.Second,
+	})
+}
+
+func TestDirectory_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory(searchEntry(janeDN, map[string][]string{"mail": {"jane@example.com"}})))
+
+	entry, err := newDirectory(s).Authenticate(context.Background(), "jane@example.com", "secret")
+
+	require.NoError(t, err)
+	assert.Equal(t, janeDN, entry.DN)
+
+	requests := s.recorded()
+	require.Len(t, requests, 3)
+	assert.Equal(t, serviceDN, requests[0].children[1].string(), "binds as the service account to search")
+	assert.Equal(t, byte(opSearchRequest), requests[1].tag)
+	assert.Equal(t, janeDN, requests[2].children[1].string(), "binds as the user to check the password")
+}
+
+func TestDirectory_Authenticate_EscapesEmail(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory())
+
+	_, err := newDirectory(s).Authenticate(context.Background(), "*)(uid=*", "secret")
+
+	assert.ErrorIs(t, err, ErrNoSuchUser)
+	filter := s.recorded()[1].children[6]
+	require.Len(t, filter.children, 2)
+	assert.Equal(t, byte(filterEqualityMatch), filter.children[1].tag)
+	assert.Equal(t, "*)(uid=*", filter.children[1].children[1].string())
+}
+
+func TestDirectory_Authenticate_Fails(t *testing.T) {
+	t.Parallel()
+
+	jane := searchEntry(janeDN, nil)
+	tests := []struct {
+		name     string
+		entries  [][]byte
+		password string
+		want     error
+	}{
+		{name: "wrong password", entries: [][]byte{jane}, password: "wrong", want: ErrInvalidCredentials},
+		{name: "no user", password: "secret", want: ErrNoSuchUser},
+		{name: "more than one user", entries: [][]byte{jane, searchEntry("uid=jane2,dc=example,dc=com", nil)}, password: "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newFakeServer(t, directory(tt.entries...))
+
+			_, err := newDirectory(s).Authenticate(context.Background(), "jane@example.com", tt.password)
+
+			require.Error(t, err)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+// An empty password would be an unauthenticated bind, which servers accept
+func TestDirectory_Authenticate_EmptyPassword(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory(searchEntry(janeDN, nil)))
+
+	_, err := newDirectory(s).Authenticate(context.Background(), "jane@example.com", "")
+
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Empty(t, s.recorded(), "the directory is not contacted")
+}
+
+func TestDirectory_Authenticate_ServiceBindFails(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t, directory(searchEntry(janeDN, nil)))
+	d := newDirectory(s)
+	d.cfg.BindPassword = "wrong"
+
+	_, err := d.Authenticate(context.Background(), "jane@example.com", "secret")
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials, "the user's password was not checked")
+	assert.Len(t, s.recorded(), 1)
+}
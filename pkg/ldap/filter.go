@@ -0,0 +1,187 @@
+// pkg/ldap/filter.go - Search filters in their string form (RFC 4515)
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Context-specific tags of the filter choices
+const (
+	filterAnd            = 0xa0
+	filterOr             = 0xa1
+	filterNot            = 0xa2
+	filterEqualityMatch  = 0xa3
+	filterSubstrings     = 0xa4
+	filterGreaterOrEqual = 0xa5
+	filterLessOrEqual    = 0xa6
+	filterPresent        = 0x87
+	filterApproxMatch    = 0xa8
+)
+
+// filterEscaper escapes what has a meaning in a filter value
+var filterEscaper = strings.NewReplacer(`\`, `\5c`, `*`, `\2a`, `(`, `\28`, `)`, `\29`, "\x00", `\00`)
+
+// EscapeFilter escapes a value, such as user input, to be matched literally
+// in a filter
+func EscapeFilter(value string) string {
+	return filterEscaper.Replace(value)
+}
+
+// compileFilter encodes a filter such as
+// (&(objectClass=person)(mail=jane@example.com)). Extensible matches are not
+// supported.
+func compileFilter(filter string) ([]byte, error) {
+	encoded, rest, err := parseFilter(strings.TrimSpace(filter))
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("ldap: unexpected %q after filter", rest)
+	}
+	return encoded, nil
+}
+
+// parseFilter encodes the parenthesized filter at the start of s and returns
+// what follows it
+func parseFilter(s string) ([]byte, string, error) {
+	if !strings.HasPrefix(s, "(") || len(s) < 2 {
+		return nil, "", fmt.Errorf("ldap: filter must be enclosed in parentheses")
+	}
+	s = s[1:]
+
+	var encoded []byte
+	switch s[0] {
+	case '&', '|':
+		tag := byte(filterAnd)
+		if s[0] == '|' {
+			tag = filterOr
+		}
+		s = s[1:]
+
+		var parts [][]byte
+		for strings.HasPrefix(s, "(") {
+			part, rest, err := parseFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			parts = append(parts, part)
+			s = rest
+		}
+		if len(parts) == 0 {
+			return nil, "", fmt.Errorf("ldap: empty filter list")
+		}
+		encoded = tlv(tag, parts...)
+	case '!':
+		part, rest, err := parseFilter(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		encoded, s = tlv(filterNot, part), rest
+	default:
+		end := strings.IndexByte(s, ')')
+		if end < 0 {
+			return nil, "", fmt.Errorf("ldap: unterminated filter")
+		}
+		item, err := parseItem(s[:end])
+		if err != nil {
+			return nil, "", err
+		}
+		encoded, s = item, s[end:]
+	}
+
+	if !strings.HasPrefix(s, ")") {
+		return nil, "", fmt.Errorf("ldap: unterminated filter")
+	}
+	return encoded, s[1:], nil
+}
+
+// parseItem encodes a comparison such as mail=jane@example.com
+func parseItem(item string) ([]byte, error) {
+	i := strings.IndexByte(item, '=')
+	if i < 1 {
+		return nil, fmt.Errorf("ldap: invalid filter item %q", item)
+	}
+	attr, value := item[:i], item[i+1:]
+
+	tag := byte(filterEqualityMatch)
+	switch attr[len(attr)-1] {
+	case '>':
+		tag, attr = filterGreaterOrEqual, attr[:len(attr)-1]
+	case '<':
+		tag, attr = filterLessOrEqual, attr[:len(attr)-1]
+	case '~':
+		tag, attr = filterApproxMatch, attr[:len(attr)-1]
+	}
+	if attr == "" || strings.ContainsAny(attr, ":()") || strings.ContainsAny(value, "()") {
+		return nil, fmt.Errorf("ldap: invalid filter item %q", item)
+	}
+
+	if tag == filterEqualityMatch && value == "*" {
+		return berString(filterPresent, attr), nil
+	}
+	if tag == filterEqualityMatch && strings.Contains(value, "*") {
+		return substrings(attr, value)
+	}
+
+	unescaped, err := unescapeValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return tlv(tag, berString(tagOctetString, attr), berString(tagOctetString, unescaped)), nil
+}
+
+// substrings encodes a value with wildcards, such as jane*@example.com
+func substrings(attr, value string) ([]byte, error) {
+	parts := strings.Split(value, "*")
+
+	var encoded [][]byte
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		unescaped, err := unescapeValue(part)
+		if err != nil {
+			return nil, err
+		}
+
+		tag := byte(0x81) // any
+		switch i {
+		case 0:
+			tag = 0x80 // initial
+		case len(parts) - 1:
+			tag = 0x82 // final
+		}
+		encoded = append(encoded, berString(tag, unescaped))
+	}
+	if len(encoded) == 0 {
+		return nil, fmt.Errorf("ldap: invalid substring filter %q", value)
+	}
+	return tlv(filterSubstrings, berString(tagOctetString, attr), tlv(tagSequence, encoded...)), nil
+}
+
+// unescapeValue decodes the \XX escapes of a filter value
+func unescapeValue(value string) (string, error) {
+	if !strings.Contains(value, `\`) {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+3 > len(value) {
+			return "", fmt.Errorf("ldap: invalid escape in filter value %q", value)
+		}
+		decoded, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("ldap: invalid escape in filter value %q", value)
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,127 @@
+package ldap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func equality(attr, value string) []byte {
+	return tlv(filterEqualityMatch, berString(tagOctetString, attr), berString(tagOctetString, value))
+}
+
+func TestEscapeFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "jane@example.com", want: "jane@example.com"},
+		{value: "*", want: `\2a`},
+		{value: "(cn=x)", want: `\28cn=x\29`},
+		{value: `a\b`, want: `a\5cb`},
+		{value: "a\x00b", want: `a\00b`},
+		{value: `\2a`, want: `\5c2a`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, EscapeFilter(tt.value), tt.value)
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []byte
+	}{
+		{name: "equality", filter: "(mail=jane@example.com)", want: equality("mail", "jane@example.com")},
+		{name: "surrounding space", filter: "  (mail=a) ", want: equality("mail", "a")},
+		{name: "escaped value", filter: `(cn=a\2ab\29)`, want: equality("cn", "a*b)")},
+		{name: "present", filter: "(mail=*)", want: berString(filterPresent, "mail")},
+		{name: "greater or equal", filter: "(age>=18)", want: tlv(filterGreaterOrEqual, berString(tagOctetString, "age"), berString(tagOctetString, "18"))},
+		{name: "less or equal", filter: "(age<=18)", want: tlv(filterLessOrEqual, berString(tagOctetString, "age"), berString(tagOctetString, "18"))},
+		{name: "approx", filter: "(cn~=jane)", want: tlv(filterApproxMatch, berString(tagOctetString, "cn"), berString(tagOctetString, "jane"))},
+		{
+			name:   "substrings",
+			filter: "(cn=ja*n*e)",
+			want: tlv(filterSubstrings, berString(tagOctetString, "cn"), tlv(tagSequence,
+				berString(0x80, "ja"), berString(0x81, "n"), berString(0x82, "e"))),
+		},
+		{
+			name:   "substrings without initial",
+			filter: "(cn=*ane)",
+			want:   tlv(filterSubstrings, berString(tagOctetString, "cn"), tlv(tagSequence, berString(0x82, "ane"))),
+		},
+		{
+			name:   "and",
+			filter: "(&(objectClass=person)(mail=a))",
+			want:   tlv(filterAnd, equality("objectClass", "person"), equality("mail", "a")),
+		},
+		{
+			name:   "nested or and not",
+			filter: "(|(mail=a)(!(uid=b)))",
+			want:   tlv(filterOr, equality("mail", "a"), tlv(filterNot, equality("uid", "b"))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compileFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompileFilter_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, filter := range []string{
+		"",
+		"mail=a",
+		"(mail=a",
+		"(mail=a))",
+		"(mail=a)(uid=b)",
+		"(=a)",
+		"(mail)",
+		"(>=a)",
+		"(&)",
+		"(!)",
+		"(cn:dn:=a)",
+		"(cn=a(b)",
+		"(cn=**)",
+		`(cn=a\2)`,
+		`(cn=a\zz)`,
+		`(cn=a\)`,
+	} {
+		_, err := compileFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+// Escaped input matches literally, whatever it holds
+func TestCompileFilter_EscapedInputCannotInject(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"*",
+		"*)(uid=*",
+		"jane)(|(objectClass=*)",
+		"admin*",
+		`\2a`,
+		"a\x00b",
+		"))(&(uid=*",
+	} {
+		filter := strings.ReplaceAll("(&(objectClass=person)(mail={email}))", "{email}", EscapeFilter(input))
+
+		got, err := compileFilter(filter)
+		require.NoError(t, err, input)
+		assert.Equal(t, tlv(filterAnd, equality("objectClass", "person"), equality("mail", input)), got, input)
+	}
+}
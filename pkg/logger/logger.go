@@ -7,7 +7,12 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
-var Logger *zap.Logger
+// Logger defaults to a no-op logger until Init is called (e.g. in tests)
+var Logger = zap.NewNop()
+
+// Output is where Init sends logs. Commands printing machine-readable
+// results set it to "stderr" to keep stdout for them.
+var Output = "stdout"
 
 func Init(level, format string) error {
 	var config zap.Config
@@ -35,7 +40,7 @@ func Init(level, format string) error {
 	}
 
 	// Set output paths
-	config.OutputPaths = []string{"stdout"}
+	config.OutputPaths = []string{Output}
 	config.ErrorOutputPaths = []string{"stderr"}
 
 	var err error
@@ -0,0 +1,120 @@
+// pkg/mail/array.go - In-memory mail driver for tests
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go-clean-gin/config"
+)
+
+// Message is an email captured by ArrayMailer
+type Message struct {
+	To          []string
+	Subject     string
+	Body        string
+	Template    string
+	Attachments []string
+}
+
+// ArrayMailer records messages instead of sending them
+type ArrayMailer struct {
+	mu     sync.Mutex
+	sent   []Message
+	config *config.EmailConfig
+}
+
+func NewArrayMailer(cfg *config.EmailConfig) *ArrayMailer {
+	return &ArrayMailer{config: cfg}
+}
+
+func (m *ArrayMailer) SendEmail(to []string, subject string, body string, attachments []string) error {
+	m.record(Message{To: to, Subject: subject, Body: body, Attachments: attachments})
+	return nil
+}
+
+// SendEmailWithTemplate renders the template when it exists so the body can be asserted;
+// a missing template is recorded with an empty body.
+func (m *ArrayMailer) SendEmailWithTemplate(to []string, subject string, templateName string, data interface{}, attachments []string) error {
+	body, err := renderTemplate(m.config, templateName, data)
+	if err != nil {
+		return err
+	}
+
+	m.record(Message{To: to, Subject: subject, Body: body, Template: templateName, Attachments: attachments})
+	return nil
+}
+
+func (m *ArrayMailer) SendBulkEmail(recipients []string, subject string, body string, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = 50 // Default batch size
+	}
+
+	for i := 0; i < len(recipients); i += batchSize {
+		end := i + batchSize
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+		m.record(Message{To: recipients[i:end], Subject: subject, Body: body})
+	}
+
+	return nil
+}
+
+func (m *ArrayMailer) TestConnection() error {
+	return nil
+}
+
+// Sent returns a copy of every captured message in send order
+func (m *ArrayMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sent := make([]Message, len(m.sent))
+	copy(sent, m.sent)
+	return sent
+}
+
+// Reset discards captured messages
+func (m *ArrayMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = nil
+}
+
+func (m *ArrayMailer) record(msg Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, msg)
+}
+
+// renderTemplate renders a template for drivers that do not send mail, so the
+// body can be inspected; a missing template renders as an empty body.
+func renderTemplate(cfg *config.EmailConfig, templateName string, data interface{}) (string, error) {
+	path := templateName
+	if !filepath.IsAbs(path) && cfg != nil {
+		path = filepath.Join(cfg.TemplateDir, templateName+".html")
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return "", nil
+	}
+
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load template: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %v", err)
+	}
+
+	return buffer.String(), nil
+}
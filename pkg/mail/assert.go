@@ -0,0 +1,75 @@
+// pkg/mail/assert.go - Test assertions for the array driver
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+// AssertSent fails the test unless a message with the subject was sent to the recipient.
+// An empty subject matches any message to the recipient.
+func AssertSent(t testing.TB, s Sender, to, subject string) Message {
+	t.Helper()
+
+	m := arrayMailer(t, s)
+	for _, msg := range m.Sent() {
+		if msg.sentTo(to) && (subject == "" || msg.Subject == subject) {
+			return msg
+		}
+	}
+
+	t.Fatalf("expected mail %q to be sent to %s; sent: %s", subject, to, summary(m.Sent()))
+	return Message{}
+}
+
+// AssertNotSent fails the test if any message was sent to the recipient
+func AssertNotSent(t testing.TB, s Sender, to string) {
+	t.Helper()
+
+	for _, msg := range arrayMailer(t, s).Sent() {
+		if msg.sentTo(to) {
+			t.Fatalf("expected no mail to %s, got %q", to, msg.Subject)
+		}
+	}
+}
+
+// AssertSentCount fails the test unless exactly n messages were sent
+func AssertSentCount(t testing.TB, s Sender, n int) {
+	t.Helper()
+
+	sent := arrayMailer(t, s).Sent()
+	if len(sent) != n {
+		t.Fatalf("expected %d mail(s) to be sent, got %d: %s", n, len(sent), summary(sent))
+	}
+}
+
+func arrayMailer(t testing.TB, s Sender) *ArrayMailer {
+	t.Helper()
+
+	m, ok := s.(*ArrayMailer)
+	if !ok {
+		t.Fatalf("mail assertions require the array driver, got %T", s)
+	}
+	return m
+}
+
+func (msg Message) sentTo(to string) bool {
+	for _, addr := range msg.To {
+		if strings.EqualFold(addr, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func summary(sent []Message) string {
+	if len(sent) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(sent))
+	for i, msg := range sent {
+		parts[i] = msg.Subject + " -> " + strings.Join(msg.To, ",")
+	}
+	return strings.Join(parts, "; ")
+}
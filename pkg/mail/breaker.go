@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"go-clean-gin/pkg/health"
+)
+
+// BreakerMailer stops calling sender while its breaker is open, failing with
+// health.ErrOpen instead of waiting on an unreachable mail server. Queued mail
+// jobs are deferred until the breaker lets a trial through.
+type BreakerMailer struct {
+	sender  Sender
+	breaker *health.Breaker
+}
+
+// NewBreaker wraps sender with breaker
+func NewBreaker(sender Sender, breaker *health.Breaker) *BreakerMailer {
+	return &BreakerMailer{sender: sender, breaker: breaker}
+}
+
+// Breaker returns the breaker guarding the mail server
+func (m *BreakerMailer) Breaker() *health.Breaker {
+	return m.breaker
+}
+
+func (m *BreakerMailer) SendEmail(to []string, subject string, body string, attachments []string) error {
+	return m.breaker.Do(func() error {
+		return m.sender.SendEmail(to, subject, body, attachments)
+	})
+}
+
+func (m *BreakerMailer) SendEmailWithTemplate(to []string, subject string, templateName string, data interface{}, attachments []string) error {
+	return m.breaker.Do(func() error {
+		return m.sender.SendEmailWithTemplate(to, subject, templateName, data, attachments)
+	})
+}
+
+func (m *BreakerMailer) SendBulkEmail(recipients []string, subject string, body string, batchSize int) error {
+	return m.breaker.Do(func() error {
+		return m.sender.SendBulkEmail(recipients, subject, body, batchSize)
+	})
+}
+
+func (m *BreakerMailer) TestConnection() error {
+	return m.breaker.Do(m.sender.TestConnection)
+}
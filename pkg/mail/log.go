@@ -0,0 +1,71 @@
+// pkg/mail/log.go - Mail drivers that never contact a mail server
+package mail
+
+import (
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer writes every email to the application log instead of sending it
+type LogMailer struct {
+	config *config.EmailConfig
+}
+
+func NewLogMailer(cfg *config.EmailConfig) *LogMailer {
+	return &LogMailer{config: cfg}
+}
+
+func (m *LogMailer) SendEmail(to []string, subject string, body string, attachments []string) error {
+	logger.Info("Email (log driver)",
+		zap.Strings("to", to),
+		zap.String("subject", subject),
+		zap.Strings("attachments", attachments),
+		zap.String("body", body),
+	)
+	return nil
+}
+
+func (m *LogMailer) SendEmailWithTemplate(to []string, subject string, templateName string, data interface{}, attachments []string) error {
+	body, err := renderTemplate(m.config, templateName, data)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Email (log driver)",
+		zap.Strings("to", to),
+		zap.String("subject", subject),
+		zap.String("template", templateName),
+		zap.Strings("attachments", attachments),
+		zap.String("body", body),
+	)
+	return nil
+}
+
+func (m *LogMailer) SendBulkEmail(recipients []string, subject string, body string, batchSize int) error {
+	return m.SendEmail(recipients, subject, body, nil)
+}
+
+func (m *LogMailer) TestConnection() error {
+	return nil
+}
+
+// NullMailer silently discards every email
+type NullMailer struct{}
+
+func (NullMailer) SendEmail(to []string, subject string, body string, attachments []string) error {
+	return nil
+}
+
+func (NullMailer) SendEmailWithTemplate(to []string, subject string, templateName string, data interface{}, attachments []string) error {
+	return nil
+}
+
+func (NullMailer) SendBulkEmail(recipients []string, subject string, body string, batchSize int) error {
+	return nil
+}
+
+func (NullMailer) TestConnection() error {
+	return nil
+}
@@ -0,0 +1,32 @@
+// pkg/mail/mail.go - Mail drivers
+package mail
+
+import (
+	"fmt"
+
+	"go-clean-gin/config"
+)
+
+// Sender is implemented by every mail driver
+type Sender interface {
+	SendEmail(to []string, subject string, body string, attachments []string) error
+	SendEmailWithTemplate(to []string, subject string, templateName string, data interface{}, attachments []string) error
+	SendBulkEmail(recipients []string, subject string, body string, batchSize int) error
+	TestConnection() error
+}
+
+// New creates a sender for the configured driver
+func New(cfg *config.EmailConfig) (Sender, error) {
+	switch cfg.Driver {
+	case "", "smtp":
+		return NewGomail(cfg)
+	case "log":
+		return NewLogMailer(cfg), nil
+	case "null":
+		return NullMailer{}, nil
+	case "array":
+		return NewArrayMailer(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,75 @@
+// pkg/matview/matview.go - Materialized views for reports and read models
+package matview
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Create creates the materialized view name from query and fills it. With a
+// unique key, a unique index on those columns lets Refresh run concurrently.
+// Call it from a migration, which keeps the query the view had at the time:
+//
+//	matview.Create(tx, "mv_orders_per_day", `SELECT created_at::date AS day, COUNT(*) AS orders
+//		FROM tb_orders GROUP BY 1`, "day")
+func Create(db *gorm.DB, name, query string, uniqueKey ...string) error {
+	quote := db.Statement.Quote
+	if err := db.Exec(fmt.Sprintf("CREATE MATERIALIZED VIEW %s AS %s", quote(name), query)).Error; err != nil {
+		return err
+	}
+	if len(uniqueKey) == 0 {
+		return nil
+	}
+
+	columns := make([]string, len(uniqueKey))
+	for i, column := range uniqueKey {
+		columns[i] = quote(column)
+	}
+	return db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)",
+		quote("uidx_"+name), quote(name), strings.Join(columns, ", "))).Error
+}
+
+// Drop drops the materialized view name and its indexes, if it exists
+func Drop(db *gorm.DB, name string) error {
+	return db.Exec(fmt.Sprintf("DROP MATERIALIZED VIEW IF EXISTS %s", db.Statement.Quote(name))).Error
+}
+
+// Refresh reruns the query of the materialized view name. A filled view with
+// a unique index on plain columns is refreshed concurrently, so it stays
+// readable with its old rows until the refresh commits; other views block
+// their readers while they refresh.
+func Refresh(db *gorm.DB, name string) error {
+	var views []struct {
+		Concurrent bool
+	}
+	err := db.Raw(`
+		SELECT m.ispopulated AND EXISTS (
+			SELECT 1 FROM pg_index i
+			WHERE i.indrelid = (quote_ident(m.schemaname) || '.' || quote_ident(m.matviewname))::regclass
+				AND i.indisunique AND i.indpred IS NULL AND i.indexprs IS NULL
+		) AS concurrent
+		FROM pg_matviews m
+		WHERE m.schemaname = current_schema() AND m.matviewname = ?`, name).Scan(&views).Error
+	if err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		return fmt.Errorf("matview: %s is not a materialized view", name)
+	}
+
+	statement := "REFRESH MATERIALIZED VIEW "
+	if views[0].Concurrent {
+		statement += "CONCURRENTLY "
+	}
+	return db.Exec(statement + db.Statement.Quote(name)).Error
+}
+
+// Names returns the materialized views of the current schema by name
+func Names(db *gorm.DB) ([]string, error) {
+	var names []string
+	err := db.Raw(`SELECT matviewname FROM pg_matviews
+		WHERE schemaname = current_schema() ORDER BY matviewname`).Scan(&names).Error
+	return names, err
+}
@@ -0,0 +1,106 @@
+// pkg/metrics/metrics.go - Prometheus metrics for background processing, load shedding, database retries and retention
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+var (
+	// JobsProcessed counts processed jobs by outcome (completed, retried, deferred, failed)
+	JobsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "queue_jobs_processed_total",
+		Help: "Number of queue jobs processed, by queue, type and status.",
+	}, []string{"queue", "type", "status"})
+
+	// JobDuration observes how long job handlers take
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "queue_job_duration_seconds",
+		Help:    "Duration of queue job handlers.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"queue", "type"})
+
+	// JobsInFlight tracks jobs currently being handled by this worker
+	JobsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "queue_jobs_in_flight",
+		Help: "Number of queue jobs currently being processed.",
+	})
+
+	// ScheduledTaskRuns counts scheduled task runs by outcome (success, error)
+	ScheduledTaskRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduler_task_runs_total",
+		Help: "Number of scheduled task runs, by task and status.",
+	}, []string{"task", "status"})
+
+	// RequestsShed counts requests rejected by load shedding, by reason
+	// (in_flight, db_pool)
+	RequestsShed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_shed_total",
+		Help: "Number of HTTP requests rejected while overloaded, by reason.",
+	}, []string{"reason"})
+
+	// RequestsOverConcurrency counts requests rejected by a route group's
+	// concurrency limit, by group
+	RequestsOverConcurrency = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_concurrency_rejected_total",
+		Help: "Number of HTTP requests rejected by a route group's concurrency limit, by group.",
+	}, []string{"group"})
+
+	// DBRetries counts repository calls retried after a transient database
+	// error, by operation and reason (serialization_failure, deadlock,
+	// connection)
+	DBRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_retries_total",
+		Help: "Number of repository calls retried after a transient database error, by operation and reason.",
+	}, []string{"operation", "reason"})
+
+	// DBRetriesExhausted counts repository calls that still failed with a
+	// transient error after their last attempt, by operation
+	DBRetriesExhausted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_retries_exhausted_total",
+		Help: "Number of repository calls that failed with a transient database error on every attempt, by operation.",
+	}, []string{"operation"})
+
+	// RetentionRowsDeleted counts rows deleted for being older than their
+	// table's retention, by rule
+	RetentionRowsDeleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retention_rows_deleted_total",
+		Help: "Number of rows deleted for being older than their retention, by rule.",
+	}, []string{"rule"})
+
+	// ScheduledTaskDuration observes how long scheduled tasks take
+	ScheduledTaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scheduler_task_duration_seconds",
+		Help:    "Duration of scheduled task runs.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"task"})
+)
+
+// Serve exposes /metrics on addr in the background. The returned server
+// should be shut down by the caller.
+func Serve(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		logger.Info("Metrics server starting", zap.String("address", addr))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("Metrics server failed", zap.Error(err))
+		}
+	}()
+
+	return server
+}
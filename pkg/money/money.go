@@ -0,0 +1,201 @@
+// pkg/money/money.go - Decimal amounts with an ISO 4217 currency
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is an exact decimal amount in a currency. It is stored in two columns
+// (embed it with gorm:"embedded;embeddedPrefix:<name>_") and encoded in JSON as
+// {"amount": "19.90", "currency": "USD"}, the amount always carrying the
+// currency's minor units.
+type Money struct {
+	Amount   decimal.Decimal `json:"amount" gorm:"type:numeric(19,4);not null"`
+	Currency string          `json:"currency" gorm:"type:char(3);not null"`
+}
+
+// New returns amount in currency
+func New(amount decimal.Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Parse reads a decimal string such as "19.90"
+func Parse(amount, currency string) (Money, error) {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	return New(d, currency), nil
+}
+
+// MustParse is Parse for literals; it panics on an invalid amount
+func MustParse(amount, currency string) Money {
+	m, err := Parse(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Add returns m + other; both must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("currency mismatch: %s and %s", m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by a quantity
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount.Mul(decimal.NewFromInt(quantity)), Currency: m.Currency}
+}
+
+// Round rounds the amount to the currency's minor units (banker's rounding)
+func (m Money) Round() Money {
+	return Money{Amount: m.Amount.RoundBank(Exponent(m.Currency)), Currency: m.Currency}
+}
+
+// IsNegative reports whether the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Amount.IsNegative()
+}
+
+// String formats the amount with the currency's minor units, e.g. "19.90 USD"
+func (m Money) String() string {
+	return m.FormatAmount() + " " + m.Currency
+}
+
+// FormatAmount formats the amount alone with the currency's minor units, e.g. "19.90"
+func (m Money) FormatAmount() string {
+	return m.Amount.StringFixedBank(Exponent(m.Currency))
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.FormatAmount(), m.Currency})
+}
+
+// UnmarshalJSON accepts the amount as a string or a number; an empty currency
+// is left empty so callers can apply the default
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   decimal.Decimal `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = New(raw.Amount, raw.Currency)
+	return nil
+}
+
+// minorUnits holds the ISO 4217 exponent of currencies that do not use two
+// decimal places
+var minorUnits = map[string]int32{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "PYG": 0, "UGX": 0, "VND": 0,
+}
+
+var (
+	mu              sync.RWMutex
+	defaultCurrency = "USD"
+	supported       = map[string]int32{"USD": 2}
+)
+
+// Configure sets the default currency and the accepted currencies. Entries are
+// ISO codes, optionally with an explicit exponent ("XAU:4"). The default is
+// always accepted.
+func Configure(defaultCode string, codes []string) error {
+	currencies := make(map[string]int32, len(codes)+1)
+	entries := append(append([]string{}, codes...), defaultCode)
+	for _, entry := range entries {
+		code, exponent, err := parseCurrency(entry)
+		if err != nil {
+			return err
+		}
+		currencies[code] = exponent
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	defaultCurrency = strings.ToUpper(strings.TrimSpace(defaultCode))
+	supported = currencies
+	return nil
+}
+
+func parseCurrency(entry string) (string, int32, error) {
+	code, exp, hasExp := strings.Cut(strings.TrimSpace(entry), ":")
+	code = strings.ToUpper(code)
+	if len(code) != 3 {
+		return "", 0, fmt.Errorf("invalid currency code %q", entry)
+	}
+
+	if !hasExp {
+		exponent, ok := minorUnits[code]
+		if !ok {
+			exponent = 2
+		}
+		return code, exponent, nil
+	}
+
+	exponent, err := strconv.Atoi(exp)
+	if err != nil || exponent < 0 || exponent > 4 {
+		return "", 0, fmt.Errorf("invalid exponent for currency %q", entry)
+	}
+	return code, int32(exponent), nil
+}
+
+// DefaultCurrency returns the configured default currency
+func DefaultCurrency() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return defaultCurrency
+}
+
+// Supported reports whether code is an accepted currency
+func Supported(code string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	_, ok := supported[code]
+	return ok
+}
+
+// Currencies returns the accepted currency codes
+func Currencies() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	codes := make([]string, 0, len(supported))
+	for code := range supported {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Exponent returns the number of minor-unit digits for code
+func Exponent(code string) int32 {
+	mu.RLock()
+	exponent, ok := supported[code]
+	mu.RUnlock()
+
+	if ok {
+		return exponent
+	}
+	if exponent, ok := minorUnits[code]; ok {
+		return exponent
+	}
+	return 2
+}
@@ -0,0 +1,76 @@
+// pkg/notify/channel.go - The configured channel: event filter, environment and throttle
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/clock"
+)
+
+// Channel posts the events configured in NOTIFY_EVENTS through a driver,
+// naming the environment in the title so one channel can serve several, and
+// drops a message identical to one posted within the throttle
+type Channel struct {
+	notifier Notifier
+	env      string
+	events   map[string]bool
+	throttle time.Duration
+	clock    clock.Clock
+
+	mu   sync.Mutex
+	last map[string]time.Time // when each event and title was last posted
+}
+
+// NewChannel wraps notifier with the configuration of the environment
+func NewChannel(notifier Notifier, cfg *config.NotifyConfig, env string, clk clock.Clock) *Channel {
+	events := make(map[string]bool, len(cfg.Events))
+	for _, event := range cfg.Events {
+		events[event] = true
+	}
+
+	return &Channel{
+		notifier: notifier,
+		env:      env,
+		events:   events,
+		throttle: cfg.Throttle,
+		clock:    clk,
+		last:     make(map[string]time.Time),
+	}
+}
+
+// Notifier returns the driver messages are posted through
+func (c *Channel) Notifier() Notifier {
+	return c.notifier
+}
+
+// Notify posts the message unless its event is not configured or it was
+// throttled
+func (c *Channel) Notify(ctx context.Context, msg Message) error {
+	if !c.events[msg.Event] || !c.allow(msg.Event+"\x00"+msg.Title) {
+		return nil
+	}
+
+	if c.env != "" {
+		msg.Title = "[" + c.env + "] " + msg.Title
+	}
+	return c.notifier.Notify(ctx, msg)
+}
+
+func (c *Channel) allow(key string) bool {
+	if c.throttle <= 0 {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if last, ok := c.last[key]; ok && now.Sub(last) < c.throttle {
+		return false
+	}
+	c.last[key] = now
+	return true
+}
@@ -0,0 +1,64 @@
+// pkg/notify/log.go - Notification drivers that never contact a chat service
+package notify
+
+import (
+	"context"
+	"sync"
+
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogNotifier writes every message to the application log instead of posting it
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, msg Message) error {
+	fields := []zap.Field{
+		zap.String("event", msg.Event),
+		zap.String("level", string(msg.Level)),
+		zap.String("title", msg.Title),
+		zap.String("text", msg.Text),
+	}
+	for _, field := range msg.Fields {
+		fields = append(fields, zap.String(field.Name, field.Value))
+	}
+
+	logger.Info("Notification (log driver)", fields...)
+	return nil
+}
+
+// NullNotifier silently discards every message
+type NullNotifier struct{}
+
+func (NullNotifier) Notify(ctx context.Context, msg Message) error {
+	return nil
+}
+
+// ArrayNotifier records messages instead of posting them, for tests
+type ArrayNotifier struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewArrayNotifier() *ArrayNotifier {
+	return &ArrayNotifier{}
+}
+
+func (n *ArrayNotifier) Notify(ctx context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+// Sent returns a copy of every captured message in send order
+func (n *ArrayNotifier) Sent() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	sent := make([]Message, len(n.sent))
+	copy(sent, n.sent)
+	return sent
+}
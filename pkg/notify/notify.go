@@ -0,0 +1,70 @@
+// pkg/notify/notify.go - Chat notifications of operational events
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"go-clean-gin/config"
+)
+
+// Operational events a channel can be configured to post, see NOTIFY_EVENTS
+const (
+	EventMigrationFailed = "migration_failed"
+	EventJobFailed       = "job_failed"
+	EventPanic           = "panic"
+	EventDeploy          = "deploy"
+)
+
+// Level is how a message is highlighted in the channel
+type Level string
+
+const (
+	LevelInfo    Level = "info"
+	LevelSuccess Level = "success"
+	LevelError   Level = "error"
+)
+
+// Field is a labelled detail shown under a message, such as a job ID
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Message is a notification of an operational event
+type Message struct {
+	Event  string // one of the Event constants, for filtering
+	Level  Level
+	Title  string
+	Text   string
+	Fields []Field
+}
+
+// Notifier is implemented by every notification driver
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// New creates a notifier for the configured driver
+func New(cfg *config.NotifyConfig) (Notifier, error) {
+	switch cfg.Driver {
+	case "", "null":
+		return NullNotifier{}, nil
+	case "slack":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("NOTIFY_WEBHOOK_URL is required for the slack driver")
+		}
+		return NewSlack(cfg.WebhookURL, cfg.Timeout), nil
+	case "teams":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("NOTIFY_WEBHOOK_URL is required for the teams driver")
+		}
+		return NewTeams(cfg.WebhookURL, cfg.Timeout), nil
+	case "log":
+		return LogNotifier{}, nil
+	case "array":
+		return NewArrayNotifier(), nil
+	default:
+		return nil, fmt.Errorf("unsupported notify driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,123 @@
+// pkg/notify/webhook.go - Slack and Microsoft Teams incoming webhooks
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// colors of the message levels, as hex without the leading #
+var colors = map[Level]string{
+	LevelInfo:    "439fe0",
+	LevelSuccess: "2eb67d",
+	LevelError:   "d63333",
+}
+
+// Slack posts messages to a Slack incoming webhook
+type Slack struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlack returns a notifier posting to the webhook at url
+func NewSlack(url string, timeout time.Duration) *Slack {
+	return &Slack{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type slackAttachment struct {
+	Color    string       `json:"color"`
+	Fallback string       `json:"fallback"`
+	Title    string       `json:"title"`
+	Text     string       `json:"text,omitempty"`
+	Fields   []slackField `json:"fields,omitempty"`
+}
+
+func (s *Slack) Notify(ctx context.Context, msg Message) error {
+	fields := make([]slackField, len(msg.Fields))
+	for i, field := range msg.Fields {
+		fields[i] = slackField{Title: field.Name, Value: field.Value, Short: len(field.Value) < 40}
+	}
+
+	return post(ctx, s.client, s.url, map[string]interface{}{
+		"attachments": []slackAttachment{{
+			Color:    "#" + colors[msg.Level],
+			Fallback: msg.Title,
+			Title:    msg.Title,
+			Text:     msg.Text,
+			Fields:   fields,
+		}},
+	})
+}
+
+// Teams posts messages to a Microsoft Teams incoming webhook as message cards
+type Teams struct {
+	url    string
+	client *http.Client
+}
+
+// NewTeams returns a notifier posting to the webhook at url
+func NewTeams(url string, timeout time.Duration) *Teams {
+	return &Teams{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type teamsSection struct {
+	Text  string      `json:"text,omitempty"`
+	Facts []teamsFact `json:"facts,omitempty"`
+}
+
+func (t *Teams) Notify(ctx context.Context, msg Message) error {
+	facts := make([]teamsFact, len(msg.Fields))
+	for i, field := range msg.Fields {
+		facts[i] = teamsFact{Name: field.Name, Value: field.Value}
+	}
+
+	return post(ctx, t.client, t.url, map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"themeColor": colors[msg.Level],
+		"summary":    msg.Title,
+		"title":      msg.Title,
+		"sections":   []teamsSection{{Text: strings.ReplaceAll(msg.Text, "\n", "<br>"), Facts: facts}},
+	})
+}
+
+// post sends body as JSON to a webhook
+func post(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("notify: encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: post message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notify: webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// pkg/pagination/pagination.go - Paging for list endpoints
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-clean-gin/pkg/response"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultLimit is the page size when a request gives none
+	DefaultLimit = 20
+	// MaxLimit caps the page size so responses stay within a payload budget
+	MaxLimit = 100
+)
+
+// ErrInvalidCursor is returned for cursors this package did not issue
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+// Params is the paging of a list request. Embed it in a query filter to bind
+// ?page=, ?limit= and ?cursor=. Pages are numbered from 1; a cursor, taken
+// from meta.next_cursor, resumes after the last item of the previous page and
+// takes precedence over page.
+type Params struct {
+	Page   int    `form:"page" validate:"omitempty,min=1"`
+	Limit  int    `form:"limit" validate:"omitempty,min=1"`
+	Cursor string `form:"cursor" validate:"omitempty,cursor"`
+}
+
+// Normalize defaults the page to 1 and the limit to defaultLimit, and caps the
+// limit at MaxLimit
+func (p *Params) Normalize(defaultLimit int) {
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+	if p.Limit <= 0 {
+		p.Limit = defaultLimit
+	}
+	if p.Limit > MaxLimit {
+		p.Limit = MaxLimit
+	}
+}
+
+// Offset returns the number of rows before the page
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// Cursor is a position in a newest-first listing: the creation time and id of
+// the last item served
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// EncodeCursor returns the opaque cursor resuming after the given item
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor made by EncodeCursor
+func DecodeCursor(cursor string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if c.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if c.ID, err = uuid.Parse(id); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Apply orders query newest first by createdAtColumn, with idColumn breaking
+// ties, and limits it to the page: the rows after the cursor when one is set,
+// otherwise the page at Offset. Without a limit every row is returned. The
+// cursor must be validated; an unreadable cursor falls back to the page.
+func Apply(query *gorm.DB, p Params, createdAtColumn, idColumn string) *gorm.DB {
+	query = query.Order(createdAtColumn + " DESC").Order(idColumn + " DESC")
+	if p.Limit <= 0 {
+		return query
+	}
+	query = query.Limit(p.Limit)
+
+	if p.Cursor != "" {
+		if cursor, err := DecodeCursor(p.Cursor); err == nil {
+			return query.Where(fmt.Sprintf("(%s, %s) < (?, ?)", createdAtColumn, idColumn), cursor.CreatedAt, cursor.ID)
+		}
+	}
+	if p.Page > 1 {
+		query = query.Offset(p.Offset())
+	}
+	return query
+}
+
+// Meta builds the response meta of a page with count items, where last
+// returns the creation time and id of the last one. A full page gets a
+// next_cursor; a cursor page has no page number.
+func Meta(p Params, total int64, count int, last func() (time.Time, uuid.UUID)) *response.Meta {
+	meta := response.Pagination(p.Page, p.Limit, total)
+
+	if count > 0 && count == p.Limit {
+		meta.NextCursor = EncodeCursor(last())
+	}
+
+	if p.Cursor != "" {
+		meta.Page = 0
+		meta.HasNext = meta.NextCursor != ""
+		meta.HasPrevious = true
+	}
+
+	return meta
+}
@@ -0,0 +1,242 @@
+// pkg/partition/partition.go - Time-range partitioned tables for high-volume data
+package partition
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Interval is the time range each partition of a table holds
+type Interval string
+
+const (
+	Daily   Interval = "daily"
+	Monthly Interval = "monthly"
+)
+
+// start returns the start of the partition holding t, in UTC
+func (i Interval) start(t time.Time) time.Time {
+	t = t.UTC()
+	if i == Daily {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// next returns the start of the partition after the one starting at start
+func (i Interval) next(start time.Time) time.Time {
+	if i == Daily {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// ahead returns the start of the partition n intervals after the one holding
+// t, or t itself when n is 0
+func (i Interval) ahead(t time.Time, n int) time.Time {
+	for ; n > 0; n-- {
+		t = i.next(i.start(t))
+	}
+	return t
+}
+
+// layout is the suffix of partition names, after _p
+func (i Interval) layout() string {
+	if i == Daily {
+		return "2006_01_02"
+	}
+	return "2006_01"
+}
+
+// Table is a table partitioned by range of a timestamp column. Its
+// partitions are named after their start, e.g. tb_audit_logs_p2026_10, and a
+// default partition, e.g. tb_audit_logs_default, takes rows no partition
+// covers yet, so inserts never fail for want of one.
+type Table struct {
+	Name     string
+	Column   string // e.g. created_at
+	Interval Interval
+}
+
+func (t Table) partitionName(start time.Time) string {
+	return t.Name + "_p" + start.Format(t.Interval.layout())
+}
+
+func (t Table) defaultName() string {
+	return t.Name + "_default"
+}
+
+// Convert turns the existing table into a partitioned one with the same
+// columns, defaults and checks, copies its rows and creates partitions from
+// its oldest row through now. The primary key becomes (id, column), since
+// unique keys must include the partition column; recreate indexes and foreign
+// keys afterwards. Call it from a migration: the rows are rewritten in its
+// transaction, which holds the table locked throughout.
+func Convert(db *gorm.DB, table Table, now time.Time) error {
+	quote := db.Statement.Quote
+	previous := table.Name + "_unpartitioned"
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", quote(table.Name), quote(previous)),
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS) PARTITION BY RANGE (%s)",
+			quote(table.Name), quote(previous), quote(table.Column)),
+		fmt.Sprintf("CREATE TABLE %s PARTITION OF %s DEFAULT", quote(table.defaultName()), quote(table.Name)),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", quote(table.Name), quote(previous)),
+		fmt.Sprintf("DROP TABLE %s", quote(previous)),
+		fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (id, %s)", quote(table.Name), quote(table.Column)),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	var oldest *time.Time
+	if err := db.Table(table.Name).Select("MIN(" + quote(table.Column) + ")").Scan(&oldest).Error; err != nil {
+		return err
+	}
+	from := now
+	if oldest != nil && oldest.Before(now) {
+		from = *oldest
+	}
+	_, err := Ensure(db, table, from, now)
+	return err
+}
+
+// Ensure creates the missing partitions of table covering from through to,
+// moving the rows of their ranges out of the default partition, and returns
+// how many it created
+func Ensure(db *gorm.DB, table Table, from, to time.Time) (int, error) {
+	existing, err := partitions(db, table)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, start := range table.missing(existing, from, to) {
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return create(tx, table, table.partitionName(start), start, table.Interval.next(start))
+		}); err != nil {
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// missing returns the starts of the partitions covering from through to that
+// are not among existing
+func (t Table) missing(existing map[string]bool, from, to time.Time) []time.Time {
+	var starts []time.Time
+	for start := t.Interval.start(from); !start.After(to); start = t.Interval.next(start) {
+		if !existing[t.partitionName(start)] {
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
+
+// create creates the partition name for start up to end. Rows of the range
+// already in the default partition keep a new partition from being attached,
+// so they are moved to it first.
+func create(tx *gorm.DB, table Table, name string, start, end time.Time) error {
+	quote := tx.Statement.Quote
+	err := tx.Exec(fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS)",
+		quote(name), quote(table.Name))).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Exec(fmt.Sprintf(`WITH moved AS (
+			DELETE FROM %s WHERE %s >= ? AND %s < ? RETURNING *
+		) INSERT INTO %s SELECT * FROM moved`,
+		quote(table.defaultName()), quote(table.Column), quote(table.Column), quote(name)), start, end).Error
+	if err != nil {
+		return err
+	}
+
+	// Bounds of DDL can't be bind parameters; they are formatted here, never
+	// taken from input
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')",
+		quote(table.Name), quote(name), literal(start), literal(end))).Error
+}
+
+// DropBefore drops the partitions of table whose whole range is before
+// cutoff, deletes the default partition's rows before it, and returns how
+// many partitions it dropped
+func DropBefore(db *gorm.DB, table Table, cutoff time.Time) (int, error) {
+	existing, err := partitions(db, table)
+	if err != nil {
+		return 0, err
+	}
+
+	dropped := 0
+	for _, name := range table.expired(existing, cutoff) {
+		if err := db.Exec("DROP TABLE " + db.Statement.Quote(name)).Error; err != nil {
+			return dropped, err
+		}
+		dropped++
+	}
+
+	err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s < ?",
+		db.Statement.Quote(table.defaultName()), db.Statement.Quote(table.Column)), cutoff).Error
+	return dropped, err
+}
+
+// expired returns the partitions among existing whose whole range is before
+// cutoff, oldest first. The default partition and tables not named like a
+// partition are left out.
+func (t Table) expired(existing map[string]bool, cutoff time.Time) []string {
+	var names []string
+	prefix := t.Name + "_p"
+	for name := range existing {
+		suffix, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		start, err := time.Parse(t.Interval.layout(), suffix)
+		if err != nil || t.Interval.next(start).After(cutoff) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Maintain creates the partitions of table from now through premake
+// intervals ahead and, with a retention, drops those entirely older than it.
+// Run it at least once per interval, e.g. daily from the scheduler.
+func Maintain(db *gorm.DB, table Table, now time.Time, premake int, retention time.Duration) (created, dropped int, err error) {
+	to := table.Interval.ahead(now, premake)
+	if created, err = Ensure(db, table, now, to); err != nil || retention <= 0 {
+		return created, 0, err
+	}
+	dropped, err = DropBefore(db, table, now.Add(-retention))
+	return created, dropped, err
+}
+
+// partitions returns the names of the partitions of table
+func partitions(db *gorm.DB, table Table) (map[string]bool, error) {
+	var names []string
+	err := db.Raw(`SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = ?::regclass`, table.Name).Scan(&names).Error
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+	return existing, nil
+}
+
+// literal formats a partition bound as a timestamptz literal
+func literal(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05") + "+00"
+}
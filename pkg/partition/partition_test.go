@@ -0,0 +1,216 @@
+package partition
+
+import (
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var (
+	auditLogs  = Table{Name: "tb_audit_logs", Column: "created_at", Interval: Monthly}
+	activities = Table{Name: "tb_activities", Column: "created_at", Interval: Daily}
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// names returns the partition names of starts
+func names(table Table, starts []time.Time) []string {
+	result := make([]string, len(starts))
+	for i, start := range starts {
+		result[i] = table.partitionName(start)
+	}
+	return result
+}
+
+// exist returns the set of partitions a table would have for names
+func exist(names ...string) map[string]bool {
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+	return existing
+}
+
+func TestTable_Names(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tb_audit_logs_p2026_10", auditLogs.partitionName(date(2026, 10, 1)))
+	assert.Equal(t, "tb_audit_logs_default", auditLogs.defaultName())
+	assert.Equal(t, "tb_activities_p2026_10_07", activities.partitionName(date(2026, 10, 7)))
+	assert.Equal(t, "tb_activities_default", activities.defaultName())
+}
+
+func TestInterval_Bounds(t *testing.T) {
+	t.Parallel()
+
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	tests := []struct {
+		name      string
+		interval  Interval
+		at        time.Time
+		start     time.Time
+		next      time.Time
+		partition string
+	}{
+		{name: "monthly", interval: Monthly, at: time.Date(2026, 10, 17, 15, 4, 5, 0, time.UTC),
+			start: date(2026, 10, 1), next: date(2026, 11, 1), partition: "tb_p2026_10"},
+		{name: "monthly on the first instant", interval: Monthly, at: date(2026, 10, 1),
+			start: date(2026, 10, 1), next: date(2026, 11, 1), partition: "tb_p2026_10"},
+		{name: "monthly on the last instant", interval: Monthly, at: date(2026, 11, 1).Add(-time.Nanosecond),
+			start: date(2026, 10, 1), next: date(2026, 11, 1), partition: "tb_p2026_10"},
+		{name: "monthly year rollover", interval: Monthly, at: time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC),
+			start: date(2026, 12, 1), next: date(2027, 1, 1), partition: "tb_p2026_12"},
+		{name: "daily", interval: Daily, at: time.Date(2026, 10, 17, 15, 4, 5, 0, time.UTC),
+			start: date(2026, 10, 17), next: date(2026, 10, 18), partition: "tb_p2026_10_17"},
+		{name: "daily month rollover", interval: Daily, at: time.Date(2026, 4, 30, 12, 0, 0, 0, time.UTC),
+			start: date(2026, 4, 30), next: date(2026, 5, 1), partition: "tb_p2026_04_30"},
+		{name: "daily year rollover", interval: Daily, at: time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC),
+			start: date(2026, 12, 31), next: date(2027, 1, 1), partition: "tb_p2026_12_31"},
+		{name: "daily leap day", interval: Daily, at: time.Date(2028, 2, 28, 12, 0, 0, 0, time.UTC),
+			start: date(2028, 2, 28), next: date(2028, 2, 29), partition: "tb_p2028_02_28"},
+		// Partitions are UTC whatever the zone of the time given
+		{name: "monthly from another zone", interval: Monthly, at: time.Date(2027, 1, 1, 6, 0, 0, 0, bangkok),
+			start: date(2026, 12, 1), next: date(2027, 1, 1), partition: "tb_p2026_12"},
+		{name: "daily from another zone", interval: Daily, at: time.Date(2026, 10, 18, 3, 0, 0, 0, bangkok),
+			start: date(2026, 10, 17), next: date(2026, 10, 18), partition: "tb_p2026_10_17"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Table{Name: "tb", Column: "created_at", Interval: tt.interval}
+
+			start := tt.interval.start(tt.at)
+
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.next, tt.interval.next(start))
+			assert.Equal(t, tt.partition, table.partitionName(start))
+		})
+	}
+}
+
+func TestInterval_Ahead(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake(time.Date(2026, 11, 20, 8, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, clk.Now(), Monthly.ahead(clk.Now(), 0))
+	assert.Equal(t, date(2026, 12, 1), Monthly.ahead(clk.Now(), 1))
+	assert.Equal(t, date(2027, 2, 1), Monthly.ahead(clk.Now(), 3), "premaking crosses the year")
+
+	clk.Advance(41 * 24 * time.Hour) // 2026-12-31 08:00
+	assert.Equal(t, date(2027, 1, 1), Daily.ahead(clk.Now(), 1))
+	assert.Equal(t, date(2027, 1, 3), Daily.ahead(clk.Now(), 3))
+}
+
+func TestTable_Missing(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake(time.Date(2026, 11, 20, 8, 0, 0, 0, time.UTC))
+	existing := exist("tb_audit_logs_default", "tb_audit_logs_p2026_11")
+
+	// What Maintain creates with premake 3: through the partition starting
+	// three months ahead
+	missing := auditLogs.missing(existing, clk.Now(), Monthly.ahead(clk.Now(), 3))
+	assert.Equal(t, []string{"tb_audit_logs_p2026_12", "tb_audit_logs_p2027_01", "tb_audit_logs_p2027_02"},
+		names(auditLogs, missing))
+
+	for _, start := range missing {
+		existing[auditLogs.partitionName(start)] = true
+	}
+
+	// The next run within the month creates nothing; the first of the next
+	// month adds one partition more
+	assert.Empty(t, auditLogs.missing(existing, clk.Now(), Monthly.ahead(clk.Now(), 3)))
+	clk.Advance(11*24*time.Hour + 16*time.Hour) // 2026-12-01 00:00
+	assert.Equal(t, []string{"tb_audit_logs_p2027_03"},
+		names(auditLogs, auditLogs.missing(existing, clk.Now(), Monthly.ahead(clk.Now(), 3))))
+}
+
+func TestTable_Missing_Daily(t *testing.T) {
+	t.Parallel()
+
+	// Convert creates partitions from the oldest row through now
+	missing := activities.missing(exist("tb_activities_p2026_12_31"), date(2026, 12, 30).Add(5*time.Hour), date(2027, 1, 1).Add(time.Hour))
+
+	assert.Equal(t, []string{"tb_activities_p2026_12_30", "tb_activities_p2027_01_01"}, names(activities, missing))
+}
+
+func TestTable_Expired(t *testing.T) {
+	t.Parallel()
+
+	existing := exist(
+		"tb_audit_logs_default",
+		"tb_audit_logs_p2025_11",
+		"tb_audit_logs_p2025_12",
+		"tb_audit_logs_p2026_01",
+		"tb_audit_logs_p2026_02",
+		"tb_audit_logs_archive",     // not a partition name
+		"tb_audit_logs_p2026",       // nor this
+		"tb_audit_logs_pa_c2025_01", // nor this
+	)
+
+	tests := []struct {
+		name   string
+		cutoff time.Time
+		want   []string
+	}{
+		{name: "before every partition", cutoff: date(2025, 11, 30)},
+		// A partition is dropped once its end is at or before the cutoff
+		{name: "at the end of a partition", cutoff: date(2025, 12, 1), want: []string{"tb_audit_logs_p2025_11"}},
+		{name: "within a partition", cutoff: date(2026, 1, 15), want: []string{"tb_audit_logs_p2025_11", "tb_audit_logs_p2025_12"}},
+		{name: "across the year", cutoff: date(2026, 2, 1), want: []string{"tb_audit_logs_p2025_11", "tb_audit_logs_p2025_12", "tb_audit_logs_p2026_01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, auditLogs.expired(existing, tt.cutoff))
+		})
+	}
+}
+
+func TestTable_Expired_Retention(t *testing.T) {
+	t.Parallel()
+
+	// Maintain drops what is entirely older than now minus the retention
+	clk := clock.NewFake(time.Date(2027, 1, 2, 3, 0, 0, 0, time.UTC))
+	existing := exist("tb_activities_p2026_12_30", "tb_activities_p2026_12_31", "tb_activities_p2027_01_01", "tb_activities_p2027_01_02")
+	retention := 48 * time.Hour
+
+	assert.Equal(t, []string{"tb_activities_p2026_12_30"}, activities.expired(existing, clk.Now().Add(-retention)))
+
+	clk.Advance(21 * time.Hour) // 2027-01-03 00:00
+	assert.Equal(t, []string{"tb_activities_p2026_12_30", "tb_activities_p2026_12_31"}, activities.expired(existing, clk.Now().Add(-retention)))
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+
+	var statements []string
+	require.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:capture", func(db *gorm.DB) {
+		statements = append(statements, db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+	}))
+
+	start := date(2026, 12, 1)
+	require.NoError(t, create(db, auditLogs, auditLogs.partitionName(start), start, Monthly.next(start)))
+
+	require.Len(t, statements, 3)
+	assert.Equal(t, `CREATE TABLE "tb_audit_logs_p2026_12" (LIKE "tb_audit_logs" INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`, statements[0])
+	assert.Contains(t, statements[1], `DELETE FROM "tb_audit_logs_default" WHERE "created_at" >= '2026-12-01 00:00:00' AND "created_at" < '2027-01-01 00:00:00'`)
+	assert.Contains(t, statements[1], `INSERT INTO "tb_audit_logs_p2026_12" SELECT * FROM moved`)
+	assert.Equal(t, `ALTER TABLE "tb_audit_logs" ATTACH PARTITION "tb_audit_logs_p2026_12" FOR VALUES FROM ('2026-12-01 00:00:00+00') TO ('2027-01-01 00:00:00+00')`, statements[2])
+}
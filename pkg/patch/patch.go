@@ -0,0 +1,463 @@
+// pkg/patch/patch.go - JSON Merge Patch and JSON Patch of update requests
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"mime"
+	"strconv"
+	"strings"
+
+	"go-clean-gin/pkg/errors"
+)
+
+// Media types of PATCH bodies
+const (
+	MergePatch = "application/merge-patch+json" // RFC 7386
+	JSONPatch  = "application/json-patch+json"  // RFC 6902
+)
+
+// Apply patches doc, an update request filled with the current values of a
+// resource, with body, a patch in the media type contentType, and decodes the
+// patched document into dst, usually a request of the same type. dst is then
+// validated and handed to the update usecase like the body of a PUT, so the
+// usecase's validation and ownership rules apply to patches too.
+//
+// A patch may not remove a field doc holds, since the update requests cannot
+// unset fields, nor add fields dst does not have. Errors are *errors.AppError:
+// 415 for other media types, 409 when a test operation fails and 400 for the
+// rest.
+func Apply(contentType string, doc interface{}, body []byte, dst interface{}) error {
+	current, err := toTree(doc)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrInternal, "Failed to encode the current document", 500)
+	}
+	original, ok := current.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.ErrInternal, "Only objects can be patched", 500)
+	}
+	kept := make([]string, 0, len(original))
+	for key, value := range original {
+		if value != nil {
+			kept = append(kept, key)
+		}
+	}
+
+	var patched interface{}
+	switch mediaType(contentType) {
+	case MergePatch:
+		patch, err := decode(body)
+		if err != nil {
+			return invalid("Merge patch is not valid JSON", err)
+		}
+		patched = merge(current, patch)
+	case JSONPatch:
+		if patched, err = applyOperations(current, body); err != nil {
+			return err
+		}
+	default:
+		return errors.ErrUnsupportedMediaTypeError
+	}
+
+	result, ok := patched.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.ErrPatchInvalid, "Patched document must be an object", 400)
+	}
+	for _, key := range kept {
+		if result[key] == nil {
+			return errors.New(errors.ErrPatchInvalid, fmt.Sprintf("%s cannot be removed", key), 400)
+		}
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return errors.Wrap(err, errors.ErrInternal, "Failed to encode the patched document", 500)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return invalid("Patched document does not match the resource", err)
+	}
+	return nil
+}
+
+// mediaType strips parameters such as charset from a Content-Type
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return parsed
+}
+
+func invalid(message string, err error) *errors.AppError {
+	return errors.New(errors.ErrPatchInvalid, message, 400).WithDetails(err.Error())
+}
+
+// toTree converts a value to the maps, slices and scalars JSON decodes into
+func toTree(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decode(encoded)
+}
+
+// decode decodes JSON keeping numbers as json.Number, so amounts survive a
+// round trip exactly
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+// merge applies a merge patch: objects are merged member by member, null
+// removes a member and any other value replaces the target
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = merge(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+// applyOperations applies the operations of a JSON Patch in order. The patch
+// is atomic: the first failing operation fails it as a whole.
+func applyOperations(doc interface{}, body []byte) (interface{}, error) {
+	decoded, err := decode(body)
+	if err != nil {
+		return nil, invalid("JSON Patch is not valid JSON", err)
+	}
+	ops, ok := decoded.([]interface{})
+	if !ok {
+		return nil, errors.New(errors.ErrPatchInvalid, "JSON Patch must be an array of operations", 400)
+	}
+
+	for i, raw := range ops {
+		op, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, errors.New(errors.ErrPatchInvalid, fmt.Sprintf("Operation %d must be an object", i), 400)
+		}
+		if doc, err = applyOperation(doc, op); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				return nil, appErr
+			}
+			return nil, errors.New(errors.ErrPatchInvalid, fmt.Sprintf("Operation %d failed", i), 400).WithDetails(err.Error())
+		}
+	}
+	return doc, nil
+}
+
+func applyOperation(doc interface{}, op map[string]interface{}) (interface{}, error) {
+	name, _ := op["op"].(string)
+	path, err := pointer(op, "path")
+	if err != nil {
+		return nil, err
+	}
+	value, hasValue := op["value"]
+
+	switch name {
+	case "add", "replace", "test":
+		if !hasValue {
+			return nil, fmt.Errorf("%s needs a value", name)
+		}
+	case "move", "copy":
+		if _, ok := op["from"]; !ok {
+			return nil, fmt.Errorf("%s needs from", name)
+		}
+	}
+
+	switch name {
+	case "add":
+		return add(doc, path, value)
+	case "remove":
+		return remove(doc, path)
+	case "replace":
+		if _, err := get(doc, path); err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		return edit(doc, path, func(container interface{}, key string) (interface{}, error) {
+			return set(container, key, value)
+		})
+	case "move":
+		from, err := pointer(op, "from")
+		if err != nil {
+			return nil, err
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, fmt.Errorf("cannot move a value into itself")
+		}
+		moved, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, moved)
+	case "copy":
+		from, err := pointer(op, "from")
+		if err != nil {
+			return nil, err
+		}
+		copied, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, clone(copied))
+	case "test":
+		got, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(got, value) {
+			return nil, errors.New(errors.ErrPatchTestFailed,
+				fmt.Sprintf("Test of %s failed", op["path"]), 409)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown op %q", name)
+	}
+}
+
+// pointer parses the JSON Pointer in the member of an operation into its
+// reference tokens
+func pointer(op map[string]interface{}, member string) ([]string, error) {
+	raw, ok := op[member].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a JSON Pointer", member)
+	}
+	if raw == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(raw, "/") {
+		return nil, fmt.Errorf("%s %q must start with /", member, raw)
+	}
+
+	tokens := strings.Split(raw[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// get returns the value the path points to
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, key := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("%s does not exist", key)
+			}
+			doc = value
+		case []interface{}:
+			i, err := index(key, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%s does not exist", key)
+		}
+	}
+	return doc, nil
+}
+
+// edit calls fn with the object or array holding the last token of path, and
+// puts the container fn returns back in its place
+func edit(doc interface{}, path []string, fn func(container interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+
+	child, err := get(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = edit(child, path[1:], fn); err != nil {
+		return nil, err
+	}
+	return set(doc, path[0], child)
+}
+
+// add adds value at path: it sets an object member and inserts into an array,
+// where - appends
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return edit(doc, path, func(container interface{}, key string) (interface{}, error) {
+		switch node := container.(type) {
+		case map[string]interface{}:
+			node[key] = value
+			return node, nil
+		case []interface{}:
+			i := len(node)
+			if key != "-" {
+				var err error
+				if i, err = index(key, len(node)); err != nil {
+					return nil, err
+				}
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("cannot add %s to a scalar", key)
+		}
+	})
+}
+
+// remove removes the member or element at path
+func remove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	return edit(doc, path, func(container interface{}, key string) (interface{}, error) {
+		switch node := container.(type) {
+		case map[string]interface{}:
+			if _, ok := node[key]; !ok {
+				return nil, fmt.Errorf("%s does not exist", key)
+			}
+			delete(node, key)
+			return node, nil
+		case []interface{}:
+			i, err := index(key, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:i], node[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%s does not exist", key)
+		}
+	})
+}
+
+// set replaces the existing member or element key of container
+func set(container interface{}, key string, value interface{}) (interface{}, error) {
+	switch node := container.(type) {
+	case map[string]interface{}:
+		node[key] = value
+		return node, nil
+	case []interface{}:
+		i, err := index(key, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = value
+		return node, nil
+	default:
+		return nil, fmt.Errorf("%s does not exist", key)
+	}
+}
+
+// index parses an array index up to max; leading zeros are not allowed
+func index(key string, max int) (int, error) {
+	if key == "" || (len(key) > 1 && key[0] == '0') || strings.TrimLeft(key, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not an array index", key)
+	}
+	i, err := strconv.Atoi(key)
+	if err != nil || i > max {
+		return 0, fmt.Errorf("index %s is out of range", key)
+	}
+	return i, nil
+}
+
+// clone deep-copies a decoded JSON value
+func clone(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for key, member := range node {
+			copied[key] = clone(member)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, element := range node {
+			copied[i] = clone(element)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// equal compares decoded JSON values the way test does: numbers by value,
+// objects regardless of member order
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, member := range x {
+			other, ok := y[key]
+			if !ok || !equal(member, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		xr, xok := new(big.Rat).SetString(x.String())
+		yr, yok := new(big.Rat).SetString(y.String())
+		return xok && yok && xr.Cmp(yr) == 0
+	default:
+		return a == b
+	}
+}
@@ -0,0 +1,322 @@
+package patch
+
+import (
+	"testing"
+
+	"go-clean-gin/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tree(t *testing.T, s string) interface{} {
+	t.Helper()
+
+	value, err := decode([]byte(s))
+	require.NoError(t, err, s)
+	return value
+}
+
+func assertCode(t *testing.T, code string, err error) {
+	t.Helper()
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, "expected an AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+// The examples of RFC 6902 Appendix A, and more of each operation
+func TestApplyOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{name: "A.1 add an object member", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux"}]`, want: `{"baz":"qux","foo":"bar"}`},
+		{name: "A.2 add an array element", doc: `{"foo":["bar","baz"]}`, patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`, want: `{"foo":["bar","qux","baz"]}`},
+		{name: "A.3 remove an object member", doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, want: `{"foo":"bar"}`},
+		{name: "A.4 remove an array element", doc: `{"foo":["bar","qux","baz"]}`, patch: `[{"op":"remove","path":"/foo/1"}]`, want: `{"foo":["bar","baz"]}`},
+		{name: "A.5 replace a value", doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"replace","path":"/baz","value":"boo"}]`, want: `{"baz":"boo","foo":"bar"}`},
+		{
+			name:  "A.6 move a value",
+			doc:   `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{name: "A.7 move an array element", doc: `{"foo":["all","grass","cows","eat"]}`, patch: `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, want: `{"foo":["all","cows","eat","grass"]}`},
+		{
+			name:  "A.8 test a value",
+			doc:   `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			want:  `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{name: "A.10 add a nested member object", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, want: `{"foo":"bar","child":{"grandchild":{}}}`},
+		{name: "A.11 ignore unrecognized members", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux","xyz":123}]`, want: `{"foo":"bar","baz":"qux"}`},
+		{name: "A.14 ~ escape ordering", doc: `{"/":9,"~1":10}`, patch: `[{"op":"test","path":"/~01","value":10}]`, want: `{"/":9,"~1":10}`},
+		{name: "A.16 add an array value", doc: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, want: `{"foo":["bar",["abc","def"]]}`},
+
+		{name: "add replaces an existing member", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/foo","value":"baz"}]`, want: `{"foo":"baz"}`},
+		{name: "add at the end index", doc: `{"foo":["a"]}`, patch: `[{"op":"add","path":"/foo/1","value":"b"}]`, want: `{"foo":["a","b"]}`},
+		{name: "add null", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":null}]`, want: `{"foo":"bar","baz":null}`},
+		{name: "add the whole document", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"","value":{"baz":1}}]`, want: `{"baz":1}`},
+		{name: "~1 is a slash", doc: `{"a/b":1}`, patch: `[{"op":"replace","path":"/a~1b","value":2}]`, want: `{"a/b":2}`},
+		{name: "~0 is a tilde", doc: `{"a~b":1}`, patch: `[{"op":"remove","path":"/a~0b"}]`, want: `{}`},
+		{name: "empty member name", doc: `{"":1}`, patch: `[{"op":"replace","path":"/","value":2}]`, want: `{"":2}`},
+		{name: "replace an array element", doc: `{"foo":["a","b"]}`, patch: `[{"op":"replace","path":"/foo/1","value":"c"}]`, want: `{"foo":["a","c"]}`},
+		{name: "replace the whole document", doc: `{"foo":"bar"}`, patch: `[{"op":"replace","path":"","value":{"baz":1}}]`, want: `{"baz":1}`},
+		{name: "move to the same path", doc: `{"foo":1}`, patch: `[{"op":"move","from":"/foo","path":"/foo"}]`, want: `{"foo":1}`},
+		{name: "move out of a child", doc: `{"foo":{"bar":1}}`, patch: `[{"op":"move","from":"/foo/bar","path":"/bar"}]`, want: `{"foo":{},"bar":1}`},
+		{name: "copy a value", doc: `{"foo":{"bar":1}}`, patch: `[{"op":"copy","from":"/foo","path":"/baz"}]`, want: `{"foo":{"bar":1},"baz":{"bar":1}}`},
+		{
+			name:  "copy is deep",
+			doc:   `{"foo":{"bar":1}}`,
+			patch: `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`,
+			want:  `{"foo":{"bar":1},"baz":{"bar":2}}`,
+		},
+		{name: "copy into an array", doc: `{"foo":["a"],"bar":"b"}`, patch: `[{"op":"copy","from":"/bar","path":"/foo/0"}]`, want: `{"foo":["b","a"],"bar":"b"}`},
+		{name: "test numbers by value", doc: `{"price":1.50}`, patch: `[{"op":"test","path":"/price","value":1.5}]`, want: `{"price":1.50}`},
+		{name: "test objects regardless of order", doc: `{"foo":{"a":1,"b":2}}`, patch: `[{"op":"test","path":"/foo","value":{"b":2,"a":1}}]`, want: `{"foo":{"a":1,"b":2}}`},
+		{name: "test null", doc: `{"foo":null}`, patch: `[{"op":"test","path":"/foo","value":null}]`, want: `{"foo":null}`},
+		{name: "no operations", doc: `{"foo":"bar"}`, patch: `[]`, want: `{"foo":"bar"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyOperations(tree(t, tt.doc), []byte(tt.patch))
+
+			require.NoError(t, err)
+			assert.Equal(t, tree(t, tt.want), got)
+		})
+	}
+}
+
+func TestApplyOperations_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		code  string
+	}{
+		{name: "A.9 test fails", doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, code: errors.ErrPatchTestFailed},
+		{name: "A.12 add to a missing parent", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz/bat","value":"qux"}]`, code: errors.ErrPatchInvalid},
+		{name: "A.13 duplicate op member", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux","op":"remove"}]`, code: errors.ErrPatchInvalid},
+		{name: "A.15 test compares types", doc: `{"/":9,"~1":10}`, patch: `[{"op":"test","path":"/~01","value":"10"}]`, code: errors.ErrPatchTestFailed},
+		{name: "test a missing member", doc: `{}`, patch: `[{"op":"test","path":"/foo","value":null}]`, code: errors.ErrPatchInvalid},
+		{name: "test numbers differ", doc: `{"price":1.5}`, patch: `[{"op":"test","path":"/price","value":1.51}]`, code: errors.ErrPatchTestFailed},
+
+		{name: "not JSON", doc: `{}`, patch: `[{`, code: errors.ErrPatchInvalid},
+		{name: "not an array", doc: `{}`, patch: `{"op":"add","path":"/a","value":1}`, code: errors.ErrPatchInvalid},
+		{name: "operation not an object", doc: `{}`, patch: `["add"]`, code: errors.ErrPatchInvalid},
+		{name: "unknown op", doc: `{}`, patch: `[{"op":"delete","path":"/a"}]`, code: errors.ErrPatchInvalid},
+		{name: "missing op", doc: `{}`, patch: `[{"path":"/a"}]`, code: errors.ErrPatchInvalid},
+		{name: "missing path", doc: `{}`, patch: `[{"op":"add","value":1}]`, code: errors.ErrPatchInvalid},
+		{name: "path without slash", doc: `{"a":1}`, patch: `[{"op":"remove","path":"a"}]`, code: errors.ErrPatchInvalid},
+		{name: "add without value", doc: `{}`, patch: `[{"op":"add","path":"/a"}]`, code: errors.ErrPatchInvalid},
+		{name: "replace without value", doc: `{"a":1}`, patch: `[{"op":"replace","path":"/a"}]`, code: errors.ErrPatchInvalid},
+		{name: "test without value", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a"}]`, code: errors.ErrPatchInvalid},
+		{name: "move without from", doc: `{"a":1}`, patch: `[{"op":"move","path":"/b"}]`, code: errors.ErrPatchInvalid},
+		{name: "copy without from", doc: `{"a":1}`, patch: `[{"op":"copy","path":"/b"}]`, code: errors.ErrPatchInvalid},
+
+		{name: "remove a missing member", doc: `{}`, patch: `[{"op":"remove","path":"/a"}]`, code: errors.ErrPatchInvalid},
+		{name: "remove the whole document", doc: `{}`, patch: `[{"op":"remove","path":""}]`, code: errors.ErrPatchInvalid},
+		{name: "replace a missing member", doc: `{}`, patch: `[{"op":"replace","path":"/a","value":1}]`, code: errors.ErrPatchInvalid},
+		{name: "move a missing member", doc: `{}`, patch: `[{"op":"move","from":"/a","path":"/b"}]`, code: errors.ErrPatchInvalid},
+		{name: "copy a missing member", doc: `{}`, patch: `[{"op":"copy","from":"/a","path":"/b"}]`, code: errors.ErrPatchInvalid},
+		{name: "add under a scalar", doc: `{"a":1}`, patch: `[{"op":"add","path":"/a/b","value":1}]`, code: errors.ErrPatchInvalid},
+
+		{name: "move into its own child", doc: `{"a":{"b":{}}}`, patch: `[{"op":"move","from":"/a","path":"/a/b/c"}]`, code: errors.ErrPatchInvalid},
+		{name: "move into its direct child", doc: `{"a":{}}`, patch: `[{"op":"move","from":"/a","path":"/a/b"}]`, code: errors.ErrPatchInvalid},
+
+		{name: "add past the end", doc: `{"a":["x"]}`, patch: `[{"op":"add","path":"/a/2","value":"y"}]`, code: errors.ErrPatchInvalid},
+		{name: "remove past the end", doc: `{"a":["x"]}`, patch: `[{"op":"remove","path":"/a/1"}]`, code: errors.ErrPatchInvalid},
+		{name: "replace past the end", doc: `{"a":["x"]}`, patch: `[{"op":"replace","path":"/a/1","value":"y"}]`, code: errors.ErrPatchInvalid},
+		{name: "test past the end", doc: `{"a":["x"]}`, patch: `[{"op":"test","path":"/a/1","value":"x"}]`, code: errors.ErrPatchInvalid},
+		{name: "remove the append index", doc: `{"a":["x"]}`, patch: `[{"op":"remove","path":"/a/-"}]`, code: errors.ErrPatchInvalid},
+		{name: "replace the append index", doc: `{"a":["x"]}`, patch: `[{"op":"replace","path":"/a/-","value":"y"}]`, code: errors.ErrPatchInvalid},
+		{name: "negative index", doc: `{"a":["x"]}`, patch: `[{"op":"add","path":"/a/-1","value":"y"}]`, code: errors.ErrPatchInvalid},
+		{name: "leading zero", doc: `{"a":["x","y"]}`, patch: `[{"op":"remove","path":"/a/01"}]`, code: errors.ErrPatchInvalid},
+		{name: "index not a number", doc: `{"a":["x"]}`, patch: `[{"op":"remove","path":"/a/first"}]`, code: errors.ErrPatchInvalid},
+		{name: "empty index", doc: `{"a":["x"]}`, patch: `[{"op":"remove","path":"/a/"}]`, code: errors.ErrPatchInvalid},
+		{name: "huge index", doc: `{"a":["x"]}`, patch: `[{"op":"remove","path":"/a/99999999999999999999"}]`, code: errors.ErrPatchInvalid},
+
+		{
+			name:  "later operation fails the patch",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"/a","value":2},{"op":"test","path":"/a","value":1}]`,
+			code:  errors.ErrPatchTestFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := applyOperations(tree(t, tt.doc), []byte(tt.patch))
+
+			assertCode(t, tt.code, err)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	// The examples of RFC 7386 Appendix A
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{target: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{target: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{target: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{target: `{"a":["b"]}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{target: `{"a":"c"}`, patch: `{"a":["b"]}`, want: `{"a":["b"]}`},
+		{target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{target: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, want: `{"a":[1]}`},
+		{target: `["a","b"]`, patch: `["c","d"]`, want: `["c","d"]`},
+		{target: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{target: `{"a":"foo"}`, patch: `null`, want: `null`},
+		{target: `{"a":"foo"}`, patch: `"bar"`, want: `"bar"`},
+		{target: `{"e":null}`, patch: `{"a":1}`, want: `{"e":null,"a":1}`},
+		{target: `[1,2]`, patch: `{"a":"b","c":null}`, want: `{"a":"b"}`},
+		{target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, want: `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.patch, func(t *testing.T) {
+			assert.Equal(t, tree(t, tt.want), merge(tree(t, tt.target), tree(t, tt.patch)))
+		})
+	}
+}
+
+// updateRequest stands for an update request of a resource
+type updateRequest struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Price       float64  `json:"price"`
+	Tags        []string `json:"tags"`
+}
+
+func current() updateRequest {
+	description := "A mug"
+	return updateRequest{Name: "Mug", Description: &description, Price: 9.5, Tags: []string{"kitchen"}}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	description := "A mug"
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        updateRequest
+	}{
+		{
+			name:        "merge patch",
+			contentType: MergePatch,
+			body:        `{"name":"Cup","tags":["kitchen","sale"]}`,
+			want:        updateRequest{Name: "Cup", Description: &description, Price: 9.5, Tags: []string{"kitchen", "sale"}},
+		},
+		{
+			name:        "merge patch with charset",
+			contentType: MergePatch + "; charset=utf-8",
+			body:        `{"price":12}`,
+			want:        updateRequest{Name: "Mug", Description: &description, Price: 12, Tags: []string{"kitchen"}},
+		},
+		{
+			name:        "JSON Patch",
+			contentType: JSONPatch,
+			body:        `[{"op":"test","path":"/name","value":"Mug"},{"op":"add","path":"/tags/-","value":"sale"},{"op":"replace","path":"/price","value":12}]`,
+			want:        updateRequest{Name: "Mug", Description: &description, Price: 12, Tags: []string{"kitchen", "sale"}},
+		},
+		{
+			name:        "empty merge patch",
+			contentType: MergePatch,
+			body:        `{}`,
+			want:        current(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got updateRequest
+			require.NoError(t, Apply(tt.contentType, current(), []byte(tt.body), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		code        string
+	}{
+		{name: "plain JSON", contentType: "application/json", body: `{"name":"Cup"}`, code: errors.ErrUnsupportedMediaType},
+		{name: "no content type", contentType: "", body: `{"name":"Cup"}`, code: errors.ErrUnsupportedMediaType},
+
+		{name: "merge patch removes a field", contentType: MergePatch, body: `{"description":null}`, code: errors.ErrPatchInvalid},
+		{name: "JSON Patch removes a field", contentType: JSONPatch, body: `[{"op":"remove","path":"/description"}]`, code: errors.ErrPatchInvalid},
+		{name: "JSON Patch sets a field to null", contentType: JSONPatch, body: `[{"op":"replace","path":"/name","value":null}]`, code: errors.ErrPatchInvalid},
+		{name: "JSON Patch moves a field away", contentType: JSONPatch, body: `[{"op":"move","from":"/name","path":"/title"}]`, code: errors.ErrPatchInvalid},
+
+		{name: "merge patch adds an unknown field", contentType: MergePatch, body: `{"owner_id":"x"}`, code: errors.ErrPatchInvalid},
+		{name: "JSON Patch adds an unknown field", contentType: JSONPatch, body: `[{"op":"add","path":"/owner_id","value":"x"}]`, code: errors.ErrPatchInvalid},
+		{name: "wrong type", contentType: MergePatch, body: `{"price":"free"}`, code: errors.ErrPatchInvalid},
+		{name: "merge patch not JSON", contentType: MergePatch, body: `{"name":`, code: errors.ErrPatchInvalid},
+		{name: "merge patch trailing data", contentType: MergePatch, body: `{"name":"Cup"} {}`, code: errors.ErrPatchInvalid},
+		{name: "merge patch replaces the document", contentType: MergePatch, body: `["Cup"]`, code: errors.ErrPatchInvalid},
+		{name: "merge patch null", contentType: MergePatch, body: `null`, code: errors.ErrPatchInvalid},
+		{name: "JSON Patch test fails", contentType: JSONPatch, body: `[{"op":"test","path":"/name","value":"Cup"}]`, code: errors.ErrPatchTestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got updateRequest
+			err := Apply(tt.contentType, current(), []byte(tt.body), &got)
+
+			assertCode(t, tt.code, err)
+		})
+	}
+}
+
+// Fields the current document leaves out may still be added
+func TestApply_AddsOmittedField(t *testing.T) {
+	t.Parallel()
+
+	doc := updateRequest{Name: "Mug", Tags: []string{}}
+	var got updateRequest
+
+	require.NoError(t, Apply(MergePatch, doc, []byte(`{"description":"A mug"}`), &got))
+	require.NotNil(t, got.Description)
+	assert.Equal(t, "A mug", *got.Description)
+}
+
+func TestApply_StatusCodes(t *testing.T) {
+	t.Parallel()
+
+	var got updateRequest
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{err: Apply("text/plain", current(), []byte(`{}`), &got), status: 415},
+		{err: Apply(JSONPatch, current(), []byte(`[{"op":"test","path":"/name","value":"Cup"}]`), &got), status: 409},
+		{err: Apply(MergePatch, current(), []byte(`{"name":null}`), &got), status: 400},
+	}
+
+	for _, tt := range tests {
+		appErr, ok := tt.err.(*errors.AppError)
+		require.True(t, ok)
+		assert.Equal(t, tt.status, appErr.StatusCode)
+	}
+}
@@ -0,0 +1,35 @@
+// pkg/policy/policy.go - Authorization decisions kept out of usecases
+package policy
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Ability is a policy method deciding whether the user may act on the
+// resource, such as ProductPolicy.CanUpdate. Its error is a failure to
+// decide, not a refusal.
+//
+// Policies live with the package of their resource, one type per entity with
+// a Can<Action> method per action:
+//
+//	func (p *OrderPolicy) CanUpdate(ctx context.Context, userID uuid.UUID, order *entity.Order) (bool, error)
+type Ability[T any] func(ctx context.Context, userID uuid.UUID, resource T) (bool, error)
+
+// Gate asks the ability whether the user may act on the resource, returning
+// nil when it allows it and denied when it doesn't
+//
+//	if err := policy.Gate(ctx, u.policy.CanDelete, userID, order, errors.ErrForbiddenError); err != nil {
+//		return err
+//	}
+func Gate[T any](ctx context.Context, ability Ability[T], userID uuid.UUID, resource T, denied error) error {
+	allowed, err := ability(ctx, userID, resource)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return denied
+	}
+	return nil
+}
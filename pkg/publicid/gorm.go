@@ -0,0 +1,210 @@
+// pkg/publicid/gorm.go - Public ID generation on insert, resolution and backfill
+package publicid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// TagSetting marks the public ID field of a model with its entity name:
+//
+//	PublicID *string `json:"public_id,omitempty" gorm:"size:32;uniqueIndex;publicid:product"`
+//
+// The field is a pointer so rows of entities without a spec store NULL,
+// which the unique index allows any number of.
+const TagSetting = "PUBLICID"
+
+// backfillBatch is how many rows Backfill fills per query
+const backfillBatch = 500
+
+// ErrNotFound is returned by Resolve for public IDs no row has
+var ErrNotFound = errors.New("publicid: not found")
+
+// Register installs a callback that gives new rows of configured entities a
+// public ID, unless they already have one
+func Register(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register("publicid:generate", generate)
+}
+
+func generate(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	field, entity := fieldOf(db.Statement.Schema)
+	if field == nil {
+		return
+	}
+	spec, ok := For(entity)
+	if !ok {
+		return
+	}
+
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			setID(db, field, spec, reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		setID(db, field, spec, value)
+	}
+}
+
+func setID(db *gorm.DB, field *schema.Field, spec Spec, value reflect.Value) {
+	if _, zero := field.ValueOf(db.Statement.Context, value); !zero {
+		return
+	}
+	id, err := spec.New()
+	if err == nil {
+		err = field.Set(db.Statement.Context, value, &id)
+	}
+	if err != nil {
+		db.AddError(err)
+	}
+}
+
+// fieldOf returns the public ID field of a model and its entity name
+func fieldOf(s *schema.Schema) (*schema.Field, string) {
+	for _, field := range s.Fields {
+		if entity := field.TagSettings[TagSetting]; entity != "" {
+			return field, entity
+		}
+	}
+	return nil, ""
+}
+
+type table struct {
+	name string
+	pk   string
+	col  string
+}
+
+// Resolver maps public IDs in URLs back to the primary keys of their rows
+type Resolver struct {
+	db     *gorm.DB
+	tables map[string]table
+}
+
+// NewResolver returns a resolver for the models, which must have a uuid
+// primary key and a field with the publicid tag setting
+func NewResolver(db *gorm.DB, models ...interface{}) (*Resolver, error) {
+	r := &Resolver{db: db, tables: make(map[string]table, len(models))}
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		field, entity := fieldOf(stmt.Schema)
+		if field == nil {
+			return nil, fmt.Errorf("%s has no field with the publicid tag setting", stmt.Schema.Table)
+		}
+		if len(stmt.Schema.PrimaryFields) != 1 {
+			return nil, fmt.Errorf("%s: public IDs need a single-column primary key", stmt.Schema.Table)
+		}
+		r.tables[entity] = table{name: stmt.Schema.Table, pk: stmt.Schema.PrimaryFields[0].DBName, col: field.DBName}
+	}
+	return r, nil
+}
+
+// Resolve returns the primary key of the entity row value names. UUIDs are
+// returned as they are, so URLs written before public IDs keep working.
+// ok is false when value is neither a UUID nor shaped like a public ID of the
+// entity; ErrNotFound is returned for public IDs no row has.
+func (r *Resolver) Resolve(ctx context.Context, entity, value string) (id uuid.UUID, ok bool, err error) {
+	if id, err := uuid.Parse(value); err == nil {
+		return id, true, nil
+	}
+
+	t, known := r.tables[entity]
+	if !known {
+		return uuid.Nil, false, nil
+	}
+	// Without a spec the entity's IDs may still be looked up, as long as they
+	// fit the column
+	if spec, configured := For(entity); configured && !spec.Matches(value) {
+		return uuid.Nil, false, nil
+	}
+	if len(value) > MaxLength {
+		return uuid.Nil, false, nil
+	}
+
+	var ids []uuid.UUID
+	err = tenancy.Conn(ctx, r.db).Table(t.name).
+		Where(clause.Eq{Column: clause.Column{Name: t.col}, Value: value}).
+		Limit(1).
+		Pluck(t.pk, &ids).Error
+	if err != nil {
+		return uuid.Nil, true, err
+	}
+	if len(ids) == 0 {
+		return uuid.Nil, true, ErrNotFound
+	}
+	return ids[0], true, nil
+}
+
+// Backfill gives public IDs to the rows of the models' configured entities
+// created before they had one, and returns how many it filled. Rows are
+// updated one at a time, so it can run while the app serves traffic.
+func Backfill(ctx context.Context, db *gorm.DB, models ...interface{}) (int64, error) {
+	var filled int64
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return filled, err
+		}
+		field, entity := fieldOf(stmt.Schema)
+		if field == nil {
+			return filled, fmt.Errorf("%s has no field with the publicid tag setting", stmt.Schema.Table)
+		}
+		spec, ok := For(entity)
+		if !ok {
+			continue
+		}
+
+		n, err := backfillTable(ctx, db, spec, stmt.Schema.Table, stmt.Schema.PrimaryFields[0].DBName, field.DBName)
+		filled += n
+		if err != nil {
+			return filled, fmt.Errorf("%s: %w", stmt.Schema.Table, err)
+		}
+	}
+	return filled, nil
+}
+
+func backfillTable(ctx context.Context, db *gorm.DB, spec Spec, name, pk, column string) (int64, error) {
+	var filled int64
+	for {
+		var ids []string
+		err := db.WithContext(ctx).Table(name).
+			Where(clause.Eq{Column: clause.Column{Name: column}, Value: nil}).
+			Limit(backfillBatch).
+			Pluck(fmt.Sprintf("CAST(%s AS text)", db.Statement.Quote(pk)), &ids).Error
+		if err != nil || len(ids) == 0 {
+			return filled, err
+		}
+
+		for _, id := range ids {
+			publicID, err := spec.New()
+			if err != nil {
+				return filled, err
+			}
+			// Only fill rows still without one, in case the app gave it one since
+			result := db.WithContext(ctx).Table(name).
+				Where(clause.Eq{Column: clause.Column{Name: pk}, Value: id}).
+				Where(clause.Eq{Column: clause.Column{Name: column}, Value: nil}).
+				Update(column, publicID)
+			if result.Error != nil {
+				return filled, fmt.Errorf("row %s: %w", id, result.Error)
+			}
+			filled += result.RowsAffected
+		}
+	}
+}
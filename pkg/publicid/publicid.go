@@ -0,0 +1,118 @@
+// pkg/publicid/publicid.go - Short public identifiers for API URLs
+package publicid
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// alphabet is the NanoID alphabet without - and _, so IDs can be selected
+// with a double click and never need escaping
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MinLength is the shortest public ID allowed; 12 characters of this alphabet
+// give about 71 bits, enough to not collide before billions of rows
+const MinLength = 12
+
+// MaxLength is the size of the public_id columns, prefix included
+const MaxLength = 32
+
+// Spec describes the public IDs of one entity, e.g. prefix "prd_" and length
+// 12 gives prd_4fQ2x9KbLm0Z. The prefix tells apart the IDs of entities.
+type Spec struct {
+	Prefix string
+	Length int
+}
+
+// New returns a random public ID of the spec
+func (s Spec) New() (string, error) {
+	// 62 characters fit in 6 bits; bytes that map past the alphabet are
+	// skipped so every character is equally likely
+	n := len(s.Prefix) + s.Length
+	id := append(make([]byte, 0, n), s.Prefix...)
+	buf := make([]byte, s.Length*2)
+	for len(id) < n {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if i := int(b & 63); i < len(alphabet) && len(id) < n {
+				id = append(id, alphabet[i])
+			}
+		}
+	}
+	return string(id), nil
+}
+
+// Matches reports whether value has the form of a public ID of the spec
+func (s Spec) Matches(value string) bool {
+	rest, ok := strings.CutPrefix(value, s.Prefix)
+	if !ok || len(rest) != s.Length {
+		return false
+	}
+	for i := 0; i < len(rest); i++ {
+		if strings.IndexByte(alphabet, rest[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseSpecs reads "entity:prefix:length" entries, e.g. "product:prd_:12",
+// into specs by entity name. The single entry "none" gives no entity public
+// IDs.
+func ParseSpecs(entries []string) (map[string]Spec, error) {
+	specs := make(map[string]Spec, len(entries))
+	if len(entries) == 1 && strings.TrimSpace(entries[0]) == "none" {
+		return specs, nil
+	}
+	for _, entry := range entries {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("public ID %q must look like entity:prefix:length", entry)
+		}
+		entity, prefix := parts[0], parts[1]
+		if _, dup := specs[entity]; dup {
+			return nil, fmt.Errorf("public ID of %q is listed twice", entity)
+		}
+
+		length, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("public ID of %q: invalid length %q", entity, parts[2])
+		}
+		if length < MinLength || len(prefix)+length > MaxLength {
+			return nil, fmt.Errorf("public ID of %q must be %d to %d characters with its prefix", entity, MinLength, MaxLength)
+		}
+		for i := 0; i < len(prefix); i++ {
+			if strings.IndexByte(alphabet+"_-", prefix[i]) < 0 {
+				return nil, fmt.Errorf("public ID of %q: prefix may only use letters, digits, _ and -", entity)
+			}
+		}
+		specs[entity] = Spec{Prefix: prefix, Length: length}
+	}
+	return specs, nil
+}
+
+var (
+	mu    sync.RWMutex
+	specs = map[string]Spec{}
+)
+
+// Configure sets the entities that get public IDs. Rows of other entities are
+// created without one, but public IDs they already have keep resolving.
+func Configure(s map[string]Spec) {
+	mu.Lock()
+	defer mu.Unlock()
+	specs = s
+}
+
+// For returns the spec of entity, and false when it gets no public IDs
+func For(entity string) (Spec, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	spec, ok := specs[entity]
+	return spec, ok
+}
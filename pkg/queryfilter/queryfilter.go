@@ -0,0 +1,224 @@
+// pkg/queryfilter/queryfilter.go - GORM conditions from tagged filter structs
+package queryfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Operators of the filter tag
+const (
+	OpEq    = "eq"    // column = value, the default
+	OpNe    = "ne"    // column <> value
+	OpGt    = "gt"    // column > value
+	OpGte   = "gte"   // column >= value
+	OpLt    = "lt"    // column < value
+	OpLte   = "lte"   // column <= value
+	OpLike  = "like"  // column LIKE %value%, case-sensitive
+	OpILike = "ilike" // column ILIKE %value%
+	OpIn    = "in"    // column IN values, from a slice or a comma-separated string
+	OpNull  = "null"  // column IS NULL when true, IS NOT NULL when false
+
+	OpContains = "contains" // JSONB column @> value encoded as JSON
+)
+
+var comparisons = map[string]string{
+	OpEq: "=", OpNe: "<>", OpGt: ">", OpGte: ">=", OpLt: "<", OpLte: "<=",
+	OpLike: "LIKE", OpILike: "ILIKE",
+}
+
+var columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// likeEscaper escapes the wildcards of LIKE, with Postgres' default escape
+// character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// condition is a filter tag parsed for a field
+type condition struct {
+	index   []int
+	columns []string
+	op      string
+}
+
+var cache sync.Map // reflect.Type -> []condition or error
+
+// Apply adds a condition to query for every field of filter, a struct or a
+// pointer to one, with a filter tag and a value set. The tag names the column
+// and the operator, eq by default:
+//
+//	type OrderFilter struct {
+//		Status   string            `form:"status" filter:"status"`
+//		MinTotal decimal.Decimal   `form:"min_total" filter:"total_amount,gte"`
+//		Statuses []string          `form:"statuses" filter:"status,in"`
+//		Search   string            `form:"search" filter:"number|note,ilike"`
+//		Pending  *bool             `form:"pending" filter:"shipped_at,null"`
+//		Labels   map[string]string `form:"-" filter:"labels,contains"`
+//	}
+//
+// Columns joined with | are matched with OR. A field is unset when it is a
+// nil pointer, empty or zero, or reports IsZero, so only what the request gave
+// filters. Fields of embedded structs are included; an invalid tag is added
+// to the query as an error.
+func Apply(query *gorm.DB, filter interface{}) *gorm.DB {
+	value := reflect.ValueOf(filter)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return query
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		query.AddError(fmt.Errorf("queryfilter: filter must be a struct, got %s", value.Kind()))
+		return query
+	}
+
+	conditions, err := parse(value.Type())
+	if err != nil {
+		query.AddError(err)
+		return query
+	}
+
+	for _, cond := range conditions {
+		field := value.FieldByIndex(cond.index)
+		if isUnset(field) {
+			continue
+		}
+		for field.Kind() == reflect.Ptr {
+			field = field.Elem()
+		}
+		query = cond.apply(query, field)
+	}
+	return query
+}
+
+func (c condition) apply(query *gorm.DB, field reflect.Value) *gorm.DB {
+	switch c.op {
+	case OpNull:
+		if field.Kind() != reflect.Bool {
+			query.AddError(fmt.Errorf("queryfilter: null filter on %s needs a bool", strings.Join(c.columns, "|")))
+			return query
+		}
+		check := "IS NOT NULL"
+		if field.Bool() {
+			check = "IS NULL"
+		}
+		return query.Where(c.join(func(column string) string { return column + " " + check }))
+
+	case OpIn:
+		values := field.Interface()
+		if field.Kind() == reflect.String {
+			values = strings.Split(field.String(), ",")
+		}
+		return query.Where(c.join(func(column string) string { return column + " IN ?" }), c.args(values)...)
+
+	case OpContains:
+		document, err := json.Marshal(field.Interface())
+		if err != nil {
+			query.AddError(fmt.Errorf("queryfilter: contains filter on %s: %w", strings.Join(c.columns, "|"), err))
+			return query
+		}
+		return query.Where(c.join(func(column string) string { return column + " @> ?" }), c.args(string(document))...)
+
+	case OpLike, OpILike:
+		pattern := "%" + likeEscaper.Replace(fmt.Sprint(field.Interface())) + "%"
+		return query.Where(c.join(func(column string) string { return column + " " + comparisons[c.op] + " ?" }), c.args(pattern)...)
+	}
+
+	return query.Where(c.join(func(column string) string { return column + " " + comparisons[c.op] + " ?" }), c.args(field.Interface())...)
+}
+
+// join ORs the condition of each column, which Where puts in parentheses
+func (c condition) join(clause func(column string) string) string {
+	clauses := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		clauses[i] = clause(column)
+	}
+	return strings.Join(clauses, " OR ")
+}
+
+// args repeats the value for each column
+func (c condition) args(value interface{}) []interface{} {
+	args := make([]interface{}, len(c.columns))
+	for i := range args {
+		args[i] = value
+	}
+	return args
+}
+
+// isUnset reports whether a filter field was left out of the request
+func isUnset(field reflect.Value) bool {
+	if field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		return field.IsNil()
+	}
+	if zeroer, ok := field.Interface().(interface{ IsZero() bool }); ok {
+		return zeroer.IsZero()
+	}
+	if field.Kind() == reflect.Slice || field.Kind() == reflect.Map {
+		return field.Len() == 0
+	}
+	return field.IsZero()
+}
+
+// parse reads the filter tags of a struct type, once
+func parse(typ reflect.Type) ([]condition, error) {
+	if cached, ok := cache.Load(typ); ok {
+		if err, ok := cached.(error); ok {
+			return nil, err
+		}
+		return cached.([]condition), nil
+	}
+
+	conditions, err := parseFields(typ, nil)
+	if err != nil {
+		cache.Store(typ, err)
+		return nil, err
+	}
+	cache.Store(typ, conditions)
+	return conditions, nil
+}
+
+func parseFields(typ reflect.Type, index []int) ([]condition, error) {
+	var conditions []condition
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		tag, ok := field.Tag.Lookup("filter")
+		if !ok {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				embedded, err := parseFields(field.Type, fieldIndex)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, embedded...)
+			}
+			continue
+		}
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		columns, op, _ := strings.Cut(tag, ",")
+		if op == "" {
+			op = OpEq
+		}
+		if _, ok := comparisons[op]; !ok && op != OpIn && op != OpNull && op != OpContains {
+			return nil, fmt.Errorf("queryfilter: unknown operator %q on %s.%s", op, typ.Name(), field.Name)
+		}
+
+		cond := condition{index: fieldIndex, columns: strings.Split(columns, "|"), op: op}
+		for _, column := range cond.columns {
+			if !columnPattern.MatchString(column) {
+				return nil, fmt.Errorf("queryfilter: invalid column %q on %s.%s", column, typ.Name(), field.Name)
+			}
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions, nil
+}
@@ -0,0 +1,291 @@
+package queryfilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type order struct {
+	ID int
+}
+
+func (order) TableName() string { return "orders" }
+
+type ownerFilter struct {
+	Owner string `filter:"orders.owner_id"`
+}
+
+type orderFilter struct {
+	ownerFilter
+	Status   string            `form:"status" filter:"status"`
+	NotState string            `form:"not_state" filter:"state,ne"`
+	MinTotal decimal.Decimal   `form:"min_total" filter:"total_amount,gte"`
+	MaxTotal *decimal.Decimal  `form:"max_total" filter:"total_amount,lte"`
+	After    time.Time         `form:"after" filter:"created_at,gt"`
+	Before   *time.Time        `form:"before" filter:"created_at,lt"`
+	Statuses []string          `form:"statuses" filter:"status,in"`
+	IDs      string            `form:"ids" filter:"id,in"`
+	Search   string            `form:"search" filter:"number|note,ilike"`
+	Code     string            `form:"code" filter:"code,like"`
+	Pending  *bool             `form:"pending" filter:"shipped_at,null"`
+	Labels   map[string]string `form:"-" filter:"labels,contains"`
+	Ignored  string            `form:"ignored" filter:"-"`
+	Untagged string            `form:"untagged"`
+}
+
+func newDryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+// query returns the SQL and arguments of a query for orders filtered by filter
+func query(t *testing.T, filter interface{}) (string, []interface{}, error) {
+	t.Helper()
+
+	var orders []order
+	stmt := Apply(newDryRun(t).Model(&order{}), filter).Find(&orders)
+	return stmt.Statement.SQL.String(), stmt.Statement.Vars, stmt.Error
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	pending, notPending := true, false
+	maxTotal := decimal.RequireFromString("100")
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter orderFilter
+		where  string
+		args   []interface{}
+	}{
+		{
+			name:  "no filters",
+			where: `SELECT * FROM "orders"`,
+		},
+		{
+			name:   "eq",
+			filter: orderFilter{Status: "paid"},
+			where:  `SELECT * FROM "orders" WHERE status = $1`,
+			args:   []interface{}{"paid"},
+		},
+		{
+			name:   "ne",
+			filter: orderFilter{NotState: "void"},
+			where:  `SELECT * FROM "orders" WHERE state <> $1`,
+			args:   []interface{}{"void"},
+		},
+		{
+			name:   "range",
+			filter: orderFilter{MinTotal: decimal.RequireFromString("10"), MaxTotal: &maxTotal},
+			where:  `SELECT * FROM "orders" WHERE total_amount >= $1 AND total_amount <= $2`,
+			args:   []interface{}{decimal.RequireFromString("10"), maxTotal},
+		},
+		{
+			name:   "time range",
+			filter: orderFilter{After: after, Before: &after},
+			where:  `SELECT * FROM "orders" WHERE created_at > $1 AND created_at < $2`,
+			args:   []interface{}{after, after},
+		},
+		{
+			name:   "in from a slice",
+			filter: orderFilter{Statuses: []string{"paid", "shipped"}},
+			where:  `SELECT * FROM "orders" WHERE status IN ($1,$2)`,
+			args:   []interface{}{"paid", "shipped"},
+		},
+		{
+			name:   "in from a comma-separated string",
+			filter: orderFilter{IDs: "1,2,3"},
+			where:  `SELECT * FROM "orders" WHERE id IN ($1,$2,$3)`,
+			args:   []interface{}{"1", "2", "3"},
+		},
+		{
+			name:   "ilike across columns",
+			filter: orderFilter{Search: "ab"},
+			where:  `SELECT * FROM "orders" WHERE number ILIKE $1 OR note ILIKE $2`,
+			args:   []interface{}{"%ab%", "%ab%"},
+		},
+		{
+			name:   "like escapes wildcards",
+			filter: orderFilter{Code: `50%_off\`},
+			where:  `SELECT * FROM "orders" WHERE code LIKE $1`,
+			args:   []interface{}{`%50\%\_off\\%`},
+		},
+		{
+			name:   "null",
+			filter: orderFilter{Pending: &pending},
+			where:  `SELECT * FROM "orders" WHERE shipped_at IS NULL`,
+		},
+		{
+			name:   "not null",
+			filter: orderFilter{Pending: &notPending},
+			where:  `SELECT * FROM "orders" WHERE shipped_at IS NOT NULL`,
+		},
+		{
+			name:   "contains",
+			filter: orderFilter{Labels: map[string]string{"channel": "web"}},
+			where:  `SELECT * FROM "orders" WHERE labels @> $1`,
+			args:   []interface{}{`{"channel":"web"}`},
+		},
+		{
+			name:   "embedded struct",
+			filter: orderFilter{ownerFilter: ownerFilter{Owner: "u1"}},
+			where:  `SELECT * FROM "orders" WHERE orders.owner_id = $1`,
+			args:   []interface{}{"u1"},
+		},
+		{
+			name:   "fields without a filter tag are ignored",
+			filter: orderFilter{Ignored: "x", Untagged: "y"},
+			where:  `SELECT * FROM "orders"`,
+		},
+		{
+			name:   "conditions are ANDed",
+			filter: orderFilter{Status: "paid", Search: "ab"},
+			where:  `SELECT * FROM "orders" WHERE status = $1 AND (number ILIKE $2 OR note ILIKE $3)`,
+			args:   []interface{}{"paid", "%ab%", "%ab%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := query(t, &tt.filter)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.where, sql)
+			if tt.args == nil {
+				assert.Empty(t, args)
+			} else {
+				assert.Equal(t, tt.args, args)
+			}
+		})
+	}
+}
+
+// The columns of an OR filter stay grouped, so they cannot widen the
+// conditions the repository scopes the query with
+func TestApply_ORIsGrouped(t *testing.T) {
+	t.Parallel()
+
+	var orders []order
+	stmt := Apply(newDryRun(t).Model(&order{}).Where("user_id = ?", 7), &orderFilter{Search: "ab"}).Find(&orders)
+
+	require.NoError(t, stmt.Error)
+	assert.Equal(t, `SELECT * FROM "orders" WHERE user_id = $1 AND (number ILIKE $2 OR note ILIKE $3)`, stmt.Statement.SQL.String())
+}
+
+func TestApply_Invalid(t *testing.T) {
+	t.Parallel()
+
+	status := "paid"
+	tests := []struct {
+		name   string
+		filter interface{}
+		err    string
+	}{
+		{
+			name:   "not a struct",
+			filter: map[string]string{"status": "paid"},
+			err:    "filter must be a struct",
+		},
+		{
+			name: "unknown operator",
+			filter: struct {
+				Status string `filter:"status,between"`
+			}{Status: "paid"},
+			err: `unknown operator "between"`,
+		},
+		{
+			name: "null on a string",
+			filter: struct {
+				Shipped *string `filter:"shipped_at,null"`
+			}{Shipped: &status},
+			err: "null filter on shipped_at needs a bool",
+		},
+		{
+			name: "contains of an unencodable value",
+			filter: struct {
+				Labels map[string]interface{} `filter:"labels,contains"`
+			}{Labels: map[string]interface{}{"f": func() {}}},
+			err: "contains filter on labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := query(t, tt.filter)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestApply_NilFilter(t *testing.T) {
+	t.Parallel()
+
+	sql, args, err := query(t, (*orderFilter)(nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM "orders"`, sql)
+	assert.Empty(t, args)
+}
+
+// Columns go into the SQL as written, so anything but a plain or qualified
+// identifier is refused before it can reach the query
+func TestApply_Columns(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"status", "_private", "orders.status", "Orders2.total_amount"}
+	for _, column := range valid {
+		assert.True(t, columnPattern.MatchString(column), column)
+	}
+
+	invalid := []string{
+		"",
+		"status; DROP TABLE orders",
+		"status = 1 OR 1",
+		`"status"`,
+		"orders.status.extra",
+		"1status",
+		"lower(status)",
+		"status--",
+		"orders.",
+	}
+	for _, column := range invalid {
+		assert.False(t, columnPattern.MatchString(column), column)
+	}
+
+	_, _, err := query(t, struct {
+		Status string `filter:"status|status = status OR 1"`
+	}{Status: "paid"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid column "status = status OR 1"`)
+}
+
+// Invalid tags are cached like valid ones, so every use of the type fails
+func TestApply_CachesErrors(t *testing.T) {
+	t.Parallel()
+
+	type badFilter struct {
+		Status string `filter:"status,between"`
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _, err := query(t, badFilter{Status: "paid"})
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,139 @@
+// pkg/queue/array.go - In-memory queue driver for tests
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/tenancy"
+
+	"github.com/google/uuid"
+)
+
+// ArrayQueue keeps jobs in memory. Every push is recorded so tests can assert on it,
+// and pending jobs can still be processed by a Worker.
+type ArrayQueue struct {
+	mu          sync.Mutex
+	pushed      []Job
+	pending     []*Job
+	maxAttempts int
+}
+
+// NewArrayQueue creates an in-memory queue driver
+func NewArrayQueue(cfg *config.QueueConfig) *ArrayQueue {
+	return &ArrayQueue{maxAttempts: cfg.MaxAttempts}
+}
+
+func (q *ArrayQueue) Push(ctx context.Context, queueName, jobType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:          uuid.New(),
+		Queue:       queueName,
+		Type:        jobType,
+		Tenant:      tenancy.FromContext(ctx),
+		Payload:     string(data),
+		MaxAttempts: q.maxAttempts,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pushed = append(q.pushed, *job)
+	q.pending = append(q.pending, job)
+	return nil
+}
+
+func (q *ArrayQueue) Pop(ctx context.Context, queues []string) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	for _, queueName := range queues {
+		for _, job := range q.pending {
+			if job.Queue != queueName || job.FailedAt != nil || job.ReservedAt != nil || job.AvailableAt.After(now) {
+				continue
+			}
+
+			job.Attempts++
+			job.ReservedAt = &now
+
+			reserved := *job
+			return &reserved, nil
+		}
+	}
+	return nil, nil
+}
+
+func (q *ArrayQueue) Complete(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, pending := range q.pending {
+		if pending.ID == job.ID {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (q *ArrayQueue) Retry(ctx context.Context, job *Job, delay time.Duration, cause error) error {
+	return q.update(job.ID, func(pending *Job) {
+		pending.Attempts = job.Attempts
+		pending.ReservedAt = nil
+		pending.AvailableAt = time.Now().Add(delay)
+		pending.LastError = errorString(cause)
+	})
+}
+
+func (q *ArrayQueue) Fail(ctx context.Context, job *Job, cause error) error {
+	return q.update(job.ID, func(pending *Job) {
+		now := time.Now()
+		pending.ReservedAt = nil
+		pending.FailedAt = &now
+		pending.LastError = errorString(cause)
+	})
+}
+
+// Pushed returns a copy of every job pushed, in push order
+func (q *ArrayQueue) Pushed() []Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pushed := make([]Job, len(q.pushed))
+	copy(pushed, q.pushed)
+	return pushed
+}
+
+// Reset discards pushed and pending jobs
+func (q *ArrayQueue) Reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pushed = nil
+	q.pending = nil
+}
+
+func (q *ArrayQueue) update(id uuid.UUID, fn func(*Job)) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, pending := range q.pending {
+		if pending.ID == id {
+			fn(pending)
+			return nil
+		}
+	}
+	return fmt.Errorf("job %s not found", id)
+}
@@ -0,0 +1,74 @@
+// pkg/queue/assert.go - Test assertions for the array driver
+package queue
+
+import (
+	"strings"
+	"testing"
+)
+
+// AssertPushed fails the test unless a job of the given type was pushed and returns it.
+// Use Job.Unmarshal to check the payload.
+func AssertPushed(t testing.TB, q Queue, jobType string) Job {
+	t.Helper()
+
+	pushed := arrayQueue(t, q).Pushed()
+	for _, job := range pushed {
+		if job.Type == jobType {
+			return job
+		}
+	}
+
+	t.Fatalf("expected job %q to be pushed; pushed: %s", jobType, summary(pushed))
+	return Job{}
+}
+
+// AssertNotPushed fails the test if a job of the given type was pushed
+func AssertNotPushed(t testing.TB, q Queue, jobType string) {
+	t.Helper()
+
+	for _, job := range arrayQueue(t, q).Pushed() {
+		if job.Type == jobType {
+			t.Fatalf("expected job %q not to be pushed", jobType)
+		}
+	}
+}
+
+// AssertPushedCount fails the test unless exactly n jobs of the given type were pushed.
+// An empty jobType counts every job.
+func AssertPushedCount(t testing.TB, q Queue, jobType string, n int) {
+	t.Helper()
+
+	pushed := arrayQueue(t, q).Pushed()
+	count := 0
+	for _, job := range pushed {
+		if jobType == "" || job.Type == jobType {
+			count++
+		}
+	}
+
+	if count != n {
+		t.Fatalf("expected %d job(s) %q to be pushed, got %d; pushed: %s", n, jobType, count, summary(pushed))
+	}
+}
+
+func arrayQueue(t testing.TB, q Queue) *ArrayQueue {
+	t.Helper()
+
+	aq, ok := q.(*ArrayQueue)
+	if !ok {
+		t.Fatalf("queue assertions require the array driver, got %T", q)
+	}
+	return aq
+}
+
+func summary(pushed []Job) string {
+	if len(pushed) == 0 {
+		return "none"
+	}
+
+	types := make([]string, len(pushed))
+	for i, job := range pushed {
+		types[i] = job.Queue + ":" + job.Type
+	}
+	return strings.Join(types, ", ")
+}
@@ -0,0 +1,131 @@
+// pkg/queue/database.go - Postgres-backed queue driver
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/tenancy"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DatabaseQueue stores jobs in tb_jobs and reserves them with SKIP LOCKED,
+// so any number of workers can poll the same table safely.
+type DatabaseQueue struct {
+	db          *gorm.DB
+	maxAttempts int
+	retryAfter  time.Duration
+}
+
+// NewDatabaseQueue creates a database queue driver
+func NewDatabaseQueue(db *gorm.DB, cfg *config.QueueConfig) *DatabaseQueue {
+	return &DatabaseQueue{
+		db:          db,
+		maxAttempts: cfg.MaxAttempts,
+		retryAfter:  cfg.RetryAfter,
+	}
+}
+
+func (q *DatabaseQueue) Push(ctx context.Context, queueName, jobType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job := &Job{
+		Queue:       queueName,
+		Type:        jobType,
+		Tenant:      tenancy.FromContext(ctx),
+		Payload:     string(data),
+		MaxAttempts: q.maxAttempts,
+		AvailableAt: time.Now(),
+	}
+
+	return q.db.WithContext(ctx).Create(job).Error
+}
+
+func (q *DatabaseQueue) Pop(ctx context.Context, queues []string) (*Job, error) {
+	for _, queueName := range queues {
+		job, err := q.popFrom(ctx, queueName)
+		if err != nil || job != nil {
+			return job, err
+		}
+	}
+	return nil, nil
+}
+
+// popFrom reserves the oldest available job on a single queue
+func (q *DatabaseQueue) popFrom(ctx context.Context, queueName string) (*Job, error) {
+	var job Job
+
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("queue = ? AND failed_at IS NULL AND available_at <= ?", queueName, now).
+			Where("reserved_at IS NULL OR reserved_at <= ?", now.Add(-q.retryAfter)).
+			Order("available_at").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		job.Attempts++
+		job.ReservedAt = &now
+
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"attempts":    job.Attempts,
+			"reserved_at": now,
+		}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (q *DatabaseQueue) Complete(ctx context.Context, job *Job) error {
+	return q.db.WithContext(ctx).Delete(&Job{}, "id = ?", job.ID).Error
+}
+
+func (q *DatabaseQueue) Retry(ctx context.Context, job *Job, delay time.Duration, cause error) error {
+	return q.db.WithContext(ctx).Model(&Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+		"attempts":     job.Attempts,
+		"reserved_at":  nil,
+		"available_at": time.Now().Add(delay),
+		"last_error":   errorString(cause),
+	}).Error
+}
+
+func (q *DatabaseQueue) Fail(ctx context.Context, job *Job, cause error) error {
+	return q.db.WithContext(ctx).Model(&Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+		"reserved_at": nil,
+		"failed_at":   time.Now(),
+		"last_error":  errorString(cause),
+	}).Error
+}
+
+// PruneFailed deletes failed jobs older than the given age
+func (q *DatabaseQueue) PruneFailed(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result := q.db.WithContext(ctx).
+		Where("failed_at IS NOT NULL AND failed_at < ?", time.Now().Add(-olderThan)).
+		Delete(&Job{})
+	return result.RowsAffected, result.Error
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
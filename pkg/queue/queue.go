@@ -0,0 +1,65 @@
+// pkg/queue/queue.go - Background job queue
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-clean-gin/config"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Job is a unit of background work stored by a queue driver
+type Job struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Queue       string     `json:"queue" gorm:"not null;index:idx_tb_jobs_queue_available,priority:1"`
+	Type        string     `json:"type" gorm:"not null"`
+	Tenant      string     `json:"tenant" gorm:"not null;default:''"` // tenant the job was pushed for
+	Payload     string     `json:"payload" gorm:"type:jsonb;not null"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null;default:3"`
+	AvailableAt time.Time  `json:"available_at" gorm:"not null;index:idx_tb_jobs_queue_available,priority:2"`
+	ReservedAt  *time.Time `json:"reserved_at"`
+	FailedAt    *time.Time `json:"failed_at" gorm:"index"`
+	LastError   string     `json:"last_error" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (Job) TableName() string {
+	return "tb_jobs"
+}
+
+// Unmarshal decodes the job payload into v
+func (j *Job) Unmarshal(v interface{}) error {
+	return json.Unmarshal([]byte(j.Payload), v)
+}
+
+// Queue is implemented by every queue driver
+type Queue interface {
+	// Push enqueues a job for the tenant of ctx; payload is encoded as JSON
+	Push(ctx context.Context, queueName, jobType string, payload interface{}) error
+	// Pop reserves the next available job from the first non-empty queue, or returns nil
+	Pop(ctx context.Context, queues []string) (*Job, error)
+	// Complete removes a successfully processed job
+	Complete(ctx context.Context, job *Job) error
+	// Retry releases a job to be attempted again after delay, keeping its attempt count
+	Retry(ctx context.Context, job *Job, delay time.Duration, cause error) error
+	// Fail marks a job as permanently failed
+	Fail(ctx context.Context, job *Job, cause error) error
+}
+
+// New creates a queue for the configured driver
+func New(cfg *config.QueueConfig, db *gorm.DB) (Queue, error) {
+	switch cfg.Driver {
+	case "", "database":
+		return NewDatabaseQueue(db, cfg), nil
+	case "array":
+		return NewArrayQueue(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported queue driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,207 @@
+// pkg/queue/worker.go - Concurrent queue worker with graceful shutdown
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/metrics"
+
+	"go.uber.org/zap"
+)
+
+// Handler processes a single job. Returning an error retries the job
+// until its attempts are exhausted; returning Defer retries it without using
+// an attempt.
+type Handler func(ctx context.Context, job *Job) error
+
+// DeferError postpones a job whose dependency is temporarily unavailable
+type DeferError struct {
+	Delay time.Duration
+	Cause error
+}
+
+func (e *DeferError) Error() string {
+	return fmt.Sprintf("deferred for %s: %v", e.Delay, e.Cause)
+}
+
+func (e *DeferError) Unwrap() error {
+	return e.Cause
+}
+
+// Defer returns an error that releases the job to run again after delay
+// without counting the attempt, for outages that are not the job's fault
+func Defer(delay time.Duration, cause error) error {
+	return &DeferError{Delay: delay, Cause: cause}
+}
+
+// WorkerOptions configures a Worker
+type WorkerOptions struct {
+	Queues       []string      // queues to consume, in priority order
+	Concurrency  int           // number of jobs processed in parallel
+	PollInterval time.Duration // idle wait between polls when all queues are empty
+	Backoff      time.Duration // delay before a failed attempt is retried
+	Timeout      time.Duration // per-job timeout (0 = no timeout)
+}
+
+// FailedFunc is called with a job that failed its last attempt
+type FailedFunc func(ctx context.Context, job *Job, err error)
+
+// Worker pulls jobs from a queue and dispatches them to registered handlers
+type Worker struct {
+	queue    Queue
+	opts     WorkerOptions
+	handlers map[string]Handler
+	onFailed []FailedFunc
+}
+
+// NewWorker creates a worker for the given queue
+func NewWorker(q Queue, opts WorkerOptions) *Worker {
+	if len(opts.Queues) == 0 {
+		opts.Queues = []string{"default"}
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+
+	return &Worker{
+		queue:    q,
+		opts:     opts,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers the handler for a job type
+func (w *Worker) Handle(jobType string, handler Handler) {
+	w.handlers[jobType] = handler
+}
+
+// OnFailed registers fn to be called when a job fails permanently, after its
+// attempts are exhausted
+func (w *Worker) OnFailed(fn FailedFunc) {
+	w.onFailed = append(w.onFailed, fn)
+}
+
+// Run processes jobs until ctx is cancelled, then waits for in-flight jobs to finish
+func (w *Worker) Run(ctx context.Context) {
+	logger.Info("Queue worker started",
+		zap.Strings("queues", w.opts.Queues),
+		zap.Int("concurrency", w.opts.Concurrency))
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+	wg.Wait()
+
+	logger.Info("Queue worker stopped")
+}
+
+// loop polls for jobs until ctx is cancelled
+func (w *Worker) loop(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := w.queue.Pop(ctx, w.opts.Queues)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("Failed to pop job", zap.Error(err))
+		}
+
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.opts.PollInterval):
+			}
+			continue
+		}
+
+		w.process(job)
+	}
+}
+
+// process runs a reserved job. It deliberately ignores the worker context so
+// a shutdown signal lets the current job finish instead of aborting it.
+func (w *Worker) process(job *Job) {
+	ctx := context.Background()
+	if w.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.opts.Timeout)
+		defer cancel()
+	}
+
+	fields := []zap.Field{
+		zap.String("job_id", job.ID.String()),
+		zap.String("queue", job.Queue),
+		zap.String("type", job.Type),
+		zap.Int("attempt", job.Attempts),
+	}
+
+	metrics.JobsInFlight.Inc()
+	started := time.Now()
+	err := w.handle(ctx, job)
+	metrics.JobDuration.WithLabelValues(job.Queue, job.Type).Observe(time.Since(started).Seconds())
+	metrics.JobsInFlight.Dec()
+
+	// Acknowledge with a fresh context so a timed-out job can still be recorded
+	ackCtx := context.Background()
+	var deferred *DeferError
+
+	switch {
+	case err == nil:
+		if ackErr := w.queue.Complete(ackCtx, job); ackErr != nil {
+			logger.Error("Failed to complete job", append(fields, zap.Error(ackErr))...)
+		}
+		metrics.JobsProcessed.WithLabelValues(job.Queue, job.Type, "completed").Inc()
+		logger.Info("Job completed", append(fields, zap.Duration("duration", time.Since(started)))...)
+
+	case errors.As(err, &deferred):
+		job.Attempts--
+		if ackErr := w.queue.Retry(ackCtx, job, deferred.Delay, err); ackErr != nil {
+			logger.Error("Failed to release job", append(fields, zap.Error(ackErr))...)
+		}
+		metrics.JobsProcessed.WithLabelValues(job.Queue, job.Type, "deferred").Inc()
+		logger.Warn("Job deferred", append(fields, zap.Duration("delay", deferred.Delay), zap.Error(err))...)
+
+	case job.Attempts < job.MaxAttempts:
+		if ackErr := w.queue.Retry(ackCtx, job, w.opts.Backoff, err); ackErr != nil {
+			logger.Error("Failed to release job", append(fields, zap.Error(ackErr))...)
+		}
+		metrics.JobsProcessed.WithLabelValues(job.Queue, job.Type, "retried").Inc()
+		logger.Warn("Job failed, will retry", append(fields, zap.Error(err))...)
+
+	default:
+		if ackErr := w.queue.Fail(ackCtx, job, err); ackErr != nil {
+			logger.Error("Failed to mark job as failed", append(fields, zap.Error(ackErr))...)
+		}
+		metrics.JobsProcessed.WithLabelValues(job.Queue, job.Type, "failed").Inc()
+		logger.Error("Job failed permanently", append(fields, zap.Error(err))...)
+		for _, fn := range w.onFailed {
+			fn(ackCtx, job, err)
+		}
+	}
+}
+
+// handle dispatches a job to its handler, converting panics into errors
+func (w *Worker) handle(ctx context.Context, job *Job) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"time"
 
+	"go-clean-gin/pkg/exchange"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -33,6 +35,17 @@ type Meta struct {
 	TotalPages  int   `json:"total_pages,omitempty"`
 	HasNext     bool  `json:"has_next,omitempty"`
 	HasPrevious bool  `json:"has_previous,omitempty"`
+
+	// TotalIsEstimate is set when Total (and so TotalPages and HasNext) comes
+	// from planner statistics rather than COUNT(*)
+	TotalIsEstimate bool `json:"total_is_estimate,omitempty"`
+
+	// NextCursor resumes the listing after this page (?cursor=); empty on the
+	// last page
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// Exchange lists the rates behind display_price when ?currency= is given
+	Exchange *exchange.Conversion `json:"exchange,omitempty"`
 }
 
 // Success sends a successful response
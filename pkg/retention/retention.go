@@ -0,0 +1,78 @@
+// pkg/retention/retention.go - Deleting rows older than their table's retention
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-clean-gin/pkg/batch"
+	"go-clean-gin/pkg/metrics"
+	"go-clean-gin/pkg/tenancy"
+
+	"gorm.io/gorm"
+)
+
+// Rule declares how long the rows of a table are kept: rows whose timestamp in
+// Column is older than After are deleted. Rows with a NULL timestamp, e.g.
+// products not deleted, are kept.
+type Rule struct {
+	Name   string        // names the rule in logs and metrics, e.g. notifications
+	Table  string        // e.g. tb_notifications
+	Column string        // e.g. created_at, or deleted_at for soft-deleted rows
+	After  time.Duration // 0 keeps rows forever
+}
+
+// Purger deletes the rows of rules older than their retention, a batch per
+// transaction, so each batch locks few rows and a failure keeps the rest for
+// the next run
+type Purger struct {
+	db    *gorm.DB
+	batch int
+}
+
+// New creates a purger deleting batch rows at a time
+func New(db *gorm.DB, batch int) *Purger {
+	if batch < 1 {
+		batch = 1000
+	}
+	return &Purger{db: db, batch: batch}
+}
+
+// Purge deletes the rows of rule older than now minus its retention and
+// returns how many it deleted, counted in retention_rows_deleted_total. With
+// dryRun it deletes nothing and returns how many it would delete. Rows locked
+// by a request are skipped until the next run.
+func (p *Purger) Purge(ctx context.Context, rule Rule, now time.Time, dryRun bool) (int64, error) {
+	if rule.After <= 0 {
+		return 0, nil
+	}
+	db := tenancy.Conn(ctx, p.db)
+	quote := db.Statement.Quote
+	cutoff := now.Add(-rule.After)
+
+	if dryRun {
+		var count int64
+		err := db.Table(rule.Table).Where(quote(rule.Column)+" < ?", cutoff).Count(&count).Error
+		return count, err
+	}
+
+	key, err := batch.PrimaryKey(db, rule.Table)
+	if err != nil {
+		return 0, err
+	}
+	statement := fmt.Sprintf("DELETE FROM %s WHERE %s", quote(rule.Table), batch.Oldest(db, rule.Table, rule.Column, key))
+
+	var deleted int64
+	for {
+		result := db.Exec(statement, cutoff, p.batch)
+		if result.Error != nil {
+			return deleted, result.Error
+		}
+		deleted += result.RowsAffected
+		metrics.RetentionRowsDeleted.WithLabelValues(rule.Name).Add(float64(result.RowsAffected))
+		if result.RowsAffected < int64(p.batch) {
+			return deleted, nil
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-clean-gin/test/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var (
+	january  = time.Date(2000, 1, 10, 0, 0, 0, 0, time.UTC)
+	february = time.Date(2000, 2, 10, 0, 0, 0, 0, time.UTC)
+)
+
+// createPartitioned creates a table partitioned by month with old rows 1-3 in
+// January and newer rows 4-8 in February, so the rows of both partitions
+// share ctids
+func createPartitioned(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+
+	table := "tb_retention_" + uuid.NewString()[:8]
+	for _, statement := range []string{
+		fmt.Sprintf("CREATE TABLE %s (id bigint, created_at timestamptz NOT NULL, PRIMARY KEY (id, created_at)) PARTITION BY RANGE (created_at)", table),
+		fmt.Sprintf("CREATE TABLE %s_p2000_01 PARTITION OF %s FOR VALUES FROM ('2000-01-01') TO ('2000-02-01')", table, table),
+		fmt.Sprintf("CREATE TABLE %s_p2000_02 PARTITION OF %s FOR VALUES FROM ('2000-02-01') TO ('2000-03-01')", table, table),
+	} {
+		require.NoError(t, db.Exec(statement).Error)
+	}
+	for id := 1; id <= 8; id++ {
+		createdAt := february
+		if id <= 3 {
+			createdAt = january
+		}
+		require.NoError(t, db.Exec(fmt.Sprintf("INSERT INTO %s (id, created_at) VALUES (?, ?)", table), id, createdAt).Error)
+	}
+	return table
+}
+
+func remainingIDs(t *testing.T, db *gorm.DB, table string) []int64 {
+	t.Helper()
+
+	var ids []int64
+	require.NoError(t, db.Table(table).Order("id").Pluck("id", &ids).Error)
+	return ids
+}
+
+func TestPurger_Purge_PartitionedTable(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	table := createPartitioned(t, db)
+	rule := Rule{Name: "test", Table: table, Column: "created_at", After: 24 * time.Hour}
+	now := time.Date(2000, 2, 2, 0, 0, 0, 0, time.UTC)
+
+	// Batches smaller than the expired rows, so a batch matching rows of the
+	// newer partition would show
+	deleted, err := New(db, 2).Purge(context.Background(), rule, now, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.Equal(t, []int64{4, 5, 6, 7, 8}, remainingIDs(t, db, table))
+}
+
+func TestPurger_Purge_DryRun(t *testing.T) {
+	t.Parallel()
+
+	db := testdb.New(t)
+	table := createPartitioned(t, db)
+	rule := Rule{Name: "test", Table: table, Column: "created_at", After: 24 * time.Hour}
+	now := time.Date(2000, 2, 2, 0, 0, 0, 0, time.UTC)
+
+	count, err := New(db, 2).Purge(context.Background(), rule, now, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Len(t, remainingIDs(t, db, table), 8)
+}
+
+func TestPurger_Purge_NoRetention(t *testing.T) {
+	deleted, err := New(nil, 0).Purge(context.Background(), Rule{Name: "test", Table: "tb_none", Column: "created_at"}, time.Now(), false)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
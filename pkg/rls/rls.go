@@ -0,0 +1,117 @@
+// pkg/rls/rls.go - Postgres row-level security session variables
+package rls
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+// Session variables read by the row-level security policies. They are set
+// with set_config(..., true), so they only last for the current transaction
+// and never leak to the next user of a pooled connection.
+const (
+	VarUserID   = "app.user_id"
+	VarUserRole = "app.user_role"
+)
+
+// VarBypass set to "on" lets a session see every row. System work, such as
+// jobs, scheduled tasks and migrations, and apps running without DB_RLS set
+// it for the whole connection; without it the policies hide every row when
+// no user is set.
+const VarBypass = "app.rls_bypass"
+
+type contextKey struct{}
+
+type identity struct {
+	userID string
+	role   string
+}
+
+// WithUser returns a copy of ctx whose queries run as the user. Without a
+// user the variables stay unset and the policies hide every row, unless the
+// connection bypasses them (VarBypass).
+func WithUser(ctx context.Context, userID, role string) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity{userID: userID, role: role})
+}
+
+// UserFromContext returns the user and role ctx's queries run as, empty when
+// they run without one
+func UserFromContext(ctx context.Context) (userID, role string) {
+	user, _ := ctx.Value(contextKey{}).(identity)
+	return user.userID, user.role
+}
+
+// Register installs callbacks that set the session variables from the
+// statement's context in every transaction. Writes already run in GORM's
+// default transaction; queries and raw statements of a user are wrapped in
+// one so the variables apply to them too. Row and Rows are left alone, since
+// their results are read after the callbacks return.
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:begin_transaction").Register("rls:set_config", setConfig); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:begin_transaction").Register("rls:set_config", setConfig); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:begin_transaction").Register("rls:set_config", setConfig); err != nil {
+		return err
+	}
+
+	query := db.Callback().Query()
+	if err := query.Before("gorm:query").Register("rls:begin_transaction", beginTransaction); err != nil {
+		return err
+	}
+	if err := query.After("rls:begin_transaction").Before("gorm:query").Register("rls:set_config", setConfig); err != nil {
+		return err
+	}
+	if err := query.After("gorm:after_query").Register("rls:commit_or_rollback_transaction", callbacks.CommitOrRollbackTransaction); err != nil {
+		return err
+	}
+
+	raw := db.Callback().Raw()
+	if err := raw.Before("gorm:raw").Register("rls:begin_transaction", beginTransaction); err != nil {
+		return err
+	}
+	if err := raw.After("rls:begin_transaction").Before("gorm:raw").Register("rls:set_config", setConfig); err != nil {
+		return err
+	}
+	return raw.After("gorm:raw").Register("rls:commit_or_rollback_transaction", callbacks.CommitOrRollbackTransaction)
+}
+
+// beginTransaction starts a transaction for statements with session
+// variables to set, unless they already run in one
+func beginTransaction(db *gorm.DB) {
+	if _, ok := userOf(db.Statement.Context); ok {
+		callbacks.BeginTransaction(db)
+	}
+}
+
+func setConfig(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); !inTx {
+		return
+	}
+
+	ctx := db.Statement.Context
+	user, ok := userOf(ctx)
+	if !ok {
+		return
+	}
+
+	_, err := db.Statement.ConnPool.ExecContext(ctx,
+		"SELECT set_config($1, $2, true), set_config($3, $4, true)",
+		VarUserID, user.userID, VarUserRole, user.role)
+	if err != nil {
+		db.AddError(err)
+	}
+}
+
+// userOf returns the user of ctx, and false when it has none
+func userOf(ctx context.Context) (identity, bool) {
+	user, _ := ctx.Value(contextKey{}).(identity)
+	return user, user.userID != ""
+}
@@ -0,0 +1,147 @@
+package rls_test
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-gin/pkg/rls"
+	"go-clean-gin/test/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// session holds the session variables as a query sees them
+type session struct {
+	UserID   string
+	UserRole string
+}
+
+const selectSession = `SELECT coalesce(current_setting('app.user_id', true), '') AS user_id,
+	coalesce(current_setting('app.user_role', true), '') AS user_role`
+
+// withCallbacks runs fn on a single connection to the test database with
+// the callbacks registered, so each query sees what the previous ones left
+// on the connection
+func withCallbacks(t *testing.T, fn func(conn *gorm.DB)) {
+	t.Helper()
+
+	sqlDB, err := testdb.Open(t).DB()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, rls.Register(db))
+
+	require.NoError(t, db.Connection(func(conn *gorm.DB) error {
+		fn(conn)
+		return nil
+	}))
+}
+
+func current(t *testing.T, db *gorm.DB) session {
+	t.Helper()
+
+	var s session
+	require.NoError(t, db.Raw(selectSession).Find(&s).Error)
+	return s
+}
+
+func TestWithUser(t *testing.T) {
+	t.Parallel()
+
+	userID, role := rls.UserFromContext(context.Background())
+	assert.Empty(t, userID)
+	assert.Empty(t, role)
+
+	userID, role = rls.UserFromContext(rls.WithUser(context.Background(), "u1", "admin"))
+	assert.Equal(t, "u1", userID)
+	assert.Equal(t, "admin", role)
+}
+
+func TestRegister_QuerySetsVariablesInTransaction(t *testing.T) {
+	withCallbacks(t, func(conn *gorm.DB) {
+		ctx := rls.WithUser(context.Background(), "u1", "member")
+
+		// set_config(..., true) outside a transaction would be gone before the
+		// query runs
+		assert.Equal(t, session{UserID: "u1", UserRole: "member"}, current(t, conn.WithContext(ctx)))
+	})
+}
+
+func TestRegister_ResetsAfterTransaction(t *testing.T) {
+	withCallbacks(t, func(conn *gorm.DB) {
+		admin := rls.WithUser(context.Background(), "u1", "admin")
+		member := rls.WithUser(context.Background(), "u2", "member")
+
+		assert.Equal(t, "admin", current(t, conn.WithContext(admin)).UserRole)
+		assert.Equal(t, session{UserID: "u2", UserRole: "member"}, current(t, conn.WithContext(member)),
+			"the next user of the connection does not inherit the role")
+		assert.Equal(t, session{}, current(t, conn), "system work on the connection sees no user")
+	})
+}
+
+func TestRegister_ExecSetsVariables(t *testing.T) {
+	withCallbacks(t, func(conn *gorm.DB) {
+		ctx := rls.WithUser(context.Background(), "u1", "member")
+
+		// Copy the user to a session-wide setting that outlives the transaction
+		require.NoError(t, conn.WithContext(ctx).Exec("SELECT set_config('test.seen_user', current_setting('app.user_id', true), false)").Error)
+
+		var seen string
+		require.NoError(t, conn.Raw("SELECT current_setting('test.seen_user')").Scan(&seen).Error)
+		assert.Equal(t, "u1", seen)
+	})
+}
+
+func TestRegister_WriteSetsVariables(t *testing.T) {
+	withCallbacks(t, func(conn *gorm.DB) {
+		require.NoError(t, conn.Exec("CREATE TEMP TABLE rls_probe (id int, user_id text DEFAULT current_setting('app.user_id', true)) ON COMMIT PRESERVE ROWS").Error)
+		defer conn.Exec("DROP TABLE rls_probe")
+
+		ctx := rls.WithUser(context.Background(), "u1", "member")
+		require.NoError(t, conn.WithContext(ctx).Table("rls_probe").Create(map[string]interface{}{"id": 1}).Error)
+
+		var userID string
+		require.NoError(t, conn.Raw("SELECT user_id FROM rls_probe WHERE id = 1").Scan(&userID).Error)
+		assert.Equal(t, "u1", userID)
+	})
+}
+
+func TestOwnerPolicy(t *testing.T) {
+	t.Parallel()
+
+	owner := uuid.New()
+	tests := []struct {
+		name     string
+		settings map[string]string
+		visible  bool
+	}{
+		{name: "no user", visible: false},
+		{name: "empty user", settings: map[string]string{rls.VarUserID: ""}, visible: false},
+		{name: "owner", settings: map[string]string{rls.VarUserID: owner.String(), rls.VarUserRole: "member"}, visible: true},
+		{name: "other user", settings: map[string]string{rls.VarUserID: uuid.NewString(), rls.VarUserRole: "member"}, visible: false},
+		{name: "admin", settings: map[string]string{rls.VarUserID: uuid.NewString(), rls.VarUserRole: "admin"}, visible: true},
+		{name: "bypass", settings: map[string]string{rls.VarBypass: "on"}, visible: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := testdb.New(t)
+
+			// The test database connects without DB_RLS, so with the bypass on
+			settings := map[string]string{rls.VarBypass: "off"}
+			for name, value := range tt.settings {
+				settings[name] = value
+			}
+			for name, value := range settings {
+				require.NoError(t, tx.Exec("SELECT set_config(?, ?, true)", name, value).Error)
+			}
+
+			var visible bool
+			require.NoError(t, tx.Raw("SELECT app_rls_owner(?)", owner).Scan(&visible).Error)
+			assert.Equal(t, tt.visible, visible)
+		})
+	}
+}
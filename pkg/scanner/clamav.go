@@ -0,0 +1,98 @@
+// pkg/scanner/clamav.go - ClamAV scanner driver
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVChunkSize is the size of the chunks files are streamed to clamd in
+const clamAVChunkSize = 32 << 10
+
+// ClamAV scans files with a clamd daemon through its INSTREAM command. Files
+// larger than clamd's StreamMaxLength fail to scan rather than pass.
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a scanner for the clamd listening at address, given as
+// tcp://host:port or unix:///path/to/clamd.sock
+func NewClamAV(address string, timeout time.Duration) (*ClamAV, error) {
+	network, addr, ok := strings.Cut(address, "://")
+	if !ok || (network != "tcp" && network != "unix") || addr == "" {
+		return nil, fmt.Errorf("invalid clamav address %q, want tcp://host:port or unix:///path", address)
+	}
+	return &ClamAV{network: network, address: addr, timeout: timeout}, nil
+}
+
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+
+	// Each chunk is prefixed with its length; an empty chunk ends the stream
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return Result{}, fmt.Errorf("clamav: %w", err)
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return Result{}, readErr
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && err != io.EOF {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+	return parseClamAVReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// parseClamAVReply reads clamd's answer to INSTREAM: "stream: OK",
+// "stream: <signature> FOUND" or "<message> ERROR"
+func parseClamAVReply(reply string) (Result, error) {
+	status := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case status == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamav: %s", reply)
+	}
+}
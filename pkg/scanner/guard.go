@@ -0,0 +1,66 @@
+// pkg/scanner/guard.go - Scanning uploads before they are stored
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/storage"
+
+	"go.uber.org/zap"
+)
+
+// InfectedError is returned for uploads a scan found malware in. The upload
+// was moved to Quarantine instead of being stored.
+type InfectedError struct {
+	Signature  string
+	Quarantine string
+}
+
+func (e *InfectedError) Error() string {
+	return fmt.Sprintf("scanner: file is infected with %s", e.Signature)
+}
+
+func (e *InfectedError) Unwrap() error {
+	return ErrInfected
+}
+
+// Guard scans uploads before they are stored and quarantines infected ones
+// under its own storage prefix, where they are never served
+type Guard struct {
+	scanner Scanner
+	storage storage.Storage
+	dir     string
+}
+
+func NewGuard(scanner Scanner, store storage.Storage, quarantineDir string) *Guard {
+	return &Guard{scanner: scanner, storage: store, dir: quarantineDir}
+}
+
+// Check scans r, the upload about to be stored at path, and rewinds it for
+// storing. Infected uploads are copied to the quarantine, at path under its
+// prefix, and reported with an *InfectedError.
+func (g *Guard) Check(ctx context.Context, path string, r io.ReadSeeker) error {
+	result, err := g.scanner.Scan(ctx, r)
+	if err != nil {
+		return err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	quarantine := g.dir + "/" + path
+	if err := g.storage.Put(ctx, quarantine, r); err != nil {
+		logger.FromContext(ctx).Error("Failed to quarantine upload", zap.String("path", path), zap.Error(err))
+		quarantine = ""
+	}
+
+	logger.FromContext(ctx).Warn("Infected upload rejected",
+		zap.String("path", path), zap.String("signature", result.Signature), zap.String("quarantine", quarantine))
+	return &InfectedError{Signature: result.Signature, Quarantine: quarantine}
+}
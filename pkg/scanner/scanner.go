@@ -0,0 +1,69 @@
+// pkg/scanner/scanner.go - Virus scanners for uploads
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go-clean-gin/config"
+)
+
+// ErrInfected is wrapped by the errors of uploads a scan found malware in
+var ErrInfected = errors.New("scanner: file is infected")
+
+// Result is the outcome of a scan. Signature names what was found in
+// infected files.
+type Result struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner is implemented by every scanner driver. Scan reads r to the end;
+// an error means the file could not be scanned, not that it is infected.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// New creates a scanner for the configured driver
+func New(cfg *config.ScannerConfig) (Scanner, error) {
+	switch cfg.Driver {
+	case "", "none":
+		return NopScanner{}, nil
+	case "clamav":
+		return NewClamAV(cfg.ClamAVAddress, cfg.Timeout)
+	case "eicar":
+		return EICARScanner{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported scanner driver: %s", cfg.Driver)
+	}
+}
+
+// NopScanner reports every file clean, for environments without a scanner
+type NopScanner struct{}
+
+func (NopScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	_, err := io.Copy(io.Discard, r)
+	return Result{}, err
+}
+
+// EICARTestFile is the standard antivirus test file, which every scanner
+// detects and which is harmless
+const EICARTestFile = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// EICARScanner detects only the EICAR test file, so the handling of infected
+// uploads can be tried out and tested without a scanning daemon
+type EICARScanner struct{}
+
+func (EICARScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if bytes.Contains(data, []byte(EICARTestFile)) {
+		return Result{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,133 @@
+// pkg/scheduler/scheduler.go - Interval-based task scheduler
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/logger"
+	"go-clean-gin/pkg/metrics"
+
+	"go.uber.org/zap"
+)
+
+// TaskFunc is the work performed by a scheduled task
+type TaskFunc func(ctx context.Context) error
+
+// Task is a named unit of work run at a fixed interval
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      TaskFunc
+}
+
+// Scheduler runs registered tasks on their intervals
+type Scheduler struct {
+	tasks []Task
+	clock clock.Clock
+}
+
+// New creates an empty scheduler
+func New() *Scheduler {
+	return &Scheduler{clock: clock.New()}
+}
+
+// SetClock replaces the clock driving tickers and durations
+func (s *Scheduler) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Every registers a task that runs once per interval
+func (s *Scheduler) Every(interval time.Duration, name string, run TaskFunc) {
+	s.tasks = append(s.tasks, Task{Name: name, Interval: interval, Run: run})
+}
+
+// Tasks returns the registered tasks
+func (s *Scheduler) Tasks() []Task {
+	return s.tasks
+}
+
+// Start runs every task on its own ticker until ctx is cancelled, then waits
+// for running tasks to finish. A task never overlaps with itself.
+func (s *Scheduler) Start(ctx context.Context) {
+	logger.Info("Scheduler started", zap.Int("tasks", len(s.tasks)))
+
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func(task Task) {
+			defer wg.Done()
+
+			ticker := s.clock.NewTicker(task.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C():
+					// Tasks run to completion even during shutdown
+					_ = s.run(context.WithoutCancel(ctx), task)
+				}
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	logger.Info("Scheduler stopped")
+}
+
+// RunAll runs every task once, in registration order
+func (s *Scheduler) RunAll(ctx context.Context) error {
+	var failed int
+	for _, task := range s.tasks {
+		if err := s.run(ctx, task); err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scheduled tasks failed", failed, len(s.tasks))
+	}
+	return nil
+}
+
+// RunTask runs a single task by name
+func (s *Scheduler) RunTask(ctx context.Context, name string) error {
+	for _, task := range s.tasks {
+		if task.Name == name {
+			return s.run(ctx, task)
+		}
+	}
+	return fmt.Errorf("scheduled task %s not found", name)
+}
+
+// run executes a task, recording logs and metrics
+func (s *Scheduler) run(ctx context.Context, task Task) (err error) {
+	started := s.clock.Now()
+	logger.Info("Running scheduled task", zap.String("task", task.Name))
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+
+		metrics.ScheduledTaskDuration.WithLabelValues(task.Name).Observe(s.clock.Since(started).Seconds())
+
+		if err != nil {
+			metrics.ScheduledTaskRuns.WithLabelValues(task.Name, "error").Inc()
+			logger.Error("Scheduled task failed", zap.String("task", task.Name), zap.Error(err))
+			return
+		}
+
+		metrics.ScheduledTaskRuns.WithLabelValues(task.Name, "success").Inc()
+		logger.Info("Scheduled task completed",
+			zap.String("task", task.Name),
+			zap.Duration("duration", s.clock.Since(started)))
+	}()
+
+	return task.Run(ctx)
+}
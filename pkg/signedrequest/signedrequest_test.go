@@ -0,0 +1,250 @@
+package signedrequest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+const (
+	secret = "ci-secret"
+	body   = `{"value":"25"}`
+)
+
+func newVerifier(t *testing.T) (*Verifier, *clock.Fake, *MemoryNonceStore) {
+	t.Helper()
+
+	keys, err := ParseKeys([]string{"ci:admin|scim:" + secret, "ops:*:ops-secret"})
+	require.NoError(t, err)
+	clk := clock.NewFake(now)
+	nonces := NewMemoryNonceStore(clk)
+	return NewVerifier(keys, nonces, 5*time.Minute, []string{"scim"}, clk), clk, nonces
+}
+
+// signedRequest builds a request signed by the ci key at now
+func signedRequest(method, target, nonce string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	r.Header.Set(KeyHeader, "ci")
+	r.Header.Set(TimestampHeader, timestamp)
+	r.Header.Set(NonceHeader, nonce)
+	r.Header.Set(SignatureHeader, Sign([]byte(secret), method, r.URL.RequestURI(), timestamp, nonce, []byte(body)))
+	return r
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	v, _, _ := newVerifier(t)
+	r := signedRequest(http.MethodPut, "/api/v1/admin/settings/products.page_size?scope=tenant", "n1")
+
+	keyID, err := v.Verify(context.Background(), r, []byte(body), "admin")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ci", keyID)
+}
+
+func TestVerify_Replayed(t *testing.T) {
+	t.Parallel()
+
+	v, _, _ := newVerifier(t)
+	r := signedRequest(http.MethodPost, "/scim/v2/Users", "n1")
+
+	_, err := v.Verify(context.Background(), r, []byte(body), "scim")
+	require.NoError(t, err)
+
+	_, err = v.Verify(context.Background(), r, []byte(body), "scim")
+	assert.ErrorIs(t, err, ErrReplayed)
+
+	// The nonce is per key
+	other := signedRequest(http.MethodPost, "/scim/v2/Users", "n1")
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	other.Header.Set(KeyHeader, "ops")
+	other.Header.Set(SignatureHeader, Sign([]byte("ops-secret"), http.MethodPost, "/scim/v2/Users", timestamp, "n1", []byte(body)))
+	_, err = v.Verify(context.Background(), other, []byte(body), "scim")
+	assert.NoError(t, err)
+}
+
+func TestVerify_Rejected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		tamper func(r *http.Request) (*http.Request, []byte)
+		scope  string
+		want   error
+	}{
+		{name: "unsigned", want: ErrUnsigned, tamper: func(r *http.Request) (*http.Request, []byte) {
+			return httptest.NewRequest(http.MethodPost, "/scim/v2/Users", nil), nil
+		}},
+		{name: "body", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			return r, []byte(`{"value":"100"}`)
+		}},
+		{name: "method", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Method = http.MethodDelete
+			return r, []byte(body)
+		}},
+		{name: "path", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.URL.Path = "/scim/v2/Users/other"
+			return r, []byte(body)
+		}},
+		{name: "query", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.URL.RawQuery = "force=true"
+			return r, []byte(body)
+		}},
+		{name: "nonce", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Set(NonceHeader, "n2")
+			return r, []byte(body)
+		}},
+		{name: "timestamp", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix()+1, 10))
+			return r, []byte(body)
+		}},
+		{name: "malformed timestamp", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Set(TimestampHeader, "yesterday")
+			return r, []byte(body)
+		}},
+		{name: "signature", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Set(SignatureHeader, strings.Repeat("0", 64))
+			return r, []byte(body)
+		}},
+		{name: "malformed signature", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Set(SignatureHeader, "not-hex")
+			return r, []byte(body)
+		}},
+		{name: "missing signature", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Del(SignatureHeader)
+			return r, []byte(body)
+		}},
+		{name: "missing nonce", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Del(NonceHeader)
+			return r, []byte(body)
+		}},
+		{name: "nonce too long", want: ErrInvalidSignature, tamper: func(r *http.Request) (*http.Request, []byte) {
+			return signedRequest(http.MethodPost, "/scim/v2/Users", strings.Repeat("n", 129)), []byte(body)
+		}},
+		{name: "unknown key", want: ErrUnknownKey, tamper: func(r *http.Request) (*http.Request, []byte) {
+			r.Header.Set(KeyHeader, "someone")
+			return r, []byte(body)
+		}},
+		{name: "scope the key lacks", scope: "exports", want: ErrScope, tamper: func(r *http.Request) (*http.Request, []byte) {
+			return r, []byte(body)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _, nonces := newVerifier(t)
+			r, b := tt.tamper(signedRequest(http.MethodPost, "/scim/v2/Users", "n1"))
+			scope := tt.scope
+			if scope == "" {
+				scope = "scim"
+			}
+
+			_, err := v.Verify(context.Background(), r, b, scope)
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.True(t, Rejected(err))
+			assert.Zero(t, nonces.Len(), "rejected requests do not spend nonces")
+		})
+	}
+}
+
+func TestVerify_Window(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    error
+	}{
+		{name: "at the window", advance: 5 * time.Minute},
+		{name: "past the window", advance: 5*time.Minute + time.Second, want: ErrStale},
+		{name: "ahead within the window", advance: -5 * time.Minute},
+		{name: "ahead past the window", advance: -5*time.Minute - time.Second, want: ErrStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, clk, _ := newVerifier(t)
+			clk.Advance(tt.advance)
+
+			_, err := v.Verify(context.Background(), signedRequest(http.MethodPost, "/scim/v2/Users", "n1"), []byte(body), "scim")
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifier_Required(t *testing.T) {
+	t.Parallel()
+
+	v, _, _ := newVerifier(t)
+
+	assert.True(t, v.Required("scim"))
+	assert.False(t, v.Required("admin"))
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake(now)
+	store := NewMemoryNonceStore(clk)
+	ctx := context.Background()
+
+	fresh, err := store.Claim(ctx, "ci", "n1", 10*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, fresh)
+
+	fresh, _ = store.Claim(ctx, "ci", "n1", 10*time.Minute)
+	assert.False(t, fresh)
+
+	// Once the timestamp it came with is stale, the nonce is forgotten
+	clk.Advance(10 * time.Minute)
+	fresh, _ = store.Claim(ctx, "ci", "n2", 10*time.Minute)
+	assert.True(t, fresh)
+	assert.Equal(t, 1, store.Len(), "the expired nonce is dropped")
+
+	fresh, _ = store.Claim(ctx, "ci", "n1", 10*time.Minute)
+	assert.True(t, fresh)
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, err := ParseKeys([]string{"ci:admin|scim:s3cr:et", "ops:*:x"})
+	require.NoError(t, err)
+
+	ci, err := keys.Key(context.Background(), "ci")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("s3cr:et"), ci.Secret)
+	assert.True(t, ci.Allows("scim"))
+	assert.False(t, ci.Allows("exports"))
+
+	ops, err := keys.Key(context.Background(), "ops")
+	require.NoError(t, err)
+	assert.True(t, ops.Allows("exports"))
+
+	_, err = keys.Key(context.Background(), "other")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	for _, invalid := range [][]string{{"ci"}, {"ci:admin"}, {"ci:admin:"}, {":admin:x"}, {"ci::x"}, {"ci:admin:x", "ci:scim:y"}} {
+		_, err := ParseKeys(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,84 @@
+// pkg/signedrequest/store.go - Keys from configuration and nonces in memory
+package signedrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+)
+
+// StaticKeys is a KeyStore of keys listed in configuration
+type StaticKeys map[string]*Key
+
+// ParseKeys parses "id:scope|scope:secret" entries
+func ParseKeys(entries []string) (StaticKeys, error) {
+	keys := make(StaticKeys, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("signing key %q must be id:scopes:secret", entry)
+		}
+		if _, ok := keys[parts[0]]; ok {
+			return nil, fmt.Errorf("signing key %q is listed twice", parts[0])
+		}
+		keys[parts[0]] = &Key{Secret: []byte(parts[2]), Scopes: strings.Split(parts[1], "|")}
+	}
+	return keys, nil
+}
+
+func (k StaticKeys) Key(ctx context.Context, id string) (*Key, error) {
+	key, ok := k[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// MemoryNonceStore keeps nonces in memory, so a nonce used with one instance
+// can be replayed against another; several instances need a shared store
+type MemoryNonceStore struct {
+	clock clock.Clock
+
+	mu     sync.Mutex
+	nonces map[string]time.Time // expiry by key ID and nonce
+	sweep  time.Time
+}
+
+func NewMemoryNonceStore(clk clock.Clock) *MemoryNonceStore {
+	return &MemoryNonceStore{clock: clk, nonces: make(map[string]time.Time)}
+}
+
+func (s *MemoryNonceStore) Claim(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	now := s.clock.Now()
+	id := keyID + "\x00" + nonce
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Expired nonces are dropped at most once per ttl
+	if !now.Before(s.sweep) {
+		for n, expiresAt := range s.nonces {
+			if !now.Before(expiresAt) {
+				delete(s.nonces, n)
+			}
+		}
+		s.sweep = now.Add(ttl)
+	}
+
+	if expiresAt, ok := s.nonces[id]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	s.nonces[id] = now.Add(ttl)
+	return true, nil
+}
+
+// Len returns the number of nonces remembered
+func (s *MemoryNonceStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.nonces)
+}
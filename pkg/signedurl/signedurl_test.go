@@ -0,0 +1,177 @@
+package signedurl
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+func newSigner() (*Signer, *clock.Fake) {
+	clk := clock.NewFake(now)
+	return New("test-secret", clk), clk
+}
+
+func sign(t *testing.T, s *Signer, rawURL string, ttl time.Duration) *url.URL {
+	t.Helper()
+
+	signed, _, err := s.Sign(rawURL, ttl)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	return u
+}
+
+func TestSign_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newSigner()
+
+	signed, expiresAt, err := s.Sign("https://example.com/auth/account/export/download?file=exports%2Fu1%2Fa.json&format=json", time.Hour)
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), expiresAt)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", u.Host)
+	assert.Equal(t, "/auth/account/export/download", u.Path)
+	assert.Equal(t, "exports/u1/a.json", u.Query().Get("file"))
+	assert.Equal(t, strconv.FormatInt(expiresAt.Unix(), 10), u.Query().Get(ExpiresParam))
+	assert.NoError(t, s.Verify(u))
+}
+
+func TestSign_ReplacesSignature(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newSigner()
+	u := sign(t, s, "/download?file=a", time.Hour)
+
+	// Signing a signed URL again leaves one signature, over the new expiry
+	resigned := sign(t, s, u.String(), 2*time.Hour)
+	assert.Len(t, resigned.Query()[SignatureParam], 1)
+	assert.NoError(t, s.Verify(resigned))
+}
+
+func TestVerify_IgnoresHostAndQueryOrder(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newSigner()
+	u := sign(t, s, "https://example.com/download?b=2&a=1", time.Hour)
+
+	moved := *u
+	moved.Scheme, moved.Host = "http", "internal:8080"
+	assert.NoError(t, s.Verify(&moved), "proxies may rewrite the host")
+
+	// The signature covers the parameters, not their order
+	parts := strings.Split(u.RawQuery, "&")
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	reordered := *u
+	reordered.RawQuery = strings.Join(parts, "&")
+	require.NotEqual(t, u.RawQuery, reordered.RawQuery)
+	assert.NoError(t, s.Verify(&reordered))
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newSigner()
+	u := sign(t, s, "https://example.com/download?file=exports%2Fu1%2Fa.json&tag=x&tag=y", time.Hour)
+
+	tests := []struct {
+		name   string
+		tamper func(u *url.URL)
+	}{
+		{name: "path", tamper: func(u *url.URL) { u.Path = "/download2" }},
+		{name: "query value", tamper: func(u *url.URL) { set(u, "file", "exports/u2/a.json") }},
+		{name: "expiry", tamper: func(u *url.URL) { set(u, ExpiresParam, strconv.FormatInt(now.Add(24*time.Hour).Unix(), 10)) }},
+		{name: "repeated value reordered", tamper: func(u *url.URL) {
+			q := u.Query()
+			q["tag"] = []string{"y", "x"}
+			u.RawQuery = q.Encode()
+		}},
+		{name: "missing parameter", tamper: func(u *url.URL) { del(u, "file") }},
+		{name: "missing repeated value", tamper: func(u *url.URL) {
+			q := u.Query()
+			q["tag"] = []string{"x"}
+			u.RawQuery = q.Encode()
+		}},
+		{name: "missing expiry", tamper: func(u *url.URL) { del(u, ExpiresParam) }},
+		{name: "extra parameter", tamper: func(u *url.URL) { set(u, "admin", "1") }},
+		{name: "extra empty parameter", tamper: func(u *url.URL) { u.RawQuery += "&admin" }},
+		{name: "missing signature", tamper: func(u *url.URL) { del(u, SignatureParam) }},
+		{name: "empty signature", tamper: func(u *url.URL) { set(u, SignatureParam, "") }},
+		{name: "malformed signature", tamper: func(u *url.URL) { set(u, SignatureParam, "not-hex") }},
+		{name: "truncated signature", tamper: func(u *url.URL) { set(u, SignatureParam, u.Query().Get(SignatureParam)[:32]) }},
+		{name: "signature first of two", tamper: func(u *url.URL) {
+			u.RawQuery = SignatureParam + "=" + strings.Repeat("0", 64) + "&" + u.RawQuery
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := *u
+			tt.tamper(&tampered)
+			assert.Equal(t, ErrInvalidSignature, s.Verify(&tampered))
+		})
+	}
+}
+
+func TestVerify_OtherKey(t *testing.T) {
+	t.Parallel()
+
+	s, clk := newSigner()
+	u := sign(t, s, "/download?file=a", time.Hour)
+
+	assert.Equal(t, ErrInvalidSignature, New("other-secret", clk).Verify(u))
+}
+
+func TestVerify_ExpiryBoundary(t *testing.T) {
+	t.Parallel()
+
+	s, clk := newSigner()
+	u := sign(t, s, "/download?file=a", time.Hour)
+
+	clk.Advance(time.Hour)
+	assert.NoError(t, s.Verify(u), "valid through the second it expires")
+
+	clk.Advance(999 * time.Millisecond)
+	assert.NoError(t, s.Verify(u))
+
+	clk.Advance(time.Millisecond)
+	assert.Equal(t, ErrExpired, s.Verify(u))
+}
+
+// Expiry is kept to whole seconds, so it is rounded down
+func TestSign_TruncatesExpiry(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewFake(now.Add(700 * time.Millisecond))
+	s := New("test-secret", clk)
+
+	_, expiresAt, err := s.Sign("/download", 10*time.Second)
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(10*time.Second), expiresAt)
+}
+
+func set(u *url.URL, key, value string) {
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+}
+
+func del(u *url.URL, key string) {
+	q := u.Query()
+	q.Del(key)
+	u.RawQuery = q.Encode()
+}
@@ -0,0 +1,80 @@
+// pkg/spec/gorm.go - Translating specifications to GORM conditions
+package spec
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Translator returns the condition of a repository's own specifications, the
+// leaves of a composed one. It returns an error for specifications it does
+// not know, which are likely meant for another repository.
+type Translator[T any] func(s Spec[T]) (clause.Expression, error)
+
+// Unsupported is the error of a Translator for a specification it does not know
+func Unsupported[T any](s Spec[T]) error {
+	return fmt.Errorf("spec: %T is not supported by this repository", s)
+}
+
+// Clause translates a specification to a condition, composing And, Or and Not
+// and handing every other specification to leaf
+func Clause[T any](s Spec[T], leaf Translator[T]) (clause.Expression, error) {
+	switch s := s.(type) {
+	case AndSpec[T]:
+		exprs, err := clauses(s, leaf)
+		if err != nil {
+			return nil, err
+		}
+		if len(exprs) == 0 {
+			return clause.Expr{SQL: "TRUE"}, nil
+		}
+		return clause.And(exprs...), nil
+
+	case OrSpec[T]:
+		exprs, err := clauses(s, leaf)
+		if err != nil {
+			return nil, err
+		}
+		if len(exprs) == 0 {
+			return clause.Expr{SQL: "FALSE"}, nil
+		}
+		return clause.Or(exprs...), nil
+
+	case NotSpec[T]:
+		expr, err := Clause(s.Spec, leaf)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Not(expr), nil
+	}
+	return leaf(s)
+}
+
+func clauses[T any](specs []Spec[T], leaf Translator[T]) ([]clause.Expression, error) {
+	exprs := make([]clause.Expression, 0, len(specs))
+	for _, s := range specs {
+		expr, err := Clause(s, leaf)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// Where adds the condition of the specification to query. A nil specification
+// matches everything; one that leaf does not know is added to the query as
+// an error.
+func Where[T any](query *gorm.DB, s Spec[T], leaf Translator[T]) *gorm.DB {
+	if s == nil {
+		return query
+	}
+	expr, err := Clause(s, leaf)
+	if err != nil {
+		query.AddError(err)
+		return query
+	}
+	return query.Where(expr)
+}
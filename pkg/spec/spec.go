@@ -0,0 +1,72 @@
+// pkg/spec/spec.go - Composable query specifications
+package spec
+
+// Spec is a condition on candidates of type T, such as products in a
+// category. Usecases compose specifications to say what they query and
+// repositories translate them to SQL with Where, so usecases never see the
+// columns. IsSatisfiedBy evaluates the same condition in memory, which lets
+// tests check a specification against sample values without a database.
+type Spec[T any] interface {
+	IsSatisfiedBy(candidate T) bool
+}
+
+// AndSpec is satisfied when all of its specifications are, including when
+// there are none
+type AndSpec[T any] []Spec[T]
+
+// OrSpec is satisfied when any of its specifications is, and never when there
+// are none
+type OrSpec[T any] []Spec[T]
+
+// NotSpec is satisfied when its specification is not
+type NotSpec[T any] struct {
+	Spec Spec[T]
+}
+
+// And combines specifications that must all be satisfied
+func And[T any](specs ...Spec[T]) Spec[T] {
+	return AndSpec[T](specs)
+}
+
+// Or combines specifications of which one must be satisfied
+func Or[T any](specs ...Spec[T]) Spec[T] {
+	return OrSpec[T](specs)
+}
+
+// Not negates a specification
+func Not[T any](s Spec[T]) Spec[T] {
+	return NotSpec[T]{Spec: s}
+}
+
+func (s AndSpec[T]) IsSatisfiedBy(candidate T) bool {
+	for _, spec := range s {
+		if !spec.IsSatisfiedBy(candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s OrSpec[T]) IsSatisfiedBy(candidate T) bool {
+	for _, spec := range s {
+		if spec.IsSatisfiedBy(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s NotSpec[T]) IsSatisfiedBy(candidate T) bool {
+	return !s.Spec.IsSatisfiedBy(candidate)
+}
+
+// Filter returns the candidates satisfying the specification
+func Filter[T any](s Spec[T], candidates []T) []T {
+	var matched []T
+	for _, candidate := range candidates {
+		if s.IsSatisfiedBy(candidate) {
+			matched = append(matched, candidate)
+		}
+	}
+	return matched
+}
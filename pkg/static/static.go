@@ -0,0 +1,118 @@
+// pkg/static/static.go - Serve a frontend build, with SPA fallback
+package static
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IndexFile is the page served for the root and, with SPA, for page routes
+const IndexFile = "index.html"
+
+// immutableMaxAge is how long content-hashed files are cached
+const immutableMaxAge = 365 * 24 * time.Hour
+
+// Options controls how files are served
+type Options struct {
+	// SPA serves IndexFile for paths without a file extension that match no
+	// file, so routes handled by the frontend load the app
+	SPA bool
+	// MaxAge is how long browsers may cache files. IndexFile is always
+	// revalidated so a deploy is picked up right away.
+	MaxAge time.Duration
+	// ImmutablePrefix is the directory of content-hashed files, which never
+	// change and are cached for a year. Empty disables it.
+	ImmutablePrefix string
+}
+
+// Handler serves the files of a frontend build
+type Handler struct {
+	files fs.FS
+	opts  Options
+}
+
+func New(files fs.FS, opts Options) *Handler {
+	return &Handler{files: files, opts: opts}
+}
+
+// Serve writes the file for the request's path and reports whether there was
+// one. Only GET and HEAD requests are served, and dotfiles never are.
+func (h *Handler) Serve(c *gin.Context) bool {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+c.Request.URL.Path), "/")
+	if name == "" {
+		name = IndexFile
+	}
+	if !h.exists(name) {
+		if !h.opts.SPA || path.Ext(name) != "" || !h.exists(IndexFile) {
+			return false
+		}
+		name = IndexFile
+	}
+
+	if err := h.serveFile(c, name); err != nil {
+		c.Error(err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
+	return true
+}
+
+// exists reports whether name is a regular file that may be served
+func (h *Handler) exists(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	info, err := fs.Stat(h.files, name)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func (h *Handler) serveFile(c *gin.Context, name string) error {
+	file, err := h.files.Open(name)
+	if err != nil {
+		return fmt.Errorf("static: open %s: %w", name, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("static: stat %s: %w", name, err)
+	}
+
+	// Embedded files can seek; anything else is read into memory
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return fmt.Errorf("static: read %s: %w", name, err)
+		}
+		content = bytes.NewReader(data)
+	}
+
+	c.Header("Cache-Control", h.cacheControl(name))
+	http.ServeContent(c.Writer, c.Request, name, info.ModTime(), content)
+	return nil
+}
+
+func (h *Handler) cacheControl(name string) string {
+	switch {
+	case name == IndexFile:
+		return "no-cache"
+	case h.opts.ImmutablePrefix != "" && strings.HasPrefix(name, h.opts.ImmutablePrefix):
+		return fmt.Sprintf("public, max-age=%d, immutable", int(immutableMaxAge.Seconds()))
+	default:
+		return fmt.Sprintf("public, max-age=%d", int(h.opts.MaxAge.Seconds()))
+	}
+}
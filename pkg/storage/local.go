@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LocalStorage stores objects on the local filesystem under a root directory
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage creates a local storage rooted at the given directory
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if root == "" {
+		root = "./storage"
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{root: root}, nil
+}
+
+// Put writes the object, creating parent directories as needed
+func (s *LocalStorage) Put(ctx context.Context, path string, r io.Reader) error {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temp file first so readers never see partial objects
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fullPath)
+}
+
+// Get opens the object for reading
+func (s *LocalStorage) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Delete removes the object
+func (s *LocalStorage) Delete(ctx context.Context, path string) error {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// List returns object paths under the prefix, sorted lexically
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	var paths []string
+
+	err := filepath.Walk(s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".tmp-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if strings.HasPrefix(rel, prefix) {
+			paths = append(paths, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Exists reports whether the object exists
+func (s *LocalStorage) Exists(ctx context.Context, path string) (bool, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(fullPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// resolve maps an object path to a filesystem path, rejecting traversal outside the root
+func (s *LocalStorage) resolve(path string) (string, error) {
+	cleaned := filepath.Clean("/" + filepath.FromSlash(path))
+	if cleaned == string(filepath.Separator) {
+		return "", fmt.Errorf("invalid storage path: %q", path)
+	}
+	return filepath.Join(s.root, cleaned), nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go-clean-gin/config"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage defines a minimal object storage backend
+type Storage interface {
+	Put(ctx context.Context, path string, r io.Reader) error
+	Get(ctx context.Context, path string) (io.ReadCloser, error)
+	Delete(ctx context.Context, path string) error
+	List(ctx context.Context, prefix string) ([]string, error)
+	Exists(ctx context.Context, path string) (bool, error)
+}
+
+// New creates a storage backend for the configured driver
+func New(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalPath)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,265 @@
+// pkg/tenancy/manager.go - Lazily opened connection pools of tenant databases
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+	"go-clean-gin/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Opener opens a connection pool to the database at dsn
+type Opener func(dsn string) (*gorm.DB, error)
+
+// Options limits the pools a Manager keeps open
+type Options struct {
+	MaxPools    int           // pools kept open at once; 0 means no limit
+	IdleTimeout time.Duration // unused pools are closed after this long; 0 keeps them open
+}
+
+// Manager hands out the connection pools of the registry's tenants. A pool is
+// opened when its tenant is first acquired and closed once it has been unused
+// for the idle timeout, or to make room when more than MaxPools are open.
+type Manager struct {
+	registry *Registry
+	open     Opener
+	opts     Options
+	clock    clock.Clock
+
+	mu    sync.Mutex
+	pools map[string]*pool
+}
+
+type pool struct {
+	ready    chan struct{} // closed once db or err is set
+	db       *gorm.DB
+	err      error
+	refs     int
+	lastUsed time.Time
+}
+
+// NewManager creates a manager that opens the registry's databases with open
+func NewManager(registry *Registry, open Opener, opts Options, clk clock.Clock) *Manager {
+	return &Manager{
+		registry: registry,
+		open:     open,
+		opts:     opts,
+		clock:    clk,
+		pools:    make(map[string]*pool),
+	}
+}
+
+// Registry returns the tenants the manager routes
+func (m *Manager) Registry() *Registry {
+	return m.registry
+}
+
+// Acquire routes ctx to the database of its tenant, opening the tenant's pool
+// on first use; Conn then returns that database. The pool is kept open until
+// release is called. Tenants without a database of their own get ctx back
+// unchanged, so their queries run on the main database.
+func (m *Manager) Acquire(ctx context.Context) (context.Context, func(), error) {
+	tenant := FromContext(ctx)
+	dsn, ok := m.registry.DSN(tenant)
+	if !ok {
+		return ctx, func() {}, nil
+	}
+
+	m.mu.Lock()
+	p, found := m.pools[tenant]
+	if !found {
+		p = &pool{ready: make(chan struct{})}
+		m.pools[tenant] = p
+	}
+	p.refs++
+	p.lastUsed = m.clock.Now()
+	m.mu.Unlock()
+
+	if !found {
+		m.openPool(tenant, dsn, p)
+	}
+
+	select {
+	case <-p.ready:
+	case <-ctx.Done():
+		m.release(p)
+		return nil, nil, ctx.Err()
+	}
+	if p.err != nil {
+		m.release(p)
+		return nil, nil, fmt.Errorf("tenancy: open database of tenant %q: %w", tenant, p.err)
+	}
+
+	var once sync.Once
+	return withDB(ctx, p.db), func() { once.Do(func() { m.release(p) }) }, nil
+}
+
+// Each runs fn on the main database and then on every tenant database,
+// carrying on past failures. Scheduled tasks use it to cover all tenants.
+func (m *Manager) Each(ctx context.Context, fn func(ctx context.Context) error) error {
+	errs := []error{fn(ctx)}
+	for _, tenant := range m.registry.Tenants() {
+		errs = append(errs, m.run(WithTenant(ctx, tenant), fn))
+	}
+	return errors.Join(errs...)
+}
+
+// Run closes idle pools until ctx is done, then closes the rest. It returns
+// at once when there is nothing to evict.
+func (m *Manager) Run(ctx context.Context) {
+	if m.opts.IdleTimeout <= 0 || len(m.registry.Tenants()) == 0 {
+		return
+	}
+
+	ticker := m.clock.NewTicker(m.opts.IdleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.Close()
+			return
+		case <-ticker.C():
+			m.Evict()
+		}
+	}
+}
+
+// Evict closes the pools that have been unused for the idle timeout and
+// returns how many it closed
+func (m *Manager) Evict() int {
+	if m.opts.IdleTimeout <= 0 {
+		return 0
+	}
+
+	m.mu.Lock()
+	var idle []string
+	for tenant, p := range m.pools {
+		if p.refs == 0 && m.clock.Since(p.lastUsed) >= m.opts.IdleTimeout {
+			idle = append(idle, tenant)
+		}
+	}
+	closed := m.remove(idle)
+	m.mu.Unlock()
+
+	m.closePools(closed, "idle")
+	return len(closed)
+}
+
+// Close closes every pool that is not in use
+func (m *Manager) Close() {
+	m.mu.Lock()
+	var unused []string
+	for tenant, p := range m.pools {
+		if p.refs == 0 {
+			unused = append(unused, tenant)
+		}
+	}
+	closed := m.remove(unused)
+	m.mu.Unlock()
+
+	m.closePools(closed, "shutdown")
+}
+
+func (m *Manager) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, release, err := m.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := fn(ctx); err != nil {
+		return fmt.Errorf("tenant %q: %w", FromContext(ctx), err)
+	}
+	return nil
+}
+
+func (m *Manager) openPool(tenant, dsn string, p *pool) {
+	p.db, p.err = m.open(dsn)
+	if p.err != nil {
+		// Forget the failed pool so the next request tries again
+		m.mu.Lock()
+		if m.pools[tenant] == p {
+			delete(m.pools, tenant)
+		}
+		m.mu.Unlock()
+		close(p.ready)
+		logger.Error("Failed to open tenant database", zap.String("tenant", tenant), zap.Error(p.err))
+		return
+	}
+	close(p.ready)
+	logger.Info("Opened tenant database", zap.String("tenant", tenant))
+
+	m.mu.Lock()
+	closed := m.remove(m.overflow())
+	m.mu.Unlock()
+	m.closePools(closed, "pool limit")
+}
+
+// overflow returns the least recently used unused pools past MaxPools.
+// Pools in use stay open even when that leaves more than MaxPools.
+func (m *Manager) overflow() []string {
+	excess := len(m.pools) - m.opts.MaxPools
+	if m.opts.MaxPools <= 0 || excess <= 0 {
+		return nil
+	}
+
+	var lru []string
+	for excess > 0 {
+		oldest := ""
+		for tenant, p := range m.pools {
+			if p.refs > 0 || slices.Contains(lru, tenant) {
+				continue
+			}
+			if oldest == "" || p.lastUsed.Before(m.pools[oldest].lastUsed) {
+				oldest = tenant
+			}
+		}
+		if oldest == "" {
+			break
+		}
+		lru = append(lru, oldest)
+		excess--
+	}
+	return lru
+}
+
+// remove takes the tenants' pools out of the manager; m.mu must be held
+func (m *Manager) remove(tenants []string) map[string]*gorm.DB {
+	closed := make(map[string]*gorm.DB, len(tenants))
+	for _, tenant := range tenants {
+		closed[tenant] = m.pools[tenant].db
+		delete(m.pools, tenant)
+	}
+	return closed
+}
+
+func (m *Manager) closePools(pools map[string]*gorm.DB, reason string) {
+	for tenant, db := range pools {
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			logger.Error("Failed to close tenant database", zap.String("tenant", tenant), zap.Error(err))
+			continue
+		}
+		logger.Info("Closed tenant database", zap.String("tenant", tenant), zap.String("reason", reason))
+	}
+}
+
+func (m *Manager) release(p *pool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p.refs--
+	p.lastUsed = m.clock.Now()
+}
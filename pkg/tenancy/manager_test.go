@@ -0,0 +1,174 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-clean-gin/pkg/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakeOpener opens pools that never connect, recording the DSNs it opened
+type fakeOpener struct {
+	mu     sync.Mutex
+	opened []string
+	fail   error
+}
+
+func (o *fakeOpener) open(dsn string) (*gorm.DB, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.opened = append(o.opened, dsn)
+	if o.fail != nil {
+		return nil, o.fail
+	}
+	return gorm.Open(postgres.New(postgres.Config{DSN: dsn}), &gorm.Config{DisableAutomaticPing: true})
+}
+
+func (o *fakeOpener) count() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.opened)
+}
+
+func newTestManager(t *testing.T, opts Options, tenants ...string) (*Manager, *fakeOpener, *clock.Fake) {
+	t.Helper()
+
+	list := make([]Tenant, len(tenants))
+	for i, tenant := range tenants {
+		list[i] = Tenant{ID: tenant, DSN: "host=" + tenant}
+	}
+	registry, err := NewRegistry(list)
+	require.NoError(t, err)
+
+	opener := &fakeOpener{}
+	clk := clock.NewFake(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	return NewManager(registry, opener.open, opts, clk), opener, clk
+}
+
+func isOpen(m *Manager, tenant string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.pools[tenant]
+	return ok
+}
+
+func TestManager_Acquire_SharesPool(t *testing.T) {
+	m, opener, _ := newTestManager(t, Options{}, "acme")
+
+	first, releaseFirst, err := m.Acquire(WithTenant(context.Background(), "acme"))
+	require.NoError(t, err)
+	second, releaseSecond, err := m.Acquire(WithTenant(context.Background(), "acme"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, opener.count())
+	assert.Same(t, Conn(first, nil).Statement.ConnPool, Conn(second, nil).Statement.ConnPool)
+
+	releaseFirst()
+	releaseFirst() // releasing twice counts once
+	assert.Equal(t, 1, m.pools["acme"].refs)
+	releaseSecond()
+	assert.Equal(t, 0, m.pools["acme"].refs)
+}
+
+func TestManager_Acquire_SharedTenant(t *testing.T) {
+	m, opener, _ := newTestManager(t, Options{}, "acme")
+	main, err := (&fakeOpener{}).open("host=main")
+	require.NoError(t, err)
+
+	ctx, release, err := m.Acquire(WithTenant(context.Background(), "globex"))
+	require.NoError(t, err)
+	defer release()
+
+	assert.Zero(t, opener.count())
+	assert.Same(t, main.Statement.ConnPool, Conn(ctx, main).Statement.ConnPool)
+}
+
+func TestManager_Acquire_RetriesFailedOpen(t *testing.T) {
+	m, opener, _ := newTestManager(t, Options{}, "acme")
+	opener.fail = errors.New("connection refused")
+
+	_, _, err := m.Acquire(WithTenant(context.Background(), "acme"))
+	assert.ErrorContains(t, err, "connection refused")
+	assert.False(t, isOpen(m, "acme"))
+
+	opener.fail = nil
+	_, release, err := m.Acquire(WithTenant(context.Background(), "acme"))
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, 2, opener.count())
+}
+
+func TestManager_Evict_ClosesIdlePools(t *testing.T) {
+	m, _, clk := newTestManager(t, Options{IdleTimeout: time.Minute}, "acme", "globex")
+
+	_, releaseAcme, err := m.Acquire(WithTenant(context.Background(), "acme"))
+	require.NoError(t, err)
+	releaseAcme()
+	_, releaseGlobex, err := m.Acquire(WithTenant(context.Background(), "globex"))
+	require.NoError(t, err)
+
+	clk.Advance(59 * time.Second)
+	assert.Zero(t, m.Evict())
+
+	// globex is still in use, however long ago it was acquired
+	clk.Advance(time.Second)
+	assert.Equal(t, 1, m.Evict())
+	assert.False(t, isOpen(m, "acme"))
+	assert.True(t, isOpen(m, "globex"))
+
+	releaseGlobex()
+	clk.Advance(time.Minute)
+	assert.Equal(t, 1, m.Evict())
+	assert.False(t, isOpen(m, "globex"))
+}
+
+func TestManager_Overflow_ClosesLeastRecentlyUsed(t *testing.T) {
+	m, _, clk := newTestManager(t, Options{MaxPools: 2}, "a", "b", "c", "d")
+	acquire := func(tenant string) func() {
+		_, release, err := m.Acquire(WithTenant(context.Background(), tenant))
+		require.NoError(t, err)
+		clk.Advance(time.Second)
+		return release
+	}
+
+	acquire("a")()
+	releaseB := acquire("b")
+	acquire("c")()
+	// a is the least recently used idle pool
+	assert.False(t, isOpen(m, "a"))
+	assert.True(t, isOpen(m, "b"))
+	assert.True(t, isOpen(m, "c"))
+
+	// b is older than c but in use, so c goes
+	acquire("d")()
+	assert.True(t, isOpen(m, "b"))
+	assert.False(t, isOpen(m, "c"))
+	assert.True(t, isOpen(m, "d"))
+	releaseB()
+}
+
+func TestManager_Each_RunsEveryTenant(t *testing.T) {
+	m, _, _ := newTestManager(t, Options{}, "acme", "globex")
+
+	var ran []string
+	err := m.Each(context.Background(), func(ctx context.Context) error {
+		ran = append(ran, FromContext(ctx))
+		if FromContext(ctx) == "acme" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	assert.Equal(t, []string{"", "acme", "globex"}, ran)
+	assert.ErrorContains(t, err, `tenant "acme": failed`)
+	assert.Equal(t, 0, m.pools["acme"].refs)
+}
@@ -0,0 +1,88 @@
+// pkg/tenancy/registry.go - Tenants with databases of their own
+package tenancy
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tenant is a tenant whose data lives in a database of its own
+type Tenant struct {
+	ID  string `yaml:"id"`
+	DSN string `yaml:"dsn"`
+}
+
+// Registry maps tenants to the DSNs of their databases
+type Registry struct {
+	dsns map[string]string
+}
+
+// NewRegistry creates a registry of the tenants
+func NewRegistry(tenants []Tenant) (*Registry, error) {
+	dsns := make(map[string]string, len(tenants))
+	for i, tenant := range tenants {
+		if tenant.ID == "" || tenant.DSN == "" {
+			return nil, fmt.Errorf("tenant %d needs an id and a dsn", i+1)
+		}
+		if _, ok := dsns[tenant.ID]; ok {
+			return nil, fmt.Errorf("tenant %q is listed twice", tenant.ID)
+		}
+		dsns[tenant.ID] = tenant.DSN
+	}
+	return &Registry{dsns: dsns}, nil
+}
+
+// LoadRegistry reads the tenants from the YAML file at path:
+//
+//	tenants:
+//	  - id: acme
+//	    dsn: host=db-acme user=app password=${ACME_DB_PASSWORD} dbname=acme sslmode=require
+//
+// Environment variables in DSNs are expanded, so passwords can stay out of
+// the file. An empty path means no tenant has a database of its own.
+func LoadRegistry(path string) (*Registry, error) {
+	if path == "" {
+		return NewRegistry(nil)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Tenants []Tenant `yaml:"tenants"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range file.Tenants {
+		file.Tenants[i].DSN = os.ExpandEnv(file.Tenants[i].DSN)
+	}
+
+	registry, err := NewRegistry(file.Tenants)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// DSN returns the DSN of the tenant's database, and false for tenants that
+// share the main database
+func (r *Registry) DSN(tenant string) (string, bool) {
+	dsn, ok := r.dsns[tenant]
+	return dsn, ok
+}
+
+// Tenants returns the tenants with databases of their own, sorted
+func (r *Registry) Tenants() []string {
+	tenants := make([]string, 0, len(r.dsns))
+	for tenant := range r.dsns {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
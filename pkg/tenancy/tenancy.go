@@ -0,0 +1,40 @@
+// pkg/tenancy/tenancy.go - Tenant of a request and the database it runs on
+package tenancy
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type contextKey int
+
+const (
+	tenantKey contextKey = iota
+	dbKey
+)
+
+// WithTenant returns a copy of ctx for the tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// FromContext returns the tenant of ctx, or "" when there is none
+func FromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey).(string)
+	return tenant
+}
+
+// Conn returns the database to run ctx's queries on: the tenant database a
+// Manager acquired for ctx, or db for tenants that share the main database.
+// Repositories call it in place of db.WithContext(ctx).
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tenantDB, ok := ctx.Value(dbKey).(*gorm.DB); ok {
+		db = tenantDB
+	}
+	return db.WithContext(ctx)
+}
+
+func withDB(ctx context.Context, db *gorm.DB) context.Context {
+	return context.WithValue(ctx, dbKey, db)
+}
@@ -3,24 +3,87 @@ package validator
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
+	"go-clean-gin/pkg/geo"
+	"go-clean-gin/pkg/money"
+	"go-clean-gin/pkg/pagination"
+
 	"github.com/go-playground/validator/v10"
 )
 
 var validate *validator.Validate
 
+// Enum is implemented by enums generated with artisan make:enum
+type Enum interface {
+	IsValid() bool
+	EnumValues() []string
+}
+
 func init() {
 	validate = validator.New()
 
 	// Register custom tag name func for better field names in errors
 	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
 		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "" {
+			// Query filters only carry form tags
+			name = strings.SplitN(fld.Tag.Get("form"), ",", 2)[0]
+		}
 		if name == "-" {
 			return ""
 		}
 		return name
 	})
+
+	// `validate:"enum"` accepts only the declared values of a generated enum type
+	validate.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		enum, ok := fl.Field().Interface().(Enum)
+		return ok && enum.IsValid()
+	})
+
+	// `validate:"money"` requires a non-negative amount in a configured currency
+	// (empty means the default) with no more decimals than the currency allows
+	validate.RegisterValidation("money", func(fl validator.FieldLevel) bool {
+		m, ok := fl.Field().Interface().(money.Money)
+		if !ok {
+			return false
+		}
+		if m.Currency == "" {
+			m.Currency = money.DefaultCurrency()
+		}
+		return money.Supported(m.Currency) && !m.IsNegative() && m.Amount.Equal(m.Round().Amount)
+	})
+
+	// `validate:"currency"` accepts a configured currency code
+	validate.RegisterValidation("currency", func(fl validator.FieldLevel) bool {
+		return money.Supported(fl.Field().String())
+	})
+
+	// `validate:"cursor"` accepts a listing cursor from meta.next_cursor
+	validate.RegisterValidation("cursor", func(fl validator.FieldLevel) bool {
+		_, err := pagination.DecodeCursor(fl.Field().String())
+		return err == nil
+	})
+
+	// `validate:"latlng"` accepts a location as lat,lng, as in ?near=13.7563,100.5018
+	validate.RegisterValidation("latlng", func(fl validator.FieldLevel) bool {
+		_, err := geo.ParsePoint(fl.Field().String())
+		return err == nil
+	})
+
+	// `validate:"list=a b"` accepts a comma-separated list of the given values,
+	// as sent in query parameters like ?include=a,b
+	validate.RegisterValidation("list", func(fl validator.FieldLevel) bool {
+		allowed := strings.Fields(fl.Param())
+		for _, item := range strings.Split(fl.Field().String(), ",") {
+			if !slices.Contains(allowed, item) {
+				return false
+			}
+		}
+		return true
+	})
 }
 
 // ValidateStruct validates a struct and returns formatted errors
@@ -39,16 +102,41 @@ func ValidateStruct(s interface{}) map[string]string {
 		switch tag {
 		case "required":
 			errors[field] = fmt.Sprintf("%s is required", field)
+		case "required_without":
+			errors[field] = fmt.Sprintf("%s is required when %s is not given", field, err.Param())
+		case "required_with":
+			errors[field] = fmt.Sprintf("%s is required with %s", field, err.Param())
 		case "email":
 			errors[field] = fmt.Sprintf("%s must be a valid email", field)
 		case "min":
-			errors[field] = fmt.Sprintf("%s must be at least %s characters", field, err.Param())
+			errors[field] = fmt.Sprintf("%s must be at least %s %s", field, err.Param(), unit(err.Kind()))
 		case "max":
-			errors[field] = fmt.Sprintf("%s must be at most %s characters", field, err.Param())
+			errors[field] = fmt.Sprintf("%s must be at most %s %s", field, err.Param(), unit(err.Kind()))
+		case "eqfield":
+			errors[field] = fmt.Sprintf("%s does not match", field)
 		case "gte":
 			errors[field] = fmt.Sprintf("%s must be greater than or equal to %s", field, err.Param())
 		case "lte":
 			errors[field] = fmt.Sprintf("%s must be less than or equal to %s", field, err.Param())
+		case "oneof":
+			errors[field] = fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(err.Param(), " ", ", "))
+		case "enum":
+			if enum, ok := err.Value().(Enum); ok {
+				errors[field] = fmt.Sprintf("%s must be one of: %s", field, strings.Join(enum.EnumValues(), ", "))
+			} else {
+				errors[field] = fmt.Sprintf("%s is invalid", field)
+			}
+		case "money":
+			errors[field] = fmt.Sprintf("%s must be a non-negative amount in one of: %s, within the currency's decimal places",
+				field, strings.Join(money.Currencies(), ", "))
+		case "currency":
+			errors[field] = fmt.Sprintf("%s must be one of: %s", field, strings.Join(money.Currencies(), ", "))
+		case "cursor":
+			errors[field] = fmt.Sprintf("%s is not a valid cursor, use meta.next_cursor from the previous page", field)
+		case "latlng":
+			errors[field] = fmt.Sprintf("%s must be a latitude and longitude like 13.7563,100.5018", field)
+		case "list":
+			errors[field] = fmt.Sprintf("%s must be a comma-separated list of: %s", field, strings.ReplaceAll(err.Param(), " ", ", "))
 		default:
 			errors[field] = fmt.Sprintf("%s is invalid", field)
 		}
@@ -57,6 +145,15 @@ func ValidateStruct(s interface{}) map[string]string {
 	return errors
 }
 
+// unit names what min and max count for a kind of field
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	}
+	return "characters"
+}
+
 // GetValidator returns the validator instance
 func GetValidator() *validator.Validate {
 	return validate
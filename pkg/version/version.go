@@ -0,0 +1,63 @@
+package version
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"runtime"
+)
+
+// Build information. Populated at build time via -ldflags, e.g.
+//
+//	go build -ldflags "-X go-clean-gin/pkg/version.Version=1.2.0 \
+//	  -X go-clean-gin/pkg/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X go-clean-gin/pkg/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info represents the build information of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// String returns a human readable version string
+func (i Info) String() string {
+	return fmt.Sprintf("%s (commit: %s, built: %s, %s)", i.Version, i.Commit, i.BuildTime, i.GoVersion)
+}
+
+// Executor is the machine, OS user and build running a command, as recorded
+// in the migration and seeder history
+type Executor struct {
+	Host    string
+	User    string
+	Version string
+}
+
+// CurrentExecutor returns the executor of the running process. A host or
+// user that cannot be looked up is left empty.
+func CurrentExecutor() Executor {
+	host, _ := os.Hostname()
+
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	return Executor{Host: host, User: name, Version: Version}
+}
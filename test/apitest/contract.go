@@ -0,0 +1,128 @@
+package apitest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/invopop/yaml"
+)
+
+// SpecFile is the OpenAPI document responses are validated against, relative to the
+// module root. Regenerate it with `make swagger` after changing handler annotations.
+const SpecFile = "docs/swagger.yaml"
+
+// contract validates responses against the documented operations
+type contract struct {
+	router   routers.Router
+	basePath string
+}
+
+var (
+	contractOnce   sync.Once
+	sharedContract *contract
+	contractErr    error
+)
+
+// loadContract parses the spec once per test binary
+func loadContract() (*contract, error) {
+	contractOnce.Do(func() {
+		sharedContract, contractErr = readContract()
+	})
+	return sharedContract, contractErr
+}
+
+func readContract() (*contract, error) {
+	root, err := moduleRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, SpecFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+
+	var doc2 openapi2.T
+	if err := yaml.Unmarshal(data, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SpecFile, err)
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to OpenAPI 3: %w", SpecFile, err)
+	}
+
+	router, err := legacy.NewRouter(doc3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec %s: %w", SpecFile, err)
+	}
+
+	return &contract{router: router, basePath: strings.TrimSuffix(doc2.BasePath, "/")}, nil
+}
+
+// validate checks a recorded response against the documented responses of its
+// operation. Routes that are not in the spec (health checks, 404s) are not checked.
+func (c *contract) validate(req *http.Request, recorder *httptest.ResponseRecorder) error {
+	if !strings.HasPrefix(req.URL.Path, c.basePath+"/") {
+		return nil
+	}
+
+	specReq := req.Clone(req.Context())
+	specReq.URL.Path = strings.TrimPrefix(req.URL.Path, c.basePath)
+
+	route, params, err := c.router.FindRoute(specReq)
+	if err != nil {
+		var routeErr *routers.RouteError
+		if errors.As(err, &routeErr) {
+			return nil
+		}
+		return err
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    specReq,
+			PathParams: params,
+			Route:      route,
+		},
+		Status: recorder.Code,
+		Header: recorder.Header(),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+		},
+	}
+	input.SetBodyBytes(recorder.Body.Bytes())
+
+	return openapi3filter.ValidateResponse(req.Context(), input)
+}
+
+// moduleRoot walks up from the working directory to the directory holding go.mod
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,93 @@
+package apitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-clean-gin/pkg/errors"
+	"go-clean-gin/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func record(write func(c *gin.Context)) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	write(c)
+	c.Writer.WriteHeaderNow()
+	return recorder
+}
+
+func TestContract_Validate(t *testing.T) {
+	spec, err := loadContract()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		write   func(c *gin.Context)
+		wantErr bool
+	}{
+		{
+			name:   "documented success envelope",
+			method: http.MethodGet,
+			path:   "/api/v1/products/8a4f2c1e-9b7d-4e3a-a5c6-1f2e3d4c5b6a",
+			write: func(c *gin.Context) {
+				response.Success(c, http.StatusOK, "Product retrieved successfully", gin.H{"name": "Keyboard"})
+			},
+		},
+		{
+			name:   "documented error envelope",
+			method: http.MethodPost,
+			path:   "/api/v1/products",
+			write: func(c *gin.Context) {
+				response.Error(c, http.StatusUnauthorized, errors.ErrUnauthorized, "Authorization header is required", nil)
+			},
+		},
+		{
+			name:   "body drifted from envelope",
+			method: http.MethodPost,
+			path:   "/api/v1/products",
+			write: func(c *gin.Context) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": "Authorization header is required"})
+			},
+			wantErr: true,
+		},
+		{
+			name:   "undocumented status code",
+			method: http.MethodGet,
+			path:   "/api/v1/products",
+			write: func(c *gin.Context) {
+				response.Error(c, http.StatusTeapot, "TEAPOT", "I'm a teapot", nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "route outside the spec",
+			method: http.MethodGet,
+			path:   "/health",
+			write: func(c *gin.Context) {
+				c.String(http.StatusOK, "OK")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			recorder := record(tt.write)
+
+			err := spec.validate(req, recorder)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
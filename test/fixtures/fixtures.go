@@ -0,0 +1,254 @@
+// Package fixtures loads declarative YAML test data into the test database.
+//
+// A fixture file maps table names to the rows to insert:
+//
+//	tb_users:
+//	  - id: 6f1c3b1e-2d4a-4c59-9a7e-0b8f5e2d1a11
+//	    email: alice@example.com
+//	    username: alice
+//	    password: hashed
+//	    first_name: Alice
+//	    last_name: Smith
+//	tb_products:
+//	  - name: Keyboard
+//	    price_amount: 49.99
+//	    price_currency: USD
+//	    category: electronics
+//	    created_by: 6f1c3b1e-2d4a-4c59-9a7e-0b8f5e2d1a11
+//
+// Tables are inserted parents first, following the foreign keys in the
+// database, so files can list tables in any order and rows may reference
+// rows from other files. Load them into a testdb transaction:
+//
+//	db := testdb.New(t)
+//	fixtures.Load(t, db, "testdata/fixtures")
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+// Row is a single fixture row keyed by column name
+type Row map[string]interface{}
+
+// Set is the parsed content of one or more fixture files, keyed by table
+type Set map[string][]Row
+
+// Load reads the fixture files (or every .yml/.yaml file in a directory) and
+// inserts their rows into db, failing the test on any error
+func Load(t testing.TB, db *gorm.DB, paths ...string) {
+	t.Helper()
+
+	set, err := Read(paths...)
+	if err != nil {
+		t.Fatalf("failed to read fixtures: %v", err)
+	}
+
+	if err := Insert(db, set); err != nil {
+		t.Fatalf("failed to load fixtures: %v", err)
+	}
+}
+
+// Read parses fixture files and directories into a single set. Rows for the
+// same table are appended in file order.
+func Read(paths ...string) (Set, error) {
+	files, err := expand(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	set := Set{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var content map[string][]Row
+		if err := yaml.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		for table, rows := range content {
+			set[table] = append(set[table], rows...)
+		}
+	}
+
+	return set, nil
+}
+
+// Insert writes the set into db, ordering tables so referenced rows exist first
+func Insert(db *gorm.DB, set Set) error {
+	deps, err := foreignKeys(db)
+	if err != nil {
+		return err
+	}
+
+	tables := make([]string, 0, len(set))
+	for table := range set {
+		tables = append(tables, table)
+	}
+
+	ordered, err := sortTables(tables, deps)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range ordered {
+		for i, row := range set[table] {
+			values, err := columnValues(row)
+			if err != nil {
+				return fmt.Errorf("%s[%d]: %w", table, i, err)
+			}
+
+			if err := db.Table(table).Create(values).Error; err != nil {
+				return fmt.Errorf("%s[%d]: %w", table, i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expand resolves directories to the fixture files they contain, sorted by name
+func expand(paths []string) ([]string, error) {
+	var files []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if !entry.IsDir() && (ext == ".yml" || ext == ".yaml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// foreignKeys returns, for each table in the current schema, the tables it references
+func foreignKeys(db *gorm.DB) (map[string][]string, error) {
+	var refs []struct {
+		Table      string
+		References string
+	}
+
+	err := db.Raw(`
+		SELECT DISTINCT tc.table_name AS "table", ccu.table_name AS "references"
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.constraint_column_usage ccu
+			ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema()
+	`).Scan(&refs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys: %w", err)
+	}
+
+	deps := make(map[string][]string)
+	for _, ref := range refs {
+		deps[ref.Table] = append(deps[ref.Table], ref.References)
+	}
+	return deps, nil
+}
+
+// sortTables orders tables so every table comes after the tables it references.
+// Self-references are ignored; ties are broken by name for stable output.
+func sortTables(tables []string, deps map[string][]string) ([]string, error) {
+	sorted := append([]string(nil), tables...)
+	sort.Strings(sorted)
+
+	included := make(map[string]bool, len(sorted))
+	for _, table := range sorted {
+		included[table] = true
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(sorted))
+	ordered := make([]string, 0, len(sorted))
+
+	var visit func(table string, path []string) error
+	visit = func(table string, path []string) error {
+		switch state[table] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular foreign keys: %s", strings.Join(append(path, table), " -> "))
+		}
+
+		state[table] = visiting
+		parents := append([]string(nil), deps[table]...)
+		sort.Strings(parents)
+		for _, parent := range parents {
+			if parent == table || !included[parent] {
+				continue
+			}
+			if err := visit(parent, append(path, table)); err != nil {
+				return err
+			}
+		}
+		state[table] = done
+		ordered = append(ordered, table)
+		return nil
+	}
+
+	for _, table := range sorted {
+		if err := visit(table, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// columnValues converts a fixture row into insertable values; nested maps and
+// lists are stored as JSON (for jsonb columns)
+func columnValues(row Row) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(row))
+
+	for column, value := range row {
+		if value == nil {
+			values[column] = nil
+			continue
+		}
+
+		switch reflect.TypeOf(value).Kind() {
+		case reflect.Map, reflect.Slice:
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", column, err)
+			}
+			values[column] = string(data)
+		default:
+			values[column] = value
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,73 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	set, err := Read("testdata")
+	require.NoError(t, err)
+
+	require.Len(t, set["tb_users"], 1)
+	require.Len(t, set["tb_products"], 1)
+	assert.Equal(t, "alice@example.com", set["tb_users"][0]["email"])
+	assert.Equal(t, 49.99, set["tb_products"][0]["price_amount"])
+
+	values, err := columnValues(set["tb_products"][0])
+	require.NoError(t, err)
+	assert.Equal(t, `{"color":"black"}`, values["metadata"])
+}
+
+func TestSortTables(t *testing.T) {
+	tests := []struct {
+		name    string
+		tables  []string
+		deps    map[string][]string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "parents first",
+			tables: []string{"tb_order_items", "tb_products", "tb_orders", "tb_users"},
+			deps: map[string][]string{
+				"tb_order_items": {"tb_orders", "tb_products"},
+				"tb_orders":      {"tb_users"},
+				"tb_products":    {"tb_users"},
+			},
+			want: []string{"tb_users", "tb_orders", "tb_products", "tb_order_items"},
+		},
+		{
+			name:   "parents outside the set are ignored",
+			tables: []string{"tb_products"},
+			deps:   map[string][]string{"tb_products": {"tb_users"}},
+			want:   []string{"tb_products"},
+		},
+		{
+			name:   "self reference",
+			tables: []string{"tb_categories"},
+			deps:   map[string][]string{"tb_categories": {"tb_categories"}},
+			want:   []string{"tb_categories"},
+		},
+		{
+			name:    "cycle",
+			tables:  []string{"a", "b"},
+			deps:    map[string][]string{"a": {"b"}, "b": {"a"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sortTables(tt.tables, tt.deps)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
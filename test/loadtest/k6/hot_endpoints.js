@@ -0,0 +1,80 @@
+// test/loadtest/k6/hot_endpoints.js - Load profile for login, product list and product create
+//
+// Prepare data, start the server, then run:
+//   go run ./cmd/artisan loadtest:seed -count=10000
+//   k6 run -e BASE_URL=http://localhost:8080 -e PRODUCTS=10000 test/loadtest/k6/hot_endpoints.js
+import http from 'k6/http';
+import { check, fail } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+const EMAIL = __ENV.LOADTEST_EMAIL || 'loadtest@example.com';
+const PASSWORD = __ENV.LOADTEST_PASSWORD || 'password123';
+const PRODUCTS = parseInt(__ENV.PRODUCTS || '10000', 10);
+const DURATION = __ENV.DURATION || '30s';
+const LIMIT = 10;
+
+const JSON_HEADERS = { 'Content-Type': 'application/json' };
+
+export const options = {
+  summaryTrendStats: ['avg', 'p(50)', 'p(95)', 'p(99)', 'max'],
+  scenarios: {
+    login: { executor: 'constant-vus', exec: 'login', vus: 5, duration: DURATION },
+    list_products: { executor: 'constant-vus', exec: 'listProducts', vus: 20, duration: DURATION },
+    create_product: { executor: 'constant-vus', exec: 'createProduct', vus: 5, duration: DURATION },
+  },
+  thresholds: {
+    http_req_failed: ['rate<0.01'],
+    'http_req_duration{endpoint:login}': ['p(95)<500'],
+    'http_req_duration{endpoint:list_first_page}': ['p(95)<150'],
+    'http_req_duration{endpoint:list_last_page}': ['p(95)<300'],
+    'http_req_duration{endpoint:create_product}': ['p(95)<200'],
+  },
+};
+
+function authenticate() {
+  const res = http.post(`${BASE_URL}/api/v1/auth/login`, JSON.stringify({ email: EMAIL, password: PASSWORD }), {
+    headers: JSON_HEADERS,
+    tags: { endpoint: 'login' },
+  });
+  check(res, { 'login 200': (r) => r.status === 200 });
+  return res;
+}
+
+export function setup() {
+  const res = authenticate();
+  if (res.status !== 200) {
+    fail(`login failed (${res.status}); run "artisan loadtest:seed" first`);
+  }
+  return { token: res.json('data.token') };
+}
+
+export function login() {
+  authenticate();
+}
+
+export function listProducts() {
+  // Alternate between the first and the last page to expose OFFSET cost
+  const lastPage = Math.max(1, Math.floor(PRODUCTS / LIMIT));
+  const first = __ITER % 2 === 0;
+  const page = first ? 1 : lastPage;
+
+  const res = http.get(`${BASE_URL}/api/v1/products?page=${page}&limit=${LIMIT}`, {
+    tags: { endpoint: first ? 'list_first_page' : 'list_last_page' },
+  });
+  check(res, { 'list 200': (r) => r.status === 200 });
+}
+
+export function createProduct(data) {
+  const body = {
+    name: `Load test product ${__VU}-${__ITER}`,
+    price: { amount: '19.99', currency: 'USD' },
+    stock: 10,
+    category: 'Electronics',
+  };
+
+  const res = http.post(`${BASE_URL}/api/v1/products`, JSON.stringify(body), {
+    headers: { ...JSON_HEADERS, Authorization: `Bearer ${data.token}` },
+    tags: { endpoint: 'create_product' },
+  });
+  check(res, { 'create 201': (r) => r.status === 201 });
+}
@@ -0,0 +1,121 @@
+// Package loadtest seeds bulk data and measures latency percentiles for the hot
+// endpoints (login, product list, product create).
+//
+// Go benchmarks use it on a testdb transaction; `artisan loadtest:seed` uses it to
+// prepare a running server for the k6 profile in test/loadtest/k6.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-clean-gin/internal/entity"
+	"go-clean-gin/internal/product"
+	"go-clean-gin/pkg/faker"
+	"go-clean-gin/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// UserEmail and UserPassword are the credentials of the user created by SeedUser
+	UserEmail    = "loadtest@example.com"
+	UserPassword = "password123"
+
+	seedBatchSize = 1000
+)
+
+// Categories are assigned round-robin so category filters hit a predictable share of rows
+var Categories = []string{"Electronics", "Fashion", "Books", "Home", "Sports"}
+
+// SeedUser returns the load test user, creating it with the given bcrypt hash if needed
+func SeedUser(db *gorm.DB, passwordHash string) (*entity.User, error) {
+	user := entity.User{
+		Email:     UserEmail,
+		Username:  "loadtest",
+		Password:  passwordHash,
+		FirstName: "Load",
+		LastName:  "Test",
+		Role:      entity.RoleUser,
+		IsActive:  true,
+	}
+
+	if err := db.Where("email = ?", UserEmail).FirstOrCreate(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to seed load test user: %w", err)
+	}
+	return &user, nil
+}
+
+// SeedProducts inserts n products owned by ownerID in batches. Data is deterministic
+// so runs against the same size are comparable.
+func SeedProducts(db *gorm.DB, ownerID uuid.UUID, n int) error {
+	f := faker.New(int64(n))
+	batch := make([]entity.Product, 0, seedBatchSize)
+
+	for i := 0; i < n; i++ {
+		batch = append(batch, entity.Product{
+			Name:        fmt.Sprintf("%s %d", f.Word(), i),
+			Description: f.Sentence(8),
+			Price:       money.New(decimal.New(int64(f.Int(100, 50000)), -2), money.DefaultCurrency()),
+			Stock:       f.Int(0, 100),
+			Category:    Categories[i%len(Categories)],
+			IsActive:    true,
+			CreatedBy:   ownerID,
+		})
+
+		if len(batch) == seedBatchSize || i == n-1 {
+			if err := db.Omit(clause.Associations).Create(&batch).Error; err != nil {
+				return fmt.Errorf("failed to seed products: %w", err)
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err := product.NewProductReadRepository(db).RefreshProductListings(context.Background(), nil); err != nil {
+		return fmt.Errorf("failed to refresh product listings: %w", err)
+	}
+	return nil
+}
+
+// Latencies records the duration of individual operations
+type Latencies struct {
+	samples []time.Duration
+}
+
+// Time runs fn and records how long it took
+func (l *Latencies) Time(fn func()) {
+	start := time.Now()
+	fn()
+	l.samples = append(l.samples, time.Since(start))
+}
+
+// Percentile returns the nearest-rank percentile (0-100) of the recorded durations
+func (l *Latencies) Percentile(p float64) time.Duration {
+	if len(l.samples) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(l.samples))
+	copy(sorted, l.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// Report adds p50/p95 latency to benchmark output (pass a *testing.B)
+func (l *Latencies) Report(b interface{ ReportMetric(float64, string) }) {
+	b.ReportMetric(float64(l.Percentile(50).Microseconds()), "p50-µs")
+	b.ReportMetric(float64(l.Percentile(95).Microseconds()), "p95-µs")
+}
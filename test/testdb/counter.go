@@ -0,0 +1,60 @@
+package testdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// QueryCounter records the SQL statements run through a session, so tests can
+// assert that a repository method does not issue one query per row.
+type QueryCounter struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+// CountQueries returns a session of db whose statements are recorded by the
+// returned counter. Only the session is affected, so parallel tests sharing
+// the connection are not counted.
+func CountQueries(db *gorm.DB) (*gorm.DB, *QueryCounter) {
+	counter := &QueryCounter{}
+	return db.Session(&gorm.Session{Logger: counter}), counter
+}
+
+// Count returns the number of statements run so far
+func (c *QueryCounter) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.queries)
+}
+
+// Queries returns the statements run so far, for failure messages
+func (c *QueryCounter) Queries() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.queries...)
+}
+
+// Reset forgets the statements recorded so far
+func (c *QueryCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = nil
+}
+
+func (c *QueryCounter) LogMode(logger.LogLevel) logger.Interface { return c }
+
+func (c *QueryCounter) Info(context.Context, string, ...interface{})  {}
+func (c *QueryCounter) Warn(context.Context, string, ...interface{})  {}
+func (c *QueryCounter) Error(context.Context, string, ...interface{}) {}
+
+func (c *QueryCounter) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	sql, _ := fc()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries = append(c.queries, sql)
+}
@@ -0,0 +1,111 @@
+// Package testdb provides per-test database isolation for integration tests.
+//
+// Each test gets its own transaction on the shared test database, rolled back
+// when the test finishes, so tests never see each other's data and can run
+// with t.Parallel(). Tests are skipped when Postgres is not reachable, so
+// `go test ./...` keeps working without a database.
+//
+// The test database is DB_NAME + "_test" unless TEST_DB_NAME is set; it is
+// created and migrated on first use.
+package testdb
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"go-clean-gin/config"
+	"go-clean-gin/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+var (
+	once    sync.Once
+	shared  *gorm.DB
+	openErr error
+)
+
+// Open returns the shared, migrated test database connection.
+// Prefer New unless the test needs to manage transactions itself.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	once.Do(func() {
+		shared, openErr = connect()
+	})
+
+	if openErr != nil {
+		t.Skipf("test database unavailable: %v", openErr)
+	}
+	return shared
+}
+
+// New returns a transaction that is rolled back when the test ends.
+//
+// Parallel tests inserting the same unique value (e.g. an email) will block
+// each other until one rolls back, so generate unique data per test.
+func New(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	tx := Open(t).Begin()
+	if tx.Error != nil {
+		t.Fatalf("failed to begin test transaction: %v", tx.Error)
+	}
+
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+
+	return tx
+}
+
+// connect creates the test database if needed and runs all migrations
+func connect() (*gorm.DB, error) {
+	cfg := config.Load()
+
+	testCfg := cfg.Database
+	testCfg.Name = os.Getenv("TEST_DB_NAME")
+	if testCfg.Name == "" {
+		testCfg.Name = cfg.Database.Name + "_test"
+	}
+
+	if err := ensureDatabase(cfg.Database, testCfg.Name); err != nil {
+		return nil, err
+	}
+
+	db, err := database.NewPostgresDB(&testCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.RunMigrations(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate test database: %w", err)
+	}
+
+	return db, nil
+}
+
+// ensureDatabase creates the named database through the maintenance database
+func ensureDatabase(cfg config.DatabaseConfig, name string) error {
+	cfg.Name = "postgres"
+
+	db, err := database.NewPostgresDB(&cfg)
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var exists bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = ?)", name).Scan(&exists).Error; err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return db.Exec(fmt.Sprintf("CREATE DATABASE %q", name)).Error
+}
@@ -0,0 +1,21 @@
+// Package web embeds the frontend build into the binary. Copy the build into
+// web/dist before compiling and set STATIC_EMBED=true to serve it.
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// Dist returns the embedded build, rooted at its index.html
+func Dist() fs.FS {
+	sub, err := fs.Sub(dist, "dist")
+	if err != nil {
+		// dist is a valid path, so this can't happen
+		panic(err)
+	}
+	return sub
+}